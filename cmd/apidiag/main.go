@@ -15,13 +15,17 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"runtime/debug"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/ehabterra/apispec/internal/diagserver"
@@ -38,10 +42,36 @@ var (
 // cliConfig is the flag-parsed form. We translate it into a diagserver.Config.
 type cliConfig struct {
 	ShowVersion bool
+	// PollInterval is how often the config file is checked for changes; 0
+	// turns reloading off.
+	PollInterval time.Duration
+	// Dirs are the --dir flags given; with more than one, or with a
+	// WorkspaceFile, the projects are served as a workspace.
+	Dirs          stringSliceFlag
+	WorkspaceFile string
+	// AuthToken and BasicAuth ("user:password") protect the server; they
+	// default to $APIDIAG_AUTH_TOKEN and $APIDIAG_BASIC_AUTH, which keep
+	// them out of the process list.
+	AuthToken string
+	BasicAuth string
+	// Timeouts bound the server's connections and its graceful shutdown.
+	Timeouts diagserver.Timeouts
 
 	srv diagserver.Config
 }
 
+// stringSliceFlag implements flag.Value for repeated string flags.
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSliceFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
 func detectVersionInfo() {
 	if Version != "0.0.1" {
 		return
@@ -101,24 +131,70 @@ func main() {
 		os.Exit(0)
 	}
 
-	server := diagserver.New(&cfg.srv)
-	if err := server.LoadMetadata(); err != nil {
-		log.Fatalf("Failed to load metadata: %v", err)
+	// SIGINT and SIGTERM stop the config watchers and shut the server down
+	// gracefully.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dirs, err := projectDirs(cfg)
+	if err != nil {
+		log.Fatalf("Failed to read workspace: %v", err)
 	}
 
 	mux := http.NewServeMux()
-	server.RegisterRoutes(mux, diagserver.RouteOptions{UIPath: "/"})
+	if len(dirs) > 1 {
+		if cfg.srv.MetadataFile != "" || cfg.srv.MetadataCache != "" {
+			log.Fatalf("--metadata and --metadata-cache serve a single project; drop them or pass one --dir")
+		}
+		workspace, err := diagserver.NewWorkspace(&cfg.srv, dirs)
+		if err != nil {
+			log.Fatalf("Failed to create workspace: %v", err)
+		}
+		if err := workspace.LoadCachedMetadata(); err != nil {
+			log.Fatalf("Failed to load metadata: %v", err)
+		}
+		go workspace.WatchConfig(ctx, cfg.PollInterval)
+		workspace.RegisterRoutes(mux, diagserver.RouteOptions{UIPath: "/"})
+		for _, p := range workspace.Projects() {
+			log.Printf("📦 Project %s: %s", p.Name, p.Dir)
+		}
+	} else {
+		cfg.srv.InputDir = dirs[0]
+		server := diagserver.New(&cfg.srv)
+		if err := server.LoadCachedMetadata(); err != nil {
+			log.Fatalf("Failed to load metadata: %v", err)
+		}
+		go server.WatchConfig(ctx, cfg.PollInterval)
+		server.RegisterRoutes(mux, diagserver.RouteOptions{UIPath: "/"})
+	}
+
+	auth, err := authOf(cfg)
+	if err != nil {
+		log.Fatalf("Invalid --basic-auth: %v", err)
+	}
+	if auth.Enabled() {
+		log.Printf("🔒 Authentication required")
+	}
 
 	addr := fmt.Sprintf("%s:%d", cfg.srv.Host, cfg.srv.Port)
 	log.Printf("🚀 API Diagram server starting on http://%s", addr)
 	if cfg.srv.Verbose {
-		log.Printf("📊 Serving %s diagrams for: %s", cfg.srv.DiagramType, cfg.srv.InputDir)
+		source := cfg.srv.InputDir
+		if cfg.srv.MetadataFile != "" {
+			source = cfg.srv.MetadataFile
+		}
+		log.Printf("📊 Serving %s diagrams for: %s", cfg.srv.DiagramType, source)
 		log.Printf("⚙️  Page size: %d, Max depth: %d", cfg.srv.PageSize, cfg.srv.MaxDepth)
+		if cfg.srv.ConfigFile != "" && cfg.srv.MetadataFile == "" && cfg.PollInterval > 0 {
+			log.Printf("👀 Reloading on changes to %s", cfg.srv.ConfigFile)
+		}
 	}
 
-	if err := http.ListenAndServe(addr, mux); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	cfg.Timeouts.Idle = 2 * time.Minute
+	if err := diagserver.ListenAndServe(ctx, addr, diagserver.RequireAuth(auth, mux), cfg.Timeouts); err != nil {
+		log.Fatalf("Server failed: %v", err)
 	}
+	log.Printf("👋 Server stopped")
 }
 
 func parseFlags() *cliConfig {
@@ -129,11 +205,25 @@ func parseFlags() *cliConfig {
 
 	flag.IntVar(&cfg.srv.Port, "port", 8080, "Server port")
 	flag.StringVar(&cfg.srv.Host, "host", "localhost", "Server host")
-	flag.StringVar(&cfg.srv.InputDir, "dir", ".", "Input directory containing Go source files")
+	flag.Var(&cfg.Dirs, "dir", "Input directory containing Go source files; repeat to serve several modules as a workspace (default \".\")")
+	flag.StringVar(&cfg.WorkspaceFile, "workspace", "", "go.work file whose modules are served as a workspace")
+	flag.StringVar(&cfg.srv.ConfigFile, "config", "", "Path to an apispec config YAML whose include/exclude settings filter the analysis")
+	flag.StringVar(&cfg.srv.ConfigFile, "c", "", "Shorthand for --config")
+	flag.DurationVar(&cfg.PollInterval, "poll-interval", time.Second, "How often to check the config file for changes (0 disables reloading)")
+	flag.StringVar(&cfg.srv.MetadataCache, "metadata-cache", "", "File to save the analyzed metadata to and load it from on restart (refresh re-analyzes)")
+	flag.StringVar(&cfg.srv.MetadataFile, "metadata", "", "Serve metadata written by apispec --write-metadata or --split-metadata instead of analyzing --dir")
 	flag.IntVar(&cfg.srv.PageSize, "page-size", 100, "Default page size for pagination")
 	flag.IntVar(&cfg.srv.MaxDepth, "max-depth", 3, "Maximum call graph depth")
 	flag.BoolVar(&cfg.srv.EnableCORS, "cors", true, "Enable CORS headers")
-	flag.DurationVar(&cfg.srv.CacheTimeout, "cache-timeout", 5*time.Minute, "Cache timeout for metadata")
+	flag.DurationVar(&cfg.srv.CacheTimeout, "cache-timeout", 5*time.Minute, "How long cached diagrams and pages are served before they are rebuilt (0 keeps them until evicted)")
+	flag.IntVar(&cfg.srv.CacheMaxEntries, "cache-entries", 256, "Maximum number of diagram pages to cache")
+	flag.IntVar(&cfg.srv.CacheMaxMB, "cache-memory", 256, "Approximate memory limit of the page cache, in MB")
+	flag.StringVar(&cfg.AuthToken, "auth-token", os.Getenv("APIDIAG_AUTH_TOKEN"), "Require this bearer token (or ?token=) on every request but /health (default $APIDIAG_AUTH_TOKEN)")
+	flag.StringVar(&cfg.BasicAuth, "basic-auth", os.Getenv("APIDIAG_BASIC_AUTH"), "Require HTTP basic auth as user:password on every request but /health (default $APIDIAG_BASIC_AUTH)")
+	flag.BoolVar(&cfg.srv.ReadOnly, "read-only", false, "Disable /api/diagram/refresh, so clients cannot trigger a re-analysis")
+	flag.DurationVar(&cfg.Timeouts.Read, "read-timeout", time.Minute, "Maximum time to read a request (0 for no limit)")
+	flag.DurationVar(&cfg.Timeouts.Write, "write-timeout", 10*time.Minute, "Maximum time to write a response, including a refresh's re-analysis (0 for no limit)")
+	flag.DurationVar(&cfg.Timeouts.Shutdown, "shutdown-timeout", 30*time.Second, "How long in-flight requests get to finish on SIGINT or SIGTERM")
 	flag.BoolVar(&cfg.srv.Verbose, "verbose", false, "Enable verbose logging")
 	flag.BoolVar(&cfg.srv.Verbose, "v", false, "Shorthand for --verbose")
 
@@ -160,10 +250,20 @@ func parseFlags() *cliConfig {
 		fmt.Fprintf(os.Stderr, "  %s --dir ./myproject --port 8080\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s --dir ./myproject --page-size 50 --max-depth 2\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s --dir ./myproject --diagram-type tracker-tree\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --dir ./myproject --config apispec.yaml\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --dir ./myproject --metadata-cache .apidiag-cache.yaml\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --metadata ./artifacts/metadata.yaml\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --dir ./services/api --dir ./services/billing\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --workspace go.work\n", os.Args[0])
 	}
 
 	flag.Parse()
 
+	cfg.srv.InputDir = "."
+	if len(cfg.Dirs) > 0 {
+		cfg.srv.InputDir = cfg.Dirs[0]
+	}
+
 	if cfg.srv.PageSize < 10 {
 		cfg.srv.PageSize = 10
 	} else if cfg.srv.PageSize > 1000 {
@@ -182,3 +282,35 @@ func parseFlags() *cliConfig {
 
 	return cfg
 }
+
+// authOf returns the credentials the server requires, if any.
+func authOf(cfg *cliConfig) (diagserver.Auth, error) {
+	auth := diagserver.Auth{Token: cfg.AuthToken, OpenPaths: []string{"/health"}}
+	if cfg.BasicAuth != "" {
+		user, password, ok := strings.Cut(cfg.BasicAuth, ":")
+		if !ok || user == "" {
+			return diagserver.Auth{}, errors.New("want user:password")
+		}
+		auth.Username, auth.Password = user, password
+	}
+	return auth, nil
+}
+
+// projectDirs returns the directories to serve: the modules of the
+// workspace file, if any, followed by the --dir flags, or the current
+// directory when neither is given.
+func projectDirs(cfg *cliConfig) ([]string, error) {
+	var dirs []string
+	if cfg.WorkspaceFile != "" {
+		used, err := diagserver.ReadWorkspaceFile(cfg.WorkspaceFile)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, used...)
+	}
+	dirs = append(dirs, cfg.Dirs...)
+	if len(dirs) == 0 {
+		dirs = []string{cfg.srv.InputDir}
+	}
+	return dirs, nil
+}
@@ -18,8 +18,10 @@ import (
 	"bytes"
 	"flag"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestDetectVersionInfo(t *testing.T) {
@@ -173,3 +175,90 @@ func TestParseFlags_VersionShorthand(t *testing.T) {
 		t.Error("--version should set ShowVersion")
 	}
 }
+
+func TestParseFlags_ConfigWatch(t *testing.T) {
+	c := withParsedFlags(nil)
+	if c.srv.ConfigFile != "" || c.PollInterval != time.Second {
+		t.Errorf("unexpected config defaults: %q, %v", c.srv.ConfigFile, c.PollInterval)
+	}
+	c = withParsedFlags([]string{"-c", "apispec.yaml", "--poll-interval", "250ms"})
+	if c.srv.ConfigFile != "apispec.yaml" || c.PollInterval != 250*time.Millisecond {
+		t.Errorf("config flags not applied: %q, %v", c.srv.ConfigFile, c.PollInterval)
+	}
+}
+
+func TestParseFlags_MetadataCache(t *testing.T) {
+	if c := withParsedFlags(nil); c.srv.MetadataCache != "" {
+		t.Errorf("metadata cache should be off by default, got %q", c.srv.MetadataCache)
+	}
+	if c := withParsedFlags([]string{"--metadata-cache", "cache.yaml"}); c.srv.MetadataCache != "cache.yaml" {
+		t.Errorf("--metadata-cache not applied: %q", c.srv.MetadataCache)
+	}
+}
+
+func TestParseFlags_MetadataFile(t *testing.T) {
+	if c := withParsedFlags(nil); c.srv.MetadataFile != "" {
+		t.Errorf("metadata file should be unset by default, got %q", c.srv.MetadataFile)
+	}
+	if c := withParsedFlags([]string{"--metadata", "metadata.yaml"}); c.srv.MetadataFile != "metadata.yaml" {
+		t.Errorf("--metadata not applied: %q", c.srv.MetadataFile)
+	}
+}
+
+func TestProjectDirs(t *testing.T) {
+	c := withParsedFlags([]string{"--dir", "./a", "--dir", "./b"})
+	if c.srv.InputDir != "./a" {
+		t.Errorf("InputDir = %q, want the first --dir", c.srv.InputDir)
+	}
+	dirs, err := projectDirs(c)
+	if err != nil || strings.Join(dirs, " ") != "./a ./b" {
+		t.Errorf("projectDirs = %v, %v", dirs, err)
+	}
+
+	if dirs, _ := projectDirs(withParsedFlags(nil)); strings.Join(dirs, " ") != "." {
+		t.Errorf("default projectDirs = %v, want [.]", dirs)
+	}
+
+	root := t.TempDir()
+	work := filepath.Join(root, "go.work")
+	if err := os.WriteFile(work, []byte("go 1.26\n\nuse (\n\t./api\n\t./billing\n)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dirs, err = projectDirs(withParsedFlags([]string{"--workspace", work, "--dir", "./extra"}))
+	want := []string{filepath.Join(root, "api"), filepath.Join(root, "billing"), "./extra"}
+	if err != nil || strings.Join(dirs, " ") != strings.Join(want, " ") {
+		t.Errorf("projectDirs with workspace = %v, %v; want %v", dirs, err, want)
+	}
+
+	if _, err := projectDirs(withParsedFlags([]string{"--workspace", filepath.Join(root, "missing.work")})); err == nil {
+		t.Error("a missing workspace file should fail")
+	}
+}
+
+func TestAuthOf(t *testing.T) {
+	auth, err := authOf(withParsedFlags(nil))
+	if err != nil || auth.Enabled() {
+		t.Errorf("no auth flags: %+v, %v", auth, err)
+	}
+
+	auth, err = authOf(withParsedFlags([]string{"--auth-token", "s3cret", "--basic-auth", "ops:pa:ss", "--read-only"}))
+	if err != nil || auth.Token != "s3cret" || auth.Username != "ops" || auth.Password != "pa:ss" {
+		t.Errorf("auth = %+v, %v", auth, err)
+	}
+	if len(auth.OpenPaths) != 1 || auth.OpenPaths[0] != "/health" {
+		t.Errorf("open paths = %v, want the health check", auth.OpenPaths)
+	}
+
+	if _, err := authOf(withParsedFlags([]string{"--basic-auth", "nopassword"})); err == nil {
+		t.Error("--basic-auth without a colon should fail")
+	}
+}
+
+func TestParseFlags_ReadOnly(t *testing.T) {
+	if c := withParsedFlags([]string{"--read-only"}); !c.srv.ReadOnly {
+		t.Error("--read-only should set ReadOnly")
+	}
+	if c := withParsedFlags(nil); c.srv.ReadOnly {
+		t.Error("ReadOnly should default false")
+	}
+}
@@ -0,0 +1,439 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/diag"
+	"github.com/ehabterra/apispec/internal/engine"
+	"github.com/ehabterra/apispec/internal/metadata"
+	intspec "github.com/ehabterra/apispec/internal/spec"
+	"github.com/ehabterra/apispec/internal/specdiff"
+	"github.com/ehabterra/apispec/spec"
+	"github.com/ehabterra/apispec/spectest"
+)
+
+const (
+	generateCommand = "generate"
+	diffCommand     = "diff"
+	validateCommand = "validate"
+	lintCommand     = "lint"
+	diagramCommand  = "diagram"
+	metadataCommand = "metadata"
+)
+
+// subcommand is a command named by the first argument; any other first
+// argument belongs to the generator.
+type subcommand struct {
+	name    string
+	summary string
+	// flags returns the command's flag set, nil when it takes none.
+	flags func() *flag.FlagSet
+	// operands says how the command's positional arguments complete.
+	operands valueKind
+	// run runs the command on the arguments after its name and returns the
+	// exit code.
+	run func(args []string) int
+	// bannerless commands write output meant to be piped or sourced.
+	bannerless bool
+}
+
+func subcommands() []subcommand {
+	return []subcommand{
+		{
+			name:     generateCommand,
+			summary:  "Generate the OpenAPI spec (the default when no command is given)",
+			flags:    func() *flag.FlagSet { return generatorFlags(&CLIConfig{}) },
+			operands: valueDir,
+			run:      runGenerate,
+		},
+		{
+			name:     diffCommand,
+			summary:  "Compare two specs, or a spec with the one generated now",
+			flags:    func() *flag.FlagSet { return diffFlags(&CLIConfig{}, new(bool)) },
+			operands: valueFile,
+			run:      runDiff,
+		},
+		{
+			name:     validateCommand,
+			summary:  "Check a generated or existing spec for structural problems",
+			flags:    func() *flag.FlagSet { return validateFlags(&CLIConfig{}, new(string)) },
+			operands: valueDir,
+			run:      runValidate,
+		},
+		{
+			name:     lintCommand,
+			summary:  "Report analysis warnings: unmapped auth, path variable typos, JSON naming",
+			flags:    func() *flag.FlagSet { return lintFlags(&CLIConfig{}) },
+			operands: valueDir,
+			run:      runLint,
+		},
+		{
+			name:     diagramCommand,
+			summary:  "Write the call graph diagram without generating the spec",
+			flags:    func() *flag.FlagSet { return diagramCommandFlags(&CLIConfig{}) },
+			operands: valueDir,
+			run:      runDiagram,
+		},
+		{
+			name:     metadataCommand,
+			summary:  "Write the analysis metadata without generating the spec",
+			flags:    func() *flag.FlagSet { return metadataCommandFlags(&CLIConfig{}) },
+			operands: valueDir,
+			run:      runMetadata,
+		},
+		{
+			name:     serveCommand,
+			summary:  "Serve the spec with Swagger UI or Redoc, regenerating it on source changes",
+			flags:    func() *flag.FlagSet { return serveFlags(&CLIConfig{}, &serveOptions{}) },
+			operands: valueDir,
+			run:      runServe,
+		},
+		{
+			name:     checkGatewayCommand,
+			summary:  "Check a gateway routing table against the extracted routes",
+			flags:    func() *flag.FlagSet { return checkGatewayFlags(&CLIConfig{}, new(string)) },
+			operands: valueDir,
+			run:      runCheckGateway,
+		},
+		{
+			name:     testCommand,
+			summary:  "Compare the spec of every module under a directory with its golden file",
+			flags:    func() *flag.FlagSet { return testFlags(&CLIConfig{}, &spectest.GoldenOptions{}) },
+			operands: valueDir,
+			run:      runTest,
+		},
+		{
+			name:       completionCommand,
+			summary:    "Print a shell completion script (bash, zsh or fish)",
+			operands:   valueWords,
+			run:        func(args []string) int { return runCompletion(args, os.Stdout) },
+			bannerless: true,
+		},
+		{
+			name:       manCommand,
+			summary:    "Print the apispec(1) man page",
+			run:        func(args []string) int { return runMan(args, os.Stdout) },
+			bannerless: true,
+		},
+	}
+}
+
+func lookupSubcommand(name string) (subcommand, bool) {
+	for _, sc := range subcommands() {
+		if sc.name == name {
+			return sc, true
+		}
+	}
+	return subcommand{}, false
+}
+
+// commandFlagSet returns an empty flag set for `apispec <name>` whose usage
+// shows synopsis and summary.
+func commandFlagSet(name, synopsis, summary string) *flag.FlagSet {
+	fs := flag.NewFlagSet("apispec "+name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s %s %s\n\n%s\n\nFlags:\n", os.Args[0], name, synopsis, summary)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseCommandFlags parses args with fs, filling unset flags from the
+// environment and the user config; a positional argument is the input
+// directory, overriding --dir.
+func parseCommandFlags(fs *flag.FlagSet, config *CLIConfig, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := applyDefaults(fs); err != nil {
+		return err
+	}
+	switch fs.NArg() {
+	case 0:
+	case 1:
+		config.InputDir = fs.Arg(0)
+	default:
+		return fmt.Errorf("%s: unexpected arguments %s", fs.Name(), strings.Join(fs.Args()[1:], " "))
+	}
+	return nil
+}
+
+// newCommandConfig returns a config holding every flag's default, for
+// commands that define only some of the flags.
+func newCommandConfig() *CLIConfig {
+	config := &CLIConfig{}
+	if err := generatorFlags(config).Parse(nil); err != nil {
+		panic(err) // parsing no arguments cannot fail
+	}
+	return config
+}
+
+// reportError prints err as an error diagnostic on stderr.
+func reportError(err error) {
+	diag.Report(diag.Diagnostic{Severity: diag.Error, Message: err.Error()})
+}
+
+// commandResult maps a parse error onto an exit code: 0 for -h, 2 otherwise.
+func commandResult(err error) int {
+	if err == flag.ErrHelp {
+		return 0
+	}
+	reportError(err)
+	return 2
+}
+
+const diffSummary = "Lists operations, parameters, request bodies, responses and component schemas\n" +
+	"added, removed or changed between two specs, marking the changes that break\n" +
+	"existing clients. With one spec, it is compared with the spec generated from\n" +
+	"--dir now. Exits 1 when the specs differ (with --breaking, only when a change\n" +
+	"is breaking)."
+
+func diffFlags(config *CLIConfig, breakingOnly *bool) *flag.FlagSet {
+	fs := commandFlagSet(diffCommand, "[flags] base.yaml [head.yaml]", diffSummary)
+	fs.BoolVar(breakingOnly, "breaking", false, "Report only breaking changes")
+	globalFlags(fs, config)
+	return fs
+}
+
+// runDiff implements `apispec diff`: 0 when nothing (breaking) changed, 1 on
+// changes, 2 on errors.
+func runDiff(args []string) int {
+	config := newCommandConfig()
+	var breakingOnly bool
+	fs := diffFlags(config, &breakingOnly)
+	if err := fs.Parse(args); err != nil {
+		return commandResult(err)
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		return 2
+	}
+
+	base, err := specdiff.Load(fs.Arg(0))
+	if err != nil {
+		reportError(err)
+		return 2
+	}
+	var head *spec.OpenAPISpec
+	if fs.NArg() == 2 {
+		head, err = specdiff.Load(fs.Arg(1))
+	} else {
+		head, _, err = runGeneration(config)
+	}
+	if err != nil {
+		reportError(err)
+		return 2
+	}
+
+	found := 0
+	for _, c := range specdiff.Compare(base, head) {
+		if breakingOnly && !c.Breaking {
+			continue
+		}
+		fmt.Println(c)
+		found++
+	}
+	if found > 0 {
+		return 1
+	}
+	fmt.Println("No changes")
+	return 0
+}
+
+const validateSummary = "Reports dangling component $refs, duplicate operationIds, path templates that\n" +
+	"disagree with their path parameters and invalid status codes in the spec\n" +
+	"generated from the source, or in an existing spec given with --spec. Exits 1\n" +
+	"when any are found."
+
+func validateFlags(config *CLIConfig, specFile *string) *flag.FlagSet {
+	fs := commandFlagSet(validateCommand, "[flags] [dir]", validateSummary)
+	fs.StringVar(specFile, "spec", "", "Validate this OpenAPI document instead of generating one")
+	globalFlags(fs, config)
+	return fs
+}
+
+// runValidate implements `apispec validate`: 0 for a valid spec, 1 on
+// issues, 2 on errors.
+func runValidate(args []string) int {
+	config := newCommandConfig()
+	var specFile string
+	if err := parseCommandFlags(validateFlags(config, &specFile), config, args); err != nil {
+		return commandResult(err)
+	}
+
+	var doc *spec.OpenAPISpec
+	var err error
+	if specFile != "" {
+		doc, err = specdiff.Load(specFile)
+	} else {
+		// The issues are the command's output: hold back the warnings
+		// generation reports on stderr, which repeat them, unless it fails.
+		var held bytes.Buffer
+		prev := diag.SetDefault(diag.NewPresenter(&held, diag.ColorEnabled(os.Stderr)))
+		doc, _, err = runGeneration(config)
+		diag.SetDefault(prev)
+		if err != nil {
+			_, _ = held.WriteTo(os.Stderr)
+		}
+	}
+	if err != nil {
+		reportError(err)
+		return 2
+	}
+
+	issues := spec.ValidateSpec(doc)
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	if len(issues) > 0 {
+		fmt.Printf("%d issues found\n", len(issues))
+		return 1
+	}
+	fmt.Println("Spec is valid")
+	return 0
+}
+
+const lintSummary = "Reports auth middleware not mapped to a security scheme, path variables read\n" +
+	"under a key the route does not declare, GET and HEAD handlers that call a\n" +
+	"repository or database mutation, routes whose path, prefix or handler could\n" +
+	"not be resolved, and JSON naming that trips up client generators. Exits 1\n" +
+	"when any are found."
+
+func lintFlags(config *CLIConfig) *flag.FlagSet {
+	fs := commandFlagSet(lintCommand, "[flags] [dir]", lintSummary)
+	globalFlags(fs, config)
+	return fs
+}
+
+// runLint implements `apispec lint`: 0 when clean, 1 on warnings, 2 on
+// errors.
+func runLint(args []string) int {
+	config := newCommandConfig()
+	if err := parseCommandFlags(lintFlags(config), config, args); err != nil {
+		return commandResult(err)
+	}
+	// The warnings are the command's output: hold back what generation
+	// reports on stderr, unless it fails and they may explain why.
+	var held bytes.Buffer
+	prev := diag.SetDefault(diag.NewPresenter(&held, diag.ColorEnabled(os.Stderr)))
+	_, genEngine, err := runGeneration(config)
+	diag.SetDefault(prev)
+	if err != nil {
+		_, _ = held.WriteTo(os.Stderr)
+		reportError(err)
+		return 2
+	}
+
+	warnings := lintWarnings(genEngine)
+	out := diag.NewPresenter(os.Stdout, diag.ColorEnabled(os.Stdout))
+	for _, w := range warnings {
+		out.Print(w)
+	}
+	if len(warnings) > 0 {
+		fmt.Printf("%d warnings\n", len(warnings))
+		return 1
+	}
+	fmt.Println("No warnings")
+	return 0
+}
+
+// lintWarnings collects the diagnostics of the engine's last run: config
+// keys it ignored, then the findings the mapper reports.
+func lintWarnings(genEngine *engine.Engine) []diag.Diagnostic {
+	found := &intspec.SecurityDiagnostics{
+		UnresolvedMiddleware: genEngine.GetUnresolvedSecurity(),
+		PathParamMismatches:  genEngine.GetPathParamMismatches(),
+		UnsafeMethodWrites:   genEngine.GetUnsafeMethodWrites(),
+		UnresolvedRoutes:     genEngine.GetUnresolvedRoutes(),
+		NamingIssues:         genEngine.GetNamingIssues(),
+		TimeLayoutMismatches: genEngine.GetTimeLayoutMismatches(),
+	}
+	return append(slices.Clone(genEngine.GetConfigDiagnostics()), found.Diagnostics()...)
+}
+
+func diagramCommandFlags(config *CLIConfig) *flag.FlagSet {
+	fs := commandFlagSet(diagramCommand, "[flags] [dir]", "Writes the call graph diagram (HTML) of the analyzed module.")
+	fs.StringVar(&config.DiagramPath, "output", "diagram.html", "Diagram file path")
+	fs.StringVar(&config.DiagramPath, "o", "diagram.html", "Shorthand for --output")
+	diagramFlags(fs, config)
+	globalFlags(fs, config)
+	return fs
+}
+
+// runDiagram implements `apispec diagram`.
+func runDiagram(args []string) int {
+	config := newCommandConfig()
+	if err := parseCommandFlags(diagramCommandFlags(config), config, args); err != nil {
+		return commandResult(err)
+	}
+	clampDiagramPageSize(config)
+	return writeAnalysis(config, func(e *engine.Engine, meta *metadata.Metadata) error {
+		if err := e.WriteDiagram(meta); err != nil {
+			return err
+		}
+		fmt.Println("Successfully generated:", config.DiagramPath)
+		return nil
+	})
+}
+
+func metadataCommandFlags(config *CLIConfig) *flag.FlagSet {
+	fs := commandFlagSet(metadataCommand, "[flags] [dir]", "Writes the metadata (packages, types, call graph) apispec extracts from the module.")
+	fs.StringVar(&config.MetadataFile, "output", engine.DefaultMetadataFile, "Metadata file path")
+	fs.StringVar(&config.MetadataFile, "o", engine.DefaultMetadataFile, "Shorthand for --output")
+	metadataFormatFlags(fs, config)
+	globalFlags(fs, config)
+	return fs
+}
+
+// runMetadata implements `apispec metadata`.
+func runMetadata(args []string) int {
+	config := newCommandConfig()
+	if err := parseCommandFlags(metadataCommandFlags(config), config, args); err != nil {
+		return commandResult(err)
+	}
+	return writeAnalysis(config, func(e *engine.Engine, meta *metadata.Metadata) error {
+		if err := e.WriteMetadata(meta); err != nil {
+			return err
+		}
+		fmt.Println("Successfully generated:", config.MetadataFile)
+		return nil
+	})
+}
+
+// writeAnalysis analyzes the module without mapping a spec and hands the
+// metadata to write.
+func writeAnalysis(config *CLIConfig, write func(*engine.Engine, *metadata.Metadata) error) int {
+	ctx, cancel := analysisContext(config)
+	defer cancel()
+
+	genEngine := engine.NewEngine(engineConfig(config))
+	meta, err := genEngine.GenerateMetadataOnlyContext(ctx)
+	err = timeoutError(config, err)
+	if err == nil {
+		err = write(genEngine, meta)
+	}
+	if err != nil {
+		reportError(err)
+		return 1
+	}
+	return 0
+}
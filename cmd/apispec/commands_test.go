@@ -0,0 +1,290 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/sarif"
+)
+
+func TestLookupSubcommand(t *testing.T) {
+	for _, name := range []string{"generate", "diff", "validate", "lint", "diagram", "metadata", "serve", "check-gateway", "test", "completion", "man"} {
+		if sc, ok := lookupSubcommand(name); !ok || sc.run == nil {
+			t.Errorf("%s: not a runnable subcommand", name)
+		}
+	}
+	// Anything else is the generator's, as before subcommands existed.
+	for _, arg := range []string{"./api", "-o", "--dir"} {
+		if _, ok := lookupSubcommand(arg); ok {
+			t.Errorf("%q taken for a subcommand", arg)
+		}
+	}
+}
+
+func TestSubcommands_ShareGlobalFlags(t *testing.T) {
+	global := describeFlags(globalFlagSet())
+	for _, sc := range subcommands() {
+		if sc.flags == nil {
+			continue
+		}
+		fs := sc.flags()
+		for _, f := range global {
+			if fs.Lookup(f.name) == nil {
+				t.Errorf("%s lacks global flag --%s", sc.name, f.name)
+			}
+		}
+	}
+}
+
+func TestParseCommandFlags(t *testing.T) {
+	config := newCommandConfig()
+	if err := parseCommandFlags(lintFlags(config), config, []string{"-c", "apispec.yaml", "./svc"}); err != nil {
+		t.Fatal(err)
+	}
+	if config.InputDir != "./svc" || config.ConfigFile != "apispec.yaml" || config.MaxNodesPerTree == 0 {
+		t.Errorf("got dir=%q config=%q max-nodes=%d", config.InputDir, config.ConfigFile, config.MaxNodesPerTree)
+	}
+	if err := parseCommandFlags(lintFlags(config), config, []string{"a", "b"}); err == nil {
+		t.Error("expected an error for a second operand")
+	}
+}
+
+// writeSpec writes an OpenAPI document to a temp file and returns its path.
+func writeSpec(t *testing.T, name, doc string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const baseSpec = `openapi: 3.1.0
+info: {title: t, version: "1"}
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/User'}
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id: {type: string}
+`
+
+func TestRunDiff(t *testing.T) {
+	base := writeSpec(t, "base.yaml", baseSpec)
+	if code := runDiff([]string{base, base}); code != 0 {
+		t.Errorf("identical specs: exit %d, want 0", code)
+	}
+
+	added := writeSpec(t, "added.yaml", baseSpec+"        name: {type: string}\n")
+	if code := runDiff([]string{base, added}); code != 1 {
+		t.Errorf("added property: exit %d, want 1", code)
+	}
+	if code := runDiff([]string{"--breaking", base, added}); code != 0 {
+		t.Errorf("--breaking with only an addition: exit %d, want 0", code)
+	}
+	if code := runDiff([]string{"--breaking", added, base}); code != 1 {
+		t.Errorf("--breaking with a removed property: exit %d, want 1", code)
+	}
+
+	if code := runDiff(nil); code != 2 {
+		t.Errorf("no operands: exit %d, want 2", code)
+	}
+	if code := runDiff([]string{filepath.Join(t.TempDir(), "missing.yaml"), base}); code != 2 {
+		t.Errorf("missing spec: exit %d, want 2", code)
+	}
+}
+
+func TestRunValidate_SpecFile(t *testing.T) {
+	if code := runValidate([]string{"--spec", writeSpec(t, "ok.yaml", baseSpec)}); code != 0 {
+		t.Errorf("valid spec: exit %d, want 0", code)
+	}
+	// Renaming the component leaves the response's $ref dangling.
+	dangling := writeSpec(t, "bad.yaml", strings.Replace(baseSpec, "    User:\n", "    Account:\n", 1))
+	if code := runValidate([]string{"--spec", dangling}); code != 1 {
+		t.Errorf("dangling $ref: exit %d, want 1", code)
+	}
+}
+
+func TestRunDiagramAndMetadata(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module test\n\ngo 1.21\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(`package main
+
+import "net/http"
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func main() {
+	http.HandleFunc("/ping", handler)
+}
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := runDiagram([]string{"-o", "graph.html", dir}); code != 0 {
+		t.Fatalf("diagram: exit %d", code)
+	}
+	if code := runMetadata([]string{"--output", "meta.yaml", dir}); code != 0 {
+		t.Fatalf("metadata: exit %d", code)
+	}
+	for _, name := range []string{"graph.html", "meta.yaml"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s not written: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "openapi.json")); err == nil {
+		t.Error("diagram/metadata wrote a spec")
+	}
+}
+
+func TestRunGoldenTests(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "ping")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module ping\n\ngo 1.22\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	writeMain := func(path string) {
+		t.Helper()
+		src := "package main\n\nimport \"net/http\"\n\nfunc main() {\n\thttp.HandleFunc(\"GET " + path +
+			"\", func(w http.ResponseWriter, r *http.Request) {})\n}\n"
+		if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeMain("/ping")
+
+	var out strings.Builder
+	if code := runGoldenTests([]string{root}, &out); code != 1 || !strings.Contains(out.String(), "MISSING") {
+		t.Fatalf("without a golden file: exit %d\n%s", code, out.String())
+	}
+	out.Reset()
+	if code := runGoldenTests([]string{"--update", root}, &out); code != 0 {
+		t.Fatalf("--update: exit %d\n%s", code, out.String())
+	}
+	out.Reset()
+	if code := runGoldenTests([]string{root}, &out); code != 0 || !strings.Contains(out.String(), "ok ") {
+		t.Fatalf("after --update: exit %d\n%s", code, out.String())
+	}
+
+	writeMain("/pong")
+	out.Reset()
+	if code := runGoldenTests([]string{root}, &out); code != 1 || !strings.Contains(out.String(), "+  /pong:") {
+		t.Fatalf("after a route change: exit %d\n%s", code, out.String())
+	}
+}
+
+func TestLintWarnings_PathParamSuggestion(t *testing.T) {
+	config := newCommandConfig()
+	config.InputDir = filepath.Join("..", "..", "testdata", "mux_path_params")
+	_, genEngine, err := runGeneration(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	warnings := lintWarnings(genEngine)
+	if len(warnings) != 1 {
+		t.Fatalf("got %d warnings, want 1: %+v", len(warnings), warnings)
+	}
+	w := warnings[0]
+	if w.Category != "path-params" || w.Pos.Line == 0 || !strings.HasSuffix(w.Pos.File, "main.go") {
+		t.Errorf("got %+v, want a path-params warning located in main.go", w)
+	}
+	if w.Help != "the path declares id" {
+		t.Errorf("help = %q", w.Help)
+	}
+}
+
+func TestWriteDiagnostics(t *testing.T) {
+	config, err := parseFlags([]string{"--diagnostics", filepath.Join(t.TempDir(), "apispec.sarif")})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	config.InputDir = filepath.Join("..", "..", "testdata", "route_diagnostics")
+	_, genEngine, err := runGeneration(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeDiagnostics(config, genEngine); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(config.Diagnostics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log sarif.Log
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("invalid SARIF JSON: %v", err)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 5 {
+		t.Fatalf("got %d results, want 5: %+v", len(results), results)
+	}
+	first := results[0]
+	if first.RuleID != "routes" || first.Level != sarif.LevelError || !strings.Contains(first.Message.Text, "listInvoices dropped") {
+		t.Errorf("first result = %+v, want the dropped route as an error", first)
+	}
+	// Files are named relative to the module for code scanning.
+	if uri := first.Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "main.go" {
+		t.Errorf("uri = %q, want main.go", uri)
+	}
+}
+
+func TestPrintRouteCoverage(t *testing.T) {
+	config, err := parseFlags([]string{"--coverage"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	config.InputDir = filepath.Join("..", "..", "testdata", "route_diagnostics")
+	_, genEngine, err := runGeneration(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	printRouteCoverage(&buf, genEngine.GetRouteCoverage(), genEngine.ModuleRoot())
+	out := buf.String()
+	for _, want := range []string{
+		"Route coverage: 5/6 registration call sites extracted (83.3%)",
+		"testdata/route_diagnostics    5/6   83.3%",
+		"Unmatched call sites (1):\n  main.go:61:2  github.com/ehabterra/apispec/testdata/route_diagnostics.listInvoices\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
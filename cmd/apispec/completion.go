@@ -0,0 +1,425 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/gateway"
+	"github.com/ehabterra/apispec/spec"
+)
+
+const (
+	completionCommand = "completion"
+	manCommand        = "man"
+)
+
+// Shells `apispec completion` writes scripts for.
+const (
+	shellBash = "bash"
+	shellZsh  = "zsh"
+	shellFish = "fish"
+)
+
+var completionShells = []string{shellBash, shellZsh, shellFish}
+
+// cliFlag is a flag with its shorthand folded in.
+type cliFlag struct {
+	name     string
+	short    string
+	usage    string
+	argName  string // value placeholder; "" for a boolean flag
+	defValue string
+}
+
+// describeFlags lists the flags of fs by name. A flag whose usage reads
+// "Shorthand for --x" becomes the short form of x.
+func describeFlags(fs *flag.FlagSet) []cliFlag {
+	var flags []cliFlag
+	shorts := map[string]string{}
+	fs.VisitAll(func(f *flag.Flag) {
+		if long, ok := strings.CutPrefix(f.Usage, "Shorthand for --"); ok {
+			shorts[long] = f.Name
+			return
+		}
+		argName, usage := flag.UnquoteUsage(f)
+		flags = append(flags, cliFlag{name: f.Name, usage: usage, argName: argName, defValue: f.DefValue})
+	})
+	for i := range flags {
+		flags[i].short = shorts[flags[i].name]
+	}
+	return flags
+}
+
+// valueKind says how a flag's value is completed.
+type valueKind int
+
+const (
+	valueText  valueKind = iota // free text, nothing to offer
+	valueFile                   // a file path
+	valueDir                    // a directory
+	valueWords                  // one of a fixed list
+)
+
+// flagValue returns how the value of flag name of command cmd ("" for the
+// generator) is completed, and the words for valueWords.
+func flagValue(cmd, name string) (valueKind, []string) {
+	if cmd == checkGatewayCommand && name == "gateway" {
+		return valueFile, nil
+	}
+	switch name {
+	case "format":
+		return valueWords, []string{formatOpenAPI, formatGatewayConfig}
+	case "gateway":
+		return valueWords, []string{gateway.KindKong, gateway.KindEnvoy}
+	case "sort":
+		return valueWords, []string{string(spec.SortSource), string(spec.SortAlpha)}
+	case "dir", "profile-dir", "schema-out":
+		return valueDir, nil
+	case "output", "config", "output-config", "diagram", "spec":
+		return valueFile, nil
+	}
+	if strings.HasSuffix(name, "-path") {
+		return valueFile, nil
+	}
+	return valueText, nil
+}
+
+// runCompletion implements `apispec completion <shell>`.
+func runCompletion(args []string, w io.Writer) int {
+	if len(args) != 1 {
+		log.Printf("usage: apispec %s %s", completionCommand, strings.Join(completionShells, "|"))
+		return 2
+	}
+	if err := writeCompletion(w, args[0]); err != nil {
+		log.Printf("%v", err)
+		return 2
+	}
+	return 0
+}
+
+func writeCompletion(w io.Writer, shell string) error {
+	var script string
+	switch shell {
+	case shellBash:
+		script = bashCompletion()
+	case shellZsh:
+		script = zshCompletion()
+	case shellFish:
+		script = fishCompletion()
+	default:
+		return fmt.Errorf("%s: unknown shell %q (want %s)", completionCommand, shell, strings.Join(completionShells, ", "))
+	}
+	_, err := io.WriteString(w, script)
+	return err
+}
+
+// commandFlags pairs each command that takes flags ("" for the generator)
+// with its flags.
+func commandFlags() []struct {
+	cmd   string
+	flags []cliFlag
+} {
+	out := []struct {
+		cmd   string
+		flags []cliFlag
+	}{{"", describeFlags(generatorFlags(&CLIConfig{}))}}
+	for _, sc := range subcommands() {
+		if sc.flags != nil {
+			out = append(out, struct {
+				cmd   string
+				flags []cliFlag
+			}{sc.name, describeFlags(sc.flags())})
+		}
+	}
+	return out
+}
+
+func subcommandNames() []string {
+	var names []string
+	for _, sc := range subcommands() {
+		names = append(names, sc.name)
+	}
+	return names
+}
+
+func bashCompletion() string {
+	var b strings.Builder
+	b.WriteString(`# bash completion for apispec
+# Load it with: source <(apispec completion bash)
+
+`)
+	for _, c := range commandFlags() {
+		fn := "_apispec_flags"
+		if c.cmd != "" {
+			fn += "_" + strings.ReplaceAll(c.cmd, "-", "_")
+		}
+		// fn completes the word after a flag and returns 0, or returns 1
+		// when the previous word takes no value.
+		fmt.Fprintf(&b, "%s() {\n    case \"$prev\" in\n", fn)
+		var text []string
+		for _, f := range c.flags {
+			if f.argName == "" {
+				continue
+			}
+			forms := bashFlagForms(f)
+			switch kind, words := flagValue(c.cmd, f.name); kind {
+			case valueFile:
+				fmt.Fprintf(&b, "    %s) COMPREPLY=($(compgen -f -- \"$cur\")) ;;\n", forms)
+			case valueDir:
+				fmt.Fprintf(&b, "    %s) COMPREPLY=($(compgen -d -- \"$cur\")) ;;\n", forms)
+			case valueWords:
+				fmt.Fprintf(&b, "    %s) COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", forms, strings.Join(words, " "))
+			default:
+				text = append(text, forms)
+			}
+		}
+		if len(text) > 0 {
+			fmt.Fprintf(&b, "    %s) COMPREPLY=() ;;\n", strings.Join(text, "|"))
+		}
+		b.WriteString("    *) return 1 ;;\n    esac\n}\n\n")
+		fmt.Fprintf(&b, "%s_words=%q\n\n", fn, strings.Join(offeredFlags(c.flags), " "))
+	}
+	b.WriteString(`_apispec() {
+    local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}" cmd=""
+    if [[ $COMP_CWORD -gt 1 ]]; then
+        case "${COMP_WORDS[1]}" in
+`)
+	fmt.Fprintf(&b, "        %s) cmd=\"${COMP_WORDS[1]}\" ;;\n", strings.Join(subcommandNames(), "|"))
+	b.WriteString(`        esac
+    fi
+
+    case "$cmd" in
+`)
+	for _, sc := range subcommands() {
+		fmt.Fprintf(&b, "    %s)\n", sc.name)
+		switch {
+		case sc.flags != nil:
+			fn := "_apispec_flags_" + strings.ReplaceAll(sc.name, "-", "_")
+			fmt.Fprintf(&b, `        %s && return
+        if [[ $cur == -* ]]; then
+            COMPREPLY=($(compgen -W "$%s_words" -- "$cur"))
+        else
+            COMPREPLY=($(compgen %s -- "$cur"))
+        fi
+`, fn, fn, bashOperandAction(sc.operands))
+		case sc.name == completionCommand:
+			fmt.Fprintf(&b, "        [[ $COMP_CWORD -eq 2 ]] && COMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(completionShells, " "))
+		}
+		b.WriteString("        return ;;\n")
+	}
+	fmt.Fprintf(&b, `    esac
+
+    _apispec_flags && return
+    if [[ $cur == -* ]]; then
+        COMPREPLY=($(compgen -W "$_apispec_flags_words" -- "$cur"))
+    elif [[ $COMP_CWORD -eq 1 ]]; then
+        COMPREPLY=($(compgen -W %q -- "$cur") $(compgen -d -- "$cur"))
+    else
+        COMPREPLY=($(compgen -d -- "$cur"))
+    fi
+}
+
+complete -o filenames -F _apispec apispec
+`, strings.Join(subcommandNames(), " "))
+	return b.String()
+}
+
+// bashOperandAction is the compgen action completing operands of kind.
+func bashOperandAction(kind valueKind) string {
+	if kind == valueFile {
+		return "-f"
+	}
+	return "-d"
+}
+
+// bashFlagForms is the case pattern matching every spelling of f: Go's flag
+// package takes one dash or two.
+func bashFlagForms(f cliFlag) string {
+	forms := []string{"--" + f.name, "-" + f.name}
+	if f.short != "" {
+		forms = append(forms, "--"+f.short, "-"+f.short)
+	}
+	return strings.Join(forms, "|")
+}
+
+// offeredFlags are the spellings completion offers: --long and -short.
+func offeredFlags(flags []cliFlag) []string {
+	var words []string
+	for _, f := range flags {
+		words = append(words, "--"+f.name)
+		if f.short != "" {
+			words = append(words, "-"+f.short)
+		}
+	}
+	sort.Strings(words)
+	return words
+}
+
+func zshCompletion() string {
+	var b strings.Builder
+	b.WriteString(`#compdef apispec
+# zsh completion for apispec
+# Save it as _apispec on $fpath, or load it with: source <(apispec completion zsh)
+
+_apispec() {
+    local -a commands=(
+`)
+	for _, sc := range subcommands() {
+		fmt.Fprintf(&b, "        %s\n", zshQuote(sc.name+":"+sc.summary))
+	}
+	b.WriteString("    )\n")
+	specs := map[string][]string{}
+	for _, c := range commandFlags() {
+		for _, f := range c.flags {
+			specs[c.cmd] = append(specs[c.cmd], zshFlagSpec(c.cmd, f))
+		}
+		operand := `'*:directory:_files -/'`
+		if sc, ok := lookupSubcommand(c.cmd); ok && sc.operands == valueFile {
+			operand = `'*:file:_files'`
+		}
+		specs[c.cmd] = append(specs[c.cmd], operand)
+	}
+	b.WriteString(`
+    if (( CURRENT > 2 )); then
+        case $words[2] in
+`)
+	for _, sc := range subcommands() {
+		fmt.Fprintf(&b, "        %s)\n", sc.name)
+		switch {
+		case sc.flags != nil:
+			b.WriteString("            shift words\n            (( CURRENT-- ))\n            _arguments -s : \\\n                ")
+			b.WriteString(strings.Join(specs[sc.name], " \\\n                "))
+			b.WriteString("\n")
+		case sc.name == completionCommand:
+			fmt.Fprintf(&b, "            (( CURRENT == 3 )) && _values shell %s\n", strings.Join(completionShells, " "))
+		}
+		b.WriteString("            return ;;\n")
+	}
+	fmt.Fprintf(&b, `        esac
+    fi
+
+    if (( CURRENT == 2 )) && [[ $PREFIX != -* ]]; then
+        _describe -t commands 'apispec command' commands
+        _files -/
+        return
+    fi
+    _arguments -s : \
+        %s
+}
+
+if [[ $zsh_eval_context[-1] == loadautofunc ]]; then
+    _apispec "$@"
+else
+    compdef _apispec apispec
+fi
+`, strings.Join(specs[""], " \\\n        "))
+	return b.String()
+}
+
+// zshFlagSpec renders f as an _arguments spec:
+// '(--dir -d)'{--dir,-d}'[Input directory]:dir:_files -/'.
+func zshFlagSpec(cmd string, f cliFlag) string {
+	desc := "[" + zshEscapeDesc(f.usage) + "]"
+	if f.argName != "" {
+		action := " "
+		switch kind, words := flagValue(cmd, f.name); kind {
+		case valueFile:
+			action = "_files"
+		case valueDir:
+			action = "_files -/"
+		case valueWords:
+			action = "(" + strings.Join(words, " ") + ")"
+		}
+		desc += ":" + f.argName + ":" + action
+	}
+	if f.short == "" {
+		return zshQuote("--" + f.name + desc)
+	}
+	return zshQuote("(--"+f.name+" -"+f.short+")") + "{--" + f.name + ",-" + f.short + "}" + zshQuote(desc)
+}
+
+func zshEscapeDesc(s string) string {
+	return strings.NewReplacer(`[`, `\[`, `]`, `\]`, `:`, `\:`).Replace(s)
+}
+
+// zshQuote single-quotes s for zsh.
+func zshQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func fishCompletion() string {
+	var b strings.Builder
+	b.WriteString(`# fish completion for apispec
+# Save it as ~/.config/fish/completions/apispec.fish, or load it with:
+#   apispec completion fish | source
+
+complete -c apispec -f
+`)
+	noSubcommand := "not __fish_seen_subcommand_from " + strings.Join(subcommandNames(), " ")
+	for _, sc := range subcommands() {
+		fmt.Fprintf(&b, "complete -c apispec -n __fish_use_subcommand -a %s -d %s\n", sc.name, fishQuote(sc.summary))
+	}
+	fmt.Fprintf(&b, "complete -c apispec -n %s -a '(__fish_complete_directories)'\n", fishQuote(noSubcommand))
+	for _, c := range commandFlags() {
+		cond := noSubcommand
+		if c.cmd != "" {
+			cond = "__fish_seen_subcommand_from " + c.cmd
+			if sc, _ := lookupSubcommand(c.cmd); sc.operands == valueFile {
+				fmt.Fprintf(&b, "complete -c apispec -n %s -F\n", fishQuote(cond))
+			} else {
+				fmt.Fprintf(&b, "complete -c apispec -n %s -a '(__fish_complete_directories)'\n", fishQuote(cond))
+			}
+		}
+		for _, f := range c.flags {
+			line := "complete -c apispec -n " + fishQuote(cond) + " -l " + f.name
+			if f.short != "" {
+				// -s takes a single character; longer shorthands are
+				// old-style (-o) single-dash options.
+				opt := "-o"
+				if len(f.short) == 1 {
+					opt = "-s"
+				}
+				line += " " + opt + " " + f.short
+			}
+			if f.argName != "" {
+				switch kind, words := flagValue(c.cmd, f.name); kind {
+				case valueFile:
+					line += " -r -F"
+				case valueDir:
+					line += " -x -a '(__fish_complete_directories)'"
+				case valueWords:
+					line += " -x -a " + fishQuote(strings.Join(words, " "))
+				default:
+					line += " -x"
+				}
+			}
+			b.WriteString(line + " -d " + fishQuote(f.usage) + "\n")
+		}
+	}
+	fmt.Fprintf(&b, "complete -c apispec -n %s -x -a %s\n",
+		fishQuote("__fish_seen_subcommand_from "+completionCommand), fishQuote(strings.Join(completionShells, " ")))
+	return b.String()
+}
+
+// fishQuote single-quotes s for fish.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
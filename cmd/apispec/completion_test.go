@@ -0,0 +1,134 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDescribeFlags_FoldsShorthands(t *testing.T) {
+	flags := describeFlags(generatorFlags(&CLIConfig{}))
+	byName := map[string]cliFlag{}
+	for _, f := range flags {
+		byName[f.name] = f
+	}
+	if _, ok := byName["o"]; ok {
+		t.Error("shorthand -o listed as its own flag")
+	}
+	if out := byName["output"]; out.short != "o" || out.argName == "" || out.defValue != "openapi.json" {
+		t.Errorf("output flag = %+v", out)
+	}
+	if v := byName["verbose"]; v.short != "vb" || v.argName != "" {
+		t.Errorf("verbose flag = %+v", v)
+	}
+}
+
+func TestCompletionScripts_CoverAllFlags(t *testing.T) {
+	for _, shell := range completionShells {
+		t.Run(shell, func(t *testing.T) {
+			var buf bytes.Buffer
+			if code := runCompletion([]string{shell}, &buf); code != 0 {
+				t.Fatalf("runCompletion(%s) = %d", shell, code)
+			}
+			script := buf.String()
+			for _, c := range commandFlags() {
+				for _, f := range c.flags {
+					// Fish names long options with -l, the others spell them out.
+					want := "--" + f.name
+					if shell == shellFish {
+						want = "-l " + f.name + " "
+					}
+					if !strings.Contains(script, want) {
+						t.Errorf("%s script lacks %q", shell, want)
+					}
+				}
+			}
+			for _, name := range subcommandNames() {
+				if !strings.Contains(script, name) {
+					t.Errorf("%s script lacks subcommand %q", shell, name)
+				}
+			}
+		})
+	}
+}
+
+func TestCompletionBash_Completes(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not installed")
+	}
+	path := filepath.Join(t.TempDir(), "apispec.bash")
+	var buf bytes.Buffer
+	runCompletion([]string{shellBash}, &buf)
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// Complete "apispec --format <TAB>" with the script loaded.
+	cmd := exec.Command(bash, "-c", `source "$1"; COMP_WORDS=(apispec --format ""); COMP_CWORD=2; _apispec; echo "${COMPREPLY[*]}"`, "bash", path)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("bash: %v\n%s", err, out)
+	}
+	if got := strings.TrimSpace(string(out)); got != "openapi gateway-config" {
+		t.Errorf("--format completions = %q", got)
+	}
+}
+
+func TestRunCompletion_UnknownShell(t *testing.T) {
+	var buf bytes.Buffer
+	if code := runCompletion([]string{"pwsh"}, &buf); code != 2 {
+		t.Errorf("unknown shell exit code = %d, want 2", code)
+	}
+	if code := runCompletion(nil, &buf); code != 2 {
+		t.Errorf("missing shell exit code = %d, want 2", code)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("wrote %q on error", buf.String())
+	}
+}
+
+func TestManPage(t *testing.T) {
+	var buf bytes.Buffer
+	if code := runMan(nil, &buf); code != 0 {
+		t.Fatalf("runMan = %d", code)
+	}
+	page := buf.String()
+	for _, want := range []string{
+		".TH APISPEC 1",
+		".SH OPTIONS",
+		`\fB\-o\fR, \fB\-\-output\fR \fIstring\fR`,
+		`(default: openapi.json)`,
+		".SS check\\-gateway",
+		`\fB\-\-gateway\fR`,
+		".SH EXAMPLES",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("man page lacks %q", want)
+		}
+	}
+	for _, line := range strings.Split(page, "\n") {
+		if strings.HasPrefix(line, "'") {
+			t.Errorf("unescaped control line %q", line)
+		}
+	}
+	if code := runMan([]string{"extra"}, &buf); code != 2 {
+		t.Errorf("runMan with arguments = %d, want 2", code)
+	}
+}
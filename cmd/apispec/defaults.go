@@ -0,0 +1,144 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envPrefix starts the environment variables that supply flag defaults:
+// APISPEC_OUTPUT for --output, APISPEC_MAX_NODES for --max-nodes.
+const envPrefix = "APISPEC_"
+
+// userConfigPath returns the user-level defaults file,
+// $XDG_CONFIG_HOME/apispec/config.yaml or ~/.config/apispec/config.yaml.
+func userConfigPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "apispec", "config.yaml")
+}
+
+// noDefaultFlags are never taken from the environment or the user config:
+// CI images commonly export APISPEC_VERSION to pin the tool itself.
+var noDefaultFlags = map[string]bool{"version": true}
+
+// envName returns the environment variable holding flag name's default.
+func envName(name string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// applyDefaults fills the flags of fs that args did not set from APISPEC_*
+// environment variables, then from the user config, so explicit flags always
+// win. A default belongs to a generator flag: it is applied to another
+// command's flag of that name only when the two mean the same thing, so
+// APISPEC_OUTPUT names the spec, never the diagram `apispec diagram -o`
+// writes.
+func applyDefaults(fs *flag.FlagSet) error {
+	userDefaults, err := loadUserDefaults(userConfigPath())
+	if err != nil {
+		return err
+	}
+
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	generator := generatorFlags(&CLIConfig{})
+
+	for _, f := range describeFlags(fs) {
+		if set[f.name] || set[f.short] || noDefaultFlags[f.name] {
+			continue
+		}
+		if g := generator.Lookup(f.name); g == nil || g.Usage != fs.Lookup(f.name).Usage {
+			continue
+		}
+		var values []string
+		var source string
+		if v, ok := os.LookupEnv(envName(f.name)); ok {
+			values, source = []string{v}, envName(f.name)
+			if _, repeatable := fs.Lookup(f.name).Value.(*stringSliceFlag); repeatable {
+				values = strings.Split(v, ",")
+			}
+		} else if v, ok := userDefaults[f.name]; ok {
+			values, source = v, userConfigPath()
+		}
+		for _, v := range values {
+			if err := fs.Set(f.name, strings.TrimSpace(v)); err != nil {
+				return fmt.Errorf("%s: invalid value %q for --%s: %v", source, v, f.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// loadUserDefaults reads the user config at path: a map from long flag names
+// to a value, or a list of values for a repeatable flag. A missing file
+// supplies nothing; a key that names no generator flag is an error.
+func loadUserDefaults(path string) (map[string][]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	generator := generatorFlags(&CLIConfig{})
+	defaults := map[string][]string{}
+	for _, name := range slices.Sorted(maps.Keys(raw)) {
+		node := raw[name]
+		f := generator.Lookup(name)
+		if f == nil {
+			return nil, fmt.Errorf("%s: unknown flag %q", path, name)
+		}
+		if long, ok := strings.CutPrefix(f.Usage, "Shorthand for --"); ok {
+			return nil, fmt.Errorf("%s: use the long name %q instead of %q", path, long, name)
+		}
+		switch node.Kind {
+		case yaml.ScalarNode:
+			defaults[name] = []string{node.Value}
+		case yaml.SequenceNode:
+			for _, item := range node.Content {
+				if item.Kind != yaml.ScalarNode {
+					return nil, fmt.Errorf("%s: %s: list items must be plain values", path, name)
+				}
+				defaults[name] = append(defaults[name], item.Value)
+			}
+		default:
+			return nil, fmt.Errorf("%s: %s: want a value or a list of values", path, name)
+		}
+	}
+	return defaults, nil
+}
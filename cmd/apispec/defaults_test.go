@@ -0,0 +1,107 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeUserConfig points the user config at a temp dir holding doc.
+func writeUserConfig(t *testing.T, doc string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if err := os.MkdirAll(filepath.Join(dir, "apispec"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "apispec", "config.yaml"), []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplyDefaults_Precedence(t *testing.T) {
+	writeUserConfig(t, `output: from-config.yaml
+contact-name: Platform Team
+max-nodes: 1000
+include-package: [internal/api, internal/web]
+`)
+	t.Setenv("APISPEC_OUTPUT", "from-env.yaml")
+	t.Setenv("APISPEC_SKIP_CGO", "false")
+	t.Setenv("APISPEC_VERSION", "0.5.2") // the tool's own pin, not --version
+
+	config, err := parseFlags([]string{"--max-nodes", "5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.OutputFile != "from-env.yaml" || !config.OutputFlagSet {
+		t.Errorf("output = %q (set=%v), want the environment's", config.OutputFile, config.OutputFlagSet)
+	}
+	if config.MaxNodesPerTree != 5 {
+		t.Errorf("max-nodes = %d, want the flag's 5", config.MaxNodesPerTree)
+	}
+	if config.ContactName != "Platform Team" || config.SkipCGOPackages || config.ShowVersion {
+		t.Errorf("contact=%q skip-cgo=%v version=%v", config.ContactName, config.SkipCGOPackages, config.ShowVersion)
+	}
+	if want := []string{"internal/api", "internal/web"}; !slices.Equal(config.IncludePackages, want) {
+		t.Errorf("include-package = %v, want %v", config.IncludePackages, want)
+	}
+
+	// A shorthand counts as setting the flag.
+	if config, err = parseFlags([]string{"-o", "flag.yaml"}); err != nil || config.OutputFile != "flag.yaml" {
+		t.Errorf("-o: output = %q, err %v", config.OutputFile, err)
+	}
+}
+
+func TestApplyDefaults_OnlySameMeaning(t *testing.T) {
+	writeUserConfig(t, "dir: ./svc\n")
+	t.Setenv("APISPEC_OUTPUT", "openapi.yaml")
+
+	config := newCommandConfig()
+	if err := parseCommandFlags(diagramCommandFlags(config), config, nil); err != nil {
+		t.Fatal(err)
+	}
+	if config.DiagramPath != "diagram.html" {
+		t.Errorf("diagram --output = %q; APISPEC_OUTPUT names the spec", config.DiagramPath)
+	}
+	if config.InputDir != "./svc" {
+		t.Errorf("--dir = %q, want the user config's", config.InputDir)
+	}
+}
+
+func TestApplyDefaults_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name, config, env, wantErr string
+	}{
+		{"bad env value", "", "lots", "APISPEC_MAX_NODES"},
+		{"unknown key", "max-cats: 3\n", "", `unknown flag "max-cats"`},
+		{"shorthand key", "o: spec.yaml\n", "", `use the long name "output"`},
+		{"nested value", "include-package: {a: b}\n", "", "want a value or a list"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			writeUserConfig(t, tc.config)
+			if tc.env != "" {
+				t.Setenv("APISPEC_MAX_NODES", tc.env)
+			}
+			_, err := parseFlags(nil)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("got %v, want an error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
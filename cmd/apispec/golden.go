@@ -0,0 +1,155 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+
+	"github.com/ehabterra/apispec/internal/diag"
+	"github.com/ehabterra/apispec/spectest"
+)
+
+const testCommand = "test"
+
+const testSummary = "Generates the spec of every module under root (testdata by default, or --dir),\n" +
+	"each directory holding a go.mod, and compares it with the module's\n" +
+	"expected_openapi.yaml, printing a unified diff for each mismatch. A module's\n" +
+	"apispec.yaml, when it has one and --config is not given, is its config.\n" +
+	"Exits 1 when a spec differs or has no golden file, 2 when one cannot be\n" +
+	"generated."
+
+// defaultTestRoot is where `apispec test` looks for modules without a root
+// argument or --dir.
+const defaultTestRoot = "testdata"
+
+func testFlags(config *CLIConfig, opts *spectest.GoldenOptions) *flag.FlagSet {
+	fs := commandFlagSet(testCommand, "[flags] [root]", testSummary)
+	fs.BoolVar(&opts.Update, "update", false, "Write the generated specs as the golden files instead of comparing")
+	fs.StringVar(&opts.GoldenFile, "golden", spectest.GoldenFile, "Name of the golden file in each module")
+	globalFlags(fs, config)
+	return fs
+}
+
+// runTest implements `apispec test`.
+func runTest(args []string) int {
+	return runGoldenTests(args, os.Stdout)
+}
+
+// runGoldenTests checks every module under the root in args against its
+// golden file, writing a line per module, and the diffs, to w.
+func runGoldenTests(args []string, w io.Writer) int {
+	config := newCommandConfig()
+	var opts spectest.GoldenOptions
+	fs := testFlags(config, &opts)
+	if err := parseCommandFlags(fs, config, args); err != nil {
+		return commandResult(err)
+	}
+	root := config.InputDir
+	if fs.NArg() == 0 && !flagGiven(fs, "dir", "d") {
+		root = defaultTestRoot
+	}
+
+	dirs, err := spectest.FindProjects(root)
+	if err != nil {
+		reportError(err)
+		return 2
+	}
+	if len(dirs) == 0 {
+		reportError(fmt.Errorf("no modules under %s", root))
+		return 2
+	}
+
+	// The diffs are the command's output: the engine's phase logs and
+	// warnings, for every module, would bury them.
+	if !config.Verbose {
+		prev := log.Writer()
+		log.SetOutput(io.Discard)
+		defer log.SetOutput(prev)
+	}
+
+	var failed, broken int
+	for _, dir := range dirs {
+		res, err := checkModule(config, dir, opts)
+		switch {
+		case err != nil:
+			fmt.Fprintf(w, "ERROR   %s: %v\n", dir, err)
+			broken++
+		case res.Updated:
+			fmt.Fprintf(w, "updated %s\n", res.Golden)
+		case res.Missing:
+			fmt.Fprintf(w, "MISSING %s (run with --update to write it)\n", res.Golden)
+			failed++
+		case res.Diff != "":
+			fmt.Fprintf(w, "FAIL    %s\n%s", dir, res.Diff)
+			failed++
+		default:
+			fmt.Fprintf(w, "ok      %s\n", dir)
+		}
+	}
+
+	fmt.Fprintf(w, "%d modules, %d failed, %d errors\n", len(dirs), failed, broken)
+	switch {
+	case broken > 0:
+		return 2
+	case failed > 0:
+		return 1
+	}
+	return 0
+}
+
+// checkModule generates the spec of the module in dir with the command's
+// flags and compares it with the module's golden file. Unless --verbose is
+// set, the warnings generation reports are held back, and shown only when
+// it fails.
+func checkModule(config *CLIConfig, dir string, opts spectest.GoldenOptions) (*spectest.GoldenResult, error) {
+	moduleConfig := *config
+	moduleConfig.InputDir = dir
+	if moduleConfig.ConfigFile == "" {
+		moduleConfig.ConfigFile = spectest.ProjectConfig(dir)
+	}
+
+	var held bytes.Buffer
+	if !config.Verbose {
+		prev := diag.SetDefault(diag.NewPresenter(&held, diag.ColorEnabled(os.Stderr)))
+		defer diag.SetDefault(prev)
+	}
+	doc, _, err := runGeneration(&moduleConfig)
+	if err != nil {
+		_, _ = held.WriteTo(os.Stderr)
+		return nil, err
+	}
+	got, err := spectest.EncodeGolden(doc, dir)
+	if err != nil {
+		return nil, err
+	}
+	return spectest.CompareGolden(dir, got, opts)
+}
+
+// flagGiven reports whether any of names was given on the command line.
+func flagGiven(fs *flag.FlagSet, names ...string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if slices.Contains(names, f.Name) {
+			set = true
+		}
+	})
+	return set
+}
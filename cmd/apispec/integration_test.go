@@ -21,8 +21,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/ehabterra/apispec/spec"
 )
 
 func TestMainCLI_Help(t *testing.T) {
@@ -529,6 +533,201 @@ func TestParseFlags(t *testing.T) {
 	}
 }
 
+func TestParseFlags_GatewayConfig(t *testing.T) {
+	config, err := parseFlags([]string{"--format", "gateway-config", "--gateway", "envoy",
+		"--gateway-upstream", "http://users:9000", "--gateway-timeout", "15s", "--gateway-retries", "3"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	opts := gatewayOptions(config)
+	if config.Format != formatGatewayConfig || opts.Kind != "envoy" || opts.Upstream != "http://users:9000" ||
+		opts.Timeout != 15*time.Second || opts.Retries != 3 {
+		t.Errorf("unexpected gateway options: format=%q %+v", config.Format, opts)
+	}
+
+	if _, err := parseFlags([]string{"--format", "swagger"}); err == nil {
+		t.Error("expected an error for an unknown --format")
+	}
+}
+
+func TestParseFlags_Sort(t *testing.T) {
+	config, err := parseFlags([]string{"--sort", "alpha"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	if got := engineConfig(config).Sort; got != spec.SortAlpha {
+		t.Errorf("engine sort policy = %q, want %q", got, spec.SortAlpha)
+	}
+
+	if _, err := parseFlags([]string{"--sort", "random"}); err == nil {
+		t.Error("expected an error for an unknown --sort")
+	}
+}
+
+func TestParseFlags_SchemasOnly(t *testing.T) {
+	config, err := parseFlags([]string{"--schemas-only", "--schema-out", "out/schemas", "--schema-base-id", "https://example.com/s/"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	if !config.SchemasOnly || config.SchemaOut != "out/schemas" || config.SchemaBaseID != "https://example.com/s/" {
+		t.Errorf("unexpected schema options: %+v", config)
+	}
+
+	if _, err := parseFlags([]string{"--schemas-only", "--format", "gateway-config"}); err == nil {
+		t.Error("expected an error combining --schemas-only with gateway-config")
+	}
+}
+
+func TestParseFlags_EmitComponentsLib(t *testing.T) {
+	config, err := parseFlags([]string{"--emit-components-lib", "shared-components.yaml"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	if config.ComponentsLib != "shared-components.yaml" {
+		t.Errorf("ComponentsLib = %q", config.ComponentsLib)
+	}
+	for _, args := range [][]string{
+		{"--emit-components-lib", "lib.yaml", "--schemas-only"},
+		{"--emit-components-lib", "lib.yaml", "--format", "gateway-config"},
+	} {
+		if _, err := parseFlags(args); err == nil {
+			t.Errorf("parseFlags(%v): expected an error", args)
+		}
+	}
+}
+
+func TestParseFlags_YAMLAnchors(t *testing.T) {
+	config, err := parseFlags([]string{"--yaml-anchors"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	if !yamlOptions(config).Anchors {
+		t.Error("--yaml-anchors should enable anchors")
+	}
+	if config, _ = parseFlags(nil); yamlOptions(config).Anchors {
+		t.Error("anchors should be off by default")
+	}
+}
+
+func TestParseFlags_Lint(t *testing.T) {
+	config, err := parseFlags([]string{"--lint", "lint.sarif"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	if config.Lint != "lint.sarif" || config.LintFormat != "sarif" {
+		t.Errorf("--lint = %q, --lint-format = %q; want lint.sarif, sarif", config.Lint, config.LintFormat)
+	}
+	if config, _ = parseFlags([]string{"--lint", "lint.json", "--lint-format", "json"}); config.LintFormat != "json" {
+		t.Errorf("--lint-format = %q, want json", config.LintFormat)
+	}
+	if _, err := parseFlags([]string{"--lint-format", "xml"}); err == nil {
+		t.Error("expected an error for an unknown --lint-format")
+	}
+}
+
+func TestParseFlags_Nullability(t *testing.T) {
+	config, err := parseFlags([]string{"--nullable-pointers", "unlessOmitempty", "--nullable-style", "typeArray", "--omitempty-optional"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	ec := engineConfig(config)
+	if ec.NullablePointers != "unlessOmitempty" || ec.NullableStyle != "typeArray" || !ec.OmitemptyOptional {
+		t.Errorf("engine nullability = %q, %q, omitempty optional %v", ec.NullablePointers, ec.NullableStyle, ec.OmitemptyOptional)
+	}
+	if _, err := parseFlags([]string{"--nullable-style", "oneOf"}); err == nil {
+		t.Error("expected an error for an unknown --nullable-style")
+	}
+	if _, err := parseFlags([]string{"--nullable-pointers", "never"}); err == nil {
+		t.Error("expected an error for an unknown --nullable-pointers")
+	}
+}
+
+func TestParseFlags_DynamicValues(t *testing.T) {
+	config, err := parseFlags([]string{"--dynamic-values", "component", "--go-type-annotations"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	ec := engineConfig(config)
+	if ec.DynamicValues != "component" || !ec.GoTypeAnnotations {
+		t.Errorf("engine dynamic values = %q, go type annotations %v", ec.DynamicValues, ec.GoTypeAnnotations)
+	}
+	if _, err := parseFlags([]string{"--dynamic-values", "empty"}); err == nil {
+		t.Error("expected an error for an unknown --dynamic-values")
+	}
+}
+
+func TestParseFlags_AnalyzeDependency(t *testing.T) {
+	config, err := parseFlags([]string{"--analyze-dependency", "example.com/httpkit", "--analyze-dependency", "example.com/authkit"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	want := []string{"example.com/httpkit", "example.com/authkit"}
+	if !slices.Equal(config.AnalyzeDependencies, want) {
+		t.Errorf("--analyze-dependency = %v, want %v", config.AnalyzeDependencies, want)
+	}
+	if got := engineConfig(config).AnalyzeDependencies; !slices.Equal(got, want) {
+		t.Errorf("engine AnalyzeDependencies = %v, want %v", got, want)
+	}
+}
+
+func TestParseFlags_BuildTargets(t *testing.T) {
+	config, err := parseFlags([]string{"--build-tags", "enterprise,integration", "--goos", "freebsd", "--goarch", "arm64"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	ec := engineConfig(config)
+	if ec.BuildTags != "enterprise,integration" || ec.GOOS != "freebsd" || ec.GOARCH != "arm64" {
+		t.Errorf("engine build target = tags %q, GOOS %q, GOARCH %q", ec.BuildTags, ec.GOOS, ec.GOARCH)
+	}
+}
+
+func TestParseFlags_StubCGO(t *testing.T) {
+	config, err := parseFlags([]string{"--stub-cgo"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	if !engineConfig(config).StubCGOPackages {
+		t.Error("--stub-cgo should set StubCGOPackages")
+	}
+	if config, _ = parseFlags(nil); config.StubCGOPackages {
+		t.Error("cgo packages that fail to build are skipped by default")
+	}
+}
+
+func TestStrictIssues(t *testing.T) {
+	issues := []spec.SpecIssue{{Location: "GET /users", Message: `response "600" is not an HTTP status code`}}
+	config, err := parseFlags([]string{"--strict"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	if err := strictIssues(config, issues); err == nil {
+		t.Error("--strict should fail on spec issues")
+	}
+	if err := strictIssues(config, nil); err != nil {
+		t.Errorf("--strict with a valid spec: %v", err)
+	}
+	if config, _ = parseFlags(nil); strictIssues(config, issues) != nil {
+		t.Error("spec issues are warnings without --strict")
+	}
+}
+
+func TestParseCheckGatewayFlags(t *testing.T) {
+	config, gatewayFile, err := parseCheckGatewayFlags([]string{"--gateway", "kong.yaml", "-c", "apispec.yaml", "./svc"})
+	if err != nil {
+		t.Fatalf("parseCheckGatewayFlags() error = %v", err)
+	}
+	if gatewayFile != "kong.yaml" || config.ConfigFile != "apispec.yaml" || config.InputDir != "./svc" {
+		t.Errorf("got gateway=%q config=%q dir=%q", gatewayFile, config.ConfigFile, config.InputDir)
+	}
+	if config.MaxNodesPerTree == 0 {
+		t.Error("analysis limits should keep their defaults")
+	}
+
+	if _, _, err := parseCheckGatewayFlags([]string{"./svc"}); err == nil {
+		t.Error("expected an error without --gateway")
+	}
+}
+
 func TestPrintVersion(t *testing.T) {
 	// Capture stdout for version output
 	oldStdout := os.Stdout
@@ -638,6 +837,28 @@ go 1.21
 	}
 }
 
+func TestRunGenerationTimeout(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte("module test\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := parseFlags([]string{"--timeout", "1ns", tempDir})
+	if err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	if config.Timeout != time.Nanosecond {
+		t.Fatalf("Timeout = %v, want 1ns", config.Timeout)
+	}
+	_, _, err = runGeneration(config)
+	if err == nil || !strings.Contains(err.Error(), "timed out after 1ns") {
+		t.Errorf("Expected a timeout error, got: %v", err)
+	}
+}
+
 // TestWriteOutputYAML tests the new streaming YAML output functionality
 func TestWriteOutputYAML(t *testing.T) {
 	// Create a temporary directory for testing
@@ -15,9 +15,13 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -25,10 +29,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ehabterra/apispec/internal/diag"
 	"github.com/ehabterra/apispec/internal/engine"
+	"github.com/ehabterra/apispec/internal/gateway"
+	"github.com/ehabterra/apispec/internal/jsonschema"
+	"github.com/ehabterra/apispec/internal/lint"
 	"github.com/ehabterra/apispec/internal/profiler"
+	intspec "github.com/ehabterra/apispec/internal/spec"
+	"github.com/ehabterra/apispec/internal/specdiff"
+	"github.com/ehabterra/apispec/internal/yamlout"
 	"github.com/ehabterra/apispec/spec"
-	"gopkg.in/yaml.v3"
 )
 
 // stringSliceFlag implements flag.Value for string slices
@@ -127,6 +137,12 @@ func printVersion() {
 	fmt.Println(engine.LicenseNotice)
 }
 
+// Output formats accepted by --format.
+const (
+	formatOpenAPI       = "openapi"
+	formatGatewayConfig = "gateway-config"
+)
+
 // CLIConfig holds the configuration parsed from command line arguments
 type CLIConfig struct {
 	Verbose                      bool
@@ -146,6 +162,8 @@ type CLIConfig struct {
 	OutputConfig                 string
 	WriteMetadata                bool
 	SplitMetadata                bool
+	StreamMetadata               bool
+	MetadataFile                 string
 	DiagramPath                  string
 	PaginatedDiagram             bool
 	DiagramPageSize              int
@@ -155,21 +173,56 @@ type CLIConfig struct {
 	MaxNestedArgsDepth           int
 	MaxRecursionDepth            int
 	LegacyTracker                bool
+	Timeout                      time.Duration
 	ShowVersion                  bool
 	OutputFlagSet                bool
 	IncludeFiles                 []string
 	IncludePackages              []string
 	IncludeFunctions             []string
 	IncludeTypes                 []string
+	IncludeRoutes                []string
 	ExcludeFiles                 []string
 	ExcludePackages              []string
 	ExcludeFunctions             []string
 	ExcludeTypes                 []string
+	ExcludeRoutes                []string
 	SkipCGOPackages              bool
+	AnalyzeDependencies          []string
+	BuildTags                    string
+	GOOS                         string
+	GOARCH                       string
+	StubCGOPackages              bool
 	AnalyzeFrameworkDependencies bool
 	AutoIncludeFrameworkPackages bool
 	AutoExcludeTests             bool
 	AutoExcludeMocks             bool
+	// Output format options
+	Format            string
+	Gateway           string
+	GatewayUpstream   string
+	GatewayTimeout    time.Duration
+	GatewayRetries    int
+	SchemasOnly       bool
+	SchemaOut         string
+	SchemaBaseID      string
+	Examples          bool
+	LiteralExamples   bool
+	NullablePointers  string
+	NullableStyle     string
+	OmitemptyOptional bool
+	DynamicValues     string
+	GoTypeAnnotations bool
+	DebugEndpoints    bool
+	SourceAnnotations bool
+	Overrides         string
+	YAMLAnchors       bool
+	Sort              string
+	Strict            bool
+	Lint              string
+	LintFormat        string
+	Diagnostics       string
+	Coverage          bool
+	ComponentsLib     string
 	// Profiling options
 	CPUProfile         bool
 	MemProfile         bool
@@ -188,34 +241,193 @@ type CLIConfig struct {
 
 // parseFlags parses command line arguments and returns a CLIConfig
 func parseFlags(args []string) (*CLIConfig, error) {
+	config := &CLIConfig{}
+	fs := generatorFlags(config)
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if err := applyDefaults(fs); err != nil {
+		return nil, err
+	}
+
+	// Handle positional arguments (override --dir flag)
+	if len(fs.Args()) > 0 {
+		config.InputDir = fs.Args()[0]
+	}
+
+	// Check if output flag was explicitly set
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "output" || f.Name == "o" {
+			config.OutputFlagSet = true
+		}
+	})
+
+	switch config.Format {
+	case formatOpenAPI, formatGatewayConfig:
+	default:
+		return nil, fmt.Errorf("unknown --format %q (want %s or %s)", config.Format, formatOpenAPI, formatGatewayConfig)
+	}
+	switch config.LintFormat {
+	case lint.FormatSARIF, lint.FormatJSON:
+	default:
+		return nil, fmt.Errorf("unknown --lint-format %q (want %s or %s)", config.LintFormat, lint.FormatSARIF, lint.FormatJSON)
+	}
+	nullability := spec.SchemaOptions{NullablePointers: config.NullablePointers, NullableStyle: config.NullableStyle}
+	if err := nullability.ValidateNullability(); err != nil {
+		return nil, err
+	}
+	if err := (spec.SchemaOptions{DynamicValues: config.DynamicValues}).ValidateDynamicValues(); err != nil {
+		return nil, err
+	}
+	if _, err := spec.ParseSortPolicy(config.Sort); err != nil {
+		return nil, fmt.Errorf("--sort: %w", err)
+	}
+	if config.SchemasOnly && config.Format != formatOpenAPI {
+		return nil, fmt.Errorf("--schemas-only cannot be combined with --format %s", config.Format)
+	}
+	if config.ComponentsLib != "" && (config.SchemasOnly || config.Format != formatOpenAPI) {
+		return nil, fmt.Errorf("--emit-components-lib writes an OpenAPI spec; it cannot be combined with --schemas-only or --format %s", formatGatewayConfig)
+	}
+
+	clampDiagramPageSize(config)
+
+	return config, nil
+}
+
+// clampDiagramPageSize keeps --diagram-page-size within 50-500.
+func clampDiagramPageSize(config *CLIConfig) {
+	if config.DiagramPageSize < 50 {
+		config.DiagramPageSize = 50
+	} else if config.DiagramPageSize > 500 {
+		config.DiagramPageSize = 500
+	}
+}
+
+// generatorExamples are the argument lists shown under Examples in the
+// usage text and the man page.
+var generatorExamples = []string{
+	"-o spec.yaml -d ./api",
+	"-o spec.yaml -d ./api --diagram diagram.html",
+	"-o spec.yaml -d ./api --diagram diagram.html --diagram-page-size 50",
+	"-o spec.yaml -d ./api --diagram diagram.html --paginated-diagram",
+}
+
+// generatorFlags defines the generator's flags on a new flag set, bound to
+// config: the global flags plus those shaping and writing the spec. Shell
+// completion and the man page are built from the same set.
+func generatorFlags(config *CLIConfig) *flag.FlagSet {
 	// Create a new flag set to avoid global state
 	fs := flag.NewFlagSet("apispec", flag.ContinueOnError)
 
-	config := &CLIConfig{}
-
 	// Version flag
 	fs.BoolVar(&config.ShowVersion, "version", false, "Show version information")
 	fs.BoolVar(&config.ShowVersion, "V", false, "Shorthand for --version")
 
 	// Custom help
 	fs.Usage = func() {
-		fmt.Fprintf(os.Stderr, "%s\n%s\n\nUsage: %s [flags]\n\nFlags:\n",
-			engine.CopyrightNotice, engine.LicenseNotice, os.Args[0])
+		fmt.Fprintf(os.Stderr, "%s\n%s\n\nUsage: %s [%s] [flags] [dir]\n       %s <command> [flags]\n\nCommands:\n",
+			engine.CopyrightNotice, engine.LicenseNotice, os.Args[0], generateCommand, os.Args[0])
+		for _, sc := range subcommands() {
+			fmt.Fprintf(os.Stderr, "  %-14s %s\n", sc.name, sc.summary)
+		}
+		fmt.Fprintf(os.Stderr, "\nFlags:\n")
 		fs.PrintDefaults()
 		fmt.Printf("\nExamples:\n")
-		fmt.Printf("  %s -o spec.yaml -d ./api\n", os.Args[0])
-		fmt.Printf("  %s -o spec.yaml -d ./api --diagram diagram.html\n", os.Args[0])
-		fmt.Printf("  %s -o spec.yaml -d ./api --diagram diagram.html --diagram-page-size 50\n", os.Args[0])
-		fmt.Printf("  %s -o spec.yaml -d ./api --diagram diagram.html --paginated-diagram\n", os.Args[0])
+		for _, example := range generatorExamples {
+			fmt.Printf("  %s %s\n", os.Args[0], example)
+		}
 		fmt.Printf("\nPerformance Tips:\n")
 		fmt.Printf("  • Use --paginated-diagram for large call graphs (1000+ edges)\n")
 		fmt.Printf("  • Use --diagram-page-size 50 for very large graphs (3000+ edges)\n")
 	}
 
-	// Parse flags
+	globalFlags(fs, config)
+	specFlags(fs, config)
+
+	fs.BoolVar(&config.WriteMetadata, "write-metadata", false, "Write metadata to file")
+	fs.BoolVar(&config.WriteMetadata, "w", false, "Shorthand for --write-metadata")
+	metadataFormatFlags(fs, config)
+
+	fs.StringVar(&config.DiagramPath, "diagram", "", "Generate call graph diagram")
+	fs.StringVar(&config.DiagramPath, "g", "", "Shorthand for --diagram")
+	diagramFlags(fs, config)
+
+	profilingFlags(fs, config)
+
+	return fs
+}
+
+// globalFlags defines the flags every command that analyzes source accepts:
+// what to load and how far to follow it.
+func globalFlags(fs *flag.FlagSet, config *CLIConfig) {
 	fs.StringVar(&config.InputDir, "dir", engine.DefaultInputDir, "Input directory containing Go source files")
 	fs.StringVar(&config.InputDir, "d", engine.DefaultInputDir, "Shorthand for --dir")
 
+	fs.StringVar(&config.ConfigFile, "config", "", "Configuration file path")
+	fs.StringVar(&config.ConfigFile, "c", "", "Shorthand for --config")
+
+	fs.IntVar(&config.MaxNodesPerTree, "max-nodes", engine.DefaultMaxNodesPerTree, "Maximum nodes per tracker tree")
+	fs.IntVar(&config.MaxNodesPerTree, "mn", engine.DefaultMaxNodesPerTree, "Shorthand for --max-nodes")
+
+	fs.IntVar(&config.MaxChildrenPerNode, "max-children", engine.DefaultMaxChildrenPerNode, "Maximum children per node")
+	fs.IntVar(&config.MaxChildrenPerNode, "mc", engine.DefaultMaxChildrenPerNode, "Shorthand for --max-children")
+
+	fs.IntVar(&config.MaxArgsPerFunction, "max-args", engine.DefaultMaxArgsPerFunction, "Maximum arguments per function")
+	fs.IntVar(&config.MaxArgsPerFunction, "ma", engine.DefaultMaxArgsPerFunction, "Shorthand for --max-args")
+
+	fs.IntVar(&config.MaxNestedArgsDepth, "max-nested-args", engine.DefaultMaxNestedArgsDepth, "Maximum nested arguments depth")
+	fs.IntVar(&config.MaxNestedArgsDepth, "md", engine.DefaultMaxNestedArgsDepth, "Shorthand for --max-nested-args")
+
+	fs.IntVar(&config.MaxRecursionDepth, "max-recursion-depth", engine.DefaultMaxRecursionDepth, "Maximum times a function may repeat on one call path, to prevent infinite loops (0 means once)")
+	fs.IntVar(&config.MaxRecursionDepth, "mrd", engine.DefaultMaxRecursionDepth, "Shorthand for --max-recursion-depth")
+
+	fs.BoolVar(&config.LegacyTracker, "legacy-tracker", false, "Use the legacy (eager) tracker tree instead of the default lazy tracker")
+
+	fs.DurationVar(&config.Timeout, "timeout", 0, "Abort the analysis after this long, e.g. 5m (0 means no limit)")
+
+	// Include/exclude flags
+	fs.Var((*stringSliceFlag)(&config.IncludeFiles), "include-file", "Include files matching pattern (can be specified multiple times)")
+	fs.Var((*stringSliceFlag)(&config.IncludePackages), "include-package", "Include packages matching pattern (can be specified multiple times)")
+	fs.Var((*stringSliceFlag)(&config.IncludeFunctions), "include-function", "Include functions matching pattern (can be specified multiple times)")
+	fs.Var((*stringSliceFlag)(&config.IncludeTypes), "include-type", "Include types matching pattern (can be specified multiple times)")
+	fs.Var((*stringSliceFlag)(&config.IncludeRoutes), "include-route", "Only document routes matching \"[METHOD] /path/glob\" (can be specified multiple times)")
+
+	fs.Var((*stringSliceFlag)(&config.ExcludeFiles), "exclude-file", "Exclude files matching pattern (can be specified multiple times)")
+	fs.Var((*stringSliceFlag)(&config.ExcludePackages), "exclude-package", "Exclude packages matching pattern (can be specified multiple times)")
+	fs.Var((*stringSliceFlag)(&config.ExcludeFunctions), "exclude-function", "Exclude functions matching pattern (can be specified multiple times)")
+	fs.Var((*stringSliceFlag)(&config.ExcludeTypes), "exclude-type", "Exclude types matching pattern (can be specified multiple times)")
+	fs.Var((*stringSliceFlag)(&config.ExcludeRoutes), "exclude-route", "Leave out routes matching \"[METHOD] /path/glob\", e.g. \"GET /internal/**\" (can be specified multiple times)")
+
+	fs.Var((*stringSliceFlag)(&config.AnalyzeDependencies), "analyze-dependency", "Analyze the source of this required module (from the module cache or vendor/) with the project's, to document the routes it registers (can be specified multiple times)")
+
+	fs.StringVar(&config.BuildTags, "build-tags", "", "Comma-separated build tags to load packages with, as go build -tags (e.g. enterprise,integration)")
+	fs.StringVar(&config.GOOS, "goos", "", "Target operating system to load packages for (default: the go command's GOOS)")
+	fs.StringVar(&config.GOARCH, "goarch", "", "Target architecture to load packages for (default: the go command's GOARCH)")
+
+	fs.BoolVar(&config.SkipCGOPackages, "skip-cgo", true, "Skip packages with CGO dependencies that may cause build errors")
+	fs.BoolVar(&config.StubCGOPackages, "stub-cgo", false, "Analyze CGO packages that fail to build from their Go syntax, without C types, instead of skipping them")
+
+	fs.BoolVar(&config.AnalyzeFrameworkDependencies, "analyze-framework-dependencies", true, "Analyze framework dependencies")
+	fs.BoolVar(&config.AnalyzeFrameworkDependencies, "afd", true, "Shorthand for --analyze-framework-dependencies")
+
+	fs.BoolVar(&config.AutoIncludeFrameworkPackages, "auto-include-framework-packages", true, "Auto-include framework packages")
+	fs.BoolVar(&config.AutoIncludeFrameworkPackages, "aifp", true, "Shorthand for --auto-include-framework-packages")
+
+	fs.BoolVar(&config.AutoExcludeTests, "auto-exclude-tests", true, "Auto-exclude test files")
+	fs.BoolVar(&config.AutoExcludeTests, "aet", true, "Shorthand for --auto-exclude-tests")
+
+	fs.BoolVar(&config.AutoExcludeMocks, "auto-exclude-mocks", true, "Auto-exclude mock files")
+	fs.BoolVar(&config.AutoExcludeMocks, "aem", true, "Shorthand for --auto-exclude-mocks")
+
+	// Verbose output control
+	fs.BoolVar(&config.Verbose, "verbose", false, "Enable verbose output")
+	fs.BoolVar(&config.Verbose, "vb", false, "Shorthand for --verbose")
+}
+
+// specFlags defines the flags that fill in the spec's info and choose how
+// and where it is written.
+func specFlags(fs *flag.FlagSet, config *CLIConfig) {
 	fs.StringVar(&config.OutputFile, "output", engine.DefaultOutputFile, "Output file path")
 	fs.StringVar(&config.OutputFile, "o", engine.DefaultOutputFile, "Shorthand for --output")
 
@@ -249,58 +461,67 @@ func parseFlags(args []string) (*CLIConfig, error) {
 	fs.StringVar(&config.OpenAPIVersion, "openapi-version", engine.DefaultOpenAPIVersion, "OpenAPI specification version")
 	fs.StringVar(&config.OpenAPIVersion, "O", engine.DefaultOpenAPIVersion, "Shorthand for --openapi-version")
 
-	fs.StringVar(&config.ConfigFile, "config", "", "Configuration file path")
-	fs.StringVar(&config.ConfigFile, "c", "", "Shorthand for --config")
-
 	fs.StringVar(&config.OutputConfig, "output-config", "", "Output effective configuration to file")
 	fs.StringVar(&config.OutputConfig, "oc", "", "Shorthand for --output-config")
 
-	fs.BoolVar(&config.WriteMetadata, "write-metadata", false, "Write metadata to file")
-	fs.BoolVar(&config.WriteMetadata, "w", false, "Shorthand for --write-metadata")
+	// Output format flags
+	fs.StringVar(&config.Format, "format", formatOpenAPI, "Output format: openapi or gateway-config")
+	fs.StringVar(&config.Gateway, "gateway", gateway.KindKong, "Gateway for --format gateway-config: kong or envoy")
+	fs.StringVar(&config.GatewayUpstream, "gateway-upstream", "http://localhost:8080", "Upstream URL routes forward to in gateway-config output")
+	fs.DurationVar(&config.GatewayTimeout, "gateway-timeout", 0, "Default route timeout in gateway-config output (overridden per operation by x-timeout)")
+	fs.IntVar(&config.GatewayRetries, "gateway-retries", 0, "Default route retries in gateway-config output (overridden per operation by x-retries)")
 
-	fs.BoolVar(&config.SplitMetadata, "split-metadata", false, "Write split metadata files")
-	fs.BoolVar(&config.SplitMetadata, "s", false, "Shorthand for --split-metadata")
+	fs.BoolVar(&config.SchemasOnly, "schemas-only", false, "Write each component schema as a standalone JSON Schema (2020-12) file instead of the spec")
+	fs.StringVar(&config.SchemaOut, "schema-out", "schemas", "Directory for --schemas-only output")
+	fs.StringVar(&config.SchemaBaseID, "schema-base-id", "", "Base URI for component schema $ids, in the spec and in --schemas-only documents (default: no $id in the spec, bare file names in --schemas-only)")
 
-	fs.StringVar(&config.DiagramPath, "diagram", "", "Generate call graph diagram")
-	fs.StringVar(&config.DiagramPath, "g", "", "Shorthand for --diagram")
+	fs.BoolVar(&config.Examples, "examples", false, "Add generated examples, following each schema's format and constraints, to bodies and parameters that have none")
+	fs.BoolVar(&config.LiteralExamples, "literal-examples", false, "Use the values struct literals and _test.go request bodies give as property and request body examples")
 
-	fs.BoolVar(&config.PaginatedDiagram, "paginated-diagram", false, "Use paginated diagram for better performance with large call graphs")
-	fs.BoolVar(&config.PaginatedDiagram, "pd", false, "Shorthand for --paginated-diagram")
+	fs.StringVar(&config.NullablePointers, "nullable-pointers", "", "Make pointer fields nullable: always, or unlessOmitempty (default: only when the config asks)")
+	fs.StringVar(&config.NullableStyle, "nullable-style", "", "How nullable properties are written: anyOf, typeArray (3.1 type: [T, \"null\"]) or nullable (3.0 nullable: true) (default: nullable for --openapi-version 3.0.x, anyOf otherwise)")
+	fs.BoolVar(&config.OmitemptyOptional, "omitempty-optional", false, "Leave omitempty and omitzero fields out of required, even when validation tags require them")
+	fs.StringVar(&config.DynamicValues, "dynamic-values", "", "How any, interface{} and json.RawMessage values are documented: object, string, or component (a $ref to a placeholder component) (default: object)")
+	fs.BoolVar(&config.GoTypeAnnotations, "go-type-annotations", false, "Mark any, interface{} and json.RawMessage schemas with the Go type they document (x-go-type)")
 
-	fs.IntVar(&config.DiagramPageSize, "diagram-page-size", 100, "Number of nodes per page in paginated diagram (50-500)")
-	fs.IntVar(&config.DiagramPageSize, "dps", 100, "Shorthand for --diagram-page-size")
+	fs.BoolVar(&config.DebugEndpoints, "include-debug-endpoints", false, "Document pprof and expvar handlers under the internal tag instead of leaving them out")
 
-	fs.IntVar(&config.MaxNodesPerTree, "max-nodes", engine.DefaultMaxNodesPerTree, "Maximum nodes per tracker tree")
-	fs.IntVar(&config.MaxNodesPerTree, "mn", engine.DefaultMaxNodesPerTree, "Shorthand for --max-nodes")
+	fs.BoolVar(&config.SourceAnnotations, "source-annotations", false, "Mark each operation and schema with the Go declaration it came from (x-go-source, x-go-function)")
 
-	fs.IntVar(&config.MaxChildrenPerNode, "max-children", engine.DefaultMaxChildrenPerNode, "Maximum children per node")
-	fs.IntVar(&config.MaxChildrenPerNode, "mc", engine.DefaultMaxChildrenPerNode, "Shorthand for --max-children")
+	fs.StringVar(&config.Overrides, "overrides", "", "Partial OpenAPI document whose info, summaries, descriptions, examples and security are merged over the generated spec")
 
-	fs.IntVar(&config.MaxArgsPerFunction, "max-args", engine.DefaultMaxArgsPerFunction, "Maximum arguments per function")
-	fs.IntVar(&config.MaxArgsPerFunction, "ma", engine.DefaultMaxArgsPerFunction, "Shorthand for --max-args")
+	fs.StringVar(&config.Sort, "sort", string(spec.SortSource), "Order of the spec's lists (tags, parameters, required properties, enum values): source or alpha")
 
-	fs.IntVar(&config.MaxNestedArgsDepth, "max-nested-args", engine.DefaultMaxNestedArgsDepth, "Maximum nested arguments depth")
-	fs.IntVar(&config.MaxNestedArgsDepth, "md", engine.DefaultMaxNestedArgsDepth, "Shorthand for --max-nested-args")
+	fs.BoolVar(&config.YAMLAnchors, "yaml-anchors", false, "Write repeated blocks (security lists, shared responses) once in YAML output and alias the rest")
 
-	fs.IntVar(&config.MaxRecursionDepth, "max-recursion-depth", engine.DefaultMaxRecursionDepth, "Maximum recursion depth to prevent infinite loops")
-	fs.IntVar(&config.MaxRecursionDepth, "mrd", engine.DefaultMaxRecursionDepth, "Shorthand for --max-recursion-depth")
+	fs.StringVar(&config.ComponentsLib, "emit-components-lib", "", "Move the component schemas into this shared components document (created, or merged into when it exists) and reference them from the spec as external $refs")
 
-	fs.BoolVar(&config.LegacyTracker, "legacy-tracker", false, "Use the legacy (eager) tracker tree instead of the default lazy tracker")
+	fs.BoolVar(&config.Strict, "strict", false, "Fail without writing output when the generated spec has structural issues (dangling $refs, duplicate operationIds, path template mismatches, invalid status codes)")
 
-	// Include/exclude flags
-	fs.Var((*stringSliceFlag)(&config.IncludeFiles), "include-file", "Include files matching pattern (can be specified multiple times)")
-	fs.Var((*stringSliceFlag)(&config.IncludePackages), "include-package", "Include packages matching pattern (can be specified multiple times)")
-	fs.Var((*stringSliceFlag)(&config.IncludeFunctions), "include-function", "Include functions matching pattern (can be specified multiple times)")
-	fs.Var((*stringSliceFlag)(&config.IncludeTypes), "include-type", "Include types matching pattern (can be specified multiple times)")
+	fs.StringVar(&config.Lint, "lint", "", "Score the spec against style rules (descriptions, 4xx responses, tags, property casing) and write the findings, pointing at the Go source, to this report file")
+	fs.StringVar(&config.LintFormat, "lint-format", lint.FormatSARIF, "Format of the --lint report: sarif or json")
+	fs.StringVar(&config.Diagnostics, "diagnostics", "", "Also write the analysis warnings (routes that could not be resolved, spec issues, ...) to this SARIF file")
+	fs.BoolVar(&config.Coverage, "coverage", false, "Print how many router registration call sites, per package, a route was extracted from, list the unmatched ones, and write no output")
+}
 
-	fs.Var((*stringSliceFlag)(&config.ExcludeFiles), "exclude-file", "Exclude files matching pattern (can be specified multiple times)")
-	fs.Var((*stringSliceFlag)(&config.ExcludePackages), "exclude-package", "Exclude packages matching pattern (can be specified multiple times)")
-	fs.Var((*stringSliceFlag)(&config.ExcludeFunctions), "exclude-function", "Exclude functions matching pattern (can be specified multiple times)")
-	fs.Var((*stringSliceFlag)(&config.ExcludeTypes), "exclude-type", "Exclude types matching pattern (can be specified multiple times)")
+// diagramFlags defines the flags shaping the call graph diagram.
+func diagramFlags(fs *flag.FlagSet, config *CLIConfig) {
+	fs.BoolVar(&config.PaginatedDiagram, "paginated-diagram", false, "Use paginated diagram for better performance with large call graphs")
+	fs.BoolVar(&config.PaginatedDiagram, "pd", false, "Shorthand for --paginated-diagram")
 
-	fs.BoolVar(&config.SkipCGOPackages, "skip-cgo", true, "Skip packages with CGO dependencies that may cause build errors")
+	fs.IntVar(&config.DiagramPageSize, "diagram-page-size", 100, "Number of nodes per page in paginated diagram (50-500)")
+	fs.IntVar(&config.DiagramPageSize, "dps", 100, "Shorthand for --diagram-page-size")
+}
+
+// metadataFormatFlags defines the flags choosing how metadata is written.
+func metadataFormatFlags(fs *flag.FlagSet, config *CLIConfig) {
+	fs.BoolVar(&config.SplitMetadata, "split-metadata", false, "Write split metadata files")
+	fs.BoolVar(&config.SplitMetadata, "s", false, "Shorthand for --split-metadata")
+	fs.BoolVar(&config.StreamMetadata, "stream-metadata", false, "Write metadata one package at a time (implied by a .gz metadata file)")
+}
 
-	// Profiling flags
+// profilingFlags defines the profiler flags.
+func profilingFlags(fs *flag.FlagSet, config *CLIConfig) {
 	fs.BoolVar(&config.CPUProfile, "cpu-profile", false, "Enable CPU profiling")
 	fs.BoolVar(&config.MemProfile, "mem-profile", false, "Enable memory profiling")
 	fs.BoolVar(&config.BlockProfile, "block-profile", false, "Enable block profiling")
@@ -315,53 +536,11 @@ func parseFlags(args []string) (*CLIConfig, error) {
 	fs.StringVar(&config.ProfileMutexPath, "mutex-profile-path", "mutex.prof", "Mutex profile output file")
 	fs.StringVar(&config.ProfileTracePath, "trace-profile-path", "trace.out", "Trace profile output file")
 	fs.StringVar(&config.ProfileMetricsPath, "metrics-path", "metrics.json", "Custom metrics output file")
-
-	fs.BoolVar(&config.AnalyzeFrameworkDependencies, "analyze-framework-dependencies", true, "Analyze framework dependencies")
-	fs.BoolVar(&config.AnalyzeFrameworkDependencies, "afd", true, "Shorthand for --analyze-framework-dependencies")
-
-	fs.BoolVar(&config.AutoIncludeFrameworkPackages, "auto-include-framework-packages", true, "Auto-include framework packages")
-	fs.BoolVar(&config.AutoIncludeFrameworkPackages, "aifp", true, "Shorthand for --auto-include-framework-packages")
-
-	fs.BoolVar(&config.AutoExcludeTests, "auto-exclude-tests", true, "Auto-exclude test files")
-	fs.BoolVar(&config.AutoExcludeTests, "aet", true, "Shorthand for --auto-exclude-tests")
-
-	fs.BoolVar(&config.AutoExcludeMocks, "auto-exclude-mocks", true, "Auto-exclude mock files")
-	fs.BoolVar(&config.AutoExcludeMocks, "aem", true, "Shorthand for --auto-exclude-mocks")
-
-	// Verbose output control
-	fs.BoolVar(&config.Verbose, "verbose", false, "Enable verbose output")
-	fs.BoolVar(&config.Verbose, "vb", false, "Shorthand for --verbose")
-
-	if err := fs.Parse(args); err != nil {
-		return nil, err
-	}
-
-	// Handle positional arguments (override --dir flag)
-	if len(fs.Args()) > 0 {
-		config.InputDir = fs.Args()[0]
-	}
-
-	// Check if output flag was explicitly set
-	fs.Visit(func(f *flag.Flag) {
-		if f.Name == "output" || f.Name == "o" {
-			config.OutputFlagSet = true
-		}
-	})
-
-	// Validate diagram page size
-	if config.DiagramPageSize < 50 {
-		config.DiagramPageSize = 50
-	} else if config.DiagramPageSize > 500 {
-		config.DiagramPageSize = 500
-	}
-
-	return config, nil
 }
 
-// runGeneration generates the OpenAPI specification and returns the spec object directly (like metadata)
-func runGeneration(config *CLIConfig) (*spec.OpenAPISpec, *engine.Engine, error) {
-	// Create engine configuration
-	engineConfig := &engine.EngineConfig{
+// engineConfig maps the CLI configuration onto the engine's.
+func engineConfig(config *CLIConfig) *engine.EngineConfig {
+	return &engine.EngineConfig{
 		InputDir:                     config.InputDir,
 		OutputFile:                   config.OutputFile,
 		Title:                        config.Title,
@@ -378,6 +557,8 @@ func runGeneration(config *CLIConfig) (*spec.OpenAPISpec, *engine.Engine, error)
 		OutputConfig:                 config.OutputConfig,
 		WriteMetadata:                config.WriteMetadata,
 		SplitMetadata:                config.SplitMetadata,
+		StreamMetadata:               config.StreamMetadata,
+		MetadataFile:                 config.MetadataFile,
 		DiagramPath:                  config.DiagramPath,
 		PaginatedDiagram:             config.PaginatedDiagram,
 		DiagramPageSize:              config.DiagramPageSize,
@@ -391,23 +572,64 @@ func runGeneration(config *CLIConfig) (*spec.OpenAPISpec, *engine.Engine, error)
 		IncludePackages:              config.IncludePackages,
 		IncludeFunctions:             config.IncludeFunctions,
 		IncludeTypes:                 config.IncludeTypes,
+		IncludeRoutes:                config.IncludeRoutes,
 		ExcludeFiles:                 config.ExcludeFiles,
 		ExcludePackages:              config.ExcludePackages,
 		ExcludeFunctions:             config.ExcludeFunctions,
 		ExcludeTypes:                 config.ExcludeTypes,
+		ExcludeRoutes:                config.ExcludeRoutes,
 		SkipCGOPackages:              config.SkipCGOPackages,
+		AnalyzeDependencies:          config.AnalyzeDependencies,
+		BuildTags:                    config.BuildTags,
+		GOOS:                         config.GOOS,
+		GOARCH:                       config.GOARCH,
+		StubCGOPackages:              config.StubCGOPackages,
 		AnalyzeFrameworkDependencies: config.AnalyzeFrameworkDependencies,
 		AutoIncludeFrameworkPackages: config.AutoIncludeFrameworkPackages,
 		AutoExcludeTests:             config.AutoExcludeTests,
 		AutoExcludeMocks:             config.AutoExcludeMocks,
+		SchemaIDBase:                 config.SchemaBaseID,
+		GenerateExamples:             config.Examples,
+		LiteralExamples:              config.LiteralExamples,
+		NullablePointers:             config.NullablePointers,
+		NullableStyle:                config.NullableStyle,
+		OmitemptyOptional:            config.OmitemptyOptional,
+		DynamicValues:                config.DynamicValues,
+		GoTypeAnnotations:            config.GoTypeAnnotations,
+		IncludeDebugEndpoints:        config.DebugEndpoints,
+		SourceAnnotations:            config.SourceAnnotations,
+		OverridesFile:                config.Overrides,
+		Sort:                         spec.SortPolicy(config.Sort),
 		Verbose:                      config.Verbose,
 	}
+}
+
+// analysisContext bounds an analysis by --timeout, when set.
+func analysisContext(config *CLIConfig) (context.Context, context.CancelFunc) {
+	if config.Timeout > 0 {
+		return context.WithTimeout(context.Background(), config.Timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// timeoutError explains an analysis cut off by --timeout.
+func timeoutError(config *CLIConfig, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("analysis timed out after %s (raise --timeout): %w", config.Timeout, err)
+	}
+	return err
+}
+
+// runGeneration generates the OpenAPI specification and returns the spec object directly (like metadata)
+func runGeneration(config *CLIConfig) (*spec.OpenAPISpec, *engine.Engine, error) {
+	ctx, cancel := analysisContext(config)
+	defer cancel()
 
 	// Create engine and generate OpenAPI spec
-	genEngine := engine.NewEngine(engineConfig)
-	openAPISpec, err := genEngine.GenerateOpenAPI()
+	genEngine := engine.NewEngine(engineConfig(config))
+	openAPISpec, err := genEngine.GenerateOpenAPIContext(ctx)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to generate OpenAPI spec: %w", err)
+		return nil, nil, fmt.Errorf("failed to generate OpenAPI spec: %w", timeoutError(config, err))
 	}
 
 	return openAPISpec, genEngine, nil
@@ -437,10 +659,22 @@ func runGenerationWithProfiling(config *CLIConfig, prof *profiler.Profiler) (*sp
 
 	// Record additional metrics
 	mc.SetGauge("generation.success", 1, "count", map[string]string{"operation": "generation"})
+	recordMatcherMetrics(mc, genEngine)
 
 	return openAPISpec, genEngine, nil
 }
 
+// recordMatcherMetrics records the pattern matcher work of a generation:
+// matchers evaluated, checks the callee index skipped, and lookups answered
+// from the per-edge memos.
+func recordMatcherMetrics(mc *profiler.MetricsCollector, genEngine *engine.Engine) {
+	stats := genEngine.GetMatcherStats()
+	tags := map[string]string{"operation": "extraction"}
+	mc.SetGauge("matchers.evaluated", float64(stats.Evaluations), "count", tags)
+	mc.SetGauge("matchers.skipped", float64(stats.Skipped), "count", tags)
+	mc.SetGauge("matchers.memoized", float64(stats.Memoized), "count", tags)
+}
+
 // generatePerformanceAnalysis generates a performance analysis report
 func generatePerformanceAnalysis(prof *profiler.Profiler, config *CLIConfig) error {
 	mc := prof.GetMetrics()
@@ -470,22 +704,182 @@ func generatePerformanceAnalysis(prof *profiler.Profiler, config *CLIConfig) err
 	return nil
 }
 
+// strictIssues fails a --strict run whose spec has structural issues.
+func strictIssues(config *CLIConfig, issues []spec.SpecIssue) error {
+	if !config.Strict || len(issues) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d spec issues found; output not written (--strict)", len(issues))
+}
+
+// gatewayOptions maps the gateway flags onto gateway.Options.
+func gatewayOptions(config *CLIConfig) gateway.Options {
+	return gateway.Options{
+		Kind:     config.Gateway,
+		Upstream: config.GatewayUpstream,
+		Timeout:  config.GatewayTimeout,
+		Retries:  config.GatewayRetries,
+	}
+}
+
+// yamlOptions maps the YAML output flags onto yamlout.Options.
+func yamlOptions(config *CLIConfig) yamlout.Options {
+	return yamlout.Options{Anchors: config.YAMLAnchors}
+}
+
+// writeSchemas writes the --schemas-only export. A relative --schema-out is
+// resolved against the analyzed module, like --output.
+func writeSchemas(openAPISpec *spec.OpenAPISpec, config *CLIConfig, genEngine *engine.Engine) error {
+	dir := config.SchemaOut
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(genEngine.ModuleRoot(), dir)
+	}
+	paths, err := jsonschema.WriteDir(openAPISpec, dir, jsonschema.Options{BaseID: config.SchemaBaseID})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Successfully generated %d schemas in %s\n", len(paths), dir)
+	return nil
+}
+
+// writeLintReport implements --lint: it scores the spec against the style
+// rules and writes the findings, located at the Go declarations the
+// operations and schemas came from, in --lint-format. A relative report path
+// is resolved against the analyzed module, like --output.
+func writeLintReport(openAPISpec *spec.OpenAPISpec, config *CLIConfig, genEngine *engine.Engine) error {
+	reportPath := config.Lint
+	if !filepath.IsAbs(reportPath) {
+		reportPath = filepath.Join(genEngine.ModuleRoot(), reportPath)
+	}
+	report := lint.Run(openAPISpec, genEngine.GetSources())
+	var buf bytes.Buffer
+	if err := report.Write(&buf, config.LintFormat, Version); err != nil {
+		return err
+	}
+	if err := os.WriteFile(reportPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write lint report: %w", err)
+	}
+	fmt.Printf("Lint score %d/100, %d findings: %s\n", report.Score, len(report.Findings), reportPath)
+	return nil
+}
+
+// writeDiagnostics implements --diagnostics: it writes the warnings of the
+// generation — those `apispec lint` reports, then the spec's structural
+// issues — as a SARIF log, naming files relative to the analyzed module. A
+// relative path is resolved against the module, like --output.
+func writeDiagnostics(config *CLIConfig, genEngine *engine.Engine) error {
+	diagPath := config.Diagnostics
+	if !filepath.IsAbs(diagPath) {
+		diagPath = filepath.Join(genEngine.ModuleRoot(), diagPath)
+	}
+	diags := lintWarnings(genEngine)
+	for _, issue := range genEngine.GetSpecIssues() {
+		diags = append(diags, issue.Diagnostic())
+	}
+	var buf bytes.Buffer
+	if err := diag.WriteSARIF(&buf, diags, Version, genEngine.ModuleRoot()); err != nil {
+		return err
+	}
+	if err := os.WriteFile(diagPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write diagnostics: %w", err)
+	}
+	fmt.Printf("Wrote %d diagnostics: %s\n", len(diags), diagPath)
+	return nil
+}
+
+// printRouteCoverage implements --coverage: it prints the share of router
+// registration call sites a route was extracted from, overall and per
+// package, then each unmatched call site, named relative to root.
+func printRouteCoverage(w io.Writer, coverage intspec.RouteCoverage, root string) {
+	total := coverage.Total()
+	fmt.Fprintf(w, "Route coverage: %d/%d registration call sites extracted (%.1f%%)\n", total.Extracted, total.Sites, total.Percent())
+	pkgs := coverage.Packages()
+	width := 0
+	for _, pkg := range pkgs {
+		width = max(width, len(pkg.Package))
+	}
+	for _, pkg := range pkgs {
+		fmt.Fprintf(w, "  %-*s  %5s  %5.1f%%\n", width, pkg.Package, fmt.Sprintf("%d/%d", pkg.Extracted, pkg.Sites), pkg.Percent())
+	}
+	unmatched := coverage.Unmatched()
+	if len(unmatched) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "Unmatched call sites (%d):\n", len(unmatched))
+	for _, site := range unmatched {
+		pos := site.Pos
+		if rel, err := filepath.Rel(root, pos); err == nil && !strings.HasPrefix(rel, "..") {
+			pos = rel
+		}
+		if site.Handler == "" {
+			fmt.Fprintf(w, "  %s\n", pos)
+			continue
+		}
+		fmt.Fprintf(w, "  %s  %s\n", pos, strings.ReplaceAll(site.Handler, intspec.TypeSep, "."))
+	}
+}
+
+// writeComponentsLib implements --emit-components-lib: it merges the spec's
+// component schemas into the library file, which it creates when missing,
+// and rewrites the spec's $refs to point into the library relative to the
+// --output file. A relative library path is resolved against the analyzed
+// module, like --output.
+func writeComponentsLib(openAPISpec *spec.OpenAPISpec, config *CLIConfig, genEngine *engine.Engine) error {
+	root := genEngine.ModuleRoot()
+	libPath := config.ComponentsLib
+	if !filepath.IsAbs(libPath) {
+		libPath = filepath.Join(root, libPath)
+	}
+	var lib *spec.OpenAPISpec
+	if _, err := os.Stat(libPath); err == nil {
+		if lib, err = specdiff.Load(libPath); err != nil {
+			return fmt.Errorf("failed to read components library: %w", err)
+		}
+	}
+
+	// The spec refers to the library by its path from the spec's directory;
+	// a spec written to stdout is taken to live in the module root.
+	specDir := root
+	if config.OutputFlagSet || config.OutputFile != engine.DefaultOutputFile {
+		outputPath := config.OutputFile
+		if !filepath.IsAbs(outputPath) {
+			outputPath = filepath.Join(root, outputPath)
+		}
+		specDir = filepath.Dir(outputPath)
+	}
+	libRef, err := filepath.Rel(specDir, libPath)
+	if err != nil {
+		libRef = libPath
+	}
+	lib = spec.ShareComponents(openAPISpec, lib, filepath.ToSlash(libRef))
+
+	var buf bytes.Buffer
+	ext := strings.ToLower(filepath.Ext(libPath))
+	if ext == ".yaml" || ext == ".yml" {
+		if err := yamlout.Encode(&buf, lib, yamlOptions(config)); err != nil {
+			return fmt.Errorf("failed to encode components library to YAML: %w", err)
+		}
+	} else {
+		data, err := json.MarshalIndent(lib, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal components library to JSON: %w", err)
+		}
+		buf.Write(data)
+	}
+	if err := os.WriteFile(libPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write components library: %w", err)
+	}
+	fmt.Println("Successfully generated:", libPath)
+	return nil
+}
+
 // writeOutput writes OpenAPI spec directly to file using streaming encoder (like metadata)
 func writeOutput(openAPISpec interface{}, config *CLIConfig, genEngine *engine.Engine) error {
 	// If output is the default (openapi.json) and no explicit output flag was set, output to stdout
 	if config.OutputFile == engine.DefaultOutputFile && !config.OutputFlagSet {
 		ext := strings.ToLower(filepath.Ext("openapi.json"))
 		if ext == ".yaml" || ext == ".yml" {
-			encoder := yaml.NewEncoder(os.Stdout)
-			encoder.SetIndent(2)
-			if err := encoder.Encode(openAPISpec); err != nil {
-				err = encoder.Close()
-				if err != nil {
-					return fmt.Errorf("failed to close YAML encoder: %w", err)
-				}
-				return fmt.Errorf("failed to encode OpenAPI spec to YAML: %w", err)
-			}
-			return encoder.Close()
+			return yamlout.Encode(os.Stdout, openAPISpec, yamlOptions(config))
 		} else {
 			data, err := json.MarshalIndent(openAPISpec, "", "  ")
 			if err != nil {
@@ -517,21 +911,9 @@ func writeOutput(openAPISpec interface{}, config *CLIConfig, genEngine *engine.E
 
 		ext := strings.ToLower(filepath.Ext(config.OutputFile))
 		if ext == ".yaml" || ext == ".yml" {
-			// Use direct file writing like metadata (no memory buffering)
-			encoder := yaml.NewEncoder(file)
-			encoder.SetIndent(2)
-
-			if err := encoder.Encode(openAPISpec); err != nil {
-				err = encoder.Close()
-				if err != nil {
-					return fmt.Errorf("failed to close YAML encoder: %w", err)
-				}
+			if err := yamlout.Encode(file, openAPISpec, yamlOptions(config)); err != nil {
 				return fmt.Errorf("failed to encode OpenAPI spec to YAML: %w", err)
 			}
-
-			if err := encoder.Close(); err != nil {
-				return fmt.Errorf("failed to close YAML encoder: %w", err)
-			}
 		} else {
 			data, err := json.MarshalIndent(openAPISpec, "", "  ")
 			if err != nil {
@@ -548,23 +930,42 @@ func writeOutput(openAPISpec interface{}, config *CLIConfig, genEngine *engine.E
 }
 
 func main() {
-	start := time.Now()
-	// Print copyright and license info at the very start
+	if len(os.Args) > 1 {
+		if sc, ok := lookupSubcommand(os.Args[1]); ok {
+			// Completion scripts and the man page are meant to be piped or
+			// sourced, so they go out without the banner.
+			if !sc.bannerless {
+				fmt.Println(engine.CopyrightNotice)
+			}
+			os.Exit(sc.run(os.Args[2:]))
+		}
+	}
+
+	// Without a command the arguments are the generator's, as they were
+	// before apispec had subcommands.
 	fmt.Println(engine.CopyrightNotice)
+	os.Exit(runGenerate(os.Args[1:]))
+}
+
+// runGenerate implements `apispec generate`, the default command, and returns
+// the exit code.
+func runGenerate(args []string) int {
+	start := time.Now()
 
 	// Parse command line arguments
-	config, err := parseFlags(os.Args[1:])
+	config, err := parseFlags(args)
 	if err != nil {
 		if err == flag.ErrHelp {
-			return
+			return 0
 		}
-		log.Fatalf("Failed to parse flags: %v", err)
+		reportError(fmt.Errorf("failed to parse flags: %w", err))
+		return 2
 	}
 
 	// Handle version flag early
 	if config.ShowVersion {
 		printVersion()
-		os.Exit(0)
+		return 0
 	}
 
 	// Initialize profiling if enabled
@@ -589,7 +990,8 @@ func main() {
 
 		prof = profiler.NewProfiler(profConfig)
 		if err := prof.Start(); err != nil {
-			log.Fatalf("Failed to start profiling: %v", err)
+			log.Printf("Failed to start profiling: %v", err)
+			return 1
 		}
 		defer func() {
 			if err := prof.Stop(); err != nil {
@@ -601,12 +1003,75 @@ func main() {
 	// Generate OpenAPI specification with profiling
 	openAPISpec, genEngine, err := runGenerationWithProfiling(config, prof)
 	if err != nil {
-		log.Fatalf("%v", err)
+		reportError(err)
+		return 1
+	}
+
+	// Hand the warnings generation printed to CI as well, before --strict
+	// can stop the run on them
+	if config.Diagnostics != "" {
+		if err := writeDiagnostics(config, genEngine); err != nil {
+			reportError(err)
+			return 1
+		}
+	}
+
+	// A dry run: report the route coverage instead of writing the spec
+	if config.Coverage {
+		printRouteCoverage(os.Stdout, genEngine.GetRouteCoverage(), genEngine.ModuleRoot())
+		fmt.Printf("Time elapsed: %s\n", time.Since(start))
+		return 0
+	}
+
+	// Generation reported the spec's structural issues as warnings; under
+	// --strict they stop the output being written.
+	if err := strictIssues(config, genEngine.GetSpecIssues()); err != nil {
+		reportError(err)
+		return 1
+	}
+
+	// Score the spec against the style rules, before --emit-components-lib
+	// moves its schemas out
+	if config.Lint != "" {
+		if err := writeLintReport(openAPISpec, config, genEngine); err != nil {
+			reportError(err)
+			return 1
+		}
+	}
+
+	// Export standalone JSON Schemas instead of the spec when requested
+	if config.SchemasOnly {
+		if err := writeSchemas(openAPISpec, config, genEngine); err != nil {
+			reportError(err)
+			return 1
+		}
+		fmt.Printf("Time elapsed: %s\n", time.Since(start))
+		return 0
+	}
+
+	// Publish the component schemas to the shared library, leaving the spec
+	// with external $refs to them
+	if config.ComponentsLib != "" {
+		if err := writeComponentsLib(openAPISpec, config, genEngine); err != nil {
+			reportError(err)
+			return 1
+		}
+	}
+
+	// Project the spec onto gateway route config when requested
+	var output interface{} = openAPISpec
+	if config.Format == formatGatewayConfig {
+		output, err = gateway.Generate(openAPISpec, gatewayOptions(config))
+		if err != nil {
+			reportError(err)
+			return 1
+		}
 	}
 
 	// Write output directly (like metadata) to avoid memory buffering
-	if err := writeOutput(openAPISpec, config, genEngine); err != nil {
-		log.Fatalf("%v", err)
+	if err := writeOutput(output, config, genEngine); err != nil {
+		reportError(err)
+		return 1
 	}
 
 	// Generate performance analysis if custom metrics are enabled
@@ -617,4 +1082,76 @@ func main() {
 	}
 
 	fmt.Printf("Time elapsed: %s\n", time.Since(start))
+	return 0
+}
+
+const checkGatewayCommand = "check-gateway"
+
+// parseCheckGatewayFlags parses `apispec check-gateway` arguments. It takes
+// its own flag set because --gateway names the routing-table file here, not
+// a gateway kind; the analysis itself runs with the regular defaults.
+func parseCheckGatewayFlags(args []string) (*CLIConfig, string, error) {
+	config, err := parseFlags(nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var gatewayFile string
+	fs := checkGatewayFlags(config, &gatewayFile)
+	if err := parseCommandFlags(fs, config, args); err != nil {
+		return nil, "", err
+	}
+	if gatewayFile == "" {
+		return nil, "", fmt.Errorf("%s: --gateway is required", checkGatewayCommand)
+	}
+	return config, gatewayFile, nil
+}
+
+// checkGatewayFlags defines the check-gateway flags on a new flag set.
+func checkGatewayFlags(config *CLIConfig, gatewayFile *string) *flag.FlagSet {
+	fs := commandFlagSet(checkGatewayCommand, "--gateway gateway.yaml [flags] [dir]", checkGatewaySummary)
+	fs.StringVar(gatewayFile, "gateway", "", "Gateway routing table to check against (Kong declarative config or Envoy route config)")
+	globalFlags(fs, config)
+	return fs
+}
+
+// checkGatewaySummary describes check-gateway in its usage and the man page.
+const checkGatewaySummary = "Reports service endpoints the gateway leaves unreachable or routes through a\n" +
+	"broader, shadowing route. Exits 1 when any are found."
+
+// runCheckGateway implements `apispec check-gateway` and returns the exit
+// code: 0 when every endpoint is routed as intended, 1 on findings, 2 on
+// errors.
+func runCheckGateway(args []string) int {
+	config, gatewayFile, err := parseCheckGatewayFlags(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		reportError(err)
+		return 2
+	}
+
+	table, err := gateway.LoadRoutingTable(gatewayFile)
+	if err != nil {
+		reportError(err)
+		return 2
+	}
+	openAPISpec, _, err := runGeneration(config)
+	if err != nil {
+		reportError(err)
+		return 2
+	}
+
+	report := gateway.Check(openAPISpec, table)
+	for _, f := range report.Findings {
+		fmt.Println(f)
+	}
+	if len(report.Findings) > 0 {
+		fmt.Printf("%d of %d service endpoints not routed as intended by %s\n",
+			len(report.Findings), report.Endpoints, gatewayFile)
+		return 1
+	}
+	fmt.Printf("All %d service endpoints routed by %s\n", report.Endpoints, gatewayFile)
+	return 0
 }
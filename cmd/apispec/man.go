@@ -0,0 +1,174 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+)
+
+// runMan implements `apispec man`: it writes the apispec(1) man page in roff.
+func runMan(args []string, w io.Writer) int {
+	if len(args) != 0 {
+		log.Printf("usage: apispec %s", manCommand)
+		return 2
+	}
+	detectVersionInfo()
+	if _, err := io.WriteString(w, manPage()); err != nil {
+		log.Printf("%s: %v", manCommand, err)
+		return 1
+	}
+	return 0
+}
+
+func manPage() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, ".TH APISPEC 1 \"\" \"apispec %s\" \"User Commands\"\n", roffEscape(Version))
+	b.WriteString(`.SH NAME
+apispec \- generate OpenAPI 3.1 specifications from Go source code
+.SH SYNOPSIS
+.B apispec
+[\fBgenerate\fR] [\fIoptions\fR] [\fIdir\fR]
+.br
+.B apispec
+\fIcommand\fR [\fIoptions\fR] [\fIoperands\fR]
+.SH DESCRIPTION
+.B apispec
+statically analyzes the Go module in \fIdir\fR (default: the current
+directory), detects the web framework it uses and writes an OpenAPI 3.1
+specification of its routes, request and response schemas and parameters.
+Without a command, the arguments are those of \fBgenerate\fR.
+`)
+	b.WriteString(".SH OPTIONS\nThese global options are accepted by every command that analyzes source code.\n")
+	global := describeFlags(globalFlagSet())
+	writeManFlags(&b, global)
+
+	b.WriteString(".SH COMMANDS\n")
+	for _, sc := range subcommands() {
+		fmt.Fprintf(&b, ".SS %s\n%s.\n", roffEscape(sc.name), roffEscape(sc.summary))
+		if summary := commandSummaries[sc.name]; summary != "" {
+			fmt.Fprintf(&b, ".PP\n%s\n", roffEscape(strings.ReplaceAll(summary, "\n", " ")))
+		}
+		if sc.name == completionCommand {
+			fmt.Fprintf(&b, ".PP\nUsage: \\fBapispec %s\\fR \\fI%s\\fR\n", completionCommand, strings.Join(completionShells, "|"))
+		}
+		if sc.flags != nil {
+			writeManFlags(&b, withoutFlags(describeFlags(sc.flags()), global))
+		}
+	}
+
+	b.WriteString(".SH EXAMPLES\n")
+	for _, ex := range generatorExamples {
+		fmt.Fprintf(&b, ".PP\n.nf\napispec %s\n.fi\n", roffEscape(ex))
+	}
+	b.WriteString(`.PP
+.nf
+apispec diff openapi.yaml
+apispec diff \-\-breaking old.yaml new.yaml
+apispec validate ./api
+apispec lint ./api
+.fi
+.PP
+.nf
+source <(apispec completion bash)
+apispec man > apispec.1
+.fi
+.SH ENVIRONMENT
+.TP
+.B APISPEC_\fINAME\fR
+Default for the flag \fB\-\-\fR\fIname\fR, with dashes written as
+underscores: \fBAPISPEC_MAX_NODES\fR for \fB\-\-max\-nodes\fR. Repeatable
+flags take a comma-separated list. Explicit flags win; \fB\-\-version\fR
+is never read from the environment.
+.TP
+.B NO_COLOR
+When set, warnings and errors are not colored even on a terminal.
+.SH FILES
+.TP
+.I ~/.config/apispec/config.yaml
+Flag defaults, keyed by long flag name, below the environment variables.
+Repeatable flags take a list. Read from \fB$XDG_CONFIG_HOME/apispec\fR
+when that is set.
+.SH SEE ALSO
+https://github.com/ehabterra/apispec
+`)
+	return b.String()
+}
+
+// commandSummaries are the longer descriptions the commands print in their
+// usage.
+var commandSummaries = map[string]string{
+	diffCommand:         diffSummary,
+	validateCommand:     validateSummary,
+	lintCommand:         lintSummary,
+	serveCommand:        serveSummary,
+	checkGatewayCommand: checkGatewaySummary,
+}
+
+// globalFlagSet holds just the global flags.
+func globalFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("apispec", flag.ContinueOnError)
+	globalFlags(fs, &CLIConfig{})
+	return fs
+}
+
+// withoutFlags drops the flags named in except, which the man page lists
+// once under OPTIONS rather than under every command.
+func withoutFlags(flags, except []cliFlag) []cliFlag {
+	skip := map[string]bool{}
+	for _, f := range except {
+		skip[f.name] = true
+	}
+	var out []cliFlag
+	for _, f := range flags {
+		if !skip[f.name] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// writeManFlags writes one .TP entry per flag.
+func writeManFlags(b *strings.Builder, flags []cliFlag) {
+	for _, f := range flags {
+		b.WriteString(".TP\n")
+		head := `\fB\-\-` + roffEscape(f.name) + `\fR`
+		if f.short != "" {
+			head = `\fB\-` + roffEscape(f.short) + `\fR, ` + head
+		}
+		if f.argName != "" {
+			head += ` \fI` + roffEscape(f.argName) + `\fR`
+		}
+		b.WriteString(head + "\n")
+		usage := roffEscape(f.usage)
+		if f.argName != "" && f.defValue != "" && f.defValue != "0" {
+			usage += fmt.Sprintf(" (default: %s)", roffEscape(f.defValue))
+		}
+		b.WriteString(usage + "\n")
+	}
+}
+
+// roffEscape escapes s for a roff text line: backslashes, dashes and a
+// leading control character.
+func roffEscape(s string) string {
+	s = strings.NewReplacer(`\`, `\e`, `-`, `\-`).Replace(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
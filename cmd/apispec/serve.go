@@ -0,0 +1,242 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ehabterra/apispec/internal/diagserver"
+)
+
+const serveCommand = "serve"
+
+const serveSummary = "Generates the spec in memory and serves it with Swagger UI, or Redoc at\n" +
+	"/?ui=redoc, and as JSON at /openapi.json. The module's .go files and the\n" +
+	"--config file are polled for changes: the spec is regenerated and open pages\n" +
+	"reload."
+
+// serveTimeouts bound the preview server's requests; each only reads the
+// spec held in memory.
+var serveTimeouts = diagserver.Timeouts{
+	Read:  time.Minute,
+	Write: time.Minute,
+	Idle:  2 * time.Minute,
+}
+
+// serveOptions are the flags only `apispec serve` takes.
+type serveOptions struct {
+	host     string
+	port     int
+	interval time.Duration
+}
+
+//go:embed serve.html
+var servePage []byte
+
+func serveFlags(config *CLIConfig, opts *serveOptions) *flag.FlagSet {
+	fs := commandFlagSet(serveCommand, "[flags] [dir]", serveSummary)
+	fs.StringVar(&opts.host, "host", "localhost", "Address to listen on")
+	fs.IntVar(&opts.port, "port", 9000, "Port to listen on")
+	fs.DurationVar(&opts.interval, "poll-interval", time.Second, "How often to check the sources for changes")
+	globalFlags(fs, config)
+	return fs
+}
+
+// runServe implements `apispec serve`. It serves until SIGINT or SIGTERM,
+// then shuts down gracefully and returns 0; 1 when the server fails, 2 on
+// flag errors.
+func runServe(args []string) int {
+	config := newCommandConfig()
+	var opts serveOptions
+	if err := parseCommandFlags(serveFlags(config, &opts), config, args); err != nil {
+		return commandResult(err)
+	}
+	if opts.interval <= 0 {
+		return commandResult(fmt.Errorf("%s: --poll-interval must be positive", serveCommand))
+	}
+
+	// SIGINT and SIGTERM stop the watcher and shut the server down
+	// gracefully.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	preview := &previewServer{}
+	root := preview.regenerate(config)
+	if root == "" {
+		root = config.InputDir
+	}
+	go watchSources(ctx, root, config.ConfigFile, opts.interval, func() {
+		log.Printf("Sources changed, regenerating")
+		preview.regenerate(config)
+	})
+
+	addr := fmt.Sprintf("%s:%d", opts.host, opts.port)
+	log.Printf("Serving the spec of %s on http://%s", root, addr)
+	if err := diagserver.ListenAndServe(ctx, addr, preview.handler(), serveTimeouts); err != nil {
+		reportError(fmt.Errorf("server failed: %w", err))
+		return 1
+	}
+	log.Printf("Server stopped")
+	return 0
+}
+
+// previewServer holds the last generated spec. A failed regeneration keeps
+// the previous spec and is shown on the page until the next one succeeds.
+type previewServer struct {
+	mu sync.RWMutex
+	// spec is the JSON of the last spec generated, nil before the first.
+	spec []byte
+	// version counts regenerations, so pages know when to reload.
+	version int
+	// err is the last regeneration's failure, "" when it succeeded.
+	err string
+}
+
+// regenerate runs the generator and publishes the result. It returns the
+// analyzed module's root, "" when generation failed.
+func (p *previewServer) regenerate(config *CLIConfig) string {
+	openAPISpec, genEngine, err := runGeneration(config)
+	var data []byte
+	if err == nil {
+		data, err = json.MarshalIndent(openAPISpec, "", "  ")
+	}
+	if err != nil {
+		reportError(err)
+		p.publish(nil, err.Error())
+		return ""
+	}
+	p.publish(data, "")
+	return genEngine.ModuleRoot()
+}
+
+// publish records a regeneration; nil data keeps the current spec.
+func (p *previewServer) publish(data []byte, errMsg string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if data != nil {
+		p.spec = data
+	}
+	p.err = errMsg
+	p.version++
+}
+
+func (p *previewServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(servePage)
+	})
+	mux.HandleFunc("GET /openapi.json", p.handleSpec)
+	mux.HandleFunc("GET /status", p.handleStatus)
+	return mux
+}
+
+func (p *previewServer) handleSpec(w http.ResponseWriter, r *http.Request) {
+	p.mu.RLock()
+	data := p.spec
+	p.mu.RUnlock()
+	if data == nil {
+		http.Error(w, "the spec has not been generated yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(data)
+}
+
+// handleStatus reports the spec version and the last error; the page polls
+// it to reload after a regeneration.
+func (p *previewServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	p.mu.RLock()
+	status := struct {
+		Version int    `json:"version"`
+		Error   string `json:"error,omitempty"`
+	}{p.version, p.err}
+	p.mu.RUnlock()
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// watchSources calls changed whenever sourceFingerprint of root (and the
+// config file) differs from its previous poll, until ctx is done.
+func watchSources(ctx context.Context, root, configFile string, interval time.Duration, changed func()) {
+	last := sourceFingerprint(root, configFile)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if next := sourceFingerprint(root, configFile); next != last {
+			last = next
+			changed()
+		}
+	}
+}
+
+// sourceFingerprint hashes the path, size and modification time of every
+// .go file, go.mod and go.sum under root, skipping the directories the go
+// tool ignores, plus configFile when set.
+func sourceFingerprint(root, configFile string) uint64 {
+	h := fnv.New64a()
+	add := func(path string, info fs.FileInfo) {
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", path, info.Size(), info.ModTime().UnixNano())
+	}
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") && name != "go.mod" && name != "go.sum" {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			add(path, info)
+		}
+		return nil
+	})
+	if configFile != "" {
+		if info, err := os.Stat(configFile); err == nil {
+			add(configFile, info)
+		} else {
+			fmt.Fprintf(h, "%s\x00missing\n", configFile)
+		}
+	}
+	return h.Sum64()
+}
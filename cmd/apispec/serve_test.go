@@ -0,0 +1,147 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const pingModule = `package main
+
+import "net/http"
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func main() {
+	http.HandleFunc("/ping", handler)
+}
+`
+
+func writeModule(t *testing.T, dir, src string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module test\n\ngo 1.22\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func get(t *testing.T, srv *httptest.Server, path string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(srv.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestPreviewServer(t *testing.T) {
+	dir := t.TempDir()
+	writeModule(t, dir, pingModule)
+	config := newCommandConfig()
+	if err := parseCommandFlags(serveFlags(config, &serveOptions{}), config, []string{dir}); err != nil {
+		t.Fatal(err)
+	}
+
+	preview := &previewServer{}
+	srv := httptest.NewServer(preview.handler())
+	defer srv.Close()
+
+	if code, _ := get(t, srv, "/openapi.json"); code != http.StatusServiceUnavailable {
+		t.Errorf("before generation: /openapi.json %d, want 503", code)
+	}
+	if root := preview.regenerate(config); root == "" {
+		t.Fatal("generation failed")
+	}
+
+	code, body := get(t, srv, "/")
+	if code != http.StatusOK || !strings.Contains(body, "swagger-ui") || !strings.Contains(body, "redoc") {
+		t.Errorf("/: %d, page lacks Swagger UI or Redoc", code)
+	}
+	code, body = get(t, srv, "/openapi.json")
+	if code != http.StatusOK || !strings.Contains(body, `"/ping"`) {
+		t.Errorf("/openapi.json: %d %s", code, body)
+	}
+
+	// A failed regeneration keeps the spec and shows the error.
+	config.InputDir = filepath.Join(dir, "missing")
+	preview.regenerate(config)
+	var status struct {
+		Version int    `json:"version"`
+		Error   string `json:"error"`
+	}
+	_, body = get(t, srv, "/status")
+	if err := json.Unmarshal([]byte(body), &status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Version != 2 || status.Error == "" {
+		t.Errorf("status after a failure: %+v", status)
+	}
+	if _, body = get(t, srv, "/openapi.json"); !strings.Contains(body, `"/ping"`) {
+		t.Error("failed regeneration dropped the last spec")
+	}
+}
+
+func TestSourceFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	writeModule(t, dir, pingModule)
+	before := sourceFingerprint(dir, "")
+
+	// Files the go tool ignores do not count.
+	for _, name := range []string{"README.md", filepath.Join("testdata", "x.go"), filepath.Join(".git", "x.go")} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if sourceFingerprint(dir, "") != before {
+		t.Error("ignored files changed the fingerprint")
+	}
+
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(dir, "main.go"), later, later); err != nil {
+		t.Fatal(err)
+	}
+	if sourceFingerprint(dir, "") == before {
+		t.Error("touching main.go kept the fingerprint")
+	}
+
+	withConfig := sourceFingerprint(dir, filepath.Join(dir, "apispec.yaml"))
+	if err := os.WriteFile(filepath.Join(dir, "apispec.yaml"), []byte("info: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if sourceFingerprint(dir, filepath.Join(dir, "apispec.yaml")) == withConfig {
+		t.Error("creating the config file kept the fingerprint")
+	}
+}
@@ -41,6 +41,8 @@ function applyConfigSections(o) {
     securitySchemes: o.securitySchemes || {},
     securityMappings: o.securityMappings || [],
     tags: o.tags || [],
+    groupTags: o.groupTags || [],
+    webhooks: o.webhooks || [],
     externalDocs: o.externalDocs || null,
     defaults: o.defaults || {},
     typeMapping: o.typeMapping || [],
@@ -237,6 +239,8 @@ function fullGenerateRequest() {
     securitySchemes: c.securitySchemes,
     securityMappings: c.securityMappings,
     tags: c.tags,
+    groupTags: c.groupTags,
+    webhooks: c.webhooks,
     externalDocs: c.externalDocs,
     defaults: c.defaults,
     typeMapping: c.typeMapping,
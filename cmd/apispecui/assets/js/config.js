@@ -192,6 +192,49 @@ function PropsEditor(sc, onPatch) {
   </div>`;
 }
 
+// FieldsEditor edits an external type's `fields`: the schemas that override
+// single properties of the types it matches, keyed by property name.
+function FieldsEditor({ fields, onChange }) {
+  const all = fields || {};
+  const addField = () => {
+    let name = "field",
+      n = 1;
+    while (all[name]) name = `field${++n}`;
+    onChange({ ...all, [name]: { type: "string" } });
+  };
+  const renameField = (oldK, raw) => {
+    const newK = (raw || "").trim();
+    if (!newK || newK === oldK || all[newK]) return; // ignore empty / dup
+    const next = {};
+    for (const [k, v] of Object.entries(all)) next[k === oldK ? newK : k] = v;
+    onChange(next);
+  };
+  const delField = (k) => {
+    const next = { ...all };
+    delete next[k];
+    onChange(Object.keys(next).length ? next : undefined);
+  };
+
+  return html`<div class="schema-items">
+    <div class="schema-adv-label">Field overrides</div>
+    ${Object.entries(all).map(
+      ([name, fs]) => html`
+        <div class="card" style="margin:6px 0">
+          <div class="row">
+            <div class="field" style="flex:1">
+              <label>Property name</label>
+              <input class="input" value=${name} placeholder="status" onChange=${(e) => renameField(name, e.target.value)} />
+            </div>
+            ${RowDelete(() => delField(name))}
+          </div>
+          <${SchemaEditor} schema=${fs} onPatch=${(p) => onChange({ ...all, [name]: applySchemaPatch(fs, p) })} />
+        </div>
+      `,
+    )}
+    <button class="btn ghost sm" onClick=${addField}>+ Add field override</button>
+  </div>`;
+}
+
 function Section({ title, hint, help, desc, children, openDefault = false }) {
   const { query, bulk } = useContext(ConfigUI);
   const [open, setOpen] = useState(openDefault);
@@ -345,11 +388,36 @@ export function ConfigMode() {
             <button class="btn secondary sm" onClick=${() => addTo("tags", { name: "", description: "" })}>+ Add tag</button>
           <//>
 
+          <${Section} title="Group tags" help="Operations registered under a router group or mount (Group('/users'), Mount('/payment', r), Route('/orders', fn)) are tagged after the group's last path segment, skipping 'api' and versions like v1. Map a prefix to another name or give it a description; the longest matching prefix wins. Example: prefix /api/v1/payment → name 'Payments', description 'Charges and refunds'." hint=${`${(c.groupTags || []).length}`}>
+            ${(c.groupTags || []).map(
+              (g, i) => html`
+                <div class="card">
+                  <div class="row">
+                    <strong style="font-size:var(--fs-sm)">Group ${i + 1}</strong>
+                    <span class="spacer"></span>${RowDelete(() => delAt("groupTags", i))}
+                  </div>
+                  ${txt("Prefix", g.prefix, (e) => updAt("groupTags", i, { prefix: e.target.value }), "/api/v1/payment")}
+                  ${txt("Tag name", g.name, (e) => updAt("groupTags", i, { name: e.target.value }), "inferred from the prefix")}
+                  ${txt("Description", g.description, (e) => updAt("groupTags", i, { description: e.target.value }))}
+                </div>
+              `,
+            )}
+            <button class="btn secondary sm" onClick=${() => addTo("groupTags", { prefix: "", name: "", description: "" })}>+ Add group tag</button>
+          <//>
+
           <${Section} title="Defaults" help="Fallback content-types and status code used when a handler doesn't make them explicit in code. Typical values: request & response content-type application/json, default response status 200. A per-pattern 'Default content-type' or an override can still take precedence.">
 
             ${txt("Request content-type", c.defaults?.requestContentType, (e) => setDefaults({ requestContentType: e.target.value }), "application/json")}
             ${txt("Response content-type", c.defaults?.responseContentType, (e) => setDefaults({ responseContentType: e.target.value }), "application/json")}
             ${txt("Default response status", c.defaults?.responseStatus, (e) => setDefaults({ responseStatus: parseInt(e.target.value, 10) || 0 }), "200")}
+            <div class="field">
+              <label>Request body required</label>
+              <select class="input" value=${c.defaults?.requestBodyRequired == null ? "" : String(c.defaults.requestBodyRequired)} onChange=${(e) => setDefaults({ requestBodyRequired: e.target.value === "" ? undefined : e.target.value === "true" })}>
+                <option value="">Inferred (optional when the decode is guarded)</option>
+                <option value="true">Always required</option>
+                <option value="false">Always optional</option>
+              </select>
+            </div>
           <//>
 
           <${Section} title="Analysis engine" help="Which tracker tree powers the analysis. The lazy tracker (default) expands the call tree on demand — it covers the same wiring styles as the legacy tree, resolves some responses/bodies the legacy tree misses, and is bounded on very dense call graphs. Choose Legacy (eager) only to compare against the previous engine's output.">
@@ -399,7 +467,7 @@ export function ConfigMode() {
             <button class="btn secondary sm" onClick=${() => addTo("typeMapping", { goType: "", openapiType: { type: "string" } })}>+ Add mapping</button>
           <//>
 
-          <${Section} title="External types" help="Like type mappings, but for types apispec can't see the source of (third-party / opaque). The fix for an 'unresolved/external placeholder type' on a NON-module type — in-module types should resolve automatically, so investigate those instead. Examples: gin.H → object (additionalProperties: true) · primitive.ObjectID → string · shared.Response → object with properties {code: integer, message: string, data: object}." hint=${`${(c.externalTypes || []).length}`}>
+          <${Section} title="External types" help="Like type mappings, but for types apispec can't see the source of (third-party / opaque). The fix for an 'unresolved/external placeholder type' on a NON-module type — in-module types should resolve automatically, so investigate those instead. The name may be a glob matching many types, and an entry may override single fields instead of the whole type. Examples: gin.H → object (additionalProperties: true) · primitive.ObjectID → string · github.com/shopspring/decimal.* → string/decimal · *.Invoice with field status → string with enum [draft, sent, paid]." hint=${`${(c.externalTypes || []).length}`}>
             ${(c.externalTypes || []).map(
               (m, i) => html`
                 <div class="card">
@@ -407,8 +475,15 @@ export function ConfigMode() {
                     <strong style="font-size:var(--fs-sm)">Type ${i + 1}</strong>
                     <span class="spacer"></span>${RowDelete(() => delAt("externalTypes", i))}
                   </div>
-                  ${txt("Name", m.name, (e) => updAt("externalTypes", i, { name: e.target.value }), "primitive.ObjectID")}
-                  <${SchemaEditor} schema=${m.openapiType} onPatch=${(p) => updAt("externalTypes", i, { openapiType: applySchemaPatch(m.openapiType, p) })} />
+                  ${txt("Name (or glob)", m.name, (e) => updAt("externalTypes", i, { name: e.target.value }), "primitive.ObjectID · github.com/shopspring/decimal.*")}
+                  <label class="row" style="cursor:pointer;gap:6px;margin:4px 0">
+                    <input type="checkbox" checked=${!!m.openapiType} onChange=${(e) => updAt("externalTypes", i, { openapiType: e.target.checked ? { type: "string" } : undefined })} />
+                    <span>Replace the whole type</span>
+                  </label>
+                  ${m.openapiType
+                    ? html`<${SchemaEditor} schema=${m.openapiType} onPatch=${(p) => updAt("externalTypes", i, { openapiType: applySchemaPatch(m.openapiType, p) })} />`
+                    : ""}
+                  <${FieldsEditor} fields=${m.fields} onChange=${(f) => updAt("externalTypes", i, { fields: f })} />
                 </div>
               `,
             )}
@@ -459,6 +534,9 @@ export function ConfigMode() {
           <${Section} title="Parameters" help="How path/query/header/cookie/form parameter reads are recognised. The 'Parameter location' sets where it appears in the spec. Examples (Gin): c.Param('id') → location path · c.Query('q') → location query · c.GetHeader('X-Token') → location header. The parameter name is read from the named-argument index." hint=${`${(fc.paramPatterns || []).length}`}>
             <${PatternList} items=${fc.paramPatterns} fields=${PATTERN_FIELDS.paramPatterns} onChange=${(a) => setFC("paramPatterns", a)} />
           <//>
+          <${Section} title="Response headers" help="How response header writes are recognised; each header name is documented on the operation's responses. Examples: w.Header().Set('X-Request-ID', id) · Gin c.Header('ETag', tag) · Fiber c.Set('X-Request-ID', id). The header name is read from the name-argument index and must be a string literal or constant. 'Header source regex' requires the header map to come from the response writer, so headers set on an outbound request are skipped." hint=${`${(fc.responseHeaderPatterns || []).length}`}>
+            <${PatternList} items=${fc.responseHeaderPatterns} fields=${PATTERN_FIELDS.responseHeaderPatterns} onChange=${(a) => setFC("responseHeaderPatterns", a)} />
+          <//>
           <${Section} title="Mounts / groups" help="How sub-router mounts/groups are recognised so nested routes inherit the right path prefix. Examples: Chi r.Mount('/api', sub) or r.Route('/v1', fn) · Gin r.Group('/v1'). Set 'Path from arg' (the prefix) and 'Router from arg' (the sub-router being mounted) and mark 'Is mount'." hint=${`${(fc.mountPatterns || []).length}`}>
             <${PatternList} items=${fc.mountPatterns} fields=${PATTERN_FIELDS.mountPatterns} onChange=${(a) => setFC("mountPatterns", a)} />
           <//>
@@ -486,7 +564,9 @@ const PATTERN_FIELDS = {
     ["handlerArgIndex", "Handler arg index", "int", "0-based position of the handler argument. e.g. in r.GET(path, handler) the handler is index 1."],
     ["pathArgIndex", "Path arg index", "int", "0-based position of the path argument. e.g. in r.GET(path, handler) the path is index 0."],
     ["methodArgIndex", "Method arg index", "int", "0-based position of the HTTP-method argument, for routers that take the method as a value. e.g. r.Handle(method, path, h) → 0."],
+    ["method", "Method", "text", "Fixed HTTP method for every matched call, for wrappers that carry the verb in their name. e.g. srv.GetJSON(path, h) → GET."],
     ["methodFromCall", "Method from call name", "bool", "Derive the HTTP method from the called function name (e.g. GET())."],
+    ["methodFromPath", "Method from path", "bool", "Split a leading verb off the path argument, as Go 1.22 ServeMux patterns carry it. e.g. mux.HandleFunc('GET /users/{id}', h) → GET /users/{id}."],
     ["methodFromHandler", "Method from handler", "bool", "Derive the method from the handler function name."],
     ["pathFromArg", "Path from arg", "bool", "Read the path from the path argument."],
     ["handlerFromArg", "Handler from arg", "bool", "Resolve the handler from the handler argument."],
@@ -497,6 +577,8 @@ const PATTERN_FIELDS = {
     ["typeFromArg", "Type from arg", "bool", "Use the matched argument's type as the body schema. e.g. c.ShouldBindJSON(&req) → uses *req's type."],
     ["typeFromReturn", "Type from return", "bool", "Use the call's RETURN type as the body type instead of an argument."],
     ["deref", "Dereference pointer", "bool", "Strip a leading * from the resolved type, so *CreateUserRequest becomes CreateUserRequest. Usually on for &req decoders."],
+    ["bodyType", "Body type", "text", "Fixed Go type of the body, for calls that decide it themselves rather than through an argument. e.g. jsonpatch.DecodePatch(body) → jsonpatch.Patch."],
+    ["contentType", "Content-type", "text", "Content-type of the bodies this pattern matches, in place of the default. e.g. application/json-patch+json for jsonpatch.DecodePatch."],
     ["requireRequestSource", "Require request source", "bool", "Only classify as a request body when the value traces back to the HTTP request — prevents config/file decoders (json.Unmarshal of a file) from being mistaken for body decoders. Pair with Request context below."],
     ["bodyFromReceiver", "Body from receiver", "bool", "The body comes from the call RECEIVER, not an argument — e.g. a json.Decoder bound to r.Body: dec.Decode(&req)."],
     ["bodySourceArgIndex", "Body source arg index", "int", "Index of the argument carrying the request-body source (the io.Reader/request), used with Require request source."],
@@ -514,11 +596,19 @@ const PATTERN_FIELDS = {
   ],
   paramPatterns: [
     ...COMMON_MATCH,
-    ["paramIn", "Parameter location", "select:path,query,header,cookie,form", "Where this parameter appears in the spec. e.g. c.Param→path, c.Query→query, c.GetHeader→header, c.Cookie→cookie, c.PostForm→form."],
+    ["paramIn", "Parameter location", "select:path,query,header,cookie,form,file,multipart", "Where this parameter appears in the spec. e.g. c.Param→path, c.Query→query, c.GetHeader→header, c.Cookie→cookie, c.PostForm→form, c.FormFile→file (a multipart file part)."],
     ["paramArgIndex", "Param arg index", "int", "Index of the argument holding the parameter NAME. e.g. c.Query('q') → 0."],
     ["typeArgIndex", "Type arg index", "int", "Index of the argument whose type is the parameter type (for typed getters)."],
     ["typeFromArg", "Type from arg", "bool", "Use the matched argument's type as the parameter type (otherwise defaults to string)."],
     ["deref", "Dereference pointer", "bool", "Strip a leading * from the resolved type."],
+    ["paramType", "Param type", "text", "Go type of the value when the getter fixes it. e.g. int for c.QueryInt('page'). Otherwise a strconv conversion in the handler, or string."],
+    ["structTag", "Struct tag", "text", "For binders that fill a whole struct (the one at Type arg index): the tag naming each field's parameter. e.g. c.ShouldBindQuery(&f) → form, c.ShouldBindUri(&p) → uri."],
+  ],
+  responseHeaderPatterns: [
+    ...COMMON_MATCH,
+    ["nameArgIndex", "Name arg index", "int", "Index of the argument holding the header NAME. e.g. w.Header().Set('X-Request-ID', id) → 0."],
+    ["header", "Header", "text", "Fixed header name for calls that carry none, e.g. Set-Cookie for http.SetCookie(w, cookie). Overrides the name arg index."],
+    ["headerSourceRegex", "Header source regex", "text", "Regex matching the call that produced the header map, as <pkg>.<Type>.<Method>. e.g. ^net/http\\.ResponseWriter\\.Header$ keeps req.Header.Set on an outbound request out. Leave blank to accept any receiver."],
   ],
   mountPatterns: [
     ...COMMON_MATCH,
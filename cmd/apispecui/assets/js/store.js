@@ -21,6 +21,8 @@ const state = {
     securitySchemes: {},
     securityMappings: [], // [{functionNameRegex,pkgRegex,recvTypeRegex,schemes:[{name:[]}]}]
     tags: [],
+    groupTags: [], // [{prefix,name,description}]
+    webhooks: [], // [{name,payloadType,callbackURL,calls,...}] — edited in the raw config
     externalDocs: null,
     defaults: {},
     typeMapping: [],
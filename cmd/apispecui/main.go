@@ -15,7 +15,6 @@
 package main
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"embed"
@@ -26,12 +25,14 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime/debug"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/ehabterra/apispec/internal/core"
@@ -40,7 +41,9 @@ import (
 	"github.com/ehabterra/apispec/internal/insight"
 	"github.com/ehabterra/apispec/internal/metadata"
 	"github.com/ehabterra/apispec/internal/spec"
+	"github.com/ehabterra/apispec/internal/yamlout"
 	pubspec "github.com/ehabterra/apispec/spec"
+	"golang.org/x/mod/modfile"
 	"gopkg.in/yaml.v3"
 )
 
@@ -113,6 +116,8 @@ type ServerConfig struct {
 	InputDir   string
 	ConfigFile string
 	Verbose    bool
+	// Timeouts bound the server's connections and its graceful shutdown.
+	Timeouts diagserver.Timeouts
 }
 
 // DetectResponse is what GET /api/detect returns: information the UI needs
@@ -130,6 +135,8 @@ type DetectResponse struct {
 	SecuritySchemes     map[string]spec.SecurityScheme `json:"securitySchemes"`
 	SecurityMappings    []spec.SecurityMapping         `json:"securityMappings"`
 	Tags                []spec.Tag                     `json:"tags"`
+	GroupTags           []spec.GroupTag                `json:"groupTags"`
+	Webhooks            []spec.Webhook                 `json:"webhooks"`
 	ExternalDocs        *spec.ExternalDocumentation    `json:"externalDocs"`
 	Defaults            spec.Defaults                  `json:"defaults"`
 	TypeMapping         []spec.TypeMapping             `json:"typeMapping"`
@@ -171,6 +178,8 @@ type GenerateRequest struct {
 	SecuritySchemes  map[string]spec.SecurityScheme `json:"securitySchemes"`
 	SecurityMappings []spec.SecurityMapping         `json:"securityMappings"`
 	Tags             []spec.Tag                     `json:"tags"`
+	GroupTags        []spec.GroupTag                `json:"groupTags"`
+	Webhooks         []spec.Webhook                 `json:"webhooks"`
 	ExternalDocs     *spec.ExternalDocumentation    `json:"externalDocs"`
 	Defaults         spec.Defaults                  `json:"defaults"`
 	TypeMapping      []spec.TypeMapping             `json:"typeMapping"`
@@ -358,9 +367,14 @@ func main() {
 	log.Printf("    Open http://%s in your browser to configure & preview", addr)
 	log.Printf("    Call graph: http://%s/diagram", addr)
 
-	if err := http.ListenAndServe(addr, mux); err != nil {
+	// SIGINT and SIGTERM let in-flight requests finish before exiting.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	cfg.Timeouts.Idle = 2 * time.Minute
+	if err := diagserver.ListenAndServe(ctx, addr, mux, cfg.Timeouts); err != nil {
 		log.Fatalf("server failed: %v", err)
 	}
+	log.Printf("👋 apispec-ui stopped")
 }
 
 func parseFlags() *ServerConfig {
@@ -372,6 +386,9 @@ func parseFlags() *ServerConfig {
 	flag.StringVar(&cfg.ConfigFile, "config", "", "Optional initial APISpec config YAML to seed the UI")
 	flag.StringVar(&cfg.ConfigFile, "c", "", "Shorthand for --config")
 	flag.BoolVar(&cfg.Verbose, "verbose", false, "Verbose logging")
+	flag.DurationVar(&cfg.Timeouts.Read, "read-timeout", time.Minute, "Maximum time to read a request (0 for no limit)")
+	flag.DurationVar(&cfg.Timeouts.Write, "write-timeout", 10*time.Minute, "Maximum time to write a response, including a generation (0 for no limit)")
+	flag.DurationVar(&cfg.Timeouts.Shutdown, "shutdown-timeout", 30*time.Second, "How long in-flight requests get to finish on SIGINT or SIGTERM")
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "apispec-ui: interactive web UI to configure and preview an OpenAPI spec\n\n")
 		fmt.Fprintf(os.Stderr, "Usage: %s [flags]\n\nFlags:\n", os.Args[0])
@@ -467,24 +484,11 @@ func findModuleRoot(start string) (string, string) {
 }
 
 func readModulePath(gomod string) string {
-	f, err := os.Open(gomod)
+	data, err := os.ReadFile(gomod)
 	if err != nil {
 		return ""
 	}
-	defer func() {
-		err = f.Close()
-		if err != nil {
-			log.Printf("failed to close %s: %v", gomod, err)
-		}
-	}()
-	scan := bufio.NewScanner(f)
-	for scan.Scan() {
-		line := strings.TrimSpace(scan.Text())
-		if strings.HasPrefix(line, "module ") {
-			return strings.TrimSpace(strings.TrimPrefix(line, "module"))
-		}
-	}
-	return ""
+	return modfile.ModulePath(data)
 }
 
 // --- handlers -------------------------------------------------------------
@@ -601,6 +605,8 @@ func (s *UIServer) buildDetectResponse(dir string) DetectResponse {
 		SecuritySchemes:     base.SecuritySchemes,
 		SecurityMappings:    base.SecurityMappings,
 		Tags:                base.Tags,
+		GroupTags:           base.GroupTags,
+		Webhooks:            base.Webhooks,
 		ExternalDocs:        base.ExternalDocs,
 		Defaults:            base.Defaults,
 		TypeMapping:         base.TypeMapping,
@@ -668,6 +674,8 @@ func looksLikeAPISpecConfig(path string) bool {
 		len(cfg.Security) > 0 ||
 		len(cfg.SecuritySchemes) > 0 ||
 		len(cfg.Tags) > 0 ||
+		len(cfg.GroupTags) > 0 ||
+		len(cfg.Webhooks) > 0 ||
 		cfg.ExternalDocs != nil ||
 		cfg.Defaults != (spec.Defaults{}) ||
 		ie(cfg.Include) ||
@@ -1098,12 +1106,9 @@ func (s *UIServer) handleSpecYAML(w http.ResponseWriter, r *http.Request) {
 	}
 	w.Header().Set("Content-Type", "application/x-yaml; charset=utf-8")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	enc := yaml.NewEncoder(w)
-	enc.SetIndent(2)
-	if err := enc.Encode(cur); err != nil {
+	if err := yamlout.Encode(w, cur, yamlout.Options{}); err != nil {
 		log.Printf("failed to encode spec YAML: %v", err)
 	}
-	_ = enc.Close()
 }
 
 func (s *UIServer) handleConfigYAML(w http.ResponseWriter, r *http.Request) {
@@ -1396,6 +1401,15 @@ func buildAPISpecConfig(req *GenerateRequest) (*spec.APISpecConfig, error) {
 		if err := cfg.ValidateSecurity(); err != nil {
 			return nil, err
 		}
+		if err := cfg.ValidateExternalTypes(); err != nil {
+			return nil, err
+		}
+		if err := cfg.Schemas.ValidateNullability(); err != nil {
+			return nil, err
+		}
+		if err := cfg.Schemas.ValidateDynamicValues(); err != nil {
+			return nil, err
+		}
 		return cfg, nil
 	}
 
@@ -1414,10 +1428,16 @@ func buildAPISpecConfig(req *GenerateRequest) (*spec.APISpecConfig, error) {
 	if len(req.Tags) > 0 {
 		cfg.Tags = req.Tags
 	}
+	if len(req.GroupTags) > 0 {
+		cfg.GroupTags = req.GroupTags
+	}
+	if len(req.Webhooks) > 0 {
+		cfg.Webhooks = req.Webhooks
+	}
 	if req.ExternalDocs != nil && (req.ExternalDocs.URL != "" || req.ExternalDocs.Description != "") {
 		cfg.ExternalDocs = req.ExternalDocs
 	}
-	if req.Defaults.RequestContentType != "" || req.Defaults.ResponseContentType != "" || req.Defaults.ResponseStatus != 0 {
+	if req.Defaults != (spec.Defaults{}) {
 		cfg.Defaults = req.Defaults
 	}
 	if len(req.TypeMapping) > 0 {
@@ -1456,11 +1476,20 @@ func buildAPISpecConfig(req *GenerateRequest) (*spec.APISpecConfig, error) {
 			cfg.Framework.RequestContext = fc.RequestContext
 		}
 	}
-	// Enforce the same security-config checks LoadAPISpecConfig applies, so
-	// structured securityMappings from the UI can't bypass validation.
+	// Enforce the same security and externalTypes checks LoadAPISpecConfig
+	// applies, so structured entries from the UI can't bypass validation.
 	if err := cfg.ValidateSecurity(); err != nil {
 		return nil, err
 	}
+	if err := cfg.ValidateExternalTypes(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Schemas.ValidateNullability(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Schemas.ValidateDynamicValues(); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
@@ -0,0 +1,230 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+	"github.com/ehabterra/apispec/spectest"
+	"gopkg.in/yaml.v3"
+)
+
+var conformanceReport = flag.String("conformance.report", "", "write the extraction conformance matrix, as Markdown, to this file")
+
+// conformanceFrameworks are the matrix columns, in order.
+var conformanceFrameworks = []string{"gin", "chi", "echo", "fiber", "mux", "net/http"}
+
+// conformanceScenarios is the file the suite reads; see its header for the
+// format.
+const conformanceScenarios = "../testdata/conformance/scenarios.yaml"
+
+type conformanceSuite struct {
+	Modules   map[string]string     `yaml:"modules"`
+	Scenarios []conformanceScenario `yaml:"scenarios"`
+}
+
+type conformanceScenario struct {
+	Name        string                 `yaml:"name"`
+	Description string                 `yaml:"description"`
+	Expect      []conformanceOperation `yaml:"expect"`
+	Sources     map[string]string      `yaml:"sources"`
+	KnownGaps   []string               `yaml:"knownGaps"`
+}
+
+type conformanceOperation struct {
+	Method      string             `yaml:"method"`
+	Path        string             `yaml:"path"`
+	Params      []conformanceParam `yaml:"params"`
+	RequestBody string             `yaml:"requestBody"`
+	Responses   map[string]string  `yaml:"responses"`
+}
+
+type conformanceParam struct {
+	Name string `yaml:"name"`
+	In   string `yaml:"in"`
+	Type string `yaml:"type"`
+}
+
+// Matrix cells.
+const (
+	conformancePass = "pass"
+	conformanceFail = "FAIL"
+	conformanceGap  = "known gap"
+	conformanceNA   = "n/a"
+)
+
+// TestConformance runs every conformance scenario against every framework
+// that has a source for it and reports which extraction capabilities each
+// framework supports. A failure outside the scenario's knownGaps fails the
+// suite, and so does a known gap that passes.
+func TestConformance(t *testing.T) {
+	data, err := os.ReadFile(conformanceScenarios)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var suite conformanceSuite
+	if err := yaml.Unmarshal(data, &suite); err != nil {
+		t.Fatalf("%s: %v", conformanceScenarios, err)
+	}
+
+	matrix := make(map[string]map[string]string, len(suite.Scenarios))
+	for _, sc := range suite.Scenarios {
+		for fw := range sc.Sources {
+			if !slices.Contains(conformanceFrameworks, fw) {
+				t.Errorf("%s: unknown framework %q", sc.Name, fw)
+			}
+		}
+		matrix[sc.Name] = map[string]string{}
+		for _, fw := range conformanceFrameworks {
+			src, ok := sc.Sources[fw]
+			if !ok {
+				matrix[sc.Name][fw] = conformanceNA
+				continue
+			}
+			t.Run(sc.Name+"/"+strings.ReplaceAll(fw, "/", "_"), func(t *testing.T) {
+				problems := runConformance(t, suite.Modules[fw], src, sc.Expect)
+				gap := slices.Contains(sc.KnownGaps, fw)
+				switch {
+				case len(problems) > 0 && gap:
+					matrix[sc.Name][fw] = conformanceGap
+					t.Logf("known gap: %s", strings.Join(problems, "; "))
+				case len(problems) > 0:
+					matrix[sc.Name][fw] = conformanceFail
+					for _, p := range problems {
+						t.Error(p)
+					}
+				case gap:
+					matrix[sc.Name][fw] = conformancePass
+					t.Errorf("%s now passes %s: remove it from the scenario's knownGaps", fw, sc.Name)
+				default:
+					matrix[sc.Name][fw] = conformancePass
+				}
+			})
+		}
+	}
+
+	report := conformanceMatrix(suite.Scenarios, matrix)
+	t.Logf("extraction conformance:\n%s", report)
+	if *conformanceReport != "" {
+		if err := os.WriteFile(*conformanceReport, []byte(report), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// runConformance generates the spec of src, built with the go.mod and
+// go.sum in moduleDir (relative to the scenarios file) when set, and returns
+// how it falls short of expect.
+func runConformance(t *testing.T, moduleDir, src string, expect []conformanceOperation) []string {
+	t.Helper()
+	files := spectest.Files{"main.go": src}
+	if moduleDir != "" {
+		for _, name := range []string{"go.mod", "go.sum"} {
+			content, err := os.ReadFile(filepath.Join(filepath.Dir(conformanceScenarios), moduleDir, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			files[name] = string(content)
+		}
+	}
+	res, err := spectest.Generate(t.TempDir(), nil, files)
+	if err != nil {
+		return []string{fmt.Sprintf("generation failed: %v", err)}
+	}
+
+	var problems []string
+	for _, want := range expect {
+		op := res.Operation(want.Method, want.Path)
+		if op == nil {
+			problems = append(problems, fmt.Sprintf("no %s %s operation", want.Method, want.Path))
+			continue
+		}
+		at := want.Method + " " + want.Path
+		for _, p := range want.Params {
+			i := slices.IndexFunc(op.Parameters, func(got spec.Parameter) bool { return got.Name == p.Name && got.In == p.In })
+			switch {
+			case i < 0:
+				problems = append(problems, fmt.Sprintf("%s: no %s parameter %q", at, p.In, p.Name))
+			case p.Type != "" && (op.Parameters[i].Schema == nil || op.Parameters[i].Schema.Type != p.Type):
+				problems = append(problems, fmt.Sprintf("%s: %s parameter %q is not of type %s", at, p.In, p.Name, p.Type))
+			}
+		}
+		if want.RequestBody != "" {
+			if op.RequestBody == nil {
+				problems = append(problems, fmt.Sprintf("%s: no request body", at))
+			} else if got := bodySchemaTitle(res, op.RequestBody.Content); got != want.RequestBody {
+				problems = append(problems, fmt.Sprintf("%s: request body schema is %q, want %s", at, got, want.RequestBody))
+			}
+		}
+		for status, schema := range want.Responses {
+			resp, ok := op.Responses[status]
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Sprintf("%s: no %s response", at, status))
+			case schema != "":
+				if got := bodySchemaTitle(res, resp.Content); got != schema {
+					problems = append(problems, fmt.Sprintf("%s: %s response schema is %q, want %s", at, status, got, schema))
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// bodySchemaTitle returns the title of the first schema in content, through
+// its component when it is a $ref.
+func bodySchemaTitle(res *spectest.Result, content map[string]spec.MediaType) string {
+	for _, mt := range slices.Sorted(maps.Keys(content)) {
+		s := content[mt].Schema
+		if s == nil {
+			continue
+		}
+		if name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/"); ok {
+			s = res.Schema(name)
+		}
+		if s != nil {
+			return s.Title
+		}
+	}
+	return ""
+}
+
+// conformanceMatrix renders the capability matrix as a Markdown table.
+func conformanceMatrix(scenarios []conformanceScenario, matrix map[string]map[string]string) string {
+	var b strings.Builder
+	b.WriteString("| Scenario |")
+	for _, fw := range conformanceFrameworks {
+		fmt.Fprintf(&b, " %s |", fw)
+	}
+	b.WriteString("\n|---|")
+	b.WriteString(strings.Repeat("---|", len(conformanceFrameworks)))
+	b.WriteString("\n")
+	for _, sc := range scenarios {
+		fmt.Fprintf(&b, "| %s |", sc.Name)
+		for _, fw := range conformanceFrameworks {
+			fmt.Fprintf(&b, " %s |", matrix[sc.Name][fw])
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
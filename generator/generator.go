@@ -78,3 +78,45 @@ func (g *Generator) PathParamMismatches() []intspec.PathParamMismatch {
 	}
 	return g.engine.GetPathParamMismatches()
 }
+
+// UnsafeMethodWrites returns the GET and HEAD handlers from the most recent
+// GenerateFromDirectory that call a repository or database mutation, nearest
+// call first. Empty when none or before any generation.
+func (g *Generator) UnsafeMethodWrites() []intspec.UnsafeMethodWrite {
+	if g.engine == nil {
+		return nil
+	}
+	return g.engine.GetUnsafeMethodWrites()
+}
+
+// NamingIssues returns the JSON naming audit findings from the most recent
+// GenerateFromDirectory: duplicate json tags, JSON names that collide after
+// case-folding, and properties spelled with different case across schemas.
+// Empty when none or before any generation.
+func (g *Generator) NamingIssues() []intspec.NamingIssue {
+	if g.engine == nil {
+		return nil
+	}
+	return g.engine.GetNamingIssues()
+}
+
+// TimeLayoutMismatches returns the marshalers that, in the most recent
+// GenerateFromDirectory, format a time with a layout their schema does not
+// describe (a date written where the schema says date-time). Empty when none
+// or before any generation.
+func (g *Generator) TimeLayoutMismatches() []intspec.TimeLayoutMismatch {
+	if g.engine == nil {
+		return nil
+	}
+	return g.engine.GetTimeLayoutMismatches()
+}
+
+// Routes returns the routes extracted by the most recent
+// GenerateFromDirectory, in the model whose JSON encoding spec.RouteInfo
+// documents. Empty before any generation.
+func (g *Generator) Routes() []*spec.RouteInfo {
+	if g.engine == nil {
+		return nil
+	}
+	return g.engine.GetRoutes()
+}
@@ -16,6 +16,7 @@ package generator
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	intspec "github.com/ehabterra/apispec/internal/spec"
@@ -49,6 +50,8 @@ func TestGenerateFromDirectory_ServeMuxMethodRouting(t *testing.T) {
 			return item.Get
 		case "POST":
 			return item.Post
+		case "DELETE":
+			return item.Delete
 		default:
 			t.Fatalf("unhandled method %q", method)
 			return nil
@@ -90,10 +93,36 @@ func TestGenerateFromDirectory_ServeMuxMethodRouting(t *testing.T) {
 		t.Fatal("GET /health missing")
 	}
 
-	// Regression guard: the method prefix must not leak into the path key.
+	// GET /files/{path...} — the trailing wildcard is an ordinary {path}
+	// parameter, documented as taking the rest of the path.
+	getFile := op("/files/{path}", "GET")
+	if getFile == nil || len(getFile.Parameters) != 1 || getFile.Parameters[0].Name != "path" {
+		t.Fatalf("GET /files/{path}: want one path parameter; op=%+v", getFile)
+	}
+	if p := getFile.Parameters[0]; p.Description == "" || p.Extensions["x-warning"] != nil {
+		t.Errorf("GET /files/{path}: remainder parameter = %+v, want a description and no fallback warning", p)
+	}
+
+	// GET /{$} — the anchor leaves the root path.
+	if index := op("/", "GET"); index == nil {
+		t.Error("GET / missing")
+	}
+
+	// GET api.example.com/hosts/{name} — the host is not part of the path.
+	if hostOp := op("/hosts/{name}", "GET"); hostOp == nil || len(hostOp.Parameters) != 1 {
+		t.Errorf("GET /hosts/{name}: want one path parameter; op=%+v", hostOp)
+	}
+
+	// DELETE /users/{id} — registered on http.DefaultServeMux.
+	if del := op("/users/{id}", "DELETE"); del == nil {
+		t.Error("DELETE /users/{id} missing")
+	}
+
+	// Regression guard: neither the method prefix nor the host may leak into
+	// the path key.
 	for path := range out.Paths {
-		if path == "GET /users/{id}" || path == "POST /users" || path == "GET /health" {
-			t.Errorf("method prefix leaked into path key: %q", path)
+		if strings.Contains(path, " ") || strings.Contains(path, "example.com") || strings.Contains(path, "...") {
+			t.Errorf("pattern syntax leaked into path key: %q", path)
 		}
 	}
 }
@@ -0,0 +1,5 @@
+module github.com/ehabterra/apispec/generator/testdata/analyze_dependency
+
+go 1.22
+
+require github.com/acme/httpkit v1.2.0
@@ -0,0 +1,29 @@
+// Package main mounts routes a vendored library registers. They are only
+// documented with --analyze-dependency github.com/acme/httpkit: by default
+// the library's source is not analyzed, and the spec holds the service's own
+// route alone.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/acme/httpkit"
+)
+
+// Order is a placed order.
+type Order struct {
+	ID    string  `json:"id"`
+	Total float64 `json:"total"`
+}
+
+func listOrders(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]Order{})
+}
+
+func main() {
+	mux := http.NewServeMux()
+	httpkit.Mount(mux)
+	mux.HandleFunc("GET /orders", listOrders)
+	http.ListenAndServe(":8080", mux)
+}
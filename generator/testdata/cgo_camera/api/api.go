@@ -0,0 +1,21 @@
+// Package api registers the endpoints that build without the camera SDK.
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Health is the service's status.
+type Health struct {
+	Status string `json:"status"`
+}
+
+func health(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Health{Status: "ok"})
+}
+
+// Register mounts the endpoints on mux.
+func Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /health", health)
+}
@@ -0,0 +1,46 @@
+// Package camera binds the vendor's camera SDK.
+package camera
+
+/*
+#cgo LDFLAGS: -lacmecam
+#include <acmecam/sdk.h>
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Frame describes a captured frame.
+type Frame struct {
+	ID     int `json:"id"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Capture is the body of a capture request.
+type Capture struct {
+	Exposure float64 `json:"exposure"`
+}
+
+func latestFrame(w http.ResponseWriter, r *http.Request) {
+	id := int(C.acmecam_last_frame())
+	json.NewEncoder(w).Encode(Frame{ID: id, Width: 1920, Height: 1080})
+}
+
+func capture(w http.ResponseWriter, r *http.Request) {
+	var req Capture
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	C.acmecam_capture(C.double(req.Exposure))
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Register mounts the camera endpoints on mux.
+func Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /frames/latest", latestFrame)
+	mux.HandleFunc("POST /frames", capture)
+}
@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/generator/testdata/cgo_camera
+
+go 1.22
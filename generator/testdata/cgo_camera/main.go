@@ -0,0 +1,19 @@
+// Package main serves a camera API: the frame endpoints live in a package
+// binding a vendor SDK through cgo, whose header is not installed where the
+// spec is generated. By default that package fails to build and is skipped;
+// with --stub-cgo its routes and DTOs are documented from its Go syntax.
+package main
+
+import (
+	"net/http"
+
+	"github.com/ehabterra/apispec/generator/testdata/cgo_camera/api"
+	"github.com/ehabterra/apispec/generator/testdata/cgo_camera/camera"
+)
+
+func main() {
+	mux := http.NewServeMux()
+	api.Register(mux)
+	camera.Register(mux)
+	http.ListenAndServe(":8080", mux)
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/engine"
+)
+
+// TestTestdata_AnalyzeDependency covers routes a vendored library registers:
+// left out by default, documented, with the library's response types, once
+// the module is analyzed on request.
+func TestTestdata_AnalyzeDependency(t *testing.T) {
+	// The fixture's dependency exists only in vendor/: build from it whatever
+	// GOFLAGS the environment sets. That is also why the fixture is kept out
+	// of the golden tree under ../testdata.
+	t.Setenv("GOFLAGS", "-mod=vendor")
+
+	generate := func(deps ...string) []string {
+		t.Helper()
+		ec := engine.DefaultEngineConfig()
+		ec.InputDir = filepath.Join("testdata", "analyze_dependency")
+		ec.AnalyzeDependencies = deps
+		out, err := engine.NewEngine(ec).GenerateOpenAPI()
+		if err != nil {
+			t.Fatalf("GenerateOpenAPI: %v", err)
+		}
+		noDanglingRefs(t, out)
+		if len(deps) > 0 && out.Components.Schemas["github_com_acme_httpkit_Status"] == nil {
+			t.Errorf("no Status component: %v", slices.Sorted(maps.Keys(out.Components.Schemas)))
+		}
+		return slices.Sorted(maps.Keys(out.Paths))
+	}
+
+	if got, want := generate(), []string{"/orders"}; !slices.Equal(got, want) {
+		t.Errorf("default paths = %v, want %v", got, want)
+	}
+	if got, want := generate("github.com/acme/httpkit"), []string{"/healthz", "/orders", "/readyz"}; !slices.Equal(got, want) {
+		t.Errorf("paths with the dependency analyzed = %v, want %v", got, want)
+	}
+}
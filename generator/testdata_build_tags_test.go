@@ -0,0 +1,53 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/engine"
+)
+
+// TestTestdata_BuildTags covers handlers behind build constraints: the
+// enterprise edition's routes come in with the build tag, the FreeBSD-only
+// route with GOOS, and neither is in the default build.
+func TestTestdata_BuildTags(t *testing.T) {
+	generate := func(tags, goos string) []string {
+		t.Helper()
+		ec := engine.DefaultEngineConfig()
+		ec.InputDir = filepath.Join("..", "testdata", "build_tags")
+		ec.BuildTags, ec.GOOS = tags, goos
+		out, err := engine.NewEngine(ec).GenerateOpenAPI()
+		if err != nil {
+			t.Fatalf("GenerateOpenAPI: %v", err)
+		}
+		noDanglingRefs(t, out)
+		return slices.Sorted(maps.Keys(out.Paths))
+	}
+
+	// Pin GOOS so the test reads the same on every host, FreeBSD included.
+	if got, want := generate("", "linux"), []string{"/users"}; !slices.Equal(got, want) {
+		t.Errorf("default paths = %v, want %v", got, want)
+	}
+	if got, want := generate("enterprise", "linux"), []string{"/licenses", "/users"}; !slices.Equal(got, want) {
+		t.Errorf("paths with -tags enterprise = %v, want %v", got, want)
+	}
+	if got, want := generate("", "freebsd"), []string{"/kstat", "/users"}; !slices.Equal(got, want) {
+		t.Errorf("paths with GOOS=freebsd = %v, want %v", got, want)
+	}
+}
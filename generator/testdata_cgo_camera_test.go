@@ -0,0 +1,71 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"maps"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/engine"
+)
+
+const cameraModels = "github_com_ehabterra_apispec_generator_testdata_cgo_camera_camera_"
+
+// TestTestdata_CgoCamera covers a cgo package whose C header is missing: it
+// fails to build and is skipped by default, and is documented from its Go
+// syntax with StubCGOPackages. The fixture's header never exists, so it is
+// kept out of the golden tree under ../testdata, whose specs would depend on
+// whether the host has cgo.
+func TestTestdata_CgoCamera(t *testing.T) {
+	if out, err := exec.Command("go", "env", "CGO_ENABLED").Output(); err != nil || strings.TrimSpace(string(out)) != "1" {
+		t.Skip("cgo is disabled: the camera package's cgo file is not part of the build")
+	}
+
+	generate := func(stub bool) []string {
+		t.Helper()
+		ec := engine.DefaultEngineConfig()
+		ec.InputDir = filepath.Join("testdata", "cgo_camera")
+		ec.StubCGOPackages = stub
+		out, err := engine.NewEngine(ec).GenerateOpenAPI()
+		if err != nil {
+			t.Fatalf("GenerateOpenAPI: %v", err)
+		}
+		noDanglingRefs(t, out)
+		if stub {
+			frame := out.Components.Schemas[cameraModels+"Frame"]
+			if frame == nil {
+				t.Fatalf("no Frame component: %v", slices.Sorted(maps.Keys(out.Components.Schemas)))
+			}
+			if got, want := slices.Sorted(maps.Keys(frame.Properties)), []string{"height", "id", "width"}; !slices.Equal(got, want) {
+				t.Errorf("Frame properties = %v, want %v", got, want)
+			}
+			if op := out.Paths["/frames"].Post; op == nil || op.RequestBody == nil {
+				t.Errorf("POST /frames = %+v, want the Capture request body", op)
+			}
+		}
+		return slices.Sorted(maps.Keys(out.Paths))
+	}
+
+	if got, want := generate(false), []string{"/health"}; !slices.Equal(got, want) {
+		t.Errorf("default paths = %v, want %v", got, want)
+	}
+	if got, want := generate(true), []string{"/frames", "/frames/latest", "/health"}; !slices.Equal(got, want) {
+		t.Errorf("paths with StubCGOPackages = %v, want %v", got, want)
+	}
+}
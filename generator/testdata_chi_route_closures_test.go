@@ -0,0 +1,77 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_ChiRouteClosures locks in chi routes registered through
+// closures: nested r.Route sub-routers, r.Group inline groups, a sub-router
+// passed by name or held in a local variable, and r.With(mw) chained in front
+// of a route, a Route or a Group. Every route carries the prefixes of the
+// closures around it, and the bearer middleware guards exactly the routes
+// its Use or With scope covers.
+func TestTestdata_ChiRouteClosures(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "chi_route_closures", spec.DefaultChiConfig())
+	noDanglingRefs(t, out)
+
+	type want struct {
+		path, method string
+		protected    bool
+	}
+	for _, w := range []want{
+		{"/health", "GET", false},
+		{"/users/", "GET", false},
+		{"/users/", "POST", true},                 // r.With(requireAuth).Post inside Route
+		{"/users/{userID}/", "GET", false},        // nested Route
+		{"/users/{userID}/", "DELETE", true},      // r.With(requireAuth, audit).Delete
+		{"/users/{userID}/comments", "GET", true}, // Group + Use inside nested Route
+		{"/admin/stats", "GET", true},             // Route inside Group + Use
+		{"/v1/status", "GET", true},               // r.With(requireAuth).Route
+		{"/version", "GET", true},                 // r.With(requireAuth).Group
+		{"/orders/", "GET", true},                 // Route with a named func
+		{"/accounts/", "GET", false},              // Route with a local func literal
+		{"/ready", "GET", false},                  // handler held in a local variable
+	} {
+		item, ok := out.Paths[w.path]
+		if !ok {
+			t.Errorf("path %q missing; have %v", w.path, mapPathKeys(out.Paths))
+			continue
+		}
+		op := opFor(item, w.method)
+		if op == nil {
+			t.Errorf("%s %s: expected operation, missing", w.method, w.path)
+			continue
+		}
+		if got := hasSecurityScheme(op.Security, "bearerAuth"); got != w.protected {
+			t.Errorf("%s %s: bearerAuth = %v, want %v (security %v)", w.method, w.path, got, w.protected, op.Security)
+		}
+	}
+
+	// A handler held in a local variable is expanded: its body supplies
+	// the response.
+	if item, ok := out.Paths["/ready"]; ok {
+		if get := opFor(item, "GET"); get != nil {
+			body := get.Responses["default"].Content["application/json"].Schema
+			if body == nil || !strings.HasSuffix(body.Ref, "_Order") {
+				t.Errorf("GET /ready: expected the Order response from the closure body, got %+v", body)
+			}
+		}
+	}
+}
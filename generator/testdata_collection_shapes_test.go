@@ -0,0 +1,96 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	intspec "github.com/ehabterra/apispec/internal/spec"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_CollectionShapes covers maps and slices of structs nested in
+// each other, as struct fields and as bodies: every level is an
+// additionalProperties or items schema, and the chain ends in a $ref to the
+// struct's component. Deeper shapes ([][]Item, map[string]*Item,
+// map[string][]Item) used to end in $refs to components never generated
+// (pkg.[]Item), or in a bare {type: object}.
+func TestTestdata_CollectionShapes(t *testing.T) {
+	out := loadTestdata(t, "collection_shapes", spec.DefaultHTTPConfig())
+	noDanglingRefs(t, out)
+
+	// Item is the only struct component: no wrapper ([]Item) is emitted as
+	// one, and the int-backed Shelf key type is inlined.
+	if got := slices.Sorted(maps.Keys(out.Components.Schemas)); len(got) != 2 {
+		t.Errorf("components = %v, want Inventory and Item", got)
+	}
+
+	inventory := componentByName(out, "_Inventory")
+	if inventory == nil {
+		t.Fatalf("no Inventory component: %v", slices.Sorted(maps.Keys(out.Components.Schemas)))
+	}
+	for prop, want := range map[string]string{
+		"bySku":    "map<Item>",
+		"pinned":   "map<Item>",
+		"batches":  "array<map<Item>>",
+		"grid":     "array<array<Item>>",
+		"bins":     "array<array<Item>>",
+		"groups":   "map<array<Item>>",
+		"zones":    "map<map<Item>>",
+		"byShelf":  "map<Item>",
+		"byRow":    "map<array<Item>>",
+		"pallets":  "array<array<Item>>",
+		"labels":   "array<array<string>>",
+		"restocks": "map<array<Item>>",
+	} {
+		if got := shapeChain(inventory.Properties[prop]); got != want {
+			t.Errorf("%s = %s, want %s", prop, got, want)
+		}
+	}
+
+	for path, want := range map[string]string{
+		"/inventory/grid":    "array<array<Item>>",
+		"/inventory/pinned":  "map<Item>",
+		"/inventory/shelves": "map<array<Item>>",
+		"/inventory/batches": "array<map<Item>>",
+	} {
+		schema := firstResponseSchemaAtStatus(t, out, path, "default")
+		if path == "/inventory/batches" {
+			schema = firstRequestSchema(t, out, path)
+		}
+		if got := shapeChain(schema); got != want {
+			t.Errorf("%s body = %s, want %s", path, got, want)
+		}
+	}
+}
+
+// shapeChain renders a schema's array/map nesting down to the component its
+// innermost $ref names (array<map<Item>>), or the innermost type.
+func shapeChain(s *intspec.Schema) string {
+	switch {
+	case s == nil:
+		return "<nil>"
+	case s.Ref != "":
+		return s.Ref[strings.LastIndex(s.Ref, "_")+1:]
+	case s.Type == "array":
+		return "array<" + shapeChain(s.Items) + ">"
+	case s.Type == "object" && s.AdditionalProperties != nil:
+		return "map<" + shapeChain(s.AdditionalProperties) + ">"
+	}
+	return s.Type
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_ConditionalRequests covers precondition headers: a GET reading
+// If-None-Match or If-Modified-Since gains 304 Not Modified carrying the ETag /
+// Last-Modified it sets, a write reading If-Match, If-Unmodified-Since or
+// If-None-Match gains 412 Precondition Failed, the headers stay optional and
+// are described, and a handler writing the status itself keeps its response.
+func TestTestdata_ConditionalRequests(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "conditional_requests", spec.DefaultHTTPConfig())
+	noDanglingRefs(t, out)
+
+	cases := []struct {
+		path, method, header string
+		want, absent         string
+	}{
+		{"/articles", "GET", "If-Modified-Since", "304", "412"},
+		{"/articles/{id}", "GET", "If-None-Match", "304", "412"},
+		{"/articles/{id}", "PUT", "If-Match", "412", "304"},
+		{"/articles/{id}", "DELETE", "If-Unmodified-Since", "412", "304"},
+		{"/drafts/{id}", "PUT", "If-None-Match", "412", "304"},
+	}
+	for _, c := range cases {
+		op := opFor(out.Paths[c.path], c.method)
+		if op == nil {
+			t.Fatalf("%s %s missing; paths %v", c.method, c.path, mapPathKeys(out.Paths))
+		}
+		param, ok := paramsByName(t, op, c.method+" "+c.path)[c.header]
+		if !ok || param.In != "header" {
+			t.Errorf("%s %s: header parameter %s missing", c.method, c.path, c.header)
+		} else if param.Required || param.Description == "" {
+			t.Errorf("%s %s: %s = %+v, want an optional, described header", c.method, c.path, c.header, param)
+		}
+		if _, ok := op.Responses[c.want]; !ok {
+			t.Errorf("%s %s: responses %v, want %s", c.method, c.path, keysOf(op.Responses), c.want)
+		}
+		if _, ok := op.Responses[c.absent]; ok {
+			t.Errorf("%s %s: unexpected %s response", c.method, c.path, c.absent)
+		}
+	}
+
+	if _, ok := out.Paths["/articles"].Get.Responses["304"].Headers["Last-Modified"]; !ok {
+		t.Errorf("GET /articles 304 should repeat Last-Modified")
+	}
+	if _, ok := out.Paths["/articles/{id}"].Get.Responses["304"].Headers["ETag"]; !ok {
+		t.Errorf("GET /articles/{id} 304 should repeat ETag")
+	}
+	if got := out.Paths["/articles/{id}"].Put.Responses["412"]; len(got.Content) == 0 {
+		t.Errorf("the 412 the handler writes should keep its body, got %+v", got)
+	}
+	if _, ok := out.Paths["/health"].Get.Responses["304"]; ok {
+		t.Errorf("a handler reading no precondition header must not gain 304")
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_ContentNegotiation covers handlers that pick the format per
+// Accept: getUser renders the same 200 with c.JSON, c.XML or c.YAML, and the
+// net/http report handler encodes it with json.NewEncoder or xml.NewEncoder.
+// Each media type is listed on the one response, with the body's schema;
+// the 404 written with c.JSON alone stays JSON only.
+func TestTestdata_ContentNegotiation(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "content_negotiation", spec.DefaultGinConfig())
+	noDanglingRefs(t, out)
+
+	cases := []struct {
+		path, status string
+		want         []string
+	}{
+		{"/users/{id}", "200", []string{"application/json", "application/xml", "application/yaml"}},
+		{"/users/{id}", "404", []string{"application/json"}},
+		{"/report", "200", []string{"application/json", "application/xml"}},
+	}
+	for _, tc := range cases {
+		item, ok := out.Paths[tc.path]
+		if !ok {
+			t.Fatalf("path %s missing; have %v", tc.path, mapPathKeys(out.Paths))
+		}
+		op := opFor(item, "GET")
+		if op == nil {
+			t.Fatalf("GET %s missing", tc.path)
+		}
+		resp, ok := op.Responses[tc.status]
+		if !ok {
+			t.Errorf("GET %s: status %s missing; have %v", tc.path, tc.status, keysOf(op.Responses))
+			continue
+		}
+		if len(resp.Content) != len(tc.want) {
+			t.Errorf("GET %s %s: content = %v, want %v", tc.path, tc.status, keysOf(resp.Content), tc.want)
+		}
+		for _, ct := range tc.want {
+			if mt, ok := resp.Content[ct]; !ok || mt.Schema == nil || mt.Schema.Ref == "" {
+				t.Errorf("GET %s %s: %s = %+v, want the body's schema", tc.path, tc.status, ct, mt.Schema)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"testing"
+
+	intspec "github.com/ehabterra/apispec/internal/spec"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_ContextValues covers the contextValues config: handlers
+// reading the User that authenticate stored under userKey — through a helper
+// (/me) or directly (/users/{userID}/orders) — get the bearer requirement, and
+// the one whose path has {userID} the documented parameter; /health, which
+// reads nothing, stays as it was.
+func TestTestdata_ContextValues(t *testing.T) {
+	cfg := spec.DefaultChiConfig()
+	cfg.SecuritySchemes = map[string]spec.SecurityScheme{"bearerAuth": {Type: "http", Scheme: "bearer"}}
+	cfg.ContextValues = []spec.ContextValue{{
+		Key:      "userKey",
+		Security: []spec.SecurityRequirement{{"bearerAuth": {}}},
+		Parameters: []intspec.Parameter{{
+			Name: "userID", In: "path", Required: true,
+			Description: "The signed-in user",
+			Schema:      &spec.Schema{Type: "integer"},
+		}},
+	}}
+	out := loadTestdataWithFixtureConfig(t, "context_values", cfg)
+	if issues := spec.ValidateSpec(out); len(issues) > 0 {
+		t.Errorf("spec issues: %v", issues)
+	}
+
+	get := func(path string) *intspec.Operation {
+		t.Helper()
+		item, ok := out.Paths[path]
+		if !ok || item.Get == nil {
+			t.Fatalf("GET %s missing; have %v", path, mapPathKeys(out.Paths))
+		}
+		return item.Get
+	}
+	bearer := func(op *intspec.Operation) bool {
+		if op.Security == nil || len(*op.Security) != 1 {
+			return false
+		}
+		_, ok := (*op.Security)[0]["bearerAuth"]
+		return ok
+	}
+
+	me := get("/me")
+	if !bearer(me) {
+		t.Errorf("GET /me security = %v, want bearerAuth (read through currentUser)", me.Security)
+	}
+	if len(me.Parameters) != 0 {
+		t.Errorf("GET /me parameters = %+v, want none (no {userID} in the path)", me.Parameters)
+	}
+
+	orders := get("/users/{userID}/orders")
+	if !bearer(orders) {
+		t.Errorf("GET /users/{userID}/orders security = %v, want bearerAuth", orders.Security)
+	}
+	var userID *intspec.Parameter
+	for i, p := range orders.Parameters {
+		if p.Name == "userID" && p.In == "path" {
+			if userID != nil {
+				t.Errorf("userID declared twice: %+v", orders.Parameters)
+			}
+			userID = &orders.Parameters[i]
+		}
+	}
+	if userID == nil || userID.Description != "The signed-in user" || userID.Schema == nil || userID.Schema.Type != "integer" {
+		t.Errorf("userID parameter = %+v, want the configured one", userID)
+	}
+
+	if health := get("/health"); health.Security != nil {
+		t.Errorf("GET /health security = %v, want none (reads no context value)", *health.Security)
+	}
+}
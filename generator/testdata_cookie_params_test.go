@@ -0,0 +1,67 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_CookieParamsHTTP covers r.Cookie reads with a literal and a
+// constant name, and http.SetCookie and a raw w.Header().Add("Set-Cookie").
+func TestTestdata_CookieParamsHTTP(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "cookie_params_http", spec.DefaultHTTPConfig())
+	noDanglingRefs(t, out)
+	paramsAndResponseHeaders(t, opFor(out.Paths["/login"], "POST"), "POST /login",
+		"cookie", nil, []string{"Set-Cookie"})
+	paramsAndResponseHeaders(t, opFor(out.Paths["/me"], "GET"), "GET /me",
+		"cookie", []string{"session", "theme"}, nil)
+	paramsAndResponseHeaders(t, opFor(out.Paths["/logout"], "POST"), "POST /logout",
+		"cookie", []string{"session"}, []string{"Set-Cookie"})
+}
+
+// TestTestdata_CookieParamsGin covers c.Cookie and c.SetCookie.
+func TestTestdata_CookieParamsGin(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "cookie_params_gin", spec.DefaultGinConfig())
+	noDanglingRefs(t, out)
+	paramsAndResponseHeaders(t, opFor(out.Paths["/login"], "POST"), "POST /login",
+		"cookie", nil, []string{"Set-Cookie"})
+	paramsAndResponseHeaders(t, opFor(out.Paths["/me"], "GET"), "GET /me",
+		"cookie", []string{"session"}, nil)
+}
+
+// TestTestdata_CookieParamsEcho covers c.Cookie and c.SetCookie.
+func TestTestdata_CookieParamsEcho(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "cookie_params_echo", spec.DefaultEchoConfig())
+	noDanglingRefs(t, out)
+	paramsAndResponseHeaders(t, opFor(out.Paths["/login"], "POST"), "POST /login",
+		"cookie", nil, []string{"Set-Cookie"})
+	paramsAndResponseHeaders(t, opFor(out.Paths["/me"], "GET"), "GET /me",
+		"cookie", []string{"session"}, nil)
+}
+
+// TestTestdata_CookieParamsFiber covers c.Cookies reads, and c.Cookie and
+// c.ClearCookie writes.
+func TestTestdata_CookieParamsFiber(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "cookie_params_fiber", spec.DefaultFiberConfig())
+	noDanglingRefs(t, out)
+	for _, path := range []string{"/login", "/logout"} {
+		paramsAndResponseHeaders(t, opFor(out.Paths[path], "POST"), "POST "+path,
+			"cookie", nil, []string{"Set-Cookie"})
+	}
+	paramsAndResponseHeaders(t, opFor(out.Paths["/me"], "GET"), "GET /me",
+		"cookie", []string{"session"}, nil)
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/engine"
+)
+
+// TestTestdata_CustomRoutePatterns extracts routes registered through a
+// homegrown ServeMux wrapper, declared only by the fixture's top-level
+// routePatterns: a ServeMux-style "GET /users/{id}" pattern, verbs fixed by
+// the method name, and a verb passed as its own argument. The config file has
+// no framework section, so the net/http defaults still read the handlers, and
+// the wrapper's inner mux.HandleFunc must not surface as a route of its own.
+func TestTestdata_CustomRoutePatterns(t *testing.T) {
+	dir := filepath.Join("..", "testdata", "custom_route_patterns")
+	for _, lazy := range []bool{true, false} {
+		ec := engine.DefaultEngineConfig()
+		ec.InputDir = dir
+		ec.ConfigFile = filepath.Join(dir, "apispec.yaml")
+		ec.UseLazyTracker = lazy
+
+		out, err := engine.NewEngine(ec).GenerateOpenAPI()
+		if err != nil {
+			t.Fatalf("GenerateOpenAPI(lazy=%v): %v", lazy, err)
+		}
+		noDanglingRefs(t, out)
+
+		want := map[string][]string{
+			"/users":      {"GET", "POST"},
+			"/users/{id}": {"DELETE", "GET"},
+		}
+		paths := mapPathKeys(out.Paths)
+		slices.Sort(paths)
+		if !slices.Equal(paths, []string{"/users", "/users/{id}"}) {
+			t.Fatalf("lazy=%v: paths = %v", lazy, paths)
+		}
+		for path, methods := range want {
+			var got []string
+			for _, m := range out.Paths[path].Operations() {
+				got = append(got, m.Method)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, methods) {
+				t.Errorf("lazy=%v: %s methods = %v, want %v", lazy, path, got, methods)
+			}
+		}
+
+		if op := opFor(out.Paths["/users"], "POST"); op == nil || op.RequestBody == nil {
+			t.Errorf("lazy=%v: POST /users has no request body", lazy)
+		}
+		if op := opFor(out.Paths["/users/{id}"], "GET"); op == nil || len(op.Parameters) != 1 || op.Parameters[0].Name != "id" {
+			t.Errorf("lazy=%v: GET /users/{id} lacks its id path parameter", lazy)
+		}
+	}
+}
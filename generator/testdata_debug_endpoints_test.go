@@ -0,0 +1,60 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_DebugEndpoints covers pprof and expvar handlers: left out of
+// the spec by default, and documented under the internal tag, and only that
+// tag, with IncludeDebugEndpoints.
+func TestTestdata_DebugEndpoints(t *testing.T) {
+	debugPaths := []string{"/debug/pprof/", "/debug/pprof/heap", "/debug/pprof/profile", "/debug/pprof/trace", "/debug/vars"}
+
+	t.Run("left out by default", func(t *testing.T) {
+		out := loadTestdataWithFixtureConfig(t, "debug_endpoints", spec.DefaultHTTPConfig())
+		if got := mapPathKeys(out.Paths); !slices.Equal(got, []string{"/health"}) {
+			t.Errorf("paths = %v, want only /health", got)
+		}
+		if slices.ContainsFunc(out.Tags, func(tag spec.Tag) bool { return tag.Name == "internal" }) {
+			t.Errorf("internal tag defined with no debug endpoint documented: %+v", out.Tags)
+		}
+	})
+
+	t.Run("documented under internal", func(t *testing.T) {
+		cfg := spec.DefaultHTTPConfig()
+		cfg.IncludeDebugEndpoints = true
+		out := loadTestdataWithFixtureConfig(t, "debug_endpoints", cfg)
+		if err := spec.ValidateSpec(out); err != nil {
+			t.Fatalf("ValidateSpec: %v", err)
+		}
+		if got, want := slices.Sorted(slices.Values(mapPathKeys(out.Paths))), append(slices.Clone(debugPaths), "/health"); !slices.Equal(got, want) {
+			t.Fatalf("paths = %v, want %v", got, want)
+		}
+		for _, path := range debugPaths {
+			item := out.Paths[path]
+			if op := firstOperation(&item); op == nil || !slices.Equal(op.Tags, []string{"internal"}) {
+				t.Errorf("%s: operation %+v, want tags [internal]", path, op)
+			}
+		}
+		if !slices.ContainsFunc(out.Tags, func(tag spec.Tag) bool { return tag.Name == "internal" && tag.Description != "" }) {
+			t.Errorf("tags = %+v, want a described internal tag", out.Tags)
+		}
+	})
+}
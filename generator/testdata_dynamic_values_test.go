@@ -0,0 +1,113 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/engine"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_DynamicValues covers the fixture's apispec.yaml: every any,
+// interface{} and json.RawMessage value refers to the AnyValue placeholder
+// and carries its Go type.
+func TestTestdata_DynamicValues(t *testing.T) {
+	out := generateDynamicValues(t, nil)
+	event := dynamicValuesEvent(t, out)
+
+	if out.Components.Schemas["AnyValue"] == nil {
+		t.Fatalf("no AnyValue component: %v", slices.Sorted(maps.Keys(out.Components.Schemas)))
+	}
+	for name, goType := range map[string]string{
+		"payload":  "json.RawMessage",
+		"previous": "json.RawMessage",
+		"context":  "any",
+		"source":   "interface{}",
+	} {
+		prop := event.Properties[name]
+		if prop == nil || prop.Ref != "#/components/schemas/AnyValue" || prop.Extensions["x-go-type"] != goType {
+			t.Errorf("%s = %+v, want a $ref to AnyValue with x-go-type %s", name, prop, goType)
+		}
+	}
+	for name, container := range map[string]func(*spec.Schema) *spec.Schema{
+		"labels": func(s *spec.Schema) *spec.Schema { return s.AdditionalProperties },
+		"parts":  func(s *spec.Schema) *spec.Schema { return s.AdditionalProperties },
+		"trace":  func(s *spec.Schema) *spec.Schema { return s.Items },
+	} {
+		prop := event.Properties[name]
+		if prop == nil || container(prop) == nil || container(prop).Ref != "#/components/schemas/AnyValue" {
+			t.Errorf("%s = %+v, want its values to refer to AnyValue", name, prop)
+		}
+	}
+}
+
+// TestTestdata_DynamicValues_Styles overrides the fixture's style from the
+// command line: by default a dynamic value is a free-form object, and
+// --dynamic-values string makes it a string.
+func TestTestdata_DynamicValues_Styles(t *testing.T) {
+	for style, want := range map[string]string{"object": "object", "string": "string"} {
+		out := generateDynamicValues(t, func(ec *engine.EngineConfig) {
+			ec.ConfigFile = ""
+			ec.DynamicValues = style
+		})
+		if out.Components.Schemas["AnyValue"] != nil {
+			t.Errorf("%s: unexpected AnyValue component", style)
+		}
+		event := dynamicValuesEvent(t, out)
+		for _, name := range []string{"payload", "context", "source"} {
+			if prop := event.Properties[name]; prop == nil || prop.Type != want || prop.Extensions["x-go-type"] != nil {
+				t.Errorf("%s: %s = %+v, want an unannotated %s", style, name, prop, want)
+			}
+		}
+		if labels := event.Properties["labels"]; labels == nil || labels.AdditionalProperties == nil || labels.AdditionalProperties.Type != want {
+			t.Errorf("%s: labels = %+v, want %s values", style, labels, want)
+		}
+	}
+}
+
+// generateDynamicValues generates the dynamic_values fixture's spec with
+// its apispec.yaml and the engine config adjusted by configure.
+func generateDynamicValues(t *testing.T, configure func(*engine.EngineConfig)) *spec.OpenAPISpec {
+	t.Helper()
+	dir := filepath.Join("..", "testdata", "dynamic_values")
+	ec := engine.DefaultEngineConfig()
+	ec.InputDir = dir
+	ec.ConfigFile = filepath.Join(dir, "apispec.yaml")
+	if configure != nil {
+		configure(ec)
+	}
+	out, err := engine.NewEngine(ec).GenerateOpenAPI()
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI: %v", err)
+	}
+	if issues := spec.ValidateSpec(out); len(issues) > 0 {
+		t.Errorf("spec issues: %v", issues)
+	}
+	noDanglingRefs(t, out)
+	return out
+}
+
+func dynamicValuesEvent(t *testing.T, out *spec.OpenAPISpec) *spec.Schema {
+	t.Helper()
+	event := out.Components.Schemas["github_com_ehabterra_apispec_testdata_dynamic_values_Event"]
+	if event == nil {
+		t.Fatalf("no Event component: %v", slices.Sorted(maps.Keys(out.Components.Schemas)))
+	}
+	return event
+}
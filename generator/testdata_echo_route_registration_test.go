@@ -0,0 +1,102 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_EchoRouteRegistration locks in echo's registration surface
+// beyond the verb helpers: e.Add / g.Add (verb from the first argument, a
+// literal or an http.Method* constant), e.Match / g.Match (an operation per
+// listed verb), e.RouteNotFound, and handlers adapted by echo.WrapHandler —
+// an http.Handler value or an http.HandlerFunc conversion — which document
+// the adapted handler rather than the adapter.
+func TestTestdata_EchoRouteRegistration(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "echo_route_registration", spec.DefaultEchoConfig())
+	noDanglingRefs(t, out)
+
+	want := map[string][]string{
+		"/items":      {"GET"},                    // e.Add(http.MethodGet, ...)
+		"/items/{id}": {"DELETE", "PUT", "PATCH"}, // e.Add("DELETE", ...) + e.Match(PUT, PATCH)
+		"/api/items":  {"POST"},                   // g.Add on a group
+		"/api/status": {"GET", "HEAD"},            // g.Match on a group
+		"/*":          {"GET"},                    // e.RouteNotFound
+		"/metrics":    {"GET"},                    // echo.WrapHandler(http.Handler value)
+		"/legacy":     {"POST"},                   // echo.WrapHandler(http.HandlerFunc(fn))
+	}
+	for path, methods := range want {
+		item, ok := out.Paths[path]
+		if !ok {
+			t.Errorf("path %q missing; have %v", path, mapPathKeys(out.Paths))
+			continue
+		}
+		for _, m := range methods {
+			if opFor(item, m) == nil {
+				t.Errorf("%s %s: expected operation, missing", m, path)
+			}
+		}
+	}
+
+	// The verbs of a Match share the handler, so their operationIds carry
+	// the verb to stay unique; the update body is on both.
+	if item, ok := out.Paths["/items/{id}"]; ok {
+		put, patch := opFor(item, "PUT"), opFor(item, "PATCH")
+		if put != nil && patch != nil {
+			if put.OperationID == patch.OperationID {
+				t.Errorf("PUT and PATCH /items/{id} share operationId %q", put.OperationID)
+			}
+			if put.RequestBody == nil || patch.RequestBody == nil {
+				t.Errorf("PUT and PATCH /items/{id}: expected the bound Item body on both")
+			}
+		}
+		if opFor(item, "POST") != nil {
+			t.Errorf("/items/{id} should not carry a POST operation")
+		}
+	}
+
+	// An adapted handler names the route and supplies its body.
+	if item, ok := out.Paths["/legacy"]; ok {
+		if post := opFor(item, "POST"); post != nil {
+			if strings.Contains(post.OperationID, "WrapHandler") || !strings.HasSuffix(post.OperationID, ".legacyEcho") {
+				t.Errorf("POST /legacy operationId = %q, want the adapted legacyEcho", post.OperationID)
+			}
+			if post.RequestBody == nil {
+				t.Errorf("POST /legacy: expected legacyEcho's decoded body")
+			}
+		}
+	}
+	if item, ok := out.Paths["/metrics"]; ok {
+		if get := opFor(item, "GET"); get != nil {
+			if get.Summary != "ServeHTTP writes the metrics." {
+				t.Errorf("GET /metrics summary = %q, want MetricsHandler.ServeHTTP's doc", get.Summary)
+			}
+			if _, ok := get.Responses["200"]; !ok {
+				t.Errorf("GET /metrics: expected the 200 response from ServeHTTP's body")
+			}
+		}
+	}
+
+	if item, ok := out.Paths["/*"]; ok {
+		if get := opFor(item, "GET"); get != nil {
+			if _, ok := get.Responses["404"]; !ok {
+				t.Errorf("GET /*: expected notFound's 404 response")
+			}
+		}
+	}
+}
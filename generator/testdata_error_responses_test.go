@@ -0,0 +1,71 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/engine"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_ErrorResponses covers the errorResponses config of the
+// fixture's apispec.yaml: echo.NewHTTPError documents its status argument
+// with apierr.Message, apierr.Reject its status argument with apierr.Problem,
+// and apierr.Write, whose status comes from the error, a default response
+// with apierr.Problem — bodies the helpers build from untyped maps.
+func TestTestdata_ErrorResponses(t *testing.T) {
+	dir := filepath.Join("..", "testdata", "error_responses")
+	ec := engine.DefaultEngineConfig()
+	ec.InputDir = dir
+	ec.ConfigFile = filepath.Join(dir, "apispec.yaml")
+	out, err := engine.NewEngine(ec).GenerateOpenAPI()
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI: %v", err)
+	}
+	noDanglingRefs(t, out)
+	if issues := spec.ValidateSpec(out); len(issues) > 0 {
+		t.Errorf("spec issues: %v", issues)
+	}
+
+	for _, tc := range []struct {
+		path, method, status, contentType, schema string
+	}{
+		{"/orders", "POST", "400", "application/json", "_apierr_Message"},
+		{"/orders", "POST", "422", "application/problem+json", "_apierr_Problem"},
+		{"/orders/{id}", "DELETE", "404", "application/json", "_apierr_Message"},
+		{"/orders/{id}", "GET", "default", "application/problem+json", "_apierr_Problem"},
+	} {
+		item, ok := out.Paths[tc.path]
+		if !ok {
+			t.Fatalf("path %q missing; have %v", tc.path, mapPathKeys(out.Paths))
+		}
+		o := opFor(item, tc.method)
+		if o == nil {
+			t.Fatalf("%s %s missing", tc.method, tc.path)
+		}
+		resp, ok := o.Responses[tc.status]
+		if !ok {
+			t.Errorf("%s %s: no %s response", tc.method, tc.path, tc.status)
+			continue
+		}
+		s := resp.Content[tc.contentType].Schema
+		if s == nil || !strings.HasSuffix(s.Ref, tc.schema) {
+			t.Errorf("%s %s %s %s = %+v, want a %s ref", tc.method, tc.path, tc.status, tc.contentType, s, tc.schema)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// TestTestdata_Extensions covers the extensions config: vendor extensions
+// land on the document, the path items and operations matched by glob, and
+// the schemas matched by Go type name, inline in both YAML and JSON.
+func TestTestdata_Extensions(t *testing.T) {
+	cfg := spec.DefaultHTTPConfig()
+	cfg.Extensions = spec.ExtensionsConfig{
+		Document: map[string]any{"x-owner": "orders-team"},
+		Paths: []spec.PathExtensions{
+			{Path: "/orders/*", Extensions: map[string]any{"x-cache": "private"}},
+		},
+		Operations: []spec.OperationExtensions{
+			{Route: "/orders/**", Extensions: map[string]any{"x-gateway-route-id": "orders"}},
+			{Route: "POST /orders", Extensions: map[string]any{"x-gateway-route-id": "orders-write", "x-rate-limit": 10}},
+		},
+		Schemas: []spec.SchemaExtensions{
+			{Type: "*Request", Extensions: map[string]any{"x-input": true}},
+		},
+	}
+	out := loadTestdataWithFixtureConfig(t, "extensions", cfg)
+	if err := spec.ValidateSpec(out); err != nil {
+		t.Fatalf("ValidateSpec: %v", err)
+	}
+
+	if got := out.Extensions["x-owner"]; got != "orders-team" {
+		t.Errorf("document x-owner = %v, want orders-team", got)
+	}
+	if got := out.Paths["/orders/{id}"].Extensions["x-cache"]; got != "private" {
+		t.Errorf("/orders/{id} x-cache = %v, want private", got)
+	}
+	if got := out.Paths["/orders"].Extensions["x-cache"]; got != nil {
+		t.Errorf("/orders x-cache = %v, want none: the glob needs a segment after /orders/", got)
+	}
+
+	for _, tc := range []struct {
+		method, path string
+		want         any
+	}{
+		{"GET", "/orders", "orders"},
+		{"GET", "/orders/{id}", "orders"},
+		{"POST", "/orders", "orders-write"}, // the later entry wins
+	} {
+		op := out.Paths[tc.path].Operation(tc.method)
+		if op == nil {
+			t.Fatalf("missing %s %s", tc.method, tc.path)
+		}
+		if got := op.Extensions["x-gateway-route-id"]; got != tc.want {
+			t.Errorf("%s %s x-gateway-route-id = %v, want %v", tc.method, tc.path, got, tc.want)
+		}
+	}
+	if got := out.Paths["/orders"].Operation("GET").Extensions["x-rate-limit"]; got != nil {
+		t.Errorf("GET /orders x-rate-limit = %v, want none", got)
+	}
+
+	for name, schema := range out.Components.Schemas {
+		want := strings.HasSuffix(name, "_CreateOrderRequest")
+		if got := schema.Extensions["x-input"] == true; got != want {
+			t.Errorf("schema %s x-input set = %v, want %v", name, got, want)
+		}
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["x-owner"] != "orders-team" {
+		t.Errorf("JSON document x-owner = %v, want orders-team", doc["x-owner"])
+	}
+	item := doc["paths"].(map[string]any)["/orders/{id}"].(map[string]any)
+	if item["x-cache"] != "private" {
+		t.Errorf("JSON path item x-cache = %v, want private", item["x-cache"])
+	}
+
+	yml, err := yaml.Marshal(out)
+	if err != nil {
+		t.Fatalf("yaml.Marshal: %v", err)
+	}
+	if !strings.Contains(string(yml), "\nx-owner: orders-team\n") {
+		t.Errorf("YAML document missing inline x-owner:\n%s", yml)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"testing"
+
+	intspec "github.com/ehabterra/apispec/internal/spec"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_FileDownload covers file responses: http.ServeContent and a
+// `Content-Disposition: attachment` header (followed by a Write or an
+// io.Copy) give a binary application/octet-stream body documenting the
+// Content-Disposition header, while an inline disposition and a JSON handler
+// keep their JSON bodies.
+func TestTestdata_FileDownload(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "file_download", spec.DefaultHTTPConfig())
+	noDanglingRefs(t, out)
+
+	responseOf := func(path, status string) intspec.Response {
+		t.Helper()
+		op := opFor(out.Paths[path], "GET")
+		if op == nil {
+			t.Fatalf("GET %s missing; paths %v", path, mapPathKeys(out.Paths))
+		}
+		resp, ok := op.Responses[status]
+		if !ok {
+			t.Fatalf("GET %s: responses %v, want %s", path, keysOf(op.Responses), status)
+		}
+		return resp
+	}
+
+	for _, c := range []struct{ path, status string }{
+		{"/files/{name}", "200"},
+		{"/reports/export", "200"},
+		{"/reports/archive", "default"},
+	} {
+		resp := responseOf(c.path, c.status)
+		media, ok := resp.Content["application/octet-stream"]
+		if !ok || len(resp.Content) != 1 {
+			t.Errorf("GET %s %s content = %v, want only application/octet-stream", c.path, c.status, keysOf(resp.Content))
+		} else if media.Schema == nil || media.Schema.Type != "string" || media.Schema.Format != "binary" {
+			t.Errorf("GET %s %s schema = %+v, want a binary string", c.path, c.status, media.Schema)
+		}
+		if _, ok := resp.Headers["Content-Disposition"]; !ok {
+			t.Errorf("GET %s %s should document Content-Disposition, got %v", c.path, c.status, resp.Headers)
+		}
+	}
+	if _, ok := responseOf("/files/{name}", "404").Content["application/octet-stream"]; ok {
+		t.Errorf("the 404 of a download is not the file")
+	}
+
+	for _, path := range []string{"/reports/preview", "/reports"} {
+		resp := responseOf(path, "default")
+		if _, ok := resp.Content["application/json"]; !ok {
+			t.Errorf("GET %s content = %v, want JSON kept", path, keysOf(resp.Content))
+		}
+	}
+}
+
+// TestTestdata_RangeRequests covers byte ranges: http.ServeContent and a
+// handler reading the Range header document an optional Range parameter, a
+// 206 Partial Content response with the success body and Content-Range, and
+// Accept-Ranges; a download without range support gains none of them.
+func TestTestdata_RangeRequests(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "file_download", spec.DefaultHTTPConfig())
+
+	for _, c := range []struct{ path, media string }{
+		{"/files/{name}", "application/octet-stream"},
+		{"/clips/{id}", "application/json"},
+	} {
+		op := opFor(out.Paths[c.path], "GET")
+		if op == nil {
+			t.Fatalf("GET %s missing; paths %v", c.path, mapPathKeys(out.Paths))
+		}
+		param, ok := paramsByName(t, op, "GET "+c.path)["Range"]
+		if !ok || param.In != "header" || param.Required || param.Description == "" {
+			t.Errorf("GET %s Range parameter = %+v, want an optional, described header", c.path, param)
+		}
+		partial, ok := op.Responses["206"]
+		if !ok {
+			t.Fatalf("GET %s: responses %v, want 206", c.path, keysOf(op.Responses))
+		}
+		if _, ok := partial.Content[c.media]; !ok {
+			t.Errorf("GET %s 206 content = %v, want %s", c.path, keysOf(partial.Content), c.media)
+		}
+		for _, h := range []string{"Content-Range", "Accept-Ranges"} {
+			if _, ok := partial.Headers[h]; !ok {
+				t.Errorf("GET %s 206 should document %s, got %v", c.path, h, keysOf(partial.Headers))
+			}
+		}
+	}
+
+	export := opFor(out.Paths["/reports/export"], "GET")
+	if _, ok := export.Responses["206"]; ok {
+		t.Errorf("GET /reports/export does not serve ranges")
+	}
+	if _, ok := paramsByName(t, export, "GET /reports/export")["Range"]; ok {
+		t.Errorf("GET /reports/export should not read Range")
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"testing"
+
+	intspec "github.com/ehabterra/apispec/internal/spec"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// multipartSchema returns op's multipart/form-data body schema, failing the
+// test when there is none.
+func multipartSchema(t *testing.T, op *intspec.Operation, name string) *intspec.Schema {
+	t.Helper()
+	if op == nil {
+		t.Fatalf("%s: operation missing", name)
+	}
+	if op.RequestBody == nil {
+		t.Fatalf("%s: request body missing", name)
+	}
+	media, ok := op.RequestBody.Content["multipart/form-data"]
+	if !ok {
+		t.Fatalf("%s: want multipart/form-data, have %v", name, keysOf(op.RequestBody.Content))
+	}
+	if media.Schema == nil {
+		t.Fatalf("%s: multipart body has no schema", name)
+	}
+	return media.Schema
+}
+
+// TestTestdata_FileUploadHTTP covers net/http uploads: r.FormFile parts are
+// binary properties of a multipart body next to the r.FormValue fields,
+// r.ParseMultipartForm alone makes the body multipart, and a form without
+// either stays urlencoded.
+func TestTestdata_FileUploadHTTP(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "file_upload_http", spec.DefaultHTTPConfig())
+	noDanglingRefs(t, out)
+
+	avatars := multipartSchema(t, opFor(out.Paths["/avatars"], "POST"), "POST /avatars")
+	if p := avatars.Properties["avatar"]; p == nil || p.Type != "string" || p.Format != "binary" {
+		t.Errorf("avatar: got %+v, want {type: string, format: binary}", p)
+	}
+	if p := avatars.Properties["title"]; p == nil || p.Type != "string" {
+		t.Errorf("title: got %+v, want a string field", p)
+	}
+
+	docs := multipartSchema(t, opFor(out.Paths["/documents"], "POST"), "POST /documents")
+	if docs.Type != "object" {
+		t.Errorf("POST /documents: got %+v, want an object", docs)
+	}
+
+	rename := opFor(out.Paths["/rename"], "POST")
+	if rename == nil || rename.RequestBody == nil {
+		t.Fatal("POST /rename: request body missing")
+	}
+	if _, ok := rename.RequestBody.Content["application/x-www-form-urlencoded"]; !ok {
+		t.Errorf("POST /rename: plain form should stay urlencoded; have %v", keysOf(rename.RequestBody.Content))
+	}
+}
+
+// TestTestdata_FileUploadGin covers gin uploads: c.FormFile and a bound
+// struct with a *multipart.FileHeader field both yield multipart bodies, and
+// the file field maps to a binary string rather than a FileHeader component.
+func TestTestdata_FileUploadGin(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "file_upload_gin", spec.DefaultGinConfig())
+	noDanglingRefs(t, out)
+
+	photos := multipartSchema(t, opFor(out.Paths["/photos"], "POST"), "POST /photos")
+	if p := photos.Properties["photo"]; p == nil || p.Format != "binary" {
+		t.Errorf("photo: got %+v, want a binary string", p)
+	}
+
+	profile := multipartSchema(t, opFor(out.Paths["/profile"], "PUT"), "PUT /profile")
+	if profile.Ref == "" {
+		t.Errorf("PUT /profile: bound struct should keep its $ref; got %+v", profile)
+	}
+	form := schemaBySuffix(out.Components.Schemas, "_ProfileForm")
+	if form == nil {
+		t.Fatalf("ProfileForm schema missing; have %v", mapSchemaKeys(out.Components.Schemas))
+	}
+	if p := form.Properties["avatar"]; p == nil || p.Type != "string" || p.Format != "binary" {
+		t.Errorf("ProfileForm.avatar: got %+v, want {type: string, format: binary}", p)
+	}
+	if s := schemaBySuffix(out.Components.Schemas, "FileHeader"); s != nil {
+		t.Error("multipart.FileHeader must not become a component")
+	}
+}
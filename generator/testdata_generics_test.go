@@ -220,3 +220,94 @@ func TestTestdata_GenericStructs(t *testing.T) {
 		}
 	}
 }
+
+// TestTestdata_GenericStructs_SchemaAnnotations checks that components carry
+// their Go type name as title (type arguments included) and, with an ID base,
+// an $id that refs inside other components point at.
+func TestTestdata_GenericStructs_SchemaAnnotations(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "generic_structs", spec.DefaultHTTPConfig())
+	schemas := out.Components.Schemas
+	for suffix, title := range map[string]string{
+		"_structs_User":              "User",
+		"_structs_Page_User":         "Page[User]",
+		"_structs_Pair_User-Product": "Pair[User, Product]",
+	} {
+		s := schemaBySuffix(schemas, suffix)
+		if s == nil {
+			t.Fatalf("%s component missing; have %v", suffix, mapSchemaKeys(schemas))
+		}
+		if s.Title != title {
+			t.Errorf("%s title = %q, want %q", suffix, s.Title, title)
+		}
+		if s.ID != "" {
+			t.Errorf("%s has $id %q without a base", suffix, s.ID)
+		}
+	}
+
+	cfg := spec.DefaultHTTPConfig()
+	cfg.Schemas = spec.SchemaOptions{OmitTitles: true, IDBase: "https://example.com/schemas"}
+	out = loadTestdata(t, "generic_structs", cfg)
+	schemas = out.Components.Schemas
+	var userName string
+	for k := range schemas {
+		if strings.HasSuffix(k, "_structs_User") {
+			userName = k
+		}
+	}
+	user := schemas[userName]
+	if user == nil || user.Title != "" {
+		t.Fatalf("User = %+v, want a component without title", user)
+	}
+	userID := "https://example.com/schemas/" + userName + ".schema.json"
+	if user.ID != userID {
+		t.Errorf("User $id = %q, want %q", user.ID, userID)
+	}
+	page := schemaBySuffix(schemas, "_structs_Page_User")
+	if page == nil || page.Properties["items"] == nil || page.Properties["items"].Items == nil {
+		t.Fatalf("Page[User] items missing: %+v", page)
+	}
+	if got := page.Properties["items"].Items.Ref; got != userID {
+		t.Errorf("Page[User].items $ref = %q, want %q", got, userID)
+	}
+}
+
+// TestTestdata_GenericHandlerWrapper locks in the HandleRequest[TRequest,
+// TResponse] wrapper: each route instantiates it with its own types, so the
+// request body is that route's TRequest and the 200 envelope's data is its
+// TResponse — the wrapper encodes APIResponse[interface{}]{Data: response},
+// so the concrete type comes from the instantiation, not the envelope.
+func TestTestdata_GenericHandlerWrapper(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "generic", spec.DefaultHTTPConfig())
+	noDanglingRefs(t, out)
+
+	want := map[string]struct{ req, resp string }{
+		"/api/email/send": {"_SendEmailRequest", "_SendEmailResponse"},
+		"/api/users":      {"_CreateUserRequest", "_CreateUserResponse"},
+		"/api/users/list": {"", "_ListUsersResponse"},
+	}
+	for path, w := range want {
+		op := opFor(out.Paths[path], "POST")
+		if op == nil {
+			t.Errorf("POST %s: missing operation; have %v", path, mapPathKeys(out.Paths))
+			continue
+		}
+		if w.req != "" {
+			if op.RequestBody == nil || op.RequestBody.Content["application/json"].Schema == nil ||
+				!strings.HasSuffix(op.RequestBody.Content["application/json"].Schema.Ref, w.req) {
+				t.Errorf("POST %s: request body = %+v, want a $ref ending %s", path, op.RequestBody, w.req)
+			}
+		}
+		schema := op.Responses["200"].Content["application/json"].Schema
+		if schema == nil {
+			t.Errorf("POST %s: missing 200 body", path)
+			continue
+		}
+		if len(schema.AllOf) != 2 || !strings.HasSuffix(schema.AllOf[0].Ref, "_APIResponse_any") {
+			t.Errorf("POST %s: 200 = %+v, want the APIResponse envelope specialised", path, schema)
+			continue
+		}
+		if data := schema.AllOf[1].Properties["data"]; data == nil || !strings.HasSuffix(data.Ref, w.resp) {
+			t.Errorf("POST %s: 200 data = %+v, want a $ref ending %s", path, data, w.resp)
+		}
+	}
+}
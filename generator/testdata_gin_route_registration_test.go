@@ -0,0 +1,104 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_GinRouteRegistration locks in gin's registration surface
+// beyond the verb helpers: r.Handle / g.Handle (verb from the first
+// argument), r.Any (an operation per verb gin registers), r.Static and
+// r.StaticFS (a file server below the mount), and handlers adapted by
+// gin.WrapH / gin.WrapF, which document the adapted handler.
+func TestTestdata_GinRouteRegistration(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "gin_route_registration", spec.DefaultGinConfig())
+	noDanglingRefs(t, out)
+
+	want := map[string][]string{
+		"/orders":            {"GET"},  // r.Handle(http.MethodGet, ...)
+		"/api/orders":        {"POST"}, // g.Handle("POST", ...) on a group
+		"/ping":              {"GET", "POST", "PUT", "PATCH", "HEAD", "OPTIONS", "DELETE"},
+		"/version":           {"GET"}, // gin.WrapH(http.Handler value)
+		"/legacy/order":      {"GET"}, // gin.WrapF(fn)
+		"/assets/{filepath}": {"GET", "HEAD"},
+		"/static/{filepath}": {"GET", "HEAD"},
+	}
+	for path, methods := range want {
+		item, ok := out.Paths[path]
+		if !ok {
+			t.Errorf("path %q missing; have %v", path, mapPathKeys(out.Paths))
+			continue
+		}
+		for _, m := range methods {
+			if opFor(item, m) == nil {
+				t.Errorf("%s %s: expected operation, missing", m, path)
+			}
+		}
+	}
+
+	// The verbs of an Any share the handler, so their operationIds carry
+	// the verb to stay unique.
+	if item, ok := out.Paths["/ping"]; ok {
+		seen := map[string]string{}
+		for _, m := range want["/ping"] {
+			op := opFor(item, m)
+			if op == nil {
+				continue
+			}
+			if prev, dup := seen[op.OperationID]; dup {
+				t.Errorf("%s and %s /ping share operationId %q", prev, m, op.OperationID)
+			}
+			seen[op.OperationID] = m
+		}
+	}
+
+	// An adapted handler names the route and supplies its responses.
+	if item, ok := out.Paths["/legacy/order"]; ok {
+		if get := opFor(item, "GET"); get != nil {
+			if strings.Contains(get.OperationID, "WrapF") || !strings.HasSuffix(get.OperationID, ".legacyOrder") {
+				t.Errorf("GET /legacy/order operationId = %q, want the adapted legacyOrder", get.OperationID)
+			}
+		}
+	}
+	if item, ok := out.Paths["/version"]; ok {
+		if get := opFor(item, "GET"); get != nil {
+			if get.Summary != "ServeHTTP writes the build version." {
+				t.Errorf("GET /version summary = %q, want VersionHandler.ServeHTTP's doc", get.Summary)
+			}
+		}
+	}
+
+	// A file server answers with the file's bytes and honours Range.
+	if item, ok := out.Paths["/assets/{filepath}"]; ok {
+		if get := opFor(item, "GET"); get != nil {
+			ok200 := get.Responses["200"]
+			if _, ok := ok200.Content["application/octet-stream"]; !ok {
+				t.Errorf("GET /assets/{filepath}: expected an octet-stream 200, got %v", ok200.Content)
+			}
+			if _, ok := get.Responses["206"]; !ok {
+				t.Errorf("GET /assets/{filepath}: expected a 206 Partial Content response")
+			}
+			for _, p := range get.Parameters {
+				if p.In == "path" && p.Name == "filepath" && p.Extensions["x-warning"] != nil {
+					t.Errorf("GET /assets/{filepath}: filepath should not carry a warning")
+				}
+			}
+		}
+	}
+}
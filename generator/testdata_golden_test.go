@@ -0,0 +1,36 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/ehabterra/apispec/spectest"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the expected_openapi.yaml golden files under testdata")
+
+// TestTestdataGolden byte-compares every fixture's spec with its
+// expected_openapi.yaml. Unlike the structural tests it fails on any output
+// change, so a deliberate one is reviewed as a diff of the goldens:
+// regenerate them with -update (or `apispec test --update testdata`).
+func TestTestdataGolden(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping golden fixtures in -short mode")
+	}
+	spectest.GoldenTree(t, filepath.Join("..", "testdata"), spectest.GoldenOptions{Update: *updateGolden})
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_GroupTagsGin covers tags inferred from gin groups — the
+// innermost resource segment of nested groups, none for an api/version group
+// or the root router, @Tags winning over the group — and groupTags renaming
+// and describing a group by prefix.
+func TestTestdata_GroupTagsGin(t *testing.T) {
+	tagsOf := func(t *testing.T, out *spec.OpenAPISpec, path, method string) []string {
+		t.Helper()
+		op := opFor(out.Paths[path], method)
+		if op == nil {
+			t.Fatalf("%s %s missing; paths %v", method, path, mapPathKeys(out.Paths))
+		}
+		return op.Tags
+	}
+
+	t.Run("inferred", func(t *testing.T) {
+		out := loadTestdataWithFixtureConfig(t, "group_tags_gin", spec.DefaultGinConfig())
+		cases := []struct {
+			path, method string
+			want         []string
+		}{
+			{"/api/v1/users/{id}", "GET", []string{"users"}},
+			{"/api/v1/users/{id}/posts/", "GET", []string{"posts"}},
+			{"/api/v1/payment/charge", "POST", []string{"payment"}},
+			{"/api/v1/users/export", "GET", []string{"exports"}},
+			{"/api/v1/health", "GET", nil},
+			{"/status", "GET", nil},
+		}
+		for _, c := range cases {
+			if got := tagsOf(t, out, c.path, c.method); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("%s %s tags = %v, want %v", c.method, c.path, got, c.want)
+			}
+		}
+		if len(out.Tags) != 0 {
+			t.Errorf("top-level tags = %v, want none without descriptions", out.Tags)
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		cfg := spec.DefaultGinConfig()
+		cfg.Tags = []spec.Tag{{Name: "users", Description: "Set by tags"}}
+		cfg.GroupTags = []spec.GroupTag{
+			{Prefix: "/api/v1/payment", Name: "Payments", Description: "Charges and refunds."},
+			{Prefix: "/api/v1", Name: "Platform"},
+			{Prefix: "/api/v1/users", Description: "Ignored: tags already defines users."},
+		}
+		out := loadTestdataWithFixtureConfig(t, "group_tags_gin", cfg)
+		if got := tagsOf(t, out, "/api/v1/payment/refund", "POST"); !reflect.DeepEqual(got, []string{"Payments"}) {
+			t.Errorf("refund tags = %v", got)
+		}
+		if got := tagsOf(t, out, "/api/v1/health", "GET"); !reflect.DeepEqual(got, []string{"Platform"}) {
+			t.Errorf("health tags = %v, want the /api/v1 entry", got)
+		}
+		if got := tagsOf(t, out, "/api/v1/users/{id}/posts/", "GET"); !reflect.DeepEqual(got, []string{"users"}) {
+			t.Errorf("posts tags = %v, want the longest prefix, /api/v1/users", got)
+		}
+		want := []spec.Tag{
+			{Name: "users", Description: "Set by tags"},
+			{Name: "Payments", Description: "Charges and refunds."},
+		}
+		if !reflect.DeepEqual(out.Tags, want) {
+			t.Errorf("top-level tags = %v, want %v", out.Tags, want)
+		}
+	})
+}
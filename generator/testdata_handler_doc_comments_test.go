@@ -15,6 +15,7 @@
 package generator
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/ehabterra/apispec/spec"
@@ -45,6 +46,15 @@ func TestTestdata_HandlerDocComments(t *testing.T) {
 			summary:     "Search accounts",
 			description: "Filters accounts by query string.\nReturns an empty list when nothing matches.",
 		},
+		{
+			// Doc links and URLs become markdown links in the description
+			// and plain text in the summary; directive lines are dropped.
+			method:      "GET",
+			path:        "/accounts/export",
+			shape:       "doc links and directives",
+			summary:     "ExportAccounts streams every Account through a json.Encoder.",
+			description: "The format is described at <https://example.com/export> and in [the guide](https://example.com/guide).",
+		},
 		{
 			method:      "POST",
 			shape:       "pointer-receiver method",
@@ -113,3 +123,43 @@ func TestTestdata_HandlerDocComments(t *testing.T) {
 		}
 	}
 }
+
+// TestTestdata_HandlerDocComments_SchemaDescriptions: the doc comments of
+// types and struct fields describe their component schemas and properties. A
+// field's trailing comment counts, a $ref property keeps the referenced
+// component untouched, and an undocumented type gets no description.
+func TestTestdata_HandlerDocComments_SchemaDescriptions(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "handler_doc_comments", spec.DefaultHTTPConfig())
+
+	var account, owner *spec.Schema
+	for name, s := range out.Components.Schemas {
+		switch {
+		case strings.HasSuffix(name, "Account"):
+			account = s
+		case strings.HasSuffix(name, "Owner"):
+			owner = s
+		}
+	}
+	if account == nil || owner == nil {
+		t.Fatalf("Account/Owner components missing; have %v", mapSchemaKeys(out.Components.Schemas))
+	}
+
+	if want := "Account is a customer account.\nIts doc comment becomes the component schema description."; account.Description != want {
+		t.Errorf("Account description: got %q, want %q", account.Description, want)
+	}
+	for prop, want := range map[string]string{
+		"id":    "ID is the account's opaque identifier.",
+		"email": "Email receives the receipts.",
+	} {
+		if got := account.Properties[prop].Description; got != want {
+			t.Errorf("%s description: got %q, want %q", prop, got, want)
+		}
+	}
+	ownerProp := account.Properties["owner"]
+	if ownerProp.Ref == "" || !strings.HasPrefix(ownerProp.Description, "Owner is the [Owner](https://pkg.go.dev/") || strings.Contains(ownerProp.Description, "nolint") {
+		t.Errorf("owner property: got %+v, want a $ref described with a linked doc comment", ownerProp)
+	}
+	if owner.Description != "" || owner.Properties["name"].Description != "" {
+		t.Errorf("undocumented Owner got a description: %+v", owner)
+	}
+}
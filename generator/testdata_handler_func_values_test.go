@@ -0,0 +1,71 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_HandlerFuncValues locks in handlers that reach the mux through
+// a value rather than inline: an http.HandlerFunc variable holding a named
+// function or a handler factory's result, a map of patterns to handlers
+// ranged over, and a slice of route structs ranged over. Each route is named
+// after the handler it holds and documented from its body.
+func TestTestdata_HandlerFuncValues(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "handler_func_values", spec.DefaultHTTPConfig())
+	noDanglingRefs(t, out)
+
+	want := map[string]struct{ method, handler string }{
+		"/widgets":     {"GET", "makeWidgetsHandler"}, // var of a factory's result
+		"/status":      {"GET", "showStatus"},         // var of a named function
+		"/gadgets":     {"GET", "gadgets"},            // map table, named function
+		"/gizmos":      {"GET", "makeGizmosHandler"},  // map table, factory call
+		"/orders":      {"POST", "placeOrder"},        // struct slice, keyed fields
+		"/orders/{id}": {"GET", "showOrder"},          // struct slice, positional fields
+	}
+	for path, w := range want {
+		item, ok := out.Paths[path]
+		if !ok {
+			t.Errorf("path %q missing; have %v", path, mapPathKeys(out.Paths))
+			continue
+		}
+		op := opFor(item, w.method)
+		if op == nil {
+			t.Errorf("%s %s: expected operation, missing", w.method, path)
+			continue
+		}
+		if !strings.HasSuffix(op.OperationID, "."+w.handler) {
+			t.Errorf("%s %s operationId = %q, want the %s handler", w.method, path, op.OperationID, w.handler)
+		}
+	}
+	if len(out.Paths) != len(want) {
+		t.Errorf("got paths %v, want only %d", mapPathKeys(out.Paths), len(want))
+	}
+
+	// The unrolled bodies are read: the posted order and its 201 response.
+	if item, ok := out.Paths["/orders"]; ok {
+		if post := opFor(item, "POST"); post != nil {
+			if post.RequestBody == nil {
+				t.Errorf("POST /orders: expected placeOrder's decoded body")
+			}
+			if _, ok := post.Responses["201"]; !ok {
+				t.Errorf("POST /orders: expected placeOrder's 201 response")
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	intspec "github.com/ehabterra/apispec/internal/spec"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// headerParamsAndResponseHeaders checks that op documents exactly the
+// request header parameters in params, and exactly the response headers in
+// headers on each of its responses.
+func headerParamsAndResponseHeaders(t *testing.T, op *intspec.Operation, name string, params, headers []string) {
+	t.Helper()
+	paramsAndResponseHeaders(t, op, name, "header", params, headers)
+}
+
+// paramsAndResponseHeaders checks that op documents exactly the parameters
+// in params at location in, and exactly the response headers in headers on
+// each of its responses.
+func paramsAndResponseHeaders(t *testing.T, op *intspec.Operation, name, in string, params, headers []string) {
+	t.Helper()
+	if op == nil {
+		t.Fatalf("%s: operation missing", name)
+	}
+	var got []string
+	for _, p := range op.Parameters {
+		if p.In == in {
+			got = append(got, p.Name)
+		}
+	}
+	sort.Strings(got)
+	sort.Strings(params)
+	if strings.Join(got, ",") != strings.Join(params, ",") {
+		t.Errorf("%s: %s parameters %v, want %v", name, in, got, params)
+	}
+
+	sort.Strings(headers)
+	for status, resp := range op.Responses {
+		var names []string
+		for h, def := range resp.Headers {
+			names = append(names, h)
+			if def.Schema == nil || def.Schema.Type != "string" {
+				t.Errorf("%s %s: header %q schema = %+v, want string", name, status, h, def.Schema)
+			}
+		}
+		sort.Strings(names)
+		if strings.Join(names, ",") != strings.Join(headers, ",") {
+			t.Errorf("%s %s: response headers %v, want %v", name, status, names, headers)
+		}
+	}
+}
+
+// TestTestdata_HeaderParamsHTTP covers r.Header.Get reads (Authorization is
+// left to the security scheme) and w.Header().Set/Add writes, chained or
+// through a variable, with a constant name. Content-Type is the media type,
+// and headers set on an outbound request are not the handler's response.
+func TestTestdata_HeaderParamsHTTP(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "header_params_http", spec.DefaultHTTPConfig())
+	noDanglingRefs(t, out)
+	headerParamsAndResponseHeaders(t, opFor(out.Paths["/accounts/{id}"], "GET"), "GET /accounts/{id}",
+		[]string{"X-Tenant-ID"}, []string{"X-Request-ID", "X-RateLimit-Remaining"})
+	headerParamsAndResponseHeaders(t, opFor(out.Paths["/accounts"], "POST"), "POST /accounts",
+		[]string{"X-Tenant-ID"}, []string{"Location"})
+}
+
+// TestTestdata_HeaderParamsGin covers c.GetHeader and c.Request.Header.Get,
+// and c.Header and c.Writer.Header().Set.
+func TestTestdata_HeaderParamsGin(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "header_params_gin", spec.DefaultGinConfig())
+	noDanglingRefs(t, out)
+	headerParamsAndResponseHeaders(t, opFor(out.Paths["/orders/{id}"], "GET"), "GET /orders/{id}",
+		[]string{"X-Tenant-ID", "If-None-Match"}, []string{"ETag", "Cache-Control"})
+}
+
+// TestTestdata_HeaderParamsEcho covers c.Request().Header.Get and
+// c.Response().Header().Set.
+func TestTestdata_HeaderParamsEcho(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "header_params_echo", spec.DefaultEchoConfig())
+	noDanglingRefs(t, out)
+	headerParamsAndResponseHeaders(t, opFor(out.Paths["/orders/{id}"], "GET"), "GET /orders/{id}",
+		[]string{"X-Tenant-ID"}, []string{"X-Request-ID"})
+}
+
+// TestTestdata_HeaderParamsFiber covers c.Get, and c.Set and c.Append.
+func TestTestdata_HeaderParamsFiber(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "header_params_fiber", spec.DefaultFiberConfig())
+	noDanglingRefs(t, out)
+	headerParamsAndResponseHeaders(t, opFor(out.Paths["/orders/{id}"], "GET"), "GET /orders/{id}",
+		[]string{"X-Tenant-ID"}, []string{"X-Request-ID", "Vary"})
+}
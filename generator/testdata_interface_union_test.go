@@ -0,0 +1,69 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	intspec "github.com/ehabterra/apispec/internal/spec"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_InterfaceUnion covers interfaces resolved from their
+// implementations: Shape, implemented by Circle and Square, maps to a oneOf
+// of both wherever it is used, with a discriminator once
+// schemas.discriminator is set; Named, implemented once, stays an object.
+func TestTestdata_InterfaceUnion(t *testing.T) {
+	component := func(out *spec.OpenAPISpec, suffix string) *intspec.Schema {
+		t.Helper()
+		for name, s := range out.Components.Schemas {
+			if strings.HasSuffix(name, suffix) {
+				return s
+			}
+		}
+		t.Fatalf("no component ending %q", suffix)
+		return nil
+	}
+
+	out := loadTestdataWithFixtureConfig(t, "interface_union", spec.DefaultHTTPConfig())
+	noDanglingRefs(t, out)
+
+	shape := component(out, "_Shape")
+	assertOneOf(t, shape, "_Circle", "_Square")
+	if shape.Type != "" || shape.Discriminator != nil {
+		t.Errorf("Shape = type %q, discriminator %+v; want a bare oneOf", shape.Type, shape.Discriminator)
+	}
+	drawing := component(out, "_Drawing")
+	if items := drawing.Properties["shapes"].Items; items == nil || !strings.HasSuffix(items.Ref, "_Shape") {
+		t.Errorf("Drawing.shapes items = %+v, want a ref to Shape", items)
+	}
+	if named := component(out, "_Named"); named.Type != "object" || len(named.OneOf) != 0 {
+		t.Errorf("Named = %+v, want a plain object (one implementation)", named)
+	}
+
+	cfg := spec.DefaultHTTPConfig()
+	cfg.Schemas.Discriminator = "kind"
+	out = loadTestdataWithFixtureConfig(t, "interface_union", cfg)
+	d := component(out, "_Shape").Discriminator
+	if d == nil || d.PropertyName != "kind" {
+		t.Fatalf("Shape discriminator = %+v, want propertyName kind", d)
+	}
+	for _, name := range []string{"Circle", "Square"} {
+		if !strings.HasSuffix(d.Mapping[name], "_"+name) {
+			t.Errorf("discriminator mapping[%s] = %q, want its component", name, d.Mapping[name])
+		}
+	}
+}
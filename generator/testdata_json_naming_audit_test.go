@@ -0,0 +1,54 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	intspec "github.com/ehabterra/apispec/internal/spec"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_JSONNamingAudit locks in the JSON naming audit: Account's two
+// "id"-tagged fields and its ownerId/ownerID pair are flagged on that schema,
+// and the order request/response disagreeing on customerId vs customerID is
+// flagged across schemas. Clean properties (amount) are not reported.
+func TestTestdata_JSONNamingAudit(t *testing.T) {
+	gen := NewGenerator(spec.DefaultHTTPConfig())
+	if _, err := gen.GenerateFromDirectory(filepath.Join("..", "testdata", "json_naming_audit")); err != nil {
+		t.Fatalf("GenerateFromDirectory: %v", err)
+	}
+
+	byKind := map[string][]intspec.NamingIssue{}
+	for _, issue := range gen.NamingIssues() {
+		byKind[issue.Kind] = append(byKind[issue.Kind], issue)
+	}
+
+	dup := byKind[intspec.NamingDuplicateJSONName]
+	if len(dup) != 1 || dup[0].JSON != "id" || strings.Join(dup[0].Names, ",") != "ID,LegacyID" ||
+		!strings.HasSuffix(dup[0].Schema, "_Account") {
+		t.Errorf("duplicate-json-name = %+v", dup)
+	}
+	fold := byKind[intspec.NamingCaseCollision]
+	if len(fold) != 1 || strings.Join(fold[0].Names, ",") != "ownerId,ownerID" {
+		t.Errorf("case-collision = %+v", fold)
+	}
+	cross := byKind[intspec.NamingCrossSchemaCase]
+	if len(cross) != 1 || strings.Join(cross[0].Names, ",") != "customerID,customerId" || len(cross[0].Schemas) != 2 {
+		t.Errorf("cross-schema-case = %+v", cross)
+	}
+}
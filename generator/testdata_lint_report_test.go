@@ -0,0 +1,65 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/engine"
+	"github.com/ehabterra/apispec/internal/lint"
+)
+
+// TestTestdata_LintReport covers --lint: the documented, tagged handler with
+// a 404 passes every rule, the bare one fails all three operation rules, and
+// the User schema mixes property casing. Each finding points at the Go
+// declaration behind it, without sourceAnnotations being set.
+func TestTestdata_LintReport(t *testing.T) {
+	ec := engine.DefaultEngineConfig()
+	ec.InputDir = filepath.Join("..", "testdata", "lint_report")
+	e := engine.NewEngine(ec)
+	out, err := e.GenerateOpenAPI()
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI: %v", err)
+	}
+
+	report := lint.Run(out, e.GetSources())
+	if report.Checks != 8 || report.Passed != 4 || report.Score != 50 {
+		t.Errorf("checks/passed/score = %d/%d/%d, want 8/4/50", report.Checks, report.Passed, report.Score)
+	}
+	want := []struct {
+		rule, location string
+		line           int
+	}{
+		{lint.RuleOperationDescription, "GET /profiles/{id}", 33},
+		{lint.RuleOperation4xxResponse, "GET /profiles/{id}", 33},
+		{lint.RuleOperationTags, "GET /profiles/{id}", 33},
+		{lint.RulePropertyCasing, "components.schemas.github_com_ehabterra_apispec_testdata_lint_report_User", 9},
+	}
+	if len(report.Findings) != len(want) {
+		t.Fatalf("findings = %v, want %d", report.Findings, len(want))
+	}
+	for i, w := range want {
+		f := report.Findings[i]
+		if f.Rule != w.rule || f.Location != w.location || f.File != "main.go" || f.Line != w.line {
+			t.Errorf("finding %d = %s, want main.go:%d: %s [%s]", i, f, w.line, w.location, w.rule)
+		}
+	}
+	for _, s := range out.Components.Schemas {
+		if _, ok := s.Extensions["x-go-source"]; ok {
+			t.Error("--lint alone should not annotate the spec")
+		}
+	}
+}
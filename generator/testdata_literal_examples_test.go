@@ -0,0 +1,100 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_LiteralExamples covers schemas.literalExamples: properties
+// take the constant values struct literals in the code and its tests give
+// them, and request bodies the JSON the tests send.
+func TestTestdata_LiteralExamples(t *testing.T) {
+	generate := func(literal bool) *spec.OpenAPISpec {
+		t.Helper()
+		cfg := spec.DefaultHTTPConfig()
+		cfg.Schemas.LiteralExamples = literal
+		openapi, err := NewGenerator(cfg).GenerateFromDirectory(filepath.Join("..", "testdata", "literal_examples"))
+		if err != nil {
+			t.Fatalf("GenerateFromDirectory: %v", err)
+		}
+		if err := spec.ValidateSpec(openapi); err != nil {
+			t.Fatalf("ValidateSpec: %v", err)
+		}
+		return openapi
+	}
+
+	t.Run("off by default", func(t *testing.T) {
+		openapi := generate(false)
+		for name, s := range openapi.Components.Schemas {
+			for prop, p := range s.Properties {
+				if p.Example != nil {
+					t.Errorf("%s.%s example = %v without literalExamples", name, prop, p.Example)
+				}
+			}
+		}
+		if media := openapi.Paths["/users"].Post.RequestBody.Content["application/json"]; media.Example != nil {
+			t.Errorf("POST /users body example = %v without literalExamples", media.Example)
+		}
+	})
+
+	openapi := generate(true)
+	component := func(name string) *spec.Schema {
+		t.Helper()
+		s := openapi.Components.Schemas["github_com_ehabterra_apispec_testdata_literal_examples_"+name]
+		if s == nil {
+			t.Fatalf("no %s component: %v", name, slices.Sorted(maps.Keys(openapi.Components.Schemas)))
+		}
+		return s
+	}
+	for _, tc := range []struct {
+		schema, prop string
+		want         any
+	}{
+		{"User", "id", int64(1)},
+		{"User", "name", "Alice"},
+		{"User", "email", "bob@example.com"}, // the first literal setting it
+		{"User", "status", "active"},         // a named constant
+		{"User", "roles", []any{"admin", "editor"}},
+		{"User", "joined", nil}, // time.Now() is no constant
+		{"Settings", "pageSize", int64(25)},
+		{"Settings", "ratio", 0.75},
+		{"Settings", "beta", true},
+		{"Address", "city", "Lisbon"}, // only in main_test.go
+	} {
+		if got := component(tc.schema).Properties[tc.prop].Example; !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s.%s example = %#v, want %#v", tc.schema, tc.prop, got, tc.want)
+		}
+	}
+
+	created := openapi.Paths["/users"].Post.RequestBody.Content["application/json"]
+	if want := map[string]any{"name": "Carol", "email": "carol@example.com"}; !reflect.DeepEqual(created.Example, want) {
+		t.Errorf("POST /users body example = %#v, want %#v", created.Example, want)
+	}
+	// Two tests send /users/2 different bodies; both are named examples.
+	updated := openapi.Paths["/users/{id}"].Put.RequestBody.Content["application/json"]
+	if got := slices.Sorted(maps.Keys(updated.Examples)); !slices.Equal(got, []string{"TestBlockUser", "TestUnblockUser"}) {
+		t.Fatalf("PUT /users/{id} body examples = %v, want TestBlockUser and TestUnblockUser", got)
+	}
+	if got := updated.Examples["TestBlockUser"].Value; !reflect.DeepEqual(got, map[string]any{"status": "blocked"}) {
+		t.Errorf("TestBlockUser example = %#v", got)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"testing"
+
+	intspec "github.com/ehabterra/apispec/internal/spec"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_LongPolling covers long polls and Retry-After: a handler racing
+// an event against time.After or time.NewTimer carries x-long-poll and gains
+// a 202 for a timed-out poll unless it answers one with 204 itself, and a
+// Retry-After header is documented with a description on every response,
+// including a long poll's added 202.
+func TestTestdata_LongPolling(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "long_polling", spec.DefaultHTTPConfig())
+	noDanglingRefs(t, out)
+
+	opOf := func(path, method string) *intspec.Operation {
+		t.Helper()
+		op := opFor(out.Paths[path], method)
+		if op == nil {
+			t.Fatalf("%s %s missing; paths %v", method, path, mapPathKeys(out.Paths))
+		}
+		return op
+	}
+
+	poll := opOf("/messages/poll", "GET")
+	if poll.Extensions["x-long-poll"] != true {
+		t.Errorf("GET /messages/poll should carry x-long-poll, got %v", poll.Extensions)
+	}
+	if _, ok := poll.Responses["202"]; ok {
+		t.Errorf("GET /messages/poll answers a timeout with 204; no 202 should be added")
+	}
+
+	wait := opOf("/jobs/{id}/wait", "GET")
+	if wait.Extensions["x-long-poll"] != true {
+		t.Errorf("GET /jobs/{id}/wait should carry x-long-poll, got %v", wait.Extensions)
+	}
+	timedOut, ok := wait.Responses["202"]
+	if !ok {
+		t.Fatalf("GET /jobs/{id}/wait: responses %v, want a 202 for a timed-out poll", keysOf(wait.Responses))
+	}
+	if h, ok := timedOut.Headers["Retry-After"]; !ok || h.Description == "" {
+		t.Errorf("GET /jobs/{id}/wait 202 Retry-After = %+v, want a described header", h)
+	}
+
+	create := opOf("/jobs", "POST")
+	if len(create.Extensions) != 0 {
+		t.Errorf("POST /jobs is not a long poll, got %v", create.Extensions)
+	}
+	if h := create.Responses["202"].Headers["Retry-After"]; h.Description == "" {
+		t.Errorf("POST /jobs 202 Retry-After = %+v, want a description", h)
+	}
+
+	if get := opOf("/jobs/{id}", "GET"); len(get.Extensions) != 0 {
+		t.Errorf("GET /jobs/{id} is not a long poll, got %v", get.Extensions)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/engine"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_MiddlewareResponses covers the middlewareResponses config of
+// the fixture's apispec.yaml: requireAuth, applied with Use in a Group, adds
+// its 401 to the group's operations unless the handler documents one; the
+// rateLimit chained with With adds 429 to its route only; and the root cors
+// middleware adds an OPTIONS preflight to every path but /health, which
+// registers its own.
+func TestTestdata_MiddlewareResponses(t *testing.T) {
+	dir := filepath.Join("..", "testdata", "middleware_responses")
+	ec := engine.DefaultEngineConfig()
+	ec.InputDir = dir
+	ec.ConfigFile = filepath.Join(dir, "apispec.yaml")
+	out, err := engine.NewEngine(ec).GenerateOpenAPI()
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI: %v", err)
+	}
+	noDanglingRefs(t, out)
+	if issues := spec.ValidateSpec(out); len(issues) > 0 {
+		t.Errorf("spec issues: %v", issues)
+	}
+
+	op := func(path, method string) *spec.Operation {
+		t.Helper()
+		item, ok := out.Paths[path]
+		if !ok {
+			t.Fatalf("path %q missing; have %v", path, mapPathKeys(out.Paths))
+		}
+		o := opFor(item, method)
+		if o == nil {
+			t.Fatalf("%s %s missing", method, path)
+		}
+		return o
+	}
+	responseSchema := func(o *spec.Operation, status, contentType string) *spec.Schema {
+		resp, ok := o.Responses[status]
+		if !ok || resp.Content[contentType].Schema == nil {
+			return nil
+		}
+		return resp.Content[contentType].Schema
+	}
+
+	for _, path := range []string{"/orders", "/orders/{id}"} {
+		s := responseSchema(op(path, "GET"), "401", "application/json")
+		if s == nil || !strings.HasSuffix(s.Ref, "_apiError") {
+			t.Errorf("GET %s 401 = %+v, want requireAuth's apiError body", path, s)
+		}
+	}
+	if s := responseSchema(op("/orders", "POST"), "401", "text/plain; charset=utf-8"); s == nil {
+		t.Errorf("POST /orders: expected createOrder's own 401 to be kept")
+	}
+	if _, ok := op("/health", "GET").Responses["401"]; ok {
+		t.Errorf("GET /health: requireAuth does not guard it, want no 401")
+	}
+
+	if _, ok := op("/orders/{id}", "GET").Responses["429"]; !ok {
+		t.Errorf("GET /orders/{id}: expected rateLimit's 429")
+	}
+	if _, ok := op("/orders", "GET").Responses["429"]; ok {
+		t.Errorf("GET /orders: rateLimit is chained onto /orders/{id} only, want no 429")
+	}
+
+	for _, path := range []string{"/orders", "/orders/{id}"} {
+		preflight := op(path, "OPTIONS")
+		if preflight.Summary != "CORS preflight" {
+			t.Errorf("OPTIONS %s summary = %q, want the preflight", path, preflight.Summary)
+		}
+		if preflight.Security == nil || len(*preflight.Security) != 0 {
+			t.Errorf("OPTIONS %s security = %v, want public", path, preflight.Security)
+		}
+		if _, ok := preflight.Responses["204"].Headers["Access-Control-Allow-Origin"]; !ok {
+			t.Errorf("OPTIONS %s: expected the Access-Control-Allow-Origin header", path)
+		}
+	}
+	if o := op("/health", "OPTIONS"); !strings.HasSuffix(o.OperationID, ".healthOptions") {
+		t.Errorf("OPTIONS /health operationId = %q, want the registered healthOptions", o.OperationID)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_ModelTags covers schemas.tagNamespaces: by default only json
+// tags name properties; with [json, db, gorm] a field without a json tag is
+// named by its db tag and then its gorm column, gorm's not null and
+// primaryKey columns are required and a varchar(N) or size bounds the string.
+func TestTestdata_ModelTags(t *testing.T) {
+	account := func(t *testing.T, cfg *spec.APISpecConfig) *spec.Schema {
+		t.Helper()
+		g := NewGenerator(cfg)
+		openapi, err := g.GenerateFromDirectory(filepath.Join("..", "testdata", "model_tags"))
+		if err != nil {
+			t.Fatalf("GenerateFromDirectory: %v", err)
+		}
+		if err := spec.ValidateSpec(openapi); err != nil {
+			t.Fatalf("ValidateSpec: %v", err)
+		}
+		noDanglingRefs(t, openapi)
+		s := openapi.Components.Schemas["github_com_ehabterra_apispec_testdata_model_tags_Account"]
+		if s == nil {
+			t.Fatalf("no Account component: %v", slices.Sorted(maps.Keys(openapi.Components.Schemas)))
+		}
+		return s
+	}
+
+	t.Run("json only by default", func(t *testing.T) {
+		s := account(t, spec.DefaultHTTPConfig())
+		if got, want := slices.Sorted(maps.Keys(s.Properties)), []string{"Email", "ID", "Nickname", "Notes", "plan"}; !slices.Equal(got, want) {
+			t.Errorf("properties = %v, want %v", got, want)
+		}
+		if len(s.Required) != 0 {
+			t.Errorf("required = %v, want none without gorm", s.Required)
+		}
+	})
+
+	t.Run("json, db, gorm", func(t *testing.T) {
+		cfg := spec.DefaultHTTPConfig()
+		cfg.Schemas.TagNamespaces = []string{"json", "db", "gorm"}
+		s := account(t, cfg)
+		if got, want := slices.Sorted(maps.Keys(s.Properties)), []string{"Notes", "account_id", "email_address", "nick", "plan"}; !slices.Equal(got, want) {
+			t.Errorf("properties = %v, want %v", got, want)
+		}
+		if got, want := slices.Sorted(slices.Values(s.Required)), []string{"account_id", "email_address"}; !slices.Equal(got, want) {
+			t.Errorf("required = %v, want %v", got, want)
+		}
+		if got := s.Properties["email_address"].MaxLength; got != 120 {
+			t.Errorf("email_address maxLength = %d, want 120 from varchar(120)", got)
+		}
+		if got := s.Properties["nick"].MaxLength; got != 32 {
+			t.Errorf("nick maxLength = %d, want 32 from size", got)
+		}
+	})
+
+	t.Run("gorm before db", func(t *testing.T) {
+		cfg := spec.DefaultHTTPConfig()
+		cfg.Schemas.TagNamespaces = []string{"gorm", "db"}
+		s := account(t, cfg)
+		if got, want := slices.Sorted(maps.Keys(s.Properties)), []string{"Notes", "account_id", "email", "nick", "plan_code"}; !slices.Equal(got, want) {
+			t.Errorf("properties = %v, want %v", got, want)
+		}
+	})
+}
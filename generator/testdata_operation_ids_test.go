@@ -0,0 +1,62 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/engine"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_OperationIDs covers the camel operationIds style of the
+// fixture's apispec.yaml: a method of a sub-package's handler is named after
+// the package, receiver and method without the module prefix, a closure after
+// its method and path, a handler serving two paths gets a numeric suffix on
+// the second, and a swaggo @ID is kept as written.
+func TestTestdata_OperationIDs(t *testing.T) {
+	dir := filepath.Join("..", "testdata", "operation_ids")
+	ec := engine.DefaultEngineConfig()
+	ec.InputDir = dir
+	ec.ConfigFile = filepath.Join(dir, "apispec.yaml")
+	out, err := engine.NewEngine(ec).GenerateOpenAPI()
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI: %v", err)
+	}
+	if issues := spec.ValidateSpec(out); len(issues) > 0 {
+		t.Errorf("spec issues: %v", issues)
+	}
+
+	for path, want := range map[string]string{
+		"/users/{id}": "handlersUserHandlerGetUser",
+		"/version":    "getVersion",
+		"/health":     "health",
+		"/healthz":    "health2",
+		"/users":      "listAllUsers",
+	} {
+		item, ok := out.Paths[path]
+		if !ok {
+			t.Fatalf("path %q missing; have %v", path, mapPathKeys(out.Paths))
+		}
+		op := opFor(item, "GET")
+		if op == nil {
+			t.Fatalf("GET %s missing", path)
+		}
+		if op.OperationID != want {
+			t.Errorf("GET %s operationId = %q, want %q", path, op.OperationID, want)
+		}
+	}
+}
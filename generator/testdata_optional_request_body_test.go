@@ -0,0 +1,65 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_OptionalRequestBody covers requestBody.required: an
+// unconditional decode that errors on failure is required; a decode under an
+// r.Body/r.ContentLength guard — in the handler or around a helper call — or
+// one tolerating io.EOF, inline or on the line after the assignment, is not.
+// defaults.requestBodyRequired overrides the inference.
+func TestTestdata_OptionalRequestBody(t *testing.T) {
+	cases := []struct {
+		path, method string
+		required     bool
+	}{
+		{"/items", "POST", true},
+		{"/items/{id}", "PATCH", false},
+		{"/items/{id}/touch", "POST", false},
+		{"/items/{id}", "PUT", false},
+		{"/items/import", "POST", false},
+	}
+	check := func(t *testing.T, out *spec.OpenAPISpec, want func(bool) bool) {
+		t.Helper()
+		noDanglingRefs(t, out)
+		for _, c := range cases {
+			op := opFor(out.Paths[c.path], c.method)
+			if op == nil || op.RequestBody == nil {
+				t.Errorf("%s %s: no request body; have %v", c.method, c.path, mapPathKeys(out.Paths))
+				continue
+			}
+			if got := op.RequestBody.Required; got != want(c.required) {
+				t.Errorf("%s %s: required = %v, want %v", c.method, c.path, got, want(c.required))
+			}
+		}
+	}
+
+	t.Run("inferred", func(t *testing.T) {
+		out := loadTestdataWithFixtureConfig(t, "optional_request_body", spec.DefaultHTTPConfig())
+		check(t, out, func(inferred bool) bool { return inferred })
+	})
+	t.Run("config default", func(t *testing.T) {
+		cfg := spec.DefaultHTTPConfig()
+		required := true
+		cfg.Defaults.RequestBodyRequired = &required
+		out := loadTestdataWithFixtureConfig(t, "optional_request_body", cfg)
+		check(t, out, func(bool) bool { return true })
+	})
+}
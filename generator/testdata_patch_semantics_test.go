@@ -0,0 +1,74 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_PatchSemantics covers partial-update bodies: a PATCH decoding
+// a struct of pointer fields is a merge patch referencing an all-optional
+// variant of the schema PUT still requires in full; jsonpatch.DecodePatch and
+// MergePatch over an io.ReadAll'd body read a JSON Patch document and a merge
+// patch; a PATCH decoding a plain struct stays application/json.
+func TestTestdata_PatchSemantics(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "patch_semantics", spec.DefaultHTTPConfig())
+	noDanglingRefs(t, out)
+
+	bodyOf := func(path, method, contentType string) string {
+		t.Helper()
+		op := opFor(out.Paths[path], method)
+		if op == nil || op.RequestBody == nil {
+			t.Fatalf("%s %s: request body missing", method, path)
+		}
+		media, ok := op.RequestBody.Content[contentType]
+		if !ok {
+			t.Fatalf("%s %s: content types %v, want %s", method, path, keysOf(op.RequestBody.Content), contentType)
+		}
+		if media.Schema.Ref != "" {
+			return strings.TrimPrefix(media.Schema.Ref, "#/components/schemas/")
+		}
+		return ""
+	}
+
+	full := bodyOf("/items/{id}", "PUT", "application/json")
+	partial := bodyOf("/items/{id}", "PATCH", "application/merge-patch+json")
+	if partial != full+"_MergePatch" {
+		t.Errorf("PATCH body = %s, want the merge patch variant of %s", partial, full)
+	}
+	if req := out.Components.Schemas[full].Required; !slices.Equal(req, []string{"name", "price"}) {
+		t.Errorf("%s required = %v, want PUT to keep it", full, req)
+	}
+	if s := out.Components.Schemas[partial]; s == nil || len(s.Required) != 0 || len(s.Properties) != 3 {
+		t.Errorf("%s = %+v, want the same properties, none required", partial, s)
+	}
+
+	if ref := bodyOf("/items/{id}/name", "PATCH", "application/json"); !strings.HasSuffix(ref, "_Rename") {
+		t.Errorf("plain-struct PATCH body = %s", ref)
+	}
+
+	bodyOf("/documents/{id}", "PATCH", "application/json-patch+json")
+	doc := opFor(out.Paths["/documents/{id}"], "PATCH").RequestBody.Content["application/json-patch+json"].Schema
+	if doc.Type != "array" || doc.Items == nil || !slices.Equal(doc.Items.Required, []string{"op", "path"}) ||
+		len(doc.Items.Properties["op"].Enum) != 6 {
+		t.Errorf("JSON Patch schema = %+v", doc)
+	}
+
+	bodyOf("/settings", "PATCH", "application/merge-patch+json")
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_ProtobufMessages covers protoc-gen-go structs: well-known
+// types collapse to the proto3 JSON scalar they stand for, and neither the
+// generator's internal state nor the XXX_ fields of older generators become
+// properties.
+func TestTestdata_ProtobufMessages(t *testing.T) {
+	g := NewGenerator(spec.DefaultHTTPConfig())
+	openapi, err := g.GenerateFromDirectory(filepath.Join("..", "testdata", "protobuf_messages"))
+	if err != nil {
+		t.Fatalf("GenerateFromDirectory: %v", err)
+	}
+	if err := spec.ValidateSpec(openapi); err != nil {
+		t.Fatalf("ValidateSpec: %v", err)
+	}
+	noDanglingRefs(t, openapi)
+
+	component := func(name string) *spec.Schema {
+		t.Helper()
+		s := openapi.Components.Schemas["github_com_ehabterra_apispec_testdata_protobuf_messages_"+name]
+		if s == nil {
+			t.Fatalf("no %s component: %v", name, slices.Sorted(maps.Keys(openapi.Components.Schemas)))
+		}
+		return s
+	}
+
+	user := component("User")
+	if got, want := slices.Sorted(maps.Keys(user.Properties)), []string{"age", "attributes", "balance", "created_at", "nickname", "session_ttl", "user_id", "verified"}; !slices.Equal(got, want) {
+		t.Errorf("User properties = %v, want %v", got, want)
+	}
+	for name, want := range map[string]struct{ typ, format string }{
+		"nickname":    {"string", ""},
+		"age":         {"integer", ""},
+		"balance":     {"string", "int64"},
+		"verified":    {"boolean", ""},
+		"created_at":  {"string", "date-time"},
+		"session_ttl": {"string", ""},
+	} {
+		p := user.Properties[name]
+		if p == nil || p.Ref != "" || p.Type != want.typ || p.Format != want.format {
+			t.Errorf("User.%s = %+v, want inline type %q format %q", name, p, want.typ, want.format)
+		}
+	}
+
+	// A Struct is an object, not a scalar, so it keeps its (now
+	// field-less) component rather than being inlined.
+	if ref := user.Properties["attributes"].Ref; ref != "#/components/schemas/google_golang_org_protobuf_types_known_structpb_Struct" {
+		t.Errorf("User.attributes $ref = %q, want the structpb.Struct component", ref)
+	} else if s := openapi.Components.Schemas["google_golang_org_protobuf_types_known_structpb_Struct"]; s.Type != "object" || len(s.Properties) != 0 {
+		t.Errorf("structpb.Struct component = %+v, want a free-form object", s)
+	}
+
+	profile := component("LegacyProfile")
+	if got := slices.Sorted(maps.Keys(profile.Properties)); !slices.Equal(got, []string{"bio"}) {
+		t.Errorf("LegacyProfile properties = %v, want [bio]", got)
+	}
+
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	intspec "github.com/ehabterra/apispec/internal/spec"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// queryParamTypes checks that op documents exactly the query parameters in
+// want, each with the given schema type ("array" means an array of strings).
+func queryParamTypes(t *testing.T, op *intspec.Operation, name string, want map[string]string) {
+	t.Helper()
+	if op == nil {
+		t.Fatalf("%s: operation missing", name)
+	}
+	got := map[string]*intspec.Schema{}
+	for _, p := range op.Parameters {
+		if p.In == "query" {
+			got[p.Name] = p.Schema
+		}
+	}
+	for param, typ := range want {
+		s := got[param]
+		switch {
+		case s == nil:
+			t.Errorf("%s: query parameter %q missing; have %v", name, param, keysOf(got))
+		case s.Type != typ:
+			t.Errorf("%s: %q type = %q, want %q", name, param, s.Type, typ)
+		case typ == "array" && (s.Items == nil || s.Items.Type != "string"):
+			t.Errorf("%s: %q items = %+v, want strings", name, param, s.Items)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("%s: query parameters %v, want %d", name, keysOf(got), len(want))
+	}
+}
+
+// TestTestdata_QueryParamsHTTP covers r.URL.Query().Get values typed by the
+// strconv call that parses them, inline or through a variable, and a value
+// parsed two ways staying a string.
+func TestTestdata_QueryParamsHTTP(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "query_params_http", spec.DefaultHTTPConfig())
+	noDanglingRefs(t, out)
+	queryParamTypes(t, opFor(out.Paths["/items"], "GET"), "GET /items", map[string]string{
+		"page":      "integer",
+		"limit":     "integer",
+		"active":    "boolean",
+		"min_price": "number",
+		"since":     "integer",
+		"offset":    "integer",
+		"sort":      "string",
+		"mode":      "string",
+	})
+}
+
+// TestTestdata_QueryParamsGin covers c.Query, DefaultQuery and GetQuery, and
+// QueryArray as an array parameter.
+func TestTestdata_QueryParamsGin(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "query_params_gin", spec.DefaultGinConfig())
+	noDanglingRefs(t, out)
+	queryParamTypes(t, opFor(out.Paths["/posts"], "GET"), "GET /posts", map[string]string{
+		"page":     "integer",
+		"per_page": "integer",
+		"q":        "string",
+		"tag":      "array",
+		"draft":    "boolean",
+	})
+}
+
+// TestTestdata_QueryParamsFiber covers fiber's typed QueryInt/QueryBool/
+// QueryFloat accessors next to a converted c.Query, and structs bound with
+// QueryParser and ParamsParser.
+func TestTestdata_QueryParamsFiber(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "query_params_fiber", spec.DefaultFiberConfig())
+	noDanglingRefs(t, out)
+	queryParamTypes(t, opFor(out.Paths["/orders"], "GET"), "GET /orders", map[string]string{
+		"page":      "integer",
+		"paid":      "boolean",
+		"min_total": "number",
+		"since":     "integer",
+		"status":    "string",
+	})
+
+	items := opFor(out.Paths["/orders/{orderID}/items"], "GET")
+	queryParamTypes(t, items, "GET /orders/{orderID}/items", map[string]string{
+		"limit": "integer",
+		"sort":  "string",
+	})
+	params := paramsByName(t, items, "GET /orders/{orderID}/items")
+	if s := params["limit"].Schema; !boundIs(s.Minimum, 1) || !boundIs(s.Maximum, 50) {
+		t.Errorf("limit schema = %+v, want [1, 50]", s)
+	}
+	if s := params["sort"].Schema; !reflect.DeepEqual(s.Enum, []any{"asc", "desc"}) {
+		t.Errorf("sort enum = %v", s.Enum)
+	}
+	if id := params["orderID"]; id.In != "path" || !id.Required || id.Schema.Type != "integer" || !boundIs(id.Schema.Minimum, 1) {
+		t.Errorf("orderID = %+v, schema %+v", id, id.Schema)
+	}
+}
+
+// paramsByName indexes op's parameters by name.
+func paramsByName(t *testing.T, op *intspec.Operation, name string) map[string]intspec.Parameter {
+	t.Helper()
+	if op == nil {
+		t.Fatalf("%s: operation missing", name)
+	}
+	got := map[string]intspec.Parameter{}
+	for _, p := range op.Parameters {
+		got[p.Name] = p
+	}
+	return got
+}
+
+// TestTestdata_BoundParamsGin covers ShouldBindQuery and ShouldBindUri: each
+// field of the bound struct, embedded ones included, becomes a parameter
+// carrying its validate constraints, named enum and doc comment, while
+// struct-valued, "-" and unexported fields are left out.
+func TestTestdata_BoundParamsGin(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "bound_params_gin", spec.DefaultGinConfig())
+	noDanglingRefs(t, out)
+	list := opFor(out.Paths["/products"], "GET")
+	queryParamTypes(t, list, "GET /products", map[string]string{
+		"page":     "integer",
+		"per_page": "integer",
+		"sort":     "string",
+		"sku":      "string",
+		"status":   "string",
+		"tag":      "array",
+		"tenant":   "string",
+	})
+
+	params := paramsByName(t, list, "GET /products")
+	if p := params["page"]; !boundIs(p.Schema.Minimum, 1) || p.Description != "Page is 1-based." || p.Required {
+		t.Errorf("page = %+v, schema %+v", p, p.Schema)
+	}
+	if s := params["per_page"].Schema; !boundIs(s.Minimum, 1) || !boundIs(s.Maximum, 100) {
+		t.Errorf("per_page schema = %+v, want [1, 100]", s)
+	}
+	if s := params["sort"].Schema; !reflect.DeepEqual(s.Enum, []any{"name", "price"}) {
+		t.Errorf("sort enum = %v", s.Enum)
+	}
+	if s := params["sku"].Schema; s.Pattern != "^[A-Z]{3}-[0-9]{4}$" {
+		t.Errorf("sku pattern = %q", s.Pattern)
+	}
+	if s := params["status"].Schema; !reflect.DeepEqual(s.Enum, []any{"draft", "published"}) {
+		t.Errorf("status enum = %v, want the Status constants", s.Enum)
+	}
+	if s := params["tag"].Schema; s.MaxItems != 5 {
+		t.Errorf("tag maxItems = %d", s.MaxItems)
+	}
+	if !params["tenant"].Required {
+		t.Error("tenant is validate:\"required\" but not a required parameter")
+	}
+	for key := range out.Components.Schemas {
+		if strings.HasSuffix(key, "_Filter") {
+			t.Errorf("the skipped Filter field leaked component %s", key)
+		}
+	}
+
+	id := paramsByName(t, opFor(out.Paths["/products/{id}"], "GET"), "GET /products/{id}")["id"]
+	if id.In != "path" || !id.Required || id.Schema.Format != "uuid" {
+		t.Errorf("id = %+v, schema %+v", id, id.Schema)
+	}
+}
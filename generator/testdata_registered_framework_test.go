@@ -0,0 +1,58 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_RegisteredFramework drives detection end to end for a router
+// apispec has no built-in config for: once registered, the in-house router's
+// Get/Post registrations are extracted through auto-detection alone (nil
+// generator config), with the stdlib response surface layered underneath.
+func TestTestdata_RegisteredFramework(t *testing.T) {
+	cfg := spec.DefaultHTTPConfig()
+	cfg.Framework.RoutePatterns = []spec.RoutePattern{{
+		CallRegex:       `^(Get|Post)$`,
+		MethodFromCall:  true,
+		PathFromArg:     true,
+		HandlerFromArg:  true,
+		PathArgIndex:    0,
+		HandlerArgIndex: 1,
+		RecvTypeRegex:   `^github\.com/ehabterra/apispec/testdata/registered_framework/router\.\*?Router$`,
+	}}
+	spec.RegisterFrameworkConfig("acme-router",
+		spec.ImportDetector(`/testdata/registered_framework/router$`), cfg)
+	t.Cleanup(func() { spec.UnregisterFrameworkConfig("acme-router") })
+
+	out := loadTestdata(t, "registered_framework", nil)
+	noDanglingRefs(t, out)
+
+	widgets, ok := out.Paths["/widgets"]
+	if !ok {
+		t.Fatalf("/widgets missing; have %v", mapPathKeys(out.Paths))
+	}
+	if widgets.Get == nil || widgets.Post == nil {
+		t.Fatalf("want GET and POST /widgets, got get=%v post=%v", widgets.Get != nil, widgets.Post != nil)
+	}
+	if _, ok := widgets.Post.Responses["201"]; !ok {
+		t.Errorf("POST /widgets should document 201, got %v", widgets.Post.Responses)
+	}
+	if len(cfg.Framework.ResponsePatterns) == 0 || len(cfg.Framework.RoutePatterns) != 1 {
+		t.Errorf("registered config must not be mutated by generation")
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_ReplacedModule covers a model declared in a module go.mod
+// replaces with a local directory: it is analyzed with the project and
+// documented as a full component, not an opaque external type.
+func TestTestdata_ReplacedModule(t *testing.T) {
+	g := NewGenerator(spec.DefaultHTTPConfig())
+	openapi, err := g.GenerateFromDirectory(filepath.Join("..", "testdata", "replaced_module", "app"))
+	if err != nil {
+		t.Fatalf("GenerateFromDirectory: %v", err)
+	}
+	if err := spec.ValidateSpec(openapi); err != nil {
+		t.Fatalf("ValidateSpec: %v", err)
+	}
+	noDanglingRefs(t, openapi)
+
+	order := openapi.Components.Schemas["github_com_acme_shared_models_Order"]
+	if order == nil {
+		t.Fatalf("no Order component: %v", slices.Sorted(maps.Keys(openapi.Components.Schemas)))
+	}
+	if got, want := slices.Sorted(maps.Keys(order.Properties)), []string{"id", "items", "total"}; !slices.Equal(got, want) {
+		t.Errorf("Order properties = %v, want %v", got, want)
+	}
+	if items := order.Properties["items"]; items == nil || items.Items == nil || items.Items.Ref != "#/components/schemas/github_com_acme_shared_models_Item" {
+		t.Errorf("Order.items = %+v, want an array of Item", items)
+	}
+}
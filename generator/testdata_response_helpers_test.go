@@ -0,0 +1,90 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_ResponseHelpers covers config-declared response helpers on the
+// generic fixture, where every handler answers through
+// respondWithSuccess(w, data) / respondWithError(w, msg, status). Without the
+// helpers each 200 is the APIResponse envelope the helper encodes, its data
+// specialised to the route's TResponse; with them the body is read from the
+// handler's own argument, resolved through the HandleRequest[TReq, TResp]
+// instantiation, and the error statuses still come from the call sites.
+func TestTestdata_ResponseHelpers(t *testing.T) {
+	cfg := spec.DefaultHTTPConfig()
+	arg := func(i int) *int { return &i }
+	cfg.Framework.ResponseHelpers = []spec.ResponseHelperPattern{
+		{CallRegex: "^respondWithSuccess$", BodyArgIndex: arg(1), StatusArgIndex: arg(-1), DefaultStatus: 200},
+		{CallRegex: "^respondWithError$", BodyArgIndex: arg(-1), StatusArgIndex: arg(2)},
+	}
+	out := loadTestdata(t, "generic", cfg)
+	noDanglingRefs(t, out)
+
+	want := map[string]string{
+		"/api/email/send": "SendEmailResponse",
+		"/api/users":      "CreateUserResponse",
+		"/api/users/list": "ListUsersResponse",
+	}
+	for path, body := range want {
+		item, ok := out.Paths[path]
+		if !ok {
+			t.Fatalf("path %s missing; have %v", path, mapPathKeys(out.Paths))
+		}
+		op := opFor(item, "POST")
+		if op == nil {
+			t.Fatalf("POST %s missing", path)
+		}
+		ok200, has := op.Responses["200"]
+		if !has || ok200.Content["application/json"].Schema == nil {
+			t.Fatalf("POST %s has no 200 body; have %v", path, keysOf(op.Responses))
+		}
+		if ref := ok200.Content["application/json"].Schema.Ref; !strings.HasSuffix(ref, "_"+body) {
+			t.Errorf("POST %s 200 body = %q, want the handler's %s", path, ref, body)
+		}
+		for _, status := range []string{"400", "500"} {
+			if _, has := op.Responses[status]; !has {
+				t.Errorf("POST %s missing status %s; have %v", path, status, keysOf(op.Responses))
+			}
+		}
+	}
+}
+
+// TestTestdata_ResponseHelpers_Unconfigured pins the baseline the helpers
+// improve on: without them the success body is the helper's own envelope,
+// with its data specialised to the handler's response type.
+func TestTestdata_ResponseHelpers_Unconfigured(t *testing.T) {
+	out := loadTestdata(t, "generic", spec.DefaultHTTPConfig())
+	op := opFor(out.Paths["/api/users"], "POST")
+	if op == nil {
+		t.Fatal("POST /api/users missing")
+	}
+	ok200, has := op.Responses["200"]
+	if !has || ok200.Content["application/json"].Schema == nil {
+		t.Fatalf("POST /api/users has no 200 body; have %v", keysOf(op.Responses))
+	}
+	schema := ok200.Content["application/json"].Schema
+	if len(schema.AllOf) != 2 || !strings.Contains(schema.AllOf[0].Ref, "APIResponse") {
+		t.Fatalf("unconfigured 200 body = %+v, want the APIResponse envelope specialised", schema)
+	}
+	if data := schema.AllOf[1].Properties["data"]; data == nil || !strings.HasSuffix(data.Ref, "_CreateUserResponse") {
+		t.Errorf("unconfigured 200 data = %+v, want the handler's CreateUserResponse", data)
+	}
+}
@@ -37,7 +37,7 @@ func TestTestdata_RecursiveTypes(t *testing.T) {
 	noDanglingRefs(t, out)
 	noUnresolvedPlaceholders(t, out)
 
-	for _, p := range []string{"/tree", "/category", "/graph"} {
+	for _, p := range []string{"/tree", "/category", "/graph", "/thread", "/scores"} {
 		if !hasPath(out, p) {
 			t.Errorf("path %q missing; have %v", p, mapPathKeys(out.Paths))
 		}
@@ -45,7 +45,7 @@ func TestTestdata_RecursiveTypes(t *testing.T) {
 
 	// Every cyclic type must be registered as its own component so the cycle
 	// can close as a $ref.
-	for _, suffix := range []string{"_TreeNode", "_Category", "_Product", "_Graph", "_Edge", "_Node"} {
+	for _, suffix := range []string{"_TreeNode", "_Category", "_Product", "_Graph", "_Edge", "_Node", "_Thread", "_Page_Thread", "_Tree_int"} {
 		if componentByName(out, suffix) == nil {
 			t.Errorf("expected component ending in %q; have %v", suffix, mapSchemaKeys(out.Components.Schemas))
 		}
@@ -59,6 +59,10 @@ func TestTestdata_RecursiveTypes(t *testing.T) {
 	}
 	assertPropRefSuffix(t, tree, "parent", "TreeNode")
 	assertArrayPropItemsRefSuffix(t, tree, "children", "TreeNode")
+	if byName := tree.Properties["byName"]; byName == nil || byName.AdditionalProperties == nil ||
+		!strings.HasSuffix(byName.AdditionalProperties.Ref, "recursive_types_TreeNode") {
+		t.Errorf("byName = %+v, want a map of $refs to TreeNode", byName)
+	}
 
 	// Mutual cycle: Category.products[] -> Product and Product.category ->
 	// Category. Both directions must resolve to a $ref, not inline forever.
@@ -72,6 +76,26 @@ func TestTestdata_RecursiveTypes(t *testing.T) {
 	// Category also nests under a parent Category (self-cycle alongside the
 	// mutual one).
 	assertPropRefSuffix(t, category, "parent", "Category")
+
+	// Generic cycles close on the instantiation: Page[Thread].next ->
+	// Page[Thread], Thread.replies -> Page[Thread] -> Thread, and
+	// Tree[int].children[] -> Tree[int]. No component is generated for the
+	// declaration Page[T] or Tree[T].
+	thread := componentByName(out, "recursive_types_Thread")
+	page := componentByName(out, "_Page_Thread")
+	scores := componentByName(out, "_Tree_int")
+	if thread == nil || page == nil || scores == nil {
+		t.Fatalf("Thread/Page[Thread]/Tree[int] components missing")
+	}
+	assertPropRefSuffix(t, thread, "replies", "_Page_Thread")
+	assertPropRefSuffix(t, page, "next", "_Page_Thread")
+	assertArrayPropItemsRefSuffix(t, page, "items", "recursive_types_Thread")
+	assertArrayPropItemsRefSuffix(t, scores, "children", "_Tree_int")
+	for _, suffix := range []string{"_Page_T", "_Tree_T"} {
+		if componentByName(out, suffix) != nil {
+			t.Errorf("unexpected component for the declaration %s; have %v", suffix, mapSchemaKeys(out.Components.Schemas))
+		}
+	}
 }
 
 // assertPropRefSuffix asserts that schema.Properties[prop] is a $ref whose
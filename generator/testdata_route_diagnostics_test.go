@@ -0,0 +1,77 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/diag"
+	"github.com/ehabterra/apispec/internal/engine"
+	intspec "github.com/ehabterra/apispec/internal/spec"
+)
+
+// TestTestdata_RouteDiagnostics covers the routes the analysis cannot
+// resolve: a path built by an expression is dropped and reported as an
+// error, a path returned by a call is documented under a placeholder, and
+// handlers that are an interface method, a map element or an interface value
+// are documented empty. Each is reported at its registration.
+func TestTestdata_RouteDiagnostics(t *testing.T) {
+	ec := engine.DefaultEngineConfig()
+	ec.InputDir = filepath.Join("..", "testdata", "route_diagnostics")
+	e := engine.NewEngine(ec)
+	out, err := e.GenerateOpenAPI()
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI: %v", err)
+	}
+
+	for _, path := range []string{"/users", "/{ordersPath}", "/plugins", "/reports", "/fallback"} {
+		if _, ok := out.Paths[path]; !ok {
+			t.Errorf("path %s missing; have %v", path, mapPathKeys(out.Paths))
+		}
+	}
+	if len(out.Paths) != 5 {
+		t.Errorf("paths = %v, want the dropped /invoices route left out", mapPathKeys(out.Paths))
+	}
+
+	want := []struct {
+		reason   string
+		dropped  bool
+		method   string
+		path     string
+		line     int
+		severity diag.Severity
+	}{
+		{intspec.UnresolvedPath, true, "", "", 61, diag.Error},
+		{intspec.UnresolvedPrefix, false, "POST", "/{ordersPath}", 60, diag.Warning},
+		{intspec.UnresolvedHandler, false, "GET", "/plugins", 45, diag.Warning},
+		{intspec.UnresolvedHandler, false, "GET", "/reports", 48, diag.Warning},
+		{intspec.UnresolvedHandler, false, "GET", "/fallback", 51, diag.Warning},
+	}
+	got := e.GetUnresolvedRoutes()
+	if len(got) != len(want) {
+		t.Fatalf("unresolved routes = %+v, want %d", got, len(want))
+	}
+	diags := (&intspec.SecurityDiagnostics{UnresolvedRoutes: got}).Diagnostics()
+	for i, w := range want {
+		r, d := got[i], diags[i]
+		if r.Reason != w.reason || r.Dropped != w.dropped || r.Method != w.method || r.Path != w.path {
+			t.Errorf("route %d = %+v, want %s %s %s (dropped %v)", i, r, w.reason, w.method, w.path, w.dropped)
+		}
+		if filepath.Base(d.Pos.File) != "main.go" || d.Pos.Line != w.line || d.Severity != w.severity {
+			t.Errorf("route %d diagnostic = %v at %s, want %v at main.go:%d", i, d.Severity, d.Pos, w.severity, w.line)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_RouteFilters covers include.routes and exclude.routes: routes
+// are kept or dropped by method and path glob after extraction, and schemas
+// only the dropped routes used leave the spec with them.
+func TestTestdata_RouteFilters(t *testing.T) {
+	tests := []struct {
+		name             string
+		include, exclude []string
+		want             map[string][]string // path -> methods
+	}{
+		{
+			name: "no filters",
+			want: map[string][]string{
+				"/users":                {"GET"},
+				"/users/{id}":           {"DELETE", "GET"},
+				"/internal/cache/stats": {"GET"},
+				"/internal/cache/flush": {"POST"},
+			},
+		},
+		{
+			name:    "exclude a subtree",
+			exclude: []string{"/internal/**"},
+			want: map[string][]string{
+				"/users":      {"GET"},
+				"/users/{id}": {"DELETE", "GET"},
+			},
+		},
+		{
+			name:    "exclude by method",
+			exclude: []string{"delete /users/*", "POST /internal/**"},
+			want: map[string][]string{
+				"/users":                {"GET"},
+				"/users/{id}":           {"GET"},
+				"/internal/cache/stats": {"GET"},
+			},
+		},
+		{
+			name:    "include then exclude",
+			include: []string{"GET /**"},
+			exclude: []string{"/internal/**"},
+			want: map[string][]string{
+				"/users":      {"GET"},
+				"/users/{id}": {"GET"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := spec.DefaultHTTPConfig()
+			cfg.Include.Routes = tc.include
+			cfg.Exclude.Routes = tc.exclude
+			out := loadTestdataWithFixtureConfig(t, "route_filters", cfg)
+			if err := spec.ValidateSpec(out); err != nil {
+				t.Fatalf("ValidateSpec: %v", err)
+			}
+			got := map[string][]string{}
+			for path, item := range out.Paths {
+				for _, op := range item.Operations() {
+					got[path] = append(got[path], op.Method)
+				}
+				slices.Sort(got[path])
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("paths = %v, want %v", got, tc.want)
+			}
+			for path, methods := range tc.want {
+				if !slices.Equal(got[path], methods) {
+					t.Errorf("%s: methods = %v, want %v", path, got[path], methods)
+				}
+			}
+			hasStats := out.Components != nil && out.Components.Schemas["github_com_ehabterra_apispec_testdata_route_filters_CacheStats"] != nil
+			if wantStats := tc.want["/internal/cache/stats"] != nil; hasStats != wantStats {
+				t.Errorf("CacheStats schema present = %v, want %v", hasStats, wantStats)
+			}
+		})
+	}
+}
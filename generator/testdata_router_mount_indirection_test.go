@@ -0,0 +1,58 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_RouterMountIndirection locks in mount prefixes for chi
+// sub-routers that reach Mount through plumbing: struct fields set in a
+// returned composite literal or afterwards, a router and its prefix passed
+// down through two helper calls, and an element of a slice or a map of
+// routers. No route is left at the sub-router's own, unprefixed path.
+func TestTestdata_RouterMountIndirection(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "router_mount_indirection", spec.DefaultChiConfig())
+	noDanglingRefs(t, out)
+
+	want := map[string]string{
+		"/users/{id}":        "showUser",    // &Modules{Users: userRouter()} from NewModules
+		"/invoices/{number}": "showInvoice", // m.Invoices = invoiceRouter()
+		"/tickets/{id}":      "showTicket",  // mountTickets -> mountSub(root, prefix, sub)
+		"/reports/{name}":    "showReport",  // []chi.Router{...}[0]
+		"/plans/{tier}":      "showPlan",    // map[string]chi.Router{...}["plans"]
+	}
+	for path, handler := range want {
+		item, ok := out.Paths[path]
+		if !ok {
+			t.Errorf("path %q missing; have %v", path, mapPathKeys(out.Paths))
+			continue
+		}
+		get := opFor(item, "GET")
+		if get == nil {
+			t.Errorf("GET %s: expected operation, missing", path)
+			continue
+		}
+		if !strings.HasSuffix(get.OperationID, "."+handler) {
+			t.Errorf("GET %s operationId = %q, want %s", path, get.OperationID, handler)
+		}
+	}
+	if len(out.Paths) != len(want) {
+		t.Errorf("paths = %v, want only the five prefixed routes", mapPathKeys(out.Paths))
+	}
+}
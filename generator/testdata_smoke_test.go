@@ -15,7 +15,10 @@
 package generator
 
 import (
+	"maps"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
@@ -239,6 +242,33 @@ func TestTestdata_EnumValidation(t *testing.T) {
 		t.Error("expected at least one property to expose an enum, found none")
 	}
 	noUnresolvedPlaceholders(t, out)
+
+	// Typed const blocks become enums named after their constants; the iota
+	// block keeps its untyped followers and its zero value.
+	user := out.Components.Schemas["github_com_ehabterra_apispec_testdata_enum_validation_User"]
+	if user == nil {
+		t.Fatalf("User schema missing; have %v", slices.Sorted(maps.Keys(out.Components.Schemas)))
+	}
+	for _, tc := range []struct {
+		prop  string
+		enum  []interface{}
+		names []string
+	}{
+		{"status", []interface{}{"active", "inactive", "pending"}, []string{"StatusActive", "StatusInactive", "StatusPending"}},
+		{"priority", []interface{}{int64(0), int64(1), int64(2), int64(3)}, []string{"PriorityLow", "PriorityMedium", "PriorityHigh", "PriorityCritical"}},
+	} {
+		prop := user.Properties[tc.prop]
+		if prop == nil {
+			t.Errorf("%s: property missing", tc.prop)
+			continue
+		}
+		if !reflect.DeepEqual(prop.Enum, tc.enum) {
+			t.Errorf("%s enum = %#v, want %#v", tc.prop, prop.Enum, tc.enum)
+		}
+		if got := prop.Extensions["x-enum-varnames"]; !reflect.DeepEqual(got, tc.names) {
+			t.Errorf("%s x-enum-varnames = %v, want %v", tc.prop, got, tc.names)
+		}
+	}
 }
 
 func anySchemaHasEnum(out *spec.OpenAPISpec) bool {
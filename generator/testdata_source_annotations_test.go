@@ -0,0 +1,129 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/engine"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_SourceAnnotations covers x-go-source and x-go-function: a
+// method handler of a sub-package points at its declaration under the
+// package's directory, a package-level handler at its function, a closure
+// only at the literal, and a component at its type, an instantiation of a
+// generic type at the generic declaration. Without sourceAnnotations, or
+// --source-annotations, nothing is added.
+func TestTestdata_SourceAnnotations(t *testing.T) {
+	dir := filepath.Join("..", "testdata", "source_annotations")
+	const mod = "github.com/ehabterra/apispec/testdata/source_annotations"
+	generate := func(t *testing.T, configure func(*engine.EngineConfig)) *spec.OpenAPISpec {
+		t.Helper()
+		ec := engine.DefaultEngineConfig()
+		ec.InputDir = dir
+		configure(ec)
+		out, err := engine.NewEngine(ec).GenerateOpenAPI()
+		if err != nil {
+			t.Fatalf("GenerateOpenAPI: %v", err)
+		}
+		if issues := spec.ValidateSpec(out); len(issues) > 0 {
+			t.Errorf("spec issues: %v", issues)
+		}
+		return out
+	}
+
+	check := func(t *testing.T, out *spec.OpenAPISpec) {
+		t.Helper()
+		for _, tc := range []struct {
+			method, path     string
+			source, function string
+		}{
+			{"GET", "/orders", "handlers/orders.go:25", mod + "/handlers.OrderHandler.List"},
+			{"GET", "/orders/{id}", "handlers/orders.go:30", mod + "/handlers.OrderHandler.Get"},
+			{"POST", "/orders", "main.go:16", mod + ".createOrder"},
+			{"GET", "/ping", "main.go:32", ""},
+		} {
+			op := opFor(out.Paths[tc.path], tc.method)
+			if op == nil {
+				t.Fatalf("%s %s missing; have %v", tc.method, tc.path, mapPathKeys(out.Paths))
+			}
+			if got := op.Extensions["x-go-source"]; got != tc.source {
+				t.Errorf("%s %s x-go-source = %v, want %q", tc.method, tc.path, got, tc.source)
+			}
+			got, ok := op.Extensions["x-go-function"]
+			if tc.function == "" && ok {
+				t.Errorf("%s %s x-go-function = %v, want none", tc.method, tc.path, got)
+			} else if tc.function != "" && got != tc.function {
+				t.Errorf("%s %s x-go-function = %v, want %q", tc.method, tc.path, got, tc.function)
+			}
+		}
+
+		for _, tc := range []struct {
+			name, source, function string
+		}{
+			{"github_com_ehabterra_apispec_testdata_source_annotations_CreateOrder", "main.go:11", mod + ".CreateOrder"},
+			{"github_com_ehabterra_apispec_testdata_source_annotations_handlers_Order", "handlers/orders.go:10", mod + "/handlers.Order"},
+			{"github_com_ehabterra_apispec_testdata_source_annotations_handlers_Page_Order", "handlers/orders.go:16", mod + "/handlers.Page"},
+		} {
+			s := out.Components.Schemas[tc.name]
+			if s == nil {
+				t.Fatalf("schema %s missing; have %v", tc.name, keysOf(out.Components.Schemas))
+			}
+			if got := s.Extensions["x-go-source"]; got != tc.source {
+				t.Errorf("%s x-go-source = %v, want %q", tc.name, got, tc.source)
+			}
+			if got := s.Extensions["x-go-function"]; got != tc.function {
+				t.Errorf("%s x-go-function = %v, want %q", tc.name, got, tc.function)
+			}
+		}
+		if ext := out.Components.Schemas["github_com_gin-gonic_gin_H"].Extensions; len(ext) > 0 {
+			t.Errorf("external gin.H annotated: %v", ext)
+		}
+	}
+
+	t.Run("config", func(t *testing.T) {
+		check(t, generate(t, func(ec *engine.EngineConfig) {
+			ec.ConfigFile = filepath.Join(dir, "apispec.yaml")
+		}))
+	})
+	t.Run("flag", func(t *testing.T) {
+		check(t, generate(t, func(ec *engine.EngineConfig) {
+			ec.SourceAnnotations = true
+		}))
+	})
+	t.Run("off by default", func(t *testing.T) {
+		out := generate(t, func(*engine.EngineConfig) {})
+		for path, item := range out.Paths {
+			for _, method := range []string{"GET", "POST"} {
+				if op := opFor(item, method); op != nil {
+					for _, key := range []string{"x-go-source", "x-go-function"} {
+						if _, ok := op.Extensions[key]; ok {
+							t.Errorf("%s %s has %s", method, path, key)
+						}
+					}
+				}
+			}
+		}
+		for name, s := range out.Components.Schemas {
+			for _, key := range []string{"x-go-source", "x-go-function"} {
+				if _, ok := s.Extensions[key]; ok {
+					t.Errorf("schema %s has %s", name, key)
+				}
+			}
+		}
+	})
+}
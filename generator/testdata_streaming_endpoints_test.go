@@ -0,0 +1,134 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_StreamingEndpoints locks in SSE detection: a verb-less
+// HandleFunc whose handler writes `Content-Type: text/event-stream` is a GET
+// operation marked x-sse with a text/event-stream success body, while a
+// handler setting an ordinary Content-Type stays unmarked.
+func TestTestdata_StreamingEndpoints(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "streaming_endpoints", spec.DefaultHTTPConfig())
+	noDanglingRefs(t, out)
+
+	events, ok := out.Paths["/events"]
+	if !ok {
+		t.Fatalf("/events missing; have %v", mapPathKeys(out.Paths))
+	}
+	if events.Post != nil {
+		t.Errorf("/events must not fall back to the POST default")
+	}
+	op := events.Get
+	if op == nil {
+		t.Fatalf("GET /events missing")
+	}
+	if op.Extensions["x-sse"] != true {
+		t.Errorf("GET /events should carry x-sse, got %v", op.Extensions)
+	}
+	if _, ok := op.Responses["200"].Content["text/event-stream"]; !ok {
+		t.Errorf("GET /events 200 should be text/event-stream, got %+v", op.Responses["200"])
+	}
+
+	status := out.Paths["/status"].Get
+	if status == nil {
+		t.Fatalf("GET /status missing")
+	}
+	if len(status.Extensions) != 0 {
+		t.Errorf("GET /status must not be marked as streaming, got %v", status.Extensions)
+	}
+}
+
+// TestTestdata_WebsocketUpgrade covers the gorilla/websocket upgrade mounted
+// under /ws in another_chi_router: the operation is marked x-websocket and
+// documents the 101 handshake response.
+func TestTestdata_WebsocketUpgrade(t *testing.T) {
+	out := loadTestdata(t, "another_chi_router", spec.DefaultChiConfig())
+
+	item, ok := out.Paths["/ws/websocket/"]
+	if !ok {
+		t.Fatalf("/ws/websocket/ missing; have %v", mapPathKeys(out.Paths))
+	}
+	op := item.Get
+	if op == nil {
+		t.Fatalf("GET /ws/websocket/ missing")
+	}
+	if op.Extensions["x-websocket"] != true {
+		t.Errorf("websocket operation should carry x-websocket, got %v", op.Extensions)
+	}
+	if _, ok := op.Responses["101"]; !ok {
+		t.Errorf("websocket operation should document 101 Switching Protocols, got %v", op.Responses)
+	}
+}
+
+// TestTestdata_NDJSONStreams covers newline-delimited JSON: a handler
+// announcing application/x-ndjson, or flushing encoded JSON chunk by chunk,
+// documents the encoded value as the item of each line; one flushing plain
+// text is only marked chunked; and a protocolPatterns entry marks the
+// project's own streaming helper.
+func TestTestdata_NDJSONStreams(t *testing.T) {
+	const prefix = "#/components/schemas/github_com_ehabterra_apispec_testdata_streaming_endpoints_"
+	cfg := spec.DefaultHTTPConfig()
+	cfg.Framework.ProtocolPatterns = append(cfg.Framework.ProtocolPatterns, spec.ProtocolPattern{
+		CallRegex: `^writeLines$`,
+		Protocol:  "ndjson",
+	})
+	out := loadTestdataWithFixtureConfig(t, "streaming_endpoints", cfg)
+	if err := spec.ValidateSpec(out); err != nil {
+		t.Fatalf("ValidateSpec: %v", err)
+	}
+
+	for path, item := range map[string]string{
+		"/orders/export": "Order",
+		"/logs":          "LogLine",
+		"/orders/recent": "Order",
+	} {
+		op := out.Paths[path].Get
+		if op == nil {
+			t.Errorf("GET %s missing", path)
+			continue
+		}
+		if op.Extensions["x-ndjson"] != true {
+			t.Errorf("GET %s should carry x-ndjson, got %v", path, op.Extensions)
+		}
+		var items []string
+		for status, resp := range op.Responses {
+			if mt, ok := resp.Content["application/x-ndjson"]; ok && mt.Schema != nil {
+				items = append(items, mt.Schema.Ref)
+			}
+			if _, ok := resp.Content["application/json"]; ok {
+				t.Errorf("GET %s %s must not document the item as the whole JSON body: %+v", path, status, resp.Content)
+			}
+		}
+		if len(items) != 1 || items[0] != prefix+item {
+			t.Errorf("GET %s should stream %s items, got %v", path, item, items)
+		}
+	}
+
+	progress := out.Paths["/jobs/progress"].Post
+	if progress == nil {
+		t.Fatalf("POST /jobs/progress missing")
+	}
+	if progress.Extensions["x-chunked"] != true || progress.Extensions["x-ndjson"] != nil {
+		t.Errorf("POST /jobs/progress should only be marked x-chunked, got %v", progress.Extensions)
+	}
+	if events := out.Paths["/events"].Get; events == nil || events.Extensions["x-sse"] != true {
+		t.Errorf("flushing must not turn the SSE stream into another protocol: %+v", events)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_SwaggoAnnotations covers swaggo annotations merged over the
+// inferred operation: typed and constrained @Param, an @Param body replacing
+// an untyped decode, @Success/@Failure replacing the inferred responses,
+// @ID/@Tags/@Security/@Deprecated, and @Router documenting a handler the
+// analysis never sees registered.
+func TestTestdata_SwaggoAnnotations(t *testing.T) {
+	out := loadTestdataWithFixtureConfig(t, "swaggo_annotations", spec.DefaultHTTPConfig())
+	noDanglingRefs(t, out)
+
+	show := opFor(out.Paths["/accounts/{id}"], "GET")
+	if show == nil {
+		t.Fatalf("GET /accounts/{id} missing; paths %v", mapPathKeys(out.Paths))
+	}
+	if show.OperationID != "getAccount" || !reflect.DeepEqual(show.Tags, []string{"accounts"}) {
+		t.Errorf("operationId/tags = %q %v", show.OperationID, show.Tags)
+	}
+	params := paramsByName(t, show, "GET /accounts/{id}")
+	if id := params["id"]; id.Schema.Type != "integer" || !boundIs(id.Schema.Minimum, 1) || id.Description != "Account ID" {
+		t.Errorf("id = %+v, schema %+v", id, id.Schema)
+	}
+	if s := params["fields"].Schema; !reflect.DeepEqual(s.Enum, []any{"id", "name"}) || s.Default != "id" {
+		t.Errorf("fields schema = %+v", s)
+	}
+	if got := slices.Sorted(maps.Keys(show.Responses)); !reflect.DeepEqual(got, []string{"200", "400", "404"}) {
+		t.Errorf("responses = %v, want the annotated statuses only", got)
+	}
+	if r := show.Responses["404"]; r.Description != "No such account" ||
+		!strings.HasSuffix(r.Content["application/json"].Schema.Ref, "_HTTPError") {
+		t.Errorf("404 = %+v", r)
+	}
+	if sec := show.Security; sec == nil || len(*sec) != 2 || !reflect.DeepEqual((*sec)[1]["OAuth2"], []string{"read", "admin"}) {
+		t.Errorf("security = %v", sec)
+	}
+
+	create := opFor(out.Paths["/accounts"], "POST")
+	if create == nil {
+		t.Fatal("POST /accounts missing")
+	}
+	if body := create.RequestBody; body == nil || !body.Required ||
+		!strings.HasSuffix(body.Content["application/json"].Schema.Ref, "_CreateAccount") {
+		t.Errorf("request body = %+v", body)
+	}
+	if !create.Deprecated {
+		t.Error("POST /accounts is @Deprecated")
+	}
+	if r := create.Responses["422"]; r.Description != "Invalid account" || r.Content["text/plain; charset=utf-8"].Schema == nil {
+		t.Errorf("bare @Failure should keep the inferred 422 body and set its description: %+v", r)
+	}
+
+	ping := opFor(out.Paths["/legacy/ping"], "GET")
+	if ping == nil {
+		t.Fatalf("@Router-only GET /legacy/ping missing; paths %v", mapPathKeys(out.Paths))
+	}
+	if h := paramsByName(t, ping, "GET /legacy/ping")["X-Request-ID"]; h.In != "header" {
+		t.Errorf("X-Request-ID = %+v", h)
+	}
+	if r := ping.Responses["200"]; r.Description != "pong" || r.Content["application/json"].Schema.Type != "string" {
+		t.Errorf("200 = %+v", r)
+	}
+
+	broken := opFor(out.Paths["/broken"], "GET")
+	if broken == nil || len(broken.Parameters) != 0 || broken.Responses["200"].Description != "" {
+		t.Errorf("malformed annotations should be skipped: %+v", broken)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_TimeLayouts covers the time layout check: marshalers writing a
+// date where the schema says date-time (a Booking field, the Day type), a
+// layout no format describes (LegacyTime) and a time type documented as an
+// object (Stamp) are reported; the field whose validate tag declares the
+// date it is written as is not.
+func TestTestdata_TimeLayouts(t *testing.T) {
+	g := NewGenerator(spec.DefaultHTTPConfig())
+	if _, err := g.GenerateFromDirectory(filepath.Join("..", "testdata", "time_layouts")); err != nil {
+		t.Fatalf("GenerateFromDirectory: %v", err)
+	}
+
+	got := g.TimeLayoutMismatches()
+	want := []struct {
+		typ, field, layout, format, declared string
+	}{
+		{"Booking", "checkIn", "2006-01-02", "date", "date-time"},
+		{"Day", "", "2006-01-02", "date", "object"},
+		{"LegacyTime", "", "Mon, 02 Jan 2006 15:04:05 MST", "", "object"},
+		{"Stamp", "", "2006-01-02T15:04:05.999999999Z07:00", "date-time", "object"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("want %d mismatches, got %d: %+v", len(want), len(got), got)
+	}
+	for i, w := range want {
+		m := got[i]
+		if m.Type != w.typ || m.Field != w.field || m.Layout != w.layout || m.Format != w.format || m.Declared != w.declared {
+			t.Errorf("[%d] = %+v, want %s.%s writing %q (%s) over %s", i, m, w.typ, w.field, w.layout, w.format, w.declared)
+		}
+		if !strings.Contains(m.Pos, "main.go:") {
+			t.Errorf("[%d] pos = %q, want the Format call in main.go", i, m.Pos)
+		}
+	}
+}
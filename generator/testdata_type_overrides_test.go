@@ -0,0 +1,123 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/engine"
+	"github.com/ehabterra/apispec/spec"
+)
+
+const typeOverrides = "github_com_ehabterra_apispec_testdata_type_overrides_"
+
+// TestTestdata_TypeOverrides covers the externalTypes overrides of the
+// fixture's apispec.yaml: a glob gives every money type a decimal string
+// schema, a field override an invoice's status its values, and the
+// unlessOmitempty policy makes the pointer fields without omitempty
+// nullable.
+func TestTestdata_TypeOverrides(t *testing.T) {
+	out := generateTypeOverrides(t, nil)
+
+	for _, name := range []string{"money_Amount", "money_Rate"} {
+		if s := out.Components.Schemas[typeOverrides+name]; s == nil || s.Type != "string" || s.Format != "decimal" {
+			t.Errorf("%s = %+v, want a decimal string", name, s)
+		}
+	}
+	invoice := out.Components.Schemas[typeOverrides+"Invoice"]
+	if invoice == nil {
+		t.Fatalf("no Invoice component: %v", slices.Sorted(maps.Keys(out.Components.Schemas)))
+	}
+	if status := invoice.Properties["status"]; status == nil || len(status.Enum) != 3 {
+		t.Errorf("status = %+v, want the overridden enum", status)
+	}
+	for name, nullable := range map[string]bool{"customer": true, "note": true, "discount": false, "total": false} {
+		prop := invoice.Properties[name]
+		if prop == nil {
+			t.Errorf("no %s property", name)
+			continue
+		}
+		if got := len(prop.AnyOf) == 2 && prop.AnyOf[1].Type == "null"; got != nullable {
+			t.Errorf("%s nullable = %v, want %v: %+v", name, got, nullable, prop)
+		}
+	}
+	if !slices.Contains(invoice.Required, "reference") {
+		t.Errorf("required = %v, want the validate-required reference", invoice.Required)
+	}
+}
+
+// TestTestdata_TypeOverrides_NullableStyles writes the fixture's nullable
+// pointers in each style, and leaves its omitempty reference out of
+// required with --omitempty-optional.
+func TestTestdata_TypeOverrides_NullableStyles(t *testing.T) {
+	invoice := func(out *spec.OpenAPISpec) *spec.Schema {
+		t.Helper()
+		s := out.Components.Schemas[typeOverrides+"Invoice"]
+		if s == nil {
+			t.Fatalf("no Invoice component: %v", slices.Sorted(maps.Keys(out.Components.Schemas)))
+		}
+		return s
+	}
+
+	typeArray := invoice(generateTypeOverrides(t, func(ec *engine.EngineConfig) {
+		ec.NullableStyle = "typeArray"
+		ec.OmitemptyOptional = true
+	}))
+	if note := typeArray.Properties["note"]; note.Type != "string" || !note.AllowNull {
+		t.Errorf("typeArray note = %+v, want type [string, null]", note)
+	}
+	if customer := typeArray.Properties["customer"]; len(customer.AnyOf) != 2 {
+		t.Errorf("typeArray customer = %+v, want a $ref or null anyOf", customer)
+	}
+	if slices.Contains(typeArray.Required, "reference") {
+		t.Errorf("required = %v, want the omitempty reference optional", typeArray.Required)
+	}
+
+	// OpenAPI 3.0 has no null type, so nullable: true is its default.
+	openapi30 := invoice(generateTypeOverrides(t, func(ec *engine.EngineConfig) {
+		ec.OpenAPIVersion = "3.0.3"
+	}))
+	if note := openapi30.Properties["note"]; note.Type != "string" || !note.Nullable {
+		t.Errorf("3.0 note = %+v, want nullable: true", note)
+	}
+	if customer := openapi30.Properties["customer"]; len(customer.AllOf) != 1 || customer.AllOf[0].Ref == "" || !customer.Nullable {
+		t.Errorf("3.0 customer = %+v, want a nullable allOf $ref", customer)
+	}
+}
+
+// generateTypeOverrides generates the type_overrides fixture's spec with
+// its apispec.yaml and the engine config adjusted by configure.
+func generateTypeOverrides(t *testing.T, configure func(*engine.EngineConfig)) *spec.OpenAPISpec {
+	t.Helper()
+	dir := filepath.Join("..", "testdata", "type_overrides")
+	ec := engine.DefaultEngineConfig()
+	ec.InputDir = dir
+	ec.ConfigFile = filepath.Join(dir, "apispec.yaml")
+	if configure != nil {
+		configure(ec)
+	}
+	out, err := engine.NewEngine(ec).GenerateOpenAPI()
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI: %v", err)
+	}
+	if issues := spec.ValidateSpec(out); len(issues) > 0 {
+		t.Errorf("spec issues: %v", issues)
+	}
+	noDanglingRefs(t, out)
+	return out
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_UnsafeMethodWrites covers the GET/HEAD write audit: a GET
+// handler deleting through the repository two calls down and a HEAD handler
+// running a raw UPDATE are reported with the nearest mutation; a GET that
+// only reads (and stores into a sync.Map) and the POST that creates are not.
+func TestTestdata_UnsafeMethodWrites(t *testing.T) {
+	g := NewGenerator(spec.DefaultHTTPConfig())
+	if _, err := g.GenerateFromDirectory(filepath.Join("..", "testdata", "unsafe_get_writes")); err != nil {
+		t.Fatalf("GenerateFromDirectory: %v", err)
+	}
+
+	got := g.UnsafeMethodWrites()
+	if len(got) != 2 {
+		t.Fatalf("want 2 unsafe writes, got %d: %+v", len(got), got)
+	}
+	want := []struct {
+		method, path, handler, callee string
+		depth                         int
+	}{
+		{"GET", "/cleanup", "cleanup", "store.Users.DeleteStale", 2},
+		{"HEAD", "/ping", "ping", "sql.DB.ExecContext", 1},
+	}
+	for i, w := range want {
+		m := got[i]
+		if m.Method != w.method || m.Path != w.path {
+			t.Errorf("[%d] = %s %s, want %s %s", i, m.Method, m.Path, w.method, w.path)
+		}
+		if !strings.HasSuffix(m.Handler, w.handler) {
+			t.Errorf("[%d] handler = %q, want it to name %s", i, m.Handler, w.handler)
+		}
+		if m.Callee != w.callee || m.Depth != w.depth {
+			t.Errorf("[%d] callee = %s at depth %d, want %s at depth %d", i, m.Callee, m.Depth, w.callee, w.depth)
+		}
+		if !strings.Contains(m.Pos, "main.go:") {
+			t.Errorf("[%d] pos = %q, want the call in main.go", i, m.Pos)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	intspec "github.com/ehabterra/apispec/internal/spec"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// validateBodyPattern recognises the fixtures' validate.Body(Rules{}) middleware.
+var validateBodyPattern = spec.ValidationPattern{CallRegex: "^Body$", PkgRegex: "/validate$"}
+
+// requestOverlay returns the constraint overlay merged into an operation's
+// request body, failing when the body is not the $ref + overlay allOf.
+func requestOverlay(t *testing.T, out *spec.OpenAPISpec, path string) *intspec.Schema {
+	t.Helper()
+	op := opFor(out.Paths[path], "POST")
+	if op == nil || op.RequestBody == nil {
+		t.Fatalf("POST %s has no request body", path)
+	}
+	schema := op.RequestBody.Content["application/json"].Schema
+	if schema == nil || len(schema.AllOf) != 2 || !strings.HasSuffix(schema.AllOf[0].Ref, "Request") {
+		t.Fatalf("POST %s body = %+v, want allOf [$ref, overlay]", path, schema)
+	}
+	return schema.AllOf[1]
+}
+
+// TestTestdata_ValidationRulesChi covers chi's r.With(validate.Body(Rules{})):
+// the rules struct's constraints reach /users only, leaving the shared
+// CreateUserRequest component (also used by /drafts) untouched.
+func TestTestdata_ValidationRulesChi(t *testing.T) {
+	cfg := spec.DefaultChiConfig()
+	cfg.Framework.ValidationPatterns = []spec.ValidationPattern{validateBodyPattern}
+	out := loadTestdata(t, "validation_rules_chi", cfg)
+	noDanglingRefs(t, out)
+
+	overlay := requestOverlay(t, out, "/users")
+	if got := strings.Join(overlay.Required, ","); got != "email,name" {
+		t.Errorf("overlay required = %q, want email,name", got)
+	}
+	if name := overlay.Properties["name"]; name == nil || name.MinLength != 2 || name.MaxLength != 64 {
+		t.Errorf("name constraints = %+v", name)
+	}
+	if email := overlay.Properties["email"]; email == nil || email.Format != "email" {
+		t.Errorf("email constraints = %+v", email)
+	}
+	if age := overlay.Properties["age"]; age == nil || !boundIs(age.Minimum, 18) || !boundIs(age.Maximum, 130) {
+		t.Errorf("age constraints = %+v", age)
+	}
+
+	draft := opFor(out.Paths["/drafts"], "POST")
+	if draft == nil || draft.RequestBody == nil {
+		t.Fatal("POST /drafts has no request body")
+	}
+	if s := draft.RequestBody.Content["application/json"].Schema; s == nil || s.Ref == "" {
+		t.Errorf("unvalidated /drafts body = %+v, want the plain $ref", s)
+	}
+	for name := range out.Components.Schemas {
+		if strings.HasSuffix(name, "Rules") {
+			t.Errorf("rules struct leaked into components as %s", name)
+		}
+	}
+}
+
+// TestTestdata_ValidationRulesGin covers gin's per-route middleware argument
+// r.POST(path, validate.Body(&Rules{}), h), including a slice's item count.
+func TestTestdata_ValidationRulesGin(t *testing.T) {
+	cfg := spec.DefaultGinConfig()
+	cfg.Framework.ValidationPatterns = []spec.ValidationPattern{validateBodyPattern}
+	out := loadTestdata(t, "validation_rules_gin", cfg)
+	noDanglingRefs(t, out)
+
+	overlay := requestOverlay(t, out, "/orders")
+	if sku := overlay.Properties["sku"]; sku == nil || sku.MinLength != 8 || sku.MaxLength != 8 {
+		t.Errorf("sku constraints = %+v", sku)
+	}
+	if qty := overlay.Properties["quantity"]; qty == nil || !boundIs(qty.Minimum, 1) || !boundIs(qty.Maximum, 100) {
+		t.Errorf("quantity constraints = %+v", qty)
+	}
+	if tags := overlay.Properties["tags"]; tags == nil || tags.Type != "array" || tags.MaxItems != 5 {
+		t.Errorf("tags constraints = %+v", tags)
+	}
+
+	quote := opFor(out.Paths["/quotes"], "POST")
+	if quote == nil || quote.RequestBody == nil {
+		t.Fatal("POST /quotes has no request body")
+	}
+	if s := quote.RequestBody.Content["application/json"].Schema; s == nil || len(s.AllOf) != 0 {
+		t.Errorf("unvalidated /quotes body = %+v, want no overlay", s)
+	}
+}
+
+// TestTestdata_ValidationRules_Unconfigured pins that without a pattern the
+// middleware is ignored and the body stays a plain $ref.
+func TestTestdata_ValidationRules_Unconfigured(t *testing.T) {
+	out := loadTestdata(t, "validation_rules_chi", spec.DefaultChiConfig())
+	op := opFor(out.Paths["/users"], "POST")
+	if op == nil || op.RequestBody == nil {
+		t.Fatal("POST /users has no request body")
+	}
+	if s := op.RequestBody.Content["application/json"].Schema; s == nil || len(s.AllOf) != 0 {
+		t.Errorf("unconfigured /users body = %+v, want no overlay", s)
+	}
+}
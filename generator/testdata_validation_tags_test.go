@@ -33,6 +33,19 @@ func schemaBySuffix(schemas map[string]*intspec.Schema, suffix string) *intspec.
 	return nil
 }
 
+// boundIs reports whether the schema bound p is set to want.
+func boundIs(p *float64, want float64) bool {
+	return p != nil && *p == want
+}
+
+// bound is the schema bound p for a failure message, nil when unset.
+func bound(p *float64) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
 // TestTestdata_ValidationTags covers validator-tag fidelity:
 //   - #167: string min/max → minLength/maxLength; numeric min/max →
 //     minimum/maximum; a decoded JSON body is required.
@@ -79,8 +92,8 @@ func TestTestdata_ValidationTags(t *testing.T) {
 	// Numeric min/max → minimum/maximum (regression guard).
 	if age := req.Properties["age"]; age == nil {
 		t.Error("age property missing")
-	} else if age.Minimum != 18 || age.Maximum != 120 {
-		t.Errorf("age: got minimum=%v maximum=%v, want 18/120", age.Minimum, age.Maximum)
+	} else if !boundIs(age.Minimum, 18) || !boundIs(age.Maximum, 120) {
+		t.Errorf("age: got minimum=%v maximum=%v, want 18/120", bound(age.Minimum), bound(age.Maximum))
 	}
 
 	// #165: on `validate:"min=1,max=10,dive,min=5,max=100"`, the pre-dive rules
@@ -94,8 +107,8 @@ func TestTestdata_ValidationTags(t *testing.T) {
 		t.Errorf("scores: got minItems=%d maxItems=%d, want 1/10 (#165 container)", scores.MinItems, scores.MaxItems)
 	case scores.Items == nil:
 		t.Error("scores.items missing")
-	case scores.Items.Minimum != 5 || scores.Items.Maximum != 100:
-		t.Errorf("scores.items: got minimum=%v maximum=%v, want 5/100 (#165 post-dive elements)", scores.Items.Minimum, scores.Items.Maximum)
+	case !boundIs(scores.Items.Minimum, 5) || !boundIs(scores.Items.Maximum, 100):
+		t.Errorf("scores.items: got minimum=%v maximum=%v, want 5/100 (#165 post-dive elements)", bound(scores.Items.Minimum), bound(scores.Items.Maximum))
 	}
 
 	// #166: a struct-level constraint on a blank marker field surfaces as a note
@@ -107,4 +120,58 @@ func TestTestdata_ValidationTags(t *testing.T) {
 	if !strings.Contains(rng.Description, "gtefield=Min") {
 		t.Errorf("Range: struct-level constraint not surfaced in description; got %q (#166)", rng.Description)
 	}
+
+	// The wider validator vocabulary on PATCH /profile's body.
+	prof := schemaBySuffix(out.Components.Schemas, "UpdateProfileRequest")
+	if prof == nil {
+		t.Fatalf("UpdateProfileRequest schema missing; have %v", mapSchemaKeys(out.Components.Schemas))
+	}
+	for name, want := range map[string]string{"id": "uuid", "email": "email", "website": "uri", "birthday": "date"} {
+		if p := prof.Properties[name]; p == nil || p.Format != want {
+			t.Errorf("%s: want format %q, got %+v", name, want, p)
+		}
+	}
+	if h := prof.Properties["handle"]; h == nil || h.MinLength != 3 || h.MaxLength != 15 {
+		t.Errorf("handle: gt=2,lt=16 should give minLength=3 maxLength=15; got %+v", h)
+	}
+	if r := prof.Properties["rating"]; r == nil || r.Minimum != nil || !boundIs(r.ExclusiveMinimum, 0.5) || !boundIs(r.Maximum, 5) || r.ExclusiveMaximum != nil {
+		t.Errorf("rating: gt=0.5,lte=5 mapped wrong; got %+v", r)
+	}
+	// A zero bound is a bound: it must not vanish as an empty value.
+	if b := prof.Properties["balance"]; b == nil || !boundIs(b.Minimum, 0) || b.ExclusiveMinimum != nil {
+		t.Errorf("balance: gte=0 should give minimum: 0; got %+v", b)
+	}
+	if c := prof.Properties["credit"]; c == nil || c.Minimum != nil || !boundIs(c.ExclusiveMinimum, 0) {
+		t.Errorf("credit: gt=0 should give exclusiveMinimum: 0; got %+v", c)
+	}
+	if d := prof.Properties["debt"]; d == nil || d.Maximum != nil || !boundIs(d.ExclusiveMaximum, 0) {
+		t.Errorf("debt: lt=0 should give exclusiveMaximum: 0; got %+v", d)
+	}
+	if tier := prof.Properties["tier"]; tier == nil || len(tier.Enum) != 3 || tier.Enum[0] != int64(1) {
+		t.Errorf("tier: integer oneof should give a typed enum; got %+v", tier)
+	}
+	if th := prof.Properties["theme"]; th == nil || len(th.Enum) != 2 || th.Enum[0] != "dark blue" {
+		t.Errorf("theme: quoted oneof value split; got %+v", th)
+	}
+	tags := prof.Properties["tags"]
+	switch {
+	case tags == nil:
+		t.Error("tags property missing")
+	case !tags.UniqueItems || tags.MinItems != 1 || tags.MaxItems != 5:
+		t.Errorf("tags: want uniqueItems, minItems=1, maxItems=5; got %+v", tags)
+	case tags.Items == nil || tags.Items.MinLength != 3 || tags.Items.MaxLength != 3:
+		t.Errorf("tags.items: len=3 should pin length; got %+v", tags.Items)
+	}
+	if l := prof.Properties["labels"]; l == nil || l.AdditionalProperties == nil || l.AdditionalProperties.MaxLength != 20 {
+		t.Errorf("labels: map dive should constrain values, not keys; got %+v", l)
+	}
+	phone := prof.Properties["phone"]
+	if phone == nil || !strings.Contains(phone.Description, "required_without=Email") {
+		t.Errorf("phone: conditional requirement not noted; got %+v", phone)
+	}
+	for _, r := range prof.Required {
+		if r == "phone" {
+			t.Error("phone: required_without must not mark the field unconditionally required")
+		}
+	}
 }
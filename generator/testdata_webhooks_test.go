@@ -0,0 +1,104 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	intspec "github.com/ehabterra/apispec/internal/spec"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// TestTestdata_Webhooks covers the webhooks config: a webhook delivered by a
+// background worker's client.Do is emitted under webhooks, one without calls
+// always is, one whose calls match nothing is left out, and one with a
+// callbackURL becomes a callback of the operation whose handler calls
+// http.Post — each with its payload as a component schema.
+func TestTestdata_Webhooks(t *testing.T) {
+	cfg := spec.DefaultHTTPConfig()
+	cfg.Webhooks = []spec.Webhook{
+		{
+			Name:        "orderStatus",
+			Summary:     "Order status changed",
+			PayloadType: "events.OrderStatus",
+			CallbackURL: "{$request.body#/callbackUrl}",
+			Calls:       []spec.WebhookCall{{CallRegex: "^Post$", RecvType: "net/http", FunctionNameRegex: "^notifyOrderStatus$"}},
+		},
+		{
+			Name:        "invoicePaid",
+			OperationID: "invoicePaid",
+			PayloadType: "events.InvoicePaid",
+			Calls:       []spec.WebhookCall{{CallRegex: "^Do$", RecvType: "net/http.*Client", FunctionNameRegex: "^sendInvoicePaid$"}},
+		},
+		{Name: "userDeleted", Method: "put", PayloadType: "github.com/ehabterra/apispec/testdata/webhooks/events.UserDeleted"},
+		{
+			Name:  "refundIssued",
+			Calls: []spec.WebhookCall{{CallRegex: "^Post$", FunctionNameRegex: "^sendRefund$"}},
+		},
+	}
+	out := loadTestdataWithFixtureConfig(t, "webhooks", cfg)
+	noDanglingRefs(t, out)
+	if issues := spec.ValidateSpec(out); len(issues) > 0 {
+		t.Errorf("spec issues: %v", issues)
+	}
+
+	payload := func(t *testing.T, op *intspec.Operation, component string) {
+		t.Helper()
+		if op == nil || op.RequestBody == nil || !op.RequestBody.Required {
+			t.Fatalf("%s: no required payload", component)
+		}
+		ref := op.RequestBody.Content["application/json"].Schema.Ref
+		if !strings.HasSuffix(ref, "_events_"+component) {
+			t.Errorf("payload $ref = %q, want the %s component", ref, component)
+		}
+		if op.Responses["200"].Description == "" {
+			t.Error("no acknowledgement response")
+		}
+	}
+
+	t.Run("webhooks", func(t *testing.T) {
+		if got := slices.Sorted(maps.Keys(out.Webhooks)); !slices.Equal(got, []string{"invoicePaid", "userDeleted"}) {
+			t.Fatalf("webhooks = %v, want invoicePaid and userDeleted", got)
+		}
+		invoice := out.Webhooks["invoicePaid"].Post
+		payload(t, invoice, "InvoicePaid")
+		if invoice.OperationID != "invoicePaid" {
+			t.Errorf("operationId = %q", invoice.OperationID)
+		}
+		payload(t, out.Webhooks["userDeleted"].Put, "UserDeleted")
+	})
+
+	t.Run("callbacks", func(t *testing.T) {
+		create := opFor(out.Paths["/orders"], "POST")
+		if create == nil {
+			t.Fatalf("POST /orders missing; paths %v", mapPathKeys(out.Paths))
+		}
+		callback, ok := create.Callbacks["orderStatus"]
+		if !ok || len(create.Callbacks) != 1 {
+			t.Fatalf("callbacks = %v, want orderStatus", create.Callbacks)
+		}
+		delivery := callback["{$request.body#/callbackUrl}"].Post
+		payload(t, delivery, "OrderStatus")
+		if delivery.Summary != "Order status changed" {
+			t.Errorf("summary = %q", delivery.Summary)
+		}
+		if get := opFor(out.Paths["/orders/{id}"], "GET"); get == nil || get.Callbacks != nil {
+			t.Errorf("GET /orders/{id} callbacks = %v, want none", get)
+		}
+	})
+}
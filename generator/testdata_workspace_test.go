@@ -0,0 +1,71 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+const workspaceModels = "github_com_ehabterra_apispec_testdata_workspace_shared_models_"
+
+// TestTestdata_Workspace covers a go.work workspace: a module's DTOs
+// declared in a sibling module resolve into full components, the services
+// it does not import stay out, and the workspace directory documents every
+// module's routes.
+func TestTestdata_Workspace(t *testing.T) {
+	t.Run("member module", func(t *testing.T) {
+		g := NewGenerator(spec.DefaultHTTPConfig())
+		openapi, err := g.GenerateFromDirectory(filepath.Join("..", "testdata", "workspace", "api"))
+		if err != nil {
+			t.Fatalf("GenerateFromDirectory: %v", err)
+		}
+		noDanglingRefs(t, openapi)
+
+		if got, want := slices.Sorted(maps.Keys(openapi.Paths)), []string{"/orders", "/orders/{id}"}; !slices.Equal(got, want) {
+			t.Errorf("paths = %v, want %v: the admin service is not imported", got, want)
+		}
+		order := openapi.Components.Schemas[workspaceModels+"Order"]
+		if order == nil {
+			t.Fatalf("no Order component: %v", slices.Sorted(maps.Keys(openapi.Components.Schemas)))
+		}
+		if got, want := slices.Sorted(maps.Keys(order.Properties)), []string{"customer", "id", "items", "placedAt"}; !slices.Equal(got, want) {
+			t.Errorf("Order properties = %v, want %v", got, want)
+		}
+		if customer := order.Properties["customer"]; customer == nil || customer.Ref != "#/components/schemas/"+workspaceModels+"Customer" {
+			t.Errorf("Order.customer = %+v, want a Customer reference", customer)
+		}
+	})
+
+	t.Run("workspace directory", func(t *testing.T) {
+		g := NewGenerator(spec.DefaultHTTPConfig())
+		openapi, err := g.GenerateFromDirectory(filepath.Join("..", "testdata", "workspace"))
+		if err != nil {
+			t.Fatalf("GenerateFromDirectory: %v", err)
+		}
+		noDanglingRefs(t, openapi)
+
+		if got, want := slices.Sorted(maps.Keys(openapi.Paths)), []string{"/admin/stats", "/orders", "/orders/{id}"}; !slices.Equal(got, want) {
+			t.Errorf("paths = %v, want %v", got, want)
+		}
+		if openapi.Components.Schemas[workspaceModels+"Order"] == nil {
+			t.Errorf("no Order component: %v", slices.Sorted(maps.Keys(openapi.Components.Schemas)))
+		}
+	})
+}
@@ -18,8 +18,13 @@ import (
 	"go/parser"
 	"go/token"
 	"io/fs"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+	"github.com/ehabterra/apispec/internal/spec"
 )
 
 // FrameworkDetector detects the web framework used in a project
@@ -75,18 +80,8 @@ func (d *FrameworkDetector) DetectAll(dir string) ([]string, error) {
 		}
 
 		for _, imp := range f.Imports {
-			importPath := strings.Trim(imp.Path.Value, "\"")
-			switch {
-			case strings.Contains(importPath, "gin-gonic/gin"):
-				add("gin")
-			case strings.Contains(importPath, "go-chi/chi"):
-				add("chi")
-			case strings.Contains(importPath, "labstack/echo"):
-				add("echo")
-			case strings.Contains(importPath, "gofiber/fiber"):
-				add("fiber")
-			case strings.Contains(importPath, "gorilla/mux"):
-				add("mux")
+			if name := importFramework(strings.Trim(imp.Path.Value, "\"")); name != "" {
+				add(name)
 			}
 		}
 		if len(frameworks) == knownFrameworks {
@@ -100,6 +95,87 @@ func (d *FrameworkDetector) DetectAll(dir string) ([]string, error) {
 	return frameworks, nil
 }
 
+// DetectAllFromMetadata is DetectAll over the imports meta records, for
+// metadata loaded without its sources. Packages and files are visited in
+// name order, so the primary framework is deterministic, though it may
+// differ from DetectAll's file-walk order.
+func (d *FrameworkDetector) DetectAllFromMetadata(meta *metadata.Metadata) []string {
+	var frameworks []string
+	seen := map[string]bool{}
+	if meta != nil && meta.StringPool != nil {
+		for _, pkgName := range slices.Sorted(maps.Keys(meta.Packages)) {
+			files := meta.Packages[pkgName].Files
+			for _, fileName := range slices.Sorted(maps.Keys(files)) {
+				var paths []string
+				for _, path := range files[fileName].Imports {
+					paths = append(paths, meta.StringPool.GetString(path))
+				}
+				slices.Sort(paths)
+				for _, path := range paths {
+					if name := importFramework(path); name != "" && !seen[name] {
+						seen[name] = true
+						frameworks = append(frameworks, name)
+					}
+				}
+			}
+		}
+	}
+
+	if len(frameworks) == 0 {
+		frameworks = append(frameworks, "net/http")
+	}
+	return frameworks
+}
+
+// importFramework names the framework an import path belongs to, or "".
+func importFramework(importPath string) string {
+	switch {
+	case strings.Contains(importPath, "gin-gonic/gin"):
+		return "gin"
+	case strings.Contains(importPath, "go-chi/chi"):
+		return "chi"
+	case strings.Contains(importPath, "labstack/echo"):
+		return "echo"
+	case strings.Contains(importPath, "gofiber/fiber"):
+		return "fiber"
+	case strings.Contains(importPath, "gorilla/mux"):
+		return "mux"
+	}
+	return ""
+}
+
+// DetectWithRegistry is DetectAll extended with the frameworks registered
+// through spec.RegisterFrameworkConfig. Built-ins are consulted first and keep
+// their positions; matching registered frameworks follow in registration
+// order. When no built-in import is found, the net/http fallback gives way to
+// the first registered match, which then becomes the primary. An empty dir
+// detects the built-ins from meta's imports (DetectAllFromMetadata).
+func (d *FrameworkDetector) DetectWithRegistry(dir string, meta *metadata.Metadata) ([]string, error) {
+	var frameworks []string
+	if dir == "" {
+		frameworks = d.DetectAllFromMetadata(meta)
+	} else {
+		var err error
+		if frameworks, err = d.DetectAll(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	var registered []string
+	for _, entry := range spec.RegisteredFrameworks() {
+		if entry.Detect(meta) {
+			registered = append(registered, entry.Name)
+		}
+	}
+	if len(registered) == 0 {
+		return frameworks, nil
+	}
+	if len(frameworks) == 1 && frameworks[0] == "net/http" {
+		return registered, nil
+	}
+	return append(frameworks, registered...), nil
+}
+
 // CollectGoFiles recursively collects all .go files from a directory
 func CollectGoFiles(dir string) ([]string, error) {
 	var goFiles []string
@@ -18,6 +18,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+	"github.com/ehabterra/apispec/internal/spec"
 )
 
 func TestNewFrameworkDetector(t *testing.T) {
@@ -162,3 +165,80 @@ func TestDetect_InvalidDirectory(t *testing.T) {
 		t.Error("Expected error for non-existent directory")
 	}
 }
+
+func TestDetectWithRegistry(t *testing.T) {
+	always := func(*metadata.Metadata) bool { return true }
+	spec.RegisterFrameworkConfig("acme", always, &spec.APISpecConfig{})
+	spec.RegisterFrameworkConfig("unused", func(*metadata.Metadata) bool { return false }, &spec.APISpecConfig{})
+	t.Cleanup(func() {
+		spec.UnregisterFrameworkConfig("acme")
+		spec.UnregisterFrameworkConfig("unused")
+	})
+
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{
+			name:   "registered replaces the net/http fallback",
+			source: "package main\n\nimport \"net/http\"\n",
+			want:   []string{"acme"},
+		},
+		{
+			name:   "built-ins come first",
+			source: "package main\n\nimport \"github.com/gin-gonic/gin\"\n",
+			want:   []string{"gin", "acme"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(tt.source), 0644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := NewFrameworkDetector().DetectWithRegistry(dir, nil)
+			if err != nil {
+				t.Fatalf("DetectWithRegistry failed: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectAllFromMetadata(t *testing.T) {
+	pool := metadata.NewStringPool()
+	meta := &metadata.Metadata{
+		StringPool: pool,
+		Packages: map[string]*metadata.Package{
+			"example.com/app/admin": {Files: map[string]*metadata.File{
+				"admin.go": {Imports: map[int]int{pool.Get("mux"): pool.Get("github.com/gorilla/mux")}},
+			}},
+			"example.com/app": {Files: map[string]*metadata.File{
+				"main.go": {Imports: map[int]int{
+					pool.Get("http"): pool.Get("net/http"),
+					pool.Get("gin"):  pool.Get("github.com/gin-gonic/gin"),
+				}},
+			}},
+		},
+	}
+
+	detector := NewFrameworkDetector()
+	got := detector.DetectAllFromMetadata(meta)
+	if len(got) != 2 || got[0] != "gin" || got[1] != "mux" {
+		t.Errorf("DetectAllFromMetadata = %v, want [gin mux] in package order", got)
+	}
+	if got, err := detector.DetectWithRegistry("", meta); err != nil || len(got) != 2 {
+		t.Errorf("DetectWithRegistry without a dir = %v, %v, want the metadata's frameworks", got, err)
+	}
+	if got := detector.DetectAllFromMetadata(&metadata.Metadata{StringPool: pool}); len(got) != 1 || got[0] != "net/http" {
+		t.Errorf("no framework imports = %v, want the net/http fallback", got)
+	}
+}
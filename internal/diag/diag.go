@@ -0,0 +1,268 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diag presents warnings and errors to people: a tagged headline, the
+// offending source line with a caret under the column, and a "did you mean"
+// suggestion when a name is a near miss of one that would work.
+//
+//	warning[path-params]: GET /users/{id}: handler main.getUser reads path variable "userId", ...
+//	  --> /src/api/main.go:23:8
+//	   |
+//	23 | 	id := mux.Vars(r)["userId"]
+//	   | 	      ^
+//	   = help: did you mean "id"?
+package diag
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Severity ranks a diagnostic.
+type Severity int
+
+const (
+	Warning Severity = iota
+	Error
+)
+
+func (s Severity) String() string {
+	if s == Error {
+		return "error"
+	}
+	return "warning"
+}
+
+// Position is a location in a source file. Line and Column are 1-based; zero
+// means unknown.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// ParsePosition parses the "file:line:col" form go/token prints. Missing
+// trailing parts are left zero; an empty string yields the zero Position.
+func ParsePosition(s string) Position {
+	var p Position
+	rest := s
+	if i := strings.LastIndexByte(rest, ':'); i >= 0 {
+		if n, err := strconv.Atoi(rest[i+1:]); err == nil {
+			p.Column, rest = n, rest[:i]
+			if j := strings.LastIndexByte(rest, ':'); j >= 0 {
+				if n, err := strconv.Atoi(rest[j+1:]); err == nil {
+					p.Line, rest = n, rest[:j]
+				}
+			}
+		}
+	}
+	if p.Line == 0 && p.Column != 0 {
+		// "file:12" — a single number is the line.
+		p.Line, p.Column = p.Column, 0
+	}
+	p.File = rest
+	return p
+}
+
+// IsValid reports whether p names a file.
+func (p Position) IsValid() bool { return p.File != "" }
+
+func (p Position) String() string {
+	switch {
+	case p.Line == 0:
+		return p.File
+	case p.Column == 0:
+		return fmt.Sprintf("%s:%d", p.File, p.Line)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// Diagnostic is one finding.
+type Diagnostic struct {
+	Severity Severity
+	// Category tags the finding, e.g. "config", "path-params", "security".
+	Category string
+	Message  string
+	// Pos locates the finding; the source line is excerpted when it can be
+	// read.
+	Pos Position
+	// Suggestion is the likely intended name, rendered as "did you mean".
+	Suggestion string
+	// Help is a further hint on how to resolve the finding.
+	Help string
+}
+
+// ANSI escapes used when color is on.
+const (
+	reset  = "\033[0m"
+	bold   = "\033[1m"
+	red    = "\033[1;31m"
+	yellow = "\033[1;33m"
+	blue   = "\033[1;34m"
+	cyan   = "\033[1;36m"
+)
+
+// Presenter writes diagnostics to w. It is safe for concurrent use.
+type Presenter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	color   bool
+	sources map[string][]string
+}
+
+// NewPresenter returns a Presenter writing to w, with ANSI colors when color
+// is set.
+func NewPresenter(w io.Writer, color bool) *Presenter {
+	return &Presenter{w: w, color: color, sources: map[string][]string{}}
+}
+
+// ColorEnabled reports whether diagnostics written to f should be colored:
+// f is a terminal, NO_COLOR is unset (https://no-color.org) and TERM is not
+// "dumb".
+func ColorEnabled(f *os.File) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Print writes d: the headline, then the source excerpt and suggestion when
+// there are any.
+func (p *Presenter) Print(d Diagnostic) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var b strings.Builder
+	sev := yellow
+	if d.Severity == Error {
+		sev = red
+	}
+	tag := d.Severity.String()
+	if d.Category != "" {
+		tag += "[" + d.Category + "]"
+	}
+	b.WriteString(p.paint(sev, tag) + p.paint(bold, ": "+d.Message) + "\n")
+
+	gutter := " "
+	if d.Pos.IsValid() {
+		line := p.sourceLine(d.Pos)
+		gutter = strings.Repeat(" ", len(strconv.Itoa(d.Pos.Line)))
+		fmt.Fprintf(&b, "%s%s %s\n", gutter, p.paint(blue, "-->"), d.Pos)
+		if line != "" {
+			fmt.Fprintf(&b, "%s %s\n", gutter, p.paint(blue, "|"))
+			fmt.Fprintf(&b, "%s %s %s\n", p.paint(blue, strconv.Itoa(d.Pos.Line)), p.paint(blue, "|"), line)
+			if d.Pos.Column > 0 {
+				fmt.Fprintf(&b, "%s %s %s%s\n", gutter, p.paint(blue, "|"), caretPad(line, d.Pos.Column), p.paint(sev, "^"))
+			}
+		}
+	}
+	if d.Suggestion != "" {
+		fmt.Fprintf(&b, "%s %s did you mean %q?\n", gutter, p.paint(cyan, "= help:"), d.Suggestion)
+	}
+	if d.Help != "" {
+		fmt.Fprintf(&b, "%s %s %s\n", gutter, p.paint(cyan, "= help:"), d.Help)
+	}
+	_, _ = io.WriteString(p.w, b.String())
+}
+
+func (p *Presenter) paint(code, s string) string {
+	if !p.color {
+		return s
+	}
+	return code + s + reset
+}
+
+// sourceLine returns line pos.Line of pos.File, or "" when it cannot be read.
+// Files are read once and cached.
+func (p *Presenter) sourceLine(pos Position) string {
+	if pos.Line <= 0 {
+		return ""
+	}
+	lines, ok := p.sources[pos.File]
+	if !ok {
+		lines = readLines(pos.File)
+		p.sources[pos.File] = lines
+	}
+	if pos.Line > len(lines) {
+		return ""
+	}
+	return strings.TrimRight(lines[pos.Line-1], " \t\r")
+}
+
+func readLines(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	var lines []string
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	return lines
+}
+
+// caretPad returns the indentation that puts a caret under the 1-based byte
+// column col of line, keeping the line's tabs so the caret lines up however
+// the terminal renders them.
+func caretPad(line string, col int) string {
+	var b strings.Builder
+	for i := 0; i < col-1 && i < len(line); i++ {
+		if line[i] == '\t' {
+			b.WriteByte('\t')
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	return b.String()
+}
+
+var std = struct {
+	sync.Mutex
+	p *Presenter
+}{}
+
+// Default returns the Presenter Report writes to: standard error, colored
+// when it is a terminal (see ColorEnabled).
+func Default() *Presenter {
+	std.Lock()
+	defer std.Unlock()
+	if std.p == nil {
+		std.p = NewPresenter(os.Stderr, ColorEnabled(os.Stderr))
+	}
+	return std.p
+}
+
+// SetDefault replaces the Presenter Report writes to and returns the previous
+// one.
+func SetDefault(p *Presenter) *Presenter {
+	prev := Default()
+	std.Lock()
+	std.p = p
+	std.Unlock()
+	return prev
+}
+
+// Report prints d with the default Presenter.
+func Report(d Diagnostic) {
+	Default().Print(d)
+}
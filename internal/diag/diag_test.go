@@ -0,0 +1,101 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diag
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParsePosition(t *testing.T) {
+	for in, want := range map[string]Position{
+		"/src/main.go:12:5":  {File: "/src/main.go", Line: 12, Column: 5},
+		"/src/main.go:12":    {File: "/src/main.go", Line: 12},
+		"C:/src/main.go:3:1": {File: "C:/src/main.go", Line: 3, Column: 1},
+		"main.go":            {File: "main.go"},
+		"":                   {},
+	} {
+		if got := ParsePosition(in); got != want {
+			t.Errorf("ParsePosition(%q) = %+v, want %+v", in, got, want)
+		}
+	}
+}
+
+func TestPresenter_Print(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(src, []byte("package main\n\nfunc h() {\n\tid := vars[\"userId\"]\n}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+	NewPresenter(&b, false).Print(Diagnostic{
+		Severity:   Warning,
+		Category:   "path-params",
+		Message:    `reads path variable "userId"`,
+		Pos:        Position{File: src, Line: 4, Column: 13},
+		Suggestion: "id",
+	})
+	want := `warning[path-params]: reads path variable "userId"
+ --> ` + src + `:4:13
+  |
+4 | 	id := vars["userId"]
+  | 	           ^
+  = help: did you mean "id"?
+`
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	// No position, no excerpt; an unreadable file keeps just the location.
+	b.Reset()
+	p := NewPresenter(&b, false)
+	p.Print(Diagnostic{Severity: Error, Message: "boom", Help: "try again"})
+	p.Print(Diagnostic{Severity: Warning, Message: "gone", Pos: Position{File: filepath.Join(t.TempDir(), "missing.go"), Line: 2}})
+	if got := b.String(); !strings.HasPrefix(got, "error: boom\n  = help: try again\nwarning: gone\n --> ") || strings.Contains(got, "|") {
+		t.Errorf("got:\n%s", got)
+	}
+}
+
+func TestPresenter_Color(t *testing.T) {
+	var b strings.Builder
+	NewPresenter(&b, true).Print(Diagnostic{Severity: Error, Message: "boom"})
+	if !strings.Contains(b.String(), red+"error"+reset) {
+		t.Errorf("no colored severity in %q", b.String())
+	}
+	t.Setenv("NO_COLOR", "1")
+	if ColorEnabled(os.Stderr) {
+		t.Error("ColorEnabled ignores NO_COLOR")
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	keys := []string{"callRegex", "recvTypeRegex", "pathArgIndex", "handlerArgIndex"}
+	for name, want := range map[string]string{
+		"callRegx":        "callRegex",
+		"callregex":       "callRegex",
+		"pathArgIdnex":    "pathArgIndex",
+		"handlerArgIndex": "",
+		"routes":          "",
+	} {
+		if got := Suggest(name, keys); got != want {
+			t.Errorf("Suggest(%q) = %q, want %q", name, got, want)
+		}
+	}
+	if got := Suggest("userID", []string{"id", "userId"}); got != "userId" {
+		t.Errorf("Suggest(userID) = %q, want userId", got)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diag
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/sarif"
+)
+
+// uncategorized is the SARIF rule of a diagnostic without a Category.
+const uncategorized = "apispec"
+
+// WriteSARIF writes diags to w as a SARIF log of apispec at toolVersion, for
+// CI to annotate the source with: each category is a rule, and each
+// diagnostic a result at its position, with its suggestion and help in the
+// message. Files under root are named relative to it, as code scanning
+// expects.
+func WriteSARIF(w io.Writer, diags []Diagnostic, toolVersion, root string) error {
+	var rules []sarif.Rule
+	var results []sarif.Result
+	for _, d := range diags {
+		rule := d.Category
+		if rule == "" {
+			rule = uncategorized
+		}
+		index := slices.IndexFunc(rules, func(r sarif.Rule) bool { return r.ID == rule })
+		if index < 0 {
+			index = len(rules)
+			rules = append(rules, sarif.Rule{ID: rule, DefaultConfiguration: sarif.Configuration{Level: sarif.LevelWarning}})
+		}
+		result := sarif.Result{
+			RuleID:    rule,
+			RuleIndex: index,
+			Level:     sarifLevel(d.Severity),
+			Message:   sarif.Message{Text: sarifMessage(d)},
+		}
+		if d.Pos.IsValid() {
+			result.Locations = []sarif.Location{{
+				PhysicalLocation: sarif.FileLocation(artifactURI(d.Pos.File, root), d.Pos.Line, d.Pos.Column),
+			}}
+		}
+		results = append(results, result)
+	}
+	return sarif.NewLog(toolVersion, rules, results).Write(w)
+}
+
+func sarifLevel(s Severity) string {
+	if s == Error {
+		return sarif.LevelError
+	}
+	return sarif.LevelWarning
+}
+
+// sarifMessage is the message of d followed, a line each, by what Print
+// renders as help.
+func sarifMessage(d Diagnostic) string {
+	text := d.Message
+	if d.Suggestion != "" {
+		text += fmt.Sprintf("\nhelp: did you mean %q?", d.Suggestion)
+	}
+	if d.Help != "" {
+		text += "\nhelp: " + d.Help
+	}
+	return text
+}
+
+// artifactURI names file relative to root with forward slashes, or as a
+// file URI when it is outside root.
+func artifactURI(file, root string) string {
+	if root != "" {
+		if rel, err := filepath.Rel(root, file); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel)
+		}
+	}
+	uri := filepath.ToSlash(file)
+	if !filepath.IsAbs(file) {
+		return uri
+	}
+	if !strings.HasPrefix(uri, "/") {
+		uri = "/" + uri // a Windows drive: file:///C:/src/main.go
+	}
+	return "file://" + uri
+}
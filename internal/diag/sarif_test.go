@@ -0,0 +1,103 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diag
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/sarif"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	root := filepath.FromSlash("/src/api")
+	diags := []Diagnostic{
+		{Severity: Error, Category: "routes", Message: "route to main.listInvoices dropped: its path could not be resolved",
+			Pos: Position{File: filepath.Join(root, "main.go"), Line: 61, Column: 2}, Help: "register the route with a constant path"},
+		{Severity: Warning, Category: "path-params", Message: `GET /users/{id}: handler main.getUser reads path variable "userId"`,
+			Pos: Position{File: filepath.Join(root, "users", "handler.go"), Line: 23}, Suggestion: "id"},
+		{Severity: Warning, Category: "routes", Message: "GET /fallback: handler net/http.Handler does not resolve to a function",
+			Pos: Position{File: filepath.FromSlash("/go/pkg/mod/example.com/shared@v1.0.0/routes.go"), Line: 7}},
+		{Severity: Warning, Message: "outside any category"},
+	}
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, diags, "1.2.3", root); err != nil {
+		t.Fatal(err)
+	}
+	var log sarif.Log
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF JSON: %v", err)
+	}
+	run := log.Runs[0]
+	var rules []string
+	for _, r := range run.Tool.Driver.Rules {
+		rules = append(rules, r.ID)
+	}
+	if want := []string{"routes", "path-params", "apispec"}; len(rules) != len(want) || rules[0] != want[0] || rules[1] != want[1] || rules[2] != want[2] {
+		t.Errorf("rules = %v, want %v", rules, want)
+	}
+
+	tests := []struct {
+		rule      string
+		index     int
+		level     string
+		message   string
+		uri       string
+		line, col int
+	}{
+		{"routes", 0, "error", "route to main.listInvoices dropped: its path could not be resolved\nhelp: register the route with a constant path", "main.go", 61, 2},
+		{"path-params", 1, "warning", "GET /users/{id}: handler main.getUser reads path variable \"userId\"\nhelp: did you mean \"id\"?", "users/handler.go", 23, 0},
+		{"routes", 0, "warning", "GET /fallback: handler net/http.Handler does not resolve to a function", "file:///go/pkg/mod/example.com/shared@v1.0.0/routes.go", 7, 0},
+		{"apispec", 2, "warning", "outside any category", "", 0, 0},
+	}
+	if len(run.Results) != len(tests) {
+		t.Fatalf("%d results, want %d", len(run.Results), len(tests))
+	}
+	for i, tc := range tests {
+		r := run.Results[i]
+		if r.RuleID != tc.rule || r.RuleIndex != tc.index || r.Level != tc.level || r.Message.Text != tc.message {
+			t.Errorf("result %d = %s #%d %s %q, want %s #%d %s %q", i, r.RuleID, r.RuleIndex, r.Level, r.Message.Text, tc.rule, tc.index, tc.level, tc.message)
+		}
+		if tc.uri == "" {
+			if len(r.Locations) != 0 {
+				t.Errorf("result %d locations = %+v, want none", i, r.Locations)
+			}
+			continue
+		}
+		if len(r.Locations) != 1 || r.Locations[0].PhysicalLocation == nil {
+			t.Fatalf("result %d locations = %+v", i, r.Locations)
+		}
+		pl := r.Locations[0].PhysicalLocation
+		if pl.ArtifactLocation.URI != tc.uri || pl.Region == nil || pl.Region.StartLine != tc.line || pl.Region.StartColumn != tc.col {
+			t.Errorf("result %d location = %s %+v, want %s:%d:%d", i, pl.ArtifactLocation.URI, pl.Region, tc.uri, tc.line, tc.col)
+		}
+	}
+}
+
+func TestWriteSARIF_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, nil, "", ""); err != nil {
+		t.Fatal(err)
+	}
+	var log sarif.Log
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF JSON: %v", err)
+	}
+	if results := log.Runs[0].Results; results == nil || len(results) != 0 {
+		t.Errorf("results = %v, want an empty list", results)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// authCookie carries the token of a browser that opened the UI with
+// ?token=, so the UI's own requests and WebSocket are let through.
+const authCookie = "apidiag_token"
+
+// Auth is the credentials RequireAuth accepts: a bearer token, a basic auth
+// user and password, or both. With neither set, it lets every request
+// through.
+type Auth struct {
+	Token    string
+	Username string
+	Password string
+	// OpenPaths are served without credentials, such as a health check.
+	OpenPaths []string
+}
+
+// Enabled reports whether any credentials are configured.
+func (a Auth) Enabled() bool {
+	return a.Token != "" || a.Username != ""
+}
+
+// RequireAuth returns next behind auth. A request is let through with the
+// token as "Authorization: Bearer <token>", as the token query parameter or
+// in the cookie set after a query parameter is accepted, or with the basic
+// auth user and password. Others get 401, with a basic auth challenge when a
+// user is configured so browsers prompt for it. CORS preflights and
+// auth.OpenPaths are always let through.
+func RequireAuth(auth Auth, next http.Handler) http.Handler {
+	if !auth.Enabled() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions || slices.Contains(auth.OpenPaths, r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if auth.Token != "" {
+			if token := r.URL.Query().Get("token"); token != "" && secretEqual(token, auth.Token) {
+				http.SetCookie(w, &http.Cookie{
+					Name:     authCookie,
+					Value:    token,
+					Path:     "/",
+					HttpOnly: true,
+					SameSite: http.SameSiteStrictMode,
+				})
+				next.ServeHTTP(w, r)
+				return
+			}
+			if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && secretEqual(bearer, auth.Token) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if cookie, err := r.Cookie(authCookie); err == nil && secretEqual(cookie.Value, auth.Token) {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		if auth.Username != "" {
+			if user, password, ok := r.BasicAuth(); ok && secretEqual(user, auth.Username) && secretEqual(password, auth.Password) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("WWW-Authenticate", `Basic realm="apidiag", charset="UTF-8"`)
+		} else {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="apidiag"`)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(ErrorResponse{
+			Error:   http.StatusText(http.StatusUnauthorized),
+			Message: "Authentication required",
+			Code:    http.StatusUnauthorized,
+		})
+	})
+}
+
+// secretEqual compares a credential in constant time.
+func secretEqual(got, want string) bool {
+	return subtle.ConstantTimeCompare([]byte(got), []byte(want)) == 1
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequireAuth(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	handler := RequireAuth(Auth{Token: "t0ken", Username: "ops", Password: "pw", OpenPaths: []string{"/health"}}, ok)
+
+	serve := func(r *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+	request := func(method, target string, set func(*http.Request)) *http.Request {
+		r := httptest.NewRequest(method, target, nil)
+		if set != nil {
+			set(r)
+		}
+		return r
+	}
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{"no credentials", request(http.MethodGet, "/api/diagram", nil), http.StatusUnauthorized},
+		{"open path", request(http.MethodGet, "/health", nil), http.StatusOK},
+		{"preflight", request(http.MethodOptions, "/api/diagram", nil), http.StatusOK},
+		{"bearer", request(http.MethodGet, "/api/diagram", func(r *http.Request) { r.Header.Set("Authorization", "Bearer t0ken") }), http.StatusOK},
+		{"wrong bearer", request(http.MethodGet, "/api/diagram", func(r *http.Request) { r.Header.Set("Authorization", "Bearer nope") }), http.StatusUnauthorized},
+		{"query token", request(http.MethodGet, "/?token=t0ken", nil), http.StatusOK},
+		{"wrong query token", request(http.MethodGet, "/?token=t0ke", nil), http.StatusUnauthorized},
+		{"cookie", request(http.MethodGet, "/api/diagram/page", func(r *http.Request) { r.AddCookie(&http.Cookie{Name: authCookie, Value: "t0ken"}) }), http.StatusOK},
+		{"basic", request(http.MethodPost, "/api/diagram/refresh", func(r *http.Request) { r.SetBasicAuth("ops", "pw") }), http.StatusOK},
+		{"wrong basic password", request(http.MethodGet, "/", func(r *http.Request) { r.SetBasicAuth("ops", "nope") }), http.StatusUnauthorized},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if w := serve(tc.req); w.Code != tc.want {
+				t.Errorf("-> %d, want %d", w.Code, tc.want)
+			}
+		})
+	}
+
+	w := serve(request(http.MethodGet, "/?token=t0ken", nil))
+	if cookie := w.Result().Cookies(); len(cookie) != 1 || cookie[0].Name != authCookie || !cookie[0].HttpOnly {
+		t.Errorf("a query token should set the %s cookie, got %v", authCookie, cookie)
+	}
+
+	w = serve(request(http.MethodGet, "/api/diagram", nil))
+	if got := w.Header().Get("WWW-Authenticate"); !strings.HasPrefix(got, "Basic") {
+		t.Errorf("challenge = %q, want basic auth so browsers prompt", got)
+	}
+	if !strings.Contains(w.Body.String(), "Authentication required") {
+		t.Errorf("body = %s", w.Body.String())
+	}
+}
+
+func TestRequireAuthTokenOnly(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	w := httptest.NewRecorder()
+	RequireAuth(Auth{Token: "t0ken"}, ok).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusUnauthorized || !strings.HasPrefix(w.Header().Get("WWW-Authenticate"), "Bearer") {
+		t.Errorf("-> %d %q, want 401 with a bearer challenge", w.Code, w.Header().Get("WWW-Authenticate"))
+	}
+
+	// Without credentials configured, everything is let through.
+	w = httptest.NewRecorder()
+	RequireAuth(Auth{}, ok).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("disabled auth -> %d", w.Code)
+	}
+}
+
+func TestReadOnlyRefresh(t *testing.T) {
+	s := injectedServer(t)
+	s.config.ReadOnly = true
+	mux := muxFor(s)
+
+	if w := do(mux, http.MethodPost, "/api/diagram/refresh"); w.Code != http.StatusForbidden {
+		t.Errorf("read-only refresh -> %d, want 403", w.Code)
+	}
+	if w := do(mux, http.MethodGet, "/api/diagram/stats"); !strings.Contains(w.Body.String(), `"read_only":true`) {
+		t.Errorf("stats do not report read-only: %s", w.Body.String())
+	}
+}
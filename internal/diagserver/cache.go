@@ -0,0 +1,260 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"container/list"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+// Diagram cache limits used when the config leaves them at zero.
+const (
+	defaultCacheEntries = 256
+	defaultCacheMB      = 256
+	// fullDiagramEntries bounds the cache of whole diagrams, one per diagram
+	// type and depth mode.
+	fullDiagramEntries = 4
+)
+
+// CacheStats describes one diagram cache. Bytes is an estimate of the memory
+// held by its entries; HitRate is Hits over lookups, zero before the first.
+type CacheStats struct {
+	Entries     int     `json:"entries"`
+	MaxEntries  int     `json:"max_entries"`
+	Bytes       int64   `json:"bytes"`
+	MaxBytes    int64   `json:"max_bytes,omitempty"`
+	TTL         string  `json:"ttl,omitempty"`
+	Hits        uint64  `json:"hits"`
+	Misses      uint64  `json:"misses"`
+	HitRate     float64 `json:"hit_rate"`
+	Evictions   uint64  `json:"evictions"`
+	Expirations uint64  `json:"expirations"`
+}
+
+// CacheStatsResponse is the /cache/stats response: the cache of filtered
+// pages and the cache of whole diagrams the pages are cut from.
+type CacheStatsResponse struct {
+	Pages    CacheStats `json:"pages"`
+	Diagrams CacheStats `json:"diagrams"`
+}
+
+// lruCache is a least recently used cache bounded by entry count and by the
+// estimated size of its values. Entries older than ttl are dropped on
+// lookup; a zero maxBytes or ttl disables that limit. Statistics outlive
+// clear, so they cover the whole life of the server.
+type lruCache[V any] struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	ttl        time.Duration
+	sizeOf     func(V) int64
+	now        func() time.Time
+
+	order *list.List // of *lruEntry[V], most recently used first
+	items map[string]*list.Element
+	bytes int64
+
+	hits, misses, evictions, expirations uint64
+}
+
+type lruEntry[V any] struct {
+	key    string
+	value  V
+	size   int64
+	stored time.Time
+}
+
+func newLRUCache[V any](maxEntries int, maxBytes int64, ttl time.Duration, sizeOf func(V) int64) *lruCache[V] {
+	return &lruCache[V]{
+		maxEntries: max(maxEntries, 1),
+		maxBytes:   max(maxBytes, 0),
+		ttl:        max(ttl, 0),
+		sizeOf:     sizeOf,
+		now:        time.Now,
+		order:      list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// get returns the value cached under key and marks it recently used.
+func (c *lruCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		c.misses++
+		var zero V
+		return zero, false
+	}
+	entry := elem.Value.(*lruEntry[V])
+	if c.ttl > 0 && c.now().Sub(entry.stored) >= c.ttl {
+		c.remove(elem)
+		c.expirations++
+		c.misses++
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	c.hits++
+	return entry.value, true
+}
+
+// put caches value under key, then evicts least recently used entries until
+// the cache is within its limits. A value larger than maxBytes on its own is
+// not cached at all.
+func (c *lruCache[V]) put(key string, value V) {
+	size := c.sizeOf(value)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+	if c.maxBytes > 0 && size > c.maxBytes {
+		return
+	}
+	entry := &lruEntry[V]{key: key, value: value, size: size, stored: c.now()}
+	c.items[key] = c.order.PushFront(entry)
+	c.bytes += size
+	for c.order.Len() > c.maxEntries || (c.maxBytes > 0 && c.bytes > c.maxBytes) {
+		c.remove(c.order.Back())
+		c.evictions++
+	}
+}
+
+func (c *lruCache[V]) remove(elem *list.Element) {
+	entry := c.order.Remove(elem).(*lruEntry[V])
+	delete(c.items, entry.key)
+	c.bytes -= entry.size
+}
+
+// clear drops every entry.
+func (c *lruCache[V]) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.items = make(map[string]*list.Element)
+	c.bytes = 0
+}
+
+func (c *lruCache[V]) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *lruCache[V]) stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := CacheStats{
+		Entries:     c.order.Len(),
+		MaxEntries:  c.maxEntries,
+		Bytes:       c.bytes,
+		MaxBytes:    c.maxBytes,
+		Hits:        c.hits,
+		Misses:      c.misses,
+		Evictions:   c.evictions,
+		Expirations: c.expirations,
+	}
+	if c.ttl > 0 {
+		stats.TTL = c.ttl.String()
+	}
+	if lookups := c.hits + c.misses; lookups > 0 {
+		stats.HitRate = float64(c.hits) / float64(lookups)
+	}
+	return stats
+}
+
+// newDiagramCaches returns the page and whole-diagram caches for config:
+// pages are bounded by CacheMaxEntries and CacheMaxMB, whole diagrams only
+// by count, since every page is cut from one. Both expire after
+// CacheTimeout.
+func newDiagramCaches(config *Config) (*lruCache[*spec.PaginatedCytoscapeData], *lruCache[*spec.CytoscapeData]) {
+	entries, mb := config.CacheMaxEntries, config.CacheMaxMB
+	if entries <= 0 {
+		entries = defaultCacheEntries
+	}
+	if mb <= 0 {
+		mb = defaultCacheMB
+	}
+	pages := newLRUCache(entries, int64(mb)<<20, config.CacheTimeout, func(p *spec.PaginatedCytoscapeData) int64 {
+		return diagramBytes(p.Nodes, p.Edges)
+	})
+	diagrams := newLRUCache(fullDiagramEntries, 0, config.CacheTimeout, func(d *spec.CytoscapeData) int64 {
+		return diagramBytes(d.Nodes, d.Edges)
+	})
+	return pages, diagrams
+}
+
+var (
+	nodeSize     = int64(reflect.TypeFor[spec.CytoscapeNode]().Size())
+	edgeSize     = int64(reflect.TypeFor[spec.CytoscapeEdge]().Size())
+	callPathSize = int64(reflect.TypeFor[spec.CallPathInfo]().Size())
+)
+
+// mapEntryOverhead approximates a map entry's share of buckets and hashing.
+const mapEntryOverhead = 48
+
+// diagramBytes estimates the memory held by nodes and edges: their structs
+// and the strings, slices and maps they own. Strings shared between nodes
+// are counted once per node, so the estimate errs on the high side.
+func diagramBytes(nodes []spec.CytoscapeNode, edges []spec.CytoscapeEdge) int64 {
+	total := int64(len(nodes))*nodeSize + int64(len(edges))*edgeSize
+	for i := range nodes {
+		d := &nodes[i].Data
+		total += int64(len(d.ID) + len(d.Label) + len(d.Parent) + len(d.Group) + len(d.Position) +
+			len(d.Type) + len(d.Package) + len(d.FunctionName) + len(d.ReceiverType) +
+			len(d.IsParentFunction) + len(d.Scope) + len(d.SignatureStr) + len(d.ArgType) +
+			len(d.ArgContext) + len(d.ArgName) + len(d.ArgValue) + len(d.ArgResolvedType))
+		for k, v := range d.Generics {
+			total += int64(len(k)+len(v)) + mapEntryOverhead
+		}
+		for k := range d.RootAssignments {
+			total += int64(len(k)) + mapEntryOverhead
+		}
+		total += int64(len(d.CallPaths)) * callPathSize
+		for _, cp := range d.CallPaths {
+			total += int64(len(cp.CallerPkg) + len(cp.CallerName) + len(cp.Position))
+			for _, v := range cp.ParamValues {
+				total += int64(len(v)) + 16
+			}
+			for k, v := range cp.GenericValues {
+				total += int64(len(k)+len(v)) + mapEntryOverhead
+			}
+		}
+	}
+	for i := range edges {
+		d := &edges[i].Data
+		total += int64(len(d.ID) + len(d.Source) + len(d.Target) + len(d.Label) + len(d.Type))
+	}
+	return total
+}
+
+// handleCacheStats reports the size, limits and hit rates of the diagram
+// caches.
+func (s *Server) handleCacheStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.writeJSON(w, CacheStatsResponse{
+		Pages:    s.cache.stats(),
+		Diagrams: s.dataCache.stats(),
+	})
+}
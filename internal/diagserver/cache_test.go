@@ -0,0 +1,179 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+// sizedCache caches ints whose size is their value.
+func sizedCache(maxEntries int, maxBytes int64, ttl time.Duration) *lruCache[int] {
+	return newLRUCache(maxEntries, maxBytes, ttl, func(v int) int64 { return int64(v) })
+}
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := sizedCache(2, 0, 0)
+	c.put("a", 1)
+	c.put("b", 2)
+	if _, ok := c.get("a"); !ok { // a is now the most recently used
+		t.Fatal("a missing")
+	}
+	c.put("c", 3)
+	if _, ok := c.get("b"); ok {
+		t.Error("b should have been evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.get(key); !ok {
+			t.Errorf("%s should still be cached", key)
+		}
+	}
+	stats := c.stats()
+	if stats.Entries != 2 || stats.Evictions != 1 || stats.Hits != 3 || stats.Misses != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if stats.HitRate != 0.75 {
+		t.Errorf("hit rate = %v, want 0.75", stats.HitRate)
+	}
+}
+
+func TestLRUCacheByteLimit(t *testing.T) {
+	c := sizedCache(10, 10, 0)
+	c.put("a", 4)
+	c.put("b", 4)
+	c.put("c", 4) // 12 bytes: a goes
+	if _, ok := c.get("a"); ok {
+		t.Error("a should have been evicted for size")
+	}
+	if got := c.stats().Bytes; got != 8 {
+		t.Errorf("bytes = %d, want 8", got)
+	}
+
+	c.put("huge", 11)
+	if _, ok := c.get("huge"); ok {
+		t.Error("a value over the byte limit should not be cached")
+	}
+	if c.len() != 2 {
+		t.Errorf("len = %d, want the 2 entries that fit", c.len())
+	}
+
+	c.put("b", 1) // replacing an entry replaces its size
+	if got := c.stats().Bytes; got != 5 {
+		t.Errorf("bytes after replace = %d, want 5", got)
+	}
+}
+
+func TestLRUCacheTTL(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := sizedCache(10, 0, time.Minute)
+	c.now = func() time.Time { return now }
+	c.put("a", 1)
+
+	now = now.Add(59 * time.Second)
+	if _, ok := c.get("a"); !ok {
+		t.Fatal("a expired early")
+	}
+	now = now.Add(time.Second)
+	if _, ok := c.get("a"); ok {
+		t.Error("a should have expired")
+	}
+	if stats := c.stats(); stats.Expirations != 1 || stats.Entries != 0 || stats.TTL != "1m0s" {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestLRUCacheClearKeepsStats(t *testing.T) {
+	c := sizedCache(10, 0, 0)
+	c.put("a", 3)
+	c.get("a")
+	c.clear()
+	if _, ok := c.get("a"); ok {
+		t.Error("a survived clear")
+	}
+	if stats := c.stats(); stats.Entries != 0 || stats.Bytes != 0 || stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestDiagramBytes(t *testing.T) {
+	small := diagramBytes([]spec.CytoscapeNode{{Data: spec.CytoscapeNodeData{ID: "a"}}}, nil)
+	big := diagramBytes([]spec.CytoscapeNode{{Data: spec.CytoscapeNodeData{
+		ID:        "a",
+		Label:     "a much longer label",
+		CallPaths: []spec.CallPathInfo{{CallerName: "main", ParamValues: []string{"x"}}},
+		Generics:  map[string]string{"T": "int"},
+	}}}, nil)
+	if small <= 0 || big <= small {
+		t.Errorf("diagramBytes: small %d, big %d", small, big)
+	}
+	withEdge := diagramBytes(nil, []spec.CytoscapeEdge{{Data: spec.CytoscapeEdgeData{ID: "e", Source: "a", Target: "b"}}})
+	if withEdge <= edgeSize {
+		t.Errorf("edge estimate %d does not count its strings", withEdge)
+	}
+}
+
+func TestHandleCacheStats(t *testing.T) {
+	s := injectedServer(t)
+	mux := muxFor(s)
+
+	for range 2 {
+		if w := do(mux, http.MethodGet, "/api/diagram/page?page=1&size=10"); w.Code != http.StatusOK {
+			t.Fatalf("page -> %d: %s", w.Code, w.Body.String())
+		}
+	}
+	w := do(mux, http.MethodGet, "/api/diagram/cache/stats")
+	if w.Code != http.StatusOK {
+		t.Fatalf("cache/stats -> %d", w.Code)
+	}
+	var resp CacheStatsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Pages.Entries != 1 || resp.Pages.Hits != 1 || resp.Pages.Misses != 1 {
+		t.Errorf("pages = %+v, want one entry, one hit and one miss", resp.Pages)
+	}
+	if resp.Pages.Bytes <= 0 || resp.Pages.MaxEntries != defaultCacheEntries || resp.Pages.MaxBytes != defaultCacheMB<<20 {
+		t.Errorf("pages = %+v", resp.Pages)
+	}
+	if resp.Diagrams.Entries != 1 || resp.Diagrams.Bytes <= 0 {
+		t.Errorf("diagrams = %+v, want the full call graph", resp.Diagrams)
+	}
+
+	if w := do(mux, http.MethodPost, "/api/diagram/cache/stats"); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST cache/stats -> %d, want 405", w.Code)
+	}
+}
+
+func TestPageCacheHonoursLimits(t *testing.T) {
+	s := injectedServer(t)
+	s.config.CacheMaxEntries = 2
+	s.cache, s.dataCache = newDiagramCaches(s.config)
+	mux := muxFor(s)
+
+	for page := 1; page <= 3; page++ {
+		path := fmt.Sprintf("/api/diagram/page?size=5&page=%d", page)
+		if w := do(mux, http.MethodGet, path); w.Code != http.StatusOK {
+			t.Fatalf("%s -> %d", path, w.Code)
+		}
+	}
+	if stats := s.cache.stats(); stats.Entries != 2 || stats.Evictions != 1 {
+		t.Errorf("pages = %+v, want 2 entries after 1 eviction", stats)
+	}
+}
@@ -32,8 +32,6 @@ func injectedServer(t *testing.T) *Server {
 	meta.BuildCallGraphMaps()
 	s := New(&Config{Host: "localhost", Port: 8080, DiagramType: "call-graph", PageSize: 50, MaxDepth: 3})
 	s.metadata = meta
-	s.cache = map[string]*spec.PaginatedCytoscapeData{}
-	s.dataCache = map[string]*spec.CytoscapeData{}
 	return s
 }
 
@@ -0,0 +1,216 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+// nodeStyle is how an export draws a node type, after the UI's stylesheet.
+type nodeStyle struct {
+	fill, stroke string
+	dotShape     string
+}
+
+var exportNodeStyles = map[string]nodeStyle{
+	"function":      {"#2ecc71", "#27ae60", "box"},
+	"argument":      {"#f39c12", "#e67e22", "diamond"},
+	"call_argument": {"#9b59b6", "#8e44ad", "hexagon"},
+	"generic":       {"#95a5a6", "#7f8c8d", "ellipse"},
+}
+
+var defaultNodeStyle = nodeStyle{"#bdc3c7", "#95a5a6", "box"}
+
+// edgeStyle returns the color of an edge type and whether it is dashed.
+func edgeStyle(edgeType string) (string, bool) {
+	switch edgeType {
+	case "calls":
+		return "#2ecc71", false
+	case "argument":
+		return "#f39c12", true
+	}
+	return "#666666", false
+}
+
+func styleOf(node spec.CytoscapeNode) nodeStyle {
+	if style, ok := exportNodeStyles[node.Data.Type]; ok {
+		return style
+	}
+	return defaultNodeStyle
+}
+
+// nodeTooltip is the hover text of an exported node: its label, then its
+// package and position when known.
+func nodeTooltip(node spec.CytoscapeNode) string {
+	parts := []string{node.Data.Label}
+	if node.Data.Package != "" {
+		parts = append(parts, node.Data.Package)
+	}
+	if node.Data.Position != "" {
+		parts = append(parts, node.Data.Position)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// writeDOT writes data as a Graphviz digraph, left to right like the UI's
+// default layout. A node whose compound parent is in data is drawn in a
+// cluster with the parent; edges to nodes outside data are left out.
+func writeDOT(w io.Writer, data *spec.CytoscapeData) error {
+	bw := bufio.NewWriter(w)
+	present := make(map[string]bool, len(data.Nodes))
+	for _, node := range data.Nodes {
+		present[node.Data.ID] = true
+	}
+	children := make(map[string][]spec.CytoscapeNode)
+	var top []spec.CytoscapeNode
+	for _, node := range data.Nodes {
+		if parent := node.Data.Parent; parent != "" && present[parent] && parent != node.Data.ID {
+			children[parent] = append(children[parent], node)
+		} else {
+			top = append(top, node)
+		}
+	}
+
+	fmt.Fprintln(bw, "digraph diagram {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, `  node [style="rounded,filled", fontname="Helvetica", fontsize=10];`)
+	fmt.Fprintln(bw, `  edge [arrowsize=0.7];`)
+
+	written := make(map[string]bool, len(data.Nodes))
+	writeLeaf := func(node spec.CytoscapeNode, indent string) {
+		written[node.Data.ID] = true
+		style := styleOf(node)
+		fmt.Fprintf(bw, "%s%s [label=%s, shape=%s, fillcolor=%s, color=%s, tooltip=%s];\n",
+			indent, dotQuote(node.Data.ID), dotQuote(node.Data.Label), style.dotShape,
+			dotQuote(style.fill), dotQuote(style.stroke), dotQuote(nodeTooltip(node)))
+	}
+	// A parent is drawn inside its own cluster, with its children.
+	var writeNode func(node spec.CytoscapeNode, indent string)
+	writeNode = func(node spec.CytoscapeNode, indent string) {
+		kids := children[node.Data.ID]
+		if len(kids) == 0 {
+			writeLeaf(node, indent)
+			return
+		}
+		fmt.Fprintf(bw, "%ssubgraph %s {\n", indent, dotQuote("cluster_"+node.Data.ID))
+		fmt.Fprintf(bw, "%s  label=%s; style=dashed; color=%s;\n", indent, dotQuote(node.Data.Label), dotQuote(styleOf(node).stroke))
+		writeLeaf(node, indent+"  ")
+		for _, kid := range kids {
+			writeNode(kid, indent+"  ")
+		}
+		fmt.Fprintf(bw, "%s}\n", indent)
+	}
+	for _, node := range top {
+		writeNode(node, "  ")
+	}
+	// Nodes in a cycle of parents have no top-level ancestor.
+	for _, node := range data.Nodes {
+		if !written[node.Data.ID] {
+			writeLeaf(node, "  ")
+		}
+	}
+
+	for _, edge := range data.Edges {
+		if !present[edge.Data.Source] || !present[edge.Data.Target] {
+			continue
+		}
+		color, dashed := edgeStyle(edge.Data.Type)
+		attrs := "color=" + dotQuote(color)
+		if dashed {
+			attrs += ", style=dashed"
+		}
+		fmt.Fprintf(bw, "  %s -> %s [%s];\n", dotQuote(edge.Data.Source), dotQuote(edge.Data.Target), attrs)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// dotQuote returns s as a DOT quoted string.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// writeSVG draws the layered layout of data as a standalone SVG document.
+// Edges run from the right side of their source to the left side of their
+// target; a node's tooltip names its package and position.
+func writeSVG(w io.Writer, data *spec.CytoscapeData) error {
+	layout := layeredLayout(data)
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="monospace" font-size="12">`+"\n",
+		layout.Width, layout.Height, layout.Width, layout.Height)
+
+	// One arrowhead per edge color.
+	markers := map[string]string{}
+	var markerOrder []string
+	for _, edge := range data.Edges {
+		color, _ := edgeStyle(edge.Data.Type)
+		if _, ok := markers[color]; !ok {
+			markers[color] = fmt.Sprintf("arrow%d", len(markers))
+			markerOrder = append(markerOrder, color)
+		}
+	}
+	fmt.Fprintln(bw, "<defs>")
+	for _, color := range markerOrder {
+		fmt.Fprintf(bw, `<marker id="%s" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="8" markerHeight="8" orient="auto-start-reverse"><path d="M0,0 L10,5 L0,10 z" fill="%s"/></marker>`+"\n",
+			markers[color], color)
+	}
+	fmt.Fprintln(bw, "</defs>")
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="#ffffff"/>`+"\n")
+
+	fmt.Fprintln(bw, `<g class="edges" fill="none" stroke-width="1.5">`)
+	for _, edge := range data.Edges {
+		from, ok1 := layout.Index[edge.Data.Source]
+		to, ok2 := layout.Index[edge.Data.Target]
+		if !ok1 || !ok2 || from == to {
+			continue
+		}
+		src, dst := layout.Nodes[from], layout.Nodes[to]
+		x1, y1 := src.X+src.Width, src.Y+src.Height/2
+		x2, y2 := dst.X, dst.Y+dst.Height/2
+		bend := max((x2-x1)/2, layoutLayerGap/2)
+		color, dashed := edgeStyle(edge.Data.Type)
+		dash := ""
+		if dashed {
+			dash = ` stroke-dasharray="5,4"`
+		}
+		fmt.Fprintf(bw, `<path d="M%.1f,%.1f C%.1f,%.1f %.1f,%.1f %.1f,%.1f" stroke="%s"%s marker-end="url(#%s)"/>`+"\n",
+			x1, y1, x1+bend, y1, x2-bend, y2, x2, y2, color, dash, markers[color])
+	}
+	fmt.Fprintln(bw, "</g>")
+
+	fmt.Fprintln(bw, `<g class="nodes">`)
+	for _, n := range layout.Nodes {
+		style := styleOf(n.Node)
+		fmt.Fprintf(bw, `<g id="%s"><title>%s</title>`, html.EscapeString(n.Node.Data.ID), html.EscapeString(nodeTooltip(n.Node)))
+		fmt.Fprintf(bw, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="6" fill="%s" stroke="%s" stroke-width="2"/>`,
+			n.X, n.Y, n.Width, n.Height, style.fill, style.stroke)
+		fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" text-anchor="middle" dominant-baseline="central" fill="#ffffff">%s</text></g>`+"\n",
+			n.X+n.Width/2, n.Y+n.Height/2, html.EscapeString(n.Node.Data.Label))
+	}
+	fmt.Fprintln(bw, "</g>")
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+// exportGraph is main -> a -> b -> main (a cycle), a -> c, with a literal
+// inside a calling c, plus an edge to a node outside the export.
+func exportGraph() *spec.CytoscapeData {
+	node := func(id, label, parent, typ string) spec.CytoscapeNode {
+		return spec.CytoscapeNode{Data: spec.CytoscapeNodeData{ID: id, Label: label, Parent: parent, Type: typ, Package: "app"}}
+	}
+	edge := func(from, to, typ string) spec.CytoscapeEdge {
+		return spec.CytoscapeEdge{Data: spec.CytoscapeEdgeData{ID: from + "_" + to, Source: from, Target: to, Type: typ}}
+	}
+	return &spec.CytoscapeData{
+		Nodes: []spec.CytoscapeNode{
+			node("main", "main", "", "function"),
+			node("a", `Handler.Serve"<x>"`, "", "function"),
+			node("b", "b", "", "function"),
+			node("c", "c", "", "function"),
+			node("lit", "FuncLit", "a", "function"),
+			node("arg", "req", "", "argument"),
+		},
+		Edges: []spec.CytoscapeEdge{
+			edge("main", "a", "calls"), edge("a", "b", "calls"), edge("b", "main", "calls"),
+			edge("a", "c", "calls"), edge("lit", "c", "calls"), edge("a", "arg", "argument"),
+			edge("a", "gone", "calls"),
+		},
+	}
+}
+
+func TestLayeredLayout(t *testing.T) {
+	data := exportGraph()
+	layout := layeredLayout(data)
+
+	at := func(id string) layoutNode { return layout.Nodes[layout.Index[id]] }
+	// The cycle is broken at its back edge b -> main, so calls run left to
+	// right from main.
+	for _, pair := range [][2]string{{"main", "a"}, {"a", "b"}, {"a", "c"}, {"lit", "c"}} {
+		if at(pair[0]).Layer >= at(pair[1]).Layer || at(pair[0]).X >= at(pair[1]).X {
+			t.Errorf("%s (layer %d) is not left of %s (layer %d)", pair[0], at(pair[0]).Layer, pair[1], at(pair[1]).Layer)
+		}
+	}
+
+	for i, a := range layout.Nodes {
+		if a.X+a.Width > layout.Width || a.Y+a.Height > layout.Height {
+			t.Errorf("%s lies outside the %vx%v drawing", a.Node.Data.ID, layout.Width, layout.Height)
+		}
+		for _, b := range layout.Nodes[i+1:] {
+			if a.X < b.X+b.Width && b.X < a.X+a.Width && a.Y < b.Y+b.Height && b.Y < a.Y+a.Height {
+				t.Errorf("%s and %s overlap", a.Node.Data.ID, b.Node.Data.ID)
+			}
+		}
+	}
+
+	again := layeredLayout(exportGraph())
+	for i := range layout.Nodes {
+		if layout.Nodes[i].X != again.Nodes[i].X || layout.Nodes[i].Y != again.Nodes[i].Y {
+			t.Fatal("layout is not deterministic")
+		}
+	}
+
+	if empty := layeredLayout(&spec.CytoscapeData{}); len(empty.Nodes) != 0 || empty.Width <= 0 || empty.Height <= 0 {
+		t.Errorf("empty layout = %+v", empty)
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeDOT(&buf, exportGraph()); err != nil {
+		t.Fatal(err)
+	}
+	dot := buf.String()
+	for _, want := range []string{
+		"digraph diagram {\n  rankdir=LR;",
+		`subgraph "cluster_a" {`,
+		`"a" [label="Handler.Serve\"<x>\"", shape=box`,
+		`"lit" [label="FuncLit"`,
+		`"arg" [label="req", shape=diamond`,
+		`"b" -> "main" [color="#2ecc71"];`,
+		`"a" -> "arg" [color="#f39c12", style=dashed];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output lacks %q:\n%s", want, dot)
+		}
+	}
+	if strings.Contains(dot, "gone") {
+		t.Error("DOT output has an edge to a node outside the export")
+	}
+	if strings.Index(dot, `"lit" [`) < strings.Index(dot, `subgraph "cluster_a"`) {
+		t.Error("the literal is not drawn inside its parent's cluster")
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeSVG(&buf, exportGraph()); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		XMLName xml.Name `xml:"svg"`
+		Groups  []struct {
+			Class string     `xml:"class,attr"`
+			Paths []struct{} `xml:"path"`
+			Nodes []struct {
+				ID    string `xml:"id,attr"`
+				Title string `xml:"title"`
+				Text  string `xml:"text"`
+			} `xml:"g"`
+		} `xml:"g"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("SVG is not well-formed: %v\n%s", err, buf.String())
+	}
+	if len(doc.Groups) != 2 || doc.Groups[0].Class != "edges" || doc.Groups[1].Class != "nodes" {
+		t.Fatalf("unexpected SVG structure: %+v", doc.Groups)
+	}
+	if got := len(doc.Groups[0].Paths); got != 6 {
+		t.Errorf("%d edges drawn, want 6", got)
+	}
+	nodes := doc.Groups[1].Nodes
+	if len(nodes) != 6 || nodes[1].ID != "a" || nodes[1].Text != `Handler.Serve"<x>"` || !strings.Contains(nodes[1].Title, "app") {
+		t.Errorf("nodes = %+v", nodes)
+	}
+}
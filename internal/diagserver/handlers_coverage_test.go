@@ -17,10 +17,12 @@ package diagserver
 import (
 	"compress/gzip"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -159,8 +161,8 @@ func TestMetadataLoadFailure(t *testing.T) {
 	}
 }
 
-// TestExportFormats covers the JSON export payload and the client-side-format
-// rejection branch, plus pagination parameter handling.
+// TestExportFormats covers the JSON, SVG and DOT exports and the
+// client-side-format rejection branch, plus pagination parameter handling.
 func TestExportFormats(t *testing.T) {
 	s := injectedServer(t)
 	mux := muxFor(s)
@@ -179,9 +181,21 @@ func TestExportFormats(t *testing.T) {
 		t.Error("export body is not valid JSON")
 	}
 
-	// Default format is svg, which is handled client-side -> 400 with hint.
-	if w := do(mux, http.MethodGet, "/api/diagram/export"); w.Code != http.StatusBadRequest {
-		t.Errorf("default svg export -> %d, want 400", w.Code)
+	// Default format is svg, rendered on the server.
+	w = do(mux, http.MethodGet, "/api/diagram/export")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "image/svg+xml" {
+		t.Errorf("default svg export -> %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	if err := xml.Unmarshal(w.Body.Bytes(), new(struct{})); err != nil {
+		t.Errorf("svg export is not well-formed XML: %v", err)
+	}
+	w = do(mux, http.MethodGet, "/api/diagram/export?format=dot&all=true")
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Body.String(), "digraph diagram {") {
+		t.Errorf("dot export -> %d: %.40q", w.Code, w.Body.String())
+	}
+	// Raster and PDF formats are still rendered by the UI -> 400 with hint.
+	if w := do(mux, http.MethodGet, "/api/diagram/export?format=png"); w.Code != http.StatusBadRequest {
+		t.Errorf("png export -> %d, want 400", w.Code)
 	}
 	// Out-of-range paging params are clamped, not rejected.
 	if w := do(mux, http.MethodGet, "/api/diagram/export?format=json&page=0&size=99999"); w.Code != http.StatusOK {
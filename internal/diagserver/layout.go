@@ -0,0 +1,259 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+// Layout geometry, in SVG user units. Labels are sized by character count
+// with a fixed advance, which the monospace font of the SVG export honours.
+const (
+	layoutCharWidth   = 7.2
+	layoutNodePadding = 16.0
+	layoutNodeHeight  = 28.0
+	layoutRowGap      = 14.0
+	layoutLayerGap    = 80.0
+	layoutMargin      = 20.0
+	// layoutSweeps is the number of barycenter passes, alternating down and
+	// up the layers, spent reducing crossings.
+	layoutSweeps = 8
+)
+
+// layoutNode is a node placed by layeredLayout: X, Y is its top-left corner.
+type layoutNode struct {
+	Node          spec.CytoscapeNode
+	Layer         int
+	X, Y          float64
+	Width, Height float64
+}
+
+// diagramLayout is a left-to-right layered drawing of a diagram.
+type diagramLayout struct {
+	Nodes         []layoutNode
+	Index         map[string]int // node ID -> position in Nodes
+	Width, Height float64
+}
+
+// layeredLayout places the nodes of data in layers, left to right in call
+// order, the way Graphviz dot draws a graph: cycles are broken by reversing
+// the edges a depth-first search finds going back, each node goes one layer
+// right of its farthest predecessor, and barycenter sweeps order each layer
+// to reduce crossings. Edges to nodes outside data are ignored, and so is
+// compound nesting: a function literal is laid out as any other node. The
+// result is deterministic for a given node and edge order.
+func layeredLayout(data *spec.CytoscapeData) *diagramLayout {
+	n := len(data.Nodes)
+	index := make(map[string]int, n)
+	for i, node := range data.Nodes {
+		index[node.Data.ID] = i
+	}
+
+	// Adjacency without self loops or duplicate pairs, in edge order.
+	succ := make([][]int, n)
+	seen := make(map[[2]int]bool)
+	for _, edge := range data.Edges {
+		from, ok1 := index[edge.Data.Source]
+		to, ok2 := index[edge.Data.Target]
+		if !ok1 || !ok2 || from == to || seen[[2]int{from, to}] {
+			continue
+		}
+		seen[[2]int{from, to}] = true
+		succ[from] = append(succ[from], to)
+	}
+	succ = acyclic(succ)
+
+	pred := make([][]int, n)
+	for from, tos := range succ {
+		for _, to := range tos {
+			pred[to] = append(pred[to], from)
+		}
+	}
+
+	// Longest-path layering, in topological order.
+	layer := make([]int, n)
+	for _, v := range topological(succ) {
+		for _, to := range succ[v] {
+			layer[to] = max(layer[to], layer[v]+1)
+		}
+	}
+	var layers [][]int
+	for v := range n {
+		for len(layers) <= layer[v] {
+			layers = append(layers, nil)
+		}
+		layers[layer[v]] = append(layers[layer[v]], v)
+	}
+
+	orderLayers(layers, succ, pred)
+
+	// Coordinates: a column per layer as wide as its widest label, a row per
+	// position, with each layer centred vertically.
+	out := &diagramLayout{Nodes: make([]layoutNode, n), Index: index}
+	for i, node := range data.Nodes {
+		out.Nodes[i] = layoutNode{
+			Node:   node,
+			Layer:  layer[i],
+			Width:  float64(utf8.RuneCountInString(node.Data.Label))*layoutCharWidth + 2*layoutNodePadding,
+			Height: layoutNodeHeight,
+		}
+	}
+	tallest := 0
+	for _, vs := range layers {
+		tallest = max(tallest, len(vs))
+	}
+	rowHeight := layoutNodeHeight + layoutRowGap
+	x := layoutMargin
+	for _, vs := range layers {
+		width := 0.0
+		for _, v := range vs {
+			width = max(width, out.Nodes[v].Width)
+		}
+		offset := float64(tallest-len(vs)) * rowHeight / 2
+		for row, v := range vs {
+			out.Nodes[v].X = x
+			out.Nodes[v].Y = layoutMargin + offset + float64(row)*rowHeight
+		}
+		x += width + layoutLayerGap
+	}
+	out.Width = max(x-layoutLayerGap+layoutMargin, 2*layoutMargin)
+	out.Height = max(float64(tallest)*rowHeight-layoutRowGap, 0) + 2*layoutMargin
+	return out
+}
+
+// acyclic returns succ with the edges that close a cycle reversed: those a
+// depth-first search from each node in order finds pointing back into its
+// own stack. A reversed edge that duplicates an existing one is dropped.
+func acyclic(succ [][]int) [][]int {
+	const (
+		unvisited = iota
+		onStack
+		done
+	)
+	state := make([]int, len(succ))
+	out := make([][]int, len(succ))
+	has := make(map[[2]int]bool)
+	add := func(from, to int) {
+		if !has[[2]int{from, to}] {
+			has[[2]int{from, to}] = true
+			out[from] = append(out[from], to)
+		}
+	}
+
+	type frame struct{ v, next int }
+	for root := range succ {
+		if state[root] != unvisited {
+			continue
+		}
+		stack := []frame{{v: root}}
+		state[root] = onStack
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next == len(succ[top.v]) {
+				state[top.v] = done
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			to := succ[top.v][top.next]
+			top.next++
+			switch state[to] {
+			case onStack:
+				add(to, top.v)
+			case done:
+				add(top.v, to)
+			default:
+				add(top.v, to)
+				state[to] = onStack
+				stack = append(stack, frame{v: to})
+			}
+		}
+	}
+	return out
+}
+
+// topological returns the nodes of the acyclic succ in topological order,
+// ties going to the lower index.
+func topological(succ [][]int) []int {
+	indegree := make([]int, len(succ))
+	for _, tos := range succ {
+		for _, to := range tos {
+			indegree[to]++
+		}
+	}
+	var queue, order []int
+	for v, d := range indegree {
+		if d == 0 {
+			queue = append(queue, v)
+		}
+	}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		order = append(order, v)
+		for _, to := range succ[v] {
+			if indegree[to]--; indegree[to] == 0 {
+				queue = append(queue, to)
+			}
+		}
+	}
+	return order
+}
+
+// orderLayers reorders each layer by the mean position of its neighbours in
+// the layer before (sweeping down) or after (sweeping up). Nodes without
+// neighbours there keep their position; ties keep the current order.
+func orderLayers(layers [][]int, succ, pred [][]int) {
+	pos := make(map[int]float64)
+	record := func(vs []int) {
+		for i, v := range vs {
+			pos[v] = float64(i)
+		}
+	}
+	for _, vs := range layers {
+		record(vs)
+	}
+
+	sortByBarycenter := func(vs []int, neighbours [][]int) {
+		key := make(map[int]float64, len(vs))
+		for i, v := range vs {
+			key[v] = float64(i)
+			if len(neighbours[v]) == 0 {
+				continue
+			}
+			sum := 0.0
+			for _, u := range neighbours[v] {
+				sum += pos[u]
+			}
+			key[v] = sum / float64(len(neighbours[v]))
+		}
+		sort.SliceStable(vs, func(i, j int) bool { return key[vs[i]] < key[vs[j]] })
+		record(vs)
+	}
+
+	for sweep := 0; sweep < layoutSweeps; sweep++ {
+		if sweep%2 == 0 {
+			for l := 1; l < len(layers); l++ {
+				sortByBarycenter(layers[l], pred)
+			}
+		} else {
+			for l := len(layers) - 2; l >= 0; l-- {
+				sortByBarycenter(layers[l], succ)
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Timeouts bound the connections of ListenAndServe. A zero Read, Write or
+// Idle means no limit; a zero ReadHeader or Shutdown takes the default.
+type Timeouts struct {
+	// ReadHeader bounds reading a request's headers, against clients that
+	// hold connections open by sending them slowly. Default 10s.
+	ReadHeader time.Duration
+	// Read bounds reading a whole request, Write writing its response. A
+	// refresh writes once the project is re-analyzed, so Write must outlast
+	// the analysis. WebSocket streams are not bound by either.
+	Read  time.Duration
+	Write time.Duration
+	Idle  time.Duration
+	// Shutdown is how long in-flight requests get to finish once ctx is
+	// done. Default 30s.
+	Shutdown time.Duration
+}
+
+const (
+	defaultReadHeaderTimeout = 10 * time.Second
+	defaultShutdownTimeout   = 30 * time.Second
+)
+
+// ListenAndServe serves handler on addr until ctx is done, then shuts down
+// gracefully: it stops accepting connections and waits up to
+// timeouts.Shutdown for in-flight requests, such as a refresh writing its
+// analysis, before closing the rest. It returns nil after a clean shutdown
+// and the shutdown context's error when requests had to be cut off.
+func ListenAndServe(ctx context.Context, addr string, handler http.Handler, timeouts Timeouts) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return Serve(ctx, listener, handler, timeouts)
+}
+
+// Serve is ListenAndServe on an open listener, which it closes.
+func Serve(ctx context.Context, listener net.Listener, handler http.Handler, timeouts Timeouts) error {
+	if timeouts.ReadHeader <= 0 {
+		timeouts.ReadHeader = defaultReadHeaderTimeout
+	}
+	if timeouts.Shutdown <= 0 {
+		timeouts.Shutdown = defaultShutdownTimeout
+	}
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: timeouts.ReadHeader,
+		ReadTimeout:       timeouts.Read,
+		WriteTimeout:      timeouts.Write,
+		IdleTimeout:       timeouts.Idle,
+	}
+
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(listener) }()
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeouts.Shutdown)
+	defer cancel()
+	err := server.Shutdown(shutdownCtx)
+	if err != nil {
+		// Requests still running past the deadline are cut off.
+		_ = server.Close()
+	}
+	if serveErr := <-served; !errors.Is(serveErr, http.ErrServerClosed) && err == nil {
+		err = serveErr
+	}
+	return err
+}
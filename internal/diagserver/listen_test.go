@@ -0,0 +1,109 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// slowServer serves a handler that answers "done" once release is closed,
+// and returns the server's address, the channel the handler signals when a
+// request arrives, and the channel Serve's result is sent on.
+func slowServer(t *testing.T, ctx context.Context, release chan struct{}, timeouts Timeouts) (string, chan struct{}, chan error) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	started := make(chan struct{}, 1)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		_, _ = io.WriteString(w, "done")
+	})
+	served := make(chan error, 1)
+	go func() { served <- Serve(ctx, listener, handler, timeouts) }()
+	return "http://" + listener.Addr().String(), started, served
+}
+
+func TestServeShutsDownGracefully(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	url, started, served := slowServer(t, ctx, release, Timeouts{Shutdown: 5 * time.Second})
+
+	body := make(chan string, 1)
+	go func() {
+		resp, err := http.Get(url)
+		if err != nil {
+			body <- "error: " + err.Error()
+			return
+		}
+		defer func() { _ = resp.Body.Close() }()
+		data, _ := io.ReadAll(resp.Body)
+		body <- string(data)
+	}()
+
+	<-started
+	cancel()
+	// The in-flight request still gets its answer.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	if got := <-body; got != "done" {
+		t.Errorf("in-flight request got %q, want done", got)
+	}
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Serve = %v, want nil after a clean shutdown", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after shutdown")
+	}
+}
+
+func TestServeCutsOffAfterShutdownTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+	url, started, served := slowServer(t, ctx, release, Timeouts{Shutdown: 50 * time.Millisecond})
+
+	go func() {
+		if resp, err := http.Get(url); err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	<-started
+	cancel()
+	select {
+	case err := <-served:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Serve = %v, want the shutdown deadline", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not give up on the stuck request")
+	}
+}
+
+func TestListenAndServeBadAddress(t *testing.T) {
+	if err := ListenAndServe(context.Background(), "127.0.0.1:-1", http.NotFoundHandler(), Timeouts{}); err == nil {
+		t.Error("an invalid address should fail")
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// LoadCachedMetadata loads the metadata saved in config.MetadataCache, so a
+// restart does not re-analyze the project. Without a cache file, or when it
+// cannot be read, it falls back to LoadMetadata, which analyzes the project
+// and writes the cache for the next start. The cache is not checked against
+// the sources: /refresh re-analyzes and rewrites it. With config.MetadataFile
+// set there is no analysis to save, and the cache is not used.
+func (s *Server) LoadCachedMetadata() error {
+	s.mu.RLock()
+	path, configFile := s.config.MetadataCache, s.config.ConfigFile
+	if s.config.MetadataFile != "" {
+		path = ""
+	}
+	s.mu.RUnlock()
+	if path == "" {
+		return s.LoadMetadata()
+	}
+
+	meta, err := metadata.LoadMetadata(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return s.LoadMetadata()
+	case err != nil:
+		log.Printf("⚠️  Ignoring metadata cache %s: %v", path, err)
+		return s.LoadMetadata()
+	}
+
+	// The cache stands in for an analysis with the current config, so a
+	// later edit of the config, not the one already made, triggers a reload.
+	var configStamp string
+	if configFile != "" {
+		configStamp = configFingerprint(configFile)
+	}
+	s.setMetadata(meta, configStamp, "cache")
+
+	log.Printf("✅ Metadata loaded from cache %s", path)
+	if s.config.Verbose {
+		log.Printf("📊 Total packages: %d", len(meta.Packages))
+		log.Printf("📊 Total call graph edges: %d", len(meta.CallGraph))
+	}
+	return nil
+}
+
+// saveMetadataCache writes meta to config.MetadataCache, when set, in the
+// format apispec --stream-metadata uses, gzip-compressed when the name ends
+// in ".gz". It goes through a temporary file, with the same extension, so a
+// crash mid-write never leaves a truncated cache behind. A failure
+// is logged, not returned: the analysis it follows still succeeded.
+func (s *Server) saveMetadataCache(meta *metadata.Metadata) {
+	s.mu.RLock()
+	path := s.config.MetadataCache
+	s.mu.RUnlock()
+	if path == "" {
+		return
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp"+filepath.Ext(path))
+	if err != nil {
+		log.Printf("⚠️  Failed to write metadata cache %s: %v", path, err)
+		return
+	}
+	tmpPath := tmp.Name()
+	_ = tmp.Close()
+
+	if err := metadata.WriteMetadataStreamFile(meta, tmpPath); err != nil {
+		_ = os.Remove(tmpPath)
+		log.Printf("⚠️  Failed to write metadata cache %s: %v", path, err)
+		return
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		log.Printf("⚠️  Failed to write metadata cache %s: %v", path, err)
+		return
+	}
+	if s.config.Verbose {
+		log.Printf("💾 Metadata cached to %s", path)
+	}
+}
+
+// loadMetadataFile serves the metadata in path, pre-generated by apispec
+// elsewhere (a CI artifact, say), without access to the sources.
+func (s *Server) loadMetadataFile(path string) error {
+	meta, err := metadata.LoadMetadataFiles(path)
+	if err != nil {
+		return fmt.Errorf("failed to load metadata file %s: %w", path, err)
+	}
+	s.setMetadata(meta, "", "file")
+
+	log.Printf("✅ Metadata loaded from %s", path)
+	if s.config.Verbose {
+		log.Printf("📊 Total packages: %d", len(meta.Packages))
+		log.Printf("📊 Total call graph edges: %d", len(meta.CallGraph))
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+func cachedServer(cache string) *Server {
+	return New(&Config{
+		Host: "localhost", Port: 8080, DiagramType: "call-graph", PageSize: 50,
+		InputDir: "../../testdata/extensions", MaxDepth: 3, MetadataCache: cache,
+	})
+}
+
+func TestLoadCachedMetadata_WritesThenReadsCache(t *testing.T) {
+	cache := filepath.Join(t.TempDir(), "metadata.yaml")
+
+	first := cachedServer(cache)
+	if err := first.LoadCachedMetadata(); err != nil {
+		t.Skipf("engine generate unavailable: %v", err)
+	}
+	if first.metadataSource != "analysis" {
+		t.Errorf("first start should analyze, got source %q", first.metadataSource)
+	}
+	if _, err := os.Stat(cache); err != nil {
+		t.Fatalf("analysis did not write the cache: %v", err)
+	}
+
+	// A restart reads the cache, even with no project to analyze.
+	second := cachedServer(cache)
+	second.config.InputDir = t.TempDir()
+	if err := second.LoadCachedMetadata(); err != nil {
+		t.Fatal(err)
+	}
+	if second.metadataSource != "cache" {
+		t.Errorf("restart should load the cache, got source %q", second.metadataSource)
+	}
+
+	for _, diagramType := range []string{"call-graph", "tracker-tree"} {
+		want := first.getAllData(diagramType, true)
+		got := second.getAllData(diagramType, true)
+		if len(got.Nodes) != len(want.Nodes) || len(got.Edges) != len(want.Edges) {
+			t.Errorf("cached %s has %d nodes, %d edges; analyzed one %d, %d",
+				diagramType, len(got.Nodes), len(got.Edges), len(want.Nodes), len(want.Edges))
+		}
+	}
+
+	// /refresh re-analyzes, which fails once the project is gone.
+	w := httptest.NewRecorder()
+	second.handleRefresh(w, httptest.NewRequest(http.MethodPost, "/api/diagram/refresh", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("refresh should re-analyze the (now empty) project, got %d", w.Code)
+	}
+}
+
+func TestLoadCachedMetadata_UnreadableCacheFallsBack(t *testing.T) {
+	cache := filepath.Join(t.TempDir(), "metadata.yaml")
+	if err := os.WriteFile(cache, []byte("call_graph: [not: {valid"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := cachedServer(cache)
+	if err := s.LoadCachedMetadata(); err != nil {
+		t.Skipf("engine generate unavailable: %v", err)
+	}
+	if s.metadataSource != "analysis" {
+		t.Errorf("a corrupt cache should fall back to analysis, got source %q", s.metadataSource)
+	}
+	if _, err := metadata.LoadMetadata(cache); err != nil {
+		t.Errorf("the analysis should have replaced the corrupt cache: %v", err)
+	}
+}
+
+func TestLoadMetadata_ServesMetadataFile(t *testing.T) {
+	analyzed := cachedServer("")
+	if err := analyzed.LoadMetadata(); err != nil {
+		t.Skipf("engine generate unavailable: %v", err)
+	}
+	dir := t.TempDir()
+	single := filepath.Join(dir, "metadata.yaml")
+	if err := metadata.WriteMetadata(analyzed.metadata, single); err != nil {
+		t.Fatal(err)
+	}
+	split := filepath.Join(dir, "split.yaml")
+	if err := metadata.WriteSplitMetadata(analyzed.metadata, split); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{single, split} {
+		// No sources: the diagrams come from the file alone.
+		s := cachedServer(filepath.Join(dir, "unused-cache.yaml"))
+		s.config.InputDir = t.TempDir()
+		s.config.MetadataFile = path
+		if err := s.LoadCachedMetadata(); err != nil {
+			t.Fatalf("%s: %v", filepath.Base(path), err)
+		}
+		if s.metadataSource != "file" {
+			t.Errorf("%s: source %q, want file", filepath.Base(path), s.metadataSource)
+		}
+
+		for _, diagramType := range []string{"call-graph", "tracker-tree"} {
+			want := analyzed.getAllData(diagramType, true)
+			got := s.getAllData(diagramType, true)
+			if len(got.Nodes) != len(want.Nodes) || len(got.Edges) != len(want.Edges) {
+				t.Errorf("%s: %s has %d nodes, %d edges; analyzed one %d, %d", filepath.Base(path),
+					diagramType, len(got.Nodes), len(got.Edges), len(want.Nodes), len(want.Edges))
+			}
+		}
+
+		// Refresh reads the file again rather than analyzing the empty dir.
+		w := httptest.NewRecorder()
+		s.handleRefresh(w, httptest.NewRequest(http.MethodPost, "/api/diagram/refresh", nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: refresh = %d, want 200", filepath.Base(path), w.Code)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "unused-cache.yaml")); !os.IsNotExist(err) {
+		t.Error("a served metadata file should not be written to the cache")
+	}
+
+	missing := cachedServer("")
+	missing.config.MetadataFile = filepath.Join(dir, "missing.yaml")
+	if err := missing.LoadMetadata(); err == nil {
+		t.Error("a missing metadata file should fail to load")
+	}
+}
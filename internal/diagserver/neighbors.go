@@ -0,0 +1,224 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+// Neighborhood directions: which edges /neighbors follows from a node.
+const (
+	directionIn   = "in"
+	directionOut  = "out"
+	directionBoth = "both"
+)
+
+const (
+	maxNeighborDepth = 10
+	// maxNeighborNodes caps an ego network at the size of the largest page.
+	maxNeighborNodes = 2000
+)
+
+// NeighborsResponse is the /neighbors response: the nodes within Depth edges
+// of Node in Direction, their compound parents, and the edges among them.
+// Truncated is set when the walk stopped at maxNeighborNodes.
+type NeighborsResponse struct {
+	Node        string               `json:"node"`
+	Direction   string               `json:"direction"`
+	Depth       int                  `json:"depth"`
+	Nodes       []spec.CytoscapeNode `json:"nodes"`
+	Edges       []spec.CytoscapeEdge `json:"edges"`
+	Truncated   bool                 `json:"truncated"`
+	DiagramType string               `json:"diagram_type"`
+}
+
+// adjacency indexes a diagram's nodes by ID and its edges by endpoint, both
+// as positions in its own copy of the diagram's slices, since /page swaps
+// the cached tracker tree's node slice for a reordered one.
+type adjacency struct {
+	nodeList []spec.CytoscapeNode
+	edgeList []spec.CytoscapeEdge
+	nodes    map[string]int
+	out      map[string][]int
+	in       map[string][]int
+}
+
+func newAdjacency(data *spec.CytoscapeData) *adjacency {
+	a := &adjacency{
+		nodeList: data.Nodes,
+		edgeList: data.Edges,
+		nodes:    make(map[string]int, len(data.Nodes)),
+		out:      make(map[string][]int),
+		in:       make(map[string][]int),
+	}
+	for i, node := range data.Nodes {
+		a.nodes[node.Data.ID] = i
+	}
+	for i, edge := range data.Edges {
+		a.out[edge.Data.Source] = append(a.out[edge.Data.Source], i)
+		a.in[edge.Data.Target] = append(a.in[edge.Data.Target], i)
+	}
+	return a
+}
+
+// neighborhood walks breadth-first from id along the edges of direction, up
+// to depth hops and limit nodes. It returns the reached nodes, nearest
+// first, followed by any compound parents they lack, the edges among them,
+// and whether limit cut the walk short.
+func (a *adjacency) neighborhood(id, direction string, depth, limit int) ([]spec.CytoscapeNode, []spec.CytoscapeEdge, bool) {
+	reached := map[string]bool{id: true}
+	order := []string{id}
+	frontier := []string{id}
+	truncated := false
+
+	for hop := 0; hop < depth && len(frontier) > 0 && !truncated; hop++ {
+		var next []string
+		visit := func(neighbor string) {
+			if reached[neighbor] || truncated {
+				return
+			}
+			if _, ok := a.nodes[neighbor]; !ok {
+				return
+			}
+			if len(order) == limit {
+				truncated = true
+				return
+			}
+			reached[neighbor] = true
+			order = append(order, neighbor)
+			next = append(next, neighbor)
+		}
+		for _, current := range frontier {
+			if direction != directionIn {
+				for _, e := range a.out[current] {
+					visit(a.edgeList[e].Data.Target)
+				}
+			}
+			if direction != directionOut {
+				for _, e := range a.in[current] {
+					visit(a.edgeList[e].Data.Source)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	nodes := make([]spec.CytoscapeNode, 0, len(order))
+	for _, nodeID := range order {
+		nodes = append(nodes, a.nodeList[a.nodes[nodeID]])
+	}
+	// A compound child cannot be drawn without its parent, as in /page.
+	for i := 0; i < len(nodes); i++ {
+		parent := nodes[i].Data.Parent
+		if parent == "" || reached[parent] {
+			continue
+		}
+		if p, ok := a.nodes[parent]; ok {
+			reached[parent] = true
+			parentNode := a.nodeList[p]
+			parentNode.Data.IsParentFunction = "true"
+			nodes = append(nodes, parentNode)
+		}
+	}
+
+	edges := []spec.CytoscapeEdge{}
+	for _, node := range nodes {
+		for _, e := range a.out[node.Data.ID] {
+			if edge := a.edgeList[e]; reached[edge.Data.Target] {
+				edges = append(edges, edge)
+			}
+		}
+	}
+	return nodes, edges, truncated
+}
+
+// handleNeighbors returns the ego network of a node of the server's diagram:
+// node (required), direction (in, out or both; default both) and depth
+// (default 1, max 10), so a client can expand the graph around a node on
+// demand instead of paging through unrelated ones.
+func (s *Server) handleNeighbors(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	id := query.Get("node")
+	if id == "" {
+		s.writeError(w, "Missing query parameter node", http.StatusBadRequest)
+		return
+	}
+	direction := query.Get("direction")
+	switch direction {
+	case "":
+		direction = directionBoth
+	case directionIn, directionOut, directionBoth:
+	default:
+		s.writeError(w, fmt.Sprintf("Invalid direction %q: use in, out or both", direction), http.StatusBadRequest)
+		return
+	}
+	depth := 1
+	if raw := query.Get("depth"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			s.writeError(w, fmt.Sprintf("Invalid depth %q", raw), http.StatusBadRequest)
+			return
+		}
+		depth = min(parsed, maxNeighborDepth)
+	}
+
+	if err := s.ensureMetadata(); err != nil {
+		s.writeError(w, fmt.Sprintf("Failed to load metadata: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	graph := s.diagramAdjacency()
+	if _, ok := graph.nodes[id]; !ok {
+		s.writeError(w, fmt.Sprintf("Node %q not found", id), http.StatusNotFound)
+		return
+	}
+	nodes, edges, truncated := graph.neighborhood(id, direction, depth, maxNeighborNodes)
+	s.writeJSON(w, NeighborsResponse{
+		Node:        id,
+		Direction:   direction,
+		Depth:       depth,
+		Nodes:       nodes,
+		Edges:       edges,
+		Truncated:   truncated,
+		DiagramType: s.config.DiagramType,
+	})
+}
+
+// diagramAdjacency returns the adjacency of the current metadata's diagram,
+// building it on first use.
+func (s *Server) diagramAdjacency() *adjacency {
+	s.mu.RLock()
+	meta, graph := s.metadata, s.adjacency
+	s.mu.RUnlock()
+	if graph != nil {
+		return graph
+	}
+
+	graph = newAdjacency(s.getAllData(s.config.DiagramType, true))
+	s.mu.Lock()
+	if s.metadata == meta {
+		s.adjacency = graph
+	}
+	s.mu.Unlock()
+	return graph
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+// neighborsGraph is main -> a -> b -> c, x -> a, with lit a function
+// literal inside b that calls c.
+func neighborsGraph() *spec.CytoscapeData {
+	data := &spec.CytoscapeData{}
+	for _, id := range []string{"main", "a", "b", "c", "x"} {
+		data.Nodes = append(data.Nodes, spec.CytoscapeNode{Data: spec.CytoscapeNodeData{ID: id, Type: "function"}})
+	}
+	data.Nodes = append(data.Nodes, spec.CytoscapeNode{Data: spec.CytoscapeNodeData{ID: "lit", Parent: "b", Type: "function"}})
+	for _, e := range [][2]string{{"main", "a"}, {"a", "b"}, {"b", "c"}, {"x", "a"}, {"lit", "c"}} {
+		data.Edges = append(data.Edges, spec.CytoscapeEdge{Data: spec.CytoscapeEdgeData{
+			ID: e[0] + "->" + e[1], Source: e[0], Target: e[1], Type: "calls",
+		}})
+	}
+	return data
+}
+
+func TestAdjacencyNeighborhood(t *testing.T) {
+	graph := newAdjacency(neighborsGraph())
+	nodeIDs := func(nodes []spec.CytoscapeNode) []string {
+		var ids []string
+		for _, n := range nodes {
+			ids = append(ids, n.Data.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		node, direction string
+		depth, limit    int
+		wantNodes       []string
+		wantEdges       int
+		wantTruncated   bool
+	}{
+		{"a", directionOut, 1, 100, []string{"a", "b"}, 1, false},
+		{"a", directionIn, 1, 100, []string{"a", "main", "x"}, 2, false},
+		{"a", directionBoth, 1, 100, []string{"a", "b", "main", "x"}, 3, false},
+		{"main", directionOut, 2, 100, []string{"main", "a", "b"}, 2, false},
+		{"a", directionOut, 0, 100, []string{"a"}, 0, false},
+		// The literal brings its parent b, and the edges among them come too.
+		{"c", directionIn, 1, 100, []string{"c", "b", "lit"}, 2, false},
+		{"a", directionBoth, 1, 2, []string{"a", "b"}, 1, true},
+	}
+	for _, tt := range tests {
+		nodes, edges, truncated := graph.neighborhood(tt.node, tt.direction, tt.depth, tt.limit)
+		got := nodeIDs(nodes)
+		if !slices.Equal(got, tt.wantNodes) || len(edges) != tt.wantEdges || truncated != tt.wantTruncated {
+			t.Errorf("neighborhood(%s, %s, %d, %d) = %v, %d edges, truncated %v; want %v, %d, %v",
+				tt.node, tt.direction, tt.depth, tt.limit, got, len(edges), truncated,
+				tt.wantNodes, tt.wantEdges, tt.wantTruncated)
+		}
+	}
+}
+
+func TestHandleNeighbors(t *testing.T) {
+	s := injectedServer(t)
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.handleNeighbors(w, httptest.NewRequest(http.MethodGet, "/api/diagram/neighbors"+query, nil))
+		return w
+	}
+
+	all := s.getAllData(s.config.DiagramType, true)
+	if len(all.Edges) == 0 {
+		t.Skip("fixture has no edges")
+	}
+	root := all.Edges[0].Data.Source
+
+	w := get("?node=" + root + "&direction=out&depth=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	var resp NeighborsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Nodes) < 2 || resp.Nodes[0].Data.ID != root || len(resp.Edges) == 0 {
+		t.Fatalf("got %d nodes, %d edges around %s", len(resp.Nodes), len(resp.Edges), root)
+	}
+	if len(resp.Nodes) >= len(all.Nodes) {
+		t.Errorf("ego network has all %d nodes", len(all.Nodes))
+	}
+	returned := map[string]bool{}
+	for _, n := range resp.Nodes {
+		returned[n.Data.ID] = true
+	}
+	for _, e := range resp.Edges {
+		if !returned[e.Data.Source] || !returned[e.Data.Target] {
+			t.Errorf("edge %s leaves the ego network", e.Data.ID)
+		}
+	}
+
+	for query, want := range map[string]int{
+		"":                                http.StatusBadRequest,
+		"?node=" + root + "&direction=up": http.StatusBadRequest,
+		"?node=" + root + "&depth=-1":     http.StatusBadRequest,
+		"?node=missing":                   http.StatusNotFound,
+	} {
+		if w := get(query); w.Code != want {
+			t.Errorf("%q: status = %d, want %d", query, w.Code, want)
+		}
+	}
+	w = httptest.NewRecorder()
+	s.handleNeighbors(w, httptest.NewRequest(http.MethodPost, "/api/diagram/neighbors?node="+root, nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want 405", w.Code)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+const (
+	maxPaths = 20
+	// defaultPathLength and maxPathLength bound the calls in a path when more
+	// than the shortest one is asked for.
+	defaultPathLength = 10
+	maxPathLength     = 30
+)
+
+// PathResponse is the /path response. From and To are the functions' base
+// IDs; Paths is empty when To is not reachable. Nodes and Edges are the
+// call-graph diagram elements the paths go through, for a client to add.
+type PathResponse struct {
+	From      string               `json:"from"`
+	To        string               `json:"to"`
+	Reachable bool                 `json:"reachable"`
+	Paths     []CallPath           `json:"paths"`
+	Nodes     []spec.CytoscapeNode `json:"nodes"`
+	Edges     []spec.CytoscapeEdge `json:"edges"`
+}
+
+// CallPath is one call path: its functions as call-graph node IDs, in call
+// order, and the calls between them.
+type CallPath struct {
+	Nodes []string   `json:"nodes"`
+	Calls []PathCall `json:"calls"`
+}
+
+// PathCall is one call of a path, between two functions' base IDs. Position
+// is the call site; Edge the call-graph edge ID.
+type PathCall struct {
+	Caller   string `json:"caller"`
+	Callee   string `json:"callee"`
+	Position string `json:"position,omitempty"`
+	Edge     string `json:"edge,omitempty"`
+}
+
+// handlePath answers how function from reaches function to: the shortest
+// call path, or with k above 1 up to k paths (max 20) of at most depth calls
+// (default 10, max 30), shortest first. from and to are node IDs of the
+// server's diagram or function base IDs ("pkg.name", "pkg.recv.name").
+func (s *Server) handlePath(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	if query.Get("from") == "" || query.Get("to") == "" {
+		s.writeError(w, "Missing query parameter from or to", http.StatusBadRequest)
+		return
+	}
+	k, err := intParam(query.Get("k"), 1)
+	if err != nil || k < 1 {
+		s.writeError(w, fmt.Sprintf("Invalid k %q", query.Get("k")), http.StatusBadRequest)
+		return
+	}
+	maxLen, err := intParam(query.Get("depth"), defaultPathLength)
+	if err != nil || maxLen < 0 {
+		s.writeError(w, fmt.Sprintf("Invalid depth %q", query.Get("depth")), http.StatusBadRequest)
+		return
+	}
+	k, maxLen = min(k, maxPaths), min(maxLen, maxPathLength)
+
+	if err := s.ensureMetadata(); err != nil {
+		s.writeError(w, fmt.Sprintf("Failed to load metadata: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	s.mu.RLock()
+	meta := s.metadata
+	s.mu.RUnlock()
+	from, ok := s.resolveFunction(meta, query.Get("from"))
+	if !ok {
+		s.writeError(w, fmt.Sprintf("Function %q not found", query.Get("from")), http.StatusNotFound)
+		return
+	}
+	to, ok := s.resolveFunction(meta, query.Get("to"))
+	if !ok {
+		s.writeError(w, fmt.Sprintf("Function %q not found", query.Get("to")), http.StatusNotFound)
+		return
+	}
+
+	var paths [][]*metadata.CallGraphEdge
+	if k == 1 {
+		if path := meta.GetCallPath(from, to); path != nil {
+			paths = append(paths, path)
+		}
+	} else {
+		paths = meta.GetCallPaths(from, to, k, maxLen)
+	}
+
+	s.writeJSON(w, buildPathResponse(from, to, paths, s.getAllData("call-graph", true), meta))
+}
+
+// resolveFunction returns the base ID of id, a node of the server's diagram
+// or a base ID, if the call graph has that function.
+func (s *Server) resolveFunction(meta *metadata.Metadata, id string) (string, bool) {
+	base := id
+	graph := s.diagramAdjacency()
+	if i, ok := graph.nodes[id]; ok {
+		base = nodeBaseID(graph.nodeList[i].Data)
+	}
+	if meta.Callers == nil {
+		meta.BuildCallGraphMaps()
+	}
+	_, caller := meta.Callers[base]
+	_, callee := meta.Callees[base]
+	return base, caller || callee
+}
+
+// nodeBaseID returns the base ID of the function a diagram node stands for.
+func nodeBaseID(d spec.CytoscapeNodeData) string {
+	return metadata.NewCallIdentifier(d.Package, d.FunctionName, d.ReceiverType, "", nil).ID(metadata.BaseID)
+}
+
+// buildPathResponse maps the paths from function from to function to onto
+// the nodes and edges of callGraph.
+func buildPathResponse(from, to string, paths [][]*metadata.CallGraphEdge, callGraph *spec.CytoscapeData, meta *metadata.Metadata) *PathResponse {
+	nodeOf := make(map[string]int)
+	for i, node := range callGraph.Nodes {
+		if node.Data.Type != "function" {
+			continue
+		}
+		if base := nodeBaseID(node.Data); nodeOf[base] == 0 {
+			nodeOf[base] = i + 1 // zero is "no node"
+		}
+	}
+	edgeOf := make(map[[2]string]int)
+	for i, edge := range callGraph.Edges {
+		edgeOf[[2]string{edge.Data.Source, edge.Data.Target}] = i
+	}
+	nodeID := func(base string) string {
+		if i := nodeOf[base]; i > 0 {
+			return callGraph.Nodes[i-1].Data.ID
+		}
+		return ""
+	}
+
+	response := &PathResponse{
+		From:      from,
+		To:        to,
+		Reachable: len(paths) > 0,
+		Paths:     []CallPath{},
+		Nodes:     []spec.CytoscapeNode{},
+		Edges:     []spec.CytoscapeEdge{},
+	}
+	addedNodes := map[string]bool{}
+	addNode := func(base string) string {
+		id := nodeID(base)
+		if id != "" && !addedNodes[id] {
+			addedNodes[id] = true
+			response.Nodes = append(response.Nodes, callGraph.Nodes[nodeOf[base]-1])
+		}
+		return id
+	}
+	addedEdges := map[int]bool{}
+
+	for _, path := range paths {
+		callPath := CallPath{Nodes: []string{addNode(from)}, Calls: []PathCall{}}
+		for _, edge := range path {
+			caller, callee := edge.Caller.BaseID(), edge.Callee.BaseID()
+			source, target := nodeID(caller), addNode(callee)
+			callPath.Nodes = append(callPath.Nodes, target)
+
+			call := PathCall{Caller: caller, Callee: callee, Position: meta.StringPool.GetString(edge.Position)}
+			if i, ok := edgeOf[[2]string{source, target}]; ok {
+				call.Edge = callGraph.Edges[i].Data.ID
+				if !addedEdges[i] {
+					addedEdges[i] = true
+					response.Edges = append(response.Edges, callGraph.Edges[i])
+				}
+			}
+			callPath.Calls = append(callPath.Calls, call)
+		}
+		response.Paths = append(response.Paths, callPath)
+	}
+	// A literal on a path is drawn inside its enclosing function.
+	for i := 0; i < len(response.Nodes); i++ {
+		parent := response.Nodes[i].Data.Parent
+		if parent == "" || addedNodes[parent] {
+			continue
+		}
+		for _, node := range callGraph.Nodes {
+			if node.Data.ID == parent {
+				addedNodes[parent] = true
+				response.Nodes = append(response.Nodes, node)
+				break
+			}
+		}
+	}
+	return response
+}
+
+// intParam parses an optional integer query parameter.
+func intParam(raw string, fallback int) (int, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	return strconv.Atoi(raw)
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestHandlePath(t *testing.T) {
+	s := injectedServer(t)
+	get := func(from, to, extra string) (*httptest.ResponseRecorder, PathResponse) {
+		w := httptest.NewRecorder()
+		target := "/api/diagram/path?from=" + url.QueryEscape(from) + "&to=" + url.QueryEscape(to) + extra
+		s.handlePath(w, httptest.NewRequest(http.MethodGet, target, nil))
+		var resp PathResponse
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return w, resp
+	}
+
+	// The farthest function main reaches is the end of the path to test.
+	var mainID string
+	for _, node := range s.getAllData("call-graph", true).Nodes {
+		if node.Data.FunctionName == "main" {
+			mainID = node.Data.ID
+		}
+	}
+	if mainID == "" {
+		t.Skip("fixture has no main")
+	}
+	reached, _, _ := s.diagramAdjacency().neighborhood(mainID, directionOut, maxNeighborDepth, maxNeighborNodes)
+	targetID := reached[len(reached)-1].Data.ID
+	if targetID == mainID {
+		t.Skip("main calls nothing")
+	}
+
+	w, resp := get(mainID, targetID, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	if !resp.Reachable || len(resp.Paths) != 1 {
+		t.Fatalf("main -> %s: reachable %v with %d paths", targetID, resp.Reachable, len(resp.Paths))
+	}
+	path := resp.Paths[0]
+	if path.Nodes[0] != mainID || path.Nodes[len(path.Nodes)-1] != targetID || len(path.Calls) != len(path.Nodes)-1 {
+		t.Errorf("path %v does not run from %s to %s", path.Nodes, mainID, targetID)
+	}
+	edges := map[string]bool{}
+	for _, e := range resp.Edges {
+		edges[e.Data.ID] = true
+	}
+	for _, call := range path.Calls {
+		if call.Edge == "" || !edges[call.Edge] {
+			t.Errorf("call %s -> %s has no diagram edge in the response", call.Caller, call.Callee)
+		}
+	}
+	if len(resp.Nodes) < len(path.Nodes) {
+		t.Errorf("%d nodes returned for a %d-node path", len(resp.Nodes), len(path.Nodes))
+	}
+
+	// Base IDs work too, and k asks for more paths, shortest first.
+	_, byBase := get(resp.From, resp.To, "&k=5")
+	if !byBase.Reachable || len(byBase.Paths) == 0 || len(byBase.Paths[0].Calls) != len(path.Calls) {
+		t.Errorf("by base ID: %d paths, want the shortest first", len(byBase.Paths))
+	}
+	for i := 1; i < len(byBase.Paths); i++ {
+		if len(byBase.Paths[i].Calls) < len(byBase.Paths[i-1].Calls) {
+			t.Error("paths not ordered by length")
+		}
+	}
+
+	if _, back := get(targetID, mainID, ""); back.Reachable || len(back.Paths) != 0 {
+		t.Errorf("%s reaches main: %+v", targetID, back.Paths)
+	}
+
+	for _, tc := range []struct {
+		from, to, extra string
+		want            int
+	}{
+		{"", targetID, "", http.StatusBadRequest},
+		{mainID, targetID, "&k=0", http.StatusBadRequest},
+		{mainID, targetID, "&depth=x", http.StatusBadRequest},
+		{mainID, "example.com/none.Missing", "", http.StatusNotFound},
+	} {
+		if w, _ := get(tc.from, tc.to, tc.extra); w.Code != tc.want {
+			t.Errorf("from=%q to=%q%s: status = %d, want %d", tc.from, tc.to, tc.extra, w.Code, tc.want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/engine"
+	"github.com/ehabterra/apispec/internal/metadata"
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+// maxRouteTraceNodes bounds the walk of a route's tracker subtree when
+// looking for its handler.
+const maxRouteTraceNodes = 5000
+
+// RouteOverlay is the /routes response: a "route" node per extracted route
+// (METHOD /path) and a "handles" edge from it to the call-graph node of its
+// handler. Edge targets are node IDs of the call-graph diagram, whatever
+// the server's diagram type.
+type RouteOverlay struct {
+	Nodes       []spec.CytoscapeNode `json:"nodes"`
+	Edges       []spec.CytoscapeEdge `json:"edges"`
+	TotalRoutes int                  `json:"total_routes"`
+	Linked      int                  `json:"linked"`
+}
+
+// handleRoutes runs the spec extractor over the loaded metadata and returns
+// the route overlay. The overlay is built once per metadata load.
+func (s *Server) handleRoutes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := s.ensureMetadata(); err != nil {
+		s.writeError(w, fmt.Sprintf("Failed to load metadata: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	overlay, err := s.routeOverlay()
+	if err != nil {
+		s.writeError(w, fmt.Sprintf("Failed to extract routes: %v", err), http.StatusInternalServerError)
+		return
+	}
+	s.writeJSON(w, overlay)
+}
+
+// routeOverlay returns the cached overlay, extracting the routes when the
+// metadata changed since the last one.
+func (s *Server) routeOverlay() (*RouteOverlay, error) {
+	s.routesMu.Lock()
+	defer s.routesMu.Unlock()
+
+	s.mu.RLock()
+	meta, overlay := s.metadata, s.routes
+	dir, configFile := s.config.InputDir, s.config.ConfigFile
+	s.mu.RUnlock()
+	if overlay != nil {
+		return overlay, nil
+	}
+
+	// The engine has not generated this metadata, so it has no module root:
+	// frameworks are detected from the imports the metadata records, which
+	// also works for a metadata file served without its sources.
+	engineConfig := s.engineConfig(dir)
+	engineConfig.ConfigFile = configFile
+	genEngine := engine.NewEngine(engineConfig)
+	if _, err := genEngine.GenerateOpenAPIFromMetadata(meta); err != nil {
+		return nil, err
+	}
+
+	overlay = buildRouteOverlay(genEngine.GetRoutes(), s.getAllData("call-graph", true), meta)
+	if s.config.Verbose {
+		log.Printf("🧭 %d routes, %d linked to their handler", overlay.TotalRoutes, overlay.Linked)
+	}
+
+	s.mu.Lock()
+	if s.metadata == meta {
+		s.routes = overlay
+	}
+	s.mu.Unlock()
+	return overlay, nil
+}
+
+// buildRouteOverlay makes a node per route and links it to its handler's
+// node in callGraph. The handler is the function named by the route's
+// handler expression that calls from within the route's tracker subtree;
+// failing that, the only function of that name in the route's package, or
+// in the whole graph. A route whose handler cannot be pinned down stays
+// unlinked rather than pointing at a namesake.
+func buildRouteOverlay(routes []*spec.RouteInfo, callGraph *spec.CytoscapeData, meta *metadata.Metadata) *RouteOverlay {
+	byKey := make(map[string]string)
+	byName := make(map[string][]spec.CytoscapeNode)
+	for _, node := range callGraph.Nodes {
+		if node.Data.Type != "function" {
+			continue
+		}
+		d := node.Data
+		byKey[functionKey(d.Package, d.ReceiverType, d.FunctionName)] = d.ID
+		byName[d.FunctionName] = append(byName[d.FunctionName], node)
+	}
+
+	overlay := &RouteOverlay{
+		Nodes:       []spec.CytoscapeNode{},
+		Edges:       []spec.CytoscapeEdge{},
+		TotalRoutes: len(routes),
+	}
+	for i, route := range routes {
+		id := fmt.Sprintf("route_%d", i+1)
+		overlay.Nodes = append(overlay.Nodes, spec.CytoscapeNode{Data: spec.CytoscapeNodeData{
+			ID:           id,
+			Label:        route.Method + " " + route.OpenAPIPath(),
+			Type:         "route",
+			Package:      route.Package,
+			FunctionName: route.Handler,
+			Position:     route.File,
+		}})
+
+		name := handlerFunctionName(route.Handler)
+		target := byKey[tracedHandlerKey(route.Node, name, meta)]
+		if target == "" {
+			target = uniqueFunction(byName[name], route.Package)
+		}
+		if target == "" {
+			continue
+		}
+		overlay.Linked++
+		overlay.Edges = append(overlay.Edges, spec.CytoscapeEdge{Data: spec.CytoscapeEdgeData{
+			ID:     id + "_handler",
+			Source: id,
+			Target: target,
+			Type:   "handles",
+		}})
+	}
+	return overlay
+}
+
+// handlerFunctionName returns the function name at the end of a route's
+// handler expression: GetUser for "users.GetUser" or
+// "example.com/app-->handler.GetUser". A function literal keeps its
+// "FuncLit:file:line:col" name, which the call graph uses too.
+func handlerFunctionName(handler string) string {
+	if strings.HasPrefix(handler, "FuncLit:") {
+		return handler
+	}
+	if i := strings.LastIndex(handler, "-->"); i >= 0 {
+		handler = handler[i+len("-->"):]
+	}
+	if i := strings.LastIndex(handler, "."); i >= 0 {
+		handler = handler[i+1:]
+	}
+	return handler
+}
+
+// tracedHandlerKey returns the functionKey of the shallowest caller named
+// name in the tracker subtree under node: the handler, seen from the calls
+// its body makes. Empty when there is none.
+func tracedHandlerKey(node spec.TrackerNodeInterface, name string, meta *metadata.Metadata) string {
+	if node == nil || name == "" || meta == nil {
+		return ""
+	}
+	queue := []spec.TrackerNodeInterface{node}
+	for visited := 0; len(queue) > 0 && visited < maxRouteTraceNodes; visited++ {
+		current := queue[0]
+		queue = queue[1:]
+		if edge := current.GetEdge(); edge != nil && current != node &&
+			meta.StringPool.GetString(edge.Caller.Name) == name {
+			return functionKey(
+				meta.StringPool.GetString(edge.Caller.Pkg),
+				meta.StringPool.GetString(edge.Caller.RecvType),
+				name)
+		}
+		queue = append(queue, current.GetChildren()...)
+	}
+	return ""
+}
+
+// uniqueFunction returns the ID of the one candidate in pkg, or else of the
+// only candidate, or "".
+func uniqueFunction(candidates []spec.CytoscapeNode, pkg string) string {
+	var inPkg []string
+	for _, node := range candidates {
+		if node.Data.Package == pkg {
+			inPkg = append(inPkg, node.Data.ID)
+		}
+	}
+	switch {
+	case len(inPkg) == 1:
+		return inPkg[0]
+	case len(inPkg) == 0 && len(candidates) == 1:
+		return candidates[0].Data.ID
+	}
+	return ""
+}
+
+// functionKey identifies a function node across the tracker tree and the
+// call-graph diagram.
+func functionKey(pkg, recv, name string) string {
+	return pkg + "\x00" + recv + "\x00" + name
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandleRoutes(t *testing.T) {
+	// The echo fixture's metadata file: routes come from the metadata alone.
+	s := injectedServer(t)
+
+	w := httptest.NewRecorder()
+	s.handleRoutes(w, httptest.NewRequest(http.MethodGet, "/api/diagram/routes", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	var overlay RouteOverlay
+	if err := json.Unmarshal(w.Body.Bytes(), &overlay); err != nil {
+		t.Fatal(err)
+	}
+	if overlay.TotalRoutes == 0 || len(overlay.Nodes) != overlay.TotalRoutes {
+		t.Fatalf("got %d route nodes for %d routes", len(overlay.Nodes), overlay.TotalRoutes)
+	}
+	if overlay.Linked != overlay.TotalRoutes || len(overlay.Edges) != overlay.Linked {
+		t.Errorf("linked %d of %d routes (%d edges), want all", overlay.Linked, overlay.TotalRoutes, len(overlay.Edges))
+	}
+
+	functions := map[string]string{}
+	for _, node := range s.getAllData("call-graph", true).Nodes {
+		if node.Data.Type == "function" {
+			functions[node.Data.ID] = node.Data.FunctionName
+		}
+	}
+	routes := map[string]string{}
+	for _, node := range overlay.Nodes {
+		if node.Data.Type != "route" || !strings.Contains(node.Data.Label, " /") {
+			t.Errorf("route node %+v, want type route and a METHOD /path label", node.Data)
+		}
+		routes[node.Data.ID] = node.Data.FunctionName
+	}
+	for _, edge := range overlay.Edges {
+		handler, ok := functions[edge.Data.Target]
+		if !ok {
+			t.Errorf("edge %s targets %s, not a call-graph function", edge.Data.ID, edge.Data.Target)
+			continue
+		}
+		if want := handlerFunctionName(routes[edge.Data.Source]); handler != want {
+			t.Errorf("%s linked to %s, want %s", edge.Data.Source, handler, want)
+		}
+	}
+
+	// Cached until the metadata changes.
+	first, _ := s.routeOverlay()
+	if again, _ := s.routeOverlay(); again != first {
+		t.Error("overlay rebuilt without a metadata change")
+	}
+	s.setMetadata(s.metadata, "", "analysis")
+	if again, _ := s.routeOverlay(); again == first {
+		t.Error("overlay kept across a metadata reload")
+	}
+
+	w = httptest.NewRecorder()
+	s.handleRoutes(w, httptest.NewRequest(http.MethodPost, "/api/diagram/routes", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want 405", w.Code)
+	}
+}
+
+func TestHandlerFunctionName(t *testing.T) {
+	tests := map[string]string{
+		"GetUser":                      "GetUser",
+		"users.GetUser":                "GetUser",
+		"example.com/app-->h.GetUser":  "GetUser",
+		"FuncLit:/src/main.go:25:21":   "FuncLit:/src/main.go:25:21",
+		"example.com/app-->handler.Do": "Do",
+	}
+	for handler, want := range tests {
+		if got := handlerFunctionName(handler); got != want {
+			t.Errorf("handlerFunctionName(%q) = %q, want %q", handler, got, want)
+		}
+	}
+}
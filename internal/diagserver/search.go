@@ -0,0 +1,292 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 200
+)
+
+// Match kinds, best first. A field's score is its kind times the field's
+// weight, so an exact package name outranks a substring of a function name.
+const (
+	matchFuzzy   = 100 // the query's characters appear in order
+	matchInfix   = 300 // a substring
+	matchSegment = 500 // a prefix of a path, dotted or camelCase segment
+	matchPrefix  = 700
+	matchExact   = 1000
+)
+
+// searchFields are the node fields a query is matched against, with their
+// weights.
+var searchFields = []struct {
+	name   string
+	weight int
+	value  func(*spec.CytoscapeNodeData) string
+}{
+	{"function", 4, func(d *spec.CytoscapeNodeData) string { return d.FunctionName }},
+	{"receiver", 3, func(d *spec.CytoscapeNodeData) string { return d.ReceiverType }},
+	{"package", 2, func(d *spec.CytoscapeNodeData) string { return d.Package }},
+	{"file", 1, func(d *spec.CytoscapeNodeData) string { return d.Position }},
+}
+
+// SearchResult is a node matching a /search query.
+type SearchResult struct {
+	ID           string `json:"id"`
+	Label        string `json:"label"`
+	Package      string `json:"package,omitempty"`
+	FunctionName string `json:"function_name,omitempty"`
+	ReceiverType string `json:"receiver_type,omitempty"`
+	Position     string `json:"position,omitempty"`
+	// Field is the field the query matched best: function, receiver,
+	// package or file.
+	Field string `json:"field"`
+	Score int    `json:"score"`
+}
+
+// SearchResponse is the /search response: the best Results, highest score
+// first, of Total matching nodes.
+type SearchResponse struct {
+	Query       string         `json:"query"`
+	Results     []SearchResult `json:"results"`
+	Total       int            `json:"total"`
+	DiagramType string         `json:"diagram_type"`
+}
+
+// searchIndex holds the function nodes of a diagram with their searchable
+// fields lowercased and their segment starts precomputed, so a query only
+// compares strings.
+type searchIndex struct {
+	entries []searchEntry
+}
+
+type searchEntry struct {
+	node   *spec.CytoscapeNodeData
+	fields []indexedField
+}
+
+type indexedField struct {
+	lower string
+	// starts are the byte offsets in lower where a segment begins.
+	starts []int
+}
+
+// newSearchIndex indexes the function nodes among nodes.
+func newSearchIndex(nodes []spec.CytoscapeNode) *searchIndex {
+	x := &searchIndex{}
+	for i := range nodes {
+		d := &nodes[i].Data
+		if d.Type != "function" {
+			continue
+		}
+		entry := searchEntry{node: d, fields: make([]indexedField, len(searchFields))}
+		for f, field := range searchFields {
+			value := field.value(d)
+			entry.fields[f] = indexedField{lower: strings.ToLower(value), starts: segmentStarts(value)}
+		}
+		x.entries = append(x.entries, entry)
+	}
+	return x
+}
+
+// search returns up to limit nodes matching every whitespace-separated term
+// of query, best first, and the number of matches. A node scores the sum of
+// its best field score per term; ties go to the shorter label, then the ID.
+func (x *searchIndex) search(query string, limit int) ([]SearchResult, int) {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return []SearchResult{}, 0
+	}
+
+	var results []SearchResult
+	for _, entry := range x.entries {
+		total, field := 0, ""
+		for _, term := range terms {
+			best, bestField := 0, ""
+			for f, indexed := range entry.fields {
+				if score := matchScore(indexed, term) * searchFields[f].weight; score > best {
+					best, bestField = score, searchFields[f].name
+				}
+			}
+			if best == 0 {
+				total = 0
+				break
+			}
+			total += best
+			if field == "" {
+				field = bestField
+			}
+		}
+		if total == 0 {
+			continue
+		}
+		d := entry.node
+		results = append(results, SearchResult{
+			ID:           d.ID,
+			Label:        d.Label,
+			Package:      d.Package,
+			FunctionName: d.FunctionName,
+			ReceiverType: d.ReceiverType,
+			Position:     d.Position,
+			Field:        field,
+			Score:        total,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if len(a.Label) != len(b.Label) {
+			return len(a.Label) < len(b.Label)
+		}
+		return a.ID < b.ID
+	})
+	total := len(results)
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	if results == nil {
+		results = []SearchResult{}
+	}
+	return results, total
+}
+
+// matchScore rates how well the lowercase term matches field, or 0.
+func matchScore(field indexedField, term string) int {
+	text := field.lower
+	switch {
+	case text == "":
+		return 0
+	case text == term:
+		return matchExact
+	case strings.HasPrefix(text, term):
+		return matchPrefix
+	}
+	for _, start := range field.starts {
+		if start < len(text) && strings.HasPrefix(text[start:], term) {
+			return matchSegment
+		}
+	}
+	if strings.Contains(text, term) {
+		return matchInfix
+	}
+	return fuzzyScore(text, term)
+}
+
+// fuzzyScore rates term as a subsequence of text: matchFuzzy for adjacent
+// characters, less the more text lies between them, and 0 when term is not a
+// subsequence. Every match scores at least 1.
+func fuzzyScore(text, term string) int {
+	pos, gaps := 0, 0
+	for i, r := range term {
+		j := strings.IndexRune(text[pos:], r)
+		if j < 0 {
+			return 0
+		}
+		if i > 0 {
+			gaps += j
+		}
+		pos += j + utf8.RuneLen(r)
+	}
+	return max(matchFuzzy-gaps*5, 1)
+}
+
+// segmentStarts returns the byte offsets in s, after the first, that start a
+// segment: the character after a separator (/ . _ - : space) or a lowercase
+// to uppercase step. Lowercasing keeps the offsets of ASCII identifiers and
+// paths; matchScore bounds-checks them for the rest.
+func segmentStarts(s string) []int {
+	var starts []int
+	var prev rune
+	for i, r := range s {
+		if i > 0 {
+			switch {
+			case strings.ContainsRune("/._-: ", prev) && !strings.ContainsRune("/._-: ", r):
+				starts = append(starts, i)
+			case unicode.IsLower(prev) && unicode.IsUpper(r):
+				starts = append(starts, i)
+			}
+		}
+		prev = r
+	}
+	return starts
+}
+
+// handleSearch ranks the function nodes of the server's diagram against q.
+// limit caps the results (default 20, max 200).
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		s.writeError(w, "Missing query parameter q", http.StatusBadRequest)
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	if err := s.ensureMetadata(); err != nil {
+		s.writeError(w, fmt.Sprintf("Failed to load metadata: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	results, total := s.nodeIndex().search(query, limit)
+	s.writeJSON(w, SearchResponse{
+		Query:       query,
+		Results:     results,
+		Total:       total,
+		DiagramType: s.config.DiagramType,
+	})
+}
+
+// nodeIndex returns the search index of the current metadata, building it
+// if the metadata was installed without one.
+func (s *Server) nodeIndex() *searchIndex {
+	s.mu.RLock()
+	meta, index := s.metadata, s.search
+	s.mu.RUnlock()
+	if index != nil {
+		return index
+	}
+
+	index = newSearchIndex(s.getAllData(s.config.DiagramType, true).Nodes)
+	s.mu.Lock()
+	if s.metadata == meta {
+		s.search = index
+	}
+	s.mu.Unlock()
+	return index
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+func searchNode(id, pkg, recv, name, pos string) spec.CytoscapeNode {
+	return spec.CytoscapeNode{Data: spec.CytoscapeNodeData{
+		ID: id, Label: name, Type: "function",
+		Package: pkg, ReceiverType: recv, FunctionName: name, Position: pos,
+	}}
+}
+
+func TestSearchIndex_Ranking(t *testing.T) {
+	x := newSearchIndex([]spec.CytoscapeNode{
+		searchNode("exact", "app/handlers", "*UserHandler", "user", "/src/handlers/user.go:10:1"),
+		searchNode("prefix", "app/handlers", "*UserHandler", "UserList", "/src/handlers/user.go:20:1"),
+		searchNode("segment", "app/handlers", "*UserHandler", "GetUser", "/src/handlers/user.go:30:1"),
+		searchNode("infix", "app/store", "", "lookupuserrow", "/src/store/db.go:5:1"),
+		searchNode("fuzzy", "app/store", "", "UpdateSession", "/src/store/db.go:9:1"),
+		searchNode("package", "app/users", "", "Init", "/src/users/init.go:1:1"),
+		{Data: spec.CytoscapeNodeData{ID: "arg", Type: "argument", FunctionName: "user"}},
+	})
+
+	ids := func(results []SearchResult) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	results, total := x.search("User", 10)
+	if want := []string{"exact", "prefix", "segment", "infix", "package"}; !slices.Equal(ids(results), want) {
+		t.Errorf("search(User) = %v, want %v", ids(results), want)
+	}
+	if total != 5 {
+		t.Errorf("total = %d, want 5", total)
+	}
+	if results[4].Field != "package" {
+		t.Errorf("app/users matched on %q, want package", results[4].Field)
+	}
+
+	if got, _ := x.search("updsess", 10); !slices.Equal(ids(got), []string{"fuzzy"}) {
+		t.Errorf("search(updsess) = %v, want the fuzzy match", ids(got))
+	}
+	if got, _ := x.search("store db.go", 10); !slices.Equal(ids(got), []string{"fuzzy", "infix"}) {
+		t.Errorf("search(store db.go) = %v, want nodes matching both terms", ids(got))
+	}
+	if got, total := x.search("user", 2); len(got) != 2 || total != 5 {
+		t.Errorf("limit 2 returned %d of %d", len(got), total)
+	}
+	if got, total := x.search("zzz", 10); len(got) != 0 || total != 0 {
+		t.Errorf("search(zzz) = %v", ids(got))
+	}
+}
+
+func TestHandleSearch(t *testing.T) {
+	s := injectedServer(t)
+
+	get := func(query string) (*httptest.ResponseRecorder, SearchResponse) {
+		w := httptest.NewRecorder()
+		s.handleSearch(w, httptest.NewRequest(http.MethodGet, "/api/diagram/search"+query, nil))
+		var resp SearchResponse
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return w, resp
+	}
+
+	w, resp := get("?q=main&limit=3")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	if len(resp.Results) == 0 || len(resp.Results) > 3 || resp.Total < len(resp.Results) {
+		t.Fatalf("got %d results of %d", len(resp.Results), resp.Total)
+	}
+	if resp.Results[0].FunctionName != "main" || resp.Results[0].Field != "function" {
+		t.Errorf("best match %+v, want the main function", resp.Results[0])
+	}
+	nodes := map[string]bool{}
+	for _, node := range s.getAllData(s.config.DiagramType, true).Nodes {
+		nodes[node.Data.ID] = true
+	}
+	for i, r := range resp.Results {
+		if !nodes[r.ID] {
+			t.Errorf("result %s is not a diagram node", r.ID)
+		}
+		if i > 0 && r.Score > resp.Results[i-1].Score {
+			t.Errorf("results not ranked: %d after %d", r.Score, resp.Results[i-1].Score)
+		}
+	}
+
+	if w, _ := get(""); w.Code != http.StatusBadRequest {
+		t.Errorf("missing q: status = %d, want 400", w.Code)
+	}
+	w = httptest.NewRecorder()
+	s.handleSearch(w, httptest.NewRequest(http.MethodPost, "/api/diagram/search?q=main", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want 405", w.Code)
+	}
+
+	// A reload rebuilds the index.
+	before := s.nodeIndex()
+	s.setMetadata(s.metadata, "", "analysis")
+	if s.search == nil || s.search == before {
+		t.Error("setMetadata did not build a new index")
+	}
+}
@@ -18,8 +18,10 @@ package diagserver
 
 import (
 	"compress/gzip"
+	"context"
 	"embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -46,13 +48,31 @@ type Config struct {
 	PageSize                     int
 	MaxDepth                     int
 	EnableCORS                   bool
-	CacheTimeout                 time.Duration
+	CacheTimeout                 time.Duration // TTL of cached diagrams and pages; zero keeps them until evicted
 	Verbose                      bool
 	AnalyzeFrameworkDependencies bool
 	AutoIncludeFrameworkPackages bool
 	AutoExcludeTests             bool
 	AutoExcludeMocks             bool
 	DiagramType                  string // "call-graph" or "tracker-tree"
+	// ConfigFile is an APISpecConfig file whose include/exclude settings
+	// filter the analysis. WatchConfig reloads it when it changes.
+	ConfigFile string
+	// MetadataCache is a file the analyzed metadata is saved to after every
+	// analysis. LoadCachedMetadata starts from it instead of re-analyzing.
+	MetadataCache string
+	// MetadataFile is metadata written by apispec --write-metadata (or the
+	// split files of --split-metadata). When set, it is served instead of
+	// analyzing InputDir, and a refresh reads it again.
+	MetadataFile string
+	// CacheMaxEntries and CacheMaxMB bound the cache of filtered pages, one
+	// entry per page and filter combination requested; the least recently
+	// used pages are evicted first. Zero means 256 entries and 256 MB.
+	CacheMaxEntries int
+	CacheMaxMB      int
+	// ReadOnly turns /refresh away, so clients of a shared server cannot
+	// trigger a re-analysis. Reloads on config changes still happen.
+	ReadOnly bool
 }
 
 // RouteOptions controls how the server's routes are mounted on a mux.
@@ -63,7 +83,9 @@ type RouteOptions struct {
 	// APIPrefix is the prefix for the JSON API. Defaults to "/api/diagram".
 	// Routes registered: <APIPrefix>, <APIPrefix>/page, <APIPrefix>/packages,
 	// <APIPrefix>/by-packages, <APIPrefix>/stats, <APIPrefix>/refresh,
-	// <APIPrefix>/export.
+	// <APIPrefix>/export, <APIPrefix>/routes, <APIPrefix>/search,
+	// <APIPrefix>/neighbors, <APIPrefix>/path, <APIPrefix>/cache/stats,
+	// <APIPrefix>/stream (WebSocket).
 	APIPrefix string
 	// HealthPath is the health-check endpoint. Defaults to "/health".
 	// Set to empty string to skip registering it.
@@ -74,11 +96,27 @@ type RouteOptions struct {
 type Server struct {
 	config *Config
 
-	mu        sync.RWMutex
-	metadata  *metadata.Metadata
-	lastLoad  time.Time
-	cache     map[string]*spec.PaginatedCytoscapeData
-	dataCache map[string]*spec.CytoscapeData
+	mu       sync.RWMutex
+	metadata *metadata.Metadata
+	lastLoad time.Time
+	// configStamp is the configFingerprint of the config file the metadata
+	// was loaded with.
+	configStamp string
+	// metadataSource is "analysis", "cache" or "file", whichever the
+	// metadata came from.
+	metadataSource string
+	// cache holds filtered pages and dataCache whole diagrams, by type and
+	// depth mode.
+	cache     *lruCache[*spec.PaginatedCytoscapeData]
+	dataCache *lruCache[*spec.CytoscapeData]
+	// routes is the route overlay of the current metadata, built on first
+	// request; routesMu keeps concurrent requests from extracting it twice.
+	routes   *RouteOverlay
+	routesMu sync.Mutex
+	// search indexes the function nodes of the current metadata's diagram,
+	// and adjacency its edges, built on the first /neighbors request.
+	search    *searchIndex
+	adjacency *adjacency
 }
 
 // PaginatedResponse represents a paginated response.
@@ -118,10 +156,11 @@ type PackageHierarchyResponse struct {
 
 // New constructs a Server with the given config.
 func New(config *Config) *Server {
+	pages, diagrams := newDiagramCaches(config)
 	return &Server{
 		config:    config,
-		cache:     make(map[string]*spec.PaginatedCytoscapeData),
-		dataCache: make(map[string]*spec.CytoscapeData),
+		cache:     pages,
+		dataCache: diagrams,
 	}
 }
 
@@ -131,20 +170,64 @@ func (s *Server) SetInputDir(dir string) {
 	s.mu.Lock()
 	s.config.InputDir = dir
 	s.metadata = nil
-	s.cache = make(map[string]*spec.PaginatedCytoscapeData)
-	s.dataCache = make(map[string]*spec.CytoscapeData)
+	s.cache.clear()
+	s.dataCache.clear()
 	s.mu.Unlock()
 }
 
 // LoadMetadata loads and analyzes the Go project at config.InputDir.
 func (s *Server) LoadMetadata() error {
+	return s.LoadMetadataContext(context.Background())
+}
+
+// LoadMetadataContext is LoadMetadata, abandoning the analysis once ctx is
+// done; the metadata loaded before is then kept.
+func (s *Server) LoadMetadataContext(ctx context.Context) error {
 	s.mu.Lock()
-	dir := s.config.InputDir
+	dir, configFile, metadataFile := s.config.InputDir, s.config.ConfigFile, s.config.MetadataFile
 	s.mu.Unlock()
 
+	if metadataFile != "" {
+		return s.loadMetadataFile(metadataFile)
+	}
+
+	var apispecConfig *spec.APISpecConfig
+	var configStamp string
+	if configFile != "" {
+		configStamp = configFingerprint(configFile)
+		loaded, err := spec.LoadAPISpecConfig(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config %s: %w", configFile, err)
+		}
+		apispecConfig = loaded
+	}
+
 	log.Printf("📁 Analyzing project: %s", dir)
 
-	engineConfig := &engine.EngineConfig{
+	engineConfig := s.engineConfig(dir)
+	engineConfig.APISpecConfig = apispecConfig
+
+	genEngine := engine.NewEngine(engineConfig)
+	meta, err := genEngine.GenerateMetadataOnlyContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to generate metadata: %w", err)
+	}
+
+	s.setMetadata(meta, configStamp, "analysis")
+	s.saveMetadataCache(meta)
+
+	log.Printf("✅ Metadata loaded successfully")
+	if s.config.Verbose {
+		log.Printf("📊 Total packages: %d", len(meta.Packages))
+		log.Printf("📊 Total call graph edges: %d", len(meta.CallGraph))
+	}
+
+	return nil
+}
+
+// engineConfig returns the engine settings the server analyzes dir with.
+func (s *Server) engineConfig(dir string) *engine.EngineConfig {
+	return &engine.EngineConfig{
 		Verbose:                      s.config.Verbose,
 		InputDir:                     dir,
 		MaxNodesPerTree:              50000,
@@ -158,27 +241,25 @@ func (s *Server) LoadMetadata() error {
 		AutoExcludeTests:             s.config.AutoExcludeTests,
 		AutoExcludeMocks:             s.config.AutoExcludeMocks,
 	}
+}
 
-	genEngine := engine.NewEngine(engineConfig)
-	meta, err := genEngine.GenerateMetadataOnly()
-	if err != nil {
-		return fmt.Errorf("failed to generate metadata: %w", err)
-	}
-
+// setMetadata installs meta, loaded with the config file whose fingerprint is
+// configStamp, drops the diagrams and routes cached from the previous
+// metadata and builds the search index of the new one.
+func (s *Server) setMetadata(meta *metadata.Metadata, configStamp, source string) {
 	s.mu.Lock()
 	s.metadata = meta
 	s.lastLoad = time.Now()
-	s.cache = make(map[string]*spec.PaginatedCytoscapeData)
-	s.dataCache = make(map[string]*spec.CytoscapeData)
+	s.configStamp = configStamp
+	s.metadataSource = source
+	s.cache.clear()
+	s.dataCache.clear()
+	s.routes = nil
+	s.search = nil
+	s.adjacency = nil
 	s.mu.Unlock()
 
-	log.Printf("✅ Metadata loaded successfully")
-	if s.config.Verbose {
-		log.Printf("📊 Total packages: %d", len(meta.Packages))
-		log.Printf("📊 Total call graph edges: %d", len(meta.CallGraph))
-	}
-
-	return nil
+	s.nodeIndex()
 }
 
 // ensureMetadata lazily loads metadata when a handler needs it.
@@ -208,7 +289,15 @@ func (s *Server) RegisterRoutes(mux *http.ServeMux, opts RouteOptions) {
 	}
 
 	mux.HandleFunc(uiPath, s.handleIndex)
+	s.registerAPI(mux, apiPrefix)
+
+	if healthPath != "" {
+		mux.HandleFunc(healthPath, s.handleHealth)
+	}
+}
 
+// registerAPI mounts the JSON API, and its WebSocket stream, under apiPrefix.
+func (s *Server) registerAPI(mux *http.ServeMux, apiPrefix string) {
 	// JSON API responses are large and very compressible — wrap them with
 	// gzip when the client accepts it.
 	mux.Handle(apiPrefix, gzipMiddleware(http.HandlerFunc(s.handleDiagram)))
@@ -218,10 +307,13 @@ func (s *Server) RegisterRoutes(mux *http.ServeMux, opts RouteOptions) {
 	mux.Handle(apiPrefix+"/stats", gzipMiddleware(http.HandlerFunc(s.handleStats)))
 	mux.HandleFunc(apiPrefix+"/refresh", s.handleRefresh)
 	mux.Handle(apiPrefix+"/export", gzipMiddleware(http.HandlerFunc(s.handleExport)))
-
-	if healthPath != "" {
-		mux.HandleFunc(healthPath, s.handleHealth)
-	}
+	mux.Handle(apiPrefix+"/routes", gzipMiddleware(http.HandlerFunc(s.handleRoutes)))
+	mux.Handle(apiPrefix+"/search", gzipMiddleware(http.HandlerFunc(s.handleSearch)))
+	mux.Handle(apiPrefix+"/neighbors", gzipMiddleware(http.HandlerFunc(s.handleNeighbors)))
+	mux.Handle(apiPrefix+"/path", gzipMiddleware(http.HandlerFunc(s.handlePath)))
+	mux.Handle(apiPrefix+"/cache/stats", gzipMiddleware(http.HandlerFunc(s.handleCacheStats)))
+	// The stream is a WebSocket, which the gzip writer cannot hijack.
+	mux.HandleFunc(apiPrefix+"/stream", s.handleStream)
 }
 
 // --- Gzip middleware -------------------------------------------------------
@@ -322,19 +414,25 @@ func (s *Server) handleDiagram(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, response)
 }
 
+// getAllData returns the whole diagram of diagramType, building it on the
+// first call.
 func (s *Server) getAllData(diagramType string, includeFullDepth bool) *spec.CytoscapeData {
+	data, _ := s.getAllDataContext(context.Background(), diagramType, includeFullDepth)
+	return data
+}
+
+// getAllDataContext is getAllData, abandoning a tracker tree build once ctx
+// is done and returning ctx's error.
+func (s *Server) getAllDataContext(ctx context.Context, diagramType string, includeFullDepth bool) (*spec.CytoscapeData, error) {
 	depthKey := "normal"
 	if includeFullDepth {
 		depthKey = "full"
 	}
 	cacheKey := fmt.Sprintf("%s:%s", diagramType, depthKey)
 
-	s.mu.RLock()
-	if cached, ok := s.dataCache[cacheKey]; ok && cached != nil {
-		s.mu.RUnlock()
-		return cached
+	if cached, ok := s.dataCache.get(cacheKey); ok && cached != nil {
+		return cached, nil
 	}
-	s.mu.RUnlock()
 
 	var data *spec.CytoscapeData
 	if diagramType == "tracker-tree" {
@@ -348,16 +446,18 @@ func (s *Server) getAllData(diagramType string, includeFullDepth bool) *spec.Cyt
 			MaxArgsPerFunction: 100,
 			MaxNestedArgsDepth: 100,
 			MaxRecursionDepth:  maxDepth,
-		}, nil)
+		}, nil, spec.WithContext(ctx))
+		// A cancelled build is partial: don't draw or cache it.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		data = spec.DrawTrackerTreeCytoscapeWithMetadata(trackerTree.GetRoots(), s.metadata)
 	} else {
 		data = spec.DrawCallGraphCytoscape(s.metadata)
 	}
 
-	s.mu.Lock()
-	s.dataCache[cacheKey] = data
-	s.mu.Unlock()
-	return data
+	s.dataCache.put(cacheKey, data)
+	return data, nil
 }
 
 func (s *Server) handlePaginatedDiagram(w http.ResponseWriter, r *http.Request) {
@@ -405,7 +505,11 @@ func (s *Server) handlePaginatedDiagram(w http.ResponseWriter, r *http.Request)
 	generics := splitCSV(r.URL.Query().Get("generic"))
 	scopeFilter := r.URL.Query().Get("scope")
 
-	data := s.generatePaginatedData(page, pageSize, depth, packages, functions, files, receivers, signatures, generics, scopeFilter)
+	data, err := s.generatePaginatedData(r.Context(), page, pageSize, depth, packages, functions, files, receivers, signatures, generics, scopeFilter)
+	if err != nil {
+		s.writeGenerationError(w, err)
+		return
+	}
 
 	loadTime := time.Since(start)
 
@@ -760,6 +864,11 @@ func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
 		"page_size":       s.config.PageSize,
 		"max_depth":       s.config.MaxDepth,
 		"input_dir":       s.config.InputDir,
+		"config_file":     s.config.ConfigFile,
+		"metadata_cache":  s.config.MetadataCache,
+		"metadata_file":   s.config.MetadataFile,
+		"metadata_source": s.metadataSource,
+		"read_only":       s.config.ReadOnly,
 	}
 
 	s.writeJSON(w, stats)
@@ -770,10 +879,14 @@ func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request) {
 		s.writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if s.config.ReadOnly {
+		s.writeError(w, "Refresh is disabled: the server is read-only", http.StatusForbidden)
+		return
+	}
 
 	log.Printf("🔄 Refreshing metadata...")
 
-	if err := s.LoadMetadata(); err != nil {
+	if err := s.LoadMetadataContext(r.Context()); err != nil {
 		s.writeError(w, fmt.Sprintf("Failed to refresh metadata: %v", err), http.StatusInternalServerError)
 		return
 	}
@@ -799,6 +912,7 @@ func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
 
 	validFormats := map[string]string{
 		"svg":  "image/svg+xml",
+		"dot":  "text/vnd.graphviz",
 		"png":  "image/png",
 		"jpg":  "image/jpeg",
 		"pdf":  "application/pdf",
@@ -807,7 +921,7 @@ func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
 
 	contentType, exists := validFormats[format]
 	if !exists {
-		s.writeError(w, "Invalid format. Supported formats: svg, png, jpg, pdf, json", http.StatusBadRequest)
+		s.writeError(w, "Invalid format. Supported formats: svg, dot, png, jpg, pdf, json", http.StatusBadRequest)
 		return
 	}
 
@@ -848,7 +962,18 @@ func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
 	generics := splitCSV(r.URL.Query().Get("generic"))
 	scopeFilter := r.URL.Query().Get("scope")
 
-	data := s.generatePaginatedData(page, pageSize, depth, packages, functions, files, receivers, signatures, generics, scopeFilter)
+	// all=true exports every node that passes the filters, not one page.
+	if r.URL.Query().Get("all") == "true" {
+		page = 1
+		pageSize = max(len(s.getAllData(s.config.DiagramType, true).Nodes), 1)
+	}
+
+	data, err := s.generatePaginatedData(r.Context(), page, pageSize, depth, packages, functions, files, receivers, signatures, generics, scopeFilter)
+	if err != nil {
+		s.writeGenerationError(w, err)
+		return
+	}
+	graph := &spec.CytoscapeData{Nodes: data.Nodes, Edges: data.Edges}
 
 	w.Header().Set("Content-Type", contentType)
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"diagram.%s\"", format))
@@ -856,7 +981,7 @@ func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
 	if s.config.EnableCORS {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 	}
 
 	switch format {
@@ -871,6 +996,18 @@ func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 
+	case "dot":
+		if err := writeDOT(w, graph); err != nil {
+			log.Printf("Failed to write DOT export: %v", err)
+		}
+		return
+
+	case "svg":
+		if err := writeSVG(w, graph); err != nil {
+			log.Printf("Failed to write SVG export: %v", err)
+		}
+		return
+
 	default:
 		message := fmt.Sprintf("Format '%s' is now handled client-side using Cytoscape.js extensions. Please use the export dropdown in the UI.", format)
 		s.writeError(w, message, http.StatusBadRequest)
@@ -960,42 +1097,38 @@ func (s *Server) calculateCallGraphDepth(data *spec.CytoscapeData) map[string]in
 	return depths
 }
 
-func (s *Server) generatePaginatedData(page, pageSize, depth int, packages, functions, files, receivers, signatures, generics []string, scopeFilter string) *spec.PaginatedCytoscapeData {
-	timeout := time.After(30 * time.Second)
-	done := make(chan *spec.PaginatedCytoscapeData, 1)
-
-	go func() {
-		result := s.generatePaginatedDataInternal(page, pageSize, depth, packages, functions, files, receivers, signatures, generics, scopeFilter)
-		done <- result
-	}()
-
-	select {
-	case result := <-done:
-		return result
-	case <-timeout:
-		return &spec.PaginatedCytoscapeData{
-			Nodes:      []spec.CytoscapeNode{},
-			Edges:      []spec.CytoscapeEdge{},
-			TotalNodes: 0,
-			TotalEdges: 0,
-			Page:       page,
-			PageSize:   pageSize,
-			HasMore:    false,
-		}
+// pageTimeout bounds building a page, so a tracker tree too large to build
+// in reasonable time fails the request instead of holding it open.
+const pageTimeout = 30 * time.Second
+
+// generatePaginatedData returns a page of the diagram, giving up once ctx
+// is done or after pageTimeout.
+func (s *Server) generatePaginatedData(ctx context.Context, page, pageSize, depth int, packages, functions, files, receivers, signatures, generics []string, scopeFilter string) (*spec.PaginatedCytoscapeData, error) {
+	ctx, cancel := context.WithTimeout(ctx, pageTimeout)
+	defer cancel()
+	return s.generatePaginatedDataInternal(ctx, page, pageSize, depth, packages, functions, files, receivers, signatures, generics, scopeFilter)
+}
+
+// writeGenerationError reports a page that could not be built in time.
+func (s *Server) writeGenerationError(w http.ResponseWriter, err error) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		s.writeError(w, fmt.Sprintf("Diagram generation timed out after %s; narrow it with filters or a lower depth", pageTimeout), http.StatusServiceUnavailable)
+		return
 	}
+	s.writeError(w, fmt.Sprintf("Diagram generation stopped: %v", err), http.StatusServiceUnavailable)
 }
 
-func (s *Server) generatePaginatedDataInternal(page, pageSize, depth int, packages, functions, files, receivers, signatures, generics []string, scopeFilter string) *spec.PaginatedCytoscapeData {
+func (s *Server) generatePaginatedDataInternal(ctx context.Context, page, pageSize, depth int, packages, functions, files, receivers, signatures, generics []string, scopeFilter string) (*spec.PaginatedCytoscapeData, error) {
 	cacheKey := fmt.Sprintf("%s-%d-%d-%d-%v-%v-%v-%v-%v-%v-%s", s.config.DiagramType, page, pageSize, depth, packages, functions, files, receivers, signatures, generics, scopeFilter)
 
-	s.mu.RLock()
-	if cached, exists := s.cache[cacheKey]; exists {
-		s.mu.RUnlock()
-		return cached
+	if cached, exists := s.cache.get(cacheKey); exists {
+		return cached, nil
 	}
-	s.mu.RUnlock()
 
-	allData := s.getAllData(s.config.DiagramType, true)
+	allData, err := s.getAllDataContext(ctx, s.config.DiagramType, true)
+	if err != nil {
+		return nil, err
+	}
 	if s.config.DiagramType == "tracker-tree" {
 		allData.Nodes = spec.OrderTrackerTreeNodesDepthFirst(allData)
 	}
@@ -1006,6 +1139,75 @@ func (s *Server) generatePaginatedDataInternal(page, pageSize, depth int, packag
 		allNodes[node.Data.ID] = &node
 	}
 
+	filteredNodes, filteredEdges := s.filterData(allData, depth, packages, functions, files, receivers, signatures, generics, scopeFilter)
+
+	var paginatedNodes []spec.CytoscapeNode
+	var start, end int
+	if s.config.DiagramType == "tracker-tree" {
+		filteredData := &spec.CytoscapeData{Nodes: filteredNodes, Edges: filteredEdges}
+		orderedNodes := spec.TraverseTrackerTreeBranchOrder(filteredData)
+
+		start = (page - 1) * pageSize
+		end = start + pageSize
+		if start < len(orderedNodes) {
+			if end > len(orderedNodes) {
+				end = len(orderedNodes)
+			}
+			paginatedNodes = orderedNodes[start:end]
+		}
+	} else {
+		start = (page - 1) * pageSize
+		end = start + pageSize
+		if start < len(filteredNodes) {
+			if end > len(filteredNodes) {
+				end = len(filteredNodes)
+			}
+			paginatedNodes = filteredNodes[start:end]
+		}
+	}
+
+	paginatedNodeIDs := make(map[string]*spec.CytoscapeNode)
+	for i := range paginatedNodes {
+		node := paginatedNodes[i]
+		paginatedNodeIDs[node.Data.ID] = &node
+	}
+
+	var paginatedEdges []spec.CytoscapeEdge
+	for _, edge := range filteredEdges {
+		if paginatedNodeIDs[edge.Data.Source] != nil && paginatedNodeIDs[edge.Data.Target] != nil {
+			paginatedEdges = append(paginatedEdges, edge)
+		}
+	}
+
+	for _, node := range paginatedNodes {
+		if node.Data.Parent != "" && paginatedNodeIDs[node.Data.Parent] == nil {
+			parentNode := allNodes[node.Data.Parent]
+			if parentNode != nil {
+				parentNode.Data.IsParentFunction = "true"
+				paginatedNodeIDs[node.Data.Parent] = parentNode
+				paginatedNodes = append(paginatedNodes, *parentNode)
+			}
+		}
+	}
+
+	result := &spec.PaginatedCytoscapeData{
+		Nodes:      paginatedNodes,
+		Edges:      paginatedEdges,
+		TotalNodes: len(filteredNodes),
+		TotalEdges: len(filteredEdges),
+		Page:       page,
+		PageSize:   pageSize,
+		HasMore:    len(paginatedNodes) < len(filteredNodes),
+	}
+
+	s.cache.put(cacheKey, result)
+
+	return result, nil
+}
+
+// filterData returns the nodes of allData within depth of a call-graph root
+// that match the filters, and the edges between them.
+func (s *Server) filterData(allData *spec.CytoscapeData, depth int, packages, functions, files, receivers, signatures, generics []string, scopeFilter string) ([]spec.CytoscapeNode, []spec.CytoscapeEdge) {
 	var depthFilteredNodes []spec.CytoscapeNode
 	var depthFilteredEdges []spec.CytoscapeEdge
 
@@ -1071,70 +1273,7 @@ func (s *Server) generatePaginatedDataInternal(page, pageSize, depth int, packag
 		}
 	}
 
-	var paginatedNodes []spec.CytoscapeNode
-	var start, end int
-	if s.config.DiagramType == "tracker-tree" {
-		filteredData := &spec.CytoscapeData{Nodes: filteredNodes, Edges: filteredEdges}
-		orderedNodes := spec.TraverseTrackerTreeBranchOrder(filteredData)
-
-		start = (page - 1) * pageSize
-		end = start + pageSize
-		if start < len(orderedNodes) {
-			if end > len(orderedNodes) {
-				end = len(orderedNodes)
-			}
-			paginatedNodes = orderedNodes[start:end]
-		}
-	} else {
-		start = (page - 1) * pageSize
-		end = start + pageSize
-		if start < len(filteredNodes) {
-			if end > len(filteredNodes) {
-				end = len(filteredNodes)
-			}
-			paginatedNodes = filteredNodes[start:end]
-		}
-	}
-
-	paginatedNodeIDs := make(map[string]*spec.CytoscapeNode)
-	for i := range paginatedNodes {
-		node := paginatedNodes[i]
-		paginatedNodeIDs[node.Data.ID] = &node
-	}
-
-	var paginatedEdges []spec.CytoscapeEdge
-	for _, edge := range filteredEdges {
-		if paginatedNodeIDs[edge.Data.Source] != nil && paginatedNodeIDs[edge.Data.Target] != nil {
-			paginatedEdges = append(paginatedEdges, edge)
-		}
-	}
-
-	for _, node := range paginatedNodes {
-		if node.Data.Parent != "" && paginatedNodeIDs[node.Data.Parent] == nil {
-			parentNode := allNodes[node.Data.Parent]
-			if parentNode != nil {
-				parentNode.Data.IsParentFunction = "true"
-				paginatedNodeIDs[node.Data.Parent] = parentNode
-				paginatedNodes = append(paginatedNodes, *parentNode)
-			}
-		}
-	}
-
-	result := &spec.PaginatedCytoscapeData{
-		Nodes:      paginatedNodes,
-		Edges:      paginatedEdges,
-		TotalNodes: len(filteredNodes),
-		TotalEdges: len(filteredEdges),
-		Page:       page,
-		PageSize:   pageSize,
-		HasMore:    len(paginatedNodes) < len(filteredNodes),
-	}
-
-	s.mu.Lock()
-	s.cache[cacheKey] = result
-	s.mu.Unlock()
-
-	return result
+	return filteredNodes, filteredEdges
 }
 
 // --- Small utilities -------------------------------------------------------
@@ -1276,7 +1415,7 @@ func (s *Server) writeJSON(w http.ResponseWriter, data interface{}) {
 	if s.config.EnableCORS {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 	}
 
 	if err := json.NewEncoder(w).Encode(data); err != nil {
@@ -1291,7 +1430,7 @@ func (s *Server) writeResponse(w http.ResponseWriter, data string, contentType s
 	if s.config.EnableCORS {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 	}
 
 	if _, err := w.Write([]byte(data)); err != nil {
@@ -1305,7 +1444,7 @@ func (s *Server) writeError(w http.ResponseWriter, message string, code int) {
 	if s.config.EnableCORS {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 	}
 
 	w.WriteHeader(code)
@@ -39,17 +39,14 @@ func TestPackageHierarchyTree(t *testing.T) {
 		"root/a/b/":  {},
 		"standalone": {},
 	}}
-	s.cache = map[string]*spec.PaginatedCytoscapeData{}
 	// getAllData(diagramType, true) => cacheKey "call-graph:full".
-	s.dataCache = map[string]*spec.CytoscapeData{
-		"call-graph:full": {
-			Nodes: []spec.CytoscapeNode{
-				{Data: spec.CytoscapeNodeData{ID: "n1", Package: "root/a"}},
-				{Data: spec.CytoscapeNodeData{ID: "n2", Package: "root/a/b"}},
-				{Data: spec.CytoscapeNodeData{ID: "n3", Package: ""}}, // empty package skipped
-			},
+	s.dataCache.put("call-graph:full", &spec.CytoscapeData{
+		Nodes: []spec.CytoscapeNode{
+			{Data: spec.CytoscapeNodeData{ID: "n1", Package: "root/a"}},
+			{Data: spec.CytoscapeNodeData{ID: "n2", Package: "root/a/b"}},
+			{Data: spec.CytoscapeNodeData{ID: "n3", Package: ""}}, // empty package skipped
 		},
-	}
+	})
 
 	w := httptest.NewRecorder()
 	s.handlePackageHierarchy(w, httptest.NewRequest(http.MethodGet, "/api/diagram/packages", nil))
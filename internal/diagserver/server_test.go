@@ -16,7 +16,9 @@ package diagserver
 
 import (
 	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -100,10 +102,10 @@ func TestSetInputDirInvalidatesCache(t *testing.T) {
 	if server.metadata != nil {
 		t.Error("Expected metadata to be cleared after SetInputDir")
 	}
-	if len(server.cache) != 0 {
+	if server.cache.len() != 0 {
 		t.Error("Expected paginated cache to be cleared")
 	}
-	if len(server.dataCache) != 0 {
+	if server.dataCache.len() != 0 {
 		t.Error("Expected data cache to be cleared")
 	}
 }
@@ -232,9 +234,40 @@ func TestGeneratePaginatedData(t *testing.T) {
 		CallGraph: []metadata.CallGraphEdge{},
 	}
 
-	data := server.generatePaginatedData(1, 10, 3, nil, nil, nil, nil, nil, nil, "")
-	if data == nil {
-		t.Fatal("Expected non-nil data with metadata")
+	data, err := server.generatePaginatedData(context.Background(), 1, 10, 3, nil, nil, nil, nil, nil, nil, "")
+	if err != nil || data == nil {
+		t.Fatalf("Expected non-nil data with metadata, got error %v", err)
+	}
+}
+
+func TestGeneratePaginatedDataCancelled(t *testing.T) {
+	server := newTestServer()
+	server.config.DiagramType = "tracker-tree"
+	server.metadata = &metadata.Metadata{
+		StringPool: metadata.NewStringPool(),
+		Packages:   make(map[string]*metadata.Package),
+		CallGraph:  []metadata.CallGraphEdge{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := server.generatePaginatedData(ctx, 1, 10, 3, nil, nil, nil, nil, nil, nil, ""); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if server.dataCache.len() != 0 {
+		t.Error("A cancelled tracker tree should not be cached")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/diagram/page", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	server.handlePaginatedDiagram(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 for a cancelled request, got %d", w.Code)
+	}
+
+	// The next request builds the diagram.
+	if _, err := server.generatePaginatedData(context.Background(), 1, 10, 3, nil, nil, nil, nil, nil, nil, ""); err != nil {
+		t.Fatalf("Expected the diagram after cancellation, got %v", err)
 	}
 }
 
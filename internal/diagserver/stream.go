@@ -0,0 +1,305 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ehabterra/apispec/internal/spec"
+	"github.com/gorilla/websocket"
+)
+
+// Stream modes: how /stream groups the nodes it sends.
+const (
+	StreamByFrontier = "bfs"     // by distance from a call-graph root
+	StreamByPackage  = "package" // by package, in name order
+)
+
+// streamWriteTimeout bounds how long a slow client may hold up one message.
+const streamWriteTimeout = 10 * time.Second
+
+// StreamMessage is one message of the /stream WebSocket. A stream is a
+// "meta" message with the totals, the "batch" messages, then "done". Every
+// node of a batch comes after its compound parent, and every edge after both
+// of its endpoints, so a client can add each batch to the graph as it
+// arrives.
+type StreamMessage struct {
+	Type        string               `json:"type"`
+	Seq         int                  `json:"seq,omitempty"`
+	Nodes       []spec.CytoscapeNode `json:"nodes,omitempty"`
+	Edges       []spec.CytoscapeEdge `json:"edges,omitempty"`
+	Frontier    *int                 `json:"frontier,omitempty"` // bfs mode; unset for unreachable nodes
+	Package     string               `json:"package,omitempty"`  // package mode
+	TotalNodes  int                  `json:"total_nodes,omitempty"`
+	TotalEdges  int                  `json:"total_edges,omitempty"`
+	Batches     int                  `json:"batches,omitempty"`
+	Mode        string               `json:"mode,omitempty"`
+	DiagramType string               `json:"diagram_type,omitempty"`
+}
+
+// streamGroup is a run of nodes sent together: one BFS frontier or one
+// package.
+type streamGroup struct {
+	frontier *int
+	pkg      string
+	nodes    []spec.CytoscapeNode
+}
+
+// handleStream upgrades the request to a WebSocket and pushes the diagram
+// in batches. It takes the filters of /page plus mode (bfs or package) and
+// batch, the maximum number of nodes per message.
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	mode := query.Get("mode")
+	if mode == "" {
+		mode = StreamByFrontier
+	}
+	if mode != StreamByFrontier && mode != StreamByPackage {
+		s.writeError(w, fmt.Sprintf("Unknown stream mode %q (want %s or %s)", mode, StreamByFrontier, StreamByPackage), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.ensureMetadata(); err != nil {
+		s.writeError(w, fmt.Sprintf("Failed to load metadata: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	batchSize, _ := strconv.Atoi(query.Get("batch"))
+	if batchSize < 1 {
+		batchSize = s.config.PageSize
+	}
+	if batchSize > 2000 {
+		batchSize = 2000
+	}
+
+	depth := s.config.MaxDepth
+	if depthStr := query.Get("depth"); depthStr != "" {
+		depth, _ = strconv.Atoi(depthStr)
+		if depth < 0 {
+			depth = 0
+		}
+	}
+
+	// The upgrader accepts only pages served from this host. EnableCORS
+	// does not widen that: its wildcard would let any site a user visits
+	// open the stream with their credentials.
+	upgrader := websocket.Upgrader{}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already replied with an HTTP error.
+		return
+	}
+	defer conn.Close()
+
+	// The client only ever closes the stream; reading notices that so the
+	// remaining batches are not written to a dead connection.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	allData := s.getAllData(s.config.DiagramType, true)
+	if s.config.DiagramType == "tracker-tree" {
+		allData.Nodes = spec.OrderTrackerTreeNodesDepthFirst(allData)
+	}
+	nodes, edges := s.filterData(allData, depth,
+		splitCSV(query.Get("package")), splitCSV(query.Get("function")), splitCSV(query.Get("file")),
+		splitCSV(query.Get("receiver")), splitCSV(query.Get("signature")), splitCSV(query.Get("generic")),
+		query.Get("scope"))
+
+	var groups []streamGroup
+	if mode == StreamByPackage {
+		groups = groupByPackage(nodes)
+	} else {
+		var depths map[string]int
+		if s.config.DiagramType != "tracker-tree" {
+			depths = s.calculateCallGraphDepth(allData)
+		}
+		groups = groupByFrontier(nodes, depths)
+	}
+	batches := streamBatches(groups, edges, allData.Nodes, batchSize)
+
+	send := func(msg StreamMessage) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		_ = conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		if err := conn.WriteJSON(msg); err != nil {
+			if s.config.Verbose {
+				log.Printf("Stream closed: %v", err)
+			}
+			return false
+		}
+		return true
+	}
+
+	if !send(StreamMessage{
+		Type:        "meta",
+		TotalNodes:  len(nodes),
+		TotalEdges:  len(edges),
+		Batches:     len(batches),
+		Mode:        mode,
+		DiagramType: s.config.DiagramType,
+	}) {
+		return
+	}
+	for _, batch := range batches {
+		if !send(batch) {
+			return
+		}
+	}
+	if !send(StreamMessage{Type: "done", TotalNodes: len(nodes), TotalEdges: len(edges), Batches: len(batches)}) {
+		return
+	}
+	_ = conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(time.Second))
+}
+
+// groupByFrontier groups nodes by their depth, shallowest first. Depths come
+// from depths when it is set (call graphs) and from the nodes themselves
+// otherwise (tracker trees). Nodes no root reaches go last, in one group
+// without a frontier. Nodes keep their order within a group.
+func groupByFrontier(nodes []spec.CytoscapeNode, depths map[string]int) []streamGroup {
+	byDepth := map[int][]spec.CytoscapeNode{}
+	var unreached []spec.CytoscapeNode
+	for _, node := range nodes {
+		d, ok := node.Data.Depth, true
+		if depths != nil {
+			d, ok = depths[node.Data.ID]
+		}
+		if !ok {
+			unreached = append(unreached, node)
+			continue
+		}
+		byDepth[d] = append(byDepth[d], node)
+	}
+
+	levels := make([]int, 0, len(byDepth))
+	for d := range byDepth {
+		levels = append(levels, d)
+	}
+	sort.Ints(levels)
+
+	groups := make([]streamGroup, 0, len(levels)+1)
+	for _, d := range levels {
+		frontier := d
+		groups = append(groups, streamGroup{frontier: &frontier, nodes: byDepth[d]})
+	}
+	if len(unreached) > 0 {
+		groups = append(groups, streamGroup{nodes: unreached})
+	}
+	return groups
+}
+
+// groupByPackage groups nodes by package, in package name order. Nodes keep
+// their order within a group.
+func groupByPackage(nodes []spec.CytoscapeNode) []streamGroup {
+	byPackage := map[string][]spec.CytoscapeNode{}
+	for _, node := range nodes {
+		byPackage[node.Data.Package] = append(byPackage[node.Data.Package], node)
+	}
+
+	names := make([]string, 0, len(byPackage))
+	for name := range byPackage {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	groups := make([]streamGroup, 0, len(names))
+	for _, name := range names {
+		groups = append(groups, streamGroup{pkg: name, nodes: byPackage[name]})
+	}
+	return groups
+}
+
+// streamBatches splits groups into batch messages of at most batchSize
+// nodes. A node whose compound parent has not been sent yet is preceded by
+// the parent, looked up in all, and each edge goes out in the first batch
+// that completes its endpoints. Edges to nodes never sent are dropped.
+func streamBatches(groups []streamGroup, edges []spec.CytoscapeEdge, all []spec.CytoscapeNode, batchSize int) []StreamMessage {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	parents := make(map[string]spec.CytoscapeNode)
+	for _, node := range all {
+		parents[node.Data.ID] = node
+	}
+	incident := make(map[string][]int)
+	for i, edge := range edges {
+		incident[edge.Data.Source] = append(incident[edge.Data.Source], i)
+		if edge.Data.Target != edge.Data.Source {
+			incident[edge.Data.Target] = append(incident[edge.Data.Target], i)
+		}
+	}
+
+	sentNodes := make(map[string]bool)
+	sentEdges := make([]bool, len(edges))
+	var batches []StreamMessage
+
+	for _, group := range groups {
+		for start := 0; start < len(group.nodes); start += batchSize {
+			end := min(start+batchSize, len(group.nodes))
+			batch := StreamMessage{Type: "batch", Seq: len(batches) + 1, Frontier: group.frontier, Package: group.pkg}
+
+			add := func(node spec.CytoscapeNode) {
+				sentNodes[node.Data.ID] = true
+				batch.Nodes = append(batch.Nodes, node)
+				for _, i := range incident[node.Data.ID] {
+					e := edges[i].Data
+					if !sentEdges[i] && sentNodes[e.Source] && sentNodes[e.Target] {
+						sentEdges[i] = true
+						batch.Edges = append(batch.Edges, edges[i])
+					}
+				}
+			}
+
+			for _, node := range group.nodes[start:end] {
+				if sentNodes[node.Data.ID] {
+					continue
+				}
+				if p := node.Data.Parent; p != "" && !sentNodes[p] {
+					if parent, ok := parents[p]; ok {
+						parent.Data.IsParentFunction = "true"
+						add(parent)
+					}
+				}
+				add(node)
+			}
+			if len(batch.Nodes) > 0 {
+				batches = append(batches, batch)
+			}
+		}
+	}
+	return batches
+}
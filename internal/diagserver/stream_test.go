@@ -0,0 +1,185 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/spec"
+	"github.com/gorilla/websocket"
+)
+
+func streamNode(id, parent, pkg string) spec.CytoscapeNode {
+	return spec.CytoscapeNode{Data: spec.CytoscapeNodeData{ID: id, Parent: parent, Package: pkg}}
+}
+
+func streamEdge(source, target string) spec.CytoscapeEdge {
+	return spec.CytoscapeEdge{Data: spec.CytoscapeEdgeData{ID: source + "->" + target, Source: source, Target: target}}
+}
+
+func TestStreamBatchesByFrontier(t *testing.T) {
+	nodes := []spec.CytoscapeNode{
+		streamNode("main", "", "app"),
+		streamNode("b", "", "app"),
+		streamNode("a", "", "app"),
+		streamNode("orphan", "", "app"),
+	}
+	edges := []spec.CytoscapeEdge{streamEdge("main", "a"), streamEdge("a", "b"), streamEdge("main", "b")}
+	depths := map[string]int{"main": 0, "a": 1, "b": 1}
+
+	batches := streamBatches(groupByFrontier(nodes, depths), edges, nodes, 1)
+
+	var order []string
+	for i, b := range batches {
+		if b.Seq != i+1 {
+			t.Errorf("batch %d has seq %d", i, b.Seq)
+		}
+		for _, n := range b.Nodes {
+			order = append(order, n.Data.ID)
+		}
+	}
+	if got := strings.Join(order, ","); got != "main,b,a,orphan" {
+		t.Errorf("node order = %s, want main,b,a,orphan", got)
+	}
+	if f := batches[0].Frontier; f == nil || *f != 0 {
+		t.Errorf("first batch frontier = %v, want 0", f)
+	}
+	if f := batches[3].Frontier; f != nil {
+		t.Errorf("unreached nodes should have no frontier, got %d", *f)
+	}
+	// main->b completes with b, main->a and a->b with a.
+	if len(batches[1].Edges) != 1 || len(batches[2].Edges) != 2 {
+		t.Errorf("edges per batch = %d, %d, want 1, 2", len(batches[1].Edges), len(batches[2].Edges))
+	}
+}
+
+func TestStreamBatchesByPackageSendsParentsFirst(t *testing.T) {
+	all := []spec.CytoscapeNode{
+		streamNode("handler", "", "api"),
+		streamNode("handler.arg", "handler", "zeta"),
+		streamNode("store", "", "db"),
+	}
+	// The parent itself did not pass the filters.
+	filtered := all[1:]
+
+	batches := streamBatches(groupByPackage(filtered), []spec.CytoscapeEdge{streamEdge("handler", "handler.arg")}, all, 10)
+	if len(batches) != 2 {
+		t.Fatalf("got %d batches, want one per package", len(batches))
+	}
+	if batches[0].Package != "db" || batches[1].Package != "zeta" {
+		t.Errorf("packages = %s, %s, want db, zeta", batches[0].Package, batches[1].Package)
+	}
+	got := batches[1].Nodes
+	if len(got) != 2 || got[0].Data.ID != "handler" || got[0].Data.IsParentFunction != "true" {
+		t.Errorf("parent should precede its child, got %+v", got)
+	}
+	if len(batches[1].Edges) != 1 {
+		t.Errorf("edge to the parent should be sent with the child, got %v", batches[1].Edges)
+	}
+}
+
+func TestHandleStream(t *testing.T) {
+	s := injectedServer(t)
+	mux := http.NewServeMux()
+	s.RegisterRoutes(mux, RouteOptions{})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/diagram/stream?batch=5"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var meta StreamMessage
+	if err := conn.ReadJSON(&meta); err != nil {
+		t.Fatal(err)
+	}
+	if meta.Type != "meta" || meta.Mode != StreamByFrontier || meta.TotalNodes == 0 {
+		t.Fatalf("unexpected meta message %+v", meta)
+	}
+
+	sent := map[string]bool{}
+	batches := 0
+	for {
+		var msg StreamMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.Type == "done" {
+			break
+		}
+		if msg.Type != "batch" {
+			t.Fatalf("unexpected %q message", msg.Type)
+		}
+		batches++
+		// At most one parent is added per node of the batch.
+		if len(msg.Nodes) > 2*5 {
+			t.Errorf("batch %d has %d nodes, over the requested size", msg.Seq, len(msg.Nodes))
+		}
+		for _, n := range msg.Nodes {
+			sent[n.Data.ID] = true
+		}
+		for _, e := range msg.Edges {
+			if !sent[e.Data.Source] || !sent[e.Data.Target] {
+				t.Errorf("edge %s arrived before its endpoints", e.Data.ID)
+			}
+		}
+	}
+	if batches != meta.Batches {
+		t.Errorf("got %d batches, meta announced %d", batches, meta.Batches)
+	}
+	if len(sent) < meta.TotalNodes {
+		t.Errorf("got %d nodes, meta announced %d", len(sent), meta.TotalNodes)
+	}
+}
+
+func TestHandleStreamChecksOrigin(t *testing.T) {
+	s := injectedServer(t)
+	s.config.EnableCORS = true
+	mux := http.NewServeMux()
+	s.RegisterRoutes(mux, RouteOptions{})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/diagram/stream?batch=5"
+	conn, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"https://elsewhere.example"}})
+	if err == nil {
+		conn.Close()
+		t.Fatal("cross-origin stream accepted with CORS enabled")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("cross-origin stream -> %v, want 403", resp)
+	}
+
+	conn, _, err = websocket.DefaultDialer.Dial(url, http.Header{"Origin": {srv.URL}})
+	if err != nil {
+		t.Fatalf("same-origin stream: %v", err)
+	}
+	conn.Close()
+}
+
+func TestHandleStreamRejectsUnknownMode(t *testing.T) {
+	s := injectedServer(t)
+	w := httptest.NewRecorder()
+	s.handleStream(w, httptest.NewRequest(http.MethodGet, "/api/diagram/stream?mode=random", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown mode -> %d, want 400", w.Code)
+	}
+}
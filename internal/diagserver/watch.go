@@ -0,0 +1,72 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// WatchConfig polls config.ConfigFile every interval until ctx is done. When
+// the file changes, the metadata is reloaded with its settings and the cached
+// diagrams are dropped, so the next request reflects the edit without a
+// restart. A config that fails to load is logged and the previous metadata
+// is kept. Changes made since the metadata was last loaded count, so an
+// edit is not missed while the watch starts. WatchConfig returns at once
+// when no config file is set, or when config.MetadataFile is served: the
+// config filters an analysis that never runs.
+func (s *Server) WatchConfig(ctx context.Context, interval time.Duration) {
+	s.mu.RLock()
+	path, last := s.config.ConfigFile, s.configStamp
+	if s.config.MetadataFile != "" {
+		path = ""
+	}
+	s.mu.RUnlock()
+	if path == "" || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		next := configFingerprint(path)
+		if next == last {
+			continue
+		}
+		last = next
+		log.Printf("🔄 Config %s changed, reloading metadata...", path)
+		if err := s.LoadMetadata(); err != nil {
+			log.Printf("⚠️  Keeping the previous diagrams: %v", err)
+		}
+	}
+}
+
+// configFingerprint identifies the state of the file at path by its size and
+// modification time, or as missing.
+func configFingerprint(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "missing"
+	}
+	return fmt.Sprintf("%d-%d", info.Size(), info.ModTime().UnixNano())
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+func TestWatchConfigReloadsOnChange(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "apispec.yaml")
+	if err := os.WriteFile(configFile, []byte("include: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := New(&Config{
+		Host: "localhost", Port: 8080, DiagramType: "call-graph",
+		InputDir: "../../testdata/extensions", MaxDepth: 3, ConfigFile: configFile,
+	})
+	if err := s.LoadMetadata(); err != nil {
+		t.Skipf("engine generate unavailable: %v", err)
+	}
+	s.mu.Lock()
+	first := s.metadata
+	s.cache.put("stale", &spec.PaginatedCytoscapeData{})
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.WatchConfig(ctx, 10*time.Millisecond)
+
+	if err := os.WriteFile(configFile, []byte("exclude:\n  functions: [getOrder]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	reloaded := waitFor(t, func() bool {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return s.metadata != first
+	})
+	if !reloaded {
+		t.Fatal("metadata was not reloaded after the config changed")
+	}
+	s.mu.RLock()
+	second, cached := s.metadata, s.cache.len()
+	s.mu.RUnlock()
+	if cached != 0 {
+		t.Errorf("cache has %d entries after the reload, want none", cached)
+	}
+
+	// A config that no longer loads keeps the diagrams of the last good one.
+	if err := os.WriteFile(configFile, []byte("info: [unterminated\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	s.mu.RLock()
+	kept := s.metadata == second
+	s.mu.RUnlock()
+	if !kept {
+		t.Error("a broken config replaced the metadata")
+	}
+}
+
+func TestWatchConfigWithoutFileReturns(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		newTestServer().WatchConfig(context.Background(), time.Millisecond)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("WatchConfig without a config file did not return")
+	}
+}
+
+// waitFor polls cond for up to five seconds.
+func waitFor(t *testing.T, cond func() bool) bool {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if cond() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,370 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+// projectsPath is where a workspace mounts each project's UI and API:
+// /projects/<name>/ and /projects/<name>/api/diagram.
+const projectsPath = "/projects/"
+
+// Project is one module of a workspace. Name is unique in the workspace and
+// safe in a URL path; Module is the module path of the project's go.mod, or
+// empty when it has none.
+type Project struct {
+	Name   string `json:"name"`
+	Dir    string `json:"dir"`
+	Module string `json:"module,omitempty"`
+	// Path is where the project's UI is served; its API is under
+	// Path + "api/diagram".
+	Path string `json:"path"`
+}
+
+// Workspace serves several projects, each analyzed on its own by a Server,
+// for monorepos with more than one go.mod. The first project is also served
+// at the usual paths, so a one-project workspace behaves as a Server.
+type Workspace struct {
+	projects []Project
+	servers  []*Server
+}
+
+// WorkspaceResponse is the /api/workspace response.
+type WorkspaceResponse struct {
+	Projects []Project `json:"projects"`
+}
+
+// CrossModuleCall is a call from a function of one project into a package of
+// another. Caller and Callee are function base IDs.
+type CrossModuleCall struct {
+	FromProject string `json:"from_project"`
+	Caller      string `json:"caller"`
+	ToProject   string `json:"to_project"`
+	Callee      string `json:"callee"`
+	Position    string `json:"position,omitempty"`
+}
+
+// CrossModuleResponse is the /api/workspace/graph response: the calls
+// between projects, and a diagram of them with a compound node per project
+// holding a node per package, namespaced "<project>:<package>", and an edge
+// per pair of calling and called package, labelled with its call count.
+type CrossModuleResponse struct {
+	Projects []Project            `json:"projects"`
+	Calls    []CrossModuleCall    `json:"calls"`
+	Nodes    []spec.CytoscapeNode `json:"nodes"`
+	Edges    []spec.CytoscapeEdge `json:"edges"`
+}
+
+// NewWorkspace returns a workspace of the projects in dirs, each served with
+// a copy of config whose InputDir is the project's directory. Projects are
+// named after their directory, with a numeric suffix when two share a name.
+func NewWorkspace(config *Config, dirs []string) (*Workspace, error) {
+	if len(dirs) == 0 {
+		return nil, errors.New("workspace has no projects")
+	}
+	w := &Workspace{}
+	used := map[string]bool{}
+	for _, dir := range dirs {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", dir, err)
+		}
+		if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("project %s is not a directory", dir)
+		}
+		name := projectName(abs)
+		for i := 2; used[name]; i++ {
+			name = fmt.Sprintf("%s-%d", projectName(abs), i)
+		}
+		used[name] = true
+
+		projectConfig := *config
+		projectConfig.InputDir = abs
+		w.projects = append(w.projects, Project{
+			Name:   name,
+			Dir:    abs,
+			Module: modulePath(abs),
+			Path:   projectsPath + name + "/",
+		})
+		w.servers = append(w.servers, New(&projectConfig))
+	}
+	return w, nil
+}
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// projectName is the URL-safe base name of dir.
+func projectName(dir string) string {
+	name := strings.Trim(unsafeNameChars.ReplaceAllString(filepath.Base(dir), "-"), "-.")
+	if name == "" {
+		return "project"
+	}
+	return name
+}
+
+// modulePath returns the module path declared by dir's go.mod, if any.
+func modulePath(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	return modfile.ModulePath(data)
+}
+
+// ReadWorkspaceFile returns the module directories a go.work file uses,
+// resolved against the file's directory.
+func ReadWorkspaceFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	work, err := modfile.ParseWork(path, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	base := filepath.Dir(path)
+	var dirs []string
+	for _, use := range work.Use {
+		dir := filepath.FromSlash(use.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(base, dir)
+		}
+		dirs = append(dirs, dir)
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("%s uses no modules", path)
+	}
+	return dirs, nil
+}
+
+// Projects returns the workspace's projects, in the order given.
+func (w *Workspace) Projects() []Project {
+	return append([]Project(nil), w.projects...)
+}
+
+// Server returns the server of the named project, or nil.
+func (w *Workspace) Server(name string) *Server {
+	for i, p := range w.projects {
+		if p.Name == name {
+			return w.servers[i]
+		}
+	}
+	return nil
+}
+
+// LoadCachedMetadata loads every project's metadata, as Server's method of
+// the same name does.
+func (w *Workspace) LoadCachedMetadata() error {
+	for i, s := range w.servers {
+		if err := s.LoadCachedMetadata(); err != nil {
+			return fmt.Errorf("project %s: %w", w.projects[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// WatchConfig reloads each project when the config file changes, until ctx
+// is done; see Server.WatchConfig.
+func (w *Workspace) WatchConfig(ctx context.Context, interval time.Duration) {
+	for _, s := range w.servers {
+		go s.WatchConfig(ctx, interval)
+	}
+	<-ctx.Done()
+}
+
+// RegisterRoutes mounts the first project on mux as Server.RegisterRoutes
+// does, every project's UI and API under its Path, and the workspace API:
+// <APIPrefix>/../workspace lists the projects and .../workspace/graph returns
+// the calls between them. With the default prefix these are /api/workspace
+// and /api/workspace/graph.
+func (w *Workspace) RegisterRoutes(mux *http.ServeMux, opts RouteOptions) {
+	w.servers[0].RegisterRoutes(mux, opts)
+
+	apiPrefix := opts.APIPrefix
+	if apiPrefix == "" {
+		apiPrefix = "/api/diagram"
+	}
+	for i, p := range w.projects {
+		mux.HandleFunc(p.Path, w.servers[i].handleIndex)
+		w.servers[i].registerAPI(mux, strings.TrimSuffix(p.Path, "/")+apiPrefix)
+	}
+
+	workspacePrefix := pathParent(apiPrefix) + "/workspace"
+	mux.Handle(workspacePrefix, gzipMiddleware(http.HandlerFunc(w.handleWorkspace)))
+	mux.Handle(workspacePrefix+"/graph", gzipMiddleware(http.HandlerFunc(w.handleGraph)))
+}
+
+// pathParent returns p without its last element: "/api" for "/api/diagram".
+func pathParent(p string) string {
+	if i := strings.LastIndex(strings.TrimSuffix(p, "/"), "/"); i > 0 {
+		return p[:i]
+	}
+	return ""
+}
+
+func (w *Workspace) handleWorkspace(rw http.ResponseWriter, r *http.Request) {
+	s := w.servers[0]
+	if r.Method != http.MethodGet {
+		s.writeError(rw, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.writeJSON(rw, WorkspaceResponse{Projects: w.Projects()})
+}
+
+// handleGraph returns the calls between the workspace's projects, loading
+// any project not analyzed yet.
+func (w *Workspace) handleGraph(rw http.ResponseWriter, r *http.Request) {
+	s := w.servers[0]
+	if r.Method != http.MethodGet {
+		s.writeError(rw, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	for i, server := range w.servers {
+		if err := server.ensureMetadata(); err != nil {
+			s.writeError(rw, fmt.Sprintf("Failed to load metadata of %s: %v", w.projects[i].Name, err), http.StatusInternalServerError)
+			return
+		}
+	}
+	s.writeJSON(rw, w.crossModuleGraph())
+}
+
+// ownerOf returns the index of the project whose module holds pkg, the one
+// with the longest matching module path, or -1.
+func (w *Workspace) ownerOf(pkg string) int {
+	owner, longest := -1, -1
+	for i, p := range w.projects {
+		if p.Module == "" || len(p.Module) <= longest {
+			continue
+		}
+		if pkg == p.Module || strings.HasPrefix(pkg, p.Module+"/") {
+			owner, longest = i, len(p.Module)
+		}
+	}
+	return owner
+}
+
+// crossModuleGraph collects the call-graph edges of each project whose
+// caller is in the project's module and whose callee is in another
+// project's.
+func (w *Workspace) crossModuleGraph() *CrossModuleResponse {
+	response := &CrossModuleResponse{
+		Projects: w.Projects(),
+		Calls:    []CrossModuleCall{},
+		Nodes:    []spec.CytoscapeNode{},
+		Edges:    []spec.CytoscapeEdge{},
+	}
+	type packageEdge struct{ from, to string }
+	edgeCalls := map[packageEdge]int{}
+	var edgeOrder []packageEdge
+	packages := map[string]bool{}
+	projectsUsed := map[int]bool{}
+
+	for i, s := range w.servers {
+		s.mu.RLock()
+		meta := s.metadata
+		s.mu.RUnlock()
+		if meta == nil {
+			continue
+		}
+		for j := range meta.CallGraph {
+			edge := &meta.CallGraph[j]
+			callerPkg := meta.StringPool.GetString(edge.Caller.Pkg)
+			calleePkg := meta.StringPool.GetString(edge.Callee.Pkg)
+			if w.ownerOf(callerPkg) != i {
+				continue
+			}
+			target := w.ownerOf(calleePkg)
+			if target < 0 || target == i {
+				continue
+			}
+			response.Calls = append(response.Calls, CrossModuleCall{
+				FromProject: w.projects[i].Name,
+				Caller:      edge.Caller.BaseID(),
+				ToProject:   w.projects[target].Name,
+				Callee:      edge.Callee.BaseID(),
+				Position:    meta.StringPool.GetString(edge.Position),
+			})
+
+			from := w.projects[i].Name + ":" + callerPkg
+			to := w.projects[target].Name + ":" + calleePkg
+			key := packageEdge{from, to}
+			if edgeCalls[key] == 0 {
+				edgeOrder = append(edgeOrder, key)
+			}
+			edgeCalls[key]++
+			for _, node := range []struct {
+				id, pkg string
+				project int
+			}{{from, callerPkg, i}, {to, calleePkg, target}} {
+				if packages[node.id] {
+					continue
+				}
+				packages[node.id] = true
+				projectsUsed[node.project] = true
+				response.Nodes = append(response.Nodes, spec.CytoscapeNode{Data: spec.CytoscapeNodeData{
+					ID:      node.id,
+					Label:   node.pkg,
+					Parent:  "project:" + w.projects[node.project].Name,
+					Type:    "package",
+					Package: node.pkg,
+					Group:   w.projects[node.project].Name,
+				}})
+			}
+		}
+	}
+
+	var used []int
+	for i := range projectsUsed {
+		used = append(used, i)
+	}
+	sort.Ints(used)
+	for _, i := range used {
+		p := w.projects[i]
+		label := p.Name
+		if p.Module != "" {
+			label += " (" + p.Module + ")"
+		}
+		response.Nodes = append(response.Nodes, spec.CytoscapeNode{Data: spec.CytoscapeNodeData{
+			ID:    "project:" + p.Name,
+			Label: label,
+			Type:  "project",
+			Group: p.Name,
+		}})
+	}
+	for n, key := range edgeOrder {
+		response.Edges = append(response.Edges, spec.CytoscapeEdge{Data: spec.CytoscapeEdgeData{
+			ID:     fmt.Sprintf("cross_%d", n),
+			Source: key.from,
+			Target: key.to,
+			Label:  fmt.Sprintf("%d", edgeCalls[key]),
+			Type:   "cross_module",
+		}})
+	}
+	return response
+}
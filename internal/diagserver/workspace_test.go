@@ -0,0 +1,222 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// writeModule creates dir with a go.mod declaring module.
+func writeModule(t *testing.T, dir, module string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module "+module+"\n\ngo 1.26\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// callsMeta is metadata whose call graph has one edge per call, each a
+// pair of "pkg.func" names.
+func callsMeta(calls [][2]string) *metadata.Metadata {
+	m := &metadata.Metadata{StringPool: metadata.NewStringPool()}
+	call := func(id string) metadata.Call {
+		dot := len(id) - 1
+		for id[dot] != '.' {
+			dot--
+		}
+		return metadata.Call{
+			Meta: m, Pkg: m.StringPool.Get(id[:dot]), Name: m.StringPool.Get(id[dot+1:]),
+			RecvType: -1, Position: -1, Scope: -1, SignatureStr: -1,
+		}
+	}
+	for _, c := range calls {
+		m.CallGraph = append(m.CallGraph, metadata.CallGraphEdge{Caller: call(c[0]), Callee: call(c[1]), Position: -1})
+	}
+	return m
+}
+
+func testWorkspace(t *testing.T) *Workspace {
+	t.Helper()
+	root := t.TempDir()
+	api := writeModule(t, filepath.Join(root, "api"), "example.com/api")
+	billing := writeModule(t, filepath.Join(root, "billing"), "example.com/billing")
+	nested := writeModule(t, filepath.Join(root, "nested", "api"), "example.com/api/v2")
+
+	w, err := NewWorkspace(&Config{Host: "localhost", Port: 8080, DiagramType: "call-graph", PageSize: 50, MaxDepth: 3},
+		[]string{api, billing, nested})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.servers[0].metadata = callsMeta([][2]string{
+		{"example.com/api/handlers.Charge", "example.com/billing/pay.Charge"},
+		{"example.com/api/handlers.Refund", "example.com/billing/pay.Refund"},
+		{"example.com/api/handlers.Charge", "example.com/api/handlers.validate"},
+		{"example.com/api/handlers.List", "example.com/api/v2/store.List"},
+		{"example.com/api/handlers.List", "fmt.Println"},
+	})
+	w.servers[1].metadata = callsMeta([][2]string{
+		{"example.com/billing/pay.Charge", "example.com/billing/pay.save"},
+		// Dependencies analyzed with a project are not its own calls.
+		{"example.com/api/handlers.Charge", "example.com/billing/pay.Charge"},
+	})
+	w.servers[2].metadata = callsMeta(nil)
+	return w
+}
+
+func TestNewWorkspace(t *testing.T) {
+	w := testWorkspace(t)
+	projects := w.Projects()
+	want := []struct{ name, module string }{
+		{"api", "example.com/api"},
+		{"billing", "example.com/billing"},
+		{"api-2", "example.com/api/v2"},
+	}
+	if len(projects) != len(want) {
+		t.Fatalf("projects = %+v", projects)
+	}
+	for i, p := range projects {
+		if p.Name != want[i].name || p.Module != want[i].module || p.Path != "/projects/"+want[i].name+"/" {
+			t.Errorf("project %d = %+v, want %s (%s)", i, p, want[i].name, want[i].module)
+		}
+		if w.Server(p.Name) != w.servers[i] || w.servers[i].config.InputDir != p.Dir {
+			t.Errorf("project %s is not served from its own directory", p.Name)
+		}
+	}
+	if w.Server("missing") != nil {
+		t.Error("Server of an unknown project should be nil")
+	}
+
+	if _, err := NewWorkspace(&Config{}, nil); err == nil {
+		t.Error("an empty workspace should fail")
+	}
+	if _, err := NewWorkspace(&Config{}, []string{filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Error("a missing project directory should fail")
+	}
+}
+
+func TestReadWorkspaceFile(t *testing.T) {
+	root := t.TempDir()
+	work := filepath.Join(root, "go.work")
+	if err := os.WriteFile(work, []byte("go 1.26\n\nuse (\n\t./api\n\t../shared\n)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dirs, err := ReadWorkspaceFile(work)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dirs) != 2 || dirs[0] != filepath.Join(root, "api") || dirs[1] != filepath.Join(filepath.Dir(root), "shared") {
+		t.Errorf("dirs = %v", dirs)
+	}
+
+	empty := filepath.Join(root, "empty.work")
+	if err := os.WriteFile(empty, []byte("go 1.26\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadWorkspaceFile(empty); err == nil {
+		t.Error("a workspace without modules should fail")
+	}
+}
+
+func TestCrossModuleGraph(t *testing.T) {
+	graph := testWorkspace(t).crossModuleGraph()
+
+	if len(graph.Calls) != 3 {
+		t.Fatalf("calls = %+v, want the two billing calls and the v2 call", graph.Calls)
+	}
+	first := graph.Calls[0]
+	if first.FromProject != "api" || first.ToProject != "billing" ||
+		first.Caller != "example.com/api/handlers.Charge" || first.Callee != "example.com/billing/pay.Charge" {
+		t.Errorf("first call = %+v", first)
+	}
+	// The longest module path owns a package.
+	if last := graph.Calls[2]; last.ToProject != "api-2" {
+		t.Errorf("v2 call went to %s, want api-2", last.ToProject)
+	}
+
+	nodes := map[string]string{}
+	for _, n := range graph.Nodes {
+		nodes[n.Data.ID] = n.Data.Parent
+	}
+	for id, parent := range map[string]string{
+		"api:example.com/api/handlers":    "project:api",
+		"billing:example.com/billing/pay": "project:billing",
+		"api-2:example.com/api/v2/store":  "project:api-2",
+		"project:api":                     "",
+		"project:billing":                 "",
+		"project:api-2":                   "",
+	} {
+		if got, ok := nodes[id]; !ok || got != parent {
+			t.Errorf("node %s: parent %q (present %v), want %q", id, got, ok, parent)
+		}
+	}
+	if len(graph.Edges) != 2 {
+		t.Fatalf("edges = %+v", graph.Edges)
+	}
+	if e := graph.Edges[0].Data; e.Source != "api:example.com/api/handlers" || e.Target != "billing:example.com/billing/pay" || e.Label != "2" {
+		t.Errorf("package edge = %+v, want 2 calls from handlers to pay", e)
+	}
+}
+
+func TestWorkspaceRoutes(t *testing.T) {
+	w := testWorkspace(t)
+	mux := http.NewServeMux()
+	w.RegisterRoutes(mux, RouteOptions{UIPath: "/"})
+
+	resp := do(mux, http.MethodGet, "/api/workspace")
+	var listing WorkspaceResponse
+	if resp.Code != http.StatusOK || json.Unmarshal(resp.Body.Bytes(), &listing) != nil || len(listing.Projects) != 3 {
+		t.Fatalf("/api/workspace -> %d %s", resp.Code, resp.Body.String())
+	}
+
+	resp = do(mux, http.MethodGet, "/api/workspace/graph")
+	var graph CrossModuleResponse
+	if resp.Code != http.StatusOK || json.Unmarshal(resp.Body.Bytes(), &graph) != nil || len(graph.Calls) != 3 {
+		t.Fatalf("/api/workspace/graph -> %d %s", resp.Code, resp.Body.String())
+	}
+
+	for _, path := range []string{"/", "/projects/billing/", "/health"} {
+		if resp := do(mux, http.MethodGet, path); resp.Code != http.StatusOK {
+			t.Errorf("%s -> %d", path, resp.Code)
+		}
+	}
+	// Each project answers from its own metadata.
+	for path, want := range map[string]int{
+		"/api/diagram/stats":                  5,
+		"/projects/api/api/diagram/stats":     5,
+		"/projects/billing/api/diagram/stats": 2,
+	} {
+		resp := do(mux, http.MethodGet, path)
+		var stats map[string]any
+		if resp.Code != http.StatusOK || json.Unmarshal(resp.Body.Bytes(), &stats) != nil {
+			t.Fatalf("%s -> %d", path, resp.Code)
+		}
+		if got := int(stats["total_edges"].(float64)); got != want {
+			t.Errorf("%s total_edges = %d, want %d", path, got, want)
+		}
+	}
+
+	if resp := do(mux, http.MethodPost, "/api/workspace"); resp.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /api/workspace -> %d", resp.Code)
+	}
+}
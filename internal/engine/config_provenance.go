@@ -0,0 +1,178 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ehabterra/apispec/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// effectiveConfigHeader opens the file --output-config writes.
+const effectiveConfigHeader = `Effective apispec configuration. The comment above each section names
+where its values came from, in the order they were applied: a later source
+extends or overrides an earlier one.`
+
+// configSources records where each top-level section of the effective config
+// came from, for the comments of --output-config. A nil *configSources
+// records nothing, so tracking costs nothing when no config is written.
+type configSources struct {
+	sections map[string][]string
+}
+
+// newConfigSources returns a recorder when the effective config is written,
+// nil otherwise.
+func (e *Engine) newConfigSources() *configSources {
+	if e.config.OutputConfig == "" {
+		return nil
+	}
+	return &configSources{sections: map[string][]string{}}
+}
+
+// add notes source for section, once. An empty source is no source.
+func (s *configSources) add(section, source string) {
+	if s == nil || source == "" || slices.Contains(s.sections[section], source) {
+		return
+	}
+	s.sections[section] = append(s.sections[section], source)
+}
+
+// record notes source for every section cfg sets.
+func (s *configSources) record(cfg *spec.APISpecConfig, source string) {
+	if s == nil {
+		return
+	}
+	for section := range configSections(cfg) {
+		s.add(section, source)
+	}
+}
+
+// recordFile notes the config file at path for the sections it declares,
+// read from the file itself: its decoded form cannot tell an empty section
+// from an absent one.
+func (s *configSources) recordFile(path string) {
+	if s == nil {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return
+	}
+	content := doc.Content[0].Content
+	for i := 0; i+1 < len(content); i += 2 {
+		if !emptyNode(content[i+1]) {
+			s.add(content[i].Value, "config file "+path)
+		}
+	}
+}
+
+// track runs apply, which changes cfg, and notes source for the sections it
+// changed.
+func (s *configSources) track(cfg *spec.APISpecConfig, source string, apply func()) {
+	if s == nil {
+		apply()
+		return
+	}
+	before := configSections(cfg)
+	apply()
+	for section, value := range configSections(cfg) {
+		if before[section] != value {
+			s.add(section, source)
+		}
+	}
+}
+
+// marshal renders cfg as YAML, each top-level section headed by a comment
+// naming its sources.
+func (s *configSources) marshal(cfg *spec.APISpecConfig) ([]byte, error) {
+	if s == nil {
+		return yaml.Marshal(cfg)
+	}
+	var doc yaml.Node
+	if err := doc.Encode(cfg); err != nil {
+		return nil, err
+	}
+	doc.HeadComment = effectiveConfigHeader
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		key := doc.Content[i]
+		if sources := s.sections[key.Value]; len(sources) > 0 {
+			key.HeadComment = "from: " + strings.Join(sources, "; ")
+		}
+	}
+	return yaml.Marshal(&doc)
+}
+
+// configSections returns the sections cfg sets, each with its YAML, by key.
+func configSections(cfg *spec.APISpecConfig) map[string]string {
+	var doc yaml.Node
+	if err := doc.Encode(cfg); err != nil {
+		return nil
+	}
+	sections := map[string]string{}
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		value := doc.Content[i+1]
+		if emptyNode(value) {
+			continue
+		}
+		out, err := yaml.Marshal(value)
+		if err != nil {
+			continue
+		}
+		sections[doc.Content[i].Value] = string(out)
+	}
+	return sections
+}
+
+// emptyNode reports whether n holds no value: null, an empty scalar, or an
+// empty mapping or sequence.
+func emptyNode(n *yaml.Node) bool {
+	switch n.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		return len(n.Content) == 0
+	case yaml.ScalarNode:
+		return n.Tag == "!!null" || n.Value == ""
+	}
+	return false
+}
+
+// flagSource names the command-line flags among flags that were given a
+// value other than their default, as a config source, or "" when none was.
+func flagSource(flags ...setFlag) string {
+	var set []string
+	for _, f := range flags {
+		if f.set {
+			set = append(set, f.name)
+		}
+	}
+	if len(set) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("command line (%s)", strings.Join(set, ", "))
+}
+
+// setFlag is a command-line flag and whether it was given a non-default
+// value.
+type setFlag struct {
+	name string
+	set  bool
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+func TestConfigSources_TrackNotesChangedSections(t *testing.T) {
+	s := &configSources{sections: map[string][]string{}}
+	cfg := &spec.APISpecConfig{}
+	cfg.Info.Title = "Shop"
+	s.record(cfg, "user config")
+
+	s.track(cfg, "command line (--examples)", func() { cfg.Schemas.Examples = true })
+	s.track(cfg, "command line (--title)", func() {}) // changes nothing
+	s.track(cfg, "", func() { cfg.Defaults.ResponseStatus = 200 })
+
+	if got := s.sections["info"]; len(got) != 1 || got[0] != "user config" {
+		t.Errorf("info sources = %v, want [user config]", got)
+	}
+	if got := s.sections["schemas"]; len(got) != 1 || got[0] != "command line (--examples)" {
+		t.Errorf("schemas sources = %v, want [command line (--examples)]", got)
+	}
+	if got := s.sections["defaults"]; len(got) != 0 {
+		t.Errorf("defaults sources = %v, want none for an empty source", got)
+	}
+}
+
+func TestConfigSources_NilRecordsNothing(t *testing.T) {
+	var s *configSources
+	cfg := &spec.APISpecConfig{}
+	applied := false
+	s.track(cfg, "anything", func() { applied = true })
+	if !applied {
+		t.Error("track on a nil recorder should still apply the change")
+	}
+	out, err := s.marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(out), "#") {
+		t.Errorf("nil recorder should write no comments, got:\n%s", out)
+	}
+}
+
+func TestConfigSources_RecordFileUsesDeclaredKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apispec.yaml")
+	if err := os.WriteFile(path, []byte("info:\n  title: Shop\nexclude: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := &configSources{sections: map[string][]string{}}
+	s.recordFile(path)
+	if got := s.sections["info"]; len(got) != 1 || got[0] != "config file "+path {
+		t.Errorf("info sources = %v", got)
+	}
+	if _, ok := s.sections["exclude"]; ok {
+		t.Error("an empty section in the file should not be attributed to it")
+	}
+}
+
+func TestFlagSource(t *testing.T) {
+	if got := flagSource(setFlag{"--title", false}); got != "" {
+		t.Errorf("flagSource with no set flags = %q, want empty", got)
+	}
+	got := flagSource(setFlag{"--title", true}, setFlag{"--description", false}, setFlag{"--api-version", true})
+	if want := "command line (--title, --api-version)"; got != want {
+		t.Errorf("flagSource = %q, want %q", got, want)
+	}
+}
+
+func TestEngine_OutputConfigAnnotatesSources(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module testapp\n\ngo 1.21\n",
+		"main.go": `package main
+
+import "net/http"
+
+func main() {
+	http.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	})
+	http.ListenAndServe(":8080", nil)
+}`,
+		"apispec.yaml": "defaults:\n  responseStatus: 201\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	configFile := filepath.Join(tempDir, "apispec.yaml")
+	outputConfig := filepath.Join(tempDir, "used-config.yaml")
+
+	engine := NewEngine(&EngineConfig{
+		InputDir:         tempDir,
+		ConfigFile:       configFile,
+		OutputConfig:     outputConfig,
+		Title:            "Shop",
+		GenerateExamples: true,
+	})
+	if _, err := engine.GenerateOpenAPI(); err != nil {
+		t.Fatalf("Expected successful generation, got error: %v", err)
+	}
+
+	data, err := os.ReadFile(outputConfig)
+	if err != nil {
+		t.Fatalf("Failed to read effective config: %v", err)
+	}
+	out := string(data)
+	for _, want := range []string{
+		"# Effective apispec configuration.",
+		"# from: config file " + configFile + "\ndefaults:",
+		"# from: command line (--examples)\nschemas:",
+		"# from: command line (--title) and built-in defaults\ninfo:",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("effective config missing %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -31,12 +32,12 @@ import (
 
 	"github.com/ehabterra/apispec/internal/callgraph"
 	"github.com/ehabterra/apispec/internal/core"
+	"github.com/ehabterra/apispec/internal/diag"
 	"github.com/ehabterra/apispec/internal/metadata"
 	intspec "github.com/ehabterra/apispec/internal/spec"
 	"github.com/ehabterra/apispec/pkg/patterns"
 	"github.com/ehabterra/apispec/spec"
 	"golang.org/x/tools/go/packages"
-	"gopkg.in/yaml.v3"
 )
 
 // VerboseLogger provides conditional logging based on verbose setting
@@ -120,6 +121,8 @@ type EngineConfig struct {
 	OutputConfig       string
 	WriteMetadata      bool
 	SplitMetadata      bool
+	StreamMetadata     bool   // stream metadata one package at a time; implied by a ".gz" MetadataFile
+	MetadataFile       string // metadata output path; DefaultMetadataFile when empty
 	DiagramPath        string
 	PaginatedDiagram   bool
 	DiagramPageSize    int
@@ -134,13 +137,31 @@ type EngineConfig struct {
 	IncludePackages              []string
 	IncludeFunctions             []string
 	IncludeTypes                 []string
+	IncludeRoutes                []string
 	ExcludeFiles                 []string
 	ExcludePackages              []string
 	ExcludeFunctions             []string
 	ExcludeTypes                 []string
+	ExcludeRoutes                []string
 	SkipCGOPackages              bool
 	AnalyzeFrameworkDependencies bool
 	AutoIncludeFrameworkPackages bool
+	// AnalyzeDependencies lists required modules whose source, from the
+	// module cache or vendor/, is analyzed with the project's, so the routes
+	// a shared library registers are documented.
+	AnalyzeDependencies []string
+	// BuildTags, GOOS and GOARCH select the files packages are loaded with,
+	// as `go build -tags` and the environment do; empty means the go
+	// command's defaults for the host. BuildTags is comma-separated.
+	BuildTags string
+	GOOS      string
+	GOARCH    string
+	// StubCGOPackages keeps cgo packages that fail to build — a C header or
+	// library missing on the host — instead of skipping them: their Go
+	// syntax is analyzed with the type information that does not depend on
+	// C, so the routes and DTOs they declare are still documented. It
+	// overrides SkipCGOPackages.
+	StubCGOPackages bool
 	// ResolveCallGraph builds the SSA+VTA resolved call graph alongside
 	// metadata (docs/TRACKER_REDESIGN.md step 2). Off by default until the
 	// summary-based analyses consume it; enable to expose it via
@@ -161,6 +182,45 @@ type EngineConfig struct {
 	// Auto-exclude common mock files and folders (e.g., *_mock.go, mocks/)
 	AutoExcludeMocks bool
 
+	// SchemaIDBase gives every component schema an $id under this base URI
+	// (see spec.SchemaOptions.IDBase) unless the config sets one.
+	SchemaIDBase string
+
+	// GenerateExamples turns on spec.SchemaOptions.Examples.
+	GenerateExamples bool
+
+	// LiteralExamples turns on spec.SchemaOptions.LiteralExamples.
+	LiteralExamples bool
+
+	// NullablePointers and NullableStyle set spec.SchemaOptions.NullablePointers
+	// and NullableStyle unless the config sets them.
+	NullablePointers string
+	NullableStyle    string
+
+	// OmitemptyOptional turns on spec.SchemaOptions.OmitemptyOptional.
+	OmitemptyOptional bool
+
+	// DynamicValues sets spec.SchemaOptions.DynamicValues unless the config
+	// sets it.
+	DynamicValues string
+
+	// GoTypeAnnotations turns on spec.SchemaOptions.GoTypeAnnotations.
+	GoTypeAnnotations bool
+
+	// IncludeDebugEndpoints turns on spec.APISpecConfig.IncludeDebugEndpoints.
+	IncludeDebugEndpoints bool
+
+	// SourceAnnotations turns on spec.APISpecConfig.SourceAnnotations.
+	SourceAnnotations bool
+
+	// OverridesFile is a partial OpenAPI document merged over the generated
+	// spec (see spec.ApplySpecOverrides).
+	OverridesFile string
+
+	// Sort orders the lists of the generated spec (see spec.SortSpec);
+	// empty is spec.SortSource.
+	Sort intspec.SortPolicy
+
 	// Verbose output control
 	Verbose bool
 
@@ -177,9 +237,11 @@ type EngineConfig struct {
 	Context context.Context
 
 	moduleRoot string
+	module     goModule
 }
 
-// ctx returns the configured context or a background context.
+// ctx returns the configured context, or a background context: the context
+// of the calls that take none.
 func (e *Engine) ctx() context.Context {
 	if e.config != nil && e.config.Context != nil {
 		return e.config.Context
@@ -206,6 +268,7 @@ func DefaultEngineConfig() *EngineConfig {
 		OutputConfig:                 "",
 		WriteMetadata:                false,
 		SplitMetadata:                false,
+		StreamMetadata:               false,
 		DiagramPath:                  "",
 		PaginatedDiagram:             true,
 		DiagramPageSize:              100,
@@ -239,7 +302,9 @@ func (e *Engine) reportPhase(phase string, elapsed time.Duration) {
 	}
 }
 
-// Engine represents the OpenAPI generation engine
+// Engine represents the OpenAPI generation engine. It keeps the results of
+// its last generation, so it runs one generation at a time; concurrent
+// generations each need their own Engine.
 type Engine struct {
 	config   *EngineConfig
 	metadata *metadata.Metadata
@@ -250,6 +315,10 @@ type Engine struct {
 	// error message.
 	skipped []SkippedPackage
 
+	// packageDirs maps each analyzed project package to its directory, where
+	// CollectTestFixtures finds its tests.
+	packageDirs map[string]string
+
 	// unresolvedSecurity lists auth middleware detected during the last
 	// generation that matched no SecurityMapping. Surfaced to callers (the UI)
 	// so the user can map it to a scheme.
@@ -259,6 +328,44 @@ type Engine struct {
 	// whose key matches no route placeholder, gathered during the last generation.
 	pathParamMismatches []intspec.PathParamMismatch
 
+	// unsafeMethodWrites lists GET and HEAD handlers that call a repository or
+	// database mutation, gathered during the last generation.
+	unsafeMethodWrites []intspec.UnsafeMethodWrite
+
+	// namingIssues lists JSON naming problems in the emitted schemas,
+	// gathered during the last generation.
+	namingIssues []intspec.NamingIssue
+
+	// timeLayoutMismatches lists marshalers formatting a time with a layout
+	// the schema does not describe, gathered during the last generation.
+	timeLayoutMismatches []intspec.TimeLayoutMismatch
+
+	// unresolvedRoutes lists the route registrations left out of the spec,
+	// or documented from a guess, during the last generation.
+	unresolvedRoutes []intspec.UnresolvedRoute
+
+	// routeCoverage measures the route registrations extracted during the
+	// last generation.
+	routeCoverage intspec.RouteCoverage
+
+	// routes lists the routes extracted during the last generation.
+	routes []*intspec.RouteInfo
+
+	// sources locates the Go declarations of the operations and schemas of
+	// the last generation.
+	sources map[string]intspec.SourceLocation
+
+	// matcherStats counts the pattern matcher work of the last generation.
+	matcherStats intspec.MatcherStats
+
+	// configDiagnostics lists the warnings from loading ConfigFile (unknown
+	// keys) and applying OverridesFile, gathered during the last generation.
+	configDiagnostics []diag.Diagnostic
+
+	// specIssues lists the structural problems ValidateSpec found in the
+	// last generated spec.
+	specIssues []intspec.SpecIssue
+
 	// resolvedGraph is the SSA+VTA resolved call graph, built during
 	// GenerateMetadataOnly when config.ResolveCallGraph is set.
 	resolvedGraph *callgraph.Resolved
@@ -335,6 +442,11 @@ func (e *Engine) GenerateMetadataOnly() (*metadata.Metadata, error) {
 
 // GenerateMetadataOnlyWithLogger generates only metadata and call graph without OpenAPI spec with a custom logger
 func (e *Engine) GenerateMetadataOnlyWithLogger(logger *VerboseLogger) (*metadata.Metadata, error) {
+	return e.generateMetadata(e.ctx(), logger)
+}
+
+// generateMetadata is GenerateMetadataOnlyWithLogger cancelled by ctx.
+func (e *Engine) generateMetadata(ctx context.Context, logger *VerboseLogger) (*metadata.Metadata, error) {
 	// Fold any include/exclude patterns carried on the APISpecConfig (e.g. set
 	// via the UI or a config file) into the EngineConfig filter fields, which
 	// shouldIncludePackage / shouldIncludeFile actually read. Without this the
@@ -358,6 +470,14 @@ func (e *Engine) GenerateMetadataOnlyWithLogger(logger *VerboseLogger) (*metadat
 	if err != nil {
 		return nil, fmt.Errorf("could not find Go module: %w", err)
 	}
+	e.config.module = readGoModule(e.config.moduleRoot)
+	e.config.module.analyzeDependencies(e.config.AnalyzeDependencies, logger)
+	if e.config.module.vendor {
+		logger.Println("Building from vendor/: replaced modules resolve to their vendored copies")
+	}
+	if work := e.config.module.workspace; work != "" {
+		logger.Printf("Workspace %s: analyzing %s\n", work, strings.Join(e.config.module.projectModules(), ", "))
+	}
 
 	// Create file set and file info mapping for metadata generation
 	fset := token.NewFileSet()
@@ -366,21 +486,25 @@ func (e *Engine) GenerateMetadataOnlyWithLogger(logger *VerboseLogger) (*metadat
 	cfg := &packages.Config{
 		// NeedCompiledGoFiles and NeedTypesSizes are required by the SSA
 		// builder (config.ResolveCallGraph); harmless additions otherwise.
-		Mode:    packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesSizes | packages.NeedTypesInfo | packages.NeedImports,
-		Dir:     e.config.moduleRoot,
-		Fset:    fset,
-		Context: e.ctx(),
+		Mode:       packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesSizes | packages.NeedTypesInfo | packages.NeedImports,
+		Dir:        e.config.moduleRoot,
+		Env:        e.buildEnv(),
+		BuildFlags: e.buildFlags(),
+		Fset:       fset,
+		Context:    ctx,
 	}
 
 	// Filter packages and files based on include/exclude patterns
 	t0 := time.Now()
-	filteredPkgs, err := e.loadFilteredPackages(cfg)
+	filteredPkgs, err := e.loadFilteredPackages(cfg, e.config.module.loadPatterns(logger))
+	// The go list driver reports cancellation as a plain message, so check
+	// the context first to return its error.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to load filtered packages: %w", err)
 	}
-	if err := e.ctx().Err(); err != nil {
-		return nil, err
-	}
 	e.reportPhase(fmt.Sprintf("loaded %d packages", len(filteredPkgs)), time.Since(t0))
 
 	// Filter out packages with errors and continue with valid packages
@@ -389,6 +513,11 @@ func (e *Engine) GenerateMetadataOnlyWithLogger(logger *VerboseLogger) (*metadat
 
 	e.skipped = nil
 	for _, pkg := range filteredPkgs {
+		if len(pkg.Errors) > 0 && e.config.StubCGOPackages && usesCgo(pkg) {
+			logger.Printf("Warning: Loading package %s from its Go syntax, without C types: %s\n", pkg.PkgPath, pkg.Errors[0].Msg)
+			validPkgs = append(validPkgs, pkg)
+			continue
+		}
 		if len(pkg.Errors) > 0 {
 			errorCount++
 			// Log errors but continue processing other packages
@@ -398,7 +527,7 @@ func (e *Engine) GenerateMetadataOnlyWithLogger(logger *VerboseLogger) (*metadat
 			}
 			// Record (only in-module packages — third-party type errors are
 			// rarely actionable by the user) so the caller can surface them.
-			if mp := e.moduleImportPath(); mp == "" || pkg.PkgPath == mp || strings.HasPrefix(pkg.PkgPath, mp+"/") {
+			if e.isProjectPackage(pkg.PkgPath) {
 				reason := ""
 				if len(pkg.Errors) > 0 {
 					reason = pkg.Errors[0].Msg
@@ -483,20 +612,31 @@ func (e *Engine) GenerateMetadataOnlyWithLogger(logger *VerboseLogger) (*metadat
 		}
 	}
 
+	e.packageDirs = make(map[string]string)
+	for pkgPath, files := range pkgsMetadata {
+		if !e.isProjectPackage(pkgPath) {
+			continue
+		}
+		for fileName := range files {
+			e.packageDirs[pkgPath] = filepath.Dir(fileName)
+			break
+		}
+	}
+
 	// Generate metadata (now only on framework packages if auto-include is enabled)
 	tMeta := time.Now()
-	meta := metadata.GenerateMetadataWithLogger(pkgsMetadata, fileToInfo, importPaths, fset, logger, e.moduleImportPath())
-	e.reportPhase(fmt.Sprintf("metadata generated (%d call edges, %d pkgs)", len(meta.CallGraph), len(meta.Packages)), time.Since(tMeta))
-	if err := e.ctx().Err(); err != nil {
+	meta, err := metadata.GenerateMetadataContext(ctx, pkgsMetadata, fileToInfo, importPaths, fset, logger, e.config.module.path, e.config.module.localModulePaths()...)
+	if err != nil {
 		return nil, err
 	}
+	e.reportPhase(fmt.Sprintf("metadata generated (%d call edges, %d pkgs)", len(meta.CallGraph), len(meta.Packages)), time.Since(tMeta))
 
 	// Resolved call graph (SSA+VTA) from the same loaded packages.
 	if e.config.ResolveCallGraph {
 		tResolved := time.Now()
 		e.resolvedGraph = callgraph.Build(filteredPkgs)
 		e.reportPhase(fmt.Sprintf("resolved call graph built (%d functions)", len(e.resolvedGraph.Graph.Nodes)), time.Since(tResolved))
-		if err := e.ctx().Err(); err != nil {
+		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
 	}
@@ -527,74 +667,115 @@ func defaultFrameworkConfig(framework string) *spec.APISpecConfig {
 	case "mux":
 		return spec.DefaultMuxConfig()
 	default:
+		if cfg, ok := spec.RegisteredFrameworkConfig(framework); ok {
+			return cfg
+		}
 		return spec.DefaultHTTPConfig()
 	}
 }
 
+// GenerateOpenAPIContext is GenerateOpenAPI cancelled by ctx, which takes
+// precedence over config.Context: package loading, metadata generation and
+// the tracker tree build stop once ctx is done, and it returns ctx's error.
+func (e *Engine) GenerateOpenAPIContext(ctx context.Context) (*spec.OpenAPISpec, error) {
+	return e.generateOpenAPI(ctx)
+}
+
+// GenerateMetadataOnlyContext is GenerateMetadataOnly cancelled by ctx, as
+// GenerateOpenAPIContext is.
+func (e *Engine) GenerateMetadataOnlyContext(ctx context.Context) (*metadata.Metadata, error) {
+	return e.generateMetadata(ctx, NewVerboseLogger(e.config.Verbose))
+}
+
 func (e *Engine) GenerateOpenAPI() (*spec.OpenAPISpec, error) {
+	return e.generateOpenAPI(e.ctx())
+}
+
+// generateOpenAPI is GenerateOpenAPI cancelled by ctx.
+func (e *Engine) generateOpenAPI(ctx context.Context) (*spec.OpenAPISpec, error) {
 	// Generate metadata using the shared method
-	meta, err := e.GenerateMetadataOnly()
+	meta, err := e.generateMetadata(ctx, NewVerboseLogger(e.config.Verbose))
 	if err != nil {
 		return nil, err
 	}
 
 	// Generate diagram if requested
 	if e.config.DiagramPath != "" {
-		// Use absolute path for diagram file
-		diagramPath := e.config.DiagramPath
-		if !filepath.IsAbs(diagramPath) {
-			diagramPath = filepath.Join(e.config.moduleRoot, diagramPath)
-		}
-
-		// Choose between paginated and regular diagram based on configuration
-		if e.config.PaginatedDiagram {
-			// Use paginated visualization for better performance with large call graphs
-			// This solves the 3997-edge performance problem by loading data progressively
-			err = intspec.GeneratePaginatedCytoscapeHTML(meta, diagramPath, e.config.DiagramPageSize)
-			if err != nil {
-				return nil, fmt.Errorf("failed to generate paginated diagram: %w", err)
-			}
-		} else {
-			// Use regular call graph visualization for smaller graphs
-			err = intspec.GenerateCallGraphCytoscapeHTML(meta, diagramPath)
-			if err != nil {
-				return nil, fmt.Errorf("failed to generate diagram: %w", err)
-			}
+		if err := e.WriteDiagram(meta); err != nil {
+			return nil, err
 		}
 	}
 
+	return e.openAPIFromMetadata(ctx, meta)
+}
+
+// GenerateOpenAPIFromMetadata maps metadata to an OpenAPI spec: the second
+// half of GenerateOpenAPI. meta is usually the engine's own
+// GenerateMetadataOnly result; metadata loaded from a file works too, and
+// with no module root to scan its frameworks are detected from the imports
+// it records.
+func (e *Engine) GenerateOpenAPIFromMetadata(meta *metadata.Metadata) (*spec.OpenAPISpec, error) {
+	return e.openAPIFromMetadata(e.ctx(), meta)
+}
+
+// openAPIFromMetadata is GenerateOpenAPIFromMetadata cancelled by ctx.
+func (e *Engine) openAPIFromMetadata(ctx context.Context, meta *metadata.Metadata) (*spec.OpenAPISpec, error) {
 	// Framework dependency analysis is now handled in GenerateMetadataOnly()
 
 	// Detect frameworks and load configuration. The first-seen framework is
 	// the primary (whose Defaults/Info and unscoped helper patterns apply);
 	// any further recognised frameworks merge in below as scoped views.
+	// Frameworks registered via spec.RegisterFrameworkConfig are consulted
+	// after the built-ins.
 	detector := core.NewFrameworkDetector()
-	frameworks, err := detector.DetectAll(e.config.moduleRoot)
+	frameworks, err := detector.DetectWithRegistry(e.config.moduleRoot, meta)
 	if err != nil {
 		return nil, fmt.Errorf("failed to detect framework: %w", err)
 	}
 	framework := frameworks[0]
 
 	var apispecConfig *spec.APISpecConfig
+	sources := e.newConfigSources()
+	e.configDiagnostics = nil
 	if e.config.APISpecConfig != nil {
 		// Use the directly provided config
 		apispecConfig = e.config.APISpecConfig
+		sources.record(apispecConfig, "the config passed to the engine")
 	} else if e.config.ConfigFile != "" {
 		// Load config from file
-		apispecConfig, err = spec.LoadAPISpecConfig(e.config.ConfigFile)
+		apispecConfig, e.configDiagnostics, err = intspec.LoadAPISpecConfigWithDiagnostics(e.config.ConfigFile)
+		for _, d := range e.configDiagnostics {
+			diag.Report(d)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to load config: %w", err)
 		}
+		sources.recordFile(e.config.ConfigFile)
+		// A file without a framework section (only info, overrides or
+		// top-level routePatterns) extends the detected framework's
+		// defaults rather than switching extraction off.
+		if !apispecConfig.Framework.DeclaresPatterns() {
+			sources.track(apispecConfig, "built-in "+framework+" defaults", func() {
+				defaults := defaultFrameworkConfig(framework)
+				apispecConfig.Framework = defaults.Framework
+				if apispecConfig.Defaults == (intspec.Defaults{}) {
+					apispecConfig.Defaults = defaults.Defaults
+				}
+			})
+		}
 	} else {
 		// Auto-detect framework and use defaults
 		apispecConfig = defaultFrameworkConfig(framework)
+		sources.record(apispecConfig, "built-in "+framework+" defaults")
 		// Additional recognised frameworks (a gin API next to a gorilla/mux
 		// admin router, half-migrated projects): merge each one's
 		// receiver-scoped view so its registrations are traced too. Scoped
 		// patterns cannot claim another framework's calls, so the merge is
 		// inert where the secondary framework is imported but not routing.
 		for _, fw := range frameworks[1:] {
-			apispecConfig = spec.MergeFrameworkConfigs(apispecConfig, spec.SecondaryView(defaultFrameworkConfig(fw)))
+			sources.track(apispecConfig, "built-in "+fw+" defaults, scoped to its routers", func() {
+				apispecConfig = spec.MergeFrameworkConfigs(apispecConfig, spec.SecondaryView(defaultFrameworkConfig(fw)))
+			})
 		}
 		// Layer the stdlib net/http surface under the detected framework:
 		// mixed projects (a framework API plus plain ServeMux ops endpoints
@@ -604,48 +785,112 @@ func (e *Engine) GenerateOpenAPI() (*spec.OpenAPISpec, error) {
 		// the merge inert for pure-framework projects; user-supplied configs
 		// (the branches above) are never augmented.
 		if framework != "net/http" {
-			apispecConfig = spec.MergeFrameworkConfigs(apispecConfig, spec.HTTPSecondaryConfig())
+			sources.track(apispecConfig, "built-in net/http defaults, scoped to ServeMux", func() {
+				apispecConfig = spec.MergeFrameworkConfigs(apispecConfig, spec.HTTPSecondaryConfig())
+			})
 		}
 	}
 
 	// Merge built-in auth/security library presets based on the project's
 	// imports (framework preset -> library presets -> user config; user wins).
 	// The engine stays framework-agnostic: this only augments config data.
-	intspec.ApplySecurityPresets(apispecConfig, meta)
+	sources.track(apispecConfig, "security presets for the imported auth libraries", func() {
+		intspec.ApplySecurityPresets(apispecConfig, meta)
+	})
 
 	// Set info from configuration (only if not already set in APISpecConfig)
-	if apispecConfig.Info.Title == "" {
-		apispecConfig.Info.Title = e.config.Title
-	}
-	if apispecConfig.Info.Description == "" {
-		desc := e.config.Description
-		if !strings.HasSuffix(desc, FullLicenseNotice) {
-			desc += FullLicenseNotice
+	infoSource := "built-in defaults"
+	if flags := flagSource(
+		setFlag{"--title", e.config.Title != DefaultTitle},
+		setFlag{"--api-version", e.config.APIVersion != DefaultAPIVersion},
+		setFlag{"--description", e.config.Description != ""},
+		setFlag{"--terms", e.config.TermsOfService != ""},
+		setFlag{"--contact-name", e.config.ContactName != DefaultContactName},
+		setFlag{"--contact-url", e.config.ContactURL != DefaultContactURL},
+		setFlag{"--contact-email", e.config.ContactEmail != DefaultContactEmail},
+		setFlag{"--license-name", e.config.LicenseName != ""},
+		setFlag{"--license-url", e.config.LicenseURL != ""},
+	); flags != "" {
+		infoSource = flags + " and built-in defaults"
+	}
+	sources.track(apispecConfig, infoSource, func() { e.applyInfo(apispecConfig) })
+
+	sources.track(apispecConfig, "command line (--schema-base-id)", func() {
+		if apispecConfig.Schemas.IDBase == "" {
+			apispecConfig.Schemas.IDBase = e.config.SchemaIDBase
 		}
-		apispecConfig.Info.Description = desc
-	}
-	if apispecConfig.Info.Version == "" {
-		apispecConfig.Info.Version = e.config.APIVersion
-	}
-	if apispecConfig.Info.TermsOfService == "" {
-		apispecConfig.Info.TermsOfService = e.config.TermsOfService
-	}
-	if apispecConfig.Info.Contact == nil {
-		apispecConfig.Info.Contact = &intspec.Contact{
-			Name:  e.config.ContactName,
-			URL:   e.config.ContactURL,
-			Email: e.config.ContactEmail,
+	})
+	sources.track(apispecConfig, "command line (--examples)", func() {
+		if e.config.GenerateExamples {
+			apispecConfig.Schemas.Examples = true
+		}
+	})
+	sources.track(apispecConfig, "command line (--literal-examples)", func() {
+		if e.config.LiteralExamples {
+			apispecConfig.Schemas.LiteralExamples = true
+		}
+	})
+	sources.track(apispecConfig, "command line (--nullable-pointers)", func() {
+		if apispecConfig.Schemas.NullablePointers == "" {
+			apispecConfig.Schemas.NullablePointers = e.config.NullablePointers
+		}
+	})
+	sources.track(apispecConfig, "command line (--nullable-style)", func() {
+		if apispecConfig.Schemas.NullableStyle == "" {
+			apispecConfig.Schemas.NullableStyle = e.config.NullableStyle
 		}
+	})
+	// OpenAPI 3.0 has no null type: its nullable properties need the keyword.
+	sources.track(apispecConfig, "OpenAPI version (--openapi-version)", func() {
+		if apispecConfig.Schemas.NullableStyle == "" && strings.HasPrefix(e.config.OpenAPIVersion, "3.0") {
+			apispecConfig.Schemas.NullableStyle = intspec.NullableStyleNullable
+		}
+	})
+	sources.track(apispecConfig, "command line (--omitempty-optional)", func() {
+		if e.config.OmitemptyOptional {
+			apispecConfig.Schemas.OmitemptyOptional = true
+		}
+	})
+	if err := apispecConfig.Schemas.ValidateNullability(); err != nil {
+		return nil, err
 	}
-	if apispecConfig.Info.License == nil {
-		apispecConfig.Info.License = &intspec.License{
-			Name: e.config.LicenseName,
-			URL:  e.config.LicenseURL,
+	sources.track(apispecConfig, "command line (--dynamic-values)", func() {
+		if apispecConfig.Schemas.DynamicValues == "" {
+			apispecConfig.Schemas.DynamicValues = e.config.DynamicValues
 		}
+	})
+	sources.track(apispecConfig, "command line (--go-type-annotations)", func() {
+		if e.config.GoTypeAnnotations {
+			apispecConfig.Schemas.GoTypeAnnotations = true
+		}
+	})
+	if err := apispecConfig.Schemas.ValidateDynamicValues(); err != nil {
+		return nil, err
 	}
+	sources.track(apispecConfig, "command line (--include-debug-endpoints)", func() {
+		if e.config.IncludeDebugEndpoints {
+			apispecConfig.IncludeDebugEndpoints = true
+		}
+	})
+	sources.track(apispecConfig, "command line (--source-annotations)", func() {
+		if e.config.SourceAnnotations {
+			apispecConfig.SourceAnnotations = true
+		}
+	})
 
 	// Merge CLI include/exclude patterns with loaded configuration
-	e.mergeIncludeExcludePatterns(apispecConfig)
+	sources.track(apispecConfig, "command line (--include-*/--exclude-* filters)", func() {
+		e.mergeIncludeExcludePatterns(apispecConfig)
+	})
+	if err := apispecConfig.ValidateRouteFilters(); err != nil {
+		return nil, err
+	}
+
+	// Tests are not loaded with the packages; their fixtures are read only
+	// when literal examples are asked for.
+	if apispecConfig.Schemas.LiteralExamples {
+		metadata.CollectTestFixtures(meta, e.packageDirs)
+	}
 
 	// Prepare generator config
 	generatorConfig := intspec.GeneratorConfig{
@@ -662,21 +907,26 @@ func (e *Engine) GenerateOpenAPI() (*spec.OpenAPISpec, error) {
 		MaxNestedArgsDepth: e.config.MaxNestedArgsDepth,
 		MaxRecursionDepth:  e.config.MaxRecursionDepth,
 	}
-	if err := e.ctx().Err(); err != nil {
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 	tTree := time.Now()
 	var tree intspec.TrackerTreeInterface
 	if e.config.UseLazyTracker {
 		tree = intspec.NewLazyTree(meta, limits,
-			intspec.WithHandlerInterfaceMethods(apispecConfig.Framework.HandlerInterfaceMethods))
+			intspec.WithHandlerInterfaceMethods(apispecConfig.Framework.HandlerInterfaceMethods),
+			intspec.WithHandlerAdapters(apispecConfig.Framework.HandlerAdapters),
+			intspec.WithMountPatterns(apispecConfig.Framework.MountPatterns))
 		e.reportPhase("tracker tree ready (lazy)", time.Since(tTree))
 	} else {
 		tree = intspec.NewTrackerTree(meta, limits, NewVerboseLogger(e.config.Verbose),
-			intspec.WithEagerHandlerInterfaceMethods(apispecConfig.Framework.HandlerInterfaceMethods))
+			intspec.WithEagerHandlerInterfaceMethods(apispecConfig.Framework.HandlerInterfaceMethods),
+			intspec.WithEagerHandlerAdapters(apispecConfig.Framework.HandlerAdapters),
+			intspec.WithEagerMountPatterns(apispecConfig.Framework.MountPatterns),
+			intspec.WithContext(ctx))
 		e.reportPhase("tracker tree built", time.Since(tTree))
 	}
-	if err := e.ctx().Err(); err != nil {
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
@@ -689,25 +939,42 @@ func (e *Engine) GenerateOpenAPI() (*spec.OpenAPISpec, error) {
 	if secDiag != nil {
 		e.unresolvedSecurity = secDiag.UnresolvedMiddleware
 		e.pathParamMismatches = secDiag.PathParamMismatches
+		e.unsafeMethodWrites = secDiag.UnsafeMethodWrites
+		e.namingIssues = secDiag.NamingIssues
+		e.timeLayoutMismatches = secDiag.TimeLayoutMismatches
+		e.unresolvedRoutes = secDiag.UnresolvedRoutes
+		e.routeCoverage = secDiag.RouteCoverage
+		e.routes = secDiag.Routes
+		e.sources = secDiag.Sources
+		e.matcherStats = secDiag.MatcherStats
 	}
 	e.reportPhase(fmt.Sprintf("spec mapped (%d paths)", len(openAPISpec.Paths)), time.Since(tSpec))
 
-	// Handle metadata writing if requested
-	if e.config.WriteMetadata {
-		// Use absolute path for metadata file
-		metadataPath := DefaultMetadataFile
-		if !filepath.IsAbs(metadataPath) {
-			metadataPath = filepath.Join(e.config.moduleRoot, metadataPath)
+	if e.config.OverridesFile != "" {
+		overrides, diags, err := intspec.LoadSpecOverrides(e.config.OverridesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load overrides: %w", err)
 		}
+		diags = append(diags, intspec.ApplySpecOverrides(openAPISpec, overrides)...)
+		for _, d := range diags {
+			diag.Report(d)
+		}
+		e.configDiagnostics = append(e.configDiagnostics, diags...)
+	}
 
-		if e.config.SplitMetadata {
-			if err := metadata.WriteSplitMetadata(meta, metadataPath); err != nil {
-				return nil, fmt.Errorf("failed to write split metadata: %w", err)
-			}
-		} else {
-			if err := metadata.WriteMetadata(meta, metadataPath); err != nil {
-				return nil, fmt.Errorf("failed to write metadata: %w", err)
-			}
+	intspec.SortSpec(openAPISpec, e.config.Sort)
+
+	// Validate the finished spec, so output that downstream tools would
+	// reject is reported here rather than there.
+	e.specIssues = intspec.ValidateSpec(openAPISpec)
+	for _, issue := range e.specIssues {
+		diag.Report(issue.Diagnostic())
+	}
+
+	// Handle metadata writing if requested
+	if e.config.WriteMetadata {
+		if err := e.WriteMetadata(meta); err != nil {
+			return nil, err
 		}
 	}
 
@@ -719,7 +986,7 @@ func (e *Engine) GenerateOpenAPI() (*spec.OpenAPISpec, error) {
 			configPath = filepath.Join(e.config.moduleRoot, configPath)
 		}
 
-		cfgYaml, err := yaml.Marshal(apispecConfig)
+		cfgYaml, err := sources.marshal(apispecConfig)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal effective config: %w", err)
 		}
@@ -732,6 +999,40 @@ func (e *Engine) GenerateOpenAPI() (*spec.OpenAPISpec, error) {
 	return openAPISpec, nil
 }
 
+// applyInfo fills the info fields cfg leaves empty from the engine's
+// settings.
+func (e *Engine) applyInfo(cfg *spec.APISpecConfig) {
+	if cfg.Info.Title == "" {
+		cfg.Info.Title = e.config.Title
+	}
+	if cfg.Info.Description == "" {
+		desc := e.config.Description
+		if !strings.HasSuffix(desc, FullLicenseNotice) {
+			desc += FullLicenseNotice
+		}
+		cfg.Info.Description = desc
+	}
+	if cfg.Info.Version == "" {
+		cfg.Info.Version = e.config.APIVersion
+	}
+	if cfg.Info.TermsOfService == "" {
+		cfg.Info.TermsOfService = e.config.TermsOfService
+	}
+	if cfg.Info.Contact == nil {
+		cfg.Info.Contact = &intspec.Contact{
+			Name:  e.config.ContactName,
+			URL:   e.config.ContactURL,
+			Email: e.config.ContactEmail,
+		}
+	}
+	if cfg.Info.License == nil {
+		cfg.Info.License = &intspec.License{
+			Name: e.config.LicenseName,
+			URL:  e.config.LicenseURL,
+		}
+	}
+}
+
 // applyConfigFilters folds the include/exclude patterns from the
 // APISpecConfig (set via a config file or the UI) into the EngineConfig filter
 // fields that shouldIncludePackage / shouldIncludeFile read. It unions with any
@@ -786,6 +1087,9 @@ func (e *Engine) mergeIncludeExcludePatterns(config *spec.APISpecConfig) {
 	if len(e.config.IncludeTypes) > 0 {
 		config.Include.Types = append(config.Include.Types, e.config.IncludeTypes...)
 	}
+	if len(e.config.IncludeRoutes) > 0 {
+		config.Include.Routes = append(config.Include.Routes, e.config.IncludeRoutes...)
+	}
 
 	// Merge exclude patterns
 	if len(e.config.ExcludeFiles) > 0 {
@@ -800,13 +1104,123 @@ func (e *Engine) mergeIncludeExcludePatterns(config *spec.APISpecConfig) {
 	if len(e.config.ExcludeTypes) > 0 {
 		config.Exclude.Types = append(config.Exclude.Types, e.config.ExcludeTypes...)
 	}
+	if len(e.config.ExcludeRoutes) > 0 {
+		config.Exclude.Routes = append(config.Exclude.Routes, e.config.ExcludeRoutes...)
+	}
+}
+
+// WriteDiagram writes the call graph diagram of meta to DiagramPath,
+// resolved against the module root. meta must come from this engine, which
+// locates the module root while generating it.
+func (e *Engine) WriteDiagram(meta *metadata.Metadata) error {
+	diagramPath := e.moduleRelative(e.config.DiagramPath)
+
+	// Choose between paginated and regular diagram based on configuration
+	if e.config.PaginatedDiagram {
+		// Use paginated visualization for better performance with large call graphs
+		// This solves the 3997-edge performance problem by loading data progressively
+		if err := intspec.GeneratePaginatedCytoscapeHTML(meta, diagramPath, e.config.DiagramPageSize); err != nil {
+			return fmt.Errorf("failed to generate paginated diagram: %w", err)
+		}
+		return nil
+	}
+	// Use regular call graph visualization for smaller graphs
+	if err := intspec.GenerateCallGraphCytoscapeHTML(meta, diagramPath); err != nil {
+		return fmt.Errorf("failed to generate diagram: %w", err)
+	}
+	return nil
+}
+
+// WriteMetadata writes meta to MetadataFile (DefaultMetadataFile when
+// empty), resolved against the module root, split into one file per section
+// when SplitMetadata is set and as a stream of per-package documents when
+// StreamMetadata is set or the name ends in ".gz", which also compresses
+// it. Like WriteDiagram, meta must come from this engine.
+func (e *Engine) WriteMetadata(meta *metadata.Metadata) error {
+	name := e.config.MetadataFile
+	if name == "" {
+		name = DefaultMetadataFile
+	}
+	metadataPath := e.moduleRelative(name)
+	stream := e.config.StreamMetadata || strings.HasSuffix(name, ".gz")
+
+	if e.config.SplitMetadata && stream {
+		return fmt.Errorf("split metadata cannot be streamed or compressed")
+	}
+	if stream {
+		if err := metadata.WriteMetadataStreamFile(meta, metadataPath); err != nil {
+			return fmt.Errorf("failed to write metadata stream: %w", err)
+		}
+		return nil
+	}
+	if e.config.SplitMetadata {
+		if err := metadata.WriteSplitMetadata(meta, metadataPath); err != nil {
+			return fmt.Errorf("failed to write split metadata: %w", err)
+		}
+		return nil
+	}
+	if err := metadata.WriteMetadata(meta, metadataPath); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+	return nil
+}
+
+// buildFlags returns the go command flags packages are loaded with.
+func (e *Engine) buildFlags() []string {
+	if e.config.BuildTags == "" {
+		return nil
+	}
+	return []string{"-tags=" + e.config.BuildTags}
+}
+
+// buildEnv returns the environment packages are loaded with: the module's,
+// with GOOS and GOARCH when they are set. nil inherits apispec's.
+func (e *Engine) buildEnv() []string {
+	env := e.config.module.env
+	if e.config.GOOS == "" && e.config.GOARCH == "" {
+		return env
+	}
+	if env == nil {
+		env = os.Environ()
+	}
+	env = slices.Clone(env)
+	if e.config.GOOS != "" {
+		env = append(env, "GOOS="+e.config.GOOS)
+	}
+	if e.config.GOARCH != "" {
+		env = append(env, "GOARCH="+e.config.GOARCH)
+	}
+	return env
+}
+
+// usesCgo reports whether a file of pkg imports "C". When cgo fails, the
+// package still holds the syntax of its files, type-checked without C.
+func usesCgo(pkg *packages.Package) bool {
+	for _, f := range pkg.Syntax {
+		for _, imp := range f.Imports {
+			if imp.Path.Value == `"C"` {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// moduleRelative resolves a relative output path against the module root.
+func (e *Engine) moduleRelative(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(e.config.moduleRoot, path)
 }
 
 func (e *Engine) ModuleRoot() string {
 	return e.config.moduleRoot
 }
 
-// findModuleRoot finds the root directory of a Go module by looking for go.mod
+// findModuleRoot finds the root directory of a Go module by looking for go.mod,
+// or of a workspace by looking for go.work: a go.work met first makes its
+// directory, whose modules are analyzed together, the root.
 func (e *Engine) findModuleRoot(startPath string) (string, error) {
 	absPath, err := filepath.Abs(startPath)
 	if err != nil {
@@ -819,6 +1233,9 @@ func (e *Engine) findModuleRoot(startPath string) (string, error) {
 		if _, err := os.Stat(goModPath); err == nil {
 			return current, nil
 		}
+		if _, err := os.Stat(filepath.Join(current, "go.work")); err == nil {
+			return current, nil
+		}
 
 		parent := filepath.Dir(current)
 		if parent == current {
@@ -827,29 +1244,24 @@ func (e *Engine) findModuleRoot(startPath string) (string, error) {
 		current = parent
 	}
 
-	return "", fmt.Errorf("no go.mod found in %s or any parent directory", startPath)
+	return "", fmt.Errorf("no go.mod found in %s or any parent directory (nor a go.work)", startPath)
 }
 
-// moduleImportPath reads the `module` path from go.mod at the resolved module
-// root. This is the authoritative project import prefix; metadata generation
-// uses it to classify project vs library packages (driving the Insight
-// call-graph stats and external-vs-internal type resolution) instead of
-// inferring it from import paths. Returns "" if go.mod is missing/unreadable.
-func (e *Engine) moduleImportPath() string {
-	if e.config.moduleRoot == "" {
-		return ""
-	}
-	data, err := os.ReadFile(filepath.Join(e.config.moduleRoot, "go.mod"))
-	if err != nil {
-		return ""
+// isProjectPackage reports whether pkgPath belongs to the analyzed module, to
+// a module go.mod replaces with a local directory, or to a workspace module
+// analyzed with it. Everything counts as the project's when there is no
+// module path to go by.
+func (e *Engine) isProjectPackage(pkgPath string) bool {
+	mods := e.config.module.projectModules()
+	if len(mods) == 0 {
+		return true
 	}
-	for _, line := range strings.Split(string(data), "\n") {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "module ") {
-			return strings.TrimSpace(strings.TrimPrefix(line, "module"))
+	for _, mp := range mods {
+		if pkgPath == mp || strings.HasPrefix(pkgPath, mp+"/") {
+			return true
 		}
 	}
-	return ""
+	return false
 }
 
 // matchesPattern checks if a path matches a gitignore-style pattern
@@ -860,7 +1272,7 @@ func matchesPattern(pattern, path string) bool {
 // shouldIncludePackage checks if a package should be included based on include/exclude patterns
 func (e *Engine) shouldIncludePackage(pkgPath string) bool {
 	// Auto-exclude known problematic CGO dependencies if enabled
-	if e.config.SkipCGOPackages {
+	if e.config.SkipCGOPackages && !e.config.StubCGOPackages {
 		cgoProblematicPatterns := []string{
 			"*/tensorflow/*",     // TensorFlow C bindings
 			"*/govips/*",         // VIPS image processing
@@ -983,10 +1395,11 @@ func (e *Engine) shouldIncludeFile(fileName string) bool {
 	return true // No include patterns specified, so include
 }
 
-// loadFilteredPackages loads packages with filtering based on include/exclude patterns
-func (e *Engine) loadFilteredPackages(cfg *packages.Config) ([]*packages.Package, error) {
+// loadFilteredPackages loads the packages matching loadPatterns with filtering
+// based on include/exclude patterns
+func (e *Engine) loadFilteredPackages(cfg *packages.Config, loadPatterns []string) ([]*packages.Package, error) {
 	// Load all packages first to ensure proper Go module resolution
-	pkgs, err := packages.Load(cfg, "./...")
+	pkgs, err := packages.Load(cfg, loadPatterns...)
 	if err != nil {
 		return nil, err
 	}
@@ -1050,6 +1463,73 @@ func (e *Engine) GetPathParamMismatches() []intspec.PathParamMismatch {
 	return e.pathParamMismatches
 }
 
+// GetUnsafeMethodWrites returns the GET and HEAD handlers from the most recent
+// generation that call a repository or database mutation. Empty when none.
+func (e *Engine) GetUnsafeMethodWrites() []intspec.UnsafeMethodWrite {
+	return e.unsafeMethodWrites
+}
+
+// GetConfigDiagnostics returns the warnings from loading the config and
+// overrides files in the most recent generation, such as keys no field
+// decodes or overrides for routes that were not generated. Empty when none.
+func (e *Engine) GetConfigDiagnostics() []diag.Diagnostic {
+	return e.configDiagnostics
+}
+
+// GetSpecIssues returns the structural problems (dangling $refs, duplicate
+// operationIds, path template mismatches, invalid status codes) found in the
+// spec of the most recent generation. Empty when none.
+func (e *Engine) GetSpecIssues() []intspec.SpecIssue {
+	return e.specIssues
+}
+
+// GetNamingIssues returns the JSON naming audit findings (duplicate json
+// tags, case collisions, cross-schema case mismatches) from the most recent
+// generation. Empty when none.
+func (e *Engine) GetNamingIssues() []intspec.NamingIssue {
+	return e.namingIssues
+}
+
+// GetTimeLayoutMismatches returns the MarshalJSON and MarshalText methods
+// found, during the most recent generation, to format a time with a layout
+// their schema does not describe. Empty when none.
+func (e *Engine) GetTimeLayoutMismatches() []intspec.TimeLayoutMismatch {
+	return e.timeLayoutMismatches
+}
+
+// GetUnresolvedRoutes returns the route registrations the most recent
+// generation left out of the spec, or documented from a guess, because their
+// path, a prefix or their handler could not be resolved. Empty when none.
+func (e *Engine) GetUnresolvedRoutes() []intspec.UnresolvedRoute {
+	return e.unresolvedRoutes
+}
+
+// GetRouteCoverage returns the router registration call sites the most
+// recent generation met and whether a route was extracted from each.
+func (e *Engine) GetRouteCoverage() intspec.RouteCoverage {
+	return e.routeCoverage
+}
+
+// GetRoutes returns the routes extracted during the most recent generation,
+// the ones the spec's paths were built from. Empty when none.
+func (e *Engine) GetRoutes() []*intspec.RouteInfo {
+	return e.routes
+}
+
+// GetSources returns where the most recent generation found the Go
+// declaration of each operation ("GET /users/{id}") and component schema
+// ("components.schemas.User"). Empty when none.
+func (e *Engine) GetSources() map[string]intspec.SourceLocation {
+	return e.sources
+}
+
+// GetMatcherStats returns how many pattern matcher checks the most recent
+// generation evaluated, skipped through the callee index, and answered from
+// the per-edge memos.
+func (e *Engine) GetMatcherStats() intspec.MatcherStats {
+	return e.matcherStats
+}
+
 // SkippedPackages returns the in-module packages excluded from the most recent
 // analysis because they failed to type-check. A non-empty result means the
 // spec is likely incomplete — usually the project doesn't build (e.g. an
@@ -1137,13 +1617,13 @@ func (e *Engine) filterToFrameworkPackages(
 	// project packages would discard interface implementations that are only
 	// reached through dependency injection (e.g. a concrete store assigned to
 	// an interface field), breaking interface→concrete resolution and type
-	// inference. Only third-party non-framework deps are pruned.
-	modPath := e.moduleImportPath()
+	// inference. Only third-party non-framework deps are pruned; locally
+	// replaced modules are the project's too.
 	keep := func(pkgPath string) bool {
 		if frameworkPackages[pkgPath] {
 			return true
 		}
-		return modPath != "" && (pkgPath == modPath || strings.HasPrefix(pkgPath, modPath+"/"))
+		return len(e.config.module.projectModules()) > 0 && e.isProjectPackage(pkgPath)
 	}
 
 	// Filter packages metadata
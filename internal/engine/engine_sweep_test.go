@@ -16,6 +16,7 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -33,37 +34,67 @@ func TestEngineCtx(t *testing.T) {
 	}
 }
 
-func TestModuleImportPath(t *testing.T) {
-	e := NewEngine(&EngineConfig{})
+func TestEngineRunContext(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n\ngo 1.26\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
 
-	// No module root resolved yet.
-	if got := e.moduleImportPath(); got != "" {
-		t.Errorf("empty root = %q", got)
+	e := NewEngine(&EngineConfig{InputDir: dir})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := e.GenerateOpenAPIContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("GenerateOpenAPIContext = %v, want context.Canceled", err)
+	}
+	if _, err := e.GenerateMetadataOnlyContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("GenerateMetadataOnlyContext = %v, want context.Canceled", err)
+	}
+	// The run context is passed down, never stored on the engine.
+	if e.ctx().Err() != nil {
+		t.Error("the cancelled run context outlived its call")
+	}
+	if _, err := e.GenerateOpenAPIContext(context.Background()); err != nil {
+		t.Errorf("GenerateOpenAPIContext = %v after a cancelled run", err)
 	}
 
-	// go.mod present: the module line wins.
-	dir := t.TempDir()
-	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("// comment\nmodule example.com/mymod\n\ngo 1.26\n"), 0o644); err != nil {
-		t.Fatal(err)
+	// A run context takes precedence over a cancelled configured one.
+	e = NewEngine(&EngineConfig{InputDir: dir, Context: ctx})
+	if _, err := e.GenerateOpenAPIContext(context.Background()); err != nil {
+		t.Errorf("GenerateOpenAPIContext = %v with a cancelled config.Context", err)
 	}
-	e.config.moduleRoot = dir
-	if got := e.moduleImportPath(); got != "example.com/mymod" {
-		t.Errorf("module path = %q, want example.com/mymod", got)
+	if _, err := e.GenerateOpenAPI(); !errors.Is(err, context.Canceled) {
+		t.Errorf("GenerateOpenAPI = %v, want config.Context's context.Canceled", err)
 	}
+}
 
-	// go.mod without a module line.
-	dir2 := t.TempDir()
-	if err := os.WriteFile(filepath.Join(dir2, "go.mod"), []byte("go 1.26\n"), 0o644); err != nil {
-		t.Fatal(err)
+func TestModulePathFromGoMod(t *testing.T) {
+	write := func(t *testing.T, gomod string) string {
+		t.Helper()
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return dir
 	}
-	e.config.moduleRoot = dir2
-	if got := e.moduleImportPath(); got != "" {
-		t.Errorf("missing module line = %q, want empty", got)
+
+	for _, tc := range []struct {
+		name, gomod, want string
+	}{
+		{"module line", "// comment\nmodule example.com/mymod\n\ngo 1.26\n", "example.com/mymod"},
+		{"quoted", "module \"example.com/quoted\"\n", "example.com/quoted"},
+		{"trailing comment", "module example.com/commented // fork of example.com/upstream\n", "example.com/commented"},
+		{"no module line", "go 1.26\n", ""},
+	} {
+		if got := readGoModule(write(t, tc.gomod)).path; got != tc.want {
+			t.Errorf("%s: module path = %q, want %q", tc.name, got, tc.want)
+		}
 	}
 
 	// go.mod unreadable/missing.
-	e.config.moduleRoot = filepath.Join(dir2, "nope")
-	if got := e.moduleImportPath(); got != "" {
+	if got := readGoModule(filepath.Join(t.TempDir(), "nope")).path; got != "" {
 		t.Errorf("missing go.mod = %q, want empty", got)
 	}
 }
@@ -79,6 +110,7 @@ func TestShouldIncludePackage(t *testing.T) {
 		{"cgo sqlite skipped", EngineConfig{SkipCGOPackages: true}, "github.com/mattn/go-sqlite3", false},
 		{"cgo graft-tensorflow skipped", EngineConfig{SkipCGOPackages: true}, "github.com/x/graft/tensorflow", false},
 		{"cgo off keeps sqlite", EngineConfig{}, "github.com/mattn/go-sqlite3", true},
+		{"cgo stub keeps sqlite", EngineConfig{SkipCGOPackages: true, StubCGOPackages: true}, "github.com/mattn/go-sqlite3", true},
 		{"auto-exclude _test pkg", EngineConfig{AutoExcludeTests: true}, "example.com/app/foo_test", false},
 		{"auto-exclude mocks pkg", EngineConfig{AutoExcludeMocks: true}, "example.com/app/mocks", false},
 		{"auto-exclude stubs pkg", EngineConfig{AutoExcludeMocks: true}, "example.com/app/stubs", false},
@@ -18,6 +18,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/ehabterra/apispec/internal/metadata"
 )
 
 func TestDefaultEngineConfig(t *testing.T) {
@@ -321,6 +323,28 @@ func main() {
 	}
 }
 
+func TestEngine_WriteMetadataStream(t *testing.T) {
+	tempDir := t.TempDir()
+	meta := &metadata.Metadata{StringPool: metadata.NewStringPool()}
+
+	for _, config := range []*EngineConfig{
+		{MetadataFile: filepath.Join(tempDir, "streamed.yaml"), StreamMetadata: true},
+		{MetadataFile: filepath.Join(tempDir, "compressed.yaml.gz")},
+	} {
+		if err := NewEngine(config).WriteMetadata(meta); err != nil {
+			t.Fatalf("WriteMetadata(%s) failed: %v", config.MetadataFile, err)
+		}
+		if _, err := metadata.LoadMetadata(config.MetadataFile); err != nil {
+			t.Errorf("LoadMetadata(%s) failed: %v", config.MetadataFile, err)
+		}
+	}
+
+	split := NewEngine(&EngineConfig{MetadataFile: filepath.Join(tempDir, "split.yaml.gz"), SplitMetadata: true})
+	if err := split.WriteMetadata(meta); err == nil {
+		t.Error("expected an error for compressed split metadata")
+	}
+}
+
 func TestEngine_GenerateOpenAPI_WithDiagram(t *testing.T) {
 	// Create a temporary directory with a Go module
 	tempDir, err := os.MkdirTemp("", "apispec_test_diagram")
@@ -0,0 +1,324 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+// goModule is what the engine reads from the analyzed module's go.mod.
+type goModule struct {
+	// path is the module path of the `module` directive: the project's
+	// import prefix. Metadata generation classifies project vs library
+	// packages by it rather than inferring it from import paths.
+	path string
+
+	// localReplaces maps each required module that go.mod replaces with a
+	// directory (`replace example.com/shared => ../shared`) to that
+	// directory, made absolute. Those modules are the project's own code, kept
+	// out of the module proxy, so they are analyzed alongside it.
+	localReplaces map[string]string
+
+	// vendor reports whether the go command builds from vendor/ (see
+	// vendorMode). A locally replaced module then resolves to its vendored
+	// copy, and its directory need not exist.
+	vendor bool
+
+	// workspace is the go.work file the go command uses for the module, empty
+	// outside workspace mode.
+	workspace string
+
+	// workspaceModules maps the other modules the workspace uses to their
+	// directories: all of them when the analyzed directory is the workspace
+	// itself (workspaceRoot), otherwise those the module imports. Like
+	// locally replaced modules, they are the project's own code.
+	workspaceModules map[string]string
+	workspaceRoot    bool
+
+	// required lists the modules go.mod requires, directly or not.
+	required map[string]bool
+
+	// dependencies are the required modules analyzed on request
+	// (--analyze-dependency), read from the module cache or vendor/: routes a
+	// shared library registers are then traced like the project's own.
+	dependencies []string
+
+	// env is the environment the go command runs with, nil to inherit
+	// apispec's (see workspaceGoFlags).
+	env []string
+}
+
+// readGoModule reads root/go.mod and, in workspace mode, the go.work file
+// the go command uses for root. A missing or malformed go.mod yields the
+// zero goModule: the go command reports the problem itself when loading, and
+// metadata generation falls back to inferring the module path.
+func readGoModule(root string) goModule {
+	mod := parseGoMod(root)
+	if work := goWork(root); work != "" {
+		mod.useWorkspace(root, work)
+	}
+	return mod
+}
+
+// parseGoMod reads the module path, local replacements and vendoring of
+// root/go.mod.
+func parseGoMod(root string) goModule {
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return goModule{}
+	}
+	f, err := modfile.Parse("go.mod", data, nil)
+	if err != nil {
+		return goModule{}
+	}
+
+	required := make(map[string]bool, len(f.Require))
+	for _, r := range f.Require {
+		required[r.Mod.Path] = true
+	}
+	mod := goModule{required: required}
+	if f.Module != nil {
+		mod.path = f.Module.Mod.Path
+	}
+	for _, r := range f.Replace {
+		// A module replaced by another module version (New.Version set) is
+		// still a dependency; only a directory replacement is local source.
+		// A replacement of a module nothing requires is never loaded.
+		if r.New.Version != "" || !required[r.Old.Path] {
+			continue
+		}
+		dir := r.New.Path
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(root, dir)
+		}
+		if mod.localReplaces == nil {
+			mod.localReplaces = make(map[string]string)
+		}
+		mod.localReplaces[r.Old.Path] = filepath.Clean(dir)
+	}
+
+	goVersion := ""
+	if f.Go != nil {
+		goVersion = f.Go.Version
+	}
+	mod.vendor = vendorMode(root, goVersion, goFlags(root))
+	return mod
+}
+
+// localModulePaths returns the locally replaced module paths, the other
+// workspace modules and the dependencies analyzed, sorted.
+func (m goModule) localModulePaths() []string {
+	paths := make([]string, 0, len(m.localReplaces)+len(m.workspaceModules)+len(m.dependencies))
+	for p := range m.localReplaces {
+		paths = append(paths, p)
+	}
+	for p := range m.workspaceModules {
+		paths = append(paths, p)
+	}
+	paths = append(paths, m.dependencies...)
+	slices.Sort(paths)
+	return slices.Compact(paths)
+}
+
+// analyzeDependencies marks the modules in paths to be analyzed with the
+// project. A module go.mod does not require is left out with a warning: the
+// go command has no version of it to load.
+func (m *goModule) analyzeDependencies(paths []string, logger *VerboseLogger) {
+	for _, path := range paths {
+		switch {
+		case path == m.path || slices.Contains(m.localModulePaths(), path):
+			// Analyzed already.
+		case !m.required[path]:
+			logger.Warnf("Warning: --analyze-dependency %s: go.mod does not require it; its packages are not analyzed\n", path)
+		default:
+			m.dependencies = append(m.dependencies, path)
+		}
+	}
+}
+
+// projectModules returns the module paths whose packages are the project's:
+// the module's own, then the local ones.
+func (m goModule) projectModules() []string {
+	if m.path == "" {
+		return m.localModulePaths()
+	}
+	return append([]string{m.path}, m.localModulePaths()...)
+}
+
+// goWork returns the go.work file the go command uses in root, or "" outside
+// workspace mode (no go.work above root, GOWORK=off) or when the go command
+// can't be run.
+func goWork(root string) string {
+	cmd := exec.Command("go", "env", "GOWORK")
+	cmd.Dir = root
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	work := strings.TrimSpace(string(out))
+	if work == "off" {
+		return ""
+	}
+	return work
+}
+
+// useWorkspace reads the modules the go.work file at work uses. Analyzing
+// the workspace directory itself documents them all; analyzing one of them
+// adds the others it imports, so the types it shares with them (DTOs in a
+// sibling module) resolve into schemas while the routes of unrelated
+// services in the same workspace stay out.
+func (m *goModule) useWorkspace(root, work string) {
+	data, err := os.ReadFile(work)
+	if err != nil {
+		return
+	}
+	f, err := modfile.ParseWork(work, data, nil)
+	if err != nil {
+		return
+	}
+	m.workspace = work
+	base := filepath.Dir(work)
+	m.workspaceRoot = filepath.Clean(base) == filepath.Clean(root) && m.path == ""
+	for _, use := range f.Use {
+		dir := filepath.FromSlash(use.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(base, dir)
+		}
+		gomod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			continue
+		}
+		path := modfile.ModulePath(gomod)
+		if path == "" || path == m.path {
+			continue
+		}
+		if m.workspaceModules == nil {
+			m.workspaceModules = make(map[string]string)
+		}
+		m.workspaceModules[path] = filepath.Clean(dir)
+	}
+
+	// The go command refuses -mod=mod in workspace mode: drop it rather
+	// than fail to load anything.
+	if flags, changed := workspaceGoFlags(goFlags(root)); changed {
+		m.env = append(os.Environ(), "GOFLAGS="+flags)
+	}
+	if !m.workspaceRoot && len(m.workspaceModules) > 0 {
+		if imported, err := m.importedModules(root); err == nil {
+			for path := range m.workspaceModules {
+				if !imported[path] {
+					delete(m.workspaceModules, path)
+				}
+			}
+		}
+	}
+}
+
+// importedModules returns the modules providing the packages root's
+// packages import, directly or not. On error every workspace module is kept.
+func (m goModule) importedModules(root string) (map[string]bool, error) {
+	cmd := exec.Command("go", "list", "-deps", "-e", "-f", "{{with .Module}}{{.Path}}{{end}}", "./...")
+	cmd.Dir = root
+	cmd.Env = m.env
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	imported := make(map[string]bool)
+	for _, line := range strings.Fields(string(out)) {
+		imported[line] = true
+	}
+	return imported, nil
+}
+
+// workspaceGoFlags removes the -mod=mod flags from goflags, which the go
+// command rejects in workspace mode, and reports whether there were any.
+func workspaceGoFlags(goflags string) (string, bool) {
+	var kept []string
+	changed := false
+	for _, f := range strings.Fields(goflags) {
+		if f == "-mod=mod" || f == "--mod=mod" {
+			changed = true
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return strings.Join(kept, " "), changed
+}
+
+// vendorMode mirrors the go command's choice of -mod: an explicit -mod flag
+// in GOFLAGS wins; otherwise vendor/ is used when vendor/modules.txt exists
+// and go.mod declares go 1.14 or later.
+func vendorMode(root, goVersion, goflags string) bool {
+	mode := ""
+	for _, f := range strings.Fields(goflags) {
+		if v, ok := strings.CutPrefix(f, "-mod="); ok {
+			mode = v
+		} else if v, ok := strings.CutPrefix(f, "--mod="); ok {
+			mode = v
+		}
+	}
+	if mode != "" {
+		return mode == "vendor"
+	}
+	if goVersion == "" || semver.Compare("v"+goVersion, "v1.14") < 0 {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(root, "vendor", "modules.txt"))
+	return err == nil
+}
+
+// goFlags returns the effective GOFLAGS, including a value set with
+// `go env -w`, falling back to the environment when the go command can't be
+// run.
+func goFlags(root string) string {
+	cmd := exec.Command("go", "env", "GOFLAGS")
+	cmd.Dir = root
+	out, err := cmd.Output()
+	if err != nil {
+		return os.Getenv("GOFLAGS")
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// loadPatterns returns the package patterns to load: the module itself,
+// every locally replaced module and the workspace modules analyzed. Without
+// vendoring, a replacement whose directory is missing is left out with a
+// warning; the packages importing it fail to type-check and are reported as
+// skipped. A workspace directory is no module of its own: only its modules
+// are loaded.
+func (m goModule) loadPatterns(logger *VerboseLogger) []string {
+	var patterns []string
+	if !m.workspaceRoot {
+		patterns = append(patterns, "./...")
+	}
+	for _, path := range m.localModulePaths() {
+		if dir, replaced := m.localReplaces[path]; replaced && !m.vendor {
+			if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+				logger.Warnf("Warning: go.mod replaces %s with %s, which has no go.mod; its packages are not analyzed\n", path, dir)
+				continue
+			}
+		}
+		patterns = append(patterns, path+"/...")
+	}
+	return patterns
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestReadGoModule(t *testing.T) {
+	dir := t.TempDir()
+	gomod := `module example.com/app
+
+go 1.22
+
+require (
+	example.com/shared v0.0.0
+	example.com/tools v0.0.0
+	example.com/fork v1.2.0
+)
+
+replace example.com/shared => ../shared
+
+replace example.com/fork => github.com/me/fork v1.2.1
+
+replace example.com/unused => ./unused
+
+replace example.com/tools => /opt/tools
+`
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mod := readGoModule(dir)
+	if got := mod.localModulePaths(); !slices.Equal(got, []string{"example.com/shared", "example.com/tools"}) {
+		t.Errorf("local modules = %v, want shared and tools only", got)
+	}
+	if got, want := mod.localReplaces["example.com/shared"], filepath.Join(filepath.Dir(dir), "shared"); got != want {
+		t.Errorf("shared dir = %q, want %q", got, want)
+	}
+	if got := mod.localReplaces["example.com/tools"]; got != "/opt/tools" {
+		t.Errorf("tools dir = %q, want /opt/tools", got)
+	}
+
+	// Neither replacement exists on disk, so only the module itself is loaded.
+	if got := mod.loadPatterns(NewVerboseLogger(false)); !slices.Equal(got, []string{"./..."}) {
+		t.Errorf("patterns = %v, want [./...]", got)
+	}
+	// A vendored build resolves them from vendor/ regardless.
+	mod.vendor = true
+	if got := mod.loadPatterns(NewVerboseLogger(false)); !slices.Equal(got, []string{"./...", "example.com/shared/...", "example.com/tools/..."}) {
+		t.Errorf("vendored patterns = %v", got)
+	}
+
+	if got := readGoModule(filepath.Join(dir, "missing")); got.localReplaces != nil || got.vendor {
+		t.Errorf("missing go.mod = %+v, want zero", got)
+	}
+}
+
+func TestAnalyzeDependencies(t *testing.T) {
+	mod := goModule{
+		path:          "example.com/app",
+		required:      map[string]bool{"example.com/httpkit": true, "example.com/shared": true},
+		localReplaces: map[string]string{"example.com/shared": "/src/shared"},
+	}
+	mod.analyzeDependencies([]string{"example.com/httpkit", "example.com/shared", "example.com/app", "example.com/unknown"}, NewVerboseLogger(false))
+	if !slices.Equal(mod.dependencies, []string{"example.com/httpkit"}) {
+		t.Errorf("dependencies = %v, want httpkit only", mod.dependencies)
+	}
+	if got := mod.projectModules(); !slices.Equal(got, []string{"example.com/app", "example.com/httpkit", "example.com/shared"}) {
+		t.Errorf("project modules = %v", got)
+	}
+	mod.vendor = true
+	if got := mod.loadPatterns(NewVerboseLogger(false)); !slices.Equal(got, []string{"./...", "example.com/httpkit/...", "example.com/shared/..."}) {
+		t.Errorf("patterns = %v", got)
+	}
+}
+
+func TestReadGoModule_Workspace(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.work":              "go 1.22\n\nuse (\n\t./api\n\t./admin\n\t./shared\n)\n",
+		"api/go.mod":           "module example.com/api\n\ngo 1.22\n",
+		"api/main.go":          "package main\n\nimport _ \"example.com/shared/models\"\n\nfunc main() {}\n",
+		"admin/go.mod":         "module example.com/admin\n\ngo 1.22\n",
+		"admin/main.go":        "package main\n\nfunc main() {}\n",
+		"shared/go.mod":        "module example.com/shared\n\ngo 1.22\n",
+		"shared/models/dto.go": "package models\n\ntype Order struct{}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("GOWORK", "")
+	logger := NewVerboseLogger(false)
+
+	// A member module analyzes the workspace modules it imports.
+	api := readGoModule(filepath.Join(root, "api"))
+	if api.workspace != filepath.Join(root, "go.work") || api.workspaceRoot {
+		t.Errorf("api workspace = %q (root %v), want %s/go.work", api.workspace, api.workspaceRoot, root)
+	}
+	if got := api.projectModules(); !slices.Equal(got, []string{"example.com/api", "example.com/shared"}) {
+		t.Errorf("api project modules = %v, want api and shared", got)
+	}
+	if got := api.loadPatterns(logger); !slices.Equal(got, []string{"./...", "example.com/shared/..."}) {
+		t.Errorf("api patterns = %v", got)
+	}
+
+	// The workspace directory analyzes every module, and no module of its own.
+	ws := readGoModule(root)
+	if !ws.workspaceRoot || ws.path != "" {
+		t.Errorf("workspace = %+v, want the workspace root", ws)
+	}
+	if got := ws.loadPatterns(logger); !slices.Equal(got, []string{"example.com/admin/...", "example.com/api/...", "example.com/shared/..."}) {
+		t.Errorf("workspace patterns = %v", got)
+	}
+
+	t.Setenv("GOWORK", "off")
+	if got := readGoModule(filepath.Join(root, "api")); got.workspace != "" || got.workspaceModules != nil {
+		t.Errorf("GOWORK=off = %+v, want no workspace", got)
+	}
+}
+
+func TestWorkspaceGoFlags(t *testing.T) {
+	for _, tc := range []struct {
+		goflags, want string
+		changed       bool
+	}{
+		{"", "", false},
+		{"-mod=mod", "", true},
+		{"-trimpath --mod=mod -tags=e2e", "-trimpath -tags=e2e", true},
+		{"-mod=readonly", "-mod=readonly", false},
+	} {
+		if got, changed := workspaceGoFlags(tc.goflags); got != tc.want || changed != tc.changed {
+			t.Errorf("workspaceGoFlags(%q) = %q, %v; want %q, %v", tc.goflags, got, changed, tc.want, tc.changed)
+		}
+	}
+}
+
+func TestVendorMode(t *testing.T) {
+	vendored := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(vendored, "vendor"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(vendored, "vendor", "modules.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	plain := t.TempDir()
+
+	for _, tc := range []struct {
+		name, root, goVersion, goflags string
+		want                           bool
+	}{
+		{"vendor dir, go 1.22", vendored, "1.22", "", true},
+		{"vendor dir, go 1.13", vendored, "1.13", "", false},
+		{"vendor dir, -mod=mod", vendored, "1.22", "-mod=mod", false},
+		{"vendor dir, -mod=readonly", vendored, "1.22", "-trimpath -mod=readonly", false},
+		{"no vendor dir", plain, "1.22", "", false},
+		{"no vendor dir, -mod=vendor", plain, "1.22", "-mod=vendor", true},
+		{"last -mod wins", vendored, "1.22", "-mod=vendor --mod=mod", false},
+	} {
+		if got := vendorMode(tc.root, tc.goVersion, tc.goflags); got != tc.want {
+			t.Errorf("%s: vendorMode = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// Path match kinds of a TableRoute.
+const (
+	MatchExact  = "exact"
+	MatchPrefix = "prefix"
+	MatchRegex  = "regex"
+)
+
+// TableRoute is one path entry of a gateway routing table, normalised across
+// gateway kinds. A gateway route declaring several paths yields one
+// TableRoute per path.
+type TableRoute struct {
+	Name    string
+	Target  string   // Kong service or Envoy cluster
+	Methods []string // empty matches any method
+	Match   string   // MatchExact, MatchPrefix or MatchRegex
+	Path    string   // literal path/prefix, or the regex source
+
+	re *regexp.Regexp
+}
+
+// RoutingTable is a gateway's routes in evaluation order: the first entry
+// that matches a request is the one the gateway uses.
+type RoutingTable struct {
+	Kind   string
+	Routes []TableRoute
+}
+
+// LoadRoutingTable reads a Kong declarative config or an Envoy route config
+// (either the stub emitted by --format gateway-config or a bare
+// RouteConfiguration) from path.
+func LoadRoutingTable(path string) (*RoutingTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gateway: failed to read %s: %w", path, err)
+	}
+	return ParseRoutingTable(data)
+}
+
+// ParseRoutingTable is LoadRoutingTable for in-memory YAML or JSON.
+func ParseRoutingTable(data []byte) (*RoutingTable, error) {
+	var probe map[string]interface{}
+	if err := yaml.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("gateway: failed to parse routing table: %w", err)
+	}
+	switch {
+	case probe["_format_version"] != nil || probe["services"] != nil:
+		var cfg KongConfig
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("gateway: failed to parse Kong config: %w", err)
+		}
+		return kongTable(&cfg)
+	case probe["route_config"] != nil:
+		var cfg EnvoyConfig
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("gateway: failed to parse Envoy config: %w", err)
+		}
+		return envoyTable(&cfg.RouteConfig)
+	case probe["virtual_hosts"] != nil:
+		var rc EnvoyRouteConfig
+		if err := yaml.Unmarshal(data, &rc); err != nil {
+			return nil, fmt.Errorf("gateway: failed to parse Envoy route config: %w", err)
+		}
+		return envoyTable(&rc)
+	default:
+		return nil, fmt.Errorf("gateway: unrecognised routing table (want a Kong declarative config or an Envoy route config)")
+	}
+}
+
+// kongTable orders Kong routes the way its traditional router evaluates
+// them, approximately: regex paths first (higher regex_priority first), then
+// plain prefix paths longest first; routes constrained by method win ties
+// over method-less ones; declaration order breaks the rest.
+func kongTable(cfg *KongConfig) (*RoutingTable, error) {
+	type ranked struct {
+		TableRoute
+		priority int
+		seq      int
+	}
+	var all []ranked
+	for _, svc := range cfg.Services {
+		for _, r := range svc.Routes {
+			paths := r.Paths
+			if len(paths) == 0 {
+				paths = []string{"/"}
+			}
+			for _, p := range paths {
+				tr := TableRoute{Name: r.Name, Target: svc.Name, Methods: upper(r.Methods), Match: MatchPrefix, Path: p}
+				if expr, ok := strings.CutPrefix(p, "~"); ok {
+					if !strings.HasPrefix(expr, "^") {
+						expr = "^" + expr // Kong anchors regex paths at the start
+					}
+					re, err := regexp.Compile(expr)
+					if err != nil {
+						return nil, fmt.Errorf("gateway: route %s: invalid regex path %q: %w", r.Name, p, err)
+					}
+					tr.Match, tr.Path, tr.re = MatchRegex, expr, re
+				}
+				all = append(all, ranked{TableRoute: tr, priority: r.RegexPriority, seq: len(all)})
+			}
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		a, b := all[i], all[j]
+		if (a.Match == MatchRegex) != (b.Match == MatchRegex) {
+			return a.Match == MatchRegex
+		}
+		if a.Match == MatchRegex && a.priority != b.priority {
+			return a.priority > b.priority
+		}
+		if a.Match == MatchPrefix && len(a.Path) != len(b.Path) {
+			return len(a.Path) > len(b.Path)
+		}
+		if (len(a.Methods) > 0) != (len(b.Methods) > 0) {
+			return len(a.Methods) > 0
+		}
+		return a.seq < b.seq
+	})
+	table := &RoutingTable{Kind: KindKong}
+	for _, r := range all {
+		table.Routes = append(table.Routes, r.TableRoute)
+	}
+	return table, nil
+}
+
+// envoyTable keeps Envoy's first-match declaration order across the route
+// config's virtual hosts. Domain matching is not modelled.
+func envoyTable(rc *EnvoyRouteConfig) (*RoutingTable, error) {
+	table := &RoutingTable{Kind: KindEnvoy}
+	for _, vh := range rc.VirtualHosts {
+		for _, r := range vh.Routes {
+			tr := TableRoute{Name: r.Name, Target: r.Action.Cluster}
+			for _, h := range r.Match.Headers {
+				if h.Name == ":method" && h.StringMatch["exact"] != "" {
+					tr.Methods = append(tr.Methods, strings.ToUpper(h.StringMatch["exact"]))
+				}
+			}
+			switch m := r.Match; {
+			case m.SafeRegex != nil:
+				expr := "^(?:" + m.SafeRegex.Regex + ")$" // safe_regex must match the whole path
+				re, err := regexp.Compile(expr)
+				if err != nil {
+					return nil, fmt.Errorf("gateway: route %s: invalid safe_regex %q: %w", r.Name, m.SafeRegex.Regex, err)
+				}
+				tr.Match, tr.Path, tr.re = MatchRegex, m.SafeRegex.Regex, re
+			case m.Path != "":
+				tr.Match, tr.Path = MatchExact, m.Path
+			default:
+				tr.Match, tr.Path = MatchPrefix, m.Prefix
+			}
+			table.Routes = append(table.Routes, tr)
+		}
+	}
+	return table, nil
+}
+
+func upper(methods []string) []string {
+	out := make([]string, len(methods))
+	for i, m := range methods {
+		out[i] = strings.ToUpper(m)
+	}
+	return out
+}
+
+func (r *TableRoute) matchesPath(path string) bool {
+	switch r.Match {
+	case MatchExact:
+		return path == r.Path
+	case MatchRegex:
+		return r.re.MatchString(path)
+	default:
+		return strings.HasPrefix(path, r.Path)
+	}
+}
+
+func (r *TableRoute) matchesMethod(method string) bool {
+	return len(r.Methods) == 0 || slices.Contains(r.Methods, method)
+}
+
+// specificity ranks how narrowly a route targets a path: exact beats regex
+// beats prefix, and a longer literal beats a shorter one of the same kind.
+func (r *TableRoute) specificity() (int, int) {
+	switch r.Match {
+	case MatchExact:
+		return 2, len(r.Path)
+	case MatchRegex:
+		return 1, len(r.Path)
+	default:
+		return 0, len(r.Path)
+	}
+}
+
+func (r *TableRoute) describe() string {
+	return fmt.Sprintf("%s (%s %s -> %s)", r.Name, r.Match, r.Path, r.Target)
+}
+
+// Finding kinds reported by Check.
+const (
+	FindingUnreachable = "unreachable"
+	FindingShadowed    = "shadowed"
+)
+
+// Finding is one service endpoint the gateway would not route as intended.
+type Finding struct {
+	Kind   string
+	Method string
+	Path   string // OpenAPI path of the service endpoint
+	Detail string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%-11s %-7s %s: %s", f.Kind, f.Method, f.Path, f.Detail)
+}
+
+// Report is the outcome of Check.
+type Report struct {
+	Endpoints int // service endpoints checked
+	Findings  []Finding
+}
+
+// Check replays every operation of s against the routing table. An endpoint
+// is unreachable when no route matches its method and path, and shadowed
+// when the route the gateway picks is less specific than another route that
+// also matches it — the narrower route (and whatever upstream or plugins it
+// carries) never sees the traffic. Templated segments are probed with "1".
+func Check(s *spec.OpenAPISpec, table *RoutingTable) *Report {
+	paths := make([]string, 0, len(s.Paths))
+	for p := range s.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	report := &Report{}
+	for _, p := range paths {
+		probe := pathParam.ReplaceAllString(p, "1")
+		for _, mo := range operationsOf(s.Paths[p]) {
+			report.Endpoints++
+			if f, ok := checkEndpoint(table, mo.method, p, probe); ok {
+				report.Findings = append(report.Findings, f)
+			}
+		}
+	}
+	return report
+}
+
+func checkEndpoint(table *RoutingTable, method, path, probe string) (Finding, bool) {
+	var matches []*TableRoute
+	var pathOnly *TableRoute
+	for i := range table.Routes {
+		r := &table.Routes[i]
+		if !r.matchesPath(probe) {
+			continue
+		}
+		if r.matchesMethod(method) {
+			matches = append(matches, r)
+		} else if pathOnly == nil {
+			pathOnly = r
+		}
+	}
+
+	if len(matches) == 0 {
+		detail := "no gateway route matches the path"
+		if pathOnly != nil {
+			detail = fmt.Sprintf("route %s matches the path but not method %s", pathOnly.describe(), method)
+		}
+		return Finding{Kind: FindingUnreachable, Method: method, Path: path, Detail: detail}, true
+	}
+
+	winner, best := matches[0], matches[0]
+	for _, r := range matches[1:] {
+		bk, bl := best.specificity()
+		rk, rl := r.specificity()
+		if rk > bk || (rk == bk && rl > bl) {
+			best = r
+		}
+	}
+	wk, wl := winner.specificity()
+	bk, bl := best.specificity()
+	if bk > wk || (bk == wk && bl > wl) {
+		return Finding{
+			Kind:   FindingShadowed,
+			Method: method,
+			Path:   path,
+			Detail: fmt.Sprintf("route %s takes precedence over more specific route %s", winner.describe(), best.describe()),
+		}, true
+	}
+	return Finding{}, false
+}
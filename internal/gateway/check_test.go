@@ -0,0 +1,123 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestCheck_GeneratedConfigRoundTrips checks that the configs Generate emits
+// route every endpoint of the spec they came from.
+func TestCheck_GeneratedConfigRoundTrips(t *testing.T) {
+	for _, kind := range []string{KindKong, KindEnvoy} {
+		t.Run(kind, func(t *testing.T) {
+			cfg, err := Generate(testSpec(), Options{Kind: kind})
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := yaml.Marshal(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			table, err := ParseRoutingTable(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if table.Kind != kind {
+				t.Errorf("detected kind %q, want %q", table.Kind, kind)
+			}
+			report := Check(testSpec(), table)
+			if report.Endpoints != 3 || len(report.Findings) != 0 {
+				t.Errorf("want 3 clean endpoints, got %d with findings %v", report.Endpoints, report.Findings)
+			}
+		})
+	}
+}
+
+func TestCheck_Kong(t *testing.T) {
+	table, err := ParseRoutingTable([]byte(`
+_format_version: "3.0"
+services:
+  - name: users
+    url: http://users:8080
+    routes:
+      - name: list-users
+        paths: [/users]
+        methods: [GET]
+      - name: get-user
+        paths: ["~/users/(?<id>[^/]+)$"]
+        methods: [GET]
+      - name: users-catchall
+        paths: ["~/users/.*"]
+        regex_priority: 10
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := Check(testSpec(), table)
+	want := map[string]string{
+		"POST /users":     FindingUnreachable,
+		"GET /users/{id}": FindingShadowed,
+	}
+	if len(report.Findings) != len(want) {
+		t.Fatalf("got findings %v, want %v", report.Findings, want)
+	}
+	for _, f := range report.Findings {
+		if want[f.Method+" "+f.Path] != f.Kind {
+			t.Errorf("unexpected finding %v", f)
+		}
+	}
+	if d := report.Findings[0].Detail; !strings.Contains(d, "list-users") || !strings.Contains(d, "method POST") {
+		t.Errorf("unreachable detail should name the path-only match: %q", d)
+	}
+}
+
+func TestCheck_EnvoyFirstMatchWins(t *testing.T) {
+	table, err := ParseRoutingTable([]byte(`
+name: local
+virtual_hosts:
+  - name: all
+    domains: ["*"]
+    routes:
+      - name: legacy
+        match: {prefix: /users}
+        route: {cluster: legacy}
+      - name: get-user
+        match:
+          safe_regex: {regex: "/users/[^/]+"}
+          headers: [{name: ":method", string_match: {exact: GET}}]
+        route: {cluster: users}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := Check(testSpec(), table)
+	if len(report.Findings) != 1 {
+		t.Fatalf("got findings %v, want only GET /users/{id} shadowed", report.Findings)
+	}
+	f := report.Findings[0]
+	if f.Kind != FindingShadowed || f.Path != "/users/{id}" || !strings.Contains(f.Detail, "legacy") {
+		t.Errorf("unexpected finding %v", f)
+	}
+}
+
+func TestParseRoutingTable_Unrecognised(t *testing.T) {
+	if _, err := ParseRoutingTable([]byte("paths: {}\n")); err == nil {
+		t.Fatal("expected an error for a non-gateway document")
+	}
+}
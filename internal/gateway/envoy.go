@@ -0,0 +1,156 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+// EnvoyConfig is an Envoy route-configuration stub: the RouteConfiguration
+// to embed in an http_connection_manager plus the upstream cluster it targets.
+// Listener wiring is deployment-specific and left to the operator.
+type EnvoyConfig struct {
+	RouteConfig EnvoyRouteConfig `yaml:"route_config" json:"route_config"`
+	Clusters    []EnvoyCluster   `yaml:"clusters" json:"clusters"`
+}
+
+// EnvoyRouteConfig mirrors envoy.config.route.v3.RouteConfiguration.
+type EnvoyRouteConfig struct {
+	Name         string             `yaml:"name" json:"name"`
+	VirtualHosts []EnvoyVirtualHost `yaml:"virtual_hosts" json:"virtual_hosts"`
+}
+
+// EnvoyVirtualHost mirrors envoy.config.route.v3.VirtualHost.
+type EnvoyVirtualHost struct {
+	Name    string       `yaml:"name" json:"name"`
+	Domains []string     `yaml:"domains" json:"domains"`
+	Routes  []EnvoyRoute `yaml:"routes" json:"routes"`
+}
+
+// EnvoyRoute mirrors envoy.config.route.v3.Route.
+type EnvoyRoute struct {
+	Name   string           `yaml:"name" json:"name"`
+	Match  EnvoyRouteMatch  `yaml:"match" json:"match"`
+	Action EnvoyRouteAction `yaml:"route" json:"route"`
+}
+
+// EnvoyRouteMatch matches an exact path, or a RE2 regex for templated paths,
+// plus the :method pseudo-header. Prefix is never generated; it is read from
+// hand-written configs by check-gateway.
+type EnvoyRouteMatch struct {
+	Path      string             `yaml:"path,omitempty" json:"path,omitempty"`
+	Prefix    string             `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+	SafeRegex *EnvoyRegex        `yaml:"safe_regex,omitempty" json:"safe_regex,omitempty"`
+	Headers   []EnvoyHeaderMatch `yaml:"headers" json:"headers"`
+}
+
+// EnvoyRegex mirrors envoy.type.matcher.v3.RegexMatcher.
+type EnvoyRegex struct {
+	Regex string `yaml:"regex" json:"regex"`
+}
+
+// EnvoyHeaderMatch matches one request header exactly.
+type EnvoyHeaderMatch struct {
+	Name        string            `yaml:"name" json:"name"`
+	StringMatch map[string]string `yaml:"string_match" json:"string_match"`
+}
+
+// EnvoyRouteAction forwards to the cluster with the route's timeout and
+// retry policy.
+type EnvoyRouteAction struct {
+	Cluster     string            `yaml:"cluster" json:"cluster"`
+	Timeout     string            `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	RetryPolicy *EnvoyRetryPolicy `yaml:"retry_policy,omitempty" json:"retry_policy,omitempty"`
+}
+
+// EnvoyRetryPolicy mirrors envoy.config.route.v3.RetryPolicy.
+type EnvoyRetryPolicy struct {
+	RetryOn    string `yaml:"retry_on" json:"retry_on"`
+	NumRetries int    `yaml:"num_retries" json:"num_retries"`
+}
+
+// EnvoyCluster is a minimal STRICT_DNS cluster for the upstream.
+type EnvoyCluster struct {
+	Name           string              `yaml:"name" json:"name"`
+	Type           string              `yaml:"type" json:"type"`
+	ConnectTimeout string              `yaml:"connect_timeout" json:"connect_timeout"`
+	LoadAssignment EnvoyLoadAssignment `yaml:"load_assignment" json:"load_assignment"`
+}
+
+// EnvoyLoadAssignment lists the cluster's single upstream endpoint.
+type EnvoyLoadAssignment struct {
+	ClusterName string                `yaml:"cluster_name" json:"cluster_name"`
+	Endpoints   []EnvoyLocalityLbEnds `yaml:"endpoints" json:"endpoints"`
+}
+
+// EnvoyLocalityLbEnds mirrors envoy.config.endpoint.v3.LocalityLbEndpoints.
+type EnvoyLocalityLbEnds struct {
+	LbEndpoints []EnvoyLbEndpoint `yaml:"lb_endpoints" json:"lb_endpoints"`
+}
+
+// EnvoyLbEndpoint wraps one socket address.
+type EnvoyLbEndpoint struct {
+	Endpoint struct {
+		Address struct {
+			SocketAddress EnvoySocketAddress `yaml:"socket_address" json:"socket_address"`
+		} `yaml:"address" json:"address"`
+	} `yaml:"endpoint" json:"endpoint"`
+}
+
+// EnvoySocketAddress is a host:port pair.
+type EnvoySocketAddress struct {
+	Address   string `yaml:"address" json:"address"`
+	PortValue int    `yaml:"port_value" json:"port_value"`
+}
+
+func envoyConfig(routes []Route, opts Options) (*EnvoyConfig, error) {
+	host, port, err := upstreamHostPort(opts.Upstream)
+	if err != nil {
+		return nil, err
+	}
+	vh := EnvoyVirtualHost{Name: opts.Name, Domains: []string{"*"}}
+	for _, r := range routes {
+		er := EnvoyRoute{
+			Name: r.Name,
+			Match: EnvoyRouteMatch{
+				Headers: []EnvoyHeaderMatch{{Name: ":method", StringMatch: map[string]string{"exact": r.Method}}},
+			},
+			Action: EnvoyRouteAction{Cluster: opts.Name},
+		}
+		if isTemplated(r.Path) {
+			er.Match.SafeRegex = &EnvoyRegex{Regex: pathRegex(r.Path, false)}
+		} else {
+			er.Match.Path = r.Path
+		}
+		if r.Timeout > 0 {
+			er.Action.Timeout = r.Timeout.String()
+		}
+		if r.Retries > 0 {
+			er.Action.RetryPolicy = &EnvoyRetryPolicy{RetryOn: "5xx,connect-failure,reset", NumRetries: r.Retries}
+		}
+		vh.Routes = append(vh.Routes, er)
+	}
+
+	var ep EnvoyLbEndpoint
+	ep.Endpoint.Address.SocketAddress = EnvoySocketAddress{Address: host, PortValue: port}
+	return &EnvoyConfig{
+		RouteConfig: EnvoyRouteConfig{Name: opts.Name, VirtualHosts: []EnvoyVirtualHost{vh}},
+		Clusters: []EnvoyCluster{{
+			Name:           opts.Name,
+			Type:           "STRICT_DNS",
+			ConnectTimeout: "5s",
+			LoadAssignment: EnvoyLoadAssignment{
+				ClusterName: opts.Name,
+				Endpoints:   []EnvoyLocalityLbEnds{{LbEndpoints: []EnvoyLbEndpoint{ep}}},
+			},
+		}},
+	}, nil
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gateway projects a generated OpenAPI spec onto API-gateway route
+// configuration stubs (Kong declarative config, Envoy route config). It reads
+// only the spec — paths, methods, operationIds and the x-timeout / x-retries
+// hints — so any consumer of the extraction pipeline can reuse the same
+// route model for infrastructure automation.
+package gateway
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+// Supported gateway kinds.
+const (
+	KindKong  = "kong"
+	KindEnvoy = "envoy"
+)
+
+// Options controls gateway config generation.
+type Options struct {
+	Kind     string        // KindKong or KindEnvoy
+	Name     string        // service/cluster name; derived from the spec title when empty
+	Upstream string        // upstream base URL, e.g. http://localhost:8080
+	Timeout  time.Duration // default per-route timeout; 0 leaves the gateway default
+	Retries  int           // default per-route retry count; 0 leaves the gateway default
+}
+
+// Route is one (method, path) operation projected from the spec, with its
+// effective timeout and retry policy.
+type Route struct {
+	Name    string
+	Method  string
+	Path    string // OpenAPI path template, e.g. /users/{id}
+	Timeout time.Duration
+	Retries int
+}
+
+// Generate builds the gateway configuration document for s. The result is a
+// plain value tree (structs with yaml/json tags) ready for either encoder.
+func Generate(s *spec.OpenAPISpec, opts Options) (interface{}, error) {
+	if s == nil {
+		return nil, fmt.Errorf("gateway: nil spec")
+	}
+	if opts.Upstream == "" {
+		opts.Upstream = "http://localhost:8080"
+	}
+	if opts.Name == "" {
+		opts.Name = slug(s.Info.Title)
+	}
+	routes, err := Routes(s, opts)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(opts.Kind) {
+	case "", KindKong:
+		return kongConfig(routes, opts)
+	case KindEnvoy:
+		return envoyConfig(routes, opts)
+	default:
+		return nil, fmt.Errorf("gateway: unknown kind %q (want %s|%s)", opts.Kind, KindKong, KindEnvoy)
+	}
+}
+
+// Routes flattens the spec's operations into gateway routes, sorted by path
+// then method so the output is deterministic. An operation's x-timeout /
+// x-retries override the defaults in opts.
+func Routes(s *spec.OpenAPISpec, opts Options) ([]Route, error) {
+	paths := make([]string, 0, len(s.Paths))
+	for p := range s.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var routes []Route
+	names := map[string]int{}
+	for _, p := range paths {
+		for _, mo := range operationsOf(s.Paths[p]) {
+			r := Route{
+				Method:  mo.method,
+				Path:    p,
+				Timeout: opts.Timeout,
+				Retries: opts.Retries,
+			}
+			if v, ok := mo.op.Extensions["x-timeout"]; ok {
+				d, err := time.ParseDuration(fmt.Sprint(v))
+				if err != nil {
+					return nil, fmt.Errorf("gateway: %s %s: invalid x-timeout %v: %w", mo.method, p, v, err)
+				}
+				r.Timeout = d
+			}
+			if v, ok := mo.op.Extensions["x-retries"]; ok {
+				n, err := strconv.Atoi(fmt.Sprint(v))
+				if err != nil {
+					return nil, fmt.Errorf("gateway: %s %s: invalid x-retries %v: %w", mo.method, p, v, err)
+				}
+				r.Retries = n
+			}
+			r.Name = uniqueName(names, routeName(mo.op.OperationID, mo.method, p))
+			routes = append(routes, r)
+		}
+	}
+	return routes, nil
+}
+
+type methodOp struct {
+	method string
+	op     *spec.Operation
+}
+
+// operationsOf returns the (method, operation) pairs declared on a path in a
+// fixed method order.
+func operationsOf(pi spec.PathItem) []methodOp {
+	var out []methodOp
+	add := func(m string, op *spec.Operation) {
+		if op != nil {
+			out = append(out, methodOp{m, op})
+		}
+	}
+	add("GET", pi.Get)
+	add("POST", pi.Post)
+	add("PUT", pi.Put)
+	add("DELETE", pi.Delete)
+	add("PATCH", pi.Patch)
+	add("OPTIONS", pi.Options)
+	add("HEAD", pi.Head)
+	return out
+}
+
+var nonNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// slug reduces s to the [a-z0-9-] alphabet gateway object names accept.
+func slug(s string) string {
+	s = strings.Trim(nonNameChars.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if s == "" {
+		return "api"
+	}
+	return s
+}
+
+// routeName names a route after its operationId with the package path
+// dropped ("pkg/handlers.User.Get" -> "user-get"), falling back to
+// method+path.
+func routeName(operationID, method, path string) string {
+	if operationID != "" {
+		if i := strings.LastIndex(operationID, "/"); i >= 0 {
+			operationID = operationID[i+1:]
+		}
+		if i := strings.Index(operationID, "."); i >= 0 && i < len(operationID)-1 {
+			operationID = operationID[i+1:]
+		}
+		return slug(operationID)
+	}
+	return slug(method + "-" + path)
+}
+
+// uniqueName suffixes repeated names (-2, -3, ...) in encounter order.
+func uniqueName(seen map[string]int, name string) string {
+	seen[name]++
+	if n := seen[name]; n > 1 {
+		return fmt.Sprintf("%s-%d", name, n)
+	}
+	return name
+}
+
+var pathParam = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// isTemplated reports whether an OpenAPI path has {param} segments.
+func isTemplated(path string) bool {
+	return pathParam.MatchString(path)
+}
+
+// pathRegex renders an OpenAPI path template as an anchored regex, one
+// segment per parameter. named selects PCRE named groups (Kong) over plain
+// groups (Envoy's RE2 safe_regex).
+func pathRegex(path string, named bool) string {
+	var b strings.Builder
+	b.WriteString("^")
+	last := 0
+	for _, m := range pathParam.FindAllStringSubmatchIndex(path, -1) {
+		b.WriteString(regexp.QuoteMeta(path[last:m[0]]))
+		if named {
+			b.WriteString("(?<" + path[m[2]:m[3]] + ">[^/]+)")
+		} else {
+			b.WriteString("[^/]+")
+		}
+		last = m[1]
+	}
+	b.WriteString(regexp.QuoteMeta(path[last:]))
+	b.WriteString("$")
+	return b.String()
+}
+
+// upstreamHostPort splits the upstream URL into host and port, defaulting the
+// port from the scheme.
+func upstreamHostPort(upstream string) (string, int, error) {
+	u, err := url.Parse(upstream)
+	if err != nil || u.Host == "" {
+		return "", 0, fmt.Errorf("gateway: invalid upstream URL %q", upstream)
+	}
+	port := 80
+	if u.Scheme == "https" {
+		port = 443
+	}
+	if p := u.Port(); p != "" {
+		if port, err = strconv.Atoi(p); err != nil {
+			return "", 0, fmt.Errorf("gateway: invalid upstream port %q", p)
+		}
+	}
+	return u.Hostname(), port, nil
+}
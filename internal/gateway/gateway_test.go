@@ -0,0 +1,121 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+func testSpec() *spec.OpenAPISpec {
+	return &spec.OpenAPISpec{
+		Info: spec.Info{Title: "Users API"},
+		Paths: map[string]spec.PathItem{
+			"/users": {
+				Get:  &spec.Operation{OperationID: "example.com/svc/handlers.UserHandler.List"},
+				Post: &spec.Operation{OperationID: "example.com/svc/handlers.UserHandler.Create"},
+			},
+			"/users/{id}": {
+				Get: &spec.Operation{
+					OperationID: "example.com/svc/handlers.UserHandler.Get",
+					Extensions:  map[string]interface{}{"x-timeout": "30s", "x-retries": 2},
+				},
+			},
+		},
+	}
+}
+
+func TestRoutes_SortedWithOverrides(t *testing.T) {
+	routes, err := Routes(testSpec(), Options{Timeout: 5 * time.Second, Retries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Route{
+		{Name: "userhandler-list", Method: "GET", Path: "/users", Timeout: 5 * time.Second, Retries: 1},
+		{Name: "userhandler-create", Method: "POST", Path: "/users", Timeout: 5 * time.Second, Retries: 1},
+		{Name: "userhandler-get", Method: "GET", Path: "/users/{id}", Timeout: 30 * time.Second, Retries: 2},
+	}
+	if len(routes) != len(want) {
+		t.Fatalf("got %d routes, want %d: %+v", len(routes), len(want), routes)
+	}
+	for i := range want {
+		if routes[i] != want[i] {
+			t.Errorf("route %d = %+v, want %+v", i, routes[i], want[i])
+		}
+	}
+}
+
+func TestRoutes_InvalidTimeout(t *testing.T) {
+	s := testSpec()
+	s.Paths["/users/{id}"].Get.Extensions["x-timeout"] = "soon"
+	if _, err := Routes(s, Options{}); err == nil {
+		t.Fatal("expected an error for an unparsable x-timeout")
+	}
+}
+
+func TestGenerate_Kong(t *testing.T) {
+	out, err := Generate(testSpec(), Options{Kind: KindKong, Upstream: "http://users:8080"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := out.(*KongConfig)
+	if cfg.FormatVersion != "3.0" || len(cfg.Services) != 2 {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	base, dedicated := cfg.Services[0], cfg.Services[1]
+	if base.Name != "users-api" || len(base.Routes) != 2 || base.ReadTimeout != 0 {
+		t.Errorf("base service = %+v", base)
+	}
+	if dedicated.Name != "users-api-userhandler-get" || dedicated.ReadTimeout != 30000 || dedicated.Retries != 2 {
+		t.Errorf("dedicated service = %+v", dedicated)
+	}
+	if got := dedicated.Routes[0].Paths[0]; got != "~^/users/(?<id>[^/]+)$" {
+		t.Errorf("templated path = %q", got)
+	}
+}
+
+func TestGenerate_Envoy(t *testing.T) {
+	out, err := Generate(testSpec(), Options{Kind: KindEnvoy, Upstream: "https://users.internal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := out.(*EnvoyConfig)
+	routes := cfg.RouteConfig.VirtualHosts[0].Routes
+	if len(routes) != 3 {
+		t.Fatalf("got %d routes, want 3", len(routes))
+	}
+	if routes[0].Match.Path != "/users" || routes[0].Match.Headers[0].StringMatch["exact"] != "GET" {
+		t.Errorf("route 0 match = %+v", routes[0].Match)
+	}
+	get := routes[2]
+	if get.Match.SafeRegex == nil || get.Match.SafeRegex.Regex != "^/users/[^/]+$" {
+		t.Errorf("templated match = %+v", get.Match)
+	}
+	if get.Action.Timeout != "30s" || get.Action.RetryPolicy == nil || get.Action.RetryPolicy.NumRetries != 2 {
+		t.Errorf("route action = %+v", get.Action)
+	}
+	addr := cfg.Clusters[0].LoadAssignment.Endpoints[0].LbEndpoints[0].Endpoint.Address.SocketAddress
+	if addr.Address != "users.internal" || addr.PortValue != 443 {
+		t.Errorf("cluster address = %+v", addr)
+	}
+}
+
+func TestGenerate_UnknownKind(t *testing.T) {
+	if _, err := Generate(testSpec(), Options{Kind: "nginx"}); err == nil {
+		t.Fatal("expected an error for an unknown gateway kind")
+	}
+}
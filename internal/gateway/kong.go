@@ -0,0 +1,81 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import "time"
+
+// KongConfig is a Kong declarative configuration (decK / DB-less format).
+type KongConfig struct {
+	FormatVersion string        `yaml:"_format_version" json:"_format_version"`
+	Services      []KongService `yaml:"services" json:"services"`
+}
+
+// KongService is one upstream service with its routes. Kong scopes timeouts
+// and retries to the service, so routes with their own policy get their own
+// service pointing at the same upstream.
+type KongService struct {
+	Name           string      `yaml:"name" json:"name"`
+	URL            string      `yaml:"url" json:"url"`
+	Retries        int         `yaml:"retries,omitempty" json:"retries,omitempty"`
+	ConnectTimeout int64       `yaml:"connect_timeout,omitempty" json:"connect_timeout,omitempty"`
+	ReadTimeout    int64       `yaml:"read_timeout,omitempty" json:"read_timeout,omitempty"`
+	WriteTimeout   int64       `yaml:"write_timeout,omitempty" json:"write_timeout,omitempty"`
+	Routes         []KongRoute `yaml:"routes" json:"routes"`
+}
+
+// KongRoute matches one method on one path. Templated paths use Kong's "~"
+// regex-path prefix with named captures.
+type KongRoute struct {
+	Name          string   `yaml:"name" json:"name"`
+	Paths         []string `yaml:"paths" json:"paths"`
+	Methods       []string `yaml:"methods" json:"methods"`
+	StripPath     bool     `yaml:"strip_path" json:"strip_path"`
+	RegexPriority int      `yaml:"regex_priority,omitempty" json:"regex_priority,omitempty"`
+}
+
+func kongConfig(routes []Route, opts Options) (*KongConfig, error) {
+	if _, _, err := upstreamHostPort(opts.Upstream); err != nil {
+		return nil, err
+	}
+	base := kongService(opts.Name, opts.Upstream, opts.Timeout, opts.Retries)
+	out := &KongConfig{FormatVersion: "3.0"}
+	var dedicated []KongService
+	for _, r := range routes {
+		kr := KongRoute{Name: r.Name, Paths: []string{r.Path}, Methods: []string{r.Method}}
+		if isTemplated(r.Path) {
+			kr.Paths = []string{"~" + pathRegex(r.Path, true)}
+		}
+		if r.Timeout == opts.Timeout && r.Retries == opts.Retries {
+			base.Routes = append(base.Routes, kr)
+			continue
+		}
+		svc := kongService(opts.Name+"-"+r.Name, opts.Upstream, r.Timeout, r.Retries)
+		svc.Routes = []KongRoute{kr}
+		dedicated = append(dedicated, svc)
+	}
+	if len(base.Routes) > 0 {
+		out.Services = append(out.Services, base)
+	}
+	out.Services = append(out.Services, dedicated...)
+	return out, nil
+}
+
+func kongService(name, upstream string, timeout time.Duration, retries int) KongService {
+	svc := KongService{Name: name, URL: upstream, Retries: retries}
+	if ms := timeout.Milliseconds(); ms > 0 {
+		svc.ConnectTimeout, svc.ReadTimeout, svc.WriteTimeout = ms, ms, ms
+	}
+	return svc
+}
@@ -137,7 +137,7 @@ func classifyPkg(meta *metadata.Metadata, pkg, recv string) string {
 	if pkg == "" {
 		return "standard" // universe scope (builtins)
 	}
-	if mp := meta.CurrentModulePath; mp != "" && (pkg == mp || strings.HasPrefix(pkg, mp+"/")) {
+	if meta.IsProjectPackage(pkg) {
 		return "project"
 	}
 	first := pkg
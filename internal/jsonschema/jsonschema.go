@@ -0,0 +1,192 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonschema exports a generated spec's component schemas as
+// standalone JSON Schema (draft 2020-12) documents, one file per component,
+// so they can validate messages outside OpenAPI. Component $refs become
+// relative cross-file references resolved against each document's $id.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+// Draft is the $schema URI of every exported document.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+const componentRefPrefix = "#/components/schemas/"
+
+// Options controls the export.
+type Options struct {
+	// BaseID prefixes each document's $id (e.g. "https://example.com/schemas/").
+	// Empty leaves $id as the bare file name, which keeps the cross-file refs
+	// resolvable relative to wherever the files are served from.
+	BaseID string
+}
+
+// FileName is the file a component schema is exported to.
+func FileName(component string) string {
+	return component + spec.SchemaFileSuffix
+}
+
+// Export converts every component schema of s into a standalone document,
+// keyed by FileName.
+func Export(s *spec.OpenAPISpec, opts Options) (map[string][]byte, error) {
+	if s == nil || s.Components == nil {
+		return map[string][]byte{}, nil
+	}
+	out := make(map[string][]byte, len(s.Components.Schemas))
+	for name, schema := range s.Components.Schemas {
+		if schema == nil {
+			continue
+		}
+		raw, err := json.Marshal(schema)
+		if err != nil {
+			return nil, fmt.Errorf("jsonschema: %s: %w", name, err)
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return nil, fmt.Errorf("jsonschema: %s: %w", name, err)
+		}
+		convert(doc)
+		doc["$schema"] = Draft
+		doc["$id"] = spec.ComponentSchemaID(opts.BaseID, name)
+		if _, ok := doc["title"]; !ok {
+			doc["title"] = name
+		}
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("jsonschema: %s: %w", name, err)
+		}
+		out[FileName(name)] = append(data, '\n')
+	}
+	return out, nil
+}
+
+// WriteDir exports s into dir (created if missing) and returns the written
+// file paths, sorted.
+func WriteDir(s *spec.OpenAPISpec, dir string, opts Options) ([]string, error) {
+	docs, err := Export(s, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("jsonschema: failed to create %s: %w", dir, err)
+	}
+	names := make([]string, 0, len(docs))
+	for name := range docs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, docs[name], 0644); err != nil {
+			return nil, fmt.Errorf("jsonschema: failed to write %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// convert rewrites an OpenAPI schema object (decoded from JSON) into JSON
+// Schema 2020-12 in place:
+//   - component $refs become relative file references;
+//   - boolean exclusiveMinimum/exclusiveMaximum (OpenAPI 3.0) become the
+//     numeric 2020-12 form, taking the bound from minimum/maximum;
+//   - example becomes examples;
+//   - OpenAPI-only vocabulary (discriminator, xml, externalDocs) is dropped.
+//
+// Only subschema positions are descended into, so a property that happens to
+// be named "example" or "$ref" is left alone.
+func convert(node map[string]interface{}) {
+	if ref, ok := node["$ref"].(string); ok && strings.HasPrefix(ref, componentRefPrefix) {
+		node["$ref"] = FileName(strings.TrimPrefix(ref, componentRefPrefix))
+	}
+	exclusiveBound(node, "exclusiveMinimum", "minimum")
+	exclusiveBound(node, "exclusiveMaximum", "maximum")
+	nullableType(node)
+	if ex, ok := node["example"]; ok {
+		delete(node, "example")
+		node["examples"] = []interface{}{ex}
+	}
+	delete(node, "discriminator")
+	delete(node, "xml")
+	delete(node, "externalDocs")
+
+	for _, key := range []string{"items", "additionalProperties", "not"} {
+		if sub, ok := node[key].(map[string]interface{}); ok {
+			convert(sub)
+		}
+	}
+	for _, key := range []string{"allOf", "oneOf", "anyOf"} {
+		list, _ := node[key].([]interface{})
+		for _, item := range list {
+			if sub, ok := item.(map[string]interface{}); ok {
+				convert(sub)
+			}
+		}
+	}
+	props, _ := node["properties"].(map[string]interface{})
+	for _, prop := range props {
+		if sub, ok := prop.(map[string]interface{}); ok {
+			convert(sub)
+		}
+	}
+}
+
+// nullableType turns OpenAPI 3.0's nullable: true into a null type: a
+// type array, or an anyOf for the allOf wrapping a nullable $ref.
+func nullableType(node map[string]interface{}) {
+	nullable, _ := node["nullable"].(bool)
+	delete(node, "nullable")
+	if !nullable {
+		return
+	}
+	if typ, ok := node["type"].(string); ok {
+		node["type"] = []interface{}{typ, "null"}
+		return
+	}
+	if allOf, ok := node["allOf"].([]interface{}); ok && len(allOf) == 1 {
+		delete(node, "allOf")
+		node["anyOf"] = []interface{}{allOf[0], map[string]interface{}{"type": "null"}}
+	}
+}
+
+// exclusiveBound turns {exclusive: true, bound: n} into {exclusive: n}, and
+// leaves a numeric exclusive bound alone. An omitted bound is 0, which
+// generators before the numeric bounds dropped via omitempty.
+func exclusiveBound(node map[string]interface{}, exclusive, bound string) {
+	flag, ok := node[exclusive].(bool)
+	if !ok {
+		return
+	}
+	delete(node, exclusive)
+	if !flag {
+		return
+	}
+	value, ok := node[bound]
+	if !ok {
+		value = 0.0
+	}
+	delete(node, bound)
+	node[exclusive] = value
+}
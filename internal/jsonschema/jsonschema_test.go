@@ -0,0 +1,154 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+func testSpec() *spec.OpenAPISpec {
+	return &spec.OpenAPISpec{Components: &spec.Components{Schemas: map[string]*spec.Schema{
+		"Order": {
+			Type:     "object",
+			Required: []string{"customer"},
+			Properties: map[string]*spec.Schema{
+				"customer": {Ref: "#/components/schemas/Customer"},
+				"lines":    {Type: "array", Items: &spec.Schema{Ref: "#/components/schemas/Line"}},
+				"total":    {Type: "number", ExclusiveMinimum: float64Ptr(0), Example: 9.5},
+				// A property named like a keyword must survive untouched.
+				"example": {Type: "string", Enum: []interface{}{"a", "b"}},
+			},
+			Discriminator: &spec.Discriminator{PropertyName: "kind"},
+		},
+		"Customer": {Type: "object", Title: "A customer"},
+		"Line": {Type: "object", Properties: map[string]*spec.Schema{
+			"qty":  {Type: "integer", ExclusiveMaximum: float64Ptr(10)},
+			"note": {Type: "string", Nullable: true},
+			"sku":  {AllOf: []*spec.Schema{{Ref: "#/components/schemas/Customer"}}, Nullable: true},
+		}},
+	}}}
+}
+
+func decode(t *testing.T, data []byte) map[string]interface{} {
+	t.Helper()
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func TestExport_Draft2020Documents(t *testing.T) {
+	docs, err := Export(testSpec(), Options{BaseID: "https://example.com/schemas"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 3 {
+		t.Fatalf("got %d documents, want 3", len(docs))
+	}
+
+	order := decode(t, docs["Order.schema.json"])
+	if order["$schema"] != Draft || order["$id"] != "https://example.com/schemas/Order.schema.json" {
+		t.Errorf("$schema/$id = %v / %v", order["$schema"], order["$id"])
+	}
+	if order["title"] != "Order" {
+		t.Errorf("title = %v, want the component name", order["title"])
+	}
+	if _, ok := order["discriminator"]; ok {
+		t.Error("OpenAPI-only discriminator should be dropped")
+	}
+
+	props := order["properties"].(map[string]interface{})
+	if ref := props["customer"].(map[string]interface{})["$ref"]; ref != "Customer.schema.json" {
+		t.Errorf("customer $ref = %v", ref)
+	}
+	items := props["lines"].(map[string]interface{})["items"].(map[string]interface{})
+	if items["$ref"] != "Line.schema.json" {
+		t.Errorf("lines.items $ref = %v", items["$ref"])
+	}
+	total := props["total"].(map[string]interface{})
+	if total["exclusiveMinimum"] != 0.0 || total["minimum"] != nil {
+		t.Errorf("total bounds = %v", total)
+	}
+	if ex, ok := total["examples"].([]interface{}); !ok || len(ex) != 1 || ex[0] != 9.5 {
+		t.Errorf("total examples = %v", total["examples"])
+	}
+	if _, ok := props["example"]; !ok {
+		t.Error(`property named "example" was rewritten`)
+	}
+
+	if title := decode(t, docs["Customer.schema.json"])["title"]; title != "A customer" {
+		t.Errorf("explicit title overwritten: %v", title)
+	}
+	lineProps := decode(t, docs["Line.schema.json"])["properties"].(map[string]interface{})
+	qty := lineProps["qty"].(map[string]interface{})
+	if qty["exclusiveMaximum"] != 10.0 || qty["maximum"] != nil {
+		t.Errorf("qty bounds = %v", qty)
+	}
+	note, _ := json.Marshal(lineProps["note"])
+	if string(note) != `{"type":["string","null"]}` {
+		t.Errorf("nullable note = %s", note)
+	}
+	sku, _ := json.Marshal(lineProps["sku"])
+	if string(sku) != `{"anyOf":[{"$ref":"Customer.schema.json"},{"type":"null"}]}` {
+		t.Errorf("nullable sku = %s", sku)
+	}
+}
+
+// TestConvert_BooleanBounds pins that a 3.0 document's boolean exclusive
+// bounds become the numeric 2020-12 form.
+func TestConvert_BooleanBounds(t *testing.T) {
+	node := map[string]interface{}{"type": "number", "minimum": 0.5, "exclusiveMinimum": true, "maximum": 5.0, "exclusiveMaximum": false}
+	convert(node)
+	if node["exclusiveMinimum"] != 0.5 || node["minimum"] != nil || node["maximum"] != 5.0 || node["exclusiveMaximum"] != nil {
+		t.Errorf("bounds = %v", node)
+	}
+	zero := map[string]interface{}{"type": "integer", "exclusiveMaximum": true}
+	convert(zero)
+	if zero["exclusiveMaximum"] != 0.0 {
+		t.Errorf("an omitted maximum is 0; bounds = %v", zero)
+	}
+}
+
+func TestWriteDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "schemas")
+	paths, err := WriteDir(testSpec(), dir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Customer.schema.json", "Line.schema.json", "Order.schema.json"}
+	if len(paths) != len(want) {
+		t.Fatalf("wrote %v, want %v", paths, want)
+	}
+	for i, p := range paths {
+		if filepath.Base(p) != want[i] {
+			t.Errorf("path %d = %s, want %s", i, p, want[i])
+		}
+	}
+	data, err := os.ReadFile(paths[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := decode(t, data)["$id"]; id != "Order.schema.json" {
+		t.Errorf("$id without base = %v", id)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint scores a generated OpenAPI spec against style rules named as
+// Spectral names its own (operation-description, operation-tags, ...) and
+// reports the findings as SARIF or JSON for CI annotation. A finding points
+// at the Go declaration the operation or schema was generated from, so the
+// annotation lands on the handler or type to change.
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+// Rule ids.
+const (
+	RuleOperationDescription = "operation-description"
+	RuleOperation4xxResponse = "operation-4xx-response"
+	RuleOperationTags        = "operation-tags"
+	RulePropertyCasing       = "property-casing"
+)
+
+// Rule is a style rule Run checks.
+type Rule struct {
+	ID          string
+	Description string
+}
+
+// Rules lists the rules Run checks, in the order it checks them.
+var Rules = []Rule{
+	{RuleOperationDescription, "Operations have a summary or description: document the handler with a Go doc comment."},
+	{RuleOperation4xxResponse, "Operations document at least one 4xx response."},
+	{RuleOperationTags, "Operations have at least one tag: register the route under a router group or mount."},
+	{RulePropertyCasing, "Schema properties do not mix snake_case and camelCase names."},
+}
+
+// Finding is one violation of a rule.
+type Finding struct {
+	Rule     string `json:"rule"`
+	Location string `json:"location"` // as spec.SpecIssue names it: "GET /users/{id}", "components.schemas.User"
+	Message  string `json:"message"`
+	// File and Line locate the Go declaration of the operation or schema,
+	// File relative to the module root; empty when it is not known.
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Function string `json:"function,omitempty"`
+}
+
+func (f Finding) String() string {
+	s := fmt.Sprintf("%s: %s [%s]", f.Location, f.Message, f.Rule)
+	if f.File != "" {
+		s = fmt.Sprintf("%s:%d: %s", f.File, f.Line, s)
+	}
+	return s
+}
+
+// Report is the outcome of Run. Every rule applied to an operation or schema
+// is a check; Score is the percentage of checks that passed.
+type Report struct {
+	Score    int       `json:"score"`
+	Checks   int       `json:"checks"`
+	Passed   int       `json:"passed"`
+	Findings []Finding `json:"findings"`
+}
+
+// status4xx matches a client error response key: "404" or the "4XX" range.
+var status4xx = regexp.MustCompile(`^4(\d\d|XX)$`)
+
+// Run checks s against Rules, locating the findings through sources (see
+// engine.Engine.GetSources). Findings are in path and method order, then
+// component order.
+func Run(s *spec.OpenAPISpec, sources map[string]spec.SourceLocation) Report {
+	l := &linter{sources: sources, report: Report{Findings: []Finding{}}}
+	if s != nil {
+		for _, path := range slices.Sorted(maps.Keys(s.Paths)) {
+			for _, mo := range s.Paths[path].Operations() {
+				l.operation(mo.Method+" "+path, mo.Method, mo.Operation)
+			}
+		}
+		if s.Components != nil {
+			for _, name := range slices.Sorted(maps.Keys(s.Components.Schemas)) {
+				l.schema("components.schemas."+name, s.Components.Schemas[name])
+			}
+		}
+	}
+	l.report.Score = 100
+	if l.report.Checks > 0 {
+		l.report.Score = l.report.Passed * 100 / l.report.Checks
+	}
+	return l.report
+}
+
+type linter struct {
+	sources map[string]spec.SourceLocation
+	report  Report
+}
+
+// check counts one check of rule at location, a finding when it failed.
+func (l *linter) check(rule, location string, passed bool, format string, args ...any) {
+	l.report.Checks++
+	if passed {
+		l.report.Passed++
+		return
+	}
+	src := l.sources[location]
+	l.report.Findings = append(l.report.Findings, Finding{
+		Rule:     rule,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+		File:     src.File,
+		Line:     src.Line,
+		Function: src.Function,
+	})
+}
+
+func (l *linter) operation(location, method string, op *spec.Operation) {
+	l.check(RuleOperationDescription, location, op.Summary != "" || op.Description != "",
+		"operation has no summary or description")
+	// A CORS preflight answers the browser, not the client.
+	if method != "OPTIONS" {
+		l.check(RuleOperation4xxResponse, location, has4xxResponse(op), "operation documents no 4xx response")
+	}
+	l.check(RuleOperationTags, location, len(op.Tags) > 0, "operation has no tags")
+}
+
+func has4xxResponse(op *spec.Operation) bool {
+	for status := range op.Responses {
+		if status4xx.MatchString(status) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *linter) schema(location string, s *spec.Schema) {
+	if s == nil || len(s.Properties) == 0 {
+		return
+	}
+	var snake, camel string
+	for _, name := range slices.Sorted(maps.Keys(s.Properties)) {
+		switch {
+		case snake == "" && isSnakeCase(name):
+			snake = name
+		case camel == "" && isCamelCase(name):
+			camel = name
+		}
+	}
+	l.check(RulePropertyCasing, location, snake == "" || camel == "",
+		"properties mix snake_case (%s) and camelCase (%s)", snake, camel)
+}
+
+// isSnakeCase reports whether name joins lower-case words with underscores:
+// created_at, not _id or ID_FIELD.
+func isSnakeCase(name string) bool {
+	return strings.Contains(strings.Trim(name, "_"), "_") && strings.ToLower(name) == name
+}
+
+// isCamelCase reports whether name starts lower-case and capitalizes a later
+// word: createdAt.
+func isCamelCase(name string) bool {
+	if name == "" || strings.Contains(name, "_") || strings.ToLower(name[:1]) != name[:1] {
+		return false
+	}
+	return strings.ToLower(name) != name
+}
@@ -0,0 +1,178 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/sarif"
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+func testSpec() *spec.OpenAPISpec {
+	ok := spec.Response{Description: "OK"}
+	return &spec.OpenAPISpec{
+		Paths: map[string]spec.PathItem{
+			"/users/{id}": {
+				Get: &spec.Operation{
+					Summary:   "Get a user",
+					Tags:      []string{"users"},
+					Responses: map[string]spec.Response{"200": ok, "404": ok},
+				},
+				Delete: &spec.Operation{
+					Description: "Deletes a user.",
+					Tags:        []string{"users"},
+					Responses:   map[string]spec.Response{"204": ok, "4XX": ok},
+				},
+				Options: &spec.Operation{
+					Summary:   "CORS preflight",
+					Tags:      []string{"users"},
+					Responses: map[string]spec.Response{"204": ok},
+				},
+			},
+			"/health": {
+				Get: &spec.Operation{Responses: map[string]spec.Response{"200": ok, "default": ok}},
+			},
+		},
+		Components: &spec.Components{Schemas: map[string]*spec.Schema{
+			"User": {Type: "object", Properties: map[string]*spec.Schema{
+				"id": {Type: "string"}, "firstName": {Type: "string"}, "last_name": {Type: "string"},
+			}},
+			"Profile": {Type: "object", Properties: map[string]*spec.Schema{
+				"displayName": {Type: "string"}, "_id": {Type: "string"}, "URL": {Type: "string"},
+			}},
+			"Page": {Ref: "#/components/schemas/User"},
+		}},
+	}
+}
+
+func TestRun(t *testing.T) {
+	sources := map[string]spec.SourceLocation{
+		"GET /health":             {File: "main.go", Line: 12, Function: "example.com/api.health"},
+		"components.schemas.User": {File: "users/user.go", Line: 5, Function: "example.com/api/users.User"},
+	}
+	report := Run(testSpec(), sources)
+
+	want := []Finding{
+		{Rule: RuleOperationDescription, Location: "GET /health", Message: "operation has no summary or description", File: "main.go", Line: 12, Function: "example.com/api.health"},
+		{Rule: RuleOperation4xxResponse, Location: "GET /health", Message: "operation documents no 4xx response", File: "main.go", Line: 12, Function: "example.com/api.health"},
+		{Rule: RuleOperationTags, Location: "GET /health", Message: "operation has no tags", File: "main.go", Line: 12, Function: "example.com/api.health"},
+		{Rule: RulePropertyCasing, Location: "components.schemas.User", Message: "properties mix snake_case (last_name) and camelCase (firstName)", File: "users/user.go", Line: 5, Function: "example.com/api/users.User"},
+	}
+	if !reflect.DeepEqual(report.Findings, want) {
+		t.Errorf("findings:\n got %+v\nwant %+v", report.Findings, want)
+	}
+	// 4 operations × 3 rules, less the preflight's 4xx check, and 2 schemas
+	// with properties.
+	if report.Checks != 13 || report.Passed != 9 || report.Score != 69 {
+		t.Errorf("checks/passed/score = %d/%d/%d, want 13/9/69", report.Checks, report.Passed, report.Score)
+	}
+}
+
+func TestRun_EmptySpec(t *testing.T) {
+	report := Run(&spec.OpenAPISpec{}, nil)
+	if report.Score != 100 || report.Checks != 0 || report.Findings == nil {
+		t.Errorf("report = %+v, want a score of 100 and an empty findings list", report)
+	}
+}
+
+func TestPropertyCase(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		snake, camel bool
+	}{
+		{"created_at", true, false},
+		{"createdAt", false, true},
+		{"id", false, false},
+		{"_id", false, false},
+		{"ID_FIELD", false, false},
+		{"URL", false, false},
+		{"Created", false, false},
+	} {
+		if got := isSnakeCase(tc.name); got != tc.snake {
+			t.Errorf("isSnakeCase(%q) = %v, want %v", tc.name, got, tc.snake)
+		}
+		if got := isCamelCase(tc.name); got != tc.camel {
+			t.Errorf("isCamelCase(%q) = %v, want %v", tc.name, got, tc.camel)
+		}
+	}
+}
+
+func TestReportWrite(t *testing.T) {
+	report := Run(testSpec(), map[string]spec.SourceLocation{
+		"GET /health": {File: "main.go", Line: 12},
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := report.Write(&buf, FormatSARIF, "1.2.3"); err != nil {
+			t.Fatal(err)
+		}
+		var log sarif.Log
+		if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+			t.Fatalf("invalid SARIF JSON: %v", err)
+		}
+		if log.Version != "2.1.0" || len(log.Runs) != 1 {
+			t.Fatalf("log = %+v", log)
+		}
+		run := log.Runs[0]
+		if run.Tool.Driver.Version != "1.2.3" || len(run.Tool.Driver.Rules) != len(Rules) {
+			t.Errorf("driver = %+v", run.Tool.Driver)
+		}
+		if run.Properties["score"] != report.Score {
+			t.Errorf("score property = %d, want %d", run.Properties["score"], report.Score)
+		}
+		if len(run.Results) != len(report.Findings) {
+			t.Fatalf("%d results, want %d", len(run.Results), len(report.Findings))
+		}
+		first := run.Results[0]
+		if first.RuleID != RuleOperationDescription || first.RuleIndex != 0 {
+			t.Errorf("first result rule = %s #%d", first.RuleID, first.RuleIndex)
+		}
+		if pl := first.Locations[0].PhysicalLocation; pl == nil || pl.ArtifactLocation.URI != "main.go" || pl.Region == nil || pl.Region.StartLine != 12 {
+			t.Errorf("first result physical location = %+v", pl)
+		}
+		// The schema finding has no source: only its logical location.
+		last := run.Results[len(run.Results)-1]
+		if last.Locations[0].PhysicalLocation != nil || last.Locations[0].LogicalLocations[0].FullyQualifiedName != "components.schemas.User" {
+			t.Errorf("last result locations = %+v", last.Locations)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := report.Write(&buf, FormatJSON, ""); err != nil {
+			t.Fatal(err)
+		}
+		var got Report
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if !reflect.DeepEqual(got, report) {
+			t.Errorf("round trip:\n got %+v\nwant %+v", got, report)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		err := report.Write(&bytes.Buffer{}, "xml", "")
+		if err == nil || !strings.Contains(err.Error(), `unknown report format "xml"`) {
+			t.Errorf("err = %v", err)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/ehabterra/apispec/internal/sarif"
+)
+
+// Report formats.
+const (
+	FormatSARIF = "sarif"
+	FormatJSON  = "json"
+)
+
+// Write writes r to w in format, FormatSARIF or FormatJSON. toolVersion is
+// the apispec version a SARIF log names as its driver's.
+func (r Report) Write(w io.Writer, format, toolVersion string) error {
+	switch format {
+	case FormatSARIF:
+		return r.sarif(toolVersion).Write(w)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+	return fmt.Errorf("lint: unknown report format %q (want %s or %s)", format, FormatSARIF, FormatJSON)
+}
+
+func (r Report) sarif(toolVersion string) sarif.Log {
+	var rules []sarif.Rule
+	for _, rule := range Rules {
+		rules = append(rules, sarif.Rule{
+			ID:                   rule.ID,
+			ShortDescription:     &sarif.Message{Text: rule.Description},
+			DefaultConfiguration: sarif.Configuration{Level: sarif.LevelWarning},
+		})
+	}
+
+	// Every rule is style advice, not an error.
+	var results []sarif.Result
+	for _, f := range r.Findings {
+		loc := sarif.Location{LogicalLocations: []sarif.LogicalLocation{{FullyQualifiedName: f.Location}}}
+		if f.File != "" {
+			loc.PhysicalLocation = sarif.FileLocation(f.File, f.Line, 0)
+		}
+		results = append(results, sarif.Result{
+			RuleID:    f.Rule,
+			RuleIndex: slices.IndexFunc(Rules, func(r Rule) bool { return r.ID == f.Rule }),
+			Level:     sarif.LevelWarning,
+			Message:   sarif.Message{Text: f.Location + ": " + f.Message},
+			Locations: []sarif.Location{loc},
+		})
+	}
+
+	log := sarif.NewLog(toolVersion, rules, results)
+	log.Runs[0].Properties = map[string]int{"score": r.Score, "checks": r.Checks, "passed": r.Passed}
+	return log
+}
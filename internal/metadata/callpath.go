@@ -0,0 +1,169 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+// Call paths between two functions of the call graph: "how does main reach
+// this DB call". Functions are BaseIDs ("pkg.name" / "pkg.recv.name"), the
+// keys of meta.Callers. A function calling another from several sites is one
+// step, taken through its first call edge, so paths differ in the functions
+// they pass through rather than in call sites.
+
+// maxPathSearchSteps bounds the work of enumerating call paths, which grows
+// exponentially with their length in densely connected graphs.
+const maxPathSearchSteps = 1_000_000
+
+// IsReachableFrom reports whether function to is reachable from function
+// from through calls. A function reaches itself.
+func (m *Metadata) IsReachableFrom(from, to string) bool {
+	return m.GetCallPath(from, to) != nil
+}
+
+// GetCallPath returns the edges of a shortest call path from function from
+// to function to, in call order, or nil when to is not reachable. The path
+// from a function to itself is empty but not nil.
+func (m *Metadata) GetCallPath(from, to string) []*CallGraphEdge {
+	if from == to {
+		return []*CallGraphEdge{}
+	}
+	if m.Callers == nil {
+		m.BuildCallGraphMaps()
+	}
+
+	// Breadth-first, remembering the edge each function was first reached by.
+	via := map[string]*CallGraphEdge{from: nil}
+	frontier := []string{from}
+	for len(frontier) > 0 {
+		var next []string
+		for _, fn := range frontier {
+			for _, edge := range m.callSteps(fn) {
+				callee := edge.Callee.BaseID()
+				if _, seen := via[callee]; seen {
+					continue
+				}
+				via[callee] = edge
+				if callee == to {
+					return pathTo(via, to)
+				}
+				next = append(next, callee)
+			}
+		}
+		frontier = next
+	}
+	return nil
+}
+
+// GetCallPaths returns up to k call paths from function from to function to
+// with at most maxLen calls each, shortest first. Paths never visit a
+// function twice. Paths of equal length come in call graph order, and the
+// search gives up, returning what it found, after maxPathSearchSteps steps.
+func (m *Metadata) GetCallPaths(from, to string, k, maxLen int) [][]*CallGraphEdge {
+	if k < 1 || maxLen < 0 {
+		return nil
+	}
+	if from == to {
+		return [][]*CallGraphEdge{{}}
+	}
+	if m.Callers == nil {
+		m.BuildCallGraphMaps()
+	}
+
+	// dist is the length of the shortest path from each function to to, for
+	// the functions within maxLen calls of it: the search only steps where
+	// the remaining length still suffices.
+	dist := map[string]int{to: 0}
+	frontier := []string{to}
+	for d := 1; d <= maxLen && len(frontier) > 0; d++ {
+		var next []string
+		for _, fn := range frontier {
+			for _, edge := range m.Callees[fn] {
+				caller := edge.Caller.BaseID()
+				if _, seen := dist[caller]; !seen {
+					dist[caller] = d
+					next = append(next, caller)
+				}
+			}
+		}
+		frontier = next
+	}
+	shortest, ok := dist[from]
+	if !ok {
+		return nil
+	}
+
+	var paths [][]*CallGraphEdge
+	steps := 0
+	onPath := map[string]bool{}
+	var path []*CallGraphEdge
+	// walk extends path from fn with exactly remaining more calls.
+	var walk func(fn string, remaining int)
+	walk = func(fn string, remaining int) {
+		if len(paths) == k || steps >= maxPathSearchSteps {
+			return
+		}
+		steps++
+		if remaining == 0 {
+			if fn == to {
+				paths = append(paths, append([]*CallGraphEdge(nil), path...))
+			}
+			return
+		}
+		if fn == to {
+			return
+		}
+		onPath[fn] = true
+		for _, edge := range m.callSteps(fn) {
+			callee := edge.Callee.BaseID()
+			if d, ok := dist[callee]; !ok || d > remaining-1 || onPath[callee] {
+				continue
+			}
+			path = append(path, edge)
+			walk(callee, remaining-1)
+			path = path[:len(path)-1]
+		}
+		delete(onPath, fn)
+	}
+	for length := shortest; length <= maxLen && len(paths) < k && steps < maxPathSearchSteps; length++ {
+		walk(from, length)
+	}
+	return paths
+}
+
+// callSteps returns the first call edge from fn to each function it calls,
+// in call graph order.
+func (m *Metadata) callSteps(fn string) []*CallGraphEdge {
+	edges := m.Callers[fn]
+	seen := make(map[string]bool, len(edges))
+	steps := make([]*CallGraphEdge, 0, len(edges))
+	for _, edge := range edges {
+		callee := edge.Callee.BaseID()
+		if !seen[callee] {
+			seen[callee] = true
+			steps = append(steps, edge)
+		}
+	}
+	return steps
+}
+
+// pathTo unwinds the edges via records back from to.
+func pathTo(via map[string]*CallGraphEdge, to string) []*CallGraphEdge {
+	var path []*CallGraphEdge
+	for edge := via[to]; edge != nil; edge = via[edge.Caller.BaseID()] {
+		path = append(path, edge)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
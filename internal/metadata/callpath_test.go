@@ -0,0 +1,96 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// pathNames renders a path as "a>b>c" over the bare function names.
+func pathNames(path []*CallGraphEdge) string {
+	if len(path) == 0 {
+		return ""
+	}
+	names := []string{strings.TrimPrefix(path[0].Caller.BaseID(), "p.")}
+	for _, edge := range path {
+		names = append(names, strings.TrimPrefix(edge.Callee.BaseID(), "p."))
+	}
+	return strings.Join(names, ">")
+}
+
+// callPathMeta: main reaches db through svc directly and through a longer
+// detour via cache, which also loops back to svc; a second main -> svc call
+// site must not double the paths. orphan is unreachable.
+func callPathMeta() *Metadata {
+	return metaWithEdges([][2]string{
+		{"main", "svc"}, {"main", "svc"}, {"svc", "db"},
+		{"main", "cache"}, {"cache", "svc"}, {"cache", "load"}, {"load", "db"},
+		{"svc", "cache"}, {"orphan", "db"},
+	})
+}
+
+func TestGetCallPath(t *testing.T) {
+	m := callPathMeta()
+
+	if got := pathNames(m.GetCallPath("p.main", "p.db")); got != "main>svc>db" {
+		t.Errorf("shortest main -> db = %q", got)
+	}
+	if got := m.GetCallPath("p.db", "p.main"); got != nil {
+		t.Errorf("db -> main = %q, want nil", pathNames(got))
+	}
+	if got := m.GetCallPath("p.main", "p.main"); got == nil || len(got) != 0 {
+		t.Errorf("main -> main = %v, want an empty path", got)
+	}
+
+	if !m.IsReachableFrom("p.main", "p.load") || !m.IsReachableFrom("p.svc", "p.svc") {
+		t.Error("reachable functions reported unreachable")
+	}
+	if m.IsReachableFrom("p.main", "p.orphan") || m.IsReachableFrom("p.db", "p.svc") {
+		t.Error("unreachable functions reported reachable")
+	}
+}
+
+func TestGetCallPaths(t *testing.T) {
+	m := callPathMeta()
+
+	var got []string
+	for _, path := range m.GetCallPaths("p.main", "p.db", 10, 10) {
+		got = append(got, pathNames(path))
+	}
+	want := []string{
+		"main>svc>db",
+		"main>cache>svc>db",
+		"main>cache>load>db",
+		"main>svc>cache>load>db",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %q, want %q", got, want)
+	}
+
+	if paths := m.GetCallPaths("p.main", "p.db", 2, 10); len(paths) != 2 || pathNames(paths[1]) != "main>cache>svc>db" {
+		t.Errorf("k=2 returned %d paths", len(paths))
+	}
+	if paths := m.GetCallPaths("p.main", "p.db", 10, 2); len(paths) != 1 {
+		t.Errorf("maxLen=2 returned %d paths, want only the shortest", len(paths))
+	}
+	if paths := m.GetCallPaths("p.main", "p.db", 10, 1); paths != nil {
+		t.Errorf("maxLen=1 returned %d paths, want none", len(paths))
+	}
+	if paths := m.GetCallPaths("p.main", "p.orphan", 10, 10); paths != nil {
+		t.Errorf("unreachable target returned %d paths", len(paths))
+	}
+}
@@ -107,6 +107,39 @@ func TestCovmetaGetComments(t *testing.T) {
 	}
 }
 
+func TestTypeDoc(t *testing.T) {
+	file, _ := covmetaParse(t, `package p
+
+// Single documents an ungrouped declaration.
+type Single struct{}
+
+// Group documents the block, not its members.
+type (
+	// Member is documented inside the group.
+	Member int
+	Bare   int
+)
+`)
+	got := map[string]string{}
+	for _, decl := range file.Decls {
+		gd := decl.(*ast.GenDecl)
+		for _, spec := range gd.Specs {
+			tspec := spec.(*ast.TypeSpec)
+			got[tspec.Name.Name] = typeDoc(gd, tspec)
+		}
+	}
+	want := map[string]string{
+		"Single": "Single documents an ungrouped declaration.",
+		"Member": "Member is documented inside the group.",
+		"Bare":   "",
+	}
+	for name, doc := range want {
+		if got[name] != doc {
+			t.Errorf("typeDoc(%s) = %q, want %q", name, got[name], doc)
+		}
+	}
+}
+
 func TestCovmetaGetFieldTag(t *testing.T) {
 	file, _ := covmetaParse(t, covmetaHelperSrc)
 	_, _, _, taggedField, untaggedField := covmetaFindNodes(file)
@@ -115,6 +115,18 @@ func (u User) Label() string { return u.Name }
 func generateOnce(t *testing.T, cfg *packages.Config) []byte {
 	t.Helper()
 
+	out, err := yaml.Marshal(generateFixtureMetadata(t, cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// generateFixtureMetadata loads the fixture packages and runs metadata
+// generation over them.
+func generateFixtureMetadata(t *testing.T, cfg *packages.Config) *metadata.Metadata {
+	t.Helper()
+
 	fset := token.NewFileSet()
 	loadCfg := *cfg
 	loadCfg.Mode = packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
@@ -144,12 +156,7 @@ func generateOnce(t *testing.T, cfg *packages.Config) []byte {
 		}
 	}
 
-	meta := metadata.GenerateMetadata(pkgsMetadata, fileToInfo, importPaths, fset)
-	out, err := yaml.Marshal(meta)
-	if err != nil {
-		t.Fatal(err)
-	}
-	return out
+	return metadata.GenerateMetadata(pkgsMetadata, fileToInfo, importPaths, fset)
 }
 
 // TestGenerateMetadataDeterministic asserts that repeated metadata generation
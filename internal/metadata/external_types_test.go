@@ -75,6 +75,7 @@ func TestIsExternalPackage(t *testing.T) {
 		{testCurrentModulePath + "/internal/x", false},
 		{"github.com/google/uuid", true},
 		{"github.com/me/other-project/foo", true},
+		{testCurrentModulePath + "-fork/api", true},
 	}
 	for _, tc := range cases {
 		got := isExternalPackage(tc.pkgPath, testCurrentModulePath)
@@ -83,3 +84,19 @@ func TestIsExternalPackage(t *testing.T) {
 		}
 	}
 }
+
+func TestIsProjectPackage(t *testing.T) {
+	meta := &Metadata{CurrentModulePath: testCurrentModulePath, LocalModulePaths: []string{"example.com/shared"}}
+	for pkg, want := range map[string]bool{
+		testCurrentModulePath:            true,
+		testCurrentModulePath + "/api":   true,
+		"example.com/shared/models":      true,
+		"example.com/sharedutil":         false,
+		"github.com/google/uuid":         false,
+		testCurrentModulePath + "-tools": false,
+	} {
+		if got := meta.IsProjectPackage(pkg); got != want {
+			t.Errorf("IsProjectPackage(%q) = %v, want %v", pkg, got, want)
+		}
+	}
+}
@@ -124,6 +124,20 @@ func getComments(node ast.Node) string {
 	return ""
 }
 
+// typeDoc returns the doc comment of a type declared by tspec in decl. As in
+// go/doc, the comment above an ungrouped "type T ..." belongs to the
+// declaration, and to the spec only inside a "type ( ... )" group.
+func typeDoc(decl *ast.GenDecl, tspec *ast.TypeSpec) string {
+	doc := tspec.Doc
+	if doc == nil && !decl.Lparen.IsValid() {
+		doc = decl.Doc
+	}
+	if doc == nil {
+		return ""
+	}
+	return strings.TrimSpace(doc.Text())
+}
+
 // getFieldTag extracts the tag from a struct field
 func getFieldTag(field *ast.Field) string {
 	if field == nil || field.Tag == nil {
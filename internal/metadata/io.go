@@ -215,29 +215,46 @@ func setupMetadataReferences(metadata *Metadata) {
 		}
 	}
 
+	relinkCallGraph(metadata)
+
 	// Build the Callers map from the loaded call graph
 	metadata.BuildCallGraphMaps()
 }
 
-// LoadMetadata loads metadata from a YAML file
-func LoadMetadata(filename string) (*Metadata, error) {
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, err
+// relinkCallGraph restores the edge pointers the YAML format does not keep:
+// each call argument's link to the edge of the call it makes, and each
+// chained call's ChainParent. Without them a reloaded call graph loses the
+// tracker-tree branches that go through a call passed as an argument
+// (r.Handle("/x", h.Make())) or a chained router (app.Group("/v1").Use(mw)).
+//
+// Both are recovered the way analysis set them. An argument links to the
+// edge whose callee instance ID equals the argument's ID, and a chained call
+// (ChainDepth > 0) is recorded right after the call it is chained on.
+func relinkCallGraph(metadata *Metadata) {
+	byInstance := make(map[string]*CallGraphEdge, len(metadata.CallGraph))
+	for i := range metadata.CallGraph {
+		edge := &metadata.CallGraph[i]
+		byInstance[edge.Callee.InstanceID()] = edge
 	}
 
-	var metadata Metadata
-	err = yaml.Unmarshal(data, &metadata)
-	if err != nil {
-		return nil, err
+	for i := range metadata.CallGraph {
+		edge := &metadata.CallGraph[i]
+		for _, arg := range edge.Args {
+			if arg != nil && arg.Edge == nil && arg.GetKind() == KindCall {
+				arg.Edge = byInstance[arg.ID()]
+			}
+		}
+		if edge.ChainDepth > 0 && edge.ChainParent == nil && i > 0 {
+			edge.ChainParent = &metadata.CallGraph[i-1]
+		}
 	}
+}
 
-	setupMetadataReferences(&metadata)
-
-	// Process function return types to fill ResolvedType
-	metadata.ProcessFunctionReturnTypes()
-
-	return &metadata, nil
+// LoadMetadata loads metadata from a YAML file: the single document
+// WriteMetadata writes or the stream WriteMetadataStreamFile writes, either
+// of them gzip-compressed.
+func LoadMetadata(filename string) (*Metadata, error) {
+	return readMetadataFile(filename)
 }
 
 // LoadSplitMetadata loads metadata from 3 separate files
@@ -280,6 +297,25 @@ func LoadSplitMetadata(baseFilename string) (*Metadata, error) {
 	return metadata, nil
 }
 
+// LoadMetadataFiles loads metadata written by WriteMetadata or
+// WriteSplitMetadata. path is the single file, the base name the split files
+// were written for (metadata.yaml for metadata-call-graph.yaml and its
+// siblings), or any one of the split files.
+func LoadMetadataFiles(path string) (*Metadata, error) {
+	for _, suffix := range []string{stringPoolSuffix, packagesSuffix, callGraphSuffix} {
+		if strings.HasSuffix(path, suffix) {
+			return LoadSplitMetadata(strings.TrimSuffix(path, suffix) + ".yaml")
+		}
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		base := strings.TrimSuffix(path, filepath.Ext(path))
+		if _, splitErr := os.Stat(base + callGraphSuffix); splitErr == nil {
+			return LoadSplitMetadata(path)
+		}
+	}
+	return LoadMetadata(path)
+}
+
 // LoadYAML loads data from a YAML file
 func LoadYAML(filename string, data interface{}) error {
 	fileData, err := os.ReadFile(filename)
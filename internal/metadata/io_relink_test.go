@@ -0,0 +1,99 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// relinkFixture has the two links the YAML format drops: a call passed as an
+// argument and a chain of calls on one receiver.
+var relinkFixture = testModule{
+	Name: "relink",
+	Files: map[string]interface{}{
+		"main.go": `package main
+
+type Router struct{}
+
+func (r *Router) Group(prefix string) *Router      { return r }
+func (r *Router) Use(mw func()) *Router             { return r }
+func (r *Router) Handle(path string, h func()) *Router { return r }
+
+func middleware() func() { return func() {} }
+func handler() func()    { return func() {} }
+
+func main() {
+	r := &Router{}
+	r.Group("/v1").Use(middleware()).Handle("/users", handler())
+}
+`,
+	},
+}
+
+func TestLoadMetadata_RelinksCallGraph(t *testing.T) {
+	meta := generateFixtureMetadata(t, exportModules(t, []testModule{relinkFixture}))
+
+	path := filepath.Join(t.TempDir(), "metadata.yaml")
+	if err := metadata.WriteMetadata(meta, path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := metadata.LoadMetadata(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.CallGraph) != len(meta.CallGraph) {
+		t.Fatalf("loaded %d edges, generated %d", len(loaded.CallGraph), len(meta.CallGraph))
+	}
+
+	var chains, argCalls int
+	for i := range meta.CallGraph {
+		want, got := &meta.CallGraph[i], &loaded.CallGraph[i]
+		name := want.Callee.InstanceID()
+
+		switch {
+		case want.ChainParent == nil && got.ChainParent != nil:
+			t.Errorf("%s: unexpected chain parent %s", name, got.ChainParent.Callee.InstanceID())
+		case want.ChainParent != nil:
+			chains++
+			if got.ChainParent == nil {
+				t.Errorf("%s: lost chain parent %s", name, want.ChainParent.Callee.InstanceID())
+			} else if got.ChainParent.Callee.InstanceID() != want.ChainParent.Callee.InstanceID() {
+				t.Errorf("%s: chain parent %s, want %s", name,
+					got.ChainParent.Callee.InstanceID(), want.ChainParent.Callee.InstanceID())
+			}
+		}
+
+		for j, arg := range want.Args {
+			switch {
+			case arg.Edge == nil && got.Args[j].Edge != nil:
+				t.Errorf("%s arg %d: unexpected edge link", name, j)
+			case arg.Edge != nil:
+				argCalls++
+				if got.Args[j].Edge == nil {
+					t.Errorf("%s arg %d: lost link to %s", name, j, arg.Edge.Callee.InstanceID())
+				} else if got.Args[j].Edge.Callee.InstanceID() != arg.Edge.Callee.InstanceID() {
+					t.Errorf("%s arg %d: linked to %s, want %s", name, j,
+						got.Args[j].Edge.Callee.InstanceID(), arg.Edge.Callee.InstanceID())
+				}
+			}
+		}
+	}
+	if chains == 0 || argCalls == 0 {
+		t.Fatalf("fixture produced %d chained calls and %d call arguments, want both", chains, argCalls)
+	}
+}
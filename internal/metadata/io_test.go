@@ -245,6 +245,53 @@ func TestLoadSplitMetadata(t *testing.T) {
 	}
 }
 
+func TestLoadMetadataFiles(t *testing.T) {
+	stringPool := NewStringPool()
+	meta := &Metadata{
+		StringPool: stringPool,
+		CallGraph: []CallGraphEdge{
+			{
+				Caller: Call{Name: stringPool.Get("main")},
+				Callee: Call{Name: stringPool.Get("handler")},
+			},
+		},
+	}
+
+	tempDir := t.TempDir()
+	single := filepath.Join(tempDir, "single.yaml")
+	if err := WriteMetadata(meta, single); err != nil {
+		t.Fatal(err)
+	}
+	split := filepath.Join(tempDir, "split.yaml")
+	if err := WriteSplitMetadata(meta, split); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{
+		single,
+		split,
+		filepath.Join(tempDir, "split"),
+		filepath.Join(tempDir, "split-call-graph.yaml"),
+		filepath.Join(tempDir, "split-string-pool.yaml"),
+	} {
+		loaded, err := LoadMetadataFiles(path)
+		if err != nil {
+			t.Errorf("LoadMetadataFiles(%s): %v", filepath.Base(path), err)
+			continue
+		}
+		if len(loaded.CallGraph) != 1 || loaded.StringPool.GetString(loaded.CallGraph[0].Callee.Name) != "handler" {
+			t.Errorf("LoadMetadataFiles(%s) call graph = %+v", filepath.Base(path), loaded.CallGraph)
+		}
+		if len(loaded.Callers) == 0 {
+			t.Errorf("LoadMetadataFiles(%s) did not rebuild the call graph maps", filepath.Base(path))
+		}
+	}
+
+	if _, err := LoadMetadataFiles(filepath.Join(tempDir, "missing.yaml")); err == nil {
+		t.Error("Expected error when loading missing metadata")
+	}
+}
+
 func TestLoadYAML(t *testing.T) {
 	// Test loading valid YAML
 	testData := map[string]interface{}{
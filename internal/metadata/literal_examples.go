@@ -0,0 +1,441 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"maps"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// RequestFixture is a JSON request body a test sends: the body of an
+// httptest.NewRequest or http.NewRequest call in a _test.go file.
+type RequestFixture struct {
+	// Method is the request's HTTP method, upper-case.
+	Method string
+	// Path is the request target's path, without query or host.
+	Path string
+	// Body is the decoded JSON body.
+	Body any
+	// Test names the function making the request.
+	Test string
+}
+
+// recordLiteralExamples records, in meta.LiteralExamples, the constant
+// values file's struct literals give their fields: the mock `users` slice of
+// a handler, a default config. Only constants and slices of them are kept;
+// the first literal seen for a field wins.
+func recordLiteralExamples(file *ast.File, info *types.Info, meta *Metadata) {
+	if info == nil {
+		return
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		cl, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		t := info.TypeOf(cl)
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		named, ok := types.Unalias(t).(*types.Named)
+		if !ok || named.Obj().Pkg() == nil || named.TypeArgs().Len() > 0 {
+			return true
+		}
+		if _, ok := named.Underlying().(*types.Struct); !ok {
+			return true
+		}
+		meta.addLiteralFields(named.Obj().Pkg().Path()+"."+named.Obj().Name(), cl, info)
+		return true
+	})
+}
+
+// addLiteralFields records the constant fields of cl, a literal of the
+// struct type key.
+func (m *Metadata) addLiteralFields(key string, cl *ast.CompositeLit, info *types.Info) {
+	for _, elt := range cl.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		name, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		v, ok := literalValue(kv.Value, info)
+		if !ok {
+			continue
+		}
+		if m.LiteralExamples == nil {
+			m.LiteralExamples = make(map[string]map[string]any)
+		}
+		fields := m.LiteralExamples[key]
+		if fields == nil {
+			fields = make(map[string]any)
+			m.LiteralExamples[key] = fields
+		}
+		if _, seen := fields[name.Name]; !seen {
+			fields[name.Name] = v
+		}
+	}
+}
+
+// literalValue evaluates expr as a JSON value: a constant, or a slice or
+// array literal of constants. info resolves named constants; without it
+// (test files are parsed, not type-checked) only literals evaluate.
+func literalValue(expr ast.Expr, info *types.Info) (any, bool) {
+	if info != nil {
+		if tv, ok := info.Types[expr]; ok && tv.Value != nil {
+			return constantValue(tv.Value)
+		}
+	}
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return constantValue(constant.MakeFromLiteral(e.Value, e.Kind, 0))
+	case *ast.UnaryExpr:
+		if e.Op != token.SUB {
+			return nil, false
+		}
+		lit, ok := e.X.(*ast.BasicLit)
+		if !ok || (lit.Kind != token.INT && lit.Kind != token.FLOAT) {
+			return nil, false
+		}
+		return constantValue(constant.UnaryOp(token.SUB, constant.MakeFromLiteral(lit.Value, lit.Kind, 0), 0))
+	case *ast.Ident:
+		switch e.Name {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	case *ast.ParenExpr:
+		return literalValue(e.X, info)
+	case *ast.CompositeLit:
+		if _, ok := e.Type.(*ast.ArrayType); !ok {
+			return nil, false
+		}
+		values := make([]any, 0, len(e.Elts))
+		for _, elt := range e.Elts {
+			v, ok := literalValue(elt, info)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, v)
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// constantValue converts a constant to its JSON form. A rune is a number,
+// as encoding/json writes it.
+func constantValue(v constant.Value) (any, bool) {
+	switch v.Kind() {
+	case constant.Bool:
+		return constant.BoolVal(v), true
+	case constant.String:
+		return constant.StringVal(v), true
+	case constant.Int:
+		if i, exact := constant.Int64Val(v); exact {
+			return i, true
+		}
+	case constant.Float:
+		if f, _ := constant.Float64Val(v); !math.IsInf(f, 0) {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// CollectTestFixtures parses the _test.go files of the analyzed packages,
+// given as import path to directory, for struct literals of their types and
+// for JSON request bodies. Test files are not type-checked, so a type is
+// known by name: `User{...}` in the package's own tests, `users.User{...}`
+// through an import, and the elided elements of a []User literal. The
+// values add to meta.LiteralExamples (literals in the package itself win)
+// and the bodies become meta.RequestFixtures.
+func CollectTestFixtures(meta *Metadata, dirs map[string]string) {
+	fset := token.NewFileSet()
+	for _, pkgPath := range slices.Sorted(maps.Keys(dirs)) {
+		matches, _ := filepath.Glob(filepath.Join(dirs[pkgPath], "*_test.go"))
+		slices.Sort(matches)
+		for _, path := range matches {
+			src, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			file, err := parser.ParseFile(fset, path, src, 0)
+			if err != nil {
+				continue
+			}
+			t := &testFile{meta: meta, pkgPath: pkgPath, dirs: dirs, file: file, imports: map[string]string{}, seen: map[*ast.CompositeLit]bool{}}
+			for _, imp := range file.Imports {
+				p, _ := strconv.Unquote(imp.Path.Value)
+				name := p[strings.LastIndex(p, "/")+1:]
+				if imp.Name != nil {
+					name = imp.Name.Name
+				}
+				t.imports[name] = p
+			}
+			t.collect()
+		}
+	}
+}
+
+// testFile is one parsed _test.go file of package pkgPath.
+type testFile struct {
+	meta    *Metadata
+	pkgPath string
+	dirs    map[string]string // analyzed import path -> directory
+	file    *ast.File
+	imports map[string]string // import name -> path
+	seen    map[*ast.CompositeLit]bool
+}
+
+func (t *testFile) collect() {
+	for _, decl := range t.file.Decls {
+		fn, _ := decl.(*ast.FuncDecl)
+		ast.Inspect(decl, func(n ast.Node) bool {
+			switch x := n.(type) {
+			case *ast.CompositeLit:
+				if !t.seen[x] {
+					t.literal(x, t.typeKey(x.Type))
+				}
+			case *ast.CallExpr:
+				if fn != nil {
+					t.request(x, fn.Name.Name)
+				}
+			}
+			return true
+		})
+	}
+}
+
+// literal records cl, whose type is key ("" when not an analyzed struct),
+// and the elements of a slice or map literal, whose type is elided.
+func (t *testFile) literal(cl *ast.CompositeLit, key string) {
+	t.seen[cl] = true
+	if key != "" && t.isStruct(key) {
+		// Literals in the package's own code were recorded first and win.
+		t.meta.addLiteralFields(key, cl, nil)
+	}
+	var elem string
+	switch typ := cl.Type.(type) {
+	case *ast.ArrayType:
+		elem = t.typeKey(typ.Elt)
+	case *ast.MapType:
+		elem = t.typeKey(typ.Value)
+	}
+	for _, elt := range cl.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			elt = kv.Value
+		}
+		if u, ok := elt.(*ast.UnaryExpr); ok && u.Op == token.AND {
+			elt = u.X
+		}
+		if nested, ok := elt.(*ast.CompositeLit); ok && nested.Type == nil {
+			t.literal(nested, elem)
+		}
+	}
+}
+
+// typeKey returns the "pkgpath.Name" of the analyzed type expr names, or "".
+// An external test package (users_test) declares its own types, so a bare
+// type name there is not the package's.
+func (t *testFile) typeKey(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return t.typeKey(e.X)
+	case *ast.Ident:
+		if !strings.HasSuffix(t.file.Name.Name, "_test") {
+			return t.pkgPath + "." + e.Name
+		}
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			if p, ok := t.imports[x.Name]; ok {
+				if _, analyzed := t.dirs[p]; analyzed {
+					return p + "." + e.Sel.Name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// isStruct reports whether key names a struct type in the metadata.
+func (t *testFile) isStruct(key string) bool {
+	i := strings.LastIndex(key, ".")
+	pkg := t.meta.Packages[key[:i]]
+	if pkg == nil {
+		return false
+	}
+	typ := pkg.Types[key[i+1:]]
+	return typ != nil && t.meta.StringPool.GetString(typ.Kind) == "struct"
+}
+
+// request records the JSON body of call, an httptest.NewRequest,
+// http.NewRequest or http.NewRequestWithContext made in function test.
+func (t *testFile) request(call *ast.CallExpr, test string) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	x, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+	args := call.Args
+	switch {
+	case t.imports[x.Name] == "net/http/httptest" && sel.Sel.Name == "NewRequest",
+		t.imports[x.Name] == "net/http" && sel.Sel.Name == "NewRequest":
+	case t.imports[x.Name] == "net/http" && sel.Sel.Name == "NewRequestWithContext":
+		if len(args) > 0 {
+			args = args[1:]
+		}
+	default:
+		return
+	}
+	if len(args) != 3 {
+		return
+	}
+	method := t.method(args[0])
+	target, ok := t.stringValue(args[1])
+	if method == "" || !ok {
+		return
+	}
+	u, err := url.Parse(target)
+	if err != nil || u.Path == "" {
+		return
+	}
+	raw, ok := t.bodyString(args[2])
+	if !ok || !json.Valid([]byte(raw)) {
+		return
+	}
+	var body any
+	if err := json.Unmarshal([]byte(raw), &body); err != nil {
+		return
+	}
+	t.meta.RequestFixtures = append(t.meta.RequestFixtures, RequestFixture{
+		Method: method,
+		Path:   u.Path,
+		Body:   body,
+		Test:   test,
+	})
+}
+
+// method evaluates a request's method argument: a string or an
+// http.MethodXxx constant.
+func (t *testFile) method(expr ast.Expr) string {
+	if sel, ok := expr.(*ast.SelectorExpr); ok {
+		if x, ok := sel.X.(*ast.Ident); ok && t.imports[x.Name] == "net/http" {
+			if m, ok := strings.CutPrefix(sel.Sel.Name, "Method"); ok {
+				return strings.ToUpper(m)
+			}
+		}
+		return ""
+	}
+	s, ok := t.stringValue(expr)
+	if !ok {
+		return ""
+	}
+	s = strings.ToUpper(s)
+	switch s {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+		http.MethodHead, http.MethodOptions:
+		return s
+	}
+	return ""
+}
+
+// bodyString evaluates a request's body argument:
+// strings.NewReader(s), bytes.NewBufferString(s), or bytes.NewReader or
+// bytes.NewBuffer of []byte(s).
+func (t *testFile) bodyString(expr ast.Expr) (string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	arg := call.Args[0]
+	switch t.imports[x.Name] + "." + sel.Sel.Name {
+	case "strings.NewReader", "bytes.NewBufferString":
+		return t.stringValue(arg)
+	case "bytes.NewReader", "bytes.NewBuffer":
+		conv, ok := arg.(*ast.CallExpr)
+		if !ok || len(conv.Args) != 1 {
+			return "", false
+		}
+		if arr, ok := conv.Fun.(*ast.ArrayType); !ok || arr.Len != nil || !isIdent(arr.Elt, "byte") {
+			return "", false
+		}
+		return t.stringValue(conv.Args[0])
+	}
+	return "", false
+}
+
+// stringValue evaluates a string literal, or an identifier declared once in
+// the function with one.
+func (t *testFile) stringValue(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind == token.STRING {
+			s, err := strconv.Unquote(e.Value)
+			return s, err == nil
+		}
+	case *ast.Ident:
+		if e.Obj == nil {
+			return "", false
+		}
+		switch decl := e.Obj.Decl.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range decl.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && id.Name == e.Name && len(decl.Rhs) == len(decl.Lhs) {
+					return t.stringValue(decl.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range decl.Names {
+				if name.Name == e.Name && i < len(decl.Values) {
+					return t.stringValue(decl.Values[i])
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == name
+}
@@ -15,6 +15,7 @@
 package metadata
 
 import (
+	"context"
 	"fmt"
 	"go/ast"
 	"go/token"
@@ -165,7 +166,17 @@ type VerboseLogger interface {
 // go.mod by the caller). It's preferred over inferring the path from import
 // paths, which is only a heuristic and mis-detects when third-party packages
 // are analyzed alongside the project (see the inference block below).
-func GenerateMetadataWithLogger(pkgs map[string]map[string]*ast.File, fileToInfo map[*ast.File]*types.Info, importPaths map[string]string, fset *token.FileSet, logger VerboseLogger, modulePath string) *Metadata {
+// localModules are the module paths go.mod replaces with a local directory;
+// their packages are the project's own code, like the main module's.
+func GenerateMetadataWithLogger(pkgs map[string]map[string]*ast.File, fileToInfo map[*ast.File]*types.Info, importPaths map[string]string, fset *token.FileSet, logger VerboseLogger, modulePath string, localModules ...string) *Metadata {
+	metadata, _ := GenerateMetadataContext(context.Background(), pkgs, fileToInfo, importPaths, fset, logger, modulePath, localModules...)
+	return metadata
+}
+
+// GenerateMetadataContext is GenerateMetadataWithLogger, stopped between
+// packages once ctx is cancelled; it then returns ctx's error and no
+// metadata.
+func GenerateMetadataContext(ctx context.Context, pkgs map[string]map[string]*ast.File, fileToInfo map[*ast.File]*types.Info, importPaths map[string]string, fset *token.FileSet, logger VerboseLogger, modulePath string, localModules ...string) (*Metadata, error) {
 	funcMap := BuildFuncMap(pkgs)
 
 	if logger != nil {
@@ -232,6 +243,10 @@ func GenerateMetadataWithLogger(pkgs map[string]map[string]*ast.File, fileToInfo
 		}
 	}
 
+	if modulePath == "" && logger != nil {
+		logger.Printf("No module path from go.mod; inferred %q from package import paths\n", currentModulePath)
+	}
+
 	metadata := &Metadata{
 		StringPool: NewStringPool(),
 		Packages:   make(map[string]*Package),
@@ -245,6 +260,7 @@ func GenerateMetadataWithLogger(pkgs map[string]map[string]*ast.File, fileToInfo
 
 		// Set the current module path
 		CurrentModulePath: currentModulePath,
+		LocalModulePaths:  localModules,
 
 		// External-type facts discovered during the type walk.
 		ExternalTypes: make(map[string]ExternalTypeFact),
@@ -255,6 +271,9 @@ func GenerateMetadataWithLogger(pkgs map[string]map[string]*ast.File, fileToInfo
 	// entire serialized metadata) per run.
 	sortedPkgNames := slices.Sorted(maps.Keys(pkgs))
 	for _, pkgName := range sortedPkgNames {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		files := pkgs[pkgName]
 		sortedFileNames := slices.Sorted(maps.Keys(files))
 		pkg := &Package{
@@ -322,6 +341,7 @@ func GenerateMetadataWithLogger(pkgs map[string]map[string]*ast.File, fileToInfo
 					ReturnVars:    returnVars,
 					Returns:       allReturns,
 					Filename:      metadata.StringPool.Get(fileName),
+					OptionalBody:  detectOptionalBody(fn.Body, info, fset),
 				}
 				m.SignatureStr = metadata.StringPool.Get(CallArgToString(&m.Signature))
 				allTypeMethods[recvType] = append(allTypeMethods[recvType], m)
@@ -364,6 +384,7 @@ func GenerateMetadataWithLogger(pkgs map[string]map[string]*ast.File, fileToInfo
 
 			// Process struct instances and assignments
 			processStructInstances(file, info, pkgName, fset, f, constMap, metadata)
+			recordLiteralExamples(file, info, metadata)
 
 			// Process imports
 			processImports(file, metadata, f)
@@ -388,6 +409,9 @@ func GenerateMetadataWithLogger(pkgs map[string]map[string]*ast.File, fileToInfo
 	// it directly makes the CallGraph edge order (and therefore roots, traversal
 	// order, and the whole generated spec) differ between runs.
 	for _, pkgName := range sortedPkgNames {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		// Build call graph
 		buildCallGraph(pkgs[pkgName], pkgs, pkgName, fileToInfo, fset, funcMap, metadata)
 	}
@@ -443,7 +467,7 @@ func GenerateMetadataWithLogger(pkgs map[string]map[string]*ast.File, fileToInfo
 		logger.Println("assignment Count:", assignmentCount)
 	}
 
-	return metadata
+	return metadata, nil
 }
 
 // BuildAssignmentRelationships builds assignment relationships for all call graph edges
@@ -629,7 +653,7 @@ func processTypes(file *ast.File, info *types.Info, pkgName string, fset *token.
 
 		for _, spec := range genDecl.Specs {
 			if tspec, ok := spec.(*ast.TypeSpec); ok {
-				processTypeSpec(tspec, info, pkgName, fset, f, allTypeMethods, allTypes, metadata, false)
+				processTypeSpec(tspec, typeDoc(genDecl, tspec), info, pkgName, fset, f, allTypeMethods, allTypes, metadata, false)
 			}
 		}
 	}
@@ -641,11 +665,11 @@ func processTypes(file *ast.File, info *types.Info, pkgName string, fset *token.
 	processLocalTypes(file, info, pkgName, fset, f, allTypeMethods, allTypes, metadata)
 }
 
-// processTypeSpec records a single type declaration into the file's type table.
-// When local is true the spec came from inside a function body; such a type is
+// processTypeSpec records a single type declaration, documented by doc, into
+// the file's type table. When local is true the spec came from inside a function body; such a type is
 // only added if its name isn't already taken by a package-level type in this
 // file, so a real package type is never shadowed by a function-local one.
-func processTypeSpec(tspec *ast.TypeSpec, info *types.Info, pkgName string, fset *token.FileSet, f *File, allTypeMethods map[string][]Method, allTypes map[string]*Type, metadata *Metadata, local bool) {
+func processTypeSpec(tspec *ast.TypeSpec, doc string, info *types.Info, pkgName string, fset *token.FileSet, f *File, allTypeMethods map[string][]Method, allTypes map[string]*Type, metadata *Metadata, local bool) {
 	// Skip mock/fake/stub types
 	if isMockName(tspec.Name.Name) {
 		return
@@ -657,9 +681,10 @@ func processTypeSpec(tspec *ast.TypeSpec, info *types.Info, pkgName string, fset
 	}
 
 	t := &Type{
-		Name:  metadata.StringPool.Get(tspec.Name.Name),
-		Pkg:   metadata.StringPool.Get(pkgName),
-		Scope: metadata.StringPool.Get(getScope(tspec.Name.Name)),
+		Name:     metadata.StringPool.Get(tspec.Name.Name),
+		Pkg:      metadata.StringPool.Get(pkgName),
+		Scope:    metadata.StringPool.Get(getScope(tspec.Name.Name)),
+		Position: metadata.StringPool.Get(getPosition(tspec.Name.Pos(), fset)),
 	}
 
 	// Extract declared type-parameter names for generic types (e.g. the "T"
@@ -675,7 +700,7 @@ func processTypeSpec(tspec *ast.TypeSpec, info *types.Info, pkgName string, fset
 	}
 
 	// Extract comments
-	t.Comments = metadata.StringPool.Get(getComments(tspec))
+	t.Comments = metadata.StringPool.Get(doc)
 
 	// Process type kind
 	processTypeKind(tspec, info, pkgName, fset, t, allTypes, metadata)
@@ -704,7 +729,7 @@ func processLocalTypes(file *ast.File, info *types.Info, pkgName string, fset *t
 			}
 			for _, spec := range gd.Specs {
 				if tspec, ok := spec.(*ast.TypeSpec); ok {
-					processTypeSpec(tspec, info, pkgName, fset, f, allTypeMethods, allTypes, metadata, true)
+					processTypeSpec(tspec, typeDoc(gd, tspec), info, pkgName, fset, f, allTypeMethods, allTypes, metadata, true)
 				}
 			}
 			return true
@@ -867,7 +892,7 @@ func recordExternalTypeFactsVisited(t types.Type, meta *Metadata, visited map[*t
 		if obj == nil || obj.Pkg() == nil {
 			return
 		}
-		if !isExternalPackage(obj.Pkg().Path(), meta.CurrentModulePath) {
+		if meta.IsProjectPackage(obj.Pkg().Path()) || !isExternalPackage(obj.Pkg().Path(), meta.CurrentModulePath) {
 			return // internal type: it renders as its own component
 		}
 		if _, seen := visited[obj]; seen {
@@ -908,8 +933,9 @@ func isExternalPackage(pkgPath, currentModulePath string) bool {
 		return false
 	}
 
-	// If the package path starts with the current module path, it's internal
-	if strings.HasPrefix(pkgPath, currentModulePath) {
+	// A package of the current module is internal. Without a module path
+	// nothing can be told apart, so everything is.
+	if currentModulePath == "" || pkgPath == currentModulePath || strings.HasPrefix(pkgPath, currentModulePath+"/") {
 		return false
 	}
 
@@ -917,6 +943,17 @@ func isExternalPackage(pkgPath, currentModulePath string) bool {
 	return true
 }
 
+// IsProjectPackage reports whether pkgPath belongs to the analyzed module or
+// to a module go.mod replaces with a local directory.
+func (m *Metadata) IsProjectPackage(pkgPath string) bool {
+	for _, mp := range append([]string{m.CurrentModulePath}, m.LocalModulePaths...) {
+		if mp != "" && (pkgPath == mp || strings.HasPrefix(pkgPath, mp+"/")) {
+			return true
+		}
+	}
+	return false
+}
+
 // processStructFields processes fields of a struct type
 
 // AnonStructTypePrefix is the tag carried by every synthetic type that
@@ -1212,6 +1249,7 @@ func processFunctions(file *ast.File, info *types.Info, pkgName string, fset *to
 			Returns:        allReturns,
 			AssignmentMap:  assignmentsInFunc,
 			MethodDispatch: detectMethodDispatch(fn.Body, info, fset),
+			OptionalBody:   detectOptionalBody(fn.Body, info, fset),
 		}
 
 		f.Functions[fn.Name.Name].SignatureStr = metadata.StringPool.Get(CallArgToString(&f.Functions[fn.Name.Name].Signature))
@@ -1502,6 +1540,7 @@ func buildCallGraph(files map[string]*ast.File, pkgs map[string]map[string]*ast.
 		fileNames = append(fileNames, fileName)
 	}
 	sort.Strings(fileNames)
+	tables := collectRangeTables(files, fileToInfo)
 	for _, fileName := range fileNames {
 		file := files[fileName]
 		var argMap = map[string]*CallArgument{}
@@ -1510,15 +1549,24 @@ func buildCallGraph(files map[string]*ast.File, pkgs map[string]map[string]*ast.
 		info := fileToInfo[file]
 
 		var assignStmt *ast.AssignStmt
+		// The nodes enclosing the one visited, to find the range loop a
+		// call sits in.
+		var stack []ast.Node
 
 		ast.Inspect(file, func(n ast.Node) bool {
 			if n == nil {
+				stack = stack[:len(stack)-1]
 				return true
 			}
+			stack = append(stack, n)
 
 			if call, ok := n.(*ast.CallExpr); ok {
+				recorded := len(metadata.CallGraph)
 				processCallExpression(call, file, pkgs, pkgName, assignStmt, fileToInfo, funcMap, fset, metadata, info, calleeMap, argMap)
 				assignStmt = nil
+				if loop := enclosingRangeLoop(stack, call, info, tables); loop != nil {
+					unrollRangeCall(call, loop, recorded, info, pkgName, fset, metadata, calleeMap, argMap)
+				}
 			} else if assign, ok := n.(*ast.AssignStmt); ok {
 				// Find which variable this call is assigned to
 				assignStmt = assign
@@ -1622,7 +1670,6 @@ func processCallExpression(call *ast.CallExpr, file *ast.File, pkgs map[string]m
 		args := make([]*CallArgument, len(call.Args))
 		for i, arg := range call.Args {
 			args[i] = ExprToCallArgument(arg, info, pkgName, fset, metadata)
-			argMap[args[i].ID()] = args[i]
 		}
 
 		// Build parameter-to-argument mapping
@@ -1676,6 +1723,13 @@ func processCallExpression(call *ast.CallExpr, file *ast.File, pkgs map[string]m
 			return
 		}
 
+		// Register the arguments only for the edge that is kept: a chained
+		// call is visited again after its chain processed it, and the copies
+		// made then would take the links meant for the recorded arguments.
+		for _, arg := range args {
+			argMap[arg.ID()] = arg
+		}
+
 		// Use funcMap to get callee function declaration
 		var assignmentsInFunc = make(map[string][]Assignment)
 
@@ -15,6 +15,7 @@
 package metadata
 
 import (
+	"context"
 	"errors"
 	"go/ast"
 	"go/importer"
@@ -755,6 +756,50 @@ func TestSweepGenerateMetadataModulePathInference(t *testing.T) {
 	}
 }
 
+// TestGenerateMetadataModulePathFromGoMod pins that the module path the
+// caller read from go.mod wins over inference, which takes a single-package
+// module's package path for the module path, and that locally replaced
+// modules are carried along.
+func TestGenerateMetadataModulePathFromGoMod(t *testing.T) {
+	fset := token.NewFileSet()
+	empty := map[string]map[string]*ast.File{}
+	noInfo := map[*ast.File]*types.Info{}
+
+	single := map[string]string{"main.go": "example.com/app/cmd/api"}
+	if md := GenerateMetadata(empty, noInfo, single, fset); md.CurrentModulePath != "example.com/app/cmd/api" {
+		t.Errorf("inferred single-package module path = %q", md.CurrentModulePath)
+	}
+	if md := GenerateMetadataWithLogger(empty, noInfo, single, fset, nil, "example.com/app"); md.CurrentModulePath != "example.com/app" {
+		t.Errorf("go.mod module path = %q, want example.com/app", md.CurrentModulePath)
+	}
+
+	md := GenerateMetadataWithLogger(empty, noInfo, map[string]string{
+		"a.go": "github.com/me/fork/api",
+		"b.go": "github.com/me/fork/store",
+	}, fset, nil, "github.com/me/fork", "example.com/shared")
+	if md.CurrentModulePath != "github.com/me/fork" || !md.IsProjectPackage("example.com/shared/models") {
+		t.Errorf("module path = %q, local modules = %v", md.CurrentModulePath, md.LocalModulePaths)
+	}
+}
+
+func TestGenerateMetadataContextCancelled(t *testing.T) {
+	src := "package p\n\ntype Svc struct{}\n\nfunc (s Svc) Run() {}\n"
+	file, info, fset := sweepTypeCheck(t, src)
+	pkgs := map[string]map[string]*ast.File{"p": {"p.go": file}}
+	fileToInfo := map[*ast.File]*types.Info{file: info}
+	importPaths := map[string]string{"p.go": "p"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if md, err := GenerateMetadataContext(ctx, pkgs, fileToInfo, importPaths, fset, nil, "p"); md != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled generation = %v, %v; want no metadata and context.Canceled", md, err)
+	}
+	md, err := GenerateMetadataContext(context.Background(), pkgs, fileToInfo, importPaths, fset, nil, "p")
+	if err != nil || md.Packages["p"] == nil {
+		t.Errorf("generation = %v, %v; want package p", md, err)
+	}
+}
+
 func TestSweepGenerateMetadataSkipsMockReceivers(t *testing.T) {
 	src := "package p\n\ntype MockSvc struct{}\n\nfunc (m MockSvc) Do() {}\n\ntype Svc struct{}\n\nfunc (s Svc) Run() {}\n"
 	file, info, fset := sweepTypeCheck(t, src)
@@ -813,8 +858,8 @@ func TestSweepProcessTypeSpecSkips(t *testing.T) {
 	m := sweepMeta()
 	f := &File{Types: map[string]*Type{"User": {}}}
 
-	processTypeSpec(&ast.TypeSpec{Name: ast.NewIdent("MockUser")}, nil, "p", nil, f, nil, nil, m, false)
-	processTypeSpec(&ast.TypeSpec{Name: ast.NewIdent("User")}, nil, "p", nil, f, nil, nil, m, true)
+	processTypeSpec(&ast.TypeSpec{Name: ast.NewIdent("MockUser")}, "", nil, "p", nil, f, nil, nil, m, false)
+	processTypeSpec(&ast.TypeSpec{Name: ast.NewIdent("User")}, "", nil, "p", nil, f, nil, nil, m, true)
 	if len(f.Types) != 1 {
 		t.Errorf("both specs should be skipped, got %d types", len(f.Types))
 	}
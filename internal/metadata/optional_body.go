@@ -0,0 +1,172 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// detectOptionalBody returns the line ranges of a function body where reading
+// the request body is optional: the arms of an `if r.Body != nil`,
+// `if r.Body != http.NoBody` or `if r.ContentLength > 0` guard, and a decode
+// whose error is checked against io.EOF — `if err := dec.Decode(&v); err != nil
+// && err != io.EOF`, or the same check on the line after `err := …`. A request
+// body decoded inside one of these ranges is not required.
+//
+// As in detectMethodDispatch, the request is identified by its type
+// (`*net/http.Request`, so gin's c.Request counts too) and io.EOF through the
+// type checker, not by name. Returns nil for bodies with no such guard.
+func detectOptionalBody(body *ast.BlockStmt, info *types.Info, fset *token.FileSet) []LineRange {
+	if body == nil || info == nil || fset == nil {
+		return nil
+	}
+	var ranges []LineRange
+	line := func(pos token.Pos) int { return fset.Position(pos).Line }
+	eofCheck := func(list []ast.Stmt) {
+		for i, stmt := range list {
+			ifStmt, ok := stmt.(*ast.IfStmt)
+			if !ok || !mentionsEOF(ifStmt.Cond, info) {
+				continue
+			}
+			start := ifStmt.Pos()
+			if assign, ok := prevErrAssign(list, i, ifStmt.Cond, info); ok {
+				start = assign.Pos()
+			}
+			ranges = append(ranges, LineRange{StartLine: line(start), EndLine: line(ifStmt.Cond.End())})
+		}
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.BlockStmt:
+			eofCheck(stmt.List)
+		case *ast.CaseClause:
+			eofCheck(stmt.Body)
+		case *ast.IfStmt:
+			if isBodyPresenceCheck(stmt.Cond, info) {
+				ranges = append(ranges, LineRange{StartLine: line(stmt.Body.Pos()), EndLine: line(stmt.Body.End())})
+			}
+		}
+		return true
+	})
+	return ranges
+}
+
+// isBodyPresenceCheck reports whether cond, or one of its && operands, tests
+// that the request carries a body: `r.Body != nil`, `r.Body != http.NoBody`,
+// `r.ContentLength > 0` or `r.ContentLength != 0`.
+func isBodyPresenceCheck(cond ast.Expr, info *types.Info) bool {
+	be, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+	if !ok {
+		return false
+	}
+	switch be.Op {
+	case token.LAND:
+		return isBodyPresenceCheck(be.X, info) || isBodyPresenceCheck(be.Y, info)
+	case token.NEQ:
+		if isRequestField(be.X, "Body", info) {
+			return isNil(be.Y, info) || isNoBody(be.Y, info)
+		}
+		return isRequestField(be.X, "ContentLength", info) && isZero(be.Y, info)
+	case token.GTR:
+		return isRequestField(be.X, "ContentLength", info) && isZero(be.Y, info)
+	}
+	return false
+}
+
+// isRequestField reports whether expr is `<request>.<field>` on a
+// `*net/http.Request`.
+func isRequestField(expr ast.Expr, field string, info *types.Info) bool {
+	sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != field {
+		return false
+	}
+	t := info.TypeOf(sel.X)
+	return t != nil && t.String() == "*net/http.Request"
+}
+
+func isNil(expr ast.Expr, info *types.Info) bool {
+	tv, ok := info.Types[expr]
+	return ok && tv.IsNil()
+}
+
+func isZero(expr ast.Expr, info *types.Info) bool {
+	tv, ok := info.Types[expr]
+	return ok && tv.Value != nil && tv.Value.String() == "0"
+}
+
+// isNoBody reports whether expr is net/http.NoBody.
+func isNoBody(expr ast.Expr, info *types.Info) bool {
+	return isPkgVar(expr, "net/http", "NoBody", info)
+}
+
+// mentionsEOF reports whether expr refers to io.EOF anywhere, as in
+// `err != io.EOF` or `errors.Is(err, io.EOF)`.
+func mentionsEOF(expr ast.Expr, info *types.Info) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if e, ok := n.(ast.Expr); ok && isPkgVar(e, "io", "EOF", info) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+func isPkgVar(expr ast.Expr, pkg, name string, info *types.Info) bool {
+	sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	v, ok := info.Uses[sel.Sel].(*types.Var)
+	return ok && v.Name() == name && v.Pkg() != nil && v.Pkg().Path() == pkg
+}
+
+// prevErrAssign returns the statement before list[i] when it assigns an
+// identifier that cond reads — the `err := dec.Decode(&v)` whose error the
+// io.EOF check at list[i] inspects.
+func prevErrAssign(list []ast.Stmt, i int, cond ast.Expr, info *types.Info) (*ast.AssignStmt, bool) {
+	if i == 0 {
+		return nil, false
+	}
+	assign, ok := list[i-1].(*ast.AssignStmt)
+	if !ok {
+		return nil, false
+	}
+	read := map[types.Object]bool{}
+	ast.Inspect(cond, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if obj := info.Uses[id]; obj != nil {
+				read[obj] = true
+			}
+		}
+		return true
+	})
+	for _, lhs := range assign.Lhs {
+		id, ok := lhs.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		obj := info.Defs[id]
+		if obj == nil {
+			obj = info.Uses[id]
+		}
+		if obj != nil && read[obj] {
+			return assign, true
+		}
+	}
+	return nil, false
+}
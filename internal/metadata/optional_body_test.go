@@ -0,0 +1,107 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+// typeCheckBodyHandler is typeCheckHandler with encoding/json, errors and io
+// in scope, and the handler starting on line 11.
+func typeCheckBodyHandler(t *testing.T, body string) (*ast.BlockStmt, *types.Info, *token.FileSet) {
+	t.Helper()
+	src := "package p\n\nimport (\n\t\"encoding/json\"\n\t\"errors\"\n\t\"io\"\n\t\"net/http\"\n)\n\nvar _ = errors.Is\n" +
+		"func h(w http.ResponseWriter, r *http.Request) {\n" + body + "\n}\n"
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "h.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	info := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Defs:  map[*ast.Ident]types.Object{},
+		Uses:  map[*ast.Ident]types.Object{},
+	}
+	conf := types.Config{Importer: importer.Default(), Error: func(error) {}}
+	_, _ = conf.Check("p", fset, []*ast.File{file}, info)
+	for _, d := range file.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok && fn.Name.Name == "h" {
+			return fn.Body, info, fset
+		}
+	}
+	t.Fatal("handler h not found")
+	return nil, nil, nil
+}
+
+func TestDetectOptionalBody(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want []LineRange
+	}{
+		{
+			name: "unconditional decode",
+			body: "var v any\nif err := json.NewDecoder(r.Body).Decode(&v); err != nil {\nreturn\n}",
+		},
+		{
+			name: "body guard",
+			body: "var v any\nif r.Body != nil {\n_ = json.NewDecoder(r.Body).Decode(&v)\n}",
+			want: []LineRange{{13, 15}},
+		},
+		{
+			name: "content length in a conjunction",
+			body: "var v any\nif v == nil && (r.ContentLength > 0) {\n_ = json.NewDecoder(r.Body).Decode(&v)\n}",
+			want: []LineRange{{13, 15}},
+		},
+		{
+			name: "no-body guard",
+			body: "var v any\nif r.Body != http.NoBody {\n_ = json.NewDecoder(r.Body).Decode(&v)\n}",
+			want: []LineRange{{13, 15}},
+		},
+		{
+			name: "negated guard is not optional",
+			body: "var v any\nif r.ContentLength == 0 {\nreturn\n}\n_ = json.NewDecoder(r.Body).Decode(&v)",
+		},
+		{
+			name: "inline EOF check",
+			body: "var v any\nif err := json.NewDecoder(r.Body).Decode(&v); err != nil && err != io.EOF {\nreturn\n}",
+			want: []LineRange{{13, 13}},
+		},
+		{
+			name: "EOF check after the assignment",
+			body: "var v any\nerr := json.NewDecoder(r.Body).Decode(&v)\nif err != nil &&\n!errors.Is(err, io.EOF) {\nreturn\n}",
+			want: []LineRange{{13, 15}},
+		},
+		{
+			name: "EOF check in a switch case",
+			body: "var v any\nswitch {\ncase true:\nerr := json.NewDecoder(r.Body).Decode(&v)\nif errors.Is(err, io.EOF) {\nreturn\n}\n}",
+			want: []LineRange{{15, 16}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			body, info, fset := typeCheckBodyHandler(t, c.body)
+			if got := detectOptionalBody(body, info, fset); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("ranges = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+)
+
+// maxUnrolledElements bounds the literals a range loop is unrolled over.
+const maxUnrolledElements = 64
+
+// rangeTables maps a variable to the composite literal it is defined from,
+// for variables never assigned again, indexed into or addressed — the route
+// tables a registration loop ranges over:
+//
+//	handlers := map[string]http.HandlerFunc{"GET /a": a, "GET /b": b}
+//	for pattern, h := range handlers { mux.HandleFunc(pattern, h) }
+type rangeTables map[types.Object]*ast.CompositeLit
+
+// collectRangeTables scans a package's files for such variables, local or
+// package-level.
+func collectRangeTables(files map[string]*ast.File, fileToInfo map[*ast.File]*types.Info) rangeTables {
+	tables := rangeTables{}
+	mutated := map[types.Object]bool{}
+	for _, file := range files {
+		info := fileToInfo[file]
+		if info == nil {
+			continue
+		}
+		// A use that can change the variable's contents: the target of an
+		// assignment, of an index assignment, or of &.
+		markMutated := func(expr ast.Expr) {
+			for {
+				switch e := expr.(type) {
+				case *ast.ParenExpr:
+					expr = e.X
+					continue
+				case *ast.IndexExpr:
+					expr = e.X
+					continue
+				case *ast.Ident:
+					if obj := info.Uses[e]; obj != nil {
+						mutated[obj] = true
+					}
+				}
+				return
+			}
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for i, lhs := range n.Lhs {
+					id, ok := lhs.(*ast.Ident)
+					if ok && info.Defs[id] != nil && len(n.Lhs) == len(n.Rhs) {
+						if cl, ok := n.Rhs[i].(*ast.CompositeLit); ok {
+							tables[info.Defs[id]] = cl
+						}
+						continue
+					}
+					markMutated(lhs)
+				}
+			case *ast.ValueSpec:
+				if len(n.Names) == len(n.Values) {
+					for i, id := range n.Names {
+						if cl, ok := n.Values[i].(*ast.CompositeLit); ok && info.Defs[id] != nil {
+							tables[info.Defs[id]] = cl
+						}
+					}
+				}
+			case *ast.IncDecStmt:
+				markMutated(n.X)
+			case *ast.UnaryExpr:
+				if n.Op == token.AND {
+					markMutated(n.X)
+				}
+			}
+			return true
+		})
+	}
+	for obj := range mutated {
+		delete(tables, obj)
+	}
+	return tables
+}
+
+// rangeLoop is a `for k, v := range <literal>` loop whose body is unrolled.
+type rangeLoop struct {
+	key, value types.Object // nil when blank or absent
+	elems      []rangeElem
+}
+
+// rangeElem is one element of the ranged literal: its key (nil for a
+// positional slice element), its value and its index.
+type rangeElem struct {
+	key, value ast.Expr
+	index      int
+}
+
+// rangeLoopOver returns the loop stmt ranges over when it defines its
+// variables and ranges over a composite literal, directly or through a
+// variable in tables; nil otherwise.
+func rangeLoopOver(stmt *ast.RangeStmt, info *types.Info, tables rangeTables) *rangeLoop {
+	if stmt.Tok != token.DEFINE || info == nil {
+		return nil
+	}
+	x := ast.Unparen(stmt.X)
+	var lit *ast.CompositeLit
+	switch x := x.(type) {
+	case *ast.CompositeLit:
+		lit = x
+	case *ast.Ident:
+		lit = tables[info.Uses[x]]
+	}
+	if lit == nil || len(lit.Elts) == 0 || len(lit.Elts) > maxUnrolledElements {
+		return nil
+	}
+	loop := &rangeLoop{}
+	if id, ok := stmt.Key.(*ast.Ident); ok {
+		loop.key = info.Defs[id]
+	}
+	if id, ok := stmt.Value.(*ast.Ident); ok {
+		loop.value = info.Defs[id]
+	}
+	if loop.key == nil && loop.value == nil {
+		return nil
+	}
+	for i, elt := range lit.Elts {
+		el := rangeElem{value: elt, index: i}
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			el.key, el.value = kv.Key, kv.Value
+		}
+		loop.elems = append(loop.elems, el)
+	}
+	return loop
+}
+
+// enclosingRangeLoop returns the unrollable loop whose body directly holds
+// call — stack ends with call — or nil. A call in a closure inside the loop
+// is left alone.
+func enclosingRangeLoop(stack []ast.Node, call *ast.CallExpr, info *types.Info, tables rangeTables) *rangeLoop {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncLit, *ast.FuncDecl:
+			return nil
+		case *ast.RangeStmt:
+			if call.Pos() < n.Body.Pos() {
+				return nil
+			}
+			return rangeLoopOver(n, info, tables)
+		}
+	}
+	return nil
+}
+
+// substitute returns expr for one element: the loop's key or value variable
+// is replaced by the element's key or value, and a field of the value
+// variable by the field of the element's struct literal. ok is false when
+// expr refers to the loop but the element does not supply the value.
+func (l *rangeLoop) substitute(expr ast.Expr, el rangeElem, info *types.Info) (out ast.Expr, used, ok bool) {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		switch obj := info.Uses[e]; {
+		case obj == nil:
+		case obj == l.key:
+			if el.key != nil {
+				return el.key, true, true
+			}
+			return &ast.BasicLit{ValuePos: el.value.Pos(), Kind: token.INT, Value: strconv.Itoa(el.index)}, true, true
+		case obj == l.value:
+			return el.value, true, true
+		}
+	case *ast.SelectorExpr:
+		id, isIdent := e.X.(*ast.Ident)
+		if !isIdent || l.value == nil || info.Uses[id] != l.value {
+			break
+		}
+		field := structLiteralField(el.value, e.Sel.Name, info)
+		return field, true, field != nil
+	}
+	return expr, false, true
+}
+
+// structLiteralField returns the value given to field in a struct literal
+// (or its address), keyed or positional; nil when the literal omits it.
+func structLiteralField(expr ast.Expr, field string, info *types.Info) ast.Expr {
+	if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		expr = u.X
+	}
+	lit, ok := ast.Unparen(expr).(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	var st *types.Struct
+	if t := info.TypeOf(lit); t != nil {
+		st, _ = t.Underlying().(*types.Struct)
+	}
+	for i, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if id, ok := kv.Key.(*ast.Ident); ok && id.Name == field {
+				return kv.Value
+			}
+			continue
+		}
+		if st != nil && i < st.NumFields() && st.Field(i).Name() == field {
+			return elt
+		}
+	}
+	return nil
+}
+
+// unrollRangeCall replaces the edge recorded for call, a call inside loop's
+// body, with one edge per element of the ranged literal when its arguments
+// refer to the loop's variables. Each edge carries the element's values as
+// its arguments and the element's position as its own, so a registration
+// loop over a route table registers each route. The edge is left as is when
+// any element does not supply a value the call reads.
+func unrollRangeCall(call *ast.CallExpr, loop *rangeLoop, recorded int, info *types.Info, pkgName string, fset *token.FileSet, metadata *Metadata, calleeMap map[string]*CallGraphEdge, argMap map[string]*CallArgument) {
+	if len(metadata.CallGraph) != recorded+1 {
+		return
+	}
+	last := metadata.CallGraph[recorded]
+	if last.ChainParent != nil || metadata.StringPool.GetString(last.Callee.Position) != getPosition(call.Pos(), fset) {
+		return
+	}
+
+	perElem := make([][]ast.Expr, len(loop.elems))
+	takesHandler := false
+	for i, el := range loop.elems {
+		perElem[i] = make([]ast.Expr, len(call.Args))
+		for j, arg := range call.Args {
+			out, used, ok := loop.substitute(arg, el, info)
+			if !ok {
+				return
+			}
+			perElem[i][j] = out
+			takesHandler = takesHandler || (used && isHandlerValueType(info.TypeOf(arg)))
+		}
+	}
+	// Only a call handed a function or interface from the table registers
+	// something per element; a loop encoding or logging each element is
+	// one call.
+	if !takesHandler {
+		return
+	}
+
+	edges := make([]CallGraphEdge, 0, len(loop.elems))
+	for i, el := range loop.elems {
+		edge := last
+		edge.Args = make([]*CallArgument, len(call.Args))
+		for j, expr := range perElem[i] {
+			edge.Args[j] = ExprToCallArgument(expr, info, pkgName, fset, metadata)
+			argMap[edge.Args[j].ID()] = edge.Args[j]
+		}
+		edge.ParamArgMap = map[string]CallArgument{}
+		edge.TypeParamMap = map[string]string{}
+		extractParamsAndTypeParams(call, info, edge.Args, edge.ParamArgMap, edge.TypeParamMap)
+
+		pos := metadata.StringPool.Get(getPosition(el.value.Pos(), fset))
+		edge.Position = pos
+		callee := edge.NewCall(last.Callee.Name, last.Callee.Pkg, pos, last.Callee.RecvType, last.Callee.Scope)
+		callee.SignatureStr = last.Callee.SignatureStr
+		edge.Callee = *callee
+		applyTypeParameterResolution(&edge)
+		edges = append(edges, edge)
+	}
+
+	metadata.CallGraph = append(metadata.CallGraph[:recorded], edges...)
+	for i := range edges {
+		e := &metadata.CallGraph[recorded+i]
+		calleeMap[e.Callee.InstanceID()] = e
+	}
+	if last.ParentFunction != nil {
+		unrollParentFunctionEdge(metadata, &last, metadata.CallGraph[recorded:])
+	}
+}
+
+// isHandlerValueType reports whether t is a func type or an interface, the
+// types a handler is held as (http.HandlerFunc, echo.HandlerFunc,
+// http.Handler).
+func isHandlerValueType(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	switch t.Underlying().(type) {
+	case *types.Signature, *types.Interface:
+		return true
+	}
+	return false
+}
+
+// unrollParentFunctionEdge swaps the closure-body index entry for the
+// unrolled edge with entries for its copies.
+func unrollParentFunctionEdge(metadata *Metadata, last *CallGraphEdge, copies []CallGraphEdge) {
+	id := last.ParentFunction.ID()
+	list := metadata.ParentFunctions[id]
+	for i, e := range list {
+		if e.Position != last.Position || e.Callee.InstanceID() != last.Callee.InstanceID() {
+			continue
+		}
+		replaced := append([]*CallGraphEdge{}, list[:i]...)
+		for j := range copies {
+			replaced = append(replaced, &copies[j])
+		}
+		metadata.ParentFunctions[id] = append(replaced, list[i+1:]...)
+		return
+	}
+}
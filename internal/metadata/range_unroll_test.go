@@ -0,0 +1,158 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+const rangeUnrollSrc = `package p
+
+import "net/http"
+
+type route struct {
+	pattern string
+	handler http.HandlerFunc
+}
+
+func a(w http.ResponseWriter, r *http.Request) {}
+func b(w http.ResponseWriter, r *http.Request) {}
+
+func register(mux *http.ServeMux) {
+	handlers := map[string]http.HandlerFunc{"GET /a": a, "GET /b": b}
+	for pattern, h := range handlers {
+		mux.HandleFunc(pattern, h)
+	}
+	for _, rt := range []route{{pattern: "GET /c", handler: a}, {"GET /d", b}} {
+		mux.HandleFunc(rt.pattern, rt.handler)
+	}
+	for i, h := range []http.HandlerFunc{a, b} {
+		go func() { mux.HandleFunc("GET /e", h) }()
+		_ = i
+	}
+	grown := map[string]http.HandlerFunc{"GET /f": a}
+	grown["GET /g"] = b
+	for pattern, h := range grown {
+		mux.HandleFunc(pattern, h)
+	}
+}
+`
+
+// rangeUnrollCalls type-checks rangeUnrollSrc and returns its range tables,
+// types.Info and each mux.HandleFunc call with the node stack leading to it.
+func rangeUnrollCalls(t *testing.T) (rangeTables, *types.Info, []*ast.CallExpr, [][]ast.Node) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", rangeUnrollSrc, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	info := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Defs:  map[*ast.Ident]types.Object{},
+		Uses:  map[*ast.Ident]types.Object{},
+	}
+	conf := types.Config{Importer: importer.Default()}
+	if _, err := conf.Check("p", fset, []*ast.File{file}, info); err != nil {
+		t.Fatalf("typecheck: %v", err)
+	}
+	tables := collectRangeTables(map[string]*ast.File{"p.go": file}, map[*ast.File]*types.Info{file: info})
+
+	var calls []*ast.CallExpr
+	var stacks [][]ast.Node
+	var stack []ast.Node
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "HandleFunc" {
+				calls = append(calls, call)
+				stacks = append(stacks, append([]ast.Node{}, stack...))
+			}
+		}
+		return true
+	})
+	if len(calls) != 4 {
+		t.Fatalf("found %d HandleFunc calls, want 4", len(calls))
+	}
+	return tables, info, calls, stacks
+}
+
+// substituted renders the arguments call gets for each element of loop.
+func substituted(t *testing.T, loop *rangeLoop, call *ast.CallExpr, info *types.Info) [][]string {
+	t.Helper()
+	var out [][]string
+	for _, el := range loop.elems {
+		var args []string
+		for _, arg := range call.Args {
+			expr, _, ok := loop.substitute(arg, el, info)
+			if !ok {
+				t.Fatalf("substitute(%s) not ok", types.ExprString(arg))
+			}
+			args = append(args, types.ExprString(expr))
+		}
+		out = append(out, args)
+	}
+	return out
+}
+
+func TestRangeUnroll(t *testing.T) {
+	tables, info, calls, stacks := rangeUnrollCalls(t)
+
+	t.Run("map table", func(t *testing.T) {
+		loop := enclosingRangeLoop(stacks[0], calls[0], info, tables)
+		if loop == nil {
+			t.Fatal("expected the loop over handlers to unroll")
+		}
+		got := substituted(t, loop, calls[0], info)
+		want := [][]string{{`"GET /a"`, "a"}, {`"GET /b"`, "b"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("struct slice literal", func(t *testing.T) {
+		loop := enclosingRangeLoop(stacks[1], calls[1], info, tables)
+		if loop == nil {
+			t.Fatal("expected the loop over []route to unroll")
+		}
+		got := substituted(t, loop, calls[1], info)
+		want := [][]string{{`"GET /c"`, "a"}, {`"GET /d"`, "b"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("call in a closure", func(t *testing.T) {
+		if loop := enclosingRangeLoop(stacks[2], calls[2], info, tables); loop != nil {
+			t.Error("a call inside a closure in the loop should not unroll")
+		}
+	})
+
+	t.Run("table written to", func(t *testing.T) {
+		if loop := enclosingRangeLoop(stacks[3], calls[3], info, tables); loop != nil {
+			t.Error("a table assigned to after its definition should not unroll")
+		}
+	})
+}
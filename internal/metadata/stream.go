@@ -0,0 +1,260 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Streamed metadata is a YAML stream of small documents rather than the one
+// document WriteMetadata writes: a header, then the string pool in chunks,
+// a document per package, the call graph in chunks and the framework
+// dependencies. Writing and reading it holds one document at a time on top
+// of the metadata itself, where decoding the single document builds a node
+// tree of the whole file first.
+const (
+	streamFormat  = "apispec-metadata-stream"
+	streamVersion = 1
+
+	// streamChunk is how many strings or call graph edges a document holds.
+	streamChunk = 1000
+
+	// gzipSuffix marks a compressed metadata file.
+	gzipSuffix = ".gz"
+)
+
+// streamHeaderPrefix starts every metadata stream; LoadMetadata looks for
+// it to tell a stream from a single document.
+var streamHeaderPrefix = []byte("format: " + streamFormat + "\n")
+
+// streamRecord is one document of a metadata stream. The header sets
+// Format and Version, with the counts of the records to come; every other
+// document sets one of the remaining fields.
+type streamRecord struct {
+	Format      string `yaml:"format,omitempty"`
+	Version     int    `yaml:"version,omitempty"`
+	StringCount int    `yaml:"string_count,omitempty"`
+	EdgeCount   int    `yaml:"edge_count,omitempty"`
+
+	Strings []string `yaml:"strings,omitempty"`
+
+	Package string           `yaml:"package,omitempty"`
+	Files   map[string]*File `yaml:"files,omitempty"`
+	Types   map[string]*Type `yaml:"types,omitempty"`
+
+	CallGraph []CallGraphEdge `yaml:"call_graph,omitempty"`
+
+	FrameworkDependencyList *FrameworkDependencyList `yaml:"framework_dependency_list,omitempty"`
+}
+
+// WriteMetadataStream writes meta to w as streamed metadata, encoding one
+// package or chunk at a time.
+func WriteMetadataStream(w io.Writer, meta *Metadata) error {
+	if meta == nil {
+		return fmt.Errorf("metadata cannot be nil")
+	}
+
+	var values []string
+	if meta.StringPool != nil {
+		values = meta.StringPool.values
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(streamRecord{
+		Format:      streamFormat,
+		Version:     streamVersion,
+		StringCount: len(values),
+		EdgeCount:   len(meta.CallGraph),
+	}); err != nil {
+		return err
+	}
+
+	for chunk := range slices.Chunk(values, streamChunk) {
+		if err := encoder.Encode(streamRecord{Strings: chunk}); err != nil {
+			return fmt.Errorf(errorFailedWriteStringPool, err)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(meta.Packages)) {
+		record := streamRecord{Package: name}
+		if pkg := meta.Packages[name]; pkg != nil {
+			record.Files, record.Types = pkg.Files, pkg.Types
+		}
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf(errorFailedWritePackages, err)
+		}
+	}
+
+	for chunk := range slices.Chunk(meta.CallGraph, streamChunk) {
+		if err := encoder.Encode(streamRecord{CallGraph: chunk}); err != nil {
+			return fmt.Errorf(errorFailedWriteCallGraph, err)
+		}
+	}
+
+	if meta.FrameworkDependencyList != nil {
+		if err := encoder.Encode(streamRecord{FrameworkDependencyList: meta.FrameworkDependencyList}); err != nil {
+			return err
+		}
+	}
+
+	return encoder.Close()
+}
+
+// ReadMetadataStream reads streamed metadata from r, decoding one document
+// at a time, and links it up as LoadMetadata does.
+func ReadMetadataStream(r io.Reader) (*Metadata, error) {
+	decoder := yaml.NewDecoder(r)
+
+	var header streamRecord
+	if err := decoder.Decode(&header); err != nil {
+		return nil, fmt.Errorf("failed to read metadata stream header: %w", err)
+	}
+	if header.Format != streamFormat {
+		return nil, fmt.Errorf("not a metadata stream: format %q", header.Format)
+	}
+	if header.Version != streamVersion {
+		return nil, fmt.Errorf("unsupported metadata stream version %d", header.Version)
+	}
+
+	metadata := &Metadata{
+		StringPool: &StringPool{
+			strings: make(map[string]int, header.StringCount),
+			values:  make([]string, 0, header.StringCount),
+		},
+		Packages:  make(map[string]*Package),
+		CallGraph: make([]CallGraphEdge, 0, header.EdgeCount),
+	}
+	for {
+		var record streamRecord
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case record.Strings != nil:
+			pool := metadata.StringPool
+			for _, s := range record.Strings {
+				pool.strings[s] = len(pool.values)
+				pool.values = append(pool.values, s)
+			}
+		case record.Package != "":
+			metadata.Packages[record.Package] = &Package{Files: record.Files, Types: record.Types}
+		case record.CallGraph != nil:
+			metadata.CallGraph = append(metadata.CallGraph, record.CallGraph...)
+		case record.FrameworkDependencyList != nil:
+			metadata.FrameworkDependencyList = record.FrameworkDependencyList
+		}
+	}
+
+	setupMetadataReferences(metadata)
+
+	// Process function return types to fill ResolvedType
+	metadata.ProcessFunctionReturnTypes()
+
+	return metadata, nil
+}
+
+// WriteMetadataStreamFile writes meta to filename as streamed metadata,
+// gzip-compressed when the name ends in ".gz".
+func WriteMetadataStreamFile(meta *Metadata, filename string) (err error) {
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, filePerm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	buffered := bufio.NewWriterSize(file, 64<<10)
+	var w io.Writer = buffered
+	var compressed *gzip.Writer
+	if strings.HasSuffix(filename, gzipSuffix) {
+		compressed = gzip.NewWriter(buffered)
+		w = compressed
+	}
+
+	if err := WriteMetadataStream(w, meta); err != nil {
+		return err
+	}
+	if compressed != nil {
+		if err := compressed.Close(); err != nil {
+			return err
+		}
+	}
+	return buffered.Flush()
+}
+
+// readMetadataFile decodes the metadata in filename: streamed metadata or a
+// single document, either of them gzip-compressed.
+func readMetadataFile(filename string) (*Metadata, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Printf("Failed to close file: %v", err)
+		}
+	}()
+
+	r := bufio.NewReader(file)
+	if magic, _ := r.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		decompressed, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = decompressed.Close() }()
+		r = bufio.NewReader(decompressed)
+	}
+
+	if header, _ := r.Peek(len(streamHeaderPrefix)); bytes.Equal(header, streamHeaderPrefix) {
+		return ReadMetadataStream(r)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var metadata Metadata
+	if err := yaml.Unmarshal(data, &metadata); err != nil {
+		return nil, err
+	}
+
+	setupMetadataReferences(&metadata)
+
+	// Process function return types to fill ResolvedType
+	metadata.ProcessFunctionReturnTypes()
+
+	return &metadata, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// streamTestMetadata builds metadata with more strings and edges than one
+// stream chunk holds.
+func streamTestMetadata() *Metadata {
+	sp := NewStringPool()
+	meta := &Metadata{
+		StringPool: sp,
+		Packages: map[string]*Package{
+			"example.com/app": {
+				Files: map[string]*File{
+					"main.go": {
+						Functions: map[string]*Function{
+							"main": {Name: sp.Get("main"), Pkg: sp.Get("example.com/app")},
+						},
+					},
+				},
+				Types: map[string]*Type{
+					"User": {Name: sp.Get("User"), Pkg: sp.Get("example.com/app"), Kind: sp.Get("struct")},
+				},
+			},
+			"example.com/app/empty": {},
+		},
+		FrameworkDependencyList: &FrameworkDependencyList{},
+	}
+	for i := range streamChunk + 10 {
+		meta.CallGraph = append(meta.CallGraph, CallGraphEdge{
+			Caller: Call{Name: sp.Get("main"), Pkg: sp.Get("example.com/app")},
+			Callee: Call{Name: sp.Get(fmt.Sprintf("handler%d", i)), Pkg: sp.Get("example.com/app")},
+		})
+	}
+	return meta
+}
+
+func TestMetadataStreamRoundTrip(t *testing.T) {
+	for _, name := range []string{"metadata.yaml", "metadata.yaml.gz"} {
+		t.Run(name, func(t *testing.T) {
+			meta := streamTestMetadata()
+			path := filepath.Join(t.TempDir(), name)
+			if err := WriteMetadataStreamFile(meta, path); err != nil {
+				t.Fatalf("WriteMetadataStreamFile failed: %v", err)
+			}
+
+			loaded, err := LoadMetadata(path)
+			if err != nil {
+				t.Fatalf("LoadMetadata failed: %v", err)
+			}
+			if !reflect.DeepEqual(loaded.StringPool.values, meta.StringPool.values) {
+				t.Errorf("string pool differs: got %d strings, want %d", len(loaded.StringPool.values), len(meta.StringPool.values))
+			}
+			if idx := loaded.StringPool.Get("handler7"); loaded.StringPool.GetString(idx) != "handler7" {
+				t.Errorf("string pool lookup broken after load")
+			}
+			if len(loaded.Packages) != 2 {
+				t.Fatalf("expected 2 packages, got %d", len(loaded.Packages))
+			}
+			if loaded.Packages["example.com/app"].Types["User"] == nil {
+				t.Error("expected type User to survive the round trip")
+			}
+			if len(loaded.CallGraph) != len(meta.CallGraph) {
+				t.Fatalf("expected %d edges, got %d", len(meta.CallGraph), len(loaded.CallGraph))
+			}
+			last := loaded.CallGraph[len(loaded.CallGraph)-1]
+			if got := loaded.StringPool.GetString(last.Callee.Name); got != fmt.Sprintf("handler%d", streamChunk+9) {
+				t.Errorf("edges out of order: last callee %q", got)
+			}
+			if last.Caller.Meta != loaded || last.Callee.Meta != loaded {
+				t.Error("expected edges to be linked to the loaded metadata")
+			}
+			if loaded.FrameworkDependencyList == nil {
+				t.Error("expected framework dependency list to survive the round trip")
+			}
+		})
+	}
+}
+
+func TestWriteMetadataStreamNil(t *testing.T) {
+	if err := WriteMetadataStream(&bytes.Buffer{}, nil); err == nil {
+		t.Error("expected error when writing nil metadata")
+	}
+}
+
+func TestReadMetadataStreamRejectsOtherVersions(t *testing.T) {
+	input := "format: apispec-metadata-stream\nversion: 99\n"
+	if _, err := ReadMetadataStream(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), "version 99") {
+		t.Errorf("expected unsupported version error, got %v", err)
+	}
+	if _, err := ReadMetadataStream(strings.NewReader("packages: {}\n")); err == nil {
+		t.Error("expected error for a single document")
+	}
+}
+
+func TestLoadMetadataGzipDocument(t *testing.T) {
+	meta := streamTestMetadata()
+	dir := t.TempDir()
+	plainPath := filepath.Join(dir, "metadata.yaml")
+	if err := WriteMetadata(meta, plainPath); err != nil {
+		t.Fatalf("WriteMetadata failed: %v", err)
+	}
+	plain, err := os.ReadFile(plainPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(plain); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "metadata.yaml.gz")
+	if err := os.WriteFile(path, compressed.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadMetadata(path)
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if len(loaded.CallGraph) != len(meta.CallGraph) {
+		t.Errorf("expected %d edges, got %d", len(meta.CallGraph), len(loaded.CallGraph))
+	}
+}
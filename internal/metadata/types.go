@@ -160,6 +160,20 @@ type Metadata struct {
 	// Current module path for external type detection
 	CurrentModulePath string `yaml:"-"`
 
+	// LocalModulePaths are the modules go.mod replaces with a local
+	// directory (`replace example.com/shared => ../shared`). Their packages
+	// are analyzed and classified as project code, like the main module's.
+	LocalModulePaths []string `yaml:"-"`
+
+	// LiteralExamples maps struct types ("pkgpath.Name") to the constant
+	// values struct literals give their fields, by Go field name (see
+	// recordLiteralExamples and CollectTestFixtures).
+	LiteralExamples map[string]map[string]any `yaml:"-"`
+
+	// RequestFixtures lists the JSON request bodies the analyzed packages'
+	// tests send; filled by CollectTestFixtures.
+	RequestFixtures []RequestFixture `yaml:"-"`
+
 	// ExternalTypes records facts about external (third-party) named types
 	// referenced anywhere in the analyzed code, keyed by every name form
 	// under which the type may later be looked up (full import path and
@@ -408,6 +422,7 @@ type Type struct {
 	Methods       []Method `yaml:"methods,omitempty"`
 	Comments      int      `yaml:"comments,omitempty"`
 	Tags          []int    `yaml:"tags,omitempty"`
+	Position      int      `yaml:"position,omitempty"`
 
 	// Declared type-parameter names for generic types, e.g. ["T"] for
 	// `type Page[T any] struct{...}`. The spec layer zips these positionally
@@ -453,6 +468,9 @@ type Method struct {
 
 	// map of variable name to all assignments (for alias/reassignment tracking)
 	AssignmentMap map[string][]Assignment `yaml:"assignments,omitempty"`
+
+	// OptionalBody is Function.OptionalBody for a method.
+	OptionalBody []LineRange `yaml:"optional_body,omitempty"`
 }
 
 // Function represents a function
@@ -486,6 +504,11 @@ type Function struct {
 	// body, so a net/http handler that branches on the verb can be split into
 	// one operation per HTTP method. Empty for handlers that don't dispatch.
 	MethodDispatch []MethodBranch `yaml:"method_dispatch,omitempty"`
+
+	// OptionalBody records where the function reads the request body only
+	// conditionally (see detectOptionalBody), so a body decoded there is not
+	// marked required.
+	OptionalBody []LineRange `yaml:"optional_body,omitempty"`
 }
 
 // MethodBranch is one arm of an `r.Method` dispatch: the HTTP method(s) it
@@ -497,6 +520,17 @@ type MethodBranch struct {
 	EndLine   int      `yaml:"end_line,omitempty"`   // last line of the branch body (inclusive)
 }
 
+// LineRange is an inclusive range of source lines within one file.
+type LineRange struct {
+	StartLine int `yaml:"start_line,omitempty"`
+	EndLine   int `yaml:"end_line,omitempty"`
+}
+
+// Contains reports whether line falls within r.
+func (r LineRange) Contains(line int) bool {
+	return line >= r.StartLine && line <= r.EndLine
+}
+
 // Variable represents a variable
 type Variable struct {
 	Name          int         `yaml:"name,omitempty"`
@@ -1127,7 +1161,10 @@ type TrackerLimits struct {
 	MaxChildrenPerNode int
 	MaxArgsPerFunction int
 	MaxNestedArgsDepth int
-	MaxRecursionDepth  int
+	// MaxRecursionDepth caps how often a function may repeat on one call
+	// path of the eager tracker tree. 0 or less is the same as 1: each
+	// function is followed once per path.
+	MaxRecursionDepth int
 }
 
 // ProcessFunctionReturnTypes processes all functions and methods in the metadata
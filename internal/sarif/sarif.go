@@ -0,0 +1,154 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sarif is the subset of SARIF 2.1.0 apispec writes its reports in:
+// one run of the apispec driver, its rules, and results located at a file
+// and line, a spec location, or both. Code scanning and the other CI
+// annotators read the rule, level, message and physical location of each
+// result.
+package sarif
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Version is the SARIF version a Log is written in.
+const Version = "2.1.0"
+
+// Levels of a rule or result.
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+)
+
+// Log is a SARIF log: the document a report is written as.
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run is one run of a tool; Properties carries the run's totals.
+type Run struct {
+	Tool       Tool           `json:"tool"`
+	Results    []Result       `json:"results"`
+	Properties map[string]int `json:"properties,omitempty"`
+}
+
+// Tool describes the tool of a Run.
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver is the tool's main component and the rules it checks.
+type Driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri"`
+	Rules          []Rule `json:"rules"`
+}
+
+// Rule is a check the driver makes; results refer to it by index.
+type Rule struct {
+	ID                   string        `json:"id"`
+	ShortDescription     *Message      `json:"shortDescription,omitempty"`
+	DefaultConfiguration Configuration `json:"defaultConfiguration"`
+}
+
+// Configuration is a rule's default level.
+type Configuration struct {
+	Level string `json:"level"`
+}
+
+// Message is the plain-text message of a result or rule.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Result is one finding.
+type Result struct {
+	RuleID    string     `json:"ruleId"`
+	RuleIndex int        `json:"ruleIndex"`
+	Level     string     `json:"level"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+}
+
+// Location places a result in a file, in the spec, or both.
+type Location struct {
+	PhysicalLocation *PhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []LogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+// PhysicalLocation is a file and, when known, a region of it.
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           *Region          `json:"region,omitempty"`
+}
+
+// ArtifactLocation names a file, relative to the root of the scanned module.
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// Region is a 1-based line and column of a file.
+type Region struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// LogicalLocation names a place in the spec: "GET /users/{id}",
+// "components.schemas.User".
+type LogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// NewLog returns a Log of one run of apispec at toolVersion, checking rules,
+// with results.
+func NewLog(toolVersion string, rules []Rule, results []Result) Log {
+	if results == nil {
+		results = []Result{}
+	}
+	return Log{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: Version,
+		Runs: []Run{{
+			Tool: Tool{Driver: Driver{
+				Name:           "apispec",
+				Version:        toolVersion,
+				InformationURI: "https://github.com/ehabterra/apispec",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+// FileLocation returns the PhysicalLocation of line (and column, when not
+// zero) of file; a zero line locates the whole file.
+func FileLocation(file string, line, column int) *PhysicalLocation {
+	loc := &PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: file}}
+	if line > 0 {
+		loc.Region = &Region{StartLine: line, StartColumn: column}
+	}
+	return loc
+}
+
+// Write writes l to w as indented JSON.
+func (l Log) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(l)
+}
@@ -103,6 +103,11 @@ func (r *bodySourceResolver) check(arg *metadata.CallArgument, edge *metadata.Ca
 
 	switch arg.GetKind() {
 	case metadata.KindSelector, metadata.KindCall:
+		// body, _ := io.ReadAll(r.Body) reads the request body as much as
+		// r.Body itself does.
+		if src := r.wrappedReader(arg); src != nil && r.check(src, edge, visited) {
+			return true
+		}
 		root, segs := peelAccessorChain(arg)
 		if root != nil && r.chainMatches(root, segs, edge) {
 			return true
@@ -120,6 +125,39 @@ func (r *bodySourceResolver) check(arg *metadata.CallArgument, edge *metadata.Ca
 	return false
 }
 
+// bodyReaderWrappers maps the functions that read or wrap an io.Reader,
+// by short package-qualified name, to the index of the reader argument.
+var bodyReaderWrappers = map[string]int{
+	"io.ReadAll":          0,
+	"ioutil.ReadAll":      0,
+	"io.LimitReader":      0,
+	"bufio.NewReader":     0,
+	"http.MaxBytesReader": 1,
+}
+
+// wrappedReader returns the reader argument of a call to one of
+// bodyReaderWrappers, or nil.
+func (r *bodySourceResolver) wrappedReader(arg *metadata.CallArgument) *metadata.CallArgument {
+	if arg.GetKind() != metadata.KindCall || arg.Fun == nil {
+		return nil
+	}
+	var name string
+	if arg.Edge != nil {
+		name = r.contextProvider.GetString(arg.Edge.Callee.Pkg) + "." + r.contextProvider.GetString(arg.Edge.Callee.Name)
+	} else if arg.Fun.GetKind() == metadata.KindSelector && arg.Fun.X != nil && arg.Fun.Sel != nil {
+		name = arg.Fun.X.GetName() + "." + arg.Fun.Sel.GetName()
+	}
+	i, ok := bodyReaderWrappers[shortTypeName(name)]
+	if !ok || i >= len(arg.Args) {
+		return nil
+	}
+	src := arg.Args[i]
+	if src != nil && src.Meta == nil {
+		src.Meta = arg.Meta
+	}
+	return src
+}
+
 // chainMatches reports whether the (root, segs) chain points at a request
 // body. The root's type (or its traced origin's type) must match one of the
 // configured TypeRegexes and the dotted accessor must match one of the
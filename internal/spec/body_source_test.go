@@ -217,3 +217,39 @@ func TestBodySourceResolver_Disabled(t *testing.T) {
 		t.Fatalf("disabled resolver must be permissive")
 	}
 }
+
+// TestBodySourceResolver_WrappedReader traces a body read through io.ReadAll,
+// http.MaxBytesReader and the like to the reader they wrap.
+func TestBodySourceResolver_WrappedReader(t *testing.T) {
+	meta := newTestMeta()
+	cp := NewContextProvider(meta)
+	r := newBodySourceResolver(&APISpecConfig{Framework: FrameworkConfig{RequestContext: netHTTPRequestContext}}, cp)
+
+	call := func(pkg, fn string, args ...*metadata.CallArgument) *metadata.CallArgument {
+		a := mkMethodCall(meta, mkIdent(meta, pkg, ""), mkIdent(meta, fn, ""))
+		a.Args = args
+		return a
+	}
+	body := func(root string, typ string) *metadata.CallArgument {
+		return mkSelector(meta, mkIdent(meta, root, typ), mkIdent(meta, "Body", ""))
+	}
+	w := mkIdent(meta, "w", "net/http.ResponseWriter")
+
+	cases := []struct {
+		name string
+		expr *metadata.CallArgument
+		want bool
+	}{
+		{"io.ReadAll(r.Body)", call("io", "ReadAll", body("r", "*net/http.Request")), true},
+		{"io.ReadAll(http.MaxBytesReader(w, r.Body, n))", call("io", "ReadAll", call("http", "MaxBytesReader", w, body("r", "*net/http.Request"))), true},
+		{"io.ReadAll(f.Body)", call("io", "ReadAll", body("f", "*os.File")), false},
+		{"strings.ToUpper(r.Body)", call("strings", "ToUpper", body("r", "*net/http.Request")), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := r.IsRequestSource(tc.expr, &metadata.CallGraphEdge{}); got != tc.want {
+				t.Fatalf("IsRequestSource(%s) = %v, want %v", tc.name, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/diag"
+)
+
+// ShareComponents moves the component schemas of s into lib, a components
+// library published at libRef, and points every $ref to them in s at the
+// library ("shared.yaml#/components/schemas/User"). A nil lib starts a new
+// library. Schemas lib already has are reused when they are the same; one
+// that differs stays in s, reported, along with the schemas referring to it,
+// so several services can publish into one library without overwriting each
+// other's models. It returns the library.
+func ShareComponents(s, lib *OpenAPISpec, libRef string) (*OpenAPISpec, []diag.Diagnostic) {
+	if lib == nil {
+		lib = &OpenAPISpec{
+			OpenAPI: s.OpenAPI,
+			Info:    Info{Title: "Shared components", Version: s.Info.Version},
+			Paths:   map[string]PathItem{},
+		}
+	}
+	if lib.Paths == nil {
+		lib.Paths = map[string]PathItem{}
+	}
+	if lib.Components == nil {
+		lib.Components = &Components{}
+	}
+	if lib.Components.Schemas == nil {
+		lib.Components.Schemas = map[string]*Schema{}
+	}
+	if s.Components == nil || len(s.Components.Schemas) == 0 {
+		return lib, nil
+	}
+	schemas := s.Components.Schemas
+	names := slices.Sorted(maps.Keys(schemas))
+
+	var diags []diag.Diagnostic
+	local := map[string]bool{}
+	for _, name := range names {
+		if existing, ok := lib.Components.Schemas[name]; ok && !sameSchema(existing, schemas[name]) {
+			local[name] = true
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Category: "components",
+				Message:  fmt.Sprintf("schema %s differs from the one in %s; it stays in the spec", name, libRef),
+				Help:     "align the Go type with the shared model, or rename it",
+			})
+		}
+	}
+	// A shared schema may only refer to shared ones: inside the library its
+	// refs resolve against the library's schemas.
+	for changed := len(local) > 0; changed; {
+		changed = false
+		for _, name := range names {
+			if local[name] {
+				continue
+			}
+			refs := map[string]bool{}
+			schemaRefNames(schemas[name], refs, map[*Schema]bool{})
+			for ref := range refs {
+				if local[ref] {
+					local[name], changed = true, true
+					break
+				}
+			}
+		}
+	}
+
+	ids := map[string]string{}
+	for _, name := range names {
+		if local[name] {
+			continue
+		}
+		if _, ok := lib.Components.Schemas[name]; !ok {
+			lib.Components.Schemas[name] = schemas[name]
+		}
+		ids[refComponentsSchemasPrefix+name] = libRef + refComponentsSchemasPrefix + name
+		delete(schemas, name)
+	}
+	if len(schemas) == 0 {
+		s.Components.Schemas = nil
+	}
+	rebaseDocumentRefs(s, ids)
+	if c := s.Components; len(c.Schemas)+len(c.Responses)+len(c.Parameters)+len(c.Examples)+len(c.RequestBodies)+
+		len(c.Headers)+len(c.SecuritySchemes)+len(c.Links)+len(c.Callbacks) == 0 {
+		s.Components = nil
+	}
+	markBooleanBounds(lib)
+	return lib, diags
+}
+
+// sameSchema reports whether a and b encode to the same JSON, so a schema
+// read back from a library file compares equal to the generated one.
+func sameSchema(a, b *Schema) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
+
+// schemaRefNames adds the names of the components s refers to into refs.
+func schemaRefNames(s *Schema, refs map[string]bool, visiting map[*Schema]bool) {
+	if s == nil || visiting[s] {
+		return
+	}
+	visiting[s] = true
+	if name, ok := strings.CutPrefix(s.Ref, refComponentsSchemasPrefix); ok {
+		refs[name] = true
+	}
+	for _, sub := range []*Schema{s.Items, s.AdditionalProperties, s.Not} {
+		schemaRefNames(sub, refs, visiting)
+	}
+	for _, list := range [][]*Schema{s.AllOf, s.OneOf, s.AnyOf} {
+		for _, sub := range list {
+			schemaRefNames(sub, refs, visiting)
+		}
+	}
+	for _, prop := range s.Properties {
+		schemaRefNames(prop, refs, visiting)
+	}
+}
+
+// rebaseDocumentRefs replaces the component $refs found in ids everywhere in
+// s: operations, webhooks, callbacks and the components themselves.
+func rebaseDocumentRefs(s *OpenAPISpec, ids map[string]string) {
+	mapDocumentSchemas(s, func(schema *Schema) *Schema {
+		return rebaseRefs(schema, ids, map[*Schema]bool{})
+	})
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// serviceSpec is a spec whose POST /orders takes an Order (referring to
+// Item) and answers with an Error, and whose Item also has a header use.
+func serviceSpec() *OpenAPISpec {
+	ref := func(name string) *Schema { return &Schema{Ref: refComponentsSchemasPrefix + name} }
+	return &OpenAPISpec{
+		OpenAPI: "3.1.1",
+		Info:    Info{Title: "orders", Version: "2.0.0"},
+		Paths: map[string]PathItem{
+			"/orders": {Post: &Operation{
+				Parameters:  []Parameter{{Name: "item", In: "query", Schema: ref("Item")}},
+				RequestBody: &RequestBody{Content: map[string]MediaType{"application/json": {Schema: ref("Order")}}},
+				Responses: map[string]Response{
+					"400": {Description: "bad", Content: map[string]MediaType{"application/json": {Schema: ref("Error")}}},
+				},
+			}},
+		},
+		Components: &Components{Schemas: map[string]*Schema{
+			"Order": {Type: "object", Properties: map[string]*Schema{"items": {Type: "array", Items: ref("Item")}}},
+			"Item":  {Type: "object", Properties: map[string]*Schema{"sku": {Type: "string"}}},
+			"Error": {Type: "object", Properties: map[string]*Schema{"message": {Type: "string"}}},
+		}},
+	}
+}
+
+func TestShareComponents_NewLibrary(t *testing.T) {
+	s := serviceSpec()
+	lib, diags := ShareComponents(s, nil, "../shared.yaml")
+	if len(diags) != 0 {
+		t.Errorf("diagnostics = %v, want none", diags)
+	}
+	if got := slices.Sorted(maps.Keys(lib.Components.Schemas)); !slices.Equal(got, []string{"Error", "Item", "Order"}) {
+		t.Errorf("library schemas = %v", got)
+	}
+	if lib.OpenAPI != "3.1.1" || lib.Info.Version != "2.0.0" || lib.Paths == nil {
+		t.Errorf("library header = %q %+v paths=%v", lib.OpenAPI, lib.Info, lib.Paths)
+	}
+	if lib.Components.Schemas["Order"].Properties["items"].Items.Ref != "#/components/schemas/Item" {
+		t.Error("refs inside the library should stay local to it")
+	}
+	if s.Components != nil {
+		t.Errorf("service components = %+v, want none", s.Components)
+	}
+	op := s.Paths["/orders"].Post
+	for where, got := range map[string]string{
+		"parameter":    op.Parameters[0].Schema.Ref,
+		"request body": op.RequestBody.Content["application/json"].Schema.Ref,
+		"response":     op.Responses["400"].Content["application/json"].Schema.Ref,
+	} {
+		if !strings.HasPrefix(got, "../shared.yaml#/components/schemas/") {
+			t.Errorf("%s $ref = %q, want it in the library", where, got)
+		}
+	}
+	if issues := ValidateSpec(s); len(issues) != 0 {
+		t.Errorf("service spec issues: %v", issues)
+	}
+}
+
+// A schema the library already defines differently stays in the service,
+// and so does every schema referring to it; identical ones are reused.
+func TestShareComponents_ExistingLibrary(t *testing.T) {
+	lib := &OpenAPISpec{OpenAPI: "3.1.1", Info: Info{Title: "Shared components"}, Components: &Components{Schemas: map[string]*Schema{
+		"Item":  {Type: "object", Properties: map[string]*Schema{"sku": {Type: "integer"}}},
+		"Error": {Type: "object", Properties: map[string]*Schema{"message": {Type: "string"}}},
+		"Other": {Type: "string"},
+	}}}
+	s := serviceSpec()
+	lib, diags := ShareComponents(s, lib, "shared.yaml")
+
+	if len(diags) != 1 || !strings.Contains(diags[0].Message, "schema Item differs") {
+		t.Errorf("diagnostics = %v, want the Item conflict", diags)
+	}
+	if got := slices.Sorted(maps.Keys(lib.Components.Schemas)); !slices.Equal(got, []string{"Error", "Item", "Other"}) {
+		t.Errorf("library schemas = %v", got)
+	}
+	if lib.Components.Schemas["Item"].Properties["sku"].Type != "integer" {
+		t.Error("the library's Item was overwritten")
+	}
+	if got := slices.Sorted(maps.Keys(s.Components.Schemas)); !slices.Equal(got, []string{"Item", "Order"}) {
+		t.Errorf("service schemas = %v, want Item and the Order referring to it", got)
+	}
+	op := s.Paths["/orders"].Post
+	if got := op.Responses["400"].Content["application/json"].Schema.Ref; got != "shared.yaml#/components/schemas/Error" {
+		t.Errorf("Error $ref = %q", got)
+	}
+	if got := op.Parameters[0].Schema.Ref; got != "#/components/schemas/Item" {
+		t.Errorf("Item $ref = %q, want the service's own", got)
+	}
+	if issues := ValidateSpec(s); len(issues) != 0 {
+		t.Errorf("service spec issues: %v", issues)
+	}
+}
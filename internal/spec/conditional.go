@@ -0,0 +1,73 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "net/http"
+
+// conditionalHeaderDocs describes the RFC 9110 precondition headers a handler
+// reads, keyed by canonical header name.
+var conditionalHeaderDocs = map[string]string{
+	"If-None-Match":       "Entity tags of the representation the client holds. A GET answers 304 Not Modified while one still matches; any other method fails with 412 (`*` fails when the resource exists).",
+	"If-Modified-Since":   "Date of the representation the client holds. A GET answers 304 Not Modified when nothing changed since.",
+	"If-Match":            "Entity tag the client last saw. The request fails with 412 Precondition Failed when the resource has changed.",
+	"If-Unmodified-Since": "Date the client last saw the resource. The request fails with 412 Precondition Failed when it has changed since.",
+}
+
+// applyConditionalRequests documents the conditional requests a handler
+// supports, detected from the precondition headers it reads: the header
+// parameters get a description, and the operation gains 304 Not Modified
+// (GET/HEAD reading If-None-Match or If-Modified-Since) and 412 Precondition
+// Failed (If-Match, If-Unmodified-Since, or If-None-Match on any other
+// method). It runs before applyResponseHeaders, so a 304 repeats the ETag /
+// Last-Modified the handler sets. Responses the handler writes itself are
+// kept as detected.
+func applyConditionalRequests(op *Operation, method string) {
+	if op == nil {
+		return
+	}
+	read := map[string]bool{}
+	for i := range op.Parameters {
+		p := &op.Parameters[i]
+		if p.In != "header" {
+			continue
+		}
+		name := http.CanonicalHeaderKey(p.Name)
+		doc, ok := conditionalHeaderDocs[name]
+		if !ok {
+			continue
+		}
+		read[name] = true
+		if p.Description == "" {
+			p.Description = doc
+		}
+	}
+	if len(read) == 0 {
+		return
+	}
+
+	safe := method == http.MethodGet || method == http.MethodHead
+	notModified := safe && (read["If-None-Match"] || read["If-Modified-Since"])
+	preconditionFailed := read["If-Match"] || read["If-Unmodified-Since"] || (!safe && read["If-None-Match"])
+
+	if op.Responses == nil {
+		op.Responses = map[string]Response{}
+	}
+	if _, ok := op.Responses["304"]; notModified && !ok {
+		op.Responses["304"] = Response{Description: "Not Modified"}
+	}
+	if _, ok := op.Responses["412"]; preconditionFailed && !ok {
+		op.Responses["412"] = Response{Description: "Precondition Failed"}
+	}
+}
@@ -40,18 +40,29 @@ type FrameworkConfig struct {
 	// A registration like `r.Method(GET, "/health", deps.Health)` names no method
 	// at all, so without this the handler's body is unreachable and the route
 	// yields no params, request body, response, or summary (issue #204).
-	// Frameworks whose handlers are plain func types (gin, echo, fiber) leave
-	// this empty. Expansion only follows a method the concrete type actually
-	// declares, so an unrelated value in a handler position resolves to nothing
-	// rather than to a guess.
+	// Frameworks whose handlers are plain func types (gin, fiber) leave this
+	// empty; echo sets it for the http.Handler values echo.WrapHandler adapts.
+	// Expansion only follows a method the concrete type actually declares, so
+	// an unrelated value in a handler position resolves to nothing rather than
+	// to a guess.
 	HandlerInterfaceMethods []string `yaml:"handlerInterfaceMethods,omitempty" json:"handlerInterfaceMethods,omitempty"`
 
+	// HandlerAdapters recognise calls that adapt another handler to the
+	// framework's handler type (echo.WrapHandler(h), http.HandlerFunc(fn)).
+	// A route registered with one documents the handler it adapts.
+	HandlerAdapters []HandlerAdapterPattern `yaml:"handlerAdapters,omitempty" json:"handlerAdapters,omitempty"`
+
 	// Request body extraction patterns
 	RequestBodyPatterns []RequestBodyPattern `yaml:"requestBodyPatterns" json:"requestBodyPatterns,omitempty"`
 
 	// Response extraction patterns
 	ResponsePatterns []ResponsePattern `yaml:"responsePatterns" json:"responsePatterns,omitempty"`
 
+	// ResponseHelpers name application helpers that write the response on the
+	// handler's behalf (respondWithSuccess(w, data), respondWithError(w, msg,
+	// status)). See ResponseHelperPattern.
+	ResponseHelpers []ResponseHelperPattern `yaml:"responseHelpers,omitempty" json:"responseHelpers,omitempty"`
+
 	// Parameter extraction patterns
 	ParamPatterns []ParamPattern `yaml:"paramPatterns" json:"paramPatterns,omitempty"`
 
@@ -72,6 +83,21 @@ type FrameworkConfig struct {
 	// actually originate from an HTTP request.
 	RequestContext RequestContextConfig `yaml:"requestContext,omitempty" json:"requestContext,omitempty"`
 
+	// Streaming/upgrade patterns. These recognise calls inside a handler that
+	// turn the route into a long-lived connection (a websocket upgrade, a
+	// server-sent event stream) instead of a plain request/response exchange.
+	ProtocolPatterns []ProtocolPattern `yaml:"protocolPatterns,omitempty" json:"protocolPatterns,omitempty"`
+
+	// ResponseHeaderPatterns recognise calls inside a handler that set a
+	// response header (w.Header().Set, gin's c.Header, fiber's c.Set). The
+	// header names are documented on the operation's responses.
+	ResponseHeaderPatterns []ResponseHeaderPattern `yaml:"responseHeaderPatterns,omitempty" json:"responseHeaderPatterns,omitempty"`
+
+	// ValidationPatterns recognise route-level validation middleware built from
+	// a rules struct (validate.Body(CreateUserRules{})); the struct's
+	// constraints are merged into that route's request body schema.
+	ValidationPatterns []ValidationPattern `yaml:"validationPatterns,omitempty" json:"validationPatterns,omitempty"`
+
 	// ResponseContext is the write-side mirror of RequestContext: it identifies
 	// the HTTP response writer so a generic encoder (json.NewEncoder(x).Encode(v))
 	// is only treated as a response when x traces to the response writer. Used to
@@ -79,6 +105,15 @@ type FrameworkConfig struct {
 	ResponseContext ResponseContextConfig `yaml:"responseContext,omitempty" json:"responseContext,omitempty"`
 }
 
+// DeclaresPatterns reports whether f configures any extraction pattern.
+func (f *FrameworkConfig) DeclaresPatterns() bool {
+	return len(f.RoutePatterns) > 0 || len(f.RequestBodyPatterns) > 0 ||
+		len(f.ResponsePatterns) > 0 || len(f.ResponseHelpers) > 0 ||
+		len(f.ParamPatterns) > 0 || len(f.MountPatterns) > 0 ||
+		len(f.SecurityPatterns) > 0 || len(f.ProtocolPatterns) > 0 ||
+		len(f.ResponseHeaderPatterns) > 0 || len(f.ValidationPatterns) > 0
+}
+
 // ResponseContextConfig identifies the HTTP response writer for a framework so
 // an encoder's write destination can be traced to it — the write-side mirror of
 // RequestContextConfig (issue #170). Classification is by PROVENANCE, not type
@@ -182,6 +217,22 @@ type RoutePattern struct {
 	PathArgIndex    int `yaml:"pathArgIndex,omitempty" json:"pathArgIndex,omitempty"`       // Which arg contains path
 	HandlerArgIndex int `yaml:"handlerArgIndex,omitempty" json:"handlerArgIndex,omitempty"` // Which arg contains handler
 
+	// Method is the HTTP method of every matched call, for wrappers that fix
+	// the verb (srv.GetJSON("/users", h)). A verb prefix on the path still
+	// wins when MethodFromPath is set.
+	Method string `yaml:"method,omitempty" json:"method,omitempty"`
+
+	// Methods are the HTTP methods every matched call registers, for calls
+	// routing several verbs to one handler (gin's r.Any("/x", h)). The route
+	// yields an operation per method.
+	Methods []string `yaml:"methods,omitempty" json:"methods,omitempty"`
+
+	// FileServer marks a call serving the files of a file system under the
+	// path (gin's r.StaticFS("/assets", fs)) rather than registering a
+	// handler. The route is documented at path/{filepath} and answers with
+	// the file's bytes; Method or Methods give its verbs.
+	FileServer bool `yaml:"fileServer,omitempty" json:"fileServer,omitempty"`
+
 	// Extraction hints
 	MethodFromCall    bool `yaml:"methodFromCall,omitempty" json:"methodFromCall,omitempty"`       // Extract method from function name
 	MethodFromHandler bool `yaml:"methodFromHandler,omitempty" json:"methodFromHandler,omitempty"` // Extract method from handler function name
@@ -215,6 +266,13 @@ type RequestBodyPattern struct {
 	TypeFromReturn bool `yaml:"typeFromReturn,omitempty" json:"typeFromReturn,omitempty"` // Extract type from return value
 	Deref          bool `yaml:"deref,omitempty" json:"deref,omitempty"`                   // Dereference pointer types
 
+	// BodyType is the body's Go type when the call itself fixes it rather
+	// than an argument carrying it — jsonpatch.DecodePatch always reads a
+	// jsonpatch.Patch. ContentType overrides defaults.requestContentType for
+	// bodies this pattern matches.
+	BodyType    string `yaml:"bodyType,omitempty" json:"bodyType,omitempty"`
+	ContentType string `yaml:"contentType,omitempty" json:"contentType,omitempty"`
+
 	// Body-source verification. When RequireRequestSource is true, the
 	// matcher only accepts the call if its data source can be traced back to
 	// a request-context body accessor (see FrameworkConfig.RequestContext).
@@ -255,6 +313,15 @@ type ResponsePattern struct {
 	DefaultStatus int `yaml:"defaultStatus,omitempty" json:"defaultStatus,omitempty"`
 	// DefaultContentType overrides the config default content type when set
 	DefaultContentType string `yaml:"defaultContentType,omitempty" json:"defaultContentType,omitempty"`
+	// ContentTypeByCall maps a matched call's name, case-insensitively, to
+	// the content type it writes (XML: application/xml), so one catch-all
+	// pattern documents each renderer with its own media type.
+	ContentTypeByCall map[string]string `yaml:"contentTypeByCall,omitempty" json:"contentTypeByCall,omitempty"`
+	// BodyType is the body's Go type when the call itself fixes it rather
+	// than an argument carrying it — an error helper always writes its
+	// error envelope. Named as in source (apierr.Problem) or by import path;
+	// used when TypeFromArg is false.
+	BodyType string `yaml:"bodyType,omitempty" json:"bodyType,omitempty"`
 
 	// RequireResponseDestination gates the pattern on write-destination: the
 	// encoded value is only a response when its destination writer traces (via
@@ -275,6 +342,123 @@ type ResponsePattern struct {
 	CalleeRecvTypePatterns []string `yaml:"calleeRecvTypePatterns,omitempty" json:"calleeRecvTypePatterns,omitempty"`
 }
 
+// ResponseHelperPattern describes a response helper: a function the handler
+// calls instead of writing the response itself. A call to a matching helper is
+// read as the response at the handler's call site — the body from the argument
+// at BodyArgIndex, the status from StatusArgIndex — so the payload keeps its
+// concrete type instead of the `any` the helper encodes internally. When the
+// helper takes a body argument the walk does not descend into it; otherwise
+// (BodyArgIndex -1) the helper's own write still supplies the body and only the
+// status comes from the call site.
+//
+// Argument indexes are zero-based; -1, or leaving the index out, means the
+// helper has no such argument.
+// Example:
+//
+//	responseHelpers:
+//	  - callRegex: ^respondWithSuccess$
+//	    bodyArgIndex: 1
+//	    statusArgIndex: -1
+//	    defaultStatus: 200
+//	  - callRegex: ^respondWithError$
+//	    bodyArgIndex: -1
+//	    statusArgIndex: 2
+type ResponseHelperPattern struct {
+	// CallRegex matches the helper's name; RecvTypeRegex optionally narrows a
+	// method helper to its receiver type.
+	CallRegex     string `yaml:"callRegex" json:"callRegex"`
+	RecvTypeRegex string `yaml:"recvTypeRegex,omitempty" json:"recvTypeRegex,omitempty"`
+
+	// Pointers, so an omitted index is told apart from argument 0.
+	BodyArgIndex   *int `yaml:"bodyArgIndex,omitempty" json:"bodyArgIndex,omitempty"`
+	StatusArgIndex *int `yaml:"statusArgIndex,omitempty" json:"statusArgIndex,omitempty"`
+
+	// DefaultStatus is used when StatusArgIndex is -1 or the argument does not
+	// resolve to a constant.
+	DefaultStatus      int    `yaml:"defaultStatus,omitempty" json:"defaultStatus,omitempty"`
+	DefaultContentType string `yaml:"defaultContentType,omitempty" json:"defaultContentType,omitempty"`
+	Deref              bool   `yaml:"deref,omitempty" json:"deref,omitempty"` // Dereference pointer body types
+}
+
+// HandlerAdapterPattern recognises a call that adapts the handler in one of
+// its arguments to the framework's handler type. Adapters nest: in
+// echo.WrapHandler(http.HandlerFunc(fn)) both calls are seen through to fn.
+// Example:
+//
+//	handlerAdapters:
+//	  - callRegex: ^WrapHandler$
+//	    recvTypeRegex: ^github\.com/labstack/echo(/v\d)?$
+//	    handlerArgIndex: 0
+type HandlerAdapterPattern struct {
+	// CallRegex matches the adapter's name; RecvTypeRegex optionally narrows
+	// it to the package declaring a function or the receiver type of a
+	// method.
+	CallRegex     string `yaml:"callRegex" json:"callRegex"`
+	RecvTypeRegex string `yaml:"recvTypeRegex,omitempty" json:"recvTypeRegex,omitempty"`
+
+	// HandlerArgIndex is the argument holding the adapted handler.
+	HandlerArgIndex int `yaml:"handlerArgIndex,omitempty" json:"handlerArgIndex,omitempty"`
+}
+
+// bodyArg is the argument holding the body, -1 when there is none.
+func (h ResponseHelperPattern) bodyArg() int {
+	return argIndex(h.BodyArgIndex)
+}
+
+// argIndex returns the configured argument index, -1 when it is unset.
+func argIndex(index *int) int {
+	if index == nil {
+		return -1
+	}
+	return *index
+}
+
+// responsePattern translates the helper into the ResponsePattern its call site
+// is extracted with.
+func (h ResponseHelperPattern) responsePattern() ResponsePattern {
+	body, status := h.bodyArg(), argIndex(h.StatusArgIndex)
+	return ResponsePattern{
+		CallRegex:          h.CallRegex,
+		RecvTypeRegex:      h.RecvTypeRegex,
+		TypeArgIndex:       body,
+		TypeFromArg:        body >= 0,
+		StatusArgIndex:     status,
+		StatusFromArg:      status >= 0,
+		DefaultStatus:      h.DefaultStatus,
+		DefaultContentType: h.DefaultContentType,
+		Deref:              h.Deref,
+	}
+}
+
+// ValidationPattern describes validation middleware constructed from a rules
+// struct and attached to a single route — chi's
+// `r.With(validate.Body(CreateUserRules{})).Post(...)` or gin's
+// `r.POST(path, validate.Body(CreateUserRules{}), h)`. The constraints in the
+// rules struct's field tags are merged into that operation's request body as
+// an allOf overlay, so the shared request component stays unconstrained for
+// routes registered without the middleware.
+//
+// Example:
+//
+//	validationPatterns:
+//	  - callRegex: ^Body$
+//	    pkgRegex: /validate$
+//	    rulesArgIndex: 0
+type ValidationPattern struct {
+	// CallRegex matches the middleware constructor's name; PkgRegex
+	// optionally narrows it to the constructor's package.
+	CallRegex string `yaml:"callRegex" json:"callRegex"`
+	PkgRegex  string `yaml:"pkgRegex,omitempty" json:"pkgRegex,omitempty"`
+
+	// RulesArgIndex is the constructor argument holding the rules struct
+	// literal (T{} or &T{}).
+	RulesArgIndex int `yaml:"rulesArgIndex,omitempty" json:"rulesArgIndex,omitempty"`
+
+	// TagName is the struct tag carrying the rules, in go-playground/validator
+	// syntax. Defaults to "validate".
+	TagName string `yaml:"tagName,omitempty" json:"tagName,omitempty"`
+}
+
 // ParamPattern defines how to extract parameter information
 type ParamPattern struct {
 	// Function call patterns to match
@@ -284,14 +468,27 @@ type ParamPattern struct {
 	RecvTypeRegex     string `yaml:"recvTypeRegex,omitempty" json:"recvTypeRegex,omitempty"`
 
 	// Parameter location and extraction
-	ParamIn       string `yaml:"paramIn,omitempty" json:"paramIn,omitempty"`             // path, query, header, cookie
-	ParamArgIndex int    `yaml:"paramArgIndex,omitempty" json:"paramArgIndex,omitempty"` // Which arg contains parameter
+	ParamIn       string `yaml:"paramIn,omitempty" json:"paramIn,omitempty"`             // path, query, header, cookie; form, file, multipart fold into a form body
+	ParamArgIndex int    `yaml:"paramArgIndex,omitempty" json:"paramArgIndex,omitempty"` // Which arg contains parameter (-1: none, e.g. a multipart marker)
 	TypeArgIndex  int    `yaml:"typeArgIndex,omitempty" json:"typeArgIndex,omitempty"`   // Which arg contains type info
 
 	// Extraction hints
 	TypeFromArg bool `yaml:"typeFromArg,omitempty" json:"typeFromArg,omitempty"` // Extract type from argument
 	Deref       bool `yaml:"deref,omitempty" json:"deref,omitempty"`             // Dereference pointer types
 
+	// ParamType is the Go type of the value when the accessor fixes it, as
+	// fiber's QueryInt ("int") or gin's QueryArray ("[]string"). Without it
+	// the type is taken from a strconv conversion of the value in the
+	// handler, falling back to string.
+	ParamType string `yaml:"paramType,omitempty" json:"paramType,omitempty"`
+
+	// StructTag marks a binder that fills a whole struct, as gin's
+	// ShouldBindQuery(&q): the argument at TypeArgIndex is that struct, and
+	// each exported field becomes a ParamIn parameter named by this tag ("form",
+	// "query", "uri"), the field name when untagged. The field's validate tag
+	// constrains the parameter's schema as it would a body property.
+	StructTag string `yaml:"structTag,omitempty" json:"structTag,omitempty"`
+
 	// NameFromMapKey extracts parameter names from the string-literal keys used
 	// to index this call's map result inside the handler, rather than from a
 	// call argument. This is the gorilla/mux idiom `mux.Vars(r)["id"]`, where
@@ -339,6 +536,86 @@ type MountPattern struct {
 	CalleeRecvTypePatterns []string `yaml:"calleeRecvTypePatterns,omitempty" json:"calleeRecvTypePatterns,omitempty"`
 }
 
+// Protocol values for ProtocolPattern.Protocol. They name the long-lived
+// protocol a route switches to and select the vendor extension the mapper
+// emits on the operation (x-websocket / x-sse / x-ndjson / x-long-poll).
+// ProtocolChunked marks a handler that flushes its body in pieces: one that
+// encodes JSON is an NDJSON stream. ProtocolDownload marks a route that
+// answers with a file instead: its success body is binary and carries a
+// Content-Disposition header.
+const (
+	ProtocolWebSocket = "websocket"
+	ProtocolSSE       = "sse"
+	ProtocolNDJSON    = "ndjson"
+	ProtocolChunked   = "chunked"
+	ProtocolLongPoll  = "long-poll"
+	ProtocolDownload  = "download"
+)
+
+// ProtocolPattern recognises a call inside a handler that upgrades the route
+// to a streaming protocol: gorilla's Upgrader.Upgrade, nhooyr/coder's
+// websocket.Accept, or a `Content-Type: text/event-stream` header write. A
+// websocket handshake and an EventSource request are both plain GETs on the
+// wire, so the operation stays in the spec — the pattern only marks it.
+type ProtocolPattern struct {
+	// Function call patterns to match
+	CallRegex         string `yaml:"callRegex,omitempty" json:"callRegex,omitempty"`
+	FunctionNameRegex string `yaml:"functionNameRegex,omitempty" json:"functionNameRegex,omitempty"`
+	RecvType          string `yaml:"recvType,omitempty" json:"recvType,omitempty"`
+	RecvTypeRegex     string `yaml:"recvTypeRegex,omitempty" json:"recvTypeRegex,omitempty"`
+
+	// Protocol is the protocol the matched call switches to. One of the
+	// Protocol* constants (websocket|sse|ndjson|chunked|long-poll|download).
+	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+
+	// ArgValueRegex gates the match on the resolved value of Args[ArgIndex].
+	// A header write only marks an SSE stream when the value is
+	// text/event-stream — the same Set call writes every other header too.
+	ArgIndex      int    `yaml:"argIndex,omitempty" json:"argIndex,omitempty"`
+	ArgValueRegex string `yaml:"argValueRegex,omitempty" json:"argValueRegex,omitempty"`
+
+	// Package/type filtering
+	CallerPkgPatterns      []string `yaml:"callerPkgPatterns,omitempty" json:"callerPkgPatterns,omitempty"`
+	CallerRecvTypePatterns []string `yaml:"callerRecvTypePatterns,omitempty" json:"callerRecvTypePatterns,omitempty"`
+	CalleePkgPatterns      []string `yaml:"calleePkgPatterns,omitempty" json:"calleePkgPatterns,omitempty"`
+	CalleeRecvTypePatterns []string `yaml:"calleeRecvTypePatterns,omitempty" json:"calleeRecvTypePatterns,omitempty"`
+}
+
+// ResponseHeaderPattern recognises a call that sets a response header and
+// names the argument carrying the header name. http.Header is shared by the
+// request, the response and any outbound request a handler builds, so
+// HeaderSourceRegex can require the header map to come from the response
+// writer.
+type ResponseHeaderPattern struct {
+	// Function call patterns to match
+	CallRegex         string `yaml:"callRegex,omitempty" json:"callRegex,omitempty"`
+	FunctionNameRegex string `yaml:"functionNameRegex,omitempty" json:"functionNameRegex,omitempty"`
+	RecvType          string `yaml:"recvType,omitempty" json:"recvType,omitempty"`
+	RecvTypeRegex     string `yaml:"recvTypeRegex,omitempty" json:"recvTypeRegex,omitempty"`
+
+	// NameArgIndex is the argument holding the header name. Only a string
+	// literal or constant names a header; anything else is skipped.
+	NameArgIndex int `yaml:"nameArgIndex,omitempty" json:"nameArgIndex,omitempty"`
+
+	// Header is the header a call always sets, for calls that take no header
+	// name: http.SetCookie(w, c) and the framework cookie setters set
+	// "Set-Cookie". When set, NameArgIndex is not used.
+	Header string `yaml:"header,omitempty" json:"header,omitempty"`
+
+	// HeaderSourceRegex gates the match on the call that produced the
+	// receiver, as "<pkg>.<RecvType>.<Method>": the chained call in
+	// w.Header().Set, or the call assigned to h in h := w.Header();
+	// h.Set. "^net/http\\.ResponseWriter\\.Header$" keeps req.Header.Set
+	// on an outbound request out. Empty means any receiver.
+	HeaderSourceRegex string `yaml:"headerSourceRegex,omitempty" json:"headerSourceRegex,omitempty"`
+
+	// Package/type filtering
+	CallerPkgPatterns      []string `yaml:"callerPkgPatterns,omitempty" json:"callerPkgPatterns,omitempty"`
+	CallerRecvTypePatterns []string `yaml:"callerRecvTypePatterns,omitempty" json:"callerRecvTypePatterns,omitempty"`
+	CalleePkgPatterns      []string `yaml:"calleePkgPatterns,omitempty" json:"calleePkgPatterns,omitempty"`
+	CalleeRecvTypePatterns []string `yaml:"calleeRecvTypePatterns,omitempty" json:"calleeRecvTypePatterns,omitempty"`
+}
+
 // Security scope values for SecurityPattern.Scope. They describe how far the
 // middleware matched by a SecurityPattern reaches.
 const (
@@ -519,6 +796,13 @@ type Override struct {
 	ResponseStatus int      `yaml:"responseStatus,omitempty" json:"responseStatus,omitempty"`
 	ResponseType   string   `yaml:"responseType,omitempty" json:"responseType,omitempty"`
 	Tags           []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+
+	// Timeout and Retries are gateway hints for the operation: a Go duration
+	// string ("5s") and an upstream retry count. They surface as x-timeout /
+	// x-retries on the operation and drive the per-route timeout and retry
+	// policy of the gateway-config output.
+	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	Retries int    `yaml:"retries,omitempty" json:"retries,omitempty"`
 }
 
 // IncludeExclude defines what to include/exclude
@@ -527,6 +811,9 @@ type IncludeExclude struct {
 	Packages  []string `yaml:"packages" json:"packages,omitempty"`
 	Functions []string `yaml:"functions" json:"functions,omitempty"`
 	Types     []string `yaml:"types" json:"types,omitempty"`
+	// Routes are "[METHOD] /path/glob" entries matched against the extracted
+	// routes' OpenAPI paths (see filterRoutes), e.g. "GET /internal/**".
+	Routes []string `yaml:"routes" json:"routes,omitempty"`
 }
 
 // matchesPattern checks if a path matches a gitignore-style pattern
@@ -667,13 +954,109 @@ type Defaults struct {
 	RequestContentType  string `yaml:"requestContentType,omitempty" json:"requestContentType,omitempty"`
 	ResponseContentType string `yaml:"responseContentType,omitempty" json:"responseContentType,omitempty"`
 	ResponseStatus      int    `yaml:"responseStatus,omitempty" json:"responseStatus,omitempty"`
+
+	// RequestBodyRequired, when set, is the `required` of every decoded
+	// request body. Unset, a body is required unless the handler only reads
+	// it conditionally (`if r.Body != nil`, an io.EOF-tolerant decode).
+	RequestBodyRequired *bool `yaml:"requestBodyRequired,omitempty" json:"requestBodyRequired,omitempty"`
+}
+
+// OperationIDOptions controls how operationIds are generated. By default an
+// operation is named after its handler, qualified by import path
+// (github.com/acme/api/handlers.GetUser). A swaggo @ID is kept as written;
+// generated ids that collide get a numeric suffix whatever the options.
+type OperationIDOptions struct {
+	// Style "camel" canonicalizes generated ids to camelCase without the
+	// module prefix: handlers.UserHandler.GetUser becomes
+	// handlersUserHandlerGetUser, and a handler of the module's root
+	// package just getUser.
+	Style string `yaml:"style,omitempty" json:"style,omitempty"`
+	// Template, when set, renders each generated id with text/template
+	// from the operation's Method, Path, PathCamel, Package, Function, Tag
+	// and Suffix; "{{.Method}}{{.PathCamel}}" names GET /users/{id}
+	// getUsersId. The result is canonicalized as for the camel style.
+	Template string `yaml:"template,omitempty" json:"template,omitempty"`
+}
+
+// SchemaOptions controls the annotations added to component schemas.
+type SchemaOptions struct {
+	// OmitTitles leaves components without the `title` taken from their Go
+	// type name (`User`, `Page[User]`).
+	OmitTitles bool `yaml:"omitTitles,omitempty" json:"omitTitles,omitempty"`
+	// IDBase, when set, gives every component an `$id` of IDBase plus its
+	// standalone file name (see ComponentSchemaID). `$id` is JSON Schema
+	// 2020-12 vocabulary, so this targets OpenAPI 3.1 documents.
+	IDBase string `yaml:"idBase,omitempty" json:"idBase,omitempty"`
+	// Examples adds a generated `example` to every request body, response
+	// body and parameter that has none, following the schema's format and
+	// constraints (see addExamples).
+	Examples bool `yaml:"examples,omitempty" json:"examples,omitempty"`
+	// LiteralExamples takes examples from the code: a property gets the
+	// constant value a struct literal gives its field (the mock `users`
+	// slice of a handler, a fixture in a _test.go file), and a request body
+	// the JSON a test sends to its operation (see applyLiteralExamples).
+	LiteralExamples bool `yaml:"literalExamples,omitempty" json:"literalExamples,omitempty"`
+	// ExampleSeed varies the generated examples. The same seed always yields
+	// the same examples.
+	ExampleSeed uint64 `yaml:"exampleSeed,omitempty" json:"exampleSeed,omitempty"`
+	// ExampleEpoch is the instant generated date, date-time and time examples
+	// are offset from: an RFC 3339 date-time or a date, or "now" for the time
+	// of generation. Empty keeps the fixed default, 2024-01-01T00:00:00Z.
+	// Examples are always written in UTC.
+	ExampleEpoch string `yaml:"exampleEpoch,omitempty" json:"exampleEpoch,omitempty"`
+	// Discriminator names the property that tells the implementations of an
+	// interface apart. When set, the `oneOf` generated for an interface with
+	// several implementations carries a `discriminator` on it, mapping each
+	// Go type name to its component.
+	Discriminator string `yaml:"discriminator,omitempty" json:"discriminator,omitempty"`
+	// TagNamespaces lists the struct tags property names are read from, in
+	// order of precedence: with [json, db, gorm] a field without a json tag
+	// is named by its db tag, then by its gorm column. Listing gorm also
+	// marks `not null` and `primaryKey` columns required and bounds
+	// varchar(N) strings. Empty keeps json alone.
+	TagNamespaces []string `yaml:"tagNamespaces,omitempty" json:"tagNamespaces,omitempty"`
+	// NullablePointers documents pointer fields as nullable: "always", or
+	// "unlessOmitempty" for those without an omitempty (or omitzero) json
+	// option, which encoding/json writes as null when nil. Empty documents
+	// a pointer as the type it points to.
+	NullablePointers string `yaml:"nullablePointers,omitempty" json:"nullablePointers,omitempty"`
+	// NullableStyle is how a nullable schema is written: "anyOf" (the
+	// default) an anyOf of the schema and {type: null}; "typeArray" the
+	// OpenAPI 3.1 type: [T, "null"]; "nullable" the OpenAPI 3.0
+	// nullable: true. A $ref cannot carry either of the last two, so it is
+	// wrapped in an anyOf or allOf of its own.
+	NullableStyle string `yaml:"nullableStyle,omitempty" json:"nullableStyle,omitempty"`
+	// OmitemptyOptional leaves a property out of `required` when its json
+	// tag has omitempty (or omitzero), even if a validation tag requires
+	// it: encoding/json may leave it out of the document.
+	OmitemptyOptional bool `yaml:"omitemptyOptional,omitempty" json:"omitemptyOptional,omitempty"`
+	// DynamicValues is how a value encoding/json can give any JSON type is
+	// documented — an any or interface{} field, a json.RawMessage, the
+	// values of a map[string]any: "object" (the default) a free-form
+	// object; "string" a string; "component" a $ref to a placeholder
+	// component every JSON value matches.
+	DynamicValues string `yaml:"dynamicValues,omitempty" json:"dynamicValues,omitempty"`
+	// DynamicComponent names the placeholder component of the "component"
+	// style. Empty names it AnyValue.
+	DynamicComponent string `yaml:"dynamicComponent,omitempty" json:"dynamicComponent,omitempty"`
+	// GoTypeAnnotations marks those schemas with x-go-type, the Go type
+	// they document (any, json.RawMessage), and x-go-type-import where the
+	// type needs one, so a code generator restores the Go type.
+	GoTypeAnnotations bool `yaml:"goTypeAnnotations,omitempty" json:"goTypeAnnotations,omitempty"`
 }
 
-// ExternalType defines an external type that should be treated as known
+// ExternalType defines an external type that should be treated as known, or
+// overrides the schemas of some of a type's fields. Name is a full type name
+// or a glob over them (see externalType).
 type ExternalType struct {
-	Name        string  `yaml:"name" json:"name,omitempty"`               // Full type name (e.g., "primitive.ObjectID")
+	Name        string  `yaml:"name" json:"name,omitempty"`               // Full type name (e.g., "primitive.ObjectID") or glob ("github.com/shopspring/decimal.*")
 	OpenAPIType *Schema `yaml:"openapiType" json:"openapiType,omitempty"` // OpenAPI schema for this type
 	Description string  `yaml:"description,omitempty" json:"description,omitempty"`
+	// Fields overrides the schemas of the matched types' properties, keyed
+	// by property name; the other properties are generated as usual. An
+	// entry with Fields and no OpenAPIType leaves the type itself to the
+	// generator.
+	Fields map[string]*Schema `yaml:"fields,omitempty" json:"fields,omitempty"`
 }
 
 // APISpecConfig is the main configuration struct
@@ -697,12 +1080,23 @@ type APISpecConfig struct {
 	// Defaults
 	Defaults Defaults `yaml:"defaults" json:"defaults,omitempty"`
 
+	// Component schema annotations (title, $id)
+	Schemas SchemaOptions `yaml:"schemas,omitempty" json:"schemas,omitempty"`
+
+	// OperationId generation (style, template)
+	OperationIDs OperationIDOptions `yaml:"operationIds,omitempty" json:"operationIds,omitempty"`
+
 	// OpenAPI metadata
 	Info            Info                      `yaml:"info" json:"info,omitempty"`
 	Servers         []Server                  `yaml:"servers" json:"servers,omitempty"`
 	Security        []SecurityRequirement     `yaml:"security" json:"security,omitempty"`
 	SecuritySchemes map[string]SecurityScheme `yaml:"securitySchemes" json:"securitySchemes,omitempty"`
 
+	// RoutePatterns declare the registration calls of homegrown router
+	// wrappers (see CustomRoutePattern). They are extracted in addition to
+	// Framework.RoutePatterns.
+	RoutePatterns []CustomRoutePattern `yaml:"routePatterns,omitempty" json:"routePatterns,omitempty"`
+
 	// SecurityMappings resolve detected auth middleware to security schemes
 	// (see SecurityMapping). Framework-agnostic; merged from library presets and
 	// user config. Works together with Framework.SecurityPatterns (scope).
@@ -719,6 +1113,250 @@ type APISpecConfig struct {
 	presetsApplied bool                   `yaml:"-" json:"-"`
 	Tags           []Tag                  `yaml:"tags" json:"tags,omitempty"`
 	ExternalDocs   *ExternalDocumentation `yaml:"externalDocs" json:"externalDocs,omitempty"`
+
+	// GroupTags name the tag of the operations registered under a router
+	// group or mount prefix (see GroupTag).
+	GroupTags []GroupTag `yaml:"groupTags,omitempty" json:"groupTags,omitempty"`
+
+	// Webhooks document the requests the API sends to its clients (see
+	// Webhook).
+	Webhooks []Webhook `yaml:"webhooks,omitempty" json:"webhooks,omitempty"`
+
+	// ContextValues document what the request-scoped values middleware
+	// stores in the context imply for the handlers reading them (see
+	// ContextValue).
+	ContextValues []ContextValue `yaml:"contextValues,omitempty" json:"contextValues,omitempty"`
+
+	// MiddlewareResponses document the responses middleware in a route's
+	// chain answers with on the handler's behalf (see MiddlewareResponse).
+	MiddlewareResponses []MiddlewareResponse `yaml:"middlewareResponses,omitempty" json:"middlewareResponses,omitempty"`
+
+	// ErrorResponses document the error responses of the calls handlers
+	// answer errors with (see ErrorResponse).
+	ErrorResponses []ErrorResponse `yaml:"errorResponses,omitempty" json:"errorResponses,omitempty"`
+
+	// IncludeDebugEndpoints documents the net/http/pprof and expvar handlers
+	// the service registers under the `internal` tag. By default they are
+	// left out of the spec, with a warning.
+	IncludeDebugEndpoints bool `yaml:"includeDebugEndpoints,omitempty" json:"includeDebugEndpoints,omitempty"`
+
+	// SourceAnnotations marks each operation and component schema with the
+	// Go declaration it was generated from: `x-go-source` (file:line, the
+	// file relative to the module root) and `x-go-function` (the handler, or
+	// the schema's type, as `go doc` names it).
+	SourceAnnotations bool `yaml:"sourceAnnotations,omitempty" json:"sourceAnnotations,omitempty"`
+
+	// Extensions inject vendor extensions (x-owner, x-gateway-route-id, ...)
+	// into the generated document (see ExtensionsConfig).
+	Extensions ExtensionsConfig `yaml:"extensions,omitempty" json:"extensions,omitempty"`
+}
+
+// ExtensionsConfig adds `x-*` vendor extensions to the generated document,
+// its path items, operations and component schemas. A key must start with
+// "x-". When several entries set the same key on one object the last wins,
+// and a configured value replaces a generated one (x-websocket, x-timeout).
+type ExtensionsConfig struct {
+	// Document holds the extensions of the document root.
+	Document map[string]any `yaml:"document,omitempty" json:"document,omitempty"`
+	// Paths add extensions to the path items whose template matches.
+	Paths []PathExtensions `yaml:"paths,omitempty" json:"paths,omitempty"`
+	// Operations add extensions to the operations their route matches.
+	Operations []OperationExtensions `yaml:"operations,omitempty" json:"operations,omitempty"`
+	// Schemas add extensions to the component schemas generated from the
+	// Go types whose name matches.
+	Schemas []SchemaExtensions `yaml:"schemas,omitempty" json:"schemas,omitempty"`
+}
+
+// PathExtensions are the extensions of the path items whose OpenAPI path
+// template ("/users/{id}") matches Path, a gitignore-style glob such as
+// "/admin/**".
+type PathExtensions struct {
+	Path       string         `yaml:"path" json:"path"`
+	Extensions map[string]any `yaml:"extensions" json:"extensions"`
+}
+
+// OperationExtensions are the extensions of the operations Route selects:
+// "[METHOD] /path/glob", like the include.routes / exclude.routes entries
+// ("GET /users/**", "/internal/*").
+type OperationExtensions struct {
+	Route      string         `yaml:"route" json:"route"`
+	Extensions map[string]any `yaml:"extensions" json:"extensions"`
+}
+
+// SchemaExtensions are the extensions of the component schemas whose Go type
+// matches Type, a glob over either the type's simple name ("User",
+// "Page[User]", "*Request") or its package-qualified one
+// ("github.com/acme/shop/models.*").
+type SchemaExtensions struct {
+	Type       string         `yaml:"type" json:"type"`
+	Extensions map[string]any `yaml:"extensions" json:"extensions"`
+}
+
+// ContextValue documents a value middleware stores in the request context —
+// context.WithValue(ctx, userKey, user), c.Set("user", user) — for the
+// operations whose handler reads it back: ctx.Value(userKey), c.Get("user"),
+// c.MustGet, c.Locals, directly or in a helper the handler calls. A value an
+// auth middleware stores means the operation needs that authentication, and
+// often a parameter the middleware consumed on the handler's behalf.
+type ContextValue struct {
+	// Key is the key the value is read with: an identifier (userKey), one
+	// qualified by its package (auth.UserKey), the type of a struct key
+	// (ctxKey for ctxKey{}), or a string key (user for c.Get("user")).
+	Key string `yaml:"key" json:"key"`
+	// Security is the requirement of operations reading the value. It
+	// applies only where no auth middleware was detected.
+	Security []SecurityRequirement `yaml:"security,omitempty" json:"security,omitempty"`
+	// Parameters are added to operations reading the value, unless they
+	// already have one of the same name and location. A path parameter is
+	// added only where the path has its placeholder.
+	Parameters []Parameter `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+}
+
+// MiddlewareResponse documents what a middleware does to the operations it
+// guards: an auth middleware rejects the request with 401, a rate limiter
+// with 429, and a CORS middleware answers the browser's OPTIONS preflight.
+// It matches the middleware detected in a route's chain — r.Use(mw),
+// r.With(mw), a wrapping call — by identity, as a SecurityMapping does.
+type MiddlewareResponse struct {
+	// Match the middleware identity. Empty fields are ignored; at least one
+	// is required.
+	FunctionNameRegex string `yaml:"functionNameRegex,omitempty" json:"functionNameRegex,omitempty"`
+	PkgRegex          string `yaml:"pkgRegex,omitempty" json:"pkgRegex,omitempty"`
+	RecvTypeRegex     string `yaml:"recvTypeRegex,omitempty" json:"recvTypeRegex,omitempty"`
+
+	// Responses are added to the guarded operations, unless the handler
+	// already documents the status.
+	Responses []MiddlewareStatus `yaml:"responses,omitempty" json:"responses,omitempty"`
+
+	// Preflight adds an OPTIONS operation answering the CORS preflight to
+	// every guarded path that does not register one.
+	Preflight bool `yaml:"preflight,omitempty" json:"preflight,omitempty"`
+}
+
+// MiddlewareStatus is a response a middleware writes.
+type MiddlewareStatus struct {
+	Status int `yaml:"status" json:"status"`
+	// Description replaces the status text.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// BodyType is the Go type of the body, named as in source
+	// (apierr.Error) or by import path. Without it the body is the plain
+	// text http.Error writes.
+	BodyType string `yaml:"bodyType,omitempty" json:"bodyType,omitempty"`
+	// ContentType of the body; application/json for a BodyType when empty.
+	ContentType string `yaml:"contentType,omitempty" json:"contentType,omitempty"`
+}
+
+// ErrorResponse maps a call a handler answers an error with to the response
+// it produces: an error helper (render.Error(w, err), apierr.Write(c, err))
+// or a framework error the handler returns (echo.NewHTTPError(code, msg),
+// fiber.NewError(code, msg)), whose body is written far from the handler or
+// built from an untyped map. The call is read as a response at the
+// handler's call site with BodyType as its body; the walk does not descend
+// into it.
+//
+// Example:
+//
+//	errorResponses:
+//	  - callRegex: ^NewHTTPError$
+//	    recvTypeRegex: ^github\.com/labstack/echo/v4$
+//	    statusArgIndex: 0
+//	    bodyType: apierr.Message
+//	  - callRegex: ^Write$
+//	    recvTypeRegex: /apierr$
+//	    statusArgIndex: -1
+//	    status: 500
+//	    bodyType: apierr.Problem
+type ErrorResponse struct {
+	// CallRegex matches the call's name; RecvTypeRegex optionally narrows it
+	// to the package declaring a function or the receiver type of a method.
+	CallRegex     string `yaml:"callRegex" json:"callRegex"`
+	RecvTypeRegex string `yaml:"recvTypeRegex,omitempty" json:"recvTypeRegex,omitempty"`
+
+	// StatusArgIndex is the zero-based argument holding the status; -1 means
+	// the call takes none. Status is used when there is no such argument or
+	// it does not resolve to a constant; without either the response is
+	// documented as `default`.
+	StatusArgIndex int `yaml:"statusArgIndex,omitempty" json:"statusArgIndex,omitempty"`
+	Status         int `yaml:"status,omitempty" json:"status,omitempty"`
+
+	// BodyType is the Go type of the body, named as in source
+	// (apierr.Problem) or by import path.
+	BodyType string `yaml:"bodyType" json:"bodyType"`
+	// ContentType of the body; defaults.responseContentType when empty.
+	ContentType string `yaml:"contentType,omitempty" json:"contentType,omitempty"`
+}
+
+// responsePattern translates the entry into the ResponsePattern its call site
+// is extracted with.
+func (r ErrorResponse) responsePattern() ResponsePattern {
+	return ResponsePattern{
+		CallRegex:          r.CallRegex,
+		RecvTypeRegex:      r.RecvTypeRegex,
+		StatusArgIndex:     r.StatusArgIndex,
+		StatusFromArg:      r.StatusArgIndex >= 0,
+		DefaultStatus:      r.Status,
+		DefaultContentType: r.ContentType,
+		BodyType:           r.BodyType,
+	}
+}
+
+// GroupTag maps a router group or mount prefix — Group("/payment"),
+// Mount("/payment", r), Route("/payment", fn) — to the tag of the operations
+// registered under it. Without an entry, the tag is the last static segment of
+// the group's path, skipping "api" and version segments such as v1.
+type GroupTag struct {
+	// Prefix is matched against the full group path, parameters written
+	// either way ("/orgs/:org" or "/orgs/{org}"); the longest match wins.
+	Prefix string `yaml:"prefix" json:"prefix"`
+	// Name is the tag; empty means the name inferred from Prefix.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Description, when set, is emitted with the tag in the document's
+	// top-level tags.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// Webhook documents a request the API sends out — an event delivered to a
+// subscriber's URL. Without CallbackURL it is emitted under the document's
+// webhooks (OpenAPI 3.1); with one, it is a callback of every operation whose
+// handler makes one of Calls, the subscriber's URL taken from that request.
+type Webhook struct {
+	// Name keys the webhook under webhooks, or the callback under an
+	// operation's callbacks.
+	Name string `yaml:"name" json:"name"`
+	// Method is the HTTP method of the delivery; POST when empty.
+	Method      string   `yaml:"method,omitempty" json:"method,omitempty"`
+	Summary     string   `yaml:"summary,omitempty" json:"summary,omitempty"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	OperationID string   `yaml:"operationId,omitempty" json:"operationId,omitempty"`
+	Tags        []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+
+	// PayloadType is the Go type of the delivered body, named as in source
+	// (models.OrderEvent, []models.OrderEvent) or by import path.
+	PayloadType string `yaml:"payloadType,omitempty" json:"payloadType,omitempty"`
+	// ContentType of the payload; application/json when empty.
+	ContentType string `yaml:"contentType,omitempty" json:"contentType,omitempty"`
+
+	// CallbackURL is the runtime expression naming where the subscriber
+	// asked to be called, e.g. {$request.body#/callbackUrl}.
+	CallbackURL string `yaml:"callbackURL,omitempty" json:"callbackURL,omitempty"`
+
+	// Calls are the outbound calls that deliver the webhook, such as
+	// http.Post or (*http.Client).Do in a notifier function. When set, the
+	// webhook is documented only if the code makes one of them.
+	Calls []WebhookCall `yaml:"calls,omitempty" json:"calls,omitempty"`
+}
+
+// WebhookCall recognises an outbound call that delivers a webhook. It
+// matches like a ProtocolPattern: FunctionNameRegex names the function
+// making the call, which tells apart the webhooks sent through the same
+// client.
+type WebhookCall struct {
+	CallRegex         string `yaml:"callRegex,omitempty" json:"callRegex,omitempty"`
+	FunctionNameRegex string `yaml:"functionNameRegex,omitempty" json:"functionNameRegex,omitempty"`
+	RecvType          string `yaml:"recvType,omitempty" json:"recvType,omitempty"`
+	RecvTypeRegex     string `yaml:"recvTypeRegex,omitempty" json:"recvTypeRegex,omitempty"`
+	ArgIndex          int    `yaml:"argIndex,omitempty" json:"argIndex,omitempty"`
+	ArgValueRegex     string `yaml:"argValueRegex,omitempty" json:"argValueRegex,omitempty"`
 }
 
 // ShouldIncludeFile checks if a file should be included based on include/exclude filters
@@ -841,6 +1479,134 @@ func netHTTPResponsePatterns() []ResponsePattern {
 	}
 }
 
+// ndjsonContentType matches the media types of a newline-delimited JSON
+// stream.
+const ndjsonContentType = `^\s*application/(x-ndjson|ndjson|jsonl|x-jsonlines|stream\+json)\b`
+
+// streamingProtocolPatterns returns the websocket/SSE/NDJSON detection
+// patterns that hold for every framework: the websocket libraries all take
+// the net/http writer and request, an SSE or NDJSON stream on any router built
+// over net/http announces itself through http.Header, and a chunked body is
+// flushed through http.Flusher or http.ResponseController. Frameworks with
+// their own stream API (gin's SSEvent, fiber's Ctx.Set) append to
+// httpProtocolPatterns.
+func streamingProtocolPatterns() []ProtocolPattern {
+	return []ProtocolPattern{
+		{
+			CallRegex:     `^Upgrade$`,
+			RecvTypeRegex: `^github\.com/gorilla/websocket\.\*?Upgrader$`,
+			Protocol:      ProtocolWebSocket,
+		},
+		{
+			CallRegex:     `^Accept$`,
+			RecvTypeRegex: `^(nhooyr\.io/websocket|github\.com/coder/websocket)$`,
+			Protocol:      ProtocolWebSocket,
+		},
+		{
+			CallRegex:     `^(Set|Add)$`,
+			RecvType:      "net/http.Header",
+			Protocol:      ProtocolSSE,
+			ArgIndex:      1,
+			ArgValueRegex: `text/event-stream`,
+		},
+		{
+			CallRegex:     `^(Set|Add)$`,
+			RecvType:      "net/http.Header",
+			Protocol:      ProtocolNDJSON,
+			ArgIndex:      1,
+			ArgValueRegex: ndjsonContentType,
+		},
+		{
+			CallRegex:     `^Flush$`,
+			RecvTypeRegex: `^net/http\.\*?(Flusher|ResponseController)$`,
+			Protocol:      ProtocolChunked,
+		},
+	}
+}
+
+// longPollPatterns returns the calls that bound how long a handler holds a
+// request open waiting for something to return: a time.After or time.NewTimer
+// racing the event in a select is the long-poll idiom.
+func longPollPatterns() []ProtocolPattern {
+	return []ProtocolPattern{
+		{
+			CallRegex:     `^(After|NewTimer)$`,
+			RecvTypeRegex: `^time$`,
+			Protocol:      ProtocolLongPoll,
+		},
+	}
+}
+
+// httpProtocolPatterns returns the protocol patterns every framework shares:
+// streams, long polls and file downloads.
+func httpProtocolPatterns() []ProtocolPattern {
+	patterns := streamingProtocolPatterns()
+	patterns = append(patterns, longPollPatterns()...)
+	return append(patterns, fileDownloadPatterns()...)
+}
+
+// attachmentDisposition matches a Content-Disposition value that asks the
+// client to save the body as a file.
+const attachmentDisposition = `^\s*attachment\b`
+
+// fileDownloadPatterns returns the net/http calls that answer with a file:
+// http.ServeContent, and a `Content-Disposition: attachment` header write.
+// An inline disposition renders in the browser and is not a download.
+// Frameworks with their own file helpers (gin's FileAttachment, echo's
+// Attachment, fiber's Download) append to httpProtocolPatterns.
+func fileDownloadPatterns() []ProtocolPattern {
+	return []ProtocolPattern{
+		{
+			CallRegex:     `^ServeContent$`,
+			RecvTypeRegex: `^net/http$`,
+			Protocol:      ProtocolDownload,
+		},
+		{
+			CallRegex:     `^(Set|Add)$`,
+			RecvType:      "net/http.Header",
+			Protocol:      ProtocolDownload,
+			ArgIndex:      1,
+			ArgValueRegex: attachmentDisposition,
+		},
+	}
+}
+
+// setCookieHeader is the response header cookie setters write.
+const setCookieHeader = "Set-Cookie"
+
+// acceptRangesHeader is the response header file servers answer with to
+// announce byte-range support; see applyRangeRequests.
+const acceptRangesHeader = "Accept-Ranges"
+
+// httpResponseHeaderPatterns returns the net/http calls that set a response
+// header: Set and Add on a header map obtained from a response writer whose
+// Header method matches sourceRegex, http.SetCookie, and the file servers
+// that answer Range requests (Accept-Ranges).
+func httpResponseHeaderPatterns(sourceRegex string) []ResponseHeaderPattern {
+	return []ResponseHeaderPattern{
+		{
+			CallRegex:         `^(Set|Add)$`,
+			RecvType:          "net/http.Header",
+			NameArgIndex:      0,
+			HeaderSourceRegex: sourceRegex,
+		},
+		{
+			CallRegex:     `^SetCookie$`,
+			RecvTypeRegex: `^net/http$`,
+			Header:        setCookieHeader,
+		},
+		{
+			CallRegex:     `^(ServeContent|ServeFile|ServeFileFS)$`,
+			RecvTypeRegex: `^net/http$`,
+			Header:        acceptRangesHeader,
+		},
+	}
+}
+
+// httpResponseWriterHeader matches net/http's ResponseWriter.Header, the
+// source of every response header map on a router built over net/http.
+const httpResponseWriterHeader = `^net/http\.ResponseWriter\.Header$`
+
 // Response detection for json.Marshal is intentionally NOT a standalone
 // pattern. json.Marshal(v) returns []byte with no writer argument, so matching
 // it in isolation over-detects: a Marshal reachable anywhere (e.g. a downstream
@@ -868,6 +1634,28 @@ func jsonEncodePattern(recvTypeRegex string) ResponsePattern {
 	}
 }
 
+// markupEncodePatterns returns the XML and YAML Encoder.Encode response
+// patterns, documented with their own media type. They precede
+// jsonEncodePattern, which may match any receiver, so a handler negotiating
+// the format per Accept documents each encoder it writes with.
+func markupEncodePatterns() []ResponsePattern {
+	xmlEncode := jsonEncodePattern(`^encoding/xml\.\*Encoder$`)
+	xmlEncode.DefaultContentType = "application/xml"
+	yamlEncode := jsonEncodePattern(`^(gopkg\.in/yaml\.v[23]|github\.com/goccy/go-yaml)\.\*Encoder$`)
+	yamlEncode.DefaultContentType = "application/yaml"
+	return []ResponsePattern{xmlEncode, yamlEncode}
+}
+
+// renderContentTypes returns the media types of the renderer calls of the
+// (?i)(JSON|String|XML|...) catch-all that write a markup format; the others
+// keep the default content type.
+func renderContentTypes() map[string]string {
+	return map[string]string{
+		"XML":  "application/xml",
+		"YAML": "application/yaml",
+	}
+}
+
 // jsonDecodeRequestPattern returns the json.Decoder.Decode request-body
 // pattern. recvTypeRegex varies between frameworks (some restrict to
 // *Decoder, some accept any receiver).
@@ -897,6 +1685,32 @@ func jsonUnmarshalRequestPattern(recvTypeRegex string) RequestBodyPattern {
 	}
 }
 
+// jsonPatchRequestPatterns returns the request-body patterns for
+// evanphx/json-patch: DecodePatch reads an RFC 6902 JSON Patch document and
+// MergePatch applies an RFC 7386 merge patch, each from a body read off the
+// request.
+func jsonPatchRequestPatterns() []RequestBodyPattern {
+	const recv = `^github\.com/evanphx/json-patch(/v5)?$`
+	return []RequestBodyPattern{
+		{
+			CallRegex:            `^DecodePatch$`,
+			RecvTypeRegex:        recv,
+			BodyType:             "jsonpatch.Patch",
+			ContentType:          jsonPatchContentType,
+			RequireRequestSource: true,
+			BodySourceArgIndex:   0,
+		},
+		{
+			CallRegex:            `^MergePatch$`,
+			RecvTypeRegex:        recv,
+			BodyType:             "map[string]interface{}",
+			ContentType:          mergePatchContentType,
+			RequireRequestSource: true,
+			BodySourceArgIndex:   1,
+		},
+	}
+}
+
 // stdDefaults returns the Defaults block shared by every framework config,
 // parameterised on responseStatus (HTTP-style defaults all use 200; Chi's
 // older config kept its own constant — preserved here for parity).
@@ -38,8 +38,9 @@ func DefaultChiConfig() *APISpecConfig {
 			StatusFromArg:  true,
 			RecvTypeRegex:  "^github\\.com/go-chi/render$",
 		},
-		jsonEncodePattern(".*json(iter)?\\.\\*?Encoder"),
 	)
+	responsePatterns = append(responsePatterns, markupEncodePatterns()...)
+	responsePatterns = append(responsePatterns, jsonEncodePattern(".*json(iter)?\\.\\*?Encoder"))
 
 	return &APISpecConfig{
 		Framework: FrameworkConfig{
@@ -86,7 +87,7 @@ func DefaultChiConfig() *APISpecConfig {
 			},
 			RequestContext:  netHTTPRequestContext,
 			ResponseContext: netHTTPResponseContext,
-			RequestBodyPatterns: []RequestBodyPattern{
+			RequestBodyPatterns: append([]RequestBodyPattern{
 				{
 					CallRegex:            `^DecodeJSON$`,
 					TypeArgIndex:         1,
@@ -98,7 +99,7 @@ func DefaultChiConfig() *APISpecConfig {
 				},
 				jsonDecodeRequestPattern(".*json(iter)?\\.\\*Decoder"),
 				jsonUnmarshalRequestPattern("json"),
-			},
+			}, jsonPatchRequestPatterns()...),
 			ResponsePatterns: responsePatterns,
 			ParamPatterns: []ParamPattern{
 				{
@@ -124,6 +125,21 @@ func DefaultChiConfig() *APISpecConfig {
 					ParamIn:       "form",
 					ParamArgIndex: 0,
 				},
+				{
+					// r.FormFile("avatar") — an uploaded file part.
+					CallRegex:     "^FormFile$",
+					ParamIn:       "file",
+					ParamArgIndex: 0,
+					RecvType:      "net/http.*Request",
+				},
+				{
+					// r.ParseMultipartForm(maxMemory) names no field but makes
+					// the form body multipart/form-data.
+					CallRegex:     "^ParseMultipartForm$",
+					ParamIn:       "multipart",
+					ParamArgIndex: -1,
+					RecvType:      "net/http.*Request",
+				},
 				{
 					CallRegex:     "^Get$",
 					ParamIn:       "query",
@@ -136,8 +152,24 @@ func DefaultChiConfig() *APISpecConfig {
 					ParamArgIndex: 0,
 					RecvType:      "net/http.*Request",
 				},
+				{
+					// r.Header.Get("X-Tenant-ID") — a request header.
+					CallRegex:     "^Get$",
+					ParamIn:       "header",
+					ParamArgIndex: 0,
+					RecvType:      "net/http.Header",
+				},
+				{
+					// r.Cookie("session") — a request cookie.
+					CallRegex:     "^Cookie$",
+					ParamIn:       "cookie",
+					ParamArgIndex: 0,
+					RecvType:      "net/http.*Request",
+				},
 			},
-			SecurityPatterns: chiSecurityPatterns(),
+			SecurityPatterns:       chiSecurityPatterns(),
+			ProtocolPatterns:       httpProtocolPatterns(),
+			ResponseHeaderPatterns: httpResponseHeaderPatterns(httpResponseWriterHeader),
 			// Receiver-scoped so these survive SecondaryView when chi is not the
 			// primary framework — an unscoped pattern is dropped from a
 			// secondary config, which left chi-wired mounts untraced in mixed
@@ -162,6 +194,16 @@ func DefaultChiConfig() *APISpecConfig {
 					IsMount:        true,
 					RecvTypeRegex:  chiRouterRecv,
 				},
+				{
+					// r.Group(fn) adds no prefix; it is a mount so middleware
+					// chained in front of it (r.With(mw).Group(fn)) reaches
+					// the routes fn registers.
+					CallRegex:      `^Group$`,
+					RouterFromArg:  true,
+					RouterArgIndex: 0,
+					IsMount:        true,
+					RecvTypeRegex:  chiRouterRecv,
+				},
 			},
 		},
 		Defaults: stdDefaults(defaultResponseStatus),
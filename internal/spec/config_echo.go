@@ -34,13 +34,14 @@ func DefaultEchoConfig() *APISpecConfig {
 	responsePatterns := netHTTPResponsePatterns()
 	responsePatterns = append(responsePatterns,
 		ResponsePattern{
-			CallRegex:      `^(?i)(JSON|String|XML|YAML|ProtoBuf|Data|File|Redirect)$`,
-			StatusArgIndex: 0,
-			TypeArgIndex:   1,
-			TypeFromArg:    true,
-			StatusFromArg:  true,
-			Deref:          true,
-			RecvTypeRegex:  "github\\.com/labstack/echo/v\\d\\.Context",
+			CallRegex:         `^(?i)(JSON|String|XML|YAML|ProtoBuf|Data|File|Redirect)$`,
+			StatusArgIndex:    0,
+			TypeArgIndex:      1,
+			TypeFromArg:       true,
+			StatusFromArg:     true,
+			Deref:             true,
+			RecvTypeRegex:     "github\\.com/labstack/echo/v\\d\\.Context",
+			ContentTypeByCall: renderContentTypes(),
 		},
 		ResponsePattern{
 			CallRegex:      `^(?i)(NoContent)$`,
@@ -49,11 +50,26 @@ func DefaultEchoConfig() *APISpecConfig {
 			TypeArgIndex:   -1,
 			RecvTypeRegex:  "github\\.com/labstack/echo/v\\d\\.Context",
 		},
-		jsonEncodePattern(".*json(iter)?\\.\\*?Encoder"),
 	)
+	responsePatterns = append(responsePatterns, markupEncodePatterns()...)
+	responsePatterns = append(responsePatterns, jsonEncodePattern(".*json(iter)?\\.\\*?Encoder"))
 
 	return &APISpecConfig{
 		Framework: FrameworkConfig{
+			// echo.WrapHandler takes an http.Handler, which echo invokes
+			// through ServeHTTP.
+			HandlerInterfaceMethods: []string{"ServeHTTP"},
+			HandlerAdapters: []HandlerAdapterPattern{
+				{
+					CallRegex:     `^WrapHandler$`,
+					RecvTypeRegex: "^github\\.com/labstack/echo(/v\\d)?$",
+				},
+				{
+					// http.HandlerFunc(fn), as in echo.WrapHandler(http.HandlerFunc(fn)).
+					CallRegex:     `^HandlerFunc$`,
+					RecvTypeRegex: "^net/http$",
+				},
+			},
 			RoutePatterns: []RoutePattern{
 				{
 					CallRegex:       `^(?i)(GET|POST|PUT|DELETE|PATCH|OPTIONS|HEAD)$`,
@@ -64,9 +80,34 @@ func DefaultEchoConfig() *APISpecConfig {
 					HandlerArgIndex: 1,
 					RecvTypeRegex:   "^github\\.com/labstack/echo(/v\\d)?\\.\\*(Echo|Group)$",
 				},
+				{
+					// e.Add(http.MethodGet, "/x", h) — the verb is the first
+					// argument. e.Match([]string{GET, HEAD}, "/x", h) passes a
+					// list of verbs there and yields an operation per verb.
+					CallRegex:       `^(Add|Match)$`,
+					PathFromArg:     true,
+					HandlerFromArg:  true,
+					MethodArgIndex:  0,
+					PathArgIndex:    1,
+					HandlerArgIndex: 2,
+					RecvTypeRegex:   "^github\\.com/labstack/echo(/v\\d)?\\.\\*(Echo|Group)$",
+				},
+				{
+					// e.RouteNotFound("/*", h) answers every verb. As with
+					// chi's Handle, the verb comes from the handler name when
+					// it carries one and defaults (not explicitly) otherwise.
+					CallRegex:         `^RouteNotFound$`,
+					PathFromArg:       true,
+					HandlerFromArg:    true,
+					MethodFromHandler: true,
+					MethodArgIndex:    -1,
+					PathArgIndex:      0,
+					HandlerArgIndex:   1,
+					RecvTypeRegex:     "^github\\.com/labstack/echo(/v\\d)?\\.\\*(Echo|Group)$",
+				},
 			},
 			RequestContext: echoRequestContext,
-			RequestBodyPatterns: []RequestBodyPattern{
+			RequestBodyPatterns: append([]RequestBodyPattern{
 				{
 					CallRegex:     `^(?i)(Bind)$`,
 					TypeArgIndex:  0,
@@ -76,7 +117,7 @@ func DefaultEchoConfig() *APISpecConfig {
 				},
 				jsonDecodeRequestPattern(".*json(iter)?\\.\\*Decoder"),
 				jsonUnmarshalRequestPattern("json"),
-			},
+			}, jsonPatchRequestPatterns()...),
 			ResponsePatterns: responsePatterns,
 			ParamPatterns: []ParamPattern{
 				{
@@ -90,18 +131,78 @@ func DefaultEchoConfig() *APISpecConfig {
 					ParamArgIndex: 0,
 					RecvTypeRegex: "github\\.com/labstack/echo/v\\d\\.Context",
 				},
+				{
+					// c.QueryParams().Get("q") — QueryParams returns url.Values.
+					CallRegex:     "^Get$",
+					ParamIn:       "query",
+					ParamArgIndex: 0,
+					RecvType:      "net/url.Values",
+				},
 				{
 					CallRegex:     "^FormValue$",
 					ParamIn:       "form",
 					ParamArgIndex: 0,
 				},
+				{
+					// c.FormFile("file") — an uploaded file part.
+					CallRegex:     "^FormFile$",
+					ParamIn:       "file",
+					ParamArgIndex: 0,
+					RecvTypeRegex: "^github\\.com/labstack/echo(/v\\d)?\\.Context$",
+				},
+				{
+					// c.MultipartForm() names no field but makes the form body
+					// multipart/form-data.
+					CallRegex:     "^MultipartForm$",
+					ParamIn:       "multipart",
+					ParamArgIndex: -1,
+					RecvTypeRegex: "^github\\.com/labstack/echo(/v\\d)?\\.Context$",
+				},
 				{
 					CallRegex:     "^Cookie$",
 					ParamIn:       "cookie",
 					ParamArgIndex: 0,
+					RecvTypeRegex: "^github\\.com/labstack/echo(/v\\d)?\\.Context$",
+				},
+				{
+					// c.Request().Header.Get("X-Tenant-ID") — a request header.
+					CallRegex:     "^Get$",
+					ParamIn:       "header",
+					ParamArgIndex: 0,
+					RecvType:      "net/http.Header",
 				},
 			},
 			SecurityPatterns: echoSecurityPatterns(),
+			// c.Attachment(file, name) serves a file for download;
+			// c.Response().Flush() sends a chunk of a streamed body.
+			ProtocolPatterns: append(httpProtocolPatterns(),
+				ProtocolPattern{
+					CallRegex:     `^Attachment$`,
+					RecvTypeRegex: "github\\.com/labstack/echo/v\\d\\.Context",
+					Protocol:      ProtocolDownload,
+				},
+				ProtocolPattern{
+					CallRegex:     `^Flush$`,
+					RecvTypeRegex: `^github\.com/labstack/echo(/v\d)?\.\*?Response$`,
+					Protocol:      ProtocolChunked,
+				},
+			),
+			// c.Response().Header().Set — echo's Response wraps the writer;
+			// c.SetCookie(cookie) sets Set-Cookie.
+			ResponseHeaderPatterns: append(
+				httpResponseHeaderPatterns(`^(net/http\.ResponseWriter|github\.com/labstack/echo(/v\d)?\.\*?Response)\.Header$`),
+				ResponseHeaderPattern{
+					CallRegex:     `^SetCookie$`,
+					RecvTypeRegex: `^github\.com/labstack/echo(/v\d)?\.Context$`,
+					Header:        setCookieHeader,
+				},
+				// c.File / c.Attachment / c.Inline serve through http.ServeContent.
+				ResponseHeaderPattern{
+					CallRegex:     `^(File|Attachment|Inline)$`,
+					RecvTypeRegex: `^github\.com/labstack/echo(/v\d)?\.Context$`,
+					Header:        acceptRangesHeader,
+				},
+			),
 			MountPatterns: []MountPattern{
 				{
 					CallRegex:      `^Group$`,
@@ -32,12 +32,13 @@ func DefaultFiberConfig() *APISpecConfig {
 	responsePatterns := netHTTPResponsePatterns()
 	responsePatterns = append(responsePatterns,
 		ResponsePattern{
-			CallRegex:      `^JSON$`,
-			StatusArgIndex: -1, // Fiber's c.JSON does not take status, only data
-			TypeArgIndex:   0,
-			TypeFromArg:    true,
-			Deref:          true,
-			RecvTypeRegex:  `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+			CallRegex:         `^(JSON|XML)$`,
+			StatusArgIndex:    -1, // Fiber's c.JSON does not take status, only data
+			TypeArgIndex:      0,
+			TypeFromArg:       true,
+			Deref:             true,
+			RecvTypeRegex:     `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+			ContentTypeByCall: renderContentTypes(),
 		},
 		ResponsePattern{
 			CallRegex:      `^Status$`,
@@ -59,8 +60,9 @@ func DefaultFiberConfig() *APISpecConfig {
 			TypeArgIndex:   -1,
 			RecvTypeRegex:  `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
 		},
-		jsonEncodePattern(".*json(iter)?\\.\\*?Encoder"),
 	)
+	responsePatterns = append(responsePatterns, markupEncodePatterns()...)
+	responsePatterns = append(responsePatterns, jsonEncodePattern(".*json(iter)?\\.\\*?Encoder"))
 
 	return &APISpecConfig{
 		Framework: FrameworkConfig{
@@ -76,7 +78,7 @@ func DefaultFiberConfig() *APISpecConfig {
 				},
 			},
 			RequestContext: fiberRequestContext,
-			RequestBodyPatterns: []RequestBodyPattern{
+			RequestBodyPatterns: append([]RequestBodyPattern{
 				{
 					CallRegex:     `^BodyParser$`,
 					TypeArgIndex:  0,
@@ -86,9 +88,16 @@ func DefaultFiberConfig() *APISpecConfig {
 				},
 				jsonDecodeRequestPattern(".*json(iter)?\\.\\*?Decoder"),
 				jsonUnmarshalRequestPattern("json"),
-			},
+			}, jsonPatchRequestPatterns()...),
 			ResponsePatterns: responsePatterns,
 			ParamPatterns: []ParamPattern{
+				{
+					// c.Get("X-Tenant-ID") reads a request header.
+					CallRegex:     "^Get$",
+					ParamIn:       "header",
+					ParamArgIndex: 0,
+					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+				},
 				{
 					CallRegex:     "^Params$",
 					ParamIn:       "path",
@@ -101,12 +110,67 @@ func DefaultFiberConfig() *APISpecConfig {
 					ParamArgIndex: 0,
 					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
 				},
+				{
+					CallRegex:     "^QueryInt$",
+					ParamIn:       "query",
+					ParamArgIndex: 0,
+					ParamType:     "int",
+					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+				},
+				{
+					CallRegex:     "^QueryBool$",
+					ParamIn:       "query",
+					ParamArgIndex: 0,
+					ParamType:     "bool",
+					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+				},
+				{
+					CallRegex:     "^QueryFloat$",
+					ParamIn:       "query",
+					ParamArgIndex: 0,
+					ParamType:     "float64",
+					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+				},
+				{
+					// c.QueryParser(&q) — one query parameter per field of q,
+					// named by its query tag.
+					CallRegex:     "^QueryParser$",
+					ParamIn:       "query",
+					ParamArgIndex: -1,
+					TypeArgIndex:  0,
+					StructTag:     "query",
+					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+				},
+				{
+					// c.ParamsParser(&p) — one path parameter per params-tagged field.
+					CallRegex:     "^ParamsParser$",
+					ParamIn:       "path",
+					ParamArgIndex: -1,
+					TypeArgIndex:  0,
+					StructTag:     "params",
+					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+				},
 				{
 					CallRegex:     "^FormValue$",
 					ParamIn:       "form",
 					ParamArgIndex: 0,
 					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
 				},
+				{
+					// c.FormFile("file") — an uploaded file part.
+					CallRegex:     "^FormFile$",
+					ParamIn:       "file",
+					ParamArgIndex: 0,
+					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+				},
+				{
+					// c.MultipartForm() names no field but makes the form body
+					// multipart/form-data.
+					CallRegex:     "^MultipartForm$",
+					ParamIn:       "multipart",
+					ParamArgIndex: -1,
+					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+				},
 				{
 					CallRegex:     "^Cookies$",
 					ParamIn:       "cookie",
@@ -115,6 +179,56 @@ func DefaultFiberConfig() *APISpecConfig {
 				},
 			},
 			SecurityPatterns: fiberSecurityPatterns(),
+			// fiber runs on fasthttp: headers go through Ctx.Set, its
+			// websocket middleware wraps the handler with websocket.New, and
+			// c.Download / c.Attachment serve files.
+			ProtocolPatterns: append(httpProtocolPatterns(),
+				ProtocolPattern{
+					CallRegex:     `^Set$`,
+					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+					Protocol:      ProtocolSSE,
+					ArgIndex:      1,
+					ArgValueRegex: `text/event-stream`,
+				},
+				ProtocolPattern{
+					CallRegex:     `^Set$`,
+					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+					Protocol:      ProtocolNDJSON,
+					ArgIndex:      1,
+					ArgValueRegex: ndjsonContentType,
+				},
+				ProtocolPattern{
+					CallRegex:     `^New$`,
+					RecvTypeRegex: `^github\.com/gofiber/(contrib/)?websocket(/v\d)?$`,
+					Protocol:      ProtocolWebSocket,
+				},
+				ProtocolPattern{
+					CallRegex:     `^(Download|Attachment)$`,
+					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+					Protocol:      ProtocolDownload,
+				},
+				ProtocolPattern{
+					CallRegex:     `^Set$`,
+					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+					Protocol:      ProtocolDownload,
+					ArgIndex:      1,
+					ArgValueRegex: attachmentDisposition,
+				},
+			),
+			ResponseHeaderPatterns: []ResponseHeaderPattern{
+				{
+					// c.Set("X-Request-ID", id) / c.Append("Vary", "Origin").
+					CallRegex:     `^(Set|Append)$`,
+					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+					NameArgIndex:  0,
+				},
+				{
+					// c.Cookie(&fiber.Cookie{...}) / c.ClearCookie("session").
+					CallRegex:     `^(Cookie|ClearCookie)$`,
+					RecvTypeRegex: `^github\.com/gofiber/fiber(/v\d)?\.\*Ctx$`,
+					Header:        setCookieHeader,
+				},
+			},
 			MountPatterns: []MountPattern{
 				{
 					CallRegex:      `^Mount$`,
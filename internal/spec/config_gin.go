@@ -34,17 +34,28 @@ func DefaultGinConfig() *APISpecConfig {
 	responsePatterns := netHTTPResponsePatterns()
 	responsePatterns = append(responsePatterns,
 		ResponsePattern{
-			CallRegex:      `^(?i)(JSON|String|XML|YAML|ProtoBuf|Data|File|Redirect)$`,
-			StatusArgIndex: 0,
-			TypeArgIndex:   1,
-			TypeFromArg:    true,
-			StatusFromArg:  true,
+			CallRegex:         `^(?i)(JSON|String|XML|YAML|ProtoBuf|Data|File|Redirect)$`,
+			StatusArgIndex:    0,
+			TypeArgIndex:      1,
+			TypeFromArg:       true,
+			StatusFromArg:     true,
+			ContentTypeByCall: renderContentTypes(),
 		},
-		jsonEncodePattern(""),
 	)
+	responsePatterns = append(responsePatterns, markupEncodePatterns()...)
+	responsePatterns = append(responsePatterns, jsonEncodePattern(""))
 
 	return &APISpecConfig{
 		Framework: FrameworkConfig{
+			HandlerInterfaceMethods: []string{"ServeHTTP"},
+			HandlerAdapters: []HandlerAdapterPattern{
+				{
+					// gin.WrapH(h) adapts an http.Handler, gin.WrapF(fn) an
+					// http.HandlerFunc.
+					CallRegex:     `^(WrapH|WrapF)$`,
+					RecvTypeRegex: `^github\.com/gin-gonic/gin$`,
+				},
+			},
 			RoutePatterns: []RoutePattern{
 				{
 					CallRegex:       `^(?i)(GET|POST|PUT|DELETE|PATCH|OPTIONS|HEAD)$`,
@@ -55,9 +66,43 @@ func DefaultGinConfig() *APISpecConfig {
 					HandlerArgIndex: 1,
 					RecvTypeRegex:   "^github\\.com/gin-gonic/gin\\.\\*(Engine|RouterGroup)$",
 				},
+				{
+					// r.Handle(http.MethodGet, "/x", h) — the verb is the
+					// first argument.
+					CallRegex:       `^Handle$`,
+					PathFromArg:     true,
+					HandlerFromArg:  true,
+					MethodArgIndex:  0,
+					PathArgIndex:    1,
+					HandlerArgIndex: 2,
+					RecvTypeRegex:   "^github\\.com/gin-gonic/gin\\.\\*(Engine|RouterGroup)$",
+				},
+				{
+					// r.Any("/x", h) registers gin's anyMethods; CONNECT and
+					// TRACE have no OpenAPI operation and are left out.
+					CallRegex:       `^Any$`,
+					Methods:         []string{"GET", "POST", "PUT", "PATCH", "HEAD", "OPTIONS", "DELETE"},
+					PathFromArg:     true,
+					HandlerFromArg:  true,
+					MethodArgIndex:  -1,
+					PathArgIndex:    0,
+					HandlerArgIndex: 1,
+					RecvTypeRegex:   "^github\\.com/gin-gonic/gin\\.\\*(Engine|RouterGroup)$",
+				},
+				{
+					// r.Static("/assets", "./public") and r.StaticFS("/assets",
+					// fs) serve the files below the mount on GET and HEAD.
+					CallRegex:      `^(Static|StaticFS)$`,
+					Methods:        []string{"GET", "HEAD"},
+					FileServer:     true,
+					PathFromArg:    true,
+					MethodArgIndex: -1,
+					PathArgIndex:   0,
+					RecvTypeRegex:  "^github\\.com/gin-gonic/gin\\.\\*(Engine|RouterGroup)$",
+				},
 			},
 			RequestContext: ginRequestContext,
-			RequestBodyPatterns: []RequestBodyPattern{
+			RequestBodyPatterns: append([]RequestBodyPattern{
 				{
 					CallRegex:    `^(?i)(BindJSON|ShouldBindJSON|BindXML|BindYAML|BindForm|ShouldBind)$`,
 					TypeArgIndex: 0,
@@ -66,7 +111,7 @@ func DefaultGinConfig() *APISpecConfig {
 				},
 				jsonDecodeRequestPattern(""),
 				jsonUnmarshalRequestPattern(""),
-			},
+			}, jsonPatchRequestPatterns()...),
 			ResponsePatterns: responsePatterns,
 			ParamPatterns: []ParamPattern{
 				{
@@ -84,13 +129,133 @@ func DefaultGinConfig() *APISpecConfig {
 					ParamIn:       "query",
 					ParamArgIndex: 0,
 				},
+				{
+					CallRegex:     "^GetQuery$",
+					ParamIn:       "query",
+					ParamArgIndex: 0,
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`,
+				},
+				{
+					// c.QueryArray("tag") — a repeated key (?tag=a&tag=b).
+					CallRegex:     "^(Get)?QueryArray$",
+					ParamIn:       "query",
+					ParamArgIndex: 0,
+					ParamType:     "[]string",
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`,
+				},
+				{
+					// c.ShouldBindQuery(&q) — one query parameter per field of
+					// q, named by its form tag.
+					CallRegex:     "^(ShouldBindQuery|BindQuery)$",
+					ParamIn:       "query",
+					ParamArgIndex: -1,
+					TypeArgIndex:  0,
+					StructTag:     "form",
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`,
+				},
+				{
+					// c.ShouldBindUri(&p) — one path parameter per uri-tagged field.
+					CallRegex:     "^(ShouldBindUri|BindUri)$",
+					ParamIn:       "path",
+					ParamArgIndex: -1,
+					TypeArgIndex:  0,
+					StructTag:     "uri",
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`,
+				},
 				{
 					CallRegex:     "^GetHeader$",
 					ParamIn:       "header",
 					ParamArgIndex: 0,
 				},
+				{
+					// c.Cookie("session") — a request cookie.
+					CallRegex:     "^Cookie$",
+					ParamIn:       "cookie",
+					ParamArgIndex: 0,
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`,
+				},
+				{
+					// c.Request.Header.Get("X-Tenant-ID") — a request header.
+					CallRegex:     "^Get$",
+					ParamIn:       "header",
+					ParamArgIndex: 0,
+					RecvType:      "net/http.Header",
+				},
+				{
+					// c.FormFile("file") — an uploaded file part.
+					CallRegex:     "^FormFile$",
+					ParamIn:       "file",
+					ParamArgIndex: 0,
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`,
+				},
+				{
+					// c.MultipartForm() names no field but makes the form body
+					// multipart/form-data.
+					CallRegex:     "^MultipartForm$",
+					ParamIn:       "multipart",
+					ParamArgIndex: -1,
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`,
+				},
 			},
 			SecurityPatterns: ginSecurityPatterns(),
+			// gin streams through its own Context API (c.SSEvent / c.Stream)
+			// rather than an http.Header write, announces an NDJSON body with
+			// c.Header("Content-Type", ...), flushes chunks through
+			// c.Writer.Flush, and serves attachments with c.FileAttachment or
+			// a c.Header("Content-Disposition", ...).
+			ProtocolPatterns: append(httpProtocolPatterns(),
+				ProtocolPattern{
+					CallRegex:     `^(SSEvent|Stream)$`,
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`,
+					Protocol:      ProtocolSSE,
+				},
+				ProtocolPattern{
+					CallRegex:     `^Header$`,
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`,
+					Protocol:      ProtocolNDJSON,
+					ArgIndex:      1,
+					ArgValueRegex: ndjsonContentType,
+				},
+				ProtocolPattern{
+					CallRegex:     `^Flush$`,
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.ResponseWriter$`,
+					Protocol:      ProtocolChunked,
+				},
+				ProtocolPattern{
+					CallRegex:     `^FileAttachment$`,
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`,
+					Protocol:      ProtocolDownload,
+				},
+				ProtocolPattern{
+					CallRegex:     `^Header$`,
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`,
+					Protocol:      ProtocolDownload,
+					ArgIndex:      1,
+					ArgValueRegex: attachmentDisposition,
+				},
+			),
+			// c.Header("X-Request-ID", id), or c.Writer.Header().Set;
+			// c.SetCookie(name, value, ...) sets Set-Cookie.
+			ResponseHeaderPatterns: append(
+				httpResponseHeaderPatterns(`^(net/http|github\.com/gin-gonic/gin)\.ResponseWriter\.Header$`),
+				ResponseHeaderPattern{
+					CallRegex:     `^Header$`,
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`,
+					NameArgIndex:  0,
+				},
+				ResponseHeaderPattern{
+					CallRegex:     `^SetCookie$`,
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`,
+					Header:        setCookieHeader,
+				},
+				// c.File / c.FileAttachment / c.FileFromFS serve through
+				// http.ServeFile.
+				ResponseHeaderPattern{
+					CallRegex:     `^(File|FileAttachment|FileFromFS)$`,
+					RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`,
+					Header:        acceptRangesHeader,
+				},
+			),
 			MountPatterns: []MountPattern{
 				{
 					CallRegex:      `^Group$`,
@@ -61,14 +61,16 @@ func DefaultHTTPConfig() *APISpecConfig {
 	responsePatterns := netHTTPResponsePatterns()
 	responsePatterns = append(responsePatterns,
 		ResponsePattern{
-			CallRegex:      `^(?i)(JSON|String|XML|YAML|ProtoBuf|Data|File|Redirect)$`,
-			StatusArgIndex: 0,
-			TypeArgIndex:   1,
-			TypeFromArg:    true,
-			Deref:          true,
+			CallRegex:         `^(?i)(JSON|String|XML|YAML|ProtoBuf|Data|File|Redirect)$`,
+			StatusArgIndex:    0,
+			TypeArgIndex:      1,
+			TypeFromArg:       true,
+			Deref:             true,
+			ContentTypeByCall: renderContentTypes(),
 		},
-		jsonEncodePattern(""),
 	)
+	responsePatterns = append(responsePatterns, markupEncodePatterns()...)
+	responsePatterns = append(responsePatterns, jsonEncodePattern(""))
 
 	return &APISpecConfig{
 		Framework: FrameworkConfig{
@@ -96,9 +98,11 @@ func DefaultHTTPConfig() *APISpecConfig {
 					HandlerArgIndex: 1,
 				},
 			},
-			SecurityPatterns: httpSecurityPatterns(),
-			RequestContext:   netHTTPRequestContext,
-			ResponseContext:  netHTTPResponseContext,
+			SecurityPatterns:       httpSecurityPatterns(),
+			ProtocolPatterns:       httpProtocolPatterns(),
+			ResponseHeaderPatterns: httpResponseHeaderPatterns(httpResponseWriterHeader),
+			RequestContext:         netHTTPRequestContext,
+			ResponseContext:        netHTTPResponseContext,
 			MountPatterns: []MountPattern{
 				{
 					CallRegex:      `^Handle$`,
@@ -112,10 +116,10 @@ func DefaultHTTPConfig() *APISpecConfig {
 					RouterArgTypeRegex: `^\*?(github\.com/go-chi/chi(/v\d)?\.(Mux|Router)|github\.com/gorilla/mux\.Router|net/http\.ServeMux|github\.com/labstack/echo(/v\d)?\.Echo|github\.com/gin-gonic/gin\.(Engine|RouterGroup)|github\.com/gofiber/fiber(/v\d)?\.App)$`,
 				},
 			},
-			RequestBodyPatterns: []RequestBodyPattern{
+			RequestBodyPatterns: append([]RequestBodyPattern{
 				jsonDecodeRequestPattern(""),
 				jsonUnmarshalRequestPattern(""),
-			},
+			}, jsonPatchRequestPatterns()...),
 			ResponsePatterns: responsePatterns,
 			ParamPatterns: []ParamPattern{
 				{
@@ -123,6 +127,21 @@ func DefaultHTTPConfig() *APISpecConfig {
 					ParamIn:       "form",
 					ParamArgIndex: 0,
 				},
+				{
+					// r.FormFile("avatar") — an uploaded file part.
+					CallRegex:     "^FormFile$",
+					ParamIn:       "file",
+					ParamArgIndex: 0,
+					RecvType:      "net/http.*Request",
+				},
+				{
+					// r.ParseMultipartForm(maxMemory) names no field but makes
+					// the form body multipart/form-data.
+					CallRegex:     "^ParseMultipartForm$",
+					ParamIn:       "multipart",
+					ParamArgIndex: -1,
+					RecvType:      "net/http.*Request",
+				},
 				{
 					// r.Header.Get("X-Foo") — scope to the http.Header
 					// receiver so package-level funcs that happen to be named
@@ -145,6 +164,7 @@ func DefaultHTTPConfig() *APISpecConfig {
 					CallRegex:     "^Cookie$",
 					ParamIn:       "cookie",
 					ParamArgIndex: 0,
+					RecvType:      "net/http.*Request",
 				},
 				{
 					// Go 1.22 ServeMux path wildcards: id := r.PathValue("id")
@@ -0,0 +1,180 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/diag"
+	"gopkg.in/yaml.v3"
+)
+
+// unknownConfigKeys returns a diagnostic for every mapping key in the config
+// document root that no field of APISpecConfig decodes — a misspelled
+// "callRegx" is otherwise dropped without a word and its pattern silently
+// matches everything. Each names the key's line in file and the closest
+// known key at that level.
+func unknownConfigKeys(file string, root *yaml.Node) []diag.Diagnostic {
+	return unknownYAMLKeys(file, root, reflect.TypeOf(APISpecConfig{}), "config")
+}
+
+// unknownYAMLKeys is unknownConfigKeys for a document decoded into t; the
+// diagnostics carry category.
+func unknownYAMLKeys(file string, root *yaml.Node, t reflect.Type, category string) []diag.Diagnostic {
+	if root == nil {
+		return nil
+	}
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return nil
+		}
+		root = root.Content[0]
+	}
+	w := keyWalker{file: file, category: category}
+	w.walk(root, t, "")
+	return w.out
+}
+
+type keyWalker struct {
+	file     string
+	category string
+	out      []diag.Diagnostic
+}
+
+var yamlUnmarshaler = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+
+// walk checks node against t, the Go type it decodes into, and recurses
+// through structs, maps and slices. Types that take arbitrary keys
+// (interface values, inline maps, custom unmarshalers) end the walk.
+func (w *keyWalker) walk(node *yaml.Node, t reflect.Type, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if node == nil || node.Kind == yaml.AliasNode || reflect.PointerTo(t).Implements(yamlUnmarshaler) {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		fields, open := yamlFields(t)
+		if open {
+			return
+		}
+		names := make([]string, 0, len(fields))
+		for _, f := range fields {
+			names = append(names, f.name)
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "<<" {
+				continue
+			}
+			f, ok := findYAMLField(fields, key.Value)
+			if !ok {
+				w.out = append(w.out, diag.Diagnostic{
+					Severity:   diag.Warning,
+					Category:   w.category,
+					Message:    fmt.Sprintf("unknown key %q%s is ignored", key.Value, inPath(path)),
+					Pos:        diag.Position{File: w.file, Line: key.Line, Column: key.Column},
+					Suggestion: diag.Suggest(key.Value, names),
+				})
+				continue
+			}
+			w.walk(value, f.typ, joinKeyPath(path, key.Value))
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			w.walk(node.Content[i+1], t.Elem(), joinKeyPath(path, node.Content[i].Value))
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, item := range node.Content {
+			w.walk(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+type yamlField struct {
+	name string
+	typ  reflect.Type
+}
+
+// yamlFields lists the keys a struct decodes, flattening ",inline" structs.
+// open is set when an inline map accepts any other key.
+func yamlFields(t reflect.Type) (fields []yamlField, open bool) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if strings.Contains(","+opts+",", ",inline,") {
+			ft := sf.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() != reflect.Struct {
+				return nil, true
+			}
+			inner, innerOpen := yamlFields(ft)
+			if innerOpen {
+				return nil, true
+			}
+			fields = append(fields, inner...)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		fields = append(fields, yamlField{name: name, typ: sf.Type})
+	}
+	return fields, false
+}
+
+func findYAMLField(fields []yamlField, name string) (yamlField, bool) {
+	for _, f := range fields {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return yamlField{}, false
+}
+
+func joinKeyPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func inPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	return " in " + path
+}
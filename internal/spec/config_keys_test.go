@@ -0,0 +1,75 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadAPISpecConfigWithDiagnostics_UnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apispec.yaml")
+	content := `info:
+  title: Users
+framework:
+  routePatterns:
+    - callRegx: ^HandleFunc$
+      recvTypeRegex: mux
+  requestBodyPatterns:
+    - callRegex: ^Decode$
+routePatterns:
+  - callRegex: ^RegisterJSON$
+    handlerArgIndex: 1
+    methodbycall: {GetJSON: GET}
+securitySchemes:
+  bearerAuth: {type: http, scheme: bearer}
+overrides:
+  - functionName: getUser
+    responseType: User
+    x-note: anything
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, diags, err := LoadAPISpecConfigWithDiagnostics(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Info.Title != "Users" || len(cfg.RoutePatterns) != 1 {
+		t.Errorf("config not decoded: %+v", cfg)
+	}
+
+	type issue struct {
+		message    string
+		line       int
+		suggestion string
+	}
+	want := []issue{
+		{`unknown key "callRegx" in framework.routePatterns[0] is ignored`, 5, "callRegex"},
+		{`unknown key "methodbycall" in routePatterns[0] is ignored`, 12, "methodByCall"},
+		{`unknown key "x-note" in overrides[0] is ignored`, 18, ""},
+	}
+	if len(diags) != len(want) {
+		t.Fatalf("got %d diagnostics, want %d: %+v", len(diags), len(want), diags)
+	}
+	for i, w := range want {
+		d := diags[i]
+		if d.Message != w.message || d.Pos.File != path || d.Pos.Line != w.line || d.Suggestion != w.suggestion {
+			t.Errorf("[%d] got %q at line %d (suggest %q), want %q at line %d (suggest %q)",
+				i, d.Message, d.Pos.Line, d.Suggestion, w.message, w.line, w.suggestion)
+		}
+	}
+}
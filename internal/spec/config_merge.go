@@ -32,8 +32,8 @@ import "strings"
 //     status from arg 0 and would misread status-less calls like fiber's
 //     c.JSON(obj) if merged into that framework.
 //   - Marshal/Encode/Decode variants use the encoder/decoder-scoped forms.
-//   - FormValue/Cookie param patterns are omitted until they gain receiver
-//     scoping; the scoped header/query/PathValue patterns are included.
+//   - FormValue param patterns are omitted until they gain receiver
+//     scoping; the scoped header/query/cookie/PathValue patterns are included.
 func HTTPSecondaryConfig() *APISpecConfig {
 	serveMuxRecv := "^net/http(\\.\\*ServeMux)?$"
 	return &APISpecConfig{
@@ -73,12 +73,14 @@ func HTTPSecondaryConfig() *APISpecConfig {
 					RouterArgTypeRegex: `^\*?(github\.com/go-chi/chi(/v\d)?\.(Mux|Router)|github\.com/gorilla/mux\.Router|net/http\.ServeMux|github\.com/labstack/echo(/v\d)?\.Echo|github\.com/gin-gonic/gin\.(Engine|RouterGroup)|github\.com/gofiber/fiber(/v\d)?\.App)$`,
 				},
 			},
-			SecurityPatterns: httpSecurityPatterns(),
-			RequestContext:   netHTTPRequestContext,
-			RequestBodyPatterns: []RequestBodyPattern{
+			SecurityPatterns:       httpSecurityPatterns(),
+			ProtocolPatterns:       httpProtocolPatterns(),
+			ResponseHeaderPatterns: httpResponseHeaderPatterns(httpResponseWriterHeader),
+			RequestContext:         netHTTPRequestContext,
+			RequestBodyPatterns: append([]RequestBodyPattern{
 				jsonDecodeRequestPattern(".*json(iter)?\\.\\*Decoder"),
 				jsonUnmarshalRequestPattern("json"),
-			},
+			}, jsonPatchRequestPatterns()...),
 			ResponsePatterns: netHTTPResponsePatterns(),
 			ParamPatterns: []ParamPattern{
 				{
@@ -99,6 +101,12 @@ func HTTPSecondaryConfig() *APISpecConfig {
 					ParamArgIndex: 0,
 					RecvType:      "net/http.*Request",
 				},
+				{
+					CallRegex:     "^Cookie$",
+					ParamIn:       "cookie",
+					ParamArgIndex: 0,
+					RecvType:      "net/http.*Request",
+				},
 			},
 		},
 	}
@@ -139,6 +147,11 @@ func SecondaryView(cfg *APISpecConfig) *APISpecConfig {
 			out.Framework.ResponsePatterns = append(out.Framework.ResponsePatterns, p)
 		}
 	}
+	for _, p := range cfg.Framework.ResponseHelpers {
+		if p.RecvTypeRegex != "" {
+			out.Framework.ResponseHelpers = append(out.Framework.ResponseHelpers, p)
+		}
+	}
 	for _, p := range cfg.Framework.ParamPatterns {
 		if p.RecvType != "" || p.RecvTypeRegex != "" {
 			out.Framework.ParamPatterns = append(out.Framework.ParamPatterns, p)
@@ -154,6 +167,16 @@ func SecondaryView(cfg *APISpecConfig) *APISpecConfig {
 			out.Framework.SecurityPatterns = append(out.Framework.SecurityPatterns, p)
 		}
 	}
+	for _, p := range cfg.Framework.ProtocolPatterns {
+		if p.RecvType != "" || p.RecvTypeRegex != "" {
+			out.Framework.ProtocolPatterns = append(out.Framework.ProtocolPatterns, p)
+		}
+	}
+	for _, p := range cfg.Framework.ResponseHeaderPatterns {
+		if p.RecvType != "" || p.RecvTypeRegex != "" {
+			out.Framework.ResponseHeaderPatterns = append(out.Framework.ResponseHeaderPatterns, p)
+		}
+	}
 	return out
 }
 
@@ -180,6 +203,14 @@ func MergeFrameworkConfigs(primary *APISpecConfig, secondaries ...*APISpecConfig
 	for _, p := range primary.Framework.ResponsePatterns {
 		seenResp[patternKey(p.CallRegex, p.RecvTypeRegex, "")] = true
 	}
+	seenHelper := map[string]bool{}
+	for _, p := range primary.Framework.ResponseHelpers {
+		seenHelper[patternKey(p.CallRegex, p.RecvTypeRegex, "")] = true
+	}
+	seenValidation := map[string]bool{}
+	for _, p := range primary.Framework.ValidationPatterns {
+		seenValidation[patternKey(p.CallRegex, p.PkgRegex, "")] = true
+	}
 	seenParam := map[string]bool{}
 	for _, p := range primary.Framework.ParamPatterns {
 		seenParam[patternKey(p.CallRegex, p.RecvTypeRegex+"\x00"+p.RecvType, p.ParamIn)] = true
@@ -192,6 +223,14 @@ func MergeFrameworkConfigs(primary *APISpecConfig, secondaries ...*APISpecConfig
 	for _, p := range primary.Framework.SecurityPatterns {
 		seenSec[patternKey(p.CallRegex, p.RecvTypeRegex, string(p.Scope))] = true
 	}
+	seenProto := map[string]bool{}
+	for _, p := range primary.Framework.ProtocolPatterns {
+		seenProto[patternKey(p.CallRegex, p.RecvTypeRegex+"\x00"+p.RecvType, p.Protocol)] = true
+	}
+	seenHeader := map[string]bool{}
+	for _, p := range primary.Framework.ResponseHeaderPatterns {
+		seenHeader[patternKey(p.CallRegex, p.RecvTypeRegex+"\x00"+p.RecvType, p.HeaderSourceRegex+"\x00"+p.Header)] = true
+	}
 
 	for _, sec := range secondaries {
 		if sec == nil {
@@ -215,6 +254,12 @@ func MergeFrameworkConfigs(primary *APISpecConfig, secondaries ...*APISpecConfig
 				primary.Framework.ResponsePatterns = append(primary.Framework.ResponsePatterns, p)
 			}
 		}
+		for _, p := range sec.Framework.ResponseHelpers {
+			if k := patternKey(p.CallRegex, p.RecvTypeRegex, ""); !seenHelper[k] {
+				seenHelper[k] = true
+				primary.Framework.ResponseHelpers = append(primary.Framework.ResponseHelpers, p)
+			}
+		}
 		for _, p := range sec.Framework.ParamPatterns {
 			if k := patternKey(p.CallRegex, p.RecvTypeRegex+"\x00"+p.RecvType, p.ParamIn); !seenParam[k] {
 				seenParam[k] = true
@@ -233,6 +278,24 @@ func MergeFrameworkConfigs(primary *APISpecConfig, secondaries ...*APISpecConfig
 				primary.Framework.SecurityPatterns = append(primary.Framework.SecurityPatterns, p)
 			}
 		}
+		for _, p := range sec.Framework.ProtocolPatterns {
+			if k := patternKey(p.CallRegex, p.RecvTypeRegex+"\x00"+p.RecvType, p.Protocol); !seenProto[k] {
+				seenProto[k] = true
+				primary.Framework.ProtocolPatterns = append(primary.Framework.ProtocolPatterns, p)
+			}
+		}
+		for _, p := range sec.Framework.ResponseHeaderPatterns {
+			if k := patternKey(p.CallRegex, p.RecvTypeRegex+"\x00"+p.RecvType, p.HeaderSourceRegex+"\x00"+p.Header); !seenHeader[k] {
+				seenHeader[k] = true
+				primary.Framework.ResponseHeaderPatterns = append(primary.Framework.ResponseHeaderPatterns, p)
+			}
+		}
+		for _, p := range sec.Framework.ValidationPatterns {
+			if k := patternKey(p.CallRegex, p.PkgRegex, ""); !seenValidation[k] {
+				seenValidation[k] = true
+				primary.Framework.ValidationPatterns = append(primary.Framework.ValidationPatterns, p)
+			}
+		}
 		primary.Framework.RequestContext.TypeRegexes = appendUniqueStrings(
 			primary.Framework.RequestContext.TypeRegexes, sec.Framework.RequestContext.TypeRegexes...)
 		primary.Framework.RequestContext.BodyAccessors = appendUniqueStrings(
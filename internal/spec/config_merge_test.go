@@ -135,6 +135,36 @@ func TestMergeFrameworkConfigs(t *testing.T) {
 		}
 	})
 
+	t.Run("response helpers append with the primary's variant winning", func(t *testing.T) {
+		primary := DefaultHTTPConfig()
+		primary.Framework.ResponseHelpers = []ResponseHelperPattern{{CallRegex: "^respond$", BodyArgIndex: intPtr(1), StatusArgIndex: intPtr(-1)}}
+		sec := HTTPSecondaryConfig()
+		sec.Framework.ResponseHelpers = []ResponseHelperPattern{
+			{CallRegex: "^respond$", BodyArgIndex: intPtr(2)},
+			{CallRegex: "^fail$", BodyArgIndex: intPtr(-1), StatusArgIndex: intPtr(1)},
+		}
+		MergeFrameworkConfigs(primary, sec)
+		got := primary.Framework.ResponseHelpers
+		if len(got) != 2 || *got[0].BodyArgIndex != 1 || got[1].CallRegex != "^fail$" {
+			t.Errorf("merged response helpers = %+v", got)
+		}
+	})
+
+	t.Run("validation patterns append with the primary's variant winning", func(t *testing.T) {
+		primary := DefaultChiConfig()
+		primary.Framework.ValidationPatterns = []ValidationPattern{{CallRegex: "^Body$", PkgRegex: "/validate$", TagName: "rules"}}
+		sec := HTTPSecondaryConfig()
+		sec.Framework.ValidationPatterns = []ValidationPattern{
+			{CallRegex: "^Body$", PkgRegex: "/validate$"},
+			{CallRegex: "^Query$", PkgRegex: "/validate$"},
+		}
+		MergeFrameworkConfigs(primary, sec)
+		got := primary.Framework.ValidationPatterns
+		if len(got) != 2 || got[0].TagName != "rules" || got[1].CallRegex != "^Query$" {
+			t.Errorf("merged validation patterns = %+v", got)
+		}
+	})
+
 	t.Run("nil secondaries are ignored", func(t *testing.T) {
 		primary := DefaultMuxConfig()
 		before := len(primary.Framework.RoutePatterns)
@@ -14,7 +14,10 @@
 
 package spec
 
-import "net/http"
+import (
+	"net/http"
+	"slices"
+)
 
 // DefaultMethodExtractionConfig returns the default verb-from-handler-name
 // method extraction rules used by frameworks that don't carry the HTTP
@@ -95,12 +98,13 @@ func DefaultMuxConfig() *APISpecConfig {
 			},
 			RequestContext:  netHTTPRequestContext,
 			ResponseContext: netHTTPResponseContext,
-			RequestBodyPatterns: []RequestBodyPattern{
+			RequestBodyPatterns: append([]RequestBodyPattern{
 				jsonDecodeRequestPattern(".*json(iter)?\\.\\*?Decoder"),
 				jsonUnmarshalRequestPattern("json"),
-			},
-			ResponsePatterns: append(netHTTPResponsePatterns(),
-				jsonEncodePattern(".*json(iter)?\\.\\*?Encoder"),
+			}, jsonPatchRequestPatterns()...),
+			ResponsePatterns: slices.Concat(netHTTPResponsePatterns(),
+				markupEncodePatterns(),
+				[]ResponsePattern{jsonEncodePattern(".*json(iter)?\\.\\*?Encoder")},
 			),
 			ParamPatterns: []ParamPattern{
 				// gorilla/mux exposes path variables as a map: `mux.Vars(r)["id"]`.
@@ -114,8 +118,24 @@ func DefaultMuxConfig() *APISpecConfig {
 					NameFromMapKey: true,
 					RecvTypeRegex:  `^github\.com/gorilla/mux$`,
 				},
+				{
+					// r.Header.Get("X-Tenant-ID") — a request header.
+					CallRegex:     "^Get$",
+					ParamIn:       "header",
+					ParamArgIndex: 0,
+					RecvType:      "net/http.Header",
+				},
+				{
+					// r.Cookie("session") — a request cookie.
+					CallRegex:     "^Cookie$",
+					ParamIn:       "cookie",
+					ParamArgIndex: 0,
+					RecvType:      "net/http.*Request",
+				},
 			},
-			SecurityPatterns: muxSecurityPatterns(),
+			SecurityPatterns:       muxSecurityPatterns(),
+			ProtocolPatterns:       httpProtocolPatterns(),
+			ResponseHeaderPatterns: httpResponseHeaderPatterns(httpResponseWriterHeader),
 			MountPatterns: []MountPattern{
 				{
 					CallRegex:     `^PathPrefix$`,
@@ -15,6 +15,8 @@
 package spec
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -261,3 +263,48 @@ func TestConfigPatternValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestResponseHelperPattern_ResponsePattern(t *testing.T) {
+	body := ResponseHelperPattern{CallRegex: "^respondWithSuccess$", BodyArgIndex: intPtr(1), StatusArgIndex: intPtr(-1), DefaultStatus: 200}.responsePattern()
+	if !body.TypeFromArg || body.TypeArgIndex != 1 || body.StatusFromArg || body.DefaultStatus != 200 {
+		t.Errorf("body helper translated to %+v", body)
+	}
+	status := ResponseHelperPattern{CallRegex: "^respondWithError$", BodyArgIndex: intPtr(-1), StatusArgIndex: intPtr(2)}.responsePattern()
+	if status.TypeFromArg || !status.StatusFromArg || status.StatusArgIndex != 2 || status.CallRegex != "^respondWithError$" {
+		t.Errorf("status helper translated to %+v", status)
+	}
+}
+
+// TestResponseHelperPattern_OmittedIndexes pins that a helper config leaving
+// out bodyArgIndex or statusArgIndex reads no argument for it, rather than
+// argument 0.
+func TestResponseHelperPattern_OmittedIndexes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apispec.yaml")
+	content := `framework:
+  responseHelpers:
+    - callRegex: ^respondWithSuccess$
+      bodyArgIndex: 1
+      defaultStatus: 200
+    - callRegex: ^respondWithError$
+      statusArgIndex: 0
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadAPISpecConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	helpers := cfg.Framework.ResponseHelpers
+	if len(helpers) != 2 {
+		t.Fatalf("got %d response helpers, want 2", len(helpers))
+	}
+	body := helpers[0].responsePattern()
+	if !body.TypeFromArg || body.TypeArgIndex != 1 || body.StatusFromArg || body.DefaultStatus != 200 {
+		t.Errorf("helper without statusArgIndex translated to %+v", body)
+	}
+	status := helpers[1].responsePattern()
+	if status.TypeFromArg || !status.StatusFromArg || status.StatusArgIndex != 0 {
+		t.Errorf("helper without bodyArgIndex translated to %+v", status)
+	}
+}
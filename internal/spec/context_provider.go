@@ -79,8 +79,9 @@ func (c *ContextProviderImpl) callArgToString(arg *metadata.CallArgument, sep *s
 		}
 		return "map"
 
-	case metadata.KindUnary:
-		// Handle unary expressions (e.g., *X)
+	case metadata.KindUnary, metadata.KindStar:
+		// Handle unary expressions and pointer types (e.g., *X, the value
+		// of map[string]*X{})
 		if arg.X != nil {
 			return "*" + c.callArgToString(arg.X, nil)
 		}
@@ -227,6 +227,26 @@ func TestContextProvider_callArgToString_WithVariousKinds(t *testing.T) {
 	}
 }
 
+func TestContextProvider_callArgToString_StarKind(t *testing.T) {
+	meta := &metadata.Metadata{StringPool: metadata.NewStringPool()}
+	provider := NewContextProvider(meta)
+
+	ident := metadata.NewCallArgument(meta)
+	ident.SetKind(metadata.KindIdent)
+	ident.SetName("Item")
+	ident.SetPkg("main")
+	ident.SetType("main.Item")
+
+	star := metadata.NewCallArgument(meta)
+	star.SetKind(metadata.KindStar)
+	star.X = ident
+
+	want := "*main" + TypeSep + "Item"
+	if got := provider.callArgToString(star, nil); got != want {
+		t.Errorf("callArgToString(star) = %q, want %q", got, want)
+	}
+}
+
 func TestContextProvider_callArgToString_WithNilMetadata(t *testing.T) {
 	// Create provider with nil metadata
 	provider := &ContextProviderImpl{meta: nil}
@@ -0,0 +1,170 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/diag"
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// contextGetters are the methods request-scoped values are read back with:
+// context.Context.Value, gin's Get and MustGet, echo's Get, fiber's Locals.
+var contextGetters = map[string]bool{"Value": true, "Get": true, "MustGet": true, "Locals": true}
+
+// contextValuesOf returns the keys of the configured context values a node's
+// call reads, memoized per edge like webhooksOf.
+func (e *Extractor) contextValuesOf(node TrackerNodeInterface) []string {
+	if e.cfg == nil || len(e.cfg.ContextValues) == 0 || node == nil || node.GetEdge() == nil {
+		return nil
+	}
+	edge := node.GetEdge()
+	if keys, ok := e.contextValuesByEdge[edge]; ok {
+		return keys
+	}
+	var keys []string
+	if isContextGetter(edge) && len(edge.Args) > 0 {
+		names := contextKeyNames(edge.Args[0])
+		for _, cv := range e.cfg.ContextValues {
+			if cv.Key != "" && slices.Contains(names, cv.Key) && !slices.Contains(keys, cv.Key) {
+				keys = append(keys, cv.Key)
+			}
+		}
+	}
+	if e.contextValuesByEdge == nil {
+		e.contextValuesByEdge = map[*metadata.CallGraphEdge][]string{}
+	}
+	e.contextValuesByEdge[edge] = keys
+	return keys
+}
+
+// isContextGetter reports whether edge reads a value back from a request
+// context: a getter method of context.Context or of a framework's context
+// type (gin.Context, echo.Context, fiber.Ctx).
+func isContextGetter(edge *metadata.CallGraphEdge) bool {
+	meta := edge.Callee.Meta
+	if !contextGetters[getString(meta, edge.Callee.Name)] {
+		return false
+	}
+	recv := strings.TrimPrefix(getString(meta, edge.Callee.RecvType), "*")
+	if i := strings.LastIndexAny(recv, "./"); i >= 0 {
+		recv = recv[i+1:]
+	}
+	return strings.HasSuffix(recv, "Context") || recv == "Ctx"
+}
+
+// contextKeyNames returns the names a key argument can be configured by:
+// userKey and auth.userKey for an identifier, auth.UserKey and UserKey for a
+// selector, the type of ctxKey{} and ctxKey("user") and the string of a
+// literal key.
+func contextKeyNames(arg *metadata.CallArgument) []string {
+	if arg == nil {
+		return nil
+	}
+	switch arg.GetKind() {
+	case metadata.KindIdent:
+		names := []string{arg.GetName()}
+		if pkg := arg.GetPkg(); pkg != "" {
+			names = append(names, pkg[strings.LastIndex(pkg, "/")+1:]+"."+arg.GetName())
+		}
+		return names
+	case metadata.KindSelector:
+		if arg.X == nil || arg.Sel == nil {
+			return nil
+		}
+		return []string{arg.X.GetName() + "." + arg.Sel.GetName(), arg.Sel.GetName()}
+	case metadata.KindLiteral:
+		if s, err := strconv.Unquote(arg.GetValue()); err == nil {
+			return []string{s}
+		}
+	case metadata.KindCompositeLit, metadata.KindUnary, metadata.KindParen:
+		return contextKeyNames(arg.X)
+	case metadata.KindTypeConversion:
+		names := contextKeyNames(arg.Fun)
+		for _, a := range arg.Args {
+			names = append(names, contextKeyNames(a)...)
+		}
+		return names
+	}
+	return nil
+}
+
+// addContextValues records the context values the route's handler reads,
+// once each.
+func addContextValues(route *RouteInfo, keys ...string) {
+	for _, key := range keys {
+		if !slices.Contains(route.ContextValues, key) {
+			route.ContextValues = append(route.ContextValues, key)
+		}
+	}
+}
+
+// applyContextValues documents what the context values a route's handler
+// reads imply: their security, when no auth middleware was detected for the
+// route, and their parameters, unless the route already has them.
+func (e *Extractor) applyContextValues(route *RouteInfo) {
+	if len(route.ContextValues) == 0 {
+		return
+	}
+	detected := route.Security != nil
+	placeholders := pathPlaceholders(route.OpenAPIPath())
+	for _, cv := range e.cfg.ContextValues {
+		if !slices.Contains(route.ContextValues, cv.Key) {
+			continue
+		}
+		if !detected && len(cv.Security) > 0 {
+			route.Security = dedupSecurityRequirements(append(route.Security, cv.Security...))
+		}
+		for _, p := range cv.Parameters {
+			if p.In == "path" && !slices.Contains(placeholders, p.Name) {
+				continue
+			}
+			if slices.ContainsFunc(route.Params, func(q Parameter) bool { return q.Name == p.Name && q.In == p.In }) {
+				continue
+			}
+			route.Params = append(route.Params, p)
+		}
+	}
+}
+
+// reportUnreadContextValues reports configured context values no route's
+// handler reads: a misspelled key documents nothing.
+func (e *Extractor) reportUnreadContextValues(routes []*RouteInfo) {
+	if e.cfg == nil {
+		return
+	}
+	for i, cv := range e.cfg.ContextValues {
+		if cv.Key == "" {
+			reportContextValue(fmt.Sprintf("contextValues[%d] has no key and is ignored", i), "set key to the key handlers read the value with")
+			continue
+		}
+		if !slices.ContainsFunc(routes, func(r *RouteInfo) bool { return slices.Contains(r.ContextValues, cv.Key) }) {
+			reportContextValue(fmt.Sprintf("contextValues[%d]: no handler reads key %s", i, cv.Key), "name the key as handlers pass it to Value or Get (userKey, auth.UserKey, or the string)")
+		}
+	}
+}
+
+func reportContextValue(message, help string) {
+	diag.Report(diag.Diagnostic{
+		Severity: diag.Warning,
+		Category: "context-values",
+		Message:  message,
+		Help:     help,
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+func TestContextKeyNames(t *testing.T) {
+	meta := newTestMeta()
+	literal := func(v string) *metadata.CallArgument {
+		a := metadata.NewCallArgument(meta)
+		a.SetKind(metadata.KindLiteral)
+		a.SetValue(v)
+		return a
+	}
+	wrap := func(kind string, x *metadata.CallArgument) *metadata.CallArgument {
+		a := metadata.NewCallArgument(meta)
+		a.SetKind(kind)
+		a.X = x
+		return a
+	}
+	pkgIdent := mkIdent(meta, "userKey", "")
+	pkgIdent.Pkg = meta.StringPool.Get("example.com/app/auth")
+	conversion := metadata.NewCallArgument(meta)
+	conversion.SetKind(metadata.KindTypeConversion)
+	conversion.Fun = mkIdent(meta, "ctxKey", "")
+	conversion.Args = []*metadata.CallArgument{literal(`"user"`)}
+
+	for _, tc := range []struct {
+		name string
+		arg  *metadata.CallArgument
+		want []string
+	}{
+		{"identifier", pkgIdent, []string{"userKey", "auth.userKey"}},
+		{"selector", mkSelector(meta, mkIdent(meta, "auth", ""), mkIdent(meta, "UserKey", "")), []string{"auth.UserKey", "UserKey"}},
+		{"string", literal(`"user"`), []string{"user"}},
+		{"struct key", wrap(metadata.KindUnary, wrap(metadata.KindCompositeLit, mkIdent(meta, "ctxKey", ""))), []string{"ctxKey"}},
+		{"conversion", conversion, []string{"ctxKey", "user"}},
+		{"call", wrap(metadata.KindCall, nil), nil},
+	} {
+		if got := contextKeyNames(tc.arg); !slices.Equal(got, tc.want) {
+			t.Errorf("%s: contextKeyNames = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
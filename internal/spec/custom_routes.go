@@ -0,0 +1,148 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/diag"
+)
+
+// CustomRoutePattern declares the registration call of a homegrown router
+// wrapper — srv.RegisterJSON("GET /users/{id}", h) — in a handful of fields.
+// It is the compact form of a RoutePattern: the path and handler always come
+// from arguments, and a Go 1.22 ServeMux verb prefix on the path ("GET /x")
+// is split off as the method.
+//
+// The method is taken, in order, from Method, the MethodByCall entry of the
+// call's name, the MethodArgIndex argument, and the path's verb prefix.
+// Argument indexes are zero-based. MethodArgIndex is unused while it names
+// the path or handler argument, so it may be omitted when the path comes
+// first. Example:
+//
+//	routePatterns:
+//	  - recvTypeRegex: myapp/server\.\*Server$
+//	    callRegex: ^RegisterJSON$           # srv.RegisterJSON("GET /users", h)
+//	    handlerArgIndex: 1
+//	  - recvTypeRegex: myapp/server\.\*Server$
+//	    methodByCall: {GetJSON: GET, PostJSON: POST}
+//	    handlerArgIndex: 1
+//	  - callRegex: ^Route$                  # api.Route("GET", "/users", h)
+//	    methodArgIndex: 0
+//	    pathArgIndex: 1
+//	    handlerArgIndex: 2
+type CustomRoutePattern struct {
+	// CallRegex matches the registration method's name; RecvTypeRegex
+	// optionally narrows it to the wrapper's receiver type.
+	CallRegex     string `yaml:"callRegex,omitempty" json:"callRegex,omitempty"`
+	RecvTypeRegex string `yaml:"recvTypeRegex,omitempty" json:"recvTypeRegex,omitempty"`
+
+	PathArgIndex    int `yaml:"pathArgIndex,omitempty" json:"pathArgIndex,omitempty"`
+	HandlerArgIndex int `yaml:"handlerArgIndex,omitempty" json:"handlerArgIndex,omitempty"`
+	MethodArgIndex  int `yaml:"methodArgIndex,omitempty" json:"methodArgIndex,omitempty"`
+
+	// Method fixes the HTTP method of every matched call.
+	Method string `yaml:"method,omitempty" json:"method,omitempty"`
+
+	// MethodByCall maps registration method names to their HTTP method
+	// (GetJSON: GET). Each entry matches that name, so CallRegex may be left
+	// empty.
+	MethodByCall map[string]string `yaml:"methodByCall,omitempty" json:"methodByCall,omitempty"`
+}
+
+// routePatterns translates p into the RoutePatterns its calls are extracted
+// with: one for CallRegex, and one per MethodByCall entry in name order.
+func (p CustomRoutePattern) routePatterns() []RoutePattern {
+	method := p.MethodArgIndex
+	if method == p.PathArgIndex || method == p.HandlerArgIndex {
+		method = -1
+	}
+	base := RoutePattern{
+		CallRegex:       p.CallRegex,
+		RecvTypeRegex:   p.RecvTypeRegex,
+		Method:          p.Method,
+		MethodArgIndex:  method,
+		PathArgIndex:    p.PathArgIndex,
+		HandlerArgIndex: p.HandlerArgIndex,
+		MethodFromPath:  true,
+		PathFromArg:     true,
+		HandlerFromArg:  true,
+	}
+
+	var patterns []RoutePattern
+	if p.CallRegex != "" {
+		patterns = append(patterns, base)
+	}
+	for _, call := range slices.Sorted(maps.Keys(p.MethodByCall)) {
+		rp := base
+		rp.CallRegex = "^" + regexp.QuoteMeta(call) + "$"
+		if rp.Method == "" {
+			rp.Method = p.MethodByCall[call]
+		}
+		patterns = append(patterns, rp)
+	}
+	return patterns
+}
+
+// ValidateRoutePatterns rejects top-level route patterns that match nothing,
+// carry regexes that do not compile, name an unknown HTTP method, or read the
+// path and handler from the same (or a negative) argument index. It returns
+// the first error encountered.
+func (c *APISpecConfig) ValidateRoutePatterns() error {
+	for i, p := range c.RoutePatterns {
+		if p.CallRegex == "" && len(p.MethodByCall) == 0 {
+			return fmt.Errorf("routePatterns[%d]: needs callRegex or methodByCall", i)
+		}
+		for _, f := range []struct{ name, expr string }{
+			{"callRegex", p.CallRegex}, {"recvTypeRegex", p.RecvTypeRegex},
+		} {
+			if _, err := regexp.Compile(f.expr); err != nil {
+				return fmt.Errorf("routePatterns[%d]: invalid regex in %s %q: %w", i, f.name, f.expr, err)
+			}
+		}
+		if p.PathArgIndex < 0 || p.HandlerArgIndex < 0 || p.MethodArgIndex < 0 {
+			return fmt.Errorf("routePatterns[%d]: argument indexes must not be negative", i)
+		}
+		if p.PathArgIndex == p.HandlerArgIndex {
+			return fmt.Errorf("routePatterns[%d]: pathArgIndex and handlerArgIndex are both %d", i, p.PathArgIndex)
+		}
+		if p.Method != "" && !isHTTPMethod(strings.ToUpper(p.Method)) {
+			return fmt.Errorf("routePatterns[%d]: invalid method %q%s", i, p.Method, methodHint(p.Method))
+		}
+		for _, call := range slices.Sorted(maps.Keys(p.MethodByCall)) {
+			if !isHTTPMethod(strings.ToUpper(p.MethodByCall[call])) {
+				return fmt.Errorf("routePatterns[%d].methodByCall[%s]: invalid method %q%s", i, call, p.MethodByCall[call], methodHint(p.MethodByCall[call]))
+			}
+		}
+	}
+	return nil
+}
+
+// methodHint suggests the HTTP method a misspelled one likely meant:
+// ` (did you mean "GET"?)` for "GTE", or "" when none is close.
+func methodHint(method string) string {
+	if s := diag.Suggest(method, []string{
+		http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete,
+		http.MethodPatch, http.MethodOptions, http.MethodHead, http.MethodConnect, http.MethodTrace,
+	}); s != "" {
+		return fmt.Sprintf(" (did you mean %q?)", s)
+	}
+	return ""
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCustomRoutePattern_RoutePatterns(t *testing.T) {
+	p := CustomRoutePattern{
+		CallRegex:       `^Register$`,
+		RecvTypeRegex:   `\.\*Server$`,
+		HandlerArgIndex: 1,
+		MethodByCall:    map[string]string{"PostJSON": "POST", "GetJSON": "GET"},
+	}
+	got := p.routePatterns()
+	if len(got) != 3 {
+		t.Fatalf("got %d patterns, want 3", len(got))
+	}
+	for i, want := range []struct{ call, method string }{
+		{`^Register$`, ""}, {`^GetJSON$`, "GET"}, {`^PostJSON$`, "POST"},
+	} {
+		rp := got[i]
+		if rp.CallRegex != want.call || rp.Method != want.method {
+			t.Errorf("[%d] call=%q method=%q, want %q %q", i, rp.CallRegex, rp.Method, want.call, want.method)
+		}
+		if rp.RecvTypeRegex != p.RecvTypeRegex || !rp.PathFromArg || !rp.HandlerFromArg || !rp.MethodFromPath {
+			t.Errorf("[%d] lost the shared matcher or extraction hints: %+v", i, rp)
+		}
+		// The default 0 names the path argument, so no method argument.
+		if rp.PathArgIndex != 0 || rp.HandlerArgIndex != 1 || rp.MethodArgIndex != -1 {
+			t.Errorf("[%d] indexes path=%d handler=%d method=%d", i, rp.PathArgIndex, rp.HandlerArgIndex, rp.MethodArgIndex)
+		}
+	}
+
+	route := CustomRoutePattern{CallRegex: `^Route$`, MethodArgIndex: 0, PathArgIndex: 1, HandlerArgIndex: 2}.routePatterns()
+	if len(route) != 1 || route[0].MethodArgIndex != 0 {
+		t.Errorf("method argument lost: %+v", route)
+	}
+}
+
+func TestValidateRoutePatterns(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		pattern CustomRoutePattern
+		wantErr string
+	}{
+		{"valid", CustomRoutePattern{CallRegex: `^Register$`, HandlerArgIndex: 1}, ""},
+		{"method map only", CustomRoutePattern{MethodByCall: map[string]string{"GetJSON": "get"}, HandlerArgIndex: 1}, ""},
+		{"no matcher", CustomRoutePattern{RecvTypeRegex: `Server$`, HandlerArgIndex: 1}, "needs callRegex or methodByCall"},
+		{"bad regex", CustomRoutePattern{CallRegex: `(`, HandlerArgIndex: 1}, "invalid regex in callRegex"},
+		{"same index", CustomRoutePattern{CallRegex: `^Register$`}, "pathArgIndex and handlerArgIndex are both 0"},
+		{"negative index", CustomRoutePattern{CallRegex: `^Register$`, HandlerArgIndex: -1}, "must not be negative"},
+		{"bad method", CustomRoutePattern{CallRegex: `^Register$`, HandlerArgIndex: 1, Method: "FETCH"}, `invalid method "FETCH"`},
+		{"misspelled method", CustomRoutePattern{CallRegex: `^Register$`, HandlerArgIndex: 1, Method: "GTE"}, `invalid method "GTE" (did you mean "GET"?)`},
+		{"bad mapped method", CustomRoutePattern{MethodByCall: map[string]string{"Grab": "FETCH"}, HandlerArgIndex: 1}, "methodByCall[Grab]"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&APISpecConfig{RoutePatterns: []CustomRoutePattern{tc.pattern}}).ValidateRoutePatterns()
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Errorf("got %v, want an error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
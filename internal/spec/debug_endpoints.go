@@ -0,0 +1,81 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/diag"
+)
+
+// debugTag is the tag debug endpoints are documented under.
+const debugTag = "internal"
+
+// debugHandlers are the handler functions, by import path, that serve
+// runtime internals: profiles (net/http/pprof) and exported variables
+// (expvar).
+var debugHandlers = map[string][]string{
+	"net/http/pprof": {"Index", "Cmdline", "Profile", "Symbol", "Trace", "Handler"},
+	"expvar":         {"Handler"},
+}
+
+// isDebugEndpoint reports whether a route's handler is one of debugHandlers.
+// Only the exact import paths count: a service's own pprof package or its
+// own handler at /debug/vars is part of its API.
+func isDebugEndpoint(r *RouteInfo) bool {
+	name, ok := strings.CutPrefix(r.Function, r.Package+".")
+	return ok && slices.Contains(debugHandlers[r.Package], name)
+}
+
+// debugEndpoints keeps debug endpoints out of production specs: without
+// cfg.IncludeDebugEndpoints they are dropped and reported, otherwise they are
+// tagged `internal` in place of their inferred tags.
+func debugEndpoints(cfg *APISpecConfig, routes []*RouteInfo) []*RouteInfo {
+	include := cfg != nil && cfg.IncludeDebugEndpoints
+	var dropped []string
+	kept := routes[:0:0]
+	for _, r := range routes {
+		switch {
+		case !isDebugEndpoint(r):
+			kept = append(kept, r)
+		case include:
+			r.Tags = []string{debugTag}
+			kept = append(kept, r)
+		case !slices.Contains(dropped, r.Path):
+			dropped = append(dropped, r.Path)
+		}
+	}
+	if len(dropped) > 0 {
+		diag.Report(diag.Diagnostic{
+			Severity: diag.Warning,
+			Category: "debug-endpoints",
+			Message:  fmt.Sprintf("left out the debug endpoints (pprof, expvar) at %s", strings.Join(dropped, ", ")),
+			Help:     "pass --include-debug-endpoints (includeDebugEndpoints in the config) to document them under the internal tag",
+		})
+	}
+	return kept
+}
+
+// debugTagDefinition adds a description of the `internal` tag to tags when
+// a debug endpoint uses it and the config does not define it.
+func debugTagDefinition(tags []Tag, routes []*RouteInfo) []Tag {
+	if slices.ContainsFunc(tags, func(t Tag) bool { return t.Name == debugTag }) ||
+		!slices.ContainsFunc(routes, func(r *RouteInfo) bool { return slices.Contains(r.Tags, debugTag) && isDebugEndpoint(r) }) {
+		return tags
+	}
+	return append(slices.Clip(tags), Tag{Name: debugTag, Description: "Debug endpoints: runtime profiles (pprof) and exported variables (expvar). Not for public use."})
+}
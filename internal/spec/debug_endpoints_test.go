@@ -0,0 +1,60 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "testing"
+
+func TestIsDebugEndpoint(t *testing.T) {
+	route := func(path, pkg, fn string) *RouteInfo {
+		return &RouteInfo{Path: path, Package: pkg, Function: pkg + "." + fn}
+	}
+	tests := []struct {
+		name  string
+		route *RouteInfo
+		want  bool
+	}{
+		{"pprof index", route("/debug/pprof/", "net/http/pprof", "Index"), true},
+		{"pprof named profile", route("/debug/pprof/heap", "net/http/pprof", "Handler"), true},
+		{"pprof at another path", route("/internal/profile", "net/http/pprof", "Profile"), true},
+		{"expvar", route("/debug/vars", "expvar", "Handler"), true},
+		{"non-handler from pprof", route("/x", "net/http/pprof", "init"), false},
+		{"user pprof package", route("/debug/pprof/", "example.com/app/pprof", "Index"), false},
+		{"user package ending in pprof", route("/profiles", "example.com/httppprof", "List"), false},
+		{"user /debug/vars handler", route("/debug/vars", "example.com/app", "vars"), false},
+		{"user handler under /debug/pprof", route("/debug/pprof/trace", "example.com/app", "trace"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDebugEndpoint(tt.route); got != tt.want {
+				t.Errorf("isDebugEndpoint(%s %s) = %v, want %v", tt.route.Path, tt.route.Function, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDebugEndpointsKeepsUserHandlers(t *testing.T) {
+	routes := []*RouteInfo{
+		{Path: "/debug/vars", Package: "example.com/app", Function: "example.com/app.vars", Tags: []string{"debug"}},
+		{Path: "/debug/pprof/", Package: "example.com/app/pprof", Function: "example.com/app/pprof.Index"},
+		{Path: "/debug/pprof/", Package: "net/http/pprof", Function: "net/http/pprof.Index"},
+	}
+	kept := debugEndpoints(&APISpecConfig{}, routes)
+	if len(kept) != 2 || kept[0] != routes[0] || kept[1] != routes[1] {
+		t.Fatalf("kept = %+v, want the two user handlers", kept)
+	}
+	if len(kept[0].Tags) != 1 || kept[0].Tags[0] != "debug" {
+		t.Errorf("tags = %v, want the user handler's own tags", kept[0].Tags)
+	}
+}
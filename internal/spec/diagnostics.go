@@ -0,0 +1,98 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/diag"
+)
+
+// Diagnostics renders the findings as warnings for diag.Presenter, in the
+// order the mapper reports them: unresolved middleware, path-variable key
+// mismatches, GET/HEAD handlers that write, unresolved routes, naming issues,
+// then time layout mismatches.
+func (d *SecurityDiagnostics) Diagnostics() []diag.Diagnostic {
+	if d == nil {
+		return nil
+	}
+	var out []diag.Diagnostic
+	for _, r := range d.UnresolvedMiddleware {
+		out = append(out, unresolvedMiddlewareDiagnostic(r))
+	}
+	for _, m := range d.PathParamMismatches {
+		out = append(out, m.diagnostic())
+	}
+	for _, w := range d.UnsafeMethodWrites {
+		out = append(out, w.diagnostic())
+	}
+	for _, r := range d.UnresolvedRoutes {
+		out = append(out, r.diagnostic())
+	}
+	for _, n := range d.NamingIssues {
+		out = append(out, n.diagnostic())
+	}
+	for _, m := range d.TimeLayoutMismatches {
+		out = append(out, m.diagnostic())
+	}
+	return out
+}
+
+func unresolvedMiddlewareDiagnostic(r MiddlewareRef) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Category: "security",
+		Message:  fmt.Sprintf("auth middleware %s is not mapped to a security scheme", r),
+		Pos:      diag.ParsePosition(r.Position),
+		Help:     "add a securityMapping for it",
+	}
+}
+
+func (m PathParamMismatch) diagnostic() diag.Diagnostic {
+	d := diag.Diagnostic{
+		Severity:   diag.Warning,
+		Category:   "path-params",
+		Message:    fmt.Sprintf("%s %s: handler %s reads path variable %q, but the path declares no such parameter", m.Method, m.Path, m.Handler, m.Key),
+		Pos:        diag.ParsePosition(m.Pos),
+		Suggestion: m.Suggestion,
+	}
+	if m.Suggestion == "" {
+		if names := pathPlaceholders(m.Path); len(names) > 0 {
+			d.Help = "the path declares " + strings.Join(names, ", ")
+		} else {
+			d.Help = "the path declares no parameters; add a {" + m.Key + "} segment or drop the read"
+		}
+	}
+	return d
+}
+
+func (n NamingIssue) diagnostic() diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Category: "naming",
+		Message:  n.Kind + ": " + n.String(),
+	}
+}
+
+// Diagnostic renders the issue as a warning for diag.Presenter.
+func (i SpecIssue) Diagnostic() diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Category: "spec",
+		Message:  i.String(),
+		Help:     i.Help,
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"go/doc/comment"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// docLinkBaseURL is where resolved Go doc links point, as in `go doc -http`.
+const docLinkBaseURL = "https://pkg.go.dev"
+
+var (
+	// docDirectiveRe matches tool directives that CommentGroup.Text leaves in
+	// a doc comment: old-style build constraints, and `//go:` / `//nolint`
+	// directives written with a space after the slashes.
+	docDirectiveRe = regexp.MustCompile(`^(\+build\b|go:[a-z]+\b|nolint\b)`)
+	// docLinkDefRe matches a link definition line: "[Text]: URL".
+	docLinkDefRe = regexp.MustCompile(`^\[([^\[\]]+)\]:\s*(\S+)$`)
+	// docBracketRe matches a bracketed span that may be a doc link.
+	docBracketRe = regexp.MustCompile(`\[([^\[\]\n]+)\]`)
+	// docURLRe matches a bare http(s) URL.
+	docURLRe = regexp.MustCompile(`https?://[^\s<>()\[\]]+`)
+)
+
+// stripDocDirectives drops directive lines from a doc comment.
+func stripDocDirectives(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !docDirectiveRe.MatchString(strings.TrimSpace(line)) {
+			kept = append(kept, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// docLinker rewrites Go doc links ([Name], [pkg.Type], [pkg.Type.Method],
+// [Text] with a "[Text]: URL" definition) in the doc comment of a
+// declaration in pkgPath. Package names resolve through the imports of the
+// package's files and symbols of the package itself through its metadata,
+// following the go/doc/comment rules, so "[0, 1)" or an undefined "[note]"
+// is left alone.
+type docLinker struct {
+	parser  comment.Parser
+	pkgPath string
+	defs    map[string]string
+}
+
+// newDocLinker returns a linker for text and text without its link
+// definition lines.
+func newDocLinker(meta *metadata.Metadata, pkgPath, text string) (*docLinker, string) {
+	l := &docLinker{pkgPath: pkgPath, defs: map[string]string{}}
+	l.parser.LookupPackage = func(name string) (string, bool) {
+		return lookupImport(meta, pkgPath, name)
+	}
+	l.parser.LookupSym = func(recv, name string) bool {
+		return lookupSymbol(meta, pkgPath, recv, name)
+	}
+
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if m := docLinkDefRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			l.defs[m[1]] = m[2]
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return l, strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// markdown turns doc links into markdown links and bare URLs into
+// autolinks. Indented lines are code blocks and stay verbatim.
+func (l *docLinker) markdown(text string) string {
+	return l.eachProseLine(text, func(line string) string {
+		line = l.replaceLinks(line, func(label, url string) string {
+			return "[" + strings.ReplaceAll(label, "*", `\*`) + "](" + url + ")"
+		})
+		return replaceBareURLs(line)
+	})
+}
+
+// plain drops the brackets of doc links, for the plain-text summary.
+func (l *docLinker) plain(text string) string {
+	return l.eachProseLine(text, func(line string) string {
+		return l.replaceLinks(line, func(label, _ string) string { return label })
+	})
+}
+
+func (l *docLinker) eachProseLine(text string, fn func(string) string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		lines[i] = fn(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// replaceLinks calls render for every bracketed span that is a doc link or
+// a defined link and is not already a markdown link.
+func (l *docLinker) replaceLinks(line string, render func(label, url string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range docBracketRe.FindAllStringSubmatchIndex(line, -1) {
+		start, end := m[0], m[1]
+		if !docLinkBoundary(line, start-1) || !docLinkBoundary(line, end) ||
+			(end < len(line) && line[end] == ':') {
+			continue
+		}
+		label := line[m[2]:m[3]]
+		url, ok := l.defs[label]
+		if !ok {
+			url, ok = l.docLinkURL(label)
+		}
+		if !ok {
+			continue
+		}
+		b.WriteString(line[last:start])
+		b.WriteString(render(label, url))
+		last = end
+	}
+	if last == 0 {
+		return line
+	}
+	b.WriteString(line[last:])
+	return b.String()
+}
+
+// docLinkURL resolves label as a Go doc link.
+func (l *docLinker) docLinkURL(label string) (string, bool) {
+	doc := l.parser.Parse("[" + label + "]")
+	if len(doc.Content) != 1 {
+		return "", false
+	}
+	para, ok := doc.Content[0].(*comment.Paragraph)
+	if !ok || len(para.Text) != 1 {
+		return "", false
+	}
+	link, ok := para.Text[0].(*comment.DocLink)
+	if !ok {
+		return "", false
+	}
+	if link.ImportPath == "" {
+		link.ImportPath = l.pkgPath
+	}
+	return link.DefaultURL(docLinkBaseURL), true
+}
+
+// docLinkBoundary reports whether the byte at i may border a doc link: the
+// line boundary, a space or punctuation other than an opening "(" (which
+// would make the span an existing markdown link).
+func docLinkBoundary(line string, i int) bool {
+	if i < 0 || i >= len(line) {
+		return true
+	}
+	c := line[i]
+	switch {
+	case c == '(':
+		return false
+	case c == '_', c >= '0' && c <= '9', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= 0x80:
+		return false
+	}
+	return true
+}
+
+// replaceBareURLs wraps URLs in <> unless they already sit in a markdown
+// link or autolink. Trailing sentence punctuation stays outside.
+func replaceBareURLs(line string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range docURLRe.FindAllStringIndex(line, -1) {
+		start, end := m[0], m[1]
+		for end > start && strings.ContainsRune(".,:;!?'\"", rune(line[end-1])) {
+			end--
+		}
+		if start > 0 && (line[start-1] == '(' || line[start-1] == '<') {
+			continue
+		}
+		b.WriteString(line[last:start])
+		b.WriteString("<" + line[start:end] + ">")
+		last = end
+	}
+	if last == 0 {
+		return line
+	}
+	b.WriteString(line[last:])
+	return b.String()
+}
+
+// lookupImport resolves a package name used in a doc comment of pkgPath to
+// an import path through the imports of the package's files. A name that
+// maps to different paths in different files does not resolve.
+func lookupImport(meta *metadata.Metadata, pkgPath, name string) (string, bool) {
+	if meta == nil || meta.StringPool == nil {
+		return "", false
+	}
+	pkg := meta.Packages[pkgPath]
+	if pkg == nil {
+		return "", false
+	}
+	found := ""
+	for _, file := range pkg.Files {
+		for aliasIdx, pathIdx := range file.Imports {
+			alias, importPath := meta.StringPool.GetString(aliasIdx), meta.StringPool.GetString(pathIdx)
+			// An unaliased import is recorded under its path.
+			if alias == importPath {
+				alias = importName(importPath)
+			}
+			if alias != name {
+				continue
+			}
+			if found != "" && found != importPath {
+				return "", false
+			}
+			found = importPath
+		}
+	}
+	return found, found != ""
+}
+
+// importName is the conventional package name of an import path: its last
+// element, skipping a major-version suffix ("/v2", "yaml.v3").
+func importName(importPath string) string {
+	base := path.Base(importPath)
+	if len(base) > 1 && base[0] == 'v' && isAllDigits(base[1:]) && importPath != base {
+		base = path.Base(path.Dir(importPath))
+	}
+	if i := strings.LastIndex(base, ".v"); i > 0 && isAllDigits(base[i+2:]) {
+		base = base[:i]
+	}
+	return base
+}
+
+func isAllDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupSymbol reports whether pkgPath declares name (recv == "") or type
+// recv with method name.
+func lookupSymbol(meta *metadata.Metadata, pkgPath, recv, name string) bool {
+	if meta == nil || meta.StringPool == nil {
+		return false
+	}
+	if recv != "" {
+		return findMethodByName(meta, pkgPath, recv, name) != nil
+	}
+	return findType(meta, pkgPath, name) != nil || findFunctionByName(meta, pkgPath, name) != nil
+}
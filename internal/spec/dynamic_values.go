@@ -0,0 +1,121 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// How a value of no fixed JSON type is documented
+// (SchemaOptions.DynamicValues).
+const (
+	DynamicValuesObject    = "object"
+	DynamicValuesString    = "string"
+	DynamicValuesComponent = "component"
+)
+
+// DefaultDynamicComponent names the placeholder component of the
+// "component" style when SchemaOptions.DynamicComponent is empty.
+const DefaultDynamicComponent = "AnyValue"
+
+// componentNameRe is the form OpenAPI allows a components/schemas key.
+var componentNameRe = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// dynamicValueImports maps the dynamic types that are not builtins to the
+// import path their x-go-type needs.
+var dynamicValueImports = map[string]string{
+	"json.RawMessage": "encoding/json",
+}
+
+// ValidateDynamicValues rejects an unknown dynamicValues style, and a
+// dynamicComponent that is not a valid component name.
+func (o SchemaOptions) ValidateDynamicValues() error {
+	switch o.DynamicValues {
+	case "", DynamicValuesObject, DynamicValuesString, DynamicValuesComponent:
+	default:
+		return fmt.Errorf("schemas.dynamicValues: unknown style %q (want %q, %q or %q)", o.DynamicValues, DynamicValuesObject, DynamicValuesString, DynamicValuesComponent)
+	}
+	if o.DynamicComponent != "" && !componentNameRe.MatchString(o.DynamicComponent) {
+		return fmt.Errorf("schemas.dynamicComponent: %q is not a valid component name (want letters, digits, '.', '-' or '_')", o.DynamicComponent)
+	}
+	return nil
+}
+
+// dynamicGoType returns the Go spelling of goType when encoding/json can
+// give it any JSON value — any, interface{} or json.RawMessage — and "" for
+// every other type.
+func dynamicGoType(goType string) string {
+	switch goType = strings.ReplaceAll(goType, TypeSep, "."); goType {
+	case "any", "interface{}":
+		return goType
+	case "json.RawMessage", "encoding/json.RawMessage":
+		return "json.RawMessage"
+	}
+	return ""
+}
+
+// dynamicComponentName is the placeholder component the "component" style
+// refers to.
+func (o SchemaOptions) dynamicComponentName() string {
+	if o.DynamicComponent != "" {
+		return o.DynamicComponent
+	}
+	return DefaultDynamicComponent
+}
+
+// dynamicComponentSchema is the placeholder component of the "component"
+// style: a schema every JSON value matches.
+func dynamicComponentSchema() *Schema {
+	return &Schema{Description: "Any JSON value."}
+}
+
+// dynamicValueSchema documents a value of the dynamic Go type goType (see
+// dynamicGoType) in the style schemas.dynamicValues picks, annotated with
+// x-go-type when schemas.goTypeAnnotations is set. The "component" style
+// returns the placeholder it refers to in schemas, and marks it used so a
+// caller that drops schemas still gets it emitted.
+func dynamicValueSchema(usedTypes map[string]*Schema, goType string, cfg *APISpecConfig) (*Schema, map[string]*Schema) {
+	var opts SchemaOptions
+	if cfg != nil {
+		opts = cfg.Schemas
+	}
+	schemas := map[string]*Schema{}
+
+	var schema *Schema
+	switch opts.DynamicValues {
+	case DynamicValuesString:
+		schema = &Schema{Type: "string"}
+	case DynamicValuesComponent:
+		name := opts.dynamicComponentName()
+		placeholder := dynamicComponentSchema()
+		schemas[name] = placeholder
+		if usedTypes != nil {
+			markUsedType(usedTypes, name, placeholder)
+		}
+		schema = addRefSchemaForType(name)
+	default:
+		schema = &Schema{Type: "object"}
+	}
+
+	if opts.GoTypeAnnotations {
+		schema.Extensions = map[string]interface{}{"x-go-type": goType}
+		if path, ok := dynamicValueImports[goType]; ok {
+			schema.Extensions["x-go-type-import"] = map[string]string{"path": path}
+		}
+	}
+	return schema, schemas
+}
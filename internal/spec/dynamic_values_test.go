@@ -0,0 +1,115 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "testing"
+
+func TestDynamicGoType(t *testing.T) {
+	for goType, want := range map[string]string{
+		"any":                                    "any",
+		"interface{}":                            "interface{}",
+		"json.RawMessage":                        "json.RawMessage",
+		"encoding/json.RawMessage":               "json.RawMessage",
+		"encoding/json" + TypeSep + "RawMessage": "json.RawMessage",
+		"example.com/json.RawMessage":            "",
+		"map[string]any":                         "",
+		"string":                                 "",
+	} {
+		if got := dynamicGoType(goType); got != want {
+			t.Errorf("dynamicGoType(%q) = %q, want %q", goType, got, want)
+		}
+	}
+}
+
+func TestValidateDynamicValues(t *testing.T) {
+	for _, o := range []SchemaOptions{
+		{},
+		{DynamicValues: DynamicValuesObject},
+		{DynamicValues: DynamicValuesString},
+		{DynamicValues: DynamicValuesComponent, DynamicComponent: "JSONValue"},
+	} {
+		if err := o.ValidateDynamicValues(); err != nil {
+			t.Errorf("ValidateDynamicValues(%+v) = %v", o, err)
+		}
+	}
+	for _, o := range []SchemaOptions{
+		{DynamicValues: "empty"},
+		{DynamicValues: DynamicValuesComponent, DynamicComponent: "Any Value"},
+	} {
+		if err := o.ValidateDynamicValues(); err == nil {
+			t.Errorf("ValidateDynamicValues(%+v) = nil, want an error", o)
+		}
+	}
+}
+
+func TestDynamicValueSchema(t *testing.T) {
+	t.Run("object by default", func(t *testing.T) {
+		s, extra := dynamicValueSchema(map[string]*Schema{}, "any", DefaultAPISpecConfig())
+		if s.Type != "object" || len(s.Extensions) != 0 || len(extra) != 0 {
+			t.Errorf("got %+v, %v; want a plain free-form object", s, extra)
+		}
+	})
+
+	t.Run("string", func(t *testing.T) {
+		cfg := DefaultAPISpecConfig()
+		cfg.Schemas.DynamicValues = DynamicValuesString
+		if s, _ := dynamicValueSchema(nil, "json.RawMessage", cfg); s.Type != "string" {
+			t.Errorf("got %+v, want a string", s)
+		}
+	})
+
+	t.Run("component", func(t *testing.T) {
+		cfg := DefaultAPISpecConfig()
+		cfg.Schemas.DynamicValues = DynamicValuesComponent
+		cfg.Schemas.DynamicComponent = "JSONValue"
+		usedTypes := map[string]*Schema{}
+		s, extra := dynamicValueSchema(usedTypes, "any", cfg)
+		if s.Ref != refComponentsSchemasPrefix+"JSONValue" {
+			t.Errorf("ref = %q, want the JSONValue placeholder", s.Ref)
+		}
+		if extra["JSONValue"] == nil || usedTypes["JSONValue"] == nil {
+			t.Errorf("placeholder not returned and marked used: %v, %v", extra, usedTypes)
+		}
+	})
+
+	t.Run("go type annotations", func(t *testing.T) {
+		cfg := DefaultAPISpecConfig()
+		cfg.Schemas.GoTypeAnnotations = true
+		s, _ := dynamicValueSchema(nil, "json.RawMessage", cfg)
+		if s.Extensions["x-go-type"] != "json.RawMessage" {
+			t.Errorf("x-go-type = %v", s.Extensions["x-go-type"])
+		}
+		if imp, ok := s.Extensions["x-go-type-import"].(map[string]string); !ok || imp["path"] != "encoding/json" {
+			t.Errorf("x-go-type-import = %v", s.Extensions["x-go-type-import"])
+		}
+		if s, _ := dynamicValueSchema(nil, "any", cfg); s.Extensions["x-go-type-import"] != nil {
+			t.Errorf("any needs no import: %v", s.Extensions)
+		}
+	})
+}
+
+// json.RawMessage used to fall through to a $ref to a component nothing
+// emitted.
+func TestMapGoTypeToOpenAPISchema_RawMessageInline(t *testing.T) {
+	for _, goType := range []string{"encoding/json.RawMessage", "*encoding/json.RawMessage"} {
+		s, schemas := mapGoTypeToOpenAPISchema(map[string]*Schema{}, goType, nil, DefaultAPISpecConfig(), nil)
+		if s == nil || s.Ref != "" || s.Type != "object" || len(schemas) != 0 {
+			t.Errorf("%s = %+v (%v), want an inline object", goType, s, schemas)
+		}
+	}
+	if canAddRefSchemaForType("encoding/json.RawMessage") {
+		t.Error("json.RawMessage must not become a component")
+	}
+}
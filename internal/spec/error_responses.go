@@ -0,0 +1,90 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/diag"
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// ValidateErrorResponses rejects errorResponses entries that match no call,
+// carry regexes that do not compile, name no body type, or give a status
+// outside 100-599. It returns the first error encountered.
+func (c *APISpecConfig) ValidateErrorResponses() error {
+	for i, r := range c.ErrorResponses {
+		if r.CallRegex == "" {
+			return fmt.Errorf("errorResponses[%d]: needs callRegex", i)
+		}
+		for _, f := range []struct{ name, expr string }{
+			{"callRegex", r.CallRegex}, {"recvTypeRegex", r.RecvTypeRegex},
+		} {
+			if _, err := regexp.Compile(f.expr); err != nil {
+				return fmt.Errorf("errorResponses[%d]: invalid regex in %s %q: %w", i, f.name, f.expr, err)
+			}
+		}
+		if r.BodyType == "" {
+			return fmt.Errorf("errorResponses[%d]: needs bodyType", i)
+		}
+		if r.Status != 0 && (r.Status < 100 || r.Status > 599) {
+			return fmt.Errorf("errorResponses[%d]: invalid status %d", i, r.Status)
+		}
+	}
+	return nil
+}
+
+// reportUndeclaredErrorBodies reports errorResponses whose body type no
+// analyzed package declares: their responses would reference nothing.
+func (e *Extractor) reportUndeclaredErrorBodies() {
+	if e.cfg == nil || len(e.cfg.ErrorResponses) == 0 {
+		return
+	}
+	meta := e.tree.GetMetadata()
+	if meta == nil {
+		return
+	}
+	for i, r := range e.cfg.ErrorResponses {
+		if r.BodyType != "" && !declaresType(meta, r.BodyType) {
+			diag.Report(diag.Diagnostic{
+				Severity: diag.Warning,
+				Category: "error-responses",
+				Message:  fmt.Sprintf("errorResponses[%d]: body type %s is not declared in the analyzed packages", i, r.BodyType),
+				Help:     "name it as in source (apierr.Problem) or by import path",
+			})
+		}
+	}
+}
+
+// declaresType reports whether typ, named as namedGoType reads it, is a
+// builtin or declared by an analyzed package; an unqualified name may be
+// declared by any of them.
+func declaresType(meta *metadata.Metadata, typ string) bool {
+	if namedGoType(meta, "", typ) != "" {
+		return true
+	}
+	name := strings.TrimLeft(typ, "[]")
+	if strings.Contains(name, ".") {
+		return false
+	}
+	for _, p := range meta.SortedPackageNames() {
+		if typeInPackage(meta.Packages[p], name) != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateErrorResponses(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		r       ErrorResponse
+		wantErr string
+	}{
+		{"valid", ErrorResponse{CallRegex: "^NewHTTPError$", BodyType: "apierr.Message"}, ""},
+		{"fixed status", ErrorResponse{CallRegex: "^Write$", StatusArgIndex: -1, Status: 500, BodyType: "apierr.Problem"}, ""},
+		{"no call", ErrorResponse{BodyType: "apierr.Problem"}, "errorResponses[0]: needs callRegex"},
+		{"bad regex", ErrorResponse{CallRegex: "^Write$", RecvTypeRegex: "(", BodyType: "apierr.Problem"}, "errorResponses[0]: invalid regex in recvTypeRegex"},
+		{"no body", ErrorResponse{CallRegex: "^Write$"}, "errorResponses[0]: needs bodyType"},
+		{"bad status", ErrorResponse{CallRegex: "^Write$", Status: 42, BodyType: "apierr.Problem"}, "errorResponses[0]: invalid status 42"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := APISpecConfig{ErrorResponses: []ErrorResponse{tc.r}}
+			err := cfg.ValidateErrorResponses()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,437 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exampleEpoch anchors generated dates, so a date-time example is a fixed
+// offset from it rather than from the clock. schemas.exampleEpoch moves it.
+var exampleEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// exampleEpochNow is the schemas.exampleEpoch value anchoring examples at
+// the time of generation.
+const exampleEpochNow = "now"
+
+// ExampleEpochTime returns the instant generated dates are offset from: the
+// default epoch when ExampleEpoch is empty, the current time (to the second)
+// for "now", otherwise ExampleEpoch read as an RFC 3339 date-time or a
+// date. The result is always in UTC, whatever zone the value or the machine
+// is in.
+func (o SchemaOptions) ExampleEpochTime() (time.Time, error) {
+	switch o.ExampleEpoch {
+	case "":
+		return exampleEpoch, nil
+	case exampleEpochNow:
+		return time.Now().UTC().Truncate(time.Second), nil
+	}
+	if t, err := time.Parse(time.RFC3339, o.ExampleEpoch); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse(time.DateOnly, o.ExampleEpoch); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("schemas.exampleEpoch: %q is not %q, an RFC 3339 date-time or a date", o.ExampleEpoch, exampleEpochNow)
+}
+
+// maxExampleDepth bounds nesting through properties and items; deeper values
+// are left out (or fail the example when required).
+const maxExampleDepth = 8
+
+// addExamples fills in an example for every request and response body, and
+// every parameter, that has a schema but no example. Values follow the
+// schema's format — RFC 3339 date-times in UTC, RFC 4122 UUIDs, emails under
+// example.com — and stay within its enum, bounds, lengths and pattern. A
+// schema no value can be produced for (an unsatisfiable pattern on a
+// required field, a recursive type) gets no example rather than one that
+// fails its own schema.
+//
+// Generation is seeded from opts.ExampleSeed and each value's location
+// (path, method, status, media type, parameter), so a spec regenerates
+// byte-identically and unrelated edits do not shift the examples of other
+// operations. Dates are offset from opts' example epoch; an epoch that does
+// not parse falls back to the default one.
+func addExamples(spec *OpenAPISpec, opts SchemaOptions) {
+	if spec == nil {
+		return
+	}
+	var components map[string]*Schema
+	if spec.Components != nil {
+		components = spec.Components.Schemas
+	}
+	epoch, err := opts.ExampleEpochTime()
+	if err != nil {
+		epoch = exampleEpoch
+	}
+	gen := func(location string) *exampleGen {
+		g := newExampleGen(opts.ExampleSeed, location, components)
+		g.epoch = epoch
+		return g
+	}
+	for _, path := range slices.Sorted(maps.Keys(spec.Paths)) {
+		item := spec.Paths[path]
+		for _, mo := range item.Operations() {
+			op := mo.Operation
+			at := path + " " + mo.Method
+			for i := range op.Parameters {
+				p := &op.Parameters[i]
+				if p.Example == nil && p.Schema != nil {
+					p.Example = gen(at+" "+p.In+" "+p.Name).value(p.Schema, p.Name, 0)
+				}
+			}
+			if op.RequestBody != nil {
+				fillMediaExamples(op.RequestBody.Content, at+" request", gen)
+			}
+			for _, status := range slices.Sorted(maps.Keys(op.Responses)) {
+				fillMediaExamples(op.Responses[status].Content, at+" "+status, gen)
+			}
+		}
+	}
+}
+
+func fillMediaExamples(content map[string]MediaType, at string, gen func(location string) *exampleGen) {
+	for _, mt := range slices.Sorted(maps.Keys(content)) {
+		media := content[mt]
+		if media.Schema == nil || media.Example != nil || len(media.Examples) > 0 {
+			continue
+		}
+		// Binary bodies (file downloads, uploads) have no JSON rendering.
+		if !strings.Contains(mt, "json") && !strings.HasPrefix(mt, "text/") &&
+			mt != "application/x-www-form-urlencoded" && mt != "multipart/form-data" {
+			continue
+		}
+		if v := gen(at+" "+mt).value(media.Schema, "", 0); v != nil {
+			media.Example = v
+			content[mt] = media
+		}
+	}
+}
+
+// exampleGen produces the example of one location.
+type exampleGen struct {
+	rng        *rand.Rand
+	epoch      time.Time
+	components map[string]*Schema
+	resolving  map[string]bool
+}
+
+func newExampleGen(seed uint64, location string, components map[string]*Schema) *exampleGen {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(location))
+	return &exampleGen{
+		rng:        rand.New(rand.NewPCG(seed, h.Sum64())),
+		epoch:      exampleEpoch,
+		components: components,
+		resolving:  map[string]bool{},
+	}
+}
+
+// value returns an example for s, named name in its parent (a property or
+// parameter name, used for plain strings), or nil when none validates.
+func (g *exampleGen) value(s *Schema, name string, depth int) any {
+	if s == nil || depth > maxExampleDepth {
+		return nil
+	}
+	if s.Ref != "" {
+		target, ok := g.components[strings.TrimPrefix(s.Ref, refComponentsSchemasPrefix)]
+		if !ok || g.resolving[s.Ref] {
+			return nil
+		}
+		g.resolving[s.Ref] = true
+		defer delete(g.resolving, s.Ref)
+		return g.value(target, name, depth)
+	}
+	if s.Example != nil {
+		return s.Example
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[0]
+	}
+	if s.Default != nil {
+		return s.Default
+	}
+	if len(s.AllOf) > 0 {
+		return g.allOf(s, name, depth)
+	}
+	for _, alts := range [][]*Schema{s.OneOf, s.AnyOf} {
+		for _, alt := range alts {
+			if v := g.value(alt, name, depth); v != nil {
+				return v
+			}
+		}
+	}
+
+	switch s.Type {
+	case "object":
+		return g.object(s, depth)
+	case "array":
+		return g.array(s, name, depth)
+	case "string":
+		return g.str(s, name)
+	case "integer":
+		return g.integer(s)
+	case "number":
+		return g.number(s)
+	case "boolean":
+		return true
+	case "":
+		if len(s.Properties) > 0 {
+			return g.object(s, depth)
+		}
+	}
+	return nil
+}
+
+// allOf merges the examples of the object parts; a part that yields no
+// object fails the example.
+func (g *exampleGen) allOf(s *Schema, name string, depth int) any {
+	merged := map[string]any{}
+	for _, part := range s.AllOf {
+		v, ok := g.value(part, name, depth).(map[string]any)
+		if !ok {
+			return nil
+		}
+		maps.Copy(merged, v)
+	}
+	if len(s.Properties) > 0 {
+		v, ok := g.object(s, depth).(map[string]any)
+		if !ok {
+			return nil
+		}
+		maps.Copy(merged, v)
+	}
+	return merged
+}
+
+func (g *exampleGen) object(s *Schema, depth int) any {
+	out := map[string]any{}
+	for _, key := range slices.Sorted(maps.Keys(s.Properties)) {
+		prop := s.Properties[key]
+		if v := g.value(prop, key, depth+1); v != nil {
+			out[key] = v
+		} else if slices.Contains(s.Required, key) {
+			return nil
+		}
+	}
+	if len(s.Properties) == 0 && s.AdditionalProperties != nil {
+		if v := g.value(s.AdditionalProperties, "", depth+1); v != nil {
+			out["key"] = v
+		}
+	}
+	return out
+}
+
+func (g *exampleGen) array(s *Schema, name string, depth int) any {
+	n := max(1, s.MinItems)
+	if s.MaxItems > 0 && n > s.MaxItems {
+		n = s.MaxItems
+	}
+	out := make([]any, 0, n)
+	for i := 0; i < n; i++ {
+		v := g.value(s.Items, name, depth+1)
+		if v == nil {
+			if s.MinItems > 0 {
+				return nil
+			}
+			break
+		}
+		if i > 0 && s.UniqueItems {
+			// Items come from the same schema: a plain string can be told
+			// apart by a suffix; anything else ends the list early.
+			str, ok := v.(string)
+			it := s.Items
+			plain := it.Ref == "" && it.Format == "" && it.Pattern == "" && it.MaxLength == 0 &&
+				len(it.Enum) == 0 && it.Example == nil && it.Default == nil
+			if !ok || !plain {
+				if i < s.MinItems {
+					return nil
+				}
+				break
+			}
+			v = str + strconv.Itoa(i+1)
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// str returns a string in s's format, fitted to its length bounds and
+// checked against its pattern.
+func (g *exampleGen) str(s *Schema, name string) any {
+	v, free := g.formatted(s.Format, name)
+	if free {
+		for len(v) < s.MinLength {
+			v += "x"
+		}
+		if s.MaxLength > 0 && len(v) > s.MaxLength {
+			v = v[:s.MaxLength]
+		}
+	}
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil || !re.MatchString(v) {
+			return nil
+		}
+	}
+	return v
+}
+
+// formatted returns an example of a string format; free reports a value with
+// no format of its own, which may be padded or cut to the length bounds.
+func (g *exampleGen) formatted(format, name string) (v string, free bool) {
+	r := g.rng
+	switch format {
+	case "date-time":
+		return g.epoch.Add(time.Duration(r.IntN(365*24*3600)) * time.Second).Format(time.RFC3339), false
+	case "date":
+		return g.epoch.AddDate(0, 0, r.IntN(365)).Format(time.DateOnly), false
+	case "time":
+		return g.epoch.Add(time.Duration(r.IntN(24*3600)) * time.Second).Format(time.TimeOnly), false
+	case "duration":
+		return fmt.Sprintf("PT%dM", 1+r.IntN(59)), false
+	case "uuid":
+		var b [16]byte
+		for i := range b {
+			b[i] = byte(r.UintN(256))
+		}
+		b[6] = b[6]&0x0f | 0x40 // version 4
+		b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), false
+	case "email":
+		return fmt.Sprintf("user%d@example.com", 1+r.IntN(999)), false
+	case "uri", "url":
+		return fmt.Sprintf("https://example.com/resources/%d", 1+r.IntN(999)), false
+	case "hostname":
+		return fmt.Sprintf("host%d.example.com", 1+r.IntN(99)), false
+	case "ipv4":
+		return fmt.Sprintf("192.0.2.%d", 1+r.IntN(254)), false // TEST-NET-1
+	case "ipv6":
+		return fmt.Sprintf("2001:db8::%x", 1+r.IntN(0xfffe)), false // documentation prefix
+	case "byte":
+		b := make([]byte, 6)
+		for i := range b {
+			b[i] = byte(r.UintN(256))
+		}
+		return base64.StdEncoding.EncodeToString(b), false
+	case "int64", "int32":
+		return strconv.Itoa(1 + r.IntN(1000)), false
+	case "decimal":
+		return fmt.Sprintf("%d.%02d", 1+r.IntN(999), r.IntN(100)), false
+	case "objectid":
+		b := make([]byte, 12)
+		for i := range b {
+			b[i] = byte(r.UintN(256))
+		}
+		return fmt.Sprintf("%x", b), false
+	case "password":
+		return "********", true
+	}
+	if name == "" {
+		return "string", true
+	}
+	return name, true
+}
+
+// integer returns an integer within s's bounds and multipleOf.
+func (g *exampleGen) integer(s *Schema) any {
+	lo, hi := 1.0, 100.0
+	lower, upper, lowerExclusive, upperExclusive := schemaBounds(s)
+	switch {
+	case lower != nil && upper != nil:
+		lo, hi = *lower, *upper
+	case lower != nil:
+		lo, hi = *lower, *lower+100
+		if *lower < 0 {
+			hi = 0
+		}
+	case upper != nil:
+		lo, hi = math.Min(1, *upper), *upper
+	}
+	if lowerExclusive {
+		lo = math.Floor(lo) + 1
+	}
+	if upperExclusive {
+		hi = math.Ceil(hi) - 1
+	}
+	lo, hi = math.Ceil(lo), math.Floor(hi)
+	if s.Format == "int32" {
+		hi = math.Min(hi, math.MaxInt32)
+	}
+	if hi < lo {
+		return nil
+	}
+	v := lo + float64(g.rng.Int64N(int64(hi-lo)+1))
+	if m := s.MultipleOf; m > 0 {
+		v = math.Ceil(v/m) * m
+		if v > hi {
+			v = math.Ceil(lo/m) * m
+			if v > hi {
+				return nil
+			}
+		}
+	}
+	return int64(v)
+}
+
+// schemaBounds returns s's lower and upper bounds, nil when unset, and
+// whether each is exclusive.
+func schemaBounds(s *Schema) (lower, upper *float64, lowerExclusive, upperExclusive bool) {
+	lower, upper = s.Minimum, s.Maximum
+	if s.ExclusiveMinimum != nil {
+		lower, lowerExclusive = s.ExclusiveMinimum, true
+	}
+	if s.ExclusiveMaximum != nil {
+		upper, upperExclusive = s.ExclusiveMaximum, true
+	}
+	return lower, upper, lowerExclusive, upperExclusive
+}
+
+// number returns a two-decimal value within s's bounds. Generated numbers
+// are JSON numbers, so they render the same in every locale.
+func (g *exampleGen) number(s *Schema) any {
+	if s.MultipleOf > 0 {
+		return g.integer(s)
+	}
+	lo, hi := 1.0, 100.0
+	lower, upper, lowerExclusive, upperExclusive := schemaBounds(s)
+	switch {
+	case lower != nil && upper != nil:
+		lo, hi = *lower, *upper
+	case lower != nil:
+		lo, hi = *lower, *lower+100
+	case upper != nil:
+		lo, hi = math.Min(1, *upper), *upper
+	}
+	v := lo + g.rng.Float64()*(hi-lo)
+	v = math.Round(v*100) / 100
+	if v < lo || (lowerExclusive && v == lo) {
+		v = lo + (hi-lo)/2
+	}
+	if v > hi || (upperExclusive && v == hi) {
+		v = lo + (hi-lo)/2
+	}
+	return v
+}
@@ -0,0 +1,291 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+var uuidV4 = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// checkExample reports how v fails s: the subset of JSON Schema the example
+// generator promises to honor.
+func checkExample(v any, s *Schema, components map[string]*Schema) error {
+	if s.Ref != "" {
+		return checkExample(v, components[strings.TrimPrefix(s.Ref, refComponentsSchemasPrefix)], components)
+	}
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, v) {
+		return fmt.Errorf("%v not in enum %v", v, s.Enum)
+	}
+	switch s.Type {
+	case "object":
+		m, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%v is not an object", v)
+		}
+		for _, key := range s.Required {
+			if _, ok := m[key]; !ok {
+				return fmt.Errorf("required %q missing", key)
+			}
+		}
+		for key, pv := range m {
+			if prop := s.Properties[key]; prop != nil {
+				if err := checkExample(pv, prop, components); err != nil {
+					return fmt.Errorf("%s: %w", key, err)
+				}
+			}
+		}
+	case "array":
+		items, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("%v is not an array", v)
+		}
+		if len(items) < s.MinItems || (s.MaxItems > 0 && len(items) > s.MaxItems) {
+			return fmt.Errorf("%d items", len(items))
+		}
+		for i, item := range items {
+			if s.UniqueItems && slices.IndexFunc(items[:i], func(o any) bool { return reflect.DeepEqual(o, item) }) >= 0 {
+				return fmt.Errorf("item %d repeats", i)
+			}
+			if err := checkExample(item, s.Items, components); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%v is not a string", v)
+		}
+		if len(str) < s.MinLength || (s.MaxLength > 0 && len(str) > s.MaxLength) {
+			return fmt.Errorf("%q breaks the length bounds", str)
+		}
+		if s.Pattern != "" && !regexp.MustCompile(s.Pattern).MatchString(str) {
+			return fmt.Errorf("%q does not match %s", str, s.Pattern)
+		}
+		return checkFormat(str, s.Format)
+	case "integer", "number":
+		var f float64
+		switch n := v.(type) {
+		case int64:
+			f = float64(n)
+		case float64:
+			f = n
+		default:
+			return fmt.Errorf("%v is not a number", v)
+		}
+		if s.Type == "integer" && f != math.Trunc(f) {
+			return fmt.Errorf("%v is not an integer", v)
+		}
+		lower, upper, lowerExclusive, upperExclusive := schemaBounds(s)
+		if (lower != nil && (f < *lower || lowerExclusive && f == *lower)) ||
+			(upper != nil && (f > *upper || upperExclusive && f == *upper)) {
+			return fmt.Errorf("%v out of [%v, %v]", v, bound(lower), bound(upper))
+		}
+		if s.MultipleOf > 0 && math.Mod(f, s.MultipleOf) != 0 {
+			return fmt.Errorf("%v is not a multiple of %v", v, s.MultipleOf)
+		}
+	}
+	return nil
+}
+
+func checkFormat(v, format string) error {
+	var err error
+	switch format {
+	case "date-time":
+		var t time.Time
+		if t, err = time.Parse(time.RFC3339, v); err == nil && t.Location() != time.UTC {
+			err = fmt.Errorf("not UTC")
+		}
+	case "date":
+		_, err = time.Parse(time.DateOnly, v)
+	case "uuid":
+		if !uuidV4.MatchString(v) {
+			err = fmt.Errorf("not a v4 UUID")
+		}
+	case "email":
+		_, err = mail.ParseAddress(v)
+	case "uri":
+		var u *url.URL
+		if u, err = url.Parse(v); err == nil && !u.IsAbs() {
+			err = fmt.Errorf("not absolute")
+		}
+	case "ipv4", "ipv6":
+		if net.ParseIP(v) == nil {
+			err = fmt.Errorf("not an IP")
+		}
+	case "byte":
+		_, err = base64.StdEncoding.DecodeString(v)
+	}
+	if err != nil {
+		return fmt.Errorf("%q is not a %s: %v", v, format, err)
+	}
+	return nil
+}
+
+func TestExampleGen_HonorsFormatsAndConstraints(t *testing.T) {
+	components := map[string]*Schema{
+		"Address": {Type: "object", Required: []string{"city"}, Properties: map[string]*Schema{
+			"city": {Type: "string", MinLength: 8},
+			"zip":  {Type: "string", Pattern: `^[0-9]{5}$`}, // unsatisfiable here, optional
+		}},
+		"Node": {Type: "object", Properties: map[string]*Schema{
+			"children": {Type: "array", Items: &Schema{Ref: "#/components/schemas/Node"}},
+		}},
+	}
+	schema := &Schema{Type: "object", Required: []string{"id", "createdAt"}, Properties: map[string]*Schema{
+		"id":        {Type: "string", Format: "uuid"},
+		"createdAt": {Type: "string", Format: "date-time"},
+		"birthday":  {Type: "string", Format: "date"},
+		"email":     {Type: "string", Format: "email"},
+		"homepage":  {Type: "string", Format: "uri"},
+		"ip":        {Type: "string", Format: "ipv4"},
+		"ip6":       {Type: "string", Format: "ipv6"},
+		"avatar":    {Type: "string", Format: "byte"},
+		"code":      {Type: "string", MaxLength: 2},
+		"status":    {Type: "string", Enum: []any{"active", "disabled"}},
+		"age":       {Type: "integer", Format: "int32", Minimum: float64Ptr(18), Maximum: float64Ptr(21)},
+		"score":     {Type: "integer", ExclusiveMinimum: float64Ptr(-10), MultipleOf: 5},
+		"ratio":     {Type: "number", Minimum: float64Ptr(0.5), Maximum: float64Ptr(0.75)},
+		"tags":      {Type: "array", MinItems: 3, UniqueItems: true, Items: &Schema{Type: "string"}},
+		"address":   {Ref: "#/components/schemas/Address"},
+		"tree":      {Ref: "#/components/schemas/Node"},
+		"labels":    {Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+	}}
+
+	for _, seed := range []uint64{0, 1, 42} {
+		for _, at := range []string{"/a GET 200 application/json", "/b POST request application/json"} {
+			v := newExampleGen(seed, at, components).value(schema, "", 0)
+			if v == nil {
+				t.Fatalf("seed %d %s: no example", seed, at)
+			}
+			if err := checkExample(v, schema, components); err != nil {
+				t.Errorf("seed %d %s: %v\nexample: %v", seed, at, err, v)
+			}
+			m := v.(map[string]any)
+			if _, ok := m["address"].(map[string]any)["zip"]; ok {
+				t.Errorf("zip matches no generated value, yet got one: %v", m["address"])
+			}
+		}
+	}
+}
+
+func TestExampleGen_UnsatisfiableRequiredField(t *testing.T) {
+	s := &Schema{Type: "object", Required: []string{"zip"}, Properties: map[string]*Schema{
+		"zip": {Type: "string", Pattern: `^[0-9]{5}$`},
+	}}
+	if v := newExampleGen(0, "x", nil).value(s, "", 0); v != nil {
+		t.Errorf("got %v, want no example", v)
+	}
+}
+
+func TestAddExamples_DeterministicAndKeepsExisting(t *testing.T) {
+	build := func() *OpenAPISpec {
+		user := &Schema{Type: "object", Properties: map[string]*Schema{
+			"id":      {Type: "string", Format: "uuid"},
+			"created": {Type: "string", Format: "date-time"},
+		}}
+		return &OpenAPISpec{
+			Components: &Components{Schemas: map[string]*Schema{"User": user}},
+			Paths: map[string]PathItem{
+				"/users/{id}": {Get: &Operation{
+					Parameters: []Parameter{
+						{Name: "id", In: "path", Schema: &Schema{Type: "string", Format: "uuid"}},
+						{Name: "v", In: "query", Schema: &Schema{Type: "integer"}, Example: 7},
+					},
+					Responses: map[string]Response{
+						"200": {Content: map[string]MediaType{
+							"application/json":         {Schema: &Schema{Ref: "#/components/schemas/User"}},
+							"application/octet-stream": {Schema: &Schema{Type: "string", Format: "binary"}},
+						}},
+					},
+				}},
+			},
+		}
+	}
+
+	a, b, c := build(), build(), build()
+	addExamples(a, SchemaOptions{ExampleSeed: 7})
+	addExamples(b, SchemaOptions{ExampleSeed: 7})
+	addExamples(c, SchemaOptions{ExampleSeed: 8})
+	if !reflect.DeepEqual(a, b) {
+		t.Error("same seed, different examples")
+	}
+	if reflect.DeepEqual(a, c) {
+		t.Error("different seeds, same examples")
+	}
+
+	op := a.Paths["/users/{id}"].Get
+	if id, _ := op.Parameters[0].Example.(string); !uuidV4.MatchString(id) {
+		t.Errorf("path id example = %v, want a UUID", op.Parameters[0].Example)
+	}
+	if op.Parameters[1].Example != 7 {
+		t.Errorf("existing example replaced: %v", op.Parameters[1].Example)
+	}
+	content := op.Responses["200"].Content
+	if err := checkExample(content["application/json"].Example, content["application/json"].Schema, a.Components.Schemas); err != nil {
+		t.Error(err)
+	}
+	if ex := content["application/octet-stream"].Example; ex != nil {
+		t.Errorf("binary body got example %v", ex)
+	}
+}
+
+func TestExampleEpochTime(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want time.Time
+	}{
+		{"", exampleEpoch},
+		{"2030-06-15", time.Date(2030, time.June, 15, 0, 0, 0, 0, time.UTC)},
+		{"2030-06-15T12:00:00+02:00", time.Date(2030, time.June, 15, 10, 0, 0, 0, time.UTC)},
+	} {
+		got, err := SchemaOptions{ExampleEpoch: tc.in}.ExampleEpochTime()
+		if err != nil || !got.Equal(tc.want) || got.Location() != time.UTC {
+			t.Errorf("ExampleEpochTime(%q) = %v, %v; want %v in UTC", tc.in, got, err, tc.want)
+		}
+	}
+
+	before := time.Now().UTC().Truncate(time.Second)
+	now, err := SchemaOptions{ExampleEpoch: "now"}.ExampleEpochTime()
+	if err != nil || now.Before(before) || now.Location() != time.UTC {
+		t.Errorf(`ExampleEpochTime("now") = %v, %v; want the current time in UTC`, now, err)
+	}
+	if _, err := (SchemaOptions{ExampleEpoch: "yesterday"}).ExampleEpochTime(); err == nil {
+		t.Error(`ExampleEpochTime("yesterday") succeeded, want an error`)
+	}
+
+	// Examples follow the epoch: a date-time lands within the year after it.
+	s := &OpenAPISpec{Paths: map[string]PathItem{"/e": {Get: &Operation{
+		Parameters: []Parameter{{Name: "since", In: "query", Schema: &Schema{Type: "string", Format: "date-time"}}},
+	}}}}
+	addExamples(s, SchemaOptions{ExampleEpoch: "2030-06-15"})
+	v, _ := s.Paths["/e"].Get.Parameters[0].Example.(string)
+	got, err := time.Parse(time.RFC3339, v)
+	epoch := time.Date(2030, time.June, 15, 0, 0, 0, 0, time.UTC)
+	if err != nil || got.Before(epoch) || got.After(epoch.AddDate(1, 0, 0)) || !strings.HasSuffix(v, "Z") {
+		t.Errorf("date-time example = %q, want a UTC time in the year after %s", v, epoch.Format(time.DateOnly))
+	}
+}
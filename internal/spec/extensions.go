@@ -0,0 +1,148 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/typemodel"
+	"github.com/ehabterra/apispec/pkg/patterns"
+)
+
+// ValidateExtensions rejects extensions config the generator could not
+// apply: a key without the "x-" prefix, an entry without its match field, or
+// an operation route that does not parse. It returns the first error
+// encountered.
+func (c *APISpecConfig) ValidateExtensions() error {
+	x := c.Extensions
+	if err := validateExtensionKeys("extensions.document", x.Document); err != nil {
+		return err
+	}
+	for i, p := range x.Paths {
+		at := fmt.Sprintf("extensions.paths[%d]", i)
+		if !strings.HasPrefix(p.Path, "/") {
+			return fmt.Errorf("%s: path glob %q must start with /", at, p.Path)
+		}
+		if err := validateExtensionKeys(at, p.Extensions); err != nil {
+			return err
+		}
+	}
+	for i, o := range x.Operations {
+		at := fmt.Sprintf("extensions.operations[%d]", i)
+		if _, err := parseRouteFilter(o.Route); err != nil {
+			return fmt.Errorf("%s route %q: %w", at, o.Route, err)
+		}
+		if err := validateExtensionKeys(at, o.Extensions); err != nil {
+			return err
+		}
+	}
+	for i, s := range x.Schemas {
+		at := fmt.Sprintf("extensions.schemas[%d]", i)
+		if s.Type == "" {
+			return fmt.Errorf("%s: type is required", at)
+		}
+		if err := validateExtensionKeys(at, s.Extensions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateExtensionKeys rejects the keys of extensions that are not vendor
+// extensions, in sorted order so the error is stable.
+func validateExtensionKeys(at string, extensions map[string]any) error {
+	for _, key := range slices.Sorted(maps.Keys(extensions)) {
+		if !strings.HasPrefix(key, "x-") {
+			return fmt.Errorf("%s: extension %q must start with \"x-\"", at, key)
+		}
+	}
+	return nil
+}
+
+// applyConfigExtensions adds the document, path and operation extensions of
+// cfg to spec. Component schemas get theirs when they are generated (see
+// applySchemaExtensions), while their Go types are known. Entries that do not
+// parse were rejected when the config was loaded and are skipped here.
+func applyConfigExtensions(spec *OpenAPISpec, cfg *APISpecConfig) {
+	if spec == nil || cfg == nil {
+		return
+	}
+	x := cfg.Extensions
+	spec.Extensions = mergeExtensions(spec.Extensions, x.Document)
+	if len(x.Paths) == 0 && len(x.Operations) == 0 {
+		return
+	}
+	for path, item := range spec.Paths {
+		for _, p := range x.Paths {
+			if patterns.Match(p.Path, path) {
+				item.Extensions = mergeExtensions(item.Extensions, p.Extensions)
+			}
+		}
+		for _, m := range item.Operations() {
+			for _, o := range x.Operations {
+				if f, err := parseRouteFilter(o.Route); err == nil && f.matches(m.Method, path) {
+					m.Operation.Extensions = mergeExtensions(m.Operation.Extensions, o.Extensions)
+				}
+			}
+		}
+		spec.Paths[path] = item
+	}
+}
+
+// applySchemaExtensions adds the schema extensions of rules to the
+// components generated from a matching Go type (goTypes, keyed by component
+// name). Like annotateComponentSchemas it replaces a component by a copy,
+// since its schema may be shared with inline use sites.
+func applySchemaExtensions(components Components, goTypes map[string]string, rules []SchemaExtensions) {
+	if len(rules) == 0 {
+		return
+	}
+	for name, schema := range components.Schemas {
+		goType, ok := goTypes[name]
+		if schema == nil || schema.Ref != "" || !ok {
+			continue
+		}
+		t := typemodel.Parse(goType)
+		simple, qualified := t.Simple(), t.String()
+		var extensions map[string]any
+		for _, r := range rules {
+			if patterns.Match(r.Type, simple) || patterns.Match(r.Type, qualified) {
+				extensions = mergeExtensions(extensions, r.Extensions)
+			}
+		}
+		if len(extensions) == 0 {
+			continue
+		}
+		annotated := *schema
+		annotated.Extensions = mergeExtensions(maps.Clone(schema.Extensions), extensions)
+		components.Schemas[name] = &annotated
+	}
+}
+
+// mergeExtensions sets the entries of add on dst, allocating it when needed,
+// and returns it.
+func mergeExtensions(dst, add map[string]any) map[string]any {
+	if len(add) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]any, len(add))
+	}
+	maps.Copy(dst, add)
+	return dst
+}
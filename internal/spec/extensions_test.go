@@ -0,0 +1,86 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateExtensions(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		x       ExtensionsConfig
+		wantErr string
+	}{
+		{"valid", ExtensionsConfig{
+			Document:   map[string]any{"x-owner": "team"},
+			Paths:      []PathExtensions{{Path: "/admin/**", Extensions: map[string]any{"x-internal": true}}},
+			Operations: []OperationExtensions{{Route: "GET /users/*", Extensions: map[string]any{"x-gateway-route-id": "users"}}},
+			Schemas:    []SchemaExtensions{{Type: "User", Extensions: map[string]any{"x-entity": "user"}}},
+		}, ""},
+		{"document key without prefix", ExtensionsConfig{Document: map[string]any{"owner": "team"}}, `extensions.document: extension "owner" must start with "x-"`},
+		{"relative path", ExtensionsConfig{Paths: []PathExtensions{{Path: "admin/**"}}}, `extensions.paths[0]: path glob "admin/**" must start with /`},
+		{"misspelled method", ExtensionsConfig{Operations: []OperationExtensions{{Route: "GTE /users"}}}, `extensions.operations[0] route "GTE /users": invalid method "GTE"`},
+		{"missing type", ExtensionsConfig{Schemas: []SchemaExtensions{{Extensions: map[string]any{"x-a": 1}}}}, "extensions.schemas[0]: type is required"},
+		{"schema key without prefix", ExtensionsConfig{Schemas: []SchemaExtensions{{Type: "User", Extensions: map[string]any{"entity": 1}}}}, `extensions.schemas[0]: extension "entity"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := APISpecConfig{Extensions: tc.x}
+			err := cfg.ValidateExtensions()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplySchemaExtensions(t *testing.T) {
+	shared := &Schema{Type: "object", Extensions: map[string]any{"x-go-type": "User"}}
+	components := Components{Schemas: map[string]*Schema{
+		"models_User":      shared,
+		"models_Page_User": {Type: "object"},
+		"api_Error":        {Type: "object"},
+	}}
+	goTypes := map[string]string{
+		"models_User":      "example.com/app/models-->User",
+		"models_Page_User": "example.com/app/models-->Page[User]",
+		"api_Error":        "example.com/app/api-->Error",
+	}
+	applySchemaExtensions(components, goTypes, []SchemaExtensions{
+		{Type: "User", Extensions: map[string]any{"x-entity": "user"}},
+		{Type: "example.com/app/models.*", Extensions: map[string]any{"x-domain": "models"}},
+	})
+
+	user := components.Schemas["models_User"]
+	if user.Extensions["x-entity"] != "user" || user.Extensions["x-domain"] != "models" || user.Extensions["x-go-type"] != "User" {
+		t.Errorf("User extensions = %v", user.Extensions)
+	}
+	if _, ok := shared.Extensions["x-entity"]; ok {
+		t.Error("the shared schema was modified; the component should be a copy")
+	}
+	if page := components.Schemas["models_Page_User"]; page.Extensions["x-entity"] != nil || page.Extensions["x-domain"] != "models" {
+		t.Errorf("Page[User] extensions = %v, want only x-domain", page.Extensions)
+	}
+	if e := components.Schemas["api_Error"]; len(e.Extensions) != 0 {
+		t.Errorf("Error extensions = %v, want none", e.Extensions)
+	}
+}
@@ -51,6 +51,46 @@ var wellKnownExternalSchemas = map[string]*Schema{
 	"github.com/shopspring/decimal.Decimal": {Type: "string", Format: "decimal"},
 	"decimal.Decimal":                       {Type: "string", Format: "decimal"},
 
+	// Uploaded files bound into a request struct (gin/echo `form` binding).
+	// They are multipart parts, so a body carrying one is sent as
+	// multipart/form-data (see isUploadBody).
+	"mime/multipart.FileHeader": {Type: "string", Format: "binary"},
+	"multipart.FileHeader":      {Type: "string", Format: "binary"},
+	"mime/multipart.File":       {Type: "string", Format: "binary"},
+	"multipart.File":            {Type: "string", Format: "binary"},
+
+	// Other decimal and big number types with a text form.
+	"github.com/cockroachdb/apd/v3.Decimal": {Type: "string", Format: "decimal"},
+	"github.com/gofrs/uuid/v5.UUID":         {Type: "string", Format: "uuid"},
+
+	// An RFC 6902 JSON Patch document, as evanphx/json-patch decodes it.
+	"jsonpatch.Patch": jsonPatchSchema,
+
+	// Standard library types, as encoding/json writes them. Without an entry
+	// they resolve to a $ref to a component that is never generated (the
+	// standard library is not analyzed), or, for big.Int, to a guessed string.
+	"time.Time": {Type: "string", Format: "date-time"},
+	// A Duration has no marshaler: it is written as its nanoseconds. A
+	// service sending "1h30m" strings maps its wrapper type, or time.Duration
+	// itself, to {type: string, format: duration} with typeMapping.
+	"time.Duration": {Type: "integer", Format: "int64"},
+	"time.Weekday":  {Type: "integer", Minimum: float64Ptr(0), Maximum: float64Ptr(6)},
+	"time.Month":    {Type: "integer", Minimum: float64Ptr(1), Maximum: float64Ptr(12)},
+	// big.Int marshals as a JSON number of any size; big.Float and big.Rat
+	// through their text form ("1.5", "3/4").
+	"math/big.Int":   {Type: "integer"},
+	"big.Int":        {Type: "integer"},
+	"math/big.Float": {Type: "string"},
+	"big.Float":      {Type: "string"},
+	"math/big.Rat":   {Type: "string"},
+	"big.Rat":        {Type: "string"},
+	// A json.Number is a string in Go and a number on the wire.
+	"encoding/json.Number": {Type: "number"},
+	"json.Number":          {Type: "number"},
+	"net.IP":               {Type: "string"},
+	// HardwareAddr is a []byte without a text form, so it is base64.
+	"net.HardwareAddr": {Type: "string", Format: "byte"},
+
 	// NOTE: database/sql.Null* deliberately omitted. They have no custom JSON
 	// marshaler, so encoding/json emits the struct ({"String":"…","Valid":…}).
 	// Without a registry entry they resolve to that struct component, which is
@@ -111,24 +151,11 @@ func lookupConfigSchema(cfg *APISpecConfig, goType string) *Schema {
 }
 
 // configHasExternalType reports whether goType matches a user externalTypes
-// entry (exact preferred over short-name, as in lookupConfigSchema). Such types
-// are emitted as named components by the existing externalTypes path, so the
-// built-in registry must not pre-empt them.
+// entry (exact preferred over short-name over glob, see externalType). Such
+// types are emitted as named components by the existing externalTypes path,
+// so the built-in registry must not pre-empt them.
 func configHasExternalType(cfg *APISpecConfig, goType string) bool {
-	if cfg == nil {
-		return false
-	}
-	for _, e := range cfg.ExternalTypes { // exact first
-		if e.Name == goType {
-			return true
-		}
-	}
-	for _, e := range cfg.ExternalTypes { // then short-name fallback
-		if shortNameMatchesBare(e.Name, goType) {
-			return true
-		}
-	}
-	return false
+	return cfg.externalType(goType) != nil
 }
 
 // lowConfidenceExternalNote is attached as a schema Description when an
@@ -171,6 +198,11 @@ func resolveExternalType(goType string, cfg *APISpecConfig, meta *metadata.Metad
 		return cloneSchema(s), nil, true
 	}
 
+	// Protobuf well-known types, in their proto3 JSON form.
+	if s := protobufWellKnownSchema(goType); s != nil {
+		return s, nil, true
+	}
+
 	// 2. Structural rule driven by metadata facts.
 	if meta != nil {
 		fact, ok := meta.ExternalTypes[goType]
@@ -36,6 +36,12 @@ func TestResolveExternalType_Registry(t *testing.T) {
 		{"uuid full path", "github.com/google/uuid.UUID", "string", "uuid"},
 		{"uuid short", "uuid.UUID", "string", "uuid"},
 		{"decimal", "github.com/shopspring/decimal.Decimal", "string", "decimal"},
+		{"time", "time.Time", "string", "date-time"},
+		{"duration nanoseconds", "time.Duration", "integer", "int64"},
+		{"big int is a JSON number", "math/big.Int", "integer", ""},
+		{"big rat text", "math/big.Rat", "string", ""},
+		{"json number", "encoding/json.Number", "number", ""},
+		{"hardware address base64", "net.HardwareAddr", "string", "byte"},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -123,6 +129,20 @@ func TestResolveExternalType_ConfigWins(t *testing.T) {
 	}
 }
 
+func TestMapGoType_TypeMappingOverridesRegistry(t *testing.T) {
+	cfg := &APISpecConfig{TypeMapping: []TypeMapping{
+		{GoType: "time.Duration", OpenAPIType: &Schema{Type: "string", Format: "duration"}},
+	}}
+	s, _ := mapGoTypeToOpenAPISchema(map[string]*Schema{}, "[]time.Duration", nil, cfg, map[string]bool{})
+	if s == nil || s.Items == nil || s.Items.Type != "string" || s.Items.Format != "duration" {
+		t.Fatalf("[]time.Duration with a typeMapping = %+v, want array of {string,duration}", s)
+	}
+	s, _ = mapGoTypeToOpenAPISchema(map[string]*Schema{}, "time.Duration", nil, nil, map[string]bool{})
+	if s == nil || s.Type != "integer" || s.Format != "int64" {
+		t.Fatalf("time.Duration = %+v, want {integer,int64}", s)
+	}
+}
+
 func TestLookupConfigSchema_ShortAndFullName(t *testing.T) {
 	cfg := &APISpecConfig{
 		TypeMapping: []TypeMapping{{GoType: "uuid.UUID", OpenAPIType: &Schema{Type: "string", Format: "uuid"}}},
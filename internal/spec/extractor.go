@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/ehabterra/apispec/internal/diag"
 	"github.com/ehabterra/apispec/internal/metadata"
 	"github.com/ehabterra/apispec/internal/typemodel"
 )
@@ -39,41 +40,80 @@ const (
 	unresolvedStatus = -1
 )
 
-// RouteInfo represents extracted route information
+// RouteInfo represents extracted route information.
+//
+// Its JSON form is a public contract (see the spec package): the json tags
+// below are the field names, and the analysis handles (UsedTypes, Metadata,
+// Node) are left out.
 type RouteInfo struct {
-	Path      string
-	MountPath string
-	Method    string
-	Handler   string
-	Package   string
-	File      string
-	Function  string
-	Summary   string
+	Path      string `json:"path"`
+	MountPath string `json:"mountPath,omitempty"`
+	Method    string `json:"method"`
+	Handler   string `json:"handler"`
+	Package   string `json:"package,omitempty"`
+	File      string `json:"file,omitempty"`
+	Function  string `json:"function,omitempty"`
+	Summary   string `json:"summary,omitempty"`
 	// Description is the operation's long description, sourced from the handler's
 	// Go doc comment (issue #168) when not otherwise set.
-	Description string
-	Tags        []string
-	Request     *RequestInfo
-	Response    map[string]*ResponseInfo
-	Params      []Parameter
+	Description string       `json:"description,omitempty"`
+	Tags        []string     `json:"tags,omitempty"`
+	Request     *RequestInfo `json:"request,omitempty"`
+	// Response is keyed by status code ("200"; "-1" when unresolved).
+	Response map[string]*ResponseInfo `json:"responses,omitempty"`
+	Params   []Parameter              `json:"parameters,omitempty"`
 
 	// OperationIDSuffix disambiguates the operationId when one handler yields
 	// several operations (e.g. an r.Method dispatch split into GET/POST). Empty
 	// for ordinary routes. Appended as "_<suffix>" to the computed operationId.
-	OperationIDSuffix string
+	OperationIDSuffix string `json:"operationIdSuffix,omitempty"`
+
+	// OperationID and Deprecated come from the handler's swaggo @ID and
+	// @Deprecated annotations (see applyAnnotations). OperationID replaces
+	// the computed operationId.
+	OperationID string `json:"operationId,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+
+	// Protocol names the streaming protocol the handler upgrades the
+	// connection to (ProtocolWebSocket / ProtocolSSE), detected through
+	// ProtocolPatterns. Empty for ordinary request/response routes.
+	Protocol string `json:"protocol,omitempty"`
+
+	// ResponseHeaders names the headers the handler sets on its response,
+	// detected through ResponseHeaderPatterns, in the order first seen.
+	ResponseHeaders []string `json:"responseHeaders,omitempty"`
+
+	// Webhooks names the configured webhooks (APISpecConfig.Webhooks) whose
+	// delivering call the handler makes, in the order first seen.
+	Webhooks []string `json:"webhooks,omitempty"`
+
+	// ContextValues names the keys of the configured context values
+	// (APISpecConfig.ContextValues) the handler reads, in the order first
+	// seen.
+	ContextValues []string `json:"contextValues,omitempty"`
+
+	// Timeout and Retries carry the gateway hints from a matching Override
+	// (see Override.Timeout). Zero values mean "use the gateway default".
+	Timeout string `json:"timeout,omitempty"`
+	Retries int    `json:"retries,omitempty"`
 
 	// MethodExplicit is true when Method was resolved from the registration
 	// (a verb-carrying call/arg/path, e.g. router.GET or "GET /x"), and false
 	// when it fell back to the default. Only verb-less routes are eligible for
 	// r.Method-dispatch splitting — a router that registers a concrete verb
 	// won't dispatch the other verbs to the handler.
-	MethodExplicit bool
+	MethodExplicit bool `json:"methodExplicit,omitempty"`
+
+	// Methods lists every verb a multi-verb registration (echo's
+	// e.Match([]string{GET, HEAD}, ...)) serves, Method being the first.
+	// ExtractRoutes splits such a route into one per verb.
+	Methods []string `json:"methods,omitempty"`
 
-	UsedTypes map[string]*Schema
-	Metadata  *metadata.Metadata
+	UsedTypes map[string]*Schema `json:"-"`
+	Metadata  *metadata.Metadata `json:"-"`
 
 	// Resolved router group prefix (if any)
-	GroupPrefix string
+	GroupPrefix string `json:"groupPrefix,omitempty"`
 
 	// Security holds the per-operation OpenAPI security requirements resolved
 	// from auth middleware detected in scope. Semantics:
@@ -82,13 +122,25 @@ type RouteInfo struct {
 	//   non-nil empty-> explicitly public (overrides global); renders as
 	//                   `security: []`.
 	//   non-empty    -> the operation is protected by these requirements.
-	Security []SecurityRequirement
+	// JSON keeps the distinction: null inherits, [] is public.
+	Security []SecurityRequirement `json:"security"`
+
+	// Middleware lists the middleware detected guarding the route, in the
+	// order first seen. It is collected only when middlewareResponses are
+	// configured (see MiddlewareResponse).
+	Middleware []MiddlewareRef `json:"middleware,omitempty"`
 
 	// DynamicParams names path placeholders synthesized from unresolvable
 	// call expressions (issue #34). The mapper uses these to emit one
 	// shared component parameter per name and $ref it from each operation
 	// instead of inlining a fresh declaration on every route.
-	DynamicParams []string
+	DynamicParams []string `json:"dynamicParams,omitempty"`
+
+	// RemainderParams names the ServeMux trailing wildcards ({path...}) of
+	// Path. They match the rest of the path, slashes included, which an
+	// OpenAPI path template cannot say, so the mapper documents it on the
+	// parameter.
+	RemainderParams []string `json:"remainderParams,omitempty"`
 
 	// Node is the tracker-tree node where this route was matched (the route
 	// registration call). Its subtree is the interface-resolved handler flow;
@@ -118,41 +170,51 @@ func (r *RouteInfo) IsValid() bool {
 
 // RequestInfo represents request information
 type RequestInfo struct {
-	ContentType string
-	BodyType    string
-	Schema      *Schema
+	ContentType string  `json:"contentType,omitempty"`
+	BodyType    string  `json:"bodyType,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
 
 	// OneOfTypes holds the concrete types a polymorphic body resolves to
 	// (issue #201). BodyType stays the interface — it is the Go type, and
 	// several consumers key off it — but component collection marks these
 	// instead, so the interface is not emitted as a component that nothing
 	// references.
-	OneOfTypes []string
+	OneOfTypes []string `json:"oneOfTypes,omitempty"`
 
 	// File and Line locate the call site that produced this request body, used
 	// to attribute it to an r.Method dispatch branch (see splitMethodDispatchRoutes).
-	File string
-	Line int
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+
+	// Optional is set when the handler only conditionally reads the body
+	// (see requestBodyOptional); the request body is then not required.
+	Optional bool `json:"optional,omitempty"`
 }
 
 // ResponseInfo represents response information
 type ResponseInfo struct {
-	StatusCode  int
-	ContentType string
-	BodyType    string
-	Schema      *Schema
+	// StatusCode is -1 when the status could not be resolved; the spec
+	// documents it as the "default" response.
+	StatusCode  int     `json:"statusCode"`
+	ContentType string  `json:"contentType,omitempty"`
+	BodyType    string  `json:"bodyType,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
 
 	// OneOfTypes holds the concrete types a polymorphic body resolves to
 	// (issue #201). BodyType stays the interface — it is the Go type, and
 	// several consumers key off it — but component collection marks these
 	// instead, so the interface is not emitted as a component that nothing
 	// references.
-	OneOfTypes []string
+	OneOfTypes []string `json:"oneOfTypes,omitempty"`
+
+	// Description replaces the status text as the response description; set
+	// from a swaggo @Success/@Failure annotation.
+	Description string `json:"description,omitempty"`
 
 	// File and Line locate the call site that produced this response, used to
 	// attribute it to an r.Method dispatch branch (see splitMethodDispatchRoutes).
-	File string
-	Line int
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
 }
 
 // Extractor provides a cleaner, more modular approach to extraction
@@ -171,6 +233,21 @@ type Extractor struct {
 	requestMatchers  []RequestPatternMatcher
 	responseMatchers []ResponsePatternMatcher
 	paramMatchers    []ParamPatternMatcher
+	protocolMatchers []ProtocolPatternMatcher
+	headerMatchers   []ResponseHeaderPatternMatcher
+	// webhookMatchers hold the matchers of each configured webhook's calls,
+	// indexed like APISpecConfig.Webhooks.
+	webhookMatchers [][]ProtocolPatternMatcher
+	// contextValuesByEdge memoizes contextValuesOf.
+	contextValuesByEdge map[*metadata.CallGraphEdge][]string
+
+	// errorResponses is the number of leading responseMatchers built from
+	// ErrorResponses, and responseHelpers the number built from
+	// Framework.ResponseHelpers after them (same order), so an error or
+	// helper call site wins over any generic pattern that would also claim
+	// it.
+	errorResponses  int
+	responseHelpers int
 
 	// securityUnresolved collects auth middleware that was detected but matched
 	// no SecurityMapping, deduped by identity. Surfaced as a warning (CLI) and
@@ -184,6 +261,23 @@ type Extractor struct {
 	pathParamMismatches  []PathParamMismatch
 	pathParamMismatchSet map[string]struct{}
 
+	// droppedRoutes collects route registrations whose path or handler could
+	// not be resolved (see recordRouteSite), droppedRouteSet their call
+	// sites, and resolvedRouteSites the call sites that produced a route.
+	droppedRoutes      []UnresolvedRoute
+	droppedRouteSet    map[string]struct{}
+	resolvedRouteSites map[string]bool
+
+	// routeSites lists every registration call site met (see noteRouteSite),
+	// routeSiteIndex their positions, for the route coverage report.
+	routeSites     []RouteSite
+	routeSiteIndex map[string]int
+
+	// unsafeMethodWrites collects GET and HEAD handlers that reach a mutation
+	// call (see recordUnsafeMethodWrite), one per operation.
+	unsafeMethodWrites   []UnsafeMethodWrite
+	unsafeMethodWriteSet map[string]struct{}
+
 	// parentFnIndex maps a function's BaseID to call edges made inside func
 	// literals lexically nested in it (keyed by ParentFunction). Lets wrapper
 	// look-through reach a library call that lives in the closure a middleware
@@ -213,12 +307,36 @@ type Extractor struct {
 	respMatcherByEdge  map[*metadata.CallGraphEdge]int16
 	reqMatcherByEdge   map[*metadata.CallGraphEdge]int16
 	paramMatcherByEdge map[*metadata.CallGraphEdge]int16
+	protocolByEdge     map[*metadata.CallGraphEdge]string
+	webhooksByEdge     map[*metadata.CallGraphEdge][]string
 	// Route matching keeps ALL matching indexes (not just the first):
 	// executeRoutePattern arbitrates between them by priority and extraction
 	// success. Multi-framework config merging multiplied the route-matcher
 	// count, which made the per-node linear scan visible on large-project
 	// profiles; matching depends only on edge facts, so it memoizes cleanly.
 	routeMatchersByEdge map[*metadata.CallGraphEdge][]int16
+
+	// Callee-name indexes per matcher family (see calleeIndex): a node is
+	// only checked against the matchers whose call regex can accept its
+	// callee, instead of every matcher. matcherStats counts the work for the
+	// metrics output.
+	routeIndex    *calleeIndex
+	mountIndex    *calleeIndex
+	securityIndex *calleeIndex
+	requestIndex  *calleeIndex
+	responseIndex *calleeIndex
+	paramIndex    *calleeIndex
+	matcherStats  MatcherStats
+
+	// optionalBodies maps a source file to the line ranges where a handler
+	// reads the request body only conditionally; see requestBodyOptional.
+	optionalBodies map[string][]metadata.LineRange
+
+	// annotations caches each handler's parsed swaggo annotations (nil when
+	// it has none) and the package its doc comment was read from, keyed by
+	// Package|Function; see handlerAnnotations.
+	annotations    map[string]*swaggoAnnotations
+	annotationPkgs map[string]string
 	// reachSets caches, per accessor pattern, which function BaseIDs
 	// transitively reach a matching call. See reachability.go.
 	reachSets map[string]map[string]bool
@@ -250,64 +368,155 @@ func NewExtractor(tree TrackerTreeInterface, cfg *APISpecConfig) *Extractor {
 	return extractor
 }
 
-// initializePatternMatchers initializes all pattern matchers
+// initializePatternMatchers initializes all pattern matchers, compiles their
+// regexes and indexes each family by callee name.
 func (e *Extractor) initializePatternMatchers() {
 	// Initialize route matchers
+	var routeCalls []string
 	for _, pattern := range e.cfg.Framework.RoutePatterns {
 		matcher := NewRoutePatternMatcher(pattern, e.cfg, e.contextProvider, e.typeResolver)
 		e.routeMatchers = append(e.routeMatchers, matcher)
+		routeCalls = append(routeCalls, pattern.CallRegex)
+		precompileRegexes(pattern.CallRegex, pattern.FunctionNameRegex, pattern.RecvTypeRegex)
+	}
+	for _, custom := range e.cfg.RoutePatterns {
+		for _, pattern := range custom.routePatterns() {
+			matcher := NewRoutePatternMatcher(pattern, e.cfg, e.contextProvider, e.typeResolver)
+			e.routeMatchers = append(e.routeMatchers, matcher)
+			routeCalls = append(routeCalls, pattern.CallRegex)
+			precompileRegexes(pattern.CallRegex, pattern.FunctionNameRegex, pattern.RecvTypeRegex)
+		}
 	}
+	e.routeIndex = newCalleeIndex(routeCalls)
 
 	// Initialize mount matchers
+	var mountCalls []string
 	for _, pattern := range e.cfg.Framework.MountPatterns {
 		matcher := NewMountPatternMatcher(pattern, e.cfg, e.contextProvider, e.typeResolver)
 		e.mountMatchers = append(e.mountMatchers, matcher)
+		mountCalls = append(mountCalls, pattern.CallRegex)
+		precompileRegexes(pattern.CallRegex, pattern.FunctionNameRegex, pattern.RecvTypeRegex)
 	}
+	e.mountIndex = newCalleeIndex(mountCalls)
 
 	// Initialize security matchers
+	var securityCalls []string
 	for _, pattern := range e.cfg.Framework.SecurityPatterns {
 		matcher := NewSecurityPatternMatcher(pattern, e.cfg, e.contextProvider, e.typeResolver)
 		e.securityMatchers = append(e.securityMatchers, matcher)
+		securityCalls = append(securityCalls, pattern.CallRegex)
+		precompileRegexes(pattern.CallRegex, pattern.FunctionNameRegex, pattern.RecvTypeRegex)
 	}
+	e.securityIndex = newCalleeIndex(securityCalls)
 
 	// Initialize request matchers
+	var requestCalls []string
 	for _, pattern := range e.cfg.Framework.RequestBodyPatterns {
 		matcher := NewRequestPatternMatcher(pattern, e.cfg, e.contextProvider, e.typeResolver)
 		e.requestMatchers = append(e.requestMatchers, matcher)
+		requestCalls = append(requestCalls, pattern.CallRegex)
+		precompileRegexes(pattern.CallRegex, pattern.FunctionNameRegex, pattern.RecvTypeRegex)
 	}
+	e.requestIndex = newCalleeIndex(requestCalls)
 
-	// Initialize response matchers
+	// Initialize response matchers: error responses, then response helpers,
+	// then the framework's patterns.
+	var responseCalls []string
+	for _, er := range e.cfg.ErrorResponses {
+		pattern := er.responsePattern()
+		matcher := NewResponsePatternMatcher(pattern, e.cfg, e.contextProvider, e.typeResolver)
+		e.responseMatchers = append(e.responseMatchers, matcher)
+		responseCalls = append(responseCalls, pattern.CallRegex)
+		precompileRegexes(pattern.CallRegex, pattern.FunctionNameRegex, pattern.RecvTypeRegex)
+	}
+	e.errorResponses = len(e.cfg.ErrorResponses)
+	for _, helper := range e.cfg.Framework.ResponseHelpers {
+		pattern := helper.responsePattern()
+		matcher := NewResponsePatternMatcher(pattern, e.cfg, e.contextProvider, e.typeResolver)
+		e.responseMatchers = append(e.responseMatchers, matcher)
+		responseCalls = append(responseCalls, pattern.CallRegex)
+		precompileRegexes(pattern.CallRegex, pattern.FunctionNameRegex, pattern.RecvTypeRegex)
+	}
+	e.responseHelpers = len(e.cfg.Framework.ResponseHelpers)
 	for _, pattern := range e.cfg.Framework.ResponsePatterns {
 		matcher := NewResponsePatternMatcher(pattern, e.cfg, e.contextProvider, e.typeResolver)
 		e.responseMatchers = append(e.responseMatchers, matcher)
+		responseCalls = append(responseCalls, pattern.CallRegex)
+		precompileRegexes(pattern.CallRegex, pattern.FunctionNameRegex, pattern.RecvTypeRegex)
 	}
+	e.responseIndex = newCalleeIndex(responseCalls)
 
 	// Initialize param matchers
+	var paramCalls []string
 	for _, pattern := range e.cfg.Framework.ParamPatterns {
 		matcher := NewParamPatternMatcher(pattern, e.cfg, e.contextProvider, e.typeResolver)
 		e.paramMatchers = append(e.paramMatchers, matcher)
+		paramCalls = append(paramCalls, pattern.CallRegex)
+		precompileRegexes(pattern.CallRegex, pattern.FunctionNameRegex, pattern.RecvTypeRegex)
+	}
+	e.paramIndex = newCalleeIndex(paramCalls)
+
+	// Initialize protocol matchers
+	for _, pattern := range e.cfg.Framework.ProtocolPatterns {
+		matcher := NewProtocolPatternMatcher(pattern, e.cfg, e.contextProvider, e.typeResolver)
+		e.protocolMatchers = append(e.protocolMatchers, matcher)
+	}
+
+	// Initialize response header matchers
+	for _, pattern := range e.cfg.Framework.ResponseHeaderPatterns {
+		matcher := NewResponseHeaderPatternMatcher(pattern, e.cfg, e.contextProvider, e.typeResolver)
+		e.headerMatchers = append(e.headerMatchers, matcher)
+	}
+
+	// Initialize webhook call matchers
+	for _, webhook := range e.cfg.Webhooks {
+		var matchers []ProtocolPatternMatcher
+		for _, call := range webhook.Calls {
+			matchers = append(matchers, NewProtocolPatternMatcher(call.protocolPattern(), e.cfg, e.contextProvider, e.typeResolver))
+		}
+		e.webhookMatchers = append(e.webhookMatchers, matchers)
 	}
 }
 
 // ExtractRoutes extracts all routes from the tracker tree
 func (e *Extractor) ExtractRoutes() []*RouteInfo {
+	e.reportUndeclaredErrorBodies()
 	routes := make([]*RouteInfo, 0)
 	for _, root := range e.tree.GetRoots() {
 		e.traverseForRoutes(root, "", nil, nil, nil, &routes)
 	}
+	e.settleDroppedRoutes()
 	routes = dropSubsumedMountPrefixes(routes)
+	routes = splitMultiMethodRoutes(routes)
 
 	// Split handlers that dispatch on r.Method (switch/if) into one route per
 	// HTTP method, before the per-route diagnostics below run on the settled set.
 	routes = splitMethodDispatchRoutes(routes)
+	applyProtocolDefaults(routes)
+	e.applyPatchSemantics(routes)
 
-	// Diagnose map-key path-variable reads whose key matches no path placeholder.
+	// Document handlers only a swaggo @Router annotation places.
+	routes = append(routes, e.annotatedRoutes(routes)...)
+
+	// Document the security and parameters implied by the context values
+	// middleware stored for the handlers.
+	for _, r := range routes {
+		e.applyContextValues(r)
+	}
+	e.reportUnreadContextValues(routes)
+
+	// Diagnose map-key path-variable reads whose key matches no path placeholder,
+	// and GET/HEAD handlers that write.
 	// Done over the finalised route set so method/path are settled (handleRouteNode
 	// runs on transient, pre-dedup route objects).
 	for _, r := range routes {
 		e.recordPathVarKeyMismatches(r)
+		e.recordUnsafeMethodWrite(r)
 	}
-	return routes
+
+	// Document the responses of the middleware guarding each route, and the
+	// CORS preflight operations they answer.
+	return e.applyMiddlewareResponses(routes)
 }
 
 // dropSubsumedMountPrefixes removes spurious partially-mounted duplicates of a
@@ -461,7 +670,12 @@ func (e *Extractor) executeMountPattern(node TrackerNodeInterface) (MountInfo, b
 	var bestPriority int
 	var found bool
 
-	for _, matcher := range e.mountMatchers {
+	if node == nil {
+		return bestMatch, false
+	}
+	for _, i := range e.candidateMatchers(e.mountIndex, node.GetEdge()) {
+		matcher := e.mountMatchers[i]
+		e.matcherStats.Evaluations++
 		if matcher.MatchNode(node) {
 			priority := matcher.GetPriority()
 			if !found || priority > bestPriority {
@@ -505,12 +719,14 @@ func (e *Extractor) routeMatchersFor(node TrackerNodeInterface) []int16 {
 	}
 	edge := node.GetEdge()
 	if idxs, ok := e.routeMatchersByEdge[edge]; ok {
+		e.matcherStats.Memoized++
 		return idxs
 	}
 	var idxs []int16
-	for i, matcher := range e.routeMatchers {
-		if matcher.MatchNode(node) {
-			idxs = append(idxs, int16(i))
+	for _, i := range e.candidateMatchers(e.routeIndex, edge) {
+		e.matcherStats.Evaluations++
+		if e.routeMatchers[i].MatchNode(node) {
+			idxs = append(idxs, i)
 		}
 	}
 	if e.routeMatchersByEdge == nil {
@@ -525,7 +741,12 @@ func (e *Extractor) routeMatchersFor(node TrackerNodeInterface) []int16 {
 // false when no security pattern applies (the common case).
 func (e *Extractor) collectNodeSecurity(node TrackerNodeInterface) (refs []MiddlewareRef, scope string, matched bool) {
 	var bestPriority int
-	for _, m := range e.securityMatchers {
+	if node == nil {
+		return nil, "", false
+	}
+	for _, i := range e.candidateMatchers(e.securityIndex, node.GetEdge()) {
+		m := e.securityMatchers[i]
+		e.matcherStats.Evaluations++
 		if !m.MatchNode(node) {
 			continue
 		}
@@ -570,8 +791,13 @@ func (e *Extractor) collectChainSecurity(node TrackerNodeInterface) []Middleware
 		var bestPriority int
 		var bestRefs []MiddlewareRef
 		var found bool
-		for _, m := range e.securityMatchers {
-			if m.Scope() != SecurityScopeRoute || !m.MatchEdge(parent) {
+		for _, i := range e.candidateMatchers(e.securityIndex, parent) {
+			m := e.securityMatchers[i]
+			if m.Scope() != SecurityScopeRoute {
+				continue
+			}
+			e.matcherStats.Evaluations++
+			if !m.MatchEdge(parent) {
 				continue
 			}
 			if p := m.GetPriority(); !found || p > bestPriority {
@@ -660,29 +886,25 @@ func dedupMiddlewareRefs(refs []MiddlewareRef) []MiddlewareRef {
 	return out
 }
 
-// applyRouteSecurity resolves and sets routeInfo.Security from the inherited
-// (router/subtree) middleware plus any route-scope or handler-wrapper middleware
-// on the route registration call itself. Unmatched middleware is recorded for
-// diagnostics. When no security is configured/detected, routeInfo.Security is
-// left nil so the operation inherits the document-level security and output is
-// unchanged.
-func (e *Extractor) applyRouteSecurity(node TrackerNodeInterface, routeInfo *RouteInfo, mountMW []MiddlewareRef) {
+// routeMiddleware collects the middleware guarding a route: the inherited
+// (router/subtree) middleware plus any route-scope, chained or handler-wrapper
+// middleware on the route registration call itself. Nothing is collected when
+// no security patterns are configured.
+func (e *Extractor) routeMiddleware(node TrackerNodeInterface, mountMW []MiddlewareRef) (definite, speculative []MiddlewareRef) {
 	if len(e.securityMatchers) == 0 {
-		return
+		return nil, nil
 	}
 
 	// Definite middleware: inherited router/subtree middleware plus route-scope
 	// middleware on the call itself. These come from dedicated middleware slots,
 	// so an unresolved one genuinely means "you forgot to map it" -> warn.
-	definite := append([]MiddlewareRef{}, mountMW...)
+	definite = append([]MiddlewareRef{}, mountMW...)
 
 	// Speculative middleware: the handler argument of a net/http-style Handle is
 	// a wrapping call (auth(h)) that is syntactically indistinguishable from a
 	// handler factory (newUserHandler()). We only treat it as auth when looking
 	// through its body finds a known auth library; otherwise it is silently
 	// ignored (no warning), since it is probably not middleware at all.
-	var speculative []MiddlewareRef
-
 	if refs, scope, ok := e.collectNodeSecurity(node); ok {
 		switch scope {
 		case SecurityScopeRoute:
@@ -695,7 +917,15 @@ func (e *Extractor) applyRouteSecurity(node TrackerNodeInterface, routeInfo *Rou
 	// Chained-call middleware (e.g. chi r.With(mw).Get(...)): the middleware is
 	// on the route's chain-parent edge, which guards only this route.
 	definite = append(definite, e.collectChainSecurity(node)...)
+	return definite, speculative
+}
 
+// applyRouteSecurity resolves and sets routeInfo.Security from the middleware
+// routeMiddleware collected. Unmatched definite middleware is recorded for
+// diagnostics. When no security is configured/detected, routeInfo.Security is
+// left nil so the operation inherits the document-level security and output is
+// unchanged.
+func (e *Extractor) applyRouteSecurity(routeInfo *RouteInfo, definite, speculative []MiddlewareRef) {
 	var reqs []SecurityRequirement
 	public := false
 
@@ -816,6 +1046,12 @@ func (e *Extractor) handleMountNode(node TrackerNodeInterface, mountInfo MountIn
 	if refs, scope, ok := e.collectNodeSecurity(node); ok && scope == SecurityScopeSubtree {
 		subtreeMW = mergeMW(mountMW, refs)
 	}
+	// Route-scope middleware chained in front of the mount (chi's
+	// r.With(mw).Route("/x", fn) or r.With(mw).Group(fn)) guards what the
+	// mount registers, just as it guards a chained route.
+	if refs := e.collectChainSecurity(node); len(refs) > 0 {
+		subtreeMW = mergeMW(subtreeMW, refs)
+	}
 	// Router-scope middleware among the mount's children (e.g. a `Use` inside a
 	// chi Group(func(r){ r.Use(...); ... }) closure) is correlated per caller.
 	routerByCaller := e.collectRouterSecurityByCaller(node.GetChildren())
@@ -829,7 +1065,7 @@ func (e *Extractor) handleMountNode(node TrackerNodeInterface, mountInfo MountIn
 	for _, child := range node.GetChildren() {
 		var newTags []string
 		if mountPath != "" {
-			newTags = []string{mountPath}
+			newTags = e.groupTags(mountPath)
 		} else {
 			newTags = mountTags
 		}
@@ -860,7 +1096,14 @@ func (e *Extractor) handleRouteNode(node TrackerNodeInterface, routeInfo *RouteI
 
 	// Resolve per-operation security: inherited (router/subtree) middleware plus
 	// any route-scope or handler-wrapper middleware on the route call itself.
-	e.applyRouteSecurity(node, routeInfo, mountMW)
+	// The middleware is kept on the route for middlewareResponses, merged
+	// with what earlier contexts found; copy it first, as resolving security
+	// dedups in place.
+	definite, speculative := e.routeMiddleware(node, mountMW)
+	if len(e.cfg.MiddlewareResponses) > 0 {
+		routeInfo.Middleware = dedupMiddlewareRefs(slices.Concat(routeInfo.Middleware, definite, speculative))
+	}
+	e.applyRouteSecurity(routeInfo, definite, speculative)
 
 	// The same route CALL SITE reached again through another traversal
 	// context reproduces byte-identical extraction (fragments are pure and
@@ -889,16 +1132,21 @@ func (e *Extractor) handleRouteNode(node TrackerNodeInterface, routeInfo *RouteI
 	// order-insensitive pairing model.
 	visitedEdges := make(map[chainStep]bool)
 	var respCandidates []responseCandidate
-	e.extractRouteChildren(node, routeInfo, mountTags, routes, visitedEdges, &chainInterner{}, 0, &respCandidates)
+	e.extractRouteChildren(node, routeInfo, mountTags, routes, visitedEdges, &chainInterner{}, 0, &respCandidates, false)
 	e.pairAndFillResponses(routeInfo, respCandidates)
 
 	// Add map-key path params (mux.Vars) for placeholders the handler reads via
 	// the accessor — including through helper wrappers the subtree walk misses.
 	e.completeMapKeyPathParams(routeInfo)
 
-	// Apply overrides
+	// Merge constraints from per-route validation middleware into the body.
+	e.applyRouteValidation(node, routeInfo)
+
+	// Merge swaggo annotations on the handler, then config overrides.
+	e.applyAnnotations(routeInfo)
 	e.overrideApplier.ApplyOverrides(routeInfo)
 
+	e.recordRouteSite(node, routeInfo)
 	if routeInfo.IsValid() && routes != nil {
 		// Update existing route or add new one. Dedup key is the
 		// effective OpenAPI identity (mount + path + method + handler)
@@ -942,6 +1190,15 @@ func mergeRouteExtraction(existing, next *RouteInfo) {
 	if len(existing.Security) == 0 {
 		existing.Security = next.Security
 	}
+	if existing.Protocol == "" {
+		existing.Protocol = next.Protocol
+	}
+	for _, name := range next.ResponseHeaders {
+		addResponseHeader(existing, name)
+	}
+	addWebhooks(existing, next.Webhooks...)
+	addContextValues(existing, next.ContextValues...)
+	existing.Middleware = dedupMiddlewareRefs(slices.Concat(existing.Middleware, next.Middleware))
 }
 
 // handleRouterAssignment handles router assignment for mounts
@@ -957,7 +1214,7 @@ func (e *Extractor) handleRouterAssignment(mountInfo MountInfo, mountPath string
 		for _, child := range children {
 			var newTags []string
 			if mountPath != "" {
-				newTags = []string{mountPath}
+				newTags = e.groupTags(mountPath)
 			} else {
 				newTags = mountTags
 			}
@@ -1101,10 +1358,14 @@ func frameChainKey(chain []string, node TrackerNodeInterface) string {
 	return "" // route/handler frame
 }
 
-func (e *Extractor) extractRouteChildren(routeNode TrackerNodeInterface, route *RouteInfo, mountTags []string, routes *[]*RouteInfo, visitedEdges map[chainStep]bool, ci *chainInterner, chainID int, respCandidates *[]responseCandidate) {
+func (e *Extractor) extractRouteChildren(routeNode TrackerNodeInterface, route *RouteInfo, mountTags []string, routes *[]*RouteInfo, visitedEdges map[chainStep]bool, ci *chainInterner, chainID int, respCandidates *[]responseCandidate, inRegistration bool) {
 	for _, child := range routeNode.GetChildren() {
-		// Check for route patterns in children nodes
-		if isRoute := e.executeRoutePattern(child, route); isRoute {
+		// Check for route patterns in children nodes. Calls made by the
+		// registration function's own body (a router wrapper forwarding
+		// to mux.HandleFunc), and everything below them, are how this
+		// route is registered, not more of it.
+		inBody := inRegistration || registers(route.Node, child)
+		if !inBody && e.executeRoutePattern(child, route) {
 			e.handleRouteNode(child, route, "", mountTags, route.DynamicParams, nil, routes)
 		}
 
@@ -1119,6 +1380,7 @@ func (e *Extractor) extractRouteChildren(routeNode TrackerNodeInterface, route *
 			if f, l, _ := calleePosition(child); req.File == "" {
 				req.File, req.Line = f, l
 			}
+			req.Optional = e.requestBodyOptional(child, route)
 			route.Request = preferRequestInfo(route.Request, req)
 		}
 
@@ -1141,24 +1403,75 @@ func (e *Extractor) extractRouteChildren(routeNode TrackerNodeInterface, route *
 		// Extract parameters
 		route.Params = append(route.Params, e.extractParamsFromNode(child, route)...)
 
+		// Mark websocket upgrades / SSE streams; the first protocol seen wins,
+		// except that a chunked body gives way to the stream it turns out to
+		// be.
+		if route.Protocol == "" {
+			route.Protocol = e.protocolOf(child)
+		} else if route.Protocol == ProtocolChunked {
+			if proto := e.protocolOf(child); proto == ProtocolSSE || proto == ProtocolNDJSON || proto == ProtocolWebSocket {
+				route.Protocol = proto
+			}
+		}
+
+		// Record the response headers the handler sets.
+		addResponseHeader(route, e.responseHeaderOf(child, route))
+
+		// Record the webhooks the handler delivers.
+		addWebhooks(route, e.webhooksOf(child)...)
+
+		// Record the request-scoped values the handler reads.
+		addContextValues(route, e.contextValuesOf(child)...)
+
+		// A response helper that takes the body as an argument is the
+		// response: what it encodes internally is the generic envelope
+		// its parameter was erased to, so the walk stops at the call.
+		if e.isOpaqueResponseHelper(child) {
+			continue
+		}
+
 		// Recursive extraction. The chain grows only through CALL nodes —
 		// argument nodes reference values within the current frame.
 		childChainID := chainID
 		if child != nil && child.GetArgument() == nil && child.GetEdge() != nil {
 			childChainID = ci.push(chainID, child.GetEdge().Callee.ID())
 		}
-		e.extractRouteChildren(child, route, mountTags, routes, visitedEdges, ci, childChainID, respCandidates)
+		e.extractRouteChildren(child, route, mountTags, routes, visitedEdges, ci, childChainID, respCandidates, inBody)
 	}
 
 	// Extract parameters from the route node itself
 	route.Params = append(route.Params, e.extractParamsFromNode(routeNode, route)...)
 }
 
+// registers reports whether child is a call made by the function the route
+// node calls, i.e. a statement of the registration call's own body.
+func registers(routeNode, child TrackerNodeInterface) bool {
+	if routeNode == nil || child == nil || routeNode.GetEdge() == nil || child.GetEdge() == nil {
+		return false
+	}
+	return child.GetEdge().Caller.BaseID() == routeNode.GetEdge().Callee.BaseID()
+}
+
 // matchesResponsePattern reports whether any response matcher accepts the node.
 func (e *Extractor) matchesResponsePattern(node TrackerNodeInterface) bool {
 	return e.responseMatcherIndex(node) >= 0
 }
 
+// isOpaqueResponseHelper reports whether the node calls a configured error
+// response, or a response helper whose body is read from its arguments
+// (see ResponseHelperPattern.bodyArg).
+func (e *Extractor) isOpaqueResponseHelper(node TrackerNodeInterface) bool {
+	if node == nil || node.GetArgument() != nil {
+		return false
+	}
+	idx := int(e.responseMatcherIndex(node))
+	if idx >= 0 && idx < e.errorResponses {
+		return true
+	}
+	idx -= e.errorResponses
+	return idx >= 0 && idx < e.responseHelpers && e.cfg.Framework.ResponseHelpers[idx].bodyArg() >= 0
+}
+
 // responseMatcherIndex returns the first response matcher accepting the
 // node's edge, memoized per edge (see the memo fields for why this is sound).
 func (e *Extractor) responseMatcherIndex(node TrackerNodeInterface) int16 {
@@ -1167,15 +1480,10 @@ func (e *Extractor) responseMatcherIndex(node TrackerNodeInterface) int16 {
 	}
 	edge := node.GetEdge()
 	if idx, ok := e.respMatcherByEdge[edge]; ok {
+		e.matcherStats.Memoized++
 		return idx
 	}
-	idx := int16(-1)
-	for i, matcher := range e.responseMatchers {
-		if matcher.MatchNode(node) {
-			idx = int16(i)
-			break
-		}
-	}
+	idx := e.firstMatcher(e.responseIndex, node, func(i int16) bool { return e.responseMatchers[i].MatchNode(node) })
 	if e.respMatcherByEdge == nil {
 		e.respMatcherByEdge = map[*metadata.CallGraphEdge]int16{}
 	}
@@ -1261,6 +1569,13 @@ func (e *Extractor) pairAndFillResponses(route *RouteInfo, candidates []response
 	store := func(resp *ResponseInfo) {
 		slot := fmt.Sprintf("%d", resp.StatusCode)
 		existing := route.Response[slot]
+		if existing != nil && existing.BodyType != "" && resp.BodyType != "" &&
+			existing.ContentType != resp.ContentType {
+			// The handler negotiates the format: the same status written in
+			// another media type is kept beside it (see negotiatedSlot).
+			slot = negotiatedSlot(resp.StatusCode, resp.ContentType)
+			existing = route.Response[slot]
+		}
 		switch {
 		case existing == nil:
 			route.Response[slot] = resp
@@ -1333,6 +1648,13 @@ func (e *Extractor) pairAndFillResponses(route *RouteInfo, candidates []response
 	}
 }
 
+// negotiatedSlot is the route.Response slot of a status written in a media
+// type other than its first one's: "200 application/xml". buildResponses
+// renders it as another media type of the status.
+func negotiatedSlot(status int, contentType string) string {
+	return strconv.Itoa(status) + " " + contentType
+}
+
 // handlerCallDepths returns the call-graph distance (in hops) from the
 // route's handler function to every function reachable from it, via a BFS
 // over meta.Callers. Used to rank undetermined-status response fragments by
@@ -1497,14 +1819,10 @@ func (e *Extractor) extractRequestFromNode(node TrackerNodeInterface, route *Rou
 	}
 	edge := node.GetEdge()
 	idx, ok := e.reqMatcherByEdge[edge]
-	if !ok {
-		idx = -1
-		for i, matcher := range e.requestMatchers {
-			if matcher.MatchNode(node) {
-				idx = int16(i)
-				break
-			}
-		}
+	if ok {
+		e.matcherStats.Memoized++
+	} else {
+		idx = e.firstMatcher(e.requestIndex, node, func(i int16) bool { return e.requestMatchers[i].MatchNode(node) })
 		if e.reqMatcherByEdge == nil {
 			e.reqMatcherByEdge = map[*metadata.CallGraphEdge]int16{}
 		}
@@ -1519,21 +1837,18 @@ func (e *Extractor) extractRequestFromNode(node TrackerNodeInterface, route *Rou
 // extractParamsFromNode extracts parameter information from a node. Most
 // patterns yield at most one parameter (returned as a single-element slice),
 // but map-key patterns (gorilla/mux's `Vars(r)["id"]`) can yield several,
-// one per indexed key that matches a path placeholder.
+// one per indexed key that matches a path placeholder, and struct binders one
+// per bound field.
 func (e *Extractor) extractParamsFromNode(node TrackerNodeInterface, route *RouteInfo) []Parameter {
 	if node == nil || node.GetEdge() == nil {
 		return nil
 	}
 	edge := node.GetEdge()
 	idx, ok := e.paramMatcherByEdge[edge]
-	if !ok {
-		idx = -1
-		for i, matcher := range e.paramMatchers {
-			if matcher.MatchNode(node) {
-				idx = int16(i)
-				break
-			}
-		}
+	if ok {
+		e.matcherStats.Memoized++
+	} else {
+		idx = e.firstMatcher(e.paramIndex, node, func(i int16) bool { return e.paramMatchers[i].MatchNode(node) })
 		if e.paramMatcherByEdge == nil {
 			e.paramMatcherByEdge = map[*metadata.CallGraphEdge]int16{}
 		}
@@ -1552,6 +1867,10 @@ func (e *Extractor) extractParamsFromNode(node TrackerNodeInterface, route *Rout
 		if impl, ok := matcher.(*ParamPatternMatcherImpl); ok && impl.pattern.NameFromMapKey {
 			return nil
 		}
+		// Struct binders (gin's ShouldBindQuery) yield one parameter per field.
+		if impl, ok := matcher.(*ParamPatternMatcherImpl); ok && impl.pattern.StructTag != "" {
+			return impl.ExtractStructParams(node, route)
+		}
 		if param := matcher.ExtractParam(node, route); param != nil {
 			return []Parameter{*param}
 		}
@@ -1639,6 +1958,11 @@ type PathParamMismatch struct {
 	Path    string // OpenAPI path (regex constraints stripped)
 	Handler string // handler function (package-qualified)
 	Key     string // key read in code, e.g. mux.Vars(r)["userId"]
+	// Pos is where the key is read ("file:line:col"), or the handler's
+	// position when the read's is unknown.
+	Pos string
+	// Suggestion is the path placeholder the key most likely meant, if any.
+	Suggestion string
 }
 
 // PathParamMismatches returns the map-key path-variable diagnostics gathered
@@ -1665,8 +1989,9 @@ func (e *Extractor) recordPathVarKeyMismatches(route *RouteInfo) {
 	if len(keys) == 0 {
 		return
 	}
+	placeholderNames := pathPlaceholders(route.Path)
 	placeholders := make(map[string]bool)
-	for _, n := range pathPlaceholders(route.Path) {
+	for _, n := range placeholderNames {
 		placeholders[n] = true
 	}
 	// Sorted iteration keeps the diagnostics list deterministic.
@@ -1689,10 +2014,12 @@ func (e *Extractor) recordPathVarKeyMismatches(route *RouteInfo) {
 		}
 		e.pathParamMismatchSet[dedup] = struct{}{}
 		e.pathParamMismatches = append(e.pathParamMismatches, PathParamMismatch{
-			Method:  route.Method,
-			Path:    openAPIPath,
-			Handler: route.Function,
-			Key:     key,
+			Method:     route.Method,
+			Path:       openAPIPath,
+			Handler:    route.Function,
+			Key:        key,
+			Pos:        keys[key],
+			Suggestion: diag.Suggest(key, placeholderNames),
 		})
 	}
 }
@@ -1708,11 +2035,12 @@ func (e *Extractor) mapKeyAccessor() *ParamPattern {
 	return nil
 }
 
-// recoverAccessorKeys returns the set of literal keys the route's handler reads
-// through the map-key accessor (direct `vars["id"]` on an accessor-derived
+// recoverAccessorKeys returns the literal keys the route's handler reads
+// through the map-key accessor, each with the earliest position it is read at
+// (the handler's own position when the read carries none) (direct `vars["id"]` on an accessor-derived
 // variable, or inline `mux.Vars(r)["id"]`). Dynamic keys and keys passed into
 // helpers are not recovered — the diagnostic errs toward no false positives.
-func (e *Extractor) recoverAccessorKeys(route *RouteInfo, accessor ParamPattern) map[string]struct{} {
+func (e *Extractor) recoverAccessorKeys(route *RouteInfo, accessor ParamPattern) map[string]string {
 	meta := route.Metadata
 	bareFunc := route.Function
 	if route.Package != "" {
@@ -1742,19 +2070,29 @@ func (e *Extractor) recoverAccessorKeys(route *RouteInfo, accessor ParamPattern)
 		}
 	}
 
-	keys := make(map[string]struct{})
+	keys := make(map[string]string)
 	for _, asgns := range fn.AssignmentMap {
 		for i := range asgns {
 			collectAccessorKeys(&asgns[i].Value, accessorVars, callRe, recvRe, keys)
 		}
 	}
+	if meta.StringPool != nil {
+		if fnPos := meta.StringPool.GetString(fn.Position); diag.ParsePosition(fnPos).Line > 0 {
+			for k, pos := range keys {
+				if pos == "" {
+					keys[k] = fnPos
+				}
+			}
+		}
+	}
 	return keys
 }
 
 // collectAccessorKeys walks an expression tree, recording the literal key of any
 // `X["key"]` index where X is an accessor-derived variable or an inline accessor
-// call. Recurses so nested expressions (`"John " + vars["id"]`) are covered.
-func collectAccessorKeys(arg *metadata.CallArgument, accessorVars map[string]bool, callRe, recvRe *regexp.Regexp, out map[string]struct{}) {
+// call, with its position (the earliest one for a key read more than once).
+// Recurses so nested expressions (`"John " + vars["id"]`) are covered.
+func collectAccessorKeys(arg *metadata.CallArgument, accessorVars map[string]bool, callRe, recvRe *regexp.Regexp, out map[string]string) {
 	if arg == nil {
 		return
 	}
@@ -1768,7 +2106,10 @@ func collectAccessorKeys(arg *metadata.CallArgument, accessorVars map[string]boo
 		}
 		if derived {
 			if key := strings.Trim(arg.Fun.GetValue(), "\"`"); key != "" {
-				out[key] = struct{}{}
+				pos := arg.GetPosition()
+				if prev, seen := out[key]; !seen || (pos != "" && (prev == "" || positionBefore(pos, prev))) {
+					out[key] = pos
+				}
 			}
 		}
 	}
@@ -1780,6 +2121,18 @@ func collectAccessorKeys(arg *metadata.CallArgument, accessorVars map[string]boo
 	}
 }
 
+// positionBefore reports whether "file:line:col" position a precedes b.
+func positionBefore(a, b string) bool {
+	pa, pb := diag.ParsePosition(a), diag.ParsePosition(b)
+	if pa.File != pb.File {
+		return pa.File < pb.File
+	}
+	if pa.Line != pb.Line {
+		return pa.Line < pb.Line
+	}
+	return pa.Column < pb.Column
+}
+
 // isAccessorCall reports whether a call-argument is a call to the accessor
 // (e.g. `mux.Vars(r)`), matching the call name and receiver/package regexes.
 func isAccessorCall(x *metadata.CallArgument, callRe, recvRe *regexp.Regexp) bool {
@@ -2211,6 +2564,18 @@ func (r *ResponsePatternMatcherImpl) GetPriority() int {
 	return priority
 }
 
+// callContentType returns the pattern's ContentTypeByCall entry for the
+// called function's name, matched case-insensitively as the renderer
+// catch-all matches it.
+func (r *ResponsePatternMatcherImpl) callContentType(callName string) string {
+	for name, contentType := range r.pattern.ContentTypeByCall {
+		if strings.EqualFold(name, callName) {
+			return contentType
+		}
+	}
+	return ""
+}
+
 // ExtractResponse extracts response information from a matched node.
 //
 // Returns a slice to support conditional status codes (issue #39): when the
@@ -2244,17 +2609,20 @@ func (r *ResponsePatternMatcherImpl) ExtractResponse(node TrackerNodeInterface,
 		}
 	}
 
+	edge := node.GetEdge()
 	contentType := r.cfg.Defaults.ResponseContentType
 	if r.pattern.DefaultContentType != "" {
 		contentType = r.pattern.DefaultContentType
 	}
+	if ct := r.callContentType(r.contextProvider.GetString(edge.Callee.Name)); ct != "" {
+		contentType = ct
+	}
 
 	respInfo := &ResponseInfo{
 		StatusCode:  leastStatusCode - 1,
 		ContentType: contentType,
 	}
 
-	edge := node.GetEdge()
 	if r.pattern.StatusFromArg && len(edge.Args) > r.pattern.StatusArgIndex {
 		statusArg := edge.Args[r.pattern.StatusArgIndex]
 		statusStr := r.contextProvider.GetArgumentInfo(statusArg)
@@ -2443,6 +2811,13 @@ func (r *ResponsePatternMatcherImpl) ExtractResponse(node TrackerNodeInterface,
 		}
 
 		respInfo.Schema = schema
+	} else if r.pattern.BodyType != "" {
+		bodyType := namedGoType(route.Metadata, route.Package, r.pattern.BodyType)
+		if bodyType == "" {
+			bodyType = r.pattern.BodyType
+		}
+		respInfo.BodyType = bodyType
+		respInfo.Schema, _ = mapGoTypeToOpenAPISchema(route.UsedTypes, bodyType, route.Metadata, r.cfg, nil)
 	}
 
 	// Conditional status codes (issue #39): if the status arg is a local
@@ -2935,6 +3310,59 @@ func (r *ResponsePatternMatcherImpl) statusCodeOfValue(value *metadata.CallArgum
 	return r.schemaMapper.MapStatusCode(impl.GetArgumentInfo(value))
 }
 
+// strconvResultTypes are the Go types the strconv parsers produce from a
+// parameter's string value.
+var strconvResultTypes = map[string]string{
+	"Atoi":       "int",
+	"ParseInt":   "int64",
+	"ParseUint":  "uint64",
+	"ParseFloat": "float64",
+	"ParseBool":  "bool",
+}
+
+// convertedParamType returns the Go type a parameter value read by edge is
+// parsed into by strconv in the same function, either passed directly
+// (`strconv.Atoi(q.Get("page"))`) or through the variable it was assigned
+// to (`s := q.Get("page")` … `strconv.Atoi(s)`). Returns "" when the value
+// is not converted or converted more than one way.
+func convertedParamType(meta *metadata.Metadata, edge *metadata.CallGraphEdge) string {
+	if meta == nil || meta.StringPool == nil || edge == nil {
+		return ""
+	}
+	varName := edge.CalleeRecvVarName
+	found := ""
+	for _, conv := range meta.Callers[edge.Caller.BaseID()] {
+		if getString(meta, conv.Callee.Pkg) != "strconv" || len(conv.Args) == 0 {
+			continue
+		}
+		goType, ok := strconvResultTypes[getString(meta, conv.Callee.Name)]
+		if !ok {
+			continue
+		}
+		arg := conv.Args[0]
+		switch arg.GetKind() {
+		case metadata.KindCall:
+			// The pointer may predate the call graph's last reallocation,
+			// so the call is matched by its position.
+			if arg.Edge == nil || arg.Edge.Callee.Position != edge.Callee.Position ||
+				arg.Edge.Callee.Name != edge.Callee.Name {
+				continue
+			}
+		case metadata.KindIdent:
+			if varName == "" || varName == "_" || arg.GetName() != varName {
+				continue
+			}
+		default:
+			continue
+		}
+		if found != "" && found != goType {
+			return ""
+		}
+		found = goType
+	}
+	return found
+}
+
 // resolveTypeOrigin traces the origin of a type through assignments and type parameters
 func (r *ResponsePatternMatcherImpl) resolveTypeOrigin(arg *metadata.CallArgument, node TrackerNodeInterface, originalType string) string {
 	// NEW: If the argument has resolved type information, use it
@@ -2949,6 +3377,14 @@ func (r *ResponsePatternMatcherImpl) resolveTypeOrigin(arg *metadata.CallArgumen
 		}
 	}
 
+	// A local of a bare type-parameter type (`response TResponse` inside a
+	// generic handler factory, handed to a response helper) carries no generic
+	// marker; the instantiation's type-parameter map still names the concrete
+	// type.
+	if concrete := traceGenericOrigin(node, arg.GetType()); concrete != "" {
+		return concrete
+	}
+
 	// Selector expression like `api.Message` — resolve the field's declared
 	// type via metadata so the schema mapper doesn't $ref a nonexistent
 	// "APIError.Message" pseudo-type.
@@ -3232,10 +3668,16 @@ func (p *ParamPatternMatcherImpl) ExtractParam(node TrackerNodeInterface, route
 	}
 
 	edge := node.GetEdge()
-	if len(edge.Args) > p.pattern.ParamArgIndex {
+	if p.pattern.ParamArgIndex >= 0 && len(edge.Args) > p.pattern.ParamArgIndex {
 		param.Name = p.contextProvider.GetArgumentInfo(edge.Args[p.pattern.ParamArgIndex])
 	}
 
+	// An uploaded file (r.FormFile, c.FormFile) is a binary multipart part,
+	// whatever Go type the accessor returns.
+	if p.pattern.ParamIn == paramInFile {
+		param.Schema = &Schema{Type: "string", Format: "binary"}
+	}
+
 	if p.pattern.TypeFromArg && len(edge.Args) > p.pattern.TypeArgIndex {
 		arg := edge.Args[p.pattern.TypeArgIndex]
 		paramType := p.contextProvider.GetArgumentInfo(arg)
@@ -3258,6 +3700,16 @@ func (p *ParamPatternMatcherImpl) ExtractParam(node TrackerNodeInterface, route
 		param.Schema = schema
 	}
 
+	if param.Schema == nil {
+		goType := p.pattern.ParamType
+		if goType == "" {
+			goType = convertedParamType(route.Metadata, edge)
+		}
+		if goType != "" {
+			param.Schema, _ = mapGoTypeToOpenAPISchema(route.UsedTypes, goType, route.Metadata, p.cfg, nil)
+		}
+	}
+
 	// Ensure all parameters have a schema - default to string if none specified
 	if param.Schema == nil {
 		param.Schema = &Schema{Type: "string"}
@@ -3341,6 +3793,12 @@ func (o *OverrideApplierImpl) ApplyOverrides(routeInfo *RouteInfo) {
 			if len(override.Tags) > 0 {
 				routeInfo.Tags = override.Tags
 			}
+			if override.Timeout != "" {
+				routeInfo.Timeout = override.Timeout
+			}
+			if override.Retries > 0 {
+				routeInfo.Retries = override.Retries
+			}
 		}
 	}
 }
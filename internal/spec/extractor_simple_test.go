@@ -139,6 +139,24 @@ func TestExtractor_ApplyOverrides(t *testing.T) {
 	}
 }
 
+func TestOverrideApplier_GatewayHints(t *testing.T) {
+	applier := NewOverrideApplier(&APISpecConfig{Overrides: []Override{
+		{FunctionName: "pkg.GetUser", Timeout: "30s", Retries: 2},
+	}})
+
+	route := &RouteInfo{Function: "pkg.GetUser"}
+	applier.ApplyOverrides(route)
+	if route.Timeout != "30s" || route.Retries != 2 {
+		t.Errorf("gateway hints not applied: timeout=%q retries=%d", route.Timeout, route.Retries)
+	}
+
+	other := &RouteInfo{Function: "pkg.ListUsers"}
+	applier.ApplyOverrides(other)
+	if other.Timeout != "" || other.Retries != 0 {
+		t.Errorf("hints leaked onto unmatched route: timeout=%q retries=%d", other.Timeout, other.Retries)
+	}
+}
+
 func TestExtractor_IsValid_Simple(t *testing.T) {
 	// Create test metadata and extractor
 	stringPool := metadata.NewStringPool()
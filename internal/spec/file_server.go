@@ -0,0 +1,60 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+// fileServerParam names the trailing wildcard a file-server route is
+// documented with, the file path below the mount.
+const fileServerParam = "filepath"
+
+// applyFileServer documents a route registered by a file-server call
+// (RoutePattern.FileServer, e.g. gin's StaticFS): there is no handler in the
+// module to trace, so the route is named after the registration call and
+// answers with the bytes of the file at the path below the mount. The
+// Accept-Ranges header makes the mapper add the Range parameter and 206
+// response the file server honours.
+func applyFileServer(route *RouteInfo, callName string) {
+	if route.Handler == "" {
+		route.Handler = callName
+		route.Function = callName
+	}
+	if route.Response == nil {
+		route.Response = map[string]*ResponseInfo{}
+	}
+	status := strconv.Itoa(http.StatusOK)
+	if _, ok := route.Response[status]; !ok {
+		route.Response[status] = &ResponseInfo{
+			StatusCode:  http.StatusOK,
+			ContentType: "application/octet-stream",
+			Schema:      &Schema{Type: "string", Format: "binary"},
+		}
+	}
+	if !slices.ContainsFunc(route.Params, func(p Parameter) bool {
+		return p.In == "path" && p.Name == fileServerParam
+	}) {
+		route.Params = append(route.Params, Parameter{
+			Name:     fileServerParam,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	addResponseHeader(route, acceptRangesHeader)
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "testing"
+
+func TestApplyFileServer(t *testing.T) {
+	route := &RouteInfo{Path: "/assets/{filepath}", Method: "GET"}
+	applyFileServer(route, "StaticFS")
+	applyFileServer(route, "StaticFS")
+
+	if route.Handler != "StaticFS" || route.Function != "StaticFS" {
+		t.Errorf("handler = %q, function = %q; want the registration call", route.Handler, route.Function)
+	}
+	resp := route.Response["200"]
+	if resp == nil || resp.ContentType != "application/octet-stream" || resp.Schema == nil || resp.Schema.Format != "binary" {
+		t.Errorf("200 response = %+v, want a binary octet-stream body", resp)
+	}
+	if len(route.Params) != 1 || route.Params[0].Name != fileServerParam || route.Params[0].In != "path" {
+		t.Errorf("params = %+v, want the single filepath path parameter", route.Params)
+	}
+	if len(route.ResponseHeaders) != 1 || route.ResponseHeaders[0] != acceptRangesHeader {
+		t.Errorf("response headers = %v, want [%s]", route.ResponseHeaders, acceptRangesHeader)
+	}
+
+	// A handler the pattern already resolved keeps its name.
+	named := &RouteInfo{Handler: "pkg.serve", Function: "serve"}
+	applyFileServer(named, "StaticFS")
+	if named.Handler != "pkg.serve" || named.Function != "serve" {
+		t.Errorf("handler = %q, function = %q; want the resolved handler kept", named.Handler, named.Function)
+	}
+}
@@ -120,4 +120,49 @@ func TestResolveFormParams(t *testing.T) {
 			t.Errorf("required = %v, want [token]", schema.Required)
 		}
 	})
+
+	t.Run("file param makes the body multipart", func(t *testing.T) {
+		file := Parameter{Name: "avatar", In: "file", Schema: &Schema{Type: "string", Format: "binary"}}
+		params, body := resolveFormParams("POST", []Parameter{file, formParam("title")}, false)
+		if body == nil {
+			t.Fatal("expected a request body")
+		}
+		media, ok := body.Content["multipart/form-data"]
+		if !ok {
+			t.Fatalf("missing multipart/form-data media type; have %v", body.Content)
+		}
+		if p := media.Schema.Properties["avatar"]; p == nil || p.Format != "binary" {
+			t.Errorf("avatar: got %+v, want a binary string", p)
+		}
+		if _, ok := media.Schema.Properties["title"]; !ok {
+			t.Error("title form field should sit beside the file")
+		}
+		if len(params) != 0 {
+			t.Errorf("file and form params should be consumed; got %+v", params)
+		}
+	})
+
+	t.Run("multipart marker alone gives an untyped multipart body", func(t *testing.T) {
+		params, body := resolveFormParams("POST", []Parameter{{In: "multipart"}, {Name: "id", In: "path"}}, false)
+		if body == nil {
+			t.Fatal("expected a request body")
+		}
+		if _, ok := body.Content["multipart/form-data"]; !ok {
+			t.Errorf("want multipart/form-data; have %v", body.Content)
+		}
+		if len(params) != 1 || params[0].Name != "id" {
+			t.Errorf("marker must be dropped, others kept; got %+v", params)
+		}
+	})
+
+	t.Run("file params are dropped without a body", func(t *testing.T) {
+		file := Parameter{Name: "avatar", In: "file"}
+		params, body := resolveFormParams("GET", []Parameter{file, formParam("q")}, false)
+		if body != nil {
+			t.Errorf("GET should not synthesize a body; got %+v", body)
+		}
+		if len(params) != 1 || params[0].Name != "q" || params[0].In != "query" {
+			t.Errorf("got %+v, want only q in:query", params)
+		}
+	})
 }
@@ -0,0 +1,102 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// funcVariable returns the function a local variable holds when arg names
+// one, and nil otherwise:
+//
+//	accountRoutes := func(r chi.Router) { r.Get("/", list) }
+//	r.Route("/accounts", accountRoutes)
+//	var widgets http.HandlerFunc = makeWidgetsHandler(deps)
+//	var status http.HandlerFunc = showStatus
+//
+// The value is a func literal, a named function or method value, or a call
+// to a handler factory (a function returning a func literal). Calls are
+// recorded against the function itself, not the variable, so both tracker
+// engines expand such an argument as the value it holds and the route
+// matcher names the route after it; without it the sub-router's routes
+// vanish and the handler body goes unread. The variable's last assignment in
+// the calling function (or, for a call inside a closure, the function
+// declaring it) is the one in effect.
+func funcVariable(edge *metadata.CallGraphEdge, arg *metadata.CallArgument, meta *metadata.Metadata) *metadata.CallArgument {
+	if edge == nil || arg == nil || meta == nil || arg.GetKind() != metadata.KindIdent {
+		return nil
+	}
+	name := arg.GetName()
+	assigns := lookupAssignments(edge, name, meta)
+	if len(assigns) == 0 && edge.ParentFunction != nil {
+		outer := metadata.CallGraphEdge{Caller: *edge.ParentFunction}
+		assigns = lookupAssignments(&outer, name, meta)
+	}
+	if len(assigns) == 0 {
+		return nil
+	}
+	last := assigns[len(assigns)-1]
+	// A variable of the same name in another scope has another type.
+	lhs := last.Lhs
+	if lhs.Meta == nil {
+		lhs.Meta = meta
+	}
+	if lhsType := lhs.GetType(); lhsType != "" && lhsType != arg.GetType() {
+		return nil
+	}
+	value := last.Value
+	if value.Meta == nil {
+		value.Meta = meta
+	}
+	switch value.GetKind() {
+	case metadata.KindFuncLit:
+	case metadata.KindIdent, metadata.KindSelector:
+		if !strings.HasPrefix(value.GetType(), "func(") {
+			return nil
+		}
+	case metadata.KindCall:
+		if !isHandlerFactoryCall(meta, &value) {
+			return nil
+		}
+		// Link the call to its edge, as an inline factory call argument is.
+		if value.Edge == nil {
+			value.Edge = callEdgeOf(meta, edge, &value)
+		}
+	default:
+		return nil
+	}
+	return &value
+}
+
+// isHandlerFactoryCall reports whether call calls a function whose result is
+// a func literal.
+func isHandlerFactoryCall(meta *metadata.Metadata, call *metadata.CallArgument) bool {
+	fn := calledFunction(meta, call)
+	return fn != nil && len(fn.ReturnVars) > 0 && fn.ReturnVars[0].GetKind() == metadata.KindFuncLit
+}
+
+// callEdgeOf returns the edge recorded for call in the function edge's caller
+// runs, or nil.
+func callEdgeOf(meta *metadata.Metadata, edge *metadata.CallGraphEdge, call *metadata.CallArgument) *metadata.CallGraphEdge {
+	id := call.ID()
+	for _, e := range meta.Callers[edge.Caller.BaseID()] {
+		if e.Callee.InstanceID() == id {
+			return e
+		}
+	}
+	return nil
+}
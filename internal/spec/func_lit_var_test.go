@@ -0,0 +1,97 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+func TestFuncVariable(t *testing.T) {
+	meta := newTestMeta()
+	sp := meta.StringPool
+	const fnType = "func(r github.com/go-chi/chi/v5.Router)"
+
+	lit := metadata.NewCallArgument(meta)
+	lit.SetKind(metadata.KindFuncLit)
+	lit.SetName("FuncLit:main.go:12:19")
+	mw := mkIdent(meta, "audit", "")
+	const handlerType = "net/http.HandlerFunc"
+	show := mkIdent(meta, "showStatus", "func(w net/http.ResponseWriter, r *net/http.Request)")
+	show.SetPkg("app")
+	call := func(name string) *metadata.CallArgument {
+		c := metadata.NewCallArgument(meta)
+		c.SetKind(metadata.KindCall)
+		c.Fun = mkIdent(meta, name, "")
+		c.Fun.SetPkg("app")
+		return c
+	}
+	factory := &metadata.Function{Name: sp.Get("makeWidgets"), Pkg: sp.Get("app"), ReturnVars: []metadata.CallArgument{*lit}}
+	store := &metadata.Function{Name: sp.Get("newStore"), Pkg: sp.Get("app"), ReturnVars: []metadata.CallArgument{*mkIdent(meta, "s", "")}}
+
+	fn := &metadata.Function{
+		Name: sp.Get("main"),
+		Pkg:  sp.Get("app"),
+		AssignmentMap: map[string][]metadata.Assignment{
+			"routes":  {{Value: *lit, Lhs: *mkIdent(meta, "routes", fnType)}},
+			"mw":      {{Value: *mw}},
+			"status":  {{Value: *show, Lhs: *mkIdent(meta, "status", handlerType)}},
+			"widgets": {{Value: *call("makeWidgets"), Lhs: *mkIdent(meta, "widgets", handlerType)}},
+			"store":   {{Value: *call("newStore"), Lhs: *mkIdent(meta, "store", handlerType)}},
+		},
+	}
+	meta.Packages = map[string]*metadata.Package{
+		"app": {Files: map[string]*metadata.File{
+			"main.go": {Functions: map[string]*metadata.Function{"main": fn, "makeWidgets": factory, "newStore": store}},
+		}},
+	}
+	mainCall := metadata.Call{Meta: meta, Name: sp.Get("main"), Pkg: sp.Get("app"), RecvType: -1}
+	edge := &metadata.CallGraphEdge{Caller: mainCall}
+
+	got := funcVariable(edge, mkIdent(meta, "routes", fnType), meta)
+	if got == nil || got.GetKind() != metadata.KindFuncLit || got.GetName() != "FuncLit:main.go:12:19" {
+		t.Fatalf("routes: got %+v, want the assigned func literal", got)
+	}
+
+	// A call inside a closure resolves through the function declaring it.
+	inner := &metadata.CallGraphEdge{
+		Caller:         metadata.Call{Meta: meta, Name: sp.Get("FuncLit:main.go:20:5"), Pkg: sp.Get("app"), RecvType: -1},
+		ParentFunction: &mainCall,
+	}
+	if got := funcVariable(inner, mkIdent(meta, "routes", fnType), meta); got == nil {
+		t.Error("routes inside a closure: want the func literal from the enclosing function")
+	}
+
+	// A named function and a handler factory's closure held in a variable of
+	// a named func type.
+	if got := funcVariable(edge, mkIdent(meta, "status", handlerType), meta); got == nil || got.GetName() != "showStatus" {
+		t.Errorf("status: got %+v, want the showStatus function value", got)
+	}
+	if got := funcVariable(edge, mkIdent(meta, "widgets", handlerType), meta); got == nil || got.GetKind() != metadata.KindCall {
+		t.Errorf("widgets: got %+v, want the makeWidgets factory call", got)
+	}
+
+	for name, arg := range map[string]*metadata.CallArgument{
+		"not a function value": mkIdent(meta, "mw", "func(http.Handler) http.Handler"),
+		"another scope's var":  mkIdent(meta, "routes", "string"),
+		"not a factory":        mkIdent(meta, "store", handlerType),
+		"no assignment":        mkIdent(meta, "orderRoutes", fnType),
+	} {
+		if got := funcVariable(edge, arg, meta); got != nil {
+			t.Errorf("%s: got %+v, want nil", name, got)
+		}
+	}
+}
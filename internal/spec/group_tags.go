@@ -0,0 +1,101 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"slices"
+	"strings"
+)
+
+// versionSegment matches API version path segments (v1, v2.1), which name
+// no resource and so make poor tags.
+var versionSegment = mustCachedRegex(`^[vV]\d+(\.\d+)*$`)
+
+// groupTags returns the tags of the operations registered under the group at
+// mountPath: the configured groupTags entry for its longest matching prefix,
+// else the inferred name (see inferGroupTag), else none.
+func (e *Extractor) groupTags(mountPath string) []string {
+	path := convertPathToOpenAPI(mountPath)
+	name := inferGroupTag(path)
+	if gt, ok := e.cfg.groupTag(path); ok {
+		name = gt.tagName()
+	}
+	if name == "" {
+		return nil
+	}
+	return []string{name}
+}
+
+// inferGroupTag names a group after the last static segment of its path,
+// skipping parameters, "api" and version segments: /api/v1/users and
+// /orgs/{org}/users both give "users". Returns "" when nothing is left.
+func inferGroupTag(path string) string {
+	segments := strings.Split(path, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		s := segments[i]
+		if s == "" || strings.HasPrefix(s, "{") || strings.EqualFold(s, "api") || versionSegment.MatchString(s) {
+			continue
+		}
+		return s
+	}
+	return ""
+}
+
+// groupTag returns the groupTags entry whose prefix is the longest one
+// containing path.
+func (c *APISpecConfig) groupTag(path string) (GroupTag, bool) {
+	if c == nil {
+		return GroupTag{}, false
+	}
+	var best GroupTag
+	bestLen := -1
+	for _, gt := range c.GroupTags {
+		prefix := strings.TrimSuffix(convertPathToOpenAPI(gt.Prefix), "/")
+		if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			continue
+		}
+		if len(prefix) > bestLen {
+			best, bestLen = gt, len(prefix)
+		}
+	}
+	return best, bestLen >= 0
+}
+
+func (gt GroupTag) tagName() string {
+	if gt.Name != "" {
+		return gt.Name
+	}
+	return inferGroupTag(convertPathToOpenAPI(gt.Prefix))
+}
+
+// groupTagDefinitions returns tags followed by a definition for each described
+// groupTags entry that an operation uses and tags does not already define.
+func groupTagDefinitions(tags []Tag, groupTags []GroupTag, routes []*RouteInfo) []Tag {
+	tags = slices.Clip(tags)
+	used := map[string]bool{}
+	for _, r := range routes {
+		for _, t := range r.Tags {
+			used[t] = true
+		}
+	}
+	for _, gt := range groupTags {
+		name := gt.tagName()
+		if gt.Description == "" || !used[name] || slices.ContainsFunc(tags, func(t Tag) bool { return t.Name == name }) {
+			continue
+		}
+		tags = append(tags, Tag{Name: name, Description: gt.Description})
+	}
+	return tags
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "testing"
+
+func TestInferGroupTag(t *testing.T) {
+	cases := map[string]string{
+		"/users":              "users",
+		"/api/v1/users":       "users",
+		"/orgs/{org}/members": "members",
+		"/users/{id}":         "users",
+		"/API/V2.1/":          "",
+		"/api/v1":             "",
+		"":                    "",
+		"/billing/invoices/":  "invoices",
+	}
+	for path, want := range cases {
+		if got := inferGroupTag(path); got != want {
+			t.Errorf("inferGroupTag(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestGroupTagLongestPrefix(t *testing.T) {
+	cfg := &APISpecConfig{GroupTags: []GroupTag{
+		{Prefix: "/", Name: "root"},
+		{Prefix: "/orgs/:org", Name: "orgs"},
+		{Prefix: "/orgs/{org}/members", Name: "members"},
+	}}
+	cases := map[string]string{
+		"/health":                  "root",
+		"/orgs/{org}":              "orgs",
+		"/orgs/{org}/teams":        "orgs",
+		"/orgs/{org}/members/{id}": "members",
+		"/orgsx":                   "root",
+	}
+	for path, want := range cases {
+		if gt, ok := cfg.groupTag(path); !ok || gt.Name != want {
+			t.Errorf("groupTag(%q) = %+v, %v; want %s", path, gt, ok, want)
+		}
+	}
+	if _, ok := (*APISpecConfig)(nil).groupTag("/x"); ok {
+		t.Error("nil config matched")
+	}
+}
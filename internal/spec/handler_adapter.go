@@ -0,0 +1,81 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// maxAdapterDepth bounds how many nested adapter calls are seen through.
+const maxAdapterDepth = 8
+
+// unwrapHandlerAdapters returns the handler arg adapts when it is a call to
+// one of adapters (FrameworkConfig.HandlerAdapters), seeing through nested
+// adapters, and arg itself otherwise. Both tracker engines and the route
+// matcher read handler arguments through it, so the route is named after, and
+// expanded into, the same handler.
+func unwrapHandlerAdapters(arg *metadata.CallArgument, adapters []HandlerAdapterPattern) *metadata.CallArgument {
+	if len(adapters) == 0 {
+		return arg
+	}
+	for range maxAdapterDepth {
+		inner := adaptedHandler(arg, adapters)
+		if inner == nil {
+			return arg
+		}
+		arg = inner
+	}
+	return arg
+}
+
+// adaptedHandler returns the argument of the adapter call or conversion arg
+// holding the adapted handler, or nil when arg is not one.
+func adaptedHandler(arg *metadata.CallArgument, adapters []HandlerAdapterPattern) *metadata.CallArgument {
+	if arg == nil || arg.Fun == nil {
+		return nil
+	}
+	// http.HandlerFunc(fn) is recorded as a conversion, not a call.
+	if kind := arg.GetKind(); kind != metadata.KindCall && kind != metadata.KindTypeConversion {
+		return nil
+	}
+	fun := arg.Fun
+	// The package of a function, or the receiver type of a method.
+	owner := fun.GetPkg()
+	if fun.GetKind() == metadata.KindSelector && fun.Sel != nil {
+		owner = fun.Sel.GetPkg()
+		if fun.X != nil && fun.X.Type != -1 {
+			owner = fun.X.GetType()
+		}
+		fun = fun.Sel
+	}
+	name := fun.GetName()
+	for _, a := range adapters {
+		if a.HandlerArgIndex < 0 || a.HandlerArgIndex >= len(arg.Args) {
+			continue
+		}
+		if !adapterRegexMatches(a.CallRegex, name) || (a.RecvTypeRegex != "" && !adapterRegexMatches(a.RecvTypeRegex, owner)) {
+			continue
+		}
+		return arg.Args[a.HandlerArgIndex]
+	}
+	return nil
+}
+
+// adapterRegexMatches reports whether pattern matches s; an invalid pattern
+// matches nothing.
+func adapterRegexMatches(pattern, s string) bool {
+	re, err := cachedRegex(pattern)
+	return err == nil && re.MatchString(s)
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// adapterCall builds the argument pkg.name(inner), a call or a conversion.
+func adapterCall(meta *metadata.Metadata, kind, pkg, name string, inner *metadata.CallArgument) *metadata.CallArgument {
+	x := metadata.NewCallArgument(meta)
+	x.SetKind(metadata.KindIdent)
+	x.SetName(pkg)
+	sel := metadata.NewCallArgument(meta)
+	sel.SetKind(metadata.KindIdent)
+	sel.SetName(name)
+	sel.SetPkg(pkg)
+	fun := metadata.NewCallArgument(meta)
+	fun.SetKind(metadata.KindSelector)
+	fun.X, fun.Sel = x, sel
+
+	call := metadata.NewCallArgument(meta)
+	call.SetKind(kind)
+	call.Fun = fun
+	call.Args = []*metadata.CallArgument{inner}
+	return call
+}
+
+func TestUnwrapHandlerAdapters(t *testing.T) {
+	meta := &metadata.Metadata{StringPool: metadata.NewStringPool()}
+	adapters := DefaultEchoConfig().Framework.HandlerAdapters
+
+	fn := metadata.NewCallArgument(meta)
+	fn.SetKind(metadata.KindIdent)
+	fn.SetName("legacy")
+
+	conversion := adapterCall(meta, metadata.KindTypeConversion, "net/http", "HandlerFunc", fn)
+	wrapped := adapterCall(meta, metadata.KindCall, "github.com/labstack/echo/v4", "WrapHandler", conversion)
+	other := adapterCall(meta, metadata.KindCall, "example.com/auth", "WrapHandler", fn)
+
+	for _, tc := range []struct {
+		name     string
+		arg      *metadata.CallArgument
+		adapters []HandlerAdapterPattern
+		want     *metadata.CallArgument
+	}{
+		{"nested adapters", wrapped, adapters, fn},
+		{"conversion", conversion, adapters, fn},
+		{"plain handler", fn, adapters, fn},
+		{"other package declines", other, adapters, other},
+		{"no adapters", wrapped, nil, wrapped},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := unwrapHandlerAdapters(tc.arg, tc.adapters); got != tc.want {
+				t.Errorf("got %s, want %s", metadata.CallArgToString(got), metadata.CallArgToString(tc.want))
+			}
+		})
+	}
+}
@@ -578,3 +578,55 @@ func TestFindMethodByName(t *testing.T) {
 		t.Error("nil metadata must not resolve")
 	}
 }
+
+// TestHandlerDocLinks covers doc comment cleanup: directive lines are dropped,
+// Go doc links and bare URLs become markdown links in the description and
+// plain text in the summary, and brackets that are not links stay as written.
+func TestHandlerDocLinks(t *testing.T) {
+	meta := docMeta(t)
+	file := meta.Packages["app"].Files["app.go"]
+	file.Imports = map[int]int{
+		meta.StringPool.Get("example.com/app/models"): meta.StringPool.Get("example.com/app/models"),
+		meta.StringPool.Get("yaml"):                   meta.StringPool.Get("gopkg.in/yaml.v3"),
+	}
+	file.Functions["Linked"] = &metadata.Function{
+		Name: meta.StringPool.Get("Linked"),
+		Comments: meta.StringPool.Get("Linked returns a [models.User] by [Handler.Create].\n" +
+			"+build linux\n" +
+			"Encodes with [yaml.Marshal] per [the spec], see https://example.com/docs.\n" +
+			"Range [0, 10] and [note] stay; so does [a](https://a.io) and [Plain][1].\n" +
+			"go:generate mockgen\n" +
+			"\n" +
+			"\tcurl https://example.com/[Plain]\n" +
+			"\n" +
+			"[the spec]: https://spec.example.com\n" +
+			"nolint:errcheck"),
+	}
+
+	route := &RouteInfo{Metadata: meta, Package: "app", Function: "app.Linked"}
+	summary, desc := handlerDoc(route)
+	if want := "Linked returns a models.User by Handler.Create."; summary != want {
+		t.Errorf("summary: got %q, want %q", summary, want)
+	}
+	want := "Encodes with [yaml.Marshal](https://pkg.go.dev/gopkg.in/yaml.v3#Marshal) per [the spec](https://spec.example.com), see <https://example.com/docs>.\n" +
+		"Range [0, 10] and [note] stay; so does [a](https://a.io) and [Plain](https://pkg.go.dev/app#Plain)[1].\n" +
+		"\n" +
+		"\tcurl https://example.com/[Plain]"
+	if desc != want {
+		t.Errorf("description:\n got %q\nwant %q", desc, want)
+	}
+}
+
+func TestImportName(t *testing.T) {
+	for in, want := range map[string]string{
+		"net/http":                "http",
+		"gopkg.in/yaml.v3":        "yaml",
+		"github.com/jackc/pgx/v5": "pgx",
+		"github.com/acme/v2":      "acme",
+		"example.com/app/models":  "models",
+	} {
+		if got := importName(in); got != want {
+			t.Errorf("importName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -146,6 +146,14 @@ func handlerValueTypeOf(arg *metadata.CallArgument) (pkg, name string) {
 	if arg == nil {
 		return "", ""
 	}
+	// A literal value (echo.WrapHandler(MetricsHandler{})) is typed by its
+	// type expression, which names the type it declares.
+	if arg.GetKind() == metadata.KindCompositeLit {
+		if x := arg.X; x != nil && x.GetKind() == metadata.KindIdent && x.GetPkg() != "" {
+			return x.GetPkg(), x.GetName()
+		}
+		return "", ""
+	}
 	// A func signature has to be rejected up front: the type model has no
 	// function kind (a signature is "otherwise opaque" KindNamed), so TypeRef
 	// splits "func(w http.ResponseWriter, r *http.Request)" at its last dot and
@@ -180,15 +188,8 @@ func splitTypeKey(key string) (pkg, name string, ok bool) {
 // tree materializes nodes up front, so the matching edges are looked up here —
 // every edge whose CALLER is the resolved handler method, which is exactly what
 // the existing method-value branch does for a selector argument.
-func attachHandlerValueChildren(
-	tree *TrackerTree,
-	meta *metadata.Metadata,
-	argNode *TrackerNode,
-	arg *metadata.CallArgument,
-	visited map[string]int,
-	assignmentIndex *assigmentIndexMap,
-	limits metadata.TrackerLimits,
-) {
+func (b *trackerBuild) attachHandlerValueChildren(argNode *TrackerNode, arg *metadata.CallArgument, limits metadata.TrackerLimits) {
+	tree, meta := b.tree, b.meta
 	if tree == nil || argNode == nil || len(tree.handlerMethods) == 0 {
 		return
 	}
@@ -216,9 +217,7 @@ func attachHandlerValueChildren(
 				(e.Caller.RecvType != recvIdx && e.Caller.RecvType != starRecvIdx) {
 				continue
 			}
-			if child := NewTrackerNode(tree, meta, argNode.Key(), e.Callee.ID(), e, nil, visited, assignmentIndex, limits); child != nil {
-				argNode.AddChild(child)
-			}
+			b.node(argNode.Key(), e.Callee.ID(), e, nil, limits, argNode.AddChild)
 		}
 	}
 }
@@ -62,6 +62,15 @@ func TestHandlerValueTypeOf(t *testing.T) {
 	untyped.SetKind(metadata.KindIdent)
 	untyped.SetName("x")
 
+	// A literal value (MetricsHandler{}) is typed by its type expression.
+	typeExpr := metadata.NewCallArgument(meta)
+	typeExpr.SetKind(metadata.KindIdent)
+	typeExpr.SetName("MetricsHandler")
+	typeExpr.SetPkg("app")
+	literal := metadata.NewCallArgument(meta)
+	literal.SetKind(metadata.KindCompositeLit)
+	literal.X = typeExpr
+
 	for _, tc := range []struct {
 		name       string
 		arg        *metadata.CallArgument
@@ -70,6 +79,7 @@ func TestHandlerValueTypeOf(t *testing.T) {
 		{"named pointer type", named, "app", "H"},
 		{"func signature declines", fn, "", ""},
 		{"untyped declines", untyped, "", ""},
+		{"composite literal", literal, "app", "MetricsHandler"},
 		{"nil declines", nil, "", ""},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
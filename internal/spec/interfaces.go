@@ -65,6 +65,24 @@ type SecurityPatternMatcher interface {
 	ExtractMiddlewareFromEdge(edge *metadata.CallGraphEdge) []MiddlewareRef
 }
 
+// ProtocolPatternMatcher matches calls that upgrade a route to a streaming
+// protocol (websocket, server-sent events).
+type ProtocolPatternMatcher interface {
+	PatternMatcher
+
+	// Protocol returns the Protocol* value the matched call switches to.
+	Protocol() string
+}
+
+// ResponseHeaderPatternMatcher matches calls that set a response header.
+type ResponseHeaderPatternMatcher interface {
+	PatternMatcher
+
+	// HeaderName returns the header the matched call sets, or "" when it
+	// cannot be named or is not a response header.
+	HeaderName(node TrackerNodeInterface, meta *metadata.Metadata) string
+}
+
 // RequestPatternMatcher matches request body patterns
 type RequestPatternMatcher interface {
 	PatternMatcher
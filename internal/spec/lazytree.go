@@ -58,6 +58,16 @@ type LazyTree struct {
 	// handlerValueKeys and issue #204. Empty for func-handler frameworks.
 	handlerMethods []string
 
+	// handlerAdapters are the framework's handler adapters: an argument
+	// calling one expands as the handler it adapts (see
+	// unwrapHandlerAdapters).
+	handlerAdapters []HandlerAdapterPattern
+
+	// mountOrigins resolves a mount's router argument that reaches the mount
+	// through a literal, a parameter or a returned struct; nil without mount
+	// patterns.
+	mountOrigins *mountOrigins
+
 	// calleeEdges memoizes, per function base key, the filtered+ordered call
 	// edges used to expand any node of that function. Computed once.
 	calleeEdges map[string][]*metadata.CallGraphEdge
@@ -374,6 +384,20 @@ func WithHandlerInterfaceMethods(methods []string) LazyTreeOption {
 	return func(t *LazyTree) { t.handlerMethods = methods }
 }
 
+// WithHandlerAdapters supplies the framework's handler adapters
+// (FrameworkConfig.HandlerAdapters) so a handler registered through one
+// expands into the adapted handler's body.
+func WithHandlerAdapters(adapters []HandlerAdapterPattern) LazyTreeOption {
+	return func(t *LazyTree) { t.handlerAdapters = adapters }
+}
+
+// WithMountPatterns supplies the framework's mount patterns
+// (FrameworkConfig.MountPatterns) so a mounted router that reaches the mount
+// through a literal, a parameter or a returned struct expands under it.
+func WithMountPatterns(patterns []MountPattern) LazyTreeOption {
+	return func(t *LazyTree) { t.mountOrigins = newMountOrigins(t.meta, patterns) }
+}
+
 func NewLazyTree(meta *metadata.Metadata, limits metadata.TrackerLimits, opts ...LazyTreeOption) *LazyTree {
 	t := &LazyTree{
 		meta:        meta,
@@ -668,6 +692,10 @@ func (t *LazyTree) buildPlan(n *LazyNode) []childSpec {
 			if i >= t.limits.MaxArgsPerFunction {
 				break
 			}
+			arg = unwrapHandlerAdapters(arg, t.handlerAdapters)
+			if lit := funcVariable(ownerEdge, arg, meta); lit != nil {
+				arg = lit
+			}
 			argID := arg.ID()
 			if argID == "" || arg.GetName() == "nil" ||
 				ownerEdge.Caller.ID() == metadata.StripToBase(argID) || ownerEdge.Callee.ID() == argID {
@@ -765,6 +793,16 @@ func (t *LazyTree) buildPlan(n *LazyNode) []childSpec {
 		}
 		expandKey(metadata.StripToBase(producerID))
 	}
+	// Mounted router reaching the mount through a slice or map literal, a
+	// struct literal's field or a parameter (see mountOrigins).
+	if n.isArgument {
+		for _, producerID := range t.mountOrigins.routerProducers(n.edge, n.arg) {
+			for _, edge := range t.receiverChildren[producerID] {
+				appendCallee(edge, false)
+			}
+			expandKey(producerID)
+		}
+	}
 	// Chain children are listed under this node (so matchers see
 	// `.Methods("GET")` on the route call, or `.Use(mw)` on a group) but
 	// parented at the call-site scope — processChainRelationships' rule.
@@ -0,0 +1,167 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// literalPropertyExample returns the example schemas.literalExamples gives
+// field goField of struct pkgPath.typeName: the value a struct literal in
+// the code or its tests gives the field, when it fits s. A value of the
+// wrong kind, or outside s's enum, is no example.
+func literalPropertyExample(cfg *APISpecConfig, meta *metadata.Metadata, pkgPath, typeName, goField string, s *Schema) any {
+	if cfg == nil || !cfg.Schemas.LiteralExamples || meta == nil || s == nil || s.Ref != "" || s.Example != nil {
+		return nil
+	}
+	v, ok := meta.LiteralExamples[pkgPath+"."+typeName][goField]
+	if !ok || !exampleFits(v, s) {
+		return nil
+	}
+	return v
+}
+
+// exampleFits reports whether v, a literal's JSON value, is of s's type and
+// within its enum.
+func exampleFits(v any, s *Schema) bool {
+	if s == nil || s.Ref != "" {
+		return false
+	}
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return fmt.Sprint(e) == fmt.Sprint(v) }) {
+		return false
+	}
+	switch v := v.(type) {
+	case string:
+		return s.Type == "string"
+	case int64:
+		return s.Type == "integer" || s.Type == "number"
+	case float64:
+		return s.Type == "number" || (s.Type == "integer" && v == float64(int64(v)))
+	case bool:
+		return s.Type == "boolean"
+	case []any:
+		if s.Type != "array" {
+			return false
+		}
+		for _, item := range v {
+			if !exampleFits(item, s.Items) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// applyRequestFixtures gives JSON request bodies the bodies tests send to
+// their operation: the `example` when the tests agree on one, otherwise
+// `examples` named after the tests. A fixture's path is matched against the
+// path templates, so a request to /users/42 documents /users/{id}. Bodies
+// that already have an example are left alone.
+func applyRequestFixtures(spec *OpenAPISpec, fixtures []metadata.RequestFixture) {
+	if spec == nil || len(fixtures) == 0 {
+		return
+	}
+	templates := slices.Sorted(maps.Keys(spec.Paths))
+	type target struct{ path, method string }
+	byOp := map[target][]metadata.RequestFixture{}
+	var order []target
+	for _, f := range fixtures {
+		path := matchPathTemplate(templates, f.Path)
+		if path == "" {
+			continue
+		}
+		t := target{path, f.Method}
+		if _, seen := byOp[t]; !seen {
+			order = append(order, t)
+		}
+		byOp[t] = append(byOp[t], f)
+	}
+
+	for _, t := range order {
+		op := spec.Paths[t.path].Operation(t.method)
+		if op == nil || op.RequestBody == nil {
+			continue
+		}
+		for _, mt := range slices.Sorted(maps.Keys(op.RequestBody.Content)) {
+			media := op.RequestBody.Content[mt]
+			if !strings.Contains(mt, "json") || media.Example != nil || len(media.Examples) > 0 {
+				continue
+			}
+			var distinct []metadata.RequestFixture
+			for _, f := range byOp[t] {
+				if !slices.ContainsFunc(distinct, func(d metadata.RequestFixture) bool { return reflect.DeepEqual(d.Body, f.Body) }) {
+					distinct = append(distinct, f)
+				}
+			}
+			if len(distinct) == 1 {
+				media.Example = distinct[0].Body
+			} else {
+				media.Examples = make(map[string]Example, len(distinct))
+				for _, f := range distinct {
+					name := f.Test
+					for i := 2; media.Examples[name].Value != nil; i++ {
+						name = fmt.Sprintf("%s_%d", f.Test, i)
+					}
+					media.Examples[name] = Example{Summary: "Sent by " + f.Test, Value: f.Body}
+				}
+			}
+			op.RequestBody.Content[mt] = media
+		}
+	}
+}
+
+// matchPathTemplate returns the template among templates that path, a
+// concrete request path, fills in: the template itself when it has no
+// parameters, otherwise the one whose literal segments all match, preferring
+// the most literal segments. It returns "" when none does.
+func matchPathTemplate(templates []string, path string) string {
+	if slices.Contains(templates, path) {
+		return path
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	best, bestLiterals := "", -1
+	for _, tmpl := range templates {
+		parts := strings.Split(strings.Trim(tmpl, "/"), "/")
+		if len(parts) != len(segments) {
+			continue
+		}
+		literals := 0
+		for i, part := range parts {
+			if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+				if segments[i] == "" {
+					literals = -1
+					break
+				}
+				continue
+			}
+			if part != segments[i] {
+				literals = -1
+				break
+			}
+			literals++
+		}
+		if literals > bestLiterals {
+			best, bestLiterals = tmpl, literals
+		}
+	}
+	return best
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "testing"
+
+func TestMatchPathTemplate(t *testing.T) {
+	templates := []string{"/users", "/users/me", "/users/{id}", "/users/{id}/orders/{orderId}"}
+	for _, tc := range []struct{ path, want string }{
+		{"/users", "/users"},
+		{"/users/me", "/users/me"},
+		{"/users/42", "/users/{id}"},
+		{"/users/42/orders/7", "/users/{id}/orders/{orderId}"},
+		{"/users/42/orders", ""},
+		{"/orders", ""},
+	} {
+		if got := matchPathTemplate(templates, tc.path); got != tc.want {
+			t.Errorf("matchPathTemplate(%q) = %q, want %q", tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestExampleFits(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		v    any
+		s    *Schema
+		want bool
+	}{
+		{"string", "a", &Schema{Type: "string"}, true},
+		{"int as number", int64(3), &Schema{Type: "number"}, true},
+		{"whole float as integer", 2.0, &Schema{Type: "integer"}, true},
+		{"fraction as integer", 2.5, &Schema{Type: "integer"}, false},
+		{"string as integer", "3", &Schema{Type: "integer"}, false},
+		{"in enum", "on", &Schema{Type: "string", Enum: []any{"on", "off"}}, true},
+		{"outside enum", "maybe", &Schema{Type: "string", Enum: []any{"on", "off"}}, false},
+		{"array", []any{int64(1), int64(2)}, &Schema{Type: "array", Items: &Schema{Type: "integer"}}, true},
+		{"mixed array", []any{int64(1), "x"}, &Schema{Type: "array", Items: &Schema{Type: "integer"}}, false},
+		{"ref", "a", &Schema{Ref: "#/components/schemas/Name"}, false},
+	} {
+		if got := exampleFits(tc.v, tc.s); got != tc.want {
+			t.Errorf("%s: exampleFits = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -15,15 +15,17 @@
 package spec
 
 import (
+	"cmp"
 	"fmt"
 	"go/ast"
 	godoc "go/doc"
+	"go/token"
 	"go/types"
-	"log"
 	"maps"
 	"net/http"
 	"os"
 	"reflect"
+	"regexp"
 	"slices"
 	"sort"
 	"strconv"
@@ -31,6 +33,7 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/ehabterra/apispec/internal/diag"
 	"github.com/ehabterra/apispec/internal/metadata"
 	"github.com/ehabterra/apispec/internal/typemodel"
 )
@@ -91,24 +94,72 @@ type GeneratorConfig struct {
 	APIVersion     string `yaml:"apiVersion"`
 }
 
-// LoadAPISpecConfig loads a APISpecConfig from a YAML file
+// LoadAPISpecConfig loads a APISpecConfig from a YAML file. Keys no field
+// decodes are reported as warnings (see LoadAPISpecConfigWithDiagnostics).
 func LoadAPISpecConfig(path string) (*APISpecConfig, error) {
+	config, diags, err := LoadAPISpecConfigWithDiagnostics(path)
+	for _, d := range diags {
+		diag.Report(d)
+	}
+	return config, err
+}
+
+// LoadAPISpecConfigWithDiagnostics is LoadAPISpecConfig returning, rather than
+// reporting, a warning for each key no field decodes, with its line and the
+// closest known key.
+func LoadAPISpecConfigWithDiagnostics(path string) (*APISpecConfig, []diag.Diagnostic, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, err
+	}
 	var config APISpecConfig
-	err = yaml.Unmarshal(data, &config)
-	if err != nil {
-		return nil, err
+	if root.Kind != 0 {
+		if err := root.Decode(&config); err != nil {
+			return nil, nil, err
+		}
 	}
+	diags := unknownConfigKeys(path, &root)
 
 	if err := config.ValidateSecurity(); err != nil {
-		return nil, err
+		return nil, diags, err
+	}
+	if err := config.ValidateRoutePatterns(); err != nil {
+		return nil, diags, err
+	}
+	if _, err := config.Schemas.ExampleEpochTime(); err != nil {
+		return nil, diags, err
+	}
+	if err := config.Schemas.ValidateTagNamespaces(); err != nil {
+		return nil, diags, err
+	}
+	if err := config.ValidateRouteFilters(); err != nil {
+		return nil, diags, err
+	}
+	if err := config.ValidateExtensions(); err != nil {
+		return nil, diags, err
+	}
+	if err := config.ValidateMiddlewareResponses(); err != nil {
+		return nil, diags, err
+	}
+	if err := config.ValidateErrorResponses(); err != nil {
+		return nil, diags, err
+	}
+	if err := config.ValidateOperationIDs(); err != nil {
+		return nil, diags, err
+	}
+	if err := config.ValidateExternalTypes(); err != nil {
+		return nil, diags, err
+	}
+	if err := config.Schemas.ValidateNullability(); err != nil {
+		return nil, diags, err
 	}
 
-	return &config, nil
+	return &config, diags, nil
 }
 
 // DefaultAPISpecConfig returns a default configuration
@@ -126,6 +177,39 @@ type SecurityDiagnostics struct {
 	// (mux.Vars(r)["userId"]) whose key matches no route placeholder — a likely
 	// typo, since the read is always empty.
 	PathParamMismatches []PathParamMismatch
+
+	// UnsafeMethodWrites lists GET and HEAD handlers that call a repository or
+	// database mutation, which those methods must not do.
+	UnsafeMethodWrites []UnsafeMethodWrite
+
+	// NamingIssues lists JSON naming in the emitted schemas that commonly
+	// breaks client generation (see auditJSONNaming).
+	NamingIssues []NamingIssue
+
+	// TimeLayoutMismatches lists marshalers that format a time with a layout
+	// the schema does not describe.
+	TimeLayoutMismatches []TimeLayoutMismatch
+
+	// UnresolvedRoutes lists the route registrations left out of the spec,
+	// or documented from a guess, because a path, prefix or handler could
+	// not be resolved.
+	UnresolvedRoutes []UnresolvedRoute
+
+	// RouteCoverage lists the router registration call sites the call graph
+	// reaches and whether a route was extracted from each, before the debug
+	// endpoint and route filters.
+	RouteCoverage RouteCoverage
+
+	// Routes lists the extracted routes the spec's paths were built from,
+	// after the debug endpoint and route filters.
+	Routes []*RouteInfo
+
+	// Sources locates the Go declaration of each operation and component
+	// schema, keyed as SpecIssue.Location names them.
+	Sources map[string]SourceLocation
+
+	// MatcherStats counts the pattern matcher work of the extraction.
+	MatcherStats MatcherStats
 }
 
 // MapMetadataToOpenAPI maps metadata to OpenAPI specification.
@@ -142,41 +226,65 @@ func MapMetadataToOpenAPIWithDiagnostics(tree TrackerTreeInterface, cfg *APISpec
 
 	// Extract routes
 	routes := extractor.ExtractRoutes()
+	webhooks := extractor.resolveWebhooks()
+	coverage := extractor.RouteCoverage(routes)
+
+	// pprof and expvar handlers are operational, not API: leave them out
+	// unless asked to document them.
+	routes = debugEndpoints(cfg, routes)
+	routes = filterRoutes(cfg, routes)
 
 	// Warn about auth middleware that was detected but matched no
 	// SecurityMapping, so the user knows what to map. apispecui surfaces the
 	// same list for interactive assignment (see design doc §5). Only warn when
 	// some mappings exist (a library was detected or the user configured them);
 	// otherwise auth detection is effectively off and the noise is unwanted.
-	if unresolved := extractor.UnresolvedSecurity(); len(unresolved) > 0 && len(cfg.SecurityMappings) > 0 {
-		names := make([]string, len(unresolved))
-		for i, r := range unresolved {
-			names[i] = r.String()
+	if len(cfg.SecurityMappings) > 0 {
+		for _, r := range extractor.UnresolvedSecurity() {
+			diag.Report(unresolvedMiddlewareDiagnostic(r))
 		}
-		log.Printf("[security] %d auth middleware not mapped to a security scheme "+
-			"(add securityMappings to resolve): %s", len(unresolved), strings.Join(names, ", "))
 	}
 
 	// Warn about handlers that read a path variable by a key with no matching
 	// path placeholder (e.g. mux.Vars(r)["userId"] on a /users/{id} route) — a
 	// likely typo, since the read is always empty.
 	for _, m := range extractor.PathParamMismatches() {
-		log.Printf("[path-params] %s %s: handler %s reads path variable %q, "+
-			"but the path declares no such parameter (did you mean a different key or path segment?)",
-			m.Method, m.Path, m.Handler, m.Key)
+		diag.Report(m.diagnostic())
+	}
+
+	// Warn about GET/HEAD handlers that reach a mutation call: clients, caches
+	// and crawlers repeat those requests freely.
+	for _, w := range extractor.UnsafeMethodWrites() {
+		diag.Report(w.diagnostic())
 	}
 
-	// Build paths
 	// cfg is optional here (the nil case is handled below for Info), so read the
 	// handler methods defensively rather than dereferencing it unconditionally.
 	var handlerMethods []string
 	if cfg != nil {
 		handlerMethods = cfg.Framework.HandlerInterfaceMethods
 	}
+
+	// Warn about the routes missing from the spec, or documented from a
+	// guess, so the endpoints the analysis could not follow are not lost
+	// silently.
+	unresolved := append(slices.Clone(extractor.DroppedRoutes()), unresolvedRoutes(routes, handlerMethods...)...)
+	for _, r := range unresolved {
+		diag.Report(r.diagnostic())
+	}
+
+	// Build paths
 	paths := buildPathsFromRoutes(routes, handlerMethods...)
+	assignOperationIDs(paths, routes, cfg, tree.GetMetadata().CurrentModulePath, webhookOperationIDs(webhooks)...)
+	sources := operationSources(paths, routes, tree.GetMetadata().CurrentModulePath, handlerMethods...)
+	addCallbacks(paths, routes, webhooks)
 
-	// Generate component schemas
-	components := generateComponentSchemas(tree.GetMetadata(), cfg, routes)
+	// Generate component schemas, the webhook payloads' among them
+	components, goTypes := generateComponentSchemas(tree.GetMetadata(), cfg, append(slices.Clip(routes), webhookRoutes(webhooks)...))
+	maps.Copy(sources, schemaSources(components, goTypes, tree.GetMetadata()))
+	if cfg != nil && cfg.SourceAnnotations {
+		annotateSources(paths, components, sources)
+	}
 
 	// Register shared component parameters for dynamic-path placeholders
 	// (issue #34). Each unique placeholder name across routes becomes one
@@ -184,6 +292,25 @@ func MapMetadataToOpenAPIWithDiagnostics(tree TrackerTreeInterface, cfg *APISpec
 	// buildPathsFromRoutes for the per-operation wiring.
 	addDynamicPathParamComponents(&components, routes)
 
+	// A merge patch body sends only the members to change; point it at an
+	// all-optional variant of the resource schema.
+	addMergePatchVariants(paths, &components)
+
+	// Audit JSON naming of the emitted schemas: duplicate json tags, names
+	// colliding after case-folding, and properties spelled with different
+	// case across schemas all trip up client generators.
+	namingIssues := auditJSONNaming(tree.GetMetadata(), &components)
+	for _, issue := range namingIssues {
+		diag.Report(issue.diagnostic())
+	}
+
+	// Marshalers that format a time with a layout the schema does not
+	// describe make the spec disagree with every payload.
+	timeLayouts := auditTimeLayouts(tree.GetMetadata(), cfg, &components)
+	for _, m := range timeLayouts {
+		diag.Report(m.diagnostic())
+	}
+
 	// Use Info from config if present, else fallback to GeneratorConfig
 	var info Info
 	if cfg != nil && (cfg.Info.Title != "" || cfg.Info.Description != "" || cfg.Info.Version != "") {
@@ -203,10 +330,11 @@ func MapMetadataToOpenAPIWithDiagnostics(tree TrackerTreeInterface, cfg *APISpec
 		OpenAPI:      genCfg.OpenAPIVersion,
 		Info:         info,
 		Paths:        paths,
+		Webhooks:     webhookItems(webhooks, genCfg.OpenAPIVersion),
 		Components:   &components,
 		Servers:      cfg.Servers,
 		Security:     cfg.Security,
-		Tags:         cfg.Tags,
+		Tags:         debugTagDefinition(groupTagDefinitions(cfg.Tags, cfg.GroupTags, routes), routes),
 		ExternalDocs: cfg.ExternalDocs,
 	}
 
@@ -221,16 +349,34 @@ func MapMetadataToOpenAPIWithDiagnostics(tree TrackerTreeInterface, cfg *APISpec
 		spec.Components.SecuritySchemes = schemes
 	}
 
+	applyConfigExtensions(spec, cfg)
+
+	if cfg != nil && cfg.Schemas.LiteralExamples {
+		applyRequestFixtures(spec, tree.GetMetadata().RequestFixtures)
+	}
+	if cfg != nil && cfg.Schemas.Examples {
+		addExamples(spec, cfg.Schemas)
+	}
+
 	diag := &SecurityDiagnostics{
 		UnresolvedMiddleware: extractor.UnresolvedSecurity(),
 		PathParamMismatches:  extractor.PathParamMismatches(),
-	}
+		UnsafeMethodWrites:   extractor.UnsafeMethodWrites(),
+		NamingIssues:         namingIssues,
+		TimeLayoutMismatches: timeLayouts,
+		UnresolvedRoutes:     unresolved,
+		RouteCoverage:        coverage,
+		Routes:               routes,
+		Sources:              sources,
+		MatcherStats:         extractor.MatcherStats(),
+	}
+	markBooleanBounds(spec)
 	return spec, diag, nil
 }
 
 // reconcileSecuritySchemes returns the securityScheme catalog to emit: all
 // user-defined schemes, plus preset schemes referenced by an operation or the
-// global security. Referenced names defined in neither are logged as warnings.
+// global security. Referenced names defined in neither are reported as warnings.
 func reconcileSecuritySchemes(cfg *APISpecConfig, routes []*RouteInfo) map[string]SecurityScheme {
 	out := make(map[string]SecurityScheme, len(cfg.SecuritySchemes))
 	for name, scheme := range cfg.SecuritySchemes {
@@ -264,8 +410,16 @@ func reconcileSecuritySchemes(cfg *APISpecConfig, routes []*RouteInfo) map[strin
 	}
 	if len(dangling) > 0 {
 		sort.Strings(dangling)
-		log.Printf("[security] %d security scheme(s) referenced but not defined "+
-			"(add them to securitySchemes): %s", len(dangling), strings.Join(dangling, ", "))
+		known := slices.Sorted(maps.Keys(out))
+		for _, name := range dangling {
+			diag.Report(diag.Diagnostic{
+				Severity:   diag.Warning,
+				Category:   "security",
+				Message:    fmt.Sprintf("security scheme %q is referenced but not defined", name),
+				Suggestion: diag.Suggest(name, known),
+				Help:       "add it to securitySchemes",
+			})
+		}
 	}
 
 	return out
@@ -294,6 +448,9 @@ func buildPathsFromRoutes(routes []*RouteInfo, handlerMethods ...string) map[str
 
 		// Create operation
 		operationID := pkg + strings.Replace(strings.Replace(route.Function, TypeSep, ".", 1), pkg, "", 1)
+		if route.OperationID != "" {
+			operationID = route.OperationID
+		}
 		if route.OperationIDSuffix != "" {
 			operationID += "_" + route.OperationIDSuffix
 		}
@@ -316,14 +473,15 @@ func buildPathsFromRoutes(routes []*RouteInfo, handlerMethods ...string) map[str
 			Summary:     summary,
 			Description: description,
 			Tags:        route.Tags,
+			Deprecated:  route.Deprecated,
 		}
 
 		// Add request body if present. A detected request body means the handler
 		// decodes it, so it is required (issue #167) — an OpenAPI requestBody
-		// defaults to optional otherwise.
+		// defaults to optional otherwise — unless the decode is guarded.
 		if route.Request != nil {
 			operation.RequestBody = &RequestBody{
-				Required: true,
+				Required: !route.Request.Optional,
 				Content: map[string]MediaType{
 					route.Request.ContentType: {
 						Schema: route.Request.Schema,
@@ -332,15 +490,16 @@ func buildPathsFromRoutes(routes []*RouteInfo, handlerMethods ...string) map[str
 			}
 		}
 
-		// r.FormValue-style reads carry the sentinel location "form", which is
-		// not a valid OpenAPI parameter location (issue #171). Resolve it to a
-		// real location from the HTTP method: for body-bearing methods
-		// (POST/PUT/PATCH) the values form an application/x-www-form-urlencoded
-		// request body; otherwise (GET/HEAD/DELETE/…) they are query params —
-		// Go's FormValue reads the URL query for those. A pre-existing request
-		// body (e.g. decoded JSON) is never clobbered: form params then fall
-		// back to query so we still emit a valid location.
-		params, formBody := resolveFormParams(route.Method, route.Params, operation.RequestBody != nil)
+		// r.FormValue-style reads carry the sentinel location "form" (uploads
+		// "file"), which is not a valid OpenAPI parameter location (issue
+		// #171). Resolve it to a real location from the HTTP method: for
+		// body-bearing methods (POST/PUT/PATCH) the values form a urlencoded
+		// or multipart request body; otherwise (GET/HEAD/DELETE/…) they are
+		// query params — Go's FormValue reads the URL query for those. A
+		// pre-existing request body (e.g. decoded JSON) is never clobbered:
+		// form params then fall back to query so we still emit a valid
+		// location.
+		params, formBody := resolveFormParams(route.Method, dropIgnoredHeaderParams(route.Params), operation.RequestBody != nil)
 		if formBody != nil {
 			operation.RequestBody = formBody
 		}
@@ -357,9 +516,22 @@ func buildPathsFromRoutes(routes []*RouteInfo, handlerMethods ...string) map[str
 		// considered "covered" by ensureAllPathParams below.
 		operation.Parameters = appendDynamicParamRefs(operation.Parameters, route.DynamicParams)
 		operation.Parameters = ensureAllPathParams(openAPIPath, operation.Parameters, pathParamPatterns(rawPath))
+		describeRemainderParams(operation.Parameters, route.RemainderParams)
 
 		// Add responses
 		operation.Responses = buildResponses(route.Response)
+		applyProtocolToOperation(operation, streamProtocol(route))
+		applyConditionalRequests(operation, route.Method)
+		responseHeaders := applyRangeRequests(operation, route.Method, route.ResponseHeaders)
+		applyResponseHeaders(operation.Responses, responseHeaders)
+
+		// Gateway hints from overrides, consumed by the gateway-config output.
+		if route.Timeout != "" {
+			setOperationExtension(operation, "x-timeout", route.Timeout)
+		}
+		if route.Retries > 0 {
+			setOperationExtension(operation, "x-retries", route.Retries)
+		}
 
 		// Per-operation security resolved from detected auth middleware.
 		// route.Security: nil => inherit the document-level security (field
@@ -422,6 +594,18 @@ func ensureAllPathParams(openAPIPath string, params []Parameter, patterns map[st
 	return params
 }
 
+// describeRemainderParams notes on each path parameter named in remainder (a
+// ServeMux {name...} wildcard) that its value is the rest of the path and may
+// contain slashes. A description the code or an override already set is kept.
+func describeRemainderParams(params []Parameter, remainder []string) {
+	for i := range params {
+		p := &params[i]
+		if p.In == "path" && p.Description == "" && slices.Contains(remainder, p.Name) {
+			p.Description = "The remainder of the path; may contain slashes."
+		}
+	}
+}
+
 // appendDynamicParamRefs adds one $ref entry per dynamic placeholder name,
 // pointing at the shared component parameter. Duplicates (a name already
 // covered by an inline parameter or another $ref) are skipped.
@@ -582,25 +766,46 @@ func methodTakesRequestBody(method string) bool {
 	}
 }
 
-// resolveFormParams rewrites the sentinel "form" parameter location (emitted for
-// r.FormValue-style reads) into a valid OpenAPI shape (issue #171). Form values
-// are ambiguous in Go — FormValue reads the URL query for GET and the
-// urlencoded body for POST — so the HTTP method decides:
+// Sentinel parameter locations emitted by ParamPatterns and resolved by
+// resolveFormParams. None is a valid OpenAPI location, so none survives into
+// the output.
+const (
+	paramInForm      = "form"      // r.FormValue: a form field
+	paramInFile      = "file"      // r.FormFile / c.FormFile: an uploaded file
+	paramInMultipart = "multipart" // r.ParseMultipartForm: the form is multipart; names no field
+)
+
+// Form request body media types.
+const (
+	formURLEncoded    = "application/x-www-form-urlencoded"
+	multipartFormData = "multipart/form-data"
+)
+
+// resolveFormParams rewrites the sentinel form locations (emitted for
+// r.FormValue-style reads, file uploads and multipart parsing) into a valid
+// OpenAPI shape (issue #171). Form values are ambiguous in Go — FormValue
+// reads the URL query for GET and the form body for POST — so the HTTP method
+// decides:
 //
 //   - body-bearing method (POST/PUT/PATCH) with no existing request body:
-//     the form params are folded into an application/x-www-form-urlencoded
-//     request body and removed from the parameter list.
-//   - otherwise: each form param is rewritten to `in: query`, a valid location.
+//     the form params are folded into a request body and removed from the
+//     parameter list. The body is multipart/form-data when the handler reads
+//     a file or parses a multipart form, else
+//     application/x-www-form-urlencoded.
+//   - otherwise: each form param is rewritten to `in: query`, a valid
+//     location. File params have no location outside a body and are dropped.
 //
 // hasRequestBody guards against clobbering an already-detected body (e.g.
 // decoded JSON); in that case the query-param fallback is used. Non-form
 // params pass through untouched. The input slice is never mutated.
 func resolveFormParams(method string, params []Parameter, hasRequestBody bool) ([]Parameter, *RequestBody) {
-	hasForm := false
+	hasForm, multipart := false, false
 	for i := range params {
-		if params[i].In == "form" {
+		switch params[i].In {
+		case paramInForm:
 			hasForm = true
-			break
+		case paramInFile, paramInMultipart:
+			hasForm, multipart = true, true
 		}
 	}
 	if !hasForm {
@@ -610,11 +815,14 @@ func resolveFormParams(method string, params []Parameter, hasRequestBody bool) (
 	kept := make([]Parameter, 0, len(params))
 	var formParams []Parameter
 	for _, p := range params {
-		if p.In == "form" {
+		switch p.In {
+		case paramInForm, paramInFile:
 			formParams = append(formParams, p)
-			continue
+		case paramInMultipart:
+			// Marker only: it switches the media type and names no field.
+		default:
+			kept = append(kept, p)
 		}
-		kept = append(kept, p)
 	}
 
 	if methodTakesRequestBody(method) && !hasRequestBody {
@@ -630,9 +838,13 @@ func resolveFormParams(method string, params []Parameter, hasRequestBody bool) (
 			}
 		}
 		sort.Strings(schema.Required)
+		contentType := formURLEncoded
+		if multipart {
+			contentType = multipartFormData
+		}
 		body := &RequestBody{
 			Content: map[string]MediaType{
-				"application/x-www-form-urlencoded": {Schema: schema},
+				contentType: {Schema: schema},
 			},
 		}
 		return kept, body
@@ -640,6 +852,9 @@ func resolveFormParams(method string, params []Parameter, hasRequestBody bool) (
 
 	// Query-param fallback (non-body methods, or a body already exists).
 	for _, fp := range formParams {
+		if fp.In == paramInFile {
+			continue
+		}
 		fp.In = "query"
 		kept = append(kept, fp)
 	}
@@ -676,19 +891,40 @@ func buildResponses(respInfo map[string]*ResponseInfo) map[string]Response {
 	}
 	sort.Strings(keys)
 
+	//
+	// A status written in several media types (a handler negotiating JSON,
+	// XML or YAML per Accept) keeps its first one in chosen and the others,
+	// stored in negotiatedSlot slots, in negotiated; a slot whose first media
+	// type went to another method's branch stands in for it.
 	chosen := make(map[string]*ResponseInfo)
+	negotiated := make(map[string][]*ResponseInfo)
+	defaultByMedia := make(map[string]*ResponseInfo)
 	for _, k := range keys {
 		resp := respInfo[k]
-		statusCode := k
+		statusCode, _, isNegotiated := strings.Cut(k, " ")
+		if isNegotiated && chosen[statusCode] != nil {
+			negotiated[statusCode] = append(negotiated[statusCode], resp)
+			continue
+		}
 		// if status less than 0, use "default" to indicate unknown/invalid status
 		// OpenAPI only accepts status codes 100-599, "default", or vendor extensions
 		if resp.StatusCode < 0 {
 			statusCode = "default"
 			chosen[statusCode] = preferResponseInfo(chosen[statusCode], resp)
+			if resp.BodyType != "" {
+				defaultByMedia[resp.ContentType] = preferResponseInfo(defaultByMedia[resp.ContentType], resp)
+			}
 			continue
 		}
 		chosen[statusCode] = resp
 	}
+	if def := chosen["default"]; def != nil && def.BodyType != "" {
+		for _, contentType := range slices.Sorted(maps.Keys(defaultByMedia)) {
+			if contentType != def.ContentType {
+				negotiated["default"] = append(negotiated["default"], defaultByMedia[contentType])
+			}
+		}
+	}
 
 	// "default" here means "status could not be determined" — a fallback, not an
 	// OpenAPI catch-all. Once concrete statuses are resolved, a default adds
@@ -708,6 +944,9 @@ func buildResponses(respInfo map[string]*ResponseInfo) map[string]Response {
 		if resp.StatusCode < 0 || description == "" {
 			description = "Status code could not be determined"
 		}
+		if resp.Description != "" {
+			description = resp.Description
+		}
 
 		// Bodyless status codes (204, 304, 1xx) must not carry a response body
 		// per the OpenAPI spec — emit them with no `content` block. Any body the
@@ -718,13 +957,19 @@ func buildResponses(respInfo map[string]*ResponseInfo) map[string]Response {
 			continue
 		}
 
+		content := map[string]MediaType{
+			resp.ContentType: {
+				Schema: resp.Schema,
+			},
+		}
+		for _, other := range negotiated[statusCode] {
+			if _, ok := content[other.ContentType]; !ok {
+				content[other.ContentType] = MediaType{Schema: other.Schema}
+			}
+		}
 		responses[statusCode] = Response{
 			Description: description,
-			Content: map[string]MediaType{
-				resp.ContentType: {
-					Schema: resp.Schema,
-				},
-			},
+			Content:     content,
 		}
 	}
 
@@ -864,8 +1109,9 @@ func pathParamPatterns(path string) map[string]string {
 	return out
 }
 
-// generateComponentSchemas generates component schemas from metadata
-func generateComponentSchemas(meta *metadata.Metadata, cfg *APISpecConfig, routes []*RouteInfo) Components {
+// generateComponentSchemas generates component schemas from metadata and
+// returns the Go type key each was generated from, keyed by component name.
+func generateComponentSchemas(meta *metadata.Metadata, cfg *APISpecConfig, routes []*RouteInfo) (Components, map[string]string) {
 	components := Components{
 		Schemas: make(map[string]*Schema),
 	}
@@ -874,12 +1120,26 @@ func generateComponentSchemas(meta *metadata.Metadata, cfg *APISpecConfig, route
 	usedTypes := collectUsedTypesFromRoutes(routes)
 
 	// Generate schemas for used types
-	generateSchemas(usedTypes, cfg, components, meta)
+	goTypes := generateSchemas(usedTypes, cfg, components, meta)
+	if cfg != nil {
+		annotateComponentSchemas(components, goTypes, cfg.Schemas)
+		applySchemaExtensions(components, goTypes, cfg.Extensions.Schemas)
+	}
 
-	return components
+	return components, goTypes
 }
 
-func generateSchemas(usedTypes map[string]*Schema, cfg *APISpecConfig, components Components, meta *metadata.Metadata) {
+// generateSchemas fills components with a schema per used type and returns
+// the Go type key each component was generated from, keyed by component
+// name.
+func generateSchemas(usedTypes map[string]*Schema, cfg *APISpecConfig, components Components, meta *metadata.Metadata) map[string]string {
+	goTypes := make(map[string]string)
+	put := func(key string, schema *Schema) {
+		name := schemaComponentNameReplacer.Replace(key)
+		components.Schemas[name] = schema
+		goTypes[name] = key
+	}
+
 	// Iterate in sorted order: generateSchemaFromType's recursion guard turns
 	// already-visited types into $refs, so map-range order would decide
 	// inline-vs-$ref per run.
@@ -891,14 +1151,31 @@ func generateSchemas(usedTypes map[string]*Schema, cfg *APISpecConfig, component
 			continue
 		}
 
-		// Check external types
-		if cfg != nil {
-			for _, externalType := range cfg.ExternalTypes {
-				if externalType.Name == strings.ReplaceAll(typeName, TypeSep, ".") {
-					components.Schemas[schemaComponentNameReplacer.Replace(typeName)] = externalType.OpenAPIType
-					continue
-				}
+		// A slice or array ([]X, the element a [][]X body is marked by) is
+		// inlined at its use site; only its element can be a component.
+		// Resolving the wrapper in metadata would find the element's type
+		// and emit it a second time under the wrapper's name.
+		if ref := typemodel.Parse(typeName); ref.Kind == typemodel.KindSlice || ref.Kind == typemodel.KindArray {
+			core := ref.Core()
+			if core == nil || core.Kind != typemodel.KindNamed {
+				continue
 			}
+			if _, marked := usedTypes[core.Raw()]; marked {
+				continue
+			}
+			typeName = core.Raw()
+		}
+
+		// Check external types
+		if externalType := cfg.externalType(typeName); externalType != nil {
+			put(typeName, externalType.OpenAPIType)
+			continue
+		}
+
+		// The placeholder schemas.dynamicValues: component refers to.
+		if cfg != nil && cfg.Schemas.DynamicValues == DynamicValuesComponent && typeName == cfg.Schemas.dynamicComponentName() {
+			put(typeName, dynamicComponentSchema())
+			continue
 		}
 
 		// Known external types (uuid.UUID, decimal.Decimal, sql.Null*, …) are
@@ -908,7 +1185,7 @@ func generateSchemas(usedTypes map[string]*Schema, cfg *APISpecConfig, component
 		if s, _, ok := resolveExternalType(typeName, cfg, meta, usedTypes, map[string]bool{}); ok {
 			if s != nil && !isPrimitiveShapedSchema(s) {
 				// Non-primitive resolution (rare): emit it as a real component.
-				components.Schemas[schemaComponentNameReplacer.Replace(typeName)] = s
+				put(typeName, s)
 			}
 			// Primitive-shaped (the common case): inlined; emit no component.
 			continue
@@ -922,9 +1199,8 @@ func generateSchemas(usedTypes map[string]*Schema, cfg *APISpecConfig, component
 			// placeholder for primitives and container types — those are
 			// emitted inline and never reach a $ref site.
 			if canAddRefSchemaForType(typeName) {
-				key := schemaComponentNameReplacer.Replace(typeName)
-				if _, exists := components.Schemas[key]; !exists {
-					components.Schemas[key] = unresolvedExternalPlaceholder(typeName)
+				if _, exists := components.Schemas[schemaComponentNameReplacer.Replace(typeName)]; !exists {
+					put(typeName, unresolvedExternalPlaceholder(typeName))
 				}
 			}
 			continue
@@ -944,14 +1220,15 @@ func generateSchemas(usedTypes map[string]*Schema, cfg *APISpecConfig, component
 				schema, schemas = generateSchemaFromType(usedTypes, key, typ, meta, cfg, nil)
 			}
 			if schema != nil {
-				components.Schemas[schemaComponentNameReplacer.Replace(key)] = schema
+				put(key, schema)
 			}
 			for schemaKey, newSchema := range schemas {
-				components.Schemas[schemaComponentNameReplacer.Replace(schemaKey)] = newSchema
+				put(schemaKey, newSchema)
 			}
 
 		}
 	}
+	return goTypes
 }
 
 // collectUsedTypesFromRoutes collects all types used in routes
@@ -1140,13 +1417,9 @@ func generateSchemaFromType(usedTypes map[string]*Schema, key string, typ *metad
 	}
 
 	// Check external types
-	if cfg != nil {
-		for _, externalType := range cfg.ExternalTypes {
-			if externalType.Name == strings.ReplaceAll(derivedKey, TypeSep, ".") {
-				markUsedType(usedTypes, derivedKey, externalType.OpenAPIType)
-				return externalType.OpenAPIType, schemas
-			}
-		}
+	if externalType := cfg.externalType(derivedKey); externalType != nil {
+		markUsedType(usedTypes, derivedKey, externalType.OpenAPIType)
+		return externalType.OpenAPIType, schemas
 	}
 
 	// Get type kind from string pool
@@ -1159,7 +1432,7 @@ func generateSchemaFromType(usedTypes map[string]*Schema, key string, typ *metad
 	case "struct":
 		schema, newSchemas = generateStructSchema(usedTypes, key, typ, meta, cfg, visitedTypes)
 	case "interface":
-		schema = generateInterfaceSchema()
+		schema, newSchemas = generateInterfaceSchema(usedTypes, typ, meta, cfg, visitedTypes)
 	case "alias":
 		schema, newSchemas = generateAliasSchema(usedTypes, typ, meta, cfg, visitedTypes)
 	default:
@@ -1199,33 +1472,70 @@ func genericArgText(a *typemodel.TypeRef) string {
 	return a.Simple()
 }
 
-// substituteTypeParams replaces a parametric field type with its concrete
-// argument, preserving leading slice/pointer markers so `Items []T` becomes
-// `[]User` and `Data T` becomes `User`. Field types that don't reduce to a
-// declared type parameter are returned unchanged.
+// substituteTypeParams replaces the type parameters in a parametric field
+// type with their concrete arguments, wherever they occur: `Items []T`
+// becomes `[]User`, `Data T` becomes `User`, and the self-reference
+// `Next *Page[T]` becomes `*Page[User]`, so a recursive generic refers back to
+// its own instantiation. Field types without a declared type parameter are
+// returned unchanged.
 func substituteTypeParams(fieldType string, genericTypes map[string]string) string {
 	if len(genericTypes) == 0 {
 		return fieldType
 	}
-	prefix := ""
-	rest := fieldType
-	for {
-		if strings.HasPrefix(rest, "[]") {
-			prefix += "[]"
-			rest = rest[2:]
-			continue
-		}
-		if strings.HasPrefix(rest, "*") {
-			prefix += "*"
-			rest = rest[1:]
-			continue
+	ref := typemodel.Parse(fieldType)
+	if !substituteTypeParamRefs(ref, genericTypes) {
+		return fieldType
+	}
+	return ref.String()
+}
+
+// substituteTypeParamRefs replaces, in place, each unqualified named ref that
+// is a type parameter with its concrete argument (kept verbatim), and reports
+// whether it replaced any.
+func substituteTypeParamRefs(ref *typemodel.TypeRef, genericTypes map[string]string) bool {
+	if ref == nil {
+		return false
+	}
+	if ref.Kind != typemodel.KindNamed {
+		key := substituteTypeParamRefs(ref.Key, genericTypes)
+		return substituteTypeParamRefs(ref.Elem, genericTypes) || key
+	}
+	if concrete, ok := genericTypes[ref.Name]; ok && ref.Pkg == "" && len(ref.Args) == 0 {
+		*ref = typemodel.TypeRef{Kind: typemodel.KindNamed, Name: concrete}
+		return true
+	}
+	replaced := false
+	for _, arg := range ref.Args {
+		if substituteTypeParamRefs(arg, genericTypes) {
+			replaced = true
 		}
-		break
 	}
-	if concrete, ok := genericTypes[rest]; ok {
-		return prefix + concrete
+	return replaced
+}
+
+// qualifyLocalTypes qualifies the package-local named types of a field type
+// with the package declaring the field, through every wrapper: [][]Item,
+// map[string]*Item and [2][]Item all name pkg.Item. Builtins stay bare.
+func qualifyLocalTypes(fieldType, pkgName string) string {
+	ref := typemodel.Parse(fieldType)
+	qualifyLocalRefs(ref, pkgName)
+	return ref.String()
+}
+
+// qualifyLocalRefs sets pkgName on each unqualified, non-builtin named ref
+// reachable through ref's wrappers. Generic arguments keep their form.
+func qualifyLocalRefs(ref *typemodel.TypeRef, pkgName string) {
+	if ref == nil {
+		return
+	}
+	if ref.Kind != typemodel.KindNamed {
+		qualifyLocalRefs(ref.Key, pkgName)
+		qualifyLocalRefs(ref.Elem, pkgName)
+		return
+	}
+	if ref.Pkg == "" && token.IsIdentifier(ref.Name) && !metadata.IsPrimitiveType(ref.Name) {
+		ref.Pkg = pkgName
 	}
-	return fieldType
 }
 
 // generateStructSchema generates a schema for a struct type
@@ -1259,22 +1569,25 @@ func generateStructSchema(usedTypes map[string]*Schema, key string, typ *metadat
 		}
 	}
 
+	pkgName := getStringFromPool(meta, typ.Pkg)
+
 	schema := &Schema{
-		Type:       "object",
-		Properties: make(map[string]*Schema),
-		Required:   []string{},
+		Type:        "object",
+		Description: declDoc(meta, pkgName, typ.Comments),
+		Properties:  make(map[string]*Schema),
+		Required:    []string{},
 	}
 
-	pkgName := getStringFromPool(meta, typ.Pkg)
-
 	for _, field := range typ.Fields {
 		fieldName := getStringFromPool(meta, field.Name)
+		goFieldName := fieldName
 		fieldType := getStringFromPool(meta, field.Type)
 
 		// Skip fields that encoding/json never serializes: a `json:"-"` tag,
 		// or an unexported field. Mirrors the anonymous-struct path so both
-		// stay consistent.
-		if jsonFieldOmitted(getStringFromPool(meta, field.Tag)) || !ast.IsExported(fieldName) {
+		// stay consistent. The XXX_ bookkeeping of protobuf messages is not
+		// part of their JSON form either.
+		if jsonFieldOmitted(getStringFromPool(meta, field.Tag)) || !ast.IsExported(fieldName) || protobufInternalField(fieldName) {
 			// A blank marker field (`_ struct{} `validate:"gtefield=Min"`)
 			// carries struct-level, cross-field validation that OpenAPI cannot
 			// express natively. Surface it as a note on the schema description so
@@ -1293,6 +1606,8 @@ func generateStructSchema(usedTypes map[string]*Schema, key string, typ *metadat
 			fieldType = genericType
 		}
 
+		pointer := strings.HasPrefix(fieldType, "*")
+
 		// Check if fieldType is an alias/enum and resolve to underlying type
 		// But don't resolve array or map types as we need the original type for enum detection
 		if !strings.HasPrefix(fieldType, "[]") && !strings.Contains(fieldType, "map[") {
@@ -1301,10 +1616,10 @@ func generateStructSchema(usedTypes map[string]*Schema, key string, typ *metadat
 			}
 		}
 
-		// Extract JSON tag if present
-		jsonName := extractJSONName(getStringFromPool(meta, field.Tag))
-		if jsonName != "" {
-			fieldName = jsonName
+		// Name the property from its tag: json, or the model tags
+		// (db, gorm) configured in schemas.tagNamespaces.
+		if name := propertyName(cfg, getStringFromPool(meta, field.Tag)); name != "" {
+			fieldName = name
 		}
 
 		// Extract validation constraints from struct tag
@@ -1314,7 +1629,9 @@ func generateStructSchema(usedTypes map[string]*Schema, key string, typ *metadat
 		var fieldSchema *Schema
 		var newSchemas map[string]*Schema
 
-		if field.NestedType != nil {
+		if override := cfg.fieldOverride(pkgName+"."+getStringFromPool(meta, typ.Name), fieldName, goFieldName); override != nil {
+			fieldSchema = override
+		} else if field.NestedType != nil {
 			// Handle nested struct type
 			fieldOriginalType := getStringFromPool(meta, field.NestedType.Name)
 
@@ -1328,11 +1645,7 @@ func generateStructSchema(usedTypes map[string]*Schema, key string, typ *metadat
 			isPrimitive := metadata.IsPrimitiveType(fieldType)
 
 			if !isPrimitive && !strings.Contains(fieldType, ".") {
-				re := mustCachedRegex(`((\[\])?\*?)(.+)$`)
-				matches := re.FindStringSubmatch(fieldType)
-				if len(matches) >= 4 {
-					fieldType = matches[1] + pkgName + "." + matches[3]
-				}
+				fieldType = qualifyLocalTypes(fieldType, pkgName)
 			}
 
 			derivedFieldType := strings.TrimPrefix(fieldType, "*")
@@ -1369,6 +1682,12 @@ func generateStructSchema(usedTypes map[string]*Schema, key string, typ *metadat
 			}
 		}
 
+		if doc := declDoc(meta, pkgName, field.Comments); doc != "" && fieldSchema != nil {
+			described := *fieldSchema
+			described.Description = doc
+			fieldSchema = &described
+		}
+
 		// Apply validation constraints to the schema
 		if validationConstraints != nil {
 			applyValidationConstraints(fieldSchema, validationConstraints)
@@ -1378,6 +1697,10 @@ func generateStructSchema(usedTypes map[string]*Schema, key string, typ *metadat
 				schema.Required = append(schema.Required, fieldName)
 			}
 		}
+		applyGormTag(cfg, schema, fieldName, fieldSchema, getStringFromPool(meta, field.Tag))
+		if omitemptyOptional(cfg, getStringFromPool(meta, field.Tag)) {
+			schema.Required = slices.DeleteFunc(schema.Required, func(r string) bool { return r == fieldName })
+		}
 
 		// Detect and apply enum values from constants if no enum was specified in tags
 		// Only apply enum detection for custom types (not built-in types)
@@ -1387,35 +1710,76 @@ func generateStructSchema(usedTypes map[string]*Schema, key string, typ *metadat
 
 			// Only detect enums for custom types, not built-in types like string, int, etc.
 			if !metadata.IsPrimitiveType(originalFieldType) {
-				if enumValues := detectEnumFromConstants(originalFieldType, pkgName, meta); len(enumValues) > 0 {
+				if enum := detectConstantEnum(originalFieldType, pkgName, meta); len(enum.Values) > 0 {
 					switch fieldSchema.Type {
 					case "array":
-						fieldSchema.Items.Enum = enumValues
+						enum.apply(fieldSchema.Items)
 					case "object":
-						if fieldSchema.AdditionalProperties != nil {
-							fieldSchema.AdditionalProperties.Enum = enumValues
-						}
+						enum.apply(fieldSchema.AdditionalProperties)
 					default:
-						fieldSchema.Enum = enumValues
+						enum.apply(fieldSchema)
 					}
 
 				}
 			}
 		}
 
+		if v := literalPropertyExample(cfg, meta, pkgName, getStringFromPool(meta, typ.Name), goFieldName, fieldSchema); v != nil {
+			withExample := *fieldSchema
+			withExample.Example = v
+			fieldSchema = &withExample
+		}
+
+		if pointer && nullablePointer(cfg, getStringFromPool(meta, field.Tag)) {
+			fieldSchema = nullableSchema(cfg, fieldSchema)
+		}
+
 		schema.Properties[fieldName] = fieldSchema
 	}
 
 	return schema, schemas
 }
 
-// generateInterfaceSchema generates a schema for an interface type
-func generateInterfaceSchema() *Schema {
-	// For interfaces, we'll create a generic object schema
-	// In a more sophisticated implementation, you might analyze interface methods
-	return &Schema{
-		Type: "object",
+// generateInterfaceSchema generates a schema for an interface type. An
+// interface the analyzed code implements more than once is one of those
+// implementations on the wire, so it maps to a `oneOf` of their components,
+// with a `discriminator` when schemas.discriminator names the property. With
+// fewer implementations there is nothing to choose between and the interface
+// stays a generic object.
+func generateInterfaceSchema(usedTypes map[string]*Schema, typ *metadata.Type, meta *metadata.Metadata, cfg *APISpecConfig, visitedTypes map[string]bool) (*Schema, map[string]*Schema) {
+	schemas := map[string]*Schema{}
+	if typ == nil || meta == nil {
+		return &Schema{Type: "object"}, schemas
+	}
+	var members []*Schema
+	mapping := map[string]string{}
+	for _, impl := range interfaceImplementers(meta, getStringFromPool(meta, typ.Pkg), getStringFromPool(meta, typ.Name)) {
+		implType := typeByName(impl.pkg, impl.typ, meta)
+		if implType == nil || getStringFromPool(meta, implType.Kind) == "interface" {
+			continue
+		}
+		member, newSchemas := mapGoTypeToOpenAPISchema(usedTypes, impl.pkg+TypeSep+impl.typ, meta, cfg, visitedTypes)
+		if member == nil || member.Ref == "" {
+			continue
+		}
+		maps.Copy(schemas, newSchemas)
+		members = append(members, member)
+		if _, taken := mapping[impl.typ]; taken {
+			// Same-named types from two packages: leave the values to the
+			// default, the component names.
+			mapping = nil
+		} else if mapping != nil {
+			mapping[impl.typ] = member.Ref
+		}
+	}
+	if len(members) < 2 {
+		return &Schema{Type: "object"}, schemas
+	}
+	schema := &Schema{OneOf: members}
+	if cfg != nil && cfg.Schemas.Discriminator != "" {
+		schema.Discriminator = &Discriminator{PropertyName: cfg.Schemas.Discriminator, Mapping: mapping}
 	}
+	return schema, schemas
 }
 
 // generateAliasSchema generates a schema for an alias type
@@ -1437,9 +1801,9 @@ func generateAliasSchema(usedTypes map[string]*Schema, typ *metadata.Type, meta
 		}
 
 		// Detect enum values for this alias type using the original type name
-		if enumValues := detectEnumFromConstants(originalTypeName, pkgName, meta); len(enumValues) > 0 {
+		if enum := detectConstantEnum(originalTypeName, pkgName, meta); len(enum.Values) > 0 {
 			// Apply enum values to the schema
-			schema.Enum = enumValues
+			enum.apply(schema)
 		}
 	}
 
@@ -1637,9 +2001,29 @@ func receiverTypeName(meta *metadata.Metadata, pkg, recv string) string {
 	return field
 }
 
-// handlerComments returns the Go doc comment recorded for the route's handler,
-// resolving every handler shape. RouteInfo.Function is the rendered handler
-// argument, and the shapes differ (issue #168 originally handled only the first):
+// handlerComments returns the Go doc comment recorded for the route's handler
+// and the package declaring it, which doc links in the comment are relative
+// to (see handlerDeclaration). Returns "" for an anonymous (func-literal) or
+// undocumented handler.
+func handlerComments(route *RouteInfo, handlerMethods ...string) (doc, pkg string) {
+	decl, ok := handlerDeclaration(route, handlerMethods...)
+	if !ok {
+		return "", ""
+	}
+	return getStringFromPool(route.Metadata, decl.comments), decl.pkg
+}
+
+// handlerDecl is the declaration serving a route: the package declaring it,
+// its name ("Func" or "Recv.Method"), and the pooled doc comment and source
+// position the metadata records for it.
+type handlerDecl struct {
+	pkg, name          string
+	comments, position int
+}
+
+// handlerDeclaration resolves the declaration of the route's handler, for
+// every handler shape. RouteInfo.Function is the rendered handler argument,
+// and the shapes differ (issue #168 originally handled only the first):
 //
 //	pkg.Handler                 — a package-level function
 //	pkg-->pkg.Recv.Method       — a method value (h.Handler)
@@ -1647,8 +2031,9 @@ func receiverTypeName(meta *metadata.Metadata, pkg, recv string) string {
 //
 // The method shapes resolve through the per-Type methods table, which
 // findFunctionByName cannot reach — it indexes only receiver-less declarations.
-// Returns "" for an anonymous (func-literal) or undocumented handler.
-func handlerComments(route *RouteInfo, handlerMethods ...string) string {
+// Reports false for an anonymous (func-literal) handler or one the metadata
+// does not declare.
+func handlerDeclaration(route *RouteInfo, handlerMethods ...string) (handlerDecl, bool) {
 	name := route.Function
 	// The separator between the package and the rest is TypeSep in some render
 	// paths and a plain dot in others, so normalize before splitting. The package
@@ -1665,35 +2050,47 @@ func handlerComments(route *RouteInfo, handlerMethods ...string) string {
 	if i := strings.LastIndexByte(name, '.'); i >= 0 {
 		recv := receiverTypeName(route.Metadata, route.Package, name[:i])
 		if m := findMethodByName(route.Metadata, route.Package, recv, name[i+1:]); m != nil {
-			return getStringFromPool(route.Metadata, m.Comments)
+			return methodDecl(route.Metadata, route.Package, m), true
 		}
-		return handlerValueComments(route, name, handlerMethods...)
+		return handlerValueDeclaration(route, name, handlerMethods...)
 	}
 	if fn := findFunctionByName(route.Metadata, route.Package, name); fn != nil {
-		return getStringFromPool(route.Metadata, fn.Comments)
+		return handlerDecl{pkg: route.Package, name: name, comments: fn.Comments, position: fn.Position}, true
 	}
-	return handlerValueComments(route, name, handlerMethods...)
+	return handlerValueDeclaration(route, name, handlerMethods...)
 }
 
-// handlerValueComments resolves the doc comment of a handler passed as a *value*
-// (issue #204): the registration names no method, so the framework's handler
-// interface supplies it. `name` is the rendered handler argument with the package
-// prefix already stripped — either a type name ("H", from `mux.Handle("/x", h)`)
-// or a field path ("Deps.Health", from `r.Method(GET, "/health", deps.Health)`),
-// both of which receiverTypeName resolves to the declaring type.
+// methodDecl is the handlerDecl of method m declared in pkg.
+func methodDecl(meta *metadata.Metadata, pkg string, m *metadata.Method) handlerDecl {
+	recv := strings.TrimPrefix(getStringFromPool(meta, m.Receiver), "*")
+	return handlerDecl{
+		pkg:      pkg,
+		name:     recv + "." + getStringFromPool(meta, m.Name),
+		comments: m.Comments,
+		position: m.Position,
+	}
+}
+
+// handlerValueDeclaration resolves the declaration of a handler passed as a
+// *value* (issue #204): the registration names no method, so the framework's
+// handler interface supplies it. `name` is the rendered handler argument with
+// the package prefix already stripped — either a type name ("H", from
+// `mux.Handle("/x", h)`) or a field path ("Deps.Health", from
+// `r.Method(GET, "/health", deps.Health)`), both of which receiverTypeName
+// resolves to the declaring type.
 //
 // This mirrors LazyTree.handlerValueKeys so the summary and the expanded body
 // agree on which method serves the route: whenever one resolves, so does the
-// other. A value whose type declares no configured handler method yields "",
-// never a same-named method picked from elsewhere.
-func handlerValueComments(route *RouteInfo, name string, handlerMethods ...string) string {
+// other. A value whose type declares no configured handler method resolves to
+// nothing, never to a same-named method picked from elsewhere.
+func handlerValueDeclaration(route *RouteInfo, name string, handlerMethods ...string) (handlerDecl, bool) {
 	if len(handlerMethods) == 0 || name == "" {
-		return ""
+		return handlerDecl{}, false
 	}
 	recv := receiverTypeName(route.Metadata, route.Package, name)
 	for _, hm := range handlerMethods {
 		if m := findMethodByName(route.Metadata, route.Package, recv, hm); m != nil {
-			return getStringFromPool(route.Metadata, m.Comments)
+			return methodDecl(route.Metadata, route.Package, m), true
 		}
 	}
 	// The value may be interface-typed (a field declared `http.Handler`), whose
@@ -1710,18 +2107,18 @@ func handlerValueComments(route *RouteInfo, name string, handlerMethods ...strin
 	}
 	impls := implementersOfExternal(route.Metadata, key)
 	if len(impls) != 1 {
-		return ""
+		return handlerDecl{}, false
 	}
 	i := strings.LastIndexByte(impls[0], '.')
 	if i < 0 {
-		return ""
+		return handlerDecl{}, false
 	}
 	for _, hm := range handlerMethods {
 		if m := findMethodByName(route.Metadata, impls[0][:i], impls[0][i+1:], hm); m != nil {
-			return getStringFromPool(route.Metadata, m.Comments)
+			return methodDecl(route.Metadata, impls[0][:i], m), true
 		}
 	}
-	return ""
+	return handlerDecl{}, false
 }
 
 // valueTypeKey returns the fully-qualified type key ("net/http.Handler") of the
@@ -1757,10 +2154,9 @@ func valueTypeKey(route *RouteInfo, name string) string {
 //	// @Description  Registers a new account.
 //	// @Router       /accounts [post]
 //
-// Only @Summary and @Description are consumed; every other annotation line is
-// dropped rather than swept into the prose (they are structured directives, not
-// description text, and the rest of the pipeline derives those facts from the
-// code itself). A line that does not start with '@' continues the annotation
+// Only @Summary and @Description are consumed here; every other annotation line
+// is dropped rather than swept into the prose — the extractor merges those over
+// the inferred operation (see applyAnnotations). A line that does not start with '@' continues the annotation
 // above it, which is how multi-line descriptions are written.
 //
 // ok is false when the comment carries no annotations at all, so a plain Go doc
@@ -1853,24 +2249,37 @@ func isSpaceByte(b byte) bool { return b == ' ' || b == '\t' || b == '\n' || b =
 // handlerDoc resolves the handler's Go doc comment into an operation summary
 // and description, per the common Go→OpenAPI convention (issue #168): swaggo
 // annotations win when present, otherwise the first sentence is the summary and
-// the remainder the description. Returns empty strings when the handler is
-// anonymous or undocumented — callers keep whatever summary/description they
-// already had.
+// the remainder the description. Directive lines are dropped; doc links and
+// URLs become markdown links in the description and plain text in the
+// summary. Returns empty strings when the handler is anonymous or
+// undocumented — callers keep whatever summary/description they already had.
 func handlerDoc(route *RouteInfo, handlerMethods ...string) (summary, description string) {
 	if route == nil || route.Metadata == nil || route.Function == "" {
 		return "", ""
 	}
-	doc := handlerComments(route, handlerMethods...)
+	doc, pkg := handlerComments(route, handlerMethods...)
+	links, doc := newDocLinker(route.Metadata, pkg, stripDocDirectives(doc))
 	if doc == "" {
 		return "", ""
 	}
 	if s, d, ok := swaggoDoc(doc); ok {
-		return s, d
+		summary, description = s, d
+	} else if summary, description = splitSynopsis(doc); summary == "" {
+		summary, description = doc, ""
 	}
-	if summary, description = splitSynopsis(doc); summary != "" {
-		return summary, description
+	return links.plain(summary), links.markdown(description)
+}
+
+// declDoc renders the Go doc comment of a type or struct field declared in
+// pkgPath as a schema description: directives dropped, doc links and URLs
+// turned into markdown links. A field's trailing line comment counts as its
+// doc.
+func declDoc(meta *metadata.Metadata, pkgPath string, comments int) string {
+	links, doc := newDocLinker(meta, pkgPath, stripDocDirectives(getStringFromPool(meta, comments)))
+	if doc == "" {
+		return ""
 	}
-	return doc, ""
+	return links.markdown(doc)
 }
 
 // ValidationConstraints represents validation constraints extracted from struct tags
@@ -1883,6 +2292,16 @@ type ValidationConstraints struct {
 	Pattern   string
 	Required  bool
 	Enum      []interface{}
+	// ExclusiveMin/ExclusiveMax mark Min/Max as strict bounds (gt/lt): an
+	// exclusive numeric bound, or one more/less than the length or item count.
+	ExclusiveMin bool
+	ExclusiveMax bool
+	// UniqueItems is set by `unique` on a slice.
+	UniqueItems bool
+	// Conditions keeps rules that make the field required (or excluded) only
+	// relative to other fields — required_if, required_without, ... — which a
+	// static schema cannot express. They are documented on the field instead.
+	Conditions []string
 	// Dive holds the constraints that follow a `dive` token in a validator tag;
 	// they apply to the ELEMENTS of a slice/map rather than the container
 	// (issue #165). Nil when the tag has no `dive`.
@@ -1922,6 +2341,9 @@ func extractValidationConstraints(tag string) *ValidationConstraints {
 			// the loop below.
 			if before, after, found := splitOnDive(validateTag); found {
 				validateTag = before
+				// Map-key rules (dive,keys,...,endkeys,...) constrain the keys,
+				// which a schema cannot express; keep the value rules.
+				after = dropDiveKeys(after)
 				if trimmed := strings.Trim(after, ", "); trimmed != "" {
 					constraints.Dive = extractValidationConstraints(`validate:"` + trimmed + `"`)
 				}
@@ -1938,16 +2360,22 @@ func extractValidationConstraints(tag string) *ValidationConstraints {
 				rule := strings.TrimSpace(ruleSet[1])
 				if rule == "required" {
 					constraints.Required = true
-				} else if strings.HasPrefix(rule, "min=") {
-					if val, err := strconv.Atoi(strings.TrimPrefix(rule, "min=")); err == nil {
-						// For numeric validation, use Min instead of MinLength
-						constraints.Min = &[]float64{float64(val)}[0]
-					}
-				} else if strings.HasPrefix(rule, "max=") {
-					if val, err := strconv.Atoi(strings.TrimPrefix(rule, "max=")); err == nil {
-						// For numeric validation, use Max instead of MaxLength
-						constraints.Max = &[]float64{float64(val)}[0]
+				} else if name, value, ok := strings.Cut(rule, "="); ok && boundRules[name] {
+					// min/gte/gt and max/lte/lt: a value range for numbers, a
+					// length for strings, an item count for slices — which one
+					// is decided by the schema type in applyValidationConstraints.
+					if val, err := strconv.ParseFloat(value, 64); err == nil {
+						switch name {
+						case "min", "gte", "gt":
+							constraints.Min = &val
+							constraints.ExclusiveMin = name == "gt"
+						default:
+							constraints.Max = &val
+							constraints.ExclusiveMax = name == "lt"
+						}
 					}
+				} else if conditionalRules[name] {
+					constraints.Conditions = append(constraints.Conditions, rule)
 				} else if strings.HasPrefix(rule, "len=") {
 					// Length validation for strings, arrays, slices
 					if val, err := strconv.Atoi(strings.TrimPrefix(rule, "len=")); err == nil {
@@ -1968,11 +2396,34 @@ func extractValidationConstraints(tag string) *ValidationConstraints {
 					constraints.Pattern = strings.TrimPrefix(rule, "regexp=")
 				} else if strings.HasPrefix(rule, "oneof=") {
 					// One of validation - creates enum values
-					enumPart := strings.TrimPrefix(rule, "oneof=")
-					enumValues := strings.Split(enumPart, " ")
-					for _, val := range enumValues {
-						constraints.Enum = append(constraints.Enum, strings.TrimSpace(val))
+					for _, val := range splitOneOf(strings.TrimPrefix(rule, "oneof=")) {
+						constraints.Enum = append(constraints.Enum, val)
+					}
+				} else if rule == "unique" {
+					constraints.UniqueItems = true
+				} else if rule == "uri" || rule == "http_url" {
+					constraints.Format = `uri`
+				} else if rule == "uuid_rfc4122" {
+					constraints.Format = `uuid`
+				} else if strings.HasPrefix(rule, "datetime=") {
+					// A Go time layout: date-only, time-only and RFC 3339
+					// layouts have formats; any other layout is left
+					// unconstrained.
+					if format := layoutFormat(strings.TrimPrefix(rule, "datetime=")); format != "" {
+						constraints.Format = format
 					}
+				} else if strings.HasPrefix(rule, "startswith=") {
+					constraints.Pattern = "^" + regexp.QuoteMeta(strings.TrimPrefix(rule, "startswith="))
+				} else if strings.HasPrefix(rule, "endswith=") {
+					constraints.Pattern = regexp.QuoteMeta(strings.TrimPrefix(rule, "endswith=")) + "$"
+				} else if strings.HasPrefix(rule, "contains=") {
+					constraints.Pattern = regexp.QuoteMeta(strings.TrimPrefix(rule, "contains="))
+				} else if rule == "lowercase" {
+					constraints.Pattern = `^[^\p{Lu}]*$`
+				} else if rule == "uppercase" {
+					constraints.Pattern = `^[^\p{Ll}]*$`
+				} else if rule == "e164" {
+					constraints.Pattern = `^\+[1-9]?[0-9]{7,14}$`
 				} else if rule == "email" {
 					// Email validation - set pattern
 					constraints.Format = `email`
@@ -2187,6 +2638,7 @@ func extractValidationConstraints(tag string) *ValidationConstraints {
 		constraints.Min == nil && constraints.Max == nil &&
 		constraints.Pattern == "" && constraints.Format == "" &&
 		!constraints.Required && len(constraints.Enum) == 0 &&
+		!constraints.UniqueItems && len(constraints.Conditions) == 0 &&
 		constraints.Dive == nil {
 		return nil
 	}
@@ -2194,6 +2646,90 @@ func extractValidationConstraints(tag string) *ValidationConstraints {
 	return constraints
 }
 
+// boundRules are the go-playground/validator rules that bound a value, length
+// or item count from below (min, gte, gt) or above (max, lte, lt).
+var boundRules = map[string]bool{"min": true, "gte": true, "gt": true, "max": true, "lte": true, "lt": true}
+
+// conditionalRules make a field required or excluded depending on other
+// fields; see ValidationConstraints.Conditions.
+var conditionalRules = map[string]bool{
+	"required_if": true, "required_unless": true,
+	"required_with": true, "required_with_all": true,
+	"required_without": true, "required_without_all": true,
+	"excluded_if": true, "excluded_unless": true,
+	"excluded_with": true, "excluded_with_all": true,
+	"excluded_without": true, "excluded_without_all": true,
+}
+
+// splitOneOf splits a oneof value list on spaces, keeping single-quoted
+// values (oneof='red green' blue) whole.
+func splitOneOf(list string) []string {
+	var out []string
+	for list = strings.TrimSpace(list); list != ""; list = strings.TrimSpace(list) {
+		if list[0] == '\'' {
+			if end := strings.IndexByte(list[1:], '\''); end >= 0 {
+				out = append(out, list[1:end+1])
+				list = list[end+2:]
+				continue
+			}
+		}
+		val, rest, _ := strings.Cut(list, " ")
+		out = append(out, val)
+		list = rest
+	}
+	return out
+}
+
+// dropDiveKeys removes a leading keys,...,endkeys section from post-dive rules.
+func dropDiveKeys(rules string) string {
+	trimmed := strings.TrimLeft(rules, ", ")
+	if !strings.HasPrefix(trimmed, "keys") {
+		return rules
+	}
+	if _, after, ok := strings.Cut(trimmed, "endkeys"); ok {
+		return after
+	}
+	return ""
+}
+
+// typedEnum converts oneof values to the schema's type, so an integer field
+// enumerates numbers rather than their spellings. Values that do not parse
+// are kept as written.
+func typedEnum(schemaType string, values []interface{}) []interface{} {
+	if schemaType != "integer" && schemaType != "number" {
+		return values
+	}
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v
+		str, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if schemaType == "integer" {
+			if n, err := strconv.ParseInt(str, 10, 64); err == nil {
+				out[i] = n
+			}
+		} else if f, err := strconv.ParseFloat(str, 64); err == nil {
+			out[i] = f
+		}
+	}
+	return out
+}
+
+// strictBound turns an exclusive length or count bound into the inclusive
+// integer one JSON Schema expresses: gt=3 is minLength 4, lt=3 is maxLength 2.
+func strictBound(v float64, exclusive bool, delta int) int {
+	if exclusive {
+		return int(v) + delta
+	}
+	return int(v)
+}
+
+// float64Ptr returns a pointer to v, for the optional numeric bounds of a
+// schema.
+func float64Ptr(v float64) *float64 { return &v }
+
 // applyValidationConstraints applies validation constraints to an OpenAPI schema
 func applyValidationConstraints(schema *Schema, constraints *ValidationConstraints) {
 	if schema == nil || constraints == nil {
@@ -2208,29 +2744,40 @@ func applyValidationConstraints(schema *Schema, constraints *ValidationConstrain
 		if constraints.MinLength != nil {
 			schema.MinLength = *constraints.MinLength
 		} else if constraints.Min != nil {
-			schema.MinLength = int(*constraints.Min)
+			schema.MinLength = strictBound(*constraints.Min, constraints.ExclusiveMin, 1)
 		}
 		if constraints.MaxLength != nil {
 			schema.MaxLength = *constraints.MaxLength
 		} else if constraints.Max != nil {
-			schema.MaxLength = int(*constraints.Max)
+			schema.MaxLength = strictBound(*constraints.Max, constraints.ExclusiveMax, -1)
 		}
 	}
 
 	// Apply numeric constraints (for integer and number types)
 	if schema.Type == "integer" || schema.Type == "number" {
+		// gt/lt are the numeric exclusiveMinimum/exclusiveMaximum of JSON
+		// Schema 2020-12; a 3.0 document writes them back as booleans (see
+		// markBooleanBounds).
 		if constraints.Min != nil {
-			schema.Minimum = *constraints.Min
+			if constraints.ExclusiveMin {
+				schema.Minimum, schema.ExclusiveMinimum = nil, float64Ptr(*constraints.Min)
+			} else {
+				schema.Minimum, schema.ExclusiveMinimum = float64Ptr(*constraints.Min), nil
+			}
 		}
 		if constraints.Max != nil {
-			schema.Maximum = *constraints.Max
+			if constraints.ExclusiveMax {
+				schema.Maximum, schema.ExclusiveMaximum = nil, float64Ptr(*constraints.Max)
+			} else {
+				schema.Maximum, schema.ExclusiveMaximum = float64Ptr(*constraints.Max), nil
+			}
 		}
 		// Also check min/max from validate tags for numeric types
 		if constraints.MinLength != nil && schema.Type == "integer" {
-			schema.Minimum = float64(*constraints.MinLength)
+			schema.Minimum = float64Ptr(float64(*constraints.MinLength))
 		}
 		if constraints.MaxLength != nil && schema.Type == "integer" {
-			schema.Maximum = float64(*constraints.MaxLength)
+			schema.Maximum = float64Ptr(float64(*constraints.MaxLength))
 		}
 	}
 
@@ -2241,12 +2788,15 @@ func applyValidationConstraints(schema *Schema, constraints *ValidationConstrain
 		if constraints.MinLength != nil {
 			schema.MinItems = *constraints.MinLength
 		} else if constraints.Min != nil {
-			schema.MinItems = int(*constraints.Min)
+			schema.MinItems = strictBound(*constraints.Min, constraints.ExclusiveMin, 1)
 		}
 		if constraints.MaxLength != nil {
 			schema.MaxItems = *constraints.MaxLength
 		} else if constraints.Max != nil {
-			schema.MaxItems = int(*constraints.Max)
+			schema.MaxItems = strictBound(*constraints.Max, constraints.ExclusiveMax, -1)
+		}
+		if constraints.UniqueItems {
+			schema.UniqueItems = true
 		}
 		// Post-`dive` constraints apply to each element (issue #165). Clone the
 		// item schema before mutating it: even though promoted element types
@@ -2273,22 +2823,71 @@ func applyValidationConstraints(schema *Schema, constraints *ValidationConstrain
 	if len(constraints.Enum) > 0 {
 		switch schema.Type {
 		case "array":
-			schema.Items.Enum = constraints.Enum
+			if schema.Items != nil {
+				schema.Items.Enum = typedEnum(schema.Items.Type, constraints.Enum)
+			}
 		case "object":
 			if schema.AdditionalProperties != nil {
-				schema.AdditionalProperties.Enum = constraints.Enum
+				schema.AdditionalProperties.Enum = typedEnum(schema.AdditionalProperties.Type, constraints.Enum)
 			}
 		default:
-			schema.Enum = constraints.Enum
+			schema.Enum = typedEnum(schema.Type, constraints.Enum)
 		}
 	}
+
+	// Post-`dive` constraints on a map apply to its values; cloned for the
+	// same reason as array items above.
+	if schema.Type == "object" && constraints.Dive != nil && schema.AdditionalProperties != nil {
+		schema.AdditionalProperties = cloneSchema(schema.AdditionalProperties)
+		applyValidationConstraints(schema.AdditionalProperties, constraints.Dive)
+	}
+
+	// Requirements relative to other fields cannot be expressed per field;
+	// document them so the rule is not silently lost.
+	if len(constraints.Conditions) > 0 {
+		schema.Description = appendConstraintNote(schema.Description,
+			"Conditionally required: "+strings.Join(constraints.Conditions, ", "))
+	}
 }
 
 // detectEnumFromConstants detects if a type has associated constants that form an enum
 // This is a generic implementation using enhanced metadata with types.Info
 func detectEnumFromConstants(goType string, pkgName string, meta *metadata.Metadata) []interface{} {
+	return detectConstantEnum(goType, pkgName, meta).Values
+}
+
+// constantEnum is the enum a named type's const block declares.
+type constantEnum struct {
+	Values []interface{}
+	// Names are the constants declaring Values, index for index.
+	Names []string
+}
+
+// apply sets the enum on s, with the constant names as x-enum-varnames so
+// client generators name the members after the Go constants.
+func (c constantEnum) apply(s *Schema) {
+	if s == nil || len(c.Values) == 0 {
+		return
+	}
+	s.Enum = c.Values
+	if len(c.Names) != len(c.Values) {
+		return
+	}
+	// Copy the extensions: s may be a shallow clone of a shared schema.
+	extensions := maps.Clone(s.Extensions)
+	if extensions == nil {
+		extensions = map[string]interface{}{}
+	}
+	extensions["x-enum-varnames"] = c.Names
+	s.Extensions = extensions
+}
+
+// detectConstantEnum finds the constants declared with goType (the typed
+// constant and the iota constants following it in its block) and returns
+// the largest block's values and names.
+func detectConstantEnum(goType string, pkgName string, meta *metadata.Metadata) constantEnum {
 	if meta == nil {
-		return nil
+		return constantEnum{}
 	}
 
 	var goTypePkgName string
@@ -2298,8 +2897,10 @@ func detectEnumFromConstants(goType string, pkgName string, meta *metadata.Metad
 		goType = core.Name
 	}
 
-	// Group constants by their resolved type and group index
-	constantGroups := make(map[string]map[int][]EnumConstant)
+	// Group constants by their const block. Only the block's first constant
+	// carries the type (`PriorityLow Priority = iota`), so the block, not the
+	// declared type, is what keeps an iota enum together.
+	constantGroups := make(map[int][]EnumConstant)
 
 	targetPkgName := pkgName
 	if goTypePkgName != "" {
@@ -2327,11 +2928,6 @@ func detectEnumFromConstants(goType string, pkgName string, meta *metadata.Metad
 					if typeMatches(targetType, goType, meta) ||
 						(varType == "" && isInSameGroupAsTypedConstant(variable.GroupIndex, goType, file.Variables, meta)) {
 						groupIndex := variable.GroupIndex
-
-						if constantGroups[targetType] == nil {
-							constantGroups[targetType] = make(map[int][]EnumConstant)
-						}
-
 						enumConst := EnumConstant{
 							Name:     varName,
 							Type:     varType,
@@ -2340,30 +2936,22 @@ func detectEnumFromConstants(goType string, pkgName string, meta *metadata.Metad
 							Group:    groupIndex,
 						}
 
-						constantGroups[targetType][groupIndex] = append(
-							constantGroups[targetType][groupIndex],
-							enumConst,
-						)
+						constantGroups[groupIndex] = append(constantGroups[groupIndex], enumConst)
 					}
 				}
 			}
 		}
 	}
 
-	// Find the best enum group for this type
-	var bestEnumValues []interface{}
-	var maxGroupSize int
-
-	for _, groups := range constantGroups {
-		for _, group := range groups {
-			if len(group) > maxGroupSize {
-				maxGroupSize = len(group)
-				bestEnumValues = extractEnumValues(group)
-			}
+	// Find the best enum group for this type; ties go to the first block.
+	var best []EnumConstant
+	for _, groupIndex := range slices.Sorted(maps.Keys(constantGroups)) {
+		if group := constantGroups[groupIndex]; len(group) > len(best) {
+			best = group
 		}
 	}
-
-	return bestEnumValues
+	values, names := extractEnum(best)
+	return constantEnum{Values: values, Names: names}
 }
 
 // EnumConstant represents a constant that might be part of an enum
@@ -2377,7 +2965,18 @@ type EnumConstant struct {
 
 // extractEnumValues extracts the actual values from enum constants
 func extractEnumValues(constants []EnumConstant) []interface{} {
-	var values []interface{}
+	values, _ := extractEnum(constants)
+	return values
+}
+
+// extractEnum extracts the values of enum constants and the constants' names,
+// sorted by value (numerically for numbers) so the order is stable.
+func extractEnum(constants []EnumConstant) ([]interface{}, []string) {
+	type member struct {
+		value interface{}
+		name  string
+	}
+	var members []member
 
 	for _, constant := range constants {
 		if constant.Value != nil {
@@ -2386,27 +2985,58 @@ func extractEnumValues(constants []EnumConstant) []interface{} {
 			case *types.Const:
 				// Handle types.Const values
 				if v.Val() != nil {
-					extracted := extractConstantValue(v.Val())
-					values = append(values, extracted)
+					members = append(members, member{extractConstantValue(v.Val()), constant.Name})
 				}
 			default:
 				// The values are already in their proper form (string, int, etc.)
 				// Just extract them using our helper function
-				extracted := extractConstantValue(v)
-				values = append(values, extracted)
+				members = append(members, member{extractConstantValue(v), constant.Name})
 			}
 		}
 	}
 
 	// Sort the values to ensure consistent order
-	sort.Slice(values, func(i, j int) bool {
-		// Convert to strings for comparison
-		valI := fmt.Sprintf("%v", values[i])
-		valJ := fmt.Sprintf("%v", values[j])
-		return valI < valJ
+	sort.SliceStable(members, func(i, j int) bool {
+		if c := compareEnumValues(members[i].value, members[j].value); c != 0 {
+			return c < 0
+		}
+		return members[i].name < members[j].name
 	})
 
-	return values
+	if len(members) == 0 {
+		return nil, nil
+	}
+	values := make([]interface{}, len(members))
+	names := make([]string, len(members))
+	for i, m := range members {
+		values[i] = m.value
+		names[i] = m.name
+	}
+	return values, names
+}
+
+// enumNumber returns v as a float64 when it is numeric.
+func enumNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// compareEnumValues orders enum values numerically when both are numbers
+// and by their text otherwise.
+func compareEnumValues(a, b interface{}) int {
+	x, xNum := enumNumber(a)
+	y, yNum := enumNumber(b)
+	if xNum && yNum && x != y {
+		return cmp.Compare(x, y)
+	}
+	return strings.Compare(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
 }
 
 // extractConstantValue extracts the actual value from a constant.Value
@@ -2538,12 +3168,14 @@ func mapGoTypeToOpenAPISchema(usedTypes map[string]*Schema, goType string, meta
 	}
 
 	// Check external types (emitted as named components by generateSchemas).
-	if cfg != nil {
-		for _, externalType := range cfg.ExternalTypes {
-			if externalType.Name == goType {
-				schemas[goType] = externalType.OpenAPIType
-			}
-		}
+	if externalType := cfg.externalType(goType); externalType != nil {
+		schemas[goType] = externalType.OpenAPIType
+	} else if dynamic := dynamicGoType(goType); dynamic != "" {
+		// any, interface{} and json.RawMessage hold any JSON value; how
+		// they are documented is schemas.dynamicValues' choice.
+		s, extra := dynamicValueSchema(usedTypes, dynamic, cfg)
+		maps.Copy(schemas, extra)
+		return s, schemas
 	}
 
 	// Resolve well-known / marshaler-based external types (uuid.UUID,
@@ -2664,12 +3296,12 @@ func mapGoTypeToOpenAPISchema(usedTypes map[string]*Schema, goType string, meta
 			}
 
 			// Detect enum values for this element type
-			if enumValues := detectEnumFromConstants(elementType, pkgName, meta); len(enumValues) > 0 {
+			if enum := detectConstantEnum(elementType, pkgName, meta); len(enum.Values) > 0 {
 				// Apply enum values to the stored schema if it exists
 				if storedSchema, exists := schemas[resolvedType]; exists {
-					storedSchema.Enum = enumValues
+					enum.apply(storedSchema)
 				} else {
-					items.Enum = enumValues
+					enum.apply(items)
 				}
 			}
 		}
@@ -2700,11 +3332,13 @@ func mapGoTypeToOpenAPISchema(usedTypes map[string]*Schema, goType string, meta
 			// "pkg.interface{}", falls into the unresolved-external
 			// branch, and emits a $ref to a component nothing
 			// populates (the Redoc "Invalid reference token" error).
+			// The qualifier a struct field's type is given already ends in
+			// its dot (pkg.map[string]Node), which must not double.
 			if startIdx > 0 && !metadata.IsPrimitiveType(valueType) {
-				valueType = goType[:startIdx] + "." + valueType
+				valueType = strings.TrimSuffix(goType[:startIdx], ".") + "." + valueType
 			}
 
-			if keyType == "string" {
+			if jsonObjectKey(keyType, cfg, meta) {
 				var resolvedType string
 				if resolvedType = resolveUnderlyingType(valueType, meta); resolvedType == "" {
 					resolvedType = valueType
@@ -2730,14 +3364,14 @@ func mapGoTypeToOpenAPISchema(usedTypes map[string]*Schema, goType string, meta
 					}
 
 					// Detect enum values for this value type
-					if enumValues := detectEnumFromConstants(valueType, pkgName, meta); len(enumValues) > 0 {
+					if enum := detectConstantEnum(valueType, pkgName, meta); len(enum.Values) > 0 {
 						// Apply enum values to the stored schema if it exists
 						if storedSchema, exists := usedTypes[resolvedType]; exists && storedSchema != nil {
-							storedSchema.Enum = enumValues
+							enum.apply(storedSchema)
 						} else if storedSchema, exists := schemas[resolvedType]; exists {
-							storedSchema.Enum = enumValues
+							enum.apply(storedSchema)
 						} else {
-							additionalProperties.Enum = enumValues
+							enum.apply(additionalProperties)
 						}
 					}
 				}
@@ -2749,7 +3383,7 @@ func mapGoTypeToOpenAPISchema(usedTypes map[string]*Schema, goType string, meta
 
 				return schema, schemas
 			}
-			// Non-string keys are not supported in OpenAPI, fallback to generic object
+			// Other keys (structs, floats, …) fail to marshal; fall back to a generic object
 			schema = &Schema{Type: "object"}
 
 			return schema, schemas
@@ -2857,14 +3491,14 @@ func mapGoTypeToOpenAPISchema(usedTypes map[string]*Schema, goType string, meta
 	case "int", "int8", "int16", "int32", "int64":
 		return &Schema{Type: "integer"}, schemas
 	case "uint", "uint8", "uint16", "uint32", "uint64", "byte":
-		return &Schema{Type: "integer", Minimum: 0}, schemas
+		return &Schema{Type: "integer", Minimum: float64Ptr(0)}, schemas
 	case "float32", "float64":
 		return &Schema{Type: "number"}, schemas
 	case "bool":
 		return &Schema{Type: "boolean"}, schemas
 	case "time.Time":
 		return &Schema{Type: "string", Format: "date-time"}, schemas
-	case "interface{}", "struct{}", "any":
+	case "struct{}":
 		return &Schema{Type: "object"}, schemas
 	default:
 		// For custom types, check if it's a struct in metadata
@@ -2908,11 +3542,31 @@ func mapGoTypeToOpenAPISchema(usedTypes map[string]*Schema, goType string, meta
 	}
 }
 
+// jsonObjectKey reports whether encoding/json writes a map keyed by keyType
+// as a JSON object: string and integer keys, and keys a text marshaler
+// turns into strings (uuid.UUID, …), including named types of those.
+func jsonObjectKey(keyType string, cfg *APISpecConfig, meta *metadata.Metadata) bool {
+	if resolved := resolveUnderlyingType(keyType, meta); resolved != "" {
+		keyType = resolved
+	}
+	switch keyType {
+	case "string", "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte", "rune":
+		return true
+	}
+	return isInlineExternalType(keyType, cfg, meta)
+}
+
 func canAddRefSchemaForType(key string) bool {
 	if metadata.IsPrimitiveType(key) || strings.HasPrefix(key, "[]") || strings.Contains(key, "map[") {
 		return false
 	}
 
+	// json.RawMessage is documented in place (see dynamicValueSchema).
+	if dynamicGoType(key) != "" {
+		return false
+	}
+
 	// Exclude _nested types from reference schema generation
 	if strings.HasSuffix(key, "_nested") {
 		return false
@@ -2982,8 +3636,8 @@ func schemaFromAnonStructLiteral(usedTypes map[string]*Schema, goType string, me
 		maps.Copy(schemas, newSchemas)
 
 		propName := name
-		if jsonName := extractJSONName(tag); jsonName != "" {
-			propName = jsonName
+		if tagName := propertyName(cfg, tag); tagName != "" {
+			propName = tagName
 		}
 		if schema.Properties == nil {
 			schema.Properties = map[string]*Schema{}
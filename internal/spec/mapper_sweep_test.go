@@ -15,6 +15,7 @@
 package spec
 
 import (
+	"encoding/json"
 	"go/constant"
 	"go/token"
 	"go/types"
@@ -358,6 +359,11 @@ func TestSubstituteTypeParams(t *testing.T) {
 		{"pointer param", "*T", map[string]string{"T": "User"}, "*User"},
 		{"slice of pointer param", "[]*T", map[string]string{"T": "User"}, "[]*User"},
 		{"unrelated type unchanged", "*Other", map[string]string{"T": "User"}, "*Other"},
+		{"self reference", "*Page[T]", map[string]string{"T": "User"}, "*Page[User]"},
+		{"slice of self", "[]Tree[T]", map[string]string{"T": "int"}, "[]Tree[int]"},
+		{"swapped params", "*Edge[V, K]", map[string]string{"K": "string", "V": "int"}, "*Edge[int, string]"},
+		{"map value", "map[K]Edge[K, V]", map[string]string{"K": "string", "V": "int"}, "map[string]Edge[string, int]"},
+		{"qualified name is no param", "*pkg.T", map[string]string{"T": "User"}, "*pkg.T"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -368,6 +374,47 @@ func TestSubstituteTypeParams(t *testing.T) {
 	}
 }
 
+func TestQualifyLocalTypes(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldType string
+		want      string
+	}{
+		{"plain", "Item", "pkg.Item"},
+		{"nested slice", "[][]Item", "[][]pkg.Item"},
+		{"map of pointer", "map[string]*Item", "map[string]*pkg.Item"},
+		{"slice of map", "[]map[string]Item", "[]map[string]pkg.Item"},
+		{"array of slice", "[2][]Item", "[2][]pkg.Item"},
+		{"named key", "map[Shelf]Item", "map[pkg.Shelf]pkg.Item"},
+		{"builtins unchanged", "[][]string", "[][]string"},
+		{"qualified unchanged", "map[string]other.Item", "map[string]other.Item"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := qualifyLocalTypes(tt.fieldType, "pkg"); got != tt.want {
+				t.Errorf("qualifyLocalTypes(%q) = %q, want %q", tt.fieldType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONObjectKey(t *testing.T) {
+	meta, _ := sweepMeta(t)
+	cfg := DefaultAPISpecConfig()
+	for key, want := range map[string]bool{
+		"string":           true,
+		"int64":            true,
+		"uint8":            true,
+		"float64":          false,
+		"bool":             false,
+		"main.Page[T any]": false,
+	} {
+		if got := jsonObjectKey(key, cfg, meta); got != want {
+			t.Errorf("jsonObjectKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
 func TestGenerateStructSchema_DeclarationFormGenerics(t *testing.T) {
 	meta, _ := sweepMeta(t)
 	page := meta.Packages["main"].Files["main.go"].Types["Page"]
@@ -640,8 +687,8 @@ func TestApplyValidationConstraints_Branches(t *testing.T) {
 	t.Run("integer length bounds become min/max", func(t *testing.T) {
 		s := &Schema{Type: "integer"}
 		applyValidationConstraints(s, &ValidationConstraints{MinLength: &minLen, MaxLength: &maxLen})
-		if s.Minimum != 2 || s.Maximum != 8 {
-			t.Errorf("got minimum/maximum %v/%v, want 2/8", s.Minimum, s.Maximum)
+		if !boundIs(s.Minimum, 2) || !boundIs(s.Maximum, 8) {
+			t.Errorf("got minimum/maximum %v/%v, want 2/8", bound(s.Minimum), bound(s.Maximum))
 		}
 	})
 }
@@ -660,6 +707,38 @@ func TestExtractEnumValues_TypesConst(t *testing.T) {
 	}
 }
 
+func TestExtractEnum_NumericOrderAndNames(t *testing.T) {
+	mk := func(name string, val int64) EnumConstant {
+		return EnumConstant{Name: name, Value: constant.MakeInt64(val)}
+	}
+	values, names := extractEnum([]EnumConstant{mk("Ten", 10), mk("Two", 2), mk("Zero", 0)})
+	if want := []interface{}{int64(0), int64(2), int64(10)}; !reflect.DeepEqual(values, want) {
+		t.Errorf("values = %v, want %v", values, want)
+	}
+	if want := []string{"Zero", "Two", "Ten"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+func TestConstantEnumApply(t *testing.T) {
+	shared := map[string]interface{}{"x-other": true}
+	s := &Schema{Type: "integer", Extensions: shared}
+	constantEnum{Values: []interface{}{int64(0), int64(1)}, Names: []string{"Low", "High"}}.apply(s)
+	if got := s.Extensions["x-enum-varnames"]; !reflect.DeepEqual(got, []string{"Low", "High"}) {
+		t.Errorf("x-enum-varnames = %v", got)
+	}
+	if _, ok := shared["x-enum-varnames"]; ok {
+		t.Error("apply wrote into the shared extensions map")
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"integer","enum":[0,1],"x-enum-varnames":["Low","High"],"x-other":true}`; string(data) != want {
+		t.Errorf("json = %s, want %s", data, want)
+	}
+}
+
 func TestTypeMatches_AliasResolvesToTarget(t *testing.T) {
 	meta, _ := sweepMeta(t)
 	if !typeMatches("Status", "string", meta) {
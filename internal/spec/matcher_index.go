@@ -0,0 +1,211 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"regexp/syntax"
+	"slices"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// maxIndexedNames caps how many callee names one pattern may expand to
+// before it is treated as unindexable. Framework call regexes are short
+// alternations (^(GET|POST|...)$); anything larger is rare enough that a
+// linear check is cheaper than the expansion.
+const maxIndexedNames = 64
+
+// MatcherStats counts pattern matcher work during one extraction. It is
+// exposed in the --custom-metrics output to verify the effect of the callee
+// index on large trees.
+type MatcherStats struct {
+	// Evaluations is the number of MatchNode/MatchEdge calls made.
+	Evaluations int64
+	// Skipped is the number of matcher checks the callee index avoided:
+	// matchers whose call regex cannot accept the edge's callee name.
+	Skipped int64
+	// Memoized is the number of lookups answered by the per-edge verdict
+	// memos without consulting any matcher.
+	Memoized int64
+}
+
+// calleeIndex maps a callee name to the matchers, by index in their family's
+// slice, whose call regex can accept it. A call regex that is an anchored
+// finite set of names (^(GET|POST)$, ^Bind$) is indexed under each name;
+// any other regex, or none, makes its matcher a candidate for every callee.
+// Candidates keep matcher order, since the families pick the first or the
+// highest-priority match and ties go to the earlier matcher.
+type calleeIndex struct {
+	byName map[string][]int16
+	always []int16
+	size   int
+}
+
+// newCalleeIndex indexes the call regexes of a matcher family, in matcher
+// order.
+func newCalleeIndex(callRegexes []string) *calleeIndex {
+	x := &calleeIndex{byName: map[string][]int16{}, size: len(callRegexes)}
+	for i, re := range callRegexes {
+		names, ok := literalNames(re)
+		if !ok {
+			x.always = append(x.always, int16(i))
+			continue
+		}
+		for _, name := range names {
+			x.byName[name] = append(x.byName[name], int16(i))
+		}
+	}
+	// Fold the unindexed matchers into every named list once, so a lookup is
+	// a single map read.
+	for name, idxs := range x.byName {
+		merged := append(idxs, x.always...)
+		slices.Sort(merged)
+		x.byName[name] = slices.Compact(merged)
+	}
+	return x
+}
+
+// candidates returns the indexes of the matchers that may accept callee, in
+// matcher order.
+func (x *calleeIndex) candidates(callee string) []int16 {
+	if idxs, ok := x.byName[callee]; ok {
+		return idxs
+	}
+	return x.always
+}
+
+// literalNames returns the complete set of strings an anchored regex
+// matches, when that set is small and finite. ok is false for an empty,
+// invalid, unanchored or case-folding pattern, or one whose language is
+// too large to enumerate.
+func literalNames(pattern string) (names []string, ok bool) {
+	if pattern == "" {
+		return nil, false
+	}
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, false
+	}
+	re = re.Simplify()
+	if re.Op != syntax.OpConcat || len(re.Sub) < 2 {
+		return nil, false
+	}
+	first, last := re.Sub[0], re.Sub[len(re.Sub)-1]
+	if (first.Op != syntax.OpBeginText && first.Op != syntax.OpBeginLine) ||
+		(last.Op != syntax.OpEndText && last.Op != syntax.OpEndLine) {
+		return nil, false
+	}
+	return regexLanguage(&syntax.Regexp{Op: syntax.OpConcat, Sub: re.Sub[1 : len(re.Sub)-1]})
+}
+
+// regexLanguage enumerates the strings re matches, for the finite,
+// case-sensitive subset of regex syntax the parser produces from
+// alternations of names.
+func regexLanguage(re *syntax.Regexp) ([]string, bool) {
+	if re.Flags&syntax.FoldCase != 0 {
+		return nil, false
+	}
+	switch re.Op {
+	case syntax.OpEmptyMatch:
+		return []string{""}, true
+	case syntax.OpLiteral:
+		return []string{string(re.Rune)}, true
+	case syntax.OpCapture:
+		return regexLanguage(re.Sub[0])
+	case syntax.OpCharClass:
+		var out []string
+		for i := 0; i < len(re.Rune); i += 2 {
+			for r := re.Rune[i]; r <= re.Rune[i+1]; r++ {
+				if len(out) == maxIndexedNames {
+					return nil, false
+				}
+				out = append(out, string(r))
+			}
+		}
+		return out, true
+	case syntax.OpQuest:
+		sub, ok := regexLanguage(re.Sub[0])
+		if !ok {
+			return nil, false
+		}
+		return append([]string{""}, sub...), true
+	case syntax.OpAlternate:
+		var out []string
+		for _, s := range re.Sub {
+			sub, ok := regexLanguage(s)
+			if !ok || len(out)+len(sub) > maxIndexedNames {
+				return nil, false
+			}
+			out = append(out, sub...)
+		}
+		return out, true
+	case syntax.OpConcat:
+		out := []string{""}
+		for _, s := range re.Sub {
+			sub, ok := regexLanguage(s)
+			if !ok || len(out)*len(sub) > maxIndexedNames {
+				return nil, false
+			}
+			var next []string
+			for _, prefix := range out {
+				for _, suffix := range sub {
+					next = append(next, prefix+suffix)
+				}
+			}
+			out = next
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+// precompileRegexes compiles the non-empty patterns into the shared regex
+// cache, so matching never pays for a compile. An invalid pattern is left
+// for the matcher to reject, as before.
+func precompileRegexes(patterns ...string) {
+	for _, p := range patterns {
+		if p != "" {
+			_, _ = cachedRegex(p)
+		}
+	}
+}
+
+// candidateMatchers returns the matchers of the family x indexes that may
+// accept edge, counting the ones it rules out. A nil edge matches nothing.
+func (e *Extractor) candidateMatchers(x *calleeIndex, edge *metadata.CallGraphEdge) []int16 {
+	if edge == nil {
+		return nil
+	}
+	idxs := x.candidates(e.contextProvider.GetString(edge.Callee.Name))
+	e.matcherStats.Skipped += int64(x.size - len(idxs))
+	return idxs
+}
+
+// firstMatcher returns the first candidate of node's edge that match
+// accepts, or -1, counting each evaluation.
+func (e *Extractor) firstMatcher(x *calleeIndex, node TrackerNodeInterface, match func(int16) bool) int16 {
+	for _, i := range e.candidateMatchers(x, node.GetEdge()) {
+		e.matcherStats.Evaluations++
+		if match(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// MatcherStats returns the pattern matcher work done by the extractor so far.
+func (e *Extractor) MatcherStats() MatcherStats {
+	return e.matcherStats
+}
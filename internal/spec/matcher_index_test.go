@@ -0,0 +1,112 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestLiteralNames(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []string // nil: not indexable
+	}{
+		{`^Bind$`, []string{"Bind"}},
+		{`^(GET|POST|Get|Group)$`, []string{"GET", "POST", "Get", "Group"}},
+		{`^(?:Handle|HandleFunc)$`, []string{"Handle", "HandleFunc"}},
+		{`^JSONP?$`, []string{"JSON", "JSONP"}},
+		{`^Bind(JSON|XML)?$`, []string{"Bind", "BindJSON", "BindXML"}},
+		{`Bind`, nil},         // unanchored: a substring match
+		{`^Bind`, nil},        // prefix match
+		{`^Bind.*$`, nil},     // infinite
+		{`(?i)^bind$`, nil},   // case folding
+		{`^[A-Za-z]+$`, nil},  // too large
+		{``, nil},             // no call regex
+		{`^(unclosed$`, nil},  // invalid
+		{`^GET$|^POST$`, nil}, // alternation of anchored branches
+		{`^(Get|Post)(Handler)?$`, []string{"Get", "GetHandler", "Post", "PostHandler"}},
+	}
+	for _, tt := range tests {
+		got, ok := literalNames(tt.pattern)
+		if tt.want == nil {
+			if ok {
+				t.Errorf("literalNames(%q) = %v, want not indexable", tt.pattern, got)
+			}
+			continue
+		}
+		slices.Sort(got)
+		want := slices.Sorted(slices.Values(tt.want))
+		if !ok || !slices.Equal(got, want) {
+			t.Errorf("literalNames(%q) = %v, %v, want %v", tt.pattern, got, ok, want)
+		}
+	}
+}
+
+func TestCalleeIndexCandidates(t *testing.T) {
+	x := newCalleeIndex([]string{`^(GET|POST)$`, `.*Handler$`, `^GET$`, ``})
+	if got := x.candidates("GET"); !slices.Equal(got, []int16{0, 1, 2, 3}) {
+		t.Errorf("candidates(GET) = %v", got)
+	}
+	if got := x.candidates("POST"); !slices.Equal(got, []int16{0, 1, 3}) {
+		t.Errorf("candidates(POST) = %v", got)
+	}
+	if got := x.candidates("Other"); !slices.Equal(got, []int16{1, 3}) {
+		t.Errorf("candidates(Other) = %v, want only the unindexed matchers", got)
+	}
+}
+
+func TestExtractor_CalleeIndexKeepsRoutes(t *testing.T) {
+	tree, _ := loadEchoTree(t)
+
+	indexed := NewExtractor(tree, DefaultEchoConfig())
+	routes := indexed.ExtractRoutes()
+	if len(routes) == 0 {
+		t.Skip("fixture produced no routes")
+	}
+
+	// The same extraction with every matcher a candidate for every callee
+	// is the linear scan the index replaces.
+	linear := NewExtractor(tree, DefaultEchoConfig())
+	for _, x := range []**calleeIndex{&linear.routeIndex, &linear.mountIndex, &linear.securityIndex, &linear.requestIndex, &linear.responseIndex, &linear.paramIndex} {
+		*x = newCalleeIndex(make([]string, (*x).size))
+	}
+	want := linear.ExtractRoutes()
+
+	if got, wantIDs := routeIDs(routes), routeIDs(want); !slices.Equal(got, wantIDs) {
+		t.Errorf("indexed routes = %v, linear routes = %v", got, wantIDs)
+	}
+
+	stats, base := indexed.MatcherStats(), linear.MatcherStats()
+	if stats.Skipped == 0 || base.Skipped != 0 {
+		t.Errorf("skipped = %d (linear %d), want the index to skip checks", stats.Skipped, base.Skipped)
+	}
+	if stats.Evaluations >= base.Evaluations {
+		t.Errorf("evaluations = %d, want fewer than the linear scan's %d", stats.Evaluations, base.Evaluations)
+	}
+	if stats.Memoized == 0 {
+		t.Error("no lookups were answered from the per-edge memos")
+	}
+}
+
+func routeIDs(routes []*RouteInfo) []string {
+	var ids []string
+	for _, r := range routes {
+		ids = append(ids, fmt.Sprintf("%s %s", r.Method, r.Path))
+	}
+	slices.Sort(ids)
+	return ids
+}
@@ -15,6 +15,7 @@
 package spec
 
 import (
+	"maps"
 	"strings"
 
 	"github.com/ehabterra/apispec/internal/metadata"
@@ -46,6 +47,35 @@ func splitMethodDispatchRoutes(routes []*RouteInfo) []*RouteInfo {
 	return out
 }
 
+// splitMultiMethodRoutes expands each route registered for several verbs at
+// once (RouteInfo.Methods) into one route per verb, in the order they were
+// listed. The operations share the handler, so their operationIds carry the
+// verb, as a dispatch split's do.
+func splitMultiMethodRoutes(routes []*RouteInfo) []*RouteInfo {
+	out := make([]*RouteInfo, 0, len(routes))
+	for _, route := range routes {
+		if len(route.Methods) < 2 {
+			out = append(out, route)
+			continue
+		}
+		for _, m := range route.Methods {
+			nr := *route // shallow copy; per-verb Method/Request/Response below
+			nr.Method = m
+			nr.Methods = nil
+			nr.OperationIDSuffix = m
+			// Later passes adjust a route's request per verb (a PATCH body
+			// becomes a merge patch), so each verb gets its own.
+			if route.Request != nil {
+				req := *route.Request
+				nr.Request = &req
+			}
+			nr.Response = maps.Clone(route.Response)
+			out = append(out, &nr)
+		}
+	}
+	return out
+}
+
 // methodDispatchFor returns the handler's r.Method dispatch branches and the
 // source file the handler is defined in (for same-file position scoping), or
 // nil when the route's handler doesn't dispatch on the method.
@@ -217,6 +217,32 @@ func TestSplitMethodDispatchRoutes_PassThrough(t *testing.T) {
 	}
 }
 
+// splitMultiMethodRoutes must give each verb of a multi-verb registration its
+// own route, operationId suffix and request.
+func TestSplitMultiMethodRoutes(t *testing.T) {
+	req := &RequestInfo{ContentType: "application/json", BodyType: "app.Item"}
+	routes := []*RouteInfo{
+		{Path: "/items/{id}", Method: "PUT", Methods: []string{"PUT", "PATCH"}, Function: "app.update", Request: req},
+		{Path: "/items", Method: "GET", Function: "app.list"},
+	}
+	got := splitMultiMethodRoutes(routes)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 routes, got %d", len(got))
+	}
+	for i, want := range []string{"PUT", "PATCH"} {
+		r := got[i]
+		if r.Method != want || r.OperationIDSuffix != want || r.Methods != nil {
+			t.Errorf("route %d: method %q suffix %q methods %v, want %s", i, r.Method, r.OperationIDSuffix, r.Methods, want)
+		}
+		if r.Request == nil || r.Request == req || r.Request.BodyType != "app.Item" {
+			t.Errorf("route %d: expected its own copy of the request, got %+v", i, r.Request)
+		}
+	}
+	if got[2] != routes[1] {
+		t.Errorf("single-verb route should pass through unchanged, got %+v", got[2])
+	}
+}
+
 func equalStrs(a, b []string) bool {
 	if len(a) != len(b) {
 		return false
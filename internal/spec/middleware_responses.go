@@ -0,0 +1,253 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/diag"
+)
+
+// preflightHeaders are the headers a CORS middleware answers a preflight
+// request with.
+var preflightHeaders = []string{
+	"Access-Control-Allow-Origin",
+	"Access-Control-Allow-Methods",
+	"Access-Control-Allow-Headers",
+}
+
+// ValidateMiddlewareResponses rejects middlewareResponses entries that can
+// never match, carry regexes that do not compile, document nothing, or name
+// a status outside 100-599. It returns the first error encountered.
+func (c *APISpecConfig) ValidateMiddlewareResponses() error {
+	for i, m := range c.MiddlewareResponses {
+		if m.FunctionNameRegex == "" && m.PkgRegex == "" && m.RecvTypeRegex == "" {
+			return fmt.Errorf("middlewareResponses[%d]: needs at least one identity matcher (functionNameRegex/pkgRegex/recvTypeRegex)", i)
+		}
+		for _, f := range []struct{ name, expr string }{
+			{"functionNameRegex", m.FunctionNameRegex}, {"pkgRegex", m.PkgRegex}, {"recvTypeRegex", m.RecvTypeRegex},
+		} {
+			if _, err := regexp.Compile(f.expr); err != nil {
+				return fmt.Errorf("middlewareResponses[%d]: invalid regex in %s %q: %w", i, f.name, f.expr, err)
+			}
+		}
+		if len(m.Responses) == 0 && !m.Preflight {
+			return fmt.Errorf("middlewareResponses[%d]: needs responses or preflight:true", i)
+		}
+		for j, r := range m.Responses {
+			if r.Status < 100 || r.Status > 599 {
+				return fmt.Errorf("middlewareResponses[%d].responses[%d]: invalid status %d", i, j, r.Status)
+			}
+		}
+	}
+	return nil
+}
+
+// matches reports whether the entry's identity matchers all match ref, as
+// a SecurityMapping's do.
+func (m MiddlewareResponse) matches(ref MiddlewareRef) bool {
+	return SecurityMapping{
+		FunctionNameRegex: m.FunctionNameRegex,
+		PkgRegex:          m.PkgRegex,
+		RecvTypeRegex:     m.RecvTypeRegex,
+	}.matches(ref)
+}
+
+// applyMiddlewareResponses documents what the configured middleware in a
+// route's chain answers with: their responses on the route, and an OPTIONS
+// operation for the paths a CORS middleware guards. It returns routes with
+// the preflight routes appended.
+func (e *Extractor) applyMiddlewareResponses(routes []*RouteInfo) []*RouteInfo {
+	if e.cfg == nil || len(e.cfg.MiddlewareResponses) == 0 {
+		return routes
+	}
+	matched := make([]bool, len(e.cfg.MiddlewareResponses))
+	undeclared := map[string]bool{}
+	var guarded []*RouteInfo
+	for _, route := range routes {
+		for i, m := range e.cfg.MiddlewareResponses {
+			if !slices.ContainsFunc(route.Middleware, m.matches) {
+				continue
+			}
+			matched[i] = true
+			for _, status := range m.Responses {
+				if !e.addMiddlewareResponse(route, status) {
+					undeclared[status.BodyType] = true
+				}
+			}
+			if m.Preflight && !slices.Contains(guarded, route) {
+				guarded = append(guarded, route)
+			}
+		}
+	}
+
+	for i, m := range e.cfg.MiddlewareResponses {
+		if !matched[i] {
+			reportMiddlewareResponse(fmt.Sprintf("middlewareResponses[%d]: no route's middleware matches it", i), "match the middleware as it is applied: its function, package or receiver type")
+		}
+		for _, status := range m.Responses {
+			if undeclared[status.BodyType] {
+				reportMiddlewareResponse(fmt.Sprintf("middlewareResponses[%d]: body type %s is not declared in the analyzed packages", i, status.BodyType), "name it as in source (apierr.Error) or by import path")
+				delete(undeclared, status.BodyType)
+			}
+		}
+	}
+	return append(routes, preflightRoutes(routes, guarded)...)
+}
+
+// addMiddlewareResponse adds status to the route's responses unless the
+// handler documents it already. It reports false when the status names a
+// body type the analyzed packages do not declare.
+func (e *Extractor) addMiddlewareResponse(route *RouteInfo, status MiddlewareStatus) bool {
+	key := strconv.Itoa(status.Status)
+	if _, ok := route.Response[key]; ok {
+		return true
+	}
+	resp := &ResponseInfo{
+		StatusCode:  status.Status,
+		Description: status.Description,
+		ContentType: "text/plain; charset=utf-8",
+		Schema:      &Schema{Type: "string"},
+	}
+	ok := true
+	if status.BodyType != "" {
+		if goType := e.annotationGoType(route, "", status.BodyType); goType != "" {
+			if route.UsedTypes == nil {
+				route.UsedTypes = map[string]*Schema{}
+			}
+			resp.BodyType = goType
+			resp.Schema, _ = mapGoTypeToOpenAPISchema(route.UsedTypes, goType, route.Metadata, e.cfg, nil)
+			resp.ContentType = "application/json"
+		} else {
+			ok = false
+		}
+	}
+	if status.ContentType != "" {
+		resp.ContentType = status.ContentType
+	}
+	if route.Response == nil {
+		route.Response = map[string]*ResponseInfo{}
+	}
+	route.Response[key] = resp
+	return ok
+}
+
+// preflightRoutes returns an OPTIONS route for each path of the guarded
+// routes that registers no OPTIONS handler. A path's preflight is named
+// after the first of its routes whose handler no other preflight is named
+// after, keeping operationIds unique.
+func preflightRoutes(routes, guarded []*RouteInfo) []*RouteInfo {
+	registered := map[string]bool{}
+	for _, route := range routes {
+		if strings.EqualFold(route.Method, http.MethodOptions) {
+			registered[route.OpenAPIPath()] = true
+		}
+	}
+	var paths []string
+	byPath := map[string][]*RouteInfo{}
+	for _, route := range guarded {
+		path := route.OpenAPIPath()
+		if registered[path] {
+			continue
+		}
+		if _, ok := byPath[path]; !ok {
+			paths = append(paths, path)
+		}
+		byPath[path] = append(byPath[path], route)
+	}
+
+	var out []*RouteInfo
+	named := map[string]bool{}
+	for _, path := range paths {
+		candidates := byPath[path]
+		route := candidates[0]
+		for _, c := range candidates {
+			if !named[c.Package+"."+c.Function] {
+				route = c
+				break
+			}
+		}
+		named[route.Package+"."+route.Function] = true
+		out = append(out, preflightRoute(route))
+	}
+	return out
+}
+
+// preflightRoute builds the OPTIONS route answering the CORS preflight for
+// route's path. The middleware answers it before any handler or auth runs,
+// so it is public and bodyless.
+func preflightRoute(route *RouteInfo) *RouteInfo {
+	preflight := &RouteInfo{
+		Path:              route.Path,
+		MountPath:         route.MountPath,
+		Method:            http.MethodOptions,
+		MethodExplicit:    true,
+		Handler:           route.Handler,
+		Package:           route.Package,
+		File:              route.File,
+		Function:          route.Function,
+		Summary:           "CORS preflight",
+		Description:       "Answers the browser's preflight request with the origins, methods and headers the path allows.",
+		Tags:              route.Tags,
+		OperationID:       route.OperationID,
+		OperationIDSuffix: http.MethodOptions,
+		Security:          []SecurityRequirement{},
+		DynamicParams:     route.DynamicParams,
+		RemainderParams:   route.RemainderParams,
+		ResponseHeaders:   preflightHeaders,
+		Metadata:          route.Metadata,
+		UsedTypes:         map[string]*Schema{},
+		Response: map[string]*ResponseInfo{
+			strconv.Itoa(http.StatusNoContent): {StatusCode: http.StatusNoContent},
+		},
+	}
+	for _, p := range route.Params {
+		if p.In == "path" {
+			preflight.Params = append(preflight.Params, p)
+		}
+	}
+	preflight.Params = append(preflight.Params,
+		Parameter{
+			Name: "Origin", In: "header", Required: true,
+			Description: "The origin of the page making the request.",
+			Schema:      &Schema{Type: "string"},
+		},
+		Parameter{
+			Name: "Access-Control-Request-Method", In: "header", Required: true,
+			Description: "The method of the request the browser is about to send.",
+			Schema:      &Schema{Type: "string"},
+		},
+		Parameter{
+			Name: "Access-Control-Request-Headers", In: "header",
+			Description: "The headers of the request the browser is about to send.",
+			Schema:      &Schema{Type: "string"},
+		},
+	)
+	return preflight
+}
+
+func reportMiddlewareResponse(message, help string) {
+	diag.Report(diag.Diagnostic{
+		Severity: diag.Warning,
+		Category: "middleware-responses",
+		Message:  message,
+		Help:     help,
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateMiddlewareResponses(t *testing.T) {
+	unauthorized := []MiddlewareStatus{{Status: 401}}
+	for _, tc := range []struct {
+		name    string
+		m       MiddlewareResponse
+		wantErr string
+	}{
+		{"valid", MiddlewareResponse{FunctionNameRegex: "^requireAuth$", Responses: unauthorized}, ""},
+		{"preflight only", MiddlewareResponse{PkgRegex: "github.com/go-chi/cors", Preflight: true}, ""},
+		{"no matcher", MiddlewareResponse{Responses: unauthorized}, "middlewareResponses[0]: needs at least one identity matcher"},
+		{"bad regex", MiddlewareResponse{RecvTypeRegex: "(", Responses: unauthorized}, "middlewareResponses[0]: invalid regex in recvTypeRegex"},
+		{"documents nothing", MiddlewareResponse{FunctionNameRegex: "^cors$"}, "middlewareResponses[0]: needs responses or preflight:true"},
+		{"bad status", MiddlewareResponse{FunctionNameRegex: "^limit$", Responses: []MiddlewareStatus{{Status: 42}}}, "middlewareResponses[0].responses[0]: invalid status 42"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := APISpecConfig{MiddlewareResponses: []MiddlewareResponse{tc.m}}
+			err := cfg.ValidateMiddlewareResponses()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPreflightRoutes(t *testing.T) {
+	route := func(method, path, fn string) *RouteInfo {
+		return &RouteInfo{Method: method, Path: path, Package: "app", Function: fn}
+	}
+	list := route("GET", "/items", "items")
+	create := route("POST", "/items", "createItem")
+	show := route("GET", "/items/{id}", "items") // the same handler on another path
+	status := route("GET", "/status", "status")
+	statusOptions := route("OPTIONS", "/status", "statusOptions")
+	routes := []*RouteInfo{list, create, show, status, statusOptions}
+
+	got := preflightRoutes(routes, []*RouteInfo{list, create, show, status})
+	want := []struct{ path, fn string }{
+		{"/items", "items"},
+		{"/items/{id}", "items"}, // no other route to name it after
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d preflight routes, want %d (none for /status, which registers OPTIONS)", len(got), len(want))
+	}
+	for i, w := range want {
+		if got[i].Path != w.path || got[i].Function != w.fn || got[i].Method != "OPTIONS" || got[i].OperationIDSuffix != "OPTIONS" {
+			t.Errorf("preflight %d = %s %s (%s, suffix %q), want OPTIONS %s (%s)", i, got[i].Method, got[i].Path, got[i].Function, got[i].OperationIDSuffix, w.path, w.fn)
+		}
+		if got[i].Security == nil || len(got[i].Security) != 0 {
+			t.Errorf("preflight %s security = %v, want public", got[i].Path, got[i].Security)
+		}
+	}
+
+	// A later path is named after a handler no earlier preflight took.
+	got = preflightRoutes(nil, []*RouteInfo{list, show, route("DELETE", "/items/{id}", "deleteItem")})
+	if len(got) != 2 || got[1].Function != "deleteItem" {
+		t.Errorf("got %v, want /items/{id} named after deleteItem", got)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// defaultTagNamespaces is the precedence used when schemas.tagNamespaces is
+// not set: properties are named by their json tag alone, as encoding/json
+// names them.
+var defaultTagNamespaces = []string{"json"}
+
+// tagNamespaces returns the struct tags property names are read from, in
+// order of precedence.
+func tagNamespaces(cfg *APISpecConfig) []string {
+	if cfg == nil || len(cfg.Schemas.TagNamespaces) == 0 {
+		return defaultTagNamespaces
+	}
+	return cfg.Schemas.TagNamespaces
+}
+
+// ValidateTagNamespaces rejects empty and repeated tagNamespaces entries,
+// and entries that cannot be a struct tag key.
+func (o SchemaOptions) ValidateTagNamespaces() error {
+	for i, ns := range o.TagNamespaces {
+		if ns == "" || strings.ContainsAny(ns, " :\"`") {
+			return fmt.Errorf("schemas.tagNamespaces[%d]: %q is not a struct tag key", i, ns)
+		}
+		if slices.Contains(o.TagNamespaces[:i], ns) {
+			return fmt.Errorf("schemas.tagNamespaces[%d]: %q is listed twice", i, ns)
+		}
+	}
+	return nil
+}
+
+// propertyName returns the name a field with the given tag is emitted under:
+// the name given by the first configured namespace that names it (json:"id",
+// db:"user_id", gorm:"column:user_id"), or "" to keep the Go field name. A
+// `-` in db or gorm only excludes the field from the database, so it names
+// nothing and the next namespace is tried.
+func propertyName(cfg *APISpecConfig, tag string) string {
+	for _, ns := range tagNamespaces(cfg) {
+		if name := tagNamespaceName(tag, ns); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// tagNamespaceName returns the field name one struct tag namespace gives:
+// the column setting of a gorm tag, and the first comma-separated element of
+// any other (json, db, bson, …).
+func tagNamespaceName(tag, ns string) string {
+	switch ns {
+	case "json":
+		return extractJSONName(tag)
+	case "gorm":
+		value, _ := gormSetting(tag, "column")
+		return value
+	}
+	value, ok := reflect.StructTag(tag).Lookup(ns)
+	if !ok {
+		return ""
+	}
+	name, _, _ := strings.Cut(value, ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// gormSetting looks up one setting of a gorm tag
+// (gorm:"column:user_id;type:varchar(64);not null"). Setting names are
+// case-insensitive and flags such as `not null` have an empty value.
+func gormSetting(tag, name string) (string, bool) {
+	value, ok := reflect.StructTag(tag).Lookup("gorm")
+	if !ok {
+		return "", false
+	}
+	for _, setting := range strings.Split(value, ";") {
+		key, val, _ := strings.Cut(setting, ":")
+		if strings.EqualFold(strings.ReplaceAll(strings.TrimSpace(key), " ", ""), name) {
+			return strings.TrimSpace(val), true
+		}
+	}
+	return "", false
+}
+
+var gormSizedType = regexp.MustCompile(`(?i)^(?:var)?char\((\d+)\)$`)
+
+// applyGormTag documents what a gorm tag says about a column when gorm is
+// among the configured tag namespaces: `not null` and `primaryKey` columns
+// are always present, so the property is required, and a char(N) or
+// varchar(N) type (or a size) bounds a string's length.
+func applyGormTag(cfg *APISpecConfig, parent *Schema, name string, field *Schema, tag string) {
+	if !slices.Contains(tagNamespaces(cfg), "gorm") {
+		return
+	}
+	_, notNull := gormSetting(tag, "notnull")
+	_, primary := gormSetting(tag, "primarykey")
+	if !primary {
+		_, primary = gormSetting(tag, "primary_key")
+	}
+	if (notNull || primary) && !slices.Contains(parent.Required, name) {
+		parent.Required = append(parent.Required, name)
+	}
+
+	if field == nil || field.Type != "string" || field.MaxLength != 0 {
+		return
+	}
+	size, _ := gormSetting(tag, "size")
+	if typ, ok := gormSetting(tag, "type"); ok {
+		if m := gormSizedType.FindStringSubmatch(typ); m != nil {
+			size = m[1]
+		}
+	}
+	if n, err := strconv.Atoi(size); err == nil && n > 0 {
+		field.MaxLength = n
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "testing"
+
+func TestTagNamespaceName(t *testing.T) {
+	for _, tc := range []struct {
+		tag, ns, want string
+	}{
+		{`json:"id,omitempty" db:"user_id"`, "json", "id"},
+		{`json:"id,omitempty" db:"user_id"`, "db", "user_id"},
+		{`db:"-"`, "db", ""},
+		{`bson:"_id,omitempty"`, "bson", "_id"},
+		{`gorm:"type:varchar(64);column:user_name;not null"`, "gorm", "user_name"},
+		{`gorm:"Column: user_name"`, "gorm", "user_name"},
+		{`gorm:"-"`, "gorm", ""},
+		{`mydb:"x"`, "db", ""},
+	} {
+		if got := tagNamespaceName(tc.tag, tc.ns); got != tc.want {
+			t.Errorf("tagNamespaceName(%s, %s) = %q, want %q", tc.tag, tc.ns, got, tc.want)
+		}
+	}
+}
+
+func TestApplyGormTag(t *testing.T) {
+	cfg := &APISpecConfig{Schemas: SchemaOptions{TagNamespaces: []string{"gorm"}}}
+	parent := &Schema{Type: "object", Required: []string{"id"}}
+	field := &Schema{Type: "string"}
+	applyGormTag(cfg, parent, "id", field, `gorm:"primaryKey;type:char(36)"`)
+	applyGormTag(cfg, parent, "name", &Schema{Type: "string"}, `gorm:"NOT NULL"`)
+	applyGormTag(cfg, parent, "bio", &Schema{Type: "string"}, `gorm:"type:text"`)
+	if len(parent.Required) != 2 || parent.Required[1] != "name" {
+		t.Errorf("required = %v, want [id name]", parent.Required)
+	}
+	if field.MaxLength != 36 {
+		t.Errorf("maxLength = %d, want 36 from char(36)", field.MaxLength)
+	}
+
+	plain := &Schema{Type: "object"}
+	applyGormTag(&APISpecConfig{}, plain, "name", &Schema{Type: "string"}, `gorm:"not null"`)
+	if len(plain.Required) != 0 {
+		t.Errorf("required = %v without gorm in tagNamespaces, want none", plain.Required)
+	}
+}
+
+func TestValidateTagNamespaces(t *testing.T) {
+	for _, tc := range []struct {
+		ns   []string
+		fail bool
+	}{
+		{nil, false},
+		{[]string{"json", "db", "gorm"}, false},
+		{[]string{"json", ""}, true},
+		{[]string{"db", "json", "db"}, true},
+		{[]string{"json db"}, true},
+	} {
+		err := SchemaOptions{TagNamespaces: tc.ns}.ValidateTagNamespaces()
+		if (err != nil) != tc.fail {
+			t.Errorf("ValidateTagNamespaces(%q) = %v, want failure %v", tc.ns, err, tc.fail)
+		}
+	}
+}
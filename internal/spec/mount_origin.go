@@ -0,0 +1,360 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// maxOriginDepth bounds how many assignments, parameters, literals and
+// returns a mounted router is followed through.
+const maxOriginDepth = 12
+
+// mountOrigins finds the calls that produced the router a mount registers
+// when the value reaches the mount through plumbing the assignment links do
+// not follow. The tracker trees link `sub := newRouter(); r.Mount("/x", sub)`
+// and a field set by assignment (`app.users = users`, functional options)
+// themselves; mountOrigins adds:
+//
+//   - an element of a slice or map literal: r.Mount("/x", routers["x"]),
+//     r.Mount("/x", routers[0]);
+//   - a struct field set in a composite literal, here or in the function
+//     that returned the struct: r.Mount("/x", mods.Users) with
+//     mods := &Modules{Users: newUsers()};
+//   - a function parameter: the argument each caller passes, followed up
+//     through as many callers as it takes.
+//
+// Both tracker engines hang the producers' subtrees under the mount's
+// router argument, so the mount prefix reaches the routes they register.
+// Only the router arguments of FrameworkConfig.MountPatterns are resolved.
+type mountOrigins struct {
+	meta     *metadata.Metadata
+	patterns []MountPattern
+}
+
+// originLit is a composite literal found while resolving, with the call
+// edge whose caller function it appears in.
+type originLit struct {
+	ctx *metadata.CallGraphEdge
+	lit *metadata.CallArgument
+}
+
+// newMountOrigins returns nil when there are no mount patterns to serve.
+func newMountOrigins(meta *metadata.Metadata, patterns []MountPattern) *mountOrigins {
+	if meta == nil || len(patterns) == 0 {
+		return nil
+	}
+	return &mountOrigins{meta: meta, patterns: patterns}
+}
+
+// routerProducers returns the base IDs of the calls that produced arg when
+// it is the router argument of a mount call on edge, and nil otherwise or
+// when the tracker's own assignment links already reach the value.
+func (o *mountOrigins) routerProducers(edge *metadata.CallGraphEdge, arg *metadata.CallArgument) []string {
+	if o == nil || edge == nil || arg == nil || !o.isRouterArg(edge, arg) {
+		return nil
+	}
+	var out []string
+	seen := map[string]bool{}
+	for _, id := range o.resolve(edge, arg, 0, true) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// isRouterArg reports whether edge calls a mount pattern and arg is the
+// argument at the pattern's RouterArgIndex.
+func (o *mountOrigins) isRouterArg(edge *metadata.CallGraphEdge, arg *metadata.CallArgument) bool {
+	callName := getString(o.meta, edge.Callee.Name)
+	fqRecvType := getString(o.meta, edge.Callee.Pkg)
+	if recv := getString(o.meta, edge.Callee.RecvType); recv != "" {
+		if fqRecvType != "" {
+			fqRecvType += "." + recv
+		} else {
+			fqRecvType = recv
+		}
+	}
+	for _, p := range o.patterns {
+		if !p.IsMount || !p.RouterFromArg || p.RouterArgIndex < 0 || p.RouterArgIndex >= len(edge.Args) {
+			continue
+		}
+		if edge.Args[p.RouterArgIndex] != arg {
+			continue
+		}
+		if p.CallRegex != "" && !adapterRegexMatches(p.CallRegex, callName) {
+			continue
+		}
+		if p.RecvTypeRegex != "" && !adapterRegexMatches(p.RecvTypeRegex, fqRecvType) {
+			continue
+		}
+		if p.RecvTypeRegex == "" && p.RecvType != "" && p.RecvType != fqRecvType {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// resolve returns the producers of arg evaluated in ctx's caller function.
+// At the top level, a variable assigned from a call is left to the
+// tracker's assignment links, so the subtree is not attached twice.
+func (o *mountOrigins) resolve(ctx *metadata.CallGraphEdge, arg *metadata.CallArgument, depth int, top bool) []string {
+	if arg == nil || depth > maxOriginDepth {
+		return nil
+	}
+	switch arg.GetKind() {
+	case metadata.KindCall:
+		if top {
+			return nil
+		}
+		if key := callBaseKey(arg); key != "" {
+			return []string{key}
+		}
+	case metadata.KindUnary, metadata.KindParen, metadata.KindStar:
+		return o.resolve(ctx, arg.X, depth+1, top)
+	case metadata.KindIdent:
+		if assigns := lookupAssignments(ctx, arg.GetName(), o.meta); len(assigns) > 0 {
+			value := o.withMeta(assigns[len(assigns)-1].Value)
+			if top && value.GetKind() == metadata.KindCall {
+				return nil
+			}
+			return o.resolve(ctx, value, depth+1, false)
+		}
+		var out []string
+		for _, caller := range o.callersOf(ctx) {
+			if value, ok := caller.ParamArgMap[arg.GetName()]; ok {
+				out = append(out, o.resolve(caller, o.withMeta(value), depth+1, false)...)
+			}
+		}
+		return out
+	case metadata.KindSelector:
+		if arg.Sel == nil {
+			return nil
+		}
+		field := arg.Sel.GetName()
+		var out []string
+		for _, l := range o.literals(arg.X, ctx, depth+1) {
+			for _, elt := range l.lit.Args {
+				if elt.GetKind() == metadata.KindKeyValue && elt.X != nil && elt.X.GetName() == field {
+					out = append(out, o.resolve(l.ctx, elt.Fun, depth+1, false)...)
+				}
+			}
+		}
+		return out
+	case metadata.KindIndex:
+		var out []string
+		for _, l := range o.literals(arg.X, ctx, depth+1) {
+			for _, elt := range literalElements(l.lit, arg.Fun) {
+				out = append(out, o.resolve(l.ctx, elt, depth+1, false)...)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// literals returns the composite literals arg may evaluate to: itself, the
+// value a variable or parameter was given, or what a function returns.
+func (o *mountOrigins) literals(arg *metadata.CallArgument, ctx *metadata.CallGraphEdge, depth int) []originLit {
+	if arg == nil || depth > maxOriginDepth {
+		return nil
+	}
+	switch arg.GetKind() {
+	case metadata.KindCompositeLit:
+		return []originLit{{ctx: ctx, lit: arg}}
+	case metadata.KindUnary, metadata.KindParen, metadata.KindStar:
+		return o.literals(arg.X, ctx, depth+1)
+	case metadata.KindIdent:
+		if assigns := lookupAssignments(ctx, arg.GetName(), o.meta); len(assigns) > 0 {
+			return o.literals(o.withMeta(assigns[len(assigns)-1].Value), ctx, depth+1)
+		}
+		var out []originLit
+		for _, caller := range o.callersOf(ctx) {
+			if value, ok := caller.ParamArgMap[arg.GetName()]; ok {
+				out = append(out, o.literals(o.withMeta(value), caller, depth+1)...)
+			}
+		}
+		return out
+	case metadata.KindCall:
+		fn := calledFunction(o.meta, arg)
+		if fn == nil || len(fn.ReturnVars) == 0 {
+			return nil
+		}
+		body := &metadata.CallGraphEdge{Caller: metadata.Call{Meta: o.meta, Name: fn.Name, Pkg: fn.Pkg, RecvType: -1}}
+		return o.literals(o.withMeta(fn.ReturnVars[0]), body, depth+1)
+	}
+	return nil
+}
+
+// literalElements returns the values of lit that index selects: the entry
+// whose key is the same literal, the element at a literal position, or
+// every element when the index is not a literal.
+func literalElements(lit, index *metadata.CallArgument) []*metadata.CallArgument {
+	key := ""
+	if index != nil && index.GetKind() == metadata.KindLiteral {
+		key = index.GetValue()
+	}
+	var out []*metadata.CallArgument
+	for i, elt := range lit.Args {
+		value := elt
+		if elt.GetKind() == metadata.KindKeyValue {
+			value = elt.Fun
+			if key != "" && elt.X != nil && elt.X.GetValue() != key {
+				continue
+			}
+		} else if key != "" {
+			if n, err := strconv.Atoi(key); err == nil && n != i {
+				continue
+			}
+		}
+		out = append(out, value)
+	}
+	return out
+}
+
+// callersOf returns the edges calling ctx's caller function.
+func (o *mountOrigins) callersOf(ctx *metadata.CallGraphEdge) []*metadata.CallGraphEdge {
+	return o.meta.Callees[ctx.Caller.BaseID()]
+}
+
+// calledFunction returns the declaration of the plain function arg calls.
+func calledFunction(meta *metadata.Metadata, arg *metadata.CallArgument) *metadata.Function {
+	fun := arg.Fun
+	if fun == nil {
+		return nil
+	}
+	if fun.GetKind() == metadata.KindSelector && fun.Sel != nil {
+		fun = fun.Sel
+	}
+	if fun.GetKind() != metadata.KindIdent {
+		return nil
+	}
+	return findFunction(meta, fun.GetPkg(), fun.GetName())
+}
+
+// withMeta returns a copy of v bound to the metadata; assignment values and
+// parameter arguments are stored by value.
+func (o *mountOrigins) withMeta(v metadata.CallArgument) *metadata.CallArgument {
+	if v.Meta == nil {
+		v.Meta = o.meta
+	}
+	return &v
+}
+
+// callBaseKey returns the base ID of the function or method a call
+// argument calls: its recorded edge's callee when there is one, else the
+// name it calls qualified by package (and receiver type for a method).
+func callBaseKey(arg *metadata.CallArgument) string {
+	if arg.Edge != nil {
+		return metadata.StripToBase(strings.TrimPrefix(arg.Edge.Callee.ID(), "*"))
+	}
+	fun := arg.Fun
+	if fun == nil {
+		return ""
+	}
+	if fun.GetKind() == metadata.KindSelector && fun.Sel != nil {
+		name, pkg := fun.Sel.GetName(), fun.Sel.GetPkg()
+		if name == "" || pkg == "" {
+			return ""
+		}
+		if fun.X != nil && fun.X.Type != -1 {
+			recv := strings.TrimPrefix(fun.X.GetType(), "*")
+			recv = strings.TrimPrefix(strings.TrimPrefix(recv, pkg+"."), "*")
+			if recv != "" {
+				return pkg + "." + recv + "." + name
+			}
+		}
+		return pkg + "." + name
+	}
+	if name, pkg := fun.GetName(), fun.GetPkg(); name != "" && pkg != "" {
+		return pkg + "." + name
+	}
+	return ""
+}
+
+// paramBinding returns the value every caller passes for arg when arg names
+// a parameter of edge's caller function, following it up through further
+// parameters: the "/tickets" in
+//
+//	func mountSub(r chi.Router, prefix string, sub chi.Router) { r.Mount(prefix, sub) }
+//	mountSub(root, "/tickets", tickets)
+//
+// It returns nil when arg is not a parameter, when a caller is missing or
+// when callers disagree, so a shared helper keeps its placeholder rather than
+// borrowing one caller's prefix.
+func paramBinding(meta *metadata.Metadata, edge *metadata.CallGraphEdge, arg *metadata.CallArgument) *metadata.CallArgument {
+	var bound *metadata.CallArgument
+	for range maxOriginDepth {
+		if meta == nil || edge == nil || arg == nil || arg.GetKind() != metadata.KindIdent {
+			break
+		}
+		if len(lookupAssignments(edge, arg.GetName(), meta)) > 0 {
+			break
+		}
+		var next *metadata.CallArgument
+		var nextEdge *metadata.CallGraphEdge
+		for _, caller := range meta.Callees[edge.Caller.BaseID()] {
+			value, ok := caller.ParamArgMap[arg.GetName()]
+			if !ok {
+				return nil
+			}
+			if value.Meta == nil {
+				value.Meta = meta
+			}
+			if next != nil && !sameArgValue(next, &value) {
+				return nil
+			}
+			next, nextEdge = &value, caller
+		}
+		if next == nil {
+			break
+		}
+		bound, edge, arg = next, nextEdge, next
+	}
+	return bound
+}
+
+// sameArgValue reports whether a and b denote the same value: literals by
+// value, since their IDs carry the source position, everything else by ID.
+func sameArgValue(a, b *metadata.CallArgument) bool {
+	if a.GetKind() != b.GetKind() {
+		return false
+	}
+	if a.GetKind() == metadata.KindLiteral {
+		return a.GetValue() == b.GetValue()
+	}
+	return a.ID() == b.ID()
+}
+
+// attachMountOriginChildren hangs the calls of the functions that produced a
+// mount's router argument under argNode in the eager tree — LazyTree expands
+// the same producers in buildPlan.
+func (b *trackerBuild) attachMountOriginChildren(argNode *TrackerNode, edge *metadata.CallGraphEdge, arg *metadata.CallArgument, limits metadata.TrackerLimits) {
+	tree, meta := b.tree, b.meta
+	if tree == nil || argNode == nil {
+		return
+	}
+	for _, key := range tree.mountOrigins.routerProducers(edge, arg) {
+		for _, e := range meta.Callers[key] {
+			b.node(argNode.Key(), e.Callee.ID(), e, nil, limits, argNode.AddChild)
+		}
+	}
+}
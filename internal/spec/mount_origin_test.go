@@ -0,0 +1,93 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+func mkLiteral(meta *metadata.Metadata, value, position string) *metadata.CallArgument {
+	a := metadata.NewCallArgument(meta)
+	a.SetKind(metadata.KindLiteral)
+	a.SetValue(value)
+	a.SetPosition(position)
+	return a
+}
+
+func mkKeyValue(meta *metadata.Metadata, key, value *metadata.CallArgument) *metadata.CallArgument {
+	a := metadata.NewCallArgument(meta)
+	a.SetKind(metadata.KindKeyValue)
+	a.X = key
+	a.Fun = value
+	return a
+}
+
+func TestLiteralElements(t *testing.T) {
+	meta := newTestMeta()
+	users, plans := mkIdent(meta, "users", ""), mkIdent(meta, "plans", "")
+
+	byName := metadata.NewCallArgument(meta)
+	byName.SetKind(metadata.KindCompositeLit)
+	byName.Args = []*metadata.CallArgument{
+		mkKeyValue(meta, mkLiteral(meta, `"users"`, "main.go:3:1"), users),
+		mkKeyValue(meta, mkLiteral(meta, `"plans"`, "main.go:3:9"), plans),
+	}
+	if got := literalElements(byName, mkLiteral(meta, `"plans"`, "main.go:9:1")); len(got) != 1 || got[0] != plans {
+		t.Errorf(`byName["plans"] = %v, want the plans entry`, got)
+	}
+	if got := literalElements(byName, mkIdent(meta, "key", "string")); len(got) != 2 {
+		t.Errorf("byName[key] = %v, want every entry", got)
+	}
+
+	list := metadata.NewCallArgument(meta)
+	list.SetKind(metadata.KindCompositeLit)
+	list.Args = []*metadata.CallArgument{users, plans}
+	if got := literalElements(list, mkLiteral(meta, "1", "main.go:9:1")); len(got) != 1 || got[0] != plans {
+		t.Errorf("list[1] = %v, want the second element", got)
+	}
+}
+
+func TestParamBinding(t *testing.T) {
+	meta := newTestMeta()
+	sp := meta.StringPool
+	call := func(name string) metadata.Call {
+		return metadata.Call{Meta: meta, Name: sp.Get(name), Pkg: sp.Get("app"), RecvType: -1}
+	}
+	prefix := mkIdent(meta, "prefix", "string")
+	meta.CallGraph = []metadata.CallGraphEdge{
+		// mountTickets calls mountSub(root, "/tickets", sub) twice, from two
+		// call sites; main calls mountShared with two different prefixes.
+		{Caller: call("mountTickets"), Callee: call("mountSub"), ParamArgMap: map[string]metadata.CallArgument{"prefix": *mkLiteral(meta, `"/tickets"`, "main.go:20:17")}},
+		{Caller: call("mountTickets"), Callee: call("mountSub"), ParamArgMap: map[string]metadata.CallArgument{"prefix": *mkLiteral(meta, `"/tickets"`, "main.go:21:17")}},
+		{Caller: call("main"), Callee: call("mountShared"), ParamArgMap: map[string]metadata.CallArgument{"prefix": *mkLiteral(meta, `"/a"`, "main.go:30:14")}},
+		{Caller: call("main"), Callee: call("mountShared"), ParamArgMap: map[string]metadata.CallArgument{"prefix": *mkLiteral(meta, `"/b"`, "main.go:31:14")}},
+	}
+	meta.BuildCallGraphMaps()
+
+	mount := &metadata.CallGraphEdge{Caller: call("mountSub")}
+	if got := paramBinding(meta, mount, prefix); got == nil || got.GetValue() != `"/tickets"` {
+		t.Errorf("mountSub prefix = %v, want the \"/tickets\" both callers pass", got)
+	}
+	shared := &metadata.CallGraphEdge{Caller: call("mountShared")}
+	if got := paramBinding(meta, shared, prefix); got != nil {
+		t.Errorf("mountShared prefix = %v, want nil when callers disagree", got)
+	}
+	uncalled := &metadata.CallGraphEdge{Caller: call("main")}
+	if got := paramBinding(meta, uncalled, prefix); got != nil {
+		t.Errorf("main prefix = %v, want nil without callers", got)
+	}
+}
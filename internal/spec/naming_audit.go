@@ -0,0 +1,209 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"go/ast"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// Naming issue kinds reported by the JSON naming audit.
+const (
+	// NamingDuplicateJSONName: two fields of one struct serialize under the
+	// same JSON name; encoding/json silently drops both (or keeps the
+	// shallower), and the schema shows only one.
+	NamingDuplicateJSONName = "duplicate-json-name"
+	// NamingCaseCollision: two JSON names of one struct differ only by case.
+	// encoding/json decodes keys case-insensitively, so which field a payload
+	// key lands in is ambiguous, while most generated clients treat them as
+	// distinct.
+	NamingCaseCollision = "case-collision"
+	// NamingCrossSchemaCase: the same property is spelled with different case
+	// across schemas (userId vs userID), which makes generated clients grow
+	// near-duplicate accessors.
+	NamingCrossSchemaCase = "cross-schema-case"
+)
+
+// NamingIssue is one finding of the JSON naming audit.
+type NamingIssue struct {
+	Kind    string
+	Schema  string   // component schema; empty for NamingCrossSchemaCase
+	JSON    string   // NamingDuplicateJSONName only: the shared JSON name
+	Names   []string // the colliding Go fields or property spellings
+	Schemas []string // NamingCrossSchemaCase only: schemas using each spelling, as "Schema.prop"
+}
+
+func (n NamingIssue) String() string {
+	switch n.Kind {
+	case NamingDuplicateJSONName:
+		return fmt.Sprintf("%s: fields %s share JSON name %q", n.Schema, strings.Join(n.Names, ", "), n.JSON)
+	case NamingCaseCollision:
+		return fmt.Sprintf("%s: JSON names %s differ only by case", n.Schema, strings.Join(n.Names, ", "))
+	default:
+		return fmt.Sprintf("property spelled %s across schemas (%s)",
+			strings.Join(n.Names, " / "), strings.Join(n.Schemas, ", "))
+	}
+}
+
+// auditJSONNaming checks the emitted component schemas for JSON naming that
+// commonly breaks client generation. Per-struct checks read the Go fields from
+// metadata (the generated property map has already collapsed duplicates);
+// the cross-schema check reads the emitted properties. Results are sorted.
+func auditJSONNaming(meta *metadata.Metadata, components *Components) []NamingIssue {
+	if components == nil || len(components.Schemas) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(components.Schemas))
+	for name := range components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	structs := structsByComponentName(meta)
+	var issues []NamingIssue
+	for _, name := range names {
+		if typ := structs[name]; typ != nil {
+			issues = append(issues, auditStructJSONNames(meta, name, typ)...)
+		}
+	}
+
+	// lower-cased property -> spelling -> "Schema.prop" users
+	spellings := map[string]map[string][]string{}
+	for _, name := range names {
+		schema := components.Schemas[name]
+		if schema == nil {
+			continue
+		}
+		for prop := range schema.Properties {
+			key := strings.ToLower(prop)
+			if spellings[key] == nil {
+				spellings[key] = map[string][]string{}
+			}
+			spellings[key][prop] = append(spellings[key][prop], name+"."+prop)
+		}
+	}
+	// Only spellings spread over several schemas count here; a single
+	// schema's own case clash is already a NamingCaseCollision.
+	keys := make([]string, 0, len(spellings))
+	for k, v := range spellings {
+		if len(v) > 1 && spreadAcrossSchemas(v) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		issue := NamingIssue{Kind: NamingCrossSchemaCase}
+		for spelling := range spellings[k] {
+			issue.Names = append(issue.Names, spelling)
+		}
+		sort.Strings(issue.Names)
+		for _, spelling := range issue.Names {
+			uses := spellings[k][spelling]
+			sort.Strings(uses)
+			issue.Schemas = append(issue.Schemas, uses...)
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// spreadAcrossSchemas reports whether the "Schema.prop" uses of a property's
+// spellings come from more than one schema.
+func spreadAcrossSchemas(bySpelling map[string][]string) bool {
+	var first string
+	for _, uses := range bySpelling {
+		for _, use := range uses {
+			schema := use[:strings.LastIndex(use, ".")]
+			if first == "" {
+				first = schema
+			} else if schema != first {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// structsByComponentName indexes the module's struct types by the component
+// schema name they are emitted under (component keys are sanitised and cannot
+// be parsed back into a Go type).
+func structsByComponentName(meta *metadata.Metadata) map[string]*metadata.Type {
+	out := map[string]*metadata.Type{}
+	if meta == nil {
+		return out
+	}
+	for _, pkgName := range meta.SortedPackageNames() {
+		for _, fileName := range slices.Sorted(maps.Keys(meta.Packages[pkgName].Files)) {
+			for typeName, typ := range meta.Packages[pkgName].Files[fileName].Types {
+				if typ == nil || len(typ.Fields) == 0 {
+					continue
+				}
+				key := schemaComponentNameReplacer.Replace(pkgName + TypeSep + typeName)
+				if _, exists := out[key]; !exists {
+					out[key] = typ
+				}
+			}
+		}
+	}
+	return out
+}
+
+// auditStructJSONNames reports duplicate and case-colliding JSON names among
+// the serialized fields of the struct behind a component schema.
+func auditStructJSONNames(meta *metadata.Metadata, schemaName string, typ *metadata.Type) []NamingIssue {
+	byName := map[string][]string{} // JSON name -> Go fields
+	var order []string
+	for _, field := range typ.Fields {
+		fieldName := getStringFromPool(meta, field.Name)
+		tag := getStringFromPool(meta, field.Tag)
+		if fieldName == "" || jsonFieldOmitted(tag) || !ast.IsExported(fieldName) || protobufInternalField(fieldName) {
+			continue
+		}
+		jsonName := fieldName
+		if n := extractJSONName(tag); n != "" {
+			jsonName = n
+		}
+		if _, seen := byName[jsonName]; !seen {
+			order = append(order, jsonName)
+		}
+		byName[jsonName] = append(byName[jsonName], fieldName)
+	}
+
+	var issues []NamingIssue
+	byFold := map[string][]string{}
+	var foldOrder []string
+	for _, jsonName := range order {
+		if fields := byName[jsonName]; len(fields) > 1 {
+			issues = append(issues, NamingIssue{Kind: NamingDuplicateJSONName, Schema: schemaName, JSON: jsonName, Names: fields})
+		}
+		key := strings.ToLower(jsonName)
+		if _, seen := byFold[key]; !seen {
+			foldOrder = append(foldOrder, key)
+		}
+		byFold[key] = append(byFold[key], jsonName)
+	}
+	for _, key := range foldOrder {
+		if spellings := byFold[key]; len(spellings) > 1 {
+			issues = append(issues, NamingIssue{Kind: NamingCaseCollision, Schema: schemaName, Names: slices.Clone(spellings)})
+		}
+	}
+	return issues
+}
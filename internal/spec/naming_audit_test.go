@@ -0,0 +1,48 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "testing"
+
+func objectWith(props ...string) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, p := range props {
+		s.Properties[p] = &Schema{Type: "string"}
+	}
+	return s
+}
+
+func TestAuditJSONNaming_CrossSchemaOnlyAcrossSchemas(t *testing.T) {
+	// A case clash inside one schema is a per-struct finding, not a
+	// cross-schema one.
+	single := &Components{Schemas: map[string]*Schema{"A": objectWith("userId", "userID")}}
+	if issues := auditJSONNaming(nil, single); len(issues) != 0 {
+		t.Errorf("single schema: got %v, want none", issues)
+	}
+
+	multi := &Components{Schemas: map[string]*Schema{
+		"A": objectWith("userId", "name"),
+		"B": objectWith("userID", "name"),
+		"C": objectWith("userId"),
+	}}
+	issues := auditJSONNaming(nil, multi)
+	if len(issues) != 1 {
+		t.Fatalf("got %v, want one cross-schema issue", issues)
+	}
+	want := "property spelled userID / userId across schemas (B.userID, A.userId, C.userId)"
+	if got := issues[0].String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -14,16 +14,35 @@
 
 package spec
 
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
 // OpenAPISpec represents the root OpenAPI specification
 type OpenAPISpec struct {
 	OpenAPI      string                 `yaml:"openapi" json:"openapi"`
 	Info         Info                   `yaml:"info,omitempty" json:"info,omitempty"`
 	Servers      []Server               `yaml:"servers,omitempty" json:"servers,omitempty"`
 	Paths        map[string]PathItem    `yaml:"paths" json:"paths"`
+	Webhooks     map[string]PathItem    `yaml:"webhooks,omitempty" json:"webhooks,omitempty"`
 	Components   *Components            `yaml:"components,omitempty" json:"components,omitempty"`
 	Security     []SecurityRequirement  `yaml:"security,omitempty" json:"security,omitempty"`
 	Tags         []Tag                  `yaml:"tags,omitempty" json:"tags,omitempty"`
 	ExternalDocs *ExternalDocumentation `yaml:"externalDocs,omitempty" json:"externalDocs,omitempty"`
+	// Extensions holds the document's vendor extensions (x-owner, ...),
+	// rendered inline next to the standard fields.
+	Extensions map[string]interface{} `yaml:",inline" json:"-"`
+}
+
+// MarshalJSON inlines the document's vendor extensions.
+func (s OpenAPISpec) MarshalJSON() ([]byte, error) {
+	type plain OpenAPISpec
+	return marshalWithExtensions(plain(s), s.Extensions)
 }
 
 // Info represents the OpenAPI info object
@@ -76,8 +95,50 @@ type PathItem struct {
 	Options     *Operation  `yaml:"options,omitempty" json:"options,omitempty"`
 	Head        *Operation  `yaml:"head,omitempty" json:"head,omitempty"`
 	Parameters  []Parameter `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	// Extensions holds the path item's vendor extensions, rendered inline.
+	Extensions map[string]interface{} `yaml:",inline" json:"-"`
+}
+
+// MarshalJSON inlines the path item's vendor extensions.
+func (p PathItem) MarshalJSON() ([]byte, error) {
+	type plain PathItem
+	return marshalWithExtensions(plain(p), p.Extensions)
+}
+
+// MethodOperation is one operation of a path item with its HTTP method.
+type MethodOperation struct {
+	Method    string
+	Operation *Operation
+}
+
+// Operations lists the item's operations in a fixed method order.
+func (p PathItem) Operations() []MethodOperation {
+	var ops []MethodOperation
+	for _, m := range []MethodOperation{
+		{"GET", p.Get}, {"POST", p.Post}, {"PUT", p.Put}, {"PATCH", p.Patch},
+		{"DELETE", p.Delete}, {"HEAD", p.Head}, {"OPTIONS", p.Options},
+	} {
+		if m.Operation != nil {
+			ops = append(ops, m)
+		}
+	}
+	return ops
+}
+
+// Operation returns the item's operation for method, nil when it has none.
+func (p PathItem) Operation(method string) *Operation {
+	for _, m := range p.Operations() {
+		if m.Method == strings.ToUpper(method) {
+			return m.Operation
+		}
+	}
+	return nil
 }
 
+// Callback maps the runtime expression of the URL a request is sent to onto
+// the request, described as a path item.
+type Callback map[string]PathItem
+
 // Operation represents an OpenAPI operation
 type Operation struct {
 	Tags        []string            `yaml:"tags,omitempty" json:"tags,omitempty"`
@@ -87,6 +148,8 @@ type Operation struct {
 	Parameters  []Parameter         `yaml:"parameters,omitempty" json:"parameters,omitempty"`
 	RequestBody *RequestBody        `yaml:"requestBody,omitempty" json:"requestBody,omitempty"`
 	Responses   map[string]Response `yaml:"responses" json:"responses"`
+	Callbacks   map[string]Callback `yaml:"callbacks,omitempty" json:"callbacks,omitempty"`
+	Deprecated  bool                `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`
 	// Security is a pointer so its three states are distinguishable: nil =>
 	// omitted (the operation inherits the document-level security); a non-nil
 	// pointer to an empty slice => `security: []` (explicitly public, overriding
@@ -94,6 +157,15 @@ type Operation struct {
 	// plain slice with omitempty cannot tell "inherit" from "explicitly public".
 	Security     *[]SecurityRequirement `yaml:"security,omitempty" json:"security,omitempty"`
 	ExternalDocs *ExternalDocumentation `yaml:"externalDocs,omitempty" json:"externalDocs,omitempty"`
+	// Extensions holds vendor extensions (x-websocket, x-sse, ...). They are
+	// rendered inline next to the standard fields, as OpenAPI requires.
+	Extensions map[string]interface{} `yaml:",inline" json:"-"`
+}
+
+// MarshalJSON inlines the operation's vendor extensions.
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type plain Operation
+	return marshalWithExtensions(plain(o), o.Extensions)
 }
 
 // Parameter represents an OpenAPI parameter
@@ -105,7 +177,35 @@ type Parameter struct {
 	Required    bool                   `yaml:"required,omitempty" json:"required,omitempty"`
 	Schema      *Schema                `yaml:"schema,omitempty" json:"schema,omitempty"`
 	Example     interface{}            `yaml:"example,omitempty" json:"example,omitempty"`
-	Extensions  map[string]interface{} `yaml:",inline" json:",inline"`
+	Extensions  map[string]interface{} `yaml:",inline" json:"-"`
+}
+
+// MarshalJSON inlines the parameter's vendor extensions. encoding/json has
+// no ",inline" option, so without this they would nest under "Extensions".
+func (p Parameter) MarshalJSON() ([]byte, error) {
+	type plain Parameter
+	return marshalWithExtensions(plain(p), p.Extensions)
+}
+
+// marshalWithExtensions encodes v as a JSON object and splices the extension
+// keys into it. The extension map encodes with sorted keys, so the output is
+// deterministic.
+func marshalWithExtensions(v interface{}, extensions map[string]interface{}) ([]byte, error) {
+	base, err := json.Marshal(v)
+	if err != nil || len(extensions) == 0 {
+		return base, err
+	}
+	extra, err := json.Marshal(extensions)
+	if err != nil {
+		return nil, err
+	}
+	if len(base) <= 2 {
+		return extra, nil
+	}
+	out := make([]byte, 0, len(base)+len(extra))
+	out = append(out, base[:len(base)-1]...)
+	out = append(out, ',')
+	return append(out, extra[1:]...), nil
 }
 
 // RequestBody represents an OpenAPI request body
@@ -144,6 +244,7 @@ type Schema struct {
 	Format               string                 `yaml:"format,omitempty" json:"format,omitempty"`
 	Description          string                 `yaml:"description,omitempty" json:"description,omitempty"`
 	Title                string                 `yaml:"title,omitempty" json:"title,omitempty"`
+	ID                   string                 `yaml:"$id,omitempty" json:"$id,omitempty"`
 	Default              interface{}            `yaml:"default,omitempty" json:"default,omitempty"`
 	Example              interface{}            `yaml:"example,omitempty" json:"example,omitempty"`
 	ReadOnly             bool                   `yaml:"readOnly,omitempty" json:"readOnly,omitempty"`
@@ -161,10 +262,10 @@ type Schema struct {
 	MinLength            int                    `yaml:"minLength,omitempty" json:"minLength,omitempty"`
 	MaxLength            int                    `yaml:"maxLength,omitempty" json:"maxLength,omitempty"`
 	Pattern              string                 `yaml:"pattern,omitempty" json:"pattern,omitempty"`
-	Minimum              float64                `yaml:"minimum,omitempty" json:"minimum,omitempty"`
-	Maximum              float64                `yaml:"maximum,omitempty" json:"maximum,omitempty"`
-	ExclusiveMinimum     bool                   `yaml:"exclusiveMinimum,omitempty" json:"exclusiveMinimum,omitempty"`
-	ExclusiveMaximum     bool                   `yaml:"exclusiveMaximum,omitempty" json:"exclusiveMaximum,omitempty"`
+	Minimum              *float64               `yaml:"minimum,omitempty" json:"minimum,omitempty"`
+	Maximum              *float64               `yaml:"maximum,omitempty" json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64               `yaml:"exclusiveMinimum,omitempty" json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64               `yaml:"exclusiveMaximum,omitempty" json:"exclusiveMaximum,omitempty"`
 	MultipleOf           float64                `yaml:"multipleOf,omitempty" json:"multipleOf,omitempty"`
 	MinItems             int                    `yaml:"minItems,omitempty" json:"minItems,omitempty"`
 	MaxItems             int                    `yaml:"maxItems,omitempty" json:"maxItems,omitempty"`
@@ -175,6 +276,305 @@ type Schema struct {
 	Discriminator        *Discriminator         `yaml:"discriminator,omitempty" json:"discriminator,omitempty"`
 	XML                  *XML                   `yaml:"xml,omitempty" json:"xml,omitempty"`
 	ExternalDocs         *ExternalDocumentation `yaml:"externalDocs,omitempty" json:"externalDocs,omitempty"`
+	// Nullable is OpenAPI 3.0's keyword for a schema that also accepts null.
+	Nullable bool `yaml:"nullable,omitempty" json:"nullable,omitempty"`
+	// AllowNull writes Type as [Type, "null"], the OpenAPI 3.1 form of the
+	// same.
+	AllowNull bool `yaml:"-" json:"-"`
+	// booleanBounds writes ExclusiveMinimum and ExclusiveMaximum the OpenAPI
+	// 3.0 way: the bound as minimum/maximum, and exclusiveMinimum/
+	// exclusiveMaximum: true. Set on the schemas of a 3.0 document (see
+	// markBooleanBounds).
+	booleanBounds bool
+	// Extensions holds vendor extensions (x-enum-varnames, ...), rendered
+	// inline like an operation's.
+	Extensions map[string]interface{} `yaml:",inline" json:"-"`
+}
+
+// MarshalJSON inlines the schema's vendor extensions.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type plain Schema
+	s, flags := s.withBooleanBounds()
+	if len(flags) > 0 {
+		extensions := make(map[string]interface{}, len(s.Extensions)+len(flags))
+		maps.Copy(extensions, s.Extensions)
+		for _, flag := range flags {
+			extensions[flag] = true
+		}
+		s.Extensions = extensions
+	}
+	if !s.AllowNull || s.Type == "" {
+		return marshalWithExtensions(plain(s), s.Extensions)
+	}
+	types, err := json.Marshal([]string{s.Type, "null"})
+	if err != nil {
+		return nil, err
+	}
+	s.Type = ""
+	rest, err := marshalWithExtensions(plain(s), s.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	out := append([]byte(`{"type":`), types...)
+	if len(rest) > 2 {
+		out = append(out, ',')
+	}
+	return append(out, rest[1:]...), nil
+}
+
+// MarshalYAML writes an AllowNull schema's type as a [Type, "null"]
+// sequence, and a booleanBounds schema's exclusive bounds as booleans.
+func (s Schema) MarshalYAML() (interface{}, error) {
+	type plain Schema
+	s, flags := s.withBooleanBounds()
+	if (!s.AllowNull || s.Type == "") && len(flags) == 0 {
+		return plain(s), nil
+	}
+	var node yaml.Node
+	if err := node.Encode(plain(s)); err != nil {
+		return nil, err
+	}
+	for i := 0; s.AllowNull && i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "type" {
+			node.Content[i+1] = &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle, Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: s.Type},
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "null", Style: yaml.DoubleQuotedStyle},
+			}}
+		}
+	}
+	for _, flag := range flags {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: flag},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"})
+	}
+	return &node, nil
+}
+
+// withBooleanBounds returns s with a booleanBounds schema's exclusive bounds
+// moved to minimum/maximum, and the exclusiveMinimum/exclusiveMaximum keys
+// to write as true beside them: the fields hold 3.1's numbers.
+func (s Schema) withBooleanBounds() (Schema, []string) {
+	if !s.booleanBounds {
+		return s, nil
+	}
+	var flags []string
+	if s.ExclusiveMinimum != nil {
+		s.Minimum, s.ExclusiveMinimum = s.ExclusiveMinimum, nil
+		flags = append(flags, "exclusiveMinimum")
+	}
+	if s.ExclusiveMaximum != nil {
+		s.Maximum, s.ExclusiveMaximum = s.ExclusiveMaximum, nil
+		flags = append(flags, "exclusiveMaximum")
+	}
+	return s, flags
+}
+
+// markBooleanBounds sets booleanBounds on every schema of s when s is an
+// OpenAPI 3.0 document, where exclusiveMinimum and exclusiveMaximum are
+// booleans.
+func markBooleanBounds(s *OpenAPISpec) {
+	if s == nil || !strings.HasPrefix(s.OpenAPI, "3.0") {
+		return
+	}
+	mapDocumentSchemas(s, func(schema *Schema) *Schema {
+		return withBooleanBoundsFlag(schema, map[*Schema]bool{})
+	})
+}
+
+// withBooleanBoundsFlag returns s with booleanBounds set on it and its
+// subschemas that have an exclusive bound. It copies only along changed
+// paths, so schemas shared with other documents keep the 3.1 form.
+func withBooleanBoundsFlag(s *Schema, visiting map[*Schema]bool) *Schema {
+	if s == nil || visiting[s] {
+		return s
+	}
+	visiting[s] = true
+	defer delete(visiting, s)
+
+	c := *s
+	changed := false
+	if !s.booleanBounds && (s.ExclusiveMinimum != nil || s.ExclusiveMaximum != nil) {
+		c.booleanBounds, changed = true, true
+	}
+	for _, field := range []**Schema{&c.Items, &c.AdditionalProperties, &c.Not} {
+		if r := withBooleanBoundsFlag(*field, visiting); r != *field {
+			*field, changed = r, true
+		}
+	}
+	for _, list := range []*[]*Schema{&c.AllOf, &c.OneOf, &c.AnyOf} {
+		cloned := false
+		for i, item := range *list {
+			r := withBooleanBoundsFlag(item, visiting)
+			if r == item {
+				continue
+			}
+			if !cloned {
+				cloned = true
+				*list = slices.Clone(*list)
+			}
+			(*list)[i], changed = r, true
+		}
+	}
+	copied := false
+	for key, prop := range s.Properties {
+		r := withBooleanBoundsFlag(prop, visiting)
+		if r == prop {
+			continue
+		}
+		if !copied {
+			copied = true
+			c.Properties = maps.Clone(s.Properties)
+		}
+		c.Properties[key], changed = r, true
+	}
+	if !changed {
+		return s
+	}
+	return &c
+}
+
+// mapDocumentSchemas replaces every top-level schema of s with fn's result
+// for it: those of operations, webhooks, callbacks and the components.
+func mapDocumentSchemas(s *OpenAPISpec, fn func(*Schema) *Schema) {
+	for path, item := range s.Paths {
+		s.Paths[path] = mapItemSchemas(item, fn)
+	}
+	for name, item := range s.Webhooks {
+		s.Webhooks[name] = mapItemSchemas(item, fn)
+	}
+	c := s.Components
+	if c == nil {
+		return
+	}
+	for name, schema := range c.Schemas {
+		c.Schemas[name] = fn(schema)
+	}
+	for _, p := range c.Parameters {
+		if p != nil {
+			p.Schema = fn(p.Schema)
+		}
+	}
+	for _, body := range c.RequestBodies {
+		if body != nil {
+			mapContentSchemas(body.Content, fn)
+		}
+	}
+	for _, resp := range c.Responses {
+		if resp != nil {
+			mapResponseSchemas(resp, fn)
+		}
+	}
+	for _, h := range c.Headers {
+		if h != nil {
+			h.Schema = fn(h.Schema)
+		}
+	}
+}
+
+func mapItemSchemas(item PathItem, fn func(*Schema) *Schema) PathItem {
+	item.Parameters = mapParameterSchemas(item.Parameters, fn)
+	for _, m := range item.Operations() {
+		op := m.Operation
+		op.Parameters = mapParameterSchemas(op.Parameters, fn)
+		if op.RequestBody != nil {
+			mapContentSchemas(op.RequestBody.Content, fn)
+		}
+		for status, resp := range op.Responses {
+			mapResponseSchemas(&resp, fn)
+			op.Responses[status] = resp
+		}
+		for _, callback := range op.Callbacks {
+			for expr, cbItem := range callback {
+				callback[expr] = mapItemSchemas(cbItem, fn)
+			}
+		}
+	}
+	return item
+}
+
+func mapParameterSchemas(params []Parameter, fn func(*Schema) *Schema) []Parameter {
+	if len(params) == 0 {
+		return params
+	}
+	out := slices.Clone(params)
+	for i := range out {
+		out[i].Schema = fn(out[i].Schema)
+	}
+	return out
+}
+
+func mapResponseSchemas(resp *Response, fn func(*Schema) *Schema) {
+	mapContentSchemas(resp.Content, fn)
+	for name, h := range resp.Headers {
+		h.Schema = fn(h.Schema)
+		resp.Headers[name] = h
+	}
+}
+
+func mapContentSchemas(content map[string]MediaType, fn func(*Schema) *Schema) {
+	for mt, media := range content {
+		media.Schema = fn(media.Schema)
+		content[mt] = media
+	}
+}
+
+// UnmarshalYAML also reads OpenAPI 3.0's boolean exclusiveMinimum and
+// exclusiveMaximum: {minimum: 5, exclusiveMinimum: true} is the 3.1
+// exclusiveMinimum: 5.
+func (s *Schema) UnmarshalYAML(node *yaml.Node) error {
+	type plain Schema
+	if node.Kind == yaml.MappingNode {
+		node = numericExclusiveBounds(node)
+	}
+	return node.Decode((*plain)(s))
+}
+
+// numericExclusiveBounds returns a copy of the mapping node with each
+// boolean exclusive bound replaced by the bound it makes exclusive.
+func numericExclusiveBounds(node *yaml.Node) *yaml.Node {
+	value := func(key string) (int, *yaml.Node) {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return i, node.Content[i+1]
+			}
+		}
+		return -1, nil
+	}
+	drop := map[int]bool{}
+	replace := map[int]*yaml.Node{}
+	for exclusive, bound := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+		i, flag := value(exclusive)
+		if flag == nil || flag.Tag != "!!bool" {
+			continue
+		}
+		if flag.Value != "true" {
+			drop[i] = true
+			continue
+		}
+		j, b := value(bound)
+		if b == nil {
+			b = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "0"}
+		} else {
+			drop[j] = true
+		}
+		replace[i+1] = b
+	}
+	if len(drop) == 0 && len(replace) == 0 {
+		return node
+	}
+	c := *node
+	c.Content = nil
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if drop[i] {
+			continue
+		}
+		v := node.Content[i+1]
+		if r, ok := replace[i+1]; ok {
+			v = r
+		}
+		c.Content = append(c.Content, node.Content[i], v)
+	}
+	return &c
 }
 
 // Discriminator represents an OpenAPI discriminator
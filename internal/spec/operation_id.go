@@ -0,0 +1,230 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// OperationIDStyleCamel is the OperationIDOptions.Style that canonicalizes
+// generated operationIds to camelCase.
+const OperationIDStyleCamel = "camel"
+
+// operationIDFields are the fields an OperationIDOptions.Template renders.
+type operationIDFields struct {
+	Method    string // lower-case HTTP method: get
+	Path      string // OpenAPI path: /users/{id}
+	PathCamel string // the path's segments in camelCase: UsersId
+	Package   string // handler package, less the module prefix: handlers
+	Function  string // handler, less its package: UserHandler.GetUser
+	Tag       string // the operation's first tag
+	Suffix    string // what tells apart operations one handler yields: GET
+}
+
+// ValidateOperationIDs rejects an unknown operationIds style and a template
+// that does not parse or names a field it does not have.
+func (c *APISpecConfig) ValidateOperationIDs() error {
+	o := c.OperationIDs
+	if o.Style != "" && o.Style != OperationIDStyleCamel {
+		return fmt.Errorf("operationIds.style: unknown style %q (want %q)", o.Style, OperationIDStyleCamel)
+	}
+	if o.Template == "" {
+		return nil
+	}
+	tmpl, err := template.New("operationId").Option("missingkey=error").Parse(o.Template)
+	if err != nil {
+		return fmt.Errorf("operationIds.template: %w", err)
+	}
+	if err := tmpl.Execute(&strings.Builder{}, operationIDFields{}); err != nil {
+		return fmt.Errorf("operationIds.template: %w", err)
+	}
+	return nil
+}
+
+// assignOperationIDs renames the generated operationIds of paths as
+// configured and gives the ones that collide a numeric suffix, in path and
+// method order so the first keeps its name. An id set with a swaggo @ID and a
+// webhook's configured id are kept as written.
+func assignOperationIDs(paths map[string]PathItem, routes []*RouteInfo, cfg *APISpecConfig, modulePath string, reserved ...string) {
+	var opts OperationIDOptions
+	if cfg != nil {
+		opts = cfg.OperationIDs
+	}
+	var tmpl *template.Template
+	if opts.Template != "" {
+		// ValidateOperationIDs has vetted it; a config built in code that
+		// skips validation falls back to the default ids.
+		tmpl, _ = template.New("operationId").Option("missingkey=error").Parse(opts.Template)
+	}
+	canonical := tmpl != nil || opts.Style == OperationIDStyleCamel
+
+	byOperation := map[string]*RouteInfo{}
+	for _, route := range routes {
+		byOperation[routeOperationKey(route)] = route
+	}
+
+	used := map[string]bool{}
+	for _, id := range reserved {
+		used[id] = true
+	}
+	type generated struct {
+		op    *Operation
+		route *RouteInfo
+		path  string
+	}
+	var pending []generated
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		for _, mo := range paths[path].Operations() {
+			route := byOperation[path+" "+mo.Method]
+			if route != nil && route.OperationID != "" {
+				used[mo.Operation.OperationID] = true
+				continue
+			}
+			pending = append(pending, generated{op: mo.Operation, route: route, path: path})
+		}
+	}
+
+	for _, g := range pending {
+		id := g.op.OperationID
+		if canonical && g.route != nil {
+			fields := newOperationIDFields(g.route, g.path, modulePath)
+			if tmpl != nil {
+				var b strings.Builder
+				if err := tmpl.Execute(&b, fields); err == nil {
+					id = b.String()
+				}
+			} else {
+				id = strings.Join([]string{fields.Package, fields.Function, fields.Suffix}, " ")
+			}
+			if c := camelIdentifier(id); c != "" {
+				id = c
+			}
+		}
+		sep := "_"
+		if last, _ := utf8.DecodeLastRuneInString(id); canonical && !unicode.IsDigit(last) {
+			sep = ""
+		}
+		base := id
+		for n := 2; used[id]; n++ {
+			id = base + sep + strconv.Itoa(n)
+		}
+		used[id] = true
+		g.op.OperationID = id
+	}
+}
+
+// routeOperationKey is the "path METHOD" of the operation buildPathsFromRoutes
+// builds for route.
+func routeOperationKey(route *RouteInfo) string {
+	return convertPathToOpenAPI(joinPaths(route.MountPath, route.Path)) + " " + strings.ToUpper(route.Method)
+}
+
+// newOperationIDFields reads the template fields of route's operation at
+// path, stripping modulePath from the package and from the type arguments of
+// a generic handler.
+func newOperationIDFields(route *RouteInfo, opPath, modulePath string) operationIDFields {
+	pkg := route.Package
+	function := strings.TrimPrefix(route.Function, pkg+".")
+	if _, expr, ok := strings.Cut(function, TypeSep); ok {
+		// A method value names its receiver as written where it is taken,
+		// pkg.Type.Method, under the package that takes it.
+		function = strings.TrimPrefix(expr, path.Base(pkg)+".")
+	}
+	fields := operationIDFields{
+		Method:    strings.ToLower(route.Method),
+		Path:      opPath,
+		PathCamel: upperFirst(camelIdentifier(opPath)),
+		Package:   stripModulePath(pkg, modulePath),
+		Function:  stripModulePath(function, modulePath),
+		Suffix:    route.OperationIDSuffix,
+	}
+	if strings.HasPrefix(fields.Function, "FuncLit:") {
+		// A function literal has no name, only a position.
+		fields.Function = fields.Method + fields.PathCamel
+	}
+	if len(route.Tags) > 0 {
+		fields.Tag = route.Tags[0]
+	}
+	return fields
+}
+
+// stripModulePath drops the import paths from the qualified names in s,
+// keeping a package's name; the module's root package is dropped entirely,
+// so github.com/acme/api/handlers.User becomes handlers.User and
+// github.com/acme/api.User just User.
+func stripModulePath(s, modulePath string) string {
+	if modulePath != "" {
+		if s == modulePath {
+			return ""
+		}
+		s = strings.ReplaceAll(s, modulePath+".", "")
+	}
+	return mustCachedRegex(`[\w.~-]+/(?:[\w.~-]+/)*`).ReplaceAllString(s, "")
+}
+
+// camelIdentifier joins the alphanumeric words of s in camelCase: the first
+// word lower-cased (a leading acronym entirely, HTTPServer becomes
+// httpServer), the others capitalized, and an all-caps one such as a method
+// suffix title-cased (GET becomes Get).
+func camelIdentifier(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for i, w := range words {
+		switch {
+		case i == 0:
+			b.WriteString(lowerFirstWord(w))
+		case strings.ToUpper(w) == w:
+			b.WriteString(upperFirst(strings.ToLower(w)))
+		default:
+			b.WriteString(upperFirst(w))
+		}
+	}
+	return b.String()
+}
+
+// lowerFirstWord lower-cases the leading upper-case run of w, leaving the
+// capital that starts the next word: HTTPServer becomes httpServer, ID id.
+func lowerFirstWord(w string) string {
+	runes := []rune(w)
+	n := 0
+	for n < len(runes) && unicode.IsUpper(runes[n]) {
+		n++
+	}
+	if n > 1 && n < len(runes) && unicode.IsLower(runes[n]) {
+		n--
+	}
+	for i := range n {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+func upperFirst(w string) string {
+	r, size := utf8.DecodeRuneInString(w)
+	if size == 0 {
+		return w
+	}
+	return string(unicode.ToUpper(r)) + w[size:]
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateOperationIDs(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		o       OperationIDOptions
+		wantErr string
+	}{
+		{"default", OperationIDOptions{}, ""},
+		{"camel", OperationIDOptions{Style: "camel"}, ""},
+		{"template", OperationIDOptions{Template: "{{.Method}}{{.PathCamel}}"}, ""},
+		{"unknown style", OperationIDOptions{Style: "snake"}, `operationIds.style: unknown style "snake"`},
+		{"unparsable template", OperationIDOptions{Template: "{{.Method"}, "operationIds.template:"},
+		{"unknown field", OperationIDOptions{Template: "{{.Verb}}"}, "operationIds.template:"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := APISpecConfig{OperationIDs: tc.o}
+			err := cfg.ValidateOperationIDs()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCamelIdentifier(t *testing.T) {
+	for in, want := range map[string]string{
+		"handlers.UserHandler.GetUser":           "handlersUserHandlerGetUser",
+		" getUser":                               "getUser",
+		"HTTPServer.Serve":                       "httpServerServe",
+		"ID":                                     "id",
+		"itemHandler GET":                        "itemHandlerGet",
+		"/users/{id}/orders":                     "usersIdOrders",
+		"HandleRequest[SendEmailRequest, Reply]": "handleRequestSendEmailRequestReply",
+		"":                                       "",
+	} {
+		if got := camelIdentifier(in); got != want {
+			t.Errorf("camelIdentifier(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestStripModulePath(t *testing.T) {
+	const module = "github.com/acme/api"
+	for in, want := range map[string]string{
+		module:                                 "",
+		module + "/handlers":                   "handlers",
+		"HandleRequest[" + module + ".User]":   "HandleRequest[User]",
+		"List[" + module + "/models.User]":     "List[models.User]",
+		"github.com/other/lib/promhttp.Handle": "promhttp.Handle",
+	} {
+		if got := stripModulePath(in, module); got != want {
+			t.Errorf("stripModulePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestAssignOperationIDs(t *testing.T) {
+	const module = "github.com/acme/api"
+	route := func(method, path, pkg, fn string) *RouteInfo {
+		return &RouteInfo{Method: method, Path: path, Package: pkg, Function: fn, Tags: []string{"users"}}
+	}
+	routes := []*RouteInfo{
+		route("GET", "/users", module, "list"),
+		route("GET", "/people", module, "list"),
+		route("GET", "/users/{id}", module+"/handlers", module+TypeSep+"handlers.UserHandler.Get"),
+		route("POST", "/users", module, "create"),
+	}
+	routes[3].OperationID = "createUser" // a swaggo @ID
+	build := func(o OperationIDOptions, reserved ...string) map[string]string {
+		paths := buildPathsFromRoutes(routes)
+		assignOperationIDs(paths, routes, &APISpecConfig{OperationIDs: o}, module, reserved...)
+		ids := map[string]string{}
+		for path, item := range paths {
+			for _, mo := range item.Operations() {
+				ids[mo.Method+" "+path] = mo.Operation.OperationID
+			}
+		}
+		return ids
+	}
+	check := func(t *testing.T, got, want map[string]string) {
+		t.Helper()
+		for k, w := range want {
+			if got[k] != w {
+				t.Errorf("%s operationId = %q, want %q", k, got[k], w)
+			}
+		}
+	}
+
+	t.Run("default ids keep their form; a collision gets a suffix", func(t *testing.T) {
+		check(t, build(OperationIDOptions{}), map[string]string{
+			"GET /people": module + ".list",
+			"GET /users":  module + ".list_2",
+			"POST /users": "createUser",
+		})
+	})
+	t.Run("camel", func(t *testing.T) {
+		check(t, build(OperationIDOptions{Style: "camel"}), map[string]string{
+			"GET /people":     "list",
+			"GET /users":      "list2",
+			"GET /users/{id}": "handlersUserHandlerGet",
+			"POST /users":     "createUser",
+		})
+	})
+	t.Run("template", func(t *testing.T) {
+		check(t, build(OperationIDOptions{Template: "{{.Method}}{{.PathCamel}}"}), map[string]string{
+			"GET /people":     "getPeople",
+			"GET /users":      "getUsers",
+			"GET /users/{id}": "getUsersId",
+		})
+		check(t, build(OperationIDOptions{Template: "{{.Tag}}_{{.Function}}"}), map[string]string{
+			"GET /people": "usersList",
+			"GET /users":  "usersList2",
+		})
+	})
+	t.Run("explicit ids are reserved", func(t *testing.T) {
+		check(t, build(OperationIDOptions{Template: "{{.Method}}{{.PathCamel}}"}, "getPeople"), map[string]string{
+			"GET /people": "getPeople2",
+		})
+	})
+}
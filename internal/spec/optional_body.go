@@ -0,0 +1,72 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"slices"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// requestBodyOptional reports whether the request body decoded at node is
+// optional: defaults.requestBodyRequired when configured, otherwise whether
+// the decode — or a call leading to it from the route's handler, such as a
+// decodeJSON(r, &v) helper — sits where the handler only conditionally reads
+// the body (an `if r.Body != nil` arm, an io.EOF-tolerant error check; see
+// metadata.Function.OptionalBody).
+func (e *Extractor) requestBodyOptional(node TrackerNodeInterface, route *RouteInfo) bool {
+	if e.cfg != nil && e.cfg.Defaults.RequestBodyRequired != nil {
+		return !*e.cfg.Defaults.RequestBodyRequired
+	}
+	ranges := e.optionalBodyRanges(route.Metadata)
+	if len(ranges) == 0 {
+		return false
+	}
+	for n := node; n != nil && n != route.Node; n = n.GetParent() {
+		file, line, _ := calleePosition(n)
+		if slices.ContainsFunc(ranges[file], func(r metadata.LineRange) bool { return r.Contains(line) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// optionalBodyRanges indexes every function's and method's OptionalBody by
+// source file, built once per extractor.
+func (e *Extractor) optionalBodyRanges(meta *metadata.Metadata) map[string][]metadata.LineRange {
+	if e.optionalBodies != nil || meta == nil {
+		return e.optionalBodies
+	}
+	e.optionalBodies = map[string][]metadata.LineRange{}
+	add := func(pos int, ranges []metadata.LineRange) {
+		if len(ranges) > 0 {
+			file := fileOfPosition(meta.StringPool.GetString(pos))
+			e.optionalBodies[file] = append(e.optionalBodies[file], ranges...)
+		}
+	}
+	for _, pkg := range meta.Packages {
+		for _, file := range pkg.Files {
+			for _, fn := range file.Functions {
+				add(fn.Position, fn.OptionalBody)
+			}
+			for _, typ := range file.Types {
+				for _, m := range typ.Methods {
+					add(m.Position, m.OptionalBody)
+				}
+			}
+		}
+	}
+	return e.optionalBodies
+}
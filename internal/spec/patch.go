@@ -0,0 +1,135 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"go/ast"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// Partial-update request body media types.
+const (
+	mergePatchContentType = "application/merge-patch+json" // RFC 7386
+	jsonPatchContentType  = "application/json-patch+json"  // RFC 6902
+)
+
+// mergePatchSuffix names the all-optional variant of a component used as a
+// merge patch body.
+const mergePatchSuffix = "_MergePatch"
+
+// jsonPatchSchema is an RFC 6902 JSON Patch document: a list of operations,
+// each naming its target by JSON Pointer.
+var jsonPatchSchema = &Schema{
+	Type: "array",
+	Items: &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"op":    {Type: "string", Enum: []any{"add", "remove", "replace", "move", "copy", "test"}},
+			"path":  {Type: "string", Description: "JSON Pointer to the target location."},
+			"from":  {Type: "string", Description: "JSON Pointer to the source location of a move or copy."},
+			"value": {Description: "The value to add, replace or test."},
+		},
+		Required: []string{"op", "path"},
+	},
+}
+
+// applyPatchSemantics sends PATCH bodies decoded into a struct of pointer
+// fields as a JSON Merge Patch: a field left out of the document stays nil,
+// which is how the handler tells "unchanged" from "set". Bodies whose content
+// type something else already decided are left alone.
+func (e *Extractor) applyPatchSemantics(routes []*RouteInfo) {
+	for _, r := range routes {
+		if r.Method != http.MethodPatch || r.Request == nil || r.Request.ContentType != e.cfg.Defaults.RequestContentType {
+			continue
+		}
+		if isPointerFieldStruct(r.Request.BodyType, r.Metadata) {
+			r.Request.ContentType = mergePatchContentType
+		}
+	}
+}
+
+// isPointerFieldStruct reports whether bodyType is a struct whose every
+// JSON-visible field is a pointer.
+func isPointerFieldStruct(bodyType string, meta *metadata.Metadata) bool {
+	if meta == nil || bodyType == "" {
+		return false
+	}
+	typ := findTypesInMetadata(meta, strings.TrimPrefix(bodyType, "*"))[strings.TrimPrefix(bodyType, "*")]
+	if typ == nil || getStringFromPool(meta, typ.Kind) != "struct" || len(typ.Embeds) > 0 {
+		return false
+	}
+	fields := 0
+	for _, field := range typ.Fields {
+		name := getStringFromPool(meta, field.Name)
+		if !ast.IsExported(name) || jsonFieldOmitted(getStringFromPool(meta, field.Tag)) || protobufInternalField(name) {
+			continue
+		}
+		if !strings.HasPrefix(getStringFromPool(meta, field.Type), "*") {
+			return false
+		}
+		fields++
+	}
+	return fields > 0
+}
+
+// addMergePatchVariants points every merge patch body at an all-optional
+// variant of its schema: a merge patch carries only the members to change, so
+// the required list of the full resource does not apply. A component with
+// required properties gets a "<name>_MergePatch" sibling without them, shared
+// by every operation that patches it.
+func addMergePatchVariants(paths map[string]PathItem, components *Components) {
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		for _, mo := range paths[path].Operations() {
+			op := mo.Operation
+			if op.RequestBody == nil {
+				continue
+			}
+			media, ok := op.RequestBody.Content[mergePatchContentType]
+			if !ok || media.Schema == nil {
+				continue
+			}
+			media.Schema = mergePatchSchema(media.Schema, components)
+			op.RequestBody.Content[mergePatchContentType] = media
+		}
+	}
+}
+
+func mergePatchSchema(schema *Schema, components *Components) *Schema {
+	if schema.Ref == "" {
+		if len(schema.Required) == 0 {
+			return schema
+		}
+		optional := *schema
+		optional.Required = nil
+		return &optional
+	}
+	name := strings.TrimPrefix(schema.Ref, refComponentsSchemasPrefix)
+	target := components.Schemas[name]
+	if target == nil || len(target.Required) == 0 {
+		return schema
+	}
+	variant := name + mergePatchSuffix
+	if _, ok := components.Schemas[variant]; !ok {
+		optional := *target
+		optional.Required = nil
+		components.Schemas[variant] = &optional
+	}
+	return &Schema{Ref: refComponentsSchemasPrefix + variant}
+}
@@ -16,6 +16,7 @@ package spec
 
 import (
 	"net/http"
+	"slices"
 	"strings"
 	"unicode"
 
@@ -72,6 +73,16 @@ func (b *BasePatternMatcher) resolvePathArg(arg *metadata.CallArgument) (path, d
 	return b.contextProvider.GetArgumentInfo(arg), ""
 }
 
+// pathResolved reports whether an empty rendering of the path argument arg
+// is its value: an empty literal, or a constant naming one.
+func pathResolved(arg *metadata.CallArgument) bool {
+	switch arg.GetKind() {
+	case metadata.KindLiteral, metadata.KindIdent, metadata.KindSelector:
+		return true
+	}
+	return false
+}
+
 // serveMuxTrailingWildcard matches Go 1.22 ServeMux trailing wildcards
 // ({path...}), which OpenAPI cannot express. The capture group keeps the
 // parameter name so it can be rewritten to a plain {path} segment.
@@ -95,14 +106,42 @@ func splitMethodFromPath(raw string) (method, path string) {
 }
 
 // normalizeServeMuxPath rewrites ServeMux-specific path syntax into OpenAPI
-// path templating: trailing wildcards ({path...}) collapse to {path}, and the
-// {$} end-of-path anchor is dropped.
+// path templating: a host prefix ("api.example.com/users") is dropped,
+// trailing wildcards ({path...}) collapse to {path}, and the {$} end-of-path
+// anchor is dropped.
 func normalizeServeMuxPath(path string) string {
+	path = stripServeMuxHost(path)
 	path = serveMuxTrailingWildcard.ReplaceAllString(path, "{$1}")
 	path = strings.ReplaceAll(path, "{$}", "")
 	return path
 }
 
+// stripServeMuxHost drops the host of a host-specific ServeMux pattern
+// ("api.example.com/users" → "/users"). Only a prefix that looks like a host
+// — a dot or a port, no wildcard — is dropped, so a wrapper registering a
+// relative "users/{id}" keeps its path.
+func stripServeMuxHost(path string) string {
+	i := strings.IndexByte(path, '/')
+	if i <= 0 {
+		return path
+	}
+	host := path[:i]
+	if strings.ContainsAny(host, "{}") || (!strings.ContainsAny(host, ".:") && host != "localhost") {
+		return path
+	}
+	return path[i:]
+}
+
+// serveMuxRemainderParams returns the names of the trailing wildcards
+// ({path...}) in a ServeMux pattern, which match the rest of the path.
+func serveMuxRemainderParams(path string) []string {
+	var names []string
+	for _, m := range serveMuxTrailingWildcard.FindAllStringSubmatch(path, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
 // isHTTPMethod reports whether s is a recognised HTTP method (upper-case).
 func isHTTPMethod(s string) bool {
 	switch s {
@@ -224,7 +263,7 @@ func (r *RoutePatternMatcherImpl) ExtractRoute(node TrackerNodeInterface, routeI
 	// Extract handler information
 	if r.pattern.HandlerFromArg && len(edge.Args) > r.pattern.HandlerArgIndex {
 		found = true
-		handlerArg := edge.Args[r.pattern.HandlerArgIndex]
+		handlerArg := r.handlerArg(edge)
 		if handlerArg.GetKind() == metadata.KindIdent || handlerArg.GetKind() == metadata.KindFuncLit {
 
 			handlerName := handlerArg.GetName()
@@ -256,7 +295,21 @@ func (r *RoutePatternMatcherImpl) extractRouteDetails(node TrackerNodeInterface,
 	found := false
 	edge := node.GetEdge()
 
-	if r.pattern.MethodFromCall {
+	if len(r.pattern.Methods) > 0 {
+		routeInfo.Method = strings.ToUpper(r.pattern.Methods[0])
+		routeInfo.MethodExplicit = true
+		if len(r.pattern.Methods) > 1 {
+			routeInfo.Methods = make([]string, len(r.pattern.Methods))
+			for i, m := range r.pattern.Methods {
+				routeInfo.Methods[i] = strings.ToUpper(m)
+			}
+		}
+		found = true
+	} else if r.pattern.Method != "" {
+		routeInfo.Method = strings.ToUpper(r.pattern.Method)
+		routeInfo.MethodExplicit = true
+		found = true
+	} else if r.pattern.MethodFromCall {
 		funcName := r.contextProvider.GetString(edge.Callee.Name)
 		routeInfo.Method = r.extractMethodFromFunctionNameWithConfig(funcName, r.pattern.MethodExtraction)
 		routeInfo.MethodExplicit = true
@@ -265,7 +318,7 @@ func (r *RoutePatternMatcherImpl) extractRouteDetails(node TrackerNodeInterface,
 		// Extract method from handler function name. Only a real mapping hit
 		// makes the verb explicit — a DefaultMethod fallback keeps the route
 		// open so a `switch r.Method` handler still splits per dispatch verb.
-		handlerArg := edge.Args[r.pattern.HandlerArgIndex]
+		handlerArg := r.handlerArg(edge)
 		handlerName := r.contextProvider.GetArgumentInfo(handlerArg)
 		if handlerName != "" {
 			var matched bool
@@ -275,30 +328,26 @@ func (r *RoutePatternMatcherImpl) extractRouteDetails(node TrackerNodeInterface,
 		}
 	} else if r.pattern.MethodArgIndex >= 0 && len(edge.Args) > r.pattern.MethodArgIndex {
 		methodArg := edge.Args[r.pattern.MethodArgIndex]
-		methodValue := methodArg.GetValue()
-
-		// Handle different method extraction patterns
-		if methodValue != "" {
-			// Clean up method value - remove quotes and extract HTTP method
-			cleanMethod := strings.Trim(methodValue, "\"'")
 
-			// Check if it's a valid HTTP method
-			if r.isValidHTTPMethod(cleanMethod) {
-				routeInfo.Method = strings.ToUpper(cleanMethod)
-				routeInfo.MethodExplicit = true
-				found = true
-			} else {
-				// If not a valid method, try to extract from argument info
-				argInfo := r.contextProvider.GetArgumentInfo(methodArg)
-				if argInfo != "" {
-					cleanArgInfo := strings.Trim(argInfo, "\"'")
-					if r.isValidHTTPMethod(cleanArgInfo) {
-						routeInfo.Method = strings.ToUpper(cleanArgInfo)
-						routeInfo.MethodExplicit = true
-						found = true
-					}
+		// A method list (echo's e.Match([]string{GET, HEAD}, ...)) registers
+		// the route for each verb; ExtractRoutes splits it per verb.
+		var methods []string
+		if methodArg.GetKind() == metadata.KindCompositeLit {
+			for _, elt := range methodArg.Args {
+				if m := r.methodFromArg(elt); m != "" && !slices.Contains(methods, m) {
+					methods = append(methods, m)
 				}
 			}
+		} else if m := r.methodFromArg(methodArg); m != "" {
+			methods = []string{m}
+		}
+		if len(methods) > 0 {
+			routeInfo.Method = methods[0]
+			routeInfo.MethodExplicit = true
+			if len(methods) > 1 {
+				routeInfo.Methods = methods
+			}
+			found = true
 		}
 
 		// If we still don't have a method, try to infer from context (if enabled)
@@ -321,10 +370,18 @@ func (r *RoutePatternMatcherImpl) extractRouteDetails(node TrackerNodeInterface,
 				routeInfo.MethodExplicit = true
 				path = rest
 			}
+			routeInfo.RemainderParams = serveMuxRemainderParams(path)
 			path = normalizeServeMuxPath(path)
 		}
 		routeInfo.Path = path
-		if routeInfo.Path == "" {
+		if r.pattern.FileServer {
+			routeInfo.Path = strings.TrimSuffix(path, "/") + "/{" + fileServerParam + "}"
+			routeInfo.RemainderParams = append(routeInfo.RemainderParams, fileServerParam)
+		}
+		// An empty literal registers the mount's root; an expression that
+		// renders empty could not be resolved, and leaves the route without
+		// a path rather than documenting it at the root.
+		if routeInfo.Path == "" && pathResolved(edge.Args[r.pattern.PathArgIndex]) {
 			routeInfo.Path = "/"
 		}
 		if dynName != "" {
@@ -333,14 +390,23 @@ func (r *RoutePatternMatcherImpl) extractRouteDetails(node TrackerNodeInterface,
 		found = true
 	}
 
+	if r.pattern.FileServer {
+		applyFileServer(routeInfo, r.contextProvider.GetString(edge.Callee.Name))
+		found = true
+	}
+
 	if r.pattern.HandlerFromArg && len(edge.Args) > r.pattern.HandlerArgIndex {
-		routeInfo.Handler = r.contextProvider.GetArgumentInfo(edge.Args[r.pattern.HandlerArgIndex])
-		routeInfo.Function = r.contextProvider.GetArgumentInfo(edge.Args[r.pattern.HandlerArgIndex])
+		handlerArg := r.handlerArg(edge)
+		routeInfo.Handler = r.contextProvider.GetArgumentInfo(handlerArg)
+		routeInfo.Function = r.contextProvider.GetArgumentInfo(handlerArg)
 
-		pkg := edge.Args[r.pattern.HandlerArgIndex].GetPkg()
+		pkg := handlerArg.GetPkg()
 		if pkg == "" {
-			if node != nil && edge != nil && edge.Args[r.pattern.HandlerArgIndex].Fun != nil {
-				pkg = edge.Args[r.pattern.HandlerArgIndex].Fun.GetPkg()
+			if node != nil && edge != nil && handlerArg.Fun != nil {
+				pkg = handlerArg.Fun.GetPkg()
+			} else if handlerArg.GetKind() == metadata.KindCompositeLit && handlerArg.X != nil {
+				// A handler value literal: MetricsHandler{}.
+				pkg = handlerArg.X.GetPkg()
 			}
 		}
 		routeInfo.Package = pkg
@@ -350,6 +416,39 @@ func (r *RoutePatternMatcherImpl) extractRouteDetails(node TrackerNodeInterface,
 	return found
 }
 
+// handlerArg returns the route's handler argument, seen through the
+// framework's handler adapters (echo.WrapHandler(h) is h) and a variable
+// holding a named function or a handler factory's closure (see
+// funcVariable). A variable holding a func literal keeps naming the route.
+func (r *RoutePatternMatcherImpl) handlerArg(edge *metadata.CallGraphEdge) *metadata.CallArgument {
+	arg := edge.Args[r.pattern.HandlerArgIndex]
+	if r.cfg != nil {
+		arg = unwrapHandlerAdapters(arg, r.cfg.Framework.HandlerAdapters)
+	}
+	if v := funcVariable(edge, arg, edge.Caller.Meta); v != nil && v.GetKind() != metadata.KindFuncLit {
+		arg = v
+	}
+	return arg
+}
+
+// methodFromArg returns the HTTP method an argument names, as a literal
+// ("GET") or a constant (http.MethodGet), or "" when it names none.
+func (r *RoutePatternMatcherImpl) methodFromArg(arg *metadata.CallArgument) string {
+	methodValue := arg.GetValue()
+	if methodValue == "" {
+		return ""
+	}
+	// Clean up method value - remove quotes and extract HTTP method
+	if cleanMethod := strings.Trim(methodValue, "\"'"); r.isValidHTTPMethod(cleanMethod) {
+		return strings.ToUpper(cleanMethod)
+	}
+	// If not a valid method, try to extract from argument info
+	if cleanArgInfo := strings.Trim(r.contextProvider.GetArgumentInfo(arg), "\"'"); r.isValidHTTPMethod(cleanArgInfo) {
+		return strings.ToUpper(cleanArgInfo)
+	}
+	return ""
+}
+
 // isValidHTTPMethod checks if a string is a valid HTTP method
 func (r *RoutePatternMatcherImpl) isValidHTTPMethod(method string) bool {
 	validMethods := []string{
@@ -555,7 +654,14 @@ func (m *MountPatternMatcherImpl) ExtractMount(node TrackerNodeInterface) MountI
 	edge := node.GetEdge()
 	// Extract path if available
 	if m.pattern.PathFromArg && len(edge.Args) > m.pattern.PathArgIndex {
-		path, dynName := m.resolvePathArg(edge.Args[m.pattern.PathArgIndex])
+		// A prefix handed down as a parameter resolves to the value its
+		// callers pass; the mount node sits under the router's producer, not
+		// the helper's call, so the binding is read from the call graph.
+		pathArg := edge.Args[m.pattern.PathArgIndex]
+		if bound := paramBinding(edge.Caller.Meta, edge, pathArg); bound != nil {
+			pathArg = bound
+		}
+		path, dynName := m.resolvePathArg(pathArg)
 		mountInfo.Path = path
 		if dynName != "" {
 			mountInfo.DynamicParams = append(mountInfo.DynamicParams, dynName)
@@ -876,6 +982,13 @@ func (r *RequestPatternMatcherImpl) ExtractRequest(node TrackerNodeInterface, ro
 	reqInfo := &RequestInfo{
 		ContentType: r.cfg.Defaults.RequestContentType,
 	}
+	if r.pattern.ContentType != "" {
+		reqInfo.ContentType = r.pattern.ContentType
+	}
+	if r.pattern.BodyType != "" {
+		reqInfo.BodyType = r.pattern.BodyType
+		reqInfo.Schema, _ = mapGoTypeToOpenAPISchema(route.UsedTypes, r.pattern.BodyType, route.Metadata, r.cfg, nil)
+	}
 
 	edge := node.GetEdge()
 	if r.pattern.TypeFromArg && len(edge.Args) > r.pattern.TypeArgIndex {
@@ -960,10 +1073,35 @@ func (r *RequestPatternMatcherImpl) ExtractRequest(node TrackerNodeInterface, ro
 	if reqInfo.BodyType == "" {
 		return nil
 	}
+	if isUploadBody(reqInfo.BodyType, route.Metadata) {
+		reqInfo.ContentType = multipartFormData
+	}
 
 	return reqInfo
 }
 
+// isUploadBody reports whether the bound body type has a file field
+// (*multipart.FileHeader, []*multipart.FileHeader, multipart.File). Such a
+// struct can only be bound from a multipart form.
+func isUploadBody(bodyType string, meta *metadata.Metadata) bool {
+	if meta == nil {
+		return false
+	}
+	for _, typ := range findTypesInMetadata(meta, bodyType) {
+		if typ == nil {
+			continue
+		}
+		for _, field := range typ.Fields {
+			ft := strings.TrimLeft(getStringFromPool(meta, field.Type), "*[]")
+			switch shortTypeName(ft) {
+			case "multipart.FileHeader", "multipart.File":
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Helper methods for BasePatternMatcher
 func (b *BasePatternMatcher) matchPattern(pattern, value string) bool {
 	if pattern == "" {
@@ -0,0 +1,105 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"strings"
+)
+
+// This file maps the structs protoc-gen-go generates. Their JSON form is the
+// proto3 JSON mapping (protojson, as grpc-gateway and Connect serve it):
+// well-known types collapse to the scalar they wrap, a Timestamp to an RFC
+// 3339 string, and the generator's bookkeeping fields are not part of it.
+
+// protobufWellKnownPackages are the import paths of the well-known types,
+// current and from the deprecated github.com/golang/protobuf module, keyed to
+// the package name the schemas below are listed under.
+var protobufWellKnownPackages = map[string]string{
+	"google.golang.org/protobuf/types/known/anypb":       "anypb",
+	"google.golang.org/protobuf/types/known/durationpb":  "durationpb",
+	"google.golang.org/protobuf/types/known/emptypb":     "emptypb",
+	"google.golang.org/protobuf/types/known/fieldmaskpb": "fieldmaskpb",
+	"google.golang.org/protobuf/types/known/structpb":    "structpb",
+	"google.golang.org/protobuf/types/known/timestamppb": "timestamppb",
+	"google.golang.org/protobuf/types/known/wrapperspb":  "wrapperspb",
+	"github.com/golang/protobuf/ptypes/any":              "anypb",
+	"github.com/golang/protobuf/ptypes/duration":         "durationpb",
+	"github.com/golang/protobuf/ptypes/empty":            "emptypb",
+	"github.com/golang/protobuf/ptypes/struct":           "structpb",
+	"github.com/golang/protobuf/ptypes/timestamp":        "timestamppb",
+	"github.com/golang/protobuf/ptypes/wrappers":         "wrapperspb",
+	"google.golang.org/genproto/protobuf/field_mask":     "fieldmaskpb",
+}
+
+// protobufWellKnownSchema returns the proto3 JSON schema of a well-known
+// type, given by full import path (google.golang.org/protobuf/types/known/
+// timestamppb.Timestamp) or by its *pb package name (timestamppb.Timestamp),
+// or nil. Each call returns a fresh schema, so callers may decorate it.
+func protobufWellKnownSchema(goType string) *Schema {
+	i := strings.LastIndex(goType, ".")
+	if i < 0 {
+		return nil
+	}
+	pkg, name := goType[:i], goType[i+1:]
+	if strings.Contains(pkg, "/") {
+		pkg = protobufWellKnownPackages[pkg]
+	}
+
+	switch pkg + "." + name {
+	case "timestamppb.Timestamp":
+		return &Schema{Type: "string", Format: "date-time"}
+	case "durationpb.Duration":
+		return &Schema{Type: "string", Pattern: `^-?[0-9]+(\.[0-9]{1,9})?s$`, Description: "Duration in seconds with an `s` suffix, such as `1.5s`."}
+	case "fieldmaskpb.FieldMask":
+		return &Schema{Type: "string", Description: "Comma-separated field paths in lowerCamelCase."}
+	case "wrapperspb.StringValue":
+		return &Schema{Type: "string"}
+	case "wrapperspb.BytesValue":
+		return &Schema{Type: "string", Format: "byte"}
+	case "wrapperspb.BoolValue":
+		return &Schema{Type: "boolean"}
+	case "wrapperspb.Int32Value", "wrapperspb.UInt32Value":
+		return &Schema{Type: "integer"}
+	case "wrapperspb.Int64Value":
+		// 64-bit integers are written as strings, which JavaScript can hold.
+		return &Schema{Type: "string", Format: "int64", Pattern: `^-?[0-9]+$`}
+	case "wrapperspb.UInt64Value":
+		return &Schema{Type: "string", Format: "int64", Pattern: `^[0-9]+$`}
+	case "wrapperspb.FloatValue", "wrapperspb.DoubleValue":
+		return &Schema{Type: "number"}
+	case "emptypb.Empty", "structpb.Struct":
+		return &Schema{Type: "object"}
+	case "structpb.ListValue":
+		return &Schema{Type: "array", Items: &Schema{Description: "Any JSON value."}}
+	case "structpb.Value":
+		return &Schema{Description: "Any JSON value."}
+	case "anypb.Any":
+		return &Schema{
+			Type:        "object",
+			Description: "A message of any type, named by its `@type` URL, with the message's fields alongside.",
+			Properties:  map[string]*Schema{"@type": {Type: "string"}},
+			Required:    []string{"@type"},
+		}
+	}
+	return nil
+}
+
+// protobufInternalField reports whether a struct field is bookkeeping of an
+// older protobuf generator (XXX_unrecognized, XXX_sizecache, …), which the
+// message's JSON form does not carry. The state fields of protoc-gen-go's
+// current output are unexported and skipped like any other.
+func protobufInternalField(name string) bool {
+	return strings.HasPrefix(name, "XXX_")
+}
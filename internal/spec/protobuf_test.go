@@ -0,0 +1,67 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "testing"
+
+func TestProtobufWellKnownSchema(t *testing.T) {
+	for _, tc := range []struct {
+		goType, typ, format string
+	}{
+		{"google.golang.org/protobuf/types/known/timestamppb.Timestamp", "string", "date-time"},
+		{"timestamppb.Timestamp", "string", "date-time"},
+		{"github.com/golang/protobuf/ptypes/timestamp.Timestamp", "string", "date-time"},
+		{"google.golang.org/protobuf/types/known/wrapperspb.Int64Value", "string", "int64"},
+		{"wrapperspb.UInt32Value", "integer", ""},
+		{"wrapperspb.BytesValue", "string", "byte"},
+		{"durationpb.Duration", "string", ""},
+		{"emptypb.Empty", "object", ""},
+	} {
+		s := protobufWellKnownSchema(tc.goType)
+		if s == nil || s.Type != tc.typ || s.Format != tc.format {
+			t.Errorf("protobufWellKnownSchema(%s) = %+v, want type %q format %q", tc.goType, s, tc.typ, tc.format)
+		}
+	}
+
+	for _, goType := range []string{
+		"github.com/acme/api/timestamppb.Stamp",
+		"github.com/acme/api/wrapperspb.StringValue",
+		"time.Time",
+		"Timestamp",
+	} {
+		if s := protobufWellKnownSchema(goType); s != nil {
+			t.Errorf("protobufWellKnownSchema(%s) = %+v, want nil", goType, s)
+		}
+	}
+
+	a, b := protobufWellKnownSchema("anypb.Any"), protobufWellKnownSchema("anypb.Any")
+	a.Properties["@type"].Description = "changed"
+	if b.Properties["@type"].Description != "" {
+		t.Error("protobufWellKnownSchema returned a shared schema")
+	}
+}
+
+func TestProtobufInternalField(t *testing.T) {
+	for name, want := range map[string]bool{
+		"XXX_unrecognized":     true,
+		"XXX_NoUnkeyedLiteral": true,
+		"XXX":                  false,
+		"UserId":               false,
+	} {
+		if got := protobufInternalField(name); got != want {
+			t.Errorf("protobufInternalField(%s) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,371 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// ProtocolPatternMatcherImpl implements ProtocolPatternMatcher
+type ProtocolPatternMatcherImpl struct {
+	*BasePatternMatcher
+	pattern ProtocolPattern
+}
+
+// NewProtocolPatternMatcher creates a new protocol pattern matcher
+func NewProtocolPatternMatcher(pattern ProtocolPattern, cfg *APISpecConfig, contextProvider ContextProvider, typeResolver TypeResolver) *ProtocolPatternMatcherImpl {
+	return &ProtocolPatternMatcherImpl{
+		BasePatternMatcher: NewBasePatternMatcher(cfg, contextProvider, typeResolver),
+		pattern:            pattern,
+	}
+}
+
+// MatchNode checks if a node matches the protocol pattern. Like the other
+// matcher families the verdict depends only on the node's edge, so the
+// extractor memoizes it per edge.
+func (p *ProtocolPatternMatcherImpl) MatchNode(node TrackerNodeInterface) bool {
+	if node == nil || node.GetEdge() == nil {
+		return false
+	}
+
+	edge := node.GetEdge()
+	callName := p.contextProvider.GetString(edge.Callee.Name)
+	recvType := p.contextProvider.GetString(edge.Callee.RecvType)
+	recvPkg := p.contextProvider.GetString(edge.Callee.Pkg)
+
+	// Build fully qualified receiver type
+	fqRecvType := recvPkg
+	if fqRecvType != "" && recvType != "" {
+		fqRecvType += "." + recvType
+	} else if recvType != "" {
+		fqRecvType = recvType
+	}
+
+	if p.pattern.CallRegex != "" && !p.matchPattern(p.pattern.CallRegex, callName) {
+		return false
+	}
+
+	if p.pattern.FunctionNameRegex != "" {
+		funcName := p.contextProvider.GetString(edge.Caller.Name)
+		if !p.matchPattern(p.pattern.FunctionNameRegex, funcName) {
+			return false
+		}
+	}
+
+	if p.pattern.RecvTypeRegex != "" {
+		re, err := cachedRegex(p.pattern.RecvTypeRegex)
+		if err != nil || !re.MatchString(fqRecvType) {
+			return false
+		}
+	} else if p.pattern.RecvType != "" && p.pattern.RecvType != fqRecvType {
+		return false
+	}
+
+	if p.pattern.ArgValueRegex != "" {
+		if p.pattern.ArgIndex < 0 || len(edge.Args) <= p.pattern.ArgIndex {
+			return false
+		}
+		value := p.argValue(edge.Args[p.pattern.ArgIndex])
+		if !p.matchPattern(p.pattern.ArgValueRegex, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// argValue renders a gating argument as its constant value: a string literal
+// or a const ident resolves to the unquoted text, anything else to whatever
+// GetArgumentInfo yields (which never matches a literal value regex).
+func (p *ProtocolPatternMatcherImpl) argValue(arg *metadata.CallArgument) string {
+	if arg == nil {
+		return ""
+	}
+	value := p.contextProvider.GetArgumentInfo(arg)
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return strings.Trim(value, "\"'`")
+}
+
+// GetPattern returns the protocol pattern
+func (p *ProtocolPatternMatcherImpl) GetPattern() interface{} {
+	return p.pattern
+}
+
+// GetPriority returns the priority of this pattern
+func (p *ProtocolPatternMatcherImpl) GetPriority() int {
+	priority := 0
+	if p.pattern.CallRegex != "" {
+		priority += 10
+	}
+	if p.pattern.FunctionNameRegex != "" {
+		priority += 5
+	}
+	if p.pattern.RecvTypeRegex != "" || p.pattern.RecvType != "" {
+		priority += 3
+	}
+	return priority
+}
+
+// Protocol returns the protocol the matched call switches to
+func (p *ProtocolPatternMatcherImpl) Protocol() string {
+	return p.pattern.Protocol
+}
+
+// protocolOf returns the protocol a route-subtree node upgrades to, or "" when
+// no protocol matcher accepts it. Memoized per edge like the response/request
+// matcher verdicts.
+func (e *Extractor) protocolOf(node TrackerNodeInterface) string {
+	if len(e.protocolMatchers) == 0 || node == nil || node.GetEdge() == nil {
+		return ""
+	}
+	edge := node.GetEdge()
+	if proto, ok := e.protocolByEdge[edge]; ok {
+		return proto
+	}
+	proto := ""
+	for _, matcher := range e.protocolMatchers {
+		if matcher.MatchNode(node) {
+			proto = matcher.Protocol()
+			break
+		}
+	}
+	if e.protocolByEdge == nil {
+		e.protocolByEdge = map[*metadata.CallGraphEdge]string{}
+	}
+	e.protocolByEdge[edge] = proto
+	return proto
+}
+
+// applyProtocolDefaults pins streaming routes to GET. A websocket handshake
+// and an EventSource request are GETs by definition, so a verb-less
+// registration (HandleFunc, Handle) must not fall through to the POST
+// default. Routes with an explicit verb, or that were split per
+// `switch r.Method` branch, keep theirs. A long poll or a file download may
+// answer any verb (a POST export), so it is left alone.
+func applyProtocolDefaults(routes []*RouteInfo) {
+	for _, r := range routes {
+		if (r.Protocol != ProtocolWebSocket && r.Protocol != ProtocolSSE) || r.MethodExplicit || r.OperationIDSuffix != "" {
+			continue
+		}
+		r.Method = "GET"
+	}
+}
+
+// applyProtocolToOperation marks an operation whose handler upgrades the
+// connection. Websockets get x-websocket plus the 101 Switching Protocols
+// handshake response; SSE streams get x-sse plus a text/event-stream body on
+// the success response (added alongside any body already detected, since a
+// handler may still answer JSON on its non-streaming branches). NDJSON
+// streams get x-ndjson and document the encoded value as the item of each
+// line (see applyNDJSONResponse); other chunked bodies get x-chunked. Long
+// polls get x-long-poll plus a 202 for a poll that timed out, unless the
+// handler answers that with its own 202 or 204. Downloads get a binary
+// success body instead; see applyDownloadResponse.
+func applyProtocolToOperation(op *Operation, protocol string) {
+	if op == nil || protocol == "" {
+		return
+	}
+	if op.Responses == nil {
+		op.Responses = map[string]Response{}
+	}
+	switch protocol {
+	case ProtocolWebSocket:
+		setOperationExtension(op, "x-websocket", true)
+		if op.Description == "" {
+			op.Description = "WebSocket endpoint: the client opens it with an HTTP GET carrying `Upgrade: websocket`, " +
+				"and on success the connection switches protocols."
+		}
+		if _, ok := op.Responses["101"]; !ok {
+			op.Responses["101"] = Response{Description: "Switching Protocols"}
+		}
+	case ProtocolSSE:
+		setOperationExtension(op, "x-sse", true)
+		if op.Description == "" {
+			op.Description = "Server-Sent Events endpoint: the response is a `text/event-stream` kept open while events are pushed."
+		}
+		status := successStatus(op.Responses)
+		resp := op.Responses[status]
+		if resp.Description == "" {
+			resp.Description = "Event stream"
+		}
+		content := make(map[string]MediaType, len(resp.Content)+1)
+		for ct, mt := range resp.Content {
+			content[ct] = mt
+		}
+		if _, ok := content["text/event-stream"]; !ok {
+			content["text/event-stream"] = MediaType{Schema: &Schema{Type: "string"}}
+		}
+		resp.Content = content
+		op.Responses[status] = resp
+	case ProtocolNDJSON:
+		applyNDJSONResponse(op)
+	case ProtocolChunked:
+		setOperationExtension(op, "x-chunked", true)
+	case ProtocolLongPoll:
+		setOperationExtension(op, "x-long-poll", true)
+		if op.Description == "" {
+			op.Description = "Long-poll endpoint: the server holds the request open until there is something to return " +
+				"or the poll times out; after a timeout the client polls again."
+		}
+		_, accepted := op.Responses["202"]
+		_, noContent := op.Responses["204"]
+		if !accepted && !noContent {
+			op.Responses["202"] = Response{Description: "The poll timed out with nothing to return; poll again"}
+		}
+	case ProtocolDownload:
+		applyDownloadResponse(op.Responses)
+	}
+}
+
+// ndjsonMediaType is the media type NDJSON streams are documented under.
+const ndjsonMediaType = "application/x-ndjson"
+
+// applyNDJSONResponse documents the success response of a newline-delimited
+// JSON stream. The JSON body detected from the handler is what one Encode
+// call writes, so it becomes the schema of each line under
+// application/x-ndjson instead of the schema of the whole body. Without one,
+// any JSON value per line is documented.
+func applyNDJSONResponse(op *Operation) {
+	setOperationExtension(op, "x-ndjson", true)
+	if op.Description == "" {
+		op.Description = "Streaming endpoint: the response is newline-delimited JSON, one item per line, " +
+			"written as the items are produced."
+	}
+	status := bodyStatus(op.Responses)
+	resp := op.Responses[status]
+	if resp.Description == "" || status == "default" {
+		resp.Description = "Stream of newline-delimited JSON items"
+	}
+	var item *Schema
+	content := make(map[string]MediaType, len(resp.Content)+1)
+	for _, ct := range slices.Sorted(maps.Keys(resp.Content)) {
+		mt := resp.Content[ct]
+		if isJSONMediaType(ct) {
+			if item == nil {
+				item = mt.Schema
+			}
+			continue
+		}
+		content[ct] = mt
+	}
+	if item == nil {
+		item = &Schema{}
+	}
+	if _, ok := content[ndjsonMediaType]; !ok {
+		content[ndjsonMediaType] = MediaType{Schema: item}
+	}
+	resp.Content = content
+	op.Responses[status] = resp
+}
+
+// streamProtocol returns the protocol a route's operation is documented
+// with. Flushing alone says the body arrives in pieces, not what they are: a
+// chunked route that encodes JSON is an NDJSON stream, and any other stays
+// chunked.
+func streamProtocol(route *RouteInfo) string {
+	if route.Protocol != ProtocolChunked {
+		return route.Protocol
+	}
+	for _, resp := range route.Response {
+		if resp != nil && resp.BodyType != "" && isJSONMediaType(resp.ContentType) {
+			return ProtocolNDJSON
+		}
+	}
+	return ProtocolChunked
+}
+
+// contentDispositionHeader documents the header a download response carries.
+var contentDispositionHeader = Header{
+	Description: "Marks the body as a file to save, e.g. `attachment; filename=\"report.csv\"`.",
+	Schema:      &Schema{Type: "string"},
+}
+
+// applyDownloadResponse turns the success response of a file download into
+// an application/octet-stream binary body with a Content-Disposition header.
+// The bytes a handler writes or copies read as a JSON string (or as nothing
+// at all through http.ServeContent), so the JSON content is dropped; other
+// media types the handler announced are kept. Without a 2xx response the
+// undetermined default response is the file.
+func applyDownloadResponse(responses map[string]Response) {
+	status := bodyStatus(responses)
+	resp := responses[status]
+	if resp.Description == "" || status == "default" {
+		resp.Description = "File download"
+	}
+	content := make(map[string]MediaType, len(resp.Content)+1)
+	for ct, mt := range resp.Content {
+		if !isJSONMediaType(ct) {
+			content[ct] = mt
+		}
+	}
+	content["application/octet-stream"] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	resp.Content = content
+	headers := make(map[string]Header, len(resp.Headers)+1)
+	for name, h := range resp.Headers {
+		headers[name] = h
+	}
+	headers["Content-Disposition"] = contentDispositionHeader
+	resp.Headers = headers
+	responses[status] = resp
+}
+
+// successStatus returns the lowest 2xx status key among the responses, or
+// "200" when there is none.
+func successStatus(responses map[string]Response) string {
+	best := ""
+	for status := range responses {
+		if len(status) == 3 && status[0] == '2' && (best == "" || status < best) {
+			best = status
+		}
+	}
+	if best == "" {
+		return "200"
+	}
+	return best
+}
+
+// bodyStatus returns the status whose body a handler answers with: the
+// lowest 2xx, else the undetermined default response, else "200".
+func bodyStatus(responses map[string]Response) string {
+	status := successStatus(responses)
+	if _, ok := responses[status]; !ok {
+		if _, ok := responses["default"]; ok {
+			return "default"
+		}
+	}
+	return status
+}
+
+// setOperationExtension records a vendor extension on the operation.
+func setOperationExtension(op *Operation, key string, value interface{}) {
+	if op.Extensions == nil {
+		op.Extensions = map[string]interface{}{}
+	}
+	op.Extensions[key] = value
+}
+
+// isJSONMediaType reports application/json and the structured +json types.
+func isJSONMediaType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
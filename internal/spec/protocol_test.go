@@ -0,0 +1,194 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestApplyProtocolToOperation_SSEKeepsExistingBody(t *testing.T) {
+	op := &Operation{Responses: map[string]Response{
+		"200": {Description: "OK", Content: map[string]MediaType{
+			"application/json": {Schema: &Schema{Type: "object"}},
+		}},
+	}}
+	applyProtocolToOperation(op, ProtocolSSE)
+
+	if op.Extensions["x-sse"] != true {
+		t.Fatalf("x-sse not set: %v", op.Extensions)
+	}
+	content := op.Responses["200"].Content
+	if _, ok := content["text/event-stream"]; !ok {
+		t.Errorf("text/event-stream missing from 200: %v", content)
+	}
+	if _, ok := content["application/json"]; !ok {
+		t.Errorf("existing JSON body must be kept: %v", content)
+	}
+}
+
+func TestApplyProtocolToOperation_WebSocket(t *testing.T) {
+	op := &Operation{Description: "chat room"}
+	applyProtocolToOperation(op, ProtocolWebSocket)
+
+	if op.Extensions["x-websocket"] != true {
+		t.Fatalf("x-websocket not set: %v", op.Extensions)
+	}
+	if _, ok := op.Responses["101"]; !ok {
+		t.Errorf("101 response missing: %v", op.Responses)
+	}
+	if op.Description != "chat room" {
+		t.Errorf("an existing description must not be replaced, got %q", op.Description)
+	}
+}
+
+func TestApplyProtocolToOperation_Download(t *testing.T) {
+	op := &Operation{Responses: map[string]Response{
+		"default": {Description: "Status code could not be determined", Content: map[string]MediaType{
+			"application/json": {Schema: &Schema{Type: "string", Format: "byte"}},
+			"text/csv":         {Schema: &Schema{Type: "string"}},
+		}},
+		"404": {Description: "Not Found"},
+	}}
+	applyProtocolToOperation(op, ProtocolDownload)
+
+	if len(op.Extensions) != 0 {
+		t.Errorf("a download is not an upgrade, got extensions %v", op.Extensions)
+	}
+	resp := op.Responses["default"]
+	if mt, ok := resp.Content["application/octet-stream"]; !ok || mt.Schema.Format != "binary" {
+		t.Errorf("default should be an octet-stream binary body: %v", resp.Content)
+	}
+	if _, ok := resp.Content["application/json"]; ok {
+		t.Errorf("the JSON body must be replaced: %v", resp.Content)
+	}
+	if _, ok := resp.Content["text/csv"]; !ok {
+		t.Errorf("an announced media type must be kept: %v", resp.Content)
+	}
+	if _, ok := resp.Headers["Content-Disposition"]; !ok {
+		t.Errorf("Content-Disposition header missing: %v", resp.Headers)
+	}
+	if _, ok := op.Responses["200"]; ok {
+		t.Errorf("the undetermined default is the file; no 200 should be added: %v", op.Responses)
+	}
+	if _, ok := op.Responses["404"].Headers["Content-Disposition"]; ok {
+		t.Errorf("error responses must not carry Content-Disposition")
+	}
+}
+
+func TestApplyProtocolToOperation_NDJSON(t *testing.T) {
+	item := &Schema{Ref: "#/components/schemas/Order"}
+	op := &Operation{Responses: map[string]Response{
+		"200": {Description: "OK", Content: map[string]MediaType{
+			"application/json": {Schema: item},
+		}},
+		"400": {Description: "Bad Request", Content: map[string]MediaType{
+			"application/json": {Schema: &Schema{Type: "string"}},
+		}},
+	}}
+	applyProtocolToOperation(op, ProtocolNDJSON)
+
+	if op.Extensions["x-ndjson"] != true {
+		t.Fatalf("x-ndjson not set: %v", op.Extensions)
+	}
+	content := op.Responses["200"].Content
+	if mt, ok := content["application/x-ndjson"]; !ok || mt.Schema != item {
+		t.Errorf("200 should stream the encoded item: %v", content)
+	}
+	if _, ok := content["application/json"]; ok {
+		t.Errorf("the item must not stay the whole JSON body: %v", content)
+	}
+	if _, ok := op.Responses["400"].Content["application/json"]; !ok {
+		t.Errorf("error responses must keep their JSON body: %v", op.Responses["400"])
+	}
+
+	bare := &Operation{}
+	applyProtocolToOperation(bare, ProtocolNDJSON)
+	if mt, ok := bare.Responses["200"].Content["application/x-ndjson"]; !ok || mt.Schema == nil {
+		t.Errorf("a stream with no detected body should document any JSON item: %v", bare.Responses)
+	}
+}
+
+func TestStreamProtocol(t *testing.T) {
+	jsonBody := map[string]*ResponseInfo{"200": {StatusCode: 200, ContentType: "application/json", BodyType: "main.Order"}}
+	for _, tc := range []struct {
+		route *RouteInfo
+		want  string
+	}{
+		{&RouteInfo{Protocol: ProtocolChunked, Response: jsonBody}, ProtocolNDJSON},
+		{&RouteInfo{Protocol: ProtocolChunked}, ProtocolChunked},
+		{&RouteInfo{Protocol: ProtocolChunked, Response: map[string]*ResponseInfo{"200": {ContentType: "text/plain", BodyType: "string"}}}, ProtocolChunked},
+		{&RouteInfo{Protocol: ProtocolSSE, Response: jsonBody}, ProtocolSSE},
+		{&RouteInfo{}, ""},
+	} {
+		if got := streamProtocol(tc.route); got != tc.want {
+			t.Errorf("streamProtocol(%s, %v) = %q, want %q", tc.route.Protocol, tc.route.Response, got, tc.want)
+		}
+	}
+}
+
+func TestApplyProtocolDefaults(t *testing.T) {
+	verbless := &RouteInfo{Method: "POST", Protocol: ProtocolWebSocket}
+	explicit := &RouteInfo{Method: "POST", Protocol: ProtocolSSE, MethodExplicit: true}
+	plain := &RouteInfo{Method: "POST"}
+	download := &RouteInfo{Method: "POST", Protocol: ProtocolDownload}
+	longPoll := &RouteInfo{Method: "POST", Protocol: ProtocolLongPoll}
+	applyProtocolDefaults([]*RouteInfo{verbless, explicit, plain, download, longPoll})
+
+	if verbless.Method != "GET" {
+		t.Errorf("verb-less streaming route should become GET, got %s", verbless.Method)
+	}
+	if explicit.Method != "POST" {
+		t.Errorf("explicit verb must be kept, got %s", explicit.Method)
+	}
+	if plain.Method != "POST" {
+		t.Errorf("non-streaming route must be untouched, got %s", plain.Method)
+	}
+	if download.Method != "POST" || longPoll.Method != "POST" {
+		t.Errorf("a download or long poll may answer any verb, got %s, %s", download.Method, longPoll.Method)
+	}
+}
+
+// TestOperationJSONInlinesExtensions pins that vendor extensions render as
+// top-level x-* keys in JSON output, not nested under "Extensions".
+func TestOperationJSONInlinesExtensions(t *testing.T) {
+	op := Operation{OperationID: "stream", Responses: map[string]Response{}}
+	setOperationExtension(&op, "x-sse", true)
+
+	data, err := json.Marshal(op)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid JSON %s: %v", data, err)
+	}
+	if got["x-sse"] != true || got["operationId"] != "stream" {
+		t.Errorf("extension not inlined: %s", data)
+	}
+	if strings.Contains(string(data), "Extensions") {
+		t.Errorf("Extensions field leaked into JSON: %s", data)
+	}
+
+	p := Parameter{Name: "id", In: "path", Extensions: map[string]interface{}{"x-go-name": "ID"}}
+	data, err = json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"x-go-name":"ID"`) {
+		t.Errorf("parameter extension not inlined: %s", data)
+	}
+}
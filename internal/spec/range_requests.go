@@ -0,0 +1,88 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+)
+
+const (
+	rangeHeader        = "Range"
+	contentRangeHeader = "Content-Range"
+	rangeHeaderDoc     = "Byte ranges to return, e.g. `bytes=0-1023`. A satisfiable range answers 206 Partial Content."
+)
+
+// applyRangeRequests documents byte-range support on a GET or HEAD whose
+// handler parses the Range header itself, or serves through a file server
+// that does (http.ServeContent, detected as its Accept-Ranges header). The
+// operation gains an optional Range header parameter and a 206 Partial
+// Content response carrying the success body and Content-Range. It returns
+// the response headers to document on every response, with Accept-Ranges
+// added; the input slice is never mutated.
+func applyRangeRequests(op *Operation, method string, responseHeaders []string) []string {
+	if op == nil || (method != http.MethodGet && method != http.MethodHead) {
+		return responseHeaders
+	}
+	rangeParam := slices.IndexFunc(op.Parameters, func(p Parameter) bool {
+		return p.In == "header" && http.CanonicalHeaderKey(p.Name) == rangeHeader
+	})
+	acceptsRanges := slices.ContainsFunc(responseHeaders, func(name string) bool {
+		return http.CanonicalHeaderKey(name) == acceptRangesHeader
+	})
+	if rangeParam < 0 && !acceptsRanges {
+		return responseHeaders
+	}
+
+	if rangeParam < 0 {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        rangeHeader,
+			In:          "header",
+			Description: rangeHeaderDoc,
+			Schema:      &Schema{Type: "string"},
+		})
+	} else if op.Parameters[rangeParam].Description == "" {
+		op.Parameters[rangeParam].Description = rangeHeaderDoc
+	}
+
+	if op.Responses == nil {
+		op.Responses = map[string]Response{}
+	}
+	partial, ok := op.Responses["206"]
+	if !ok {
+		partial = Response{
+			Description: "Partial Content",
+			Content:     maps.Clone(op.Responses[bodyStatus(op.Responses)].Content),
+		}
+	}
+	if _, ok := partial.Headers[contentRangeHeader]; !ok {
+		headers := make(map[string]Header, len(partial.Headers)+1)
+		for name, h := range partial.Headers {
+			headers[name] = h
+		}
+		headers[contentRangeHeader] = Header{
+			Description: "The range returned and the full length, e.g. `bytes 0-1023/4096`.",
+			Schema:      &Schema{Type: "string"},
+		}
+		partial.Headers = headers
+	}
+	op.Responses["206"] = partial
+
+	if acceptsRanges {
+		return responseHeaders
+	}
+	return append(slices.Clip(responseHeaders), acceptRangesHeader)
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// FrameworkDetectFunc reports whether a project (as seen through its
+// metadata) uses a registered framework.
+type FrameworkDetectFunc func(meta *metadata.Metadata) bool
+
+// RegisteredFramework is one entry of the framework registry.
+type RegisteredFramework struct {
+	Name   string
+	Detect FrameworkDetectFunc
+	Config *APISpecConfig
+}
+
+// BuiltinFrameworks are the framework names the detector recognises without
+// registration. They cannot be registered over.
+var BuiltinFrameworks = []string{"gin", "chi", "echo", "fiber", "mux", "net/http"}
+
+var frameworkRegistry struct {
+	sync.RWMutex
+	entries []RegisteredFramework
+}
+
+// RegisterFrameworkConfig adds an in-house router framework: when detector
+// matches the analysed project, cfg is used exactly like a built-in default
+// config (as the primary when no built-in framework is found, otherwise
+// merged in as a scoped secondary). Built-ins are always consulted first.
+// Registering a name again replaces the earlier entry in place. It panics on
+// an empty or built-in name or a nil detector/config, in the manner of
+// database/sql.Register — these are programming errors at init time.
+func RegisterFrameworkConfig(name string, detector FrameworkDetectFunc, cfg *APISpecConfig) {
+	if name == "" || detector == nil || cfg == nil {
+		panic("spec: RegisterFrameworkConfig requires a name, detector and config")
+	}
+	if slices.Contains(BuiltinFrameworks, name) {
+		panic(fmt.Sprintf("spec: RegisterFrameworkConfig: %q is a built-in framework", name))
+	}
+	frameworkRegistry.Lock()
+	defer frameworkRegistry.Unlock()
+	entry := RegisteredFramework{Name: name, Detect: detector, Config: cfg}
+	for i, e := range frameworkRegistry.entries {
+		if e.Name == name {
+			frameworkRegistry.entries[i] = entry
+			return
+		}
+	}
+	frameworkRegistry.entries = append(frameworkRegistry.entries, entry)
+}
+
+// UnregisterFrameworkConfig removes a registered framework; unknown names are
+// ignored. Mostly useful to keep tests independent.
+func UnregisterFrameworkConfig(name string) {
+	frameworkRegistry.Lock()
+	defer frameworkRegistry.Unlock()
+	frameworkRegistry.entries = slices.DeleteFunc(frameworkRegistry.entries, func(e RegisteredFramework) bool {
+		return e.Name == name
+	})
+}
+
+// RegisteredFrameworks returns the registry in registration order.
+func RegisteredFrameworks() []RegisteredFramework {
+	frameworkRegistry.RLock()
+	defer frameworkRegistry.RUnlock()
+	return slices.Clone(frameworkRegistry.entries)
+}
+
+// RegisteredFrameworkConfig returns a copy of the config registered under
+// name. The copy is safe to merge into and mutate: the engine layers
+// secondaries, presets and CLI filters onto the config it is handed.
+func RegisteredFrameworkConfig(name string) (*APISpecConfig, bool) {
+	frameworkRegistry.RLock()
+	defer frameworkRegistry.RUnlock()
+	for _, e := range frameworkRegistry.entries {
+		if e.Name == name {
+			return cloneConfig(e.Config), true
+		}
+	}
+	return nil, false
+}
+
+// ImportDetector returns a FrameworkDetectFunc matching projects that import
+// a package whose path matches any of the regexes — the same signal the
+// built-in detection uses.
+func ImportDetector(importRegexes ...string) FrameworkDetectFunc {
+	return func(meta *metadata.Metadata) bool {
+		return anyImportMatches(collectImports(meta), importRegexes)
+	}
+}
+
+// cloneConfig copies cfg deeply enough that appends and map writes on the
+// copy never reach the original: every slice and map the engine grows is
+// cloned; pattern values themselves are treated as read-only.
+func cloneConfig(cfg *APISpecConfig) *APISpecConfig {
+	c := *cfg
+	f := &c.Framework
+	f.RoutePatterns = slices.Clone(f.RoutePatterns)
+	f.HandlerInterfaceMethods = slices.Clone(f.HandlerInterfaceMethods)
+	f.HandlerAdapters = slices.Clone(f.HandlerAdapters)
+	f.RequestBodyPatterns = slices.Clone(f.RequestBodyPatterns)
+	f.ResponsePatterns = slices.Clone(f.ResponsePatterns)
+	f.ResponseHelpers = slices.Clone(f.ResponseHelpers)
+	f.ParamPatterns = slices.Clone(f.ParamPatterns)
+	f.MountPatterns = slices.Clone(f.MountPatterns)
+	f.SecurityPatterns = slices.Clone(f.SecurityPatterns)
+	f.ProtocolPatterns = slices.Clone(f.ProtocolPatterns)
+	f.ResponseHeaderPatterns = slices.Clone(f.ResponseHeaderPatterns)
+	f.ValidationPatterns = slices.Clone(f.ValidationPatterns)
+	f.RequestContext.TypeRegexes = slices.Clone(f.RequestContext.TypeRegexes)
+	f.RequestContext.BodyAccessors = slices.Clone(f.RequestContext.BodyAccessors)
+	c.TypeMapping = slices.Clone(c.TypeMapping)
+	c.ExternalTypes = slices.Clone(c.ExternalTypes)
+	c.Overrides = slices.Clone(c.Overrides)
+	c.Include = cloneIncludeExclude(c.Include)
+	c.Exclude = cloneIncludeExclude(c.Exclude)
+	c.Servers = slices.Clone(c.Servers)
+	c.Security = slices.Clone(c.Security)
+	c.SecuritySchemes = maps.Clone(c.SecuritySchemes)
+	c.RoutePatterns = slices.Clone(c.RoutePatterns)
+	c.SecurityMappings = slices.Clone(c.SecurityMappings)
+	c.presetSchemes = maps.Clone(c.presetSchemes)
+	c.Tags = slices.Clone(c.Tags)
+	c.GroupTags = slices.Clone(c.GroupTags)
+	c.Webhooks = slices.Clone(c.Webhooks)
+	return &c
+}
+
+func cloneIncludeExclude(ie IncludeExclude) IncludeExclude {
+	return IncludeExclude{
+		Files:     slices.Clone(ie.Files),
+		Packages:  slices.Clone(ie.Packages),
+		Functions: slices.Clone(ie.Functions),
+		Types:     slices.Clone(ie.Types),
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+func TestRegisterFrameworkConfig_ReplaceAndUnregister(t *testing.T) {
+	never := func(*metadata.Metadata) bool { return false }
+	RegisterFrameworkConfig("acme", never, &APISpecConfig{Info: Info{Title: "v1"}})
+	RegisterFrameworkConfig("other", never, &APISpecConfig{})
+	RegisterFrameworkConfig("acme", never, &APISpecConfig{Info: Info{Title: "v2"}})
+	t.Cleanup(func() {
+		UnregisterFrameworkConfig("acme")
+		UnregisterFrameworkConfig("other")
+	})
+
+	entries := RegisteredFrameworks()
+	if len(entries) != 2 || entries[0].Name != "acme" || entries[1].Name != "other" {
+		t.Fatalf("re-registration must replace in place, got %+v", entries)
+	}
+	cfg, ok := RegisteredFrameworkConfig("acme")
+	if !ok || cfg.Info.Title != "v2" {
+		t.Errorf("RegisteredFrameworkConfig(acme) = %+v, %v", cfg, ok)
+	}
+
+	UnregisterFrameworkConfig("acme")
+	if _, ok := RegisteredFrameworkConfig("acme"); ok {
+		t.Error("acme still registered after UnregisterFrameworkConfig")
+	}
+}
+
+func TestRegisterFrameworkConfig_Panics(t *testing.T) {
+	detect := func(*metadata.Metadata) bool { return true }
+	for name, register := range map[string]func(){
+		"builtin":    func() { RegisterFrameworkConfig("gin", detect, &APISpecConfig{}) },
+		"empty name": func() { RegisterFrameworkConfig("", detect, &APISpecConfig{}) },
+		"nil detect": func() { RegisterFrameworkConfig("x", nil, &APISpecConfig{}) },
+		"nil config": func() { RegisterFrameworkConfig("x", detect, nil) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic")
+				}
+			}()
+			register()
+		})
+	}
+}
+
+func TestRegisteredFrameworkConfig_ReturnsIndependentCopy(t *testing.T) {
+	orig := DefaultChiConfig()
+	RegisterFrameworkConfig("acme", func(*metadata.Metadata) bool { return true }, orig)
+	t.Cleanup(func() { UnregisterFrameworkConfig("acme") })
+
+	routes := len(orig.Framework.RoutePatterns)
+	cfg, _ := RegisteredFrameworkConfig("acme")
+	MergeFrameworkConfigs(cfg, DefaultGinConfig())
+	cfg.Exclude.Files = append(cfg.Exclude.Files, "gen/**")
+	if cfg.SecuritySchemes == nil {
+		cfg.SecuritySchemes = map[string]SecurityScheme{}
+	}
+	cfg.SecuritySchemes["bearer"] = SecurityScheme{Type: "http"}
+
+	if len(orig.Framework.RoutePatterns) != routes || len(orig.Exclude.Files) != 0 {
+		t.Errorf("mutating the copy leaked into the registered config")
+	}
+	if _, leaked := orig.SecuritySchemes["bearer"]; leaked {
+		t.Errorf("security scheme leaked into the registered config")
+	}
+}
+
+func TestImportDetector(t *testing.T) {
+	detect := ImportDetector(`^github\.com/acme/router$`)
+	if !detect(metaWithImports("net/http", "github.com/acme/router")) {
+		t.Error("expected a match on the acme router import")
+	}
+	if detect(metaWithImports("github.com/acme/router/v2/internal")) {
+		t.Error("unexpected match on an unrelated import")
+	}
+	if detect(nil) {
+		t.Error("nil metadata must not match")
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// ResponseHeaderPatternMatcherImpl implements ResponseHeaderPatternMatcher
+type ResponseHeaderPatternMatcherImpl struct {
+	*BasePatternMatcher
+	pattern ResponseHeaderPattern
+}
+
+// NewResponseHeaderPatternMatcher creates a new response header pattern matcher
+func NewResponseHeaderPatternMatcher(pattern ResponseHeaderPattern, cfg *APISpecConfig, contextProvider ContextProvider, typeResolver TypeResolver) *ResponseHeaderPatternMatcherImpl {
+	return &ResponseHeaderPatternMatcherImpl{
+		BasePatternMatcher: NewBasePatternMatcher(cfg, contextProvider, typeResolver),
+		pattern:            pattern,
+	}
+}
+
+// MatchNode checks if a node's call matches the pattern's call and receiver.
+// The header source is checked by HeaderName.
+func (p *ResponseHeaderPatternMatcherImpl) MatchNode(node TrackerNodeInterface) bool {
+	if node == nil || node.GetEdge() == nil || node.GetArgument() != nil {
+		return false
+	}
+
+	edge := node.GetEdge()
+	callName := p.contextProvider.GetString(edge.Callee.Name)
+
+	if p.pattern.CallRegex != "" && !p.matchPattern(p.pattern.CallRegex, callName) {
+		return false
+	}
+
+	if p.pattern.FunctionNameRegex != "" {
+		funcName := p.contextProvider.GetString(edge.Caller.Name)
+		if !p.matchPattern(p.pattern.FunctionNameRegex, funcName) {
+			return false
+		}
+	}
+
+	fqRecvType := p.qualifiedRecvType(&edge.Callee)
+	if p.pattern.RecvTypeRegex != "" {
+		re, err := cachedRegex(p.pattern.RecvTypeRegex)
+		if err != nil || !re.MatchString(fqRecvType) {
+			return false
+		}
+	} else if p.pattern.RecvType != "" && p.pattern.RecvType != fqRecvType {
+		return false
+	}
+
+	return true
+}
+
+// qualifiedRecvType renders a callee as "<pkg>.<RecvType>", or just the
+// package for a package-level function.
+func (p *ResponseHeaderPatternMatcherImpl) qualifiedRecvType(call *metadata.Call) string {
+	recvType := p.contextProvider.GetString(call.RecvType)
+	recvPkg := p.contextProvider.GetString(call.Pkg)
+	if recvPkg != "" && recvType != "" {
+		return recvPkg + "." + recvType
+	}
+	if recvType != "" {
+		return recvType
+	}
+	return recvPkg
+}
+
+// GetPattern returns the response header pattern
+func (p *ResponseHeaderPatternMatcherImpl) GetPattern() interface{} {
+	return p.pattern
+}
+
+// GetPriority returns the priority of this pattern
+func (p *ResponseHeaderPatternMatcherImpl) GetPriority() int {
+	priority := 0
+	if p.pattern.CallRegex != "" {
+		priority += 10
+	}
+	if p.pattern.FunctionNameRegex != "" {
+		priority += 5
+	}
+	if p.pattern.RecvTypeRegex != "" || p.pattern.RecvType != "" {
+		priority += 3
+	}
+	return priority
+}
+
+// HeaderName returns the header a matched call sets: the pattern's fixed
+// Header, or the constant name argument. It returns "" when the name is not a
+// constant or the receiver does not come from HeaderSourceRegex.
+func (p *ResponseHeaderPatternMatcherImpl) HeaderName(node TrackerNodeInterface, meta *metadata.Metadata) string {
+	edge := node.GetEdge()
+	if p.pattern.HeaderSourceRegex != "" && !p.headerSourceMatches(edge, meta) {
+		return ""
+	}
+	if p.pattern.Header != "" {
+		return p.pattern.Header
+	}
+	if p.pattern.NameArgIndex < 0 || len(edge.Args) <= p.pattern.NameArgIndex {
+		return ""
+	}
+	return constStringArg(p.contextProvider, meta, edge.Args[p.pattern.NameArgIndex])
+}
+
+// headerSourceMatches reports whether the receiver of edge was produced by a
+// call matching HeaderSourceRegex: its chain parent (w.Header().Set), or the
+// call in the same function whose result was assigned to the receiver
+// variable (h := w.Header(); h.Set).
+func (p *ResponseHeaderPatternMatcherImpl) headerSourceMatches(edge *metadata.CallGraphEdge, meta *metadata.Metadata) bool {
+	re, err := cachedRegex(p.pattern.HeaderSourceRegex)
+	if err != nil {
+		return false
+	}
+	source := func(src *metadata.CallGraphEdge) bool {
+		return re.MatchString(p.qualifiedRecvType(&src.Callee) + "." + p.contextProvider.GetString(src.Callee.Name))
+	}
+	if edge.ChainParent != nil {
+		return source(edge.ChainParent)
+	}
+	if edge.CalleeVarName == "" || meta == nil {
+		return false
+	}
+	for _, sibling := range meta.Callers[edge.Caller.BaseID()] {
+		if sibling.CalleeRecvVarName == edge.CalleeVarName && source(sibling) {
+			return true
+		}
+	}
+	return false
+}
+
+// constStringArg resolves a string literal or a string constant (local or
+// package-qualified) to its value.
+func constStringArg(cp ContextProvider, meta *metadata.Metadata, arg *metadata.CallArgument) string {
+	if arg == nil {
+		return ""
+	}
+	switch arg.GetKind() {
+	case metadata.KindLiteral:
+		return cp.GetArgumentInfo(arg)
+	case metadata.KindSelector:
+		return constStringArg(cp, meta, arg.Sel)
+	case metadata.KindIdent:
+		if meta == nil {
+			return ""
+		}
+		pkg := meta.Packages[arg.GetPkg()]
+		if pkg == nil {
+			return ""
+		}
+		for _, file := range pkg.Files {
+			if v, ok := file.Variables[arg.GetName()]; ok && getString(meta, v.Tok) == "const" {
+				return cp.GetArgumentInfo(arg)
+			}
+		}
+	}
+	return ""
+}
+
+// responseHeaderOf returns the response header a route-subtree node sets, or
+// "" when no response header matcher accepts it.
+func (e *Extractor) responseHeaderOf(node TrackerNodeInterface, route *RouteInfo) string {
+	for _, matcher := range e.headerMatchers {
+		if matcher.MatchNode(node) {
+			if name := matcher.HeaderName(node, route.Metadata); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// addResponseHeader records name on the route once, comparing names as
+// canonical MIME header keys.
+func addResponseHeader(route *RouteInfo, name string) {
+	if name == "" || ignoredResponseHeader(name) {
+		return
+	}
+	key := http.CanonicalHeaderKey(name)
+	for _, existing := range route.ResponseHeaders {
+		if http.CanonicalHeaderKey(existing) == key {
+			return
+		}
+	}
+	route.ResponseHeaders = append(route.ResponseHeaders, name)
+}
+
+// ignoredResponseHeader reports headers OpenAPI describes elsewhere: a
+// response's Content-Type is the key of its content map.
+func ignoredResponseHeader(name string) bool {
+	return strings.EqualFold(name, "Content-Type")
+}
+
+// ignoredHeaderParam reports header parameters OpenAPI ignores: Accept and
+// Content-Type are described by the media types, Authorization by the
+// security schemes.
+func ignoredHeaderParam(name string) bool {
+	switch http.CanonicalHeaderKey(name) {
+	case "Accept", "Content-Type", "Authorization":
+		return true
+	}
+	return false
+}
+
+// dropIgnoredHeaderParams removes the header parameters ignoredHeaderParam
+// reports. The input slice is never mutated.
+func dropIgnoredHeaderParams(params []Parameter) []Parameter {
+	var kept []Parameter
+	for i, p := range params {
+		if p.In == "header" && ignoredHeaderParam(p.Name) {
+			if kept == nil {
+				kept = append(make([]Parameter, 0, len(params)), params[:i]...)
+			}
+			continue
+		}
+		if kept != nil {
+			kept = append(kept, p)
+		}
+	}
+	if kept == nil {
+		return params
+	}
+	return kept
+}
+
+// responseHeaderDocs describes the response headers whose meaning a client
+// must act on, keyed by canonical header name.
+var responseHeaderDocs = map[string]string{
+	"Retry-After":                  "Seconds, or an HTTP date, to wait before retrying the request or polling again.",
+	"Access-Control-Allow-Origin":  "The origin allowed to read the response, or * for any.",
+	"Access-Control-Allow-Methods": "The methods allowed for the path.",
+	"Access-Control-Allow-Headers": "The request headers allowed for the path.",
+}
+
+// applyResponseHeaders documents the headers a handler sets on every
+// response of its operation. The handler sets them on the shared writer, so
+// they cannot be attributed to a status; a response that carries its own
+// definition keeps it.
+func applyResponseHeaders(responses map[string]Response, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	for status, resp := range responses {
+		headers := make(map[string]Header, len(resp.Headers)+len(names))
+		for name, h := range resp.Headers {
+			headers[name] = h
+		}
+		for _, name := range names {
+			if _, ok := headers[name]; !ok {
+				headers[name] = Header{
+					Description: responseHeaderDocs[http.CanonicalHeaderKey(name)],
+					Schema:      &Schema{Type: "string"},
+				}
+			}
+		}
+		resp.Headers = headers
+		responses[status] = resp
+	}
+}
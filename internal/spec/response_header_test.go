@@ -0,0 +1,111 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddResponseHeader(t *testing.T) {
+	route := NewRouteInfo()
+	for _, name := range []string{"X-Request-ID", "", "x-request-id", "Content-Type", "content-type", "ETag"} {
+		addResponseHeader(route, name)
+	}
+	if want := []string{"X-Request-ID", "ETag"}; !reflect.DeepEqual(route.ResponseHeaders, want) {
+		t.Errorf("ResponseHeaders = %v, want %v", route.ResponseHeaders, want)
+	}
+}
+
+func TestDropIgnoredHeaderParams(t *testing.T) {
+	params := []Parameter{
+		{Name: "authorization", In: "header"},
+		{Name: "X-Tenant-ID", In: "header"},
+		{Name: "Accept", In: "header"},
+		{Name: "Authorization", In: "query"},
+	}
+	got := dropIgnoredHeaderParams(params)
+	want := []Parameter{{Name: "X-Tenant-ID", In: "header"}, {Name: "Authorization", In: "query"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dropIgnoredHeaderParams = %+v, want %+v", got, want)
+	}
+	if params[0].Name != "authorization" || len(params) != 4 {
+		t.Errorf("input mutated: %+v", params)
+	}
+
+	kept := params[1:2]
+	if got := dropIgnoredHeaderParams(kept); &got[0] != &kept[0] {
+		t.Error("a slice with nothing to drop should be returned as is")
+	}
+}
+
+func TestApplyResponseHeaders(t *testing.T) {
+	own := Header{Description: "Where the order lives", Schema: &Schema{Type: "string", Format: "uri"}}
+	responses := map[string]Response{
+		"201": {Description: "Created", Headers: map[string]Header{"Location": own}},
+		"400": {Description: "Bad Request"},
+	}
+	applyResponseHeaders(responses, []string{"Location", "X-Request-ID"})
+
+	for status, resp := range responses {
+		if h, ok := resp.Headers["X-Request-ID"]; !ok || h.Schema == nil || h.Schema.Type != "string" {
+			t.Errorf("%s: X-Request-ID = %+v", status, resp.Headers["X-Request-ID"])
+		}
+	}
+	if got := responses["201"].Headers["Location"]; !reflect.DeepEqual(got, own) {
+		t.Errorf("201 Location = %+v, want the response's own definition", got)
+	}
+	if _, ok := responses["400"].Headers["Location"]; !ok {
+		t.Error("400: Location missing")
+	}
+}
+
+func TestResponseHeaderPattern_FixedHeader(t *testing.T) {
+	cfg := DefaultHTTPConfig()
+	var setCookie *ResponseHeaderPattern
+	for i, p := range cfg.Framework.ResponseHeaderPatterns {
+		if p.Header == setCookieHeader {
+			setCookie = &cfg.Framework.ResponseHeaderPatterns[i]
+		}
+	}
+	if setCookie == nil {
+		t.Fatal("DefaultHTTPConfig has no Set-Cookie response header pattern")
+	}
+
+	want := len(cfg.Framework.ResponseHeaderPatterns) + 1
+	merged := MergeFrameworkConfigs(cfg, &APISpecConfig{Framework: FrameworkConfig{ResponseHeaderPatterns: []ResponseHeaderPattern{
+		{CallRegex: setCookie.CallRegex, RecvTypeRegex: setCookie.RecvTypeRegex, Header: "X-Other"},
+	}}})
+	if got := len(merged.Framework.ResponseHeaderPatterns); got != want {
+		t.Errorf("patterns differing only by header merged to %d, want %d", got, want)
+	}
+}
+
+func TestSecondaryView_KeepsScopedResponseHeaderPatterns(t *testing.T) {
+	cfg := &APISpecConfig{Framework: FrameworkConfig{ResponseHeaderPatterns: []ResponseHeaderPattern{
+		{CallRegex: `^Header$`, RecvTypeRegex: `^github\.com/gin-gonic/gin\.\*?Context$`},
+		{CallRegex: `^SetHeader$`},
+	}}}
+	got := SecondaryView(cfg).Framework.ResponseHeaderPatterns
+	if len(got) != 1 || got[0].CallRegex != `^Header$` {
+		t.Errorf("SecondaryView kept %+v, want only the receiver-scoped pattern", got)
+	}
+
+	base := DefaultHTTPConfig()
+	merged := MergeFrameworkConfigs(base, HTTPSecondaryConfig())
+	if n, want := len(merged.Framework.ResponseHeaderPatterns), len(base.Framework.ResponseHeaderPatterns); n != want {
+		t.Errorf("merging the same http.Header patterns twice left %d patterns, want %d", n, want)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBuildResponses_NegotiatedMediaTypes(t *testing.T) {
+	ref := func(name string) *Schema { return &Schema{Ref: "#/components/schemas/" + name} }
+	mediaTypes := func(r Response) []string {
+		var out []string
+		for ct := range r.Content {
+			out = append(out, ct)
+		}
+		slices.Sort(out)
+		return out
+	}
+
+	t.Run("a status written in several media types lists each", func(t *testing.T) {
+		r := buildResponses(map[string]*ResponseInfo{
+			"200":                  {StatusCode: 200, ContentType: "application/json", BodyType: "User", Schema: ref("User")},
+			"200 application/xml":  {StatusCode: 200, ContentType: "application/xml", BodyType: "User", Schema: ref("User")},
+			"200 application/yaml": {StatusCode: 200, ContentType: "application/yaml", BodyType: "User", Schema: ref("User")},
+		})
+		want := []string{"application/json", "application/xml", "application/yaml"}
+		if got := mediaTypes(r["200"]); !slices.Equal(got, want) {
+			t.Errorf("200 media types = %v, want %v", got, want)
+		}
+		if len(r) != 1 {
+			t.Errorf("got %d responses, want the negotiated slots folded into 200", len(r))
+		}
+	})
+
+	t.Run("a negotiated slot without its first media type stands in for it", func(t *testing.T) {
+		r := buildResponses(map[string]*ResponseInfo{
+			"200 application/xml": {StatusCode: 200, ContentType: "application/xml", BodyType: "User", Schema: ref("User")},
+		})
+		if got := mediaTypes(r["200"]); !slices.Equal(got, []string{"application/xml"}) {
+			t.Errorf("200 media types = %v, want [application/xml]", got)
+		}
+	})
+
+	t.Run("undetermined-status bodies keep one media type each", func(t *testing.T) {
+		r := buildResponses(map[string]*ResponseInfo{
+			"-1": {StatusCode: -1, ContentType: "application/xml", BodyType: "Report", Schema: ref("Report")},
+			"-2": {StatusCode: -2, ContentType: "application/json", BodyType: "Report", Schema: ref("Report")},
+			"-3": {StatusCode: -3, ContentType: "application/json", BodyType: "any", Schema: &Schema{Type: "object"}},
+		})
+		if got := mediaTypes(r["default"]); !slices.Equal(got, []string{"application/json", "application/xml"}) {
+			t.Errorf("default media types = %v, want json and xml", got)
+		}
+		if s := r["default"].Content["application/json"].Schema; s == nil || s.Ref == "" {
+			t.Errorf("default json schema = %+v, want the concrete Report over the generic object", s)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// RouteSite is a router registration call the call graph reaches: a call a
+// route pattern matched, whether or not a route could be extracted from it.
+type RouteSite struct {
+	Pos       string // the registration call ("file:line:col")
+	Package   string // package making the call
+	Handler   string // handler as registered (package-qualified); empty when unknown
+	Extracted bool   // at least one extracted route comes from the call
+}
+
+// PackageCoverage counts the registration call sites of one package and how
+// many of them were extracted.
+type PackageCoverage struct {
+	Package   string
+	Sites     int
+	Extracted int
+}
+
+// Percent is the share of the package's call sites extracted, 100 when it
+// has none.
+func (c PackageCoverage) Percent() float64 {
+	return percent(c.Extracted, c.Sites)
+}
+
+// RouteCoverage measures how much of the router registration the extraction
+// followed: every registration call site, in the order met, and whether a
+// route came out of it.
+type RouteCoverage struct {
+	Sites []RouteSite
+}
+
+// Total counts the call sites across packages.
+func (c RouteCoverage) Total() PackageCoverage {
+	total := PackageCoverage{Sites: len(c.Sites)}
+	for _, s := range c.Sites {
+		if s.Extracted {
+			total.Extracted++
+		}
+	}
+	return total
+}
+
+// Packages counts the call sites of each package, sorted by package path.
+func (c RouteCoverage) Packages() []PackageCoverage {
+	var pkgs []PackageCoverage
+	for _, s := range c.Sites {
+		i := slices.IndexFunc(pkgs, func(p PackageCoverage) bool { return p.Package == s.Package })
+		if i < 0 {
+			i = len(pkgs)
+			pkgs = append(pkgs, PackageCoverage{Package: s.Package})
+		}
+		pkgs[i].Sites++
+		if s.Extracted {
+			pkgs[i].Extracted++
+		}
+	}
+	slices.SortFunc(pkgs, func(a, b PackageCoverage) int { return strings.Compare(a.Package, b.Package) })
+	return pkgs
+}
+
+// Unmatched returns the call sites no route was extracted from, in the order
+// met.
+func (c RouteCoverage) Unmatched() []RouteSite {
+	var out []RouteSite
+	for _, s := range c.Sites {
+		if !s.Extracted {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func percent(n, of int) float64 {
+	if of == 0 {
+		return 100
+	}
+	return float64(n) * 100 / float64(of)
+}
+
+// noteRouteSite records the registration call at pos, once however many
+// traversal contexts reach it.
+func (e *Extractor) noteRouteSite(edge *metadata.CallGraphEdge, pos string, route *RouteInfo) {
+	if i, ok := e.routeSiteIndex[pos]; ok {
+		if e.routeSites[i].Handler == "" {
+			e.routeSites[i].Handler = route.Function
+		}
+		return
+	}
+	if e.routeSiteIndex == nil {
+		e.routeSiteIndex = map[string]int{}
+	}
+	e.routeSiteIndex[pos] = len(e.routeSites)
+	e.routeSites = append(e.routeSites, RouteSite{
+		Pos:     pos,
+		Package: getString(e.tree.GetMetadata(), edge.Caller.Pkg),
+		Handler: route.Function,
+	})
+}
+
+// RouteCoverage returns the registration call sites the extraction met,
+// each marked extracted when one of routes — the extracted set — was
+// registered there.
+func (e *Extractor) RouteCoverage(routes []*RouteInfo) RouteCoverage {
+	registered := make(map[string]bool, len(routes))
+	for _, r := range routes {
+		registered[r.File] = true
+	}
+	sites := slices.Clone(e.routeSites)
+	for i := range sites {
+		sites[i].Extracted = registered[sites[i].Pos]
+	}
+	return RouteCoverage{Sites: sites}
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+func TestRouteCoverage(t *testing.T) {
+	meta := &metadata.Metadata{StringPool: metadata.NewStringPool()}
+	limits := metadata.TrackerLimits{MaxNodesPerTree: 100, MaxChildrenPerNode: 10, MaxArgsPerFunction: 5, MaxNestedArgsDepth: 3}
+	ex := NewExtractor(NewMockTrackerTree(meta, limits), &APISpecConfig{})
+	site := func(pkg, pos string) TrackerNodeInterface {
+		return &TrackerNode{key: pos, CallGraphEdge: &metadata.CallGraphEdge{
+			Caller:   metadata.Call{Pkg: meta.StringPool.Get(pkg)},
+			Position: meta.StringPool.Get(pos),
+		}}
+	}
+
+	ex.recordRouteSite(site("example.com/api", "main.go:10:2"), &RouteInfo{Method: "GET", Path: "/users", Handler: "listUsers", Function: "example.com/api.listUsers"})
+	// Reached again through another traversal context: one call site.
+	ex.recordRouteSite(site("example.com/api", "main.go:10:2"), &RouteInfo{Method: "GET", Path: "/users", Handler: "listUsers", Function: "example.com/api.listUsers"})
+	ex.recordRouteSite(site("example.com/api/admin", "admin/routes.go:5:2"), &RouteInfo{Function: "example.com/api/admin.audit"})
+	ex.recordRouteSite(site("example.com/api/admin", "admin/routes.go:6:2"), &RouteInfo{Method: "GET", Path: "/stats", Handler: "stats", Function: "example.com/api/admin.stats"})
+	coverage := ex.RouteCoverage([]*RouteInfo{{File: "main.go:10:2"}, {File: "admin/routes.go:6:2"}})
+
+	if got, want := coverage.Total(), (PackageCoverage{Sites: 3, Extracted: 2}); got != want {
+		t.Errorf("Total() = %+v, want %+v", got, want)
+	}
+	wantPkgs := []PackageCoverage{
+		{Package: "example.com/api", Sites: 1, Extracted: 1},
+		{Package: "example.com/api/admin", Sites: 2, Extracted: 1},
+	}
+	if got := coverage.Packages(); !reflect.DeepEqual(got, wantPkgs) {
+		t.Errorf("Packages() = %+v, want %+v", got, wantPkgs)
+	}
+	if got := coverage.Packages()[1].Percent(); got != 50 {
+		t.Errorf("Percent() = %v, want 50", got)
+	}
+	wantUnmatched := []RouteSite{{Pos: "admin/routes.go:5:2", Package: "example.com/api/admin", Handler: "example.com/api/admin.audit"}}
+	if got := coverage.Unmatched(); !reflect.DeepEqual(got, wantUnmatched) {
+		t.Errorf("Unmatched() = %+v, want %+v", got, wantUnmatched)
+	}
+	if got := (RouteCoverage{}).Total().Percent(); got != 100 {
+		t.Errorf("empty coverage Percent() = %v, want 100", got)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/diag"
+)
+
+// Why a route registration is not fully documented (UnresolvedRoute.Reason).
+const (
+	// UnresolvedPath: the registration's path could not be resolved; the
+	// route is left out of the spec.
+	UnresolvedPath = "path"
+	// UnresolvedHandler: the registration's handler could not be resolved —
+	// left out of the spec when nothing names it, documented without
+	// parameters, body or responses when it names no function apispec can
+	// follow (an interface value, a map or slice element, a conversion).
+	UnresolvedHandler = "handler"
+	// UnresolvedPrefix: a path segment, usually a mount prefix, is computed
+	// at run time; the route is documented under a synthesized placeholder.
+	UnresolvedPrefix = "prefix"
+)
+
+// UnresolvedRoute records a route registration the extraction could not
+// fully resolve — surfaced as a diagnostic so the endpoints missing from the
+// spec, or documented from a guess, are not lost silently.
+type UnresolvedRoute struct {
+	Method  string // HTTP method; empty when the route was dropped before it was known
+	Path    string // OpenAPI path; empty when it could not be resolved
+	Handler string // handler as registered (package-qualified); empty when unknown
+	Reason  string // UnresolvedPath, UnresolvedHandler or UnresolvedPrefix
+	// Param is the placeholder synthesized for an UnresolvedPrefix.
+	Param string
+	// Dropped reports that the route is missing from the spec.
+	Dropped bool
+	// Pos is the registration call ("file:line:col").
+	Pos string
+}
+
+// recordRouteSite notes whether the registration at node produced a route.
+// The same call site is reached through every traversal context; it counts
+// as dropped only when none of them resolved it (see settleDroppedRoutes).
+func (e *Extractor) recordRouteSite(node TrackerNodeInterface, route *RouteInfo) {
+	edge := node.GetEdge()
+	if edge == nil {
+		return
+	}
+	pos := getString(e.tree.GetMetadata(), edge.Position)
+	e.noteRouteSite(edge, pos, route)
+	if route.IsValid() {
+		if e.resolvedRouteSites == nil {
+			e.resolvedRouteSites = map[string]bool{}
+		}
+		e.resolvedRouteSites[pos] = true
+		return
+	}
+	if e.droppedRouteSet == nil {
+		e.droppedRouteSet = map[string]struct{}{}
+	}
+	if _, ok := e.droppedRouteSet[pos]; ok {
+		return
+	}
+	e.droppedRouteSet[pos] = struct{}{}
+	dropped := UnresolvedRoute{Reason: UnresolvedHandler, Handler: route.Function, Dropped: true, Pos: pos}
+	if route.Path == "" {
+		dropped.Reason = UnresolvedPath
+	} else {
+		dropped.Method, dropped.Path = route.Method, route.OpenAPIPath()
+	}
+	e.droppedRoutes = append(e.droppedRoutes, dropped)
+}
+
+// settleDroppedRoutes keeps the dropped registrations no traversal context
+// resolved.
+func (e *Extractor) settleDroppedRoutes() {
+	kept := e.droppedRoutes[:0]
+	for _, r := range e.droppedRoutes {
+		if !e.resolvedRouteSites[r.Pos] {
+			kept = append(kept, r)
+		}
+	}
+	e.droppedRoutes = kept
+}
+
+// DroppedRoutes returns the route registrations left out of the spec because
+// their path or handler could not be resolved, in the order they were met.
+func (e *Extractor) DroppedRoutes() []UnresolvedRoute {
+	return e.droppedRoutes
+}
+
+// unresolvedRoutes returns the routes documented from a guess: one whose
+// handler names no function or method of the analyzed packages, and one per
+// placeholder synthesized for a path segment computed at run time.
+func unresolvedRoutes(routes []*RouteInfo, handlerMethods ...string) []UnresolvedRoute {
+	var out []UnresolvedRoute
+	for _, route := range routes {
+		path := route.OpenAPIPath()
+		for _, param := range route.DynamicParams {
+			out = append(out, UnresolvedRoute{
+				Method:  route.Method,
+				Path:    path,
+				Handler: route.Function,
+				Reason:  UnresolvedPrefix,
+				Param:   param,
+				Pos:     route.File,
+			})
+		}
+		if !documentsHandler(route) && !handlerResolved(route, handlerMethods...) {
+			out = append(out, UnresolvedRoute{
+				Method:  route.Method,
+				Path:    path,
+				Handler: route.Function,
+				Reason:  UnresolvedHandler,
+				Pos:     route.File,
+			})
+		}
+	}
+	return out
+}
+
+// documentsHandler reports whether extraction found anything the handler
+// reads or writes: a generic or factory-built handler apispec follows through
+// the call graph resolves no declaration, yet is documented.
+func documentsHandler(route *RouteInfo) bool {
+	return len(route.Response) > 0 || route.Request != nil || len(route.Params) > 0
+}
+
+// handlerResolved reports whether route's handler is a function literal, a
+// function or method the analyzed packages declare, or a file server, whose
+// handler is the standard library's.
+func handlerResolved(route *RouteInfo, handlerMethods ...string) bool {
+	if slices.Contains(route.RemainderParams, fileServerParam) || strings.Contains(route.Function, "FuncLit:") {
+		return true
+	}
+	_, ok := handlerDeclaration(route, handlerMethods...)
+	return ok
+}
+
+func (r UnresolvedRoute) diagnostic() diag.Diagnostic {
+	d := diag.Diagnostic{
+		Severity: diag.Warning,
+		Category: "routes",
+		Pos:      diag.ParsePosition(r.Pos),
+	}
+	if r.Dropped {
+		d.Severity = diag.Error
+	}
+	switch {
+	case r.Reason == UnresolvedPath:
+		d.Message = "route registration dropped: its path could not be resolved"
+		if r.Handler != "" {
+			d.Message = fmt.Sprintf("route to %s dropped: its path could not be resolved", strings.ReplaceAll(r.Handler, TypeSep, "."))
+		}
+		d.Help = "register the route with a constant path, or one built from constants"
+	case r.Dropped:
+		d.Message = fmt.Sprintf("%s %s: route dropped: its handler could not be resolved", r.Method, r.Path)
+		d.Help = "pass a named function, method value or function literal as the handler"
+	case r.Reason == UnresolvedPrefix:
+		d.Message = fmt.Sprintf("%s %s: path segment {%s} is computed at run time and documented as a path parameter", r.Method, r.Path, r.Param)
+		d.Help = "build the path, and the prefixes it is mounted under, from constants"
+	default:
+		d.Message = fmt.Sprintf("%s %s: handler %s does not resolve to a function, so its parameters and responses are not documented", r.Method, r.Path, strings.ReplaceAll(r.Handler, TypeSep, "."))
+		d.Help = "pass a named function, method value or function literal as the handler"
+	}
+	return d
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/diag"
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+func TestRecordRouteSite(t *testing.T) {
+	meta := &metadata.Metadata{StringPool: metadata.NewStringPool()}
+	limits := metadata.TrackerLimits{MaxNodesPerTree: 100, MaxChildrenPerNode: 10, MaxArgsPerFunction: 5, MaxNestedArgsDepth: 3}
+	ex := NewExtractor(NewMockTrackerTree(meta, limits), &APISpecConfig{})
+	site := func(pos string) TrackerNodeInterface {
+		return &TrackerNode{key: pos, CallGraphEdge: &metadata.CallGraphEdge{Position: meta.StringPool.Get(pos)}}
+	}
+
+	// No path: dropped wherever it is reached.
+	ex.recordRouteSite(site("main.go:10:2"), &RouteInfo{Function: "main.listInvoices"})
+	ex.recordRouteSite(site("main.go:10:2"), &RouteInfo{Function: "main.listInvoices"})
+	// No handler in one traversal context, resolved in another: not dropped.
+	ex.recordRouteSite(site("main.go:11:2"), &RouteInfo{Method: "GET", Path: "/users"})
+	ex.recordRouteSite(site("main.go:11:2"), &RouteInfo{Method: "GET", Path: "/users", Handler: "listUsers"})
+	// No handler anywhere.
+	ex.recordRouteSite(site("main.go:12:2"), &RouteInfo{Method: "GET", MountPath: "/api", Path: "/{id:[0-9]+}"})
+	ex.settleDroppedRoutes()
+
+	want := []UnresolvedRoute{
+		{Handler: "main.listInvoices", Reason: UnresolvedPath, Dropped: true, Pos: "main.go:10:2"},
+		{Method: "GET", Path: "/api/{id}", Reason: UnresolvedHandler, Dropped: true, Pos: "main.go:12:2"},
+	}
+	if got := ex.DroppedRoutes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DroppedRoutes() =\n %+v\nwant\n %+v", got, want)
+	}
+}
+
+func TestUnresolvedRoutes(t *testing.T) {
+	routes := []*RouteInfo{
+		// A handler documented through the call graph, under a prefix
+		// computed at run time.
+		{Method: "GET", MountPath: "/{mountPoint}", Path: "/users", Function: "main.HandleRequest[main.Req]",
+			DynamicParams: []string{"mountPoint"}, File: "main.go:20:2",
+			Response: map[string]*ResponseInfo{"200": {StatusCode: 200}}},
+		// A handler read from a map: nothing documented, nothing declared.
+		{Method: "GET", Path: "/reports", Function: "*map[string]net/http.HandlerFunc", File: "main.go:21:2"},
+		// A function literal and a file server resolve without a declaration.
+		{Method: "GET", Path: "/ping", Function: "main.FuncLit:/src/main.go:22:25", File: "main.go:22:2"},
+		{Method: "GET", Path: "/static/{file}", Function: "FileServer", RemainderParams: []string{fileServerParam}, File: "main.go:23:2"},
+	}
+	want := []UnresolvedRoute{
+		{Method: "GET", Path: "/{mountPoint}/users", Handler: "main.HandleRequest[main.Req]", Reason: UnresolvedPrefix, Param: "mountPoint", Pos: "main.go:20:2"},
+		{Method: "GET", Path: "/reports", Handler: "*map[string]net/http.HandlerFunc", Reason: UnresolvedHandler, Pos: "main.go:21:2"},
+	}
+	if got := unresolvedRoutes(routes); !reflect.DeepEqual(got, want) {
+		t.Errorf("unresolvedRoutes() =\n %+v\nwant\n %+v", got, want)
+	}
+}
+
+func TestUnresolvedRoute_Diagnostic(t *testing.T) {
+	tests := []struct {
+		name     string
+		route    UnresolvedRoute
+		severity diag.Severity
+		message  string
+	}{
+		{"path", UnresolvedRoute{Handler: "main" + TypeSep + "listInvoices", Reason: UnresolvedPath, Dropped: true},
+			diag.Error, "route to main.listInvoices dropped: its path could not be resolved"},
+		{"path without handler", UnresolvedRoute{Reason: UnresolvedPath, Dropped: true},
+			diag.Error, "route registration dropped: its path could not be resolved"},
+		{"dropped handler", UnresolvedRoute{Method: "GET", Path: "/users", Reason: UnresolvedHandler, Dropped: true},
+			diag.Error, "GET /users: route dropped: its handler could not be resolved"},
+		{"prefix", UnresolvedRoute{Method: "GET", Path: "/{mountPoint}/users", Reason: UnresolvedPrefix, Param: "mountPoint"},
+			diag.Warning, "GET /{mountPoint}/users: path segment {mountPoint} is computed at run time and documented as a path parameter"},
+		{"handler", UnresolvedRoute{Method: "GET", Path: "/fallback", Handler: "net/http.Handler", Reason: UnresolvedHandler},
+			diag.Warning, "GET /fallback: handler net/http.Handler does not resolve to a function, so its parameters and responses are not documented"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.route.Pos = "/src/main.go:12:2"
+			d := tc.route.diagnostic()
+			if d.Severity != tc.severity || d.Category != "routes" || d.Message != tc.message {
+				t.Errorf("diagnostic() = %v[%s] %q, want %v[routes] %q", d.Severity, d.Category, d.Message, tc.severity, tc.message)
+			}
+			if d.Pos.Line != 12 || d.Help == "" {
+				t.Errorf("diagnostic() pos = %v, help = %q", d.Pos, d.Help)
+			}
+		})
+	}
+}
+
+func TestPathResolved(t *testing.T) {
+	meta := exSweepMeta()
+	binary := metadata.NewCallArgument(meta)
+	binary.SetKind(metadata.KindBinary)
+	for _, tc := range []struct {
+		arg  *metadata.CallArgument
+		want bool
+	}{
+		{sweepLit(meta, `""`), true},
+		{sweepIdent(meta, "root"), true},
+		{binary, false},
+	} {
+		if got := pathResolved(tc.arg); got != tc.want {
+			t.Errorf("pathResolved(%s) = %v, want %v", tc.arg.GetKind(), got, tc.want)
+		}
+	}
+}
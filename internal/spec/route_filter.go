@@ -0,0 +1,125 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ehabterra/apispec/pkg/patterns"
+)
+
+// routeFilter is a parsed include.routes / exclude.routes entry: an optional
+// HTTP method (any when empty) and a gitignore-style glob over the
+// OpenAPI path, such as "GET /internal/**" or "/debug/*".
+type routeFilter struct {
+	method string
+	path   string
+}
+
+// parseRouteFilter parses "[METHOD] /path/glob". The method is
+// case-insensitive, and "*" stands for any method.
+func parseRouteFilter(rule string) (routeFilter, error) {
+	fields := strings.Fields(rule)
+	var f routeFilter
+	switch len(fields) {
+	case 1:
+		f.path = fields[0]
+	case 2:
+		f.method, f.path = strings.ToUpper(fields[0]), fields[1]
+		if f.method == "*" {
+			f.method = ""
+		} else if !isHTTPMethod(f.method) {
+			return f, fmt.Errorf("invalid method %q%s", fields[0], methodHint(fields[0]))
+		}
+	default:
+		return f, fmt.Errorf("want \"[METHOD] /path/glob\"")
+	}
+	if !strings.HasPrefix(f.path, "/") {
+		return f, fmt.Errorf("path glob %q must start with /", f.path)
+	}
+	return f, nil
+}
+
+// matches reports whether the filter selects method on path, an OpenAPI path
+// with {param} placeholders.
+func (f routeFilter) matches(method, path string) bool {
+	if f.method != "" && f.method != strings.ToUpper(method) {
+		return false
+	}
+	return patterns.Match(f.path, path)
+}
+
+// ValidateRouteFilters rejects include.routes and exclude.routes entries
+// that do not parse. It returns the first error encountered.
+func (c *APISpecConfig) ValidateRouteFilters() error {
+	for _, list := range []struct {
+		name  string
+		rules []string
+	}{{"include.routes", c.Include.Routes}, {"exclude.routes", c.Exclude.Routes}} {
+		for i, rule := range list.rules {
+			if _, err := parseRouteFilter(rule); err != nil {
+				return fmt.Errorf("%s[%d] %q: %w", list.name, i, rule, err)
+			}
+		}
+	}
+	return nil
+}
+
+// filterRoutes drops the routes the config's route filters leave out of the
+// published spec: with include.routes, those matching none of them, and any
+// matching an exclude.routes entry. Unlike the package and file filters this
+// runs after extraction, so an endpoint can be hidden without losing the code
+// that serves the others. Entries that do not parse were rejected when the
+// config was loaded and are skipped here.
+func filterRoutes(cfg *APISpecConfig, routes []*RouteInfo) []*RouteInfo {
+	if cfg == nil || (len(cfg.Include.Routes) == 0 && len(cfg.Exclude.Routes) == 0) {
+		return routes
+	}
+	include, exclude := parseRouteFilters(cfg.Include.Routes), parseRouteFilters(cfg.Exclude.Routes)
+	kept := routes[:0:0]
+	for _, r := range routes {
+		path := r.OpenAPIPath()
+		if len(cfg.Include.Routes) > 0 && !anyRouteFilterMatches(include, r.Method, path) {
+			continue
+		}
+		if anyRouteFilterMatches(exclude, r.Method, path) {
+			continue
+		}
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+// parseRouteFilters parses rules, skipping the ones that do not parse.
+func parseRouteFilters(rules []string) []routeFilter {
+	var filters []routeFilter
+	for _, rule := range rules {
+		if f, err := parseRouteFilter(rule); err == nil {
+			filters = append(filters, f)
+		}
+	}
+	return filters
+}
+
+// anyRouteFilterMatches reports whether any of filters selects the route.
+func anyRouteFilterMatches(filters []routeFilter, method, path string) bool {
+	for _, f := range filters {
+		if f.matches(method, path) {
+			return true
+		}
+	}
+	return false
+}
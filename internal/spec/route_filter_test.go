@@ -0,0 +1,88 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRouteFilterMatches(t *testing.T) {
+	for _, tc := range []struct {
+		rule, method, path string
+		want               bool
+	}{
+		{"/internal/**", "POST", "/internal/cache/flush", true},
+		{"/internal/**", "GET", "/users", false},
+		{"GET /internal/**", "get", "/internal/cache", true},
+		{"get /internal/**", "DELETE", "/internal/cache", false},
+		{"* /users/*", "PATCH", "/users/{id}", true},
+		{"/users/*", "GET", "/users/{id}/orders", false},
+		{"/debug/*", "GET", "/debug/vars", true},
+	} {
+		f, err := parseRouteFilter(tc.rule)
+		if err != nil {
+			t.Fatalf("parseRouteFilter(%q): %v", tc.rule, err)
+		}
+		if got := f.matches(tc.method, tc.path); got != tc.want {
+			t.Errorf("%q matches %s %s = %v, want %v", tc.rule, tc.method, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestValidateRouteFilters(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		cfg     APISpecConfig
+		wantErr string
+	}{
+		{"valid", APISpecConfig{Include: IncludeExclude{Routes: []string{"/api/**"}}, Exclude: IncludeExclude{Routes: []string{"GET /api/internal/**"}}}, ""},
+		{"misspelled method", APISpecConfig{Exclude: IncludeExclude{Routes: []string{"GTE /internal/**"}}}, `exclude.routes[0] "GTE /internal/**": invalid method "GTE" (did you mean "GET"?)`},
+		{"relative path", APISpecConfig{Include: IncludeExclude{Routes: []string{"api/**"}}}, "must start with /"},
+		{"extra field", APISpecConfig{Exclude: IncludeExclude{Routes: []string{"GET /a /b"}}}, "[METHOD] /path/glob"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.ValidateRouteFilters()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilterRoutes(t *testing.T) {
+	routes := []*RouteInfo{
+		{Method: "GET", MountPath: "/api", Path: "/users/:id"},
+		{Method: "POST", MountPath: "/api", Path: "/internal/reindex"},
+		{Method: "GET", Path: "/health"},
+	}
+	cfg := &APISpecConfig{
+		Include: IncludeExclude{Routes: []string{"/api/**"}},
+		Exclude: IncludeExclude{Routes: []string{"POST /api/internal/**"}},
+	}
+	got := filterRoutes(cfg, routes)
+	if len(got) != 1 || got[0] != routes[0] {
+		t.Fatalf("filterRoutes kept %d routes, want only GET /api/users/{id}", len(got))
+	}
+	if got := filterRoutes(&APISpecConfig{}, routes); len(got) != len(routes) {
+		t.Errorf("filterRoutes without filters kept %d of %d routes", len(got), len(routes))
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/typemodel"
+)
+
+// SchemaFileSuffix completes a component name into the file name of its
+// standalone JSON Schema document.
+const SchemaFileSuffix = ".schema.json"
+
+// ComponentSchemaID is the $id of a component: base (a directory-style URI,
+// given a trailing slash when missing) plus the component's standalone file
+// name. With an empty base it is the bare file name. The spec's components
+// and the --schemas-only documents share it, so either resolves the other.
+func ComponentSchemaID(base, component string) string {
+	if base != "" && !strings.HasSuffix(base, "/") {
+		base += "/"
+	}
+	return base + component + SchemaFileSuffix
+}
+
+// annotateComponentSchemas sets each component's title from the Go type it
+// was generated from (goTypes, keyed by component name) and, with an IDBase,
+// its $id. Values already present are kept. The component is replaced by an
+// annotated copy because its schema may also be shared by inline use sites
+// or come from user config.
+//
+// An $id makes the component its own resource, against which a
+// "#/components/schemas/X" fragment inside it no longer resolves, so refs
+// within identified components are rewritten to the target's $id. Refs in
+// paths keep the document-relative form.
+func annotateComponentSchemas(components Components, goTypes map[string]string, opts SchemaOptions) {
+	ids := make(map[string]string)
+	for name, schema := range components.Schemas {
+		if schema == nil || schema.Ref != "" {
+			continue
+		}
+		title, id := schema.Title, schema.ID
+		if title == "" && !opts.OmitTitles {
+			if goType, ok := goTypes[name]; ok {
+				title = typemodel.Parse(goType).Simple()
+			}
+		}
+		if id == "" && opts.IDBase != "" {
+			id = ComponentSchemaID(opts.IDBase, name)
+		}
+		if id != "" {
+			ids[refComponentsSchemasPrefix+name] = id
+		}
+		if title == schema.Title && id == schema.ID {
+			continue
+		}
+		annotated := *schema
+		annotated.Title, annotated.ID = title, id
+		components.Schemas[name] = &annotated
+	}
+	if opts.IDBase == "" {
+		return
+	}
+	// A $ref-only component stands for its target.
+	for name, schema := range components.Schemas {
+		if schema != nil && schema.Ref != "" {
+			if id, ok := ids[schema.Ref]; ok {
+				ids[refComponentsSchemasPrefix+name] = id
+			}
+		}
+	}
+	for name, schema := range components.Schemas {
+		if schema != nil && schema.ID != "" {
+			components.Schemas[name] = rebaseRefs(schema, ids, map[*Schema]bool{})
+		}
+	}
+}
+
+// rebaseRefs returns s with every component $ref found in ids replaced by
+// that component's $id. Subschemas are copied only along changed paths, so
+// schemas shared with other sites are never modified.
+func rebaseRefs(s *Schema, ids map[string]string, visiting map[*Schema]bool) *Schema {
+	if s == nil || visiting[s] {
+		return s
+	}
+	visiting[s] = true
+	defer delete(visiting, s)
+
+	c := *s
+	changed := false
+	if id, ok := ids[s.Ref]; ok {
+		c.Ref, changed = id, true
+	}
+	for _, field := range []**Schema{&c.Items, &c.AdditionalProperties, &c.Not} {
+		if r := rebaseRefs(*field, ids, visiting); r != *field {
+			*field, changed = r, true
+		}
+	}
+	for _, list := range []*[]*Schema{&c.AllOf, &c.OneOf, &c.AnyOf} {
+		if r, ok := rebaseList(*list, ids, visiting); ok {
+			*list, changed = r, true
+		}
+	}
+	copied := false
+	for key, prop := range s.Properties {
+		r := rebaseRefs(prop, ids, visiting)
+		if r == prop {
+			continue
+		}
+		if !copied {
+			copied = true
+			c.Properties = make(map[string]*Schema, len(s.Properties))
+			for k, v := range s.Properties {
+				c.Properties[k] = v
+			}
+		}
+		c.Properties[key], changed = r, true
+	}
+	if !changed {
+		return s
+	}
+	return &c
+}
+
+// rebaseList applies rebaseRefs to each schema in list, returning a new
+// slice and true when any of them changed.
+func rebaseList(list []*Schema, ids map[string]string, visiting map[*Schema]bool) ([]*Schema, bool) {
+	var out []*Schema
+	for i, item := range list {
+		r := rebaseRefs(item, ids, visiting)
+		if r == item {
+			continue
+		}
+		if out == nil {
+			out = append([]*Schema(nil), list...)
+		}
+		out[i] = r
+	}
+	return out, out != nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import "testing"
+
+func TestComponentSchemaID(t *testing.T) {
+	for _, base := range []string{"https://example.com/schemas", "https://example.com/schemas/"} {
+		if got, want := ComponentSchemaID(base, "User"), "https://example.com/schemas/User.schema.json"; got != want {
+			t.Errorf("ComponentSchemaID(%q) = %q, want %q", base, got, want)
+		}
+	}
+}
+
+func annotationFixture() (Components, map[string]string, *Schema) {
+	shared := &Schema{Type: "object", Properties: map[string]*Schema{
+		"owner": {Ref: "#/components/schemas/models.User"},
+		"tags":  {Type: "array", Items: &Schema{Ref: "#/components/schemas/Tag"}},
+	}}
+	components := Components{Schemas: map[string]*Schema{
+		"Page_models.User": shared,
+		"models.User":      {Type: "object"},
+		"Tag":              {Ref: "#/components/schemas/models.User"},
+		"Named":            {Type: "string", Title: "Custom"},
+	}}
+	goTypes := map[string]string{
+		"Page_models.User": "example.com/app/models.Page[example.com/app/models.User]",
+		"models.User":      "example.com/app/models.User",
+		"Named":            "example.com/app/models.Named",
+	}
+	return components, goTypes, shared
+}
+
+func TestAnnotateComponentSchemas_Titles(t *testing.T) {
+	components, goTypes, shared := annotationFixture()
+	annotateComponentSchemas(components, goTypes, SchemaOptions{})
+
+	if got := components.Schemas["Page_models.User"].Title; got != "Page[User]" {
+		t.Errorf("generic title = %q, want Page[User]", got)
+	}
+	if got := components.Schemas["models.User"].Title; got != "User" {
+		t.Errorf("title = %q, want User", got)
+	}
+	if got := components.Schemas["Named"].Title; got != "Custom" {
+		t.Errorf("existing title overwritten: %q", got)
+	}
+	if s := components.Schemas["Tag"]; s.Title != "" || s.ID != "" {
+		t.Errorf("$ref-only component must not be annotated: %+v", s)
+	}
+	if shared.Title != "" {
+		t.Error("shared schema was mutated")
+	}
+	if components.Schemas["models.User"].ID != "" {
+		t.Error("$id set without a base")
+	}
+
+	components, goTypes, _ = annotationFixture()
+	annotateComponentSchemas(components, goTypes, SchemaOptions{OmitTitles: true})
+	if got := components.Schemas["models.User"].Title; got != "" {
+		t.Errorf("OmitTitles: title = %q", got)
+	}
+}
+
+func TestAnnotateComponentSchemas_IDs(t *testing.T) {
+	components, goTypes, shared := annotationFixture()
+	annotateComponentSchemas(components, goTypes, SchemaOptions{IDBase: "https://example.com/s"})
+
+	page := components.Schemas["Page_models.User"]
+	if page.ID != "https://example.com/s/Page_models.User.schema.json" {
+		t.Errorf("$id = %q", page.ID)
+	}
+	userID := "https://example.com/s/models.User.schema.json"
+	if got := page.Properties["owner"].Ref; got != userID {
+		t.Errorf("owner $ref = %q, want %q", got, userID)
+	}
+	// Tag is an alias of models.User, so refs to it resolve to that $id.
+	if got := page.Properties["tags"].Items.Ref; got != userID {
+		t.Errorf("tags item $ref = %q, want %q", got, userID)
+	}
+	if shared.Properties["owner"].Ref != "#/components/schemas/models.User" ||
+		shared.Properties["tags"].Items.Ref != "#/components/schemas/Tag" {
+		t.Error("shared subschemas were mutated")
+	}
+}
@@ -75,7 +75,7 @@ func (s *SchemaMapperImpl) MapGoTypeToOpenAPISchema(goType string) *Schema {
 				case "uint", "uint8", "uint16", "uint32", "uint64", "byte":
 					return &Schema{
 						Type:                 "object",
-						AdditionalProperties: &Schema{Type: "integer", Minimum: 0},
+						AdditionalProperties: &Schema{Type: "integer", Minimum: float64Ptr(0)},
 					}
 				case "float32", "float64":
 					return &Schema{
@@ -110,7 +110,7 @@ func (s *SchemaMapperImpl) MapGoTypeToOpenAPISchema(goType string) *Schema {
 		case "int", "int8", "int16", "int32", "int64":
 			return &Schema{Type: "array", Items: &Schema{Type: "integer"}}
 		case "uint", "uint8", "uint16", "uint32", "uint64", "byte":
-			return &Schema{Type: "array", Items: &Schema{Type: "integer", Minimum: 0}}
+			return &Schema{Type: "array", Items: &Schema{Type: "integer", Minimum: float64Ptr(0)}}
 		case "float32", "float64":
 			return &Schema{Type: "array", Items: &Schema{Type: "number"}}
 		case "bool":
@@ -133,7 +133,7 @@ func (s *SchemaMapperImpl) MapGoTypeToOpenAPISchema(goType string) *Schema {
 	case "int", "int8", "int16", "int32", "int64":
 		return &Schema{Type: "integer"}
 	case "uint", "uint8", "uint16", "uint32", "uint64", "byte":
-		return &Schema{Type: "integer", Minimum: 0}
+		return &Schema{Type: "integer", Minimum: float64Ptr(0)}
 	case "float32", "float64":
 		return &Schema{Type: "number"}
 	case "bool":
@@ -164,7 +164,7 @@ func TestSchemaMapper_MapTypes(t *testing.T) {
 			goType: "map[string]uint",
 			expected: &Schema{
 				Type:                 "object",
-				AdditionalProperties: &Schema{Type: "integer", Minimum: 0},
+				AdditionalProperties: &Schema{Type: "integer", Minimum: float64Ptr(0)},
 			},
 		},
 		{
@@ -259,7 +259,7 @@ func TestSchemaMapper_SliceTypes(t *testing.T) {
 			goType: "[]uint",
 			expected: &Schema{
 				Type:  "array",
-				Items: &Schema{Type: "integer", Minimum: 0},
+				Items: &Schema{Type: "integer", Minimum: float64Ptr(0)},
 			},
 		},
 		{
@@ -352,32 +352,32 @@ func TestSchemaMapper_BasicTypes(t *testing.T) {
 		{
 			name:     "uint",
 			goType:   "uint",
-			expected: &Schema{Type: "integer", Minimum: 0},
+			expected: &Schema{Type: "integer", Minimum: float64Ptr(0)},
 		},
 		{
 			name:     "uint8",
 			goType:   "uint8",
-			expected: &Schema{Type: "integer", Minimum: 0},
+			expected: &Schema{Type: "integer", Minimum: float64Ptr(0)},
 		},
 		{
 			name:     "uint16",
 			goType:   "uint16",
-			expected: &Schema{Type: "integer", Minimum: 0},
+			expected: &Schema{Type: "integer", Minimum: float64Ptr(0)},
 		},
 		{
 			name:     "uint32",
 			goType:   "uint32",
-			expected: &Schema{Type: "integer", Minimum: 0},
+			expected: &Schema{Type: "integer", Minimum: float64Ptr(0)},
 		},
 		{
 			name:     "uint64",
 			goType:   "uint64",
-			expected: &Schema{Type: "integer", Minimum: 0},
+			expected: &Schema{Type: "integer", Minimum: float64Ptr(0)},
 		},
 		{
 			name:     "byte",
 			goType:   "byte",
-			expected: &Schema{Type: "integer", Minimum: 0},
+			expected: &Schema{Type: "integer", Minimum: float64Ptr(0)},
 		},
 		{
 			name:     "float32",
@@ -397,7 +397,7 @@ func TestSchemaMapper_BasicTypes(t *testing.T) {
 		{
 			name:     "[]byte",
 			goType:   "[]byte",
-			expected: &Schema{Type: "array", Items: &Schema{Type: "integer", Minimum: 0}},
+			expected: &Schema{Type: "array", Items: &Schema{Type: "integer", Minimum: float64Ptr(0)}},
 		},
 		{
 			name:     "[]string",
@@ -738,10 +738,10 @@ func schemasEqual(a, b *Schema) bool {
 	if a.Ref != b.Ref {
 		return false
 	}
-	if a.Minimum != b.Minimum {
+	if bound(a.Minimum) != bound(b.Minimum) {
 		return false
 	}
-	if a.Maximum != b.Maximum {
+	if bound(a.Maximum) != bound(b.Maximum) {
 		return false
 	}
 	if a.Items != nil && b.Items != nil {
@@ -49,6 +49,13 @@ func TestNormalizeServeMuxPath(t *testing.T) {
 		{"/files/{path...}", "/files/{path}"}, // trailing wildcard
 		{"/items/{$}", "/items/"},             // end-of-path anchor dropped
 		{"/static/{dir...}/{$}", "/static/{dir}/"},
+		// Host-specific patterns keep only the path.
+		{"api.example.com/users/{id}", "/users/{id}"},
+		{"localhost:8080/health", "/health"},
+		{"localhost/{$}", "/"},
+		// A relative path is not mistaken for a host.
+		{"users/{id}", "users/{id}"},
+		{"{tenant}/users", "{tenant}/users"},
 	}
 	for _, c := range cases {
 		if got := normalizeServeMuxPath(c.in); got != c.want {
@@ -56,3 +63,12 @@ func TestNormalizeServeMuxPath(t *testing.T) {
 		}
 	}
 }
+
+func TestServeMuxRemainderParams(t *testing.T) {
+	if got := serveMuxRemainderParams("/files/{path...}"); len(got) != 1 || got[0] != "path" {
+		t.Errorf("got %v, want [path]", got)
+	}
+	if got := serveMuxRemainderParams("/users/{id}"); got != nil {
+		t.Errorf("got %v, want none", got)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SortPolicy orders the lists of a generated spec. Map keys (paths,
+// operations, properties, responses, components) are written sorted by the
+// encoders whatever the policy.
+type SortPolicy string
+
+const (
+	// SortSource keeps lists in the order the analysis produces them: tags
+	// and parameters as the routes and handlers declare them, required
+	// properties and validation enums as the struct fields and tags list
+	// them, and constant enums by value.
+	SortSource SortPolicy = "source"
+	// SortAlpha sorts the lists too: document and operation tags by name,
+	// parameters by location (path, query, header, cookie) then name,
+	// required properties by name and enum values by value. Servers and
+	// security requirements keep their order, which carries meaning.
+	SortAlpha SortPolicy = "alpha"
+)
+
+// SortPolicies lists the valid policies, the default first.
+var SortPolicies = []SortPolicy{SortSource, SortAlpha}
+
+// ParseSortPolicy returns the policy named s; "" is SortSource.
+func ParseSortPolicy(s string) (SortPolicy, error) {
+	if s == "" {
+		return SortSource, nil
+	}
+	if policy := SortPolicy(s); slices.Contains(SortPolicies, policy) {
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown sort policy %q (want %s or %s)", s, SortSource, SortAlpha)
+}
+
+// SortSpec orders the lists of s by policy. SortSource leaves them as they
+// are.
+func SortSpec(s *OpenAPISpec, policy SortPolicy) {
+	if s == nil || policy != SortAlpha {
+		return
+	}
+	sorter := alphaSorter{visited: map[*Schema]bool{}}
+
+	slices.SortStableFunc(s.Tags, func(a, b Tag) int { return strings.Compare(a.Name, b.Name) })
+	for _, item := range s.Paths {
+		sorter.pathItem(item)
+	}
+	for _, item := range s.Webhooks {
+		sorter.pathItem(item)
+	}
+
+	c := s.Components
+	if c == nil {
+		return
+	}
+	for _, schema := range c.Schemas {
+		sorter.schema(schema)
+	}
+	for _, p := range c.Parameters {
+		if p != nil {
+			sorter.schema(p.Schema)
+		}
+	}
+	for _, body := range c.RequestBodies {
+		if body != nil {
+			sorter.content(body.Content)
+		}
+	}
+	for _, resp := range c.Responses {
+		if resp != nil {
+			sorter.response(*resp)
+		}
+	}
+	for _, h := range c.Headers {
+		if h != nil {
+			sorter.schema(h.Schema)
+		}
+	}
+}
+
+// alphaSorter applies SortAlpha. Schemas are shared between operations and
+// may be recursive, so each is sorted once.
+type alphaSorter struct {
+	visited map[*Schema]bool
+}
+
+func (a alphaSorter) pathItem(item PathItem) {
+	a.parameters(item.Parameters)
+	for _, m := range item.Operations() {
+		op := m.Operation
+		slices.Sort(op.Tags)
+		a.parameters(op.Parameters)
+		if op.RequestBody != nil {
+			a.content(op.RequestBody.Content)
+		}
+		for _, resp := range op.Responses {
+			a.response(resp)
+		}
+		for _, callback := range op.Callbacks {
+			for _, cbItem := range callback {
+				a.pathItem(cbItem)
+			}
+		}
+	}
+}
+
+// parameterLocations ranks parameter locations in the order they appear
+// in a request line and its headers.
+var parameterLocations = map[string]int{"path": 0, "query": 1, "header": 2, "cookie": 3}
+
+func (a alphaSorter) parameters(params []Parameter) {
+	slices.SortStableFunc(params, func(x, y Parameter) int {
+		return cmp.Or(
+			cmp.Compare(parameterRank(x), parameterRank(y)),
+			strings.Compare(x.Name, y.Name),
+			strings.Compare(x.Ref, y.Ref),
+		)
+	})
+	for _, p := range params {
+		a.schema(p.Schema)
+	}
+}
+
+// parameterRank orders $ref'd parameters, whose location is in the
+// component, after the inline ones.
+func parameterRank(p Parameter) int {
+	if rank, ok := parameterLocations[p.In]; ok {
+		return rank
+	}
+	return len(parameterLocations)
+}
+
+func (a alphaSorter) response(resp Response) {
+	a.content(resp.Content)
+	for _, h := range resp.Headers {
+		a.schema(h.Schema)
+	}
+}
+
+func (a alphaSorter) content(content map[string]MediaType) {
+	for _, media := range content {
+		a.schema(media.Schema)
+	}
+}
+
+func (a alphaSorter) schema(s *Schema) {
+	if s == nil || a.visited[s] {
+		return
+	}
+	a.visited[s] = true
+
+	slices.Sort(s.Required)
+	sortEnum(s)
+	for _, sub := range []*Schema{s.Items, s.AdditionalProperties, s.Not} {
+		a.schema(sub)
+	}
+	for _, list := range [][]*Schema{s.AllOf, s.OneOf, s.AnyOf} {
+		for _, sub := range list {
+			a.schema(sub)
+		}
+	}
+	for _, prop := range s.Properties {
+		a.schema(prop)
+	}
+}
+
+// sortEnum sorts the enum of s by value, keeping its x-enum-varnames in
+// step.
+func sortEnum(s *Schema) {
+	if len(s.Enum) < 2 {
+		return
+	}
+	names, _ := s.Extensions["x-enum-varnames"].([]string)
+	if len(names) != len(s.Enum) {
+		names = nil
+	}
+	sort.Stable(enumSorter{values: s.Enum, names: names})
+}
+
+// enumSorter sorts enum values, and the constant names beside them when
+// there are any.
+type enumSorter struct {
+	values []interface{}
+	names  []string
+}
+
+func (e enumSorter) Len() int { return len(e.values) }
+
+func (e enumSorter) Less(i, j int) bool {
+	return compareEnumValues(e.values[i], e.values[j]) < 0
+}
+
+func (e enumSorter) Swap(i, j int) {
+	e.values[i], e.values[j] = e.values[j], e.values[i]
+	if e.names != nil {
+		e.names[i], e.names[j] = e.names[j], e.names[i]
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"reflect"
+	"testing"
+)
+
+// sortFixture is a spec whose lists are all out of order.
+func sortFixture() *OpenAPISpec {
+	status := &Schema{
+		Type:       "string",
+		Enum:       []interface{}{"pending", "active", "closed"},
+		Extensions: map[string]interface{}{"x-enum-varnames": []string{"StatusPending", "StatusActive", "StatusClosed"}},
+	}
+	user := &Schema{
+		Type:     "object",
+		Required: []string{"name", "email", "id"},
+		Properties: map[string]*Schema{
+			"status":  status,
+			"parent":  {Ref: "#/components/schemas/User"},
+			"retries": {Type: "integer", Enum: []interface{}{10, 2, 1}},
+		},
+	}
+	user.Properties["self"] = user
+	return &OpenAPISpec{
+		Tags: []Tag{{Name: "users"}, {Name: "admin"}},
+		Paths: map[string]PathItem{
+			"/users/{id}": {
+				Get: &Operation{
+					Tags: []string{"users", "admin"},
+					Parameters: []Parameter{
+						{Name: "X-Trace", In: "header"},
+						{Name: "page", In: "query"},
+						{Ref: "#/components/parameters/Tenant"},
+						{Name: "limit", In: "query"},
+						{Name: "id", In: "path"},
+					},
+					Responses: map[string]Response{
+						"200": {Content: map[string]MediaType{"application/json": {Schema: user}}},
+					},
+				},
+			},
+		},
+		Components: &Components{Schemas: map[string]*Schema{"User": user}},
+	}
+}
+
+func TestSortSpecAlpha(t *testing.T) {
+	s := sortFixture()
+	SortSpec(s, SortAlpha)
+
+	if got := []string{s.Tags[0].Name, s.Tags[1].Name}; !reflect.DeepEqual(got, []string{"admin", "users"}) {
+		t.Errorf("document tags = %v", got)
+	}
+	op := s.Paths["/users/{id}"].Get
+	if !reflect.DeepEqual(op.Tags, []string{"admin", "users"}) {
+		t.Errorf("operation tags = %v", op.Tags)
+	}
+	var params []string
+	for _, p := range op.Parameters {
+		params = append(params, p.Name+p.Ref)
+	}
+	want := []string{"id", "limit", "page", "X-Trace", "#/components/parameters/Tenant"}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("parameters = %v, want %v", params, want)
+	}
+
+	user := s.Components.Schemas["User"]
+	if !reflect.DeepEqual(user.Required, []string{"email", "id", "name"}) {
+		t.Errorf("required = %v", user.Required)
+	}
+	status := user.Properties["status"]
+	if !reflect.DeepEqual(status.Enum, []interface{}{"active", "closed", "pending"}) {
+		t.Errorf("string enum = %v", status.Enum)
+	}
+	if names := status.Extensions["x-enum-varnames"]; !reflect.DeepEqual(names, []string{"StatusActive", "StatusClosed", "StatusPending"}) {
+		t.Errorf("x-enum-varnames not kept in step with the enum: %v", names)
+	}
+	if got := user.Properties["retries"].Enum; !reflect.DeepEqual(got, []interface{}{1, 2, 10}) {
+		t.Errorf("numeric enum = %v, want numeric order", got)
+	}
+}
+
+func TestSortSpecSourceKeepsOrder(t *testing.T) {
+	s := sortFixture()
+	SortSpec(s, SortSource)
+	if !reflect.DeepEqual(s, sortFixture()) {
+		t.Error("SortSource changed the spec")
+	}
+	SortSpec(nil, SortAlpha)
+}
+
+func TestParseSortPolicy(t *testing.T) {
+	for in, want := range map[string]SortPolicy{"": SortSource, "source": SortSource, "alpha": SortAlpha} {
+		if got, err := ParseSortPolicy(in); err != nil || got != want {
+			t.Errorf("ParseSortPolicy(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseSortPolicy("random"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"maps"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+	"github.com/ehabterra/apispec/internal/typemodel"
+)
+
+const (
+	// extGoSource is the vendor extension holding the file:line of the Go
+	// declaration an operation or schema was generated from.
+	extGoSource = "x-go-source"
+	// extGoFunction is the vendor extension naming that declaration as
+	// `go doc` does: import path, then the symbol.
+	extGoFunction = "x-go-function"
+)
+
+// SourceLocation is the Go declaration an operation or component schema was
+// generated from.
+type SourceLocation struct {
+	File     string // relative to the module root (see sourcePosition)
+	Line     int
+	Function string // as `go doc` names it; empty for a function literal
+}
+
+// String renders l as x-go-source does, "file:line"; "" when the file is
+// unknown.
+func (l SourceLocation) String() string {
+	if l.File == "" {
+		return ""
+	}
+	return l.File + ":" + strconv.Itoa(l.Line)
+}
+
+// operationSources returns where the handler of each operation of paths
+// built from routes is declared, keyed as SpecIssue.Location names the
+// operation ("GET /users/{id}"). A function literal has no name, so its
+// location is the literal's and carries no Function.
+func operationSources(paths map[string]PathItem, routes []*RouteInfo, modulePath string, handlerMethods ...string) map[string]SourceLocation {
+	byOperation := map[string]*RouteInfo{}
+	for _, route := range routes {
+		byOperation[routeOperationKey(route)] = route
+	}
+	sources := map[string]SourceLocation{}
+	for p, item := range paths {
+		for _, mo := range item.Operations() {
+			route := byOperation[p+" "+mo.Method]
+			if route == nil {
+				continue
+			}
+			var loc SourceLocation
+			if _, pos, ok := strings.Cut(route.Function, "FuncLit:"); ok {
+				loc = sourcePosition(pos, route.Package, modulePath)
+			} else if decl, ok := handlerDeclaration(route, handlerMethods...); ok {
+				loc = sourcePosition(getStringFromPool(route.Metadata, decl.position), decl.pkg, modulePath)
+				loc.Function = decl.pkg + "." + decl.name
+			}
+			if loc != (SourceLocation{}) {
+				sources[mo.Method+" "+p] = loc
+			}
+		}
+	}
+	return sources
+}
+
+// schemaSources returns where the type of each component schema the analyzed
+// packages declare is declared, keyed "components.schemas.<name>". goTypes
+// maps a component to its Go type, as generateSchemas returns it; an
+// instantiation of a generic type is located at the generic declaration.
+func schemaSources(components Components, goTypes map[string]string, meta *metadata.Metadata) map[string]SourceLocation {
+	sources := map[string]SourceLocation{}
+	for name, schema := range components.Schemas {
+		goType, ok := goTypes[name]
+		if schema == nil || schema.Ref != "" || !ok {
+			continue
+		}
+		t := typemodel.Parse(goType)
+		if !t.IsNamed() || t.Pkg == "" {
+			continue
+		}
+		typ := findType(meta, t.Pkg, t.Name)
+		// Position 0 is the unset field of metadata written before types
+		// recorded one; a type's name is always pooled ahead of its position.
+		if typ == nil || typ.Position <= 0 {
+			continue
+		}
+		loc := sourcePosition(getStringFromPool(meta, typ.Position), t.Pkg, meta.CurrentModulePath)
+		loc.Function = t.Pkg + "." + t.Name
+		sources[refComponentsSchemasLocation+name] = loc
+	}
+	return sources
+}
+
+// refComponentsSchemasLocation prefixes the SpecIssue.Location of a
+// component schema.
+const refComponentsSchemasLocation = "components.schemas."
+
+// annotateSources sets x-go-source and x-go-function on each operation of
+// paths and component schema of components that sources locates.
+func annotateSources(paths map[string]PathItem, components Components, sources map[string]SourceLocation) {
+	for p, item := range paths {
+		for _, mo := range item.Operations() {
+			if loc, ok := sources[mo.Method+" "+p]; ok {
+				mo.Operation.Extensions = mergeExtensions(mo.Operation.Extensions, sourceExtensions(loc))
+			}
+		}
+	}
+	for name, schema := range components.Schemas {
+		loc, ok := sources[refComponentsSchemasLocation+name]
+		if !ok || schema == nil {
+			continue
+		}
+		// Component schemas may be shared with use sites; annotate a copy.
+		annotated := *schema
+		annotated.Extensions = mergeExtensions(maps.Clone(schema.Extensions), sourceExtensions(loc))
+		components.Schemas[name] = &annotated
+	}
+}
+
+// sourceExtensions returns the x-go-source and x-go-function extensions of
+// loc, leaving out an empty one.
+func sourceExtensions(loc SourceLocation) map[string]any {
+	extensions := map[string]any{}
+	if source := loc.String(); source != "" {
+		extensions[extGoSource] = source
+	}
+	if loc.Function != "" {
+		extensions[extGoFunction] = loc.Function
+	}
+	return extensions
+}
+
+// sourcePosition locates a metadata position ("file:line:col") of a
+// declaration in pkg, with the file relative to the root of modulePath: the
+// package's directory under the module joined with the file's name, so the
+// spec does not depend on where the module is checked out. A file of a
+// package outside the module is named by the package's import path. The
+// location is empty for a position without a line.
+func sourcePosition(pos, pkg, modulePath string) SourceLocation {
+	file, line, ok := cutLastColon(pos)
+	if !ok {
+		return SourceLocation{}
+	}
+	// Drop the column; a position on column 0 is written without one.
+	if f, l, ok := cutLastColon(file); ok && isLineNumber(l) {
+		file, line = f, l
+	}
+	n, err := strconv.Atoi(line)
+	if err != nil || n <= 0 {
+		return SourceLocation{}
+	}
+	file = file[strings.LastIndexAny(file, `/\`)+1:]
+	dir := pkg
+	if modulePath != "" && (pkg == modulePath || strings.HasPrefix(pkg, modulePath+"/")) {
+		dir = strings.TrimPrefix(strings.TrimPrefix(pkg, modulePath), "/")
+	}
+	return SourceLocation{File: path.Join(dir, file), Line: n}
+}
+
+func isLineNumber(s string) bool {
+	n, err := strconv.Atoi(s)
+	return err == nil && n > 0
+}
+
+// cutLastColon splits s around its last colon.
+func cutLastColon(s string) (before, after string, found bool) {
+	i := strings.LastIndexByte(s, ':')
+	if i < 0 {
+		return s, "", false
+	}
+	return s[:i], s[i+1:], true
+}
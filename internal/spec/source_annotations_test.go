@@ -0,0 +1,88 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+func TestSourcePosition(t *testing.T) {
+	const mod = "github.com/acme/api"
+	tests := []struct {
+		name     string
+		pos, pkg string
+		want     string
+	}{
+		{"root package", "/src/api/main.go:12:6", mod, "main.go:12"},
+		{"sub-package", "/src/api/internal/users/handler.go:40:1", mod + "/internal/users", "internal/users/handler.go:40"},
+		{"no column", "/src/api/main.go:12", mod, "main.go:12"},
+		{"windows path", `C:\src\api\users\handler.go:7:2`, mod + "/users", "users/handler.go:7"},
+		{"outside the module", "/go/pkg/mod/example.com/shared@v1.0.0/types.go:3:6", "example.com/shared", "example.com/shared/types.go:3"},
+		{"module sharing a prefix", "/src/apix/main.go:5:1", mod + "x", "github.com/acme/apix/main.go:5"},
+		{"not a position", "ident", mod, ""},
+		{"empty", "", mod, ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sourcePosition(tc.pos, tc.pkg, mod).String(); got != tc.want {
+				t.Errorf("sourcePosition(%q, %q) = %q, want %q", tc.pos, tc.pkg, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSchemaSources(t *testing.T) {
+	const pkg = "github.com/acme/api/users"
+	meta := &metadata.Metadata{StringPool: metadata.NewStringPool(), CurrentModulePath: "github.com/acme/api"}
+	sp := meta.StringPool
+	user := &metadata.Type{Name: sp.Get("User"), Pkg: sp.Get(pkg)}
+	user.Position = sp.Get("/src/api/users/user.go:9:6")
+	legacy := &metadata.Type{Name: sp.Get("Legacy"), Pkg: sp.Get(pkg)} // written without a position
+	meta.Packages = map[string]*metadata.Package{
+		pkg: {Types: map[string]*metadata.Type{"User": user, "Legacy": legacy}},
+	}
+
+	components := Components{Schemas: map[string]*Schema{
+		"users.User":   {Type: "object", Extensions: map[string]any{"x-owner": "team"}},
+		"users.Legacy": {Type: "object"},
+		"uuid.UUID":    {Type: "string"},
+		"users.Alias":  {Ref: refComponentsSchemasPrefix + "users.User"},
+	}}
+	shared := components.Schemas["users.User"]
+	sources := schemaSources(components, map[string]string{
+		"users.User":   pkg + "-->User",
+		"users.Legacy": pkg + "-->Legacy",
+		"uuid.UUID":    "github.com/google/uuid-->UUID",
+		"users.Alias":  pkg + "-->User",
+	}, meta)
+	want := map[string]SourceLocation{
+		"components.schemas.users.User": {File: "users/user.go", Line: 9, Function: pkg + ".User"},
+	}
+	if !reflect.DeepEqual(sources, want) {
+		t.Errorf("schemaSources = %v, want %v", sources, want)
+	}
+
+	annotateSources(nil, components, sources)
+	got := components.Schemas["users.User"].Extensions
+	if got["x-go-source"] != "users/user.go:9" || got["x-go-function"] != pkg+".User" || got["x-owner"] != "team" {
+		t.Errorf("users.User extensions = %v", got)
+	}
+	if len(shared.Extensions) != 1 {
+		t.Errorf("shared schema modified: %v", shared.Extensions)
+	}
+}
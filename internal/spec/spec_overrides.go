@@ -0,0 +1,385 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/diag"
+	"gopkg.in/yaml.v3"
+)
+
+// SpecOverrides is a partial OpenAPI document laid over the generated spec by
+// ApplySpecOverrides, so hand-written docs survive regeneration. Only the
+// documentation parts of the document are read: info, security, and per
+// operation the summary, description, security, and parameter, body and
+// response descriptions and examples. Paths, operations, parameters and
+// responses are matched against what was generated; they are never created.
+type SpecOverrides struct {
+	Info     *Info                    `yaml:"info,omitempty"`
+	Security *[]SecurityRequirement   `yaml:"security,omitempty"`
+	Paths    map[string]*PathOverride `yaml:"paths,omitempty"`
+
+	// file and root locate entries in the source document for diagnostics.
+	file string
+	root *yaml.Node
+}
+
+// PathOverride overrides one path item, keyed by the generated path
+// template ("/users/{id}").
+type PathOverride struct {
+	Summary     string             `yaml:"summary,omitempty"`
+	Description string             `yaml:"description,omitempty"`
+	Get         *OperationOverride `yaml:"get,omitempty"`
+	Post        *OperationOverride `yaml:"post,omitempty"`
+	Put         *OperationOverride `yaml:"put,omitempty"`
+	Delete      *OperationOverride `yaml:"delete,omitempty"`
+	Patch       *OperationOverride `yaml:"patch,omitempty"`
+	Options     *OperationOverride `yaml:"options,omitempty"`
+	Head        *OperationOverride `yaml:"head,omitempty"`
+}
+
+type methodOverride struct {
+	method string
+	op     *OperationOverride
+}
+
+// operations returns the overridden operations in PathItem.Operations order.
+func (p *PathOverride) operations() []methodOverride {
+	var out []methodOverride
+	for _, m := range []methodOverride{
+		{"GET", p.Get}, {"POST", p.Post}, {"PUT", p.Put}, {"DELETE", p.Delete},
+		{"PATCH", p.Patch}, {"OPTIONS", p.Options}, {"HEAD", p.Head},
+	} {
+		if m.op != nil {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// OperationOverride overrides one operation. Security replaces the
+// generated requirements outright; "security: []" marks it public.
+type OperationOverride struct {
+	Summary     string                   `yaml:"summary,omitempty"`
+	Description string                   `yaml:"description,omitempty"`
+	Security    *[]SecurityRequirement   `yaml:"security,omitempty"`
+	Parameters  []ParameterOverride      `yaml:"parameters,omitempty"`
+	RequestBody *BodyOverride            `yaml:"requestBody,omitempty"`
+	Responses   map[string]*BodyOverride `yaml:"responses,omitempty"`
+}
+
+// ParameterOverride documents a generated parameter, found by name and, when
+// set, location.
+type ParameterOverride struct {
+	Name        string      `yaml:"name"`
+	In          string      `yaml:"in,omitempty"`
+	Description string      `yaml:"description,omitempty"`
+	Example     interface{} `yaml:"example,omitempty"`
+}
+
+// BodyOverride documents a generated request body or response.
+type BodyOverride struct {
+	Description string                    `yaml:"description,omitempty"`
+	Content     map[string]*MediaOverride `yaml:"content,omitempty"`
+}
+
+// MediaOverride sets the examples of a generated media type. Example
+// replaces any examples and Examples are merged by name, replacing a single
+// example: OpenAPI allows only one of the two.
+type MediaOverride struct {
+	Example  interface{}        `yaml:"example,omitempty"`
+	Examples map[string]Example `yaml:"examples,omitempty"`
+}
+
+// LoadSpecOverrides reads an overrides document. Keys it does not read are
+// reported as diagnostics, like the config file's.
+func LoadSpecOverrides(path string) (*SpecOverrides, []diag.Diagnostic, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, err
+	}
+	o := &SpecOverrides{file: path, root: &root}
+	if root.Kind != 0 {
+		if err := root.Decode(o); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, at := range slices.Sorted(maps.Keys(o.Paths)) {
+		p := o.Paths[at]
+		if p == nil {
+			continue
+		}
+		for _, m := range p.operations() {
+			for i, param := range m.op.Parameters {
+				if param.Name == "" {
+					return nil, nil, fmt.Errorf("paths.%s.%s.parameters[%d]: name is required", at, strings.ToLower(m.method), i)
+				}
+			}
+		}
+	}
+	return o, unknownYAMLKeys(path, &root, reflect.TypeOf(SpecOverrides{}), "overrides"), nil
+}
+
+// ApplySpecOverrides merges o into s. An override whose path, operation,
+// parameter, body, response or media type is not in s is skipped and
+// reported — usually a route that was renamed since the override was
+// written.
+func ApplySpecOverrides(s *OpenAPISpec, o *SpecOverrides) []diag.Diagnostic {
+	if s == nil || o == nil {
+		return nil
+	}
+	a := overrideApplier{o: o}
+	if o.Info != nil {
+		mergeInfo(&s.Info, o.Info)
+	}
+	if o.Security != nil {
+		s.Security = *o.Security
+	}
+	pathKeys := slices.Sorted(maps.Keys(s.Paths))
+	for _, path := range slices.Sorted(maps.Keys(o.Paths)) {
+		po := o.Paths[path]
+		if po == nil {
+			continue
+		}
+		item, ok := s.Paths[path]
+		if !ok {
+			a.stale(fmt.Sprintf("path %s is not in the generated spec", path), diag.Suggest(path, pathKeys), "", "paths", path)
+			continue
+		}
+		if po.Summary != "" {
+			item.Summary = po.Summary
+		}
+		if po.Description != "" {
+			item.Description = po.Description
+		}
+		generated := item.Operations()
+		for _, m := range po.operations() {
+			i := slices.IndexFunc(generated, func(g MethodOperation) bool { return g.Method == m.method })
+			if i < 0 {
+				var methods []string
+				for _, g := range generated {
+					methods = append(methods, g.Method)
+				}
+				a.stale(fmt.Sprintf("%s %s is not in the generated spec", m.method, path), "",
+					"the path has "+strings.Join(methods, ", "), "paths", path, strings.ToLower(m.method))
+				continue
+			}
+			a.operation(generated[i].Operation, m.op, m.method+" "+path, "paths", path, strings.ToLower(m.method))
+		}
+		s.Paths[path] = item
+	}
+	// Overridden parameter and body schemas arrive in the 3.1 form.
+	markBooleanBounds(s)
+	return a.out
+}
+
+func mergeInfo(dst, src *Info) {
+	for _, f := range []struct{ dst, src *string }{
+		{&dst.Title, &src.Title},
+		{&dst.Description, &src.Description},
+		{&dst.Version, &src.Version},
+		{&dst.TermsOfService, &src.TermsOfService},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	if src.Contact != nil {
+		if dst.Contact == nil {
+			dst.Contact = &Contact{}
+		}
+		for _, f := range []struct{ dst, src *string }{
+			{&dst.Contact.Name, &src.Contact.Name},
+			{&dst.Contact.URL, &src.Contact.URL},
+			{&dst.Contact.Email, &src.Contact.Email},
+		} {
+			if *f.src != "" {
+				*f.dst = *f.src
+			}
+		}
+	}
+	if src.License != nil {
+		if dst.License == nil {
+			dst.License = &License{}
+		}
+		if src.License.Name != "" {
+			dst.License.Name = src.License.Name
+		}
+		if src.License.URL != "" {
+			dst.License.URL = src.License.URL
+		}
+	}
+}
+
+type overrideApplier struct {
+	o   *SpecOverrides
+	out []diag.Diagnostic
+}
+
+// stale reports an override that matches nothing generated; keys locate it
+// in the overrides document.
+func (a *overrideApplier) stale(msg, suggestion, help string, keys ...string) {
+	a.out = append(a.out, diag.Diagnostic{
+		Severity:   diag.Warning,
+		Category:   "overrides",
+		Message:    msg + "; the override is ignored",
+		Pos:        a.o.position(keys...),
+		Suggestion: suggestion,
+		Help:       help,
+	})
+}
+
+func (a *overrideApplier) operation(op *Operation, o *OperationOverride, at string, keys ...string) {
+	if o.Summary != "" {
+		op.Summary = o.Summary
+	}
+	if o.Description != "" {
+		op.Description = o.Description
+	}
+	if o.Security != nil {
+		security := slices.Clone(*o.Security)
+		if security == nil {
+			security = []SecurityRequirement{}
+		}
+		op.Security = &security
+	}
+
+	for i, po := range o.Parameters {
+		j := slices.IndexFunc(op.Parameters, func(p Parameter) bool {
+			return p.Name == po.Name && (po.In == "" || p.In == po.In)
+		})
+		if j < 0 {
+			var names []string
+			for _, p := range op.Parameters {
+				names = append(names, p.Name)
+			}
+			a.stale(fmt.Sprintf("%s has no parameter %q", at, po.Name), diag.Suggest(po.Name, names), "",
+				append(keys, "parameters", strconv.Itoa(i))...)
+			continue
+		}
+		p := &op.Parameters[j]
+		if po.Description != "" {
+			p.Description = po.Description
+		}
+		if po.Example != nil {
+			p.Example = po.Example
+		}
+	}
+
+	if o.RequestBody != nil {
+		if op.RequestBody == nil {
+			a.stale(at+" has no request body", "", "", append(keys, "requestBody")...)
+		} else {
+			if o.RequestBody.Description != "" {
+				op.RequestBody.Description = o.RequestBody.Description
+			}
+			a.content(op.RequestBody.Content, o.RequestBody.Content, at+" request body", append(keys, "requestBody")...)
+		}
+	}
+
+	statuses := slices.Sorted(maps.Keys(op.Responses))
+	for _, status := range slices.Sorted(maps.Keys(o.Responses)) {
+		ro := o.Responses[status]
+		if ro == nil {
+			continue
+		}
+		resp, ok := op.Responses[status]
+		if !ok {
+			a.stale(fmt.Sprintf("%s has no %s response", at, status), "",
+				"it responds with "+strings.Join(statuses, ", "), append(keys, "responses", status)...)
+			continue
+		}
+		if ro.Description != "" {
+			resp.Description = ro.Description
+		}
+		a.content(resp.Content, ro.Content, fmt.Sprintf("%s %s response", at, status), append(keys, "responses", status)...)
+		op.Responses[status] = resp
+	}
+}
+
+func (a *overrideApplier) content(content map[string]MediaType, overrides map[string]*MediaOverride, at string, keys ...string) {
+	types := slices.Sorted(maps.Keys(content))
+	for _, mt := range slices.Sorted(maps.Keys(overrides)) {
+		mo := overrides[mt]
+		if mo == nil {
+			continue
+		}
+		media, ok := content[mt]
+		if !ok {
+			a.stale(fmt.Sprintf("%s has no %s content", at, mt), diag.Suggest(mt, types), "",
+				append(keys, "content", mt)...)
+			continue
+		}
+		if mo.Example != nil {
+			media.Example = mo.Example
+			media.Examples = nil
+		}
+		if len(mo.Examples) > 0 {
+			if media.Examples == nil {
+				media.Examples = map[string]Example{}
+			}
+			maps.Copy(media.Examples, mo.Examples)
+			media.Example = nil
+		}
+		content[mt] = media
+	}
+}
+
+// position returns where keys (mapping keys, or sequence indexes) lead in
+// the overrides document, as far as they resolve.
+func (o *SpecOverrides) position(keys ...string) diag.Position {
+	if o.root == nil {
+		return diag.Position{}
+	}
+	node := o.root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	pos := diag.Position{File: o.file}
+	for _, key := range keys {
+		next := (*yaml.Node)(nil)
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					pos.Line, pos.Column = node.Content[i].Line, node.Content[i].Column
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if i, err := strconv.Atoi(key); err == nil && i < len(node.Content) {
+				next = node.Content[i]
+				pos.Line, pos.Column = next.Line, next.Column
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return pos
+}
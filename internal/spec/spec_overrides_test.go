@@ -0,0 +1,172 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const overridesYAML = `info:
+  title: Users API
+  contact:
+    email: api@example.com
+security:
+  - bearerAuth: []
+paths:
+  /users/{id}:
+    summary: A single user
+    get:
+      summary: Fetch a user
+      description: Returns the user, or 404.
+      security: []
+      parameters:
+        - name: id
+          description: The user's UUID.
+          example: 5f0c
+      responses:
+        "200":
+          description: The user.
+          content:
+            application/json:
+              examples:
+                admin:
+                  value: {id: 5f0c, role: admin}
+        "418":
+          description: Never sent.
+    delete:
+      summary: Delete a user
+  /user/{id}:
+    get:
+      summary: Stale
+  /users:
+    post:
+      requestBody:
+        description: The user to create.
+        content:
+          application/json:
+            example: {name: Ada}
+      sumary: typo
+`
+
+func overridesFixture() *OpenAPISpec {
+	return &OpenAPISpec{
+		Info: Info{Title: "Generated API", Version: "1.0.0", Contact: &Contact{Name: "Ehab"}},
+		Paths: map[string]PathItem{
+			"/users/{id}": {Get: &Operation{
+				Summary:    "getUser",
+				Parameters: []Parameter{{Name: "id", In: "path", Required: true}},
+				Security:   &[]SecurityRequirement{{"bearerAuth": {}}},
+				Responses: map[string]Response{
+					"200": {Description: "OK", Content: map[string]MediaType{
+						"application/json": {Example: map[string]any{"id": "generated"}},
+					}},
+				},
+			}},
+			"/users": {Post: &Operation{
+				RequestBody: &RequestBody{Content: map[string]MediaType{"application/json": {}}},
+				Responses:   map[string]Response{"201": {Description: "Created"}},
+			}},
+		},
+	}
+}
+
+func TestSpecOverrides_LoadAndApply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.yaml")
+	if err := os.WriteFile(path, []byte(overridesYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	o, diags, err := LoadSpecOverrides(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diags) != 1 || !strings.Contains(diags[0].Message, `unknown key "sumary"`) || diags[0].Suggestion != "summary" || diags[0].Pos.Line != 40 {
+		t.Fatalf("load diagnostics = %+v, want the sumary typo at line 40", diags)
+	}
+
+	s := overridesFixture()
+	diags = ApplySpecOverrides(s, o)
+
+	if s.Info.Title != "Users API" || s.Info.Version != "1.0.0" {
+		t.Errorf("info = %+v", s.Info)
+	}
+	if c := s.Info.Contact; c.Name != "Ehab" || c.Email != "api@example.com" {
+		t.Errorf("contact = %+v, want merged", c)
+	}
+	if len(s.Security) != 1 {
+		t.Errorf("top-level security = %v", s.Security)
+	}
+
+	item := s.Paths["/users/{id}"]
+	if item.Summary != "A single user" {
+		t.Errorf("path summary = %q", item.Summary)
+	}
+	get := item.Get
+	if get.Summary != "Fetch a user" || get.Description != "Returns the user, or 404." {
+		t.Errorf("get = %q / %q", get.Summary, get.Description)
+	}
+	if get.Security == nil || len(*get.Security) != 0 {
+		t.Errorf("security = %v, want the explicit empty list", get.Security)
+	}
+	if p := get.Parameters[0]; p.Description != "The user's UUID." || p.Example != "5f0c" {
+		t.Errorf("param = %+v", p)
+	}
+	resp := get.Responses["200"]
+	media := resp.Content["application/json"]
+	if resp.Description != "The user." || media.Example != nil || len(media.Examples) != 1 {
+		t.Errorf("200 = %+v, want the named example in place of the generated one", resp)
+	}
+	if want := map[string]any{"id": "5f0c", "role": "admin"}; !reflect.DeepEqual(media.Examples["admin"].Value, want) {
+		t.Errorf("admin example = %#v", media.Examples["admin"].Value)
+	}
+
+	post := s.Paths["/users"].Post
+	if post.RequestBody.Description != "The user to create." || post.RequestBody.Content["application/json"].Example == nil {
+		t.Errorf("request body = %+v", post.RequestBody)
+	}
+
+	var got []string
+	for _, d := range diags {
+		got = append(got, d.Message)
+		if d.Category != "overrides" || d.Pos.File != path || d.Pos.Line == 0 {
+			t.Errorf("diagnostic %q not located: %+v", d.Message, d.Pos)
+		}
+	}
+	want := []string{
+		"path /user/{id} is not in the generated spec; the override is ignored",
+		"GET /users/{id} has no 418 response; the override is ignored",
+		"DELETE /users/{id} is not in the generated spec; the override is ignored",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("apply diagnostics:\n got %q\nwant %q", got, want)
+	}
+	if diags[0].Suggestion != "/users/{id}" || diags[0].Pos.Line != 30 {
+		t.Errorf("stale path: suggestion %q at line %d", diags[0].Suggestion, diags[0].Pos.Line)
+	}
+}
+
+func TestLoadSpecOverrides_ParameterNeedsName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.yaml")
+	doc := "paths:\n  /x:\n    get:\n      parameters:\n        - description: nameless\n"
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := LoadSpecOverrides(path); err == nil || !strings.Contains(err.Error(), "paths./x.get.parameters[0]: name is required") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -108,30 +108,30 @@ func TestWrapperSpecialisationHelpers(t *testing.T) {
 	}
 
 	// wrapperFieldIsGeneric: interface{} and *any are generic; int is not.
-	if !wrapperFieldIsGeneric(m, wt, "Data") {
+	if !wrapperFieldIsGeneric(m, wt, "app.Envelope", "Data") {
 		t.Error("interface{} field should be generic")
 	}
-	if !wrapperFieldIsGeneric(m, wt, "Any") {
+	if !wrapperFieldIsGeneric(m, wt, "app.Envelope", "Any") {
 		t.Error("*any field should be generic")
 	}
-	if wrapperFieldIsGeneric(m, wt, "Code") {
+	if wrapperFieldIsGeneric(m, wt, "app.Envelope", "Code") {
 		t.Error("int field should not be generic")
 	}
-	if wrapperFieldIsGeneric(m, wt, "Missing") || wrapperFieldIsGeneric(m, nil, "Data") {
+	if wrapperFieldIsGeneric(m, wt, "app.Envelope", "Missing") || wrapperFieldIsGeneric(m, nil, "", "Data") {
 		t.Error("missing field / nil type should not be generic")
 	}
 
 	// jsonNameForField: tag name, fallback to field name, missing field.
-	if got := jsonNameForField(m, wt, "Data"); got != "data" {
+	if got := jsonNameForField(m, wt, "Data", nil); got != "data" {
 		t.Errorf("json name = %q, want data", got)
 	}
-	if got := jsonNameForField(m, wt, "Code"); got != "Code" {
+	if got := jsonNameForField(m, wt, "Code", nil); got != "Code" {
 		t.Errorf("untagged json name = %q, want Code", got)
 	}
-	if got := jsonNameForField(m, wt, "Missing"); got != "" {
+	if got := jsonNameForField(m, wt, "Missing", nil); got != "" {
 		t.Errorf("missing field json name = %q", got)
 	}
-	if got := jsonNameForField(m, nil, "Data"); got != "" {
+	if got := jsonNameForField(m, nil, "Data", nil); got != "" {
 		t.Errorf("nil wrapper json name = %q", got)
 	}
 }
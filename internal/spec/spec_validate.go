@@ -0,0 +1,219 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// SpecIssue is a structural problem in an OpenAPI document: something a
+// validator rejects or a client generator trips over.
+type SpecIssue struct {
+	Location string // e.g. "GET /users/{id}" or "components.schemas.User"
+	Message  string
+	// Help says how to resolve the issue in the analysed code, when there is
+	// something to change there.
+	Help string
+}
+
+func (i SpecIssue) String() string {
+	return i.Location + ": " + i.Message
+}
+
+// pathTemplateParam matches a `{name}` segment of an OpenAPI path.
+var pathTemplateParam = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// ValidateSpec checks s for dangling component $refs, duplicate
+// operationIds, path templates that disagree with their path parameters, and
+// response keys that are not HTTP status codes. Webhooks and callbacks are
+// checked like operations. Issues are ordered by path, method, webhook and
+// component name.
+func ValidateSpec(s *OpenAPISpec) []SpecIssue {
+	if s == nil {
+		return nil
+	}
+	v := &specValidator{spec: s, operationIDs: map[string]string{}}
+	for _, path := range slices.Sorted(maps.Keys(s.Paths)) {
+		item := s.Paths[path]
+		for _, m := range item.Operations() {
+			v.checkOperation(m.Method+" "+path, path, m.Operation, item.Parameters)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.Webhooks)) {
+		item := s.Webhooks[name]
+		for _, m := range item.Operations() {
+			v.checkOperation(m.Method+" webhooks."+name, "", m.Operation, item.Parameters)
+		}
+	}
+	if c := s.Components; c != nil {
+		for _, name := range slices.Sorted(maps.Keys(c.Schemas)) {
+			v.checkSchema("components.schemas."+name, c.Schemas[name])
+		}
+	}
+	return v.issues
+}
+
+type specValidator struct {
+	spec         *OpenAPISpec
+	operationIDs map[string]string // operationId -> first operation using it
+	issues       []SpecIssue
+}
+
+func (v *specValidator) report(location, help, format string, args ...interface{}) {
+	v.issues = append(v.issues, SpecIssue{Location: location, Message: fmt.Sprintf(format, args...), Help: help})
+}
+
+// Help for the issues ValidateSpec reports.
+const (
+	helpDuplicateOperationID = "name one of the handlers with an `@ID` annotation"
+	helpUnresolvedRef        = "the component was referenced but never generated; please report it with the handler that produced it"
+	helpPathParam            = "check the route's path template against the path parameters the handler reads"
+	helpStatusCode           = "write the status with a net/http Status constant or an integer literal between 100 and 599"
+)
+
+// checkOperation checks the operation at loc. path is its path template,
+// "" for a webhook or callback, whose URL is not one.
+func (v *specValidator) checkOperation(loc, path string, op *Operation, shared []Parameter) {
+	if id := op.OperationID; id != "" {
+		if first, ok := v.operationIDs[id]; ok {
+			v.report(loc, helpDuplicateOperationID, "operationId %q is already used by %s", id, first)
+		} else {
+			v.operationIDs[id] = loc
+		}
+	}
+
+	// Operation parameters override path-level ones with the same name and
+	// location.
+	declared := map[string]bool{}
+	params := append(slices.Clone(op.Parameters), shared...)
+	seen := map[string]bool{}
+	for _, p := range params {
+		resolved, ok := v.resolveParameter(loc, p)
+		if !ok {
+			continue
+		}
+		key := resolved.In + "\x00" + resolved.Name
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		v.checkSchema(loc+" parameter "+resolved.Name, resolved.Schema)
+		if resolved.In != "path" || path == "" {
+			continue
+		}
+		declared[resolved.Name] = true
+		if !strings.Contains(path, "{"+resolved.Name+"}") {
+			v.report(loc, helpPathParam, "path parameter %q does not appear in the path", resolved.Name)
+		}
+		if !resolved.Required {
+			v.report(loc, helpPathParam, "path parameter %q must be required", resolved.Name)
+		}
+	}
+	for _, m := range pathTemplateParam.FindAllStringSubmatch(path, -1) {
+		if !declared[m[1]] {
+			v.report(loc, helpPathParam, "path segment {%s} has no path parameter", m[1])
+		}
+	}
+
+	if op.RequestBody != nil {
+		for _, mt := range slices.Sorted(maps.Keys(op.RequestBody.Content)) {
+			v.checkSchema(loc+" request body "+mt, op.RequestBody.Content[mt].Schema)
+		}
+	}
+	for _, status := range slices.Sorted(maps.Keys(op.Responses)) {
+		if !validStatusKey(status) {
+			v.report(loc, helpStatusCode, "response %q is not an HTTP status code, a range such as 4XX, or default", status)
+		}
+		resp := op.Responses[status]
+		for _, mt := range slices.Sorted(maps.Keys(resp.Content)) {
+			v.checkSchema(loc+" response "+status+" "+mt, resp.Content[mt].Schema)
+		}
+		for _, h := range slices.Sorted(maps.Keys(resp.Headers)) {
+			v.checkSchema(loc+" response "+status+" header "+h, resp.Headers[h].Schema)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(op.Callbacks)) {
+		callback := op.Callbacks[name]
+		for _, expr := range slices.Sorted(maps.Keys(callback)) {
+			item := callback[expr]
+			for _, m := range item.Operations() {
+				v.checkOperation(loc+" callback "+name+" "+m.Method, "", m.Operation, item.Parameters)
+			}
+		}
+	}
+}
+
+// resolveParameter follows a #/components/parameters $ref, reporting it when
+// it dangles.
+func (v *specValidator) resolveParameter(loc string, p Parameter) (Parameter, bool) {
+	if p.Ref == "" {
+		return p, true
+	}
+	const prefix = "#/components/parameters/"
+	if name, ok := strings.CutPrefix(p.Ref, prefix); ok && v.spec.Components != nil {
+		if target := v.spec.Components.Parameters[name]; target != nil {
+			return *target, true
+		}
+	}
+	v.report(loc, helpUnresolvedRef, "$ref %q does not resolve", p.Ref)
+	return Parameter{}, false
+}
+
+// checkSchema reports every #/components/schemas $ref under s that names no
+// component schema.
+func (v *specValidator) checkSchema(loc string, s *Schema) {
+	if s == nil {
+		return
+	}
+	if s.Ref != "" {
+		const prefix = "#/components/schemas/"
+		name, ok := strings.CutPrefix(s.Ref, prefix)
+		if ok && (v.spec.Components == nil || v.spec.Components.Schemas[name] == nil) {
+			v.report(loc, helpUnresolvedRef, "$ref %q does not resolve", s.Ref)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.Properties)) {
+		v.checkSchema(loc, s.Properties[name])
+	}
+	v.checkSchema(loc, s.Items)
+	v.checkSchema(loc, s.AdditionalProperties)
+	v.checkSchema(loc, s.Not)
+	for _, group := range [][]*Schema{s.AllOf, s.OneOf, s.AnyOf} {
+		for _, sub := range group {
+			v.checkSchema(loc, sub)
+		}
+	}
+}
+
+// validStatusKey reports whether key may name an OpenAPI response: a status
+// code from 100 to 599, a range from 1XX to 5XX, or default.
+func validStatusKey(key string) bool {
+	if key == "default" {
+		return true
+	}
+	if len(key) != 3 || key[0] < '1' || key[0] > '5' {
+		return false
+	}
+	if key[1:] == "XX" {
+		return true
+	}
+	return isDigit(key[1]) && isDigit(key[2])
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
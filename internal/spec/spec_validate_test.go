@@ -0,0 +1,140 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateSpec(t *testing.T) {
+	userRef := &Schema{Ref: "#/components/schemas/User"}
+	s := &OpenAPISpec{
+		Paths: map[string]PathItem{
+			"/users/{id}": {
+				Parameters: []Parameter{{Name: "id", In: "path", Required: true}},
+				Get: &Operation{
+					OperationID: "getUser",
+					Responses:   map[string]Response{"200": {Content: map[string]MediaType{"application/json": {Schema: userRef}}}},
+				},
+				Delete: &Operation{
+					OperationID: "getUser",
+					Parameters:  []Parameter{{Ref: "#/components/parameters/Missing"}},
+				},
+			},
+			"/orgs/{org}/teams": {
+				Post: &Operation{
+					Parameters:  []Parameter{{Name: "team", In: "path"}},
+					RequestBody: &RequestBody{Content: map[string]MediaType{"application/json": {Schema: &Schema{Items: &Schema{Ref: "#/components/schemas/Team"}}}}},
+				},
+			},
+		},
+		Components: &Components{Schemas: map[string]*Schema{
+			"User": {Properties: map[string]*Schema{"org": {Ref: "#/components/schemas/Org"}}},
+		}},
+	}
+
+	var got []string
+	for _, issue := range ValidateSpec(s) {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		`POST /orgs/{org}/teams: path parameter "team" does not appear in the path`,
+		`POST /orgs/{org}/teams: path parameter "team" must be required`,
+		`POST /orgs/{org}/teams: path segment {org} has no path parameter`,
+		`POST /orgs/{org}/teams request body application/json: $ref "#/components/schemas/Team" does not resolve`,
+		`DELETE /users/{id}: operationId "getUser" is already used by GET /users/{id}`,
+		`DELETE /users/{id}: $ref "#/components/parameters/Missing" does not resolve`,
+		`components.schemas.User: $ref "#/components/schemas/Org" does not resolve`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateSpec =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestValidateSpec_OperationParameterOverridesPathLevel(t *testing.T) {
+	s := &OpenAPISpec{Paths: map[string]PathItem{
+		"/files/{name}": {
+			Parameters: []Parameter{{Name: "name", In: "path"}},
+			Get:        &Operation{Parameters: []Parameter{{Name: "name", In: "path", Required: true}}},
+		},
+	}}
+	if issues := ValidateSpec(s); len(issues) != 0 {
+		t.Errorf("ValidateSpec = %v, want none", issues)
+	}
+}
+
+func TestValidateSpec_StatusCodes(t *testing.T) {
+	responses := map[string]Response{}
+	for _, status := range []string{"200", "404", "4XX", "default", "600", "20", "2xx", "abc", "099"} {
+		responses[status] = Response{Description: status}
+	}
+	s := &OpenAPISpec{Paths: map[string]PathItem{"/ping": {Get: &Operation{Responses: responses}}}}
+
+	var got []string
+	for _, issue := range ValidateSpec(s) {
+		got = append(got, issue.String())
+		if issue.Help == "" {
+			t.Errorf("%s: no help", issue)
+		}
+	}
+	want := []string{
+		`GET /ping: response "099" is not an HTTP status code, a range such as 4XX, or default`,
+		`GET /ping: response "20" is not an HTTP status code, a range such as 4XX, or default`,
+		`GET /ping: response "2xx" is not an HTTP status code, a range such as 4XX, or default`,
+		`GET /ping: response "600" is not an HTTP status code, a range such as 4XX, or default`,
+		`GET /ping: response "abc" is not an HTTP status code, a range such as 4XX, or default`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateSpec =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestValidateSpec_WebhooksAndCallbacks(t *testing.T) {
+	delivery := func(id string) PathItem {
+		return PathItem{Post: &Operation{
+			OperationID: id,
+			RequestBody: &RequestBody{Content: map[string]MediaType{
+				"application/json": {Schema: &Schema{Ref: "#/components/schemas/Event"}},
+			}},
+			Responses: map[string]Response{"200": {Description: "OK"}},
+		}}
+	}
+	s := &OpenAPISpec{
+		Paths: map[string]PathItem{"/orders": {Post: &Operation{
+			OperationID: "createOrder",
+			Responses:   map[string]Response{"201": {Description: "Created"}},
+			Callbacks: map[string]Callback{
+				"status": {"{$request.body#/callbackUrl}": delivery("orderStatus")},
+			},
+		}}},
+		Webhooks: map[string]PathItem{"invoicePaid": delivery("createOrder")},
+	}
+
+	var got []string
+	for _, issue := range ValidateSpec(s) {
+		got = append(got, issue.String())
+	}
+	// The callback's runtime expression is not a path template, so its
+	// braces are no path parameter.
+	want := []string{
+		`POST /orders callback status POST request body application/json: $ref "#/components/schemas/Event" does not resolve`,
+		`POST webhooks.invoicePaid: operationId "createOrder" is already used by POST /orders`,
+		`POST webhooks.invoicePaid request body application/json: $ref "#/components/schemas/Event" does not resolve`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateSpec =\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"go/ast"
+	"reflect"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+	"github.com/ehabterra/apispec/internal/typemodel"
+)
+
+// maxBoundStructDepth bounds the walk through embedded structs.
+const maxBoundStructDepth = 4
+
+// ExtractStructParams expands the struct a StructTag pattern binds — gin's
+// c.ShouldBindQuery(&q), fiber's c.QueryParser(&q) — into one parameter per
+// exported field. Each is named by the pattern's tag (the field name when
+// untagged; "-" skips the field), typed like the field's property in a body
+// schema, and carries the field's validate constraints and doc comment.
+// Struct-valued fields, which no query string or path segment can carry, are
+// skipped; embedded structs contribute their fields, as the binders flatten
+// them.
+func (p *ParamPatternMatcherImpl) ExtractStructParams(node TrackerNodeInterface, route *RouteInfo) []Parameter {
+	edge := node.GetEdge()
+	if route == nil || route.Metadata == nil || p.pattern.TypeArgIndex < 0 || len(edge.Args) <= p.pattern.TypeArgIndex {
+		return nil
+	}
+	arg := edge.Args[p.pattern.TypeArgIndex]
+	// A binder wrapped in a helper taking the target as a parameter resolves
+	// to the caller's argument, as for request bodies.
+	typeNode := node
+	if resolved, rnode := resolveArgThroughParams(arg, node); resolved != arg && rnode != nil {
+		arg, typeNode = resolved, rnode
+	}
+	goType := preprocessingBodyType(p.resolveTypeOrigin(arg, typeNode, p.contextProvider.GetArgumentInfo(arg)))
+	core := typemodel.Parse(goType).Core()
+	if core == nil {
+		return nil
+	}
+	typ := typeByName(core.Pkg, core.Name, route.Metadata)
+	if typ == nil {
+		return nil
+	}
+	return p.structParams(route, typ, 0)
+}
+
+func (p *ParamPatternMatcherImpl) structParams(route *RouteInfo, typ *metadata.Type, depth int) []Parameter {
+	meta := route.Metadata
+	if depth > maxBoundStructDepth || getStringFromPool(meta, typ.Kind) != "struct" {
+		return nil
+	}
+	pkgName := getStringFromPool(meta, typ.Pkg)
+
+	var params []Parameter
+	for _, embed := range typ.Embeds {
+		core := typemodel.Parse(strings.TrimPrefix(getStringFromPool(meta, embed), "*")).Core()
+		if core == nil {
+			continue
+		}
+		pkg := core.Pkg
+		if pkg == "" {
+			pkg = pkgName
+		}
+		if embedded := typeByName(pkg, core.Name, meta); embedded != nil {
+			params = append(params, p.structParams(route, embedded, depth+1)...)
+		}
+	}
+
+	for _, field := range typ.Fields {
+		goName := getStringFromPool(meta, field.Name)
+		if !ast.IsExported(goName) || field.NestedType != nil {
+			continue
+		}
+		tag := getStringFromPool(meta, field.Tag)
+		name, _, _ := strings.Cut(reflect.StructTag(tag).Get(p.pattern.StructTag), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = goName
+		}
+
+		schema := p.boundFieldSchema(route, getStringFromPool(meta, field.Type), pkgName)
+		if schema == nil {
+			continue
+		}
+		constraints := extractValidationConstraints(tag)
+		applyValidationConstraints(schema, constraints)
+
+		// Notes applyValidationConstraints leaves on the schema, such as
+		// required_if rules, document the parameter itself.
+		description := declDoc(meta, pkgName, field.Comments)
+		if schema.Description != "" {
+			description = appendConstraintNote(description, schema.Description)
+			schema.Description = ""
+		}
+		params = append(params, Parameter{
+			Name:        name,
+			In:          p.pattern.ParamIn,
+			Description: description,
+			Required:    p.pattern.ParamIn == "path" || (constraints != nil && constraints.Required),
+			Schema:      schema,
+		})
+	}
+	return params
+}
+
+// boundFieldSchema maps a bound field's Go type as generateSchemaFromType
+// maps a property — aliases and named enums resolved to their underlying
+// type, with the enum's constants — and returns a copy the caller may
+// constrain, or nil when the field is not a scalar or an array of scalars.
+func (p *ParamPatternMatcherImpl) boundFieldSchema(route *RouteInfo, fieldType, pkgName string) *Schema {
+	meta := route.Metadata
+	original := strings.TrimPrefix(fieldType, "*")
+	resolvedType := original
+	if !strings.HasPrefix(resolvedType, "[]") && !strings.Contains(resolvedType, "map[") {
+		if underlying := resolveUnderlyingType(resolvedType, meta); underlying != "" {
+			resolvedType = underlying
+		}
+	}
+	if !metadata.IsPrimitiveType(resolvedType) && !strings.Contains(resolvedType, ".") {
+		elem := strings.TrimPrefix(strings.TrimPrefix(resolvedType, "[]"), "*")
+		resolvedType = strings.TrimSuffix(resolvedType, elem) + pkgName + "." + elem
+	}
+
+	// Mapping a struct would register it as a component no parameter uses.
+	if core := typemodel.Parse(resolvedType).Core(); core != nil {
+		if t := typeByName(core.Pkg, core.Name, meta); t != nil && getStringFromPool(meta, t.Kind) == "struct" {
+			return nil
+		}
+	}
+	mapped, _ := mapGoTypeToOpenAPISchema(route.UsedTypes, resolvedType, meta, p.cfg, nil)
+	if mapped == nil || !scalarParamSchema(mapped) {
+		return nil
+	}
+	schema := *mapped
+	if schema.Items != nil {
+		items := *schema.Items
+		schema.Items = &items
+	}
+
+	if len(schema.Enum) == 0 && !metadata.IsPrimitiveType(original) {
+		if enumValues := detectEnumFromConstants(original, pkgName, meta); len(enumValues) > 0 {
+			if schema.Type == "array" {
+				schema.Items.Enum = enumValues
+			} else {
+				schema.Enum = enumValues
+			}
+		}
+	}
+	return &schema
+}
+
+// scalarParamSchema reports whether s is a value a query string or path
+// segment can carry: a primitive, or an array of primitives.
+func scalarParamSchema(s *Schema) bool {
+	if s.Type == "array" {
+		return s.Items != nil && s.Items.Ref == "" && s.Items.Type != "" && s.Items.Type != "object" && s.Items.Type != "array"
+	}
+	return s.Ref == "" && s.Type != "" && s.Type != "object"
+}
@@ -0,0 +1,687 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ehabterra/apispec/internal/diag"
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// swaggoAnnotations holds the swaggo/swag annotations of a handler's doc
+// comment beyond @Summary and @Description, which swaggoDoc reads:
+//
+//	// @ID           getAccount
+//	// @Tags         accounts
+//	// @Accept       json
+//	// @Produce      json
+//	// @Param        id   path  int  true  "Account ID"  minimum(1)
+//	// @Success      200  {object}  model.Account
+//	// @Failure      400,404  {object}  httputil.HTTPError  "Bad ID"
+//	// @Security     ApiKeyAuth
+//	// @Router       /accounts/{id} [get]
+//
+// They merge over what static analysis inferred, annotations winning on
+// conflict (see applyAnnotations); @Router adds the routes of handlers whose
+// registration static analysis did not find (see annotatedRoutes).
+type swaggoAnnotations struct {
+	ID         string
+	Tags       []string
+	Accept     []string
+	Produce    []string
+	Params     []swaggoParam
+	Responses  []swaggoResponse
+	Routes     []swaggoRoute
+	Security   []SecurityRequirement // nil unless @Security is present
+	Deprecated bool
+}
+
+// swaggoParam is one @Param line: name, location, type, required, an optional
+// quoted description and attributes such as Enums(a, b) or minimum(1).
+type swaggoParam struct {
+	Name, In, Type, Description string
+	Required                    bool
+	Attrs                       map[string]string // lower-cased attribute name -> argument
+}
+
+// swaggoResponse is one status of a @Success, @Failure or @Response line.
+// Status is -1 for "default"; Kind is the braced {object}/{array}/{string}…
+// and Type the Go or primitive type after it, both empty for a bodyless line.
+type swaggoResponse struct {
+	Status      int
+	Kind, Type  string
+	Description string
+}
+
+type swaggoRoute struct{ Path, Method string }
+
+// swaggoMIMETypes expands swaggo's @Accept/@Produce aliases.
+var swaggoMIMETypes = map[string]string{
+	"json":                  "application/json",
+	"xml":                   "application/xml",
+	"plain":                 "text/plain",
+	"html":                  "text/html",
+	"mpfd":                  "multipart/form-data",
+	"x-www-form-urlencoded": "application/x-www-form-urlencoded",
+	"json-api":              "application/vnd.api+json",
+	"json-stream":           "application/x-json-stream",
+	"octet-stream":          "application/octet-stream",
+	"png":                   "image/png",
+	"jpeg":                  "image/jpeg",
+	"gif":                   "image/gif",
+}
+
+// swaggoParamIn maps a @Param location to a Parameter.In; formData becomes a
+// form field (or an upload), which the mapper folds into the request body.
+var swaggoParamIn = map[string]string{
+	"query": "query", "path": "path", "header": "header", "cookie": "cookie",
+	"formdata": paramInForm, "body": "body",
+}
+
+// parseSwaggoAnnotations reads the annotations of doc, or returns nil when it
+// carries none of those swaggoAnnotations holds. Lines it cannot parse are
+// returned as problems rather than guessed at.
+func parseSwaggoAnnotations(doc string) (a *swaggoAnnotations, problems []string) {
+	a = &swaggoAnnotations{}
+	found := false
+	for _, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		name, rest := line, ""
+		if i := strings.IndexFunc(line, unicode.IsSpace); i >= 0 {
+			name, rest = line[:i], strings.TrimSpace(line[i:])
+		}
+		switch strings.ToLower(name) {
+		case "@id":
+			a.ID = rest
+		case "@tags":
+			a.Tags = append(a.Tags, splitList(rest)...)
+		case "@accept":
+			a.Accept = append(a.Accept, swaggoMIMEs(rest)...)
+		case "@produce":
+			a.Produce = append(a.Produce, swaggoMIMEs(rest)...)
+		case "@param":
+			p, err := parseSwaggoParam(rest)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("@Param %s: %v", rest, err))
+				continue
+			}
+			a.Params = append(a.Params, p)
+		case "@success", "@failure", "@response":
+			rs, err := parseSwaggoResponse(rest)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s %s: %v", name, rest, err))
+				continue
+			}
+			a.Responses = append(a.Responses, rs...)
+		case "@router":
+			r, err := parseSwaggoRoute(rest)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("@Router %s: %v", rest, err))
+				continue
+			}
+			a.Routes = append(a.Routes, r)
+		case "@security":
+			if a.Security == nil {
+				a.Security = []SecurityRequirement{}
+			}
+			a.Security = append(a.Security, parseSwaggoSecurity(rest)...)
+		case "@deprecated":
+			a.Deprecated = true
+		default:
+			continue
+		}
+		found = true
+	}
+	if !found {
+		return nil, problems
+	}
+	return a, problems
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		out = append(out, f)
+	}
+	return out
+}
+
+func swaggoMIMEs(s string) []string {
+	var out []string
+	for _, m := range splitList(s) {
+		if full, ok := swaggoMIMETypes[strings.ToLower(m)]; ok {
+			m = full
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// swaggoFields splits an annotation into fields on spaces, keeping a quoted
+// "description", a {braced} kind and an attribute's (arguments) whole.
+func swaggoFields(s string) ([]string, error) {
+	var out []string
+	var cur strings.Builder
+	var closer byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case closer != 0:
+			cur.WriteByte(c)
+			if c == closer {
+				closer = 0
+			}
+		case c == '"':
+			closer = '"'
+			cur.WriteByte(c)
+		case c == '(':
+			closer = ')'
+			cur.WriteByte(c)
+		case c == '{':
+			closer = '}'
+			cur.WriteByte(c)
+		case c == ' ' || c == '\t':
+			if cur.Len() > 0 {
+				out = append(out, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	if closer != 0 {
+		return nil, fmt.Errorf("unterminated %q", closer)
+	}
+	if cur.Len() > 0 {
+		out = append(out, cur.String())
+	}
+	return out, nil
+}
+
+var swaggoAttrRe = regexp.MustCompile(`^([A-Za-z]+)\((.*)\)$`)
+
+func parseSwaggoParam(s string) (swaggoParam, error) {
+	fields, err := swaggoFields(s)
+	if err != nil {
+		return swaggoParam{}, err
+	}
+	if len(fields) < 4 {
+		return swaggoParam{}, fmt.Errorf("want name, location, type and required")
+	}
+	in, ok := swaggoParamIn[strings.ToLower(fields[1])]
+	if !ok {
+		return swaggoParam{}, fmt.Errorf("unknown location %q", fields[1])
+	}
+	required, err := strconv.ParseBool(fields[3])
+	if err != nil {
+		return swaggoParam{}, fmt.Errorf("required is %q, want true or false", fields[3])
+	}
+	p := swaggoParam{Name: fields[0], In: in, Type: fields[2], Required: required}
+	for _, f := range fields[4:] {
+		if strings.HasPrefix(f, `"`) {
+			p.Description = strings.Trim(f, `"`)
+			continue
+		}
+		if m := swaggoAttrRe.FindStringSubmatch(f); m != nil {
+			if p.Attrs == nil {
+				p.Attrs = map[string]string{}
+			}
+			p.Attrs[strings.ToLower(m[1])] = m[2]
+		}
+	}
+	if p.In == paramInForm && strings.EqualFold(p.Type, "file") {
+		p.In = paramInFile
+	}
+	return p, nil
+}
+
+func parseSwaggoResponse(s string) ([]swaggoResponse, error) {
+	fields, err := swaggoFields(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("missing status")
+	}
+	var tmpl swaggoResponse
+	rest := fields[1:]
+	if len(rest) > 0 && strings.HasPrefix(rest[0], "{") {
+		tmpl.Kind = strings.ToLower(strings.Trim(rest[0], "{}"))
+		if len(rest) < 2 {
+			return nil, fmt.Errorf("{%s} names no type", tmpl.Kind)
+		}
+		// Composition (Result{data=Account}) has no Go type to map; the
+		// outer type stands for it.
+		tmpl.Type, _, _ = strings.Cut(rest[1], "{")
+		rest = rest[2:]
+	}
+	if len(rest) > 0 && strings.HasPrefix(rest[0], `"`) {
+		tmpl.Description = strings.Trim(rest[0], `"`)
+	}
+	var out []swaggoResponse
+	for _, code := range strings.Split(fields[0], ",") {
+		r := tmpl
+		if strings.EqualFold(code, "default") {
+			r.Status = -1
+		} else if r.Status, err = strconv.Atoi(code); err != nil || r.Status < 100 || r.Status > 599 {
+			return nil, fmt.Errorf("status %q is not an HTTP status", code)
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+func parseSwaggoRoute(s string) (swaggoRoute, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 || !strings.HasPrefix(fields[1], "[") || !strings.HasSuffix(fields[1], "]") {
+		return swaggoRoute{}, fmt.Errorf("want /path [method]")
+	}
+	method := strings.ToUpper(strings.Trim(fields[1], "[]"))
+	if !slices.Contains([]string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}, method) {
+		return swaggoRoute{}, fmt.Errorf("unknown method %q", method)
+	}
+	return swaggoRoute{Path: fields[0], Method: method}, nil
+}
+
+// parseSwaggoSecurity reads `A || B` as alternatives and `A && B[scope]` as
+// schemes required together.
+func parseSwaggoSecurity(s string) []SecurityRequirement {
+	var out []SecurityRequirement
+	for _, alt := range strings.Split(s, "||") {
+		req := SecurityRequirement{}
+		for _, part := range strings.Split(alt, "&&") {
+			part = strings.TrimSpace(part)
+			name, scopes, _ := strings.Cut(part, "[")
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			list := []string{}
+			for _, sc := range strings.Split(strings.TrimSuffix(scopes, "]"), ",") {
+				if sc = strings.TrimSpace(sc); sc != "" {
+					list = append(list, sc)
+				}
+			}
+			req[name] = list
+		}
+		if len(req) > 0 {
+			out = append(out, req)
+		}
+	}
+	return out
+}
+
+// handlerAnnotations parses the annotations on route's handler, reporting
+// malformed lines and unresolvable types once per handler.
+func (e *Extractor) handlerAnnotations(route *RouteInfo) (*swaggoAnnotations, string) {
+	key := route.Package + "|" + route.Function
+	if a, ok := e.annotations[key]; ok {
+		return a, e.annotationPkgs[key]
+	}
+	var handlerMethods []string
+	if e.cfg != nil {
+		handlerMethods = e.cfg.Framework.HandlerInterfaceMethods
+	}
+	doc, pkg := handlerComments(route, handlerMethods...)
+	a, problems := parseSwaggoAnnotations(doc)
+	for _, p := range problems {
+		e.reportAnnotation(route, p)
+	}
+	if e.annotations == nil {
+		e.annotations, e.annotationPkgs = map[string]*swaggoAnnotations{}, map[string]string{}
+	}
+	e.annotations[key], e.annotationPkgs[key] = a, pkg
+	return a, pkg
+}
+
+func (e *Extractor) reportAnnotation(route *RouteInfo, problem string) {
+	diag.Report(diag.Diagnostic{
+		Severity: diag.Warning,
+		Category: "annotations",
+		Message:  fmt.Sprintf("handler %s: %s", strings.ReplaceAll(route.Function, TypeSep, "."), problem),
+		Help:     "the annotation is ignored; see https://github.com/swaggo/swag#declarative-comments-format",
+	})
+}
+
+// applyAnnotations merges the swaggo annotations on route's handler over what
+// static analysis extracted: annotated parameters replace inferred ones of the
+// same name and location, an annotated body or status replaces the inferred
+// one, and tags, operationId, security and deprecation are set outright.
+func (e *Extractor) applyAnnotations(route *RouteInfo) {
+	a, pkg := e.handlerAnnotations(route)
+	if a == nil {
+		return
+	}
+	if a.ID != "" {
+		route.OperationID = a.ID
+	}
+	if len(a.Tags) > 0 {
+		route.Tags = a.Tags
+	}
+	if a.Security != nil {
+		route.Security = a.Security
+	}
+	route.Deprecated = route.Deprecated || a.Deprecated
+
+	for _, p := range a.Params {
+		if p.In == "body" {
+			e.applyAnnotatedBody(route, pkg, p, a.Accept)
+			continue
+		}
+		param := Parameter{
+			Name:        p.Name,
+			In:          p.In,
+			Description: p.Description,
+			Required:    p.Required || p.In == "path",
+			Schema:      e.annotationSchema(route, pkg, p.Type),
+		}
+		applySwaggoAttrs(&param, p.Attrs)
+		route.Params = slices.DeleteFunc(route.Params, func(q Parameter) bool {
+			return q.Name == p.Name && (q.In == p.In || q.In == "path" && p.In == "path")
+		})
+		route.Params = append(route.Params, param)
+	}
+	if len(a.Accept) > 0 && route.Request != nil {
+		route.Request.ContentType = a.Accept[0]
+	}
+
+	contentType := e.cfg.Defaults.ResponseContentType
+	if len(a.Produce) > 0 {
+		contentType = a.Produce[0]
+	}
+	for _, r := range a.Responses {
+		resp := &ResponseInfo{StatusCode: r.Status, ContentType: contentType, Description: r.Description}
+		if r.Kind != "" {
+			typ := r.Type
+			if r.Kind == "array" {
+				typ = "[]" + strings.TrimPrefix(typ, "[]")
+			}
+			resp.BodyType = e.annotationGoType(route, pkg, typ)
+			resp.Schema = e.annotationSchema(route, pkg, typ)
+			if resp.Schema == nil {
+				continue // reported by annotationSchema
+			}
+		}
+		if existing := route.Response[strconv.Itoa(r.Status)]; existing != nil && resp.Schema == nil && r.Kind == "" {
+			// A bare `@Failure 404 "Not found"` documents the status the
+			// handler already writes; keep the inferred body.
+			existing.Description = r.Description
+			continue
+		}
+		route.Response[strconv.Itoa(r.Status)] = resp
+	}
+	// Annotated responses spell out the statuses, so the placeholder for a
+	// status analysis could not determine goes unless it was annotated too.
+	if len(a.Responses) > 0 && !slices.ContainsFunc(a.Responses, func(r swaggoResponse) bool { return r.Status == -1 }) {
+		delete(route.Response, "-1")
+	}
+}
+
+func (e *Extractor) applyAnnotatedBody(route *RouteInfo, pkg string, p swaggoParam, accept []string) {
+	contentType := e.cfg.Defaults.RequestContentType
+	if route.Request != nil && route.Request.ContentType != "" {
+		contentType = route.Request.ContentType
+	}
+	if len(accept) > 0 {
+		contentType = accept[0]
+	}
+	schema := e.annotationSchema(route, pkg, p.Type)
+	if schema == nil {
+		if route.Request != nil {
+			route.Request.Optional = !p.Required
+		}
+		return
+	}
+	route.Request = &RequestInfo{
+		ContentType: contentType,
+		BodyType:    e.annotationGoType(route, pkg, p.Type),
+		Schema:      schema,
+		Optional:    !p.Required,
+	}
+}
+
+// applySwaggoAttrs carries @Param attributes onto the parameter's schema.
+func applySwaggoAttrs(param *Parameter, attrs map[string]string) {
+	if len(attrs) == 0 || param.Schema == nil {
+		return
+	}
+	s := cloneSchema(param.Schema)
+	target := s
+	if s.Type == "array" && s.Items != nil {
+		target = s.Items
+	}
+	value := func(v string) any { return parseSwaggoValue(strings.TrimSpace(v), target.Type) }
+	for name, arg := range attrs {
+		switch name {
+		case "enums":
+			target.Enum = nil
+			for _, v := range strings.Split(arg, ",") {
+				target.Enum = append(target.Enum, value(v))
+			}
+		case "default":
+			target.Default = value(arg)
+		case "example":
+			param.Example = value(arg)
+		case "format":
+			target.Format = arg
+		case "minimum":
+			if v, err := strconv.ParseFloat(arg, 64); err == nil {
+				target.Minimum = &v
+			}
+		case "maximum":
+			if v, err := strconv.ParseFloat(arg, 64); err == nil {
+				target.Maximum = &v
+			}
+		case "minlength":
+			target.MinLength, _ = strconv.Atoi(arg)
+		case "maxlength":
+			target.MaxLength, _ = strconv.Atoi(arg)
+		}
+	}
+	param.Schema = s
+}
+
+func parseSwaggoValue(v, typ string) any {
+	switch typ {
+	case "integer":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return v
+}
+
+// swaggoPrimitives maps swaggo's primitive type names to schemas.
+var swaggoPrimitives = map[string]Schema{
+	"string":  {Type: "string"},
+	"integer": {Type: "integer"},
+	"int":     {Type: "integer"},
+	"number":  {Type: "number"},
+	"float":   {Type: "number"},
+	"boolean": {Type: "boolean"},
+	"bool":    {Type: "boolean"},
+	"object":  {Type: "object"},
+	"file":    {Type: "string", Format: "binary"},
+}
+
+// annotationSchema maps an annotation's type — a swaggo primitive, a Go
+// type, or a model named as in swaggo (model.Account, []model.Account) — to
+// a schema, or reports it and returns nil when it names no known type.
+func (e *Extractor) annotationSchema(route *RouteInfo, pkg, typ string) *Schema {
+	if elem, ok := strings.CutPrefix(typ, "[]"); ok {
+		items := e.annotationSchema(route, pkg, elem)
+		if items == nil {
+			return nil
+		}
+		return &Schema{Type: "array", Items: items}
+	}
+	if s, ok := swaggoPrimitives[strings.ToLower(typ)]; ok {
+		return &s
+	}
+	goType := e.annotationGoType(route, pkg, typ)
+	if goType == "" {
+		e.reportAnnotation(route, fmt.Sprintf("type %s is not declared in the analyzed packages", typ))
+		return nil
+	}
+	schema, _ := mapGoTypeToOpenAPISchema(route.UsedTypes, goType, route.Metadata, e.cfg, nil)
+	return schema
+}
+
+// annotationGoType resolves a type named in an annotation to its Go type:
+// a builtin as is, Name in the handler's package pkg, and model.Name in the
+// package whose name is model — the import swaggo resolves it through.
+func (e *Extractor) annotationGoType(route *RouteInfo, pkg, typ string) string {
+	if pkg == "" {
+		pkg = route.Package
+	}
+	return namedGoType(route.Metadata, pkg, typ)
+}
+
+// namedGoType resolves a type named in config or an annotation to its Go
+// type, as annotationGoType does, Name being looked up in pkg.
+func namedGoType(meta *metadata.Metadata, pkg, typ string) string {
+	if elem, ok := strings.CutPrefix(typ, "[]"); ok {
+		if goType := namedGoType(meta, pkg, elem); goType != "" {
+			return "[]" + goType
+		}
+		return ""
+	}
+	if _, ok := swaggoPrimitives[strings.ToLower(typ)]; ok || metadata.IsPrimitiveType(typ) {
+		return typ
+	}
+	if meta == nil {
+		return ""
+	}
+	qualifier, name := "", typ
+	if i := strings.LastIndexByte(typ, '.'); i >= 0 {
+		qualifier, name = typ[:i], typ[i+1:]
+	}
+	if qualifier == "" {
+		if typeInPackage(meta.Packages[pkg], name) != nil {
+			return pkg + "." + name
+		}
+		return ""
+	}
+	if typeInPackage(meta.Packages[qualifier], name) != nil {
+		return typ
+	}
+	for _, p := range meta.SortedPackageNames() {
+		if packageName(p) == qualifier && typeInPackage(meta.Packages[p], name) != nil {
+			return p + "." + name
+		}
+	}
+	return ""
+}
+
+// packageName guesses the name a package is imported by: its last path
+// element, or the one before a /vN major-version suffix.
+func packageName(pkgPath string) string {
+	base := path.Base(pkgPath)
+	if len(base) > 1 && base[0] == 'v' && strings.Trim(base[1:], "0123456789") == "" {
+		base = path.Base(path.Dir(pkgPath))
+	}
+	return base
+}
+
+// annotatedRoutes returns a route for every @Router annotation whose handler
+// static analysis found no registration for, so handlers wired up in ways it
+// cannot follow still document their operation. A @Router on a handler that
+// is registered, or naming a path and method already extracted, is ignored:
+// the code decides where a handler is mounted.
+func (e *Extractor) annotatedRoutes(routes []*RouteInfo) []*RouteInfo {
+	meta := e.tree.GetMetadata()
+	if meta == nil {
+		return nil
+	}
+	routed := map[string]bool{}
+	for _, r := range routes {
+		routed[handlerDeclKey(r)] = true
+		routed[r.Method+" "+r.OpenAPIPath()] = true
+	}
+
+	var out []*RouteInfo
+	add := func(pkg, name string, comments int) {
+		doc := getStringFromPool(meta, comments)
+		if !strings.Contains(doc, "@Router") || routed[pkg+"."+name] {
+			return
+		}
+		probe := &RouteInfo{Package: pkg, Function: pkg + "." + name, Metadata: meta}
+		a, _ := e.handlerAnnotations(probe)
+		if a == nil {
+			return
+		}
+		for _, r := range a.Routes {
+			if routed[r.Method+" "+convertPathToOpenAPI(r.Path)] {
+				continue
+			}
+			routed[r.Method+" "+convertPathToOpenAPI(r.Path)] = true
+			route := NewRouteInfo()
+			route.Path, route.Method, route.MethodExplicit = r.Path, r.Method, true
+			route.Package, route.Function, route.Handler = pkg, pkg+"."+name, name
+			route.Metadata = meta
+			e.applyAnnotations(route)
+			e.overrideApplier.ApplyOverrides(route)
+			out = append(out, route)
+		}
+	}
+	for _, pkg := range meta.SortedPackageNames() {
+		files := meta.Packages[pkg].Files
+		for _, file := range slices.Sorted(maps.Keys(files)) {
+			f := files[file]
+			for _, fn := range slices.Sorted(maps.Keys(f.Functions)) {
+				add(pkg, fn, f.Functions[fn].Comments)
+			}
+			for _, tn := range slices.Sorted(maps.Keys(f.Types)) {
+				for _, m := range f.Types[tn].Methods {
+					add(pkg, tn+"."+getStringFromPool(meta, m.Name), m.Comments)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// handlerDeclKey names the declaration serving route — "pkg.Func" or
+// "pkg.Recv.Method" — as annotatedRoutes keys handlers.
+func handlerDeclKey(route *RouteInfo) string {
+	name := strings.ReplaceAll(route.Function, TypeSep, ".")
+	if route.Package != "" {
+		for strings.HasPrefix(name, route.Package+".") {
+			name = name[len(route.Package)+1:]
+		}
+	}
+	if i := strings.LastIndexByte(name, '.'); i >= 0 && route.Metadata != nil {
+		name = receiverTypeName(route.Metadata, route.Package, name[:i]) + name[i:]
+	}
+	return route.Package + "." + name
+}
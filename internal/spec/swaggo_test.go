@@ -0,0 +1,103 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSwaggoAnnotations(t *testing.T) {
+	doc := "getUser godoc\n" +
+		"\t@Summary\tShow a user\n" +
+		"\t@ID\tgetUser\n" +
+		"\t@Tags\tusers, admin\n" +
+		"\t@Accept\tjson\n" +
+		"\t@Produce\tjson,xml\n" +
+		"\t@Param\tid\tpath\tint\ttrue\t\"User ID\"\tminimum(1)\n" +
+		"\t@Param\tq\tquery\tstring\tfalse\t\"Search, by name\"\tEnums(a, b)\tdefault(a)\n" +
+		"\t@Success\t200\t{array}\tmodel.User\n" +
+		"\t@Failure\t400,404\t{object}\tmodel.Error{detail=string}\t\"Bad\"\n" +
+		"\t@Failure\tdefault\t\"Unexpected\"\n" +
+		"\t@Security\tApiKey || OAuth2[read, write] && Basic\n" +
+		"\t@Deprecated\n" +
+		"\t@Router\t/users/{id} [get]\n"
+
+	a, problems := parseSwaggoAnnotations(doc)
+	if len(problems) > 0 {
+		t.Fatalf("problems: %v", problems)
+	}
+	if a.ID != "getUser" || !reflect.DeepEqual(a.Tags, []string{"users", "admin"}) || !a.Deprecated {
+		t.Errorf("id/tags/deprecated = %q %v %v", a.ID, a.Tags, a.Deprecated)
+	}
+	if !reflect.DeepEqual(a.Accept, []string{"application/json"}) ||
+		!reflect.DeepEqual(a.Produce, []string{"application/json", "application/xml"}) {
+		t.Errorf("accept/produce = %v %v", a.Accept, a.Produce)
+	}
+
+	wantParams := []swaggoParam{
+		{Name: "id", In: "path", Type: "int", Required: true, Description: "User ID", Attrs: map[string]string{"minimum": "1"}},
+		{Name: "q", In: "query", Type: "string", Description: "Search, by name", Attrs: map[string]string{"enums": "a, b", "default": "a"}},
+	}
+	if !reflect.DeepEqual(a.Params, wantParams) {
+		t.Errorf("params = %+v\nwant %+v", a.Params, wantParams)
+	}
+
+	wantResponses := []swaggoResponse{
+		{Status: 200, Kind: "array", Type: "model.User"},
+		{Status: 400, Kind: "object", Type: "model.Error", Description: "Bad"},
+		{Status: 404, Kind: "object", Type: "model.Error", Description: "Bad"},
+		{Status: -1, Description: "Unexpected"},
+	}
+	if !reflect.DeepEqual(a.Responses, wantResponses) {
+		t.Errorf("responses = %+v\nwant %+v", a.Responses, wantResponses)
+	}
+
+	wantSecurity := []SecurityRequirement{
+		{"ApiKey": {}},
+		{"OAuth2": {"read", "write"}, "Basic": {}},
+	}
+	if !reflect.DeepEqual(a.Security, wantSecurity) {
+		t.Errorf("security = %v, want %v", a.Security, wantSecurity)
+	}
+	if !reflect.DeepEqual(a.Routes, []swaggoRoute{{Path: "/users/{id}", Method: "GET"}}) {
+		t.Errorf("routes = %+v", a.Routes)
+	}
+}
+
+func TestParseSwaggoAnnotations_NoneOrBroken(t *testing.T) {
+	if a, problems := parseSwaggoAnnotations("Plain prose, @mentions aside.\nSecond line."); a != nil || len(problems) > 0 {
+		t.Errorf("prose parsed as annotations: %+v %v", a, problems)
+	}
+
+	doc := "@Param limit query int maybe \"Page size\"\n" +
+		"@Success ok {object} model.User\n" +
+		"@Router /users [fetch]\n" +
+		"@Tags users\n"
+	a, problems := parseSwaggoAnnotations(doc)
+	if len(problems) != 3 {
+		t.Errorf("problems = %v, want one per malformed line", problems)
+	}
+	if a == nil || len(a.Params)+len(a.Responses)+len(a.Routes) != 0 || len(a.Tags) != 1 {
+		t.Errorf("malformed lines should be skipped and the rest kept: %+v", a)
+	}
+}
+
+func TestParseSwaggoAnnotations_EmptySecurity(t *testing.T) {
+	a, _ := parseSwaggoAnnotations("@Security\n")
+	if a == nil || a.Security == nil || len(a.Security) != 0 {
+		t.Errorf("bare @Security should clear security, got %+v", a)
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ehabterra/apispec/internal/diag"
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// TimeLayoutMismatch records a type whose JSON marshaler formats a time with
+// a layout the schema does not describe: the spec says date-time while the
+// payload carries a date, or an RFC 1123 string, or the schema is not even a
+// string.
+type TimeLayoutMismatch struct {
+	Type     string // Go type owning the marshaler
+	Method   string // MarshalJSON or MarshalText
+	Field    string // JSON property formatted; empty when the value itself is
+	Layout   string // the layout passed to Format
+	Format   string // the OpenAPI format Layout writes; empty when none does
+	Declared string // what the schema declares: a format, "string" or the type
+	Pos      string // the Format call ("file:line:col")
+}
+
+// layoutFormat returns the OpenAPI string format of values written with a
+// Go time layout, or "" for layouts no format describes.
+func layoutFormat(layout string) string {
+	switch layout {
+	case time.RFC3339, time.RFC3339Nano:
+		return "date-time"
+	case time.DateOnly:
+		return "date"
+	case time.TimeOnly:
+		return "time"
+	}
+	return ""
+}
+
+// auditTimeLayouts checks the Format calls of the module's MarshalJSON and
+// MarshalText methods against the emitted schemas. A method of a type over
+// time.Time (a defined type, or a struct embedding it) formats the value
+// itself; one of a struct with time fields formats the field it selects
+// (b.CheckIn.Format). Layouts that are not constants, and fields that cannot
+// be told apart, are skipped. Results are sorted by type and field.
+func auditTimeLayouts(meta *metadata.Metadata, cfg *APISpecConfig, components *Components) []TimeLayoutMismatch {
+	if meta == nil || components == nil || len(components.Schemas) == 0 {
+		return nil
+	}
+	var out []TimeLayoutMismatch
+	seen := map[string]bool{}
+	for i := range meta.CallGraph {
+		edge := &meta.CallGraph[i]
+		method := getString(meta, edge.Caller.Name)
+		if method != "MarshalJSON" && method != "MarshalText" {
+			continue
+		}
+		pkg := getString(meta, edge.Caller.Pkg)
+		typeName := strings.TrimPrefix(getString(meta, edge.Caller.RecvType), "*")
+		typ := typeInPackage(meta.Packages[pkg], typeName)
+		if typ == nil {
+			continue
+		}
+		layout, ok := formatLayout(meta, edge, pkg, typeName, typ)
+		if !ok {
+			continue
+		}
+
+		schema := components.Schemas[schemaComponentNameReplacer.Replace(pkg+TypeSep+typeName)]
+		field := ""
+		if getString(meta, typ.Kind) == "struct" && !embedsTime(meta, typ) {
+			goField := formattedField(meta, edge)
+			if goField == "" {
+				continue
+			}
+			field = jsonPropertyOf(meta, cfg, typ, goField)
+			if field == "" || schema == nil {
+				continue
+			}
+			schema = schema.Properties[field]
+		}
+		if schema == nil {
+			continue
+		}
+		if ref, ok := strings.CutPrefix(schema.Ref, refComponentsSchemasPrefix); ok {
+			schema = components.Schemas[ref]
+			if schema == nil {
+				continue
+			}
+		}
+
+		format := layoutFormat(layout)
+		if schema.Type == "string" && schema.Format == format {
+			continue
+		}
+		key := typeName + "." + field
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		declared := schema.Format
+		if schema.Type != "string" {
+			declared = cmp.Or(schema.Type, "no type")
+		} else if declared == "" {
+			declared = "string"
+		}
+		out = append(out, TimeLayoutMismatch{
+			Type:     typeName,
+			Method:   method,
+			Field:    field,
+			Layout:   layout,
+			Format:   format,
+			Declared: declared,
+			Pos:      getString(meta, edge.Position),
+		})
+	}
+	slices.SortFunc(out, func(a, b TimeLayoutMismatch) int {
+		return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Field, b.Field))
+	})
+	return out
+}
+
+// formatLayout returns the constant layout of a Format (or AppendFormat)
+// call on a time: a time.Time method, or one promoted into typeName from an
+// embedded time.Time.
+func formatLayout(meta *metadata.Metadata, edge *metadata.CallGraphEdge, pkg, typeName string, typ *metadata.Type) (string, bool) {
+	idx := 0
+	switch getString(meta, edge.Callee.Name) {
+	case "Format":
+	case "AppendFormat":
+		idx = 1
+	default:
+		return "", false
+	}
+	calleePkg := getString(meta, edge.Callee.Pkg)
+	recv := strings.TrimPrefix(getString(meta, edge.Callee.RecvType), "*")
+	isTime := calleePkg == "time" && recv == "Time"
+	promoted := calleePkg == pkg && recv == typeName && embedsTime(meta, typ)
+	if (!isTime && !promoted) || len(edge.Args) <= idx {
+		return "", false
+	}
+	layout, err := strconv.Unquote(edge.Args[idx].GetValue())
+	if err != nil {
+		return "", false
+	}
+	return layout, true
+}
+
+// embedsTime reports whether a struct type embeds time.Time, so its
+// marshaler formats the time it is.
+func embedsTime(meta *metadata.Metadata, typ *metadata.Type) bool {
+	for _, e := range typ.Embeds {
+		if strings.TrimPrefix(getString(meta, e), "*") == "time.Time" {
+			return true
+		}
+	}
+	return false
+}
+
+// formattedField names the field a Format edge is called on (b.CheckIn in
+// b.CheckIn.Format(…)). The edge itself does not carry its receiver
+// expression, so the call is found among the arguments of the method's other
+// calls by position; "" when it is not there or not a field selection.
+func formattedField(meta *metadata.Metadata, edge *metadata.CallGraphEdge) string {
+	pos := getString(meta, edge.Position)
+	caller := edge.Caller.BaseID()
+	for i := range meta.CallGraph {
+		other := &meta.CallGraph[i]
+		if other == edge || other.Caller.BaseID() != caller {
+			continue
+		}
+		for _, arg := range other.Args {
+			if name := fieldFormattedAt(arg, pos); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+func fieldFormattedAt(arg *metadata.CallArgument, pos string) string {
+	if arg == nil {
+		return ""
+	}
+	if arg.GetKind() == metadata.KindCall && arg.GetPosition() == pos && arg.Fun != nil &&
+		arg.Fun.GetKind() == metadata.KindSelector && arg.Fun.Sel != nil && strings.HasSuffix(arg.Fun.Sel.GetName(), "Format") {
+		x := arg.Fun.X
+		if x != nil && x.GetKind() == metadata.KindSelector && x.X != nil && x.X.GetKind() == metadata.KindIdent && x.Sel != nil {
+			return x.Sel.GetName()
+		}
+		return ""
+	}
+	for _, sub := range []*metadata.CallArgument{arg.X, arg.Fun} {
+		if name := fieldFormattedAt(sub, pos); name != "" {
+			return name
+		}
+	}
+	for _, sub := range arg.Args {
+		if name := fieldFormattedAt(sub, pos); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// jsonPropertyOf returns the property a struct field is emitted under, or ""
+// when the field is not serialized.
+func jsonPropertyOf(meta *metadata.Metadata, cfg *APISpecConfig, typ *metadata.Type, goField string) string {
+	for _, field := range typ.Fields {
+		if getString(meta, field.Name) != goField {
+			continue
+		}
+		tag := getString(meta, field.Tag)
+		if jsonFieldOmitted(tag) || !ast.IsExported(goField) {
+			return ""
+		}
+		return cmp.Or(propertyName(cfg, tag), goField)
+	}
+	return ""
+}
+
+func (m TimeLayoutMismatch) diagnostic() diag.Diagnostic {
+	subject, declares := m.Type, m.Type
+	if m.Field != "" {
+		subject, declares = m.Field, m.Type+"."+m.Field
+	}
+	writes := "no OpenAPI format"
+	if m.Format != "" {
+		writes = m.Format
+	}
+	var help string
+	switch {
+	case m.Field != "" && m.Format != "":
+		help = fmt.Sprintf("declare it with a validate:\"datetime=%s\" tag", m.Layout)
+	case m.Field != "":
+		help = "format it with time.RFC3339"
+	case m.Format != "":
+		help = fmt.Sprintf("map %s with typeMapping to {type: string, format: %s}", m.Type, m.Format)
+	default:
+		help = fmt.Sprintf("format with time.RFC3339, or map %s with typeMapping to {type: string}", m.Type)
+	}
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Category: "time-format",
+		Message: fmt.Sprintf("%s.%s writes %s with layout %q (%s), but the schema of %s declares %s",
+			m.Type, m.Method, subject, m.Layout, writes, declares, m.Declared),
+		Pos:  diag.ParsePosition(m.Pos),
+		Help: help,
+	}
+}
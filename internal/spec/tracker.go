@@ -15,9 +15,11 @@
 package spec
 
 import (
+	"context"
 	"fmt"
 	"maps"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -253,10 +255,21 @@ type TrackerTree struct {
 	// with LazyTree so both engines resolve the same routes (issue #204).
 	handlerMethods []string
 
+	// handlerAdapters mirror LazyTree's: an argument calling one expands as
+	// the handler it adapts.
+	handlerAdapters []HandlerAdapterPattern
+
+	// mountOrigins mirrors LazyTree's: a mount's router argument reaching
+	// the mount through a literal, a parameter or a returned struct.
+	mountOrigins *mountOrigins
+
 	// logger receives traversal-time warnings (limit truncations, etc.).
 	// May be nil; callers should reach it via t.warn / t.info.
 	logger metadata.VerboseLogger
 
+	// ctx, if set, stops the build once cancelled; see WithContext.
+	ctx context.Context
+
 	// Enhanced tracking indices
 	variableNodes map[paramKey][]*TrackerNode // Track variable nodes by name
 
@@ -417,6 +430,30 @@ func WithEagerHandlerInterfaceMethods(methods []string) TrackerTreeOption {
 	return func(t *TrackerTree) { t.handlerMethods = methods }
 }
 
+// WithEagerHandlerAdapters is the eager tree's counterpart to
+// WithHandlerAdapters.
+func WithEagerHandlerAdapters(adapters []HandlerAdapterPattern) TrackerTreeOption {
+	return func(t *TrackerTree) { t.handlerAdapters = adapters }
+}
+
+// WithEagerMountPatterns is the eager tree's counterpart to
+// WithMountPatterns.
+func WithEagerMountPatterns(patterns []MountPattern) TrackerTreeOption {
+	return func(t *TrackerTree) { t.mountOrigins = newMountOrigins(t.meta, patterns) }
+}
+
+// WithContext stops the tree build once ctx is cancelled: no further nodes
+// are created, so the build returns promptly with a partial tree the caller
+// should discard after checking ctx.Err().
+func WithContext(ctx context.Context) TrackerTreeOption {
+	return func(t *TrackerTree) { t.ctx = ctx }
+}
+
+// cancelled reports whether the build's context is done.
+func (t *TrackerTree) cancelled() bool {
+	return t != nil && t.ctx != nil && t.ctx.Err() != nil
+}
+
 func NewTrackerTree(meta *metadata.Metadata, limits metadata.TrackerLimits, logger metadata.VerboseLogger, opts ...TrackerTreeOption) *TrackerTree {
 	t := &TrackerTree{
 		meta:          meta,
@@ -490,6 +527,9 @@ func NewTrackerTree(meta *metadata.Metadata, limits metadata.TrackerLimits, logg
 
 	// Search for assignments and variables - optimized batch processing
 	for i := range meta.CallGraph {
+		if t.cancelled() {
+			return t
+		}
 		edge := &meta.CallGraph[i]
 
 		// Cache string lookups to avoid repeated calls
@@ -565,6 +605,9 @@ func NewTrackerTree(meta *metadata.Metadata, limits metadata.TrackerLimits, logg
 	// Search for root functions
 	roots := meta.CallGraphRoots()
 	for i := range roots {
+		if t.cancelled() {
+			return t
+		}
 		edge := roots[i]
 
 		callerName := getString(meta, edge.Caller.Name)
@@ -587,6 +630,10 @@ func NewTrackerTree(meta *metadata.Metadata, limits metadata.TrackerLimits, logg
 		}
 	}
 
+	if t.cancelled() {
+		return t
+	}
+
 	// Assign children to nodes
 	traverseTree(t.roots, &assignmentNodes{assignmentIndex: assignmentIndex}, 1, nil)
 
@@ -633,17 +680,13 @@ func (t *TrackerTree) processChainRelationships() {
 		}
 
 		// Skip if the chain parent is already a child (idempotent under
-		// repeated calls).
-		alreadyChild := false
-		for _, c := range parentNode.Children {
-			if c == childNode {
-				alreadyChild = true
-				break
-			}
-		}
-		if !alreadyChild {
-			parentNode.Children = append(parentNode.Children, childNode)
+		// repeated calls), or already holds it: a chain expanded from a
+		// handler factory's closure nests the chain parent under the call,
+		// and linking back would make the two each other's ancestor.
+		if slices.Contains(parentNode.Children, childNode) || slices.Contains(childNode.Children, parentNode) {
+			continue
 		}
+		parentNode.Children = append(parentNode.Children, childNode)
 	}
 }
 
@@ -775,6 +818,13 @@ func (v *variableNodes) Assign(f func(*TrackerNode)) {
 	}
 }
 
+// traverseTree walks the tree depth first, attaching to each node the
+// children of mapObject's matching node (or the node to its parent). A node
+// key seen more than limit times ends the walk of its siblings.
+//
+// The walk keeps the path on an explicit stack of sibling lists, taken as
+// they are when a node's children are entered, so a deep tree cannot
+// overflow the goroutine stack.
 func traverseTree(nodes []*TrackerNode, mapObject interface{ Assign(func(*TrackerNode)) }, limit int, nodeCount map[string]int) bool {
 	if nodeCount == nil {
 		nodeCount = map[string]int{}
@@ -784,7 +834,20 @@ func traverseTree(nodes []*TrackerNode, mapObject interface{ Assign(func(*Tracke
 		limit = metadata.MaxSelfCallingDepth
 	}
 
-	for _, node := range nodes {
+	type siblings struct {
+		nodes []*TrackerNode
+		next  int
+	}
+	stack := []siblings{{nodes: nodes}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.next >= len(top.nodes) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		node := top.nodes[top.next]
+		top.next++
+
 		nodeKey := node.Key()
 		if nodeKey == "" {
 			continue
@@ -792,7 +855,8 @@ func traverseTree(nodes []*TrackerNode, mapObject interface{ Assign(func(*Tracke
 
 		if count, ok := nodeCount[nodeKey]; ok {
 			if count > limit {
-				return false
+				stack = stack[:len(stack)-1]
+				continue
 			}
 		}
 
@@ -823,9 +887,7 @@ func traverseTree(nodes []*TrackerNode, mapObject interface{ Assign(func(*Tracke
 			}
 		})
 
-		if traverseTree(node.Children, mapObject, limit, nodeCount) {
-			return true
-		}
+		stack = append(stack, siblings{nodes: node.Children})
 	}
 
 	return false
@@ -878,24 +940,76 @@ func classifyArgument(arg *metadata.CallArgument) ArgumentType {
 	}
 }
 
-// processArguments processes arguments with enhanced classification and tracking
+// processArguments processes arguments with enhanced classification and
+// tracking, and returns the argument nodes of edge for parentNode.
 func processArguments(tree *TrackerTree, meta *metadata.Metadata, parentNode *TrackerNode, edge *metadata.CallGraphEdge, visited map[string]int, assignmentIndex *assigmentIndexMap, limits metadata.TrackerLimits) []*TrackerNode {
+	b := &trackerBuild{
+		tree:            tree,
+		meta:            meta,
+		visited:         visited,
+		assignmentIndex: assignmentIndex,
+	}
+	var children []*TrackerNode
+	root := &trackerFrame{phase: framePhaseDone, limits: limits}
+	b.stack = []*trackerFrame{root}
+	b.run(root, func() {
+		b.arguments(parentNode, edge, limits, func(nodes []*TrackerNode) { children = nodes })
+	})
+	b.build()
+	return children
+}
+
+// argumentList is the arguments of a call that trackerBuild.arguments works
+// through, one argument per step.
+type argumentList struct {
+	parentNode *TrackerNode
+	edge       *metadata.CallGraphEdge
+	limits     metadata.TrackerLimits
+	adapters   []HandlerAdapterPattern
+	// count counts the arguments seen, against MaxArgsPerFunction.
+	count    int
+	children []*TrackerNode
+	done     func([]*TrackerNode)
+}
+
+// arguments schedules the argument nodes of edge under parentNode, each
+// argument after the subtrees of the one before it, and then hands done the
+// nodes that become parentNode's children.
+func (b *trackerBuild) arguments(parentNode *TrackerNode, edge *metadata.CallGraphEdge, limits metadata.TrackerLimits, done func([]*TrackerNode)) {
 	if edge == nil {
-		return nil
+		done(nil)
+		return
 	}
 
-	// Pre-allocate slice with known capacity to reduce allocations
-	expectedArgs := min(len(edge.Args), limits.MaxArgsPerFunction)
-	children := make([]*TrackerNode, 0, expectedArgs)
-	argCount := 0
+	a := &argumentList{
+		parentNode: parentNode,
+		edge:       edge,
+		limits:     limits,
+		// Pre-allocate slice with known capacity to reduce allocations
+		children: make([]*TrackerNode, 0, min(len(edge.Args), limits.MaxArgsPerFunction)),
+		done:     done,
+	}
+	if b.tree != nil {
+		a.adapters = b.tree.handlerAdapters
+	}
+	b.argument(a, 0)
+}
 
-	for i, arg := range edge.Args {
+// argument creates the node of a's argument i, or of the first argument
+// from i on that has one, and schedules the rest of its processing.
+func (b *trackerBuild) argument(a *argumentList, i int) {
+	tree, meta, edge, parentNode, limits := b.tree, b.meta, a.edge, a.parentNode, a.limits
+	for ; i < len(edge.Args); i++ {
+		arg := unwrapHandlerAdapters(edge.Args[i], a.adapters)
+		if lit := funcVariable(edge, arg, meta); lit != nil {
+			arg = lit
+		}
 		argEdge := arg.Edge
 
 		argID := arg.ID()
-		argCount++
+		a.count++
 
-		if argCount >= limits.MaxArgsPerFunction {
+		if a.count >= limits.MaxArgsPerFunction {
 			tree.warn("Warning: MaxArgsPerFunction limit (%d) reached for function %s.%s, truncating arguments\n",
 				limits.MaxArgsPerFunction, getString(meta, edge.Caller.Name), getString(meta, edge.Callee.Name))
 			break
@@ -931,279 +1045,301 @@ func processArguments(tree *TrackerTree, meta *metadata.Metadata, parentNode *Tr
 		// the argument names no method, so the framework's handler interface
 		// supplies it — LazyTree's handlerValueKeys, mirrored here so both
 		// engines resolve the same routes (issue #204).
-		attachHandlerValueChildren(tree, meta, argNode, arg, visited, assignmentIndex, limits)
+		b.attachHandlerValueChildren(argNode, arg, limits)
+		b.attachMountOriginChildren(argNode, edge, arg, limits)
 
-		switch argType {
-		case ArgTypeFunctionCall:
-			if arg.Fun != nil && arg.Fun.GetKind() == metadata.KindSelector && arg.Fun.X.Type != -1 {
-				selectorArg := arg.Fun
-				varName := metadata.CallArgToString(selectorArg.X)
+		// The argument's own links and subtrees, then the next argument,
+		// once the subtrees scheduled so far are built.
+		b.then(func() { b.argumentNode(a, i, arg, argNode, argType) })
+		b.then(func() { b.argument(a, i+1) })
+		return
+	}
+	a.done(a.children)
+}
 
-				pkey := paramKey{
-					Name:      varName,
-					Pkg:       getString(meta, edge.Caller.Pkg),
+// argumentNode links argNode, the node of a's argument i, by the kind of
+// the argument, and schedules the subtrees it calls for.
+func (b *trackerBuild) argumentNode(a *argumentList, i int, arg *metadata.CallArgument, argNode *TrackerNode, argType ArgumentType) {
+	tree, meta, assignmentIndex := b.tree, b.meta, b.assignmentIndex
+	edge, parentNode, limits := a.edge, a.parentNode, a.limits
+	argEdge := arg.Edge
+	argID := arg.ID()
+
+	switch argType {
+	case ArgTypeFunctionCall:
+		if arg.Fun != nil && arg.Fun.GetKind() == metadata.KindSelector && arg.Fun.X.Type != -1 {
+			selectorArg := arg.Fun
+			varName := metadata.CallArgToString(selectorArg.X)
+
+			pkey := paramKey{
+				Name:      varName,
+				Pkg:       getString(meta, edge.Caller.Pkg),
+				Container: getString(meta, edge.Caller.Name),
+			}
+
+			if parents, ok := tree.variableNodes[pkey]; ok && len(parents) > 0 {
+				// Link to most recent assignment (last in slice) for cleaner tree structure
+				mostRecentParent := parents[len(parents)-1]
+				mostRecentParent.Children = append(mostRecentParent.Children, argNode)
+			}
+
+			if selectorArg.Sel.GetKind() == metadata.KindIdent && strings.HasPrefix(selectorArg.Sel.GetType(), "func(") || strings.HasPrefix(selectorArg.Sel.GetType(), "func[") {
+				// Enhanced variable tracing and assignment linking
+				originVar, originPkg, _, _ := tree.traceOrigin(
+					varName,
+					getString(meta, edge.Caller.Name),
+					getString(meta, edge.Caller.Pkg),
+				)
+
+				// Link to assignment if exists
+				akey := assignmentKey{
+					Name:      originVar,
+					Pkg:       originPkg,
+					Type:      selectorArg.X.GetType(),
 					Container: getString(meta, edge.Caller.Name),
 				}
 
-				if parents, ok := tree.variableNodes[pkey]; ok && len(parents) > 0 {
-					// Link to most recent assignment (last in slice) for cleaner tree structure
-					mostRecentParent := parents[len(parents)-1]
-					mostRecentParent.Children = append(mostRecentParent.Children, argNode)
+				if parent, ok := (*assignmentIndex)[akey]; ok {
+					parent.Children = append(parent.Children, argNode)
 				}
 
-				if selectorArg.Sel.GetKind() == metadata.KindIdent && strings.HasPrefix(selectorArg.Sel.GetType(), "func(") || strings.HasPrefix(selectorArg.Sel.GetType(), "func[") {
-					// Enhanced variable tracing and assignment linking
-					originVar, originPkg, _, _ := tree.traceOrigin(
-						varName,
-						getString(meta, edge.Caller.Name),
-						getString(meta, edge.Caller.Pkg),
-					)
-
-					// Link to assignment if exists
-					akey := assignmentKey{
-						Name:      originVar,
-						Pkg:       originPkg,
-						Type:      selectorArg.X.GetType(),
-						Container: getString(meta, edge.Caller.Name),
-					}
+				a.children = append(a.children, argNode)
 
-					if parent, ok := (*assignmentIndex)[akey]; ok {
-						parent.Children = append(parent.Children, argNode)
-					}
+				// Get the correct edge for selector arguments
+				funcNameIndex := selectorArg.Sel.Name
+				recvType := strings.ReplaceAll(originVar, selectorArg.Sel.GetPkg()+".", "")
 
-					children = append(children, argNode)
+				// First check if ReceiverType is available (for function return values)
+				if selectorArg.ReceiverType != nil && originVar == varName {
+					recvType = selectorArg.ReceiverType.GetName()
+				}
 
-					// Get the correct edge for selector arguments
-					funcNameIndex := selectorArg.Sel.Name
-					recvType := strings.ReplaceAll(originVar, selectorArg.Sel.GetPkg()+".", "")
+				var FuncType string
+
+				if selectorArg.X.GetKind() == metadata.KindSelector && selectorArg.X.X != nil && selectorArg.X.X.Type != -1 {
+					FuncType = selectorArg.X.X.GetType()
+					FuncType = strings.ReplaceAll(FuncType, selectorArg.X.X.GetPkg()+".", "")
+					FuncType = strings.TrimPrefix(FuncType, "*")
+				} else if selectorArg.X.GetKind() == metadata.KindCall && selectorArg.X.Fun != nil && selectorArg.X.Fun.Type != -1 {
+					FuncType = selectorArg.X.Fun.GetType()
+					// For Call args, the package qualifier lives on Fun
+					// (the function being called); X is unset by
+					// handleCallExpr. Dereferencing X.X here was a
+					// long-standing nil-deref that complex_chi_router
+					// happens to trigger.
+					FuncType = strings.ReplaceAll(FuncType, selectorArg.X.Fun.GetPkg()+".", "")
+					FuncType = strings.TrimPrefix(FuncType, "*")
+				}
 
-					// First check if ReceiverType is available (for function return values)
-					if selectorArg.ReceiverType != nil && originVar == varName {
-						recvType = selectorArg.ReceiverType.GetName()
-					}
+				// Resolve interface types to concrete types using interface resolution
+				concreteRecvType := tree.ResolveInterfaceFromMetadata(recvType, FuncType, selectorArg.Sel.GetPkg())
+				if concreteRecvType != recvType {
+					recvType = concreteRecvType
+				}
 
-					var FuncType string
-
-					if selectorArg.X.GetKind() == metadata.KindSelector && selectorArg.X.X != nil && selectorArg.X.X.Type != -1 {
-						FuncType = selectorArg.X.X.GetType()
-						FuncType = strings.ReplaceAll(FuncType, selectorArg.X.X.GetPkg()+".", "")
-						FuncType = strings.TrimPrefix(FuncType, "*")
-					} else if selectorArg.X.GetKind() == metadata.KindCall && selectorArg.X.Fun != nil && selectorArg.X.Fun.Type != -1 {
-						FuncType = selectorArg.X.Fun.GetType()
-						// For Call args, the package qualifier lives on Fun
-						// (the function being called); X is unset by
-						// handleCallExpr. Dereferencing X.X here was a
-						// long-standing nil-deref that complex_chi_router
-						// happens to trigger.
-						FuncType = strings.ReplaceAll(FuncType, selectorArg.X.Fun.GetPkg()+".", "")
-						FuncType = strings.TrimPrefix(FuncType, "*")
-					}
+				recvTypeIndex := meta.StringPool.Get(recvType)
+				starRecvTypeIndex := meta.StringPool.Get("*" + recvType)
+				pkgNameIndex := meta.StringPool.Get(selectorArg.Sel.GetPkg())
 
-					// Resolve interface types to concrete types using interface resolution
-					concreteRecvType := tree.ResolveInterfaceFromMetadata(recvType, FuncType, selectorArg.Sel.GetPkg())
-					if concreteRecvType != recvType {
-						recvType = concreteRecvType
-					}
+				var funcEdge *metadata.CallGraphEdge
 
-					recvTypeIndex := meta.StringPool.Get(recvType)
-					starRecvTypeIndex := meta.StringPool.Get("*" + recvType)
-					pkgNameIndex := meta.StringPool.Get(selectorArg.Sel.GetPkg())
-
-					var funcEdge *metadata.CallGraphEdge
-
-					// Look for a call graph edge where this function is the caller
-					for _, ArgEdge := range meta.CallGraph {
-						if ArgEdge.Caller.Name == funcNameIndex && ArgEdge.Caller.Pkg == pkgNameIndex && (ArgEdge.Caller.RecvType == recvTypeIndex || ArgEdge.Caller.RecvType == starRecvTypeIndex) {
-							funcEdge = &ArgEdge
-							id := funcEdge.Callee.ID()
-							if childNode := NewTrackerNode(tree, meta, argNode.Key(), id, funcEdge, nil, visited, assignmentIndex, limits); childNode != nil {
-								argNode.AddChild(childNode)
-							}
-						}
+				// Look for a call graph edge where this function is the caller
+				for _, ArgEdge := range meta.CallGraph {
+					if ArgEdge.Caller.Name == funcNameIndex && ArgEdge.Caller.Pkg == pkgNameIndex && (ArgEdge.Caller.RecvType == recvTypeIndex || ArgEdge.Caller.RecvType == starRecvTypeIndex) {
+						funcEdge = &ArgEdge
+						id := funcEdge.Callee.ID()
+						b.node(argNode.Key(), id, funcEdge, nil, limits, argNode.AddChild)
 					}
-
-					// Handler-factory pattern: the registered handler is a *call*
-					// returning a func literal (e.g. `g.POST(p, h.Create())` where
-					// `Create() echo.HandlerFunc { return func(c) error {…} }`). The
-					// real body lives in the returned closure, so the loop above —
-					// which keys on the method as Caller — finds nothing. Attach the
-					// closure body explicitly, resolving the receiver's declared type
-					// (here the interface) to its concrete implementer(s).
-					tree.attachReturnedClosureBody(meta, argNode, selectorArg.X.GetType(), selectorArg.Sel.GetName(), selectorArg.Sel.GetPkg(), visited, assignmentIndex, limits)
 				}
-			}
 
-			// Process function call arguments recursively
-			if argNode := NewTrackerNode(tree, meta, parentNode.Key(), argID, argEdge, arg, visited, assignmentIndex, limits); argNode != nil {
-				argNode.Parent = parentNode
-				argNode.ArgType = ArgTypeFunctionCall
-				argNode.IsArgument = true
-				argNode.ArgIndex = i
-				argNode.ArgContext = fmt.Sprintf("%s.%s", getString(meta, edge.Caller.Name), getString(meta, edge.Callee.Name))
-
-				// Process nested arguments
-				if len(arg.Args) > 0 {
-					argNode.AddChildren(processArguments(tree, meta, argNode, argEdge, visited, assignmentIndex, limits))
-				}
+				// Handler-factory pattern: the registered handler is a *call*
+				// returning a func literal (e.g. `g.POST(p, h.Create())` where
+				// `Create() echo.HandlerFunc { return func(c) error {…} }`). The
+				// real body lives in the returned closure, so the loop above —
+				// which keys on the method as Caller — finds nothing. Attach the
+				// closure body explicitly, resolving the receiver's declared type
+				// (here the interface) to its concrete implementer(s).
+				b.then(func() {
+					b.attachReturnedClosureBody(argNode, selectorArg.X.GetType(), selectorArg.Sel.GetName(), selectorArg.Sel.GetPkg(), limits)
+				})
+			}
+		}
 
-				children = append(children, argNode)
+		// Process function call arguments on the same stack
+		b.node(parentNode.Key(), argID, argEdge, arg, limits, func(argNode *TrackerNode) {
+			argNode.Parent = parentNode
+			argNode.ArgType = ArgTypeFunctionCall
+			argNode.IsArgument = true
+			argNode.ArgIndex = i
+			argNode.ArgContext = fmt.Sprintf("%s.%s", getString(meta, edge.Caller.Name), getString(meta, edge.Callee.Name))
+
+			added := func(nested []*TrackerNode) {
+				argNode.AddChildren(nested)
+				a.children = append(a.children, argNode)
 				if arg.Fun != nil && arg.Fun.Position != -1 {
 					tree.positions[arg.Fun.GetPosition()] = true
 				}
 			}
+			// Process nested arguments
+			if len(arg.Args) > 0 {
+				b.arguments(argNode, argEdge, limits, added)
+			} else {
+				added(nil)
+			}
+		})
 
-		case ArgTypeVariable:
-			varName := metadata.CallArgToString(arg)
-			// Enhanced variable tracing and assignment linking
-			originVar, originPkg, _, _ := tree.traceOrigin(
-				varName,
-				getString(meta, edge.Caller.Name),
-				getString(meta, edge.Caller.Pkg),
-			)
+	case ArgTypeVariable:
+		varName := metadata.CallArgToString(arg)
+		// Enhanced variable tracing and assignment linking
+		originVar, originPkg, _, _ := tree.traceOrigin(
+			varName,
+			getString(meta, edge.Caller.Name),
+			getString(meta, edge.Caller.Pkg),
+		)
 
-			// Link to assignment if exists
-			akey := assignmentKey{
-				Name:      originVar,
-				Pkg:       originPkg,
+		// Link to assignment if exists
+		akey := assignmentKey{
+			Name:      originVar,
+			Pkg:       originPkg,
+			Type:      arg.GetType(),
+			Container: getString(meta, edge.Caller.Name),
+		}
+
+		if parent, ok := (*assignmentIndex)[akey]; ok {
+			parent.Children = append(parent.Children, argNode)
+			argNode.Parent = parent
+		} else {
+			akey = assignmentKey{
+				Name:      varName,
+				Pkg:       getString(meta, edge.Callee.Pkg),
 				Type:      arg.GetType(),
 				Container: getString(meta, edge.Caller.Name),
 			}
 
-			if parent, ok := (*assignmentIndex)[akey]; ok {
-				parent.Children = append(parent.Children, argNode)
-				argNode.Parent = parent
-			} else {
-				akey = assignmentKey{
-					Name:      varName,
-					Pkg:       getString(meta, edge.Callee.Pkg),
+			if assignmentNode, ok := (*assignmentIndex)[akey]; ok {
+				assignmentNode.Parent = argNode
+			}
+		}
+
+		pkey := paramKey{
+			Name:      originVar,
+			Pkg:       originPkg,
+			Container: getString(meta, edge.Caller.Name),
+		}
+
+		if parents, ok := tree.variableNodes[pkey]; ok && len(parents) > 0 {
+			// Link to the most recent assignment (last in slice) as it represents
+			// the actual value at the point of use. This creates a cleaner tree
+			// structure while preserving the logical relationship.
+			mostRecentParent := parents[len(parents)-1]
+			mostRecentParent.Children = append(mostRecentParent.Children, argNode)
+
+			// Optionally: Store reference to all possible origins for completeness
+			// This allows tracking all possible values without cluttering the tree
+			if argNode.RootAssignmentMap == nil {
+				argNode.RootAssignmentMap = make(map[string][]metadata.Assignment, 1)
+			}
+			// The RootAssignmentMap will be populated by the assignment linking above
+		}
+		a.children = append(a.children, argNode)
+
+	case ArgTypeLiteral:
+		// Store literal for type inference
+		a.children = append(a.children, argNode)
+
+	case ArgTypeSelector:
+		// Handling a function inside the selector
+		// Process field/method access
+		if arg.X != nil {
+			if arg.Sel.GetKind() == metadata.KindIdent && (strings.HasPrefix(arg.Sel.GetType(), "func(") || strings.HasPrefix(arg.Sel.GetType(), "func[")) {
+				varName := metadata.CallArgToString(arg.X)
+				// Enhanced variable tracing and assignment linking
+				originVar, originPkg, _, _ := tree.traceOrigin(
+					varName,
+					getString(meta, edge.Caller.Name),
+					getString(meta, edge.Caller.Pkg),
+				)
+
+				// Link to assignment if exists
+				akey := assignmentKey{
+					Name:      originVar,
+					Pkg:       originPkg,
 					Type:      arg.GetType(),
 					Container: getString(meta, edge.Caller.Name),
 				}
 
-				if assignmentNode, ok := (*assignmentIndex)[akey]; ok {
-					assignmentNode.Parent = argNode
+				if parent, ok := (*assignmentIndex)[akey]; ok {
+					parent.Children = append(parent.Children, argNode)
 				}
-			}
-
-			pkey := paramKey{
-				Name:      originVar,
-				Pkg:       originPkg,
-				Container: getString(meta, edge.Caller.Name),
-			}
 
-			if parents, ok := tree.variableNodes[pkey]; ok && len(parents) > 0 {
-				// Link to the most recent assignment (last in slice) as it represents
-				// the actual value at the point of use. This creates a cleaner tree
-				// structure while preserving the logical relationship.
-				mostRecentParent := parents[len(parents)-1]
-				mostRecentParent.Children = append(mostRecentParent.Children, argNode)
+				// Link param
+				pkey := paramKey{
+					Name:      originVar,
+					Pkg:       originPkg,
+					Container: getString(meta, edge.Caller.Name),
+				}
 
-				// Optionally: Store reference to all possible origins for completeness
-				// This allows tracking all possible values without cluttering the tree
-				if argNode.RootAssignmentMap == nil {
-					argNode.RootAssignmentMap = make(map[string][]metadata.Assignment, 1)
+				if parents, ok := tree.variableNodes[pkey]; ok && len(parents) > 0 {
+					// Link to the most recent assignment (last in slice) as it represents
+					// the actual value at the point of use
+					mostRecentParent := parents[len(parents)-1]
+					mostRecentParent.Children = append(mostRecentParent.Children, argNode)
 				}
-				// The RootAssignmentMap will be populated by the assignment linking above
-			}
-			children = append(children, argNode)
-
-		case ArgTypeLiteral:
-			// Store literal for type inference
-			children = append(children, argNode)
-
-		case ArgTypeSelector:
-			// Handling a function inside the selector
-			// Process field/method access
-			if arg.X != nil {
-				if arg.Sel.GetKind() == metadata.KindIdent && (strings.HasPrefix(arg.Sel.GetType(), "func(") || strings.HasPrefix(arg.Sel.GetType(), "func[")) {
-					varName := metadata.CallArgToString(arg.X)
-					// Enhanced variable tracing and assignment linking
-					originVar, originPkg, _, _ := tree.traceOrigin(
-						varName,
-						getString(meta, edge.Caller.Name),
-						getString(meta, edge.Caller.Pkg),
-					)
-
-					// Link to assignment if exists
-					akey := assignmentKey{
-						Name:      originVar,
-						Pkg:       originPkg,
-						Type:      arg.GetType(),
-						Container: getString(meta, edge.Caller.Name),
-					}
 
-					if parent, ok := (*assignmentIndex)[akey]; ok {
-						parent.Children = append(parent.Children, argNode)
-					}
+				// Get the correct edge for method calls
+				funcNameIndex := arg.Sel.Name
+				recvType := strings.ReplaceAll(originVar, arg.Sel.GetPkg()+".", "")
+				// If the selector is a method, we need to get the type of the receiver
+				if arg.Sel.Type != -1 && originVar == varName {
+					recvType = arg.X.GetType()
+					recvType = strings.ReplaceAll(recvType, arg.Sel.GetPkg()+".", "")
+				}
 
-					// Link param
-					pkey := paramKey{
-						Name:      originVar,
-						Pkg:       originPkg,
-						Container: getString(meta, edge.Caller.Name),
-					}
+				// First check if ReceiverType is available (for function return values)
+				if arg.ReceiverType != nil && originVar == varName {
+					recvType = arg.ReceiverType.GetName()
+				}
 
-					if parents, ok := tree.variableNodes[pkey]; ok && len(parents) > 0 {
-						// Link to the most recent assignment (last in slice) as it represents
-						// the actual value at the point of use
-						mostRecentParent := parents[len(parents)-1]
-						mostRecentParent.Children = append(mostRecentParent.Children, argNode)
-					}
+				var FuncType string
 
-					// Get the correct edge for method calls
-					funcNameIndex := arg.Sel.Name
-					recvType := strings.ReplaceAll(originVar, arg.Sel.GetPkg()+".", "")
-					// If the selector is a method, we need to get the type of the receiver
-					if arg.Sel.Type != -1 && originVar == varName {
-						recvType = arg.X.GetType()
-						recvType = strings.ReplaceAll(recvType, arg.Sel.GetPkg()+".", "")
-					}
-
-					// First check if ReceiverType is available (for function return values)
-					if arg.ReceiverType != nil && originVar == varName {
-						recvType = arg.ReceiverType.GetName()
-					}
+				if arg.X.GetKind() == metadata.KindSelector && arg.X.X.Type != -1 {
+					FuncType = arg.X.X.GetType()
+					FuncType = strings.ReplaceAll(FuncType, arg.X.X.GetPkg()+".", "")
+					FuncType = strings.TrimPrefix(FuncType, "*")
+				} else if arg.X.GetKind() == metadata.KindCall && arg.X.Fun.Type != -1 {
+					FuncType = arg.X.Fun.GetType()
+					FuncType = strings.ReplaceAll(FuncType, arg.X.Fun.GetPkg()+".", "")
+					FuncType = strings.TrimPrefix(FuncType, "*")
+				}
 
-					var FuncType string
+				// Resolve interface types to concrete types using interface resolution
+				concreteRecvType := tree.ResolveInterfaceFromMetadata(recvType, FuncType, arg.Sel.GetPkg())
+				if concreteRecvType != recvType {
+					recvType = concreteRecvType
+				}
 
-					if arg.X.GetKind() == metadata.KindSelector && arg.X.X.Type != -1 {
-						FuncType = arg.X.X.GetType()
-						FuncType = strings.ReplaceAll(FuncType, arg.X.X.GetPkg()+".", "")
-						FuncType = strings.TrimPrefix(FuncType, "*")
-					} else if arg.X.GetKind() == metadata.KindCall && arg.X.Fun.Type != -1 {
-						FuncType = arg.X.Fun.GetType()
-						FuncType = strings.ReplaceAll(FuncType, arg.X.Fun.GetPkg()+".", "")
-						FuncType = strings.TrimPrefix(FuncType, "*")
-					}
+				recvTypeIndex := meta.StringPool.Get(recvType)
+				starRecvTypeIndex := meta.StringPool.Get("*" + recvType)
+				pkgNameIndex := arg.Sel.Pkg
 
-					// Resolve interface types to concrete types using interface resolution
-					concreteRecvType := tree.ResolveInterfaceFromMetadata(recvType, FuncType, arg.Sel.GetPkg())
-					if concreteRecvType != recvType {
-						recvType = concreteRecvType
-					}
+				var funcEdge *metadata.CallGraphEdge
 
-					recvTypeIndex := meta.StringPool.Get(recvType)
-					starRecvTypeIndex := meta.StringPool.Get("*" + recvType)
-					pkgNameIndex := arg.Sel.Pkg
-
-					var funcEdge *metadata.CallGraphEdge
-
-					// Look for a call graph edge where this function is the caller
-					for _, ArgEdge := range meta.CallGraph {
-						if ArgEdge.Caller.Name == funcNameIndex && ArgEdge.Caller.Pkg == pkgNameIndex && (ArgEdge.Caller.RecvType == recvTypeIndex || ArgEdge.Caller.RecvType == starRecvTypeIndex) {
-							funcEdge = &ArgEdge
-							id := funcEdge.Callee.ID()
-							if childNode := NewTrackerNode(tree, meta, argNode.Key(), id, funcEdge, nil, visited, assignmentIndex, limits); childNode != nil {
-								argNode.AddChild(childNode)
-							}
-						}
+				// Look for a call graph edge where this function is the caller
+				for _, ArgEdge := range meta.CallGraph {
+					if ArgEdge.Caller.Name == funcNameIndex && ArgEdge.Caller.Pkg == pkgNameIndex && (ArgEdge.Caller.RecvType == recvTypeIndex || ArgEdge.Caller.RecvType == starRecvTypeIndex) {
+						funcEdge = &ArgEdge
+						id := funcEdge.Callee.ID()
+						b.node(argNode.Key(), id, funcEdge, nil, limits, argNode.AddChild)
 					}
-
-					// NOTE: the handler-factory closure resolution intentionally
-					// runs only in the KindCall branch above (handler registered as
-					// h.Create()). This KindSelector branch is a bare method value
-					// (h.Create) and is left to the existing direct-handler lookup.
 				}
+
+				// NOTE: the handler-factory closure resolution intentionally
+				// runs only in the KindCall branch above (handler registered as
+				// h.Create()). This KindSelector branch is a bare method value
+				// (h.Create) and is left to the existing direct-handler lookup.
+			}
+			// Link the argument once the method's subtrees are built.
+			b.then(func() {
 				varName := metadata.CallArgToString(arg)
 				// Trace the base object
 				baseVar, originPkg, _, _ := tree.traceOrigin(
@@ -1257,61 +1393,59 @@ func processArguments(tree *TrackerTree, meta *metadata.Metadata, parentNode *Tr
 					mostRecentParent.Children = append(mostRecentParent.Children, argNode)
 				}
 
-				children = append(children, argNode)
-			} else {
-				children = append(children, argNode)
-			}
+				a.children = append(a.children, argNode)
+			})
+		} else {
+			a.children = append(a.children, argNode)
+		}
 
-		case ArgTypeUnary:
-			// Process unary expressions (*ptr, &val)
-			if arg.X != nil {
-				// Trace the operand
-				if arg.X.GetKind() == metadata.KindIdent {
-					originVar, originPkg, _, _ := tree.traceOrigin(
-						arg.X.GetName(),
-						getString(meta, edge.Caller.Name),
-						getString(meta, edge.Caller.Pkg),
-					)
-
-					if parent, ok := (*assignmentIndex)[assignmentKey{
-						Name:      originVar,
-						Pkg:       originPkg,
-						Type:      arg.X.GetType(),
-						Container: getString(meta, edge.Caller.Name),
-					}]; ok {
-						parent.Children = append(parent.Children, argNode)
-					}
-					children = append(children, argNode)
-				} else {
-					children = append(children, argNode)
+	case ArgTypeUnary:
+		// Process unary expressions (*ptr, &val)
+		if arg.X != nil {
+			// Trace the operand
+			if arg.X.GetKind() == metadata.KindIdent {
+				originVar, originPkg, _, _ := tree.traceOrigin(
+					arg.X.GetName(),
+					getString(meta, edge.Caller.Name),
+					getString(meta, edge.Caller.Pkg),
+				)
+
+				if parent, ok := (*assignmentIndex)[assignmentKey{
+					Name:      originVar,
+					Pkg:       originPkg,
+					Type:      arg.X.GetType(),
+					Container: getString(meta, edge.Caller.Name),
+				}]; ok {
+					parent.Children = append(parent.Children, argNode)
 				}
+				a.children = append(a.children, argNode)
 			} else {
-				children = append(children, argNode)
+				a.children = append(a.children, argNode)
 			}
+		} else {
+			a.children = append(a.children, argNode)
+		}
 
-		case ArgTypeBinary:
-			// Process binary expressions (a + b)
-			children = append(children, argNode)
+	case ArgTypeBinary:
+		// Process binary expressions (a + b)
+		a.children = append(a.children, argNode)
 
-		case ArgTypeIndex:
-			// Process index expressions (arr[i])
-			children = append(children, argNode)
+	case ArgTypeIndex:
+		// Process index expressions (arr[i])
+		a.children = append(a.children, argNode)
 
-		case ArgTypeComposite:
-			// Process composite literals (struct{})
-			children = append(children, argNode)
+	case ArgTypeComposite:
+		// Process composite literals (struct{})
+		a.children = append(a.children, argNode)
 
-		case ArgTypeTypeAssert:
-			// Process type assertions (val.(type))
-			children = append(children, argNode)
+	case ArgTypeTypeAssert:
+		// Process type assertions (val.(type))
+		a.children = append(a.children, argNode)
 
-		default:
-			// Complex expressions
-			children = append(children, argNode)
-		}
+	default:
+		// Complex expressions
+		a.children = append(a.children, argNode)
 	}
-
-	return children
 }
 
 // newArgumentNode creates a lightweight TrackerNode for arguments without expensive traversal.
@@ -1340,46 +1474,205 @@ func newArgumentNode(tree *TrackerTree, parentNode *TrackerNode, argID string, e
 	return node
 }
 
-// NewTrackerNode creates a new TrackerNode for the tree.
+// trackerBuild holds what every node built by one NewTrackerNode call
+// shares.
+type trackerBuild struct {
+	tree            *TrackerTree
+	meta            *metadata.Metadata
+	visited         map[string]int
+	assignmentIndex *assigmentIndexMap
+
+	// stack holds a frame per node of the current path.
+	stack []*trackerFrame
+	// scheduled collects the steps the running step schedules (see run).
+	scheduled []func()
+}
+
+// Phases of a trackerFrame, in the order its children are built.
+const (
+	framePhaseParentFunctions = iota
+	framePhaseCallees
+	framePhaseImplementations
+	framePhaseDone
+)
+
+// trackerFrame is a node under construction. NewTrackerNode keeps a frame
+// per node of the current call path on an explicit stack rather than
+// recursing, so a deep call chain costs heap, not goroutine stack. A frame
+// builds its children phase by phase: the calls made inside func literals
+// of the function, its callees, then the concrete implementations of an
+// interface method. Before each next child it runs its steps: the
+// arguments of the callee just built, whose subtrees go on the same stack.
+type trackerFrame struct {
+	node *TrackerNode
+	id   string
+	// limits bound the node's subtree; a handler factory's closure body is
+	// built under tighter ones.
+	limits metadata.TrackerLimits
+
+	phase int
+	next  int
+
+	// steps run before the frame's next child. The slice is a stack: the
+	// next step is the last.
+	steps []func()
+	// done receives the node once it is built, in place of the parent
+	// frame's accept, when a step asked for it.
+	done func(*TrackerNode)
+
+	parentFunctionEdges []*metadata.CallGraphEdge
+	calleeEdges         []*metadata.CallGraphEdge
+	implementationEdges []*metadata.CallGraphEdge
+
+	// childCount counts the callees added, against MaxChildrenPerNode.
+	childCount int
+	// calleeEdge is the edge of the callee being built, whose arguments
+	// are processed once it is.
+	calleeEdge *metadata.CallGraphEdge
+}
+
+// NewTrackerNode creates a new TrackerNode for the tree, with the subtree
+// of calls reachable from it.
+//
+// The subtree is built depth first, in the same order a recursive descent
+// would, but on an explicit stack of trackerFrames: the length of a call
+// chain, or of a chain of nested arguments, is bounded by the tree's
+// limits, not by the goroutine stack.
+// visited counts the nodes of each ID on the current path; a node whose ID
+// is already on the path MaxRecursionDepth times is not built again, which
+// stops cycles. With no MaxRecursionDepth, an ID is built once per path.
 func NewTrackerNode(tree *TrackerTree, meta *metadata.Metadata, parentID, id string, parentEdge *metadata.CallGraphEdge, callArg *metadata.CallArgument, visited map[string]int, assignmentIndex *assigmentIndexMap, limits metadata.TrackerLimits) *TrackerNode {
+	b := &trackerBuild{
+		tree:            tree,
+		meta:            meta,
+		visited:         visited,
+		assignmentIndex: assignmentIndex,
+	}
+	node, frame := b.enter(parentID, id, parentEdge, callArg, limits)
+	if frame == nil {
+		return node
+	}
+	b.stack = []*trackerFrame{frame}
+	return b.build()
+}
+
+// build runs the stack until its bottom frame is done, and returns that
+// frame's node.
+func (b *trackerBuild) build() *TrackerNode {
+	for {
+		top := b.stack[len(b.stack)-1]
+		if n := len(top.steps); n > 0 {
+			step := top.steps[n-1]
+			top.steps = top.steps[:n-1]
+			b.run(top, step)
+			continue
+		}
+		childID, childEdge, ok := b.nextChild(top)
+		if !ok {
+			if top.node == nil {
+				// processArguments' frame, which only runs steps.
+				return nil
+			}
+			node := b.exit(top)
+			b.stack = b.stack[:len(b.stack)-1]
+			if len(b.stack) == 0 {
+				return node
+			}
+			parent := b.stack[len(b.stack)-1]
+			if top.done != nil {
+				b.run(parent, func() { top.done(node) })
+			} else {
+				b.run(parent, func() { b.accept(parent, node) })
+			}
+			continue
+		}
+		child, childFrame := b.enter(top.id, childID, childEdge, nil, top.limits)
+		if childFrame != nil {
+			b.stack = append(b.stack, childFrame)
+			continue
+		}
+		b.run(top, func() { b.accept(top, child) })
+	}
+}
+
+// run calls fn for frame, and puts the steps fn schedules ahead of frame's
+// remaining ones, so the build stays depth first.
+func (b *trackerBuild) run(frame *trackerFrame, fn func()) {
+	fn()
+	for i := len(b.scheduled) - 1; i >= 0; i-- {
+		frame.steps = append(frame.steps, b.scheduled[i])
+	}
+	clear(b.scheduled)
+	b.scheduled = b.scheduled[:0]
+}
+
+// then schedules step to run on the current frame after the steps, and the
+// subtrees, scheduled before it.
+func (b *trackerBuild) then(step func()) {
+	b.scheduled = append(b.scheduled, step)
+}
+
+// node schedules building the node id, with its subtree, on the stack; done
+// receives the node unless the node is cut off.
+func (b *trackerBuild) node(parentID, id string, parentEdge *metadata.CallGraphEdge, callArg *metadata.CallArgument, limits metadata.TrackerLimits, done func(*TrackerNode)) {
+	b.then(func() {
+		node, frame := b.enter(parentID, id, parentEdge, callArg, limits)
+		if frame != nil {
+			frame.done = done
+			b.stack = append(b.stack, frame)
+		} else if node != nil {
+			done(node)
+		}
+	})
+}
+
+// enter starts the node id. It returns the node and nil when there is
+// nothing to build below it — no node at all when the node is cut off,
+// a leaf stub once MaxNodesPerTree is reached — and otherwise the node and
+// the frame building its children.
+func (b *trackerBuild) enter(parentID, id string, parentEdge *metadata.CallGraphEdge, callArg *metadata.CallArgument, limits metadata.TrackerLimits) (*TrackerNode, *trackerFrame) {
+	tree, meta := b.tree, b.meta
 	if id == "" {
-		return nil
+		return nil, nil
 	}
 
 	// Direct recursion prevention
 	if id == parentID {
-		return nil
+		return nil, nil
 	}
 
-	// OPTIMIZED: Simple recursion depth tracking with minimal overhead
-	// Use a simple counter approach instead of complex map operations
-	recursionDepthKey := id
-	currentDepth := visited[recursionDepthKey]
+	// A cancelled build creates no more nodes, so the build unwinds.
+	if tree.cancelled() {
+		return nil, nil
+	}
 
-	// Use configurable recursion depth limit to prevent infinite recursion.
-	// Hitting this is a safety brake, not a truncation — analysis simply
-	// stops following the path further. Demoted to verbose-only so a normal
-	// run on a self-referential codebase doesn't look like an error.
-	if currentDepth >= limits.MaxRecursionDepth {
+	// Per-path repeat limit: visited counts the frames of each ID on the
+	// current path (incremented on enter, decremented on exit). Hitting
+	// this is a cycle brake, not a truncation — analysis simply stops
+	// following the path further. Demoted to verbose-only so a normal run
+	// on a self-referential codebase doesn't look like an error.
+	maxRepeats := limits.MaxRecursionDepth
+	if maxRepeats <= 0 {
+		maxRepeats = 1
+	}
+	if b.visited[id] >= maxRepeats {
 		tree.infoOnce("recursion:"+id,
-			"Info: MaxRecursionDepth limit (%d) reached for node %s (analysis continues)\n", limits.MaxRecursionDepth, id)
-		return nil
+			"Info: MaxRecursionDepth limit (%d) reached for node %s (analysis continues)\n", maxRepeats, id)
+		return nil, nil
 	}
 
 	// Limit total nodes to prevent memory explosion AND unbounded wall-clock
 	// time. This gates on the cumulative count of nodes built for the whole
-	// tree (tree.nodesBuilt), not on len(visited): `visited` is a per-path
-	// recursion-stack counter (incremented on enter, decremented on exit), so
-	// its size is the current stack depth, never the total work. A dense or
-	// cyclic graph re-expands shared callees along exponentially many distinct
-	// paths while keeping stack depth small, so the old len(visited) check
-	// never fired and such graphs ran effectively forever. The cumulative
-	// counter bounds them. Once the cap is hit, every further call returns a
-	// leaf stub immediately, so the in-flight recursion unwinds cheaply.
-	// A MaxNodesPerTree of 0 means "no cap" (the engine always sets a real
-	// default; only some unit tests leave it zero). tree may be nil in a few
-	// synthetic tests that drive NewTrackerNode directly — the cumulative
-	// counter needs a tree, so it is simply disabled there.
+	// tree (tree.nodesBuilt), not on len(visited): `visited` only holds the
+	// current path, never the total work. A dense or cyclic graph re-expands
+	// shared callees along exponentially many distinct paths while keeping
+	// paths short, so a len(visited) check would never fire and such graphs
+	// would run effectively forever. The cumulative counter bounds them.
+	// Once the cap is hit, every further node is a leaf stub, so the build
+	// unwinds cheaply. A MaxNodesPerTree of 0 means "no cap" (the engine
+	// always sets a real default; only some unit tests leave it zero). tree
+	// may be nil in a few synthetic tests that drive NewTrackerNode directly
+	// — the cumulative counter needs a tree, so it is simply disabled there.
 	if tree != nil && limits.MaxNodesPerTree > 0 && tree.nodesBuilt >= limits.MaxNodesPerTree {
 		// A single global key: once the cumulative cap is hit it is a tree-wide
 		// condition, so warn exactly once rather than once per truncated node.
@@ -1391,11 +1684,10 @@ func NewTrackerNode(tree *TrackerTree, meta *metadata.Metadata, parentID, id str
 		if parentEdge == nil && callArg == nil {
 			node.key = id
 		}
-		return node
+		return node, nil
 	}
 
-	// Increment recursion depth (single map operation)
-	visited[recursionDepthKey]++
+	b.visited[id]++
 	if tree != nil {
 		tree.nodesBuilt++
 	}
@@ -1410,6 +1702,7 @@ func NewTrackerNode(tree *TrackerTree, meta *metadata.Metadata, parentID, id str
 	if parentEdge == nil && callArg == nil {
 		node.key = id
 	}
+	frame := &trackerFrame{node: node, id: id, limits: limits}
 
 	// Process children (callees)
 	callerID := metadata.StripToBase(id)
@@ -1431,7 +1724,7 @@ func NewTrackerNode(tree *TrackerTree, meta *metadata.Metadata, parentID, id str
 				Type:      getString(meta, parentEdge.Callee.RecvType),
 				Container: getString(meta, parentEdge.Caller.Name),
 			}
-			if parent, ok := (*assignmentIndex)[assignmentKey]; ok {
+			if parent, ok := (*b.assignmentIndex)[assignmentKey]; ok {
 				parent.Children = append(parent.Children, node)
 			}
 		}
@@ -1469,9 +1762,7 @@ func NewTrackerNode(tree *TrackerTree, meta *metadata.Metadata, parentID, id str
 				continue
 			}
 			visitedParentFunctionID[parentFunctionID] = true
-			if childNode := NewTrackerNode(tree, meta, id, parentFunctionID, parentFunctionEdge, nil, visited, assignmentIndex, limits); childNode != nil {
-				node.AddChild(childNode)
-			}
+			frame.parentFunctionEdges = append(frame.parentFunctionEdges, parentFunctionEdge)
 		}
 	}
 
@@ -1489,176 +1780,228 @@ func NewTrackerNode(tree *TrackerTree, meta *metadata.Metadata, parentID, id str
 				}
 			}
 		}
+		frame.calleeEdges = edges
+	}
 
-		// Limit the number of children to prevent explosion
-		childCount := 0
-
-		for i := range edges {
-			if childCount >= limits.MaxChildrenPerNode {
-				tree.warnOnce("maxchildren:"+id,
-					"Warning: MaxChildrenPerNode limit (%d) reached for node %s, truncating children\n",
-					limits.MaxChildrenPerNode, id)
-				break
-			}
-
-			edge := *edges[i]
+	// Handle interface method calls by resolving to concrete implementations
+	if parentEdge != nil && parentEdge.Callee.RecvType != -1 {
+		frame.implementationEdges = implementationEdges(meta, parentEdge)
+	}
 
-			calleeID := edge.Callee.ID()
+	return node, frame
+}
 
-			idTypes := metadata.ExtractGenericTypes(id)
-			calleeTypes := metadata.ExtractGenericTypes(calleeID)
+// implementationEdges returns the calls made by the concrete implementations
+// of the interface method parentEdge calls.
+func implementationEdges(meta *metadata.Metadata, parentEdge *metadata.CallGraphEdge) []*metadata.CallGraphEdge {
+	recvTypeName := getString(meta, parentEdge.Callee.RecvType)
+	calleePkg := getString(meta, parentEdge.Callee.Pkg)
+	methodName := getString(meta, parentEdge.Callee.Name)
 
-			if len(calleeTypes) > 0 && !metadata.IsSubset(idTypes, calleeTypes) {
-				// Skip this instance of callee when it's generic but is not including callers types
+	pkg, exists := meta.Packages[calleePkg]
+	if !exists {
+		return nil
+	}
+	var edges []*metadata.CallGraphEdge
+	for _, file := range pkg.Files {
+		typ, exists := file.Types[recvTypeName]
+		if !exists || getString(meta, typ.Kind) != "interface" {
+			continue
+		}
+		for _, implTypeIdx := range typ.ImplementedBy {
+			implTypeName := getString(meta, implTypeIdx)
+			// ImplementedBy is "import/path.Type"; the import path
+			// itself contains dots (github.com/…), so split on the
+			// LAST dot — not every dot — to separate pkg from type.
+			dot := strings.LastIndex(implTypeName, ".")
+			if dot <= 0 || dot == len(implTypeName)-1 {
 				continue
 			}
+			implPkg, implType := implTypeName[:dot], implTypeName[dot+1:]
 
-			_, existsInArgs := meta.Args[metadata.StripToBase(calleeID)]
-
-			if edge.Callee.ID() == edge.Caller.ID() || getString(meta, edge.Callee.Name) == "nil" || existsInArgs {
-				// Skip this child as it's already present in arguments
+			implPkgObj, exists := meta.Packages[implPkg]
+			if !exists {
 				continue
 			}
-
-			if childNode := NewTrackerNode(tree, meta, id, calleeID, &edge, nil, visited, assignmentIndex, limits); childNode != nil {
-				var addedToParent bool
-
-				// Process arguments for this edge using enhanced processing
-				argumentChildren := processArguments(tree, meta, childNode, &edge, visited, assignmentIndex, limits)
-
-				// If this node uses a variable as a receiver, link to its assignment node
-				if childNode.CallGraphEdge != nil && childNode.CalleeVarName != "" && edge.Callee.RecvType != -1 {
-					funcName := getString(meta, edge.Caller.Name)
-					callerPkg := getString(meta, edge.Caller.Pkg)
-					calleePkg := getString(meta, edge.Callee.Pkg)
-
-					// Optimize receiver type resolution
-					var calleeRecvType string
-					if edge.Callee.RecvType != -1 {
-						calleeRecvType = getString(meta, edge.Callee.RecvType)
-						if calleeRecvType != "" {
-							// Resolve interface types to concrete types using interface resolution
-							concreteRecvType := tree.ResolveInterfaceFromMetadata(calleeRecvType, "", calleePkg)
-							if concreteRecvType != calleeRecvType {
-								calleeRecvType = concreteRecvType
-							}
-
-							// Build fully qualified type name efficiently
-							if strings.HasPrefix(calleeRecvType, "*") {
-								calleeRecvType = "*" + calleePkg + "." + calleeRecvType[1:]
-							} else {
-								calleeRecvType = calleePkg + "." + calleeRecvType
-							}
-						}
+			for _, implFile := range implPkgObj.Files {
+				implTypeObj, exists := implFile.Types[implType]
+				if !exists {
+					continue
+				}
+				for _, method := range implTypeObj.Methods {
+					if getString(meta, method.Name) != methodName {
+						continue
 					}
+					concreteMethodID := implPkg + "." + implType + "." + methodName
+					edges = append(edges, meta.Callers[concreteMethodID]...)
+				}
+			}
+		}
+	}
+	return edges
+}
 
-					// Trace variable origin once and cache results
-					originVar, originPkg, _, originFunc := tree.traceOrigin(
-						edge.CalleeVarName,
-						funcName,
-						callerPkg,
-					)
-
-					// Link to assignment node if found
-					if originVar != "" && originPkg != "" && originFunc != "" {
-						assignmentKey := assignmentKey{
-							Name:      originVar,
-							Pkg:       originPkg,
-							Type:      calleeRecvType,
-							Container: originFunc,
-						}
-						if parent, ok := (*assignmentIndex)[assignmentKey]; ok {
-							parent.Children = append(parent.Children, childNode)
-						}
-					}
+// nextChild returns the ID and edge of the next child frame should build,
+// or false once it has built them all.
+func (b *trackerBuild) nextChild(frame *trackerFrame) (string, *metadata.CallGraphEdge, bool) {
+	meta := b.meta
+	for {
+		switch frame.phase {
+		case framePhaseParentFunctions:
+			if frame.next < len(frame.parentFunctionEdges) {
+				edge := frame.parentFunctionEdges[frame.next]
+				frame.next++
+				return edge.Caller.ID(), edge, true
+			}
 
-					// Link to variable node if found
-					pkey := paramKey{
-						Name:      edge.CalleeVarName,
-						Pkg:       callerPkg, // Use cached value
-						Container: funcName,  // Use cached value
-					}
+		case framePhaseCallees:
+			for frame.next < len(frame.calleeEdges) {
+				// Limit the number of children to prevent explosion
+				if frame.childCount >= frame.limits.MaxChildrenPerNode {
+					b.tree.warnOnce("maxchildren:"+frame.id,
+						"Warning: MaxChildrenPerNode limit (%d) reached for node %s, truncating children\n",
+						frame.limits.MaxChildrenPerNode, frame.id)
+					break
+				}
 
-					if parents, ok := tree.variableNodes[pkey]; ok && len(parents) > 0 {
-						// Link to most recent assignment (last in slice) for cleaner tree structure
-						mostRecentParent := parents[len(parents)-1]
-						mostRecentParent.Children = append(mostRecentParent.Children, childNode)
-					}
+				// The child keeps a copy of the edge.
+				edge := *frame.calleeEdges[frame.next]
+				frame.next++
+
+				calleeID := edge.Callee.ID()
+
+				idTypes := metadata.ExtractGenericTypes(frame.id)
+				calleeTypes := metadata.ExtractGenericTypes(calleeID)
+
+				if len(calleeTypes) > 0 && !metadata.IsSubset(idTypes, calleeTypes) {
+					// Skip this instance of callee when it's generic but is not including callers types
+					continue
 				}
 
-				childNode.AddChildren(argumentChildren)
-				if !addedToParent {
-					node.AddChild(childNode)
+				_, existsInArgs := meta.Args[metadata.StripToBase(calleeID)]
+
+				if edge.Callee.ID() == edge.Caller.ID() || getString(meta, edge.Callee.Name) == "nil" || existsInArgs {
+					// Skip this child as it's already present in arguments
+					continue
+				}
+
+				frame.calleeEdge = &edge
+				return calleeID, &edge, true
+			}
+
+		case framePhaseImplementations:
+			for frame.next < len(frame.implementationEdges) {
+				edge := frame.implementationEdges[frame.next]
+				frame.next++
+				concreteCalleeID := edge.Callee.ID()
+				if b.tree.nodeMap[concreteCalleeID] != nil {
+					continue
 				}
-				childCount++
+				return concreteCalleeID, edge, true
 			}
+
+		default:
+			return "", nil, false
 		}
+		frame.phase++
+		frame.next = 0
 	}
+}
 
-	// Handle interface method calls by resolving to concrete implementations
-	if parentEdge != nil && parentEdge.Callee.RecvType != -1 {
-		recvTypeName := getString(meta, parentEdge.Callee.RecvType)
-		calleePkg := getString(meta, parentEdge.Callee.Pkg)
-		methodName := getString(meta, parentEdge.Callee.Name)
-
-		if pkg, exists := meta.Packages[calleePkg]; exists {
-			for _, file := range pkg.Files {
-				if typ, exists := file.Types[recvTypeName]; exists {
-					kindStr := getString(meta, typ.Kind)
-					if kindStr == "interface" && len(typ.ImplementedBy) > 0 {
-						for _, implTypeIdx := range typ.ImplementedBy {
-							implTypeName := getString(meta, implTypeIdx)
-							// ImplementedBy is "import/path.Type"; the import path
-							// itself contains dots (github.com/…), so split on the
-							// LAST dot — not every dot — to separate pkg from type.
-							dot := strings.LastIndex(implTypeName, ".")
-							if dot <= 0 || dot == len(implTypeName)-1 {
-								continue
-							}
-							implPkg, implType := implTypeName[:dot], implTypeName[dot+1:]
-
-							if implPkgObj, exists := meta.Packages[implPkg]; exists {
-								for _, implFile := range implPkgObj.Files {
-									if implTypeObj, exists := implFile.Types[implType]; exists {
-										for _, method := range implTypeObj.Methods {
-											if getString(meta, method.Name) != methodName {
-												continue
-											}
-
-											concreteMethodID := implPkg + "." + implType + "." + methodName
-											if concreteEdges, exists := meta.Callers[concreteMethodID]; exists {
-												for _, concreteEdge := range concreteEdges {
-													concreteCalleeID := concreteEdge.Callee.ID()
-													if tree.nodeMap[concreteCalleeID] != nil {
-														continue
-													}
-
-													if childNode := NewTrackerNode(tree, meta, id, concreteCalleeID, concreteEdge, nil, visited, assignmentIndex, limits); childNode != nil {
-														node.AddChild(childNode)
-													}
-												}
-											}
-										}
-									}
-								}
-							}
-						}
+// accept adds child, just built for frame, to frame's node.
+func (b *trackerBuild) accept(frame *trackerFrame, child *TrackerNode) {
+	if child == nil {
+		return
+	}
+	if frame.phase != framePhaseCallees {
+		frame.node.AddChild(child)
+		return
+	}
+
+	tree, meta := b.tree, b.meta
+	edge := frame.calleeEdge
+
+	// Process arguments for this edge using enhanced processing; the child
+	// joins frame's node once their subtrees are built.
+	b.arguments(child, edge, frame.limits, func(argumentChildren []*TrackerNode) {
+		// If this node uses a variable as a receiver, link to its assignment node
+		if child.CallGraphEdge != nil && child.CalleeVarName != "" && edge.Callee.RecvType != -1 {
+			funcName := getString(meta, edge.Caller.Name)
+			callerPkg := getString(meta, edge.Caller.Pkg)
+			calleePkg := getString(meta, edge.Callee.Pkg)
+
+			// Optimize receiver type resolution
+			var calleeRecvType string
+			if edge.Callee.RecvType != -1 {
+				calleeRecvType = getString(meta, edge.Callee.RecvType)
+				if calleeRecvType != "" {
+					// Resolve interface types to concrete types using interface resolution
+					concreteRecvType := tree.ResolveInterfaceFromMetadata(calleeRecvType, "", calleePkg)
+					if concreteRecvType != calleeRecvType {
+						calleeRecvType = concreteRecvType
 					}
+
+					// Build fully qualified type name efficiently
+					if strings.HasPrefix(calleeRecvType, "*") {
+						calleeRecvType = "*" + calleePkg + "." + calleeRecvType[1:]
+					} else {
+						calleeRecvType = calleePkg + "." + calleeRecvType
+					}
+				}
+			}
+
+			// Trace variable origin once and cache results
+			originVar, originPkg, _, originFunc := tree.traceOrigin(
+				edge.CalleeVarName,
+				funcName,
+				callerPkg,
+			)
+
+			// Link to assignment node if found
+			if originVar != "" && originPkg != "" && originFunc != "" {
+				assignmentKey := assignmentKey{
+					Name:      originVar,
+					Pkg:       originPkg,
+					Type:      calleeRecvType,
+					Container: originFunc,
 				}
+				if parent, ok := (*b.assignmentIndex)[assignmentKey]; ok {
+					parent.Children = append(parent.Children, child)
+				}
+			}
+
+			// Link to variable node if found
+			pkey := paramKey{
+				Name:      edge.CalleeVarName,
+				Pkg:       callerPkg, // Use cached value
+				Container: funcName,  // Use cached value
+			}
+
+			if parents, ok := tree.variableNodes[pkey]; ok && len(parents) > 0 {
+				// Link to most recent assignment (last in slice) for cleaner tree structure
+				mostRecentParent := parents[len(parents)-1]
+				mostRecentParent.Children = append(mostRecentParent.Children, child)
 			}
 		}
-	}
 
+		child.AddChildren(argumentChildren)
+		frame.node.AddChild(child)
+		frame.childCount++
+	})
+}
+
+// exit finishes frame's node once its children are built.
+func (b *trackerBuild) exit(frame *trackerFrame) *TrackerNode {
 	// Add node to hash map for O(1) lookup optimization
-	nodeID := node.Key()
-	if nodeID != "" {
-		tree.nodeMap[nodeID] = node
+	node := frame.node
+	if nodeID := node.Key(); nodeID != "" {
+		b.tree.nodeMap[nodeID] = node
 	}
 
-	// Clean up recursion depth (single map operation)
-	visited[recursionDepthKey]--
-	if visited[recursionDepthKey] == 0 {
-		delete(visited, recursionDepthKey)
+	// Take the node off the current path
+	b.visited[frame.id]--
+	if b.visited[frame.id] == 0 {
+		delete(b.visited, frame.id)
 	}
 
 	return node
@@ -1808,7 +2151,8 @@ func interfaceImplementers(meta *metadata.Metadata, ifacePkg, ifaceType string)
 // recvDecl is an interface, its concrete implementers are resolved so the
 // closure defined on the implementing type is found. It only ever adds
 // ParentFunction matches, so it is purely additive to the direct-handler loop.
-func (t *TrackerTree) attachReturnedClosureBody(meta *metadata.Metadata, argNode *TrackerNode, recvDecl, method, pkg string, visited map[string]int, assignmentIndex *assigmentIndexMap, limits metadata.TrackerLimits) {
+func (b *trackerBuild) attachReturnedClosureBody(argNode *TrackerNode, recvDecl, method, pkg string, limits metadata.TrackerLimits) {
+	t, meta := b.tree, b.meta
 	if argNode == nil || method == "" || pkg == "" {
 		return
 	}
@@ -1853,9 +2197,7 @@ func (t *TrackerTree) attachReturnedClosureBody(meta *metadata.Metadata, argNode
 		for _, k := range t.parentFunctionEdges(meta, c.pkg, method, c.typ) {
 			e := &meta.CallGraph[k]
 			id := e.Callee.ID()
-			if childNode := NewTrackerNode(t, meta, argNode.Key(), id, e, nil, visited, assignmentIndex, clim); childNode != nil {
-				argNode.AddChild(childNode)
-			}
+			b.node(argNode.Key(), id, e, nil, clim, argNode.AddChild)
 		}
 	}
 }
@@ -16,8 +16,10 @@ package spec
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
+	"runtime/debug"
 	"strings"
 	"testing"
 	"time"
@@ -1414,3 +1416,137 @@ func TestTrackerWarnOnceDedupesPerNode(t *testing.T) {
 		t.Errorf("Expected one warning for nodeB, got %d: %q", c, got)
 	}
 }
+
+func TestTrackerTreeWithContextStopsBuilding(t *testing.T) {
+	stringPool := metadata.NewStringPool()
+	meta := &metadata.Metadata{StringPool: stringPool}
+	limits := metadata.TrackerLimits{
+		MaxNodesPerTree:    1000,
+		MaxChildrenPerNode: 100,
+		MaxRecursionDepth:  10,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tree := NewTrackerTree(meta, limits, nil, WithContext(ctx))
+	if node := NewTrackerNode(tree, meta, "parent", "test", nil, nil, make(map[string]int), nil, limits); node == nil {
+		t.Fatal("a live build should create nodes")
+	}
+
+	cancel()
+	if node := NewTrackerNode(tree, meta, "parent", "other", nil, nil, make(map[string]int), nil, limits); node != nil {
+		t.Error("a cancelled build should create no more nodes")
+	}
+	if tree := NewTrackerTree(meta, limits, nil, WithContext(ctx)); len(tree.GetRoots()) != 0 {
+		t.Errorf("cancelled tree has %d roots", len(tree.GetRoots()))
+	}
+}
+
+// TestTrackerTreeDeepChainUsesNoStack builds a call chain far deeper than a
+// recursive build could take under a small stack limit: the tree is built on
+// an explicit stack, so a regression crashes the test binary.
+func TestTrackerTreeDeepChainUsesNoStack(t *testing.T) {
+	const depth = 50000
+	edges := [][4]string{{"main", "main", "main", "f1"}}
+	for i := 1; i < depth; i++ {
+		edges = append(edges, [4]string{"main", fmt.Sprintf("f%d", i), "main", fmt.Sprintf("f%d", i+1)})
+	}
+	// Close a cycle back to the top of the chain.
+	edges = append(edges, [4]string{"main", fmt.Sprintf("f%d", depth), "main", "f1"})
+	meta := reachMeta(edges)
+
+	defer debug.SetMaxStack(debug.SetMaxStack(4 << 20))
+	tree := NewTrackerTree(meta, metadata.TrackerLimits{
+		MaxNodesPerTree:    2 * depth,
+		MaxChildrenPerNode: 10,
+		MaxArgsPerFunction: 10,
+		MaxNestedArgsDepth: 10,
+		MaxRecursionDepth:  1,
+	}, nil)
+
+	roots := tree.GetRoots()
+	if len(roots) != 1 {
+		t.Fatalf("roots = %d, want main", len(roots))
+	}
+	got := 0
+	for node := roots[0]; len(node.GetChildren()) > 0; node = node.GetChildren()[0] {
+		got++
+		if got > depth {
+			t.Fatal("the cycle back to f1 was followed")
+		}
+	}
+	if got != depth {
+		t.Errorf("chain depth = %d, want %d", got, depth)
+	}
+}
+
+// TestTrackerTreeDeepArgumentChainUsesNoStack does the same for arguments:
+// f0(f1(f2(...))) nests a call in each argument, and every argument's
+// subtree goes on the tree's explicit stack too.
+func TestTrackerTreeDeepArgumentChainUsesNoStack(t *testing.T) {
+	const depth = 50000
+	meta := reachMeta([][4]string{{"main", "main", "main", "f0"}})
+	call := func(name string) metadata.Call {
+		return metadata.Call{
+			Meta: meta, Name: meta.StringPool.Get(name), Pkg: meta.StringPool.Get("main"),
+			RecvType: -1, Position: -1, Scope: -1, SignatureStr: -1,
+		}
+	}
+	var args []*metadata.CallArgument
+	for i := depth; i > 0; i-- {
+		fun := metadata.NewCallArgument(meta)
+		fun.SetKind(metadata.KindIdent)
+		fun.SetName(fmt.Sprintf("f%d", i))
+		fun.SetPkg("main")
+		arg := metadata.NewCallArgument(meta)
+		arg.SetKind(metadata.KindCall)
+		arg.Fun = fun
+		arg.Args = args
+		arg.Edge = &metadata.CallGraphEdge{Caller: call("main"), Callee: call(fmt.Sprintf("f%d", i)), Args: args}
+		args = []*metadata.CallArgument{arg}
+	}
+	meta.CallGraph[0].Args = args
+	meta.BuildCallGraphMaps()
+
+	defer debug.SetMaxStack(debug.SetMaxStack(4 << 20))
+	tree := NewTrackerTree(meta, metadata.TrackerLimits{
+		MaxNodesPerTree:    2 * depth,
+		MaxChildrenPerNode: 10,
+		MaxArgsPerFunction: 10,
+		MaxNestedArgsDepth: 10,
+		MaxRecursionDepth:  1,
+	}, nil)
+
+	roots := tree.GetRoots()
+	if len(roots) != 1 {
+		t.Fatalf("roots = %d, want main", len(roots))
+	}
+	// main -> f0, then an argument node per nested call.
+	got := -1
+	for node := roots[0]; len(node.GetChildren()) > 0; node = node.GetChildren()[0] {
+		got++
+	}
+	if got != depth {
+		t.Errorf("argument chain depth = %d, want %d", got, depth)
+	}
+}
+
+func TestTrackerTreeZeroRecursionDepthStopsCycles(t *testing.T) {
+	meta := reachMeta([][4]string{
+		{"main", "main", "main", "a"},
+		{"main", "a", "main", "b"},
+		{"main", "b", "main", "a"},
+	})
+	tree := NewTrackerTree(meta, metadata.TrackerLimits{MaxNodesPerTree: 100, MaxChildrenPerNode: 10}, nil)
+	roots := tree.GetRoots()
+	if len(roots) != 1 {
+		t.Fatalf("roots = %d, want main", len(roots))
+	}
+	// main -> a -> b, and b's call back to a is not followed.
+	var names []string
+	for node := roots[0]; len(node.GetChildren()) > 0; node = node.GetChildren()[0] {
+		names = append(names, getString(meta, node.GetChildren()[0].GetEdge().Callee.Name))
+	}
+	if strings.Join(names, ",") != "a,b" {
+		t.Errorf("chain = %v, want a,b", names)
+	}
+}
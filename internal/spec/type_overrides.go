@@ -0,0 +1,200 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ehabterra/apispec/pkg/patterns"
+)
+
+// Nullability policies for pointer fields (SchemaOptions.NullablePointers).
+const (
+	NullablePointersAlways          = "always"
+	NullablePointersUnlessOmitempty = "unlessOmitempty"
+)
+
+// How a nullable schema is written (SchemaOptions.NullableStyle).
+const (
+	NullableStyleAnyOf     = "anyOf"
+	NullableStyleTypeArray = "typeArray"
+	NullableStyleNullable  = "nullable"
+)
+
+// ValidateExternalTypes rejects an externalTypes entry without a name, or
+// with neither a schema nor field overrides, and a field override without a
+// schema.
+func (c *APISpecConfig) ValidateExternalTypes() error {
+	for i, e := range c.ExternalTypes {
+		if strings.TrimSpace(e.Name) == "" {
+			return fmt.Errorf("externalTypes[%d]: name is required", i)
+		}
+		if e.OpenAPIType == nil && len(e.Fields) == 0 {
+			return fmt.Errorf("externalTypes[%d] (%s): needs an openapiType or fields", i, e.Name)
+		}
+		for _, field := range slices.Sorted(maps.Keys(e.Fields)) {
+			if field == "" || e.Fields[field] == nil {
+				return fmt.Errorf("externalTypes[%d] (%s): fields.%s: a property name and schema are required", i, e.Name, field)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateNullability rejects an unknown nullablePointers policy or
+// nullableStyle.
+func (o SchemaOptions) ValidateNullability() error {
+	switch o.NullablePointers {
+	case "", NullablePointersAlways, NullablePointersUnlessOmitempty:
+	default:
+		return fmt.Errorf("schemas.nullablePointers: unknown policy %q (want %q or %q)", o.NullablePointers, NullablePointersAlways, NullablePointersUnlessOmitempty)
+	}
+	switch o.NullableStyle {
+	case "", NullableStyleAnyOf, NullableStyleTypeArray, NullableStyleNullable:
+	default:
+		return fmt.Errorf("schemas.nullableStyle: unknown style %q (want %q, %q or %q)", o.NullableStyle, NullableStyleAnyOf, NullableStyleTypeArray, NullableStyleNullable)
+	}
+	return nil
+}
+
+// externalTypeMatch ranks how an externalTypes name matches goType: 3 for
+// the exact name, 2 for the short pkg-qualified name, 1 for a glob, matched
+// as the include/exclude filters are ("github.com/shopspring/decimal.*",
+// "*.Money", "github.com/acme/**"), and 0 for no match. Only the exact name
+// matches a wrapped type ([]T, *T, map[K]V).
+func externalTypeMatch(name, goType string) int {
+	goType = strings.ReplaceAll(goType, TypeSep, ".")
+	switch {
+	case name == goType:
+		return 3
+	case shortNameMatchesBare(name, goType):
+		return 2
+	case strings.ContainsAny(name, "*?") && isBareTypeName(goType) && patterns.Match(name, goType):
+		return 1
+	}
+	return 0
+}
+
+// matchExternalType returns the externalTypes entry keep accepts that
+// matches goType best — an exact name over a short name over a glob, and
+// the first declared among equals — or nil.
+func (c *APISpecConfig) matchExternalType(goType string, keep func(*ExternalType) bool) *ExternalType {
+	if c == nil {
+		return nil
+	}
+	var best *ExternalType
+	bestRank := 0
+	for i := range c.ExternalTypes {
+		e := &c.ExternalTypes[i]
+		if !keep(e) {
+			continue
+		}
+		if rank := externalTypeMatch(e.Name, goType); rank > bestRank {
+			best, bestRank = e, rank
+		}
+	}
+	return best
+}
+
+// externalType returns the externalTypes entry giving goType its schema, or
+// nil. Entries carrying only field overrides do not count.
+func (c *APISpecConfig) externalType(goType string) *ExternalType {
+	return c.matchExternalType(goType, func(e *ExternalType) bool { return e.OpenAPIType != nil })
+}
+
+// fieldOverride returns a copy of the schema an externalTypes entry gives
+// the property of goType named property (or goField, its Go name), or nil.
+func (c *APISpecConfig) fieldOverride(goType, property, goField string) *Schema {
+	e := c.matchExternalType(goType, func(e *ExternalType) bool {
+		return e.Fields[property] != nil || e.Fields[goField] != nil
+	})
+	if e == nil {
+		return nil
+	}
+	if s := e.Fields[property]; s != nil {
+		return cloneSchema(s)
+	}
+	return cloneSchema(e.Fields[goField])
+}
+
+// nullablePointer reports whether the policy documents a pointer field with
+// the given struct tag as nullable.
+func nullablePointer(cfg *APISpecConfig, tag string) bool {
+	if cfg == nil {
+		return false
+	}
+	switch cfg.Schemas.NullablePointers {
+	case NullablePointersAlways:
+		return true
+	case NullablePointersUnlessOmitempty:
+		return !jsonOmitempty(tag)
+	}
+	return false
+}
+
+// omitemptyOptional reports whether a property with the given struct tag is
+// kept out of `required` because encoding/json may leave it out.
+func omitemptyOptional(cfg *APISpecConfig, tag string) bool {
+	return cfg != nil && cfg.Schemas.OmitemptyOptional && jsonOmitempty(tag)
+}
+
+// jsonOmitempty reports whether the json tag has the omitempty or omitzero
+// option.
+func jsonOmitempty(tag string) bool {
+	v, _ := reflect.StructTag(tag).Lookup("json")
+	_, opts, _ := strings.Cut(v, ",")
+	for opt := range strings.SplitSeq(opts, ",") {
+		if opt == "omitempty" || opt == "omitzero" {
+			return true
+		}
+	}
+	return false
+}
+
+// nullableSchema documents s as also accepting null, written in the
+// configured style (see SchemaOptions.NullableStyle). The description moves
+// to the outermost schema, and an enum gains null, which it must list for
+// null to validate.
+func nullableSchema(cfg *APISpecConfig, s *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+	inner := *s
+	if len(inner.Enum) > 0 && !slices.Contains(inner.Enum, nil) {
+		inner.Enum = append(slices.Clone(inner.Enum), nil)
+	}
+	style := ""
+	if cfg != nil {
+		style = cfg.Schemas.NullableStyle
+	}
+	switch {
+	case style == NullableStyleNullable && inner.Ref != "":
+		return &Schema{Description: inner.Description, AllOf: []*Schema{{Ref: inner.Ref}}, Nullable: true}
+	case style == NullableStyleNullable:
+		inner.Nullable = true
+		return &inner
+	case style == NullableStyleTypeArray && inner.Type != "" && inner.Ref == "":
+		inner.AllowNull = true
+		return &inner
+	}
+	inner.Enum = s.Enum // the {type: null} branch admits null
+	out := &Schema{Description: inner.Description, AnyOf: []*Schema{&inner, {Type: "null"}}}
+	inner.Description = ""
+	return out
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestExternalType(t *testing.T) {
+	cfg := &APISpecConfig{ExternalTypes: []ExternalType{
+		{Name: "github.com/shopspring/decimal.*", OpenAPIType: &Schema{Type: "string", Format: "decimal"}},
+		{Name: "decimal.Decimal", OpenAPIType: &Schema{Type: "number"}},
+		{Name: "github.com/acme/**", Fields: map[string]*Schema{"total": {Type: "string"}}},
+		{Name: "github.com/acme/billing.Money", OpenAPIType: &Schema{Type: "integer"}},
+	}}
+	for _, tc := range []struct {
+		goType string
+		want   string // the matched schema's type; "" for none
+	}{
+		{"github.com/shopspring/decimal.NullDecimal", "string"}, // glob
+		{"github.com/shopspring/decimal.Decimal", "number"},     // short name over glob
+		{"github.com/shopspring/decimal" + TypeSep + "Decimal", "number"},
+		{"github.com/acme/billing.Money", "integer"}, // fields-only entries give no schema
+		{"github.com/acme/billing.Invoice", ""},
+		{"[]github.com/shopspring/decimal.NullDecimal", ""}, // wrapped: exact name only
+	} {
+		got := ""
+		if e := cfg.externalType(tc.goType); e != nil {
+			got = e.OpenAPIType.Type
+		}
+		if got != tc.want {
+			t.Errorf("externalType(%s) = %q, want %q", tc.goType, got, tc.want)
+		}
+	}
+	if (*APISpecConfig)(nil).externalType("decimal.Decimal") != nil {
+		t.Error("a nil config has no external types")
+	}
+}
+
+func TestFieldOverride(t *testing.T) {
+	cfg := &APISpecConfig{ExternalTypes: []ExternalType{
+		{Name: "github.com/acme/**", Fields: map[string]*Schema{"status": {Type: "string"}, "Total": {Type: "number"}}},
+		{Name: "github.com/acme/billing.Invoice", Fields: map[string]*Schema{"status": {Type: "string", Enum: []any{"draft", "paid"}}}},
+	}}
+	if s := cfg.fieldOverride("github.com/acme/billing.Invoice", "status", "Status"); s == nil || len(s.Enum) != 2 {
+		t.Errorf("status = %+v, want the exact entry's enum", s)
+	}
+	if s := cfg.fieldOverride("github.com/acme/billing.Invoice", "total", "Total"); s == nil || s.Type != "number" {
+		t.Errorf("total = %+v, want the glob entry's, by Go field name", s)
+	}
+	if s := cfg.fieldOverride("github.com/acme/billing.Invoice", "id", "ID"); s != nil {
+		t.Errorf("id = %+v, want no override", s)
+	}
+	s := cfg.fieldOverride("github.com/acme/billing.Invoice", "status", "Status")
+	s.Description = "decorated"
+	if cfg.ExternalTypes[1].Fields["status"].Description != "" {
+		t.Error("fieldOverride must return a copy")
+	}
+}
+
+func TestNullablePointer(t *testing.T) {
+	for _, tc := range []struct {
+		policy, tag string
+		want        bool
+	}{
+		{"", `json:"note"`, false},
+		{NullablePointersAlways, `json:"note,omitempty"`, true},
+		{NullablePointersUnlessOmitempty, `json:"note"`, true},
+		{NullablePointersUnlessOmitempty, ``, true},
+		{NullablePointersUnlessOmitempty, `json:"note,omitempty"`, false},
+		{NullablePointersUnlessOmitempty, `json:",omitzero"`, false},
+	} {
+		cfg := &APISpecConfig{Schemas: SchemaOptions{NullablePointers: tc.policy}}
+		if got := nullablePointer(cfg, tc.tag); got != tc.want {
+			t.Errorf("nullablePointer(%q, %s) = %v, want %v", tc.policy, tc.tag, got, tc.want)
+		}
+	}
+
+}
+
+func TestNullableSchema(t *testing.T) {
+	ref := &Schema{Ref: "#/components/schemas/Customer", Description: "Billed to."}
+	status := &Schema{Type: "string", Enum: []any{"draft", "paid"}}
+	for _, tc := range []struct {
+		style  string
+		schema *Schema
+		want   string
+	}{
+		{"", ref, `{"description":"Billed to.","anyOf":[{"$ref":"#/components/schemas/Customer"},{"type":"null"}]}`},
+		{NullableStyleAnyOf, status, `{"anyOf":[{"type":"string","enum":["draft","paid"]},{"type":"null"}]}`},
+		{NullableStyleTypeArray, status, `{"type":["string","null"],"enum":["draft","paid",null]}`},
+		{NullableStyleTypeArray, ref, `{"description":"Billed to.","anyOf":[{"$ref":"#/components/schemas/Customer"},{"type":"null"}]}`},
+		{NullableStyleNullable, status, `{"type":"string","enum":["draft","paid",null],"nullable":true}`},
+		{NullableStyleNullable, ref, `{"description":"Billed to.","allOf":[{"$ref":"#/components/schemas/Customer"}],"nullable":true}`},
+	} {
+		cfg := &APISpecConfig{Schemas: SchemaOptions{NullableStyle: tc.style}}
+		got, err := json.Marshal(nullableSchema(cfg, tc.schema))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("%q: nullableSchema = %s, want %s", tc.style, got, tc.want)
+		}
+	}
+	if len(status.Enum) != 2 || ref.Description == "" {
+		t.Error("nullableSchema must not modify its argument")
+	}
+}
+
+func TestSchemaAllowNull_YAML(t *testing.T) {
+	out, err := yaml.Marshal(map[string]*Schema{"note": {Type: "string", AllowNull: true, MaxLength: 200}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "note:\n    type: [string, \"null\"]\n    maxLength: 200\n"; string(out) != want {
+		t.Errorf("yaml =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestOmitemptyOptional(t *testing.T) {
+	cfg := &APISpecConfig{Schemas: SchemaOptions{OmitemptyOptional: true}}
+	if !omitemptyOptional(cfg, `json:"note,omitempty" validate:"required"`) || !omitemptyOptional(cfg, `json:"at,omitzero"`) {
+		t.Error("an omitempty or omitzero property is optional")
+	}
+	if omitemptyOptional(cfg, `json:"note" validate:"required"`) || omitemptyOptional(&APISpecConfig{}, `json:"note,omitempty"`) {
+		t.Error("only omitempty properties, and only with the option set, are optional")
+	}
+}
+
+func TestValidateExternalTypes(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		cfg     APISpecConfig
+		wantErr bool
+	}{
+		{"schema", APISpecConfig{ExternalTypes: []ExternalType{{Name: "decimal.*", OpenAPIType: &Schema{Type: "string"}}}}, false},
+		{"fields", APISpecConfig{ExternalTypes: []ExternalType{{Name: "*.Invoice", Fields: map[string]*Schema{"status": {Type: "string"}}}}}, false},
+		{"no name", APISpecConfig{ExternalTypes: []ExternalType{{OpenAPIType: &Schema{Type: "string"}}}}, true},
+		{"nothing to apply", APISpecConfig{ExternalTypes: []ExternalType{{Name: "decimal.Decimal"}}}, true},
+		{"field without schema", APISpecConfig{ExternalTypes: []ExternalType{{Name: "*.Invoice", Fields: map[string]*Schema{"status": nil}}}}, true},
+	} {
+		if err := tc.cfg.ValidateExternalTypes(); (err != nil) != tc.wantErr {
+			t.Errorf("%s: ValidateExternalTypes() = %v, wantErr %v", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestValidateNullability(t *testing.T) {
+	for _, tc := range []struct {
+		opts    SchemaOptions
+		wantErr bool
+	}{
+		{SchemaOptions{}, false},
+		{SchemaOptions{NullablePointers: NullablePointersUnlessOmitempty, NullableStyle: NullableStyleNullable}, false},
+		{SchemaOptions{NullablePointers: "sometimes"}, true},
+		{SchemaOptions{NullableStyle: "oneOf"}, true},
+	} {
+		if err := tc.opts.ValidateNullability(); (err != nil) != tc.wantErr {
+			t.Errorf("ValidateNullability(%+v) = %v, wantErr %v", tc.opts, err, tc.wantErr)
+		}
+	}
+}
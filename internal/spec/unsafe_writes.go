@@ -0,0 +1,158 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/diag"
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// UnsafeMethodWrite records a GET or HEAD handler that reaches a mutation
+// call — surfaced as a diagnostic, since clients, caches and crawlers assume
+// those methods are safe to repeat.
+type UnsafeMethodWrite struct {
+	Method  string // HTTP method
+	Path    string // OpenAPI path
+	Handler string // handler function (package-qualified)
+	Callee  string // the mutation called, e.g. store.Users.DeleteStale
+	Depth   int    // calls between the handler and the mutation, 1 when direct
+	Pos     string // where the mutation is called ("file:line:col")
+}
+
+// mutationMethodRe matches the method names repositories and database
+// handles write with: Create, UpdateUser, ExecContext, but not Executor.
+var mutationMethodRe = regexp.MustCompile(`^(Create|Insert|Update|Upsert|Save|Store|Put|Patch|Delete|Remove|Drop|Truncate|Exec)([A-Z0-9_].*)?$`)
+
+// isMutationCall reports whether edge calls a method that writes: a
+// mutation-named method of a type outside the standard library, or of
+// database/sql. Standard library containers (sync.Map.Store, http.Header)
+// write only to memory and are not counted.
+func isMutationCall(meta *metadata.Metadata, edge *metadata.CallGraphEdge) bool {
+	if getString(meta, edge.Callee.RecvType) == "" {
+		return false
+	}
+	if !mutationMethodRe.MatchString(getString(meta, edge.Callee.Name)) {
+		return false
+	}
+	pkg := getString(meta, edge.Callee.Pkg)
+	return pkg == "database/sql" || !isStandardPackage(pkg)
+}
+
+// isStandardPackage reports whether pkg is a standard library import path:
+// its first element has no dot.
+func isStandardPackage(pkg string) bool {
+	first, _, _ := strings.Cut(pkg, "/")
+	return pkg != "" && !strings.Contains(first, ".")
+}
+
+// UnsafeMethodWrites returns the GET and HEAD handlers found to perform
+// writes during extraction.
+func (e *Extractor) UnsafeMethodWrites() []UnsafeMethodWrite {
+	return e.unsafeMethodWrites
+}
+
+// recordUnsafeMethodWrite looks for a mutation call reachable from a GET or
+// HEAD route's handler, nearest first (a BFS over meta.Callers), and records
+// the first one found.
+func (e *Extractor) recordUnsafeMethodWrite(route *RouteInfo) {
+	if route == nil || route.Function == "" || (route.Method != http.MethodGet && route.Method != http.MethodHead) {
+		return
+	}
+	meta := route.Metadata
+	if meta == nil {
+		meta = e.tree.GetMetadata()
+	}
+	if meta == nil {
+		return
+	}
+	edge, depth := nearestMutation(meta, route.Function)
+	if edge == nil {
+		return
+	}
+	openAPIPath := route.OpenAPIPath()
+	dedup := route.Method + " " + openAPIPath
+	if e.unsafeMethodWriteSet == nil {
+		e.unsafeMethodWriteSet = make(map[string]struct{})
+	}
+	if _, ok := e.unsafeMethodWriteSet[dedup]; ok {
+		return
+	}
+	e.unsafeMethodWriteSet[dedup] = struct{}{}
+	e.unsafeMethodWrites = append(e.unsafeMethodWrites, UnsafeMethodWrite{
+		Method:  route.Method,
+		Path:    openAPIPath,
+		Handler: route.Function,
+		Callee:  mutationCallee(meta, edge),
+		Depth:   depth,
+		Pos:     getString(meta, edge.Position),
+	})
+}
+
+// nearestMutation returns the mutation call closest to fn in the call graph
+// and its depth (1 for a call fn makes itself), or nil when fn reaches none.
+func nearestMutation(meta *metadata.Metadata, fn string) (*metadata.CallGraphEdge, int) {
+	depths := map[string]int{fn: 0}
+	queue := []string{fn}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		next := depths[cur] + 1
+		for _, edge := range meta.Callers[cur] {
+			if isMutationCall(meta, edge) {
+				return edge, next
+			}
+			callee := edge.Callee.BaseID()
+			if _, ok := depths[callee]; ok {
+				continue
+			}
+			depths[callee] = next
+			queue = append(queue, callee)
+		}
+	}
+	return nil, 0
+}
+
+// mutationCallee names the method an edge calls as pkg.Type.Method, the
+// package shortened to its last element.
+func mutationCallee(meta *metadata.Metadata, edge *metadata.CallGraphEdge) string {
+	recv := strings.TrimPrefix(getString(meta, edge.Callee.RecvType), "*")
+	if i := strings.LastIndex(recv, "/"); i >= 0 {
+		recv = recv[i+1:]
+	}
+	if !strings.Contains(recv, ".") {
+		pkg := getString(meta, edge.Callee.Pkg)
+		recv = pkg[strings.LastIndex(pkg, "/")+1:] + "." + recv
+	}
+	return recv + "." + getString(meta, edge.Callee.Name)
+}
+
+func (w UnsafeMethodWrite) diagnostic() diag.Diagnostic {
+	calls := "calls " + w.Callee
+	if w.Depth > 1 {
+		calls = fmt.Sprintf("reaches %s %d calls down", w.Callee, w.Depth)
+	}
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Category: "idempotency",
+		Message:  fmt.Sprintf("%s %s: handler %s %s, but %s must not change state", w.Method, w.Path, w.Handler, calls, w.Method),
+		Pos:      diag.ParsePosition(w.Pos),
+		Help:     "serve the write with POST, PUT or DELETE",
+	}
+}
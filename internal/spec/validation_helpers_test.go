@@ -14,7 +14,12 @@
 
 package spec
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
 
 // TestValidateTagValue covers the struct-tag extractor: present, absent, and a
 // malformed (unterminated) validate tag.
@@ -59,6 +64,19 @@ func TestAppendConstraintNote(t *testing.T) {
 	}
 }
 
+// boundIs reports whether the schema bound p is set to want.
+func boundIs(p *float64, want float64) bool {
+	return p != nil && *p == want
+}
+
+// bound is the schema bound p for a failure message, nil when unset.
+func bound(p *float64) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
 // TestApplyValidationConstraints_ByType pins that min/max route by schema type:
 // length for strings, item-count for arrays (with post-dive on items), and
 // value for numbers.
@@ -73,8 +91,8 @@ func TestApplyValidationConstraints_ByType(t *testing.T) {
 
 	num := &Schema{Type: "integer"}
 	applyValidationConstraints(num, &ValidationConstraints{Min: f(18), Max: f(120)})
-	if num.Minimum != 18 || num.Maximum != 120 {
-		t.Errorf("integer: got minimum=%v maximum=%v, want 18/120", num.Minimum, num.Maximum)
+	if !boundIs(num.Minimum, 18) || !boundIs(num.Maximum, 120) {
+		t.Errorf("integer: got minimum=%v maximum=%v, want 18/120", bound(num.Minimum), bound(num.Maximum))
 	}
 
 	arr := &Schema{Type: "array", Items: &Schema{Type: "integer"}}
@@ -85,7 +103,154 @@ func TestApplyValidationConstraints_ByType(t *testing.T) {
 	if arr.MinItems != 1 || arr.MaxItems != 10 {
 		t.Errorf("array: got minItems=%d maxItems=%d, want 1/10", arr.MinItems, arr.MaxItems)
 	}
-	if arr.Items.Minimum != 5 || arr.Items.Maximum != 100 {
-		t.Errorf("array items: got minimum=%v maximum=%v, want 5/100", arr.Items.Minimum, arr.Items.Maximum)
+	if !boundIs(arr.Items.Minimum, 5) || !boundIs(arr.Items.Maximum, 100) {
+		t.Errorf("array items: got minimum=%v maximum=%v, want 5/100", bound(arr.Items.Minimum), bound(arr.Items.Maximum))
+	}
+}
+
+// TestExtractValidationConstraints_Vocabulary covers the comparison aliases
+// (gt/gte/lt/lte), quoted oneof values, the format and pattern rules, unique,
+// map key dives and conditional requirements.
+func TestExtractValidationConstraints_Vocabulary(t *testing.T) {
+	c := extractValidationConstraints(`validate:"gt=0,lte=99"`)
+	if c == nil || c.Min == nil || *c.Min != 0 || !c.ExclusiveMin || c.Max == nil || *c.Max != 99 || c.ExclusiveMax {
+		t.Fatalf("gt/lte: got %+v", c)
+	}
+
+	c = extractValidationConstraints(`validate:"oneof='dark blue' red"`)
+	if c == nil || len(c.Enum) != 2 || c.Enum[0] != "dark blue" || c.Enum[1] != "red" {
+		t.Errorf("quoted oneof: got %+v", c)
+	}
+
+	formats := map[string]string{
+		`validate:"http_url"`:                           "uri",
+		`validate:"uri"`:                                "uri",
+		`validate:"uuid_rfc4122"`:                       "uuid",
+		`validate:"datetime=2006-01-02"`:                "date",
+		`validate:"datetime=2006-01-02T15:04:05Z07:00"`: "date-time",
+	}
+	for tag, want := range formats {
+		if c := extractValidationConstraints(tag); c == nil || c.Format != want {
+			t.Errorf("%s: got %+v, want format %q", tag, c, want)
+		}
+	}
+
+	if c := extractValidationConstraints(`validate:"startswith=a.b"`); c == nil || c.Pattern != `^a\.b` {
+		t.Errorf("startswith: got %+v", c)
+	}
+	if c := extractValidationConstraints(`validate:"unique"`); c == nil || !c.UniqueItems {
+		t.Errorf("unique: got %+v", c)
+	}
+
+	c = extractValidationConstraints(`validate:"required_if=Kind card"`)
+	if c == nil || c.Required || len(c.Conditions) != 1 || c.Conditions[0] != "required_if=Kind card" {
+		t.Errorf("required_if: got %+v", c)
+	}
+
+	c = extractValidationConstraints(`validate:"dive,keys,min=1,endkeys,max=20"`)
+	if c == nil || c.Dive == nil || c.Dive.Max == nil || *c.Dive.Max != 20 || c.Dive.Min != nil {
+		t.Errorf("map dive keys: got %+v (dive %+v)", c, c.Dive)
+	}
+}
+
+// TestApplyValidationConstraints_Vocabulary pins how the wider rule set lands
+// on each schema type.
+func TestApplyValidationConstraints_Vocabulary(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	str := &Schema{Type: "string"}
+	applyValidationConstraints(str, &ValidationConstraints{Min: f(2), ExclusiveMin: true, Max: f(16), ExclusiveMax: true})
+	if str.MinLength != 3 || str.MaxLength != 15 {
+		t.Errorf("string gt/lt: got minLength=%d maxLength=%d, want 3/15", str.MinLength, str.MaxLength)
+	}
+
+	num := &Schema{Type: "number"}
+	applyValidationConstraints(num, &ValidationConstraints{Min: f(0.5), ExclusiveMin: true, Max: f(5)})
+	if num.Minimum != nil || !boundIs(num.ExclusiveMinimum, 0.5) || !boundIs(num.Maximum, 5) || num.ExclusiveMaximum != nil {
+		t.Errorf("number gt/lte: got %+v", num)
+	}
+
+	in := &Schema{Type: "integer"}
+	applyValidationConstraints(in, &ValidationConstraints{Enum: []interface{}{"1", "2"}})
+	if len(in.Enum) != 2 || in.Enum[0] != int64(1) {
+		t.Errorf("integer oneof: got %#v", in.Enum)
+	}
+
+	arr := &Schema{Type: "array", Items: &Schema{Type: "string"}}
+	applyValidationConstraints(arr, &ValidationConstraints{UniqueItems: true, Max: f(5)})
+	if !arr.UniqueItems || arr.MaxItems != 5 {
+		t.Errorf("array unique: got %+v", arr)
+	}
+
+	m := &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}
+	applyValidationConstraints(m, &ValidationConstraints{Dive: &ValidationConstraints{Max: f(20)}})
+	if ap := m.AdditionalProperties; ap == nil || ap.MaxLength != 20 {
+		t.Errorf("map dive: got %+v", m.AdditionalProperties)
+	}
+
+	cond := &Schema{Type: "string"}
+	applyValidationConstraints(cond, &ValidationConstraints{Conditions: []string{"required_without=Email"}})
+	if cond.Description != "Conditionally required: required_without=Email" {
+		t.Errorf("conditional note: got %q", cond.Description)
+	}
+}
+
+// TestMarkBooleanBounds pins that exclusive bounds stay numeric in a 3.1
+// document and are written as booleans in a 3.0 one, without touching the
+// schemas a 3.0 document shares with others.
+func TestMarkBooleanBounds(t *testing.T) {
+	shared := &Schema{Type: "integer", ExclusiveMinimum: float64Ptr(0), Maximum: float64Ptr(5)}
+	doc := func(version string) *OpenAPISpec {
+		return &OpenAPISpec{
+			OpenAPI: version,
+			Paths: map[string]PathItem{"/items": {Get: &Operation{
+				Parameters: []Parameter{{Name: "page", In: "query", Schema: shared}},
+			}}},
+			Components: &Components{Schemas: map[string]*Schema{
+				"Item": {Type: "object", Properties: map[string]*Schema{"debt": {Type: "number", ExclusiveMaximum: float64Ptr(0)}}},
+			}},
+		}
+	}
+
+	v31 := doc("3.1.1")
+	markBooleanBounds(v31)
+	if got, _ := json.Marshal(v31.Paths["/items"].Get.Parameters[0].Schema); string(got) != `{"type":"integer","maximum":5,"exclusiveMinimum":0}` {
+		t.Errorf("3.1 page = %s", got)
+	}
+
+	v30 := doc("3.0.3")
+	markBooleanBounds(v30)
+	page := v30.Paths["/items"].Get.Parameters[0].Schema
+	if got, _ := json.Marshal(page); string(got) != `{"type":"integer","minimum":0,"maximum":5,"exclusiveMinimum":true}` {
+		t.Errorf("3.0 page = %s", got)
+	}
+	debt := v30.Components.Schemas["Item"].Properties["debt"]
+	if got, _ := yaml.Marshal(debt); string(got) != "type: number\nmaximum: 0\nexclusiveMaximum: true\n" {
+		t.Errorf("3.0 debt =\n%s", got)
+	}
+	if shared.booleanBounds || page == shared {
+		t.Error("markBooleanBounds must copy a shared schema, not modify it")
+	}
+}
+
+// TestSchemaUnmarshalYAML_BooleanBounds pins that a 3.0 boolean exclusive
+// bound in configuration reads as the numeric bound it makes exclusive.
+func TestSchemaUnmarshalYAML_BooleanBounds(t *testing.T) {
+	var s Schema
+	src := "type: number\nminimum: 0.5\nexclusiveMinimum: true\nmaximum: 5\nexclusiveMaximum: false\n"
+	if err := yaml.Unmarshal([]byte(src), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Minimum != nil || !boundIs(s.ExclusiveMinimum, 0.5) || !boundIs(s.Maximum, 5) || s.ExclusiveMaximum != nil {
+		t.Errorf("got minimum=%v exclusiveMinimum=%v maximum=%v exclusiveMaximum=%v",
+			bound(s.Minimum), bound(s.ExclusiveMinimum), bound(s.Maximum), bound(s.ExclusiveMaximum))
+	}
+
+	var zero Schema
+	if err := yaml.Unmarshal([]byte("exclusiveMinimum: true\n"), &zero); err != nil {
+		t.Fatal(err)
+	}
+	if !boundIs(zero.ExclusiveMinimum, 0) {
+		t.Errorf("an omitted minimum is 0; got exclusiveMinimum=%v", bound(zero.ExclusiveMinimum))
 	}
 }
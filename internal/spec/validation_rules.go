@@ -0,0 +1,194 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// defaultValidationTag is the struct tag a ValidationPattern reads when it
+// names none — go-playground/validator's.
+const defaultValidationTag = "validate"
+
+// applyRouteValidation merges the constraints of per-route validation
+// middleware (see ValidationPattern) into the route's request body. The
+// middleware is looked for where route-scope security middleware lives: the
+// registration call's own arguments (gin's r.POST(path, mw, h)) and its chain
+// parents (chi's r.With(mw).Post(...)). The body keeps its $ref and gains an
+// allOf overlay, so the shared component is not constrained for other routes.
+func (e *Extractor) applyRouteValidation(node TrackerNodeInterface, route *RouteInfo) {
+	if len(e.cfg.Framework.ValidationPatterns) == 0 || node == nil || node.GetEdge() == nil {
+		return
+	}
+	if route.Request == nil || route.Request.Schema == nil {
+		return // constraints need a body to attach to
+	}
+	meta := route.Metadata
+	if meta == nil {
+		meta = e.tree.GetMetadata()
+	}
+	if meta == nil {
+		return
+	}
+
+	var overlays []*Schema
+	for edge := node.GetEdge(); edge != nil; edge = edge.ChainParent {
+		for _, arg := range edge.Args {
+			if overlay := e.validationOverlay(arg, edge, meta, route); overlay != nil {
+				overlays = append(overlays, overlay)
+			}
+		}
+	}
+	if len(overlays) == 0 {
+		return
+	}
+	route.Request.Schema = &Schema{AllOf: append([]*Schema{route.Request.Schema}, overlays...)}
+}
+
+// validationOverlay returns the constraint overlay for a middleware argument
+// built by a configured validation constructor, or nil when arg is not one or
+// its rules struct cannot be resolved.
+func (e *Extractor) validationOverlay(arg *metadata.CallArgument, edge *metadata.CallGraphEdge, meta *metadata.Metadata, route *RouteInfo) *Schema {
+	if arg == nil || arg.GetKind() != metadata.KindCall {
+		return nil
+	}
+	ref, ok := middlewareRefFromArg(arg)
+	if !ok {
+		return nil
+	}
+	for _, p := range e.cfg.Framework.ValidationPatterns {
+		if !matchValidationPattern(p, ref) || p.RulesArgIndex < 0 || p.RulesArgIndex >= len(arg.Args) {
+			continue
+		}
+		rules := rulesStructType(compositeLitOf(arg.Args[p.RulesArgIndex]), e.contextProvider.GetString(edge.Caller.Pkg), meta)
+		if rules == nil {
+			continue
+		}
+		tagName := p.TagName
+		if tagName == "" {
+			tagName = defaultValidationTag
+		}
+		return rulesOverlaySchema(rules, tagName, meta, route, e.cfg)
+	}
+	return nil
+}
+
+// matchValidationPattern reports whether the constructor identity matches p.
+func matchValidationPattern(p ValidationPattern, ref MiddlewareRef) bool {
+	if p.CallRegex == "" {
+		return false
+	}
+	if re, err := cachedRegex(p.CallRegex); err != nil || !re.MatchString(ref.FunctionName) {
+		return false
+	}
+	if p.PkgRegex != "" {
+		if re, err := cachedRegex(p.PkgRegex); err != nil || !re.MatchString(ref.Pkg) {
+			return false
+		}
+	}
+	return true
+}
+
+// rulesStructType resolves the type of a rules composite literal: from its
+// recorded type when present, else by name in the registering package, else
+// by name across all packages.
+func rulesStructType(lit *metadata.CallArgument, callerPkg string, meta *metadata.Metadata) *metadata.Type {
+	if lit == nil {
+		return nil
+	}
+	if t := strings.TrimPrefix(lit.GetType(), "*"); t != "" {
+		if dot := strings.LastIndex(t, "."); dot > 0 {
+			if typ := findType(meta, t[:dot], t[dot+1:]); typ != nil {
+				return typ
+			}
+		}
+	}
+	if lit.X == nil {
+		return nil
+	}
+	name := lit.X.GetName()
+	if lit.X.GetKind() == metadata.KindSelector && lit.X.Sel != nil {
+		return findTypeAnywhere(meta, lit.X.Sel.GetName())
+	}
+	if typ := findType(meta, callerPkg, name); typ != nil {
+		return typ
+	}
+	return findTypeAnywhere(meta, name)
+}
+
+// rulesOverlaySchema builds the allOf overlay for a rules struct: one property
+// per field carrying rules in tagName, keyed by its JSON name and typed from
+// the field so length/range rules land on the right keywords. Fields whose
+// type is not primitive (or a slice of primitives) contribute only `required`.
+func rulesOverlaySchema(rules *metadata.Type, tagName string, meta *metadata.Metadata, route *RouteInfo, cfg *APISpecConfig) *Schema {
+	overlay := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := range rules.Fields {
+		field := &rules.Fields[i]
+		tag := getStringFromPool(meta, field.Tag)
+		value, ok := reflect.StructTag(tag).Lookup(tagName)
+		if !ok || value == "" || value == "-" {
+			continue
+		}
+		constraints := extractValidationConstraints(`validate:"` + value + `"`)
+		if constraints == nil {
+			continue
+		}
+		name := propertyName(cfg, tag)
+		if name == "" {
+			name = getStringFromPool(meta, field.Name)
+		}
+		if constraints.Required {
+			overlay.Required = append(overlay.Required, name)
+		}
+		prop := ruleFieldSchema(getStringFromPool(meta, field.Type), meta, route, cfg)
+		applyValidationConstraints(prop, constraints)
+		overlay.Properties[name] = prop
+	}
+	if len(overlay.Properties) == 0 {
+		return nil
+	}
+	sort.Strings(overlay.Required)
+	return overlay
+}
+
+// ruleFieldSchema types a rules field for constraint placement without
+// registering components: primitives and slices of primitives map as usual,
+// anything else stays an untyped schema.
+func ruleFieldSchema(goType string, meta *metadata.Metadata, route *RouteInfo, cfg *APISpecConfig) *Schema {
+	goType = strings.TrimPrefix(goType, "*")
+	elem := goType
+	for strings.HasPrefix(elem, "[]") {
+		elem = strings.TrimPrefix(strings.TrimPrefix(elem, "[]"), "*")
+	}
+	if resolved := resolveUnderlyingType(elem, meta); resolved != "" && metadata.IsPrimitiveType(resolved) {
+		goType = strings.TrimSuffix(goType, elem) + resolved
+		elem = resolved
+	}
+	if !metadata.IsPrimitiveType(elem) {
+		if strings.HasPrefix(goType, "[]") {
+			return &Schema{Type: "array"}
+		}
+		return &Schema{}
+	}
+	schema, _ := mapGoTypeToOpenAPISchema(route.UsedTypes, goType, meta, cfg, nil)
+	if schema == nil {
+		return &Schema{}
+	}
+	return cloneSchema(schema)
+}
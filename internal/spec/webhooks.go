@@ -0,0 +1,242 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ehabterra/apispec/internal/diag"
+	"github.com/ehabterra/apispec/internal/metadata"
+)
+
+// protocolPattern translates c into the ProtocolPattern its calls are
+// matched with.
+func (c WebhookCall) protocolPattern() ProtocolPattern {
+	return ProtocolPattern{
+		CallRegex:         c.CallRegex,
+		FunctionNameRegex: c.FunctionNameRegex,
+		RecvType:          c.RecvType,
+		RecvTypeRegex:     c.RecvTypeRegex,
+		ArgIndex:          c.ArgIndex,
+		ArgValueRegex:     c.ArgValueRegex,
+	}
+}
+
+// webhooksOf returns the names of the configured webhooks a node's call
+// delivers, memoized per edge like protocolOf.
+func (e *Extractor) webhooksOf(node TrackerNodeInterface) []string {
+	if len(e.webhookMatchers) == 0 || node == nil || node.GetEdge() == nil {
+		return nil
+	}
+	edge := node.GetEdge()
+	if names, ok := e.webhooksByEdge[edge]; ok {
+		return names
+	}
+	var names []string
+	for i, matchers := range e.webhookMatchers {
+		for _, matcher := range matchers {
+			if matcher.MatchNode(node) {
+				names = append(names, e.cfg.Webhooks[i].Name)
+				break
+			}
+		}
+	}
+	if e.webhooksByEdge == nil {
+		e.webhooksByEdge = map[*metadata.CallGraphEdge][]string{}
+	}
+	e.webhooksByEdge[edge] = names
+	return names
+}
+
+// addWebhooks records webhooks the route's handler delivers, once each.
+func addWebhooks(route *RouteInfo, names ...string) {
+	for _, name := range names {
+		if !slices.Contains(route.Webhooks, name) {
+			route.Webhooks = append(route.Webhooks, name)
+		}
+	}
+}
+
+// webhookInfo is a configured webhook resolved against the analyzed code.
+type webhookInfo struct {
+	Webhook
+	// item describes the delivery: the payload as request body and the
+	// acknowledgement the subscriber answers with.
+	item PathItem
+	// route carries the payload schema's used types into the components.
+	route *RouteInfo
+	// delivered is true when some call in the code matches Calls.
+	delivered bool
+}
+
+// resolveWebhooks resolves the configured webhooks: the payload type to a
+// schema, and whether anything in the call graph — handlers, workers,
+// background notifiers — makes one of the delivering calls. Entries without
+// a name, and payload types that name nothing, are reported.
+func (e *Extractor) resolveWebhooks() []*webhookInfo {
+	if e.cfg == nil || len(e.cfg.Webhooks) == 0 {
+		return nil
+	}
+	meta := e.tree.GetMetadata()
+	delivered := map[string]bool{}
+	if meta != nil {
+		for i := range meta.CallGraph {
+			for _, name := range e.webhooksOf(&TrackerNode{CallGraphEdge: &meta.CallGraph[i]}) {
+				delivered[name] = true
+			}
+		}
+	}
+
+	var webhooks []*webhookInfo
+	for i, w := range e.cfg.Webhooks {
+		if w.Name == "" {
+			reportWebhook(fmt.Sprintf("webhooks[%d] has no name and is ignored", i), "give it a name")
+			continue
+		}
+		info := &webhookInfo{Webhook: w, delivered: delivered[w.Name]}
+		info.route = NewRouteInfo()
+		info.route.Metadata = meta
+		info.item = e.webhookItem(info)
+		webhooks = append(webhooks, info)
+	}
+	return webhooks
+}
+
+// webhookItem builds the path item describing a webhook's delivery.
+func (e *Extractor) webhookItem(w *webhookInfo) PathItem {
+	op := &Operation{
+		OperationID: w.OperationID,
+		Summary:     w.Summary,
+		Description: w.Description,
+		Tags:        w.Tags,
+		Responses: map[string]Response{
+			"200": {Description: "Return a 2xx status to acknowledge the delivery"},
+		},
+	}
+	if w.PayloadType != "" {
+		goType := e.annotationGoType(w.route, "", w.PayloadType)
+		if goType == "" {
+			reportWebhook(fmt.Sprintf("webhook %s: payload type %s is not declared in the analyzed packages", w.Name, w.PayloadType), "name it as in source (models.Event) or by import path")
+		} else {
+			schema, _ := mapGoTypeToOpenAPISchema(w.route.UsedTypes, goType, w.route.Metadata, e.cfg, nil)
+			contentType := w.ContentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]MediaType{contentType: {Schema: schema}},
+			}
+		}
+	}
+	method := strings.ToUpper(w.Method)
+	if method == "" {
+		method = http.MethodPost
+	}
+	var item PathItem
+	setOperationOnPathItem(&item, method, op)
+	return item
+}
+
+// webhookItems returns the document's webhooks: the configured webhooks that
+// are not callbacks and, when they list delivering calls, are delivered.
+// OpenAPI 3.0 has no webhooks; they are reported and left out.
+func webhookItems(webhooks []*webhookInfo, openAPIVersion string) map[string]PathItem {
+	items := map[string]PathItem{}
+	for _, w := range webhooks {
+		switch {
+		case w.CallbackURL != "":
+			if len(w.Calls) == 0 {
+				reportWebhook(fmt.Sprintf("webhook %s has a callbackURL but no calls, so no operation sends it", w.Name), "add the calls that deliver it")
+			}
+		case len(w.Calls) > 0 && !w.delivered:
+			reportWebhook(fmt.Sprintf("webhook %s: no call in the analyzed code matches its calls; it is left out", w.Name), "check the calls against the call that delivers it")
+		default:
+			items[w.Name] = w.item
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	if strings.HasPrefix(openAPIVersion, "3.0") {
+		reportWebhook(fmt.Sprintf("OpenAPI %s has no webhooks; %d left out", openAPIVersion, len(items)), "generate OpenAPI 3.1 to include them")
+		return nil
+	}
+	return items
+}
+
+// addCallbacks documents each webhook with a CallbackURL as a callback of
+// the operations whose handlers deliver it.
+func addCallbacks(paths map[string]PathItem, routes []*RouteInfo, webhooks []*webhookInfo) {
+	callbacks := map[string]*webhookInfo{}
+	for _, w := range webhooks {
+		if w.CallbackURL != "" {
+			callbacks[w.Name] = w
+		}
+	}
+	if len(callbacks) == 0 {
+		return
+	}
+	for _, route := range routes {
+		op := paths[route.OpenAPIPath()].Operation(route.Method)
+		if op == nil {
+			continue
+		}
+		for _, name := range route.Webhooks {
+			w, ok := callbacks[name]
+			if !ok {
+				continue
+			}
+			if op.Callbacks == nil {
+				op.Callbacks = map[string]Callback{}
+			}
+			op.Callbacks[name] = Callback{w.CallbackURL: w.item}
+		}
+	}
+}
+
+// webhookRoutes returns the routes carrying the webhooks' payload types, for
+// the component schemas.
+// webhookOperationIDs returns the operationIds the webhooks are configured
+// with, which generated operationIds must not repeat.
+func webhookOperationIDs(webhooks []*webhookInfo) []string {
+	var ids []string
+	for _, w := range webhooks {
+		if w.OperationID != "" {
+			ids = append(ids, w.OperationID)
+		}
+	}
+	return ids
+}
+
+func webhookRoutes(webhooks []*webhookInfo) []*RouteInfo {
+	routes := make([]*RouteInfo, 0, len(webhooks))
+	for _, w := range webhooks {
+		routes = append(routes, w.route)
+	}
+	return routes
+}
+
+func reportWebhook(message, help string) {
+	diag.Report(diag.Diagnostic{
+		Severity: diag.Warning,
+		Category: "webhooks",
+		Message:  message,
+		Help:     help,
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"bytes"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/diag"
+)
+
+func TestWebhookItems(t *testing.T) {
+	var out bytes.Buffer
+	prev := diag.SetDefault(diag.NewPresenter(&out, false))
+	defer diag.SetDefault(prev)
+
+	call := []WebhookCall{{CallRegex: "^Post$"}}
+	webhooks := []*webhookInfo{
+		{Webhook: Webhook{Name: "manual"}},
+		{Webhook: Webhook{Name: "delivered", Calls: call}, delivered: true},
+		{Webhook: Webhook{Name: "undelivered", Calls: call}},
+		{Webhook: Webhook{Name: "callback", CallbackURL: "{$request.body#/url}", Calls: call}, delivered: true},
+		{Webhook: Webhook{Name: "orphanCallback", CallbackURL: "{$request.body#/url}"}},
+	}
+
+	items := webhookItems(webhooks, "3.1.1")
+	if got := slices.Sorted(maps.Keys(items)); !slices.Equal(got, []string{"delivered", "manual"}) {
+		t.Errorf("webhooks = %v, want delivered and manual", got)
+	}
+	for _, want := range []string{"webhook undelivered: no call", "webhook orphanCallback has a callbackURL but no calls"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("warnings lack %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if items := webhookItems(webhooks, "3.0.3"); items != nil {
+		t.Errorf("OpenAPI 3.0 webhooks = %v, want none", items)
+	}
+	if !strings.Contains(out.String(), "OpenAPI 3.0.3 has no webhooks; 2 left out") {
+		t.Errorf("3.0 warning missing:\n%s", out.String())
+	}
+}
+
+func TestAddCallbacks(t *testing.T) {
+	item := PathItem{Post: &Operation{Summary: "Status changed"}}
+	webhooks := []*webhookInfo{
+		{Webhook: Webhook{Name: "status", CallbackURL: "{$request.body#/callbackUrl}"}, item: item},
+		{Webhook: Webhook{Name: "audit"}, item: item},
+	}
+	create := &Operation{}
+	get := &Operation{}
+	paths := map[string]PathItem{"/orders/{id}": {Post: create, Get: get}}
+	routes := []*RouteInfo{
+		{Path: "/orders/{id}", Method: "POST", Webhooks: []string{"status", "audit"}},
+		{Path: "/orders/{id}", Method: "GET"},
+	}
+
+	addCallbacks(paths, routes, webhooks)
+	if len(create.Callbacks) != 1 || create.Callbacks["status"]["{$request.body#/callbackUrl}"].Post != item.Post {
+		t.Errorf("POST callbacks = %v, want the status callback only", create.Callbacks)
+	}
+	if get.Callbacks != nil {
+		t.Errorf("GET callbacks = %v, want none", get.Callbacks)
+	}
+}
@@ -15,6 +15,7 @@
 package spec
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/ehabterra/apispec/internal/metadata"
@@ -59,8 +60,21 @@ type wrapperFieldOverride struct {
 //
 // All four edges of that walk exist in the metadata already; no new
 // storage is introduced.
+//
+// The wrapper may also be built in place from the helper's own
+// parameters, as a generic handler wrapper's success path does:
+//
+//	func respondWithSuccess(w http.ResponseWriter, data interface{}) {
+//	    json.NewEncoder(w).Encode(APIResponse[interface{}]{Data: data})
+//	}
+//
+// Then the literal's fields are bound to the helper's parameters and
+// the caller's arguments for them are the concrete types. A caller
+// argument typed by a type parameter (`response` of type TResponse in
+// HandleRequest[TRequest, TResponse]) resolves through the route's
+// instantiation.
 func (r *ResponsePatternMatcherImpl) collectWrapperOverrides(arg *metadata.CallArgument, node TrackerNodeInterface) []wrapperFieldOverride {
-	if arg == nil || arg.GetKind() != metadata.KindIdent || node == nil {
+	if arg == nil || node == nil {
 		return nil
 	}
 	edge := node.GetEdge()
@@ -76,6 +90,13 @@ func (r *ResponsePatternMatcherImpl) collectWrapperOverrides(arg *metadata.CallA
 	if helper == nil {
 		return nil
 	}
+	switch arg.GetKind() {
+	case metadata.KindIdent:
+	case metadata.KindCompositeLit, metadata.KindUnary, metadata.KindParen:
+		return r.literalWrapperOverrides(arg, helper, node)
+	default:
+		return nil
+	}
 	assigns := helper.AssignmentMap[arg.GetName()]
 	if len(assigns) == 0 {
 		return nil
@@ -108,7 +129,34 @@ func (r *ResponsePatternMatcherImpl) collectWrapperOverrides(arg *metadata.CallA
 		if argAtCtor == nil {
 			continue
 		}
-		concrete := r.resolveOverrideGoType(argAtCtor, parentEdge)
+		concrete := r.resolveOverrideGoType(argAtCtor, parentEdge, node)
+		if concrete == "" {
+			continue
+		}
+		out = append(out, wrapperFieldOverride{
+			StructFieldName: fieldName,
+			GoType:          concrete,
+		})
+	}
+	return out
+}
+
+// literalWrapperOverrides is collectWrapperOverrides for a wrapper
+// literal built inside helper: each field bound to a helper parameter
+// takes the type of the caller's argument for it.
+func (r *ResponsePatternMatcherImpl) literalWrapperOverrides(lit *metadata.CallArgument, helper *metadata.Function, node TrackerNodeInterface) []wrapperFieldOverride {
+	bindings := fieldParamBindingsFromReturnVar(lit, helper)
+	parentEdge := parentEdgeOf(node)
+	if len(bindings) == 0 || parentEdge == nil {
+		return nil
+	}
+	out := make([]wrapperFieldOverride, 0, len(bindings))
+	for fieldName, paramName := range bindings {
+		callerArg, ok := parentEdge.ParamArgMap[paramName]
+		if !ok {
+			continue
+		}
+		concrete := cleanOverrideType(r.callerArgGoType(&callerArg, node))
 		if concrete == "" {
 			continue
 		}
@@ -185,13 +233,13 @@ done:
 // aren't real Go types (function names, untyped expressions,
 // interface{}, …) so the override stays a safe no-op for those
 // shapes rather than emitting a $ref to a non-existent component.
-func (r *ResponsePatternMatcherImpl) resolveOverrideGoType(argAtCtor *metadata.CallArgument, parentEdge *metadata.CallGraphEdge) string {
+func (r *ResponsePatternMatcherImpl) resolveOverrideGoType(argAtCtor *metadata.CallArgument, parentEdge *metadata.CallGraphEdge, node TrackerNodeInterface) string {
 	if argAtCtor == nil {
 		return ""
 	}
 	if argAtCtor.GetKind() == metadata.KindIdent && parentEdge != nil && parentEdge.ParamArgMap != nil {
 		if callerArg, ok := parentEdge.ParamArgMap[argAtCtor.GetName()]; ok {
-			if t := cleanOverrideType(extractCallerArgType(&callerArg, r.contextProvider)); t != "" {
+			if t := cleanOverrideType(r.callerArgGoType(&callerArg, node)); t != "" {
 				return t
 			}
 		}
@@ -199,6 +247,17 @@ func (r *ResponsePatternMatcherImpl) resolveOverrideGoType(argAtCtor *metadata.C
 	return cleanOverrideType(argAtCtor.GetType())
 }
 
+// callerArgGoType is extractCallerArgType with a type-parameter-typed
+// argument (its type the bare parameter name, TResponse) resolved to
+// its instantiation on node's path.
+func (r *ResponsePatternMatcherImpl) callerArgGoType(arg *metadata.CallArgument, node TrackerNodeInterface) string {
+	t := extractCallerArgType(arg, r.contextProvider)
+	if concrete := traceGenericOrigin(node, t); concrete != "" {
+		return concrete
+	}
+	return t
+}
+
 // extractCallerArgType pulls the Go-type string from a caller-side
 // CallArgument. handleIdent (for variable refs) and handleCallExpr
 // (for inline call expressions, single-valued) both populate
@@ -270,10 +329,10 @@ func specialiseWrapperSchema(baseSchema *Schema, overrides []wrapperFieldOverrid
 		// — already render correctly from the base $ref, and
 		// overriding them would mis-render the call-site literal
 		// (e.g. http.StatusOK or "ok") as the field's type.
-		if !wrapperFieldIsGeneric(meta, wrapperType, override.StructFieldName) {
+		if !wrapperFieldIsGeneric(meta, wrapperType, wrapperGoType, override.StructFieldName) {
 			continue
 		}
-		jsonName := jsonNameForField(meta, wrapperType, override.StructFieldName)
+		jsonName := jsonNameForField(meta, wrapperType, override.StructFieldName, cfg)
 		if jsonName == "" {
 			continue
 		}
@@ -378,9 +437,12 @@ func lookupWrapperType(meta *metadata.Metadata, goType string) *metadata.Type {
 // wrapperFieldIsGeneric reports whether the declared type of the named
 // struct field on wrapperType is `interface{}` or `any` — i.e. the
 // type system carries no concrete information and a per-route override
-// is meaningful. Fields with concrete declared types (string, int,
-// named structs, …) shouldn't be overridden by call-site literals.
-func wrapperFieldIsGeneric(meta *metadata.Metadata, wrapperType *metadata.Type, structFieldName string) bool {
+// is meaningful. A field typed by one of the wrapper's type parameters
+// counts when wrapperGoType instantiates it with `any`
+// (APIResponse[interface{}]). Fields with concrete declared types
+// (string, int, named structs, …) shouldn't be overridden by call-site
+// literals.
+func wrapperFieldIsGeneric(meta *metadata.Metadata, wrapperType *metadata.Type, wrapperGoType, structFieldName string) bool {
 	if wrapperType == nil {
 		return false
 	}
@@ -390,12 +452,18 @@ func wrapperFieldIsGeneric(meta *metadata.Metadata, wrapperType *metadata.Type,
 		}
 		declared := meta.StringPool.GetString(field.Type)
 		declared = strings.TrimPrefix(declared, "*")
+		if i := slices.Index(wrapperType.TypeParams, declared); i >= 0 {
+			declared = "any"
+			if core := typemodel.Parse(wrapperGoType).Core(); core != nil && i < len(core.Args) {
+				declared = core.Args[i].String()
+			}
+		}
 		return declared == "interface{}" || declared == "any"
 	}
 	return false
 }
 
-func jsonNameForField(meta *metadata.Metadata, wrapperType *metadata.Type, structFieldName string) string {
+func jsonNameForField(meta *metadata.Metadata, wrapperType *metadata.Type, structFieldName string, cfg *APISpecConfig) string {
 	if wrapperType == nil {
 		return ""
 	}
@@ -404,7 +472,7 @@ func jsonNameForField(meta *metadata.Metadata, wrapperType *metadata.Type, struc
 			continue
 		}
 		tag := meta.StringPool.GetString(field.Tag)
-		if name := extractJSONName(tag); name != "" {
+		if name := propertyName(cfg, tag); name != "" {
 			return name
 		}
 		return structFieldName
@@ -238,23 +238,28 @@ func TestWrapperFieldIsGeneric(t *testing.T) {
 			{Name: pool.Get("Any"), Type: pool.Get("any")},
 			{Name: pool.Get("Code"), Type: pool.Get("int")},
 			{Name: pool.Get("Ptr"), Type: pool.Get("*interface{}")},
+			{Name: pool.Get("Item"), Type: pool.Get("T")},
 		},
+		TypeParams: []string{"T"},
 	}
 
 	cases := []struct {
-		name string
-		want bool
+		name, goType string
+		want         bool
 	}{
-		{"Message", false},
-		{"Data", true},
-		{"Any", true},
-		{"Code", false},
-		{"Ptr", true},
-		{"Missing", false},
+		{"Message", "app.Envelope[any]", false},
+		{"Data", "app.Envelope[any]", true},
+		{"Any", "app.Envelope[any]", true},
+		{"Code", "app.Envelope[any]", false},
+		{"Ptr", "app.Envelope[any]", true},
+		{"Missing", "app.Envelope[any]", false},
+		// A type-parameter field is generic as the instantiation makes it.
+		{"Item", "app.Envelope[interface{}]", true},
+		{"Item", "app.Envelope[app.User]", false},
 	}
 	for _, c := range cases {
-		if got := wrapperFieldIsGeneric(meta, wrapper, c.name); got != c.want {
-			t.Errorf("wrapperFieldIsGeneric(%q) = %v, want %v", c.name, got, c.want)
+		if got := wrapperFieldIsGeneric(meta, wrapper, c.goType, c.name); got != c.want {
+			t.Errorf("wrapperFieldIsGeneric(%q, %q) = %v, want %v", c.goType, c.name, got, c.want)
 		}
 	}
 }
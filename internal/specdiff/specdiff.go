@@ -0,0 +1,205 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package specdiff compares two OpenAPI documents operation by operation and
+// schema by schema, flagging the changes that break existing clients.
+package specdiff
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/ehabterra/apispec/internal/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// Change kinds.
+const (
+	Added   = "added"
+	Removed = "removed"
+	Changed = "changed"
+)
+
+// Change is one difference between the base and head documents.
+type Change struct {
+	Kind     string // Added, Removed or Changed
+	Location string // e.g. "GET /users/{id}" or "schema User"
+	Detail   string
+	// Breaking is set when a client written against the base document can
+	// fail against the head: an operation, response or property it uses is
+	// gone, or a request now requires something it does not send.
+	Breaking bool
+}
+
+func (c Change) String() string {
+	s := fmt.Sprintf("%-7s %s", c.Kind, c.Location)
+	if c.Detail != "" {
+		s += ": " + c.Detail
+	}
+	if c.Breaking {
+		s += " [breaking]"
+	}
+	return s
+}
+
+// Load reads an OpenAPI document in JSON or YAML from path.
+func Load(path string) (*spec.OpenAPISpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("specdiff: failed to read %s: %w", path, err)
+	}
+	var doc spec.OpenAPISpec
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("specdiff: failed to parse %s: %w", path, err)
+	}
+	if doc.OpenAPI == "" {
+		return nil, fmt.Errorf("specdiff: %s is not an OpenAPI document", path)
+	}
+	return &doc, nil
+}
+
+// Compare lists the changes from base to head: operations, their parameters,
+// request bodies and response statuses, and component schemas with their
+// properties. Changes are ordered by path, method and schema name.
+func Compare(base, head *spec.OpenAPISpec) []Change {
+	var changes []Change
+	add := func(c Change) { changes = append(changes, c) }
+
+	for _, path := range sortedUnion(base.Paths, head.Paths) {
+		baseOps := operationsByMethod(base.Paths[path])
+		headOps := operationsByMethod(head.Paths[path])
+		for _, method := range sortedUnion(baseOps, headOps) {
+			loc := method + " " + path
+			b, h := baseOps[method], headOps[method]
+			switch {
+			case b == nil:
+				add(Change{Kind: Added, Location: loc})
+			case h == nil:
+				add(Change{Kind: Removed, Location: loc, Breaking: true})
+			default:
+				compareOperation(loc, b, h, add)
+			}
+		}
+	}
+
+	baseSchemas, headSchemas := componentSchemas(base), componentSchemas(head)
+	for _, name := range sortedUnion(baseSchemas, headSchemas) {
+		loc := "schema " + name
+		b, h := baseSchemas[name], headSchemas[name]
+		switch {
+		case b == nil:
+			add(Change{Kind: Added, Location: loc})
+		case h == nil:
+			// Operations that used it already report their own changes.
+			add(Change{Kind: Removed, Location: loc})
+		default:
+			compareSchema(loc, b, h, add)
+		}
+	}
+	return changes
+}
+
+func compareOperation(loc string, base, head *spec.Operation, add func(Change)) {
+	baseParams, headParams := parametersByKey(base), parametersByKey(head)
+	for _, key := range sortedUnion(baseParams, headParams) {
+		b, h := baseParams[key], headParams[key]
+		switch {
+		case b == nil:
+			add(Change{Kind: Added, Location: loc, Detail: "parameter " + key, Breaking: h.Required})
+		case h == nil:
+			add(Change{Kind: Removed, Location: loc, Detail: "parameter " + key})
+		case h.Required && !b.Required:
+			add(Change{Kind: Changed, Location: loc, Detail: "parameter " + key + " is now required", Breaking: true})
+		}
+	}
+
+	switch b, h := base.RequestBody, head.RequestBody; {
+	case b == nil && h != nil:
+		add(Change{Kind: Added, Location: loc, Detail: "request body", Breaking: h.Required})
+	case b != nil && h == nil:
+		add(Change{Kind: Removed, Location: loc, Detail: "request body"})
+	case b != nil && h.Required && !b.Required:
+		add(Change{Kind: Changed, Location: loc, Detail: "request body is now required", Breaking: true})
+	}
+
+	for _, status := range sortedUnion(base.Responses, head.Responses) {
+		_, inBase := base.Responses[status]
+		_, inHead := head.Responses[status]
+		switch {
+		case !inBase:
+			add(Change{Kind: Added, Location: loc, Detail: "response " + status})
+		case !inHead:
+			add(Change{Kind: Removed, Location: loc, Detail: "response " + status, Breaking: true})
+		}
+	}
+}
+
+func compareSchema(loc string, base, head *spec.Schema, add func(Change)) {
+	for _, name := range sortedUnion(base.Properties, head.Properties) {
+		_, inBase := base.Properties[name]
+		_, inHead := head.Properties[name]
+		switch {
+		case !inBase:
+			add(Change{Kind: Added, Location: loc, Detail: "property " + name, Breaking: slices.Contains(head.Required, name)})
+		case !inHead:
+			add(Change{Kind: Removed, Location: loc, Detail: "property " + name, Breaking: true})
+		case slices.Contains(head.Required, name) && !slices.Contains(base.Required, name):
+			add(Change{Kind: Changed, Location: loc, Detail: "property " + name + " is now required", Breaking: true})
+		}
+	}
+}
+
+func operationsByMethod(item spec.PathItem) map[string]*spec.Operation {
+	ops := map[string]*spec.Operation{}
+	for _, m := range item.Operations() {
+		ops[m.Method] = m.Operation
+	}
+	return ops
+}
+
+// parametersByKey keys an operation's inline parameters as "<in> <name>"; a
+// $ref parameter is keyed by its reference.
+func parametersByKey(op *spec.Operation) map[string]*spec.Parameter {
+	params := map[string]*spec.Parameter{}
+	for i := range op.Parameters {
+		p := &op.Parameters[i]
+		key := p.In + " " + p.Name
+		if p.Ref != "" {
+			key = p.Ref
+		}
+		params[key] = p
+	}
+	return params
+}
+
+func componentSchemas(s *spec.OpenAPISpec) map[string]*spec.Schema {
+	if s.Components == nil {
+		return nil
+	}
+	return s.Components.Schemas
+}
+
+// sortedUnion returns the keys of a and b, sorted.
+func sortedUnion[V any](a, b map[string]V) []string {
+	keys := map[string]bool{}
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	return slices.Sorted(maps.Keys(keys))
+}
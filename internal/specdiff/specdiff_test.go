@@ -0,0 +1,113 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package specdiff
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/spec"
+)
+
+func TestCompare(t *testing.T) {
+	ok := map[string]spec.Response{"200": {Description: "OK"}}
+	base := &spec.OpenAPISpec{
+		Paths: map[string]spec.PathItem{
+			"/users": {
+				Get: &spec.Operation{
+					Parameters: []spec.Parameter{{Name: "limit", In: "query"}, {Name: "q", In: "query"}},
+					Responses:  map[string]spec.Response{"200": {}, "404": {}},
+				},
+				Post: &spec.Operation{RequestBody: &spec.RequestBody{}, Responses: ok},
+			},
+			"/legacy": {Get: &spec.Operation{Responses: ok}},
+		},
+		Components: &spec.Components{Schemas: map[string]*spec.Schema{
+			"User":  {Properties: map[string]*spec.Schema{"id": {}, "nick": {}, "email": {}}},
+			"Stale": {},
+		}},
+	}
+	head := &spec.OpenAPISpec{
+		Paths: map[string]spec.PathItem{
+			"/users": {
+				Get: &spec.Operation{
+					Parameters: []spec.Parameter{{Name: "limit", In: "query", Required: true}, {Name: "page", In: "query"}},
+					Responses:  map[string]spec.Response{"200": {}, "400": {}},
+				},
+				Post: &spec.Operation{RequestBody: &spec.RequestBody{Required: true}, Responses: ok},
+			},
+			"/orgs": {Get: &spec.Operation{Responses: ok}},
+		},
+		Components: &spec.Components{Schemas: map[string]*spec.Schema{
+			"User": {Properties: map[string]*spec.Schema{"id": {}, "email": {}, "name": {}}, Required: []string{"email", "name"}},
+			"Org":  {},
+		}},
+	}
+
+	var got []string
+	for _, c := range Compare(base, head) {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"removed GET /legacy [breaking]",
+		"added   GET /orgs",
+		"changed GET /users: parameter query limit is now required [breaking]",
+		"added   GET /users: parameter query page",
+		"removed GET /users: parameter query q",
+		"added   GET /users: response 400",
+		"removed GET /users: response 404 [breaking]",
+		"changed POST /users: request body is now required [breaking]",
+		"added   schema Org",
+		"removed schema Stale",
+		"changed schema User: property email is now required [breaking]",
+		"added   schema User: property name [breaking]",
+		"removed schema User: property nick [breaking]",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Compare =\n%q\nwant\n%q", got, want)
+	}
+
+	if changes := Compare(base, base); len(changes) != 0 {
+		t.Errorf("Compare(base, base) = %v, want none", changes)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, doc string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	doc, err := Load(write("spec.json", `{"openapi": "3.1.0", "paths": {"/a": {"get": {"operationId": "a", "x-sse": true, "responses": {}}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op := doc.Paths["/a"].Get; op == nil || op.OperationID != "a" || op.Extensions["x-sse"] != true {
+		t.Errorf("loaded operation = %+v", op)
+	}
+
+	if _, err := Load(write("config.yaml", "framework: {}\n")); err == nil {
+		t.Error("expected an error for a document without an openapi version")
+	}
+	if _, err := Load(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yamlout encodes generated specs as YAML 1.2 documents. Strings a
+// YAML 1.1 reader would resolve to something else ("on", "no", "0755", the
+// "<<" merge key) are always quoted; repeated blocks can optionally be
+// written once under an anchor and referenced by alias afterwards.
+package yamlout
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Indent is the indentation width of every document.
+const Indent = 2
+
+// minAnchorNodes is the smallest block (counted in descendant nodes) worth an
+// anchor. A response with a $ref'd JSON body is ten; `{type: string}` is two.
+const minAnchorNodes = 8
+
+// Options controls the encoding.
+type Options struct {
+	// Anchors writes the first occurrence of a repeated block (a security
+	// requirement list, a shared response, a large inline schema) under an
+	// anchor and replaces later occurrences with an alias.
+	Anchors bool
+}
+
+// Encode writes v to w as a single YAML document.
+func Encode(w io.Writer, v interface{}, opts Options) error {
+	var root yaml.Node
+	if err := root.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	quoteAmbiguous(&root)
+	if opts.Anchors {
+		addAnchors(&root)
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(Indent)
+	if err := enc.Encode(&root); err != nil {
+		_ = enc.Close()
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return enc.Close()
+}
+
+// Marshal is Encode into a byte slice.
+func Marshal(v interface{}, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, v, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ambiguousPlain are strings yaml.v3 leaves plain although a YAML 1.1 reader
+// gives them a meaning: "<<" is the merge key and "=" the value key.
+var ambiguousPlain = map[string]bool{"<<": true, "=": true}
+
+// quoteAmbiguous double-quotes string scalars that would not read back as
+// strings under YAML 1.1. yaml.v3 already quotes the 1.1 booleans, octals,
+// sexagesimals and timestamps, but re-reading its own output for the node
+// tree tags a "<<" string as a merge key, so that tag is reset too.
+func quoteAmbiguous(n *yaml.Node) {
+	if n.Kind == yaml.ScalarNode && n.Style == 0 && ambiguousPlain[n.Value] {
+		if tag := n.ShortTag(); tag == "!!str" || tag == "!!merge" {
+			n.Tag = "!!str"
+			n.Style = yaml.DoubleQuotedStyle
+		}
+	}
+	for _, c := range n.Content {
+		quoteAmbiguous(c)
+	}
+}
+
+// anchorer assigns anchors in document order, so every alias follows its
+// anchor as the YAML spec requires.
+type anchorer struct {
+	digests map[*yaml.Node]string
+	sizes   map[*yaml.Node]int
+	counts  map[string]int
+	anchors map[string]*yaml.Node
+	order   []*yaml.Node
+	paths   map[*yaml.Node][]string
+	used    map[*yaml.Node]bool
+	names   map[string]int
+}
+
+// addAnchors aliases repeated blocks under root. Keys are never aliased, and
+// a block inside an aliased one is not visited again. Anchors are named
+// once linking is done, so those that end up without an alias (their
+// duplicate was inside an aliased parent) neither appear nor use up a name.
+func addAnchors(root *yaml.Node) {
+	a := &anchorer{
+		digests: map[*yaml.Node]string{},
+		sizes:   map[*yaml.Node]int{},
+		counts:  map[string]int{},
+		anchors: map[string]*yaml.Node{},
+		paths:   map[*yaml.Node][]string{},
+		used:    map[*yaml.Node]bool{},
+		names:   map[string]int{},
+	}
+	a.digest(root)
+	a.count(root, "")
+	a.link(root, nil)
+	for _, n := range a.order {
+		if a.used[n] {
+			n.Anchor = a.name(a.paths[n])
+		}
+	}
+	nameAliases(root)
+}
+
+// nameAliases points every alias at its target's final anchor name.
+func nameAliases(n *yaml.Node) {
+	if n.Kind == yaml.AliasNode {
+		n.Value = n.Alias.Anchor
+		return
+	}
+	for _, c := range n.Content {
+		nameAliases(c)
+	}
+}
+
+// digest returns a content hash of n covering kind, tag, value and children,
+// and records its descendant count.
+func (a *anchorer) digest(n *yaml.Node) string {
+	if d, ok := a.digests[n]; ok {
+		return d
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%d|%s|%s|", n.Kind, n.ShortTag(), n.Value)
+	size := 0
+	for _, c := range n.Content {
+		h.Write([]byte(a.digest(c)))
+		size += 1 + a.sizes[c]
+	}
+	d := string(h.Sum(nil))
+	a.digests[n] = d
+	a.sizes[n] = size
+	return d
+}
+
+// count tallies the occurrences of each eligible block in value position.
+func (a *anchorer) count(n *yaml.Node, key string) {
+	if a.eligible(n, key) {
+		a.counts[a.digests[n]]++
+	}
+	a.eachValue(n, key, a.count)
+}
+
+// link anchors the first occurrence of each repeated block and turns the
+// later ones into aliases.
+func (a *anchorer) link(n *yaml.Node, path []string) {
+	key := ""
+	if len(path) > 0 {
+		key = path[len(path)-1]
+	}
+	if a.eligible(n, key) && a.counts[a.digests[n]] > 1 {
+		d := a.digests[n]
+		if target, ok := a.anchors[d]; ok {
+			a.used[target] = true
+			*n = yaml.Node{Kind: yaml.AliasNode, Alias: target}
+			return
+		}
+		a.anchors[d] = n
+		a.order = append(a.order, n)
+		a.paths[n] = append([]string(nil), path...)
+	}
+	a.eachValue(n, key, func(c *yaml.Node, k string) {
+		a.link(c, append(path, k))
+	})
+}
+
+// eachValue calls fn on every value child of n with the key it sits under;
+// sequence items inherit the sequence's key.
+func (a *anchorer) eachValue(n *yaml.Node, key string, fn func(*yaml.Node, string)) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, c := range n.Content {
+			fn(c, key)
+		}
+	case yaml.SequenceNode:
+		for _, c := range n.Content {
+			fn(c, key)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			fn(n.Content[i+1], n.Content[i].Value)
+		}
+	}
+}
+
+// eligible reports whether n is worth an anchor: a non-empty security
+// requirement list, or any collection of at least minAnchorNodes nodes.
+func (a *anchorer) eligible(n *yaml.Node, key string) bool {
+	if n.Kind != yaml.MappingNode && n.Kind != yaml.SequenceNode {
+		return false
+	}
+	if len(n.Content) == 0 {
+		return false
+	}
+	if key == "security" && n.Kind == yaml.SequenceNode {
+		return true
+	}
+	return a.sizes[n] >= minAnchorNodes
+}
+
+// name derives a readable, unique anchor name from the block's key path:
+// the last key, prefixed by its parent when it is a bare status code
+// ("responses-404"). Repeats are numbered ("security-2").
+func (a *anchorer) name(path []string) string {
+	base := ""
+	if len(path) > 0 {
+		base = path[len(path)-1]
+		if isDigits(base) && len(path) > 1 {
+			base = path[len(path)-2] + "-" + base
+		}
+	}
+	base = sanitize(base)
+	if base == "" {
+		base = "block"
+	}
+	a.names[base]++
+	if n := a.names[base]; n > 1 {
+		return fmt.Sprintf("%s-%d", base, n)
+	}
+	return base
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitize keeps anchor names to letters, digits, '-' and '_' — anchors may
+// not contain whitespace or flow indicators, and '/' or '$' read poorly.
+func sanitize(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yamlout
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMarshal_QuotesYAML11Scalars(t *testing.T) {
+	values := []string{"on", "no", "Y", "0755", "0x1F", "1:20", "2026-01-01", "~", "<<", "="}
+	out, err := Marshal(map[string]interface{}{"example": values, "<<": "merge"}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range values {
+		if !strings.Contains(string(out), `- "`+v+`"`) {
+			t.Errorf("%q should be double-quoted; got:\n%s", v, out)
+		}
+	}
+	if !strings.Contains(string(out), `"<<": merge`) {
+		t.Errorf("a << key must be quoted so it is not read as a merge; got:\n%s", out)
+	}
+}
+
+func TestMarshal_Anchors(t *testing.T) {
+	notFound := map[string]interface{}{
+		"description": "Not Found",
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": map[string]interface{}{"$ref": "#/components/schemas/Error"},
+			},
+		},
+	}
+	security := []map[string][]string{{"bearerAuth": {}}}
+	op := func(summary, okStatus string) map[string]interface{} {
+		return map[string]interface{}{
+			"summary":   summary,
+			"security":  security,
+			"responses": map[string]interface{}{"404": notFound, okStatus: map[string]string{"description": "No Content"}},
+		}
+	}
+	doc := map[string]interface{}{"paths": map[string]interface{}{
+		"/a": map[string]interface{}{"get": op("a", "204"), "delete": op("remove a", "204")},
+		"/b": map[string]interface{}{"get": op("b", "205")},
+	}}
+
+	plain, err := Marshal(doc, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	anchored, err := Marshal(doc, Options{Anchors: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.ContainsAny(string(plain), "&*") {
+		t.Errorf("anchors must be opt-in; got:\n%s", plain)
+	}
+
+	out := string(anchored)
+	// /a's identical response maps share one anchor; /b's differs but still
+	// reuses the 404 inside it.
+	for _, want := range []string{"&security", "*security", "responses: &responses\n", "responses: *responses\n", "&responses-404", "*responses-404"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "responses-404-2") || strings.Contains(out, "&description") {
+		t.Errorf("unexpected extra anchors in:\n%s", out)
+	}
+	if strings.Index(out, "&security") > strings.Index(out, "*security") {
+		t.Errorf("alias precedes its anchor in:\n%s", out)
+	}
+	// The small bodyless response is left inline.
+	if strings.Contains(out, "&responses-20") {
+		t.Errorf("small blocks should not be anchored; got:\n%s", out)
+	}
+
+	var a, b interface{}
+	if err := yaml.Unmarshal(plain, &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(anchored, &b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("anchored document decodes differently:\n%s", out)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	cases := map[string]string{
+		"application/json": "application-json",
+		"$ref":             "ref",
+		"x-rate limit":     "x-rate-limit",
+		"get":              "get",
+	}
+	for in, want := range cases {
+		if got := sanitize(in); got != want {
+			t.Errorf("sanitize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import intspec "github.com/ehabterra/apispec/internal/spec"
+
+// RouteInfo is a route as extracted from the code, before it becomes an
+// OpenAPI operation: its request, its responses keyed by status code, and
+// its parameters. Its JSON encoding is a stable, machine-readable contract:
+//
+//   - Field names are the json tags, in camelCase. Empty optional fields are
+//     omitted; path, method, handler, statusCode and security are always
+//     present.
+//   - security is null when the route inherits the document's security and
+//     [] when it is explicitly public.
+//   - A response whose status could not be resolved has statusCode -1 and
+//     the key "-1"; the OpenAPI document lists it as "default".
+//   - Schemas are OpenAPI schema objects; parameters are OpenAPI parameter
+//     objects.
+//   - Analysis handles (the metadata, the tracker node, the schemas of used
+//     types) are never encoded.
+//
+// Compatibility: within a major version fields are only added. Renaming or
+// removing a field, or changing its meaning or type, is a breaking change and
+// is listed in the changelog. Consumers should ignore fields they do not know.
+type RouteInfo = intspec.RouteInfo
+
+// RequestInfo is a route's request body.
+type RequestInfo = intspec.RequestInfo
+
+// ResponseInfo is one response of a route.
+type ResponseInfo = intspec.ResponseInfo
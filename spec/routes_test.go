@@ -0,0 +1,116 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+	"github.com/ehabterra/apispec/spectest"
+)
+
+// TestRouteInfoJSONContract pins the JSON field names of the route model.
+// A failure here is a breaking change for consumers of the encoding.
+func TestRouteInfoJSONContract(t *testing.T) {
+	route := spec.RouteInfo{
+		Path:     "/users/:id",
+		Method:   "GET",
+		Handler:  "getUser",
+		Package:  "example.com/app",
+		Function: "getUser",
+		Tags:     []string{"users"},
+		Request:  &spec.RequestInfo{ContentType: "application/json", BodyType: "example.com/app.Filter", Optional: true},
+		Response: map[string]*spec.ResponseInfo{
+			"200": {StatusCode: 200, ContentType: "application/json", BodyType: "example.com/app.User",
+				Schema: &spec.Schema{Ref: "#/components/schemas/app.User"}},
+			"-1": {StatusCode: -1},
+		},
+		Params:      []spec.Parameter{{Name: "id", In: "path", Required: true, Schema: &spec.Schema{Type: "string"}}},
+		OperationID: "getUser",
+		UsedTypes:   map[string]*spec.Schema{"app.User": {Type: "object"}},
+		Metadata:    &spec.Metadata{},
+	}
+
+	got, err := json.Marshal(route)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"path":"/users/:id","method":"GET","handler":"getUser","package":"example.com/app",` +
+		`"function":"getUser","tags":["users"],` +
+		`"request":{"contentType":"application/json","bodyType":"example.com/app.Filter","optional":true},` +
+		`"responses":{"-1":{"statusCode":-1},"200":{"statusCode":200,"contentType":"application/json",` +
+		`"bodyType":"example.com/app.User","schema":{"$ref":"#/components/schemas/app.User"}}},` +
+		`"parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"string"}}],` +
+		`"operationId":"getUser","security":null}`
+	if string(got) != want {
+		t.Errorf("RouteInfo JSON =\n%s\nwant\n%s", got, want)
+	}
+
+	route.Security = []spec.SecurityRequirement{}
+	got, _ = json.Marshal(route)
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if string(decoded["security"]) != "[]" {
+		t.Errorf("explicitly public route encodes security as %s, want []", decoded["security"])
+	}
+}
+
+func TestRouteInfoJSON_ExtractedRoutes(t *testing.T) {
+	res := spectest.Run(t, spec.DefaultHTTPConfig(), `package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type User struct {
+	ID string `+"`json:\"id\"`"+`
+}
+
+func createUser(w http.ResponseWriter, r *http.Request) {
+	var u User
+	json.NewDecoder(r.Body).Decode(&u)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(u)
+}
+
+func main() {
+	http.HandleFunc("POST /users", createUser)
+	http.ListenAndServe(":8080", nil)
+}
+`)
+
+	data, err := json.Marshal(res.Routes)
+	if err != nil {
+		t.Fatalf("extracted routes do not encode: %v", err)
+	}
+	var routes []spec.RouteInfo
+	if err := json.Unmarshal(data, &routes); err != nil {
+		t.Fatal(err)
+	}
+	if len(routes) != 1 {
+		t.Fatalf("decoded %d routes, want 1:\n%s", len(routes), data)
+	}
+	r := routes[0]
+	if r.Method != "POST" || r.Path != "/users" || r.Request == nil || r.Response["201"] == nil {
+		t.Errorf("decoded route = %+v, want POST /users with a body and a 201", r)
+	}
+	if r.Metadata != nil || r.UsedTypes != nil {
+		t.Error("analysis handles leaked into the encoding")
+	}
+}
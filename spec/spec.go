@@ -12,11 +12,15 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package spec exposes a stable public API for configuration and OpenAPI types,
-// re-exported from the internal spec package.
+// Package spec exposes a stable public API for configuration, OpenAPI types
+// and the extracted route model, re-exported from the internal spec package.
 package spec
 
-import intspec "github.com/ehabterra/apispec/internal/spec"
+import (
+	"github.com/ehabterra/apispec/internal/diag"
+	"github.com/ehabterra/apispec/internal/metadata"
+	intspec "github.com/ehabterra/apispec/internal/spec"
+)
 
 // Re-export core configuration types
 type APISpecConfig = intspec.APISpecConfig
@@ -28,7 +32,37 @@ type SecurityPattern = intspec.SecurityPattern
 type SecurityMapping = intspec.SecurityMapping
 type MiddlewareRef = intspec.MiddlewareRef
 type FrameworkConfig = intspec.FrameworkConfig
+type RoutePattern = intspec.RoutePattern
+type CustomRoutePattern = intspec.CustomRoutePattern
+type RequestBodyPattern = intspec.RequestBodyPattern
+type ResponsePattern = intspec.ResponsePattern
+type ResponseHelperPattern = intspec.ResponseHelperPattern
+type HandlerAdapterPattern = intspec.HandlerAdapterPattern
+type ParamPattern = intspec.ParamPattern
+type MountPattern = intspec.MountPattern
+type ProtocolPattern = intspec.ProtocolPattern
+type ResponseHeaderPattern = intspec.ResponseHeaderPattern
+type ValidationPattern = intspec.ValidationPattern
 type Tag = intspec.Tag
+type GroupTag = intspec.GroupTag
+type Webhook = intspec.Webhook
+type WebhookCall = intspec.WebhookCall
+type ContextValue = intspec.ContextValue
+type MiddlewareResponse = intspec.MiddlewareResponse
+type MiddlewareStatus = intspec.MiddlewareStatus
+type ErrorResponse = intspec.ErrorResponse
+type OperationIDOptions = intspec.OperationIDOptions
+type SchemaOptions = intspec.SchemaOptions
+type ExtensionsConfig = intspec.ExtensionsConfig
+type PathExtensions = intspec.PathExtensions
+type OperationExtensions = intspec.OperationExtensions
+type SchemaExtensions = intspec.SchemaExtensions
+type SpecOverrides = intspec.SpecOverrides
+type PathOverride = intspec.PathOverride
+type OperationOverride = intspec.OperationOverride
+type ParameterOverride = intspec.ParameterOverride
+type BodyOverride = intspec.BodyOverride
+type MediaOverride = intspec.MediaOverride
 
 // Security scope values for SecurityPattern.Scope.
 const (
@@ -42,6 +76,10 @@ type ExternalDocumentation = intspec.ExternalDocumentation
 type Schema = intspec.Schema
 type Components = intspec.Components
 type OpenAPISpec = intspec.OpenAPISpec
+type PathItem = intspec.PathItem
+type Operation = intspec.Operation
+type MediaType = intspec.MediaType
+type Parameter = intspec.Parameter
 
 // Default framework configurations
 func DefaultGinConfig() *APISpecConfig   { return intspec.DefaultGinConfig() }
@@ -68,3 +106,98 @@ func SecondaryView(cfg *APISpecConfig) *APISpecConfig { return intspec.Secondary
 
 // LoadAPISpecConfig loads a YAML configuration file.
 func LoadAPISpecConfig(path string) (*APISpecConfig, error) { return intspec.LoadAPISpecConfig(path) }
+
+// LoadSpecOverrides loads an overrides document, a partial OpenAPI document
+// for ApplySpecOverrides. Keys it does not read are reported on standard
+// error.
+func LoadSpecOverrides(path string) (*SpecOverrides, error) {
+	o, diags, err := intspec.LoadSpecOverrides(path)
+	for _, d := range diags {
+		diag.Report(d)
+	}
+	return o, err
+}
+
+// ApplySpecOverrides merges o's info, security, summaries, descriptions and
+// examples into s. Overrides for paths, operations, parameters or responses
+// s lacks are skipped and reported on standard error.
+func ApplySpecOverrides(s *OpenAPISpec, o *SpecOverrides) {
+	for _, d := range intspec.ApplySpecOverrides(s, o) {
+		diag.Report(d)
+	}
+}
+
+// ShareComponents moves s's component schemas into lib (a new library when
+// nil), published at libRef, and points s's $refs at it. Schemas that differ
+// from lib's are kept in s and reported on standard error.
+func ShareComponents(s, lib *OpenAPISpec, libRef string) *OpenAPISpec {
+	lib, diags := intspec.ShareComponents(s, lib, libRef)
+	for _, d := range diags {
+		diag.Report(d)
+	}
+	return lib
+}
+
+// SpecIssue is a structural problem ValidateSpec found in a document.
+type SpecIssue = intspec.SpecIssue
+
+// ValidateSpec reports dangling component $refs, duplicate operationIds, and
+// path templates that disagree with their path parameters.
+func ValidateSpec(s *OpenAPISpec) []SpecIssue { return intspec.ValidateSpec(s) }
+
+// SortPolicy orders the lists (tags, parameters, required properties, enum
+// values) of a generated spec.
+type SortPolicy = intspec.SortPolicy
+
+// Sort policies for SortSpec and engine.EngineConfig.Sort.
+const (
+	SortSource = intspec.SortSource
+	SortAlpha  = intspec.SortAlpha
+)
+
+// ParseSortPolicy returns the policy named s ("source" or "alpha"); "" is
+// SortSource.
+func ParseSortPolicy(s string) (SortPolicy, error) { return intspec.ParseSortPolicy(s) }
+
+// SortSpec orders the lists of s by policy. Map keys are written sorted
+// whatever the policy.
+func SortSpec(s *OpenAPISpec, policy SortPolicy) { intspec.SortSpec(s, policy) }
+
+// Metadata is the analysed project model handed to framework detectors.
+type Metadata = metadata.Metadata
+
+// FrameworkDetectFunc reports whether a project uses a registered framework.
+type FrameworkDetectFunc = intspec.FrameworkDetectFunc
+
+// RegisteredFramework is one entry of the framework registry.
+type RegisteredFramework = intspec.RegisteredFramework
+
+// RegisterFrameworkConfig adds an in-house router framework without forking:
+// when detector matches the analysed project, cfg is used like a built-in
+// default config. Built-in frameworks are detected first. It panics on an
+// empty or built-in name or a nil detector/config.
+//
+//	func init() {
+//		spec.RegisterFrameworkConfig("acme-router",
+//			spec.ImportDetector(`^github\.com/acme/router$`), acmeConfig())
+//	}
+func RegisterFrameworkConfig(name string, detector FrameworkDetectFunc, cfg *APISpecConfig) {
+	intspec.RegisterFrameworkConfig(name, detector, cfg)
+}
+
+// UnregisterFrameworkConfig removes a registered framework.
+func UnregisterFrameworkConfig(name string) { intspec.UnregisterFrameworkConfig(name) }
+
+// RegisteredFrameworks returns the registry in registration order.
+func RegisteredFrameworks() []RegisteredFramework { return intspec.RegisteredFrameworks() }
+
+// RegisteredFrameworkConfig returns a mutable copy of a registered config.
+func RegisteredFrameworkConfig(name string) (*APISpecConfig, bool) {
+	return intspec.RegisteredFrameworkConfig(name)
+}
+
+// ImportDetector matches projects importing a package whose path matches any
+// of the regexes.
+func ImportDetector(importRegexes ...string) FrameworkDetectFunc {
+	return intspec.ImportDetector(importRegexes...)
+}
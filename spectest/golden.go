@@ -0,0 +1,246 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spectest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/engine"
+	"github.com/ehabterra/apispec/internal/yamlout"
+	"github.com/ehabterra/apispec/spec"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// GoldenFile is the file, in a project's directory, holding the spec the
+// project is expected to generate.
+const GoldenFile = "expected_openapi.yaml"
+
+// ProjectConfigFile is the apispec config a project directory may carry;
+// CheckGolden generates with it when present.
+const ProjectConfigFile = "apispec.yaml"
+
+// GoldenOptions controls CheckGolden.
+type GoldenOptions struct {
+	// Update writes the generated spec as the golden file instead of
+	// comparing with it.
+	Update bool
+
+	// GoldenFile names the golden file; empty is GoldenFile.
+	GoldenFile string
+
+	// Config is the config to generate with. Nil uses the project's
+	// ProjectConfigFile when it has one and detects the framework otherwise.
+	Config *spec.APISpecConfig
+}
+
+// GoldenResult is the outcome of checking one project.
+type GoldenResult struct {
+	// Dir is the project directory.
+	Dir string
+
+	// Golden is the path of the golden file.
+	Golden string
+
+	// Missing reports that there was no golden file to compare with.
+	Missing bool
+
+	// Updated reports that the golden file was written.
+	Updated bool
+
+	// Diff is a unified diff from the golden file to the generated spec,
+	// empty when they match.
+	Diff string
+}
+
+// OK reports whether the generated spec matched the golden file, or the
+// golden file was written.
+func (r *GoldenResult) OK() bool {
+	return r.Updated || (!r.Missing && r.Diff == "")
+}
+
+// FindProjects returns the directories under root, root included, that hold
+// a go.mod, in lexical order. Directories starting with "." or "_" are
+// skipped, as the go tool skips them.
+func FindProjects(root string) ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			if d.Name() == "go.mod" {
+				dirs = append(dirs, filepath.Dir(path))
+			}
+			return nil
+		}
+		if path != root && (strings.HasPrefix(d.Name(), ".") || strings.HasPrefix(d.Name(), "_")) {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return dirs, err
+}
+
+// CheckGolden generates the spec for the project in dir, with opts.Config
+// or else the project's ProjectConfigFile, and compares it with the
+// project's golden file, or writes the golden file when opts.Update is set.
+func CheckGolden(dir string, opts GoldenOptions) (*GoldenResult, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	engineConfig := engine.DefaultEngineConfig()
+	engineConfig.InputDir = abs
+	engineConfig.APISpecConfig = opts.Config
+	if opts.Config == nil {
+		engineConfig.ConfigFile = ProjectConfig(abs)
+	}
+	out, err := engine.NewEngine(engineConfig).GenerateOpenAPI()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", dir, err)
+	}
+
+	got, err := EncodeGolden(out, dir)
+	if err != nil {
+		return nil, err
+	}
+	return CompareGolden(dir, got, opts)
+}
+
+// ProjectConfig returns the path of the ProjectConfigFile in dir, or ""
+// when dir has none.
+func ProjectConfig(dir string) string {
+	path := filepath.Join(dir, ProjectConfigFile)
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// EncodeGolden encodes s, generated from the project in dir, as YAML the way
+// `apispec -o spec.yaml` writes it. Paths under dir (the operationIds of
+// function literals carry their file) are written relative to dir, so a
+// golden file does not depend on where the project is checked out.
+func EncodeGolden(s *spec.OpenAPISpec, dir string) ([]byte, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := yamlout.Encode(&buf, s, yamlout.Options{}); err != nil {
+		return nil, err
+	}
+	return relativizePaths(buf.Bytes(), abs), nil
+}
+
+// CompareGolden compares got, a spec EncodeGolden encoded for the project
+// in dir, with the project's golden file, or writes it as the golden file
+// when opts.Update is set. opts.Config is not used.
+func CompareGolden(dir string, got []byte, opts GoldenOptions) (*GoldenResult, error) {
+	name := opts.GoldenFile
+	if name == "" {
+		name = GoldenFile
+	}
+	res := &GoldenResult{Dir: dir, Golden: filepath.Join(dir, name)}
+
+	if opts.Update {
+		if err := os.WriteFile(res.Golden, got, 0644); err != nil {
+			return nil, err
+		}
+		res.Updated = true
+		return res, nil
+	}
+
+	want, err := os.ReadFile(res.Golden)
+	if errors.Is(err, fs.ErrNotExist) {
+		res.Missing = true
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(want, got) {
+		return res, nil
+	}
+
+	res.Diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(want)),
+		B:        difflib.SplitLines(string(got)),
+		FromFile: res.Golden,
+		ToFile:   "generated",
+		Context:  3,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// relativizePaths rewrites the paths under dir in data relative to dir.
+func relativizePaths(data []byte, dir string) []byte {
+	dirs := []string{dir}
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil && resolved != dir {
+		dirs = append(dirs, resolved)
+	}
+	for _, d := range dirs {
+		data = bytes.ReplaceAll(data, []byte(d+string(filepath.Separator)), nil)
+	}
+	return data
+}
+
+// Golden is CheckGolden for a test: it fails t with the diff when the
+// generated spec does not match the golden file, or when there is none.
+func Golden(t testing.TB, dir string, opts GoldenOptions) {
+	t.Helper()
+	res, err := CheckGolden(dir, opts)
+	if err != nil {
+		t.Fatalf("spectest: %v", err)
+	}
+	switch {
+	case res.Missing:
+		t.Errorf("%s: no golden file %s; write it with GoldenOptions.Update", dir, res.Golden)
+	case res.Diff != "":
+		t.Errorf("%s: generated spec differs from %s:\n%s", dir, res.Golden, res.Diff)
+	}
+}
+
+// GoldenTree runs Golden in a subtest for every project FindProjects finds
+// under root, named after the project's path relative to root.
+func GoldenTree(t *testing.T, root string, opts GoldenOptions) {
+	t.Helper()
+	dirs, err := FindProjects(root)
+	if err != nil {
+		t.Fatalf("spectest: %v", err)
+	}
+	if len(dirs) == 0 {
+		t.Fatalf("spectest: no projects under %s", root)
+	}
+	for _, dir := range dirs {
+		name, err := filepath.Rel(root, dir)
+		if err != nil {
+			name = dir
+		}
+		t.Run(filepath.ToSlash(name), func(t *testing.T) {
+			Golden(t, dir, opts)
+		})
+	}
+}
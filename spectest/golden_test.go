@@ -0,0 +1,100 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spectest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const goldenSrc = `package main
+
+import "net/http"
+
+func main() {
+	http.HandleFunc("GET /users", func(w http.ResponseWriter, r *http.Request) {})
+}
+`
+
+// writeModule writes a module made of main.go to dir.
+func writeModule(t *testing.T, dir, src string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"go.mod": goMod, "main.go": src} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCheckGolden(t *testing.T) {
+	dir := t.TempDir()
+	writeModule(t, dir, goldenSrc)
+
+	res, err := CheckGolden(dir, GoldenOptions{})
+	if err != nil {
+		t.Fatalf("CheckGolden failed: %v", err)
+	}
+	if !res.Missing || res.OK() {
+		t.Fatalf("expected a missing golden file, got %+v", res)
+	}
+
+	if res, err = CheckGolden(dir, GoldenOptions{Update: true}); err != nil || !res.Updated {
+		t.Fatalf("update: %+v, %v", res, err)
+	}
+	golden, err := os.ReadFile(filepath.Join(dir, GoldenFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(golden), dir) {
+		t.Errorf("golden file holds the absolute project path:\n%s", golden)
+	}
+
+	if res, err = CheckGolden(dir, GoldenOptions{}); err != nil || !res.OK() {
+		t.Fatalf("expected a match after update: %+v, %v", res, err)
+	}
+
+	writeModule(t, dir, strings.Replace(goldenSrc, "GET /users", "GET /accounts", 1))
+	if res, err = CheckGolden(dir, GoldenOptions{}); err != nil || res.OK() {
+		t.Fatalf("expected a mismatch: %+v, %v", res, err)
+	}
+	if !strings.Contains(res.Diff, "-  /users:") || !strings.Contains(res.Diff, "+  /accounts:") {
+		t.Errorf("unexpected diff:\n%s", res.Diff)
+	}
+}
+
+func TestFindProjects(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"b", "a", "a/nested", ".hidden", "_skipped"} {
+		writeModule(t, filepath.Join(root, dir), goldenSrc)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "notamodule"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	dirs, err := FindProjects(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(root, "a"), filepath.Join(root, "a", "nested"), filepath.Join(root, "b")}
+	if !reflect.DeepEqual(dirs, want) {
+		t.Errorf("FindProjects = %v, want %v", dirs, want)
+	}
+}
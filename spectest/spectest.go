@@ -0,0 +1,155 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spectest runs the full apispec pipeline over Go source held in
+// strings and returns the extracted routes and the generated spec, for tests
+// of framework patterns and custom configs:
+//
+//	res := spectest.Run(t, spec.DefaultHTTPConfig(), `package main
+//	...
+//	func main() { http.HandleFunc("GET /users/{id}", getUser) }`)
+//	if res.Operation("GET", "/users/{id}") == nil { ... }
+//
+// The sources are written to a temporary module, since the Go toolchain
+// loads packages from disk; nothing else touches the filesystem.
+package spectest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ehabterra/apispec/internal/engine"
+	"github.com/ehabterra/apispec/spec"
+)
+
+// ModulePath is the module path of the module the sources are written to,
+// unless Files supplies its own go.mod. A file in a subdirectory
+// ("handlers/users.go") is in package ModulePath + "/handlers".
+const ModulePath = "example.com/app"
+
+// goMod is the go.mod written when Files has none. Go 1.22 enables the
+// method and wildcard patterns of net/http.ServeMux.
+const goMod = "module " + ModulePath + "\n\ngo 1.22\n"
+
+// Files maps file names, relative to the module root, to their contents.
+// Sources importing modules other than the standard library need a go.mod
+// requiring them and the matching go.sum.
+type Files map[string]string
+
+// Result is what the pipeline produced for the sources.
+type Result struct {
+	// Spec is the generated OpenAPI spec.
+	Spec *spec.OpenAPISpec
+
+	// Routes are the extracted routes Spec's paths were built from.
+	Routes []*spec.RouteInfo
+}
+
+// Run generates the spec for src, the contents of main.go, with cfg (nil
+// detects the framework and uses its defaults). It fails t if generation
+// fails.
+func Run(t testing.TB, cfg *spec.APISpecConfig, src string) *Result {
+	t.Helper()
+	return RunFiles(t, cfg, Files{"main.go": src})
+}
+
+// RunFiles is Run for a module of several files.
+func RunFiles(t testing.TB, cfg *spec.APISpecConfig, files Files) *Result {
+	t.Helper()
+	res, err := Generate(t.TempDir(), cfg, files)
+	if err != nil {
+		t.Fatalf("spectest: %v", err)
+	}
+	return res
+}
+
+// Generate writes files to dir, which should be empty, and generates the
+// spec for the module they make up. It is Run for callers without a
+// testing.TB, or that keep the module around to inspect.
+func Generate(dir string, cfg *spec.APISpecConfig, files Files) (*Result, error) {
+	if _, ok := files["go.mod"]; !ok {
+		if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0644); err != nil {
+			return nil, err
+		}
+	}
+	for name, content := range files {
+		if !filepath.IsLocal(name) {
+			return nil, fmt.Errorf("file name %q is not relative to the module root", name)
+		}
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	engineConfig := engine.DefaultEngineConfig()
+	engineConfig.InputDir = dir
+	engineConfig.APISpecConfig = cfg
+	e := engine.NewEngine(engineConfig)
+	out, err := e.GenerateOpenAPI()
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Spec: out, Routes: e.GetRoutes()}, nil
+}
+
+// Route returns the extracted route for method on path, an OpenAPI path such
+// as "/users/{id}", or nil when there is none.
+func (r *Result) Route(method, path string) *spec.RouteInfo {
+	for _, route := range r.Routes {
+		if route.OpenAPIPath() == path && strings.EqualFold(route.Method, method) {
+			return route
+		}
+	}
+	return nil
+}
+
+// Operation returns the spec's operation for method on path, or nil when
+// there is none.
+func (r *Result) Operation(method, path string) *spec.Operation {
+	item, ok := r.Spec.Paths[path]
+	if !ok {
+		return nil
+	}
+	return item.Operation(method)
+}
+
+// Schema returns the component schema named name or, failing that, the one
+// component whose title is name: the Go type name of a generated schema,
+// whose component name is qualified by its package. It returns nil when
+// there is no such schema or several share the title.
+func (r *Result) Schema(name string) *spec.Schema {
+	if r.Spec.Components == nil {
+		return nil
+	}
+	if s, ok := r.Spec.Components.Schemas[name]; ok {
+		return s
+	}
+	var found *spec.Schema
+	for _, s := range r.Spec.Components.Schemas {
+		if s != nil && s.Title == name {
+			if found != nil {
+				return nil
+			}
+			found = s
+		}
+	}
+	return found
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Ehab Terra
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spectest
+
+import (
+	"testing"
+
+	"github.com/ehabterra/apispec/spec"
+)
+
+func TestRun(t *testing.T) {
+	res := Run(t, spec.DefaultHTTPConfig(), `package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type User struct {
+	ID   string `+"`json:\"id\"`"+`
+	Name string `+"`json:\"name\"`"+`
+}
+
+func getUser(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(User{ID: r.PathValue("id")})
+}
+
+func createUser(w http.ResponseWriter, r *http.Request) {
+	var u User
+	json.NewDecoder(r.Body).Decode(&u)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(u)
+}
+
+func main() {
+	http.HandleFunc("GET /users/{id}", getUser)
+	http.HandleFunc("POST /users", createUser)
+	http.ListenAndServe(":8080", nil)
+}
+`)
+
+	if len(res.Routes) != 2 {
+		t.Fatalf("got %d routes, want 2", len(res.Routes))
+	}
+	route := res.Route("post", "/users")
+	if route == nil {
+		t.Fatal("POST /users not extracted")
+	}
+	if route.Request == nil || route.Request.Schema == nil {
+		t.Errorf("POST /users request = %+v, want a User body", route.Request)
+	}
+	if _, ok := route.Response["201"]; !ok {
+		t.Errorf("POST /users responses = %v, want 201", route.Response)
+	}
+	if res.Operation("GET", "/users/{id}") == nil {
+		t.Error("GET /users/{id} missing from the spec")
+	}
+	if res.Operation("DELETE", "/users/{id}") != nil || res.Route("GET", "/missing") != nil {
+		t.Error("lookups of undocumented routes returned one")
+	}
+	user := res.Schema("User")
+	if user == nil || user.Properties["name"] == nil {
+		t.Errorf("User schema = %+v, want a name property", user)
+	}
+}
+
+func TestRunFiles(t *testing.T) {
+	cfg := spec.DefaultHTTPConfig()
+	cfg.Exclude.Routes = []string{"/internal/**"}
+	res := RunFiles(t, cfg, Files{
+		"main.go": `package main
+
+import (
+	"net/http"
+
+	"example.com/app/handlers"
+)
+
+func main() {
+	http.HandleFunc("GET /orders", handlers.ListOrders)
+	http.HandleFunc("GET /internal/stats", handlers.Stats)
+	http.ListenAndServe(":8080", nil)
+}
+`,
+		"handlers/orders.go": `package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type Order struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+func ListOrders(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]Order{})
+}
+
+func Stats(w http.ResponseWriter, r *http.Request) {}
+`,
+	})
+
+	if len(res.Routes) != 1 || res.Route("GET", "/orders") == nil {
+		t.Fatalf("routes = %d, want only GET /orders", len(res.Routes))
+	}
+	if res.Schema("Order") == nil {
+		t.Error("Order schema from the handlers package missing")
+	}
+}
+
+func TestGenerateRejectsPathsOutsideTheModule(t *testing.T) {
+	if _, err := Generate(t.TempDir(), nil, Files{"../main.go": "package main"}); err == nil {
+		t.Fatal("Generate accepted a file outside the module")
+	}
+}
@@ -0,0 +1,74 @@
+// Fixture for gin's struct binders: ShouldBindQuery and ShouldBindUri expand
+// into one parameter per field, constrained by the field's validate tag.
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Status is a product's lifecycle state.
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusPublished Status = "published"
+)
+
+// Paging is shared by every list endpoint.
+type Paging struct {
+	// Page is 1-based.
+	Page    int `form:"page" validate:"omitempty,min=1"`
+	PerPage int `form:"per_page" validate:"omitempty,min=1,max=100"`
+}
+
+type ListProductsQuery struct {
+	Paging
+	Sort   string   `form:"sort" validate:"omitempty,oneof=name price"`
+	SKU    string   `form:"sku" validate:"omitempty,regexp=^[A-Z]{3}-[0-9]{4}$"`
+	Status Status   `form:"status"`
+	Tags   []string `form:"tag" validate:"max=5"`
+	Tenant string   `form:"tenant" validate:"required"`
+	Filter Filter   `form:"filter"`
+	Debug  bool     `form:"-"`
+	cursor string
+}
+
+type Filter struct {
+	From string `json:"from"`
+}
+
+type ProductURI struct {
+	ID string `uri:"id" validate:"uuid"`
+}
+
+type Product struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func listProducts(c *gin.Context) {
+	var q ListProductsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, []Product{})
+}
+
+func getProduct(c *gin.Context) {
+	var uri ProductURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Product{ID: uri.ID})
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/products", listProducts)
+	r.GET("/products/:id", getProduct)
+	_ = r.Run(":8080")
+}
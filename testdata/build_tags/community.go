@@ -0,0 +1,8 @@
+//go:build !enterprise
+
+package main
+
+import "net/http"
+
+// registerEdition registers nothing in the community edition.
+func registerEdition(mux *http.ServeMux) {}
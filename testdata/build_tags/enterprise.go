@@ -0,0 +1,23 @@
+//go:build enterprise
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// License is an enterprise license.
+type License struct {
+	Key   string `json:"key"`
+	Seats int    `json:"seats"`
+}
+
+func listLicenses(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]License{})
+}
+
+// registerEdition registers the licensing endpoints.
+func registerEdition(mux *http.ServeMux) {
+	mux.HandleFunc("GET /licenses", listLicenses)
+}
@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/build_tags
+
+go 1.22
@@ -0,0 +1,29 @@
+// Package main registers routes from files behind build constraints: the
+// licensing endpoints of the enterprise edition (//go:build enterprise) and
+// a FreeBSD-only kernel stats endpoint. Neither is in the default build, so
+// this golden file documents /users alone; --build-tags enterprise and
+// --goos freebsd bring them in.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// User is an account.
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func listUsers(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]User{})
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users", listUsers)
+	registerEdition(mux)
+	registerPlatform(mux)
+	http.ListenAndServe(":8080", mux)
+}
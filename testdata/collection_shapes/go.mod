@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/collection_shapes
+
+go 1.22
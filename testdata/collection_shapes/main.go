@@ -0,0 +1,69 @@
+// Package main serves an inventory whose fields and bodies nest maps and
+// slices of structs, each documented as an additionalProperties/items chain
+// ending in a $ref to the struct's component.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Item is a stocked product.
+type Item struct {
+	SKU  string `json:"sku"`
+	Name string `json:"name"`
+}
+
+// Shelf identifies a shelf; encoding/json writes its keys as strings.
+type Shelf int
+
+// Inventory nests its items in maps and slices.
+type Inventory struct {
+	BySKU    map[string]Item            `json:"bySku"`
+	Pinned   map[string]*Item           `json:"pinned"`
+	Batches  []map[string]Item          `json:"batches"`
+	Grid     [][]Item                   `json:"grid"`
+	Bins     [][]*Item                  `json:"bins"`
+	Groups   map[string][]Item          `json:"groups"`
+	Zones    map[string]map[string]Item `json:"zones"`
+	ByShelf  map[Shelf]Item             `json:"byShelf"`
+	ByRow    map[int][]Item             `json:"byRow"`
+	Pallets  [2][]Item                  `json:"pallets"`
+	Labels   [][]string                 `json:"labels"`
+	Restocks map[string][]*Item         `json:"restocks"`
+}
+
+func getInventory(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Inventory{})
+}
+
+func listGrid(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([][]Item{})
+}
+
+func listPinned(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]*Item{})
+}
+
+func listByShelf(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[Shelf][]Item{})
+}
+
+func importBatches(w http.ResponseWriter, r *http.Request) {
+	var batches []map[string]Item
+	if err := json.NewDecoder(r.Body).Decode(&batches); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /inventory", getInventory)
+	mux.HandleFunc("GET /inventory/grid", listGrid)
+	mux.HandleFunc("GET /inventory/pinned", listPinned)
+	mux.HandleFunc("GET /inventory/shelves", listByShelf)
+	mux.HandleFunc("POST /inventory/batches", importBatches)
+	http.ListenAndServe(":8080", mux)
+}
@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/conditional_requests
+
+go 1.22
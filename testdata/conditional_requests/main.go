@@ -0,0 +1,91 @@
+// Package main exercises conditional requests: handlers that read
+// If-None-Match / If-Modified-Since answer 304 Not Modified on GET and 412
+// Precondition Failed on writes, handlers that read If-Match /
+// If-Unmodified-Since answer 412, whether or not the handler writes that
+// status itself.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type Article struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	ETag  string `json:"-"`
+}
+
+var articles = map[string]*Article{}
+
+// getArticle returns an article, or 304 when the client's copy is current.
+func getArticle(w http.ResponseWriter, r *http.Request) {
+	a := articles[r.PathValue("id")]
+	if a == nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("ETag", a.ETag)
+	if r.Header.Get("If-None-Match") == a.ETag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	json.NewEncoder(w).Encode(a)
+}
+
+// listArticles returns every article unless none changed since the client's
+// last fetch.
+func listArticles(w http.ResponseWriter, r *http.Request) {
+	_ = r.Header.Get("If-Modified-Since")
+	w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+	list := make([]Article, 0, len(articles))
+	for _, a := range articles {
+		list = append(list, *a)
+	}
+	json.NewEncoder(w).Encode(list)
+}
+
+// updateArticle replaces an article the client last saw at If-Match.
+func updateArticle(w http.ResponseWriter, r *http.Request) {
+	a := articles[r.PathValue("id")]
+	if a == nil || r.Header.Get("If-Match") != a.ETag {
+		http.Error(w, "article changed", http.StatusPreconditionFailed)
+		return
+	}
+	var next Article
+	if err := json.NewDecoder(r.Body).Decode(&next); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("ETag", next.ETag)
+	json.NewEncoder(w).Encode(next)
+}
+
+// deleteArticle deletes an article unchanged since If-Unmodified-Since.
+func deleteArticle(w http.ResponseWriter, r *http.Request) {
+	_ = r.Header.Get("If-Unmodified-Since")
+	delete(articles, r.PathValue("id"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// putDraft creates a draft only when none exists (If-None-Match: *).
+func putDraft(w http.ResponseWriter, r *http.Request) {
+	_ = r.Header.Get("If-None-Match")
+	w.WriteHeader(http.StatusCreated)
+}
+
+// health reports liveness.
+func health(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /articles", listArticles)
+	mux.HandleFunc("GET /articles/{id}", getArticle)
+	mux.HandleFunc("PUT /articles/{id}", updateArticle)
+	mux.HandleFunc("DELETE /articles/{id}", deleteArticle)
+	mux.HandleFunc("PUT /drafts/{id}", putDraft)
+	mux.HandleFunc("GET /health", health)
+	http.ListenAndServe(":8080", mux)
+}
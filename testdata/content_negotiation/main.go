@@ -0,0 +1,66 @@
+// Package main answers in JSON, XML or YAML depending on the request's
+// Accept header.
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	XMLName xml.Name `json:"-" xml:"user" yaml:"-"`
+	ID      string   `json:"id" xml:"id,attr" yaml:"id"`
+	Name    string   `json:"name" xml:"name" yaml:"name"`
+}
+
+type Report struct {
+	Title string `json:"title" xml:"title"`
+	Rows  int    `json:"rows" xml:"rows"`
+}
+
+type apiError struct {
+	Message string `json:"message" xml:"message" yaml:"message"`
+}
+
+// getUser returns a user in the format the client accepts.
+func getUser(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusNotFound, apiError{Message: "not found"})
+		return
+	}
+	u := User{ID: id, Name: "Ada"}
+	switch c.NegotiateFormat(gin.MIMEJSON, gin.MIMEXML, gin.MIMEYAML) {
+	case gin.MIMEXML:
+		c.XML(http.StatusOK, u)
+	case gin.MIMEYAML:
+		c.YAML(http.StatusOK, u)
+	default:
+		c.JSON(http.StatusOK, u)
+	}
+}
+
+// report writes the report with the encoder the Accept header asks for.
+func report(w http.ResponseWriter, r *http.Request) {
+	rep := Report{Title: "daily", Rows: 3}
+	if strings.Contains(r.Header.Get("Accept"), "xml") {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusOK)
+		xml.NewEncoder(w).Encode(rep)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(rep)
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/users/:id", getUser)
+	r.GET("/report", gin.WrapF(report))
+	r.Run(":8080")
+}
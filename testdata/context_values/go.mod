@@ -0,0 +1,5 @@
+module github.com/ehabterra/apispec/testdata/context_values
+
+go 1.22
+
+require github.com/go-chi/chi/v5 v5.2.2
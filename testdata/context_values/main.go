@@ -0,0 +1,73 @@
+// Package main exercises context-value-driven handlers: authenticate stores
+// the signed-in User in the request context under userKey, resolving the
+// {userID} of the path, and handlers read it back — directly, or through a
+// helper — instead of parsing the token or the path themselves. Config
+// (contextValues) documents what reading the value implies: the bearer
+// authentication and the userID path parameter.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ctxKey string
+
+const userKey ctxKey = "user"
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Order struct {
+	ID    int     `json:"id"`
+	Total float64 `json:"total"`
+}
+
+// authenticate resolves the bearer token and the {userID} path segment to the
+// User the handlers act for.
+func authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.Atoi(chi.URLParam(r, "userID"))
+		user := User{ID: id, Name: r.Header.Get("Authorization")}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userKey, user)))
+	})
+}
+
+func currentUser(ctx context.Context) User {
+	user, _ := ctx.Value(userKey).(User)
+	return user
+}
+
+// getMe reads the user through a helper.
+func getMe(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(currentUser(r.Context()))
+}
+
+// listOrders reads the user directly; the {userID} it serves was consumed by
+// authenticate.
+func listOrders(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(userKey).(User)
+	json.NewEncoder(w).Encode([]Order{{ID: user.ID}})
+}
+
+// health reads nothing from the context.
+func health(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func main() {
+	r := chi.NewRouter()
+	r.Get("/health", health)
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Get("/me", getMe)
+		r.Get("/users/{userID}/orders", listOrders)
+	})
+	http.ListenAndServe(":8080", r)
+}
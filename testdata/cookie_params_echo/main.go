@@ -0,0 +1,32 @@
+// Package main reads and sets cookies through echo's Context.
+package main
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Profile struct {
+	Username string `json:"username"`
+}
+
+func login(c echo.Context) error {
+	c.SetCookie(&http.Cookie{Name: "session", Value: "token", HttpOnly: true})
+	return c.NoContent(http.StatusNoContent)
+}
+
+func me(c echo.Context) error {
+	session, err := c.Cookie("session")
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	return c.JSON(http.StatusOK, Profile{Username: session.Value})
+}
+
+func main() {
+	e := echo.New()
+	e.POST("/login", login)
+	e.GET("/me", me)
+	e.Logger.Fatal(e.Start(":8080"))
+}
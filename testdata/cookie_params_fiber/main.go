@@ -0,0 +1,36 @@
+// Package main reads and sets cookies through fiber's Ctx.
+package main
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type Profile struct {
+	Username string `json:"username"`
+}
+
+func login(c *fiber.Ctx) error {
+	c.Cookie(&fiber.Cookie{Name: "session", Value: "token", HTTPOnly: true})
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func me(c *fiber.Ctx) error {
+	session := c.Cookies("session")
+	if session == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	return c.Status(fiber.StatusOK).JSON(Profile{Username: session})
+}
+
+func logout(c *fiber.Ctx) error {
+	c.ClearCookie("session")
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func main() {
+	app := fiber.New()
+	app.Post("/login", login)
+	app.Get("/me", me)
+	app.Post("/logout", logout)
+	_ = app.Listen(":8080")
+}
@@ -0,0 +1,33 @@
+// Package main reads and sets cookies through gin's Context.
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Profile struct {
+	Username string `json:"username"`
+}
+
+func login(c *gin.Context) {
+	c.SetCookie("session", "token", 3600, "/", "", true, true)
+	c.Status(http.StatusNoContent)
+}
+
+func me(c *gin.Context) {
+	session, err := c.Cookie("session")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	c.JSON(http.StatusOK, Profile{Username: session})
+}
+
+func main() {
+	r := gin.Default()
+	r.POST("/login", login)
+	r.GET("/me", me)
+	_ = r.Run(":8080")
+}
@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/cookie_params_http
+
+go 1.22
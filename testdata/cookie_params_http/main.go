@@ -0,0 +1,57 @@
+// Package main is a session-based API on net/http: login sets the session
+// cookie, the other endpoints read it.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const sessionCookie = "session"
+
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type Profile struct {
+	Username string `json:"username"`
+}
+
+func login(w http.ResponseWriter, r *http.Request) {
+	var creds Credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: "token", HttpOnly: true})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func me(w http.ResponseWriter, r *http.Request) {
+	c, err := r.Cookie(sessionCookie)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	_, _ = r.Cookie("theme")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(Profile{Username: c.Value})
+}
+
+func logout(w http.ResponseWriter, r *http.Request) {
+	if _, err := r.Cookie(sessionCookie); err != nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Add("Set-Cookie", sessionCookie+"=; Max-Age=0")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login", login)
+	mux.HandleFunc("GET /me", me)
+	mux.HandleFunc("POST /logout", logout)
+	_ = http.ListenAndServe(":8080", mux)
+}
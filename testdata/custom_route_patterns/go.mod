@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/custom_route_patterns
+
+go 1.22
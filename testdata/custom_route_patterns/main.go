@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ehabterra/apispec/testdata/custom_route_patterns/server"
+)
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type CreateUserRequest struct {
+	Name string `json:"name"`
+}
+
+func main() {
+	srv := server.New()
+
+	srv.RegisterJSON("GET /users/{id}", getUser)
+	srv.GetJSON("/users", listUsers)
+	srv.PostJSON("/users", createUser)
+	srv.Route(http.MethodDelete, "/users/{id}", deleteUser)
+
+	_ = srv.ListenAndServe(":8080")
+}
+
+func getUser(w http.ResponseWriter, r *http.Request) {
+	user := User{ID: r.PathValue("id"), Name: "John"}
+	_ = json.NewEncoder(w).Encode(user)
+}
+
+func listUsers(w http.ResponseWriter, r *http.Request) {
+	users := []User{{ID: "1", Name: "John"}}
+	_ = json.NewEncoder(w).Encode(users)
+}
+
+func createUser(w http.ResponseWriter, r *http.Request) {
+	var req CreateUserRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(User{ID: "2", Name: req.Name})
+}
+
+func deleteUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
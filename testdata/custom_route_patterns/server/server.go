@@ -0,0 +1,48 @@
+// Package server is a homegrown wrapper around http.ServeMux.
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type Server struct {
+	mux *http.ServeMux
+}
+
+func New() *Server {
+	return &Server{mux: http.NewServeMux()}
+}
+
+// RegisterJSON registers h under a Go 1.22 ServeMux pattern ("GET /users/{id}")
+// and marks its responses as JSON.
+func (s *Server) RegisterJSON(pattern string, h http.HandlerFunc) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		h(w, r)
+	})
+}
+
+// GetJSON and PostJSON fix the method in the registration name.
+func (s *Server) GetJSON(path string, h http.HandlerFunc) {
+	s.RegisterJSON(http.MethodGet+" "+path, h)
+}
+
+func (s *Server) PostJSON(path string, h http.HandlerFunc) {
+	s.RegisterJSON(http.MethodPost+" "+path, h)
+}
+
+// Route takes the method as its own argument.
+func (s *Server) Route(method, path string, h http.HandlerFunc) {
+	s.RegisterJSON(method+" "+path, h)
+}
+
+func (s *Server) ListenAndServe(addr string) error {
+	return http.ListenAndServe(addr, s.mux)
+}
+
+// WriteJSON encodes v as the response body.
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
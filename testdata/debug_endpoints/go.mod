@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/debug_endpoints
+
+go 1.22
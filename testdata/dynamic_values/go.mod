@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/dynamic_values
+
+go 1.22
@@ -0,0 +1,43 @@
+// Package main stores events whose payloads the service passes through
+// without decoding.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Event is a stored event.
+type Event struct {
+	ID string `json:"id"`
+	// Payload is the event body, as the producer sent it.
+	Payload json.RawMessage `json:"payload"`
+	// Previous is the payload the event replaced.
+	Previous *json.RawMessage           `json:"previous,omitempty"`
+	Context  any                        `json:"context"`
+	Source   interface{}                `json:"source"`
+	Labels   map[string]any             `json:"labels"`
+	Trace    []any                      `json:"trace"`
+	Parts    map[string]json.RawMessage `json:"parts"`
+}
+
+func getEvent(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Event{ID: r.PathValue("id")})
+}
+
+func createEvent(w http.ResponseWriter, r *http.Request) {
+	var fields map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(fields)
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /events/{id}", getEvent)
+	mux.HandleFunc("POST /events", createEvent)
+	http.ListenAndServe(":8080", mux)
+}
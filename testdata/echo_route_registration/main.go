@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Item is the resource the routes below serve.
+type Item struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ErrorBody is the catch-all 404 body.
+type ErrorBody struct {
+	Message string `json:"message"`
+}
+
+// listItems is registered via e.Add with an http.Method* constant.
+func listItems(c echo.Context) error {
+	return c.JSON(http.StatusOK, []Item{})
+}
+
+// deleteItem is registered via e.Add with a string literal verb.
+func deleteItem(c echo.Context) error {
+	_ = c.Param("id")
+	return c.NoContent(http.StatusNoContent)
+}
+
+// updateItem is registered for PUT and PATCH via e.Match.
+func updateItem(c echo.Context) error {
+	var item Item
+	if err := c.Bind(&item); err != nil {
+		return err
+	}
+	item.ID = c.Param("id")
+	return c.JSON(http.StatusOK, item)
+}
+
+// createItem is registered on a group via Add.
+func createItem(c echo.Context) error {
+	var item Item
+	if err := c.Bind(&item); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, item)
+}
+
+// status is registered on a group for GET and HEAD via Match.
+func status(c echo.Context) error {
+	return c.String(http.StatusOK, "ok")
+}
+
+// notFound answers every unmatched path.
+func notFound(c echo.Context) error {
+	return c.JSON(http.StatusNotFound, ErrorBody{Message: "not found"})
+}
+
+// MetricsHandler is a plain http.Handler mounted through echo.WrapHandler.
+type MetricsHandler struct{}
+
+// ServeHTTP writes the metrics.
+func (MetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("metrics"))
+}
+
+// legacyEcho is a net/http handler func mounted through echo.WrapHandler.
+func legacyEcho(w http.ResponseWriter, r *http.Request) {
+	var item Item
+	_ = json.NewDecoder(r.Body).Decode(&item)
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(item)
+}
+
+func main() {
+	e := echo.New()
+
+	e.Add(http.MethodGet, "/items", listItems)
+	e.Add("DELETE", "/items/:id", deleteItem)
+	e.Match([]string{http.MethodPut, http.MethodPatch}, "/items/:id", updateItem)
+
+	api := e.Group("/api")
+	api.Add(http.MethodPost, "/items", createItem)
+	api.Match([]string{"GET", "HEAD"}, "/status", status)
+
+	e.GET("/metrics", echo.WrapHandler(MetricsHandler{}))
+	e.POST("/legacy", echo.WrapHandler(http.HandlerFunc(legacyEcho)))
+
+	e.RouteNotFound("/*", notFound)
+
+	e.Logger.Fatal(e.Start(":8080"))
+}
@@ -0,0 +1,49 @@
+// Package apierr writes the service's error envelope.
+package apierr
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ErrNotFound is returned by lookups that find nothing.
+var ErrNotFound = errors.New("not found")
+
+// Problem is the body of every error Write sends.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Message is the body echo's default error handler writes for an
+// *echo.HTTPError.
+type Message struct {
+	Message string `json:"message"`
+}
+
+// Write answers err, choosing its status from the error.
+func Write(c echo.Context, err error) error {
+	status := statusOf(err)
+	return c.JSON(status, map[string]any{
+		"type":   "about:blank",
+		"title":  http.StatusText(status),
+		"status": status,
+		"detail": err.Error(),
+	})
+}
+
+// Reject answers err with the given status.
+func Reject(c echo.Context, status int, err error) error {
+	return c.JSON(status, map[string]any{"title": http.StatusText(status), "detail": err.Error()})
+}
+
+func statusOf(err error) int {
+	if errors.Is(err, ErrNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,63 @@
+// Package main answers errors through echo.NewHTTPError and the apierr
+// helpers, whose bodies the errorResponses config documents.
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/ehabterra/apispec/testdata/error_responses/apierr"
+)
+
+type Order struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+var errClosed = errors.New("orders are closed")
+
+func findOrder(id string) (Order, error) {
+	if id == "" {
+		return Order{}, apierr.ErrNotFound
+	}
+	return Order{ID: id}, nil
+}
+
+// getOrder returns one order.
+func getOrder(c echo.Context) error {
+	o, err := findOrder(c.Param("id"))
+	if err != nil {
+		return apierr.Write(c, err)
+	}
+	return c.JSON(http.StatusOK, o)
+}
+
+// createOrder stores an order.
+func createOrder(c echo.Context) error {
+	var o Order
+	if err := c.Bind(&o); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid order")
+	}
+	if o.Total > 1000 {
+		return apierr.Reject(c, http.StatusUnprocessableEntity, errClosed)
+	}
+	return c.JSON(http.StatusCreated, o)
+}
+
+// deleteOrder removes an order.
+func deleteOrder(c echo.Context) error {
+	if c.Param("id") == "" {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func main() {
+	e := echo.New()
+	e.GET("/orders/:id", getOrder)
+	e.POST("/orders", createOrder)
+	e.DELETE("/orders/:id", deleteOrder)
+	e.Logger.Fatal(e.Start(":8080"))
+}
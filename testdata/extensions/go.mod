@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/extensions
+
+go 1.22
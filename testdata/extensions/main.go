@@ -0,0 +1,40 @@
+// Package main exercises config-driven vendor extensions: platform metadata
+// such as the owning team and the gateway route id is injected into the
+// document, its paths, operations and schemas from the apispec config.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type Order struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+type CreateOrderRequest struct {
+	Total int `json:"total"`
+}
+
+func listOrders(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]Order{})
+}
+
+func createOrder(w http.ResponseWriter, r *http.Request) {
+	var req CreateOrderRequest
+	json.NewDecoder(r.Body).Decode(&req)
+	json.NewEncoder(w).Encode(Order{Total: req.Total})
+}
+
+func getOrder(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Order{ID: r.PathValue("id")})
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orders", listOrders)
+	mux.HandleFunc("POST /orders", createOrder)
+	mux.HandleFunc("GET /orders/{id}", getOrder)
+	http.ListenAndServe(":8080", mux)
+}
@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/file_download
+
+go 1.22
@@ -0,0 +1,80 @@
+// Package main exercises file download detection: a handler that serves a
+// file through http.ServeContent, or marks its body with
+// `Content-Disposition: attachment`, answers with a binary body rather than
+// JSON, while an inline disposition or a plain JSON handler stays as it is.
+// ServeContent and a handler parsing the Range header itself both answer
+// byte-range requests with 206 Partial Content.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+type Report struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// download serves a stored file, honouring Range and If-Modified-Since.
+func download(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	f, err := os.Open(name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	http.ServeContent(w, r, name, time.Time{}, f)
+}
+
+// exportReport streams the report as a CSV attachment.
+func exportReport(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Disposition", `attachment; filename="report.csv"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("id,title\n1,Quarterly\n"))
+}
+
+// archive copies a zip archive to the client.
+func archive(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Disposition", "attachment; filename=archive.zip")
+	io.Copy(w, bytes.NewReader(nil))
+}
+
+// clip returns the requested byte range of a media clip.
+func clip(w http.ResponseWriter, r *http.Request) {
+	data := []byte("0123456789")
+	if rng := r.Header.Get("Range"); rng != "" {
+		w.Header().Set("Content-Range", "bytes 0-3/10")
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(data[:4])
+		return
+	}
+	w.Write(data)
+}
+
+// preview renders the report inline in the browser.
+func preview(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Disposition", "inline")
+	w.Write([]byte("<h1>Quarterly</h1>"))
+}
+
+// report returns the report metadata.
+func report(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Report{ID: "1", Title: "Quarterly"})
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /files/{name}", download)
+	mux.HandleFunc("GET /reports/export", exportReport)
+	mux.HandleFunc("GET /reports/archive", archive)
+	mux.HandleFunc("GET /clips/{id}", clip)
+	mux.HandleFunc("GET /reports/preview", preview)
+	mux.HandleFunc("GET /reports", report)
+	http.ListenAndServe(":8080", mux)
+}
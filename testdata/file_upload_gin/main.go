@@ -0,0 +1,49 @@
+// Fixture: gin file uploads are emitted as multipart/form-data request
+// bodies, both for c.FormFile reads and for a struct bound with a
+// *multipart.FileHeader field.
+package main
+
+import (
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProfileForm is bound from a multipart form.
+type ProfileForm struct {
+	Name   string                `form:"name" json:"name" binding:"required"`
+	Avatar *multipart.FileHeader `form:"avatar" json:"avatar"`
+}
+
+type Stored struct {
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+}
+
+// uploadPhoto stores a single photo.
+func uploadPhoto(c *gin.Context) {
+	file, err := c.FormFile("photo")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, Stored{Filename: file.Filename, Size: file.Size})
+}
+
+// updateProfile binds a form with an optional avatar.
+func updateProfile(c *gin.Context) {
+	var form ProfileForm
+	if err := c.ShouldBind(&form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Stored{Filename: form.Name})
+}
+
+func main() {
+	r := gin.Default()
+	r.POST("/photos", uploadPhoto)
+	r.PUT("/profile", updateProfile)
+	_ = r.Run(":8080")
+}
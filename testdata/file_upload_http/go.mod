@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/file_upload_http
+
+go 1.22
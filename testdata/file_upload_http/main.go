@@ -0,0 +1,62 @@
+// Fixture: file uploads read through net/http are emitted as
+// multipart/form-data request bodies. r.FormFile parts become
+// `{type: string, format: binary}` properties beside the r.FormValue fields,
+// and r.ParseMultipartForm alone is enough to make the form multipart.
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+type Upload struct {
+	Title string `json:"title"`
+	Size  int64  `json:"size"`
+}
+
+// uploadAvatar stores an avatar image with a title.
+func uploadAvatar(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		http.Error(w, "bad form", http.StatusBadRequest)
+		return
+	}
+	file, header, err := r.FormFile("avatar")
+	if err != nil {
+		http.Error(w, "missing avatar", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	title := r.FormValue("title")
+	_, _ = io.Copy(io.Discard, file)
+	_ = json.NewEncoder(w).Encode(Upload{Title: title, Size: header.Size})
+}
+
+// importDocuments accepts any number of files under arbitrary field names.
+func importDocuments(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, "bad form", http.StatusBadRequest)
+		return
+	}
+	var total int64
+	for _, headers := range r.MultipartForm.File {
+		for _, h := range headers {
+			total += h.Size
+		}
+	}
+	_ = json.NewEncoder(w).Encode(Upload{Size: total})
+}
+
+// rename reads a plain urlencoded form.
+func rename(w http.ResponseWriter, r *http.Request) {
+	title := r.FormValue("title")
+	_ = json.NewEncoder(w).Encode(Upload{Title: title})
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /avatars", uploadAvatar)
+	mux.HandleFunc("POST /documents", importDocuments)
+	mux.HandleFunc("POST /rename", rename)
+	_ = http.ListenAndServe(":8080", mux)
+}
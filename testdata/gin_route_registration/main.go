@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Order is the resource the routes below serve.
+type Order struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+// listOrders is registered via r.Handle with an http.Method* constant.
+func listOrders(c *gin.Context) {
+	c.JSON(http.StatusOK, []Order{})
+}
+
+// createOrder is registered on a group via Handle with a string literal verb.
+func createOrder(c *gin.Context) {
+	var order Order
+	if err := c.ShouldBindJSON(&order); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusCreated, order)
+}
+
+// ping answers every verb, registered via r.Any.
+func ping(c *gin.Context) {
+	c.String(http.StatusOK, "pong")
+}
+
+// VersionHandler is a plain http.Handler mounted through gin.WrapH.
+type VersionHandler struct{}
+
+// ServeHTTP writes the build version.
+func (VersionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]string{"version": "1.0.0"})
+}
+
+// legacyOrder is a net/http handler func mounted through gin.WrapF.
+func legacyOrder(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(Order{ID: r.URL.Query().Get("id")})
+}
+
+func main() {
+	r := gin.Default()
+
+	r.Handle(http.MethodGet, "/orders", listOrders)
+	r.Any("/ping", ping)
+
+	api := r.Group("/api")
+	api.Handle("POST", "/orders", createOrder)
+
+	r.GET("/version", gin.WrapH(VersionHandler{}))
+	r.GET("/legacy/order", gin.WrapF(legacyOrder))
+
+	r.StaticFS("/assets", http.Dir("./public"))
+	r.Static("/static", "./public")
+
+	_ = r.Run(":8080")
+}
@@ -0,0 +1,45 @@
+// Fixture for tags inferred from router groups: nested groups tag their
+// routes after the innermost resource segment, an api/version group tags
+// nothing, and a swaggo @Tags annotation still wins over the group.
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func listUsers(c *gin.Context)  { c.JSON(http.StatusOK, []string{}) }
+func getUser(c *gin.Context)    { c.JSON(http.StatusOK, gin.H{"id": c.Param("id")}) }
+func listPosts(c *gin.Context)  { c.JSON(http.StatusOK, []string{}) }
+func charge(c *gin.Context)     { c.Status(http.StatusAccepted) }
+func refund(c *gin.Context)     { c.Status(http.StatusAccepted) }
+func health(c *gin.Context)     { c.String(http.StatusOK, "ok") }
+func rootStatus(c *gin.Context) { c.String(http.StatusOK, "up") }
+
+// exportUsers streams every user as CSV.
+//
+//	@Tags	exports
+func exportUsers(c *gin.Context) { c.String(http.StatusOK, "id,name") }
+
+func main() {
+	r := gin.New()
+	r.GET("/status", rootStatus)
+
+	v1 := r.Group("/api/v1")
+	v1.GET("/health", health)
+
+	users := v1.Group("/users")
+	users.GET("", listUsers)
+	users.GET("/:id", getUser)
+	users.GET("/export", exportUsers)
+
+	posts := users.Group("/:id/posts")
+	posts.GET("", listPosts)
+
+	payment := v1.Group("/payment")
+	payment.POST("/charge", charge)
+	payment.POST("/refund", refund)
+
+	_ = r.Run(":8080")
+}
@@ -9,8 +9,19 @@ import (
 	"net/http"
 )
 
+// Account is a customer account.
+// Its doc comment becomes the component schema description.
 type Account struct {
-	ID string `json:"id"`
+	// ID is the account's opaque identifier.
+	ID    string `json:"id"`
+	Email string `json:"email"` // Email receives the receipts.
+	// Owner is the [Owner] who opened the account.
+	// nolint:lll
+	Owner Owner `json:"owner"`
+}
+
+type Owner struct {
+	Name string `json:"name"`
 }
 
 type Handler struct{}
@@ -59,6 +70,17 @@ func listAccounts(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode([]Account{})
 }
 
+// ExportAccounts streams every [Account] through a [json.Encoder].
+// The format is described at https://example.com/export and in [the guide].
+//
+// [the guide]: https://example.com/guide
+//
+// go:generate echo export
+// nolint:gocyclo
+func (h *Handler) ExportAccounts(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode([]Account{})
+}
+
 // Deps carries the handler as a field, mirroring dependency-injected routers:
 // the receiver then renders as a field path (Deps.Accounts), not a type name.
 type Deps struct {
@@ -75,6 +97,7 @@ func main() {
 	mux.HandleFunc("PATCH /accounts", h.PatchAccount)
 	mux.HandleFunc("GET /accounts", listAccounts)
 	mux.HandleFunc("GET /accounts/search", h.SearchAccounts)
+	mux.HandleFunc("GET /accounts/export", h.ExportAccounts)
 	// A handler *value* names no method: the framework's handler interface
 	// supplies it (#204). The traced origin type must not leak in as the summary.
 	mux.Handle("OPTIONS /accounts", h)
@@ -0,0 +1,3 @@
+module testdata/handler_func_values
+
+go 1.22
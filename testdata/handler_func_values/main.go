@@ -0,0 +1,104 @@
+// Package main registers net/http handlers held in values rather than
+// named at the registration: a variable of type http.HandlerFunc set from a
+// function or a handler factory, a map of patterns to handlers and a slice
+// of route structs, each registered in a range loop.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Widget is served by a handler built by a factory.
+type Widget struct {
+	ID string `json:"id"`
+}
+
+// Gadget is served by a handler held in a map.
+type Gadget struct {
+	Name string `json:"name"`
+}
+
+// Gizmo is served by a factory-built handler held in a map.
+type Gizmo struct {
+	Size int `json:"size"`
+}
+
+// Order is served by handlers held in a slice of route structs.
+type Order struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+// Status is served by a function held in a typed variable.
+type Status struct {
+	Healthy bool `json:"healthy"`
+}
+
+type deps struct {
+	prefix string
+}
+
+func makeWidgetsHandler(d *deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]Widget{{ID: d.prefix}})
+	}
+}
+
+func makeGizmosHandler(d *deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Gizmo{Size: len(d.prefix)})
+	}
+}
+
+func showStatus(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(Status{Healthy: true})
+}
+
+func gadgets(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode([]Gadget{})
+}
+
+func placeOrder(w http.ResponseWriter, r *http.Request) {
+	var o Order
+	_ = json.NewDecoder(r.Body).Decode(&o)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(o)
+}
+
+func showOrder(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(Order{ID: r.PathValue("id")})
+}
+
+type route struct {
+	pattern string
+	handler http.HandlerFunc
+}
+
+func main() {
+	d := &deps{prefix: "w"}
+	mux := http.NewServeMux()
+
+	var widgets http.HandlerFunc = makeWidgetsHandler(d)
+	mux.HandleFunc("GET /widgets", widgets)
+
+	var status http.HandlerFunc = showStatus
+	mux.Handle("GET /status", status)
+
+	handlers := map[string]http.HandlerFunc{
+		"GET /gadgets": gadgets,
+		"GET /gizmos":  makeGizmosHandler(d),
+	}
+	for pattern, h := range handlers {
+		mux.HandleFunc(pattern, h)
+	}
+
+	for _, rt := range []route{
+		{pattern: "POST /orders", handler: placeOrder},
+		{"GET /orders/{id}", showOrder},
+	} {
+		mux.HandleFunc(rt.pattern, rt.handler)
+	}
+
+	_ = http.ListenAndServe(":8080", mux)
+}
@@ -0,0 +1,27 @@
+// Package main reads request headers and sets response headers through
+// echo's Context.
+package main
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Order struct {
+	ID     string `json:"id"`
+	Tenant string `json:"tenant"`
+}
+
+func getOrder(c echo.Context) error {
+	tenant := c.Request().Header.Get("X-Tenant-ID")
+
+	c.Response().Header().Set("X-Request-ID", "req-1")
+	return c.JSON(http.StatusOK, Order{ID: c.Param("id"), Tenant: tenant})
+}
+
+func main() {
+	e := echo.New()
+	e.GET("/orders/:id", getOrder)
+	e.Logger.Fatal(e.Start(":8080"))
+}
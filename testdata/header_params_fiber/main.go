@@ -0,0 +1,26 @@
+// Package main reads request headers and sets response headers through
+// fiber's Ctx.
+package main
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type Order struct {
+	ID     string `json:"id"`
+	Tenant string `json:"tenant"`
+}
+
+func getOrder(c *fiber.Ctx) error {
+	tenant := c.Get("X-Tenant-ID")
+
+	c.Set("X-Request-ID", "req-1")
+	c.Append("Vary", "Origin")
+	return c.Status(fiber.StatusOK).JSON(Order{ID: c.Params("id"), Tenant: tenant})
+}
+
+func main() {
+	app := fiber.New()
+	app.Get("/orders/:id", getOrder)
+	_ = app.Listen(":8080")
+}
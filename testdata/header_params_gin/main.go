@@ -0,0 +1,29 @@
+// Package main reads request headers and sets response headers through
+// gin's Context.
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Order struct {
+	ID     string `json:"id"`
+	Tenant string `json:"tenant"`
+}
+
+func getOrder(c *gin.Context) {
+	tenant := c.GetHeader("X-Tenant-ID")
+	_ = c.Request.Header.Get("If-None-Match")
+
+	c.Header("ETag", `"v1"`)
+	c.Writer.Header().Set("Cache-Control", "max-age=60")
+	c.JSON(http.StatusOK, Order{ID: c.Param("id"), Tenant: tenant})
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/orders/:id", getOrder)
+	_ = r.Run(":8080")
+}
@@ -0,0 +1,44 @@
+// Package main reads request headers and sets response headers in plain
+// net/http handlers.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const headerRateLimit = "X-RateLimit-Remaining"
+
+type Account struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func getAccount(w http.ResponseWriter, r *http.Request) {
+	tenant := r.Header.Get("X-Tenant-ID")
+	_ = r.Header.Get("Authorization") // documented by the security scheme, not as a parameter
+
+	w.Header().Set("X-Request-ID", "req-1")
+	w.Header().Set("Content-Type", "application/json")
+	h := w.Header()
+	h.Add(headerRateLimit, "99")
+	json.NewEncoder(w).Encode(Account{ID: "1", Name: tenant})
+}
+
+// createAccount calls a downstream service; the headers it sets go on the
+// outbound request, not on this handler's response.
+func createAccount(w http.ResponseWriter, r *http.Request) {
+	req, _ := http.NewRequest(http.MethodPost, "http://billing/accounts", r.Body)
+	req.Header.Set("X-Forwarded-Tenant", r.Header.Get("X-Tenant-ID"))
+	_, _ = http.DefaultClient.Do(req)
+
+	w.Header().Set("Location", "/accounts/1")
+	w.WriteHeader(http.StatusCreated)
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /accounts/{id}", getAccount)
+	mux.HandleFunc("POST /accounts", createAccount)
+	_ = http.ListenAndServe(":8080", mux)
+}
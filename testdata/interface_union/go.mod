@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/interface_union
+
+go 1.22
@@ -0,0 +1,60 @@
+// Package main exercises interface-typed schemas without a handler-local
+// assignment to narrow them: the response and the Drawing.Shapes field are
+// typed as the Shape interface, which Circle and Square implement, so both
+// map to a `oneOf` of the two components. Named has a single implementation
+// and stays a plain object.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Shape is implemented by Circle and Square.
+type Shape interface{ Area() float64 }
+
+type Circle struct {
+	Kind   string  `json:"kind"`
+	Radius float64 `json:"radius"`
+}
+
+type Square struct {
+	Kind string  `json:"kind"`
+	Side float64 `json:"side"`
+}
+
+func (c Circle) Area() float64 { return 3.14159 * c.Radius * c.Radius }
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+// Named is implemented by Drawing only.
+type Named interface{ Title() string }
+
+type Drawing struct {
+	Name   string  `json:"name"`
+	Shapes []Shape `json:"shapes"`
+	Owner  Named   `json:"owner"`
+}
+
+func (d Drawing) Title() string { return d.Name }
+
+func lookupShape(id string) Shape {
+	if id == "c" {
+		return Circle{Kind: "Circle", Radius: 1}
+	}
+	return Square{Kind: "Square", Side: 1}
+}
+
+func getShape(w http.ResponseWriter, r *http.Request) {
+	var s Shape = lookupShape(r.URL.Query().Get("id"))
+	json.NewEncoder(w).Encode(s)
+}
+
+func getDrawing(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Drawing{Name: "sketch"})
+}
+
+func main() {
+	http.HandleFunc("/shape", getShape)
+	http.HandleFunc("/drawing", getDrawing)
+	http.ListenAndServe(":8080", nil)
+}
@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/json_naming_audit
+
+go 1.22
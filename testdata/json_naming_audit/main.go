@@ -0,0 +1,47 @@
+// Package main carries the JSON naming mistakes the naming audit flags:
+// Account has two fields tagged "id" and two names that differ only by case,
+// and the order request/response spell the customer key differently.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type Account struct {
+	ID       int    `json:"id"`
+	LegacyID int    `json:"id"`
+	OwnerID  string `json:"ownerId"`
+	OwnerRef string `json:"ownerID"`
+}
+
+type CreateOrderRequest struct {
+	CustomerID string `json:"customerId"`
+	Amount     int    `json:"amount"`
+}
+
+type Order struct {
+	CustomerID string `json:"customerID"`
+	Amount     int    `json:"amount"`
+}
+
+func getAccount(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(Account{ID: 1})
+}
+
+func createOrder(w http.ResponseWriter, r *http.Request) {
+	var req CreateOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(Order{CustomerID: req.CustomerID, Amount: req.Amount})
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /accounts/{id}", getAccount)
+	mux.HandleFunc("POST /orders", createOrder)
+	_ = http.ListenAndServe(":8080", mux)
+}
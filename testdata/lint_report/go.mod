@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/lint_report
+
+go 1.22
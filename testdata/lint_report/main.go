@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// User is an account holder. Its JSON names mix snake_case and camelCase.
+type User struct {
+	ID        string `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"last_name"`
+}
+
+// Profile is a user's public profile.
+type Profile struct {
+	DisplayName string `json:"displayName"`
+	AvatarURL   string `json:"avatarUrl"`
+}
+
+// getUser returns a user.
+//
+// @Tags users
+func getUser(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		http.Error(w, "user not found", http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(User{ID: id})
+}
+
+func getProfile(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Profile{})
+}
+
+func main() {
+	http.HandleFunc("GET /users/{id}", getUser)
+	http.HandleFunc("GET /profiles/{id}", getProfile)
+	http.ListenAndServe(":8080", nil)
+}
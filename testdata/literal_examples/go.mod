@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/literal_examples
+
+go 1.22
@@ -0,0 +1,76 @@
+// Package main exercises examples mined from the code: the mock users slice
+// and default settings give property examples, and the request bodies of
+// main_test.go give request body examples.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusActive  Status = "active"
+	StatusBlocked Status = "blocked"
+)
+
+const defaultPageSize = 25
+
+type Address struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+type User struct {
+	ID      int       `json:"id"`
+	Name    string    `json:"name"`
+	Email   string    `json:"email"`
+	Status  Status    `json:"status"`
+	Roles   []string  `json:"roles"`
+	Address Address   `json:"address"`
+	Joined  time.Time `json:"joined"`
+}
+
+type Settings struct {
+	PageSize int     `json:"pageSize"`
+	Ratio    float64 `json:"ratio"`
+	Beta     bool    `json:"beta"`
+}
+
+var users = []User{
+	{ID: 1, Name: "Alice", Status: StatusActive, Roles: []string{"admin", "editor"}, Joined: time.Now()},
+	{ID: 2, Name: "Bob", Email: "bob@example.com", Status: StatusBlocked},
+}
+
+var settings = Settings{PageSize: defaultPageSize, Ratio: 0.75, Beta: true}
+
+func listUsers(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(users)
+}
+
+func createUser(w http.ResponseWriter, r *http.Request) {
+	var u User
+	json.NewDecoder(r.Body).Decode(&u)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(u)
+}
+
+func updateUser(w http.ResponseWriter, r *http.Request) {
+	var u User
+	json.NewDecoder(r.Body).Decode(&u)
+	json.NewEncoder(w).Encode(u)
+}
+
+func getSettings(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(settings)
+}
+
+func main() {
+	http.HandleFunc("GET /users", listUsers)
+	http.HandleFunc("POST /users", createUser)
+	http.HandleFunc("PUT /users/{id}", updateUser)
+	http.HandleFunc("GET /settings", getSettings)
+	http.ListenAndServe(":8080", nil)
+}
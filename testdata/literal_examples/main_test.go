@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateUser(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Carol","email":"carol@example.com"}`))
+	rec := httptest.NewRecorder()
+	createUser(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d", rec.Code)
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"rename", `{"name":"Alicia"}`},
+	}
+	for _, tc := range tests {
+		req := httptest.NewRequest("PUT", "/users/1", strings.NewReader(tc.body))
+		updateUser(httptest.NewRecorder(), req)
+	}
+}
+
+func TestBlockUser(t *testing.T) {
+	body := `{"status":"blocked"}`
+	req, _ := http.NewRequest(http.MethodPut, "http://localhost/users/2", bytes.NewReader([]byte(body)))
+	updateUser(httptest.NewRecorder(), req)
+}
+
+func TestUnblockUser(t *testing.T) {
+	body := `{"status":"active"}`
+	req, _ := http.NewRequest(http.MethodPut, "http://localhost/users/2", bytes.NewReader([]byte(body)))
+	updateUser(httptest.NewRecorder(), req)
+}
+
+var home = Address{City: "Lisbon", Country: "PT"}
@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/long_polling
+
+go 1.22
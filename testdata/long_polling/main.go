@@ -0,0 +1,71 @@
+// Package main exercises long polling and Retry-After: handlers that race an
+// event against time.After or time.NewTimer are long polls, documented with
+// x-long-poll and a 202 for a poll that timed out unless the handler answers
+// that itself, and a Retry-After header is described wherever it is set.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type Message struct {
+	ID   string `json:"id"`
+	Body string `json:"body"`
+}
+
+type Job struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+var (
+	messages = make(chan Message)
+	done     = make(chan Job)
+)
+
+// pollMessages waits up to 30 seconds for the next message.
+func pollMessages(w http.ResponseWriter, r *http.Request) {
+	select {
+	case m := <-messages:
+		json.NewEncoder(w).Encode(m)
+	case <-time.After(30 * time.Second):
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// waitJob waits for a job to finish, telling the client when to ask again.
+func waitJob(w http.ResponseWriter, r *http.Request) {
+	_ = r.PathValue("id")
+	timer := time.NewTimer(10 * time.Second)
+	defer timer.Stop()
+	select {
+	case job := <-done:
+		json.NewEncoder(w).Encode(job)
+	case <-timer.C:
+		w.Header().Set("Retry-After", "5")
+	}
+}
+
+// createJob queues a job and points the client at its status.
+func createJob(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Location", "/jobs/1")
+	w.Header().Set("Retry-After", "5")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(Job{ID: "1", Status: "queued"})
+}
+
+// getJob returns a job's current status.
+func getJob(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Job{ID: r.PathValue("id"), Status: "running"})
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /messages/poll", pollMessages)
+	mux.HandleFunc("GET /jobs/{id}/wait", waitJob)
+	mux.HandleFunc("POST /jobs", createJob)
+	mux.HandleFunc("GET /jobs/{id}", getJob)
+	http.ListenAndServe(":8080", mux)
+}
@@ -0,0 +1,94 @@
+// Package main applies auth, rate-limit and CORS middleware whose responses
+// the middlewareResponses config documents: requireAuth's 401 on the group it
+// guards, rateLimit's 429 on the one route it is chained onto, and an OPTIONS
+// preflight for every path cors guards that does not register its own.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type order struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+func cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func requireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(apiError{Code: "unauthorized", Message: "sign in"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func rateLimit(perSecond int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return next
+	}
+}
+
+func health(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// healthOptions answers OPTIONS /health itself, so no preflight is added.
+func healthOptions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Allow", "GET, OPTIONS")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func listOrders(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode([]order{})
+}
+
+// createOrder documents its own 401, which the middleware's does not replace.
+func createOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Tenant") == "" {
+		http.Error(w, "missing tenant", http.StatusUnauthorized)
+		return
+	}
+	var o order
+	_ = json.NewDecoder(r.Body).Decode(&o)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(o)
+}
+
+func getOrder(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(order{ID: chi.URLParam(r, "id")})
+}
+
+func main() {
+	r := chi.NewRouter()
+	r.Use(cors)
+	r.Get("/health", health)
+	r.Options("/health", healthOptions)
+	r.Group(func(r chi.Router) {
+		r.Use(requireAuth)
+		r.Get("/orders", listOrders)
+		r.Post("/orders", createOrder)
+		r.With(rateLimit(5)).Get("/orders/{id}", getOrder)
+	})
+	_ = http.ListenAndServe(":8080", r)
+}
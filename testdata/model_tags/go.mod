@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/model_tags
+
+go 1.22
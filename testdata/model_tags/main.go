@@ -0,0 +1,27 @@
+// Package main exercises model tags: Account is a database model served as
+// is, so only some of its fields carry json tags and the rest are named by
+// their sqlx (db) or gorm column tags. The gorm tags also say which columns
+// are NOT NULL and how long a varchar column is.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type Account struct {
+	ID       int64   `gorm:"column:account_id;primaryKey"`
+	Email    string  `db:"email_address" gorm:"column:email;type:varchar(120);not null"`
+	Nickname *string `gorm:"column:nick;size:32"`
+	Plan     string  `json:"plan" db:"plan_code"`
+	Notes    string  `db:"-" gorm:"-"`
+}
+
+func listAccounts(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]Account{})
+}
+
+func main() {
+	http.HandleFunc("GET /accounts", listAccounts)
+	http.ListenAndServe(":8080", nil)
+}
@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/operation_ids
+
+go 1.22
@@ -0,0 +1,27 @@
+// Package handlers serves the user resource.
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserHandler serves users.
+type UserHandler struct{}
+
+// GetUser returns one user.
+func (UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(User{ID: r.PathValue("id")})
+}
+
+// ListUsers returns every user.
+//
+//	@ID	listAllUsers
+func (UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]User{})
+}
@@ -0,0 +1,33 @@
+// Package main names its operations with the camel operationIds style: the
+// handler's package and name without the module prefix, a closure after its
+// method and path, and a numeric suffix where one handler serves two paths.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ehabterra/apispec/testdata/operation_ids/handlers"
+)
+
+type status struct {
+	OK bool `json:"ok"`
+}
+
+// health reports whether the service is up.
+func health(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(status{OK: true})
+}
+
+func main() {
+	users := handlers.UserHandler{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users", users.ListUsers)
+	mux.HandleFunc("GET /users/{id}", users.GetUser)
+	mux.HandleFunc("GET /health", health)
+	mux.HandleFunc("GET /healthz", health)
+	mux.HandleFunc("GET /version", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("1.0.0"))
+	})
+	http.ListenAndServe(":8080", mux)
+}
@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/optional_request_body
+
+go 1.22
@@ -0,0 +1,84 @@
+// Fixture for request body required detection: an unconditional decode makes
+// the body required; a decode under a body-presence guard, or one tolerating
+// io.EOF, leaves it optional.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+type Item struct {
+	Name  string `json:"name"`
+	Price int    `json:"price"`
+}
+
+func createItem(w http.ResponseWriter, r *http.Request) {
+	var item Item
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// patchItem applies a partial update; an empty body changes nothing.
+func patchItem(w http.ResponseWriter, r *http.Request) {
+	var patch Item
+	if r.Body != http.NoBody && r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func touchItem(w http.ResponseWriter, r *http.Request) {
+	var item Item
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeJSON(r *http.Request, v any) error {
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+type Handler struct{}
+
+func (h *Handler) ReplaceItem(w http.ResponseWriter, r *http.Request) {
+	var item Item
+	err := decodeJSON(r, &item)
+	if err != nil && err != io.EOF {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) ImportItems(w http.ResponseWriter, r *http.Request) {
+	var items []Item
+	if r.ContentLength != 0 {
+		if err := decodeJSON(r, &items); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func main() {
+	h := &Handler{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /items", createItem)
+	mux.HandleFunc("PATCH /items/{id}", patchItem)
+	mux.HandleFunc("POST /items/{id}/touch", touchItem)
+	mux.HandleFunc("PUT /items/{id}", h.ReplaceItem)
+	mux.HandleFunc("POST /items/import", h.ImportItems)
+	_ = http.ListenAndServe(":8080", mux)
+}
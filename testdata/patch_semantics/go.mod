@@ -0,0 +1,5 @@
+module github.com/ehabterra/apispec/testdata/patch_semantics
+
+go 1.22
+
+require github.com/evanphx/json-patch/v5 v5.9.11
@@ -0,0 +1,104 @@
+// Fixture for PATCH semantics: a PATCH body decoded into a struct of pointer
+// fields is a JSON Merge Patch, evanphx/json-patch's DecodePatch and
+// MergePatch read a JSON Patch and a merge patch, and a PATCH decoding a
+// plain struct stays application/json.
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+type Item struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Price float64  `json:"price"`
+	Tags  []string `json:"tags"`
+}
+
+// ItemInput is shared by PUT, which needs every required field, and PATCH,
+// which sends only the fields to change.
+type ItemInput struct {
+	Name  *string   `json:"name" validate:"required"`
+	Price *float64  `json:"price" validate:"required,gt=0"`
+	Tags  *[]string `json:"tags,omitempty"`
+}
+
+type Rename struct {
+	Name string `json:"name"`
+}
+
+func replaceItem(w http.ResponseWriter, r *http.Request) {
+	var in ItemInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(Item{})
+}
+
+func updateItem(w http.ResponseWriter, r *http.Request) {
+	var in ItemInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(Item{})
+}
+
+func renameItem(w http.ResponseWriter, r *http.Request) {
+	var in Rename
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(Item{})
+}
+
+func patchDocument(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	patch, err := jsonpatch.DecodePatch(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	doc, err := patch.Apply([]byte(`{}`))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(doc)
+}
+
+func patchSettings(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	merged, err := jsonpatch.MergePatch([]byte(`{}`), body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(merged)
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /items/{id}", replaceItem)
+	mux.HandleFunc("PATCH /items/{id}", updateItem)
+	mux.HandleFunc("PATCH /items/{id}/name", renameItem)
+	mux.HandleFunc("PATCH /documents/{id}", patchDocument)
+	mux.HandleFunc("PATCH /settings", patchSettings)
+	_ = http.ListenAndServe(":8080", mux)
+}
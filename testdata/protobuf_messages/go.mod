@@ -0,0 +1,5 @@
+module github.com/ehabterra/apispec/testdata/protobuf_messages
+
+go 1.22
+
+require google.golang.org/protobuf v1.34.1
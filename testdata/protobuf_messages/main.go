@@ -0,0 +1,24 @@
+// Package main exercises protoc-gen-go messages served as JSON: User carries
+// the generator's internal state and well-known wrapper, timestamp, duration
+// and struct types, and LegacyProfile the XXX_ bookkeeping fields of older
+// generators. None of them is part of the message's JSON form.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func getUser(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(&User{})
+}
+
+func getProfile(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(&LegacyProfile{})
+}
+
+func main() {
+	http.HandleFunc("GET /users/{id}", getUser)
+	http.HandleFunc("GET /profiles/{id}", getProfile)
+	http.ListenAndServe(":8080", nil)
+}
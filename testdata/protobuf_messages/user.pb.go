@@ -0,0 +1,36 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: user.proto
+
+package main
+
+import (
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type User struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId     string                  `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Nickname   *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Age        *wrapperspb.Int32Value  `protobuf:"bytes,3,opt,name=age,proto3" json:"age,omitempty"`
+	Balance    *wrapperspb.Int64Value  `protobuf:"bytes,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	Verified   *wrapperspb.BoolValue   `protobuf:"bytes,5,opt,name=verified,proto3" json:"verified,omitempty"`
+	CreatedAt  *timestamppb.Timestamp  `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	SessionTtl *durationpb.Duration    `protobuf:"bytes,7,opt,name=session_ttl,json=sessionTtl,proto3" json:"session_ttl,omitempty"`
+	Attributes *structpb.Struct        `protobuf:"bytes,8,opt,name=attributes,proto3" json:"attributes,omitempty"`
+}
+
+// LegacyProfile is generated by an older (gogo-style) generator that exports
+// its bookkeeping fields without a json tag.
+type LegacyProfile struct {
+	Bio                  string   `protobuf:"bytes,1,opt,name=bio,proto3" json:"bio,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `protobuf:"-"`
+	XXX_unrecognized     []byte   `protobuf:"-"`
+	XXX_sizecache        int32    `protobuf:"-"`
+}
@@ -0,0 +1,53 @@
+// Fixture for fiber query parameters: the typed QueryInt/QueryBool/QueryFloat
+// accessors, a c.Query value converted with strconv, and structs bound with
+// QueryParser and ParamsParser.
+package main
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Order struct {
+	ID    int     `json:"id"`
+	Total float64 `json:"total"`
+}
+
+func listOrders(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	paid := c.QueryBool("paid")
+	minTotal := c.QueryFloat("min_total")
+	since, _ := strconv.ParseInt(c.Query("since"), 10, 64)
+	status := c.Query("status")
+	_, _, _, _, _ = page, paid, minTotal, since, status
+	return c.Status(fiber.StatusOK).JSON([]Order{})
+}
+
+type OrderItemsQuery struct {
+	Limit int    `query:"limit" validate:"min=1,max=50"`
+	Sort  string `query:"sort" validate:"oneof=asc desc"`
+}
+
+type OrderParams struct {
+	OrderID int `params:"orderID" validate:"min=1"`
+}
+
+func listOrderItems(c *fiber.Ctx) error {
+	var params OrderParams
+	if err := c.ParamsParser(&params); err != nil {
+		return err
+	}
+	var q OrderItemsQuery
+	if err := c.QueryParser(&q); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON([]Order{})
+}
+
+func main() {
+	app := fiber.New()
+	app.Get("/orders", listOrders)
+	app.Get("/orders/:orderID/items", listOrderItems)
+	_ = app.Listen(":8080")
+}
@@ -0,0 +1,32 @@
+// Fixture for gin query parameters: c.Query values converted with strconv,
+// DefaultQuery, GetQuery and the repeated-key QueryArray.
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Post struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+func listPosts(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "20"))
+	search, _ := c.GetQuery("q")
+	tags := c.QueryArray("tag")
+	draftStr := c.Query("draft")
+	draft, _ := strconv.ParseBool(draftStr)
+	_, _, _, _, _ = page, perPage, search, tags, draft
+	c.JSON(http.StatusOK, []Post{})
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/posts", listPosts)
+	_ = r.Run(":8080")
+}
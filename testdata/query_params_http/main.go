@@ -0,0 +1,42 @@
+// Fixture for query parameter typing: a query value converted with strconv
+// documents the converted type — inline, through a variable, or through a
+// url.Values held in a variable.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+type Item struct {
+	ID    int     `json:"id"`
+	Price float64 `json:"price"`
+}
+
+func listItems(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limitStr := r.URL.Query().Get("limit")
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil {
+		limit = 20
+	}
+	q := r.URL.Query()
+	active, _ := strconv.ParseBool(q.Get("active"))
+	minPrice, _ := strconv.ParseFloat(q.Get("min_price"), 64)
+	since, _ := strconv.ParseInt(q.Get("since"), 10, 64)
+	offset, _ := strconv.ParseUint(q.Get("offset"), 10, 32)
+	sort := q.Get("sort")
+	// Parsed two ways, so no single type can be documented.
+	mode := q.Get("mode")
+	modeN, _ := strconv.Atoi(mode)
+	modeB, _ := strconv.ParseBool(mode)
+	_, _, _, _, _, _, _, _, _, _ = page, limit, active, minPrice, since, offset, sort, err, modeN, modeB
+	_ = json.NewEncoder(w).Encode([]Item{})
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /items", listItems)
+	_ = http.ListenAndServe(":8080", mux)
+}
@@ -5,7 +5,7 @@
 // unbounded recursion in generateStructSchema) and issue #14 (truncated output
 // on the same project). The schema mapper must break every type cycle by
 // emitting a $ref back to the already-registered component instead of expanding
-// the type inline forever. This fixture wires three distinct cycle shapes into
+// the type inline forever. This fixture wires four distinct cycle shapes into
 // HTTP responses so a regression surfaces as either a hang/stack-overflow (test
 // never returns) or a dangling/missing component ($ref assertions fail).
 package main
@@ -15,14 +15,15 @@ import (
 	"net/http"
 )
 
-// TreeNode is DIRECTLY self-referential through two different field kinds: a
-// pointer back to the parent and a slice of pointers to children. Both edges
-// must terminate as a $ref to TreeNode.
+// TreeNode is DIRECTLY self-referential through three different field kinds:
+// a pointer back to the parent, a slice of pointers to children and a map of
+// nodes by name. Every edge must terminate as a $ref to TreeNode.
 type TreeNode struct {
-	ID       int         `json:"id"`
-	Value    string      `json:"value"`
-	Parent   *TreeNode   `json:"parent,omitempty"`
-	Children []*TreeNode `json:"children,omitempty"`
+	ID       int                 `json:"id"`
+	Value    string              `json:"value"`
+	Parent   *TreeNode           `json:"parent,omitempty"`
+	Children []*TreeNode         `json:"children,omitempty"`
+	ByName   map[string]TreeNode `json:"byName,omitempty"`
 }
 
 // Category and Product are MUTUALLY recursive: a category lists its products,
@@ -58,6 +59,26 @@ type Node struct {
 	Graph *Graph `json:"graph,omitempty"`
 }
 
+// Page, Thread and Tree cycle THROUGH GENERIC types. Page links to the next
+// page of the same instantiation and Thread nests a page of itself; Tree
+// nests a slice of itself. Each cycle must close on the instantiation
+// (Page[Thread], Tree[int]), not on the declaration (Page[T]), whose T
+// resolves to nothing.
+type Page[T any] struct {
+	Items []T      `json:"items"`
+	Next  *Page[T] `json:"next,omitempty"`
+}
+
+type Thread struct {
+	Title   string       `json:"title"`
+	Replies Page[Thread] `json:"replies"`
+}
+
+type Tree[T any] struct {
+	Value    T         `json:"value"`
+	Children []Tree[T] `json:"children,omitempty"`
+}
+
 func getTree(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(TreeNode{})
 }
@@ -70,10 +91,20 @@ func getGraph(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(Graph{})
 }
 
+func getThread(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(Thread{})
+}
+
+func getScores(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(Tree[int]{})
+}
+
 func main() {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/tree", getTree)
 	mux.HandleFunc("/category", getCategory)
 	mux.HandleFunc("/graph", getGraph)
+	mux.HandleFunc("/thread", getThread)
+	mux.HandleFunc("/scores", getScores)
 	_ = http.ListenAndServe(":8080", mux)
 }
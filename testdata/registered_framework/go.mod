@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/registered_framework
+
+go 1.22
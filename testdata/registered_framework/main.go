@@ -0,0 +1,38 @@
+// Package main routes through an in-house router (./router). Without a
+// registered framework config no routes are found; with one registered via
+// spec.RegisterFrameworkConfig both widget endpoints are extracted.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ehabterra/apispec/testdata/registered_framework/router"
+)
+
+type Widget struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func listWidgets(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode([]Widget{{ID: 1, Name: "sprocket"}})
+}
+
+func createWidget(w http.ResponseWriter, r *http.Request) {
+	var in Widget
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(in)
+}
+
+func main() {
+	r := router.New()
+	r.Get("/widgets", listWidgets)
+	r.Post("/widgets", createWidget)
+	_ = http.ListenAndServe(":8080", r)
+}
@@ -0,0 +1,30 @@
+// Package router is a minimal in-house router that apispec has no built-in
+// config for; the fixture's tests register one via spec.RegisterFrameworkConfig.
+package router
+
+import "net/http"
+
+// Router dispatches on method and exact path.
+type Router struct {
+	routes map[string]http.HandlerFunc
+}
+
+// New returns an empty Router.
+func New() *Router {
+	return &Router{routes: map[string]http.HandlerFunc{}}
+}
+
+// Get registers h for GET path.
+func (r *Router) Get(path string, h http.HandlerFunc) { r.routes["GET "+path] = h }
+
+// Post registers h for POST path.
+func (r *Router) Post(path string, h http.HandlerFunc) { r.routes["POST "+path] = h }
+
+// ServeHTTP implements http.Handler.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if h, ok := r.routes[req.Method+" "+req.URL.Path]; ok {
+		h(w, req)
+		return
+	}
+	http.NotFound(w, req)
+}
@@ -0,0 +1,7 @@
+module github.com/ehabterra/apispec/testdata/replaced_module/app
+
+go 1.22
+
+require github.com/acme/shared v0.0.0
+
+replace github.com/acme/shared => ../shared
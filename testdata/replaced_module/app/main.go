@@ -0,0 +1,20 @@
+// Package main serves a model declared in a module that go.mod replaces with
+// a local directory. Its types are the project's own and must be documented
+// as components, not treated as opaque third-party types.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/acme/shared/models"
+)
+
+func getOrder(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(models.Order{})
+}
+
+func main() {
+	http.HandleFunc("GET /orders/{id}", getOrder)
+	http.ListenAndServe(":8080", nil)
+}
@@ -0,0 +1,14 @@
+// Package models holds the types the app serves. It lives in its own module,
+// which the app's go.mod replaces with this directory.
+package models
+
+type Order struct {
+	ID    string  `json:"id"`
+	Total float64 `json:"total"`
+	Items []Item  `json:"items"`
+}
+
+type Item struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
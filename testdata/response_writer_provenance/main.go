@@ -17,12 +17,14 @@ import (
 	"net/http/httptest"
 )
 
-// User is the real response body; Secret must never reach the response.
+// User is the real response body.
 type User struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
 
+// Secret is only ever encoded to non-writer sinks and must never reach the
+// response.
 type Secret struct {
 	Token string `json:"token"`
 }
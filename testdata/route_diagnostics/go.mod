@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/route_diagnostics
+
+go 1.22
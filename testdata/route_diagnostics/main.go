@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+)
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func listUsers(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]User{})
+}
+
+func listOrders(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]string{})
+}
+
+func listInvoices(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]string{})
+}
+
+// prefix and ordersPath are only known at run time.
+func prefix() string {
+	return os.Getenv("API_PREFIX")
+}
+
+func ordersPath() string {
+	return prefix() + "/orders"
+}
+
+// Plugin serves the routes of a plugin loaded at run time.
+type Plugin interface {
+	Handler() http.HandlerFunc
+}
+
+// handlers is filled in by the plugins.
+var handlers = map[string]http.HandlerFunc{}
+
+func register(mux *http.ServeMux, plugin Plugin, fallback http.Handler) {
+	// The handler is whatever the plugin returns.
+	mux.HandleFunc("GET /plugins", plugin.Handler())
+
+	// The handler is an element of a map.
+	mux.HandleFunc("GET /reports", handlers["reports"])
+
+	// The handler is an interface value.
+	mux.Handle("GET /fallback", fallback)
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users", listUsers)
+
+	// The paths are computed at run time: a call is documented as a path
+	// parameter, an expression is not documented.
+	mux.HandleFunc(ordersPath(), listOrders)
+	mux.HandleFunc(prefix()+"/invoices", listInvoices)
+
+	register(mux, nil, http.NotFoundHandler())
+	http.ListenAndServe(":8080", mux)
+}
@@ -0,0 +1,49 @@
+// Package main exercises per-route include/exclude filters: internal and
+// admin endpoints served next to the public API, stripped from the published
+// spec by method and path glob rather than by excluding their package.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type CacheStats struct {
+	Hits   int `json:"hits"`
+	Misses int `json:"misses"`
+}
+
+func listUsers(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]User{})
+}
+
+func getUser(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(User{ID: r.PathValue("id")})
+}
+
+func deleteUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func cacheStats(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(CacheStats{})
+}
+
+func flushCache(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users", listUsers)
+	mux.HandleFunc("GET /users/{id}", getUser)
+	mux.HandleFunc("DELETE /users/{id}", deleteUser)
+	mux.HandleFunc("GET /internal/cache/stats", cacheStats)
+	mux.HandleFunc("POST /internal/cache/flush", flushCache)
+	http.ListenAndServe(":8080", mux)
+}
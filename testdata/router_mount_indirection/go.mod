@@ -0,0 +1,5 @@
+module github.com/ehabterra/apispec/testdata/router_mount_indirection
+
+go 1.24.3
+
+require github.com/go-chi/chi/v5 v5.2.2
@@ -0,0 +1,132 @@
+// Package main mounts chi sub-routers that reach the Mount call through
+// several layers of plumbing — struct fields set in a composite literal or a
+// constructor, a router passed down through function parameters, and slices
+// and maps of routers — so each route must still get its mount prefix.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// User is served under /users.
+type User struct {
+	ID string `json:"id"`
+}
+
+// Invoice is served under /invoices.
+type Invoice struct {
+	Number string `json:"number"`
+}
+
+// Ticket is served under /tickets.
+type Ticket struct {
+	ID string `json:"id"`
+}
+
+// Report is served under /reports.
+type Report struct {
+	Name string `json:"name"`
+}
+
+// Plan is served under /plans.
+type Plan struct {
+	Tier string `json:"tier"`
+}
+
+func showUser(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(User{ID: chi.URLParam(r, "id")})
+}
+
+func showInvoice(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(Invoice{Number: chi.URLParam(r, "number")})
+}
+
+func showTicket(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(Ticket{ID: chi.URLParam(r, "id")})
+}
+
+func showReport(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(Report{Name: chi.URLParam(r, "name")})
+}
+
+func showPlan(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(Plan{Tier: chi.URLParam(r, "tier")})
+}
+
+func userRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Get("/{id}", showUser)
+	return r
+}
+
+func invoiceRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Get("/{number}", showInvoice)
+	return r
+}
+
+func ticketRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Get("/{id}", showTicket)
+	return r
+}
+
+func reportRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Get("/{name}", showReport)
+	return r
+}
+
+func planRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Get("/{tier}", showPlan)
+	return r
+}
+
+// Modules holds the sub-routers in struct fields.
+type Modules struct {
+	Users    chi.Router
+	Invoices chi.Router
+}
+
+// NewModules fills one field in the literal and one afterwards.
+func NewModules() *Modules {
+	m := &Modules{Users: userRouter()}
+	m.Invoices = invoiceRouter()
+	return m
+}
+
+// mountModules mounts the struct's fields; the struct arrives as a parameter.
+func mountModules(root chi.Router, m *Modules) {
+	root.Mount("/users", m.Users)
+	root.Mount("/invoices", m.Invoices)
+}
+
+// mountSub mounts whatever router it is handed; the router arrives through
+// two calls.
+func mountSub(root chi.Router, prefix string, sub chi.Router) {
+	root.Mount(prefix, sub)
+}
+
+func mountTickets(root chi.Router, sub chi.Router) {
+	mountSub(root, "/tickets", sub)
+}
+
+func main() {
+	root := chi.NewRouter()
+
+	mountModules(root, NewModules())
+
+	mountTickets(root, ticketRouter())
+
+	extra := []chi.Router{reportRouter()}
+	root.Mount("/reports", extra[0])
+
+	byName := map[string]chi.Router{"plans": planRouter()}
+	root.Mount("/plans", byName["plans"])
+
+	_ = http.ListenAndServe(":8080", root)
+}
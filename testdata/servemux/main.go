@@ -22,6 +22,10 @@ func main() {
 	mux.HandleFunc("GET /users/{id}", getUser) // method + wildcard
 	mux.HandleFunc("POST /users", createUser)
 	mux.HandleFunc("GET /health", health)
+	mux.HandleFunc("GET /files/{path...}", getFile)          // trailing wildcard
+	mux.HandleFunc("GET /{$}", index)                        // exact-match anchor
+	mux.HandleFunc("GET api.example.com/hosts/{name}", host) // host-specific
+	http.HandleFunc("DELETE /users/{id}", deleteUser)        // DefaultServeMux
 
 	http.ListenAndServe(":8080", mux)
 }
@@ -48,3 +52,27 @@ func health(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write([]byte("OK"))
 }
+
+type File struct {
+	Path string `json:"path"`
+}
+
+func getFile(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(File{Path: r.PathValue("path")})
+}
+
+func index(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func host(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(name))
+}
+
+func deleteUser(w http.ResponseWriter, r *http.Request) {
+	_ = r.PathValue("id")
+	w.WriteHeader(http.StatusNoContent)
+}
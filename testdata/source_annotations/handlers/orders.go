@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Order is a placed order.
+type Order struct {
+	ID    string  `json:"id"`
+	Total float64 `json:"total"`
+}
+
+// Page is one page of a listing.
+type Page[T any] struct {
+	Items []T `json:"items"`
+	Next  int `json:"next"`
+}
+
+// OrderHandler serves the orders API.
+type OrderHandler struct{}
+
+// List returns a page of orders.
+func (h *OrderHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, Page[Order]{})
+}
+
+// Get returns an order.
+func (h *OrderHandler) Get(c *gin.Context) {
+	c.JSON(http.StatusOK, Order{ID: c.Param("id")})
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/ehabterra/apispec/testdata/source_annotations/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateOrder is the body of an order to place.
+type CreateOrder struct {
+	Total float64 `json:"total"`
+}
+
+// createOrder places an order.
+func createOrder(c *gin.Context) {
+	var req CreateOrder
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, handlers.Order{Total: req.Total})
+}
+
+func main() {
+	r := gin.Default()
+	h := &handlers.OrderHandler{}
+
+	r.GET("/orders", h.List)
+	r.GET("/orders/:id", h.Get)
+	r.POST("/orders", createOrder)
+	r.GET("/ping", func(c *gin.Context) {
+		c.String(http.StatusOK, "pong")
+	})
+
+	r.Run(":8080")
+}
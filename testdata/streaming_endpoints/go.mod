@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/streaming_endpoints
+
+go 1.22
@@ -0,0 +1,102 @@
+// Package main exercises streaming-endpoint detection: a net/http handler that
+// announces a text/event-stream body is a Server-Sent Events stream and must be
+// marked x-sse (and served as GET even though HandleFunc carries no verb),
+// while a handler that only sets an ordinary Content-Type stays a plain route.
+// Handlers announcing application/x-ndjson, flushing encoded JSON chunk by
+// chunk, or writing through a streaming helper produce NDJSON items; one
+// flushing plain text is only a chunked body.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Status struct {
+	OK bool `json:"ok"`
+}
+
+type Order struct {
+	ID    string  `json:"id"`
+	Total float64 `json:"total"`
+}
+
+type LogLine struct {
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+// events streams a tick every second to the client.
+func events(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	for i := 0; i < 3; i++ {
+		fmt.Fprintf(w, "data: tick %d\n\n", i)
+		flusher.Flush()
+		time.Sleep(time.Second)
+	}
+}
+
+// status reports service health as JSON.
+func status(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(Status{OK: true})
+}
+
+// exportOrders streams every order as one JSON document per line.
+func exportOrders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(w)
+	for _, o := range []Order{{ID: "a"}, {ID: "b"}} {
+		enc.Encode(o)
+	}
+}
+
+// tailLogs flushes each log line as soon as it is encoded.
+func tailLogs(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+	enc := json.NewEncoder(w)
+	for i := 0; i < 3; i++ {
+		enc.Encode(LogLine{Level: "info", Message: "tick"})
+		rc.Flush()
+	}
+}
+
+// progress flushes plain-text progress updates.
+func progress(w http.ResponseWriter, r *http.Request) {
+	flusher := w.(http.Flusher)
+	for i := 0; i <= 100; i += 50 {
+		fmt.Fprintf(w, "%d%%\n", i)
+		flusher.Flush()
+	}
+}
+
+// writeLines is the project's own streaming helper.
+func writeLines(w http.ResponseWriter, items []Order) {
+	for _, item := range items {
+		json.NewEncoder(w).Encode(item)
+	}
+}
+
+// recentOrders streams through writeLines.
+func recentOrders(w http.ResponseWriter, r *http.Request) {
+	writeLines(w, []Order{{ID: "c"}})
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/events", events)
+	mux.HandleFunc("GET /status", status)
+	mux.HandleFunc("GET /orders/export", exportOrders)
+	mux.HandleFunc("GET /logs", tailLogs)
+	mux.HandleFunc("POST /jobs/progress", progress)
+	mux.HandleFunc("GET /orders/recent", recentOrders)
+	http.ListenAndServe(":8080", mux)
+}
@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/swaggo_annotations
+
+go 1.22
@@ -0,0 +1,78 @@
+// Fixture for swaggo annotations merged over static analysis: annotations
+// override inferred parameters, bodies and statuses, add what the code does
+// not show, and document a handler only @Router places.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ehabterra/apispec/testdata/swaggo_annotations/model"
+)
+
+// showAccount godoc
+//
+//	@Summary		Show an account
+//	@Description	Get an account by ID.
+//	@ID				getAccount
+//	@Tags			accounts
+//	@Produce		json
+//	@Param			id	path		int		true	"Account ID"	minimum(1)
+//	@Param			fields	query	string	false	"Fields to return"	Enums(id, name)	default(id)
+//	@Success		200	{object}	model.Account
+//	@Failure		400,404	{object}	model.HTTPError	"No such account"
+//	@Security		ApiKeyAuth || OAuth2[read, admin]
+//	@Router			/accounts/{id} [get]
+func showAccount(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	_ = id
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{})
+}
+
+// createAccount godoc
+//
+//	@Summary	Create an account
+//	@Tags		accounts
+//	@Accept		json
+//	@Param		account	body	model.CreateAccount	true	"The account"
+//	@Success	201	{object}	model.Account
+//	@Failure	422	"Invalid account"
+//	@Deprecated
+//	@Router		/accounts [post]
+func createAccount(w http.ResponseWriter, r *http.Request) {
+	var in map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(model.Account{})
+}
+
+// legacyPing is served by a router the analysis does not follow.
+//
+//	@Summary	Liveness probe
+//	@Param		X-Request-ID	header	string	false	"Request ID"
+//	@Success	200	{string}	string	"pong"
+//	@Router		/legacy/ping [get]
+func legacyPing(w http.ResponseWriter, r *http.Request) {
+	_, _ = w.Write([]byte("pong"))
+}
+
+// Broken annotations are reported and skipped.
+//
+//	@Param		limit	query	int	maybe	"Page size"
+//	@Success	200	{object}	model.Missing
+//	@Router		/broken [get]
+func broken(w http.ResponseWriter, r *http.Request) {}
+
+var legacy = map[string]http.HandlerFunc{"ping": legacyPing, "broken": broken}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /accounts/{id}", showAccount)
+	mux.HandleFunc("POST /accounts", createAccount)
+	_ = legacy
+	_ = http.ListenAndServe(":8080", mux)
+}
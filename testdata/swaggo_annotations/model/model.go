@@ -0,0 +1,17 @@
+// Package model holds the types the annotations name as model.X, as swaggo
+// resolves them through the handler file's imports.
+package model
+
+type Account struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type CreateAccount struct {
+	Name string `json:"name"`
+}
+
+type HTTPError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/time_layouts
+
+go 1.22
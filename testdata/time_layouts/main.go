@@ -0,0 +1,68 @@
+// Package main exercises the time layout check: a marshaler that formats a
+// time with a layout its schema does not describe — a date where the schema
+// says date-time, a layout no format names, a time type documented as an
+// object — makes the spec disagree with every payload.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Day marshals as a calendar date, not a date-time.
+type Day time.Time
+
+func (d Day) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Time(d).Format("2006-01-02"))
+}
+
+// Stamp embeds time.Time and marshals with an RFC 3339 layout, matching
+// the documented date-time.
+type Stamp struct{ time.Time }
+
+func (s Stamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Format(time.RFC3339Nano))
+}
+
+// LegacyTime marshals with a layout no OpenAPI format describes.
+type LegacyTime time.Time
+
+func (t LegacyTime) MarshalText() ([]byte, error) {
+	return []byte(time.Time(t).Format(time.RFC1123)), nil
+}
+
+// Booking writes its dates with the date-only layout. CheckOut declares it
+// through its validate tag; CheckIn is still documented as a date-time.
+type Booking struct {
+	Guest    string    `json:"guest"`
+	CheckIn  time.Time `json:"checkIn"`
+	CheckOut time.Time `json:"checkOut" validate:"datetime=2006-01-02"`
+}
+
+func (b Booking) MarshalJSON() ([]byte, error) {
+	type plain Booking
+	return json.Marshal(struct {
+		plain
+		CheckIn  string `json:"checkIn"`
+		CheckOut string `json:"checkOut"`
+	}{plain(b), b.CheckIn.Format(time.DateOnly), b.CheckOut.Format(time.DateOnly)})
+}
+
+type Event struct {
+	Name    string     `json:"name"`
+	Day     Day        `json:"day"`
+	At      Stamp      `json:"at"`
+	Legacy  LegacyTime `json:"legacy"`
+	Created time.Time  `json:"created"`
+	Booking Booking    `json:"booking"`
+}
+
+func getEvent(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Event{Name: "launch"})
+}
+
+func main() {
+	http.HandleFunc("/event", getEvent)
+	http.ListenAndServe(":8080", nil)
+}
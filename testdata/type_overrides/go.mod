@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/type_overrides
+
+go 1.22
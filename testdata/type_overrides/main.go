@@ -0,0 +1,53 @@
+// Package main serves invoices whose amounts are money types, documented
+// through the externalTypes overrides of apispec.yaml.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ehabterra/apispec/testdata/type_overrides/money"
+)
+
+// Customer is who an invoice is billed to.
+type Customer struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Invoice is a bill.
+type Invoice struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	// Total is what the customer owes.
+	Total    money.Amount  `json:"total"`
+	TaxRate  money.Rate    `json:"taxRate"`
+	Discount *money.Amount `json:"discount,omitempty"`
+	// Customer is null for a draft.
+	Customer *Customer `json:"customer"`
+	Note     *string   `json:"note"`
+	// Reference is the customer's purchase order number, left out when
+	// empty.
+	Reference string `json:"reference,omitempty" validate:"required"`
+}
+
+func getInvoice(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Invoice{ID: r.PathValue("id")})
+}
+
+func createInvoice(w http.ResponseWriter, r *http.Request) {
+	var inv Invoice
+	if err := json.NewDecoder(r.Body).Decode(&inv); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(inv)
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /invoices/{id}", getInvoice)
+	mux.HandleFunc("POST /invoices", createInvoice)
+	http.ListenAndServe(":8080", mux)
+}
@@ -0,0 +1,28 @@
+// Package money holds fixed-point amounts, which marshal to JSON as decimal
+// strings ("12.50").
+package money
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Amount is a sum of money in cents.
+type Amount struct {
+	cents int64
+}
+
+// MarshalJSON writes the amount as a decimal string.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(fmt.Sprintf("%d.%02d", a.cents/100, a.cents%100))), nil
+}
+
+// Rate is a proportion in basis points.
+type Rate struct {
+	bps int64
+}
+
+// MarshalJSON writes the rate as a decimal string.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(fmt.Sprintf("%d.%04d", r.bps/10000, r.bps%10000))), nil
+}
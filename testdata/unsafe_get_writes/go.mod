@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/unsafe_get_writes
+
+go 1.22
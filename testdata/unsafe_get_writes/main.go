@@ -0,0 +1,75 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/ehabterra/apispec/testdata/unsafe_get_writes/store"
+)
+
+type server struct {
+	db    *sql.DB
+	users *store.Users
+	seen  sync.Map
+}
+
+// listUsers only reads; remembering the caller in memory is not a write to
+// the API's resources.
+func (s *server) listUsers(w http.ResponseWriter, r *http.Request) {
+	s.seen.Store(r.RemoteAddr, true)
+	users, err := s.users.FindAll(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(users)
+}
+
+func (s *server) createUser(w http.ResponseWriter, r *http.Request) {
+	var user store.User
+	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.users.Create(r.Context(), user); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// cleanup deletes through the repository, two calls below the handler.
+func (s *server) cleanup(w http.ResponseWriter, r *http.Request) {
+	if err := s.prune(r); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) prune(r *http.Request) error {
+	return s.users.DeleteStale(r.Context())
+}
+
+// ping records the probe with a raw statement.
+func (s *server) ping(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.db.ExecContext(r.Context(), "UPDATE health SET checked_at = now()"); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func main() {
+	db, _ := sql.Open("sqlite", "app.db")
+	s := &server{db: db, users: store.New(db)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users", s.listUsers)
+	mux.HandleFunc("POST /users", s.createUser)
+	mux.HandleFunc("GET /cleanup", s.cleanup)
+	mux.HandleFunc("HEAD /ping", s.ping)
+	_ = http.ListenAndServe(":8080", mux)
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+)
+
+// User is a stored user.
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Users is the user repository.
+type Users struct {
+	db *sql.DB
+}
+
+// New returns a repository over db.
+func New(db *sql.DB) *Users {
+	return &Users{db: db}
+}
+
+// FindAll lists the users.
+func (u *Users) FindAll(ctx context.Context) ([]User, error) {
+	rows, err := u.db.QueryContext(ctx, "SELECT id, name FROM users")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var users []User
+	for rows.Next() {
+		var user User
+		if err := rows.Scan(&user.ID, &user.Name); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// Create stores a new user.
+func (u *Users) Create(ctx context.Context, user User) error {
+	_, err := u.db.ExecContext(ctx, "INSERT INTO users (id, name) VALUES (?, ?)", user.ID, user.Name)
+	return err
+}
+
+// DeleteStale removes users that never signed in.
+func (u *Users) DeleteStale(ctx context.Context) error {
+	_, err := u.db.ExecContext(ctx, "DELETE FROM users WHERE last_seen IS NULL")
+	return err
+}
@@ -0,0 +1,5 @@
+module github.com/ehabterra/apispec/testdata/validation_rules_chi
+
+go 1.22
+
+require github.com/go-chi/chi/v5 v5.2.3
@@ -0,0 +1,53 @@
+// Package main registers chi routes guarded by per-route validation middleware
+// built from a rules struct: r.With(validate.Body(Rules{})).Post(...).
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ehabterra/apispec/testdata/validation_rules_chi/validate"
+)
+
+// CreateUserRequest is the body shared by /users and /drafts.
+type CreateUserRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Age   int    `json:"age"`
+}
+
+// CreateUserRules holds the constraints validate.Body enforces on /users.
+type CreateUserRules struct {
+	Name  string `json:"name" validate:"required,min=2,max=64"`
+	Email string `json:"email" validate:"required,email"`
+	Age   int    `json:"age" validate:"min=18,max=130"`
+}
+
+func createUser(w http.ResponseWriter, r *http.Request) {
+	var req CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(req)
+}
+
+// createDraft accepts the same body without validation.
+func createDraft(w http.ResponseWriter, r *http.Request) {
+	var req CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(req)
+}
+
+func main() {
+	r := chi.NewRouter()
+	r.With(validate.Body(CreateUserRules{})).Post("/users", createUser)
+	r.Post("/drafts", createDraft)
+	_ = http.ListenAndServe(":8080", r)
+}
@@ -0,0 +1,18 @@
+// Package validate is a stand-in for an in-house validation middleware: each
+// route is guarded by a rules struct whose field tags declare the constraints.
+package validate
+
+import (
+	"net/http"
+)
+
+// Body returns middleware that checks the JSON request body against the
+// `validate` tags on rules' fields before calling next.
+func Body(rules interface{}) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = rules
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Package main registers gin routes guarded by per-route validation middleware
+// built from a rules struct: r.POST(path, validate.Body(&Rules{}), handler).
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ehabterra/apispec/testdata/validation_rules_gin/validate"
+)
+
+// CreateOrderRequest is the body shared by /orders and /quotes.
+type CreateOrderRequest struct {
+	SKU      string   `json:"sku"`
+	Quantity int      `json:"quantity"`
+	Tags     []string `json:"tags"`
+}
+
+// CreateOrderRules holds the constraints validate.Body enforces on /orders.
+type CreateOrderRules struct {
+	SKU      string   `json:"sku" validate:"required,len=8"`
+	Quantity int      `json:"quantity" validate:"required,min=1,max=100"`
+	Tags     []string `json:"tags" validate:"max=5"`
+}
+
+func createOrder(c *gin.Context) {
+	var req CreateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, req)
+}
+
+// createQuote accepts the same body without validation.
+func createQuote(c *gin.Context) {
+	var req CreateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, req)
+}
+
+func main() {
+	r := gin.New()
+	r.POST("/orders", validate.Body(&CreateOrderRules{}), createOrder)
+	r.POST("/quotes", createQuote)
+	_ = r.Run(":8080")
+}
@@ -0,0 +1,14 @@
+// Package validate is a stand-in for an in-house validation middleware: each
+// route is guarded by a rules struct whose field tags declare the constraints.
+package validate
+
+import "github.com/gin-gonic/gin"
+
+// Body returns a gin middleware that checks the JSON request body against the
+// `validate` tags on rules' fields.
+func Body(rules interface{}) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		_ = rules
+		c.Next()
+	}
+}
@@ -28,6 +28,35 @@ type Range struct {
 	_   struct{} `validate:"gtefield=Min"`
 }
 
+// UpdateProfileRequest exercises the wider go-playground/validator vocabulary:
+// gt/gte/lt/lte (zero bounds included), typed and quoted oneof, formats,
+// unique, map dive and conditional requirements.
+type UpdateProfileRequest struct {
+	ID       string            `json:"id" validate:"required,uuid"`
+	Email    string            `json:"email" validate:"omitempty,email"`
+	Website  string            `json:"website" validate:"omitempty,url"`
+	Handle   string            `json:"handle" validate:"gt=2,lt=16,lowercase"`
+	Tier     int               `json:"tier" validate:"oneof=1 2 3"`
+	Theme    string            `json:"theme" validate:"oneof='dark blue' red"`
+	Rating   float64           `json:"rating" validate:"gt=0.5,lte=5"`
+	Balance  int               `json:"balance" validate:"gte=0"`
+	Credit   float64           `json:"credit" validate:"gt=0"`
+	Debt     int               `json:"debt" validate:"lt=0"`
+	Tags     []string          `json:"tags" validate:"unique,gte=1,lte=5,dive,len=3"`
+	Labels   map[string]string `json:"labels" validate:"dive,keys,min=1,endkeys,max=20"`
+	Birthday string            `json:"birthday" validate:"datetime=2006-01-02"`
+	Phone    string            `json:"phone" validate:"required_without=Email,omitempty,e164"`
+}
+
+func updateProfile(w http.ResponseWriter, r *http.Request) {
+	var req UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(req)
+}
+
 // createAccount registers a new account.
 // It validates the payload and returns the created account.
 func createAccount(w http.ResponseWriter, r *http.Request) {
@@ -43,5 +72,6 @@ func createAccount(w http.ResponseWriter, r *http.Request) {
 func main() {
 	mux := http.NewServeMux()
 	mux.HandleFunc("POST /accounts", createAccount)
+	mux.HandleFunc("PATCH /profile", updateProfile)
 	_ = http.ListenAndServe(":8080", mux)
 }
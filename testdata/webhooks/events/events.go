@@ -0,0 +1,22 @@
+// Package events holds the payloads the service delivers to subscribers.
+package events
+
+import "time"
+
+// OrderStatus tells a subscriber an order moved on.
+type OrderStatus struct {
+	OrderID string    `json:"orderId"`
+	Status  string    `json:"status"`
+	At      time.Time `json:"at"`
+}
+
+// InvoicePaid is sent to the billing webhook once an invoice is settled.
+type InvoicePaid struct {
+	InvoiceID string `json:"invoiceId"`
+	Amount    int64  `json:"amount"`
+}
+
+// UserDeleted is sent when an account is removed.
+type UserDeleted struct {
+	UserID string `json:"userId"`
+}
@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/webhooks
+
+go 1.22
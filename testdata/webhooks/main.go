@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ehabterra/apispec/testdata/webhooks/events"
+)
+
+// CreateOrder is the body of POST /orders.
+type CreateOrder struct {
+	Item        string `json:"item"`
+	CallbackURL string `json:"callbackUrl"`
+}
+
+// Order is an accepted order.
+type Order struct {
+	ID     string `json:"id"`
+	Item   string `json:"item"`
+	Status string `json:"status"`
+}
+
+// notifyOrderStatus calls the URL the client registered with the order.
+func notifyOrderStatus(url string, order Order) {
+	body, _ := json.Marshal(events.OrderStatus{OrderID: order.ID, Status: order.Status, At: time.Now()})
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+}
+
+func createOrder(w http.ResponseWriter, r *http.Request) {
+	var req CreateOrder
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	order := Order{ID: "o-1", Item: req.Item, Status: "accepted"}
+	notifyOrderStatus(req.CallbackURL, order)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(order)
+}
+
+func getOrder(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(Order{ID: r.PathValue("id")})
+}
+
+// sendInvoicePaid delivers the billing webhook from the background worker.
+func sendInvoicePaid(client *http.Client, endpoint string, invoice events.InvoicePaid) error {
+	body, err := json.Marshal(invoice)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func runBilling(client *http.Client) {
+	for range time.Tick(time.Minute) {
+		_ = sendInvoicePaid(client, "https://billing.example.com/hook", events.InvoicePaid{InvoiceID: "i-1"})
+	}
+}
+
+func main() {
+	go runBilling(http.DefaultClient)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /orders", createOrder)
+	mux.HandleFunc("GET /orders/{id}", getOrder)
+	_ = http.ListenAndServe(":8080", mux)
+}
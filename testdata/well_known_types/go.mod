@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/well_known_types
+
+go 1.22
@@ -0,0 +1,50 @@
+// Package main serves scheduled jobs whose fields are standard library value
+// types, documented as encoding/json writes them.
+package main
+
+import (
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Job runs on a schedule.
+type Job struct {
+	ID      string     `json:"id"`
+	Created time.Time  `json:"created"`
+	LastRun *time.Time `json:"lastRun,omitempty"`
+	// Timeout is in nanoseconds.
+	Timeout  time.Duration    `json:"timeout"`
+	Backoff  []time.Duration  `json:"backoff"`
+	Weekday  time.Weekday     `json:"weekday"`
+	Month    time.Month       `json:"month"`
+	Runs     *big.Int         `json:"runs"`
+	Cost     *big.Float       `json:"cost"`
+	Share    *big.Rat         `json:"share"`
+	Priority json.Number      `json:"priority"`
+	Host     net.IP           `json:"host"`
+	MAC      net.HardwareAddr `json:"mac"`
+}
+
+func getJob(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Job{ID: r.PathValue("id")})
+}
+
+func createJob(w http.ResponseWriter, r *http.Request) {
+	var job Job
+	if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(job)
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /jobs/{id}", getJob)
+	mux.HandleFunc("POST /jobs", createJob)
+	http.ListenAndServe(":8080", mux)
+}
@@ -0,0 +1,23 @@
+// Package main is another service of the workspace. The api module does not
+// import it, so its routes stay out of the api's spec; analyzing the
+// workspace directory documents both.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Stats summarises the orders.
+type Stats struct {
+	Orders int `json:"orders"`
+}
+
+func getStats(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Stats{})
+}
+
+func main() {
+	http.HandleFunc("GET /admin/stats", getStats)
+	http.ListenAndServe(":8081", nil)
+}
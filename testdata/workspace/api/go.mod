@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/workspace/api
+
+go 1.22
@@ -0,0 +1,31 @@
+// Package main serves DTOs declared in another module of the go.work
+// workspace it belongs to. go.mod neither requires nor replaces that module:
+// the workspace resolves it, and its types are documented as components.
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ehabterra/apispec/testdata/workspace/shared/models"
+)
+
+func getOrder(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(models.Order{})
+}
+
+func createOrder(w http.ResponseWriter, r *http.Request) {
+	var order models.Order
+	if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(order)
+}
+
+func main() {
+	http.HandleFunc("GET /orders/{id}", getOrder)
+	http.HandleFunc("POST /orders", createOrder)
+	http.ListenAndServe(":8080", nil)
+}
@@ -0,0 +1,3 @@
+module github.com/ehabterra/apispec/testdata/workspace/shared
+
+go 1.22
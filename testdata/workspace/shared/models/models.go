@@ -0,0 +1,24 @@
+// Package models holds the DTOs the workspace's services share.
+package models
+
+import "time"
+
+// Order is a placed order.
+type Order struct {
+	ID       string    `json:"id"`
+	Customer Customer  `json:"customer"`
+	Items    []Item    `json:"items"`
+	PlacedAt time.Time `json:"placedAt"`
+}
+
+// Customer is who placed an order.
+type Customer struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
+
+// Item is a line of an order.
+type Item struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}